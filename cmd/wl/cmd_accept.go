@@ -3,20 +3,29 @@ package main
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/gastownhall/wasteland/internal/tui"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
 func newAcceptCmd(stdout, stderr io.Writer) *cobra.Command {
 	var (
-		quality     int
-		reliability int
-		severity    string
-		skills      string
-		message     string
-		noPush      bool
+		quality      int
+		reliability  int
+		severity     string
+		skills       string
+		message      string
+		followUp     string
+		followUpDesc string
+		noPush       bool
+		interactive  bool
 	)
 
 	cmd := &cobra.Command{
@@ -32,23 +41,39 @@ severity (leaf/branch/root), and optional skill tags.
 In wild-west mode the commit is auto-pushed to upstream and origin.
 Use --no-push to skip pushing (offline work).
 
+Use --follow-up to accept the work as partial: the completion is accepted
+and stamped, and a new open item with the given title is posted for the
+remaining work, linked to this one as its parent. The follow-up inherits
+the project, type, priority, tags and effort of the accepted item.
+
+Use --interactive (-i) to fill in the ratings in a form instead, the same
+one the TUI shows, and review the stamp before it is issued. Any rating
+flags given alongside it pre-fill the form.
+
 Examples:
   wl accept w-abc123 --quality 4
   wl accept w-abc123 --quality 5 --reliability 4 --severity branch
-  wl accept w-abc123 --quality 3 --skills "go,federation" --message "solid work"`,
+  wl accept w-abc123 --quality 3 --skills "go,federation" --message "solid work"
+  wl accept w-abc123 --quality 4 --follow-up "Add retry tests" --follow-up-description "cover the backoff path"
+  wl accept w-abc123 -i`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAccept(cmd, stdout, stderr, args[0], quality, reliability, severity, skills, message, noPush)
+			if !interactive && !cmd.Flags().Changed("quality") {
+				return fmt.Errorf(`required flag(s) "quality" not set`)
+			}
+			return runAccept(cmd, stdout, stderr, args[0], quality, reliability, severity, skills, message, followUp, followUpDesc, noPush, interactive)
 		},
 	}
 
-	cmd.Flags().IntVar(&quality, "quality", 0, "Quality rating 1-5 (required)")
+	cmd.Flags().IntVar(&quality, "quality", 0, "Quality rating 1-5 (required without --interactive)")
 	cmd.Flags().IntVar(&reliability, "reliability", 0, "Reliability rating 1-5 (defaults to quality)")
 	cmd.Flags().StringVar(&severity, "severity", "leaf", "Severity: leaf, branch, root")
 	cmd.Flags().StringVar(&skills, "skills", "", "Comma-separated skill tags")
 	cmd.Flags().StringVar(&message, "message", "", "Freeform message")
+	cmd.Flags().StringVar(&followUp, "follow-up", "", "Accept as partial and post a follow-up item with this title")
+	cmd.Flags().StringVar(&followUpDesc, "follow-up-description", "", "Description of the follow-up item (requires --follow-up)")
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
-	_ = cmd.MarkFlagRequired("quality")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Fill in the stamp in a form and preview it before accepting")
 	cmd.ValidArgsFunction = completeWantedIDs("in_review")
 	_ = cmd.RegisterFlagCompletionFunc("severity", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"leaf", "branch", "root"}, cobra.ShellCompDirectiveNoFileComp
@@ -57,13 +82,18 @@ Examples:
 	return cmd
 }
 
-func runAccept(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, quality, reliability int, severity, skills, message string, noPush bool) error {
-	if reliability == 0 {
-		reliability = quality
-	}
-
-	if err := validateAcceptInputs(quality, reliability, severity); err != nil {
-		return err
+func runAccept(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, quality, reliability int, severity, skills, message, followUp, followUpDesc string, noPush, interactive bool) error {
+	// With --interactive the form validates; the flags only seed it.
+	if !interactive {
+		if reliability == 0 {
+			reliability = quality
+		}
+		if err := validateAcceptInputs(quality, reliability, severity); err != nil {
+			return err
+		}
+		if followUpDesc != "" && strings.TrimSpace(followUp) == "" {
+			return fmt.Errorf("--follow-up-description requires --follow-up")
+		}
 	}
 
 	var skillTags []string
@@ -75,6 +105,16 @@ func runAccept(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, quality
 			}
 		}
 	}
+	input := sdk.AcceptInput{
+		Quality:     quality,
+		Reliability: reliability,
+		Severity:    severity,
+		SkillTags:   skillTags,
+		Message:     message,
+	}
+	if followUp != "" {
+		input.FollowUp = &sdk.FollowUpInput{Title: followUp, Description: followUpDesc}
+	}
 
 	wlCfg, err := resolveWasteland(cmd)
 	if err != nil {
@@ -91,34 +131,117 @@ func runAccept(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, quality
 		return err
 	}
 
-	result, err := client.Accept(wantedID, sdk.AcceptInput{
-		Quality:     quality,
-		Reliability: reliability,
-		Severity:    severity,
-		SkillTags:   skillTags,
-		Message:     message,
-	})
+	if interactive {
+		var ok bool
+		input, ok, err = promptAccept(client, wantedID, input)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(stdout, "Accept cancelled.")
+			return nil
+		}
+	}
+
+	result, err := client.Accept(wantedID, input)
 	if err != nil {
 		return err
 	}
 
 	extras := []string{
-		fmt.Sprintf("Quality: %d, Reliability: %d", quality, reliability),
-		"Severity: " + severity,
+		fmt.Sprintf("Quality: %d, Reliability: %d", input.Quality, input.Reliability),
+		"Severity: " + input.Severity,
+	}
+	if len(input.SkillTags) > 0 {
+		extras = append(extras, "Skills: "+strings.Join(input.SkillTags, ", "))
 	}
-	if len(skillTags) > 0 {
-		extras = append(extras, "Skills: "+strings.Join(skillTags, ", "))
+	if input.Message != "" {
+		extras = append(extras, "Message: "+input.Message)
 	}
-	if message != "" {
-		extras = append(extras, "Message: "+message)
+	if input.FollowUp != nil {
+		extras = append(extras, "Follow-up: "+strings.TrimSpace(input.FollowUp.Title))
 	}
 
 	renderMutationResult(stdout, "Accepted", wantedID, result, extras...)
-	printNextHint(stdout, "Next: stamp issued. View: wl status "+wantedID)
+	printNextHint(stdout, i18n.T("next.accept", wantedID))
 
 	return nil
 }
 
+// promptAccept checks that wantedID has a completion this rig may accept,
+// then has the user fill in the stamp, starting from seed. It reports
+// false if the user cancelled.
+func promptAccept(client *sdk.Client, wantedID string, seed sdk.AcceptInput) (sdk.AcceptInput, bool, error) {
+	detail, err := client.Detail(wantedID)
+	if err != nil {
+		return seed, false, err
+	}
+	if detail.Item == nil {
+		return seed, false, &commons.NotFoundError{Message: fmt.Sprintf("wanted item %q not found", wantedID)}
+	}
+	if _, err := commons.ValidateTransition(detail.Item.Status, commons.TransitionAccept); err != nil {
+		return seed, false, err
+	}
+	if detail.Completion == nil {
+		return seed, false, &commons.NotFoundError{Message: fmt.Sprintf("no completion found for item %s", wantedID)}
+	}
+	if detail.Completion.CompletedBy == client.RigHandle() {
+		return seed, false, &commons.PermissionError{Message: "cannot accept your own completion"}
+	}
+
+	cfg := tui.AcceptPromptConfig{
+		WantedID:    wantedID,
+		Title:       detail.Item.Title,
+		CompletedBy: detail.Completion.CompletedBy,
+		Author:      client.RigHandle(),
+		Evidence:    detail.Completion.Evidence,
+		Quorum:      detail.Quorum,
+		Approvals:   len(detail.Approvals),
+		Initial: tui.AcceptPlan{
+			Quality:     seed.Quality,
+			Reliability: seed.Reliability,
+			Severity:    seed.Severity,
+			Skills:      seed.SkillTags,
+			Message:     seed.Message,
+		},
+	}
+	if seed.FollowUp != nil {
+		cfg.Initial.FollowUpTitle = seed.FollowUp.Title
+		cfg.Initial.FollowUpDesc = seed.FollowUp.Description
+	}
+	plan, ok, err := runAcceptPrompt(cfg)
+	if err != nil || !ok {
+		return seed, false, err
+	}
+
+	input := sdk.AcceptInput{
+		Quality:     plan.Quality,
+		Reliability: plan.Reliability,
+		Severity:    plan.Severity,
+		SkillTags:   plan.Skills,
+		Message:     plan.Message,
+	}
+	if plan.FollowUpTitle != "" {
+		input.FollowUp = &sdk.FollowUpInput{Title: plan.FollowUpTitle, Description: plan.FollowUpDesc}
+	}
+	return input, true, nil
+}
+
+// runAcceptPrompt shows the accept form on the terminal and returns what
+// the user confirmed, or false if they cancelled.
+// Package-level variable to allow test overrides.
+var runAcceptPrompt = func(cfg tui.AcceptPromptConfig) (tui.AcceptPlan, bool, error) {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return tui.AcceptPlan{}, false, fmt.Errorf("wl accept -i needs a terminal; pass --quality instead")
+	}
+	final, err := bubbletea.NewProgram(tui.NewAcceptPrompt(cfg)).Run()
+	if err != nil {
+		return tui.AcceptPlan{}, false, fmt.Errorf("accept form: %w", err)
+	}
+	plan, ok := final.(tui.AcceptPrompt).Plan()
+	return plan, ok, nil
+}
+
 // validateAcceptInputs validates quality, reliability, and severity values.
 func validateAcceptInputs(quality, reliability int, severity string) error {
 	if quality < 1 || quality > 5 {
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"bytes"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/gastownhall/wasteland/internal/tui"
 )
 
 // Business logic tests for accepting moved to internal/sdk/ (sdk_test.go, lifecycle_test.go).
@@ -64,3 +69,93 @@ func TestValidateAcceptInputs(t *testing.T) {
 		})
 	}
 }
+
+func TestRunAccept_FollowUpDescriptionRequiresTitle(t *testing.T) {
+	t.Parallel()
+	var stdout bytes.Buffer
+	err := runAccept(nil, &stdout, io.Discard, "w-abc123", 4, 0, "leaf", "", "", "", "more tests", false, false)
+	if err == nil || !strings.Contains(err.Error(), "--follow-up-description requires --follow-up") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// inReviewDB is a noopDB holding w-abc123, posted by alice and in review
+// with a completion by completedBy.
+type inReviewDB struct {
+	noopDB
+	completedBy string
+}
+
+func (db inReviewDB) Query(sql, _ string) (string, error) {
+	switch {
+	case strings.Contains(sql, "FROM completions WHERE wanted_id="):
+		return "id,wanted_id,completed_by,evidence,stamp_id,validated_by\nc-1,w-abc123," + db.completedBy + ",https://example.com/pull/7,,\n", nil
+	case strings.Contains(sql, "FROM wanted WHERE id='"):
+		return "id,title,status,posted_by,claimed_by\nw-abc123,Fix the flaky sync test,in_review,alice," + db.completedBy + "\n", nil
+	}
+	return "", nil
+}
+
+// withAcceptPrompt points the SDK at db and replaces the accept form with
+// prompt.
+func withAcceptPrompt(t *testing.T, db commons.DB, prompt func(tui.AcceptPromptConfig) (tui.AcceptPlan, bool, error)) {
+	t.Helper()
+	saveWasteland(t)
+	withFakeSDK(t)
+	newSDKClient = func(cfg *federation.Config, _ bool) (*sdk.Client, error) {
+		return sdk.New(sdk.ClientConfig{DB: db, RigHandle: cfg.RigHandle, Mode: "wild-west", NoPush: true}), nil
+	}
+	old := runAcceptPrompt
+	runAcceptPrompt = prompt
+	t.Cleanup(func() { runAcceptPrompt = old })
+}
+
+func TestRunAccept_Interactive(t *testing.T) {
+	var got tui.AcceptPromptConfig
+	withAcceptPrompt(t, inReviewDB{completedBy: "bob"}, func(cfg tui.AcceptPromptConfig) (tui.AcceptPlan, bool, error) {
+		got = cfg
+		return tui.AcceptPlan{Quality: 5, Reliability: 4, Severity: "branch", Skills: []string{"go"}, Message: "nice"}, true, nil
+	})
+
+	var stdout bytes.Buffer
+	if err := runAccept(wastelandCmd(), &stdout, io.Discard, "w-abc123", 3, 0, "leaf", "go", "", "", "", true, true); err != nil {
+		t.Fatalf("runAccept -i: %v", err)
+	}
+	if got.CompletedBy != "bob" || got.Author != "alice" || got.Title != "Fix the flaky sync test" {
+		t.Errorf("prompt config = %+v, want bob's completion of the item, accepted by alice", got)
+	}
+	if got.Initial.Quality != 3 || got.Initial.Severity != "leaf" || len(got.Initial.Skills) != 1 {
+		t.Errorf("prompt seed = %+v, want the flag values", got.Initial)
+	}
+	for _, want := range []string{"Accepted", "Quality: 5, Reliability: 4", "Severity: branch", "Message: nice"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, &stdout)
+		}
+	}
+}
+
+func TestRunAccept_InteractiveCancelled(t *testing.T) {
+	withAcceptPrompt(t, inReviewDB{completedBy: "bob"}, func(tui.AcceptPromptConfig) (tui.AcceptPlan, bool, error) {
+		return tui.AcceptPlan{}, false, nil
+	})
+
+	var stdout bytes.Buffer
+	if err := runAccept(wastelandCmd(), &stdout, io.Discard, "w-abc123", 0, 0, "leaf", "", "", "", "", true, true); err != nil {
+		t.Fatalf("runAccept -i: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Accept cancelled.") || strings.Contains(stdout.String(), "Accepted") {
+		t.Errorf("output = %q, want only the cancellation", &stdout)
+	}
+}
+
+func TestRunAccept_InteractiveOwnCompletion(t *testing.T) {
+	withAcceptPrompt(t, inReviewDB{completedBy: "alice"}, func(tui.AcceptPromptConfig) (tui.AcceptPlan, bool, error) {
+		t.Error("the form should not open for your own completion")
+		return tui.AcceptPlan{}, false, nil
+	})
+
+	err := runAccept(wastelandCmd(), io.Discard, io.Discard, "w-abc123", 0, 0, "leaf", "", "", "", "", true, true)
+	if err == nil || !strings.Contains(err.Error(), "cannot accept your own completion") {
+		t.Fatalf("err = %v, want the self-accept error", err)
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/spf13/cobra"
+)
+
+func newAliasCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage command aliases",
+		Long: `Define shortcuts for commands you run often.
+
+An alias expands to a wl command line before the command runs; arguments
+given after the alias are appended. $RIG expands to your rig handle.
+Aliases are stored in the wasteland's config (the alias.<name> keys of
+'wl config'), so each joined wasteland has its own; with several joined,
+pass --wasteland to pick whose aliases apply.
+
+Aliases can't shadow built-in commands and don't expand other aliases.
+
+EXAMPLES:
+  wl alias set mine 'browse --claimed-by $RIG --status claimed'
+  wl mine --json
+  wl alias list
+  wl alias rm mine`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		newAliasListCmd(stdout, stderr),
+		newAliasSetCmd(stdout, stderr),
+		newAliasRmCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newAliasListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAliasList(cmd, stdout, stderr, jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newAliasSetCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name> <command...>",
+		Short: "Create or replace an alias",
+		Long: `Create or replace an alias. Quote the command in single quotes so the
+shell leaves $RIG for wl to expand.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAliasSet(cmd, stdout, stderr, args[0], strings.Join(args[1:], " "))
+		},
+	}
+}
+
+func newAliasRmCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Remove an alias",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			cfg, err := resolveWasteland(cmd)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return slices.Sorted(maps.Keys(cfg.Aliases)), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAliasRm(cmd, stdout, stderr, args[0])
+		},
+	}
+}
+
+func runAliasList(cmd *cobra.Command, stdout, _ io.Writer, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if jsonOut {
+		aliases := cfg.Aliases
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+		return writeConfigJSON(stdout, aliases)
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Aliases)) {
+		fmt.Fprintf(stdout, "%-12s %s\n", name, cfg.Aliases[name])
+	}
+	return nil
+}
+
+func runAliasSet(cmd *cobra.Command, stdout, _ io.Writer, name, expansion string) error {
+	if err := validateAlias(name, expansion); err != nil {
+		return err
+	}
+	return updateConfig(cmd, "alias set", func(cfg *federation.Config) error {
+		setAlias(cfg, name, expansion)
+		fmt.Fprintf(stdout, "%s = %s\n", name, expansion)
+		return nil
+	})
+}
+
+func runAliasRm(cmd *cobra.Command, stdout, _ io.Writer, name string) error {
+	return updateConfig(cmd, "alias rm", func(cfg *federation.Config) error {
+		if _, ok := cfg.Aliases[name]; !ok {
+			return fmt.Errorf("no alias %q", name)
+		}
+		setAlias(cfg, name, "")
+		fmt.Fprintf(stdout, "Removed alias %s\n", name)
+		return nil
+	})
+}
+
+// setAlias sets or, with an empty expansion, removes an alias.
+func setAlias(cfg *federation.Config, name, expansion string) {
+	if strings.TrimSpace(expansion) == "" {
+		delete(cfg.Aliases, name)
+		if len(cfg.Aliases) == 0 {
+			cfg.Aliases = nil
+		}
+		return
+	}
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[name] = expansion
+}
+
+var aliasNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// validateAlias checks that name is free to use as an alias and that
+// expansion starts with a built-in command.
+func validateAlias(name, expansion string) error {
+	if !aliasNameRe.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: use lowercase letters, digits and dashes", name)
+	}
+	builtins := builtinCommandNames(newRootCmd(io.Discard, io.Discard))
+	if builtins[name] {
+		return fmt.Errorf("alias %q would shadow the built-in command", name)
+	}
+	words, err := splitAliasWords(expansion)
+	if err != nil {
+		return fmt.Errorf("invalid alias %q: %w", name, err)
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("alias %q has no command", name)
+	}
+	if !builtins[words[0]] {
+		return fmt.Errorf("alias %q must start with a wl command, not %q", name, words[0])
+	}
+	return nil
+}
+
+// builtinCommandNames returns the names and aliases of root's commands,
+// plus the ones cobra adds at run time.
+func builtinCommandNames(root *cobra.Command) map[string]bool {
+	names := map[string]bool{"help": true, "completion": true}
+	for _, c := range root.Commands() {
+		names[c.Name()] = true
+		for _, a := range c.Aliases {
+			names[a] = true
+		}
+	}
+	return names
+}
+
+// expandAlias rewrites args when their command is one of the selected
+// wasteland's aliases: the alias's words replace its name, with $RIG
+// expanded, and the remaining args follow. Args are returned unchanged when
+// the command is built in, no wasteland resolves, or no alias matches.
+func expandAlias(root *cobra.Command, args []string, store federation.ConfigStore) ([]string, error) {
+	i, explicit := commandIndex(root, args)
+	if i < 0 || strings.HasPrefix(args[i], "__") {
+		return args, nil // no command, or cobra's completion machinery
+	}
+	if builtinCommandNames(root)[args[i]] {
+		return args, nil
+	}
+	cfg, err := federation.ResolveConfig(store, explicit)
+	if err != nil {
+		return args, nil
+	}
+	expansion, ok := cfg.Aliases[args[i]]
+	if !ok {
+		return args, nil
+	}
+	words, err := splitAliasWords(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("alias %q: %w", args[i], err)
+	}
+	for j, w := range words {
+		words[j] = strings.NewReplacer("${RIG}", cfg.RigHandle, "$RIG", cfg.RigHandle).Replace(w)
+	}
+	return slices.Concat(args[:i], words, args[i+1:]), nil
+}
+
+// commandIndex returns the index of the first positional arg (the command
+// name), skipping global flags and their values, and the --wasteland value
+// seen before it. It returns -1 when args hold no command.
+func commandIndex(root *cobra.Command, args []string) (int, string) {
+	flags := root.PersistentFlags()
+	var explicit string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1, explicit
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i, explicit
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		f := flags.Lookup(name)
+		if !strings.HasPrefix(arg, "--") {
+			f = nil
+			if len(name) == 1 {
+				f = flags.ShorthandLookup(name)
+			}
+		}
+		if f == nil || hasValue || f.NoOptDefVal != "" {
+			if f != nil && f.Name == "wasteland" {
+				explicit = value
+			}
+			continue
+		}
+		if i+1 < len(args) {
+			i++
+			if f.Name == "wasteland" {
+				explicit = args[i]
+			}
+		}
+	}
+	return -1, explicit
+}
+
+// splitAliasWords splits an alias into words like a shell would: on
+// whitespace, honoring single quotes, double quotes and backslashes.
+func splitAliasWords(s string) ([]string, error) {
+	var (
+		words   []string
+		cur     strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", s)
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+func TestSplitAliasWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"browse --status claimed", []string{"browse", "--status", "claimed"}},
+		{"  post   --title 'two words'  ", []string{"post", "--title", "two words"}},
+		{`post --title "say \"hi\""`, []string{"post", "--title", `say "hi"`}},
+		{`browse --search a\ b`, []string{"browse", "--search", "a b"}},
+		{"post --description ''", []string{"post", "--description", ""}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := splitAliasWords(tt.in)
+		if err != nil {
+			t.Errorf("splitAliasWords(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("splitAliasWords(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if _, err := splitAliasWords("browse 'open"); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func saveAliasConfig(t *testing.T, upstream string, aliases map[string]string) {
+	t.Helper()
+	saveTestConfig(t, &federation.Config{
+		Upstream: upstream, ForkOrg: "alice", ForkDB: "wl-commons",
+		RigHandle: "alice", Aliases: aliases, JoinedAt: time.Now(),
+	})
+}
+
+func TestExpandAlias(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveAliasConfig(t, "hop/wl-commons", map[string]string{
+		"mine":  "browse --claimed-by $RIG --status claimed",
+		"quick": "post --title '${RIG} quick fix'",
+	})
+	root := newRootCmd(io.Discard, io.Discard)
+	store := federation.NewConfigStore()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"expands with trailing args", []string{"mine", "--json"},
+			[]string{"browse", "--claimed-by", "alice", "--status", "claimed", "--json"}},
+		{"global flags before alias", []string{"--color", "never", "--wasteland=hop/wl-commons", "mine"},
+			[]string{"--color", "never", "--wasteland=hop/wl-commons", "browse", "--claimed-by", "alice", "--status", "claimed"}},
+		{"quoted words", []string{"-v", "quick"},
+			[]string{"-v", "post", "--title", "alice quick fix"}},
+		{"built-in command", []string{"browse", "mine"}, []string{"browse", "mine"}},
+		{"unknown command", []string{"nope"}, []string{"nope"}},
+		{"no command", []string{"--verbose"}, []string{"--verbose"}},
+		{"completion request", []string{"__complete", "mine"}, []string{"__complete", "mine"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandAlias(root, tt.args, store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expandAlias(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandAlias_SelectsWasteland(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveAliasConfig(t, "hop/wl-commons", map[string]string{"mine": "browse --claimed-by $RIG"})
+	saveAliasConfig(t, "hop/wl-other", nil)
+	root := newRootCmd(io.Discard, io.Discard)
+	store := federation.NewConfigStore()
+
+	// Ambiguous without --wasteland: left for cobra to report.
+	if got, _ := expandAlias(root, []string{"mine"}, store); !slices.Equal(got, []string{"mine"}) {
+		t.Errorf("ambiguous expandAlias = %q, want unchanged", got)
+	}
+	got, _ := expandAlias(root, []string{"--wasteland", "hop/wl-commons", "mine"}, store)
+	if want := []string{"--wasteland", "hop/wl-commons", "browse", "--claimed-by", "alice"}; !slices.Equal(got, want) {
+		t.Errorf("expandAlias = %q, want %q", got, want)
+	}
+	if got, _ := expandAlias(root, []string{"--wasteland", "hop/wl-other", "mine"}, store); !slices.Equal(got, []string{"--wasteland", "hop/wl-other", "mine"}) {
+		t.Errorf("other wasteland's expandAlias = %q, want unchanged", got)
+	}
+}
+
+func TestRunAliasSetListRm(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveAliasConfig(t, "hop/wl-commons", nil)
+
+	var stdout, stderr bytes.Buffer
+	if err := runAliasSet(configCmd(), &stdout, &stderr, "mine", "browse --claimed-by $RIG"); err != nil {
+		t.Fatalf("runAliasSet: %v", err)
+	}
+	stdout.Reset()
+	if err := runAliasList(configCmd(), &stdout, &stderr, false); err != nil {
+		t.Fatalf("runAliasList: %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "mine         browse --claimed-by $RIG" {
+		t.Errorf("list = %q", got)
+	}
+
+	stdout.Reset()
+	if err := runConfigGet(configCmd(), &stdout, &stderr, "alias.mine", false); err != nil {
+		t.Fatalf("runConfigGet(alias.mine): %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "browse --claimed-by $RIG" {
+		t.Errorf("config get alias.mine = %q", got)
+	}
+
+	if err := runAliasRm(configCmd(), &stdout, &stderr, "mine"); err != nil {
+		t.Fatalf("runAliasRm: %v", err)
+	}
+	cfg, err := federation.NewConfigStore().Load("hop/wl-commons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Aliases != nil {
+		t.Errorf("Aliases = %v, want nil after removing the last alias", cfg.Aliases)
+	}
+	if err := runAliasRm(configCmd(), &stdout, &stderr, "mine"); err == nil {
+		t.Error("expected error removing a missing alias")
+	}
+}
+
+func TestRunAliasSet_Invalid(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveAliasConfig(t, "hop/wl-commons", nil)
+
+	tests := []struct {
+		name, expansion, wantErr string
+	}{
+		{"browse", "browse --status open", "shadow"},
+		{"help", "browse", "shadow"},
+		{"Mine", "browse", "invalid alias name"},
+		{"mine", "rm -rf /", "must start with a wl command"},
+		{"mine", "browse 'open", "unterminated"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		err := runAliasSet(configCmd(), &stdout, &stderr, tt.name, tt.expansion)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("runAliasSet(%q, %q) error = %v, want %q", tt.name, tt.expansion, err, tt.wantErr)
+		}
+	}
+	if err := runConfigSet(configCmd(), io.Discard, io.Discard, "alias.claim", "claim", false); err == nil {
+		t.Error("config set alias.claim should refuse to shadow a built-in")
+	}
+}
@@ -39,6 +39,9 @@ func runApprove(cmd *cobra.Command, stdout, _ io.Writer, branch, comment string)
 	if err != nil {
 		return hintWrap(err)
 	}
+	if err := requireWritable(cfg, "approve"); err != nil {
+		return err
+	}
 
 	if !cfg.IsGitHub() {
 		return fmt.Errorf("approve requires GitHub provider (joined with --github)")
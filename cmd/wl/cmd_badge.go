@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newBadgeCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "badge",
+		Short: "List and award badges",
+		Long: `List the badges a rig holds, or award one.
+
+Badges recognize contributions that stamps don't capture: mentoring,
+a security find, running a release. The type is any short slug you
+choose; the reason is stored with the badge. Badges show up in
+'wl me', 'wl profile' and the leaderboard.
+
+Awarding writes straight to the upstream commons, so it is for
+maintainers with write access (a wild-west clone from 'wl join --direct').
+--sign GPG-signs the award commit even if signing is off in the config.
+
+EXAMPLES:
+  wl badge list                     # your badges
+  wl badge list alice --json
+  wl badge award alice bug-hunter --reason "found the claim race in w-abc123" --sign`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		newBadgeListCmd(stdout, stderr),
+		newBadgeAwardCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newBadgeListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "list [rig]",
+		Short: "List a rig's badges (default: yours)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handle := ""
+			if len(args) > 0 {
+				handle = args[0]
+			}
+			return runBadgeList(cmd, stdout, stderr, handle, jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newBadgeAwardCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		reason string
+		sign   bool
+	)
+	cmd := &cobra.Command{
+		Use:   "award <rig> <badge-type>",
+		Short: "Award a badge to a rig (maintainers)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBadgeAward(cmd, stdout, stderr, args[0], args[1], reason, sign)
+		},
+	}
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the badge is awarded (required)")
+	cmd.Flags().BoolVar(&sign, "sign", false, "GPG-sign the award commit")
+	_ = cmd.MarkFlagRequired("reason")
+	return cmd
+}
+
+func runBadgeList(cmd *cobra.Command, stdout, _ io.Writer, handle string, jsonOut bool) error {
+	cfg, db, err := openSyncedDB(cmd, stdout)
+	if err != nil {
+		return err
+	}
+	if handle == "" {
+		handle = cfg.RigHandle
+	}
+	badges, err := commons.QueryBadges(db, handle)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if badges == nil {
+			badges = []commons.BadgeRow{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(badges)
+	}
+	renderBadges(stdout, handle, badges)
+	return nil
+}
+
+func runBadgeAward(cmd *cobra.Command, stdout, _ io.Writer, rig, badgeType, reason string, sign bool) error {
+	if err := commons.ValidateBadgeType(badgeType); err != nil {
+		return err
+	}
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if err := requireWritable(cfg, "badge award"); err != nil {
+		return err
+	}
+
+	client, err := newSDKClient(cfg, false)
+	if err != nil {
+		return err
+	}
+	b, err := client.AwardBadge(commons.BadgeRow{RigHandle: rig, BadgeType: badgeType, Evidence: reason}, sign)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s Awarded %s to %s\n", style.Success.Render(style.IconPass), b.BadgeType, b.RigHandle)
+	fmt.Fprintf(stdout, "  %s\n", style.Dim.Render(b.ID+" — "+b.Evidence))
+	return nil
+}
+
+func renderBadges(w io.Writer, handle string, badges []commons.BadgeRow) {
+	if len(badges) == 0 {
+		fmt.Fprintf(w, "%s holds no badges.\n", handle)
+		return
+	}
+	tbl := style.NewTable(
+		style.Column{Name: "BADGE", Width: 20},
+		style.Column{Name: "AWARDED", Width: 10},
+		style.Column{Name: "REASON", Width: 50},
+	)
+	for _, b := range badges {
+		tbl.AddRow(b.BadgeType, dateOnly(b.AwardedAt), b.Evidence)
+	}
+	fmt.Fprintf(w, "%s\n\n", style.Bold.Render(fmt.Sprintf("Badges for %s (%d)", handle, len(badges))))
+	fmt.Fprint(w, tbl.Render())
+}
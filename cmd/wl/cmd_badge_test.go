@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestRenderBadges(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderBadges(&buf, "alice", []commons.BadgeRow{
+		{BadgeType: "mentor", AwardedAt: "2026-03-02 10:00:00", Evidence: "helped three newcomers"},
+	})
+	out := buf.String()
+	for _, want := range []string{"Badges for alice (1)", "mentor", "2026-03-02", "helped three newcomers"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "10:00:00") {
+		t.Errorf("award date should be trimmed:\n%s", out)
+	}
+
+	buf.Reset()
+	renderBadges(&buf, "bob", nil)
+	if out := buf.String(); out != "bob holds no badges.\n" {
+		t.Errorf("empty output = %q", out)
+	}
+}
+
+func TestBadgeAward_RequiresReasonAndValidType(t *testing.T) {
+	t.Parallel()
+	var stdout, stderr bytes.Buffer
+	root := newRootCmd(&stdout, &stderr)
+	root.SetArgs([]string{"badge", "award", "alice", "mentor"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "reason") {
+		t.Errorf("missing --reason err = %v", err)
+	}
+
+	root = newRootCmd(&stdout, &stderr)
+	root.SetArgs([]string{"badge", "award", "alice", "Not Valid", "--reason", "x"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "invalid badge type") {
+		t.Errorf("bad type err = %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newBlameCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "blame <wanted-id>",
+		Short: "Show who last changed each field of a wanted item",
+		Long: `Show field-level attribution for a wanted item.
+
+Walks the item's Dolt history (dolt_history_wanted) and reports, for each
+field, the commit that set its current value, who committed it, and when.
+Useful for auditing unexpected edits on a shared board.
+
+Examples:
+  wl blame w-abc123
+  wl blame w-abc123 --json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWantedIDs(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBlame(cmd, stdout, stderr, args[0], jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runBlame(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return err
+		}
+		sp := style.StartSpinner(stdout, "Syncing with upstream...")
+		syncErr := db.Sync()
+		sp.Stop()
+		if syncErr != nil {
+			return fmt.Errorf("syncing with upstream: %w", syncErr)
+		}
+	}
+
+	wantedID, err = resolveWantedArg(cfg, wantedID)
+	if err != nil {
+		return err
+	}
+
+	blame, err := commons.BlameWanted(db, wantedID)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(blame)
+	}
+	renderBlame(stdout, wantedID, blame)
+	return nil
+}
+
+func renderBlame(w io.Writer, wantedID string, blame []commons.FieldBlame) {
+	tbl := style.NewTable(
+		style.Column{Name: "FIELD", Width: 16},
+		style.Column{Name: "VALUE", Width: 30},
+		style.Column{Name: "COMMIT", Width: 8},
+		style.Column{Name: "COMMITTER", Width: 16},
+		style.Column{Name: "DATE", Width: 19},
+		style.Column{Name: "MESSAGE", Width: 30},
+	)
+	for _, b := range blame {
+		hash := b.CommitHash
+		if len(hash) > 8 {
+			hash = hash[:8]
+		}
+		value := b.Value
+		if value == "" {
+			value = style.Dim.Render("-")
+		}
+		tbl.AddRow(b.Field, value, hash, b.Committer, b.CommitDate, b.Message)
+	}
+
+	fmt.Fprintf(w, "%s\n\n", style.Bold.Render("Blame for "+wantedID))
+	fmt.Fprint(w, tbl.Render())
+}
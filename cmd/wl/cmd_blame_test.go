@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestRenderBlame(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderBlame(&buf, "w-abc123", []commons.FieldBlame{
+		{Field: "title", Value: "Fix login bug", CommitHash: "0123456789abcdef", Committer: "mallory", CommitDate: "2026-01-03 10:00:00", Message: "edit title"},
+		{Field: "claimed_by", Value: "", CommitHash: "aaaaaaaa", Committer: "alice", CommitDate: "2026-01-01 10:00:00"},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"Blame for w-abc123", "Fix login bug", "01234567", "mallory", "edit title", "claimed_by"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "0123456789abcdef") {
+		t.Errorf("commit hash should be abbreviated:\n%s", out)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newBranchesCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "branches",
+		Short: "List your wl/<handle>/* mutation branches with context",
+		Long: `List your wl/<handle>/* mutation branches with the item title, the
+delta against main, the open PR (if any), the age of the last commit,
+and the suggested next action:
+
+  submit   open a PR:            wl review <branch> --create-pr
+  apply    merge into main:      wl merge <branch>
+  discard  abandon the branch:   b in the TUI branch manager
+
+The TUI branch manager shows the same list; open it with B from the
+board in 'wl tui'.
+
+EXAMPLES:
+  wl branches
+  wl branches --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBranches(cmd, stdout, stderr, jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runBranches(cmd *cobra.Command, stdout, _ io.Writer, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	client, err := newSDKClient(cfg, false)
+	if err != nil {
+		return err
+	}
+
+	branches, err := client.Branches()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return renderBranchesJSON(stdout, branches)
+	}
+	renderBranches(stdout, branches, time.Now())
+	return nil
+}
+
+func renderBranches(w io.Writer, branches []sdk.BranchInfo, now time.Time) {
+	if len(branches) == 0 {
+		fmt.Fprintln(w, "No mutation branches.")
+		return
+	}
+
+	tbl := style.NewTable(
+		style.Column{Name: "BRANCH", Width: 28},
+		style.Column{Name: "TITLE", Width: 30},
+		style.Column{Name: "DELTA", Width: 10},
+		style.Column{Name: "AGE", Width: 5, Align: style.AlignRight},
+		style.Column{Name: "ACTION", Width: 16},
+		style.Column{Name: "PR", Width: 50},
+	)
+	for _, b := range branches {
+		age := "-"
+		if !b.LastCommitAt.IsZero() {
+			age = formatDuration(now.Sub(b.LastCommitAt))
+		}
+		tbl.AddRow(b.Branch, b.Title, b.Delta, age, branchActionLabels(b.Actions), b.PRURL)
+	}
+
+	fmt.Fprintf(w, "%d mutation branch(es):\n\n", len(branches))
+	fmt.Fprint(w, tbl.Render())
+}
+
+// branchActionLabels renders SDK branch actions as the short names used
+// in the CLI and TUI ("submit_pr" is shown as "submit").
+func branchActionLabels(actions []string) string {
+	if len(actions) == 0 {
+		return "-"
+	}
+	labels := make([]string, len(actions))
+	for i, a := range actions {
+		if a == "submit_pr" {
+			a = "submit"
+		}
+		labels[i] = a
+	}
+	return strings.Join(labels, ", ")
+}
+
+func renderBranchesJSON(w io.Writer, branches []sdk.BranchInfo) error {
+	type branchJSON struct {
+		Branch       string     `json:"branch"`
+		WantedID     string     `json:"wanted_id"`
+		Title        string     `json:"title"`
+		Delta        string     `json:"delta"`
+		PRURL        string     `json:"pr_url,omitempty"`
+		LastCommitAt *time.Time `json:"last_commit_at,omitempty"`
+		Actions      []string   `json:"actions"`
+	}
+	out := make([]branchJSON, 0, len(branches))
+	for _, b := range branches {
+		bj := branchJSON{
+			Branch: b.Branch, WantedID: b.WantedID, Title: b.Title, Delta: b.Delta,
+			PRURL: b.PRURL, Actions: b.Actions,
+		}
+		if !b.LastCommitAt.IsZero() {
+			t := b.LastCommitAt
+			bj.LastCommitAt = &t
+		}
+		if bj.Actions == nil {
+			bj.Actions = []string{}
+		}
+		out = append(out, bj)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+func TestRenderBranches(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	renderBranches(&buf, []sdk.BranchInfo{
+		{
+			Branch: "wl/alice/w-1", WantedID: "w-1", Title: "Fix bug", Delta: "claim",
+			LastCommitAt: now.Add(-2 * 24 * time.Hour), Actions: []string{"submit_pr", "discard"},
+		},
+		{
+			Branch: "wl/alice/w-2", WantedID: "w-2", Title: "Write docs", Delta: "done",
+			PRURL: "https://example.com/pr/2", Actions: []string{"discard"},
+		},
+	}, now)
+
+	out := buf.String()
+	for _, want := range []string{"2 mutation branch(es)", "wl/alice/w-1", "Fix bug", "claim", "2d", "submit, discard", "https://example.com/pr/2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderBranches_Empty(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderBranches(&buf, nil, time.Now())
+	if !strings.Contains(buf.String(), "No mutation branches.") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestRenderBranchesJSON(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	err := renderBranchesJSON(&buf, []sdk.BranchInfo{
+		{Branch: "wl/alice/w-1", WantedID: "w-1", Title: "Fix bug", Delta: "claim"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0]["wanted_id"] != "w-1" {
+		t.Fatalf("decoded = %v", decoded)
+	}
+	if _, ok := decoded[0]["last_commit_at"]; ok {
+		t.Error("unknown age should be omitted")
+	}
+	if actions, ok := decoded[0]["actions"].([]any); !ok || len(actions) != 0 {
+		t.Errorf("actions = %v, want empty list", decoded[0]["actions"])
+	}
+}
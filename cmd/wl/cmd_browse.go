@@ -12,6 +12,7 @@ import (
 
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
@@ -24,13 +25,18 @@ func newBrowseCmd(stdout, stderr io.Writer) *cobra.Command {
 		itemType  string
 		priority  int
 		limit     int
+		page      int
 		jsonOut   bool
 		longOut   bool
 		ephemeral bool
 		postedBy  string
 		claimedBy string
 		search    string
+		query     string
+		tags      []string
 		view      string
+		groupBy   string
+		tuiMode   bool
 	)
 
 	cmd := &cobra.Command{
@@ -41,6 +47,11 @@ func newBrowseCmd(stdout, stderr io.Writer) *cobra.Command {
 
 Pulls the latest upstream changes into your local clone and queries it.
 Use --ephemeral to clone to a temp dir instead (slower, for edge cases).
+Use -i to open the interactive TUI instead of printing a table.
+
+Results are paged: --limit sets the page size and --page selects the page.
+Use --group-by to split the page into sections by project, type,
+priority, or status, each headed by its item count.
 
 In PR mode, branch mutations are merged into the results (same as the web UI).
 Use --view to control which branches are included:
@@ -55,15 +66,37 @@ EXAMPLES:
   wl browse --status claimed         # Claimed items
   wl browse --priority 0             # Critical priority only
   wl browse --limit 5               # Show 5 items
+  wl browse --limit 20 --page 3      # Items 41-60
   wl browse --json                   # JSON output
   wl browse --json --long             # JSON with description included
+  wl browse --group-by project       # Sections per project, with counts
   wl browse --view all               # Include all rigs' branch mutations
   wl browse --posted-by alice        # Items posted by alice
   wl browse --claimed-by bob         # Items claimed by bob
   wl browse --search auth            # Search in title
-  wl browse --ephemeral              # Clone upstream (slow)`,
+  wl browse --tag go --tag auth      # Items tagged go and auth (aliases resolve)
+  wl browse --query "status:open type:bug tag:go -project:infra"
+                                     # Filter expression (same as the API's ?q=)
+  wl browse --ephemeral              # Clone upstream (slow)
+  wl browse -i                       # Interactive TUI`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runBrowse(cmd, stdout, stderr, commons.BrowseFilter{
+			if tuiMode {
+				if jsonOut {
+					return fmt.Errorf("--json cannot be combined with -i")
+				}
+				return runTUI(cmd, stdout, stderr)
+			}
+			if page < 1 {
+				return fmt.Errorf("--page must be 1 or greater")
+			}
+			group, err := commons.ParseGroupBy(groupBy)
+			if err != nil {
+				return err
+			}
+			if group != commons.GroupNone && (jsonOut || ephemeral) {
+				return fmt.Errorf("--group-by cannot be combined with --json or --ephemeral")
+			}
+			filter := commons.BrowseFilter{
 				Status:    status,
 				Project:   project,
 				Type:      itemType,
@@ -72,9 +105,16 @@ EXAMPLES:
 				PostedBy:  postedBy,
 				ClaimedBy: claimedBy,
 				Search:    search,
+				Tags:      tags,
 				View:      view,
 				Long:      longOut,
-			}, jsonOut, ephemeral)
+			}
+			if query != "" {
+				if err := commons.ParseFilterExpr(query, &filter); err != nil {
+					return fmt.Errorf("invalid --query: %w", err)
+				}
+			}
+			return runBrowse(cmd, stdout, stderr, filter, group, page, jsonOut, ephemeral)
 		},
 	}
 
@@ -87,24 +127,27 @@ EXAMPLES:
 	typeHelp += ")"
 	cmd.Flags().StringVar(&itemType, "type", "", typeHelp)
 	cmd.Flags().IntVar(&priority, "priority", -1, "Filter by priority (0=critical, 2=medium, 4=backlog)")
-	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum items to display")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum items to display (page size)")
+	cmd.Flags().IntVar(&page, "page", 1, "Page of results to display (1-based)")
+	cmd.Flags().BoolVarP(&tuiMode, "interactive", "i", false, "Open the interactive TUI")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
 	cmd.Flags().BoolVarP(&longOut, "long", "l", false, "Include description in output")
 	cmd.Flags().BoolVar(&ephemeral, "ephemeral", false, "Clone upstream to temp dir instead of querying local (slow)")
 	cmd.Flags().StringVar(&postedBy, "posted-by", "", "Filter by poster's rig handle")
 	cmd.Flags().StringVar(&claimedBy, "claimed-by", "", "Filter by claimer's rig handle")
 	cmd.Flags().StringVar(&search, "search", "", "Search in title")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Filter by tag (repeatable; aliases resolve to the registered tag)")
+	cmd.Flags().StringVar(&query, "query", "", "Filter expression, e.g. 'status:open tag:go -project:infra'")
 	cmd.Flags().StringVar(&view, "view", "", "Branch view: mine (default), all, or upstream")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Group results into sections: project, type, priority, or status")
 	_ = cmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	_ = cmd.RegisterFlagCompletionFunc("tag", completeTagNames)
 	_ = cmd.RegisterFlagCompletionFunc("status", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"open", "claimed", "in_review", "completed", "withdrawn"}, cobra.ShellCompDirectiveNoFileComp
 	})
-	_ = cmd.RegisterFlagCompletionFunc("type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		types := []string{"feature", "bug", "design", "rfc", "docs"}
-		if inferGateEnabled() {
-			types = append(types, "inference")
-		}
-		return types, cobra.ShellCompDirectiveNoFileComp
+	_ = cmd.RegisterFlagCompletionFunc("type", completeItemTypes)
+	_ = cmd.RegisterFlagCompletionFunc("group-by", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"project", "type", "priority", "status"}, cobra.ShellCompDirectiveNoFileComp
 	})
 	_ = cmd.RegisterFlagCompletionFunc("view", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"mine", "all", "upstream"}, cobra.ShellCompDirectiveNoFileComp
@@ -113,11 +156,50 @@ EXAMPLES:
 	return cmd
 }
 
-func runBrowse(cmd *cobra.Command, stdout, stderr io.Writer, filter commons.BrowseFilter, jsonOut, ephemeral bool) error {
+// applyBrowseDefaults fills filter fields from the wasteland's configured
+// defaults ('wl config set default-*') for flags not given explicitly and
+// fields not set by --query.
+func applyBrowseDefaults(cmd *cobra.Command, filter commons.BrowseFilter, d *federation.BrowseDefaults) commons.BrowseFilter {
+	if d == nil {
+		return filter
+	}
+	changed := cmd.Flags().Changed
+	if d.Project != "" && !changed("project") && filter.Project == "" {
+		filter.Project = d.Project
+	}
+	if d.Status != "" && !changed("status") && filter.Status == "" {
+		filter.Status = d.Status
+	}
+	if d.Type != "" && !changed("type") && filter.Type == "" {
+		filter.Type = d.Type
+	}
+	if d.Priority != nil && !changed("priority") && filter.Priority < 0 {
+		filter.Priority = *d.Priority
+	}
+	if d.Limit > 0 && !changed("limit") {
+		filter.Limit = d.Limit
+	}
+	return filter
+}
+
+// browseOffset converts a 1-based page number into a row offset.
+func browseOffset(limit, page int) int {
+	if limit <= 0 {
+		limit = 50
+	}
+	if page <= 1 {
+		return 0
+	}
+	return (page - 1) * limit
+}
+
+func runBrowse(cmd *cobra.Command, stdout, stderr io.Writer, filter commons.BrowseFilter, group commons.GroupBy, page int, jsonOut, ephemeral bool) error {
 	cfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
 	}
+	filter = applyBrowseDefaults(cmd, filter, cfg.Defaults)
+	filter.Offset = browseOffset(filter.Limit, page)
 
 	if cfg.ResolveBackend() == federation.BackendLocal {
 		if err := requireDolt(); err != nil {
@@ -134,7 +216,7 @@ func runBrowse(cmd *cobra.Command, stdout, stderr io.Writer, filter commons.Brow
 			return runBrowseEphemeral(stdout, cfg, query, jsonOut)
 		}
 
-		if err := runBrowseLocal(stdout, stderr, cfg, filter, jsonOut); err != nil {
+		if err := runBrowseLocal(stdout, stderr, cfg, filter, group, jsonOut); err != nil {
 			return err
 		}
 		warnIfStale(stdout, cfg)
@@ -142,10 +224,10 @@ func runBrowse(cmd *cobra.Command, stdout, stderr io.Writer, filter commons.Brow
 	}
 
 	// Remote mode: query API directly, no sync needed.
-	return runBrowseRemote(stdout, stderr, cfg, filter, jsonOut)
+	return runBrowseRemote(stdout, stderr, cfg, filter, group, jsonOut)
 }
 
-func runBrowseLocal(stdout, stderr io.Writer, cfg *federation.Config, filter commons.BrowseFilter, jsonOut bool) error {
+func runBrowseLocal(stdout, stderr io.Writer, cfg *federation.Config, filter commons.BrowseFilter, group commons.GroupBy, jsonOut bool) error {
 	spinnerOut := stdout
 	if jsonOut {
 		spinnerOut = stderr
@@ -180,10 +262,10 @@ func runBrowseLocal(stdout, stderr io.Writer, cfg *federation.Config, filter com
 	if jsonOut {
 		return renderBrowseJSON(stdout, result)
 	}
-	return renderBrowseSummaries(stdout, result, filter.Long)
+	return renderBrowseSummaries(stdout, result, filter, group)
 }
 
-func runBrowseRemote(stdout, _ io.Writer, cfg *federation.Config, filter commons.BrowseFilter, jsonOut bool) error {
+func runBrowseRemote(stdout, _ io.Writer, cfg *federation.Config, filter commons.BrowseFilter, group commons.GroupBy, jsonOut bool) error {
 	db, err := openDBFromConfig(cfg)
 	if err != nil {
 		return err
@@ -202,15 +284,22 @@ func runBrowseRemote(stdout, _ io.Writer, cfg *federation.Config, filter commons
 	if jsonOut {
 		return renderBrowseJSON(stdout, result)
 	}
-	return renderBrowseSummaries(stdout, result, filter.Long)
+	return renderBrowseSummaries(stdout, result, filter, group)
 }
 
-func renderBrowseSummaries(stdout io.Writer, result *sdk.BrowseResult, long bool) error {
+// renderBrowseSummaries prints one page of browse results as a table, or
+// as one table per section when grouped.
+func renderBrowseSummaries(stdout io.Writer, result *sdk.BrowseResult, filter commons.BrowseFilter, group commons.GroupBy) error {
 	items := result.Items
 	if len(items) == 0 {
+		if filter.Offset > 0 {
+			fmt.Fprintln(stdout, "No more wanted items (past the last page).")
+			return nil
+		}
 		fmt.Fprintln(stdout, "No wanted items found matching your filters.")
 		return nil
 	}
+	long := filter.Long
 
 	columns := []style.Column{
 		{Name: "ID", Width: 12},
@@ -228,23 +317,56 @@ func renderBrowseSummaries(stdout io.Writer, result *sdk.BrowseResult, long bool
 		style.Column{Name: "EFFORT", Width: 8},
 	)
 
-	tbl := style.NewTable(columns...)
-
-	for _, item := range items {
-		pri := wlFormatPriority(fmt.Sprintf("%d", item.Priority))
-		if long {
-			tbl.AddRow(item.ID, item.Title, item.Description, item.Project, item.Type, pri, item.PostedBy, item.Status, item.EffortLevel)
-		} else {
-			tbl.AddRow(item.ID, item.Title, item.Project, item.Type, pri, item.PostedBy, item.Status, item.EffortLevel)
+	renderTable := func(items []commons.WantedSummary) string {
+		tbl := style.NewTable(columns...)
+		for _, item := range items {
+			pri := wlFormatPriority(fmt.Sprintf("%d", item.Priority))
+			if long {
+				tbl.AddRow(item.ID, item.Title, item.Description, item.Project, item.Type, pri, item.PostedBy, i18n.Status(item.Status), item.EffortLevel)
+			} else {
+				tbl.AddRow(item.ID, item.Title, item.Project, item.Type, pri, item.PostedBy, i18n.Status(item.Status), item.EffortLevel)
+			}
 		}
+		return tbl.Render()
 	}
 
-	fmt.Fprintf(stdout, "Wanted items (%d):\n\n", len(items))
-	fmt.Fprint(stdout, tbl.Render())
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	page := filter.Offset/limit + 1
+	more := len(items) >= limit
+	if page > 1 || more {
+		fmt.Fprintf(stdout, "Wanted items %d-%d (page %d):\n\n", filter.Offset+1, filter.Offset+len(items), page)
+	} else {
+		fmt.Fprintf(stdout, "Wanted items (%d):\n\n", len(items))
+	}
+	if group == commons.GroupNone {
+		fmt.Fprint(stdout, renderTable(items))
+	} else {
+		for i, g := range commons.GroupSummaries(items, group) {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			fmt.Fprintf(stdout, "%s %s\n\n", style.Bold.Render(groupLabel(g, group)), style.Dim.Render(fmt.Sprintf("(%d)", len(g.Items))))
+			fmt.Fprint(stdout, renderTable(g.Items))
+		}
+	}
+	if more {
+		fmt.Fprintf(stdout, "\n  %s\n", style.Dim.Render(fmt.Sprintf("More results: wl browse --page %d", page+1)))
+	}
 
 	return nil
 }
 
+// groupLabel returns a section heading, with statuses in the user's locale.
+func groupLabel(g commons.SummaryGroup, by commons.GroupBy) string {
+	if by == commons.GroupStatus && g.Key != "" {
+		return i18n.Status(g.Key)
+	}
+	return g.Label(by)
+}
+
 func renderBrowseJSON(stdout io.Writer, result *sdk.BrowseResult) error {
 	enc := json.NewEncoder(stdout)
 	enc.SetIndent("", "  ")
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
 )
 
 func TestWlParseCSV_Empty(t *testing.T) {
@@ -228,3 +232,101 @@ func TestBuildBrowseQuery_EscapesSQL(t *testing.T) {
 		t.Errorf("commons.BuildBrowseQuery should escape single quotes: %q", got)
 	}
 }
+
+func TestApplyBrowseDefaults(t *testing.T) {
+	t.Parallel()
+	p := 1
+	defaults := &federation.BrowseDefaults{Project: "gastown", Status: "claimed", Priority: &p, Limit: 10}
+
+	cmd := newBrowseCmd(io.Discard, io.Discard)
+	if err := cmd.Flags().Set("status", "open"); err != nil {
+		t.Fatal(err)
+	}
+	got := applyBrowseDefaults(cmd, commons.BrowseFilter{Status: "open", Priority: -1, Limit: 50}, defaults)
+
+	if got.Project != "gastown" {
+		t.Errorf("Project = %q, want default %q", got.Project, "gastown")
+	}
+	if got.Status != "open" {
+		t.Errorf("Status = %q, want explicit flag %q", got.Status, "open")
+	}
+	if got.Priority != 1 || got.Limit != 10 {
+		t.Errorf("Priority, Limit = %d, %d; want 1, 10", got.Priority, got.Limit)
+	}
+
+	if got := applyBrowseDefaults(cmd, commons.BrowseFilter{Limit: 50}, nil); got.Limit != 50 {
+		t.Errorf("nil defaults changed filter: %+v", got)
+	}
+}
+
+func TestBrowseOffset(t *testing.T) {
+	t.Parallel()
+	tests := []struct{ limit, page, want int }{
+		{50, 1, 0},
+		{50, 2, 50},
+		{20, 3, 40},
+		{0, 2, 50}, // default page size
+	}
+	for _, tt := range tests {
+		if got := browseOffset(tt.limit, tt.page); got != tt.want {
+			t.Errorf("browseOffset(%d, %d) = %d, want %d", tt.limit, tt.page, got, tt.want)
+		}
+	}
+}
+
+func TestRenderBrowseSummaries_Paging(t *testing.T) {
+	t.Parallel()
+	result := &sdk.BrowseResult{Items: []commons.WantedSummary{
+		{ID: "w-1", Title: "One", Status: "open"},
+		{ID: "w-2", Title: "Two", Status: "open"},
+	}}
+
+	var buf bytes.Buffer
+	if err := renderBrowseSummaries(&buf, result, commons.BrowseFilter{Limit: 2, Offset: 2}, commons.GroupNone); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Wanted items 3-4 (page 2)") {
+		t.Errorf("missing page header:\n%s", out)
+	}
+	if !strings.Contains(out, "wl browse --page 3") {
+		t.Errorf("missing next-page hint:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := renderBrowseSummaries(&buf, result, commons.BrowseFilter{Limit: 50}, commons.GroupNone); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Wanted items (2)") || strings.Contains(buf.String(), "--page") {
+		t.Errorf("single page should not show paging:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	_ = renderBrowseSummaries(&buf, &sdk.BrowseResult{}, commons.BrowseFilter{Limit: 2, Offset: 4}, commons.GroupNone)
+	if !strings.Contains(buf.String(), "past the last page") {
+		t.Errorf("empty later page output = %q", buf.String())
+	}
+}
+
+func TestRenderBrowseSummaries_GroupBy(t *testing.T) {
+	t.Parallel()
+	result := &sdk.BrowseResult{Items: []commons.WantedSummary{
+		{ID: "w-1", Title: "One", Project: "gastown", Status: "open"},
+		{ID: "w-2", Title: "Two", Status: "open"},
+		{ID: "w-3", Title: "Three", Project: "beads", Status: "open"},
+		{ID: "w-4", Title: "Four", Project: "gastown", Status: "open"},
+	}}
+
+	var buf bytes.Buffer
+	if err := renderBrowseSummaries(&buf, result, commons.BrowseFilter{Limit: 50}, commons.GroupProject); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	beads, gastown, none := strings.Index(out, "beads (1)"), strings.Index(out, "gastown (2)"), strings.Index(out, "(no project) (1)")
+	if beads < 0 || gastown < 0 || none < 0 || beads > gastown || gastown > none {
+		t.Errorf("want beads, gastown, then unset project sections:\n%s", out)
+	}
+	if strings.Index(out, "w-1") > strings.Index(out, "w-4") || strings.Index(out, "w-4") > none {
+		t.Errorf("gastown items should stay together in order:\n%s", out)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
@@ -61,8 +62,7 @@ func runClaim(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush b
 	renderMutationResult(stdout, "Claimed", wantedID, result,
 		"Claimed by: "+wlCfg.RigHandle)
 
-	hint := "Next: do the work, then: wl done " + wantedID + " --evidence <url>"
-	printNextHint(stdout, hint)
+	printNextHint(stdout, i18n.T("next.claim", wantedID))
 
 	return nil
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -57,7 +58,7 @@ func runClose(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush b
 	}
 
 	renderMutationResult(stdout, "Closed", wantedID, result)
-	printNextHint(stdout, "Next: item completed. View: wl status "+wantedID)
+	printNextHint(stdout, i18n.T("next.close", wantedID))
 
 	return nil
 }
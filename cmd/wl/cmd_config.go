@@ -1,11 +1,19 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/hooks"
+	"github.com/gastownhall/wasteland/internal/i18n"
+	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
 
@@ -15,14 +23,20 @@ func newConfigCmd(stdout, stderr io.Writer) *cobra.Command {
 		Short: "Get or set wasteland configuration",
 		Long: `View or modify wasteland configuration settings.
 
+Use 'wl config list' to show every setting.
 Use 'wl config get <key>' to read a setting.
 Use 'wl config set <key> <value>' to change a setting.
+Use 'wl config profile' to manage rig profiles selected with --as.
+Pass --json to any of them for machine-readable output.
 
 Supported keys:
-  mode            Workflow mode: pr (default) or wild-west
-  signing         Enable GPG-signed Dolt commits: true or false
-  provider-type   Upstream provider type (read-only, set during 'wl join')
-  github-repo     (deprecated) Upstream GitHub repo for PR shells`,
+` + configKeysHelp() + `
+Set signing-key or a default-*, hooks.* or alias.* key to an empty
+string to clear it.
+
+Hooks run via "sh -c" with the wanted item as JSON on stdin and
+WL_HOOK_EVENT set. A failing pre-* hook aborts the mutation; post-* hook
+failures are reported as warnings.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
@@ -32,13 +46,16 @@ Supported keys:
 	cmd.AddCommand(
 		newConfigGetCmd(stdout, stderr),
 		newConfigSetCmd(stdout, stderr),
+		newConfigListCmd(stdout, stderr),
+		newConfigProfileCmd(stdout, stderr),
 	)
 
 	return cmd
 }
 
 func newConfigGetCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var jsonOut bool
+	cmd := &cobra.Command{
 		Use:   "get <key>",
 		Short: "Get a configuration value",
 		Args:  cobra.ExactArgs(1),
@@ -46,50 +63,485 @@ func newConfigGetCmd(stdout, stderr io.Writer) *cobra.Command {
 			if len(args) > 0 {
 				return nil, cobra.ShellCompDirectiveNoFileComp
 			}
-			return []string{"mode", "signing", "provider-type", "github-repo"}, cobra.ShellCompDirectiveNoFileComp
+			return configKeyNames(false), cobra.ShellCompDirectiveNoFileComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigGet(cmd, stdout, stderr, args[0])
+			return runConfigGet(cmd, stdout, stderr, args[0], jsonOut)
 		},
 	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
 }
 
 func newConfigSetCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var jsonOut bool
+	cmd := &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
 		Args:  cobra.ExactArgs(2),
-		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
 			switch len(args) {
 			case 0:
-				return []string{"mode", "signing", "github-repo"}, cobra.ShellCompDirectiveNoFileComp
+				return configKeyNames(true), cobra.ShellCompDirectiveNoFileComp
 			case 1:
-				switch args[0] {
-				case "mode":
-					return []string{"wild-west", "pr"}, cobra.ShellCompDirectiveNoFileComp
-				case "signing":
-					return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+				k := lookupConfigKey(args[0])
+				if k != nil && k.complete != nil {
+					return k.complete(cmd), cobra.ShellCompDirectiveNoFileComp
+				}
+				if k != nil {
+					return k.values, cobra.ShellCompDirectiveNoFileComp
 				}
 			}
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigSet(cmd, stdout, stderr, args[0], args[1])
+			return runConfigSet(cmd, stdout, stderr, args[0], args[1], jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newConfigListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all configuration values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runConfigList(cmd, stdout, stderr, jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+// configKey describes one key exposed by 'wl config'.
+type configKey struct {
+	name     string
+	help     string
+	values   []string                          // completion candidates for set
+	complete func(cmd *cobra.Command) []string // completion candidates read from the wasteland; overrides values
+	readOnly string                            // non-empty: reason the key can't be set
+	get      func(cfg *federation.Config) any
+	set      func(cfg *federation.Config, value string) error // validates, then applies
+}
+
+// configKeys lists the keys that can be read/written via wl config, in
+// display order.
+var configKeys = []configKey{
+	{
+		name:   "mode",
+		help:   "Workflow mode: pr (default) or wild-west",
+		values: []string{federation.ModeWildWest, federation.ModePR},
+		get:    func(cfg *federation.Config) any { return cfg.ResolveMode() },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateMode(v); err != nil {
+				return err
+			}
+			cfg.Mode = v
+			return nil
+		},
+	},
+	{
+		name:   "signing",
+		help:   "Enable GPG-signed Dolt commits: true or false",
+		values: []string{"true", "false"},
+		get:    func(cfg *federation.Config) any { return cfg.Signing },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateBool("signing", v); err != nil {
+				return err
+			}
+			cfg.Signing = v == "true"
+			return nil
+		},
+	},
+	{
+		name:   "confirm-push",
+		help:   "Ask before every wild-west push to upstream: true or false",
+		values: []string{"true", "false"},
+		get:    func(cfg *federation.Config) any { return cfg.ConfirmPush },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateBool("confirm-push", v); err != nil {
+				return err
+			}
+			cfg.ConfirmPush = v == "true"
+			return nil
+		},
+	},
+	{
+		name:   "desktop-notify",
+		help:   "Pop desktop notifications in the TUI when followed items change: true or false",
+		values: []string{"true", "false"},
+		get:    func(cfg *federation.Config) any { return cfg.DesktopNotify },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateBool("desktop-notify", v); err != nil {
+				return err
+			}
+			cfg.DesktopNotify = v == "true"
+			return nil
+		},
+	},
+	{
+		name: "signing-key",
+		help: "GPG key ID for signed commits (default: dolt's user.signingkey)",
+		get:  func(cfg *federation.Config) any { return cfg.SigningKey },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateSigningKey("signing-key", v); err != nil {
+				return err
+			}
+			cfg.SigningKey = v
+			return nil
+		},
+	},
+	{
+		name:   "sql-server",
+		help:   "Serve the local clone from a managed dolt sql-server: true or false",
+		values: []string{"true", "false"},
+		get:    func(cfg *federation.Config) any { return cfg.SQLServer },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateBool("sql-server", v); err != nil {
+				return err
+			}
+			cfg.SQLServer = v == "true"
+			return nil
+		},
+	},
+	{
+		name:   "read-only",
+		help:   "Refuse all mutations (dashboards, kiosks, untrusted agents): true or false",
+		values: []string{"true", "false"},
+		get:    func(cfg *federation.Config) any { return cfg.ReadOnly },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateBool("read-only", v); err != nil {
+				return err
+			}
+			cfg.ReadOnly = v == "true"
+			return nil
+		},
+	},
+	{
+		name:     "provider-type",
+		help:     "Upstream provider type (read-only, set during 'wl join')",
+		readOnly: "set during 'wl join'",
+		get:      func(cfg *federation.Config) any { return cfg.ResolveProviderType() },
+	},
+	{
+		name: "upstream-url",
+		help: "Dolt remote URL of the upstream commons",
+		get:  func(cfg *federation.Config) any { return cfg.UpstreamURL },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateRemoteURL(v); err != nil {
+				return err
+			}
+			cfg.UpstreamURL = v
+			return nil
+		},
+	},
+	{
+		name: "fork-org",
+		help: "Org that owns your fork",
+		get:  func(cfg *federation.Config) any { return cfg.ForkOrg },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validatePathSegment("fork-org", v); err != nil {
+				return err
+			}
+			cfg.ForkOrg = v
+			return nil
+		},
+	},
+	{
+		name: "fork-db",
+		help: "Database name of your fork",
+		get:  func(cfg *federation.Config) any { return cfg.ForkDB },
+		set: func(cfg *federation.Config, v string) error {
+			if err := validatePathSegment("fork-db", v); err != nil {
+				return err
+			}
+			cfg.ForkDB = v
+			return nil
+		},
+	},
+	{
+		name:     "rig-handle",
+		help:     "Your rig handle (read-only, set during 'wl join')",
+		readOnly: "set during 'wl join'",
+		get:      func(cfg *federation.Config) any { return cfg.RigHandle },
+	},
+	{
+		name:     "local-dir",
+		help:     "Local clone directory (read-only)",
+		readOnly: "managed by 'wl join'",
+		get:      func(cfg *federation.Config) any { return cfg.LocalDir },
+	},
+	{
+		name: "default-project",
+		help: "Default --project filter for 'wl browse'",
+		get: func(cfg *federation.Config) any {
+			return browseDefaults(cfg).Project
+		},
+		set: func(cfg *federation.Config, v string) error {
+			ensureBrowseDefaults(cfg).Project = v
+			return nil
+		},
+	},
+	{
+		name:   "default-status",
+		help:   "Default --status filter for 'wl browse'",
+		values: validStatusValues,
+		get: func(cfg *federation.Config) any {
+			return browseDefaults(cfg).Status
+		},
+		set: func(cfg *federation.Config, v string) error {
+			if v != "" && !slices.Contains(validStatusValues, v) {
+				return fmt.Errorf("invalid status %q: must be one of %s", v, strings.Join(validStatusValues, ", "))
+			}
+			ensureBrowseDefaults(cfg).Status = v
+			return nil
+		},
+	},
+	{
+		name:     "default-type",
+		help:     "Default --type filter for 'wl browse'",
+		complete: func(cmd *cobra.Command) []string { return completionVocabulary(cmd).Types },
+		get: func(cfg *federation.Config) any {
+			return browseDefaults(cfg).Type
+		},
+		set: func(cfg *federation.Config, v string) error {
+			if vocab := configVocabulary(cfg); v != "" && vocab != nil && !slices.Contains(vocab.Types, v) {
+				return fmt.Errorf("invalid type %q: must be one of %s", v, strings.Join(vocab.Types, ", "))
+			}
+			ensureBrowseDefaults(cfg).Type = v
+			return nil
+		},
+	},
+	{
+		name:   "default-priority",
+		help:   "Default --priority filter for 'wl browse' (0-4)",
+		values: []string{"0", "1", "2", "3", "4"},
+		get: func(cfg *federation.Config) any {
+			if p := browseDefaults(cfg).Priority; p != nil {
+				return *p
+			}
+			return nil
+		},
+		set: func(cfg *federation.Config, v string) error {
+			if v == "" {
+				ensureBrowseDefaults(cfg).Priority = nil
+				return nil
+			}
+			p, err := strconv.Atoi(v)
+			if err != nil || p < 0 || p > 4 {
+				return fmt.Errorf("invalid priority %q: must be 0-4", v)
+			}
+			ensureBrowseDefaults(cfg).Priority = &p
+			return nil
+		},
+	},
+	{
+		name: "default-limit",
+		help: "Default --limit for 'wl browse'",
+		get: func(cfg *federation.Config) any {
+			if l := browseDefaults(cfg).Limit; l > 0 {
+				return l
+			}
+			return nil
+		},
+		set: func(cfg *federation.Config, v string) error {
+			if v == "" {
+				ensureBrowseDefaults(cfg).Limit = 0
+				return nil
+			}
+			l, err := strconv.Atoi(v)
+			if err != nil || l <= 0 {
+				return fmt.Errorf("invalid limit %q: must be a positive integer", v)
+			}
+			ensureBrowseDefaults(cfg).Limit = l
+			return nil
 		},
+	},
+	{
+		name:   "locale",
+		help:   "Language for CLI and TUI labels (WL_LOCALE overrides)",
+		values: i18n.Locales(),
+		get:    func(cfg *federation.Config) any { return cfg.Locale },
+		set: func(cfg *federation.Config, v string) error {
+			cfg.Locale = i18n.Normalize(v)
+			return nil
+		},
+	},
+	{
+		name: "github-repo",
+		help: "(deprecated) Upstream GitHub repo for PR shells",
+		get:  func(cfg *federation.Config) any { return cfg.GitHubRepo }, //nolint:staticcheck // backward compat
+		set: func(cfg *federation.Config, v string) error {
+			if err := validateGitHubRepo(v); err != nil {
+				return err
+			}
+			cfg.GitHubRepo = v //nolint:staticcheck // backward compat
+			return nil
+		},
+	},
+	hookConfigKey(hooks.PreClaim),
+	hookConfigKey(hooks.PostClaim),
+	hookConfigKey(hooks.PreDone),
+	hookConfigKey(hooks.PostDone),
+	hookConfigKey(hooks.PreAccept),
+	hookConfigKey(hooks.PostAccept),
+}
+
+// hookConfigKey exposes the shell command for a lifecycle hook as
+// "hooks.<event>". Setting it to "" removes the hook.
+func hookConfigKey(event string) configKey {
+	return configKey{
+		name: "hooks." + event,
+		help: "Command run " + hookWhen(event),
+		get:  func(cfg *federation.Config) any { return cfg.Hooks[event] },
+		set: func(cfg *federation.Config, v string) error {
+			if strings.TrimSpace(v) == "" {
+				delete(cfg.Hooks, event)
+				if len(cfg.Hooks) == 0 {
+					cfg.Hooks = nil
+				}
+				return nil
+			}
+			if cfg.Hooks == nil {
+				cfg.Hooks = make(map[string]string)
+			}
+			cfg.Hooks[event] = v
+			return nil
+		},
+	}
+}
+
+// aliasConfigKey exposes a command alias as "alias.<name>". Setting it to
+// "" removes the alias.
+func aliasConfigKey(alias string) *configKey {
+	return &configKey{
+		name: "alias." + alias,
+		help: "Command alias (see 'wl alias')",
+		get:  func(cfg *federation.Config) any { return cfg.Aliases[alias] },
+		set: func(cfg *federation.Config, v string) error {
+			if strings.TrimSpace(v) != "" {
+				if err := validateAlias(alias, v); err != nil {
+					return err
+				}
+			}
+			setAlias(cfg, alias, v)
+			return nil
+		},
+	}
+}
+
+// hookWhen describes when a hook event fires, e.g. "before claim".
+func hookWhen(event string) string {
+	when, action, _ := strings.Cut(event, "-")
+	if when == "pre" {
+		return "before " + action + " (non-zero exit aborts)"
+	}
+	return "after " + action
+}
+
+var validStatusValues = []string{"open", "claimed", "in_review", "completed", "withdrawn"}
+
+// configVocabulary returns the item vocabulary of cfg's wasteland, or the
+// defaults when it can't be read. runConfigSet's pre-check runs against an
+// empty config, which has no wasteland: that gets nil, and the type is
+// checked once the real config is loaded.
+func configVocabulary(cfg *federation.Config) *commons.Vocabulary {
+	if cfg.Upstream == "" {
+		return nil
+	}
+	if db, err := openDBFromConfig(cfg); err == nil {
+		if v, err := commons.QueryVocabulary(db); err == nil {
+			return v
+		}
+	}
+	return commons.DefaultVocabulary()
+}
+
+// validConfigKeys is the set of key names in configKeys.
+var validConfigKeys = func() map[string]bool {
+	m := make(map[string]bool, len(configKeys))
+	for _, k := range configKeys {
+		m[k.name] = true
+	}
+	return m
+}()
+
+func lookupConfigKey(name string) *configKey {
+	if alias, ok := strings.CutPrefix(name, "alias."); ok && alias != "" {
+		return aliasConfigKey(alias)
+	}
+	for i := range configKeys {
+		if configKeys[i].name == name {
+			return &configKeys[i]
+		}
+	}
+	return nil
+}
+
+// configKeyNames returns key names in display order, optionally only the
+// writable ones.
+func configKeyNames(writableOnly bool) []string {
+	var names []string
+	for _, k := range configKeys {
+		if writableOnly && k.readOnly != "" {
+			continue
+		}
+		names = append(names, k.name)
+	}
+	return names
+}
+
+func configKeysHelp() string {
+	var b strings.Builder
+	for _, k := range configKeys {
+		fmt.Fprintf(&b, "  %-17s %s\n", k.name, k.help)
+	}
+	fmt.Fprintf(&b, "  %-17s %s\n", "alias.<name>", "Command alias (see 'wl alias')")
+	return b.String()
+}
+
+func unknownConfigKeyError(key string) error {
+	return fmt.Errorf("unknown config key %q (supported: %s)", key, strings.Join(configKeyNames(false), ", "))
+}
+
+// browseDefaults returns cfg.Defaults, or an empty value when unset.
+func browseDefaults(cfg *federation.Config) federation.BrowseDefaults {
+	if cfg.Defaults == nil {
+		return federation.BrowseDefaults{}
 	}
+	return *cfg.Defaults
 }
 
-// validConfigKeys lists the keys that can be read/written via wl config.
-var validConfigKeys = map[string]bool{
-	"mode":          true,
-	"signing":       true,
-	"github-repo":   true,
-	"provider-type": true,
+// ensureBrowseDefaults returns cfg.Defaults, allocating it if needed.
+func ensureBrowseDefaults(cfg *federation.Config) *federation.BrowseDefaults {
+	if cfg.Defaults == nil {
+		cfg.Defaults = &federation.BrowseDefaults{}
+	}
+	return cfg.Defaults
 }
 
-func runConfigGet(cmd *cobra.Command, stdout, _ io.Writer, key string) error {
-	if !validConfigKeys[key] {
-		return fmt.Errorf("unknown config key %q (supported: mode, signing, provider-type, github-repo)", key)
+// formatConfigValue renders a config value for text output; unset values
+// render as "".
+func formatConfigValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// configEntry is the JSON shape for a single key.
+type configEntry struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+func runConfigGet(cmd *cobra.Command, stdout, _ io.Writer, key string, jsonOut bool) error {
+	k := lookupConfigKey(key)
+	if k == nil {
+		return unknownConfigKeyError(key)
 	}
 
 	cfg, err := resolveWasteland(cmd)
@@ -97,39 +549,59 @@ func runConfigGet(cmd *cobra.Command, stdout, _ io.Writer, key string) error {
 		return hintWrap(err)
 	}
 
-	switch key {
-	case "mode":
-		fmt.Fprintln(stdout, cfg.ResolveMode())
-	case "signing":
-		fmt.Fprintln(stdout, cfg.Signing)
-	case "provider-type":
-		fmt.Fprintln(stdout, cfg.ResolveProviderType())
-	case "github-repo":
-		fmt.Fprintln(stdout, cfg.GitHubRepo) //nolint:staticcheck // backward compat
+	v := k.get(cfg)
+	if jsonOut {
+		return writeConfigJSON(stdout, configEntry{Key: k.name, Value: v})
 	}
+	fmt.Fprintln(stdout, formatConfigValue(v))
 	return nil
 }
 
-func runConfigSet(cmd *cobra.Command, stdout, _ io.Writer, key, value string) error {
-	if !validConfigKeys[key] {
-		return fmt.Errorf("unknown config key %q (supported: mode, signing, provider-type, github-repo)", key)
+func runConfigList(cmd *cobra.Command, stdout, _ io.Writer, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
 	}
 
-	switch key {
-	case "provider-type":
-		return fmt.Errorf("provider-type is read-only (set during 'wl join')")
-	case "mode":
-		if err := validateMode(value); err != nil {
-			return err
+	if jsonOut {
+		values := make(map[string]any, len(configKeys))
+		for _, k := range configKeys {
+			values[k.name] = k.get(cfg)
 		}
-	case "signing":
-		if err := validateSigning(value); err != nil {
-			return err
+		for name, expansion := range cfg.Aliases {
+			values["alias."+name] = expansion
 		}
-	case "github-repo":
-		if err := validateGitHubRepo(value); err != nil {
-			return err
+		return writeConfigJSON(stdout, values)
+	}
+
+	for _, k := range configKeys {
+		v := formatConfigValue(k.get(cfg))
+		if v == "" {
+			v = style.Dim.Render("(unset)")
 		}
+		fmt.Fprintf(stdout, "%-17s %s\n", k.name, v)
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Aliases)) {
+		fmt.Fprintf(stdout, "%-17s %s\n", "alias."+name, cfg.Aliases[name])
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, stdout, _ io.Writer, key, value string, jsonOut bool) error {
+	k := lookupConfigKey(key)
+	if k == nil {
+		return unknownConfigKeyError(key)
+	}
+	if k.readOnly != "" {
+		return fmt.Errorf("%s is read-only (%s)", key, k.readOnly)
+	}
+	if readOnlyRequested(cmd) {
+		return &commons.ReadOnlyError{Op: "config set"}
+	}
+
+	// Validate before touching the store so bad values fail fast.
+	if err := k.set(&federation.Config{}, value); err != nil {
+		return err
 	}
 
 	explicit, _ := cmd.Flags().GetString("wasteland")
@@ -139,23 +611,51 @@ func runConfigSet(cmd *cobra.Command, stdout, _ io.Writer, key, value string) er
 		return hintWrap(err)
 	}
 
-	switch key {
-	case "mode":
-		cfg.Mode = value
-	case "signing":
-		cfg.Signing = value == "true"
-	case "github-repo":
-		cfg.GitHubRepo = value //nolint:staticcheck // backward compat
+	if err := k.set(cfg, value); err != nil {
+		return err
+	}
+	if cfg.Defaults != nil && *cfg.Defaults == (federation.BrowseDefaults{}) {
+		cfg.Defaults = nil
 	}
 
 	if err := store.Save(cfg); err != nil {
 		return fmt.Errorf("saving wasteland config: %w", err)
 	}
 
+	if jsonOut {
+		return writeConfigJSON(stdout, configEntry{Key: k.name, Value: k.get(cfg)})
+	}
 	fmt.Fprintf(stdout, "%s = %s\n", key, value)
 	return nil
 }
 
+// updateConfig loads the selected wasteland's config, applies fn and
+// saves it. op names the mutation in the read-only error.
+func updateConfig(cmd *cobra.Command, op string, fn func(cfg *federation.Config) error) error {
+	if readOnlyRequested(cmd) {
+		return &commons.ReadOnlyError{Op: op}
+	}
+	explicit, _ := cmd.Flags().GetString("wasteland")
+	store := federation.NewConfigStore()
+	cfg, err := federation.ResolveConfig(store, explicit)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if err := fn(cfg); err != nil {
+		return err
+	}
+	if err := store.Save(cfg); err != nil {
+		return fmt.Errorf("saving wasteland config: %w", err)
+	}
+	return nil
+}
+
+func writeConfigJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
 func validateGitHubRepo(value string) error {
 	parts := strings.SplitN(value, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
@@ -164,12 +664,12 @@ func validateGitHubRepo(value string) error {
 	return nil
 }
 
-func validateSigning(value string) error {
+func validateBool(key, value string) error {
 	switch value {
 	case "true", "false":
 		return nil
 	default:
-		return fmt.Errorf("invalid signing value %q: must be \"true\" or \"false\"", value)
+		return fmt.Errorf("invalid %s value %q: must be \"true\" or \"false\"", key, value)
 	}
 }
 
@@ -181,3 +681,25 @@ func validateMode(value string) error {
 		return fmt.Errorf("invalid mode %q: must be %q or %q", value, federation.ModeWildWest, federation.ModePR)
 	}
 }
+
+func validateRemoteURL(value string) error {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok || scheme == "" || rest == "" {
+		return fmt.Errorf("invalid upstream-url %q: expected a URL like https://doltremoteapi.dolthub.com/org/db", value)
+	}
+	return nil
+}
+
+func validateSigningKey(key, value string) error {
+	if strings.ContainsAny(value, " '\"=") {
+		return fmt.Errorf("invalid %s %q: must be a GPG key ID or fingerprint", key, value)
+	}
+	return nil
+}
+
+func validatePathSegment(key, value string) error {
+	if value == "" || strings.ContainsAny(value, "/ ") {
+		return fmt.Errorf("invalid %s %q: must be a non-empty name without slashes or spaces", key, value)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/spf13/cobra"
+)
+
+func newConfigProfileCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage rig profiles",
+		Long: `Manage alternate rig identities for this wasteland.
+
+A rig profile lets several rigs share one machine and clone — say, you and
+an agent working alongside you. Each profile has its own rig handle and,
+optionally, its own HOP URI and GPG signing key. Select one per command
+with --as <profile>, or for a whole session with WL_PROFILE; --as wins.
+The selected profile's handle is used for posted_by, claimed_by,
+completed_by and the wl/<handle>/* branch names.
+
+Without --as or WL_PROFILE, commands act as the rig that joined.
+
+EXAMPLES:
+  wl config profile set agent --handle alice-bot --signing-key 3AA5C34371567BD2
+  wl --as agent claim w-abc123
+  WL_PROFILE=agent wl browse --claimed-by alice-bot
+  wl config profile list
+  wl config profile rm agent`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		newConfigProfileListCmd(stdout, stderr),
+		newConfigProfileSetCmd(stdout, stderr),
+		newConfigProfileRmCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newConfigProfileListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List rig profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runConfigProfileList(cmd, stdout, stderr, jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newConfigProfileSetCmd(stdout, stderr io.Writer) *cobra.Command {
+	var p federation.RigProfile
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Create or replace a rig profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigProfileSet(cmd, stdout, stderr, args[0], p)
+		},
+	}
+	cmd.Flags().StringVar(&p.Handle, "handle", "", "Rig handle the profile acts as (required)")
+	cmd.Flags().StringVar(&p.HopURI, "hop-uri", "", "HOP URI for the profile (default: the joined rig's)")
+	cmd.Flags().StringVar(&p.SigningKey, "signing-key", "", "GPG key ID for the profile's signed commits (default: signing-key)")
+	_ = cmd.MarkFlagRequired("handle")
+	return cmd
+}
+
+func newConfigProfileRmCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm <name>",
+		Short:             "Remove a rig profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfileNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigProfileRm(cmd, stdout, stderr, args[0])
+		},
+	}
+}
+
+func runConfigProfileList(cmd *cobra.Command, stdout, _ io.Writer, jsonOut bool) error {
+	explicit, _ := cmd.Flags().GetString("wasteland")
+	cfg, err := federation.ResolveConfig(federation.NewConfigStore(), explicit)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if jsonOut {
+		profiles := cfg.Profiles
+		if profiles == nil {
+			profiles = map[string]*federation.RigProfile{}
+		}
+		return writeConfigJSON(stdout, profiles)
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Profiles)) {
+		p := cfg.Profiles[name]
+		line := fmt.Sprintf("%-12s %s", name, p.Handle)
+		if p.HopURI != "" {
+			line += "  " + p.HopURI
+		}
+		if p.SigningKey != "" {
+			line += "  key " + p.SigningKey
+		}
+		fmt.Fprintln(stdout, line)
+	}
+	return nil
+}
+
+func runConfigProfileSet(cmd *cobra.Command, stdout, _ io.Writer, name string, p federation.RigProfile) error {
+	if err := validateProfile(name, &p); err != nil {
+		return err
+	}
+	return updateConfig(cmd, "config profile set", func(cfg *federation.Config) error {
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]*federation.RigProfile)
+		}
+		cfg.Profiles[name] = &p
+		fmt.Fprintf(stdout, "Profile %s acts as rig %s (use --as %s)\n", name, p.Handle, name)
+		return nil
+	})
+}
+
+func runConfigProfileRm(cmd *cobra.Command, stdout, _ io.Writer, name string) error {
+	return updateConfig(cmd, "config profile rm", func(cfg *federation.Config) error {
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("no profile %q", name)
+		}
+		delete(cfg.Profiles, name)
+		if len(cfg.Profiles) == 0 {
+			cfg.Profiles = nil
+		}
+		fmt.Fprintf(stdout, "Removed profile %s\n", name)
+		return nil
+	})
+}
+
+var profileNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// validateProfile checks a profile's name and fields before it's saved.
+func validateProfile(name string, p *federation.RigProfile) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits and dashes", name)
+	}
+	if err := validatePathSegment("handle", p.Handle); err != nil {
+		return err
+	}
+	return validateSigningKey("signing-key", p.SigningKey)
+}
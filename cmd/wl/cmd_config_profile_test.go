@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+func TestConfigProfile_SetListRm(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		RigHandle: "alice", JoinedAt: time.Now(),
+	})
+
+	var stdout bytes.Buffer
+	p := federation.RigProfile{Handle: "alice-bot", SigningKey: "3AA5C34371567BD2"}
+	if err := runConfigProfileSet(configCmd(), &stdout, io.Discard, "agent", p); err != nil {
+		t.Fatalf("set error: %v", err)
+	}
+
+	stdout.Reset()
+	if err := runConfigProfileList(configCmd(), &stdout, io.Discard, false); err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	if got := stdout.String(); !strings.Contains(got, "agent") || !strings.Contains(got, "alice-bot") || !strings.Contains(got, "3AA5C34371567BD2") {
+		t.Errorf("list output = %q", got)
+	}
+
+	if err := runConfigProfileRm(configCmd(), &stdout, io.Discard, "agent"); err != nil {
+		t.Fatalf("rm error: %v", err)
+	}
+	cfg, err := federation.NewConfigStore().Load("hop/wl-commons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profiles != nil {
+		t.Errorf("Profiles = %v, want nil after removing the last one", cfg.Profiles)
+	}
+	if err := runConfigProfileRm(configCmd(), &stdout, io.Discard, "agent"); err == nil {
+		t.Error("expected error removing a missing profile")
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		p    federation.RigProfile
+		ok   bool
+	}{
+		{"agent", federation.RigProfile{Handle: "alice-bot"}, true},
+		{"Agent", federation.RigProfile{Handle: "alice-bot"}, false},
+		{"agent", federation.RigProfile{Handle: "alice/bot"}, false},
+		{"agent", federation.RigProfile{Handle: "alice-bot", SigningKey: "x' OR 1"}, false},
+	}
+	for _, tt := range tests {
+		err := validateProfile(tt.name, &tt.p)
+		if (err == nil) != tt.ok {
+			t.Errorf("validateProfile(%q, %+v) error = %v, want ok=%v", tt.name, tt.p, err, tt.ok)
+		}
+	}
+}
+
+func TestResolveWasteland_Profile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("WL_PROFILE", "")
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		RigHandle: "alice", JoinedAt: time.Now(),
+		Profiles: map[string]*federation.RigProfile{
+			"agent": {Handle: "alice-bot", SigningKey: "BBBB"},
+			"ci":    {Handle: "alice-ci"},
+		},
+	})
+
+	cmd := configCmd()
+	cmd.Flags().String("as", "", "")
+
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		t.Fatalf("resolveWasteland() error: %v", err)
+	}
+	if cfg.RigHandle != "alice" || cfg.SigningKey != "" {
+		t.Errorf("no profile: handle %q key %q, want alice and none", cfg.RigHandle, cfg.SigningKey)
+	}
+
+	t.Setenv("WL_PROFILE", "ci")
+	if cfg, err = resolveWasteland(cmd); err != nil || cfg.RigHandle != "alice-ci" {
+		t.Errorf("WL_PROFILE=ci: handle %q, err %v", cfg.RigHandle, err)
+	}
+
+	_ = cmd.Flags().Set("as", "agent")
+	if cfg, err = resolveWasteland(cmd); err != nil || cfg.RigHandle != "alice-bot" {
+		t.Fatalf("--as agent should win over WL_PROFILE: handle %q, err %v", cfg.RigHandle, err)
+	}
+	if got := commitSigning(cfg).Key; got != "BBBB" {
+		t.Errorf("commitSigning().Key = %q, want the profile's key", got)
+	}
+
+	_ = cmd.Flags().Set("as", "ghost")
+	if _, err := resolveWasteland(cmd); !errors.Is(err, federation.ErrUnknownProfile) {
+		t.Errorf("--as ghost error = %v, want ErrUnknownProfile", err)
+	}
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
 	"github.com/spf13/cobra"
 )
@@ -87,7 +89,7 @@ func TestRunConfigGet_Mode(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "mode")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "mode", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(mode) error: %v", err)
 	}
@@ -104,7 +106,7 @@ func TestRunConfigGet_ModeDefault(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "mode")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "mode", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(mode) error: %v", err)
 	}
@@ -121,7 +123,7 @@ func TestRunConfigGet_ProviderType(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "provider-type")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "provider-type", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(provider-type) error: %v", err)
 	}
@@ -138,7 +140,7 @@ func TestRunConfigGet_ProviderTypeDefault(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "provider-type")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "provider-type", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(provider-type) error: %v", err)
 	}
@@ -155,7 +157,7 @@ func TestRunConfigGet_GitHubRepo(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "github-repo")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "github-repo", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(github-repo) error: %v", err)
 	}
@@ -172,7 +174,7 @@ func TestRunConfigGet_UnknownKey(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "nonexistent")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "nonexistent", false)
 	if err == nil {
 		t.Fatal("runConfigGet(nonexistent) expected error")
 	}
@@ -185,7 +187,7 @@ func TestRunConfigGet_NotJoined(t *testing.T) {
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "mode")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "mode", false)
 	if err == nil {
 		t.Fatal("runConfigGet when not joined expected error")
 	}
@@ -199,7 +201,7 @@ func TestRunConfigSet_Mode(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "mode", "pr")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "mode", "pr", false)
 	if err != nil {
 		t.Fatalf("runConfigSet(mode, pr) error: %v", err)
 	}
@@ -226,7 +228,7 @@ func TestRunConfigSet_ModeInvalid(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "mode", "chaos")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "mode", "chaos", false)
 	if err == nil {
 		t.Fatal("runConfigSet(mode, chaos) expected error")
 	}
@@ -243,7 +245,7 @@ func TestRunConfigSet_ProviderTypeReadOnly(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "provider-type", "github")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "provider-type", "github", false)
 	if err == nil {
 		t.Fatal("runConfigSet(provider-type) expected error (read-only)")
 	}
@@ -260,7 +262,7 @@ func TestRunConfigSet_GitHubRepo(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "github-repo", "org/repo")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "github-repo", "org/repo", false)
 	if err != nil {
 		t.Fatalf("runConfigSet(github-repo) error: %v", err)
 	}
@@ -275,6 +277,27 @@ func TestRunConfigSet_GitHubRepo(t *testing.T) {
 	}
 }
 
+func TestRunConfigSet_Locale(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := runConfigSet(configCmd(), &stdout, &stderr, "locale", "de_DE.UTF-8", false); err != nil {
+		t.Fatalf("runConfigSet(locale) error: %v", err)
+	}
+
+	loaded, err := federation.NewConfigStore().Load("hop/wl-commons")
+	if err != nil {
+		t.Fatalf("loading config after set: %v", err)
+	}
+	if loaded.Locale != "de-de" {
+		t.Errorf("saved Locale = %q, want %q", loaded.Locale, "de-de")
+	}
+}
+
 func TestRunConfigSet_GitHubRepoInvalid(t *testing.T) {
 	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
 	saveTestConfig(t, &federation.Config{
@@ -283,7 +306,7 @@ func TestRunConfigSet_GitHubRepoInvalid(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "github-repo", "noslash")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "github-repo", "noslash", false)
 	if err == nil {
 		t.Fatal("runConfigSet(github-repo, noslash) expected error")
 	}
@@ -294,7 +317,7 @@ func TestRunConfigSet_GitHubRepoInvalid(t *testing.T) {
 
 func TestRunConfigSet_UnknownKey(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "bogus", "value")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "bogus", "value", false)
 	if err == nil {
 		t.Fatal("runConfigSet(bogus) expected error")
 	}
@@ -317,7 +340,7 @@ func TestRunConfigGet_Signing(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "signing")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "signing", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(signing) error: %v", err)
 	}
@@ -334,7 +357,7 @@ func TestRunConfigGet_SigningDefault(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigGet(configCmd(), &stdout, &stderr, "signing")
+	err := runConfigGet(configCmd(), &stdout, &stderr, "signing", false)
 	if err != nil {
 		t.Fatalf("runConfigGet(signing) error: %v", err)
 	}
@@ -351,7 +374,7 @@ func TestRunConfigSet_Signing(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "signing", "true")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "signing", "true", false)
 	if err != nil {
 		t.Fatalf("runConfigSet(signing, true) error: %v", err)
 	}
@@ -378,7 +401,7 @@ func TestRunConfigSet_SigningInvalid(t *testing.T) {
 	})
 
 	var stdout, stderr bytes.Buffer
-	err := runConfigSet(configCmd(), &stdout, &stderr, "signing", "yes")
+	err := runConfigSet(configCmd(), &stdout, &stderr, "signing", "yes", false)
 	if err == nil {
 		t.Fatal("runConfigSet(signing, yes) expected error")
 	}
@@ -386,3 +409,185 @@ func TestRunConfigSet_SigningInvalid(t *testing.T) {
 		t.Errorf("error = %q, want to contain 'invalid signing value'", err.Error())
 	}
 }
+
+func TestRunConfigSet_DefaultFilters(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	for _, kv := range [][2]string{
+		{"default-project", "gastown"},
+		{"default-status", "claimed"},
+		{"default-priority", "0"},
+		{"default-limit", "10"},
+	} {
+		if err := runConfigSet(configCmd(), &stdout, &stderr, kv[0], kv[1], false); err != nil {
+			t.Fatalf("runConfigSet(%s, %s) error: %v", kv[0], kv[1], err)
+		}
+	}
+
+	loaded, err := federation.NewConfigStore().Load("hop/wl-commons")
+	if err != nil {
+		t.Fatalf("loading config after set: %v", err)
+	}
+	d := loaded.Defaults
+	if d == nil || d.Project != "gastown" || d.Status != "claimed" || d.Limit != 10 || d.Priority == nil || *d.Priority != 0 {
+		t.Fatalf("saved Defaults = %+v", d)
+	}
+
+	// Clearing every default drops the block entirely.
+	for _, k := range []string{"default-project", "default-status", "default-priority", "default-limit"} {
+		if err := runConfigSet(configCmd(), &stdout, &stderr, k, "", false); err != nil {
+			t.Fatalf("runConfigSet(%s, \"\") error: %v", k, err)
+		}
+	}
+	loaded, _ = federation.NewConfigStore().Load("hop/wl-commons")
+	if loaded.Defaults != nil {
+		t.Errorf("Defaults after clearing = %+v, want nil", loaded.Defaults)
+	}
+}
+
+func TestRunConfigSet_Hooks(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := runConfigSet(configCmd(), &stdout, &stderr, "hooks.pre-claim", "./check.sh", false); err != nil {
+		t.Fatalf("runConfigSet error: %v", err)
+	}
+	loaded, err := federation.NewConfigStore().Load("hop/wl-commons")
+	if err != nil {
+		t.Fatalf("loading config after set: %v", err)
+	}
+	if got := loaded.Hooks["pre-claim"]; got != "./check.sh" {
+		t.Fatalf("Hooks[pre-claim] = %q, want %q", got, "./check.sh")
+	}
+	if hookRunner(loaded, nil) == nil {
+		t.Error("hookRunner returned nil with a hook configured")
+	}
+
+	if err := runConfigSet(configCmd(), &stdout, &stderr, "hooks.pre-claim", "", false); err != nil {
+		t.Fatalf("runConfigSet clear error: %v", err)
+	}
+	loaded, _ = federation.NewConfigStore().Load("hop/wl-commons")
+	if loaded.Hooks != nil {
+		t.Errorf("Hooks after clearing = %v, want nil", loaded.Hooks)
+	}
+	if hookRunner(loaded, nil) != nil {
+		t.Error("hookRunner should be nil with no hooks configured")
+	}
+}
+
+func TestRunConfigSet_Validation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+
+	tests := []struct {
+		key, value, want string
+	}{
+		{"default-status", "done", "invalid status"},
+		{"default-type", "chore", "invalid type"},
+		{"default-priority", "7", "invalid priority"},
+		{"default-limit", "-1", "invalid limit"},
+		{"signing", "yes", "invalid signing"},
+		{"sql-server", "on", "invalid sql-server"},
+		{"read-only", "maybe", "invalid read-only"},
+		{"upstream-url", "not-a-url", "invalid upstream-url"},
+		{"fork-org", "a/b", "invalid fork-org"},
+		{"rig-handle", "bob", "read-only"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		err := runConfigSet(configCmd(), &stdout, &stderr, tt.key, tt.value, false)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("runConfigSet(%s, %q) error = %v, want to contain %q", tt.key, tt.value, err, tt.want)
+		}
+	}
+}
+
+// vocabDB answers the _meta vocabulary query with item_types.
+type vocabDB struct {
+	noopDB
+	types string
+}
+
+func (d vocabDB) Query(sql, _ string) (string, error) {
+	if strings.Contains(sql, "item_types") {
+		return "key,value\nitem_types," + d.types + "\n", nil
+	}
+	return "", nil
+}
+
+func TestRunConfigSet_DefaultTypeUsesVocabulary(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+	old := openDBFromConfig
+	openDBFromConfig = func(*federation.Config) (commons.DB, error) { return vocabDB{types: "chore"}, nil }
+	t.Cleanup(func() { openDBFromConfig = old })
+
+	var stdout, stderr bytes.Buffer
+	if err := runConfigSet(configCmd(), &stdout, &stderr, "default-type", "chore", false); err != nil {
+		t.Fatalf("runConfigSet(default-type, chore) error: %v", err)
+	}
+	loaded, _ := federation.NewConfigStore().Load("hop/wl-commons")
+	if loaded.Defaults == nil || loaded.Defaults.Type != "chore" {
+		t.Errorf("saved Defaults = %+v, want type chore", loaded.Defaults)
+	}
+
+	err := runConfigSet(configCmd(), &stdout, &stderr, "default-type", "bug", false)
+	if err == nil || !strings.Contains(err.Error(), "must be one of chore") {
+		t.Errorf("runConfigSet(default-type, bug) error = %v, want the wasteland's types", err)
+	}
+}
+
+func TestRunConfigGet_JSON(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	p := 1
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		Signing: true, Defaults: &federation.BrowseDefaults{Priority: &p},
+		JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := runConfigGet(configCmd(), &stdout, &stderr, "signing", true); err != nil {
+		t.Fatalf("runConfigGet(signing) error: %v", err)
+	}
+	var entry struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if entry.Key != "signing" || entry.Value != true {
+		t.Errorf("entry = %+v, want signing=true", entry)
+	}
+
+	stdout.Reset()
+	if err := runConfigList(configCmd(), &stdout, &stderr, true); err != nil {
+		t.Fatalf("runConfigList error: %v", err)
+	}
+	var all map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &all); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if all["mode"] != "pr" || all["default-priority"] != float64(1) || all["default-limit"] != nil {
+		t.Errorf("list = %v", all)
+	}
+	if len(all) != len(configKeys) {
+		t.Errorf("list has %d keys, want %d", len(all), len(configKeys))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
 	"github.com/gastownhall/wasteland/internal/remote"
 	"github.com/gastownhall/wasteland/internal/style"
@@ -19,12 +20,18 @@ func newCreateCmd(stdout, stderr io.Writer) *cobra.Command {
 		displayName string
 		email       string
 		name        string
+		schemaName  string
+		schemaFile  string
+		seedFile    string
 		remoteBase  string
 		gitRemote   string
 		github      bool
 		githubLocal string
 		localOnly   bool
 		signed      bool
+		createRepo  bool
+		description string
+		private     bool
 	)
 
 	cmd := &cobra.Command{
@@ -33,21 +40,41 @@ func newCreateCmd(stdout, stderr io.Writer) *cobra.Command {
 		Long: `Create a new wasteland commons database initialized with the standard schema.
 
 This command:
-  1. Initializes a new dolt database with the commons schema
+  1. Initializes a new dolt database with the chosen schema
   2. Registers the creator as a rig
   3. Commits the initial schema
-  4. Pushes to remote (unless --local-only)
-  5. Saves wasteland configuration locally
+  4. Loads sample rigs and wanted items from --seed, if given
+  5. Pushes to remote (unless --local-only)
+  6. Saves wasteland configuration locally
+
+--schema picks the schema template: "full" (default) creates every commons
+table, including completions, stamps, badges and chain_meta; "minimal"
+creates only _meta, rigs and wanted, for a lightweight board. Both add the
+report views (stale_claims, aging_open_items and, with the full schema,
+reviewer_backlog). --schema-file applies your own SQL instead. The choice
+is recorded in _meta as schema_template.
+
+The upstream database (DoltHub) or repository (GitHub) must normally exist
+before you push. --create-upstream creates it first through the provider's
+API, using --description and --private; an existing one is reused.
 
 Examples:
   wl create myorg/wl-commons                       # create and push to DoltHub
   wl create myorg/wl-commons --name "My Wasteland"  # custom display name
   wl create myorg/wl-commons --local-only            # skip push
-  wl create myorg/wl-commons --signed                # GPG-sign initial commit`,
+  wl create myorg/wl-commons --signed                # GPG-sign initial commit
+  wl create myorg/wl-board --schema minimal          # wanted board only
+  wl create myorg/wl-custom --schema-file schema.sql # custom tables
+  wl create myorg/wl-demo --seed examples.yaml       # start with sample rigs and items
+  wl create myorg/wl-commons --create-upstream --private  # create the DoltHub database too`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
-			return runCreate(stdout, stderr, args[0], name, handle, displayName, email,
-				remoteBase, gitRemote, github, githubLocal, localOnly, signed)
+			var repo *remote.RepoOptions
+			if createRepo {
+				repo = &remote.RepoOptions{Description: description, Private: private}
+			}
+			return runCreate(stdout, stderr, args[0], name, schemaName, schemaFile, seedFile, handle, displayName, email,
+				remoteBase, gitRemote, github, githubLocal, localOnly, signed, repo)
 		},
 	}
 
@@ -55,6 +82,9 @@ Examples:
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the rig registry")
 	cmd.Flags().StringVar(&email, "email", "", "Registration email (default: GPG key email if --signed, else git config user.email)")
 	cmd.Flags().StringVar(&name, "name", "", "Display name for the wasteland (stored in _meta)")
+	cmd.Flags().StringVar(&schemaName, "schema", schema.TemplateFull, "Schema template: full or minimal")
+	cmd.Flags().StringVar(&schemaFile, "schema-file", "", "Create the database from this SQL file instead of a template")
+	cmd.Flags().StringVar(&seedFile, "seed", "", "YAML file of sample rigs and wanted items to load after schema init")
 	cmd.Flags().StringVar(&remoteBase, "remote-base", "", "Base directory for file:// remotes (offline mode)")
 	cmd.Flags().StringVar(&gitRemote, "git-remote", "", "Base directory for bare git remotes")
 	cmd.Flags().BoolVar(&github, "github", false, "Use GitHub as the upstream provider")
@@ -62,12 +92,18 @@ Examples:
 	cmd.Flags().BoolVar(&localOnly, "local-only", false, "Skip pushing to remote")
 	cmd.Flags().BoolVar(&signed, "signed", false, "GPG-sign the initial commit")
 	cmd.MarkFlagsMutuallyExclusive("remote-base", "git-remote", "github", "github-local")
+	cmd.Flags().BoolVar(&createRepo, "create-upstream", false, "Create the upstream DoltHub database or GitHub repo if it doesn't exist")
+	cmd.Flags().StringVar(&description, "description", "", "Description for the upstream created by --create-upstream")
+	cmd.Flags().BoolVar(&private, "private", false, "Make the upstream created by --create-upstream private")
+	cmd.MarkFlagsMutuallyExclusive("schema", "schema-file")
+	cmd.MarkFlagsMutuallyExclusive("create-upstream", "local-only")
 
 	return cmd
 }
 
-func runCreate(stdout, stderr io.Writer, upstream, name, handle, displayName, email,
+func runCreate(stdout, stderr io.Writer, upstream, name, schemaName, schemaFile, seedFile, handle, displayName, email,
 	remoteBase, gitRemote string, github bool, githubLocal string, localOnly, signed bool,
+	repo *remote.RepoOptions,
 ) error {
 	if err := requireDolt(); err != nil {
 		return err
@@ -78,11 +114,16 @@ func runCreate(stdout, stderr io.Writer, upstream, name, handle, displayName, em
 		return err
 	}
 
+	schemaSQL, schemaName, err := resolveCreateSchema(schemaName, schemaFile)
+	if err != nil {
+		return err
+	}
+
 	localDir := federation.LocalCloneDir(org, db)
 
 	// Check if .dolt already exists for a clear error message.
 	if _, err := os.Stat(filepath.Join(localDir, ".dolt")); err == nil {
-		return fmt.Errorf("database already exists at %s", localDir)
+		return &commons.ConflictError{Message: fmt.Sprintf("database already exists at %s", localDir)}
 	}
 
 	store := federation.NewConfigStore()
@@ -107,7 +148,11 @@ func runCreate(stdout, stderr io.Writer, upstream, name, handle, displayName, em
 		provider = remote.NewFakeGitHubProvider(githubLocal)
 
 	default:
-		provider = remote.NewDoltHubProvider("")
+		token := commons.DoltHubToken()
+		if repo != nil && token == "" {
+			return fmt.Errorf("DOLTHUB_TOKEN environment variable is required to create the upstream database\n\nGet your token from https://www.dolthub.com/settings/tokens")
+		}
+		provider = remote.NewDoltHubProvider(token)
 	}
 
 	// Resolve handle — defaults to org.
@@ -115,6 +160,11 @@ func runCreate(stdout, stderr io.Writer, upstream, name, handle, displayName, em
 		handle = org
 	}
 
+	seedSQL, err := loadSeed(seedFile, handle)
+	if err != nil {
+		return err
+	}
+
 	// Resolve display name from flag or git config.
 	if displayName == "" {
 		displayName = gitConfigValue("user.name")
@@ -141,8 +191,11 @@ func runCreate(stdout, stderr io.Writer, upstream, name, handle, displayName, em
 		DisplayName: displayName,
 		OwnerEmail:  email,
 		Version:     "dev",
-		SchemaSQL:   schema.SQL,
+		SchemaSQL:   schemaSQL,
+		Schema:      schemaName,
 		Name:        name,
+		SeedSQL:     seedSQL,
+		Repo:        repo,
 		LocalOnly:   localOnly,
 		Signed:      signed,
 	})
@@ -162,3 +215,41 @@ func runCreate(stdout, stderr io.Writer, upstream, name, handle, displayName, em
 
 	return nil
 }
+
+// resolveCreateSchema returns the DDL for a new wasteland and the schema
+// template name to record for it: the SQL in file when given, otherwise
+// the named built-in template.
+func resolveCreateSchema(name, file string) (string, string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", "", fmt.Errorf("reading schema file: %w", err)
+		}
+		return schema.Custom(string(data)), schema.TemplateCustom, nil
+	}
+	if name == "" {
+		name = schema.TemplateFull
+	}
+	ddl, err := schema.Template(name)
+	if err != nil {
+		return "", "", err
+	}
+	return ddl, name, nil
+}
+
+// loadSeed reads a YAML seed file and returns the SQL that loads it, with
+// items lacking a poster attributed to creator. No file means no seed.
+func loadSeed(file, creator string) (string, error) {
+	if file == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("reading seed file: %w", err)
+	}
+	seed, err := commons.ParseSeed(data)
+	if err != nil {
+		return "", err
+	}
+	return seed.Script(creator)
+}
@@ -33,8 +33,8 @@ func TestCreateRequiresArg(t *testing.T) {
 func TestCreateInvalidUpstream(t *testing.T) {
 	t.Parallel()
 	var stdout, stderr bytes.Buffer
-	err := runCreate(&stdout, &stderr, "noslash", "", "", "", "",
-		"", "", false, "", true, false)
+	err := runCreate(&stdout, &stderr, "noslash", "", "", "", "", "", "", "",
+		"", "", false, "", true, false, nil)
 	if err == nil {
 		t.Fatal("expected error for invalid upstream")
 	}
@@ -51,8 +51,8 @@ func TestCreateAlreadyExists(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	err := runCreate(&stdout, &stderr, "org/db", "", "", "", "",
-		"", "", false, "", true, false)
+	err := runCreate(&stdout, &stderr, "org/db", "", "", "", "", "", "", "",
+		"", "", false, "", true, false, nil)
 	if err == nil {
 		t.Fatal("expected error when database already exists")
 	}
@@ -60,3 +60,53 @@ func TestCreateAlreadyExists(t *testing.T) {
 		t.Errorf("error = %q, want to contain 'already exists'", err.Error())
 	}
 }
+
+func TestResolveCreateSchema(t *testing.T) {
+	t.Parallel()
+	ddl, name, err := resolveCreateSchema("minimal", "")
+	if err != nil || name != "minimal" || strings.Contains(ddl, "stamps") {
+		t.Errorf("minimal: name=%q err=%v", name, err)
+	}
+	if _, _, err := resolveCreateSchema("huge", ""); err == nil {
+		t.Error("expected error for unknown template")
+	}
+
+	file := filepath.Join(t.TempDir(), "schema.sql")
+	if err := os.WriteFile(file, []byte("CREATE TABLE IF NOT EXISTS notes (id INT PRIMARY KEY);"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ddl, name, err = resolveCreateSchema("", file)
+	if err != nil || name != "custom" || !strings.Contains(ddl, "notes") || !strings.Contains(ddl, "_meta") {
+		t.Errorf("custom: name=%q err=%v ddl=%q", name, err, ddl)
+	}
+	if _, _, err := resolveCreateSchema("", filepath.Join(t.TempDir(), "missing.sql")); err == nil {
+		t.Error("expected error for missing schema file")
+	}
+}
+
+func TestLoadSeed(t *testing.T) {
+	t.Parallel()
+	if got, err := loadSeed("", "alice"); got != "" || err != nil {
+		t.Errorf("loadSeed(no file) = %q, %v", got, err)
+	}
+
+	file := filepath.Join(t.TempDir(), "examples.yaml")
+	seed := "rigs:\n  - handle: bob\nwanted:\n  - id: w-demo\n    title: Try the board\n"
+	if err := os.WriteFile(file, []byte(seed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadSeed(file, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "INSERT IGNORE INTO rigs") || !strings.Contains(got, "'w-demo'") || !strings.Contains(got, "'alice'") {
+		t.Errorf("seed script = %q", got)
+	}
+
+	if err := os.WriteFile(file, []byte("wanted:\n  - description: no title\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadSeed(file, "alice"); err == nil || !strings.Contains(err.Error(), "title is required") {
+		t.Errorf("loadSeed(invalid) error = %v", err)
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
 
 func newDeleteCmd(stdout, stderr io.Writer) *cobra.Command {
-	var noPush bool
+	var (
+		noPush   bool
+		siblings bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "delete <wanted-id>",
@@ -24,22 +29,27 @@ In wild-west mode any joined rig can delete.
 In wild-west mode the commit is auto-pushed to upstream and origin.
 Use --no-push to skip pushing (offline work).
 
+Use --siblings to also withdraw the copies of an item that 'wl post'
+fanned out to other wastelands.
+
 Examples:
   wl delete w-abc123
-  wl delete w-abc123 --no-push`,
+  wl delete w-abc123 --no-push
+  wl delete w-abc123 --siblings`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(cmd, stdout, stderr, args[0], noPush)
+			return runDelete(cmd, stdout, stderr, args[0], noPush, siblings)
 		},
 	}
 
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
+	cmd.Flags().BoolVar(&siblings, "siblings", false, "Also withdraw the item's siblings in other wastelands (see 'wl post --all')")
 	cmd.ValidArgsFunction = completeWantedIDs("open")
 
 	return cmd
 }
 
-func runDelete(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush bool) error {
+func runDelete(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush, siblings bool) error {
 	wlCfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
@@ -50,6 +60,13 @@ func runDelete(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush
 		return err
 	}
 
+	var group siblingGroup
+	if siblings {
+		if group, err = requireSiblings(wlCfg, wantedID); err != nil {
+			return err
+		}
+	}
+
 	client, err := newSDKClient(wlCfg, noPush)
 	if err != nil {
 		return err
@@ -72,8 +89,21 @@ func runDelete(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush
 	if result.Hint != "" {
 		fmt.Fprintf(stdout, "\n  %s\n", style.Dim.Render(result.Hint))
 	}
+	if siblings {
+		err := fanOutToSiblings(cmd, stdout, group, "Withdrawn", func(cfg *federation.Config, id string) error {
+			c, err := newSDKClient(cfg, noPush)
+			if err != nil {
+				return err
+			}
+			_, err = c.Delete(id)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-	printNextHint(stdout, "Next: wl browse to see the board")
+	printNextHint(stdout, i18n.T("next.browse"))
 
 	return nil
 }
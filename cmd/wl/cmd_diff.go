@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		branch  string
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff <wanted-id>",
+		Short: "Show how a wanted item differs between main and its branch",
+		Long: `Show row-level differences for one wanted item between main and a
+PR-mode branch.
+
+Compares the wanted row, its completions, and their stamps. By default the
+branch is your own wl/<rig>/<id>; use --branch to inspect another rig's.
+
+For a full diff of every table on a branch, use 'wl review <branch>'.
+
+Examples:
+  wl diff w-abc123
+  wl diff w-abc123 --branch wl/bob/w-abc123
+  wl diff w-abc123 --json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWantedIDs(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, stdout, stderr, args[0], branch, jsonOut)
+		},
+	}
+
+	cmd.Flags().StringVar(&branch, "branch", "", "Branch to compare against main (default: wl/<your-rig>/<id>)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, stdout, _ io.Writer, wantedID, branch string, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	wantedID, err = resolveWantedArg(cfg, wantedID)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		branch = commons.BranchName(cfg.RigHandle, wantedID)
+	}
+
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	branches, err := db.Branches(branch)
+	if err != nil {
+		return fmt.Errorf("listing branches: %w", err)
+	}
+	if !slices.Contains(branches, branch) {
+		return &commons.NotFoundError{Message: fmt.Sprintf("branch %s not found — nothing to diff for %s", branch, wantedID)}
+	}
+
+	changes, err := commons.DiffWantedItem(db, wantedID, branch)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if changes == nil {
+			changes = []commons.RowChange{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+	renderItemDiff(stdout, branch, changes)
+	return nil
+}
+
+func renderItemDiff(w io.Writer, branch string, changes []commons.RowChange) {
+	fmt.Fprintf(w, "%s\n", style.Bold.Render("main → "+branch))
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "\n  %s\n", style.Dim.Render("No differences."))
+		return
+	}
+	renderRowChanges(w, changes)
+}
+
+// renderRowChanges writes one block per changed row with its differing
+// fields: + for added, - for removed, ~ with from → to for modified.
+func renderRowChanges(w io.Writer, changes []commons.RowChange) {
+	for _, c := range changes {
+		fmt.Fprintf(w, "\n  %s %s %s\n", style.Bold.Render(c.Table), c.ID, style.Dim.Render("("+c.DiffType+")"))
+		width := 0
+		for _, f := range c.Fields {
+			width = max(width, len(f.Field))
+		}
+		for _, f := range c.Fields {
+			switch c.DiffType {
+			case "added":
+				fmt.Fprintf(w, "    %s %-*s  %s\n", style.Success.Render("+"), width, f.Field, f.To)
+			case "removed":
+				fmt.Fprintf(w, "    %s %-*s  %s\n", style.Error.Render("-"), width, f.Field, f.From)
+			default:
+				fmt.Fprintf(w, "    %s %-*s  %s → %s\n", style.Warning.Render("~"), width, f.Field,
+					style.Error.Render(diffValue(f.From)), style.Success.Render(diffValue(f.To)))
+			}
+		}
+	}
+}
+
+// diffValue renders an empty value visibly.
+func diffValue(v string) string {
+	if v == "" {
+		return "∅"
+	}
+	return v
+}
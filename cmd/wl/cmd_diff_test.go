@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestRenderItemDiff(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderItemDiff(&buf, "wl/bob/w-1", []commons.RowChange{
+		{Table: "wanted", ID: "w-1", DiffType: "modified", Fields: []commons.FieldChange{
+			{Field: "status", From: "claimed", To: "in_review"},
+			{Field: "evidence_url", From: "", To: "https://example.com"},
+		}},
+		{Table: "completions", ID: "c-1", DiffType: "added", Fields: []commons.FieldChange{
+			{Field: "completed_by", To: "bob"},
+		}},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"main → wl/bob/w-1", "wanted", "(modified)", "claimed → in_review", "∅ → https://example.com", "completions", "(added)", "+ completed_by"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderItemDiff_NoChanges(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderItemDiff(&buf, "wl/bob/w-1", nil)
+	if !strings.Contains(buf.String(), "No differences") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
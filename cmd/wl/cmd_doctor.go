@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bufio"
+	"cmp"
 	"fmt"
 	"io"
 	"os"
@@ -9,13 +11,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/remote"
 	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/gastownhall/wasteland/schema"
 	"github.com/spf13/cobra"
 )
 
 func newDoctorCmd(stdout, stderr io.Writer) *cobra.Command {
-	var fix, check bool
+	var fix, check, yes, profile, offline bool
 
 	cmd := &cobra.Command{
 		Use:   "doctor",
@@ -23,23 +28,54 @@ func newDoctorCmd(stdout, stderr io.Writer) *cobra.Command {
 		Long: `Run diagnostic checks on your wasteland setup.
 
 Verifies dolt installation, credentials, environment variables,
-and per-wasteland configuration.
+and per-wasteland configuration. For DoltHub and GitHub wastelands it
+also asks the provider whether your credentials are accepted, whether
+the upstream is readable, whether your fork exists and can be pushed to,
+and whether PRs can be opened; use --offline to skip these network checks.
+
+Use --fix to attempt auto-repair of fixable issues. Each repair asks for
+confirmation first; pass --yes to apply all repairs without prompting.
+Repairs include re-cloning a missing local clone, re-adding missing
+remotes, re-fetching upstream, regenerating missing config fields,
+pruning orphaned wl/* branches, re-applying the commons schema, and
+adding columns missing from an older schema version.
 
-Use --fix to attempt auto-repair of fixable issues.
 Use --check to exit non-zero if any warnings or failures (useful for CI).
 
+Use --profile to run the browse, dashboard, leaderboard and scoreboard
+reads against the wasteland and list the slowest statements. Queries
+slower than WL_SLOW_QUERY_MS (default 2000) are also logged as warnings
+by every command.
+
 Examples:
   wl doctor
   wl doctor --fix
-  wl doctor --check`,
+  wl doctor --fix --yes
+  wl doctor --check
+  wl doctor --offline
+  wl doctor --profile`,
 		Args: cobra.NoArgs,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runDoctor(stdout, stderr, exec.LookPath, os.Getenv, federation.NewConfigStore(), fix, check)
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if profile {
+				return runDoctorProfileCmd(cmd, stdout)
+			}
+			if fix && readOnlyRequested(cmd) {
+				return &commons.ReadOnlyError{Op: "doctor --fix"}
+			}
+			confirm := newStdinConfirm(cmd.InOrStdin(), stdout)
+			if yes {
+				confirm = func(string) bool { return true }
+			}
+			return runDoctor(stdout, stderr, exec.LookPath, os.Getenv, federation.NewConfigStore(), fix, check, offline, confirm)
 		},
 	}
 
 	cmd.Flags().BoolVar(&fix, "fix", false, "Attempt to auto-fix issues")
 	cmd.Flags().BoolVar(&check, "check", false, "Exit non-zero if any warnings or failures")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Apply --fix repairs without confirmation")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Skip the provider credential and access checks")
+	cmd.Flags().BoolVar(&profile, "profile", false, "Time the common read queries and list the slowest statements")
+	cmd.MarkFlagsMutuallyExclusive("profile", "fix")
 
 	return cmd
 }
@@ -55,27 +91,23 @@ type diagnostic struct {
 
 // doctorDeps holds injectable dependencies for testing.
 type doctorDeps struct {
-	lookPath func(string) (string, error)
-	getenv   func(string) string
-	store    federation.ConfigStore
+	lookPath  func(string) (string, error)
+	getenv    func(string) string
+	store     federation.ConfigStore
+	doltQuery func(dbDir, query string) (string, error)      // CSV output; nil → commons.DoltSQLQuery
+	access    func(providerType string) remote.AccessChecker // nil skips provider checks
 }
 
-func runDoctor(stdout, _ io.Writer, lookPath func(string) (string, error), getenv func(string) string, store federation.ConfigStore, fix, check bool) error {
-	deps := &doctorDeps{lookPath: lookPath, getenv: getenv, store: store}
+func runDoctor(stdout, _ io.Writer, lookPath func(string) (string, error), getenv func(string) string, store federation.ConfigStore, fix, check, offline bool, confirm func(string) bool) error {
+	deps := &doctorDeps{lookPath: lookPath, getenv: getenv, store: store, doltQuery: commons.DoltSQLQuery}
+	if !offline {
+		deps.access = providerAccess(getenv)
+	}
 	results := runDoctorChecks(stdout, deps)
 
 	// --fix: attempt auto-repairs.
 	if fix {
-		for _, d := range results {
-			if (d.status == "fail" || d.status == "warn") && d.fixFunc != nil {
-				fmt.Fprintf(stdout, "\n  Fixing %s...\n", d.name)
-				if err := d.fixFunc(); err != nil {
-					fmt.Fprintf(stdout, "    %s fix failed: %v\n", style.Error.Render(style.IconFail), err)
-				} else {
-					fmt.Fprintf(stdout, "    %s fixed\n", style.Success.Render(style.IconPass))
-				}
-			}
-		}
+		applyDoctorFixes(stdout, results, confirm)
 	}
 
 	// --check: exit non-zero if any issues.
@@ -90,6 +122,42 @@ func runDoctor(stdout, _ io.Writer, lookPath func(string) (string, error), geten
 	return nil
 }
 
+// applyDoctorFixes runs the fixFunc of every failing or warning diagnostic
+// the user confirms.
+func applyDoctorFixes(stdout io.Writer, results []diagnostic, confirm func(string) bool) {
+	for _, d := range results {
+		if (d.status != "fail" && d.status != "warn") || d.fixFunc == nil {
+			continue
+		}
+		prompt := fmt.Sprintf("Fix %s (%s)?", d.name, d.message)
+		if d.fixHint != "" {
+			prompt = fmt.Sprintf("Fix %s (%s) — %s?", d.name, d.message, d.fixHint)
+		}
+		if confirm != nil && !confirm(prompt) {
+			fmt.Fprintf(stdout, "\n  Skipped %s\n", d.name)
+			continue
+		}
+		fmt.Fprintf(stdout, "\n  Fixing %s...\n", d.name)
+		if err := d.fixFunc(); err != nil {
+			fmt.Fprintf(stdout, "    %s fix failed: %v\n", style.Error.Render(style.IconFail), err)
+		} else {
+			fmt.Fprintf(stdout, "    %s fixed\n", style.Success.Render(style.IconPass))
+		}
+	}
+}
+
+// newStdinConfirm returns a confirm func that prompts on w and reads a
+// y/N answer from r. Anything other than "y" or "yes" declines.
+func newStdinConfirm(r io.Reader, w io.Writer) func(string) bool {
+	reader := bufio.NewReader(r)
+	return func(prompt string) bool {
+		fmt.Fprintf(w, "\n  %s [y/N] ", prompt)
+		line, _ := reader.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		return answer == "y" || answer == "yes"
+	}
+}
+
 func runDoctorChecks(stdout io.Writer, deps *doctorDeps) []diagnostic {
 	var results []diagnostic
 
@@ -217,6 +285,12 @@ func checkWastelands(stdout io.Writer, deps *doctorDeps) []diagnostic {
 		}
 		fmt.Fprintf(stdout, "\n  %s:\n", upstream)
 
+		// Config fields that can be regenerated from the upstream path.
+		results = append(results, checkConfigFields(stdout, cfg, upstream, deps)...)
+
+		// Provider credentials, upstream/fork access and PR capability.
+		results = append(results, checkProvider(stdout, cfg, upstream, deps)...)
+
 		// Backend
 		fmt.Fprintf(stdout, "    %s Backend: %s\n", style.Success.Render(style.IconPass), cfg.ResolveBackend())
 
@@ -263,6 +337,12 @@ func checkWastelands(stdout io.Writer, deps *doctorDeps) []diagnostic {
 		} else {
 			fmt.Fprintf(stdout, "    %s Local clone: %s\n", style.Success.Render(style.IconPass), cfg.LocalDir)
 			results = append(results, diagnostic{name: upstream + "/clone", status: "pass"})
+
+			// Remotes, upstream refs, schema, and branches need a clone.
+			results = append(results, checkRemotes(stdout, cfg, upstream, deps)...)
+			results = append(results, checkSchema(stdout, cfg, upstream, deps))
+			results = append(results, checkSchemaDrift(stdout, cfg, upstream, deps))
+			results = append(results, checkOrphanedBranches(stdout, cfg, upstream, deps))
 		}
 
 		// Mode
@@ -321,22 +401,26 @@ func checkGPGSigning(stdout io.Writer, cfg *federation.Config, deps *doctorDeps)
 		return diagnostic{name: "gpg-signing", status: "warn", message: "disabled"}
 	}
 
-	// Check that dolt has a signing key configured.
-	doltPath, err := deps.lookPath("dolt")
-	if err != nil {
-		fmt.Fprintf(stdout, "    %s GPG signing: enabled but dolt not found\n", style.Warning.Render(style.IconWarn))
-		return diagnostic{name: "gpg-signing", status: "warn", message: "enabled but dolt not found"}
-	}
+	// A signing-key in config wins over dolt's configured key.
+	keyID := cfg.SigningKey
+	if keyID == "" {
+		// Check that dolt has a signing key configured.
+		doltPath, err := deps.lookPath("dolt")
+		if err != nil {
+			fmt.Fprintf(stdout, "    %s GPG signing: enabled but dolt not found\n", style.Warning.Render(style.IconWarn))
+			return diagnostic{name: "gpg-signing", status: "warn", message: "enabled but dolt not found"}
+		}
 
-	cmd := exec.Command(doltPath, "config", "--global", "--get", "sqlserver.global.signingkey")
-	keyOut, err := cmd.Output()
-	keyID := strings.TrimSpace(string(keyOut))
-	if err != nil || keyID == "" {
-		fmt.Fprintf(stdout, "    %s GPG signing: enabled but no signing key configured in dolt\n", style.Error.Render(style.IconFail))
-		fmt.Fprintf(stdout, "      Run: dolt config --global --add sqlserver.global.signingkey <your-gpg-key-id>\n")
-		return diagnostic{
-			name: "gpg-signing", status: "fail", message: "enabled but no signing key configured",
-			fixHint: "Run: dolt config --global --add sqlserver.global.signingkey <your-gpg-key-id>",
+		cmd := exec.Command(doltPath, "config", "--global", "--get", "sqlserver.global.signingkey")
+		keyOut, err := cmd.Output()
+		keyID = strings.TrimSpace(string(keyOut))
+		if err != nil || keyID == "" {
+			fmt.Fprintf(stdout, "    %s GPG signing: enabled but no signing key configured in dolt\n", style.Error.Render(style.IconFail))
+			fmt.Fprintf(stdout, "      Run: dolt config --global --add sqlserver.global.signingkey <your-gpg-key-id>\n")
+			return diagnostic{
+				name: "gpg-signing", status: "fail", message: "enabled but no signing key configured",
+				fixHint: "Run: dolt config --global --add sqlserver.global.signingkey <your-gpg-key-id>",
+			}
 		}
 	}
 
@@ -354,3 +438,351 @@ func checkGPGSigning(stdout io.Writer, cfg *federation.Config, deps *doctorDeps)
 	fmt.Fprintf(stdout, "    %s GPG signing: enabled (key %s)\n", style.Success.Render(style.IconPass), keyID)
 	return diagnostic{name: "gpg-signing", status: "pass", message: fmt.Sprintf("enabled (key %s)", keyID)}
 }
+
+// checkConfigFields reports config fields that are missing but can be
+// regenerated from the upstream path and provider type.
+func checkConfigFields(stdout io.Writer, cfg *federation.Config, upstream string, deps *doctorDeps) []diagnostic {
+	org, db, err := federation.ParseUpstream(upstream)
+	if err != nil {
+		return nil
+	}
+
+	fills := map[string]func(){}
+	var missing []string
+	if cfg.ProviderType == "" {
+		missing = append(missing, "provider_type")
+		fills["provider_type"] = func() { cfg.ProviderType = cfg.ResolveProviderType() }
+	}
+	if cfg.ForkDB == "" {
+		missing = append(missing, "fork_db")
+		fills["fork_db"] = func() { cfg.ForkDB = db }
+	}
+	if cfg.UpstreamURL == "" {
+		if p := providerForType(cfg.ResolveProviderType()); p != nil {
+			missing = append(missing, "upstream_url")
+			fills["upstream_url"] = func() { cfg.UpstreamURL = p.DatabaseURL(org, db) }
+		}
+	}
+	if cfg.LocalDir == "" && cfg.Backend == federation.BackendLocal {
+		missing = append(missing, "local_dir")
+		fills["local_dir"] = func() { cfg.LocalDir = federation.LocalCloneDir(org, db) }
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	msg := "missing " + strings.Join(missing, ", ")
+	fmt.Fprintf(stdout, "    %s Config: %s\n", style.Warning.Render(style.IconWarn), msg)
+	return []diagnostic{{
+		name:    upstream + "/config",
+		status:  "warn",
+		message: msg,
+		fixHint: "regenerate from upstream path",
+		fixFunc: func() error {
+			for _, field := range missing {
+				fills[field]()
+			}
+			return deps.store.Save(cfg)
+		},
+	}}
+}
+
+// providerForType returns a provider that can compute database URLs for the
+// given provider type without extra configuration, or nil when the type
+// needs a base directory (file, git) that isn't recorded in config.
+func providerForType(providerType string) remote.Provider {
+	switch providerType {
+	case "dolthub":
+		return remote.NewDoltHubProvider("")
+	case "github":
+		return remote.NewGitHubProvider()
+	default:
+		return nil
+	}
+}
+
+// forkRemoteURL derives the origin (fork) URL from the upstream URL by
+// swapping the org/db path suffix. Returns "" when it can't be derived.
+func forkRemoteURL(cfg *federation.Config) string {
+	org, db, err := federation.ParseUpstream(cfg.Upstream)
+	if err != nil || cfg.UpstreamURL == "" || cfg.ForkOrg == "" {
+		return ""
+	}
+	forkDB := cfg.ForkDB
+	if forkDB == "" {
+		forkDB = db
+	}
+	for _, suffix := range []string{".git", ""} {
+		tail := org + "/" + db + suffix
+		if strings.HasSuffix(cfg.UpstreamURL, tail) {
+			return strings.TrimSuffix(cfg.UpstreamURL, tail) + cfg.ForkOrg + "/" + forkDB + suffix
+		}
+	}
+	return ""
+}
+
+// checkRemotes verifies the origin and upstream remotes exist and that
+// upstream/main has been fetched.
+func checkRemotes(stdout io.Writer, cfg *federation.Config, upstream string, deps *doctorDeps) []diagnostic {
+	name := upstream + "/remotes"
+	out, err := deps.doltQuery(cfg.LocalDir, "SELECT name, url FROM dolt_remotes")
+	if err != nil {
+		fmt.Fprintf(stdout, "    %s Remotes: cannot list (%v)\n", style.Warning.Render(style.IconWarn), err)
+		return []diagnostic{{name: name, status: "warn", message: "cannot list remotes"}}
+	}
+	remotes := map[string]string{}
+	for i, row := range wlParseCSV(out) {
+		if i == 0 || len(row) < 2 {
+			continue // header
+		}
+		remotes[row[0]] = row[1]
+	}
+
+	// Configs without an upstream URL (e.g. 'wl create --local-only') have
+	// nothing to compare against.
+	if cfg.UpstreamURL == "" {
+		fmt.Fprintf(stdout, "    %s Remotes: %d configured\n", style.Success.Render(style.IconPass), len(remotes))
+		return []diagnostic{{name: name, status: "pass"}}
+	}
+
+	var results []diagnostic
+	dir := cfg.LocalDir
+	originURL, hasOrigin := remotes["origin"]
+	// Direct joins clone upstream as origin and have no upstream remote.
+	direct := hasOrigin && originURL == cfg.UpstreamURL
+
+	if !hasOrigin {
+		d := diagnostic{name: upstream + "/remote-origin", status: "fail", message: "origin remote missing"}
+		if url := forkRemoteURL(cfg); url != "" {
+			d.fixHint = "dolt remote add origin " + url
+			d.fixFunc = func() error { return runDolt(dir, "remote", "add", "origin", url) }
+		}
+		fmt.Fprintf(stdout, "    %s Remote origin: missing\n", style.Error.Render(style.IconFail))
+		results = append(results, d)
+	}
+	if _, ok := remotes["upstream"]; !ok && !direct {
+		url := cfg.UpstreamURL
+		fmt.Fprintf(stdout, "    %s Remote upstream: missing\n", style.Error.Render(style.IconFail))
+		results = append(results, diagnostic{
+			name: upstream + "/remote-upstream", status: "fail", message: "upstream remote missing",
+			fixHint: "dolt remote add upstream " + url,
+			fixFunc: func() error {
+				if err := runDolt(dir, "remote", "add", "upstream", url); err != nil {
+					return err
+				}
+				return commons.FetchRemote(dir, "upstream")
+			},
+		})
+		return results
+	}
+	if len(results) == 0 {
+		fmt.Fprintf(stdout, "    %s Remotes: ok\n", style.Success.Render(style.IconPass))
+		results = append(results, diagnostic{name: name, status: "pass"})
+	}
+	if direct {
+		return results
+	}
+
+	// Upstream refs: without a fetch, AS OF upstream/main queries fail.
+	out, err = deps.doltQuery(dir, "SELECT name FROM dolt_remote_branches WHERE name = 'remotes/upstream/main'")
+	if err == nil && len(wlParseCSV(out)) < 2 {
+		fmt.Fprintf(stdout, "    %s Upstream: upstream/main not fetched\n", style.Warning.Render(style.IconWarn))
+		results = append(results, diagnostic{
+			name: upstream + "/fetch", status: "warn", message: "upstream/main not fetched",
+			fixHint: "dolt fetch upstream",
+			fixFunc: func() error { return commons.FetchRemote(dir, "upstream") },
+		})
+	}
+	return results
+}
+
+// checkSchema verifies every table and report view in the clone's schema
+// template exists. Wastelands created from a custom schema file aren't
+// checked.
+func checkSchema(stdout io.Writer, cfg *federation.Config, upstream string, deps *doctorDeps) diagnostic {
+	name := upstream + "/schema"
+	template := schemaTemplate(cfg.LocalDir, deps)
+	if template == schema.TemplateCustom {
+		fmt.Fprintf(stdout, "    %s Schema: custom (not checked)\n", style.Success.Render(style.IconPass))
+		return diagnostic{name: name, status: "pass"}
+	}
+	ddl, err := schema.Template(template)
+	if err != nil {
+		template, ddl = schema.TemplateFull, schema.SQL
+	}
+	out, err := deps.doltQuery(cfg.LocalDir, "SHOW TABLES")
+	if err != nil {
+		fmt.Fprintf(stdout, "    %s Schema: cannot list tables (%v)\n", style.Warning.Render(style.IconWarn), err)
+		return diagnostic{name: name, status: "warn", message: "cannot list tables"}
+	}
+	have := map[string]bool{}
+	for i, row := range wlParseCSV(out) {
+		if i == 0 || len(row) == 0 {
+			continue // header
+		}
+		have[row[0]] = true
+	}
+	// SHOW TABLES lists views alongside tables.
+	missing := func(names []string) []string {
+		var out []string
+		for _, n := range names {
+			if !have[n] {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+	var problems []string
+	if tables := missing(schema.Tables(ddl)); len(tables) > 0 {
+		problems = append(problems, "missing tables: "+strings.Join(tables, ", "))
+	}
+	if views := missing(schema.Views(ddl)); len(views) > 0 {
+		problems = append(problems, "missing views: "+strings.Join(views, ", "))
+	}
+	if len(problems) == 0 {
+		fmt.Fprintf(stdout, "    %s Schema: all tables and views present\n", style.Success.Render(style.IconPass))
+		return diagnostic{name: name, status: "pass"}
+	}
+
+	msg := strings.Join(problems, "; ")
+	fmt.Fprintf(stdout, "    %s Schema: %s\n", style.Error.Render(style.IconFail), msg)
+	dir, sign := cfg.LocalDir, commitSigning(cfg)
+	return diagnostic{
+		name: name, status: "fail", message: msg,
+		fixHint: "re-apply the " + template + " commons schema",
+		fixFunc: func() error {
+			script := ddl + "\nCALL DOLT_ADD('-A');\n" + commons.CommitSQL("wl doctor: re-apply commons schema", sign)
+			return commons.DoltSQLScript(dir, script)
+		},
+	}
+}
+
+// checkSchemaDrift compares the clone's columns and schema_version against
+// the schema this release of wl expects, so a stale or newer schema is
+// reported before commands fail with unknown-column errors. Missing tables
+// are left to checkSchema.
+func checkSchemaDrift(stdout io.Writer, cfg *federation.Config, upstream string, deps *doctorDeps) diagnostic {
+	name := upstream + "/schema-drift"
+	cols, err := deps.doltQuery(cfg.LocalDir, commons.SchemaColumnsQuery)
+	if err != nil {
+		fmt.Fprintf(stdout, "    %s Schema drift: cannot list columns (%v)\n", style.Warning.Render(style.IconWarn), err)
+		return diagnostic{name: name, status: "warn", message: "cannot list columns"}
+	}
+	meta, err := deps.doltQuery(cfg.LocalDir, commons.SchemaMetaQuery)
+	if err != nil {
+		meta = ""
+	}
+	drift := commons.ParseSchemaDrift(meta, cols)
+	if drift == nil {
+		fmt.Fprintf(stdout, "    %s Schema drift: no columns reported (not checked)\n", style.Warning.Render(style.IconWarn))
+		return diagnostic{name: name, status: "warn", message: "no columns reported"}
+	}
+	drift.MissingTables = nil // reported by checkSchema
+	if drift.Empty() {
+		fmt.Fprintf(stdout, "    %s Schema drift: none (version %s)\n", style.Success.Render(style.IconPass), cmp.Or(drift.Version, "unset"))
+		return diagnostic{name: name, status: "pass"}
+	}
+
+	msg := drift.String()
+	if len(drift.MissingColumns) == 0 {
+		fmt.Fprintf(stdout, "    %s Schema drift: %s\n", style.Warning.Render(style.IconWarn), msg)
+		d := diagnostic{name: name, status: "warn", message: msg}
+		if drift.VersionDiff() > 0 {
+			d.fixHint = "upgrade wl to a release that supports schema " + drift.Version
+		}
+		return d
+	}
+	fmt.Fprintf(stdout, "    %s Schema drift: %s\n", style.Error.Render(style.IconFail), msg)
+	stmts := drift.AddColumnSQL()
+	if drift.VersionDiff() < 0 {
+		stmts = append(stmts, fmt.Sprintf("REPLACE INTO _meta (`key`, value) VALUES ('schema_version', '%s')", commons.EscapeSQL(drift.Expected)))
+	}
+	dir, sign := cfg.LocalDir, commitSigning(cfg)
+	return diagnostic{
+		name: name, status: "fail", message: msg,
+		fixHint: "add the missing columns",
+		fixFunc: func() error {
+			script := strings.Join(stmts, ";\n") + ";\nCALL DOLT_ADD('-A');\n" + commons.CommitSQL("wl doctor: add missing schema columns", sign)
+			return commons.DoltSQLScript(dir, script)
+		},
+	}
+}
+
+// schemaTemplate returns the schema template recorded in the clone's _meta
+// at creation. Wastelands that predate the key used the full schema.
+func schemaTemplate(dir string, deps *doctorDeps) string {
+	out, err := deps.doltQuery(dir, "SELECT value FROM _meta WHERE `key` = 'schema_template'")
+	if err != nil {
+		return schema.TemplateFull
+	}
+	rows := wlParseCSV(out)
+	if len(rows) < 2 || len(rows[1]) == 0 || rows[1][0] == "" {
+		return schema.TemplateFull
+	}
+	return rows[1][0]
+}
+
+// checkOrphanedBranches reports local wl/* branches with no changes
+// relative to main — typically left behind after a PR was merged or an
+// item was reverted on its branch.
+func checkOrphanedBranches(stdout io.Writer, cfg *federation.Config, upstream string, deps *doctorDeps) diagnostic {
+	name := upstream + "/branches"
+	out, err := deps.doltQuery(cfg.LocalDir, "SELECT name FROM dolt_branches WHERE name LIKE 'wl/%' ORDER BY name")
+	if err != nil {
+		fmt.Fprintf(stdout, "    %s Branches: cannot list (%v)\n", style.Warning.Render(style.IconWarn), err)
+		return diagnostic{name: name, status: "warn", message: "cannot list branches"}
+	}
+	var orphaned []string
+	for i, row := range wlParseCSV(out) {
+		if i == 0 || len(row) == 0 {
+			continue // header
+		}
+		branch := row[0]
+		diff, err := deps.doltQuery(cfg.LocalDir, fmt.Sprintf(
+			"SELECT table_name FROM dolt_diff_summary('main...%s')", commons.EscapeSQL(branch)))
+		if err != nil {
+			continue
+		}
+		if len(wlParseCSV(diff)) < 2 {
+			orphaned = append(orphaned, branch)
+		}
+	}
+	if len(orphaned) == 0 {
+		fmt.Fprintf(stdout, "    %s Branches: no orphaned wl/* branches\n", style.Success.Render(style.IconPass))
+		return diagnostic{name: name, status: "pass"}
+	}
+
+	msg := fmt.Sprintf("%d orphaned wl/* branch(es)", len(orphaned))
+	fmt.Fprintf(stdout, "    %s Branches: %s\n", style.Warning.Render(style.IconWarn), msg)
+	for _, b := range orphaned {
+		fmt.Fprintf(stdout, "      %s\n", style.Dim.Render(b))
+	}
+	dir := cfg.LocalDir
+	return diagnostic{
+		name: name, status: "warn", message: msg,
+		fixHint: "delete branches with no changes against main",
+		fixFunc: func() error {
+			for _, b := range orphaned {
+				if err := commons.DeleteBranch(dir, b); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// runDolt runs a dolt CLI command in dir, returning its output on failure.
+func runDolt(dir string, args ...string) error {
+	doltPath, err := exec.LookPath("dolt")
+	if err != nil {
+		return fmt.Errorf("dolt not found in PATH")
+	}
+	cmd := exec.Command(doltPath, args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("dolt %s: %s", args[0], strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/backend"
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+// profileTopN is how many statements wl doctor --profile lists.
+const profileTopN = 10
+
+// profileStep is one read the profile workload exercises.
+type profileStep struct {
+	name string
+	run  func(db commons.DB, rig string) error
+}
+
+// profileWorkload mirrors the reads behind wl browse, wl me, wl leaderboard
+// and the scoreboard endpoints, the hot paths an index or schema change
+// would target.
+var profileWorkload = []profileStep{
+	{"browse open", func(db commons.DB, _ string) error {
+		_, err := commons.BrowseWanted(db, commons.BrowseFilter{Status: "open", Priority: -1, Limit: 50})
+		return err
+	}},
+	{"browse all", func(db commons.DB, _ string) error {
+		_, err := commons.BrowseWanted(db, commons.BrowseFilter{Priority: -1, Limit: 50, Sort: commons.SortNewest})
+		return err
+	}},
+	{"browse search", func(db commons.DB, _ string) error {
+		_, err := commons.BrowseWanted(db, commons.BrowseFilter{Priority: -1, Limit: 50, Search: "a"})
+		return err
+	}},
+	{"dashboard", func(db commons.DB, rig string) error {
+		_, err := commons.QueryMyDashboard(db, rig)
+		return err
+	}},
+	{"leaderboard", func(db commons.DB, _ string) error {
+		_, err := commons.QueryLeaderboard(db, commons.LeaderboardFilter{Limit: 20})
+		return err
+	}},
+	{"scoreboard", func(db commons.DB, _ string) error {
+		_, err := commons.QueryScoreboard(db, 50)
+		return err
+	}},
+}
+
+func runDoctorProfileCmd(cmd *cobra.Command, stdout io.Writer) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return runDoctorProfile(stdout, db, cfg, backend.DefaultStats)
+}
+
+// runDoctorProfile runs profileWorkload against db and prints the
+// statements that took the most total time, as recorded in stats.
+func runDoctorProfile(stdout io.Writer, db commons.DB, cfg *federation.Config, stats *backend.Stats) error {
+	stats.Reset()
+
+	fmt.Fprintf(stdout, "Query profile for %s (%s backend)\n\n", cfg.Upstream, cfg.ResolveBackend())
+	for _, step := range profileWorkload {
+		start := time.Now()
+		if err := step.run(db, cfg.RigHandle); err != nil {
+			fmt.Fprintf(stdout, "  %s %-14s %v\n", style.Warning.Render(style.IconWarn), step.name, err)
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s %-14s %s\n", style.Success.Render(style.IconPass), step.name, formatQueryDuration(time.Since(start)))
+	}
+
+	top := stats.Top(profileTopN)
+	if len(top) == 0 {
+		fmt.Fprintf(stdout, "\n  No queries were recorded.\n")
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "\nTop queries by total time:\n\n")
+	tbl := style.NewTable(
+		style.Column{Name: "CALLS", Width: 5, Align: style.AlignRight},
+		style.Column{Name: "TOTAL", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "MEAN", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "MAX", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "SQL", Width: 80},
+	)
+	slow := 0
+	for _, st := range top {
+		maxText := formatQueryDuration(st.Max)
+		if backend.SlowQueryThreshold > 0 && st.Max >= backend.SlowQueryThreshold {
+			maxText = style.Warning.Render(maxText)
+			slow++
+		}
+		tbl.AddRow(
+			fmt.Sprintf("%d", st.Count),
+			formatQueryDuration(st.Total),
+			formatQueryDuration(st.Mean()),
+			maxText,
+			st.SQL,
+		)
+	}
+	fmt.Fprint(stdout, tbl.Render())
+
+	if slow > 0 {
+		fmt.Fprintf(stdout, "\n  %d statement(s) exceeded the %s slow-query threshold (WL_SLOW_QUERY_MS).\n",
+			slow, formatQueryDuration(backend.SlowQueryThreshold))
+	}
+	return nil
+}
+
+// formatQueryDuration renders d at millisecond precision, or in seconds
+// once it reaches one.
+func formatQueryDuration(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/backend"
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+// fakeProfileDB stands in for an instrumented backend: every query is
+// recorded in stats with a fixed duration and returns no rows.
+type fakeProfileDB struct {
+	commons.DB // unused methods panic
+
+	stats   *backend.Stats
+	elapsed time.Duration
+}
+
+func (f *fakeProfileDB) Query(sql, _ string) (string, error) {
+	f.stats.Record("remote", "query", sql, f.elapsed, nil)
+	return "", nil
+}
+
+// runDoctorProfile reads backend.SlowQueryThreshold, so this test is not
+// parallel.
+func TestRunDoctorProfile(t *testing.T) {
+	oldThreshold := backend.SlowQueryThreshold
+	defer func() { backend.SlowQueryThreshold = oldThreshold }()
+	backend.SlowQueryThreshold = 5 * time.Second
+
+	stats := backend.NewStats()
+	stats.Record("remote", "query", "SELECT stale FROM earlier_run", time.Hour, nil)
+	db := &fakeProfileDB{stats: stats, elapsed: 20 * time.Millisecond}
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", Backend: federation.BackendRemote}
+
+	var buf bytes.Buffer
+	if err := runDoctorProfile(&buf, db, cfg, stats); err != nil {
+		t.Fatalf("runDoctorProfile: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Query profile for hop/wl-commons", "browse open", "scoreboard", "Top queries by total time", "SELECT id, title"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "earlier_run") {
+		t.Errorf("stats from before the run should be discarded:\n%s", out)
+	}
+	if strings.Contains(out, "slow-query threshold") {
+		t.Errorf("no statement is slow, got notice:\n%s", out)
+	}
+
+	backend.SlowQueryThreshold = 10 * time.Millisecond
+	buf.Reset()
+	if err := runDoctorProfile(&buf, db, cfg, stats); err != nil {
+		t.Fatalf("runDoctorProfile: %v", err)
+	}
+	if !strings.Contains(buf.String(), "exceeded the 10ms slow-query threshold") {
+		t.Errorf("missing slow notice:\n%s", buf.String())
+	}
+}
+
+func TestFormatQueryDuration(t *testing.T) {
+	t.Parallel()
+	tests := map[time.Duration]string{
+		0:                       "0ms",
+		1500 * time.Microsecond: "1ms",
+		999 * time.Millisecond:  "999ms",
+		2500 * time.Millisecond: "2.50s",
+	}
+	for d, want := range tests {
+		if got := formatQueryDuration(d); got != want {
+			t.Errorf("formatQueryDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
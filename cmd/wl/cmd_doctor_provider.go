@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/remote"
+	"github.com/gastownhall/wasteland/internal/style"
+)
+
+// providerAccess returns the access checker for a provider type, or nil
+// for providers (file, git) that have no credentials to check.
+func providerAccess(getenv func(string) string) func(providerType string) remote.AccessChecker {
+	return func(providerType string) remote.AccessChecker {
+		switch providerType {
+		case "dolthub":
+			return remote.NewDoltHubProvider(getenv("DOLTHUB_TOKEN"))
+		case "github":
+			return remote.NewGitHubProvider()
+		}
+		return nil
+	}
+}
+
+// authHint returns what to do about missing or rejected credentials.
+func authHint(providerType string) string {
+	if providerType == "github" {
+		return "Run: gh auth login"
+	}
+	return "Create a token at https://www.dolthub.com/settings/tokens and export DOLTHUB_TOKEN"
+}
+
+// printDiagnostic writes d under label in the per-wasteland layout, with
+// its fix hint below failures and warnings.
+func printDiagnostic(stdout io.Writer, label string, d diagnostic) {
+	icon := style.Success.Render(style.IconPass)
+	switch d.status {
+	case "warn":
+		icon = style.Warning.Render(style.IconWarn)
+	case "fail":
+		icon = style.Error.Render(style.IconFail)
+	}
+	fmt.Fprintf(stdout, "    %s %s: %s\n", icon, label, d.message)
+	if d.status != "pass" && d.fixHint != "" {
+		fmt.Fprintf(stdout, "      %s\n", d.fixHint)
+	}
+}
+
+// checkProvider verifies the wasteland's provider credentials can do what
+// wl needs: read the upstream, push to the fork (or to upstream for direct
+// joins), and, in PR mode, open pull requests. It is skipped offline and
+// for providers without credentials.
+func checkProvider(stdout io.Writer, cfg *federation.Config, upstream string, deps *doctorDeps) []diagnostic {
+	if deps.access == nil || cfg.UpstreamURL == "" {
+		return nil
+	}
+	providerType := cfg.ResolveProviderType()
+	checker := deps.access(providerType)
+	if checker == nil {
+		return nil
+	}
+	org, db, err := federation.ParseUpstream(upstream)
+	if err != nil {
+		return nil
+	}
+
+	var results []diagnostic
+	add := func(label string, d diagnostic) {
+		printDiagnostic(stdout, label, d)
+		results = append(results, d)
+	}
+	authFailed := func(label, name string, err error) []diagnostic {
+		add(label, diagnostic{name: name, status: "fail", message: err.Error(), fixHint: authHint(providerType)})
+		return results
+	}
+
+	auth, err := checker.CheckAuth()
+	if err != nil {
+		return authFailed("Credentials", upstream+"/auth", err)
+	}
+	scopesOK := auth.HasScope("repo", "public_repo")
+	switch {
+	case !scopesOK:
+		add("Credentials", diagnostic{
+			name: upstream + "/auth", status: "warn",
+			message: "gh token lacks the repo scope; pushes and PRs will fail",
+			fixHint: "Run: gh auth refresh -s repo",
+		})
+	case auth.User != "":
+		add("Credentials", diagnostic{name: upstream + "/auth", status: "pass", message: "authenticated as " + auth.User})
+	default:
+		add("Credentials", diagnostic{name: upstream + "/auth", status: "pass", message: "token set"})
+	}
+
+	var authErr *remote.AuthError
+	up, err := checker.CheckRepo(org, db)
+	switch {
+	case errors.As(err, &authErr):
+		return authFailed("Upstream access", upstream+"/upstream-access", err)
+	case err != nil:
+		add("Upstream access", diagnostic{name: upstream + "/upstream-access", status: "warn", message: fmt.Sprintf("cannot check (%v)", err)})
+		return results
+	case !up.Exists:
+		add("Upstream access", diagnostic{
+			name: upstream + "/upstream-access", status: "fail",
+			message: upstream + " not found or not visible to you",
+			fixHint: "Check the upstream name, or ask its owner for read access",
+		})
+		return results
+	}
+	add("Upstream access", diagnostic{name: upstream + "/upstream-access", status: "pass", message: "fetchable"})
+
+	forkOrg, forkDB := cfg.ForkOrg, cfg.ForkDB
+	if forkDB == "" {
+		forkDB = db
+	}
+	direct := forkOrg == "" || (forkOrg == org && forkDB == db)
+	target, access := upstream, up
+	if !direct {
+		target = forkOrg + "/" + forkDB
+		access, err = checker.CheckRepo(forkOrg, forkDB)
+		switch {
+		case errors.As(err, &authErr):
+			return authFailed("Push", upstream+"/push", err)
+		case err != nil:
+			add("Push", diagnostic{name: upstream + "/push", status: "warn", message: fmt.Sprintf("cannot check fork %s (%v)", target, err)})
+			return results
+		}
+	}
+
+	pushOK := false
+	switch {
+	case !access.Exists:
+		forkErr := &remote.ForkRequiredError{UpstreamOrg: org, UpstreamDB: db, ForkOrg: forkOrg}
+		if providerType == "github" {
+			forkErr.Provider = "github"
+		}
+		add("Push", diagnostic{
+			name: upstream + "/push", status: "fail",
+			message: "fork " + target + " not found",
+			fixHint: "Fork it at " + forkErr.ForkURL() + ", or run 'wl join " + upstream + "' again",
+		})
+	case access.PushKnown && !access.CanPush:
+		hint := "Ask the owner of " + target + " for write access"
+		if !direct {
+			hint += ", or re-join with a fork you own (--fork-org)"
+		}
+		add("Push", diagnostic{name: upstream + "/push", status: "fail", message: "no push permission to " + target, fixHint: hint})
+	case !access.PushKnown:
+		pushOK = true
+		add("Push", diagnostic{name: upstream + "/push", status: "pass", message: target + " exists (pushes use dolt credentials; 'dolt creds check' verifies them)"})
+	default:
+		pushOK = true
+		add("Push", diagnostic{name: upstream + "/push", status: "pass", message: "can push to " + target})
+	}
+
+	if direct || cfg.ResolveMode() != federation.ModePR {
+		return results
+	}
+	if pushOK && scopesOK {
+		add("Pull requests", diagnostic{name: upstream + "/pr", status: "pass", message: "can open PRs from " + target})
+	} else {
+		add("Pull requests", diagnostic{
+			name: upstream + "/pr", status: "warn",
+			message: "PRs can't be opened until the problems above are fixed",
+		})
+	}
+	return results
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/remote"
+)
+
+// fakeAccess is a scripted remote.AccessChecker.
+type fakeAccess struct {
+	auth    *remote.Auth
+	authErr error
+	repos   map[string]*remote.RepoAccess // "org/db"; missing repos don't exist
+}
+
+func (f *fakeAccess) CheckAuth() (*remote.Auth, error) { return f.auth, f.authErr }
+
+func (f *fakeAccess) CheckRepo(org, db string) (*remote.RepoAccess, error) {
+	if f.authErr != nil {
+		return nil, f.authErr
+	}
+	if r, ok := f.repos[org+"/"+db]; ok {
+		return r, nil
+	}
+	return &remote.RepoAccess{}, nil
+}
+
+func runProviderCheck(t *testing.T, cfg *federation.Config, access *fakeAccess) (map[string]diagnostic, string) {
+	t.Helper()
+	var stdout bytes.Buffer
+	deps := &doctorDeps{access: func(string) remote.AccessChecker { return access }}
+	got := map[string]diagnostic{}
+	for _, d := range checkProvider(&stdout, cfg, cfg.Upstream, deps) {
+		got[strings.TrimPrefix(d.name, cfg.Upstream+"/")] = d
+	}
+	return got, stdout.String()
+}
+
+func githubForkConfig() *federation.Config {
+	return &federation.Config{
+		Upstream: "hop/wl-commons", ProviderType: "github", UpstreamURL: "https://github.com/hop/wl-commons.git",
+		ForkOrg: "alice", ForkDB: "wl-commons", Mode: federation.ModePR,
+	}
+}
+
+func TestCheckProvider_AllPass(t *testing.T) {
+	got, out := runProviderCheck(t, githubForkConfig(), &fakeAccess{
+		auth: &remote.Auth{User: "alice", Scopes: []string{"repo"}},
+		repos: map[string]*remote.RepoAccess{
+			"hop/wl-commons":   {Exists: true, PushKnown: true},
+			"alice/wl-commons": {Exists: true, CanPush: true, PushKnown: true},
+		},
+	})
+	for _, name := range []string{"auth", "upstream-access", "push", "pr"} {
+		if got[name].status != "pass" {
+			t.Errorf("%s = %+v, want pass\n%s", name, got[name], out)
+		}
+	}
+	if !strings.Contains(out, "authenticated as alice") {
+		t.Errorf("output should name the gh user:\n%s", out)
+	}
+}
+
+func TestCheckProvider_AuthRejected(t *testing.T) {
+	cfg := githubForkConfig()
+	cfg.ProviderType = "dolthub"
+	got, out := runProviderCheck(t, cfg, &fakeAccess{
+		authErr: &remote.AuthError{Provider: "DoltHub", Message: "DOLTHUB_TOKEN not set"},
+	})
+	d := got["auth"]
+	if d.status != "fail" || !strings.Contains(d.fixHint, "dolthub.com/settings/tokens") {
+		t.Errorf("auth = %+v, want failure with token remediation", d)
+	}
+	if len(got) != 1 {
+		t.Errorf("checks after an auth failure should be skipped, got %v\n%s", got, out)
+	}
+}
+
+func TestCheckProvider_MissingScope(t *testing.T) {
+	got, _ := runProviderCheck(t, githubForkConfig(), &fakeAccess{
+		auth: &remote.Auth{User: "alice", Scopes: []string{"read:org"}},
+		repos: map[string]*remote.RepoAccess{
+			"hop/wl-commons":   {Exists: true, PushKnown: true},
+			"alice/wl-commons": {Exists: true, CanPush: true, PushKnown: true},
+		},
+	})
+	if d := got["auth"]; d.status != "warn" || d.fixHint != "Run: gh auth refresh -s repo" {
+		t.Errorf("auth = %+v, want scope warning", d)
+	}
+	if got["pr"].status != "warn" {
+		t.Errorf("pr = %+v, want warning without the repo scope", got["pr"])
+	}
+}
+
+func TestCheckProvider_ForkProblems(t *testing.T) {
+	auth := &remote.Auth{User: "alice", Scopes: []string{"repo"}}
+
+	got, _ := runProviderCheck(t, githubForkConfig(), &fakeAccess{
+		auth:  auth,
+		repos: map[string]*remote.RepoAccess{"hop/wl-commons": {Exists: true, PushKnown: true}},
+	})
+	if d := got["push"]; d.status != "fail" || !strings.Contains(d.fixHint, "https://github.com/hop/wl-commons/fork") {
+		t.Errorf("push = %+v, want missing-fork failure with fork URL", d)
+	}
+
+	got, _ = runProviderCheck(t, githubForkConfig(), &fakeAccess{
+		auth: auth,
+		repos: map[string]*remote.RepoAccess{
+			"hop/wl-commons":   {Exists: true, PushKnown: true},
+			"alice/wl-commons": {Exists: true, PushKnown: true},
+		},
+	})
+	if d := got["push"]; d.status != "fail" || !strings.Contains(d.message, "no push permission") {
+		t.Errorf("push = %+v, want permission failure", d)
+	}
+}
+
+func TestCheckProvider_UpstreamNotVisible(t *testing.T) {
+	got, _ := runProviderCheck(t, githubForkConfig(), &fakeAccess{auth: &remote.Auth{User: "alice"}})
+	if d := got["upstream-access"]; d.status != "fail" || !strings.Contains(d.message, "not found") {
+		t.Errorf("upstream-access = %+v, want failure", d)
+	}
+	if _, ok := got["push"]; ok {
+		t.Error("push shouldn't be checked when the upstream isn't visible")
+	}
+}
+
+func TestCheckProvider_DirectDoltHub(t *testing.T) {
+	cfg := &federation.Config{
+		Upstream: "hop/wl-commons", ProviderType: "dolthub", UpstreamURL: "https://doltremoteapi.dolthub.com/hop/wl-commons",
+		ForkOrg: "hop", ForkDB: "wl-commons",
+	}
+	got, _ := runProviderCheck(t, cfg, &fakeAccess{
+		auth:  &remote.Auth{},
+		repos: map[string]*remote.RepoAccess{"hop/wl-commons": {Exists: true}},
+	})
+	if d := got["push"]; d.status != "pass" || !strings.Contains(d.message, "dolt creds check") {
+		t.Errorf("push = %+v, want pass noting dolt credentials", d)
+	}
+	if _, ok := got["pr"]; ok {
+		t.Error("direct joins don't open PRs")
+	}
+}
+
+func TestCheckProvider_SkippedOfflineAndLocalOnly(t *testing.T) {
+	var stdout bytes.Buffer
+	if got := checkProvider(&stdout, githubForkConfig(), "hop/wl-commons", &doctorDeps{}); got != nil {
+		t.Errorf("offline doctor should skip provider checks, got %v", got)
+	}
+	cfg := githubForkConfig()
+	cfg.UpstreamURL = ""
+	if got, _ := runProviderCheck(t, cfg, &fakeAccess{}); len(got) != 0 {
+		t.Errorf("local-only wastelands have no provider to check, got %v", got)
+	}
+}
@@ -3,17 +3,20 @@ package main
 import (
 	"bytes"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/schema"
 )
 
 // fakeConfigStore implements federation.ConfigStore for testing.
 type fakeConfigStore struct {
 	configs map[string]*federation.Config
 	listErr error
+	saved   []*federation.Config
 }
 
 func (f *fakeConfigStore) Load(upstream string) (*federation.Config, error) {
@@ -24,8 +27,12 @@ func (f *fakeConfigStore) Load(upstream string) (*federation.Config, error) {
 	return cfg, nil
 }
 
-func (f *fakeConfigStore) Save(_ *federation.Config) error { return nil }
-func (f *fakeConfigStore) Delete(_ string) error           { return nil }
+func (f *fakeConfigStore) Save(cfg *federation.Config) error {
+	f.saved = append(f.saved, cfg)
+	return nil
+}
+
+func (f *fakeConfigStore) Delete(_ string) error { return nil }
 
 func (f *fakeConfigStore) List() ([]string, error) {
 	if f.listErr != nil {
@@ -215,7 +222,7 @@ func TestDoctor_CheckFlag(t *testing.T) {
 		func(string) (string, error) { return "", &notFoundErr{} },
 		func(string) string { return "" },
 		&fakeConfigStore{configs: map[string]*federation.Config{}},
-		false, true)
+		false, true, true, nil)
 	// Should return errExit because there are warnings (dolt not found, etc.)
 	if !errors.Is(err, errExit) {
 		t.Errorf("expected errExit with --check, got: %v", err)
@@ -230,7 +237,7 @@ func TestDoctor_CheckFlag_AllPass(t *testing.T) {
 		func(string) (string, error) { return "", &notFoundErr{} },
 		func(string) string { return "" },
 		&fakeConfigStore{configs: map[string]*federation.Config{}},
-		false, true)
+		false, true, true, nil)
 	// With dolt not found, --check returns errExit
 	if !errors.Is(err, errExit) {
 		t.Errorf("expected errExit, got: %v", err)
@@ -269,6 +276,376 @@ func TestDoctor_OrphanedClone(t *testing.T) {
 	}
 }
 
+// fakeDoltQuery returns canned CSV output keyed by query substring.
+func fakeDoltQuery(responses map[string]string) func(string, string) (string, error) {
+	return func(_, query string) (string, error) {
+		for substr, out := range responses {
+			if strings.Contains(query, substr) {
+				return out, nil
+			}
+		}
+		return "", errors.New("unexpected query: " + query)
+	}
+}
+
+// allSchemaTables returns SHOW TABLES output listing every commons table
+// and view.
+func allSchemaTables() string {
+	out := "Tables_in_wl_commons\n"
+	for _, table := range append(schema.Tables(schema.SQL), schema.Views(schema.SQL)...) {
+		out += table + "\n"
+	}
+	return out
+}
+
+// allSchemaColumns returns information_schema output listing every commons
+// column except the given "table.column" entries.
+func allSchemaColumns(omit ...string) string {
+	out := "table_name,column_name\n"
+	for table, cols := range schema.Columns(schema.SQL) {
+		for _, col := range cols {
+			if !slices.Contains(omit, table+"."+col.Name) {
+				out += table + "," + col.Name + "\n"
+			}
+		}
+	}
+	return out
+}
+
+func newLocalDoctorDeps(t *testing.T, cfg *federation.Config, responses map[string]string) *doctorDeps {
+	t.Helper()
+	cfg.LocalDir = t.TempDir()
+	cfg.Backend = federation.BackendLocal
+	return &doctorDeps{
+		lookPath:  func(string) (string, error) { return "", &notFoundErr{} },
+		getenv:    func(string) string { return "" },
+		store:     &fakeConfigStore{configs: map[string]*federation.Config{cfg.Upstream: cfg}},
+		doltQuery: fakeDoltQuery(responses),
+	}
+}
+
+func findDiagnostic(results []diagnostic, name string) *diagnostic {
+	for i := range results {
+		if results[i].name == name {
+			return &results[i]
+		}
+	}
+	return nil
+}
+
+func TestDoctor_ConfigFieldsMissing(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", Backend: federation.BackendLocal}
+	store := &fakeConfigStore{configs: map[string]*federation.Config{"hop/wl-commons": cfg}}
+	deps := &doctorDeps{
+		lookPath: func(string) (string, error) { return "", &notFoundErr{} },
+		getenv:   func(string) string { return "" },
+		store:    store,
+	}
+	results := runDoctorChecks(&stdout, deps)
+	d := findDiagnostic(results, "hop/wl-commons/config")
+	if d == nil || d.fixFunc == nil {
+		t.Fatalf("expected fixable config diagnostic, got: %s", stdout.String())
+	}
+	for _, field := range []string{"provider_type", "fork_db", "upstream_url", "local_dir"} {
+		if !strings.Contains(d.message, field) {
+			t.Errorf("message %q should mention %s", d.message, field)
+		}
+	}
+	if err := d.fixFunc(); err != nil {
+		t.Fatalf("fixFunc: %v", err)
+	}
+	if len(store.saved) != 1 {
+		t.Fatalf("expected config to be saved once, got %d", len(store.saved))
+	}
+	if cfg.ProviderType != "dolthub" || cfg.ForkDB != "wl-commons" || cfg.LocalDir == "" {
+		t.Errorf("fields not regenerated: %+v", cfg)
+	}
+	if !strings.HasSuffix(cfg.UpstreamURL, "/hop/wl-commons") {
+		t.Errorf("UpstreamURL = %q", cfg.UpstreamURL)
+	}
+}
+
+func TestDoctor_MissingRemotes(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{
+		Upstream:     "hop/wl-commons",
+		ProviderType: "dolthub",
+		UpstreamURL:  "https://doltremoteapi.dolthub.com/hop/wl-commons",
+		ForkOrg:      "alice",
+		ForkDB:       "wl-commons",
+	}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"SHOW TABLES":        allSchemaTables(),
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	origin := findDiagnostic(results, "hop/wl-commons/remote-origin")
+	if origin == nil || origin.fixFunc == nil {
+		t.Fatalf("expected fixable origin diagnostic, got: %s", stdout.String())
+	}
+	if !strings.Contains(origin.fixHint, "https://doltremoteapi.dolthub.com/alice/wl-commons") {
+		t.Errorf("origin fixHint = %q, want fork URL", origin.fixHint)
+	}
+	if up := findDiagnostic(results, "hop/wl-commons/remote-upstream"); up == nil || up.fixFunc == nil {
+		t.Errorf("expected fixable upstream diagnostic, got: %s", stdout.String())
+	}
+}
+
+func TestDoctor_UpstreamNotFetched(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{
+		Upstream:     "hop/wl-commons",
+		ProviderType: "dolthub",
+		UpstreamURL:  "https://doltremoteapi.dolthub.com/hop/wl-commons",
+		ForkOrg:      "alice",
+		ForkDB:       "wl-commons",
+	}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":         "name,url\norigin,https://doltremoteapi.dolthub.com/alice/wl-commons\nupstream,https://doltremoteapi.dolthub.com/hop/wl-commons\n",
+		"dolt_remote_branches": "name\n",
+		"SHOW TABLES":          allSchemaTables(),
+		"FROM dolt_branches":   "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	if d := findDiagnostic(results, "hop/wl-commons/remotes"); d == nil || d.status != "pass" {
+		t.Errorf("expected remotes to pass, got: %s", stdout.String())
+	}
+	d := findDiagnostic(results, "hop/wl-commons/fetch")
+	if d == nil || d.status != "warn" || d.fixFunc == nil {
+		t.Errorf("expected fixable fetch warning, got: %s", stdout.String())
+	}
+}
+
+func TestDoctor_DirectJoinSkipsUpstreamRemote(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{
+		Upstream:     "hop/wl-commons",
+		ProviderType: "dolthub",
+		UpstreamURL:  "https://doltremoteapi.dolthub.com/hop/wl-commons",
+		ForkOrg:      "alice",
+		ForkDB:       "wl-commons",
+	}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\norigin,https://doltremoteapi.dolthub.com/hop/wl-commons\n",
+		"SHOW TABLES":        allSchemaTables(),
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	if d := findDiagnostic(results, "hop/wl-commons/remote-upstream"); d != nil {
+		t.Errorf("direct join should not require an upstream remote: %s", d.message)
+	}
+	if d := findDiagnostic(results, "hop/wl-commons/fetch"); d != nil {
+		t.Errorf("direct join should not check upstream refs: %s", d.message)
+	}
+}
+
+func TestDoctor_SchemaMissingTables(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", ProviderType: "dolthub", ForkDB: "wl-commons"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"SHOW TABLES":        "Tables_in_wl_commons\n_meta\nwanted\n",
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	d := findDiagnostic(results, "hop/wl-commons/schema")
+	if d == nil || d.status != "fail" || d.fixFunc == nil {
+		t.Fatalf("expected fixable schema failure, got: %s", stdout.String())
+	}
+	if !strings.Contains(d.message, "completions") || strings.Contains(d.message, "wanted") {
+		t.Errorf("message = %q, want only missing tables", d.message)
+	}
+}
+
+func TestDoctor_SchemaMinimalTemplate(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-board", ProviderType: "dolthub", ForkDB: "wl-board"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"schema_template":    "value\nminimal\n",
+		"SHOW TABLES":        "Tables_in_wl_board\n_meta\nwanted\nstale_claims\naging_open_items\n",
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	d := findDiagnostic(results, "hop/wl-board/schema")
+	if d == nil || d.status != "fail" {
+		t.Fatalf("expected schema failure, got: %s", stdout.String())
+	}
+	if d.message != "missing tables: rigs" {
+		t.Errorf("message = %q, want only the minimal template's missing table", d.message)
+	}
+}
+
+func TestDoctor_SchemaMissingViews(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", ProviderType: "dolthub", ForkDB: "wl-commons"}
+	tables := "Tables_in_wl_commons\n" + strings.Join(schema.Tables(schema.SQL), "\n") + "\nstale_claims\n"
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"SHOW TABLES":        tables,
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	d := findDiagnostic(results, "hop/wl-commons/schema")
+	if d == nil || d.status != "fail" || d.fixFunc == nil {
+		t.Fatalf("expected fixable schema failure, got: %s", stdout.String())
+	}
+	if d.message != "missing views: aging_open_items, reviewer_backlog" {
+		t.Errorf("message = %q, want the missing views", d.message)
+	}
+}
+
+func TestDoctor_SchemaCustomNotChecked(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-custom", ProviderType: "dolthub", ForkDB: "wl-custom"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"schema_template":    "value\ncustom\n",
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	if d := findDiagnostic(results, "hop/wl-custom/schema"); d == nil || d.status != "pass" {
+		t.Errorf("expected custom schema to pass unchecked, got: %s", stdout.String())
+	}
+}
+
+func TestDoctor_SchemaDriftNone(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", ProviderType: "dolthub", ForkDB: "wl-commons"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"SHOW TABLES":        allSchemaTables(),
+		"information_schema": allSchemaColumns(),
+		"schema_version'":    "key,value\nschema_version," + schema.Version + "\n",
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	if d := findDiagnostic(results, "hop/wl-commons/schema-drift"); d == nil || d.status != "pass" {
+		t.Errorf("expected no drift, got: %s", stdout.String())
+	}
+}
+
+func TestDoctor_SchemaDriftMissingColumns(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", ProviderType: "dolthub", ForkDB: "wl-commons"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"SHOW TABLES":        allSchemaTables(),
+		"information_schema": allSchemaColumns("wanted.effort_level"),
+		"schema_version'":    "key,value\nschema_version,1.1\n",
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	d := findDiagnostic(results, "hop/wl-commons/schema-drift")
+	if d == nil || d.status != "fail" || d.fixFunc == nil {
+		t.Fatalf("expected fixable drift failure, got: %s", stdout.String())
+	}
+	want := "schema version 1.1, wl expects " + schema.Version + "; missing columns: wanted.effort_level"
+	if d.message != want {
+		t.Errorf("message = %q, want %q", d.message, want)
+	}
+}
+
+func TestDoctor_SchemaDriftNewerVersion(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", ProviderType: "dolthub", ForkDB: "wl-commons"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":       "name,url\n",
+		"SHOW TABLES":        allSchemaTables(),
+		"information_schema": allSchemaColumns(),
+		"schema_version'":    "key,value\nschema_version,9.0\n",
+		"FROM dolt_branches": "name\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	d := findDiagnostic(results, "hop/wl-commons/schema-drift")
+	if d == nil || d.status != "warn" || d.fixFunc != nil {
+		t.Fatalf("expected unfixable drift warning, got: %s", stdout.String())
+	}
+	if !strings.Contains(d.fixHint, "upgrade wl") {
+		t.Errorf("fixHint = %q, want upgrade hint", d.fixHint)
+	}
+}
+
+func TestDoctor_OrphanedBranches(t *testing.T) {
+	var stdout bytes.Buffer
+	cfg := &federation.Config{Upstream: "hop/wl-commons", ProviderType: "dolthub", ForkDB: "wl-commons"}
+	deps := newLocalDoctorDeps(t, cfg, map[string]string{
+		"dolt_remotes":        "name,url\n",
+		"SHOW TABLES":         allSchemaTables(),
+		"FROM dolt_branches":  "name\nwl/alice/w-1\nwl/alice/w-2\n",
+		"main...wl/alice/w-1": "table_name\n",
+		"main...wl/alice/w-2": "table_name\nwanted\n",
+	})
+	results := runDoctorChecks(&stdout, deps)
+
+	d := findDiagnostic(results, "hop/wl-commons/branches")
+	if d == nil || d.status != "warn" || d.fixFunc == nil {
+		t.Fatalf("expected fixable branches warning, got: %s", stdout.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "wl/alice/w-1") {
+		t.Errorf("expected orphaned branch listed, got: %s", out)
+	}
+	if strings.Contains(out, "wl/alice/w-2") {
+		t.Errorf("branch with changes should not be listed, got: %s", out)
+	}
+}
+
+func TestApplyDoctorFixes_Confirmation(t *testing.T) {
+	var stdout bytes.Buffer
+	var ran []string
+	results := []diagnostic{
+		{name: "a", status: "warn", fixFunc: func() error { ran = append(ran, "a"); return nil }},
+		{name: "b", status: "fail", fixFunc: func() error { ran = append(ran, "b"); return nil }},
+		{name: "c", status: "pass", fixFunc: func() error { ran = append(ran, "c"); return nil }},
+	}
+	var prompts []string
+	confirm := func(prompt string) bool {
+		prompts = append(prompts, prompt)
+		return strings.Contains(prompt, "Fix b")
+	}
+	applyDoctorFixes(&stdout, results, confirm)
+
+	if len(prompts) != 2 {
+		t.Errorf("expected 2 prompts (passing checks skipped), got %v", prompts)
+	}
+	if len(ran) != 1 || ran[0] != "b" {
+		t.Errorf("ran = %v, want [b]", ran)
+	}
+	if !strings.Contains(stdout.String(), "Skipped a") {
+		t.Errorf("expected declined fix to be reported, got: %s", stdout.String())
+	}
+}
+
+func TestNewStdinConfirm(t *testing.T) {
+	var out bytes.Buffer
+	confirm := newStdinConfirm(strings.NewReader("y\nno\n\n"), &out)
+	if !confirm("first?") {
+		t.Error("'y' should confirm")
+	}
+	if confirm("second?") {
+		t.Error("'no' should decline")
+	}
+	if confirm("third?") {
+		t.Error("empty answer should decline")
+	}
+	if !strings.Contains(out.String(), "first? [y/N]") {
+		t.Errorf("prompt not written, got: %q", out.String())
+	}
+}
+
 type notFoundErr struct{}
 
 func (e *notFoundErr) Error() string { return "executable file not found in $PATH" }
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/i18n"
+	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/spf13/cobra"
 )
 
 func newDoneCmd(stdout, stderr io.Writer) *cobra.Command {
 	var (
-		evidence string
+		evidence []string
 		noPush   bool
+		noVerify bool
 	)
 
 	cmd := &cobra.Command{
@@ -20,33 +25,51 @@ func newDoneCmd(stdout, stderr io.Writer) *cobra.Command {
 Inserts a completion record and updates the wanted item status to 'in_review'.
 The item must be claimed by your rig.
 
-The --evidence flag provides the evidence URL (PR link, commit hash, etc.).
+The --evidence flag provides the evidence. Repeat it to submit several
+entries. Each entry is typed: pull request URLs are "pr", commit SHAs are
+"commit", other URLs are "artifact", and anything else is "text". Prefix an
+entry with "<kind>:" to set the type explicitly, e.g. 'artifact:https://...'.
 
 A completion ID is generated as c-<hash> where hash is derived from the
 wanted ID, rig handle, and timestamp.
 
+Before submitting, URL evidence is checked for reachability (GitHub pull
+requests through gh when installed, otherwise an HTTP HEAD) and commit
+evidence is looked up in the git repository of the current directory.
+Entries that pass are marked verified for reviewers; a failed check aborts
+the submission. Use --no-verify to skip the checks.
+
 In wild-west mode the commit is auto-pushed to upstream and origin.
 Use --no-push to skip pushing (offline work).
 
 Examples:
   wl done w-abc123 --evidence 'https://github.com/org/repo/pull/123'
   wl done w-abc123 --evidence 'commit abc123def'
-  wl done w-abc123 --evidence 'commit abc123def' --no-push`,
+  wl done w-abc123 --evidence 'commit abc123def' --no-push
+  wl done w-abc123 --evidence 'https://internal.example/build/42' --no-verify
+  wl done w-abc123 --evidence 'https://github.com/org/repo/pull/123' \
+    --evidence 'abc123def' --evidence 'text:benchmarks attached to the PR'`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDone(cmd, stdout, stderr, args[0], evidence, noPush)
+			return runDone(cmd, stdout, stderr, args[0], evidence, noPush, noVerify)
 		},
 	}
 
-	cmd.Flags().StringVar(&evidence, "evidence", "", "Evidence URL or description (required)")
+	cmd.Flags().StringArrayVar(&evidence, "evidence", nil, "Evidence URL, commit or description; repeat for several entries (required)")
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
+	cmd.Flags().BoolVar(&noVerify, "no-verify", false, "Skip checking that URL and commit evidence exists")
 	_ = cmd.MarkFlagRequired("evidence")
 	cmd.ValidArgsFunction = completeWantedIDs("claimed")
 
 	return cmd
 }
 
-func runDone(cmd *cobra.Command, stdout, _ io.Writer, wantedID, evidence string, noPush bool) error {
+func runDone(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, evidence []string, noPush, noVerify bool) error {
+	entries := commons.ParseEvidenceList(evidence)
+	if err := commons.ValidateEvidence(entries); err != nil {
+		return err
+	}
+
 	wlCfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
@@ -57,20 +80,46 @@ func runDone(cmd *cobra.Command, stdout, _ io.Writer, wantedID, evidence string,
 		return err
 	}
 
+	if !noVerify {
+		entries, err = verifyEvidence(entries)
+		if err != nil {
+			return err
+		}
+	}
+
 	client, err := newSDKClient(wlCfg, noPush)
 	if err != nil {
 		return err
 	}
 
-	result, err := client.Done(wantedID, evidence)
+	var result *sdk.MutationResult
+	if len(evidence) == 1 && !entries[0].Verified {
+		// A single unverified entry is stored exactly as given.
+		result, err = client.Done(wantedID, evidence[0])
+	} else {
+		result, err = client.DoneEvidence(wantedID, entries)
+	}
 	if err != nil {
 		return err
 	}
 
-	renderMutationResult(stdout, "Completion submitted for", wantedID, result,
-		"Completed by: "+wlCfg.RigHandle,
-		"Evidence: "+evidence)
-	printNextHint(stdout, "Next: wait for review. Check: wl status "+wantedID)
+	extras := []string{"Completed by: " + wlCfg.RigHandle}
+	if len(evidence) == 1 {
+		extras = append(extras, "Evidence: "+evidence[0]+verifiedSuffix(entries[0]))
+	} else {
+		for _, e := range entries {
+			extras = append(extras, fmt.Sprintf("Evidence (%s): %s%s", e.Kind, e.Value, verifiedSuffix(e)))
+		}
+	}
+	renderMutationResult(stdout, "Completion submitted for", wantedID, result, extras...)
+	printNextHint(stdout, i18n.T("next.done", wantedID))
 
 	return nil
 }
+
+func verifiedSuffix(e commons.Evidence) string {
+	if e.Verified {
+		return " ✓ verified"
+	}
+	return ""
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/site"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+// exportTables lists the tables wl export can dump, with the column rows
+// are ordered by.
+var exportTables = map[string]string{
+	"wanted":      "id",
+	"completions": "id",
+	"stamps":      "id",
+	"badges":      "id",
+	"rigs":        "handle",
+}
+
+func newExportCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		table  string
+		format string
+		output string
+		html   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a commons table as CSV or NDJSON, or the board as a static site",
+		Long: `Export a table from the wasteland commons as CSV or NDJSON.
+
+Rows are streamed from the database to the output as they are read, so
+even very large wastelands export in constant memory. Values are exported
+as text, exactly as dolt prints them.
+
+Tables: ` + strings.Join(exportTableNames(), ", ") + `
+
+With --html, renders a read-only static site instead: the board grouped
+by status, a page per item with its completion and stamp, and the
+leaderboard. Pages are plain HTML and CSS with relative links, so the
+directory can be published as-is (e.g. to GitHub Pages).
+
+EXAMPLES:
+  wl export                                  # wanted table as CSV on stdout
+  wl export --table stamps --format ndjson   # stamps as NDJSON
+  wl export --table completions -o done.csv  # write to a file
+  wl export --html site/                     # static site in site/`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if html != "" {
+				for _, name := range []string{"table", "format", "output"} {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--html cannot be combined with --%s", name)
+					}
+				}
+				return runExportHTML(cmd, stderr, html)
+			}
+			return runExport(cmd, stdout, stderr, table, format, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&table, "table", "wanted", "Table to export ("+strings.Join(exportTableNames(), ", ")+")")
+	cmd.Flags().StringVar(&format, "format", "csv", "Output format: csv or ndjson")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this file instead of stdout")
+	cmd.Flags().StringVar(&html, "html", "", "Render the board, item pages and leaderboard as a static site in this directory")
+	_ = cmd.MarkFlagDirname("html")
+	_ = cmd.RegisterFlagCompletionFunc("table", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return exportTableNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.RegisterFlagCompletionFunc("format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"csv", "ndjson"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func exportTableNames() []string {
+	names := make([]string, 0, len(exportTables))
+	for name := range exportTables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runExport(cmd *cobra.Command, stdout, stderr io.Writer, table, format, output string) error {
+	orderBy, ok := exportTables[table]
+	if !ok {
+		return fmt.Errorf("unknown table %q (valid: %s)", table, strings.Join(exportTableNames(), ", "))
+	}
+	if format != "csv" && format != "ndjson" {
+		return fmt.Errorf("invalid --format %q: must be csv or ndjson", format)
+	}
+
+	_, db, err := openExportDB(cmd, stderr)
+	if err != nil {
+		return err
+	}
+
+	rows, err := commons.QueryRows(db, fmt.Sprintf("SELECT * FROM %s ORDER BY %s", table, orderBy), "")
+	if err != nil {
+		return fmt.Errorf("querying %s: %w", table, err)
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are reported by writeExport
+
+	if output == "" {
+		if err := writeExport(stdout, rows, format); err != nil {
+			return fmt.Errorf("exporting %s: %w", table, err)
+		}
+		return nil
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", output, err)
+	}
+	if err := writeExport(f, rows, format); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("exporting %s: %w", table, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", output, err)
+	}
+	fmt.Fprintf(stderr, "%s Exported %s to %s\n", style.Bold.Render("✓"), table, output)
+	return nil
+}
+
+// openExportDB opens the wasteland's database, first syncing a local clone
+// with upstream so the export is current.
+func openExportDB(cmd *cobra.Command, stderr io.Writer) (*federation.Config, commons.DB, error) {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return nil, nil, hintWrap(err)
+	}
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return nil, nil, err
+		}
+		// The spinner goes to stderr so it never mixes with exported rows.
+		sp := style.StartSpinner(stderr, "Syncing with upstream...")
+		syncErr := db.Sync()
+		sp.Stop()
+		if syncErr != nil {
+			return nil, nil, fmt.Errorf("syncing with upstream: %w", syncErr)
+		}
+	}
+	return cfg, db, nil
+}
+
+// runExportHTML renders the board as a static site in dir.
+func runExportHTML(cmd *cobra.Command, stderr io.Writer, dir string) error {
+	cfg, db, err := openExportDB(cmd, stderr)
+	if err != nil {
+		return err
+	}
+	sp := style.StartSpinner(stderr, "Reading the board...")
+	data, err := site.Load(db, cfg.Upstream, time.Now())
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+	if err := site.Render(dir, data); err != nil {
+		return fmt.Errorf("writing site: %w", err)
+	}
+	fmt.Fprintf(stderr, "%s Exported %d item(s) and the leaderboard to %s\n",
+		style.Bold.Render("✓"), len(data.Details), filepath.Join(dir, "index.html"))
+	return nil
+}
+
+// writeExport streams rows to w as CSV (with a header) or NDJSON (one
+// object per row, keys in column order).
+func writeExport(w io.Writer, rows *commons.Rows, format string) error {
+	bw := bufio.NewWriter(w)
+	cols := rows.Columns()
+
+	if format == "csv" {
+		cw := csv.NewWriter(bw)
+		if len(cols) > 0 {
+			if err := cw.Write(cols); err != nil {
+				return err
+			}
+		}
+		for rows.Next() {
+			if err := cw.Write(rows.Values()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	} else {
+		keys := make([][]byte, len(cols))
+		for i, c := range cols {
+			keys[i], _ = json.Marshal(c)
+		}
+		var line []byte
+		for rows.Next() {
+			vals := rows.Values()
+			line = append(line[:0], '{')
+			for i := range cols {
+				if i > 0 {
+					line = append(line, ',')
+				}
+				v := ""
+				if i < len(vals) {
+					v = vals[i]
+				}
+				val, _ := json.Marshal(v)
+				line = append(line, keys[i]...)
+				line = append(line, ':')
+				line = append(line, val...)
+			}
+			line = append(line, '}', '\n')
+			if _, err := bw.Write(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// csvDB answers every query with a fixed CSV result.
+type csvDB struct {
+	noopDB
+	out string
+}
+
+func (d csvDB) Query(string, string) (string, error) { return d.out, nil }
+
+func TestWriteExport(t *testing.T) {
+	db := csvDB{out: "id,title,priority\nw-1,\"Fix \"\"the\"\" bug, fast\",1\nw-2,Docs,\n"}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "id,title,priority\nw-1,\"Fix \"\"the\"\" bug, fast\",1\nw-2,Docs,\n"},
+		{"ndjson", `{"id":"w-1","title":"Fix \"the\" bug, fast","priority":"1"}` + "\n" +
+			`{"id":"w-2","title":"Docs","priority":""}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			rows, err := commons.QueryRows(db, "SELECT * FROM wanted", "")
+			if err != nil {
+				t.Fatalf("QueryRows: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := writeExport(&buf, rows, tt.format); err != nil {
+				t.Fatalf("writeExport: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunExport_Validation(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := runExport(wastelandCmd(), &stdout, &stderr, "secrets", "csv", ""); err == nil {
+		t.Error("expected error for unknown table")
+	}
+	if err := runExport(wastelandCmd(), &stdout, &stderr, "wanted", "xml", ""); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestExportHTMLRejectsTableFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--html", "out", "--table", "stamps"},
+		{"--html", "out", "--format", "ndjson"},
+		{"--html", "out", "-o", "x.csv"},
+	} {
+		var stdout, stderr bytes.Buffer
+		cmd := newExportCmd(&stdout, &stderr)
+		cmd.SetArgs(args)
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "--html cannot be combined") {
+			t.Errorf("%v: err = %v, want --html conflict", args, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/inference"
 	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/gastownhall/wasteland/internal/style"
@@ -110,7 +111,7 @@ func runInferPost(cmd *cobra.Command, stdout, _ io.Writer, prompt, model string,
 		fmt.Fprintf(stdout, "\n  %s\n", style.Dim.Render(result.Hint))
 	}
 
-	printNextHint(stdout, "Next: wl infer run "+itemID)
+	printNextHint(stdout, i18n.T("next.infer_run", itemID))
 
 	return nil
 }
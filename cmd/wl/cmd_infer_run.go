@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/inference"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
@@ -68,7 +70,7 @@ func runInferRun(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPus
 		return fmt.Errorf("querying wanted item: %w", err)
 	}
 	if detail.Item == nil {
-		return fmt.Errorf("wanted item %s not found", wantedID)
+		return &commons.NotFoundError{Message: fmt.Sprintf("wanted item %s not found", wantedID)}
 	}
 	if detail.Item.Type != "inference" {
 		return fmt.Errorf("wanted item %s has type %q, expected \"inference\"", wantedID, detail.Item.Type)
@@ -138,7 +140,7 @@ func runInferRun(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPus
 		fmt.Fprintf(stdout, "\n  %s\n", style.Dim.Render(doneResult.Hint))
 	}
 
-	printNextHint(stdout, "Next: wl infer verify "+wantedID)
+	printNextHint(stdout, i18n.T("next.infer_verify", wantedID))
 
 	return nil
 }
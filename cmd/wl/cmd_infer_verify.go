@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/inference"
 	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/gastownhall/wasteland/internal/style"
@@ -53,7 +54,7 @@ func runInferVerify(cmd *cobra.Command, stdout, _ io.Writer, wantedID string) er
 		return fmt.Errorf("querying wanted item: %w", err)
 	}
 	if detail.Item == nil {
-		return fmt.Errorf("wanted item %s not found", wantedID)
+		return &commons.NotFoundError{Message: fmt.Sprintf("wanted item %s not found", wantedID)}
 	}
 
 	vr, err := executeInferVerify(detail, wantedID)
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newInviteCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		mode    string
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "invite",
+		Short: "Print an invite URL for joining this wasteland",
+		Long: `Print a wl+invite:// URL that others can pass to 'wl join'.
+
+The invite encodes the upstream commons, its provider (dolthub, github,
+file or git) and the workflow mode newcomers should start in, so they
+don't need to know the org/db path or which provider flags to use. For
+file and git providers the shared remote directory is included too.
+
+The suggested mode defaults to this wasteland's mode; use --mode to
+suggest a different one.
+
+EXAMPLES:
+  wl invite
+  wl invite --mode wild-west
+  wl invite --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runInvite(cmd, stdout, stderr, mode, jsonOut)
+		},
+	}
+
+	cmd.Flags().StringVar(&mode, "mode", "", "Suggested workflow mode: pr or wild-west (default: this wasteland's mode)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	_ = cmd.RegisterFlagCompletionFunc("mode", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{federation.ModePR, federation.ModeWildWest}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func runInvite(cmd *cobra.Command, stdout, _ io.Writer, mode string, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	inv, err := federation.NewInvite(cfg, mode)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return renderInviteJSON(stdout, inv)
+	}
+	renderInvite(stdout, inv)
+	return nil
+}
+
+func renderInvite(stdout io.Writer, inv *federation.Invite) {
+	url := inv.URL()
+	fmt.Fprintf(stdout, "Invite to %s (%s provider, %s mode):\n\n", inv.Upstream, inv.ProviderType, inv.Mode)
+	fmt.Fprintf(stdout, "  %s\n\n", style.Bold.Render(url))
+	fmt.Fprintf(stdout, "Others join with:\n\n")
+	fmt.Fprintf(stdout, "  wl join '%s'\n", url)
+}
+
+func renderInviteJSON(stdout io.Writer, inv *federation.Invite) error {
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		URL      string `json:"url"`
+		Upstream string `json:"upstream"`
+		Provider string `json:"provider"`
+		Mode     string `json:"mode"`
+		Base     string `json:"base,omitempty"`
+	}{inv.URL(), inv.Upstream, inv.ProviderType, inv.Mode, inv.Base})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+func TestRunInvite(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		Mode: federation.ModeWildWest, JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := runInvite(configCmd(), &stdout, &stderr, "", false); err != nil {
+		t.Fatalf("runInvite: %v", err)
+	}
+	out := stdout.String()
+	want := "wl+invite://hop/wl-commons?mode=wild-west&provider=dolthub"
+	if !strings.Contains(out, want) || !strings.Contains(out, "wl join '"+want+"'") {
+		t.Errorf("output should contain invite %q and its join command, got:\n%s", want, out)
+	}
+}
+
+func TestRunInvite_JSONWithMode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := runInvite(configCmd(), &stdout, &stderr, federation.ModeWildWest, true); err != nil {
+		t.Fatalf("runInvite: %v", err)
+	}
+	var got struct {
+		URL  string `json:"url"`
+		Mode string `json:"mode"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("decoding JSON: %v\n%s", err, stdout.String())
+	}
+	inv, err := federation.ParseInvite(got.URL)
+	if err != nil {
+		t.Fatalf("ParseInvite(%q): %v", got.URL, err)
+	}
+	if got.Mode != federation.ModeWildWest || inv.Mode != federation.ModeWildWest || inv.Upstream != "hop/wl-commons" {
+		t.Errorf("invite = %+v (json mode %q)", inv, got.Mode)
+	}
+}
+
+func TestRunInvite_InvalidMode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	saveTestConfig(t, &federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons",
+		JoinedAt: time.Now(),
+	})
+
+	var stdout, stderr bytes.Buffer
+	if err := runInvite(configCmd(), &stdout, &stderr, "chaos", false); err == nil {
+		t.Error("runInvite with an unknown mode should fail")
+	}
+}
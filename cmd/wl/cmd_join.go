@@ -1,10 +1,13 @@
 package main
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +16,8 @@ import (
 	"github.com/gastownhall/wasteland/internal/federation"
 	"github.com/gastownhall/wasteland/internal/remote"
 	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/gastownhall/wasteland/internal/tui"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +36,8 @@ func newJoinCmd(stdout, stderr io.Writer) *cobra.Command {
 		signed      bool
 		direct      bool
 		localDB     bool
+		yes         bool
+		interactive bool
 	)
 
 	cmd := &cobra.Command{
@@ -48,7 +55,20 @@ This command:
   4. Saves wasteland configuration locally
 
 The upstream argument defaults to 'hop/wl-commons' (the main wasteland).
-You can specify a different org/database path to join other wastelands.
+You can specify a different org/database path to join other wastelands,
+or pass a wl+invite:// URL from 'wl invite', which carries the upstream,
+its provider and the suggested workflow mode. Provider flags given on
+the command line override the invite's provider.
+
+With --github and no --fork-org, wl lists your GitHub account and
+organizations — those already holding a fork first — and asks where the
+fork should live. Non-interactive joins keep using DOLTHUB_ORG, or pick
+an existing fork or your account when it isn't set.
+
+With -i, a guided wizard asks for the provider, the upstream (or an
+invite link), where your fork should live, the workflow mode and your rig
+handle, runs the relevant 'wl doctor' checks, and then joins — the same
+as the equivalent flags. Other flags given alongside -i preset its answers.
 
 Getting started:
   1. Sign up at https://www.dolthub.com
@@ -61,24 +81,60 @@ Getting started:
 Examples:
   wl join
   wl join hop/wl-commons --handle my-rig
-  wl join --local-db             # clone locally (requires dolt)`,
+  wl join 'wl+invite://hop/wl-commons?mode=pr&provider=dolthub'
+  wl join --local-db             # clone locally (requires dolt)
+  wl join -i                     # guided setup`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			upstream := defaultUpstream
+			mode := ""
 			if len(args) > 0 {
 				upstream = args[0]
 			}
+			if interactive {
+				if remoteBase != "" || gitRemote != "" || githubLocal != "" || direct {
+					return fmt.Errorf("--interactive supports DoltHub and GitHub joins; drop --remote-base, --git-remote, --github-local and --direct")
+				}
+				opts := joinWizardOptions{
+					upstream: upstream, forkOrg: forkOrg, handle: handle,
+					displayName: displayName, email: email, signed: signed,
+				}
+				switch {
+				case github:
+					opts.provider = tui.JoinGitHub
+				case localDB:
+					opts.provider = tui.JoinDoltHubLocal
+				}
+				return runJoinWizard(stdout, stderr, opts)
+			}
+			if federation.IsInviteURL(upstream) {
+				inv, err := federation.ParseInvite(upstream)
+				if err != nil {
+					return err
+				}
+				upstream, mode = inv.Upstream, inv.Mode
+				if remoteBase == "" && gitRemote == "" && !github && githubLocal == "" {
+					switch inv.ProviderType {
+					case "github":
+						github = true
+					case "file":
+						remoteBase = inv.Base
+					case "git":
+						gitRemote = inv.Base
+					}
+				}
+			}
 			if localDB || remoteBase != "" || gitRemote != "" || github || githubLocal != "" {
-				return runJoin(stdout, stderr, upstream, handle, displayName, email, forkOrg, remoteBase, gitRemote, github, githubLocal, signed, direct)
+				return runJoin(stdout, stderr, upstream, handle, displayName, email, forkOrg, remoteBase, gitRemote, github, githubLocal, signed, direct, mode, yes)
 			}
-			return runJoinRemote(stdout, stderr, upstream, handle, displayName, email, forkOrg)
+			return runJoinRemote(stdout, stderr, upstream, handle, displayName, email, forkOrg, mode)
 		},
 	}
 
 	cmd.Flags().StringVar(&handle, "handle", "", "Rig handle for registration (default: fork org)")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the rig registry")
 	cmd.Flags().StringVar(&email, "email", "", "Registration email (default: GPG key email if --signed, else git config user.email)")
-	cmd.Flags().StringVar(&forkOrg, "fork-org", "", "Fork organization (default: DOLTHUB_ORG)")
+	cmd.Flags().StringVar(&forkOrg, "fork-org", "", "Fork organization (default: DOLTHUB_ORG; with --github, chosen from your accounts)")
 	cmd.Flags().StringVar(&remoteBase, "remote-base", "", "Base directory for file:// remotes (offline mode)")
 	cmd.Flags().StringVar(&gitRemote, "git-remote", "", "Base directory for bare git remotes")
 	cmd.Flags().BoolVar(&github, "github", false, "Use GitHub as the upstream provider")
@@ -86,18 +142,22 @@ Examples:
 	cmd.Flags().BoolVar(&signed, "signed", false, "GPG-sign the rig registration commit")
 	cmd.Flags().BoolVar(&direct, "direct", false, "Skip forking — clone and push to upstream directly (for maintainers)")
 	cmd.Flags().BoolVar(&localDB, "local-db", false, "Use local dolt database (clone fork, requires dolt installed)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Create a missing GitHub fork without asking")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Choose provider, upstream, fork, mode and handle in a guided wizard")
 	cmd.MarkFlagsMutuallyExclusive("remote-base", "git-remote", "github", "github-local")
 
 	return cmd
 }
 
-func runJoin(stdout, stderr io.Writer, upstream, handle, displayName, email, forkOrg, remoteBase, gitRemote string, github bool, githubLocal string, signed, direct bool) error {
+// runJoin joins a wasteland with a local clone. A non-empty mode (from an
+// invite or the join wizard) replaces the default PR mode in the saved config.
+func runJoin(stdout, stderr io.Writer, upstream, handle, displayName, email, forkOrg, remoteBase, gitRemote string, github bool, githubLocal string, signed, direct bool, mode string, yes bool) error {
 	if err := requireDolt(); err != nil {
 		return err
 	}
 
 	// Parse upstream path (validate early)
-	_, _, err := federation.ParseUpstream(upstream)
+	upstreamOrg, upstreamDB, err := federation.ParseUpstream(upstream)
 	if err != nil {
 		return err
 	}
@@ -113,7 +173,8 @@ func runJoin(stdout, stderr io.Writer, upstream, handle, displayName, email, for
 		return nil
 	}
 
-	// Resolve fork org: flag > env var
+	// Resolve fork org: flag > picker (GitHub) > env var
+	forkOrgFlag := forkOrg
 	if forkOrg == "" {
 		forkOrg = commons.DoltHubOrg()
 	}
@@ -137,10 +198,15 @@ func runJoin(stdout, stderr io.Writer, upstream, handle, displayName, email, for
 
 	case github:
 		// GitHub mode — uses gh CLI for forking, GitHub HTTPS URLs as dolt remotes.
+		gh := remote.NewGitHubProvider()
+		if forkOrgFlag == "" {
+			forkOrg = pickForkOwner(gh, upstreamOrg, upstreamDB, forkOrg, forkOwnerPrompt(os.Stdin, stdout))
+		}
 		if forkOrg == "" {
 			return fmt.Errorf("--fork-org is required in GitHub mode (or set DOLTHUB_ORG)")
 		}
-		provider = remote.NewGitHubProvider()
+		gh.ConfirmFork = githubForkConfirm(os.Stdin, stdout, yes)
+		provider = gh
 
 	case githubLocal != "":
 		// GitHub-local mode — bare git repos that report type "github" for testing.
@@ -200,10 +266,19 @@ func runJoin(stdout, stderr io.Writer, upstream, handle, displayName, email, for
 	}
 
 	cfg := result.Config
+	if mode != "" && mode != cfg.ResolveMode() {
+		cfg.Mode = mode
+		if err := store.Save(cfg); err != nil {
+			return fmt.Errorf("saving wasteland config: %w", err)
+		}
+	}
 	fmt.Fprintf(stdout, "\n%s Joined wasteland: %s\n", style.Bold.Render("✓"), upstream)
 	fmt.Fprintf(stdout, "  Handle: %s\n", cfg.RigHandle)
 	fmt.Fprintf(stdout, "  Fork: %s/%s\n", cfg.ForkOrg, cfg.ForkDB)
 	fmt.Fprintf(stdout, "  Local: %s\n", cfg.LocalDir)
+	if mode != "" {
+		fmt.Fprintf(stdout, "  Mode: %s\n", cfg.ResolveMode())
+	}
 	if result.PRURL != "" {
 		fmt.Fprintf(stdout, "  PR: %s\n", style.Bold.Render(result.PRURL))
 	}
@@ -212,7 +287,8 @@ func runJoin(stdout, stderr io.Writer, upstream, handle, displayName, email, for
 }
 
 // runJoinRemote joins a wasteland in remote mode: fork + register via DoltHub API, no local dolt needed.
-func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, forkOrg string) error {
+// A non-empty mode (from an invite or the join wizard) replaces the default PR mode.
+func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, forkOrg, mode string) error {
 	// Parse upstream path (validate early)
 	upstreamOrg, upstreamDB, err := federation.ParseUpstream(upstream)
 	if err != nil {
@@ -278,7 +354,7 @@ func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, fo
 	db := backend.NewRemoteDB(token, upstreamOrg, upstreamDB, forkOrg, upstreamDB, federation.ModePR)
 	branch := fmt.Sprintf("wl/register/%s", handle)
 	regSQL := commons.BuildRegistrationSQL(handle, forkOrg, displayName, email, "dev")
-	if err := db.Exec(branch, "", false, regSQL); err != nil {
+	if err := db.Exec(branch, "", commons.Signing{}, regSQL); err != nil {
 		return fmt.Errorf("registering rig: %w", err)
 	}
 
@@ -294,6 +370,9 @@ func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, fo
 
 	// 4. Save config with Backend: "remote", LocalDir: "".
 	hopURI := fmt.Sprintf("hop://%s/%s/", email, handle)
+	if mode == "" {
+		mode = federation.ModePR
+	}
 	cfg := &federation.Config{
 		Upstream:     upstream,
 		ProviderType: "dolthub",
@@ -302,7 +381,7 @@ func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, fo
 		ForkDB:       upstreamDB,
 		LocalDir:     "",
 		Backend:      federation.BackendRemote,
-		Mode:         federation.ModePR,
+		Mode:         mode,
 		RigHandle:    handle,
 		HopURI:       hopURI,
 		JoinedAt:     time.Now(),
@@ -315,6 +394,9 @@ func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, fo
 	fmt.Fprintf(stdout, "  Handle: %s\n", cfg.RigHandle)
 	fmt.Fprintf(stdout, "  Fork: %s/%s\n", cfg.ForkOrg, cfg.ForkDB)
 	fmt.Fprintf(stdout, "  Backend: remote (DoltHub API)\n")
+	if cfg.Mode != federation.ModePR {
+		fmt.Fprintf(stdout, "  Mode: %s\n", cfg.Mode)
+	}
 	if prURL != "" {
 		fmt.Fprintf(stdout, "  PR: %s\n", style.Bold.Render(prURL))
 	}
@@ -322,10 +404,113 @@ func runJoinRemote(stdout, _ io.Writer, upstream, handle, displayName, email, fo
 	return nil
 }
 
+// githubForkConfirm returns the prompt asked before 'wl join' creates a
+// missing GitHub fork. It returns nil — create without asking — for --yes
+// and when stdin is not a terminal, so scripted joins keep working.
+func githubForkConfirm(in *os.File, w io.Writer, yes bool) func(upstream, forkOrg string) bool {
+	if yes || !isatty.IsTerminal(in.Fd()) {
+		return nil
+	}
+	confirm := newStdinConfirm(in, w)
+	return func(upstream, forkOrg string) bool {
+		return confirm(fmt.Sprintf("%s has no fork under %s on GitHub. Create it now?", upstream, forkOrg))
+	}
+}
+
+// pickForkOwner chooses the account to fork upstreamOrg/upstreamDB into
+// when --fork-org isn't given. With a prompt, the accounts the provider
+// lists are offered, defaulting to one that already has a fork, then to
+// fallback (DOLTHUB_ORG), then to the user's own account. Without one,
+// fallback is kept and the provider is only consulted when it is empty.
+// It returns fallback when the provider can't list accounts.
+func pickForkOwner(lister remote.ForkOwnerLister, upstreamOrg, upstreamDB, fallback string, prompt func(upstream string, owners []remote.ForkOwner, def int) int) string {
+	if prompt == nil && fallback != "" {
+		return fallback
+	}
+	owners, err := lister.ListForkOwners(upstreamOrg, upstreamDB)
+	if err != nil || len(owners) == 0 {
+		return fallback
+	}
+	def := 0
+	for i, o := range owners {
+		if o.HasFork {
+			def = i
+			break
+		}
+		if strings.EqualFold(o.Name, fallback) {
+			def = i
+		}
+	}
+	if prompt == nil {
+		return owners[def].Name
+	}
+	return owners[prompt(upstreamOrg+"/"+upstreamDB, owners, def)].Name
+}
+
+// forkOwnerPrompt returns the fork picker 'wl join' shows when
+// --fork-org isn't given, or nil when stdin is not a terminal.
+func forkOwnerPrompt(in *os.File, w io.Writer) func(string, []remote.ForkOwner, int) int {
+	if !isatty.IsTerminal(in.Fd()) {
+		return nil
+	}
+	return newForkOwnerPrompt(in, w)
+}
+
+// newForkOwnerPrompt returns a picker that lists owners on w and reads a
+// number or account name from r. An empty answer or end of input picks
+// def; anything else is asked again.
+func newForkOwnerPrompt(r io.Reader, w io.Writer) func(string, []remote.ForkOwner, int) int {
+	reader := bufio.NewReader(r)
+	return func(upstream string, owners []remote.ForkOwner, def int) int {
+		fmt.Fprintf(w, "\n  Where should your fork of %s live?\n", upstream)
+		for i, o := range owners {
+			fmt.Fprintf(w, "    %d) %-24s %s\n", i+1, o.Name, style.Dim.Render(forkOwnerLabel(o)))
+		}
+		for {
+			fmt.Fprintf(w, "  Choose [%d]: ", def+1)
+			line, err := reader.ReadString('\n')
+			answer := strings.TrimSpace(line)
+			if answer == "" {
+				return def
+			}
+			if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(owners) {
+				return n - 1
+			}
+			for i, o := range owners {
+				if strings.EqualFold(o.Name, answer) {
+					return i
+				}
+			}
+			if err != nil {
+				return def
+			}
+			fmt.Fprintf(w, "  %q is not one of the choices.\n", answer)
+		}
+	}
+}
+
+// forkOwnerLabel describes a fork owner in the picker.
+func forkOwnerLabel(o remote.ForkOwner) string {
+	label := "your account"
+	if o.Org {
+		label = "organization"
+	}
+	if o.HasFork {
+		label += ", fork exists"
+	}
+	return label
+}
+
 func printForkInstructions(w io.Writer, err *remote.ForkRequiredError) {
 	fmt.Fprintf(w, "\n%s Fork required\n\n", style.Bold.Render("!"))
-	fmt.Fprintf(w, "  To join this wasteland, fork the commons on DoltHub:\n\n")
+	fmt.Fprintf(w, "  To join this wasteland, fork the commons on %s:\n\n", err.ProviderName())
 	fmt.Fprintf(w, "  1. Go to %s\n", style.Bold.Render(err.ForkURL()))
+	if err.Provider == "github" {
+		fmt.Fprintf(w, "  2. Choose the owner: %s\n", style.Bold.Render(err.ForkOrg))
+		fmt.Fprintf(w, "  3. Click %s\n", style.Bold.Render("Create fork"))
+		fmt.Fprintf(w, "  4. Rerun: %s (add %s to let wl create the fork)\n", style.Bold.Render("wl join"), style.Bold.Render("--yes"))
+		return
+	}
 	fmt.Fprintf(w, "  2. Click %s (top right)\n", style.Bold.Render("Fork"))
 	fmt.Fprintf(w, "  3. Select your organization: %s\n", style.Bold.Render(err.ForkOrg))
 	fmt.Fprintf(w, "  4. Rerun: %s\n", style.Bold.Render("wl join"))
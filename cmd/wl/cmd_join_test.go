@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
 
@@ -47,3 +48,103 @@ func TestPrintForkInstructions(t *testing.T) {
 		t.Errorf("output missing 'wl join': %q", got)
 	}
 }
+
+func TestPrintForkInstructions_GitHub(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	printForkInstructions(&buf, &remote.ForkRequiredError{
+		UpstreamOrg: "hop",
+		UpstreamDB:  "wl-commons",
+		ForkOrg:     "alice",
+		Provider:    "github",
+	})
+	got := buf.String()
+
+	for _, want := range []string{"on GitHub", "https://github.com/hop/wl-commons/fork", "Create fork", "--yes"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q: %q", want, got)
+		}
+	}
+}
+
+// fakeForkOwnerLister returns canned fork owners.
+type fakeForkOwnerLister struct {
+	owners []remote.ForkOwner
+	err    error
+	calls  int
+}
+
+func (f *fakeForkOwnerLister) ListForkOwners(_, _ string) ([]remote.ForkOwner, error) {
+	f.calls++
+	return f.owners, f.err
+}
+
+func TestPickForkOwner(t *testing.T) {
+	t.Parallel()
+	owners := []remote.ForkOwner{{Name: "alice"}, {Name: "acme", Org: true}, {Name: "widgets", Org: true}}
+	withFork := []remote.ForkOwner{{Name: "widgets", Org: true, HasFork: true}, {Name: "alice"}, {Name: "acme", Org: true}}
+	choose := func(n int) func(string, []remote.ForkOwner, int) int {
+		return func(string, []remote.ForkOwner, int) int { return n }
+	}
+	var gotDefault int
+	recordDefault := func(_ string, _ []remote.ForkOwner, def int) int {
+		gotDefault = def
+		return def
+	}
+
+	tests := []struct {
+		name      string
+		lister    *fakeForkOwnerLister
+		fallback  string
+		prompt    func(string, []remote.ForkOwner, int) int
+		want      string
+		wantCalls int
+	}{
+		{"non-interactive keeps env org", &fakeForkOwnerLister{owners: owners}, "acme", nil, "acme", 0},
+		{"non-interactive without env takes account", &fakeForkOwnerLister{owners: owners}, "", nil, "alice", 1},
+		{"non-interactive without env takes existing fork", &fakeForkOwnerLister{owners: withFork}, "", nil, "widgets", 1},
+		{"interactive choice", &fakeForkOwnerLister{owners: owners}, "", choose(2), "widgets", 1},
+		{"interactive default is env org", &fakeForkOwnerLister{owners: owners}, "acme", recordDefault, "acme", 1},
+		{"listing fails", &fakeForkOwnerLister{err: errors.New("gh not logged in")}, "acme", choose(0), "acme", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickForkOwner(tt.lister, "hop", "wl-commons", tt.fallback, tt.prompt); got != tt.want {
+				t.Errorf("pickForkOwner = %q, want %q", got, tt.want)
+			}
+			if tt.lister.calls != tt.wantCalls {
+				t.Errorf("ListForkOwners called %d times, want %d", tt.lister.calls, tt.wantCalls)
+			}
+		})
+	}
+	if gotDefault != 1 {
+		t.Errorf("default choice = %d, want the env org (1)", gotDefault)
+	}
+}
+
+func TestForkOwnerPrompt(t *testing.T) {
+	t.Parallel()
+	owners := []remote.ForkOwner{{Name: "widgets", Org: true, HasFork: true}, {Name: "alice"}}
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"\n", 0},
+		{"2\n", 1},
+		{"ALICE\n", 1},
+		{"7\nnope\n2\n", 1},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got := newForkOwnerPrompt(strings.NewReader(tt.input), &out)("hop/wl-commons", owners, 0)
+		if got != tt.want {
+			t.Errorf("input %q: choice = %d, want %d", tt.input, got, tt.want)
+		}
+		for _, want := range []string{"fork of hop/wl-commons", "1) widgets", "organization, fork exists", "2) alice", "your account", "Choose [1]"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("input %q: prompt missing %q:\n%s", tt.input, want, out.String())
+			}
+		}
+	}
+}
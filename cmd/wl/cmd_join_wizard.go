@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/remote"
+	"github.com/gastownhall/wasteland/internal/tui"
+	"github.com/mattn/go-isatty"
+)
+
+// joinWizardOptions are the join flags that carry over into 'wl join -i'.
+type joinWizardOptions struct {
+	upstream    string // argument or default; may be an invite URL
+	provider    string // tui.Join* preselected by --github or --local-db
+	forkOrg     string
+	handle      string
+	displayName string
+	email       string
+	signed      bool
+}
+
+// runJoinWizard walks the user through joining in a bubbletea wizard and
+// then joins with what they chose, exactly as the equivalent flags would.
+func runJoinWizard(stdout, stderr io.Writer, opts joinWizardOptions) error {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("wl join -i needs a terminal; pass flags instead (see 'wl join --help')")
+	}
+
+	cfg := newJoinWizardConfig(opts, &doctorDeps{lookPath: exec.LookPath, getenv: os.Getenv, access: providerAccess(os.Getenv)})
+	final, err := bubbletea.NewProgram(tui.NewJoinWizard(cfg), bubbletea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("join wizard: %w", err)
+	}
+	plan, ok := final.(tui.JoinWizard).Plan()
+	if !ok {
+		fmt.Fprintln(stdout, "Join cancelled.")
+		return nil
+	}
+	mode := plan.Mode
+	if mode == federation.ModePR {
+		mode = ""
+	}
+	switch plan.Provider {
+	case tui.JoinGitHub:
+		// The fork's owner was chosen in the wizard, so create it without asking again.
+		return runJoin(stdout, stderr, plan.Upstream, plan.Handle, opts.displayName, opts.email, plan.ForkOrg, "", "", true, "", opts.signed, false, mode, true)
+	case tui.JoinDoltHubLocal:
+		return runJoin(stdout, stderr, plan.Upstream, plan.Handle, opts.displayName, opts.email, plan.ForkOrg, "", "", false, "", opts.signed, false, mode, true)
+	default:
+		return runJoinRemote(stdout, stderr, plan.Upstream, plan.Handle, opts.displayName, opts.email, plan.ForkOrg, mode)
+	}
+}
+
+// newJoinWizardConfig seeds the wizard from the join flags and wires its
+// lookups to the providers and doctor checks.
+func newJoinWizardConfig(opts joinWizardOptions, deps *doctorDeps) tui.JoinWizardConfig {
+	cfg := tui.JoinWizardConfig{
+		Provider: opts.provider,
+		Upstream: opts.upstream,
+		Handle:   opts.handle,
+		ParseUpstream: func(input string) (string, string, error) {
+			if input == "" {
+				return "", "", errors.New("enter the wasteland's org/database")
+			}
+			if federation.IsInviteURL(input) {
+				inv, err := federation.ParseInvite(input)
+				if err != nil {
+					return "", "", err
+				}
+				return inv.Upstream, inv.Mode, nil
+			}
+			if _, _, err := federation.ParseUpstream(input); err != nil {
+				return "", "", err
+			}
+			return input, "", nil
+		},
+		ForkChoices: func(provider, upstream string) ([]tui.ForkChoice, error) {
+			return joinForkChoices(provider, upstream, opts.forkOrg, deps)
+		},
+		Preflight: func(plan tui.JoinPlan) []tui.JoinCheck {
+			return joinPreflight(plan, deps)
+		},
+	}
+	if federation.IsInviteURL(opts.upstream) {
+		if upstream, mode, err := cfg.ParseUpstream(opts.upstream); err == nil {
+			cfg.Upstream, cfg.Mode = upstream, mode
+		}
+	}
+	return cfg
+}
+
+// joinForkChoices lists the accounts a fork of upstream could live in.
+// GitHub lists the gh user's account and organizations; DoltHub tokens
+// carry no identity, so it offers --fork-org or DOLTHUB_ORG, noting
+// whether the fork exists already.
+func joinForkChoices(provider, upstream, forkOrg string, deps *doctorDeps) ([]tui.ForkChoice, error) {
+	org, db, err := federation.ParseUpstream(upstream)
+	if err != nil {
+		return nil, err
+	}
+	providerType := joinProviderType(provider)
+	var owners []remote.ForkOwner
+	if lister, ok := deps.access(providerType).(remote.ForkOwnerLister); ok {
+		owners, err = lister.ListForkOwners(org, db)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if forkOrg == "" {
+			forkOrg = deps.getenv("DOLTHUB_ORG")
+		}
+		if forkOrg == "" {
+			return nil, nil
+		}
+		owner := remote.ForkOwner{Name: forkOrg}
+		if checker := deps.access(providerType); checker != nil {
+			if access, err := checker.CheckRepo(forkOrg, db); err == nil && access.Exists {
+				owner.HasFork = true
+			}
+		}
+		owners = []remote.ForkOwner{owner}
+	}
+
+	choices := make([]tui.ForkChoice, 0, len(owners))
+	for _, o := range owners {
+		label := forkOwnerLabel(o)
+		if !o.HasFork {
+			label += ", fork will be created"
+		}
+		choices = append(choices, tui.ForkChoice{Name: o.Name, Label: label})
+	}
+	return choices, nil
+}
+
+// joinPreflight runs the doctor checks that decide whether joining with
+// plan can succeed: dolt for local clones, credentials, and read access
+// to the upstream.
+func joinPreflight(plan tui.JoinPlan, deps *doctorDeps) []tui.JoinCheck {
+	var checks []tui.JoinCheck
+	add := func(d diagnostic) {
+		checks = append(checks, tui.JoinCheck{Name: d.name, Status: d.status, Message: d.message, Hint: d.fixHint})
+	}
+
+	if plan.Provider != tui.JoinDoltHub {
+		add(checkDolt(io.Discard, deps))
+		add(checkDoltCreds(io.Discard))
+	}
+	providerType := joinProviderType(plan.Provider)
+	if providerType == "dolthub" {
+		d := checkEnvVar(io.Discard, deps, "DOLTHUB_TOKEN")
+		if d.status != "pass" {
+			d.status, d.fixHint = "fail", authHint(providerType)
+		}
+		add(d)
+	}
+
+	checker := deps.access(providerType)
+	if checker == nil {
+		return checks
+	}
+	auth, err := checker.CheckAuth()
+	if err != nil {
+		add(diagnostic{name: "credentials", status: "fail", message: err.Error(), fixHint: authHint(providerType)})
+		return checks
+	}
+	if auth.User != "" {
+		add(diagnostic{name: "credentials", status: "pass", message: "authenticated as " + auth.User})
+	}
+
+	org, db, err := federation.ParseUpstream(plan.Upstream)
+	if err != nil {
+		return checks
+	}
+	up, err := checker.CheckRepo(org, db)
+	switch {
+	case err != nil:
+		add(diagnostic{name: "upstream", status: "warn", message: fmt.Sprintf("cannot check (%v)", err)})
+	case !up.Exists:
+		add(diagnostic{
+			name: "upstream", status: "fail",
+			message: plan.Upstream + " not found or not visible to you",
+			fixHint: "Check the upstream name, or ask its owner for read access",
+		})
+	default:
+		add(diagnostic{name: "upstream", status: "pass", message: plan.Upstream + " is readable"})
+	}
+	return checks
+}
+
+// joinProviderType maps a wizard provider to its config provider type.
+func joinProviderType(provider string) string {
+	if provider == tui.JoinGitHub {
+		return "github"
+	}
+	return "dolthub"
+}
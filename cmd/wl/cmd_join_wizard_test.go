@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/remote"
+	"github.com/gastownhall/wasteland/internal/tui"
+)
+
+// listingAccess is a fakeAccess that can also list fork owners, like the
+// GitHub provider.
+type listingAccess struct {
+	*fakeAccess
+	owners []remote.ForkOwner
+}
+
+func (l *listingAccess) ListForkOwners(_, _ string) ([]remote.ForkOwner, error) {
+	return l.owners, nil
+}
+
+func wizardDeps(env map[string]string, access remote.AccessChecker) *doctorDeps {
+	return &doctorDeps{
+		lookPath: func(string) (string, error) { return "", errors.New("not found") },
+		getenv:   func(k string) string { return env[k] },
+		access:   func(string) remote.AccessChecker { return access },
+	}
+}
+
+func TestNewJoinWizardConfig(t *testing.T) {
+	t.Parallel()
+	cfg := newJoinWizardConfig(joinWizardOptions{upstream: "wl+invite://hop/wl-commons?mode=wild-west", handle: "al"}, wizardDeps(nil, nil))
+	if cfg.Upstream != "hop/wl-commons" || cfg.Mode != "wild-west" || cfg.Handle != "al" {
+		t.Errorf("invite should seed upstream and mode, got %+v", cfg)
+	}
+	if _, _, err := cfg.ParseUpstream("not-an-upstream"); err == nil {
+		t.Error("ParseUpstream accepted a value without org/database")
+	}
+	if up, mode, err := cfg.ParseUpstream("acme/commons"); err != nil || up != "acme/commons" || mode != "" {
+		t.Errorf("ParseUpstream(acme/commons) = %q, %q, %v", up, mode, err)
+	}
+}
+
+func TestJoinForkChoices(t *testing.T) {
+	t.Parallel()
+	doltHub := &fakeAccess{auth: &remote.Auth{}, repos: map[string]*remote.RepoAccess{"alice/wl-commons": {Exists: true}}}
+	got, err := joinForkChoices(tui.JoinDoltHub, "hop/wl-commons", "", wizardDeps(map[string]string{"DOLTHUB_ORG": "alice"}, doltHub))
+	if err != nil || len(got) != 1 || got[0].Name != "alice" || got[0].Label != "your account, fork exists" {
+		t.Errorf("DoltHub choices = %+v, %v", got, err)
+	}
+	if got, _ := joinForkChoices(tui.JoinDoltHub, "hop/wl-commons", "", wizardDeps(nil, doltHub)); len(got) != 0 {
+		t.Errorf("DoltHub without DOLTHUB_ORG should offer nothing, got %+v", got)
+	}
+
+	gh := &listingAccess{fakeAccess: &fakeAccess{}, owners: []remote.ForkOwner{{Name: "acme", Org: true, HasFork: true}, {Name: "alice"}}}
+	got, err = joinForkChoices(tui.JoinGitHub, "hop/wl-commons", "", wizardDeps(nil, gh))
+	if err != nil || len(got) != 2 || got[0].Label != "organization, fork exists" || got[1].Label != "your account, fork will be created" {
+		t.Errorf("GitHub choices = %+v, %v", got, err)
+	}
+}
+
+func TestJoinPreflight(t *testing.T) {
+	t.Parallel()
+	plan := tui.JoinPlan{Provider: tui.JoinDoltHubLocal, Upstream: "hop/wl-commons", ForkOrg: "alice", Mode: "pr", Handle: "alice"}
+	checks := joinPreflight(plan, wizardDeps(nil, &fakeAccess{auth: &remote.Auth{}}))
+	status := map[string]string{}
+	for _, c := range checks {
+		status[c.Name] = c.Status
+	}
+	if status["dolt"] != "fail" || status["DOLTHUB_TOKEN"] != "fail" || status["upstream"] != "fail" {
+		t.Errorf("checks = %+v, want dolt, token and upstream failures", checks)
+	}
+
+	plan.Provider = tui.JoinGitHub
+	access := &fakeAccess{auth: &remote.Auth{User: "alice"}, repos: map[string]*remote.RepoAccess{"hop/wl-commons": {Exists: true}}}
+	checks = joinPreflight(plan, wizardDeps(nil, access))
+	status = map[string]string{}
+	for _, c := range checks {
+		status[c.Name] = c.Status
+	}
+	if _, ok := status["DOLTHUB_TOKEN"]; ok {
+		t.Error("GitHub joins don't need DOLTHUB_TOKEN")
+	}
+	if status["credentials"] != "pass" || status["upstream"] != "pass" {
+		t.Errorf("checks = %+v, want credentials and upstream to pass", checks)
+	}
+
+	access.authErr = errors.New("gh is not authenticated")
+	checks = joinPreflight(plan, wizardDeps(nil, access))
+	last := checks[len(checks)-1]
+	if last.Name != "credentials" || last.Status != "fail" || last.Hint != "Run: gh auth login" {
+		t.Errorf("auth failure check = %+v", last)
+	}
+}
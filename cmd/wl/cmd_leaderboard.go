@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/gastownhall/wasteland/internal/commons"
@@ -12,7 +13,12 @@ import (
 )
 
 func newLeaderboardCmd(stdout, stderr io.Writer) *cobra.Command {
-	var limit int
+	var (
+		limit   int
+		since   string
+		project string
+		skill   string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "leaderboard",
@@ -22,20 +28,38 @@ func newLeaderboardCmd(stdout, stderr io.Writer) *cobra.Command {
 Displays completion count, average quality and reliability scores,
 and top skill tags for each rig that has earned at least one stamp.
 
+--since limits the ranking to completions within a recent period (e.g.
+30d, 2w, 12h) and adds each rig's rank change against the period before
+it, for monthly recognition. --project and --skill narrow the ranking to
+one project or one stamped skill tag.
+
 EXAMPLES:
-  wl leaderboard              # Top 20 rigs
-  wl leaderboard --limit 10   # Top 10 rigs`,
+  wl leaderboard                          # Top 20 rigs
+  wl leaderboard --limit 10               # Top 10 rigs
+  wl leaderboard --since 30d              # This month, with rank changes
+  wl leaderboard --project gastown --skill go`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runLeaderboard(cmd, stdout, stderr, limit)
+			f := commons.LeaderboardFilter{Limit: limit, Project: project, Skill: skill}
+			if since != "" {
+				d, err := commons.ParsePeriod(since)
+				if err != nil {
+					return err
+				}
+				f.Since = d
+			}
+			return runLeaderboard(cmd, stdout, stderr, f, since)
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of rigs to display")
+	cmd.Flags().StringVar(&since, "since", "", "Only count completions in this recent period (e.g. 30d, 2w)")
+	cmd.Flags().StringVar(&project, "project", "", "Only count completions of items in this project")
+	cmd.Flags().StringVar(&skill, "skill", "", "Only count completions stamped with this skill tag")
 	return cmd
 }
 
-func runLeaderboard(cmd *cobra.Command, stdout, _ io.Writer, limit int) error {
+func runLeaderboard(cmd *cobra.Command, stdout, _ io.Writer, f commons.LeaderboardFilter, since string) error {
 	cfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
@@ -57,36 +81,92 @@ func runLeaderboard(cmd *cobra.Command, stdout, _ io.Writer, limit int) error {
 			return fmt.Errorf("syncing with upstream: %w", syncErr)
 		}
 	}
-	entries, err := commons.QueryLeaderboard(db, limit)
+	entries, err := commons.QueryLeaderboard(db, f)
 	if err != nil {
 		return fmt.Errorf("querying leaderboard: %w", err)
 	}
 
+	renderLeaderboard(stdout, entries, f, since)
+	return nil
+}
+
+// renderLeaderboard writes the ranked table. since is the --since value as
+// typed; when set, a column shows each rig's rank change.
+func renderLeaderboard(w io.Writer, entries []commons.LeaderboardEntry, f commons.LeaderboardFilter, since string) {
+	var scope []string
+	if since != "" {
+		scope = append(scope, "last "+since)
+	}
+	if f.Project != "" {
+		scope = append(scope, "project "+f.Project)
+	}
+	if f.Skill != "" {
+		scope = append(scope, "skill "+f.Skill)
+	}
+
 	if len(entries) == 0 {
-		fmt.Fprintln(stdout, "No validated completions yet — the leaderboard is empty.")
-		return nil
+		if len(scope) > 0 {
+			fmt.Fprintf(w, "No validated completions for %s.\n", strings.Join(scope, ", "))
+			return
+		}
+		fmt.Fprintln(w, "No validated completions yet — the leaderboard is empty.")
+		return
 	}
 
-	tbl := style.NewTable(
-		style.Column{Name: "#", Width: 4, Align: style.AlignRight},
-		style.Column{Name: "RIG", Width: 20},
-		style.Column{Name: "DONE", Width: 6, Align: style.AlignRight},
-		style.Column{Name: "QUALITY", Width: 8, Align: style.AlignRight},
-		style.Column{Name: "RELIAB", Width: 8, Align: style.AlignRight},
-		style.Column{Name: "TOP SKILLS", Width: 30},
-	)
+	cols := []style.Column{
+		{Name: "#", Width: 4, Align: style.AlignRight},
+		{Name: "RIG", Width: 20},
+		{Name: "DONE", Width: 6, Align: style.AlignRight},
+		{Name: "QUALITY", Width: 8, Align: style.AlignRight},
+		{Name: "RELIAB", Width: 8, Align: style.AlignRight},
+		{Name: "TOP SKILLS", Width: 30},
+	}
+	hasBadges := slices.ContainsFunc(entries, func(e commons.LeaderboardEntry) bool { return len(e.Badges) > 0 })
+	if hasBadges {
+		cols = append(cols, style.Column{Name: "BADGES", Width: 24})
+	}
+	if f.Since > 0 {
+		cols = slices.Insert(cols, 1, style.Column{Name: "±", Width: 4, Align: style.AlignRight})
+	}
+	tbl := style.NewTable(cols...)
 
-	for i, e := range entries {
-		rank := fmt.Sprintf("%d", i+1)
-		done := fmt.Sprintf("%d", e.Completions)
-		quality := fmt.Sprintf("%.1f", e.AvgQuality)
-		reliab := fmt.Sprintf("%.1f", e.AvgReliab)
-		skills := strings.Join(e.TopSkills, ", ")
-		tbl.AddRow(rank, e.RigHandle, done, quality, reliab, skills)
+	for _, e := range entries {
+		row := []string{
+			fmt.Sprintf("%d", e.Rank),
+			e.RigHandle,
+			fmt.Sprintf("%d", e.Completions),
+			fmt.Sprintf("%.1f", e.AvgQuality),
+			fmt.Sprintf("%.1f", e.AvgReliab),
+			strings.Join(e.TopSkills, ", "),
+		}
+		if hasBadges {
+			row = append(row, strings.Join(e.Badges, ", "))
+		}
+		if f.Since > 0 {
+			row = slices.Insert(row, 1, rankDeltaLabel(e))
+		}
+		tbl.AddRow(row...)
 	}
 
-	fmt.Fprintf(stdout, "Leaderboard (%d rigs):\n\n", len(entries))
-	fmt.Fprint(stdout, tbl.Render())
+	title := fmt.Sprintf("Leaderboard (%d rigs)", len(entries))
+	if len(scope) > 0 {
+		title = fmt.Sprintf("Leaderboard, %s (%d rigs)", strings.Join(scope, ", "), len(entries))
+	}
+	fmt.Fprintf(w, "%s:\n\n", title)
+	fmt.Fprint(w, tbl.Render())
+}
 
-	return nil
+// rankDeltaLabel renders a rank change against the previous period:
+// ▲ for climbing, ▼ for falling, "new" for rigs unranked before.
+func rankDeltaLabel(e commons.LeaderboardEntry) string {
+	switch d := e.RankDelta(); {
+	case e.PrevRank == 0:
+		return "new"
+	case d > 0:
+		return fmt.Sprintf("▲%d", d)
+	case d < 0:
+		return fmt.Sprintf("▼%d", -d)
+	default:
+		return "="
+	}
 }
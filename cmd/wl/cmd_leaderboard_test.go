@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestRenderLeaderboard_RankDeltas(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderLeaderboard(&buf, []commons.LeaderboardEntry{
+		{RigHandle: "alice", Rank: 1, PrevRank: 3, Completions: 5},
+		{RigHandle: "bob", Rank: 2, PrevRank: 1, Completions: 4},
+		{RigHandle: "carol", Rank: 3, PrevRank: 3, Completions: 2},
+		{RigHandle: "dave", Rank: 4, Completions: 1},
+	}, commons.LeaderboardFilter{Since: 30 * 24 * time.Hour, Project: "gastown"}, "30d")
+
+	out := buf.String()
+	for _, want := range []string{"Leaderboard, last 30d, project gastown (4 rigs)", "▲2", "▼1", "=", "new"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderLeaderboard_NoDeltaColumnWithoutSince(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderLeaderboard(&buf, []commons.LeaderboardEntry{{RigHandle: "alice", Rank: 1, Completions: 5}}, commons.LeaderboardFilter{}, "")
+	if out := buf.String(); strings.Contains(out, "±") || strings.Contains(out, "new") {
+		t.Errorf("unexpected rank delta column:\n%s", out)
+	}
+
+	buf.Reset()
+	renderLeaderboard(&buf, nil, commons.LeaderboardFilter{Skill: "go"}, "")
+	if out := buf.String(); !strings.Contains(out, "No validated completions for skill go") {
+		t.Errorf("empty output = %q", out)
+	}
+}
+
+func TestRenderLeaderboard_BadgesColumn(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderLeaderboard(&buf, []commons.LeaderboardEntry{{RigHandle: "alice", Rank: 1, Completions: 5}}, commons.LeaderboardFilter{}, "")
+	if strings.Contains(buf.String(), "BADGES") {
+		t.Errorf("badges column without badges:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	renderLeaderboard(&buf, []commons.LeaderboardEntry{
+		{RigHandle: "alice", Rank: 1, Completions: 5, Badges: []string{"mentor", "bug-hunter"}},
+		{RigHandle: "bob", Rank: 2, Completions: 3},
+	}, commons.LeaderboardFilter{}, "")
+	if out := buf.String(); !strings.Contains(out, "BADGES") || !strings.Contains(out, "mentor, bug-hunter") {
+		t.Errorf("output missing badges:\n%s", out)
+	}
+}
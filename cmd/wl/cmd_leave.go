@@ -1,16 +1,24 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
 
 func newLeaveCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var purge, yes bool
+
+	cmd := &cobra.Command{
 		Use:   "leave [upstream]",
 		Short: "Leave a wasteland",
 		Long: `Leave a wasteland by removing its configuration.
@@ -21,22 +29,35 @@ If multiple are joined, specify the upstream or use --wasteland.
 The local fork clone directory is NOT deleted automatically.
 The command prints its path for manual cleanup.
 
+With --purge, leave also deletes the local clone (and with it the local
+wl/<handle>/* branches) and your wl/<handle>/* branches on the fork.
+Before deleting anything it fetches origin and lists what would be lost:
+commits not pushed to your fork and branches with open PRs. When nothing
+would be lost it asks for confirmation (--yes skips it); when something
+would, you must type the wasteland's name, and --yes is refused.
+
 Examples:
   wl leave
   wl leave steveyegge/wl-commons
-  wl leave --wasteland steveyegge/wl-commons`,
+  wl leave --wasteland steveyegge/wl-commons
+  wl leave --purge`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var positional string
 			if len(args) > 0 {
 				positional = args[0]
 			}
-			return runLeave(cmd, stdout, stderr, positional)
+			return runLeave(cmd, stdout, stderr, positional, purge, yes)
 		},
 	}
+
+	cmd.Flags().BoolVar(&purge, "purge", false, "Also delete the local clone and your branches on the fork")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Purge without asking when nothing would be lost")
+
+	return cmd
 }
 
-func runLeave(cmd *cobra.Command, stdout, _ io.Writer, positional string) error {
+func runLeave(cmd *cobra.Command, stdout, _ io.Writer, positional string, purge, yes bool) error {
 	store := federation.NewConfigStore()
 
 	// Determine which upstream to leave: positional arg > --wasteland flag > auto.
@@ -50,6 +71,17 @@ func runLeave(cmd *cobra.Command, stdout, _ io.Writer, positional string) error
 		return fmt.Errorf("resolving wasteland: %w", err)
 	}
 
+	if purge {
+		if err := requireWritable(cfg, "leave --purge"); err != nil {
+			return err
+		}
+		deps := defaultLeaveDeps(stdout)
+		if yes {
+			deps.confirm = func(string) bool { return true }
+		}
+		return purgeWasteland(stdout, cfg, store, deps, yes)
+	}
+
 	upstream := cfg.Upstream
 	if err := store.Delete(upstream); err != nil {
 		return fmt.Errorf("removing wasteland config: %w", err)
@@ -64,3 +96,265 @@ func runLeave(cmd *cobra.Command, stdout, _ io.Writer, positional string) error
 
 	return nil
 }
+
+// leaveDeps holds the external operations used by 'wl leave --purge', so
+// tests can substitute fakes.
+type leaveDeps struct {
+	fetch        func(dbDir, remote string) error
+	doltQuery    func(dbDir, query string) (string, error)
+	prState      func(cfg *federation.Config, branch string) string
+	openDB       func(cfg *federation.Config) (commons.DB, error) // remote backend: the fork
+	deleteRemote func(dbDir, remote, branch string) error
+	removeAll    func(path string) error
+	confirm      func(prompt string) bool
+	readLine     func(prompt string) string
+}
+
+func defaultLeaveDeps(stdout io.Writer) *leaveDeps {
+	reader := bufio.NewReader(os.Stdin)
+	return &leaveDeps{
+		fetch:        commons.FetchRemote,
+		doltQuery:    commons.DoltSQLQuery,
+		prState:      prStateForBranch,
+		openDB:       openDBFromConfig,
+		deleteRemote: commons.DeleteRemoteBranch,
+		removeAll:    os.RemoveAll,
+		confirm: func(prompt string) bool {
+			fmt.Fprintf(stdout, "\n  %s [y/N] ", prompt)
+			line, _ := reader.ReadString('\n')
+			answer := strings.ToLower(strings.TrimSpace(line))
+			return answer == "y" || answer == "yes"
+		},
+		readLine: func(prompt string) string {
+			fmt.Fprintf(stdout, "\n  %s ", prompt)
+			line, _ := reader.ReadString('\n')
+			return strings.TrimSpace(line)
+		},
+	}
+}
+
+// purgePlan is what 'wl leave --purge' would delete and what would be
+// lost with it.
+type purgePlan struct {
+	LocalDir      string   // clone to delete; "" when there is none
+	LocalBranches []string // wl/<handle>/* branches in the clone
+	ForkBranches  []string // wl/<handle>/* branches on origin
+	Unpushed      []string // work not on the fork, e.g. "main: 2 commits not pushed to origin"
+	OpenPRs       []string // branches whose PR is still open
+	Notes         []string // things left alone, and why
+}
+
+// atRisk reports whether purging would lose unpushed work or orphan open PRs.
+func (p *purgePlan) atRisk() bool {
+	return len(p.Unpushed) > 0 || len(p.OpenPRs) > 0
+}
+
+// collectPurgePlan inspects the clone and the fork. Anything that can't
+// be checked is reported as at risk rather than assumed safe.
+func collectPurgePlan(cfg *federation.Config, deps *leaveDeps) (*purgePlan, error) {
+	plan := &purgePlan{}
+	prefix := "wl/" + cfg.RigHandle + "/"
+
+	if cfg.ResolveBackend() != federation.BackendLocal || cfg.LocalDir == "" {
+		db, err := deps.openDB(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("opening fork: %w", err)
+		}
+		if plan.ForkBranches, err = db.Branches(prefix); err != nil {
+			return nil, fmt.Errorf("listing fork branches: %w", err)
+		}
+		if cfg.LocalDir != "" {
+			plan.Notes = append(plan.Notes, "local clone "+cfg.LocalDir+" is kept: it isn't checked for unpushed work in remote mode")
+		}
+		plan.OpenPRs = openPRBranches(cfg, deps, plan.ForkBranches)
+		return plan, nil
+	}
+
+	dir := cfg.LocalDir
+	if _, err := os.Stat(filepath.Join(dir, ".dolt")); err != nil {
+		plan.Notes = append(plan.Notes, "no dolt clone at "+dir+"; nothing to delete locally")
+		return plan, nil
+	}
+	plan.LocalDir = dir
+
+	fetched := true
+	if err := deps.fetch(dir, "origin"); err != nil {
+		fetched = false
+		plan.Unpushed = append(plan.Unpushed, fmt.Sprintf("could not fetch origin (%v): unpushed work can't be ruled out", err))
+	}
+
+	var err error
+	if plan.LocalBranches, err = queryNames(deps, dir, fmt.Sprintf(
+		"SELECT name FROM dolt_branches WHERE name LIKE '%s%%' ORDER BY name", commons.EscapeLIKE(prefix))); err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	remote, err := queryNames(deps, dir, fmt.Sprintf(
+		"SELECT name FROM dolt_remote_branches WHERE name LIKE '%s%%' ORDER BY name", commons.EscapeLIKE("remotes/origin/"+prefix)))
+	if err != nil {
+		return nil, fmt.Errorf("listing fork branches: %w", err)
+	}
+	onFork := map[string]bool{}
+	for _, r := range remote {
+		b := strings.TrimPrefix(r, "remotes/origin/")
+		plan.ForkBranches = append(plan.ForkBranches, b)
+		onFork[b] = true
+	}
+
+	if fetched {
+		unpushed := func(label, rangeSpec, what string) {
+			n, err := countCommits(deps.doltQuery, dir, rangeSpec)
+			switch {
+			case err != nil:
+				plan.Unpushed = append(plan.Unpushed, fmt.Sprintf("%s: could not count unpushed commits (%v)", label, err))
+			case n > 0:
+				plan.Unpushed = append(plan.Unpushed, fmt.Sprintf("%s: %d commit(s) %s", label, n, what))
+			}
+		}
+		unpushed("main", "origin/main..main", "not pushed to origin")
+		for _, b := range plan.LocalBranches {
+			if onFork[b] {
+				unpushed(b, "origin/"+b+".."+b, "not pushed to origin")
+			} else {
+				unpushed(b, "main.."+b, "never pushed")
+			}
+		}
+	}
+
+	all := append([]string{}, plan.LocalBranches...)
+	for _, b := range plan.ForkBranches {
+		if !slices.Contains(plan.LocalBranches, b) {
+			all = append(all, b)
+		}
+	}
+	plan.OpenPRs = openPRBranches(cfg, deps, all)
+	return plan, nil
+}
+
+// openPRBranches returns the branches that still have an open PR. Only PR
+// mode opens PRs.
+func openPRBranches(cfg *federation.Config, deps *leaveDeps, branches []string) []string {
+	if cfg.ResolveMode() != federation.ModePR {
+		return nil
+	}
+	var open []string
+	for _, b := range branches {
+		if deps.prState(cfg, b) == "open" {
+			open = append(open, b)
+		}
+	}
+	return open
+}
+
+// queryNames runs a single-column query and returns its values.
+func queryNames(deps *leaveDeps, dir, query string) ([]string, error) {
+	out, err := deps.doltQuery(dir, query)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for i, row := range wlParseCSV(out) {
+		if i == 0 || len(row) == 0 || row[0] == "" {
+			continue // header
+		}
+		names = append(names, row[0])
+	}
+	return names, nil
+}
+
+// purgeWasteland lists what purging cfg would delete and lose and, once
+// confirmed, deletes the fork branches, the config and the local clone,
+// in that order. If any fork branch can't be deleted the config and clone
+// are kept so the purge can be retried.
+func purgeWasteland(stdout io.Writer, cfg *federation.Config, store federation.ConfigStore, deps *leaveDeps, yes bool) error {
+	plan, err := collectPurgePlan(cfg, deps)
+	if err != nil {
+		return err
+	}
+	renderPurgePlan(stdout, cfg, plan)
+
+	switch {
+	case plan.atRisk() && yes:
+		return fmt.Errorf("--yes refused: purging %s would lose the work listed above; rerun without --yes to confirm", cfg.Upstream)
+	case plan.atRisk():
+		if deps.readLine(fmt.Sprintf("Type %s to delete it anyway:", style.Bold.Render(cfg.Upstream))) != cfg.Upstream {
+			fmt.Fprintln(stdout, "Aborted.")
+			return nil
+		}
+	default:
+		if !deps.confirm(fmt.Sprintf("Leave %s and delete the above?", cfg.Upstream)) {
+			fmt.Fprintln(stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	fmt.Fprintln(stdout)
+	if len(plan.ForkBranches) > 0 {
+		var deleteBranch func(string) error
+		if plan.LocalDir != "" {
+			deleteBranch = func(b string) error { return deps.deleteRemote(plan.LocalDir, "origin", b) }
+		} else {
+			db, err := deps.openDB(cfg)
+			if err != nil {
+				return fmt.Errorf("opening fork: %w", err)
+			}
+			deleteBranch = db.DeleteBranch
+		}
+		failed := 0
+		for _, b := range plan.ForkBranches {
+			if err := deleteBranch(b); err != nil {
+				failed++
+				fmt.Fprintf(stdout, "  %s %s on fork: %v\n", style.Error.Render(style.IconFail), b, err)
+				continue
+			}
+			fmt.Fprintf(stdout, "  %s deleted %s on fork\n", style.Success.Render(style.IconPass), b)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d fork branch(es) could not be deleted; kept the config and clone so 'wl leave --purge' can be retried", failed, len(plan.ForkBranches))
+		}
+	}
+
+	if err := store.Delete(cfg.Upstream); err != nil {
+		return fmt.Errorf("removing wasteland config: %w", err)
+	}
+	if plan.LocalDir != "" {
+		if err := deps.removeAll(plan.LocalDir); err != nil {
+			return fmt.Errorf("deleting local clone %s: %w", plan.LocalDir, err)
+		}
+		fmt.Fprintf(stdout, "  %s deleted %s\n", style.Success.Render(style.IconPass), plan.LocalDir)
+	}
+	fmt.Fprintf(stdout, "\n%s Left and purged wasteland: %s\n", style.Bold.Render("✓"), cfg.Upstream)
+	return nil
+}
+
+func renderPurgePlan(w io.Writer, cfg *federation.Config, plan *purgePlan) {
+	fmt.Fprintf(w, "Purging %s will delete:\n", style.Bold.Render(cfg.Upstream))
+	fmt.Fprintln(w, "  the wasteland config")
+	if plan.LocalDir != "" {
+		fmt.Fprintf(w, "  local clone %s (%d wl/%s/* branch(es))\n", plan.LocalDir, len(plan.LocalBranches), cfg.RigHandle)
+	}
+	if len(plan.ForkBranches) > 0 {
+		fmt.Fprintf(w, "  %d branch(es) on your fork %s/%s:\n", len(plan.ForkBranches), cfg.ForkOrg, cfg.ForkDB)
+		for _, b := range plan.ForkBranches {
+			fmt.Fprintf(w, "    %s\n", b)
+		}
+	}
+	for _, n := range plan.Notes {
+		fmt.Fprintf(w, "  %s\n", style.Dim.Render(n))
+	}
+
+	if len(plan.Unpushed) > 0 {
+		fmt.Fprintf(w, "\n%s Unpushed work that would be lost:\n", style.Warning.Render(style.IconWarn))
+		for _, u := range plan.Unpushed {
+			fmt.Fprintf(w, "    %s\n", u)
+		}
+	}
+	if len(plan.OpenPRs) > 0 {
+		fmt.Fprintf(w, "\n%s Branches with open PRs (the PRs would lose their source branch):\n", style.Warning.Render(style.IconWarn))
+		for _, b := range plan.OpenPRs {
+			fmt.Fprintf(w, "    %s\n", b)
+		}
+	}
+	if !plan.atRisk() {
+		fmt.Fprintf(w, "\n%s Nothing unpushed and no open PRs.\n", style.Success.Render(style.IconPass))
+	}
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -32,7 +35,7 @@ func TestRunLeave_Success(t *testing.T) {
 	cmd.Flags().String("wasteland", "", "")
 
 	var stdout, stderr bytes.Buffer
-	err := runLeave(cmd, &stdout, &stderr, "hop/wl-commons")
+	err := runLeave(cmd, &stdout, &stderr, "hop/wl-commons", false, false)
 	if err != nil {
 		t.Fatalf("runLeave() error: %v", err)
 	}
@@ -60,7 +63,7 @@ func TestRunLeave_NotJoined(t *testing.T) {
 	cmd.Flags().String("wasteland", "", "")
 
 	var stdout, stderr bytes.Buffer
-	err := runLeave(cmd, &stdout, &stderr, "hop/wl-commons")
+	err := runLeave(cmd, &stdout, &stderr, "hop/wl-commons", false, false)
 	if err == nil {
 		t.Fatal("runLeave() expected error for non-joined wasteland")
 	}
@@ -88,7 +91,7 @@ func TestRunLeave_AutoResolvesSingleWasteland(t *testing.T) {
 	cmd.Flags().String("wasteland", "", "")
 
 	var stdout, stderr bytes.Buffer
-	err := runLeave(cmd, &stdout, &stderr, "")
+	err := runLeave(cmd, &stdout, &stderr, "", false, false)
 	if err != nil {
 		t.Fatalf("runLeave() error: %v", err)
 	}
@@ -96,3 +99,157 @@ func TestRunLeave_AutoResolvesSingleWasteland(t *testing.T) {
 		t.Errorf("output missing 'Left wasteland': %q", stdout.String())
 	}
 }
+
+// purgeFixture saves a local-backend PR-mode config whose clone is a temp
+// dir with a .dolt directory, and returns it with fake leave deps.
+func purgeFixture(t *testing.T) (*federation.Config, federation.ConfigStore, *leaveDeps, *[]string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".dolt"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	store := federation.NewConfigStore()
+	cfg := &federation.Config{
+		Upstream:  "hop/wl-commons",
+		ForkOrg:   "alice",
+		ForkDB:    "wl-commons",
+		LocalDir:  dir,
+		RigHandle: "alice",
+		Backend:   federation.BackendLocal,
+		JoinedAt:  time.Now(),
+	}
+	if err := store.Save(cfg); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	var actions []string
+	deps := &leaveDeps{
+		fetch: func(string, string) error { return nil },
+		doltQuery: func(_, query string) (string, error) {
+			switch {
+			case strings.Contains(query, "dolt_remote_branches"):
+				return "name\nremotes/origin/wl/alice/w-1\n", nil
+			case strings.Contains(query, "dolt_branches"):
+				return "name\nwl/alice/w-1\nwl/alice/w-2\n", nil
+			default:
+				return "n\n0\n", nil
+			}
+		},
+		prState: func(*federation.Config, string) string { return "" },
+		deleteRemote: func(_, remote, branch string) error {
+			actions = append(actions, "delete "+remote+"/"+branch)
+			return nil
+		},
+		removeAll: func(path string) error {
+			actions = append(actions, "rm "+path)
+			return nil
+		},
+		confirm:  func(string) bool { return true },
+		readLine: func(string) string { return "" },
+	}
+	return cfg, store, deps, &actions
+}
+
+func TestPurgeWasteland_NothingAtRisk(t *testing.T) {
+	cfg, store, deps, actions := purgeFixture(t)
+
+	var stdout bytes.Buffer
+	if err := purgeWasteland(&stdout, cfg, store, deps, false); err != nil {
+		t.Fatalf("purgeWasteland() error: %v", err)
+	}
+	want := []string{"delete origin/wl/alice/w-1", "rm " + cfg.LocalDir}
+	if strings.Join(*actions, ",") != strings.Join(want, ",") {
+		t.Errorf("actions = %v, want %v", *actions, want)
+	}
+	if _, err := store.Load(cfg.Upstream); err == nil {
+		t.Error("config should be deleted after purge")
+	}
+	if !strings.Contains(stdout.String(), "Nothing unpushed") {
+		t.Errorf("output missing all-clear: %q", stdout.String())
+	}
+}
+
+func TestPurgeWasteland_Declined(t *testing.T) {
+	cfg, store, deps, actions := purgeFixture(t)
+	deps.confirm = func(string) bool { return false }
+
+	var stdout bytes.Buffer
+	if err := purgeWasteland(&stdout, cfg, store, deps, false); err != nil {
+		t.Fatalf("purgeWasteland() error: %v", err)
+	}
+	if len(*actions) != 0 {
+		t.Errorf("declined purge should delete nothing, got %v", *actions)
+	}
+	if _, err := store.Load(cfg.Upstream); err != nil {
+		t.Error("config should be kept when the purge is declined")
+	}
+}
+
+func TestPurgeWasteland_UnpushedRequiresTypedName(t *testing.T) {
+	cfg, store, deps, actions := purgeFixture(t)
+	query := deps.doltQuery
+	deps.doltQuery = func(dir, q string) (string, error) {
+		if strings.Contains(q, "main..wl/alice/w-2") {
+			return "n\n3\n", nil
+		}
+		return query(dir, q)
+	}
+
+	var stdout bytes.Buffer
+	if err := purgeWasteland(&stdout, cfg, store, deps, true); err == nil {
+		t.Fatal("--yes should be refused when work would be lost")
+	}
+	if !strings.Contains(stdout.String(), "wl/alice/w-2: 3 commit(s) never pushed") {
+		t.Errorf("output missing unpushed branch: %q", stdout.String())
+	}
+
+	deps.readLine = func(string) string { return "hop/wl-commons" }
+	if err := purgeWasteland(&stdout, cfg, store, deps, false); err != nil {
+		t.Fatalf("purgeWasteland() error: %v", err)
+	}
+	if len(*actions) != 2 {
+		t.Errorf("typed confirmation should purge, got %v", *actions)
+	}
+}
+
+func TestPurgeWasteland_OpenPRAndFetchFailure(t *testing.T) {
+	cfg, store, deps, actions := purgeFixture(t)
+	deps.fetch = func(string, string) error { return errors.New("offline") }
+	deps.prState = func(_ *federation.Config, branch string) string {
+		if branch == "wl/alice/w-1" {
+			return "open"
+		}
+		return ""
+	}
+
+	var stdout bytes.Buffer
+	if err := purgeWasteland(&stdout, cfg, store, deps, false); err != nil {
+		t.Fatalf("purgeWasteland() error: %v", err)
+	}
+	got := stdout.String()
+	for _, want := range []string{"could not fetch origin", "open PRs", "wl/alice/w-1", "Aborted."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q: %q", want, got)
+		}
+	}
+	if len(*actions) != 0 {
+		t.Errorf("wrong typed name should delete nothing, got %v", *actions)
+	}
+}
+
+func TestPurgeWasteland_ForkDeleteFailureKeepsConfig(t *testing.T) {
+	cfg, store, deps, actions := purgeFixture(t)
+	deps.deleteRemote = func(string, string, string) error { return errors.New("permission denied") }
+
+	var stdout bytes.Buffer
+	if err := purgeWasteland(&stdout, cfg, store, deps, false); err == nil {
+		t.Fatal("expected error when a fork branch can't be deleted")
+	}
+	if len(*actions) != 0 {
+		t.Errorf("clone should be kept, got %v", *actions)
+	}
+	if _, err := store.Load(cfg.Upstream); err != nil {
+		t.Error("config should be kept when fork cleanup fails")
+	}
+}
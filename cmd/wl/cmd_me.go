@@ -17,7 +17,8 @@ func newMeCmd(stdout, stderr io.Writer) *cobra.Command {
 		Use:   "me",
 		Short: "Show your personal dashboard",
 		Long: `Show your personal dashboard: claimed items, items awaiting your review,
-in-flight branch work (PR mode), and recent completions.
+in-flight branch work (PR mode), recent completions, and your reputation
+(stamp count, average quality and reliability, recent stamps, badges).
 
 Fetches both upstream (master DB) and origin (your fork), then shows
 where each item lives. Also scans wl/<handle>/* branches for PR-mode
@@ -136,6 +137,10 @@ func runMe(cmd *cobra.Command, stdout, _ io.Writer) error {
 		}
 	}
 
+	if printUpNext(stdout, openDB(dbDir), handle) {
+		printed = true
+	}
+
 	// Awaiting my review (on upstream, the canonical source).
 	ref := "upstream/main"
 	if !upstreamOK {
@@ -182,6 +187,8 @@ func runMe(cmd *cobra.Command, stdout, _ io.Writer) error {
 		fmt.Fprintf(stdout, "\nNothing here — browse the board: wl browse\n")
 	}
 
+	printReputation(stdout, openDB(dbDir), handle)
+
 	return nil
 }
 
@@ -213,6 +220,10 @@ func runMeRemote(stdout io.Writer, cfg *federation.Config) error {
 		}
 	}
 
+	if printUpNext(stdout, db, handle) {
+		printed = true
+	}
+
 	// Awaiting my review.
 	reviewCSV, err := db.Query(fmt.Sprintf(
 		"SELECT id, title, claimed_by FROM wanted WHERE posted_by = '%s' AND status = 'in_review' ORDER BY priority ASC",
@@ -253,9 +264,50 @@ func runMeRemote(stdout io.Writer, cfg *federation.Config) error {
 		fmt.Fprintf(stdout, "\nNothing here — browse the board: wl browse\n")
 	}
 
+	printReputation(stdout, db, handle)
+
 	return nil
 }
 
+// printUpNext lists released claims the rig is first in the queue for and
+// reports whether it printed anything.
+func printUpNext(stdout io.Writer, db commons.DB, handle string) bool {
+	items, err := commons.QueryUpNext(db, handle)
+	if err != nil || len(items) == 0 {
+		return false
+	}
+	fmt.Fprintf(stdout, "\n%s\n", style.Bold.Render("Up next (first in the claim queue — wl claim to take it):"))
+	for _, item := range items {
+		fmt.Fprintf(stdout, "  %-12s %-30s %s\n", item.ID, item.Title, wlFormatPriority(strconv.Itoa(item.Priority)))
+	}
+	return true
+}
+
+// printReputation summarizes the stamps and badges the rig has received.
+// It prints nothing when the rig has neither, or the query fails.
+func printReputation(stdout io.Writer, db commons.DB, handle string) {
+	rep, err := commons.QueryReputation(db, handle)
+	if err != nil || (rep.StampCount == 0 && len(rep.Badges) == 0) {
+		return
+	}
+	fmt.Fprintf(stdout, "\n%s\n", style.Bold.Render("Reputation:"))
+	fmt.Fprintf(stdout, "  %d stamp(s), avg quality %.1f, avg reliability %.1f\n", rep.StampCount, rep.AvgQuality, rep.AvgReliability)
+	if len(rep.Badges) > 0 {
+		badges := make([]string, len(rep.Badges))
+		for i, b := range rep.Badges {
+			badges[i] = b.BadgeType
+		}
+		fmt.Fprintf(stdout, "  Badges: %s\n", strings.Join(badges, ", "))
+	}
+	for _, s := range rep.RecentStamps {
+		date := s.CreatedAt
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		fmt.Fprintf(stdout, "  %-10s %-12s q%.0f r%.0f %s\n", date, s.Author, s.Quality, s.Reliability, s.Message)
+	}
+}
+
 // queryClaimedAsOf queries claimed/in_review items for a handle on a specific ref.
 // Returns data rows (no header) with columns: id, title, status, priority, effort_level, days_stale.
 func queryClaimedAsOf(dbDir, handle, ref string) [][]string {
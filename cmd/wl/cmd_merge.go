@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
@@ -13,8 +15,10 @@ import (
 
 func newMergeCmd(stdout, stderr io.Writer) *cobra.Command {
 	var (
-		noPush     bool
-		keepBranch bool
+		noPush      bool
+		keepBranch  bool
+		allApproved bool
+		yes         bool
 	)
 
 	cmd := &cobra.Command{
@@ -25,18 +29,41 @@ func newMergeCmd(stdout, stderr io.Writer) *cobra.Command {
 Performs a Dolt merge, pushes main to upstream and origin, and deletes
 the branch (unless --keep-branch is set).
 
+Before merging, a trial merge checks for conflicts. If the branch and
+main changed the same rows, the conflicting rows are shown (base, main
+and branch values) and the merge is aborted with main left untouched.
+
+With --all-approved (GitHub provider), every wl/* branch is checked and
+a summary table is shown. Branches whose PR is approved, with no
+outstanding change requests, that have changes and merge cleanly are
+merged one after another after confirmation; main is pushed once at the
+end. Each branch is re-checked for conflicts just before it is merged,
+since earlier merges in the batch move main.
+
 Examples:
   wl merge wl/my-rig/w-abc123
   wl merge wl/my-rig/w-abc123 --keep-branch
-  wl merge wl/my-rig/w-abc123 --no-push`,
-		Args: cobra.ExactArgs(1),
+  wl merge wl/my-rig/w-abc123 --no-push
+  wl merge --all-approved
+  wl merge --all-approved --yes`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all, _ := cmd.Flags().GetBool("all-approved"); all {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allApproved {
+				return runMergeAllApproved(cmd, stdout, stderr, noPush, keepBranch, yes)
+			}
 			return runMerge(cmd, stdout, stderr, args[0], noPush, keepBranch)
 		},
 	}
 
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes")
 	cmd.Flags().BoolVar(&keepBranch, "keep-branch", false, "Don't delete branch after merge")
+	cmd.Flags().BoolVar(&allApproved, "all-approved", false, "Merge every branch with an approved PR that merges cleanly")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "With --all-approved, merge without asking for confirmation")
 	cmd.ValidArgsFunction = completeBranchNames
 
 	return cmd
@@ -47,6 +74,9 @@ func runMerge(cmd *cobra.Command, stdout, _ io.Writer, branch string, noPush, ke
 	if err != nil {
 		return hintWrap(err)
 	}
+	if err := requireWritable(cfg, "merge"); err != nil {
+		return err
+	}
 
 	// Remote mode: use RemoteDB.MergeBranch via the write API.
 	if cfg.ResolveBackend() != federation.BackendLocal {
@@ -56,6 +86,7 @@ func runMerge(cmd *cobra.Command, stdout, _ io.Writer, branch string, noPush, ke
 		return runMergeRemote(stdout, cfg, branch, keepBranch)
 	}
 
+	db := openLocalClone(cfg)
 	exists, err := commons.BranchExists(cfg.LocalDir, branch)
 	if err != nil {
 		return fmt.Errorf("checking branch: %w", err)
@@ -79,14 +110,23 @@ func runMerge(cmd *cobra.Command, stdout, _ io.Writer, branch string, noPush, ke
 		return fmt.Errorf("checking out main: %w", err)
 	}
 
-	if err := commons.MergeBranch(cfg.LocalDir, branch); err != nil {
+	conflicts, err := previewMergeConflicts(commons.DoltSQLQuery, cfg.LocalDir, branch)
+	if err != nil {
+		fmt.Fprintf(stdout, "  %s could not preview merge conflicts: %v\n", style.Warning.Render(style.IconWarn), err)
+	}
+	if len(conflicts) > 0 {
+		renderMergeConflicts(stdout, branch, conflicts)
+		return fmt.Errorf("merge aborted: %d conflicting row(s) between main and %s", len(conflicts), branch)
+	}
+
+	if err := db.MergeBranch(branch); err != nil {
 		return err
 	}
 
 	fmt.Fprintf(stdout, "%s Merged %s into main\n", style.Bold.Render("✓"), branch)
 
 	if !keepBranch {
-		if err := commons.DeleteBranch(cfg.LocalDir, branch); err != nil {
+		if err := db.DeleteBranch(branch); err != nil {
 			fmt.Fprintf(stdout, "  warning: failed to delete branch %s: %v\n", branch, err)
 		} else {
 			fmt.Fprintf(stdout, "  Branch %s deleted\n", branch)
@@ -94,7 +134,7 @@ func runMerge(cmd *cobra.Command, stdout, _ io.Writer, branch string, noPush, ke
 	}
 
 	if !noPush {
-		if err := commons.PushWithSync(cfg.LocalDir, stdout); err != nil {
+		if err := db.PushWithSync(stdout); err != nil {
 			fmt.Fprintf(stdout, "\n  %s %s\n", style.Warning.Render(style.IconWarn),
 				"Push failed — merge saved locally. Run 'wl sync' to retry.")
 		}
@@ -136,6 +176,209 @@ func runMergeRemote(stdout io.Writer, cfg *federation.Config, branch string, kee
 	return nil
 }
 
+// mergeCandidate is a wl/* branch considered by merge --all-approved.
+type mergeCandidate struct {
+	Branch string
+	Review string // "approved", "changes requested" or "no approval"
+	Delta  string // "N table(s)", "no changes" or "N conflict(s)"
+	Ready  bool
+}
+
+// mergeAllDeps holds the external operations used by merge --all-approved,
+// so tests can substitute fakes.
+type mergeAllDeps struct {
+	listBranches func() ([]string, error)
+	approval     func(branch string) (approved, changesRequested bool)
+	doltQuery    func(dir, query string) (string, error)
+	merge        func(branch string) error
+	cleanup      func(branch string) // delete the branch and close its PR; best-effort
+	push         func() error
+	confirm      func(prompt string) bool
+}
+
+func runMergeAllApproved(cmd *cobra.Command, stdout, _ io.Writer, noPush, keepBranch, yes bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if err := requireWritable(cfg, "merge"); err != nil {
+		return err
+	}
+	if cfg.ResolveBackend() != federation.BackendLocal {
+		return fmt.Errorf("--all-approved requires a local clone")
+	}
+	if !cfg.IsGitHub() {
+		return fmt.Errorf("--all-approved requires GitHub provider (approvals are read from PR reviews)")
+	}
+	ghPath, err := exec.LookPath("gh")
+	if err != nil {
+		return fmt.Errorf("gh CLI not found in PATH (needed to read PR approvals)")
+	}
+	client := newGHClient(ghPath)
+
+	dir := cfg.LocalDir
+	db := openLocalClone(cfg)
+	deps := &mergeAllDeps{
+		listBranches: func() ([]string, error) { return commons.ListBranches(dir, "wl/") },
+		approval: func(branch string) (bool, bool) {
+			return prApprovalStatus(client, cfg.Upstream, cfg.ForkOrg, branch)
+		},
+		doltQuery: commons.DoltSQLQuery,
+		merge: func(branch string) error {
+			if err := commons.CheckoutMain(dir); err != nil {
+				return fmt.Errorf("checking out main: %w", err)
+			}
+			return db.MergeBranch(branch)
+		},
+		cleanup: func(branch string) {
+			if !keepBranch {
+				if err := db.DeleteBranch(branch); err != nil {
+					fmt.Fprintf(stdout, "    warning: failed to delete branch %s: %v\n", branch, err)
+				}
+			}
+			closeGitHubPR(client, cfg.Upstream, cfg.ForkOrg, cfg.ForkDB, branch, io.Discard)
+		},
+		push:    func() error { return db.PushWithSync(stdout) },
+		confirm: newStdinConfirm(os.Stdin, stdout),
+	}
+	if noPush {
+		deps.push = nil
+	}
+	if yes {
+		deps.confirm = func(string) bool { return true }
+	}
+	return mergeAllApproved(stdout, dir, deps)
+}
+
+// mergeAllApproved shows the candidate branches and merges the ready ones
+// sequentially after confirmation. Merging continues past individual
+// failures; main is pushed once at the end if anything was merged.
+func mergeAllApproved(stdout io.Writer, dir string, deps *mergeAllDeps) error {
+	candidates, err := findMergeCandidates(dir, deps)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		fmt.Fprintln(stdout, "No review branches found.")
+		return nil
+	}
+
+	var ready []string
+	tbl := style.NewTable(
+		style.Column{Name: "BRANCH", Width: 30},
+		style.Column{Name: "REVIEW", Width: 18},
+		style.Column{Name: "DELTA", Width: 14},
+		style.Column{Name: "ACTION", Width: 6},
+	)
+	for _, c := range candidates {
+		mark := "skip"
+		if c.Ready {
+			mark = "merge"
+			ready = append(ready, c.Branch)
+		}
+		tbl.AddRow(c.Branch, c.Review, c.Delta, mark)
+	}
+	fmt.Fprint(stdout, tbl.Render())
+
+	if len(ready) == 0 {
+		fmt.Fprintf(stdout, "\nNo approved branches ready to merge.\n")
+		return nil
+	}
+	if !deps.confirm(fmt.Sprintf("Merge %d approved branch(es) into main?", len(ready))) {
+		fmt.Fprintln(stdout, "Aborted.")
+		return nil
+	}
+
+	fmt.Fprintln(stdout)
+	failed := 0
+	for _, branch := range ready {
+		if err := mergeOne(dir, deps, branch); err != nil {
+			failed++
+			fmt.Fprintf(stdout, "  %s %s: %v\n", style.Error.Render(style.IconFail), branch, err)
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s merged %s\n", style.Success.Render(style.IconPass), branch)
+		deps.cleanup(branch)
+	}
+
+	if failed < len(ready) && deps.push != nil {
+		if err := deps.push(); err != nil {
+			fmt.Fprintf(stdout, "\n  %s %s\n", style.Warning.Render(style.IconWarn),
+				"Push failed — merges saved locally. Run 'wl sync' to retry.")
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d branch(es) could not be merged", failed, len(ready))
+	}
+	return nil
+}
+
+// mergeOne re-checks branch for conflicts against the current main, which
+// earlier merges in the batch may have moved, and merges it.
+func mergeOne(dir string, deps *mergeAllDeps, branch string) error {
+	conflicts, err := previewMergeConflicts(deps.doltQuery, dir, branch)
+	if err != nil {
+		return fmt.Errorf("previewing merge: %w", err)
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%d conflicting row(s) with main; skipped", len(conflicts))
+	}
+	return deps.merge(branch)
+}
+
+// findMergeCandidates classifies every wl/* branch by PR review state and
+// delta against main. A branch is ready when its PR is approved with no
+// outstanding change requests and it has changes that merge cleanly.
+func findMergeCandidates(dir string, deps *mergeAllDeps) ([]mergeCandidate, error) {
+	branches, err := deps.listBranches()
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+
+	candidates := make([]mergeCandidate, 0, len(branches))
+	for _, branch := range branches {
+		c := mergeCandidate{Branch: branch}
+		approved, changesRequested := deps.approval(branch)
+		switch {
+		case changesRequested:
+			c.Review = "changes requested"
+		case approved:
+			c.Review = "approved"
+		default:
+			c.Review = "no approval"
+		}
+
+		out, err := deps.doltQuery(dir, fmt.Sprintf(
+			"SELECT table_name FROM dolt_diff_summary('main...%s')", commons.EscapeSQL(branch)))
+		if err != nil {
+			c.Delta = "unknown"
+			candidates = append(candidates, c)
+			continue
+		}
+		tables := len(wlParseCSV(out)) - 1
+		if tables <= 0 {
+			c.Delta = "no changes"
+			candidates = append(candidates, c)
+			continue
+		}
+		c.Delta = fmt.Sprintf("%d table(s)", tables)
+
+		if c.Review == "approved" {
+			conflicts, err := previewMergeConflicts(deps.doltQuery, dir, branch)
+			switch {
+			case err != nil:
+				c.Delta = "unknown"
+			case len(conflicts) > 0:
+				c.Delta = fmt.Sprintf("%d conflict(s)", len(conflicts))
+			default:
+				c.Ready = true
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
 // mergeApprovalWarning returns a warning message based on PR approval state.
 // Returns "" if the PR is approved with no outstanding change requests.
 func mergeApprovalWarning(hasApproval, hasChangesRequested bool) string {
@@ -147,3 +390,118 @@ func mergeApprovalWarning(hasApproval, hasChangesRequested bool) string {
 	}
 	return ""
 }
+
+// mergeConflict is a row that main and a branch changed differently.
+type mergeConflict struct {
+	Table  string
+	Key    string          // row identifier, e.g. the wanted ID
+	Fields []conflictField // columns whose values differ; nil for schema conflicts
+}
+
+// conflictField is one column of a conflicting row.
+type conflictField struct {
+	Column, Base, Ours, Theirs string
+}
+
+// previewMergeConflicts runs a trial merge of branch into main using dolt's
+// preview table functions and returns the conflicting rows. Nothing is
+// merged and the working set is not touched.
+func previewMergeConflicts(doltQuery func(dir, query string) (string, error), dir, branch string) ([]mergeConflict, error) {
+	esc := commons.EscapeSQL(branch)
+	out, err := doltQuery(dir, fmt.Sprintf(
+		"SELECT * FROM dolt_preview_merge_conflicts_summary('main', '%s')", esc))
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []mergeConflict
+	rows := wlParseCSV(out)
+	for _, row := range csvMaps(rows) {
+		table := row["table"]
+		if row["num_schema_conflicts"] != "" && row["num_schema_conflicts"] != "0" {
+			conflicts = append(conflicts, mergeConflict{Table: table, Key: "(schema)"})
+		}
+		if row["num_data_conflicts"] == "" || row["num_data_conflicts"] == "0" {
+			continue
+		}
+		out, err := doltQuery(dir, fmt.Sprintf(
+			"SELECT * FROM dolt_preview_merge_conflicts('main', '%s', '%s')", esc, commons.EscapeSQL(table)))
+		if err != nil {
+			return nil, fmt.Errorf("previewing conflicts in %s: %w", table, err)
+		}
+		conflicts = append(conflicts, parseConflictRows(table, wlParseCSV(out))...)
+	}
+	return conflicts, nil
+}
+
+// csvMaps turns parsed CSV rows (header first) into column→value maps.
+func csvMaps(rows [][]string) []map[string]string {
+	if len(rows) < 2 {
+		return nil
+	}
+	out := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		m := make(map[string]string, len(rows[0]))
+		for i, col := range rows[0] {
+			if i < len(row) {
+				m[col] = row[i]
+			}
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// parseConflictRows converts dolt_preview_merge_conflicts output, which has
+// base_<col>, our_<col> and their_<col> for each column, into conflicts
+// listing only the columns whose values differ.
+func parseConflictRows(table string, rows [][]string) []mergeConflict {
+	if len(rows) == 0 {
+		return nil
+	}
+	var columns []string
+	for _, col := range rows[0] {
+		if name, ok := strings.CutPrefix(col, "our_"); ok && name != "diff_type" {
+			columns = append(columns, name)
+		}
+	}
+
+	var out []mergeConflict
+	for _, m := range csvMaps(rows) {
+		c := mergeConflict{Table: table}
+		for _, key := range []string{"id", "handle"} {
+			for _, side := range []string{"our_", "their_", "base_"} {
+				if c.Key == "" {
+					c.Key = m[side+key]
+				}
+			}
+		}
+		for _, col := range columns {
+			f := conflictField{Column: col, Base: m["base_"+col], Ours: m["our_"+col], Theirs: m["their_"+col]}
+			if m["our_diff_type"] == "removed" {
+				f.Ours = "(deleted)"
+			}
+			if m["their_diff_type"] == "removed" {
+				f.Theirs = "(deleted)"
+			}
+			if f.Ours != f.Theirs {
+				c.Fields = append(c.Fields, f)
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func renderMergeConflicts(w io.Writer, branch string, conflicts []mergeConflict) {
+	fmt.Fprintf(w, "%s Merging %s into main would conflict on %d row(s):\n",
+		style.Error.Render(style.IconFail), branch, len(conflicts))
+	for _, c := range conflicts {
+		fmt.Fprintf(w, "\n  %s %s\n", style.Bold.Render(c.Table), c.Key)
+		for _, f := range c.Fields {
+			fmt.Fprintf(w, "    %-14s %s %s  %s %s  %s %s\n", f.Column,
+				style.Dim.Render("base:"), f.Base, style.Dim.Render("main:"), f.Ours, style.Dim.Render("branch:"), f.Theirs)
+		}
+	}
+	fmt.Fprintf(w, "\nMain is unchanged. Ask the author to update the branch from main, or discard it.\n")
+}
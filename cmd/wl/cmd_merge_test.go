@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -59,3 +61,217 @@ func TestMergeRequiresArg(t *testing.T) {
 	}
 	t.Fatal("merge command not found")
 }
+
+func TestPreviewMergeConflicts(t *testing.T) {
+	t.Parallel()
+	var queries []string
+	doltQuery := func(_, query string) (string, error) {
+		queries = append(queries, query)
+		switch {
+		case strings.Contains(query, "dolt_preview_merge_conflicts_summary"):
+			return "table,num_data_conflicts,num_schema_conflicts\nwanted,1,0\nbadges,0,1\n", nil
+		case strings.Contains(query, "'wanted')"):
+			return "from_root_ish,base_id,base_status,base_title,our_id,our_status,our_title,our_diff_type,their_id,their_status,their_title,their_diff_type,dolt_conflict_id\n" +
+				"abc,w-1,open,Fix,w-1,claimed,Fix,modified,w-1,withdrawn,Fix,modified,c1\n", nil
+		}
+		return "", fmt.Errorf("unexpected query: %s", query)
+	}
+
+	conflicts, err := previewMergeConflicts(doltQuery, "/tmp/db", "wl/bob/w-1")
+	if err != nil {
+		t.Fatalf("previewMergeConflicts: %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want 2", conflicts)
+	}
+	if c := conflicts[1]; c.Table != "badges" || c.Key != "(schema)" {
+		t.Errorf("conflicts[1] = %+v, want badges schema conflict", c)
+	}
+	c := conflicts[0]
+	if c.Table != "wanted" || c.Key != "w-1" {
+		t.Errorf("conflicts[0] = %+v, want wanted w-1", c)
+	}
+	want := conflictField{Column: "status", Base: "open", Ours: "claimed", Theirs: "withdrawn"}
+	if len(c.Fields) != 1 || c.Fields[0] != want {
+		t.Errorf("fields = %+v, want [%+v]", c.Fields, want)
+	}
+	if !strings.Contains(queries[0], "'main', 'wl/bob/w-1'") {
+		t.Errorf("summary query = %s", queries[0])
+	}
+}
+
+func TestPreviewMergeConflicts_Clean(t *testing.T) {
+	t.Parallel()
+	doltQuery := func(_, _ string) (string, error) {
+		return "table,num_data_conflicts,num_schema_conflicts\n", nil
+	}
+	conflicts, err := previewMergeConflicts(doltQuery, "/tmp/db", "wl/bob/w-1")
+	if err != nil || len(conflicts) != 0 {
+		t.Errorf("conflicts = %+v, err = %v, want none", conflicts, err)
+	}
+}
+
+func TestParseConflictRows_Deleted(t *testing.T) {
+	t.Parallel()
+	rows := wlParseCSV("base_id,base_title,our_id,our_title,our_diff_type,their_id,their_title,their_diff_type\n" +
+		"w-2,Old,w-2,New,modified,,,removed\n")
+	conflicts := parseConflictRows("wanted", rows)
+	if len(conflicts) != 1 || conflicts[0].Key != "w-2" {
+		t.Fatalf("conflicts = %+v", conflicts)
+	}
+	var theirs []string
+	for _, f := range conflicts[0].Fields {
+		theirs = append(theirs, f.Column+"="+f.Theirs)
+	}
+	if got := strings.Join(theirs, ","); got != "id=(deleted),title=(deleted)" {
+		t.Errorf("their values = %s", got)
+	}
+}
+
+func TestRenderMergeConflicts(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderMergeConflicts(&buf, "wl/bob/w-1", []mergeConflict{{
+		Table:  "wanted",
+		Key:    "w-1",
+		Fields: []conflictField{{Column: "status", Base: "open", Ours: "claimed", Theirs: "withdrawn"}},
+	}})
+	out := buf.String()
+	for _, want := range []string{"wl/bob/w-1", "1 row(s)", "wanted", "w-1", "status", "claimed", "withdrawn", "Main is unchanged"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// fakeMergeAllDeps returns mergeAllDeps over four branches: w-1 approved
+// and clean, w-2 approved but conflicting, w-3 with changes requested and
+// w-4 approved but empty. Merges, cleanups and pushes are recorded.
+func fakeMergeAllDeps(confirm bool) (*mergeAllDeps, *[]string) {
+	var calls []string
+	deps := &mergeAllDeps{
+		listBranches: func() ([]string, error) {
+			return []string{"wl/alice/w-1", "wl/bob/w-2", "wl/carol/w-3", "wl/dave/w-4"}, nil
+		},
+		approval: func(branch string) (bool, bool) {
+			switch branch {
+			case "wl/alice/w-1", "wl/bob/w-2", "wl/dave/w-4":
+				return true, false
+			}
+			return false, true
+		},
+		doltQuery: func(_, query string) (string, error) {
+			switch {
+			case strings.Contains(query, "'main...wl/dave/w-4'"):
+				return "table_name\n", nil
+			case strings.Contains(query, "dolt_diff_summary"):
+				return "table_name\nwanted\n", nil
+			case strings.Contains(query, "conflicts_summary('main', 'wl/bob/w-2')"):
+				return "table,num_data_conflicts,num_schema_conflicts\nwanted,1,0\n", nil
+			case strings.Contains(query, "dolt_preview_merge_conflicts('main', 'wl/bob/w-2'"):
+				return "base_id,our_id,our_diff_type,their_id,their_diff_type\nw-2,w-2,modified,w-2,removed\n", nil
+			case strings.Contains(query, "conflicts_summary"):
+				return "table,num_data_conflicts,num_schema_conflicts\n", nil
+			}
+			return "", fmt.Errorf("unexpected query: %s", query)
+		},
+		merge: func(branch string) error {
+			calls = append(calls, "merge:"+branch)
+			return nil
+		},
+		cleanup: func(branch string) { calls = append(calls, "cleanup:"+branch) },
+		push: func() error {
+			calls = append(calls, "push")
+			return nil
+		},
+		confirm: func(string) bool { return confirm },
+	}
+	return deps, &calls
+}
+
+func TestFindMergeCandidates(t *testing.T) {
+	t.Parallel()
+	deps, _ := fakeMergeAllDeps(true)
+	candidates, err := findMergeCandidates("/tmp/db", deps)
+	if err != nil {
+		t.Fatalf("findMergeCandidates: %v", err)
+	}
+	want := []mergeCandidate{
+		{Branch: "wl/alice/w-1", Review: "approved", Delta: "1 table(s)", Ready: true},
+		{Branch: "wl/bob/w-2", Review: "approved", Delta: "1 conflict(s)"},
+		{Branch: "wl/carol/w-3", Review: "changes requested", Delta: "1 table(s)"},
+		{Branch: "wl/dave/w-4", Review: "approved", Delta: "no changes"},
+	}
+	if len(candidates) != len(want) {
+		t.Fatalf("candidates = %+v, want %+v", candidates, want)
+	}
+	for i := range want {
+		if candidates[i] != want[i] {
+			t.Errorf("candidates[%d] = %+v, want %+v", i, candidates[i], want[i])
+		}
+	}
+}
+
+func TestMergeAllApproved(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeMergeAllDeps(true)
+
+	var stdout bytes.Buffer
+	if err := mergeAllApproved(&stdout, "/tmp/db", deps); err != nil {
+		t.Fatalf("mergeAllApproved: %v", err)
+	}
+	if got := strings.Join(*calls, ","); got != "merge:wl/alice/w-1,cleanup:wl/alice/w-1,push" {
+		t.Errorf("calls = %s", got)
+	}
+	if !strings.Contains(stdout.String(), "merged wl/alice/w-1") || !strings.Contains(stdout.String(), "changes requested") {
+		t.Errorf("unexpected output:\n%s", stdout.String())
+	}
+}
+
+func TestMergeAllApproved_Declined(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeMergeAllDeps(false)
+
+	var stdout bytes.Buffer
+	if err := mergeAllApproved(&stdout, "/tmp/db", deps); err != nil {
+		t.Fatalf("mergeAllApproved: %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("declined batch made calls %v", *calls)
+	}
+	if !strings.Contains(stdout.String(), "Aborted.") {
+		t.Errorf("expected Aborted, got:\n%s", stdout.String())
+	}
+}
+
+func TestMergeAllApproved_MergeFailure(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeMergeAllDeps(true)
+	deps.merge = func(string) error { return fmt.Errorf("merge failed") }
+
+	var stdout bytes.Buffer
+	err := mergeAllApproved(&stdout, "/tmp/db", deps)
+	if err == nil || !strings.Contains(err.Error(), "1 of 1") {
+		t.Fatalf("err = %v, want 1 of 1 failure", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("failed batch should not clean up or push, got %v", *calls)
+	}
+	if !strings.Contains(stdout.String(), "merge failed") {
+		t.Errorf("output missing failure:\n%s", stdout.String())
+	}
+}
+
+func TestMergeArgsWithAllApproved(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := newMergeCmd(&stdout, &stderr)
+	if err := cmd.Flags().Set("all-approved", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Args(cmd, []string{}); err != nil {
+		t.Errorf("--all-approved should accept no arguments: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"wl/rig/w-abc"}); err == nil {
+		t.Error("--all-approved should reject a branch argument")
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/notify"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/gastownhall/wasteland/internal/xdg"
+	"github.com/spf13/cobra"
+)
+
+func newNotifyCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		daemon    bool
+		noDesktop bool
+		interval  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Report state changes of watched items and your claims and posts",
+		Long: `Check the items you follow — those added with 'wl watch', plus the open,
+claimed and in-review items you posted or claimed — and report each that
+changed status or claimant since the last check, as a line of output and
+a desktop notification (notify-send on Linux, osascript on macOS, a toast
+on Windows).
+
+The first check records a baseline. With --daemon, checks repeat every
+--interval until interrupted. The TUI can pop the same notifications:
+'wl config set desktop-notify true'.
+
+EXAMPLES:
+  wl notify                     # Check once, e.g. from cron
+  wl notify --daemon            # Keep checking every minute
+  wl notify --daemon --interval 5m --no-desktop`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if interval < 10*time.Second {
+				return fmt.Errorf("--interval must be at least 10s")
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runNotify(ctx, cmd, stdout, stderr, daemon, !noDesktop, interval)
+		},
+	}
+
+	cmd.Flags().BoolVar(&daemon, "daemon", false, "Keep checking until interrupted")
+	cmd.Flags().BoolVar(&noDesktop, "no-desktop", false, "Print changes without desktop notifications")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "Time between checks with --daemon")
+
+	return cmd
+}
+
+// notifySnapshotPath is where the last-seen state of cfg's followed items
+// is kept between checks.
+func notifySnapshotPath(cfg *federation.Config) string {
+	return filepath.Join(xdg.DataDir(), "notify", cfg.Upstream+".json")
+}
+
+// newNotifyTracker follows cfg's watched items and the rig's own, reading
+// them with fetch (see sdk.Client.Followed).
+func newNotifyTracker(cfg *federation.Config, fetch func(ids []string) ([]commons.WantedSummary, error)) *notify.Tracker {
+	return &notify.Tracker{
+		Path:    notifySnapshotPath(cfg),
+		Rig:     cfg.RigHandle,
+		Watched: cfg.Watched,
+		Fetch:   fetch,
+	}
+}
+
+// desktopNotify is the desktop notification sender.
+// Package-level variable to allow test overrides.
+var desktopNotify = notify.Desktop
+
+func runNotify(ctx context.Context, cmd *cobra.Command, stdout, stderr io.Writer, daemon, desktop bool, interval time.Duration) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return err
+		}
+	}
+	client, err := newSDKClient(cfg, true)
+	if err != nil {
+		return err
+	}
+	tracker := newNotifyTracker(cfg, client.Followed)
+
+	check := func() error {
+		err := notifyOnce(ctx, stdout, stderr, cfg.Upstream, tracker, desktop, daemon)
+		if err != nil && daemon {
+			// A flaky remote shouldn't end the daemon; try again next tick.
+			fmt.Fprintf(stderr, "%s %s: %v\n", style.Warning.Render(style.IconWarn), time.Now().Format(time.TimeOnly), err)
+			return nil
+		}
+		return err
+	}
+	if !daemon {
+		return check()
+	}
+	fmt.Fprintf(stdout, "Watching %s every %s (Ctrl-C to stop)\n", cfg.Upstream, interval)
+	return watchLoop(ctx, interval, check)
+}
+
+// notifyOnce runs one check, printing each change and sending it to the
+// desktop when asked. Quiet suppresses the "no changes" line, for the
+// daemon.
+func notifyOnce(ctx context.Context, stdout, stderr io.Writer, upstream string, t *notify.Tracker, desktop, quiet bool) error {
+	changes, err := t.Check()
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 && !quiet {
+		fmt.Fprintln(stdout, "No changes since the last check.")
+	}
+	for _, c := range changes {
+		fmt.Fprintf(stdout, "%s %s\n", style.Dim.Render(time.Now().Format(time.TimeOnly)), c)
+		if !desktop {
+			continue
+		}
+		if err := desktopNotify(ctx, "wasteland: "+upstream, c.String()); err != nil {
+			fmt.Fprintf(stderr, "%s %v\n", style.Warning.Render(style.IconWarn), err)
+		}
+	}
+	return nil
+}
+
+// tuiCheckNotify returns the TUI's periodic check when cfg enables
+// desktop-notify, or nil. Each change pops a desktop notification; the
+// descriptions go back to the TUI's status bar.
+func tuiCheckNotify(cfg *federation.Config, fetch func(ids []string) ([]commons.WantedSummary, error)) func() ([]string, error) {
+	if !cfg.DesktopNotify {
+		return nil
+	}
+	tracker := newNotifyTracker(cfg, fetch)
+	return func() ([]string, error) {
+		changes, err := tracker.Check()
+		if err != nil {
+			return nil, err
+		}
+		out := make([]string, 0, len(changes))
+		for _, c := range changes {
+			out = append(out, c.String())
+			if err := desktopNotify(context.Background(), "wasteland: "+cfg.Upstream, c.String()); err != nil {
+				slog.Debug("desktop notification failed", "error", err)
+			}
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/notify"
+)
+
+func stubDesktopNotify(t *testing.T) *[]string {
+	t.Helper()
+	var sent []string
+	orig := desktopNotify
+	desktopNotify = func(_ context.Context, title, message string) error {
+		sent = append(sent, title+": "+message)
+		return nil
+	}
+	t.Cleanup(func() { desktopNotify = orig })
+	return &sent
+}
+
+func TestNotifyOnce(t *testing.T) {
+	sent := stubDesktopNotify(t)
+	status := "claimed"
+	tracker := &notify.Tracker{
+		Path: filepath.Join(t.TempDir(), "snap.json"),
+		Rig:  "alice",
+		Fetch: func([]string) ([]commons.WantedSummary, error) {
+			return []commons.WantedSummary{{ID: "w-1", Title: "Fix docs", Status: status, ClaimedBy: "alice"}}, nil
+		},
+	}
+	var stdout, stderr bytes.Buffer
+
+	if err := notifyOnce(context.Background(), &stdout, &stderr, "hop/wl-commons", tracker, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "No changes") || len(*sent) != 0 {
+		t.Fatalf("baseline check: output %q, sent %v", stdout.String(), *sent)
+	}
+
+	status = "in_review"
+	stdout.Reset()
+	if err := notifyOnce(context.Background(), &stdout, &stderr, "hop/wl-commons", tracker, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), `w-1 "Fix docs": claimed → in_review`) {
+		t.Errorf("output = %q", stdout.String())
+	}
+	want := []string{`wasteland: hop/wl-commons: w-1 "Fix docs": claimed → in_review`}
+	if !slices.Equal(*sent, want) {
+		t.Errorf("sent %v, want %v", *sent, want)
+	}
+}
+
+func TestNotifyOnce_NoDesktop(t *testing.T) {
+	sent := stubDesktopNotify(t)
+	status := "open"
+	tracker := &notify.Tracker{
+		Path: filepath.Join(t.TempDir(), "snap.json"),
+		Rig:  "alice",
+		Fetch: func([]string) ([]commons.WantedSummary, error) {
+			return []commons.WantedSummary{{ID: "w-1", Title: "T", Status: status, PostedBy: "alice"}}, nil
+		},
+	}
+	var stdout bytes.Buffer
+	_ = notifyOnce(context.Background(), &stdout, &stdout, "hop/wl-commons", tracker, false, true)
+	status = "claimed"
+	if err := notifyOnce(context.Background(), &stdout, &stdout, "hop/wl-commons", tracker, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(*sent) != 0 {
+		t.Errorf("sent %v with desktop off", *sent)
+	}
+	if strings.Contains(stdout.String(), "No changes") {
+		t.Errorf("quiet check printed the no-changes line: %q", stdout.String())
+	}
+}
+
+func TestTUICheckNotify_Disabled(t *testing.T) {
+	t.Parallel()
+	if tuiCheckNotify(&federation.Config{}, nil) != nil {
+		t.Error("check returned with desktop-notify off")
+	}
+}
+
+func TestApplyWatch(t *testing.T) {
+	t.Parallel()
+	got := applyWatch([]string{"w-2"}, []string{"w-3", "w-1", "w-2"}, false)
+	if want := []string{"w-1", "w-2", "w-3"}; !slices.Equal(got, want) {
+		t.Errorf("add = %v, want %v", got, want)
+	}
+	got = applyWatch(got, []string{"w-2", "w-9"}, true)
+	if want := []string{"w-1", "w-3"}; !slices.Equal(got, want) {
+		t.Errorf("remove = %v, want %v", got, want)
+	}
+	if got := applyWatch([]string{"w-1"}, []string{"w-1"}, true); got != nil {
+		t.Errorf("removing the last = %v, want nil", got)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"io"
 	"strings"
 
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
@@ -19,7 +22,10 @@ func newPostCmd(stdout, stderr io.Writer) *cobra.Command {
 		priority    int
 		effort      string
 		tags        string
+		fromIssue   string
 		noPush      bool
+		all         bool
+		editor      bool
 	)
 
 	cmd := &cobra.Command{
@@ -31,15 +37,40 @@ Creates a wanted item with a unique w-<hash> ID and inserts it into the
 fork clone of the commons database. In wild-west mode the commit is
 auto-pushed to upstream (canonical) and origin (fork).
 
+Tags are rewritten to the wasteland's canonical forms (see 'wl tags');
+in a strict wasteland, unregistered tags are rejected.
+
+Use --from-github-issue to mirror a GitHub issue: its title, body and
+labels become the item's title, description and tags, the description
+links back to the issue, and a bug/enhancement/documentation label sets
+the type. Fetching the issue needs the gh CLI. Any of --title,
+--description, --tags or --type given alongside override the issue.
+
+Repeat --wasteland, or pass --all, to post the same item to several
+joined wastelands at once. Each gets its own ID; wl remembers them as
+siblings so 'wl update --siblings' and 'wl delete --siblings' can change
+them all together.
+
+Use --editor to write the item in $VISUAL or $EDITOR (vi by default):
+the fields are YAML front matter, already filled in from any flags, and
+the description is the markdown below it. The draft is checked against
+the wasteland's types and effort levels when you save; a draft with
+problems is reopened with the error at the top, and clearing the title
+aborts.
+
 Use --no-push to skip pushing (offline work).
 
 Examples:
   wl post --title "Fix auth bug" --project gastown --type bug
   wl post --title "Add federation sync" --type feature --priority 1 --effort large
   wl post --title "Update docs" --tags "docs,federation" --effort small
-  wl post --title "Offline item" --no-push`,
+  wl post --title "Offline item" --no-push
+  wl post --from-github-issue https://github.com/org/repo/issues/42 --project gastown
+  wl post --title "Audit TLS config" --wasteland hop/wl-commons --wasteland acme/wl
+  wl post --title "Adopt the new schema" --all
+  wl post --editor --project gastown`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runPost(cmd, stdout, stderr, title, description, project, itemType, priority, effort, tags, noPush)
+			return runPost(cmd, stdout, stderr, title, description, project, itemType, priority, effort, tags, fromIssue, noPush, all, editor)
 		},
 	}
 
@@ -54,25 +85,36 @@ Examples:
 	cmd.Flags().IntVar(&priority, "priority", 2, "Priority: 0=critical, 1=high, 2=medium, 3=low, 4=backlog")
 	cmd.Flags().StringVar(&effort, "effort", "medium", "Effort level: trivial, small, medium, large, epic")
 	cmd.Flags().StringVar(&tags, "tags", "", "Comma-separated tags (e.g., 'go,auth,federation')")
+	cmd.Flags().StringVar(&fromIssue, "from-github-issue", "", "Mirror a GitHub issue URL as the item (title, body, labels)")
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
+	cmd.Flags().BoolVar(&all, "all", false, "Post to every joined wasteland")
+	cmd.Flags().BoolVar(&editor, "editor", false, "Write the item in $EDITOR (fields as front matter, description as markdown)")
 
-	_ = cmd.MarkFlagRequired("title")
+	cmd.MarkFlagsOneRequired("title", "from-github-issue", "editor")
 	_ = cmd.RegisterFlagCompletionFunc("project", completeProjectNames)
-	_ = cmd.RegisterFlagCompletionFunc("type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		types := []string{"feature", "bug", "design", "rfc", "docs"}
-		if inferGateEnabled() {
-			types = append(types, "inference")
-		}
-		return types, cobra.ShellCompDirectiveNoFileComp
-	})
-	_ = cmd.RegisterFlagCompletionFunc("effort", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{"trivial", "small", "medium", "large", "epic"}, cobra.ShellCompDirectiveNoFileComp
-	})
+	_ = cmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	_ = cmd.RegisterFlagCompletionFunc("type", completeItemTypes)
+	_ = cmd.RegisterFlagCompletionFunc("effort", completeEffortLevels)
 
 	return cmd
 }
 
-func runPost(cmd *cobra.Command, stdout, _ io.Writer, title, description, project, itemType string, priority int, effort, tags string, noPush bool) error {
+func runPost(cmd *cobra.Command, stdout, _ io.Writer, title, description, project, itemType string, priority int, effort, tags, fromIssue string, noPush, all, editor bool) error {
+	var issue *githubIssue
+	if fromIssue != "" {
+		var err error
+		issue, err = fetchGitHubIssue(fromIssue)
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			title = issue.Title
+		}
+		if description == "" {
+			description = issueDescription(issue)
+		}
+	}
+
 	var tagList []string
 	if tags != "" {
 		for _, t := range strings.Split(tags, ",") {
@@ -81,23 +123,19 @@ func runPost(cmd *cobra.Command, stdout, _ io.Writer, title, description, projec
 				tagList = append(tagList, t)
 			}
 		}
+	} else if issue != nil {
+		tagList = issueTags(issue)
 	}
 
-	if err := validatePostInputs(itemType, effort, priority); err != nil {
+	if err := validatePriority(priority); err != nil {
 		return err
 	}
 
-	wlCfg, err := resolveWasteland(cmd)
-	if err != nil {
-		return hintWrap(err)
-	}
-
-	client, err := newSDKClient(wlCfg, noPush)
+	cfgs, err := fanoutTargets(cmd, federation.NewConfigStore(), all)
 	if err != nil {
 		return err
 	}
-
-	result, err := client.Post(sdk.PostInput{
+	input := sdk.PostInput{
 		Title:       title,
 		Description: description,
 		Project:     project,
@@ -105,18 +143,38 @@ func runPost(cmd *cobra.Command, stdout, _ io.Writer, title, description, projec
 		Priority:    priority,
 		EffortLevel: effort,
 		Tags:        tagList,
-	})
+	}
+	if editor {
+		if input, err = editPostInput(cmd, cfgs, input, noPush); err != nil {
+			return err
+		}
+		title, project, priority, tagList = input.Title, input.Project, input.Priority, input.Tags
+	}
+	if len(cfgs) > 1 {
+		return runPostFanout(cmd, stdout, cfgs, input, issue, noPush)
+	}
+	wlCfg := cfgs[0]
+
+	target, err := preparePost(cmd, wlCfg, input, issue, noPush)
+	if err != nil {
+		return err
+	}
+	itemType, effort = target.input.Type, target.input.EffortLevel
+	result, err := target.client.Post(target.input)
 	if err != nil {
 		return err
 	}
 
-	itemID := ""
+	itemID := result.WantedID
 	if result.Detail != nil && result.Detail.Item != nil {
 		itemID = result.Detail.Item.ID
 	}
 
 	fmt.Fprintf(stdout, "%s Posted wanted item: %s\n", style.Bold.Render("✓"), style.Bold.Render(itemID))
 	fmt.Fprintf(stdout, "  Title:    %s\n", title)
+	if issue != nil {
+		fmt.Fprintf(stdout, "  Issue:    %s\n", issue.URL)
+	}
 	if project != "" {
 		fmt.Fprintf(stdout, "  Project:  %s\n", project)
 	}
@@ -124,7 +182,9 @@ func runPost(cmd *cobra.Command, stdout, _ io.Writer, title, description, projec
 		fmt.Fprintf(stdout, "  Type:     %s\n", itemType)
 	}
 	fmt.Fprintf(stdout, "  Priority: %d\n", priority)
-	fmt.Fprintf(stdout, "  Effort:   %s\n", effort)
+	if effort != "" {
+		fmt.Fprintf(stdout, "  Effort:   %s\n", effort)
+	}
 	if len(tagList) > 0 {
 		fmt.Fprintf(stdout, "  Tags:     %s\n", strings.Join(tagList, ", "))
 	}
@@ -139,35 +199,164 @@ func runPost(cmd *cobra.Command, stdout, _ io.Writer, title, description, projec
 		fmt.Fprintf(stdout, "\n  %s\n", style.Dim.Render(result.Hint))
 	}
 
-	printNextHint(stdout, "Next: others can claim this. Browse: wl browse")
+	printNextHint(stdout, i18n.T("next.post"))
 
 	return nil
 }
 
-// validatePostInputs validates the type, effort, and priority fields.
-func validatePostInputs(itemType, effort string, priority int) error {
-	validTypes := map[string]bool{
-		"feature": true, "bug": true, "design": true, "rfc": true, "docs": true,
+// postTarget is one wasteland a post goes to, with the input fitted to
+// its vocabulary.
+type postTarget struct {
+	cfg    *federation.Config
+	client *sdk.Client
+	input  sdk.PostInput
+}
+
+// preparePost connects to cfg's wasteland and fits input to its
+// vocabulary: a default effort it doesn't know is dropped, and an issue's
+// labels pick the type. The fitted input is validated.
+func preparePost(cmd *cobra.Command, cfg *federation.Config, input sdk.PostInput, issue *githubIssue, noPush bool) (*postTarget, error) {
+	client, err := newSDKClient(cfg, noPush)
+	if err != nil {
+		return nil, err
 	}
-	validTypeNames := "feature, bug, design, rfc, docs"
-	if inferGateEnabled() {
-		validTypes["inference"] = true
-		validTypeNames += ", inference"
+
+	vocab := cliVocabulary(client.Vocabulary())
+	// A wasteland whose effort levels don't include the flag default gets
+	// no effort level unless one is given explicitly.
+	if !cmd.Flags().Changed("effort") && !vocab.ValidEffort(input.EffortLevel) {
+		input.EffortLevel = ""
+	}
+	if issue != nil && input.Type == "" {
+		input.Type = issueType(vocab, issue)
 	}
-	if itemType != "" && !validTypes[itemType] {
-		return fmt.Errorf("invalid type %q: must be one of %s", itemType, validTypeNames)
+	if err := validatePostInputs(vocab, input.Type, input.EffortLevel, input.Priority); err != nil {
+		return nil, err
 	}
+	return &postTarget{cfg: cfg, client: client, input: input}, nil
+}
 
-	validEfforts := map[string]bool{
-		"trivial": true, "small": true, "medium": true, "large": true, "epic": true,
+// editPostInput has the user write input in their editor, checking the
+// draft against the vocabulary of every wasteland it is going to.
+func editPostInput(cmd *cobra.Command, cfgs []*federation.Config, input sdk.PostInput, noPush bool) (sdk.PostInput, error) {
+	vocabs := make([]*commons.Vocabulary, len(cfgs))
+	for i, cfg := range cfgs {
+		client, err := newSDKClient(cfg, noPush)
+		if err != nil {
+			return input, err
+		}
+		vocabs[i] = cliVocabulary(client.Vocabulary())
 	}
-	if !validEfforts[effort] {
-		return fmt.Errorf("invalid effort %q: must be one of trivial, small, medium, large, epic", effort)
+
+	draft := itemDraft{
+		Title:       input.Title,
+		Project:     input.Project,
+		Type:        input.Type,
+		Priority:    input.Priority,
+		Effort:      input.EffortLevel,
+		Tags:        input.Tags,
+		Description: input.Description,
+	}
+	if !cmd.Flags().Changed("effort") && !vocabs[0].ValidEffort(draft.Effort) {
+		draft.Effort = ""
+	}
+	draft, err := editDraft(draft, draftHints(vocabs[0], "post"), func(d itemDraft) error {
+		for i, vocab := range vocabs {
+			if err := validatePostInputs(vocab, d.Type, d.Effort, d.Priority); err != nil {
+				if len(cfgs) > 1 {
+					return fmt.Errorf("%s: %w", cfgs[i].Upstream, err)
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return input, err
+	}
+	return sdk.PostInput{
+		Title:       draft.Title,
+		Description: draft.Description,
+		Project:     draft.Project,
+		Type:        draft.Type,
+		Priority:    draft.Priority,
+		EffortLevel: draft.Effort,
+		Tags:        draft.Tags,
+	}, nil
+}
+
+// runPostFanout posts input to every wasteland in cfgs and records the
+// new items as siblings, so 'wl update --siblings' and 'wl delete
+// --siblings' can reach them all later. Every wasteland is checked before
+// any is posted to; a post that fails doesn't stop the rest.
+func runPostFanout(cmd *cobra.Command, stdout io.Writer, cfgs []*federation.Config, input sdk.PostInput, issue *githubIssue, noPush bool) error {
+	targets := make([]*postTarget, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		t, err := preparePost(cmd, cfg, input, issue, noPush)
+		if err != nil {
+			return fmt.Errorf("%s: %w", cfg.Upstream, err)
+		}
+		targets = append(targets, t)
+	}
+
+	fmt.Fprintf(stdout, "Posting %q to %d wastelands\n", input.Title, len(targets))
+	group := siblingGroup{}
+	var failed []string
+	for _, t := range targets {
+		result, err := t.client.Post(t.input)
+		if err != nil {
+			fmt.Fprintf(stdout, "  %s %s: %v\n", style.Error.Render(style.IconFail), t.cfg.Upstream, err)
+			failed = append(failed, t.cfg.Upstream)
+			continue
+		}
+		group[t.cfg.Upstream] = result.WantedID
+		fmt.Fprintf(stdout, "  %s %s %s\n", style.Success.Render(style.IconPass), style.Bold.Render(result.WantedID), t.cfg.Upstream)
+		if result.Branch != "" {
+			fmt.Fprintf(stdout, "      Branch: %s\n", result.Branch)
+		}
+		if result.Detail != nil && result.Detail.PRURL != "" {
+			fmt.Fprintf(stdout, "      PR: %s\n", result.Detail.PRURL)
+		}
+	}
+
+	if len(group) > 1 {
+		if err := recordSiblings(siblingsPath(), group); err != nil {
+			return fmt.Errorf("recording sibling items: %w", err)
+		}
+		fmt.Fprintf(stdout, "\n  %s\n", style.Dim.Render("Pass --siblings to 'wl update' or 'wl delete' to change them all together."))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("posting to %s failed", strings.Join(failed, ", "))
 	}
 
+	printNextHint(stdout, i18n.T("next.post"))
+	return nil
+}
+
+// validatePostInputs validates the type, effort, and priority fields
+// against the wasteland's vocabulary. An empty effort is left unset.
+func validatePostInputs(vocab *commons.Vocabulary, itemType, effort string, priority int) error {
+	if err := vocab.CheckType(itemType); err != nil {
+		return err
+	}
+	if err := vocab.CheckEffort(effort); err != nil {
+		return err
+	}
+	return validatePriority(priority)
+}
+
+// validatePriority checks priority is in the 0-4 range.
+func validatePriority(priority int) error {
 	if priority < 0 || priority > 4 {
 		return fmt.Errorf("invalid priority %d: must be 0-4", priority)
 	}
-
 	return nil
 }
+
+// cliVocabulary hides the inference type unless the inference gate is on.
+func cliVocabulary(v *commons.Vocabulary) *commons.Vocabulary {
+	if inferGateEnabled() {
+		return v
+	}
+	return v.Without("inference")
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
 )
 
 // Business logic tests for posting moved to internal/sdk/ (sdk_test.go, lifecycle_test.go).
@@ -9,7 +12,7 @@ import (
 func TestValidatePostInputs_ValidType(t *testing.T) {
 	t.Parallel()
 	for _, typ := range []string{"feature", "bug", "design", "rfc", "docs", "inference", ""} {
-		if err := validatePostInputs(typ, "medium", 2); err != nil {
+		if err := validatePostInputs(commons.DefaultVocabulary(), typ, "medium", 2); err != nil {
 			t.Errorf("validatePostInputs(type=%q) unexpected error: %v", typ, err)
 		}
 	}
@@ -17,7 +20,7 @@ func TestValidatePostInputs_ValidType(t *testing.T) {
 
 func TestValidatePostInputs_InvalidType(t *testing.T) {
 	t.Parallel()
-	err := validatePostInputs("invalid", "medium", 2)
+	err := validatePostInputs(commons.DefaultVocabulary(), "invalid", "medium", 2)
 	if err == nil {
 		t.Error("validatePostInputs(type=invalid) expected error")
 	}
@@ -25,7 +28,7 @@ func TestValidatePostInputs_InvalidType(t *testing.T) {
 
 func TestValidatePostInputs_InvalidEffort(t *testing.T) {
 	t.Parallel()
-	err := validatePostInputs("bug", "huge", 2)
+	err := validatePostInputs(commons.DefaultVocabulary(), "bug", "huge", 2)
 	if err == nil {
 		t.Error("validatePostInputs(effort=huge) expected error")
 	}
@@ -33,15 +36,33 @@ func TestValidatePostInputs_InvalidEffort(t *testing.T) {
 
 func TestValidatePostInputs_PriorityBounds(t *testing.T) {
 	t.Parallel()
-	if err := validatePostInputs("", "medium", -1); err == nil {
+	if err := validatePostInputs(commons.DefaultVocabulary(), "", "medium", -1); err == nil {
 		t.Error("validatePostInputs(priority=-1) expected error")
 	}
-	if err := validatePostInputs("", "medium", 5); err == nil {
+	if err := validatePostInputs(commons.DefaultVocabulary(), "", "medium", 5); err == nil {
 		t.Error("validatePostInputs(priority=5) expected error")
 	}
 	for _, p := range []int{0, 1, 2, 3, 4} {
-		if err := validatePostInputs("", "medium", p); err != nil {
+		if err := validatePostInputs(commons.DefaultVocabulary(), "", "medium", p); err != nil {
 			t.Errorf("validatePostInputs(priority=%d) unexpected error: %v", p, err)
 		}
 	}
 }
+
+func TestValidatePostInputs_CustomVocabulary(t *testing.T) {
+	t.Parallel()
+	vocab := &commons.Vocabulary{Types: []string{"quest", "bug"}, Efforts: []string{"S", "M", "L"}}
+	if err := validatePostInputs(vocab, "quest", "M", 2); err != nil {
+		t.Errorf("custom type and effort: unexpected error: %v", err)
+	}
+	if err := validatePostInputs(vocab, "quest", "", 2); err != nil {
+		t.Errorf("empty effort: unexpected error: %v", err)
+	}
+	err := validatePostInputs(vocab, "feature", "M", 2)
+	if err == nil || !strings.Contains(err.Error(), "quest, bug") {
+		t.Errorf("default type on custom vocabulary: err = %v, want list of accepted types", err)
+	}
+	if err := validatePostInputs(vocab, "bug", "medium", 2); err == nil {
+		t.Error("default effort on custom vocabulary: expected error")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/pile"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
@@ -40,7 +41,7 @@ EXAMPLES:
 	return cmd
 }
 
-func runProfile(_ *cobra.Command, stdout, _ io.Writer, handle string) error {
+func runProfile(cmd *cobra.Command, stdout, _ io.Writer, handle string) error {
 	client := pile.NewDefault()
 
 	sp := style.StartSpinner(stdout, "Fetching profile...")
@@ -50,7 +51,7 @@ func runProfile(_ *cobra.Command, stdout, _ io.Writer, handle string) error {
 		return err
 	}
 	if profile == nil {
-		return fmt.Errorf("profile not found for %q", handle)
+		return &commons.NotFoundError{Message: fmt.Sprintf("profile not found for %q", handle)}
 	}
 
 	// Header
@@ -162,6 +163,8 @@ func runProfile(_ *cobra.Command, stdout, _ io.Writer, handle string) error {
 		fmt.Fprintln(stdout)
 	}
 
+	printProfileBadges(cmd, stdout, profile.Handle)
+
 	// Stats footer
 	fmt.Fprintf(stdout, "Assessments: %d  Total stars: %d  Repos: %d\n",
 		profile.AssessmentCount, profile.TotalStars, profile.TotalRepos)
@@ -169,6 +172,28 @@ func runProfile(_ *cobra.Command, stdout, _ io.Writer, handle string) error {
 	return nil
 }
 
+// printProfileBadges lists the badges handle holds in the active wasteland.
+// Best-effort: without a joined wasteland, or on failure, it prints nothing.
+func printProfileBadges(cmd *cobra.Command, stdout io.Writer, handle string) {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return
+	}
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return
+	}
+	badges, err := commons.QueryBadges(db, handle)
+	if err != nil || len(badges) == 0 {
+		return
+	}
+	fmt.Fprintf(stdout, "%s (%d, in %s)\n", style.Bold.Render("Badges"), len(badges), cfg.Upstream)
+	for _, b := range badges {
+		fmt.Fprintf(stdout, "  %-20s %s  %s\n", b.BadgeType, dateOnly(b.AwardedAt), style.Dim.Render(b.Evidence))
+	}
+	fmt.Fprintln(stdout)
+}
+
 func runProfileSearch(_ *cobra.Command, stdout, _ io.Writer, query string) error {
 	client := pile.NewDefault()
 
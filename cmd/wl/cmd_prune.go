@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newPruneCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		dryRun bool
+		yes    bool
+		apply  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete stale wl/<handle>/* branches locally and on your fork",
+		Long: `Find your wl/<handle>/* branches that are no longer needed and delete
+them from the local clone and from your fork (origin).
+
+A branch is stale when:
+  - it has no changes against main,
+  - its wanted item is completed or withdrawn on main, or
+  - its upstream PR was merged or closed (PR mode).
+
+Branches with an open PR are always kept. The stale branches are listed
+and deleted after confirmation; use --yes to skip the prompt, or
+--dry-run to only list them.
+
+With --apply (wild-west mode), branches that still hold changes are
+first merged into main, after their own confirmation, and then deleted.
+
+EXAMPLES:
+  wl prune --dry-run
+  wl prune
+  wl prune --yes
+  wl prune --apply`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runPrune(cmd, stdout, stderr, dryRun, yes, apply)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List stale branches without deleting them")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete without asking for confirmation")
+	cmd.Flags().BoolVar(&apply, "apply", false, "First merge branches with changes into main (wild-west mode)")
+
+	return cmd
+}
+
+// staleBranch is a wl/* branch that prune would delete.
+type staleBranch struct {
+	Branch string
+	Reason string
+	OnFork bool // branch also exists on origin
+}
+
+// pruneDeps holds the external operations used by prune, so tests can
+// substitute fakes. listBranches and applyAll are only set for --apply.
+type pruneDeps struct {
+	listBranches func() ([]sdk.BranchInfo, error)
+	applyAll     func() ([]sdk.BranchResult, error)
+	fetch        func(dbDir, remote string) error
+	doltQuery    func(dbDir, query string) (string, error)
+	prState      func(cfg *federation.Config, branch string) string
+	deleteLocal  func(dbDir, branch string) error
+	deleteRemote func(dbDir, remote, branch string) error
+	confirm      func(prompt string) bool
+}
+
+func runPrune(cmd *cobra.Command, stdout, _ io.Writer, dryRun, yes, apply bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if !dryRun {
+		if err := requireWritable(cfg, "prune"); err != nil {
+			return err
+		}
+	}
+	if apply && cfg.ResolveMode() != federation.ModeWildWest {
+		return fmt.Errorf("--apply requires wild-west mode; in PR mode submit branches with 'wl review <branch> --create-pr'")
+	}
+	if cfg.LocalDir == "" {
+		return fmt.Errorf("no local clone for %s: prune works on the local clone", cfg.Upstream)
+	}
+	if _, err := os.Stat(cfg.LocalDir); err != nil {
+		return fmt.Errorf("local clone missing (%s): run 'wl doctor --fix'", cfg.LocalDir)
+	}
+	if err := requireDolt(); err != nil {
+		return err
+	}
+
+	db := openLocalClone(cfg)
+	deps := &pruneDeps{
+		fetch:        commons.FetchRemote,
+		doltQuery:    commons.DoltSQLQuery,
+		prState:      prStateForBranch,
+		deleteLocal:  func(_, branch string) error { return db.DeleteBranch(branch) },
+		deleteRemote: func(_, _, branch string) error { return db.DeleteRemoteBranch(branch) },
+		confirm:      newStdinConfirm(os.Stdin, stdout),
+	}
+	if yes {
+		deps.confirm = func(string) bool { return true }
+	}
+	if apply {
+		client, err := newSDKClient(cfg, false)
+		if err != nil {
+			return err
+		}
+		deps.listBranches = client.Branches
+		deps.applyAll = client.ApplyAllBranches
+		if err := applyBranches(stdout, deps, dryRun); err != nil {
+			return err
+		}
+	}
+	return pruneBranches(stdout, cfg, deps, dryRun)
+}
+
+// applyBranches lists the branches with changes to apply and, unless
+// dryRun, merges them into main after confirmation.
+func applyBranches(stdout io.Writer, deps *pruneDeps, dryRun bool) error {
+	branches, err := deps.listBranches()
+	if err != nil {
+		return err
+	}
+	var pending []sdk.BranchInfo
+	for _, b := range branches {
+		if slices.Contains(b.Actions, "apply") {
+			pending = append(pending, b)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Fprintf(stdout, "%s No branches to apply.\n", style.Success.Render(style.IconPass))
+		return nil
+	}
+
+	fmt.Fprintln(stdout, "Branches to apply:")
+	for _, b := range pending {
+		fmt.Fprintf(stdout, "  %-30s %s\n", b.Branch, style.Dim.Render(b.Delta+": "+b.Title))
+	}
+	if dryRun {
+		fmt.Fprintf(stdout, "\nDry run: %d branch(es) would be applied.\n\n", len(pending))
+		return nil
+	}
+	if !deps.confirm(fmt.Sprintf("Apply %d branch(es) into main?", len(pending))) {
+		fmt.Fprintln(stdout, "Skipped applying.")
+		fmt.Fprintln(stdout)
+		return nil
+	}
+
+	results, err := deps.applyAll()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Fprintf(stdout, "  %s %s: %v\n", style.Error.Render(style.IconFail), r.Branch, r.Err)
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s applied %s\n", style.Success.Render(style.IconPass), r.Branch)
+	}
+	fmt.Fprintln(stdout)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d branch(es) could not be applied", failed, len(results))
+	}
+	return nil
+}
+
+// pruneBranches lists the stale branches and, unless dryRun, deletes them
+// after confirmation. Deletion continues past individual failures.
+func pruneBranches(stdout io.Writer, cfg *federation.Config, deps *pruneDeps, dryRun bool) error {
+	stale, err := findStaleBranches(stdout, cfg, deps)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Fprintf(stdout, "%s No stale branches.\n", style.Success.Render(style.IconPass))
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "Stale branches in %s:\n", style.Bold.Render(cfg.Upstream))
+	for _, sb := range stale {
+		where := "local"
+		if sb.OnFork {
+			where = "local, fork"
+		}
+		fmt.Fprintf(stdout, "  %-30s %s\n", sb.Branch, style.Dim.Render(sb.Reason+" ("+where+")"))
+	}
+
+	if dryRun {
+		fmt.Fprintf(stdout, "\nDry run: %d branch(es) would be deleted.\n", len(stale))
+		return nil
+	}
+	if !deps.confirm(fmt.Sprintf("Delete %d branch(es)?", len(stale))) {
+		fmt.Fprintln(stdout, "Aborted.")
+		return nil
+	}
+
+	fmt.Fprintln(stdout)
+	failed := 0
+	for _, sb := range stale {
+		err := deps.deleteLocal(cfg.LocalDir, sb.Branch)
+		if err == nil && sb.OnFork {
+			err = deps.deleteRemote(cfg.LocalDir, "origin", sb.Branch)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(stdout, "  %s %s: %v\n", style.Error.Render(style.IconFail), sb.Branch, err)
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s deleted %s\n", style.Success.Render(style.IconPass), sb.Branch)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d branch(es) could not be deleted", failed, len(stale))
+	}
+	return nil
+}
+
+// findStaleBranches returns the rig's wl/* branches that have no changes
+// against main, whose item is completed or withdrawn on main, or whose PR
+// was merged or closed. Branches with an open PR are never stale.
+func findStaleBranches(stdout io.Writer, cfg *federation.Config, deps *pruneDeps) ([]staleBranch, error) {
+	dir := cfg.LocalDir
+	prefix := "wl/" + cfg.RigHandle + "/"
+
+	onFork := map[string]bool{}
+	if err := deps.fetch(dir, "origin"); err != nil {
+		fmt.Fprintf(stdout, "%s fetching origin: %v\n", style.Warning.Render(style.IconWarn), err)
+	} else {
+		out, err := deps.doltQuery(dir, fmt.Sprintf(
+			"SELECT name FROM dolt_remote_branches WHERE name LIKE '%s%%'",
+			commons.EscapeLIKE("remotes/origin/"+prefix),
+		))
+		if err != nil {
+			return nil, fmt.Errorf("listing fork branches: %w", err)
+		}
+		for i, row := range wlParseCSV(out) {
+			if i == 0 || len(row) == 0 {
+				continue // header
+			}
+			onFork[strings.TrimPrefix(row[0], "remotes/origin/")] = true
+		}
+	}
+
+	out, err := deps.doltQuery(dir, fmt.Sprintf(
+		"SELECT name FROM dolt_branches WHERE name LIKE '%s%%' ORDER BY name",
+		commons.EscapeLIKE(prefix),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+
+	var stale []staleBranch
+	for i, row := range wlParseCSV(out) {
+		if i == 0 || len(row) == 0 || row[0] == "" {
+			continue // header
+		}
+		branch := row[0]
+		reason := staleReason(cfg, deps, branch)
+		if reason != "" {
+			stale = append(stale, staleBranch{Branch: branch, Reason: reason, OnFork: onFork[branch]})
+		}
+	}
+	return stale, nil
+}
+
+// staleReason explains why branch can be pruned, or returns "" to keep it.
+// Branches whose state can't be determined are kept.
+func staleReason(cfg *federation.Config, deps *pruneDeps, branch string) string {
+	if cfg.ResolveMode() == federation.ModePR {
+		switch deps.prState(cfg, branch) {
+		case "open":
+			return ""
+		case "merged":
+			return "PR merged"
+		case "closed":
+			return "PR closed"
+		}
+	}
+
+	dir := cfg.LocalDir
+	diff, err := deps.doltQuery(dir, fmt.Sprintf(
+		"SELECT table_name FROM dolt_diff_summary('main...%s')", commons.EscapeSQL(branch)))
+	if err == nil && len(wlParseCSV(diff)) < 2 {
+		return "no changes against main"
+	}
+
+	wantedID := extractWantedID(branch)
+	if wantedID == "" {
+		return ""
+	}
+	out, err := deps.doltQuery(dir, fmt.Sprintf(
+		"SELECT status FROM wanted AS OF 'main' WHERE id = '%s'", commons.EscapeSQL(wantedID)))
+	if err != nil {
+		return ""
+	}
+	rows := wlParseCSV(out)
+	if len(rows) < 2 || len(rows[1]) == 0 {
+		return ""
+	}
+	switch status := rows[1][0]; status {
+	case "completed", "withdrawn":
+		return "item " + status + " on main"
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// fakePruneDeps returns pruneDeps backed by canned query results keyed by
+// substring of the query, recording deletions.
+func fakePruneDeps(results map[string]string, prStates map[string]string, confirm bool) (*pruneDeps, *[]string) {
+	var deleted []string
+	deps := &pruneDeps{
+		fetch: func(_, _ string) error { return nil },
+		doltQuery: func(_, query string) (string, error) {
+			for k, v := range results {
+				if strings.Contains(query, k) {
+					return v, nil
+				}
+			}
+			return "", fmt.Errorf("unexpected query: %s", query)
+		},
+		prState: func(_ *federation.Config, branch string) string { return prStates[branch] },
+		deleteLocal: func(_, branch string) error {
+			deleted = append(deleted, "local:"+branch)
+			return nil
+		},
+		deleteRemote: func(_, remote, branch string) error {
+			deleted = append(deleted, remote+":"+branch)
+			return nil
+		},
+		confirm: func(string) bool { return confirm },
+	}
+	return deps, &deleted
+}
+
+var pruneResults = map[string]string{
+	"dolt_remote_branches":  "name\nremotes/origin/wl/alice/w-1\n",
+	"dolt_branches":         "name\nwl/alice/w-1\nwl/alice/w-2\nwl/alice/w-3\nwl/alice/w-4\n",
+	"'main...wl/alice/w-1'": "table_name\nwanted\n",
+	"'main...wl/alice/w-2'": "table_name\n",
+	"'main...wl/alice/w-3'": "table_name\nwanted\n",
+	"'main...wl/alice/w-4'": "table_name\nwanted\n",
+	"id = 'w-3'":            "status\ncompleted\n",
+	"id = 'w-4'":            "status\nclaimed\n",
+}
+
+func TestFindStaleBranches(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR}
+	deps, _ := fakePruneDeps(pruneResults, map[string]string{"wl/alice/w-1": "merged", "wl/alice/w-3": "open"}, true)
+
+	stale, err := findStaleBranches(&bytes.Buffer{}, cfg, deps)
+	if err != nil {
+		t.Fatalf("findStaleBranches: %v", err)
+	}
+	want := []staleBranch{
+		{Branch: "wl/alice/w-1", Reason: "PR merged", OnFork: true},
+		{Branch: "wl/alice/w-2", Reason: "no changes against main"},
+	}
+	if len(stale) != len(want) {
+		t.Fatalf("stale = %+v, want %+v", stale, want)
+	}
+	for i := range want {
+		if stale[i] != want[i] {
+			t.Errorf("stale[%d] = %+v, want %+v", i, stale[i], want[i])
+		}
+	}
+}
+
+func TestFindStaleBranches_WildWestChecksItemStatus(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModeWildWest}
+	deps, _ := fakePruneDeps(pruneResults, map[string]string{"wl/alice/w-1": "merged", "wl/alice/w-3": "open"}, true)
+
+	stale, err := findStaleBranches(&bytes.Buffer{}, cfg, deps)
+	if err != nil {
+		t.Fatalf("findStaleBranches: %v", err)
+	}
+	var got []string
+	for _, sb := range stale {
+		got = append(got, sb.Branch+"="+sb.Reason)
+	}
+	want := "wl/alice/w-2=no changes against main,wl/alice/w-3=item completed on main"
+	if strings.Join(got, ",") != want {
+		t.Errorf("stale = %v, want %s", got, want)
+	}
+}
+
+func TestPruneBranches_DryRun(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR}
+	deps, deleted := fakePruneDeps(pruneResults, map[string]string{"wl/alice/w-1": "closed"}, true)
+
+	var stdout bytes.Buffer
+	if err := pruneBranches(&stdout, cfg, deps, true); err != nil {
+		t.Fatalf("pruneBranches: %v", err)
+	}
+	if len(*deleted) != 0 {
+		t.Errorf("dry run deleted %v", *deleted)
+	}
+	if !strings.Contains(stdout.String(), "PR closed (local, fork)") || !strings.Contains(stdout.String(), "would be deleted") {
+		t.Errorf("unexpected output:\n%s", stdout.String())
+	}
+}
+
+func TestPruneBranches_Deletes(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR}
+	deps, deleted := fakePruneDeps(pruneResults, map[string]string{"wl/alice/w-1": "merged"}, true)
+
+	if err := pruneBranches(&bytes.Buffer{}, cfg, deps, false); err != nil {
+		t.Fatalf("pruneBranches: %v", err)
+	}
+	want := "local:wl/alice/w-1,origin:wl/alice/w-1,local:wl/alice/w-2,local:wl/alice/w-3"
+	if got := strings.Join(*deleted, ","); got != want {
+		t.Errorf("deleted = %s, want %s", got, want)
+	}
+}
+
+func TestPruneBranches_Declined(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR}
+	deps, deleted := fakePruneDeps(pruneResults, map[string]string{"wl/alice/w-1": "merged"}, false)
+
+	var stdout bytes.Buffer
+	if err := pruneBranches(&stdout, cfg, deps, false); err != nil {
+		t.Fatalf("pruneBranches: %v", err)
+	}
+	if len(*deleted) != 0 {
+		t.Errorf("declined prune deleted %v", *deleted)
+	}
+	if !strings.Contains(stdout.String(), "Aborted.") {
+		t.Errorf("expected Aborted, got:\n%s", stdout.String())
+	}
+}
+
+func TestPruneBranches_DeleteFailure(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR}
+	deps, _ := fakePruneDeps(pruneResults, map[string]string{"wl/alice/w-1": "merged"}, true)
+	deps.deleteRemote = func(_, _, _ string) error { return fmt.Errorf("permission denied") }
+
+	var stdout bytes.Buffer
+	err := pruneBranches(&stdout, cfg, deps, false)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("err = %v, want 1 of 3 failure", err)
+	}
+	if !strings.Contains(stdout.String(), "permission denied") || !strings.Contains(stdout.String(), "deleted wl/alice/w-2") {
+		t.Errorf("unexpected output:\n%s", stdout.String())
+	}
+}
+
+func TestApplyBranches(t *testing.T) {
+	t.Parallel()
+	deps, _ := fakePruneDeps(nil, nil, true)
+	deps.listBranches = func() ([]sdk.BranchInfo, error) {
+		return []sdk.BranchInfo{
+			{Branch: "wl/alice/w-1", Title: "Fix bug", Delta: "claim", Actions: []string{"apply", "discard"}},
+			{Branch: "wl/alice/w-2", Title: "Idle"},
+		}, nil
+	}
+	applied := 0
+	deps.applyAll = func() ([]sdk.BranchResult, error) {
+		applied++
+		return []sdk.BranchResult{{Branch: "wl/alice/w-1"}}, nil
+	}
+
+	var stdout bytes.Buffer
+	if err := applyBranches(&stdout, deps, true); err != nil {
+		t.Fatalf("applyBranches dry run: %v", err)
+	}
+	if applied != 0 || !strings.Contains(stdout.String(), "1 branch(es) would be applied") || strings.Contains(stdout.String(), "w-2") {
+		t.Errorf("dry run applied=%d, output:\n%s", applied, stdout.String())
+	}
+
+	stdout.Reset()
+	if err := applyBranches(&stdout, deps, false); err != nil {
+		t.Fatalf("applyBranches: %v", err)
+	}
+	if applied != 1 || !strings.Contains(stdout.String(), "applied wl/alice/w-1") {
+		t.Errorf("applied=%d, output:\n%s", applied, stdout.String())
+	}
+}
+
+func TestApplyBranches_Failure(t *testing.T) {
+	t.Parallel()
+	deps, _ := fakePruneDeps(nil, nil, true)
+	deps.listBranches = func() ([]sdk.BranchInfo, error) {
+		return []sdk.BranchInfo{{Branch: "wl/alice/w-1", Actions: []string{"apply"}}}, nil
+	}
+	deps.applyAll = func() ([]sdk.BranchResult, error) {
+		return []sdk.BranchResult{{Branch: "wl/alice/w-1", Err: fmt.Errorf("merge conflict")}}, nil
+	}
+
+	var stdout bytes.Buffer
+	err := applyBranches(&stdout, deps, false)
+	if err == nil || !strings.Contains(err.Error(), "1 of 1") {
+		t.Fatalf("err = %v, want 1 of 1 failure", err)
+	}
+	if !strings.Contains(stdout.String(), "merge conflict") {
+		t.Errorf("output missing failure:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+func newQueueCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		autoClaim bool
+		leave     bool
+		noPush    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "queue <wanted-id>",
+		Short: "Wait in line for a claimed wanted item",
+		Long: `Join the claim queue of a wanted item another rig has claimed.
+
+When the claim is released (wl unclaim) or expires (wl sweep), the first
+rig in the queue is up next: the item shows under "Up next" in wl me and
+the dashboard, and the releaser is told who is waiting. With --auto-claim
+the claim passes to you directly, in the same commit that releases it.
+
+Queues live in the optional claim_queue table; run 'wl doctor --fix' if
+this wasteland doesn't have it yet. Use --leave to give up your place.
+
+In wild-west mode the commit is auto-pushed to upstream and origin.
+Use --no-push to skip pushing (offline work).
+
+Examples:
+  wl queue w-abc123
+  wl queue w-abc123 --auto-claim
+  wl queue w-abc123 --leave`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQueue(cmd, stdout, stderr, args[0], autoClaim, leave, noPush)
+		},
+	}
+
+	cmd.Flags().BoolVar(&autoClaim, "auto-claim", false, "Take the claim automatically when it is your turn")
+	cmd.Flags().BoolVar(&leave, "leave", false, "Leave the claim queue instead of joining it")
+	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
+	cmd.MarkFlagsMutuallyExclusive("auto-claim", "leave")
+	cmd.ValidArgsFunction = completeWantedIDs("claimed")
+
+	return cmd
+}
+
+func runQueue(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, autoClaim, leave, noPush bool) error {
+	wlCfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	wantedID, err = resolveWantedArg(wlCfg, wantedID)
+	if err != nil {
+		return err
+	}
+
+	client, err := newSDKClient(wlCfg, noPush)
+	if err != nil {
+		return err
+	}
+
+	if leave {
+		result, err := client.Unqueue(wantedID)
+		if err != nil {
+			return err
+		}
+		renderMutationResult(stdout, "Left the claim queue for", wantedID, result)
+		return nil
+	}
+
+	result, err := client.Queue(wantedID, autoClaim)
+	if err != nil {
+		return err
+	}
+	renderMutationResult(stdout, "Queued for", wantedID, result)
+	return nil
+}
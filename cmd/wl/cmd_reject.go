@@ -3,6 +3,7 @@ package main
 import (
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -66,7 +67,7 @@ func runReject(cmd *cobra.Command, stdout, _ io.Writer, wantedID, reason string,
 	}
 
 	renderMutationResult(stdout, "Rejected", wantedID, result, extras...)
-	printNextHint(stdout, "Next: claimer can fix and resubmit: wl done "+wantedID+" --evidence <url>")
+	printNextHint(stdout, i18n.T("next.reject", wantedID))
 
 	return nil
 }
@@ -40,6 +40,9 @@ func runRequestChanges(cmd *cobra.Command, stdout, _ io.Writer, branch, comment
 	if err != nil {
 		return hintWrap(err)
 	}
+	if err := requireWritable(cfg, "request changes"); err != nil {
+		return err
+	}
 
 	if !cfg.IsGitHub() {
 		return fmt.Errorf("request-changes requires GitHub provider (joined with --github)")
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,7 +12,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/gastownhall/wasteland/internal/backend"
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
 	"github.com/gastownhall/wasteland/internal/remote"
@@ -20,12 +20,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// reviewOptions holds wl review's output and action flags.
+type reviewOptions struct {
+	jsonOut  bool // --json
+	mdOut    bool // --md
+	statOut  bool // --stat
+	createPR bool // --create-pr
+	squash   bool // --squash
+	web      bool // --web
+}
+
 func newReviewCmd(stdout, stderr io.Writer) *cobra.Command {
 	var (
-		jsonOut  bool
-		mdOut    bool
-		statOut  bool
-		createPR bool
+		opts    reviewOptions
+		comment string
+		at      string
 	)
 
 	cmd := &cobra.Command{
@@ -42,65 +51,123 @@ Output formats (mutually exclusive):
   --json       JSON diff output
   --md         Markdown-formatted diff for pasting into PRs
   --create-pr  Push branch and open a pull request on the upstream provider
+  --web        Open the branch's existing upstream PR in the browser
+
+--squash collapses the branch into a single commit before anything else,
+with a message composed from its commits (e.g. "wl claim + done: w-abc123"),
+and prints the commits it replaced and the final delta. Combine it with
+--create-pr to submit one clean commit. Squashing needs a local clone.
+
+--comment attaches a review note to the branch, for wastelands without
+a PR provider (direct mode, or file and git providers) where there is no
+PR to comment on. Notes are stored in the commons review_comments table
+and pushed, so they travel with the database; the branch diff lists
+them. With --at the note points at a row of the diff, optionally a
+column ("table/row" or "table/row/column"); without it the note is about
+the item as a whole.
 
 Examples:
   wl review                          # list wl/* branches
   wl review wl/my-rig/w-abc123       # terminal diff
   wl review wl/my-rig/w-abc123 --stat
   wl review wl/my-rig/w-abc123 --md
-  wl review wl/my-rig/w-abc123 --create-pr`,
+  wl review wl/my-rig/w-abc123 --create-pr
+  wl review wl/my-rig/w-abc123 --squash --create-pr
+  wl review wl/my-rig/w-abc123 --web
+  wl review wl/my-rig/w-abc123 --comment "needs evidence"
+  wl review wl/my-rig/w-abc123 --comment "typo" --at wanted/w-abc123/title`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var branch string
 			if len(args) == 1 {
 				branch = args[0]
 			}
-			return runReview(cmd, stdout, stderr, branch, jsonOut, mdOut, statOut, createPR)
+			if comment != "" || at != "" {
+				if opts != (reviewOptions{}) {
+					return fmt.Errorf("--comment cannot be combined with other review flags")
+				}
+				return runReviewComment(cmd, stdout, branch, comment, at)
+			}
+			return runReview(cmd, stdout, stderr, branch, opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output diff as JSON")
-	cmd.Flags().BoolVar(&mdOut, "md", false, "Output diff as Markdown")
-	cmd.Flags().BoolVar(&statOut, "stat", false, "Output diff statistics")
-	cmd.Flags().BoolVar(&createPR, "create-pr", false, "Push branch and open a PR on the upstream provider")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output diff as JSON")
+	cmd.Flags().BoolVar(&opts.mdOut, "md", false, "Output diff as Markdown")
+	cmd.Flags().BoolVar(&opts.statOut, "stat", false, "Output diff statistics")
+	cmd.Flags().BoolVar(&opts.createPR, "create-pr", false, "Push branch and open a PR on the upstream provider")
+	cmd.Flags().BoolVar(&opts.squash, "squash", false, "Collapse the branch into one commit first")
+	cmd.Flags().BoolVar(&opts.web, "web", false, "Open the branch's upstream PR in the browser")
+	cmd.Flags().StringVar(&comment, "comment", "", "Attach a review note to the branch (no PR provider)")
+	cmd.Flags().StringVar(&at, "at", "", "With --comment, the row the note is about: table/row[/column]")
 
 	return cmd
 }
 
-func runReview(cmd *cobra.Command, stdout, _ io.Writer, branch string, jsonOut, mdOut, statOut, createPR bool) error {
+func runReview(cmd *cobra.Command, stdout, _ io.Writer, branch string, opts reviewOptions) error {
 	// Validate mutually exclusive flags.
 	flagCount := 0
-	if jsonOut {
+	if opts.jsonOut {
 		flagCount++
 	}
-	if mdOut {
+	if opts.mdOut {
 		flagCount++
 	}
-	if statOut {
+	if opts.statOut {
 		flagCount++
 	}
-	if createPR {
+	if opts.createPR {
+		flagCount++
+	}
+	if opts.web {
 		flagCount++
 	}
 	if flagCount > 1 {
-		return fmt.Errorf("--json, --md, --stat, and --create-pr are mutually exclusive")
+		return fmt.Errorf("--json, --md, --stat, --create-pr, and --web are mutually exclusive")
 	}
 
-	if createPR && branch == "" {
+	if opts.createPR && branch == "" {
 		return fmt.Errorf("--create-pr requires a branch argument")
 	}
+	if opts.squash && branch == "" {
+		return fmt.Errorf("--squash requires a branch argument")
+	}
+	if opts.web && branch == "" {
+		return fmt.Errorf("--web requires a branch argument")
+	}
+	if opts.web && opts.squash {
+		return fmt.Errorf("--web cannot be combined with --squash")
+	}
 
 	cfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
 	}
+	if opts.createPR || opts.squash {
+		if err := requireWritable(cfg, "review"); err != nil {
+			return err
+		}
+	}
+
+	if opts.web {
+		return openPRInBrowser(stdout, branch, checkPRForBranch(cfg, branch))
+	}
+
+	if opts.squash {
+		if err := runSquash(stdout, cfg, branch); err != nil {
+			return err
+		}
+		if !opts.createPR && !opts.jsonOut && !opts.mdOut && !opts.statOut {
+			return nil
+		}
+	}
 
 	// Remote mode: use API for branch listing and PR creation.
 	if cfg.ResolveBackend() != federation.BackendLocal {
 		if branch == "" {
 			return listReviewBranchesRemote(stdout, cfg)
 		}
-		if createPR {
+		if opts.createPR {
 			db, err := openDBFromConfig(cfg)
 			if err != nil {
 				return err
@@ -117,7 +184,7 @@ func runReview(cmd *cobra.Command, stdout, _ io.Writer, branch string, jsonOut,
 		if err != nil {
 			return err
 		}
-		if rdb, ok := db.(*backend.RemoteDB); ok {
+		if rdb, ok := asRemoteDB(db); ok {
 			diff, err := rdb.Diff(branch)
 			if err != nil {
 				return fmt.Errorf("diff: %w", err)
@@ -137,9 +204,15 @@ func runReview(cmd *cobra.Command, stdout, _ io.Writer, branch string, jsonOut,
 		return fmt.Errorf("dolt not found in PATH — install from https://docs.dolthub.com/introduction/installation")
 	}
 
+	if opts.createPR {
+		if err := updateBranchForPR(stdout, cfg.LocalDir, branch); err != nil {
+			return err
+		}
+	}
+
 	base := diffBase(cfg.LocalDir, doltPath)
 
-	if createPR {
+	if opts.createPR {
 		switch cfg.ResolveProviderType() {
 		case "github":
 			return runGitHubPR(stdout, cfg, doltPath, branch, base)
@@ -150,7 +223,159 @@ func runReview(cmd *cobra.Command, stdout, _ io.Writer, branch string, jsonOut,
 		}
 	}
 
-	return showDiff(stdout, cfg.LocalDir, doltPath, branch, base, jsonOut, mdOut, statOut)
+	if err := showDiff(stdout, cfg.LocalDir, doltPath, branch, base, opts.jsonOut, opts.mdOut, opts.statOut); err != nil {
+		return err
+	}
+	if !opts.jsonOut && !opts.mdOut && reviewCommentsSupported(cfg) {
+		printBranchComments(stdout, cfg, branch)
+	}
+	return nil
+}
+
+// reviewCommentsSupported reports whether review notes are kept in the
+// database: wastelands in wild-west (direct) mode, or whose provider has
+// no pull requests. Otherwise reviews belong on the upstream PR.
+func reviewCommentsSupported(cfg *federation.Config) bool {
+	if cfg.ResolveMode() != federation.ModePR {
+		return true
+	}
+	switch cfg.ResolveProviderType() {
+	case "github", "dolthub":
+		return false
+	}
+	return true
+}
+
+// runReviewComment stores a review note on branch and prints it.
+func runReviewComment(cmd *cobra.Command, stdout io.Writer, branch, body, at string) error {
+	if branch == "" {
+		return fmt.Errorf("--comment requires a branch argument")
+	}
+	if body == "" {
+		return fmt.Errorf("--at requires --comment")
+	}
+	rc := commons.ReviewComment{Branch: branch, Body: body}
+	if at != "" {
+		var err error
+		if rc.Table, rc.RowKey, rc.Column, err = commons.ParseReviewTarget(at); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if err := requireWritable(cfg, "review comment"); err != nil {
+		return err
+	}
+	if !reviewCommentsSupported(cfg) {
+		return fmt.Errorf("review comments are for wastelands without a PR provider; comment on the upstream PR instead ('wl review %s --web')", branch)
+	}
+
+	client, err := newSDKClient(cfg, false)
+	if err != nil {
+		return err
+	}
+	saved, err := client.AddReviewComment(rc)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s Comment added to %s\n\n", style.Success.Render(style.IconPass), branch)
+	renderReviewComments(stdout, []commons.ReviewComment{*saved})
+	return nil
+}
+
+// printBranchComments lists the review notes on branch after its diff.
+// Best-effort: failures are silently skipped.
+func printBranchComments(stdout io.Writer, cfg *federation.Config, branch string) {
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return
+	}
+	comments, err := commons.QueryReviewComments(db, branch)
+	if err != nil || len(comments) == 0 {
+		return
+	}
+	fmt.Fprintf(stdout, "\n%s\n", style.Bold.Render(fmt.Sprintf("Review comments (%d):", len(comments))))
+	renderReviewComments(stdout, comments)
+}
+
+func renderReviewComments(w io.Writer, comments []commons.ReviewComment) {
+	for _, c := range comments {
+		header := c.Author
+		if c.CreatedAt != "" {
+			header += "  " + style.Dim.Render(c.CreatedAt)
+		}
+		if target := c.Target(); target != "" {
+			header += "  " + target
+		}
+		fmt.Fprintf(w, "  %s\n", header)
+		for _, line := range strings.Split(c.Body, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+// openPRInBrowser prints prURL and opens it in the default browser. When
+// no browser can be started (e.g. over SSH) the printed URL is enough.
+func openPRInBrowser(stdout io.Writer, branch, prURL string) error {
+	if prURL == "" {
+		return fmt.Errorf("no open PR found for %s: create one with 'wl review %s --create-pr'", branch, branch)
+	}
+	fmt.Fprintf(stdout, "%s %s\n", style.Bold.Render("PR:"), prURL)
+	if err := openBrowser(prURL); err != nil {
+		fmt.Fprintf(stdout, "  %s\n", style.Dim.Render("Could not open a browser ("+err.Error()+"); open the URL above."))
+	}
+	return nil
+}
+
+// updateBranchForPR merges the latest upstream main into branch before it
+// is pushed for a PR. Conflicts stop the PR; other failures (e.g. upstream
+// unreachable) only warn, so the branch is pushed as it is.
+func updateBranchForPR(stdout io.Writer, dbDir, branch string) error {
+	err := commons.UpdateBranchFromUpstream(dbDir, branch)
+	var conflict *commons.ConflictError
+	if errors.As(err, &conflict) {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintf(stdout, "%s could not update %s from upstream main: %v\n", style.Warning.Render(style.IconWarn), branch, err)
+	}
+	return nil
+}
+
+// runSquash collapses branch into a single commit and reports the commits
+// it replaced, the composed message and the final delta.
+func runSquash(stdout io.Writer, cfg *federation.Config, branch string) error {
+	client, err := newSDKClient(cfg, false)
+	if err != nil {
+		return err
+	}
+	if client.SquashBranch == nil {
+		return fmt.Errorf("--squash requires a local clone (backend %s)", cfg.ResolveBackend())
+	}
+	p, err := client.Squash(branch)
+	if err != nil {
+		return err
+	}
+	renderSquash(stdout, p)
+	return nil
+}
+
+func renderSquash(stdout io.Writer, p *sdk.SquashPreview) {
+	if !p.CanSquash {
+		fmt.Fprintf(stdout, "%s Nothing to squash: %s has %d commit(s).\n", style.Dim.Render(style.IconPass), p.Branch, len(p.Commits))
+		return
+	}
+	fmt.Fprintf(stdout, "%s Squashed %d commits on %s:\n", style.Success.Render(style.IconPass), len(p.Commits), p.Branch)
+	for _, msg := range p.Commits {
+		fmt.Fprintf(stdout, "  %s\n", style.Dim.Render(msg))
+	}
+	fmt.Fprintf(stdout, "  → %s\n", style.Bold.Render(p.Message))
+	if p.Delta != "" {
+		fmt.Fprintf(stdout, "  Delta: %s\n", p.Delta)
+	}
 }
 
 // diffBase returns "upstream/main" if the upstream remote exists and can be
@@ -300,7 +525,7 @@ func runGitHubPR(stdout io.Writer, cfg *federation.Config, doltPath, branch, bas
 
 	// In GitHub mode, origin is already GitHub; force-push dolt branch there.
 	// Force is safe — this is a wl/* branch on the user's own fork.
-	if err := commons.PushBranchToRemoteForce(cfg.LocalDir, "origin", branch, true, stdout); err != nil {
+	if err := openLocalClone(cfg).PushBranch(branch, stdout); err != nil {
 		return fmt.Errorf("pushing to GitHub fork: %w", err)
 	}
 
@@ -333,7 +558,7 @@ func runDoltHubPR(stdout io.Writer, cfg *federation.Config, doltPath, branch, ba
 
 	// Force-push dolt branch to origin.
 	// Force is safe — this is a wl/* branch on the user's own fork.
-	if err := commons.PushBranchToRemoteForce(cfg.LocalDir, "origin", branch, true, stdout); err != nil {
+	if err := openLocalClone(cfg).PushBranch(branch, stdout); err != nil {
 		return fmt.Errorf("pushing to DoltHub fork: %w", err)
 	}
 
@@ -483,6 +708,7 @@ func ghAPICall(ghPath, method, endpoint, body string) ([]byte, error) {
 	}
 	out, err := cmd.CombinedOutput()
 	if err != nil {
+		noteGHRateLimit(out)
 		return out, fmt.Errorf("gh api %s %s: %w (%s)", method, endpoint, err, strings.TrimSpace(string(out)))
 	}
 	return out, nil
@@ -633,7 +859,7 @@ func createPRForBranchGitHub(cfg *federation.Config, doltPath, branch, base stri
 	}
 
 	// Force-push dolt branch to origin.
-	if err := commons.PushBranchToRemoteForce(cfg.LocalDir, "origin", branch, true, io.Discard); err != nil {
+	if err := openLocalClone(cfg).PushBranch(branch, io.Discard); err != nil {
 		return "", fmt.Errorf("pushing to GitHub fork: %w", err)
 	}
 
@@ -657,7 +883,7 @@ func createPRForBranchDoltHub(cfg *federation.Config, doltPath, branch, base str
 	}
 
 	// Force-push dolt branch to origin.
-	if err := commons.PushBranchToRemoteForce(cfg.LocalDir, "origin", branch, true, io.Discard); err != nil {
+	if err := openLocalClone(cfg).PushBranch(branch, io.Discard); err != nil {
 		return "", fmt.Errorf("pushing to DoltHub fork: %w", err)
 	}
 
@@ -719,7 +945,7 @@ func createPRForBranchRemote(cfg *federation.Config, cdb commons.DB, branch stri
 
 	// Build PR description from the branch diff.
 	var prBody string
-	if rdb, ok := cdb.(*backend.RemoteDB); ok {
+	if rdb, ok := asRemoteDB(cdb); ok {
 		if diff, derr := rdb.Diff(branch); derr == nil {
 			prBody = diff
 		}
@@ -771,6 +997,46 @@ func checkPRForBranch(cfg *federation.Config, branch string) string {
 	}
 }
 
+// prStateForBranch returns the state of the upstream PR for the given
+// branch: "open", "merged", "closed", or "" if there is none or it could
+// not be determined.
+func prStateForBranch(cfg *federation.Config, branch string) string {
+	switch cfg.ResolveProviderType() {
+	case "github":
+		ghPath, err := exec.LookPath("gh")
+		if err != nil {
+			return ""
+		}
+		return findPRState(ghPath, cfg.Upstream, cfg.ForkOrg+":"+branch)
+	case "dolthub":
+		upstreamOrg, db, err := federation.ParseUpstream(cfg.Upstream)
+		if err != nil {
+			return ""
+		}
+		provider := remote.NewDoltHubProvider(os.Getenv("DOLTHUB_TOKEN"))
+		return provider.PRState(upstreamOrg, db, cfg.ForkOrg, branch)
+	default:
+		return ""
+	}
+}
+
+// findPRState returns the lowercased state of the most recent GitHub PR on
+// upstream with the given head ref, or "" if none is found.
+func findPRState(ghPath, upstreamRepo, head string) string {
+	cmd := exec.Command(ghPath, "pr", "list", "--repo", upstreamRepo, "--head", head, "--state", "all", "--limit", "1", "--json", "state")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	var prs []struct {
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(out, &prs); err != nil || len(prs) == 0 {
+		return ""
+	}
+	return strings.ToLower(prs[0].State)
+}
+
 // closePRForBranch finds and closes the PR associated with the given branch.
 // Returns nil on success or if no PR exists.
 func closePRForBranch(cfg *federation.Config, branch string) error {
@@ -966,6 +1232,29 @@ func branchURLCallback(cfg *federation.Config) func(string) string {
 	}
 }
 
+// squashBranchCallback returns a callback that squashes a branch in the
+// local clone. Returns nil for remote backends, which can't rewrite history.
+func squashBranchCallback(cfg *federation.Config) func(branch, message string) error {
+	if cfg.ResolveBackend() != federation.BackendLocal || cfg.LocalDir == "" {
+		return nil
+	}
+	return func(branch, message string) error {
+		return commons.SquashBranch(cfg.LocalDir, branch, message, commitSigning(cfg))
+	}
+}
+
+// updateBranchCallback returns a callback that merges the latest upstream
+// main into a branch in the local clone. Returns nil for remote backends,
+// whose branches are rebuilt from main by the write API.
+func updateBranchCallback(cfg *federation.Config) func(branch string) error {
+	if cfg.ResolveBackend() != federation.BackendLocal || cfg.LocalDir == "" {
+		return nil
+	}
+	return func(branch string) error {
+		return commons.UpdateBranchFromUpstream(cfg.LocalDir, branch)
+	}
+}
+
 // closeUpstreamPRCallback returns a callback that closes an upstream PR by its web URL.
 func closeUpstreamPRCallback(cfg *federation.Config) func(string) error {
 	switch cfg.ResolveProviderType() {
@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
 )
 
 func TestReviewRequiresNoMoreThanOneArg(t *testing.T) {
@@ -29,17 +33,17 @@ func TestReviewRequiresNoMoreThanOneArg(t *testing.T) {
 }
 
 func TestReviewMutuallyExclusiveFlags(t *testing.T) {
-	err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", true, true, false, false)
+	err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", reviewOptions{jsonOut: true, mdOut: true})
 	if err == nil {
 		t.Error("expected error for --json + --md")
 	}
 
-	err = runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", true, false, true, false)
+	err = runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", reviewOptions{jsonOut: true, statOut: true})
 	if err == nil {
 		t.Error("expected error for --json + --stat")
 	}
 
-	err = runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", false, true, true, false)
+	err = runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", reviewOptions{mdOut: true, statOut: true})
 	if err == nil {
 		t.Error("expected error for --md + --stat")
 	}
@@ -55,7 +59,7 @@ func TestReviewCreatePRMutuallyExclusive(t *testing.T) {
 		{"create-pr+stat", false, false, true, true},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", tc.jsonOut, tc.md, tc.stat, tc.createPR)
+			err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", reviewOptions{jsonOut: tc.jsonOut, mdOut: tc.md, statOut: tc.stat, createPR: tc.createPR})
 			if err == nil {
 				t.Error("expected error for mutually exclusive flags")
 			}
@@ -64,7 +68,7 @@ func TestReviewCreatePRMutuallyExclusive(t *testing.T) {
 }
 
 func TestReviewCreatePRRequiresBranch(t *testing.T) {
-	err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "", false, false, false, true)
+	err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "", reviewOptions{createPR: true})
 	if err == nil {
 		t.Error("expected error for --create-pr without branch")
 	}
@@ -73,6 +77,78 @@ func TestReviewCreatePRRequiresBranch(t *testing.T) {
 	}
 }
 
+func TestReviewSquashRequiresBranch(t *testing.T) {
+	err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "", reviewOptions{squash: true})
+	if err == nil || !strings.Contains(err.Error(), "--squash requires a branch") {
+		t.Errorf("err = %v, want --squash requires a branch", err)
+	}
+}
+
+func TestReviewWebRequiresBranch(t *testing.T) {
+	err := runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "", reviewOptions{web: true})
+	if err == nil || !strings.Contains(err.Error(), "--web requires a branch") {
+		t.Errorf("err = %v, want --web requires a branch", err)
+	}
+	err = runReview(nil, &bytes.Buffer{}, &bytes.Buffer{}, "wl/x/y", reviewOptions{mdOut: true, web: true})
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("err = %v, want mutually exclusive", err)
+	}
+}
+
+func TestOpenPRInBrowser(t *testing.T) {
+	orig := openBrowser
+	defer func() { openBrowser = orig }()
+
+	var opened string
+	openBrowser = func(url string) error {
+		opened = url
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := openPRInBrowser(&buf, "wl/alice/w-1", "https://github.com/org/db/pull/7"); err != nil {
+		t.Fatalf("openPRInBrowser: %v", err)
+	}
+	if opened != "https://github.com/org/db/pull/7" || !strings.Contains(buf.String(), "pull/7") {
+		t.Errorf("opened = %q, output:\n%s", opened, buf.String())
+	}
+
+	openBrowser = func(string) error { return fmt.Errorf("no display") }
+	buf.Reset()
+	if err := openPRInBrowser(&buf, "wl/alice/w-1", "https://github.com/org/db/pull/7"); err != nil {
+		t.Fatalf("openPRInBrowser without display: %v", err)
+	}
+	if !strings.Contains(buf.String(), "pull/7") || !strings.Contains(buf.String(), "no display") {
+		t.Errorf("expected URL and fallback note, got:\n%s", buf.String())
+	}
+
+	err := openPRInBrowser(&buf, "wl/alice/w-1", "")
+	if err == nil || !strings.Contains(err.Error(), "--create-pr") {
+		t.Errorf("err = %v, want hint to --create-pr", err)
+	}
+}
+
+func TestRenderSquash(t *testing.T) {
+	var buf bytes.Buffer
+	renderSquash(&buf, &sdk.SquashPreview{
+		Branch:    "wl/alice/w-1",
+		Commits:   []string{"wl claim: w-1", "wl done: w-1"},
+		Message:   "wl claim + done: w-1",
+		Delta:     "claim + done",
+		CanSquash: true,
+	})
+	for _, want := range []string{"Squashed 2 commits on wl/alice/w-1", "wl claim: w-1", "wl claim + done: w-1", "Delta: claim + done"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	renderSquash(&buf, &sdk.SquashPreview{Branch: "wl/alice/w-1", Commits: []string{"wl claim: w-1"}})
+	if !strings.Contains(buf.String(), "Nothing to squash") {
+		t.Errorf("single commit output:\n%s", buf.String())
+	}
+}
+
 func TestParseReviewStatus(t *testing.T) {
 	tests := []struct {
 		name                         string
@@ -474,3 +550,60 @@ func TestExtractWantedID(t *testing.T) {
 		}
 	}
 }
+
+func TestReviewCommentsSupported(t *testing.T) {
+	tests := []struct {
+		mode, provider string
+		want           bool
+	}{
+		{federation.ModeWildWest, "github", true},
+		{federation.ModePR, "github", false},
+		{federation.ModePR, "dolthub", false},
+		{federation.ModePR, "file", true},
+		{federation.ModePR, "git", true},
+	}
+	for _, tc := range tests {
+		cfg := &federation.Config{Mode: tc.mode, ProviderType: tc.provider}
+		if got := reviewCommentsSupported(cfg); got != tc.want {
+			t.Errorf("reviewCommentsSupported(%s, %s) = %v, want %v", tc.mode, tc.provider, got, tc.want)
+		}
+	}
+}
+
+func TestRunReviewComment(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := federation.NewConfigStore().Save(&federation.Config{
+		Upstream: "hop/wl-commons", ForkOrg: "alice", ForkDB: "wl-commons", LocalDir: "/tmp/test/wl-commons",
+		RigHandle: "alice", ProviderType: "git", Mode: federation.ModePR, JoinedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("saving config: %v", err)
+	}
+	withFakeSDK(t)
+
+	var stdout bytes.Buffer
+	if err := runReviewComment(wastelandCmd(), &stdout, "wl/bob/w-1", "needs evidence", "wanted/w-1/evidence_url"); err != nil {
+		t.Fatalf("runReviewComment: %v", err)
+	}
+	for _, want := range []string{"Comment added to wl/bob/w-1", "alice", "wanted/w-1/evidence_url", "needs evidence"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	if err := runReviewComment(wastelandCmd(), &stdout, "wl/bob/w-1", "x", "wanted"); err == nil {
+		t.Error("expected error for malformed --at target")
+	}
+	if err := runReviewComment(wastelandCmd(), &stdout, "", "x", ""); err == nil {
+		t.Error("expected error for --comment without branch")
+	}
+}
+
+func TestRunReviewComment_PRProvider(t *testing.T) {
+	saveWasteland(t) // PR mode on DoltHub
+	withFakeSDK(t)
+
+	err := runReviewComment(wastelandCmd(), &bytes.Buffer{}, "wl/bob/w-1", "x", "")
+	if err == nil || !strings.Contains(err.Error(), "without a PR provider") {
+		t.Errorf("err = %v, want PR provider error", err)
+	}
+}
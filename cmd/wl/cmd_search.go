@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/api"
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+// searchHTTPClient queries the API server named by 'wl search --remote'.
+var searchHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+func newSearchCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		types      []string
+		limit      int
+		remote     string
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search items, completions and stamps",
+		Long: `Search the wasteland for a free-text query across wanted items (title,
+description, tags), completions (evidence) and stamps (message, skills).
+
+Every word of the query must appear in a result, ignoring case. Results
+are tagged with their type and ranked: an item whose ID is the query comes
+first, then matches in titles, tags and skills ahead of matches in longer
+text.
+
+--remote sends the query to a wl serve or hosted API server instead of
+reading the local wasteland: give the server's base URL, including the
+/w/<org>/<db> prefix when it serves several wastelands.
+
+EXAMPLES:
+  wl search auth                           # everything mentioning auth
+  wl search "token refresh" --type wanted  # only wanted items
+  wl search go --type stamp --json         # stamps with the go skill
+  wl search auth --remote https://wasteland.example.com`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := commons.SearchFilter{Query: strings.Join(args, " "), Limit: limit}
+			for _, v := range types {
+				for _, t := range strings.Split(v, ",") {
+					if !commons.ValidSearchType(t) {
+						return fmt.Errorf("invalid --type %q: want %s", t, strings.Join(commons.SearchTypes, ", "))
+					}
+					f.Types = append(f.Types, t)
+				}
+			}
+			return runSearch(cmd, stdout, stderr, f, remote, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Only return these result types: wanted, completion, stamp (repeatable)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of results (at most 100)")
+	cmd.Flags().StringVar(&remote, "remote", "", "Search through the API server at this base URL")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	_ = cmd.RegisterFlagCompletionFunc("type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return commons.SearchTypes, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func runSearch(cmd *cobra.Command, stdout, _ io.Writer, f commons.SearchFilter, remote string, jsonOutput bool) error {
+	var (
+		results []commons.SearchResult
+		err     error
+	)
+	if remote != "" {
+		results, err = searchRemote(searchHTTPClient, remote, f)
+	} else {
+		results, err = searchLocal(cmd, stdout, f)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(searchResponse(f.Query, results), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, string(data))
+		return nil
+	}
+	renderSearch(stdout, f.Query, results)
+	return nil
+}
+
+func searchLocal(cmd *cobra.Command, stdout io.Writer, f commons.SearchFilter) ([]commons.SearchResult, error) {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return nil, hintWrap(err)
+	}
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return nil, err
+		}
+		sp := style.StartSpinner(stdout, "Syncing with upstream...")
+		syncErr := db.Sync()
+		sp.Stop()
+		if syncErr != nil {
+			return nil, fmt.Errorf("syncing with upstream: %w", syncErr)
+		}
+	}
+	return commons.Search(db, f)
+}
+
+// searchRemote runs f against GET /api/search on the server at base.
+func searchRemote(client *http.Client, base string, f commons.SearchFilter) ([]commons.SearchResult, error) {
+	u, err := url.Parse(strings.TrimRight(base, "/") + "/api/search")
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid --remote %q: want an http(s) URL", base)
+	}
+	q := url.Values{"q": {f.Query}}
+	if len(f.Types) > 0 {
+		q.Set("type", strings.Join(f.Types, ","))
+	}
+	if f.Limit > 0 {
+		q.Set("limit", fmt.Sprint(f.Limit))
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "wl/"+version)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("searching %s: %w", base, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("searching %s: %w", base, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("searching %s: %s", base, apiErr.Error)
+		}
+		return nil, fmt.Errorf("searching %s: %s", base, resp.Status)
+	}
+	var sr api.SearchResponse
+	if err := json.Unmarshal(body, &sr); err != nil {
+		return nil, fmt.Errorf("parsing search response from %s: %w", base, err)
+	}
+	results := make([]commons.SearchResult, len(sr.Results))
+	for i, r := range sr.Results {
+		results[i] = commons.SearchResult{
+			Type:     r.Type,
+			ID:       r.ID,
+			WantedID: r.WantedID,
+			Title:    r.Title,
+			Status:   r.Status,
+			Rig:      r.Rig,
+			Field:    r.Field,
+			Snippet:  r.Snippet,
+			Score:    r.Score,
+		}
+	}
+	return results, nil
+}
+
+// searchResponse is the --json form of results, the same shape
+// GET /api/search returns.
+func searchResponse(query string, results []commons.SearchResult) api.SearchResponse {
+	out := api.SearchResponse{Query: query, Results: make([]api.SearchResultJSON, len(results))}
+	for i, r := range results {
+		out.Results[i] = api.SearchResultJSON{
+			Type:     r.Type,
+			ID:       r.ID,
+			WantedID: r.WantedID,
+			Title:    r.Title,
+			Status:   r.Status,
+			Rig:      r.Rig,
+			Field:    r.Field,
+			Snippet:  r.Snippet,
+			Score:    r.Score,
+		}
+	}
+	return out
+}
+
+func renderSearch(w io.Writer, query string, results []commons.SearchResult) {
+	if len(results) == 0 {
+		fmt.Fprintf(w, "Nothing matches %q.\n", query)
+		return
+	}
+	for _, r := range results {
+		title := r.Title
+		if title == "" {
+			title = r.WantedID
+		}
+		head := fmt.Sprintf("%-10s %-14s %s", r.Type, r.ID, title)
+		var meta []string
+		if r.Type != commons.SearchWanted && r.WantedID != "" {
+			meta = append(meta, r.WantedID)
+		}
+		if r.Status != "" {
+			meta = append(meta, r.Status)
+		}
+		if r.Rig != "" {
+			meta = append(meta, r.Rig)
+		}
+		if len(meta) > 0 {
+			head += "  " + style.Dim.Render("("+strings.Join(meta, ", ")+")")
+		}
+		fmt.Fprintln(w, head)
+		if r.Snippet != "" && r.Field != "id" && r.Field != "title" {
+			fmt.Fprintf(w, "           %s %s\n", style.Dim.Render(r.Field+":"), r.Snippet)
+		}
+	}
+	fmt.Fprintf(w, "\n%d result(s)\n", len(results))
+}
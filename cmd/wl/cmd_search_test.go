@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestSearchRemote(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/w/hop/wl-commons/api/search" {
+			http.NotFound(w, r)
+			return
+		}
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"query":"auth fix","results":[{"type":"stamp","id":"s-1","wanted_id":"w-1","title":"Fix auth","rig":"alice","field":"message","snippet":"nice auth fix","score":6}]}`))
+	}))
+	defer srv.Close()
+
+	results, err := searchRemote(srv.Client(), srv.URL+"/w/hop/wl-commons/", commons.SearchFilter{
+		Query: "auth fix", Types: []string{"stamp", "completion"}, Limit: 5,
+	})
+	if err != nil {
+		t.Fatalf("searchRemote: %v", err)
+	}
+	if gotQuery != "limit=5&q=auth+fix&type=stamp%2Ccompletion" {
+		t.Errorf("query = %q", gotQuery)
+	}
+	if len(results) != 1 || results[0].Type != "stamp" || results[0].WantedID != "w-1" || results[0].Rig != "alice" {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestSearchRemote_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"q parameter is required"}`))
+	}))
+	defer srv.Close()
+
+	_, err := searchRemote(srv.Client(), srv.URL, commons.SearchFilter{Query: "x"})
+	if err == nil || !strings.Contains(err.Error(), "q parameter is required") {
+		t.Errorf("err = %v, want the server's message", err)
+	}
+	if _, err := searchRemote(srv.Client(), "ftp://example.com", commons.SearchFilter{Query: "x"}); err == nil {
+		t.Error("non-http remote should fail")
+	}
+}
+
+func TestRenderSearch(t *testing.T) {
+	var buf bytes.Buffer
+	renderSearch(&buf, "auth", []commons.SearchResult{
+		{Type: "wanted", ID: "w-1", WantedID: "w-1", Title: "Fix auth", Status: "open", Field: "title", Snippet: "Fix auth"},
+		{Type: "completion", ID: "c-1", WantedID: "w-2", Title: "Docs", Rig: "bob", Field: "evidence", Snippet: "https://example.com/auth"},
+	})
+	out := buf.String()
+	for _, want := range []string{"wanted", "w-1", "Fix auth", "completion", "c-1", "w-2", "bob", "evidence:", "https://example.com/auth", "2 result(s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "Fix auth") != 1 {
+		t.Errorf("title match should not repeat the title as a snippet:\n%s", out)
+	}
+
+	buf.Reset()
+	renderSearch(&buf, "zzz", nil)
+	if !strings.Contains(buf.String(), `Nothing matches "zzz"`) {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
+
+func TestSearchRejectsUnknownType(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := newSearchCmd(&stdout, &stderr)
+	cmd.SetArgs([]string{"auth", "--type", "rigs"})
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --type") {
+		t.Errorf("err = %v, want invalid --type", err)
+	}
+}
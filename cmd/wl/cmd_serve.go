@@ -7,11 +7,15 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -34,7 +38,22 @@ func newServeCmd(stdout, stderr io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "serve",
 		Short: "Start the web UI server",
-		Args:  cobra.NoArgs,
+		Long: `Start the local API server with the embedded web dashboard.
+
+The dashboard offers the same browse, detail and lifecycle actions as the
+TUI. Every joined wasteland is served: API requests pick one with the
+X-Wasteland header or a /w/{org}/{db}/ path prefix, and otherwise use the
+--wasteland default (or the first joined). The server binds to localhost
+by default since it acts with your credentials; use --host to expose it on
+another interface.
+
+Examples:
+  wl serve
+  wl serve --port 9000
+  wl serve --host 0.0.0.0
+  wl serve --cors-origin https://board.example.com --cors-methods /api/wanted=GET
+  curl localhost:8999/w/hop/wl-commons/api/wanted`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			hostedMode, _ := cmd.Flags().GetBool("hosted")
 			if hostedMode {
@@ -44,7 +63,11 @@ func newServeCmd(stdout, stderr io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().Int("port", 8999, "Port to listen on")
+	cmd.Flags().String("host", "", "Interface to bind (default 127.0.0.1; all interfaces with --hosted)")
 	cmd.Flags().Bool("dev", false, "Enable CORS for development (Vite proxy)")
+	cmd.Flags().StringSlice("cors-origin", nil, "Origin allowed to call the API cross-origin (repeatable; env WL_CORS_ORIGINS)")
+	cmd.Flags().Bool("cors-credentials", false, "Allow cookies on cross-origin requests (env WL_CORS_CREDENTIALS)")
+	cmd.Flags().StringArray("cors-methods", nil, "Methods allowed cross-origin under a path, e.g. /api/scoreboard=GET (repeatable; env WL_CORS_METHODS)")
 	cmd.Flags().Bool("hosted", false, "Run in multi-tenant hosted mode (Nango)")
 	return cmd
 }
@@ -61,6 +84,25 @@ func resolvePort(cmd *cobra.Command) int {
 	return port
 }
 
+// resolveListenAddr returns the address to bind. The self-sovereign server
+// mutates the wasteland with the local rig's credentials, so it defaults to
+// loopback; hosted mode defaults to all interfaces for PaaS deployments.
+func resolveListenAddr(host string, port int, hostedMode bool) string {
+	if host == "" && !hostedMode {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// isLoopbackHost reports whether host names a loopback interface.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // listenAndServeGraceful starts the server and shuts down gracefully on
 // SIGINT/SIGTERM, giving in-flight requests up to 10 seconds to complete.
 func listenAndServeGraceful(srv *http.Server) error {
@@ -85,6 +127,63 @@ func listenAndServeGraceful(srv *http.Server) error {
 	}
 }
 
+// resolveCORS builds the cross-origin policy from the --cors-* flags,
+// falling back to the WL_CORS_* environment variables for PaaS deployments.
+// With no origins configured, --dev allows any origin and otherwise
+// cross-origin requests get no CORS headers (nil middleware).
+func resolveCORS(cmd *cobra.Command, getenv func(string) string, devMode bool) (func(http.Handler) http.Handler, error) {
+	origins := resolveCORSOrigins(cmd, getenv)
+	credentials, _ := cmd.Flags().GetBool("cors-credentials")
+	if !cmd.Flags().Changed("cors-credentials") {
+		credentials, _ = strconv.ParseBool(getenv("WL_CORS_CREDENTIALS"))
+	}
+	routeMethods, _ := cmd.Flags().GetStringArray("cors-methods")
+	if !cmd.Flags().Changed("cors-methods") {
+		routeMethods = splitList(getenv("WL_CORS_METHODS"), " ")
+	}
+
+	if len(origins) == 0 {
+		if credentials || len(routeMethods) > 0 {
+			return nil, fmt.Errorf("--cors-credentials and --cors-methods require --cors-origin")
+		}
+		if devMode {
+			return api.CORSMiddleware, nil
+		}
+		return nil, nil
+	}
+	routes, err := api.ParseRouteMethods(routeMethods)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewCORS(api.CORSConfig{
+		AllowedOrigins:   origins,
+		AllowCredentials: credentials,
+		RouteMethods:     routes,
+		MaxAge:           10 * time.Minute,
+	})
+}
+
+// resolveCORSOrigins returns the --cors-origin origins, or WL_CORS_ORIGINS
+// when the flag isn't set.
+func resolveCORSOrigins(cmd *cobra.Command, getenv func(string) string) []string {
+	if cmd.Flags().Changed("cors-origin") {
+		origins, _ := cmd.Flags().GetStringSlice("cors-origin")
+		return origins
+	}
+	return splitList(getenv("WL_CORS_ORIGINS"), ",")
+}
+
+// splitList splits s on sep, trimming and dropping empty entries.
+func splitList(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
 func initSentry(environment string) {
 	dsn := os.Getenv("SENTRY_DSN")
 	if dsn == "" {
@@ -101,7 +200,7 @@ func initSentry(environment string) {
 }
 
 func runServe(cmd *cobra.Command, stdout, stderr io.Writer) error {
-	logger := slog.New(slog.NewJSONHandler(stdout, nil))
+	logger := slog.New(slog.NewJSONHandler(stdout, &slog.HandlerOptions{Level: logLevel}))
 	slog.SetDefault(logger)
 
 	initSentry("self-sovereign")
@@ -110,27 +209,140 @@ func runServe(cmd *cobra.Command, stdout, stderr io.Writer) error {
 	port := resolvePort(cmd)
 	devMode, _ := cmd.Flags().GetBool("dev")
 
-	cfg, err := resolveWasteland(cmd)
+	store := federation.NewConfigStore()
+	explicit, _ := cmd.Flags().GetString("wasteland")
+	localDB, _ := cmd.Flags().GetBool("local-db")
+	cfgs, err := serveWastelands(store, explicit, localDB)
 	if err != nil {
 		return hintWrap(err)
 	}
+	if readOnlyRequested(cmd) {
+		for _, cfg := range cfgs {
+			cfg.ForceReadOnly = true
+		}
+	}
+
+	// The first config is the default wasteland; the others are served
+	// alongside it and skipped with a warning if they fail to open.
+	ws := sdk.NewWorkspace(cfgs[0].RigHandle)
+	dbs := make(map[string]commons.DB, len(cfgs))
+	for i, cfg := range cfgs {
+		client, cdb, err := newServeClient(cfg, stderr)
+		if err != nil {
+			if i == 0 {
+				return err
+			}
+			slog.Warn("not serving wasteland", "upstream", cfg.Upstream, "error", err)
+			continue
+		}
+		dbs[cfg.Upstream] = cdb
+		ws.Add(sdk.UpstreamInfo{
+			Upstream: cfg.Upstream,
+			ForkOrg:  cfg.ForkOrg,
+			ForkDB:   cfg.ForkDB,
+			Mode:     cfg.ResolveMode(),
+		}, client)
+	}
+
+	server := api.NewWorkspace(ws, cfgs[0].Upstream)
+
+	// Each served wasteland gets its own scoreboard caches, selected by the
+	// request's /w/{org}/{db} prefix or X-Wasteland header.
+	for upstream, db := range dbs {
+		caches := api.ScoreboardCaches{
+			Scoreboard: api.NewScoreboardCache(db, 5*time.Minute),
+			Detail:     api.NewCachedEndpoint(newDetailRefresh(db), 5*time.Minute),
+			Dump:       api.NewCachedEndpoint(newDumpRefresh(db), 5*time.Minute),
+		}
+		server.SetWastelandScoreboard(upstream, caches)
+		for _, c := range []*api.CachedEndpoint{caches.Scoreboard, caches.Detail, caches.Dump} {
+			c.Start()
+			defer c.Stop()
+		}
+	}
 
+	rateLimiter := api.NewRateLimiter(120, 120, time.Minute)
+	defer rateLimiter.Stop()
+	generalRL := api.RateLimit(rateLimiter)
+	bodyLimit := api.MaxBytesBody(64 << 10) // 64 KB
+	sentryMiddleware := sentryhttp.New(sentryhttp.Options{Repanic: true})
+	cors, err := resolveCORS(cmd, os.Getenv, devMode)
+	if err != nil {
+		return err
+	}
+	// CORS sits inside WastelandPrefix so --cors-methods rules match the
+	// rewritten path: /w/org/db/api/wanted is checked as /api/wanted.
+	app := api.SPAHandler(server, web.Assets)
+	if cors != nil {
+		app = cors(app)
+	}
+	handler := sentryMiddleware.Handle(api.RequestLog(logger)(api.SecurityHeaders(generalRL(bodyLimit(api.WastelandPrefix(app))))))
+
+	host, _ := cmd.Flags().GetString("host")
+	addr := resolveListenAddr(host, port, false)
+	if h, _, _ := net.SplitHostPort(addr); !isLoopbackHost(h) {
+		slog.Warn("serving on a non-loopback interface; anyone who can reach it can act as your rig", "addr", addr)
+	}
+	slog.Info("server started", "mode", "self-sovereign", "addr", addr, "wastelands", len(ws.Upstreams()))
+	fmt.Fprintf(stderr, "%s Dashboard at %s\n", style.Success.Render(style.IconPass), style.Bold.Render("http://"+addr))
+	srv := &http.Server{Addr: addr, Handler: handler, MaxHeaderBytes: 1 << 20} //nolint:gosec // bind addr is user-controlled via --host/--port
+	return listenAndServeGraceful(srv)
+}
+
+// serveWastelands loads every joined wasteland for wl serve, default first:
+// the one named by --wasteland, else the first joined in sorted order.
+func serveWastelands(store federation.ConfigStore, explicit string, localDB bool) ([]*federation.Config, error) {
+	names, err := store.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing wastelands: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w (run 'wl join <upstream>')", federation.ErrNotJoined)
+	}
+	sort.Strings(names)
+	if explicit != "" {
+		if !slices.Contains(names, explicit) {
+			return nil, fmt.Errorf("loading config for %s: %w", explicit, federation.ErrNotJoined)
+		}
+		names = slices.DeleteFunc(names, func(n string) bool { return n == explicit })
+		names = append([]string{explicit}, names...)
+	}
+
+	cfgs := make([]*federation.Config, 0, len(names))
+	for _, name := range names {
+		cfg, err := store.Load(name)
+		if err != nil {
+			return nil, fmt.Errorf("loading config for %s: %w", name, err)
+		}
+		if localDB {
+			cfg.Backend = federation.BackendLocal
+		} else {
+			cfg.Backend = federation.BackendRemote
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, nil
+}
+
+// newServeClient opens and syncs one wasteland's database and builds the
+// SDK client that serves it.
+func newServeClient(cfg *federation.Config, stderr io.Writer) (*sdk.Client, commons.DB, error) {
 	var db commons.DB
 	if cfg.ResolveBackend() == federation.BackendLocal {
 		if err := requireDolt(); err != nil {
-			return err
+			return nil, nil, err
 		}
-		localDB := backend.NewLocalDB(cfg.LocalDir, cfg.ResolveMode())
+		localDB := newLocalDB(cfg)
 		db = localDB
 
-		sp := style.StartSpinner(stderr, "Syncing with upstream...")
-		err = localDB.Sync()
+		sp := style.StartSpinner(stderr, "Syncing "+cfg.Upstream+" with upstream...")
+		err := localDB.Sync()
 		sp.Stop()
 		if err != nil {
-			return fmt.Errorf("syncing with upstream: %w", err)
+			return nil, nil, fmt.Errorf("syncing %s with upstream: %w", cfg.Upstream, err)
 		}
 
-		if cfg.ResolveMode() == federation.ModePR {
+		if cfg.ResolveMode() == federation.ModePR && !cfg.IsReadOnly() {
 			if err := localDB.PushMain(io.Discard); err != nil {
 				slog.Warn("could not sync origin/main", "error", err)
 			}
@@ -138,20 +350,20 @@ func runServe(cmd *cobra.Command, stdout, stderr io.Writer) error {
 	} else {
 		token := commons.DoltHubToken()
 		if token == "" {
-			return fmt.Errorf("DOLTHUB_TOKEN required for remote mode — set it in your environment")
+			return nil, nil, fmt.Errorf("DOLTHUB_TOKEN required for remote mode — set it in your environment")
 		}
 		upOrg, upDB, err := federation.ParseUpstream(cfg.Upstream)
 		if err != nil {
-			return fmt.Errorf("parsing upstream: %w", err)
+			return nil, nil, fmt.Errorf("parsing upstream: %w", err)
 		}
 		remoteDB := backend.NewRemoteDB(token, upOrg, upDB, cfg.ForkOrg, cfg.ForkDB, cfg.ResolveMode())
 		db = remoteDB
 
-		sp := style.StartSpinner(stderr, "Syncing fork with upstream...")
+		sp := style.StartSpinner(stderr, "Syncing "+cfg.Upstream+" fork with upstream...")
 		err = remoteDB.Sync()
 		sp.Stop()
 		if err != nil {
-			slog.Warn("fork sync skipped", "error", err)
+			slog.Warn("fork sync skipped", "upstream", cfg.Upstream, "error", err)
 		}
 	}
 
@@ -176,11 +388,14 @@ func runServe(cmd *cobra.Command, stdout, stderr io.Writer) error {
 	}
 
 	client := sdk.New(sdk.ClientConfig{
-		DB:        db,
-		RigHandle: cfg.RigHandle,
-		Mode:      cfg.ResolveMode(),
-		Signing:   cfg.Signing,
-		HopURI:    cfg.HopURI,
+		DB:         commons.Chained(db, cfg.RigHandle, cfg.HopURI),
+		RigHandle:  cfg.RigHandle,
+		Mode:       cfg.ResolveMode(),
+		Signing:    cfg.Signing,
+		SigningKey: cfg.SigningKey,
+		HopURI:     cfg.HopURI,
+		ReadOnly:   cfg.IsReadOnly(),
+		Hooks:      hookRunner(cfg, stderr),
 		SaveConfig: func(mode string, signing bool) error {
 			store := federation.NewConfigStore()
 			c, err := store.Load(cfg.Upstream)
@@ -207,43 +422,19 @@ func runServe(cmd *cobra.Command, stdout, stderr io.Writer) error {
 		ListPendingItems: listPendingItemsFromPRs(cfg),
 		BranchURL:        branchURLCallback(cfg),
 		CloseUpstreamPR:  closeUpstreamPRCallback(cfg),
+		SquashBranch:     squashBranchCallback(cfg),
+		UpdateBranch:     updateBranchCallback(cfg),
+		PRState: func(branch string) string {
+			return prStateForBranch(cfg, branch)
+		},
+		PRStatusTTL: prStatusTTL,
 	})
 
-	server := api.New(client)
-
-	scoreboardCache := api.NewScoreboardCache(db, 5*time.Minute)
-	server.SetScoreboard(scoreboardCache)
-	scoreboardCache.Start()
-	defer scoreboardCache.Stop()
-
-	detailCache := api.NewCachedEndpoint(newDetailRefresh(db), 5*time.Minute)
-	server.SetScoreboardDetail(detailCache)
-	detailCache.Start()
-	defer detailCache.Stop()
-
-	dumpCache := api.NewCachedEndpoint(newDumpRefresh(db), 5*time.Minute)
-	server.SetScoreboardDump(dumpCache)
-	dumpCache.Start()
-	defer dumpCache.Stop()
-
-	rateLimiter := api.NewRateLimiter(120, 120, time.Minute)
-	defer rateLimiter.Stop()
-	generalRL := api.RateLimit(rateLimiter)
-	bodyLimit := api.MaxBytesBody(64 << 10) // 64 KB
-	sentryMiddleware := sentryhttp.New(sentryhttp.Options{Repanic: true})
-	handler := sentryMiddleware.Handle(api.RequestLog(logger)(api.SecurityHeaders(generalRL(bodyLimit(api.SPAHandler(server, web.Assets))))))
-	if devMode {
-		handler = api.CORSMiddleware(handler)
-	}
-
-	addr := fmt.Sprintf(":%d", port)
-	slog.Info("server started", "mode", "self-sovereign", "addr", addr)
-	srv := &http.Server{Addr: addr, Handler: handler, MaxHeaderBytes: 1 << 20} //nolint:gosec // bind addr is user-controlled via --port flag
-	return listenAndServeGraceful(srv)
+	return client, db, nil
 }
 
 func runServeHosted(cmd *cobra.Command, stdout, _ io.Writer) error {
-	logger := slog.New(slog.NewJSONHandler(stdout, nil))
+	logger := slog.New(slog.NewJSONHandler(stdout, &slog.HandlerOptions{Level: logLevel}))
 	slog.SetDefault(logger)
 
 	port := resolvePort(cmd)
@@ -270,6 +461,23 @@ func runServeHosted(cmd *cobra.Command, stdout, _ io.Writer) error {
 	nangoBaseURL := os.Getenv("NANGO_BASE_URL")
 	nangoIntegrationID := os.Getenv("NANGO_INTEGRATION_ID")
 
+	// Session cookie attributes: Secure (default true) and SameSite
+	// (strict, lax, or none) for deployments where the UI is cross-site.
+	cookiePolicy, err := hosted.ParseCookiePolicy(os.Getenv("WL_COOKIE_SECURE"), os.Getenv("WL_COOKIE_SAMESITE"))
+	if err != nil {
+		return err
+	}
+
+	// Per-connection daily DoltHub API call quota (0 disables it).
+	dailyQuota := hosted.DefaultDailyQuota
+	if v := os.Getenv("WL_DAILY_API_QUOTA"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid WL_DAILY_API_QUOTA %q: must be a non-negative integer", v)
+		}
+		dailyQuota = n
+	}
+
 	// Build Nango client.
 	nangoCfg := hosted.NangoConfig{
 		BaseURL:       nangoBaseURL,
@@ -281,9 +489,12 @@ func runServeHosted(cmd *cobra.Command, stdout, _ io.Writer) error {
 	// Build session store and workspace resolver.
 	sessions := hosted.NewSessionStore()
 	resolver := hosted.NewWorkspaceResolver(nangoClient, sessions)
+	resolver.SetDailyQuota(dailyQuota)
+	resolver.SetReadOnly(readOnlyRequested(cmd))
 
 	// Build the API server with hosted workspace resolution.
 	apiServer := api.NewHostedWorkspace(hosted.NewClientFunc(), hosted.NewWorkspaceFunc())
+	apiServer.SetErrorHandler(hosted.WriteQuotaError)
 
 	// Public read-only RemoteDB against hop/wl-commons (no token needed).
 	publicDB := backend.NewRemoteDB("", "hop", "wl-commons", "hop", "wl-commons", "")
@@ -315,8 +526,19 @@ func runServeHosted(cmd *cobra.Command, stdout, _ io.Writer) error {
 	})
 	apiServer.SetPublicClient(anonClient)
 
+	// Reopen stale claims on items posted by signed-in users.
+	claimSweeper := hosted.NewClaimSweeper(resolver, hosted.ClaimSweepInterval)
+	claimSweeper.Start()
+	defer claimSweeper.Stop()
+
 	// Build the hosted server and compose handlers.
 	hostedServer := hosted.NewServer(resolver, sessions, nangoClient, sessionSecret, environment)
+	hostedServer.SetCookiePolicy(cookiePolicy)
+	// Live board updates stream the public wasteland and any a signed-in
+	// user has joined (the resolver's), to the server's own pages and the
+	// configured cross-origin UIs.
+	hostedServer.SetBoardUpstreams([]string{"hop/wl-commons"})
+	hostedServer.SetWebSocketOrigins(resolveCORSOrigins(cmd, os.Getenv))
 
 	hostedRateLimiter := api.NewRateLimiter(120, 120, time.Minute)
 	defer hostedRateLimiter.Stop()
@@ -324,14 +546,19 @@ func runServeHosted(cmd *cobra.Command, stdout, _ io.Writer) error {
 	bodyLimit := api.MaxBytesBody(64 << 10) // 64 KB
 	sentryMiddleware := sentryhttp.New(sentryhttp.Options{Repanic: true})
 	handler := sentryMiddleware.Handle(api.RequestLog(logger)(api.SecurityHeaders(generalRL(bodyLimit(hostedServer.Handler(apiServer, web.Assets))))))
-	if devMode {
-		handler = api.CORSMiddleware(handler)
+	cors, err := resolveCORS(cmd, os.Getenv, devMode)
+	if err != nil {
+		return err
+	}
+	if cors != nil {
+		handler = cors(handler)
 	}
 
-	addr := fmt.Sprintf(":%d", port)
+	host, _ := cmd.Flags().GetString("host")
+	addr := resolveListenAddr(host, port, true)
 	slog.Info("server started", "mode", "hosted", "addr", addr)
 	slog.Info("nango configured", "integration_id", nangoClient.IntegrationID())
-	srv := &http.Server{Addr: addr, Handler: handler, MaxHeaderBytes: 1 << 20} //nolint:gosec // bind addr is user-controlled via --port flag
+	srv := &http.Server{Addr: addr, Handler: handler, MaxHeaderBytes: 1 << 20} //nolint:gosec // bind addr is user-controlled via --host/--port
 	return listenAndServeGraceful(srv)
 }
 
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+func TestResolveListenAddr(t *testing.T) {
+	tests := []struct {
+		host   string
+		port   int
+		hosted bool
+		want   string
+	}{
+		{"", 8999, false, "127.0.0.1:8999"},
+		{"", 8080, true, ":8080"},
+		{"0.0.0.0", 8999, false, "0.0.0.0:8999"},
+		{"::1", 9000, false, "[::1]:9000"},
+	}
+	for _, tt := range tests {
+		if got := resolveListenAddr(tt.host, tt.port, tt.hosted); got != tt.want {
+			t.Errorf("resolveListenAddr(%q, %d, %v) = %q, want %q", tt.host, tt.port, tt.hosted, got, tt.want)
+		}
+	}
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"127.0.0.1": true,
+		"::1":       true,
+		"localhost": true,
+		"0.0.0.0":   false,
+		"":          false,
+		"10.0.0.5":  false,
+	} {
+		if got := isLoopbackHost(host); got != want {
+			t.Errorf("isLoopbackHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestServeWastelands(t *testing.T) {
+	store := &fakeConfigStore{configs: map[string]*federation.Config{
+		"hop/wl-commons": {Upstream: "hop/wl-commons"},
+		"gastown/wl":     {Upstream: "gastown/wl"},
+		"acme/board":     {Upstream: "acme/board"},
+	}}
+
+	cfgs, err := serveWastelands(store, "", false)
+	if err != nil {
+		t.Fatalf("serveWastelands: %v", err)
+	}
+	if got := upstreamsOf(cfgs); !slices.Equal(got, []string{"acme/board", "gastown/wl", "hop/wl-commons"}) {
+		t.Errorf("upstreams = %v, want sorted", got)
+	}
+	if cfgs[0].Backend != federation.BackendRemote {
+		t.Errorf("Backend = %q, want remote", cfgs[0].Backend)
+	}
+
+	cfgs, err = serveWastelands(store, "hop/wl-commons", true)
+	if err != nil {
+		t.Fatalf("serveWastelands explicit: %v", err)
+	}
+	if got := upstreamsOf(cfgs); !slices.Equal(got, []string{"hop/wl-commons", "acme/board", "gastown/wl"}) {
+		t.Errorf("upstreams = %v, want explicit default first", got)
+	}
+	if cfgs[0].Backend != federation.BackendLocal {
+		t.Errorf("Backend = %q, want local", cfgs[0].Backend)
+	}
+
+	if _, err := serveWastelands(store, "nope/nope", false); !errors.Is(err, federation.ErrNotJoined) {
+		t.Errorf("unknown explicit: err = %v, want ErrNotJoined", err)
+	}
+	if _, err := serveWastelands(&fakeConfigStore{}, "", false); !errors.Is(err, federation.ErrNotJoined) {
+		t.Errorf("none joined: err = %v, want ErrNotJoined", err)
+	}
+}
+
+func upstreamsOf(cfgs []*federation.Config) []string {
+	out := make([]string, len(cfgs))
+	for i, c := range cfgs {
+		out[i] = c.Upstream
+	}
+	return out
+}
+
+func TestResolveCORS(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	cmd := newServeCmd(io.Discard, io.Discard)
+	if mw, err := resolveCORS(cmd, env(nil), false); err != nil || mw != nil {
+		t.Errorf("no config: mw=%v err=%v, want nil, nil", mw != nil, err)
+	}
+	if mw, err := resolveCORS(cmd, env(nil), true); err != nil || mw == nil {
+		t.Errorf("--dev: mw=%v err=%v, want permissive middleware", mw != nil, err)
+	}
+
+	mw, err := resolveCORS(cmd, env(map[string]string{
+		"WL_CORS_ORIGINS":     "https://a.example, https://b.example",
+		"WL_CORS_CREDENTIALS": "true",
+		"WL_CORS_METHODS":     "/api/scoreboard=GET",
+	}), false)
+	if err != nil || mw == nil {
+		t.Fatalf("env config: err=%v", err)
+	}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/wanted", nil)
+	req.Header.Set("Origin", "https://b.example")
+	mw(http.NotFoundHandler()).ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://b.example" || rec.Header().Get("Access-Control-Allow-Credentials") != "true" {
+		t.Errorf("headers = %v", rec.Header())
+	}
+
+	// Flags take precedence over the environment.
+	cmd = newServeCmd(io.Discard, io.Discard)
+	if err := cmd.Flags().Parse([]string{"--cors-origin", "*", "--cors-credentials"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolveCORS(cmd, env(map[string]string{"WL_CORS_ORIGINS": "https://a.example"}), false); err == nil {
+		t.Error("expected error for credentials with wildcard origin")
+	}
+
+	cmd = newServeCmd(io.Discard, io.Discard)
+	if _, err := resolveCORS(cmd, env(map[string]string{"WL_CORS_CREDENTIALS": "1"}), false); err == nil {
+		t.Error("expected error for credentials without origins")
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/backend"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/spf13/cobra"
+)
+
+func newSQLServerCmd(stdout, _ io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sql-server",
+		Short: "Inspect or stop the managed dolt sql-server for the local clone",
+		Long: `With 'wl config set sql-server true', local-backend commands talk to a
+persistent dolt sql-server for the clone instead of spawning dolt for every
+query. The server is started on first use, shared by every wl process
+working on the clone, and stops itself after ` + backend.DefaultSQLServerIdle.String() + ` without use.
+
+EXAMPLES:
+  wl sql-server status   # Show whether a server is running
+  wl sql-server stop     # Stop it now`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show the managed sql-server's state",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return runSQLServerStatus(cmd, stdout)
+			},
+		},
+		&cobra.Command{
+			Use:   "stop",
+			Short: "Stop the managed sql-server",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return runSQLServerStop(cmd, stdout)
+			},
+		},
+		newSQLServerRunCmd(),
+	)
+	return cmd
+}
+
+// newSQLServerRunCmd is the supervisor process started by
+// sqlServerLauncher; it isn't meant to be run by hand.
+func newSQLServerRunCmd() *cobra.Command {
+	var dir string
+	var port int
+	var idle time.Duration
+	cmd := &cobra.Command{
+		Use:    "run",
+		Short:  "Run a managed sql-server in the foreground",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if dir == "" || port == 0 {
+				return fmt.Errorf("--dir and --port are required")
+			}
+			return backend.RunSQLServer(dir, port, idle)
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "", "Dolt database directory to serve")
+	cmd.Flags().IntVar(&port, "port", 0, "Loopback port to listen on")
+	cmd.Flags().DurationVar(&idle, "idle", backend.DefaultSQLServerIdle, "Stop after this long without use")
+	return cmd
+}
+
+// sqlServerLauncher re-executes wl as the supervisor of a managed
+// sql-server for dir.
+func sqlServerLauncher(dir string, port int) *exec.Cmd {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = os.Args[0]
+	}
+	return exec.Command(exe, "sql-server", "run", "--dir", dir, "--port", strconv.Itoa(port))
+}
+
+// sqlServerDir returns the local clone a sql-server subcommand acts on.
+func sqlServerDir(cmd *cobra.Command) (string, error) {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return "", hintWrap(err)
+	}
+	if cfg.LocalDir == "" {
+		return "", fmt.Errorf("%s has no local clone", cfg.Upstream)
+	}
+	return cfg.LocalDir, nil
+}
+
+func runSQLServerStatus(cmd *cobra.Command, stdout io.Writer) error {
+	dir, err := sqlServerDir(cmd)
+	if err != nil {
+		return err
+	}
+	st, err := backend.ReadSQLServerState(dir)
+	if err != nil {
+		return err
+	}
+	if st == nil {
+		fmt.Fprintf(stdout, "No sql-server running for %s\n", dir)
+		return nil
+	}
+	fmt.Fprintf(stdout, "sql-server for %s\n", dir)
+	fmt.Fprintf(stdout, "  Address:    %s\n", st.Addr())
+	fmt.Fprintf(stdout, "  PID:        %d (dolt %d)\n", st.PID, st.ServerPID)
+	fmt.Fprintf(stdout, "  Started:    %s\n", st.StartedAt.Local().Format(time.DateTime))
+	if used := backend.SQLServerLastUsed(dir); !used.IsZero() {
+		fmt.Fprintf(stdout, "  Last used:  %s\n", used.Local().Format(time.DateTime))
+	}
+	return nil
+}
+
+func runSQLServerStop(cmd *cobra.Command, stdout io.Writer) error {
+	dir, err := sqlServerDir(cmd)
+	if err != nil {
+		return err
+	}
+	stopped, err := backend.StopSQLServer(dir)
+	if err != nil {
+		return err
+	}
+	if !stopped {
+		fmt.Fprintf(stdout, "No sql-server running for %s\n", dir)
+		return nil
+	}
+	fmt.Fprintf(stdout, "Stopped sql-server for %s\n", dir)
+	return nil
+}
+
+// newLocalDB opens cfg's local clone, through its managed sql-server when
+// cfg enables one.
+func newLocalDB(cfg *federation.Config) *backend.LocalDB {
+	db := backend.NewLocalDB(cfg.LocalDir, cfg.ResolveMode())
+	if cfg.SQLServer {
+		backend.SQLServerLauncher = sqlServerLauncher
+		db.UseSQLServer()
+	}
+	return db
+}
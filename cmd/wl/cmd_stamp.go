@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newStampCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stamp",
+		Short: "Inspect stamps given and received",
+		Long: `Inspect reputation stamps: the quality and reliability ratings a
+validator issues when accepting a completion.
+
+'wl stamp list' shows the stamps you gave and received, newest first.
+'wl stamp show' prints one stamp in full, with the wanted item it was
+issued for and the commit that added it.
+
+EXAMPLES:
+  wl stamp list                    # stamps you gave or received
+  wl stamp list --subject me       # stamps you received
+  wl stamp list --author bob       # stamps bob gave
+  wl stamp show s-abc123 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		newStampListCmd(stdout, stderr),
+		newStampShowCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newStampListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		author  string
+		subject string
+		limit   int
+		jsonOut bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stamps given and received",
+		Long: `List stamps, newest first. With no filter, lists the stamps you gave or
+received. --author and --subject take a rig handle, or "me" for yours.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStampList(cmd, stdout, stderr, author, subject, limit, jsonOut)
+		},
+	}
+	cmd.Flags().StringVar(&author, "author", "", `Only stamps issued by this rig ("me" for yours)`)
+	cmd.Flags().StringVar(&subject, "subject", "", `Only stamps received by this rig ("me" for yours)`)
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of stamps")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newStampShowCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "show <stamp-id>",
+		Short: "Show one stamp in full",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStampShow(cmd, stdout, stderr, args[0], jsonOut)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+// openSyncedDB opens the wasteland's database, syncing a local clone with
+// upstream first.
+func openSyncedDB(cmd *cobra.Command, stdout io.Writer) (*federation.Config, commons.DB, error) {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return nil, nil, hintWrap(err)
+	}
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return nil, nil, err
+		}
+		sp := style.StartSpinner(stdout, "Syncing with upstream...")
+		syncErr := db.Sync()
+		sp.Stop()
+		if syncErr != nil {
+			return nil, nil, fmt.Errorf("syncing with upstream: %w", syncErr)
+		}
+	}
+	return cfg, db, nil
+}
+
+// stampFilter builds the list filter, resolving "me" to handle. With
+// neither side given it matches stamps on either side of handle.
+func stampFilter(handle, author, subject string, limit int) commons.StampFilter {
+	me := func(v string) string {
+		if v == "me" {
+			return handle
+		}
+		return v
+	}
+	f := commons.StampFilter{Author: me(author), Subject: me(subject), Limit: limit}
+	if f.Author == "" && f.Subject == "" {
+		f.Rig = handle
+	}
+	return f
+}
+
+func runStampList(cmd *cobra.Command, stdout, _ io.Writer, author, subject string, limit int, jsonOut bool) error {
+	cfg, db, err := openSyncedDB(cmd, stdout)
+	if err != nil {
+		return err
+	}
+	stamps, err := commons.QueryStamps(db, stampFilter(cfg.RigHandle, author, subject, limit))
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		if stamps == nil {
+			stamps = []commons.StampRecord{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stamps)
+	}
+	renderStampList(stdout, stamps)
+	return nil
+}
+
+func runStampShow(cmd *cobra.Command, stdout, _ io.Writer, stampID string, jsonOut bool) error {
+	_, db, err := openSyncedDB(cmd, stdout)
+	if err != nil {
+		return err
+	}
+	s, err := commons.QueryStampRecord(db, stampID)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+	renderStamp(stdout, s)
+	return nil
+}
+
+func renderStampList(w io.Writer, stamps []commons.StampRecord) {
+	if len(stamps) == 0 {
+		fmt.Fprintln(w, "No stamps found.")
+		return
+	}
+	tbl := style.NewTable(
+		style.Column{Name: "ID", Width: 14},
+		style.Column{Name: "AUTHOR", Width: 14},
+		style.Column{Name: "SUBJECT", Width: 14},
+		style.Column{Name: "VALENCE", Width: 24},
+		style.Column{Name: "SEVERITY", Width: 8},
+		style.Column{Name: "ITEM", Width: 12},
+		style.Column{Name: "DATE", Width: 10},
+	)
+	for _, s := range stamps {
+		tbl.AddRow(s.ID, s.Author, s.Subject, formatValence(s.Valence), s.Severity, s.WantedID, dateOnly(s.CreatedAt))
+	}
+	fmt.Fprint(w, tbl.Render())
+}
+
+func renderStamp(w io.Writer, s *commons.StampRecord) {
+	fmt.Fprintf(w, "%s\n\n", style.Bold.Render("Stamp "+s.ID))
+	field := func(name, value string) {
+		if value == "" {
+			value = style.Dim.Render("-")
+		}
+		fmt.Fprintf(w, "  %-10s %s\n", name+":", value)
+	}
+	field("Author", s.Author)
+	field("Subject", s.Subject)
+	field("Valence", formatValence(s.Valence))
+	field("Severity", s.Severity)
+	field("Skills", strings.Join(s.SkillTags, ", "))
+	context := s.ContextID
+	if s.ContextType != "" && context != "" {
+		context = s.ContextType + " " + context
+	}
+	field("Context", context)
+	item := s.WantedID
+	if s.WantedTitle != "" {
+		item += " " + s.WantedTitle
+	}
+	field("Item", item)
+	field("Created", s.CreatedAt)
+	field("Message", s.Message)
+	if c := s.Commit; c != nil {
+		field("Commit", fmt.Sprintf("%s by %s at %s", c.Hash, c.Committer, c.Date))
+		if c.Message != "" {
+			fmt.Fprintf(w, "  %-10s %s\n", "", style.Dim.Render(c.Message))
+		}
+	}
+}
+
+// formatValence renders a parsed valence as "quality=4 reliability=5",
+// quality and reliability first and any other dimensions after, sorted.
+func formatValence(v map[string]float64) string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	rank := func(k string) int {
+		switch k {
+		case "quality":
+			return 0
+		case "reliability":
+			return 1
+		}
+		return 2
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if d := rank(a) - rank(b); d != 0 {
+			return d
+		}
+		return strings.Compare(a, b)
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%g", k, v[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// dateOnly trims a timestamp to its date.
+func dateOnly(ts string) string {
+	if len(ts) > 10 {
+		return ts[:10]
+	}
+	return ts
+}
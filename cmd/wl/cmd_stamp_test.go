@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestStampFilter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		author, subject string
+		want            commons.StampFilter
+	}{
+		{"default is either side", "", "", commons.StampFilter{Rig: "alice", Limit: 10}},
+		{"author me", "me", "", commons.StampFilter{Author: "alice", Limit: 10}},
+		{"subject me", "", "me", commons.StampFilter{Subject: "alice", Limit: 10}},
+		{"other rig", "bob", "me", commons.StampFilter{Author: "bob", Subject: "alice", Limit: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := stampFilter("alice", tt.author, tt.subject, 10); got != tt.want {
+				t.Errorf("stampFilter = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatValence(t *testing.T) {
+	t.Parallel()
+	got := formatValence(map[string]float64{"creativity": 3, "reliability": 5, "quality": 4.5})
+	if want := "quality=4.5 reliability=5 creativity=3"; got != want {
+		t.Errorf("formatValence = %q, want %q", got, want)
+	}
+}
+
+func TestRenderStamp(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderStamp(&buf, &commons.StampRecord{
+		ID: "s-1", Author: "bob", Subject: "alice",
+		Valence:  map[string]float64{"quality": 4, "reliability": 5},
+		Severity: "leaf", SkillTags: []string{"go", "sql"},
+		ContextType: "completion", ContextID: "c-1",
+		WantedID: "w-1", WantedTitle: "Fix auth",
+		Commit: &commons.StampCommit{Hash: "abc123", Committer: "bob", Date: "2026-03-02", Message: "wl accept: w-1"},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"Stamp s-1", "quality=4 reliability=5", "go, sql", "completion c-1", "w-1 Fix auth", "abc123 by bob", "wl accept: w-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
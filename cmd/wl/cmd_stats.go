@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newStatsCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		burndown  bool
+		since     string
+		project   string
+		milestone string
+		jsonOut   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show wanted-board counts, burndown and cycle time",
+		Long: `Show how many wanted items are in each status.
+
+With --burndown, walks the board's Dolt history (dolt_history_wanted) and
+reports the number of unfinished items (open, claimed or in review) at
+the end of each day over --since, drawn as a sparkline with a daily
+table, plus cycle-time percentiles — first claim to completion — for
+items completed in that period. --json emits the same report for
+dashboards.
+
+--project narrows the report to one project and --milestone to items
+carrying the milestone's tag.
+
+Examples:
+  wl stats
+  wl stats --burndown
+  wl stats --burndown --since 2w --project gastown
+  wl stats --burndown --milestone v1.0 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			period, err := commons.ParsePeriod(since)
+			if err != nil {
+				return err
+			}
+			if !burndown && cmd.Flags().Changed("since") {
+				return fmt.Errorf("--since applies to --burndown")
+			}
+			f := commons.StatsFilter{Project: project, Milestone: milestone}
+			return runStats(cmd, stdout, stderr, f, burndown, period, jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&burndown, "burndown", false, "Report unfinished items over time and cycle-time percentiles")
+	cmd.Flags().StringVar(&since, "since", "30d", "Burndown period (e.g. 30d, 2w)")
+	cmd.Flags().StringVar(&project, "project", "", "Only items in this project")
+	cmd.Flags().StringVar(&milestone, "milestone", "", "Only items tagged with this milestone")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, stdout, _ io.Writer, f commons.StatsFilter, burndown bool, period time.Duration, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return err
+		}
+		sp := style.StartSpinner(stdout, "Syncing with upstream...")
+		syncErr := db.Sync()
+		sp.Stop()
+		if syncErr != nil {
+			return fmt.Errorf("syncing with upstream: %w", syncErr)
+		}
+	}
+
+	if !burndown {
+		counts, err := commons.QueryStatusCounts(db, f)
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			if counts == nil {
+				counts = []commons.StatusCount{}
+			}
+			enc := json.NewEncoder(stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(counts)
+		}
+		renderStatusCounts(stdout, counts)
+		return nil
+	}
+
+	history, err := commons.QueryStatusHistory(db, f)
+	if err != nil {
+		return err
+	}
+	to := time.Now().UTC()
+	from := to.Add(-period)
+	points := commons.Burndown(history, from, to)
+	cycle := commons.CycleTimes(history, from, to)
+
+	if jsonOut {
+		return renderBurndownJSON(stdout, points, cycle)
+	}
+	renderBurndown(stdout, f, points, cycle)
+	return nil
+}
+
+func renderStatusCounts(w io.Writer, counts []commons.StatusCount) {
+	if len(counts) == 0 {
+		fmt.Fprintln(w, "No wanted items.")
+		return
+	}
+	tbl := style.NewTable(
+		style.Column{Name: "STATUS", Width: 12},
+		style.Column{Name: "ITEMS", Width: 6, Align: style.AlignRight},
+	)
+	total := 0
+	for _, c := range counts {
+		tbl.AddRow(c.Status, fmt.Sprintf("%d", c.Count))
+		total += c.Count
+	}
+	fmt.Fprintf(w, "%d wanted item(s):\n\n", total)
+	fmt.Fprint(w, tbl.Render())
+}
+
+// renderBurndown writes the burndown sparkline, the daily counts and the
+// cycle-time percentiles.
+func renderBurndown(w io.Writer, f commons.StatsFilter, points []commons.BurndownPoint, cycle commons.CycleTime) {
+	title := "Burndown"
+	var scope []string
+	if f.Project != "" {
+		scope = append(scope, "project "+f.Project)
+	}
+	if f.Milestone != "" {
+		scope = append(scope, "milestone "+f.Milestone)
+	}
+	if len(scope) > 0 {
+		title += " (" + strings.Join(scope, ", ") + ")"
+	}
+	fmt.Fprintf(w, "%s\n\n", style.Bold.Render(title))
+
+	if len(points) > 0 {
+		values := make([]int, len(points))
+		for i, p := range points {
+			values[i] = p.Open
+		}
+		first, last := points[0], points[len(points)-1]
+		fmt.Fprintf(w, "  %s  %d → %d unfinished\n", sparkline(values), first.Open, last.Open)
+		fmt.Fprintf(w, "  %s\n\n", style.Dim.Render(first.Date.Format(time.DateOnly)+" … "+last.Date.Format(time.DateOnly)))
+
+		tbl := style.NewTable(
+			style.Column{Name: "DATE", Width: 10},
+			style.Column{Name: "OPEN", Width: 5, Align: style.AlignRight},
+		)
+		for _, p := range points {
+			tbl.AddRow(p.Date.Format(time.DateOnly), fmt.Sprintf("%d", p.Open))
+		}
+		fmt.Fprint(w, tbl.Render())
+		fmt.Fprintln(w)
+	}
+
+	if cycle.Completed == 0 {
+		fmt.Fprintf(w, "Cycle time: %s\n", style.Dim.Render("no items completed in this period"))
+		return
+	}
+	fmt.Fprintf(w, "Cycle time (%d completed): p50 %s  p75 %s  p90 %s\n", cycle.Completed,
+		formatDuration(cycle.P50), formatDuration(cycle.P75), formatDuration(cycle.P90))
+}
+
+// sparkBlocks are the sparkline levels, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as block characters scaled between their
+// minimum and maximum.
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = (v - lo) * (len(sparkBlocks) - 1) / (hi - lo)
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+func renderBurndownJSON(w io.Writer, points []commons.BurndownPoint, cycle commons.CycleTime) error {
+	type pointJSON struct {
+		Date string `json:"date"`
+		Open int    `json:"open"`
+	}
+	type cycleJSON struct {
+		Completed int     `json:"completed"`
+		P50Hours  float64 `json:"p50_hours"`
+		P75Hours  float64 `json:"p75_hours"`
+		P90Hours  float64 `json:"p90_hours"`
+	}
+	out := struct {
+		Burndown  []pointJSON `json:"burndown"`
+		CycleTime cycleJSON   `json:"cycle_time"`
+	}{
+		Burndown: make([]pointJSON, 0, len(points)),
+		CycleTime: cycleJSON{
+			Completed: cycle.Completed,
+			P50Hours:  cycle.P50.Hours(),
+			P75Hours:  cycle.P75.Hours(),
+			P90Hours:  cycle.P90.Hours(),
+		},
+	}
+	for _, p := range points {
+		out.Burndown = append(out.Burndown, pointJSON{Date: p.Date.Format(time.DateOnly), Open: p.Open})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
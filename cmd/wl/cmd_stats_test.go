@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+	if got := sparkline([]int{0, 7, 14}); got != "▁▄█" {
+		t.Errorf("sparkline = %q", got)
+	}
+	if got := sparkline([]int{3, 3}); got != "▁▁" {
+		t.Errorf("flat sparkline = %q", got)
+	}
+}
+
+func TestRenderBurndown(t *testing.T) {
+	t.Parallel()
+	points := []commons.BurndownPoint{
+		{Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Open: 5},
+		{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Open: 2},
+	}
+	cycle := commons.CycleTime{Completed: 3, P50: 36 * time.Hour, P75: 48 * time.Hour, P90: 72 * time.Hour}
+
+	var buf bytes.Buffer
+	renderBurndown(&buf, commons.StatsFilter{Project: "gastown"}, points, cycle)
+	out := buf.String()
+	for _, want := range []string{"Burndown (project gastown)", "█▁", "5 → 2 unfinished", "2026-03-02", "Cycle time (3 completed): p50 1d  p75 2d  p90 3d"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := renderBurndownJSON(&buf, points, cycle); err != nil {
+		t.Fatalf("renderBurndownJSON: %v", err)
+	}
+	var got struct {
+		Burndown []struct {
+			Date string `json:"date"`
+			Open int    `json:"open"`
+		} `json:"burndown"`
+		CycleTime struct {
+			P50Hours float64 `json:"p50_hours"`
+		} `json:"cycle_time"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding JSON: %v", err)
+	}
+	if len(got.Burndown) != 2 || got.Burndown[0].Date != "2026-03-01" || got.CycleTime.P50Hours != 36 {
+		t.Errorf("JSON = %+v", got)
+	}
+}
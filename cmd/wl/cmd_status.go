@@ -1,33 +1,100 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
 
 func newStatusCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
-		Use:     "status <wanted-id>",
+	var (
+		jsonOut  bool
+		watch    bool
+		all      bool
+		delta    bool
+		interval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:     "status [wanted-id]",
 		Aliases: []string{"show"},
-		Short:   "Show detailed status for a wanted item",
-		Long: `Show the full lifecycle status of a wanted item.
+		Short:   "Show federation health or detailed status for a wanted item",
+		Long: `Show federation health, or the full lifecycle status of a wanted item.
+
+Without arguments, fetches upstream and origin and reports how your local
+clone relates to them: commits behind and ahead of upstream, commits not
+yet pushed to your fork, and open PRs. Each pending wl/<handle>/* branch
+is listed with the age of its last commit and flagged when it no longer
+merges cleanly into main. With --all, every joined wasteland is reported.
 
-Displays all fields including description, timestamps, and conditionally
-shows completion and stamp details based on the item's current state.
+With a wanted ID, displays all fields including description, timestamps,
+and conditionally shows completion and stamp details based on the item's
+current state.
+
+With --delta, lists only the items whose state on your wl/<handle>/*
+branches differs from main, with the field-level changes each branch
+carries — exactly what merging your branches would propose upstream.
+
+--json, --watch and --all apply to federation status (--json also to
+--delta). With --watch the report is refreshed every --interval until
+interrupted; combined with --json, one JSON object is written per line.
 
 Examples:
+  wl status
+  wl status --json
+  wl status --all
+  wl status --watch --interval 1m
+  wl status --delta
   wl status w-abc123`,
-		Args:              cobra.ExactArgs(1),
+		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: completeWantedIDs(""),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if delta {
+				if len(args) > 0 || watch || all {
+					return fmt.Errorf("--delta cannot be combined with a wanted ID, --watch or --all")
+				}
+				return runStatusDelta(cmd, stdout, stderr, jsonOut)
+			}
+			if len(args) == 0 {
+				if interval <= 0 {
+					return fmt.Errorf("--interval must be positive")
+				}
+				if explicit, _ := cmd.Flags().GetString("wasteland"); all && explicit != "" {
+					return fmt.Errorf("--wasteland cannot be combined with --all")
+				}
+				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				return runFederationStatus(ctx, cmd, stdout, stderr, jsonOut, watch, all, interval)
+			}
+			if jsonOut || watch || all {
+				return fmt.Errorf("--json, --watch and --all apply to federation status; run 'wl status' without a wanted ID")
+			}
 			return runStatus(cmd, stdout, stderr, args[0])
 		},
 	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output federation status as JSON")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Refresh federation status until interrupted")
+	cmd.Flags().BoolVar(&all, "all", false, "Report federation status for every joined wasteland")
+	cmd.Flags().BoolVar(&delta, "delta", false, "List only items whose state on your branches differs from main")
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "Refresh interval for --watch")
+
+	return cmd
 }
 
 func runStatus(cmd *cobra.Command, stdout, _ io.Writer, wantedID string) error {
@@ -51,13 +118,84 @@ func runStatus(cmd *cobra.Command, stdout, _ io.Writer, wantedID string) error {
 		return fmt.Errorf("querying wanted item: %w", err)
 	}
 	if detail.Item == nil {
-		return fmt.Errorf("wanted item %s not found", wantedID)
+		return &commons.NotFoundError{Message: fmt.Sprintf("wanted item %s not found", wantedID)}
 	}
 
 	renderDetailStatus(stdout, detail)
 	return nil
 }
 
+func runStatusDelta(cmd *cobra.Command, stdout, _ io.Writer, jsonOut bool) error {
+	wlCfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	client, err := newSDKClient(wlCfg, false)
+	if err != nil {
+		return err
+	}
+
+	deltas, err := client.Deltas()
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return renderStatusDeltaJSON(stdout, deltas)
+	}
+	renderStatusDelta(stdout, deltas)
+	return nil
+}
+
+// renderStatusDelta writes each item that differs from main followed by
+// the row changes its branch carries.
+func renderStatusDelta(w io.Writer, deltas []sdk.ItemDelta) {
+	if len(deltas) == 0 {
+		fmt.Fprintln(w, "No pending changes: your branches match main.")
+		return
+	}
+
+	fmt.Fprintf(w, "%d item(s) differ from main:\n", len(deltas))
+	for _, d := range deltas {
+		header := d.WantedID
+		if d.Title != "" {
+			header += ": " + d.Title
+		}
+		fmt.Fprintf(w, "\n%s\n", style.Bold.Render(header))
+		meta := "  " + d.Branch
+		if d.Delta != "" {
+			meta += "  " + d.Delta
+		}
+		if d.PRURL != "" {
+			meta += "  " + d.PRURL
+		}
+		fmt.Fprintln(w, style.Dim.Render(meta))
+		renderRowChanges(w, d.Changes)
+	}
+}
+
+func renderStatusDeltaJSON(w io.Writer, deltas []sdk.ItemDelta) error {
+	type deltaJSON struct {
+		WantedID string              `json:"wanted_id"`
+		Title    string              `json:"title"`
+		Branch   string              `json:"branch"`
+		Delta    string              `json:"delta"`
+		PRURL    string              `json:"pr_url,omitempty"`
+		Changes  []commons.RowChange `json:"changes"`
+	}
+	out := make([]deltaJSON, 0, len(deltas))
+	for _, d := range deltas {
+		out = append(out, deltaJSON{
+			WantedID: d.WantedID, Title: d.Title, Branch: d.Branch, Delta: d.Delta,
+			PRURL: d.PRURL, Changes: d.Changes,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
 // renderDetailStatus writes the formatted status output from an SDK DetailResult.
 func renderDetailStatus(w io.Writer, r *sdk.DetailResult) {
 	item := r.Item
@@ -90,6 +228,11 @@ func renderDetailStatus(w io.Writer, r *sdk.DetailResult) {
 		fmt.Fprintf(w, "  Posted by:   %s\n", item.PostedBy)
 	}
 
+	// Parent of a follow-up item
+	if item.ParentID != "" {
+		fmt.Fprintf(w, "  Follows up:  %s\n", item.ParentID)
+	}
+
 	// Tags
 	if len(item.Tags) > 0 {
 		fmt.Fprintf(w, "  Tags:        %s\n", strings.Join(item.Tags, ", "))
@@ -113,7 +256,10 @@ func renderDetailStatus(w io.Writer, r *sdk.DetailResult) {
 	// Claimed by
 	if item.ClaimedBy != "" {
 		fmt.Fprintln(w)
-		fmt.Fprintf(w, "  Claimed by:  %s\n", item.ClaimedBy)
+		fmt.Fprintf(w, "  Claimed by:  %s%s\n", item.ClaimedBy, renderClaimAge(r))
+	}
+	if len(r.Queue) > 0 {
+		fmt.Fprintf(w, "  Queue:       %s\n", commons.QueueSummary(r.Queue))
 	}
 
 	// Branch info (PR mode)
@@ -138,10 +284,14 @@ func renderDetailStatus(w io.Writer, r *sdk.DetailResult) {
 	if r.Completion != nil {
 		fmt.Fprintln(w)
 		fmt.Fprintf(w, "  Completion:  %s\n", r.Completion.ID)
-		if r.Completion.Evidence != "" {
-			fmt.Fprintf(w, "    Evidence:    %s\n", r.Completion.Evidence)
-		}
+		renderEvidence(w, r)
 		fmt.Fprintf(w, "    Completed by: %s\n", r.Completion.CompletedBy)
+		if progress := r.ApprovalProgress(); progress != "" {
+			if len(r.Approvals) > 0 {
+				progress += " (" + strings.Join(commons.Approvers(r.Approvals), ", ") + ")"
+			}
+			fmt.Fprintf(w, "    Approvals:   %s\n", progress)
+		}
 	}
 
 	// Stamp
@@ -160,17 +310,445 @@ func renderDetailStatus(w io.Writer, r *sdk.DetailResult) {
 			fmt.Fprintf(w, "    Message:     %s\n", r.Stamp.Message)
 		}
 	}
+
+	// Follow-ups posted by partial accepts
+	if len(r.FollowUps) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "  Follow-ups:")
+		for _, f := range r.FollowUps {
+			fmt.Fprintf(w, "    %s  %-10s  %s\n", f.ID, f.Status, f.Title)
+		}
+	}
+}
+
+// renderEvidence prints a completion's evidence: one entry on the
+// Evidence line, several as a list with their kinds.
+func renderEvidence(w io.Writer, r *sdk.DetailResult) {
+	entries := r.Evidence
+	if len(entries) == 0 && r.Completion.Evidence != "" {
+		entries = []commons.Evidence{commons.ParseEvidence(r.Completion.Evidence)}
+	}
+	switch len(entries) {
+	case 0:
+	case 1:
+		fmt.Fprintf(w, "    Evidence:    %s %s%s\n", entries[0].Value, style.Dim.Render("("+entries[0].Kind+")"), verifiedMark(entries[0]))
+	default:
+		fmt.Fprintf(w, "    Evidence:    %d entries\n", len(entries))
+		for _, e := range entries {
+			fmt.Fprintf(w, "      %-9s %s%s\n", e.Kind, e.Value, verifiedMark(e))
+		}
+	}
+}
+
+// verifiedMark flags evidence that was checked to exist when submitted.
+func verifiedMark(e commons.Evidence) string {
+	if !e.Verified {
+		return ""
+	}
+	return " " + style.Success.Render("✓ verified")
+}
+
+// renderClaimAge renders a claimed item's idle time, highlighted near and
+// past the wasteland's claim expiry.
+func renderClaimAge(r *sdk.DetailResult) string {
+	age, ok := r.ClaimAge(time.Now().UTC())
+	if !ok {
+		return ""
+	}
+	label := "  (" + commons.ClaimAgeLabel(age, r.ClaimExpiry) + ")"
+	switch {
+	case r.ClaimExpiry > 0 && age >= r.ClaimExpiry:
+		return style.Error.Render(label)
+	case commons.ClaimNearExpiry(age, r.ClaimExpiry):
+		return style.Warning.Render(label)
+	default:
+		return style.Dim.Render(label)
+	}
 }
 
 func colorizeStatus(status string) string {
+	label := i18n.Status(status)
 	switch status {
 	case "completed":
-		return style.Success.Render(status)
+		return style.Success.Render(label)
 	case "in_review", "claimed":
-		return style.Warning.Render(status)
+		return style.Warning.Render(label)
 	case "withdrawn":
-		return style.Dim.Render(status)
+		return style.Dim.Render(label)
+	default:
+		return label
+	}
+}
+
+// federationStatus is the health report printed by 'wl status' with no
+// arguments. Counts are -1 when they could not be determined.
+type federationStatus struct {
+	Wasteland       string              `json:"wasteland"`
+	RigHandle       string              `json:"rig_handle"`
+	Profile         string              `json:"profile,omitempty"`
+	Mode            string              `json:"mode"`
+	Backend         string              `json:"backend"`
+	LastSyncAt      *time.Time          `json:"last_sync_at,omitempty"`
+	Behind          int                 `json:"behind"`   // upstream/main commits not in main
+	Ahead           int                 `json:"ahead"`    // main commits not in upstream/main
+	Unsynced        int                 `json:"unsynced"` // main commits not pushed to origin
+	PendingBranches []pendingBranch     `json:"pending_branches"`
+	OpenPRs         int                 `json:"open_prs"`
+	RateLimits      []commons.RateLimit `json:"rate_limits,omitempty"`
+	CheckedAt       time.Time           `json:"checked_at"`
+	Errors          []string            `json:"errors,omitempty"`
+}
+
+// pendingBranch is a wl/<handle>/* branch that has not been cleaned up.
+// Conflicts counts the data and schema conflicts merging it into main
+// would produce; -1 means the check failed.
+type pendingBranch struct {
+	Branch       string     `json:"branch"`
+	WantedID     string     `json:"wanted_id"`
+	LastCommitAt *time.Time `json:"last_commit_at,omitempty"`
+	Conflicts    int        `json:"conflicts"`
+	PRURL        string     `json:"pr_url,omitempty"`
+}
+
+// statusDeps holds the external operations used to collect federation
+// status, so tests can substitute fakes.
+type statusDeps struct {
+	fetch      func(dbDir, remote string) error
+	doltQuery  func(dbDir, query string) (string, error)
+	findPR     func(cfg *federation.Config, branch string) string
+	rateLimits func(cfg *federation.Config) []commons.RateLimit // nil skips the quota check
+	now        func() time.Time
+}
+
+func defaultStatusDeps() *statusDeps {
+	return &statusDeps{
+		fetch:      commons.FetchRemote,
+		doltQuery:  commons.DoltSQLQuery,
+		findPR:     checkPRForBranch,
+		rateLimits: probeRateLimits,
+		now:        time.Now,
+	}
+}
+
+func runFederationStatus(ctx context.Context, cmd *cobra.Command, stdout, _ io.Writer, jsonOut, watch, all bool, interval time.Duration) error {
+	var cfgs []*federation.Config
+	if all {
+		var err error
+		cfgs, err = loadAllConfigs(cmd, federation.NewConfigStore())
+		if err != nil {
+			return err
+		}
+	} else {
+		cfg, err := resolveWasteland(cmd)
+		if err != nil {
+			return hintWrap(err)
+		}
+		cfgs = []*federation.Config{cfg}
+	}
+	for _, cfg := range cfgs {
+		if cfg.ResolveBackend() == federation.BackendLocal {
+			if err := requireDolt(); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	deps := defaultStatusDeps()
+	report := func() error {
+		statuses := make([]*federationStatus, 0, len(cfgs))
+		for _, cfg := range cfgs {
+			statuses = append(statuses, collectFederationStatus(cfg, deps))
+		}
+		if jsonOut {
+			if all {
+				return writeFederationStatusJSON(stdout, statuses, watch)
+			}
+			return writeFederationStatusJSON(stdout, statuses[0], watch)
+		}
+		if watch {
+			fmt.Fprintf(stdout, "%s\n", style.Dim.Render("── "+statuses[0].CheckedAt.Format(time.TimeOnly)+" ──"))
+		}
+		for i, st := range statuses {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			renderFederationStatus(stdout, st)
+		}
+		return nil
+	}
+
+	if !watch {
+		return report()
+	}
+	return watchLoop(ctx, interval, report)
+}
+
+// loadAllConfigs loads every joined wasteland, applying the --local-db
+// backend override the same way resolveWasteland does.
+func loadAllConfigs(cmd *cobra.Command, store federation.ConfigStore) ([]*federation.Config, error) {
+	names, err := store.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing wastelands: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, hintWrap(federation.ErrNotJoined)
+	}
+	localDB, _ := cmd.Flags().GetBool("local-db")
+	cfgs := make([]*federation.Config, 0, len(names))
+	for _, name := range names {
+		cfg, err := store.Load(name)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", name, err)
+		}
+		if localDB {
+			cfg.Backend = federation.BackendLocal
+		} else {
+			cfg.Backend = federation.BackendRemote
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, nil
+}
+
+// watchLoop calls fn immediately and then every interval until ctx is
+// done or fn returns an error.
+func watchLoop(ctx context.Context, interval time.Duration, fn func() error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := fn(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectFederationStatus fetches upstream and origin and reports how the
+// local clone relates to them. Individual failures are recorded in Errors
+// rather than aborting the report.
+func collectFederationStatus(cfg *federation.Config, deps *statusDeps) *federationStatus {
+	st := &federationStatus{
+		Wasteland:  cfg.Upstream,
+		RigHandle:  cfg.RigHandle,
+		Profile:    cfg.Profile,
+		Mode:       cfg.ResolveMode(),
+		Backend:    cfg.ResolveBackend(),
+		LastSyncAt: cfg.LastSyncAt,
+		Behind:     -1,
+		Ahead:      -1,
+		Unsynced:   -1,
+		CheckedAt:  deps.now(),
+	}
+	if deps.rateLimits != nil {
+		st.RateLimits = deps.rateLimits(cfg)
+	}
+	if st.Backend != federation.BackendLocal {
+		// Remote mode reads and writes the hosted database directly, so
+		// there is no local clone to drift.
+		st.Behind, st.Ahead, st.Unsynced = 0, 0, 0
+		return st
+	}
+
+	dir := cfg.LocalDir
+	upstreamOK := true
+	if err := deps.fetch(dir, "upstream"); err != nil {
+		upstreamOK = false
+		st.Errors = append(st.Errors, fmt.Sprintf("fetching upstream: %v", err))
+	}
+	originOK := true
+	if err := deps.fetch(dir, "origin"); err != nil {
+		originOK = false
+		st.Errors = append(st.Errors, fmt.Sprintf("fetching origin: %v", err))
+	}
+
+	count := func(rangeSpec string) int {
+		n, err := countCommits(deps.doltQuery, dir, rangeSpec)
+		if err != nil {
+			st.Errors = append(st.Errors, fmt.Sprintf("counting %s: %v", rangeSpec, err))
+			return -1
+		}
+		return n
+	}
+	if upstreamOK {
+		st.Behind = count("main..upstream/main")
+		st.Ahead = count("upstream/main..main")
+	}
+	if originOK {
+		st.Unsynced = count("origin/main..main")
+	}
+
+	out, err := deps.doltQuery(dir, fmt.Sprintf(
+		"SELECT name, latest_commit_date FROM dolt_branches WHERE name LIKE '%s%%' ORDER BY name",
+		commons.EscapeLIKE("wl/"+cfg.RigHandle+"/"),
+	))
+	if err != nil {
+		st.Errors = append(st.Errors, fmt.Sprintf("listing branches: %v", err))
+	}
+	st.PendingBranches = []pendingBranch{}
+	rows := wlParseCSV(out)
+	for i := 1; i < len(rows); i++ {
+		if len(rows[i]) == 0 || rows[i][0] == "" {
+			continue
+		}
+		pb := pendingBranch{Branch: rows[i][0], WantedID: extractWantedID(rows[i][0])}
+		if len(rows[i]) > 1 {
+			if t, ok := commons.ParseDoltTime(rows[i][1]); ok {
+				pb.LastCommitAt = &t
+			}
+		}
+		pb.Conflicts, err = countMergeConflicts(deps, dir, pb.Branch)
+		if err != nil {
+			pb.Conflicts = -1
+			st.Errors = append(st.Errors, fmt.Sprintf("checking %s merges cleanly: %v", pb.Branch, err))
+		}
+		if st.Mode == federation.ModePR {
+			pb.PRURL = deps.findPR(cfg, pb.Branch)
+			if pb.PRURL != "" {
+				st.OpenPRs++
+			}
+		}
+		st.PendingBranches = append(st.PendingBranches, pb)
+	}
+	return st
+}
+
+// countCommits returns the number of commits in a dolt_log two-dot range.
+func countCommits(doltQuery func(dbDir, query string) (string, error), dir, rangeSpec string) (int, error) {
+	out, err := doltQuery(dir, fmt.Sprintf(
+		"SELECT COUNT(*) AS n FROM dolt_log('%s')", commons.EscapeSQL(rangeSpec),
+	))
+	if err != nil {
+		return 0, err
+	}
+	rows := wlParseCSV(out)
+	if len(rows) < 2 || len(rows[1]) == 0 {
+		return 0, fmt.Errorf("unexpected output %q", out)
+	}
+	return strconv.Atoi(rows[1][0])
+}
+
+// countMergeConflicts returns the number of data and schema conflicts
+// merging branch into main would produce, without performing the merge.
+func countMergeConflicts(deps *statusDeps, dir, branch string) (int, error) {
+	out, err := deps.doltQuery(dir, fmt.Sprintf(
+		"SELECT COALESCE(SUM(num_data_conflicts + num_schema_conflicts), 0) AS n FROM dolt_preview_merge_conflicts_summary('main', '%s')",
+		commons.EscapeSQL(branch),
+	))
+	if err != nil {
+		return 0, err
+	}
+	rows := wlParseCSV(out)
+	if len(rows) < 2 || len(rows[1]) == 0 {
+		return 0, fmt.Errorf("unexpected output %q", out)
+	}
+	return strconv.Atoi(rows[1][0])
+}
+
+// writeFederationStatusJSON writes v, a *federationStatus or a slice of
+// them, as JSON.
+func writeFederationStatusJSON(w io.Writer, v any, compact bool) error {
+	enc := json.NewEncoder(w)
+	if !compact {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(v)
+}
+
+func renderFederationStatus(w io.Writer, st *federationStatus) {
+	fmt.Fprintf(w, "%s\n", style.Bold.Render(st.Wasteland))
+	if st.Profile != "" {
+		fmt.Fprintf(w, "  Rig:         %s (profile %s)\n", st.RigHandle, st.Profile)
+	} else {
+		fmt.Fprintf(w, "  Rig:         %s\n", st.RigHandle)
+	}
+	fmt.Fprintf(w, "  Mode:        %s (%s backend)\n", st.Mode, st.Backend)
+	if st.LastSyncAt != nil {
+		fmt.Fprintf(w, "  Last sync:   %s ago\n", formatDuration(st.CheckedAt.Sub(*st.LastSyncAt)))
+	} else {
+		fmt.Fprintf(w, "  Last sync:   %s\n", style.Dim.Render("never"))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "  Upstream:    %s\n", formatDrift(st.Behind, "behind", "run 'wl sync'"))
+	fmt.Fprintf(w, "               %s\n", formatDrift(st.Ahead, "ahead", ""))
+	fmt.Fprintf(w, "  Origin:      %s\n", formatDrift(st.Unsynced, "unpushed", ""))
+
+	fmt.Fprintf(w, "  Branches:    %d pending", len(st.PendingBranches))
+	if st.Mode == federation.ModePR {
+		fmt.Fprintf(w, ", %d with open PRs", st.OpenPRs)
+	}
+	if n := conflictingBranches(st.PendingBranches); n > 0 {
+		fmt.Fprintf(w, ", %s", style.Warning.Render(fmt.Sprintf("%d not applying cleanly", n)))
+	}
+	fmt.Fprintln(w)
+	for _, pb := range st.PendingBranches {
+		line := "    " + pb.Branch
+		if pb.LastCommitAt != nil {
+			line += "  " + style.Dim.Render(formatDuration(st.CheckedAt.Sub(*pb.LastCommitAt))+" old")
+		}
+		if pb.Conflicts > 0 {
+			line += "  " + style.Warning.Render(fmt.Sprintf("%s %d conflicts with main", style.IconWarn, pb.Conflicts))
+		}
+		if pb.PRURL != "" {
+			line += "  " + style.Dim.Render(pb.PRURL)
+		}
+		fmt.Fprintln(w, line)
+	}
+	for i, rl := range st.RateLimits {
+		label := "  API quota:   "
+		if i > 0 {
+			label = "               "
+		}
+		fmt.Fprintf(w, "%s%s\n", label, formatRateLimit(rl, st.CheckedAt))
+	}
+
+	for _, e := range st.Errors {
+		fmt.Fprintf(w, "  %s %s\n", style.Warning.Render(style.IconWarn), e)
+	}
+}
+
+// formatRateLimit renders a provider's quota, warning when it is low or
+// exhausted.
+func formatRateLimit(rl commons.RateLimit, now time.Time) string {
+	desc := rl.Describe(now)
+	switch {
+	case rl.Low(now) && rl.Limited:
+		return style.Error.Render(style.IconFail) + " " + desc
+	case rl.Low(now):
+		return style.Warning.Render(style.IconWarn) + " " + desc
+	default:
+		return style.Success.Render(style.IconPass) + " " + desc
+	}
+}
+
+// conflictingBranches counts the branches that would conflict with main.
+func conflictingBranches(branches []pendingBranch) int {
+	n := 0
+	for _, pb := range branches {
+		if pb.Conflicts > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// formatDrift renders a commit count; n < 0 means unknown.
+func formatDrift(n int, label, hint string) string {
+	switch {
+	case n < 0:
+		return style.Dim.Render("unknown")
+	case n == 0:
+		return style.Success.Render(style.IconPass) + " 0 " + label
 	default:
-		return status
+		s := style.Warning.Render(style.IconWarn) + fmt.Sprintf(" %d %s", n, label)
+		if hint != "" {
+			s += " " + style.Dim.Render("("+hint+")")
+		}
+		return s
 	}
 }
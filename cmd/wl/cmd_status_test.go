@@ -2,10 +2,15 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
 	"github.com/gastownhall/wasteland/internal/sdk"
 )
 
@@ -140,6 +145,65 @@ func TestRenderDetailStatus_Completed(t *testing.T) {
 	}
 }
 
+func TestRenderDetailStatus_ApprovalProgress(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderDetailStatus(&buf, &sdk.DetailResult{
+		Item: &commons.WantedItem{ID: "w-abc123", Title: "Fix the login bug", Status: "in_review"},
+		Completion: &commons.CompletionRecord{
+			ID:          "c-abc123def456ab",
+			WantedID:    "w-abc123",
+			CompletedBy: "worker-rig",
+		},
+		Quorum:    2,
+		Approvals: []commons.Approval{{CompletionID: "c-abc123def456ab", Approver: "reviewer-rig"}},
+	})
+
+	if out := buf.String(); !strings.Contains(out, "Approvals:   1/2 approvals (reviewer-rig)") {
+		t.Errorf("output missing approval progress:\n%s", out)
+	}
+}
+
+func TestRenderDetailStatus_FollowUps(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderDetailStatus(&buf, &sdk.DetailResult{
+		Item:      &commons.WantedItem{ID: "w-abc123", Title: "Fix the login bug", Status: "completed", ParentID: "w-parent"},
+		FollowUps: []commons.WantedItem{{ID: "w-next", Title: "Cover the retry path", Status: "open"}},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "Follows up:  w-parent") {
+		t.Errorf("output missing parent link:\n%s", out)
+	}
+	if !strings.Contains(out, "w-next  open        Cover the retry path") {
+		t.Errorf("output missing follow-up:\n%s", out)
+	}
+}
+
+func TestRenderDetailStatus_MultipleEvidence(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderDetailStatus(&buf, &sdk.DetailResult{
+		Item:       &commons.WantedItem{ID: "w-abc123", Title: "Fix the login bug", Status: "in_review"},
+		Completion: &commons.CompletionRecord{ID: "c-1", CompletedBy: "worker-rig", Evidence: "https://github.com/org/repo/pull/1; abc1234"},
+		Evidence: []commons.Evidence{
+			{Kind: commons.EvidencePR, Value: "https://github.com/org/repo/pull/1", Verified: true},
+			{Kind: commons.EvidenceCommit, Value: "abc1234"},
+		},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"Evidence:    2 entries", "pr        https://github.com/org/repo/pull/1 ✓ verified", "commit    abc1234"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "verified"); n != 1 {
+		t.Errorf("verified markers = %d, want 1:\n%s", n, out)
+	}
+}
+
 func TestRenderDetailStatus_PRMode(t *testing.T) {
 	t.Parallel()
 	var buf bytes.Buffer
@@ -172,3 +236,269 @@ func TestRenderDetailStatus_PRMode(t *testing.T) {
 		t.Errorf("output missing PR URL")
 	}
 }
+
+// fakeStatusDeps returns statusDeps backed by canned query results keyed
+// by substring of the query.
+func fakeStatusDeps(results map[string]string, fetchErr error) *statusDeps {
+	return &statusDeps{
+		fetch: func(_, _ string) error { return fetchErr },
+		doltQuery: func(_, query string) (string, error) {
+			for k, v := range results {
+				if strings.Contains(query, k) {
+					return v, nil
+				}
+			}
+			return "", fmt.Errorf("unexpected query: %s", query)
+		},
+		findPR: func(_ *federation.Config, branch string) string {
+			if branch == "wl/alice/w-1" {
+				return "https://example.com/pr/1"
+			}
+			return ""
+		},
+		now: func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestCollectFederationStatus(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{
+		Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR,
+	}
+	deps := fakeStatusDeps(map[string]string{
+		"main..upstream/main')":   "n\n3\n",
+		"'upstream/main..main')":  "n\n1\n",
+		"origin/main..main":       "n\n2\n",
+		"dolt_branches":           "name,latest_commit_date\nwl/alice/w-1,2026-02-27 11:00:00.123\nwl/alice/w-2,2026-03-01 09:00:00\n",
+		"'main', 'wl/alice/w-1')": "n\n0\n",
+		"'main', 'wl/alice/w-2')": "n\n2\n",
+	}, nil)
+
+	st := collectFederationStatus(cfg, deps)
+	if st.Behind != 3 || st.Ahead != 1 || st.Unsynced != 2 {
+		t.Errorf("behind/ahead/unsynced = %d/%d/%d, want 3/1/2", st.Behind, st.Ahead, st.Unsynced)
+	}
+	if len(st.PendingBranches) != 2 || st.PendingBranches[0].WantedID != "w-1" {
+		t.Fatalf("PendingBranches = %+v", st.PendingBranches)
+	}
+	if st.OpenPRs != 1 || st.PendingBranches[0].PRURL == "" {
+		t.Errorf("OpenPRs = %d, branches = %+v", st.OpenPRs, st.PendingBranches)
+	}
+	if pb := st.PendingBranches[0]; pb.LastCommitAt == nil || st.CheckedAt.Sub(*pb.LastCommitAt) < 47*time.Hour {
+		t.Errorf("branch 0 LastCommitAt = %v, want about 2 days before %v", pb.LastCommitAt, st.CheckedAt)
+	}
+	if st.PendingBranches[0].Conflicts != 0 || st.PendingBranches[1].Conflicts != 2 {
+		t.Errorf("conflicts = %d/%d, want 0/2", st.PendingBranches[0].Conflicts, st.PendingBranches[1].Conflicts)
+	}
+	if len(st.Errors) != 0 {
+		t.Errorf("unexpected errors: %v", st.Errors)
+	}
+
+	var text bytes.Buffer
+	renderFederationStatus(&text, st)
+	for _, want := range []string{"2d old", "3h old", "1 not applying cleanly", "2 conflicts with main"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("render output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeFederationStatusJSON(&buf, st, true); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["behind"] != float64(3) || decoded["open_prs"] != float64(1) {
+		t.Errorf("decoded = %v", decoded)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("compact JSON should be a single line, got %q", buf.String())
+	}
+}
+
+func TestCollectFederationStatus_FetchFailure(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db"}
+	deps := fakeStatusDeps(map[string]string{"dolt_branches": "name\n"}, fmt.Errorf("network down"))
+
+	st := collectFederationStatus(cfg, deps)
+	if st.Behind != -1 || st.Unsynced != -1 {
+		t.Errorf("counts should be unknown after fetch failure, got %d/%d", st.Behind, st.Unsynced)
+	}
+	if len(st.Errors) != 2 {
+		t.Errorf("Errors = %v, want 2 fetch errors", st.Errors)
+	}
+
+	var buf bytes.Buffer
+	renderFederationStatus(&buf, st)
+	if !strings.Contains(buf.String(), "unknown") || !strings.Contains(buf.String(), "network down") {
+		t.Errorf("render output missing unknown/error:\n%s", buf.String())
+	}
+}
+
+func TestCollectFederationStatus_ConflictCheckFailure(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db"}
+	deps := fakeStatusDeps(map[string]string{
+		"dolt_log":      "n\n0\n",
+		"dolt_branches": "name,latest_commit_date\nwl/alice/w-1,\n",
+	}, nil)
+
+	st := collectFederationStatus(cfg, deps)
+	if len(st.PendingBranches) != 1 {
+		t.Fatalf("PendingBranches = %+v", st.PendingBranches)
+	}
+	pb := st.PendingBranches[0]
+	if pb.Conflicts != -1 || pb.LastCommitAt != nil {
+		t.Errorf("branch = %+v, want unknown conflicts and no age", pb)
+	}
+	if len(st.Errors) != 1 || !strings.Contains(st.Errors[0], "merges cleanly") {
+		t.Errorf("Errors = %v", st.Errors)
+	}
+}
+
+func TestCollectFederationStatus_RateLimits(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", Backend: federation.BackendRemote}
+	deps := fakeStatusDeps(nil, nil)
+	now := deps.now()
+	deps.rateLimits = func(*federation.Config) []commons.RateLimit {
+		return []commons.RateLimit{
+			{Provider: "dolthub", Limit: 1000, Remaining: 50, Reset: now.Add(20 * time.Minute)},
+			{Provider: "github", Limit: 5000, Remaining: 4900},
+		}
+	}
+
+	st := collectFederationStatus(cfg, deps)
+	if len(st.RateLimits) != 2 {
+		t.Fatalf("RateLimits = %+v, want 2", st.RateLimits)
+	}
+
+	var buf bytes.Buffer
+	renderFederationStatus(&buf, st)
+	for _, want := range []string{"API quota:", "dolthub: 50/1000 requests left, resets in 20m", "github: 4900/5000 requests left"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("render output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestParseGHRateLimit(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rl, err := parseGHRateLimit([]byte(`{"resources":{"core":{"limit":5000,"remaining":0,"reset":1772367300}}}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rl.Provider != "github" || rl.Limit != 5000 || !rl.Limited || !rl.Reset.Equal(now.Add(15*time.Minute)) {
+		t.Errorf("parseGHRateLimit = %+v", rl)
+	}
+	if _, err := parseGHRateLimit([]byte("not json"), now); err == nil {
+		t.Error("expected error for malformed response")
+	}
+}
+
+func TestLoadAllConfigs(t *testing.T) {
+	t.Parallel()
+	store := &fakeConfigStore{configs: map[string]*federation.Config{
+		"hop/wl-commons": {Upstream: "hop/wl-commons"},
+		"acme/wl":        {Upstream: "acme/wl"},
+	}}
+
+	cmd := configCmd()
+	cmd.Flags().Bool("local-db", false, "")
+	_ = cmd.Flags().Set("local-db", "true")
+	cfgs, err := loadAllConfigs(cmd, store)
+	if err != nil {
+		t.Fatalf("loadAllConfigs: %v", err)
+	}
+	if len(cfgs) != 2 {
+		t.Fatalf("got %d configs, want 2", len(cfgs))
+	}
+	for _, cfg := range cfgs {
+		if cfg.Backend != federation.BackendLocal {
+			t.Errorf("%s backend = %q, want local", cfg.Upstream, cfg.Backend)
+		}
+	}
+
+	if _, err := loadAllConfigs(configCmd(), &fakeConfigStore{configs: map[string]*federation.Config{}}); err == nil {
+		t.Error("expected error with no joined wastelands")
+	}
+}
+
+func TestWatchLoop_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := watchLoop(ctx, time.Millisecond, func() error {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("watchLoop: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestRenderDetailStatus_ClaimAge(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderDetailStatus(&buf, &sdk.DetailResult{
+		Item: &commons.WantedItem{
+			ID:        "w-abc123",
+			Title:     "Fix the login bug",
+			Status:    "claimed",
+			ClaimedBy: "worker-rig",
+			UpdatedAt: time.Now().UTC().Add(-20 * 24 * time.Hour).Format(time.DateTime),
+		},
+		ClaimExpiry: 14 * 24 * time.Hour,
+	})
+
+	if out := buf.String(); !strings.Contains(out, "Claimed by:  worker-rig") || !strings.Contains(out, "20d idle, expired") {
+		t.Errorf("output missing claim age:\n%s", out)
+	}
+}
+
+func TestRenderDetailStatus_Queue(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderDetailStatus(&buf, &sdk.DetailResult{
+		Item:  &commons.WantedItem{ID: "w-abc123", Title: "Fix the login bug", Status: "claimed", ClaimedBy: "worker-rig"},
+		Queue: []commons.QueueEntry{{RigHandle: "bob", AutoClaim: true}, {RigHandle: "carol"}},
+	})
+
+	if out := buf.String(); !strings.Contains(out, "Queue:       bob (auto), carol") {
+		t.Errorf("output missing claim queue:\n%s", out)
+	}
+}
+
+func TestRenderStatusDelta(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	renderStatusDelta(&buf, []sdk.ItemDelta{{
+		WantedID: "w-abc123", Title: "Fix the login bug", Branch: "wl/bob/w-abc123", Delta: "claim",
+		Changes: []commons.RowChange{{Table: "wanted", ID: "w-abc123", DiffType: "modified", Fields: []commons.FieldChange{
+			{Field: "status", From: "open", To: "claimed"},
+		}}},
+	}})
+
+	out := buf.String()
+	for _, want := range []string{"1 item(s) differ from main", "w-abc123: Fix the login bug", "wl/bob/w-abc123  claim", "status", "open → claimed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	renderStatusDelta(&buf, nil)
+	if !strings.Contains(buf.String(), "No pending changes") {
+		t.Errorf("empty output = %q", buf.String())
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newSweepCmd(stdout, stderr io.Writer) *cobra.Command {
+	var (
+		dryRun bool
+		mine   bool
+		noPush bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Reopen claims that have gone stale",
+		Long: `Revert claims that have gone without activity for longer than the
+wasteland's claim expiry back to open, so other rigs can pick them up.
+
+The expiry is set per wasteland with the claim_expiry_days _meta key; a
+claim's last activity is the latest of the item's updated_at, its review
+comments and its completion evidence. Without the key claims never
+expire and sweep does nothing:
+
+  INSERT INTO _meta (` + "`key`" + `, value) VALUES ('claim_expiry_days', '14');
+
+Each reopened item is its own commit ('wl sweep: <id> - <notice>'), so
+the notice naming the former claimer shows in the item's history, and it
+is printed as well. Use --mine to only sweep
+items you posted, and --dry-run to list stale claims without touching
+them.
+
+In wild-west mode the commits are auto-pushed to upstream and origin.
+Use --no-push to skip pushing (offline work).
+
+Examples:
+  wl sweep --dry-run
+  wl sweep
+  wl sweep --mine`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSweep(cmd, stdout, stderr, dryRun, mine, noPush)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List stale claims without reopening them")
+	cmd.Flags().BoolVar(&mine, "mine", false, "Only sweep items you posted")
+	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
+
+	return cmd
+}
+
+func runSweep(cmd *cobra.Command, stdout, _ io.Writer, dryRun, mine, noPush bool) error {
+	wlCfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	client, err := newSDKClient(wlCfg, noPush)
+	if err != nil {
+		return err
+	}
+
+	in := sdk.SweepInput{DryRun: dryRun}
+	if mine {
+		in.PostedBy = wlCfg.RigHandle
+	}
+	result, err := client.Sweep(in)
+	if err != nil {
+		return err
+	}
+	return renderSweepResult(stdout, result, dryRun)
+}
+
+// renderSweepResult prints the stale claims found by a sweep and what
+// happened to each, and fails when any could not be reopened.
+func renderSweepResult(w io.Writer, result *sdk.SweepResult, dryRun bool) error {
+	if result.Expiry == 0 {
+		fmt.Fprintln(w, "Claims in this wasteland don't expire (no claim_expiry_days in _meta).")
+		return nil
+	}
+	days := int(result.Expiry.Hours() / 24)
+	if len(result.Claims) == 0 {
+		fmt.Fprintf(w, "%s No claims older than %d days.\n", style.Success.Render(style.IconPass), days)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "Claims without activity for %d+ days:\n", days)
+		for _, s := range result.Claims {
+			fmt.Fprintf(w, "  %-12s %-10s %5s  %s\n", s.ID, s.ClaimedBy, formatDuration(s.Age), style.Dim.Render(s.Title))
+		}
+		fmt.Fprintf(w, "\nDry run: %d claim(s) would be reopened.\n", len(result.Claims))
+		return nil
+	}
+
+	failed := 0
+	for _, s := range result.Claims {
+		if s.Err != nil {
+			failed++
+			fmt.Fprintf(w, "  %s %s: %v\n", style.Error.Render(style.IconFail), s.ID, s.Err)
+			continue
+		}
+		fmt.Fprintf(w, "  %s %s\n", style.Success.Render(style.IconPass), s.Notice())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d stale claim(s) could not be reopened", failed, len(result.Claims))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+func TestRenderSweepResult(t *testing.T) {
+	t.Parallel()
+	day := 24 * time.Hour
+	result := &sdk.SweepResult{
+		Expiry: 14 * day,
+		Claims: []sdk.SweptClaim{
+			{ID: "w-old", Title: "Old work", ClaimedBy: "bob", Age: 20 * day},
+			{ID: "w-racy", Title: "Racy", ClaimedBy: "carol", Age: 15 * day, Err: errors.New("nothing to commit")},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := renderSweepResult(&buf, result, false)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Errorf("err = %v, want 1 of 2 failed", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"claim by bob expired after 20 days without activity; w-old is open again", "w-racy: nothing to commit"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := renderSweepResult(&buf, result, true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "14+ days") || !strings.Contains(out, "w-old") || !strings.Contains(out, "2 claim(s) would be reopened") {
+		t.Errorf("dry run output:\n%s", out)
+	}
+}
+
+func TestRenderSweepResult_NoPolicy(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := renderSweepResult(&buf, &sdk.SweepResult{}, false); err != nil {
+		t.Fatalf("renderSweepResult: %v", err)
+	}
+	if !strings.Contains(buf.String(), "claim_expiry_days") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
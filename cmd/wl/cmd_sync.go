@@ -4,14 +4,20 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
 
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
 
 func newSyncCmd(stdout, stderr io.Writer) *cobra.Command {
-	var dryRun bool
+	var dryRun, all bool
+	var mo syncMergeOpts
 
 	cmd := &cobra.Command{
 		Use:   "sync",
@@ -22,20 +28,65 @@ func newSyncCmd(stdout, stderr io.Writer) *cobra.Command {
 If you have a local fork of wl-commons (created by wl join), this pulls
 the latest changes from upstream.
 
+In PR mode, sync also reconciles your wl/<handle>/* branches: a branch
+whose upstream PR was merged or closed is deleted locally and on your
+fork, so its item reads from main again instead of showing as pending.
+
+With --all, every joined wasteland is synced concurrently, with one
+progress line per wasteland and a combined summary.
+
+When local main has diverged from upstream (common after offline
+wild-west work), --strategy reports the rows that differ and merges:
+"ours" keeps your version of rows changed on both sides, "theirs" takes
+upstream's, and "manual" leaves those rows in the clone as conflicts.
+Settle them per table with --resolve, or by editing the rows with
+'dolt sql', then finish with --continue, or give up with --abort.
+
 EXAMPLES:
   wl sync                # Pull upstream changes
-  wl sync --dry-run      # Show what would change`,
+  wl sync --dry-run      # Show what would change
+  wl sync --all          # Sync every joined wasteland
+  wl sync --strategy ours
+  wl sync --strategy manual
+  wl sync --resolve wanted=theirs --continue`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runSync(cmd, stdout, stderr, dryRun)
+			if all {
+				if dryRun {
+					return fmt.Errorf("--dry-run cannot be combined with --all")
+				}
+				if mo.active() {
+					return fmt.Errorf("--strategy, --resolve, --continue and --abort work on one wasteland and cannot be combined with --all")
+				}
+				if explicit, _ := cmd.Flags().GetString("wasteland"); explicit != "" {
+					return fmt.Errorf("--wasteland cannot be combined with --all")
+				}
+				if localDB, _ := cmd.Flags().GetBool("local-db"); !localDB {
+					fmt.Fprintf(stdout, "Remote mode: reads are always fresh from the DoltHub API.\n")
+					return nil
+				}
+				if err := requireDolt(); err != nil {
+					return err
+				}
+				return runSyncAll(stdout, federation.NewConfigStore())
+			}
+			if err := mo.validate(dryRun); err != nil {
+				return err
+			}
+			return runSync(cmd, stdout, stderr, dryRun, &mo)
 		},
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without pulling")
+	cmd.Flags().BoolVar(&all, "all", false, "Sync every joined wasteland concurrently")
+	cmd.Flags().StringVar(&mo.strategy, "strategy", "", "Merge a diverged main: ours, theirs or manual")
+	cmd.Flags().StringArrayVar(&mo.resolve, "resolve", nil, "Settle a table's conflicts: <table>=ours|theirs (repeatable)")
+	cmd.Flags().BoolVar(&mo.cont, "continue", false, "Commit a manual merge once no conflicts remain")
+	cmd.Flags().BoolVar(&mo.abort, "abort", false, "Abandon a manual merge")
 
 	return cmd
 }
 
-func runSync(cmd *cobra.Command, stdout, stderr io.Writer, dryRun bool) error {
+func runSync(cmd *cobra.Command, stdout, stderr io.Writer, dryRun bool, mo *syncMergeOpts) error {
 	cfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
@@ -60,6 +111,10 @@ func runSync(cmd *cobra.Command, stdout, stderr io.Writer, dryRun bool) error {
 
 	fmt.Fprintf(stdout, "Local fork: %s\n", style.Dim.Render(forkDir))
 
+	if mo.resolving() {
+		return runSyncResolve(stdout, forkDir, mo, defaultSyncMergeDeps())
+	}
+
 	if dryRun {
 		fmt.Fprintf(stdout, "\n%s Dry run — checking upstream for changes...\n", style.Bold.Render("~"))
 
@@ -84,14 +139,20 @@ func runSync(cmd *cobra.Command, stdout, stderr io.Writer, dryRun bool) error {
 		return nil
 	}
 
-	fmt.Fprintf(stdout, "\nPulling from upstream...\n")
+	if mo.strategy != "" {
+		if err := syncWithStrategy(stdout, forkDir, mo.strategy, defaultSyncMergeDeps()); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(stdout, "\nPulling from upstream...\n")
 
-	pullCmd := exec.Command(doltPath, "pull", "upstream", "main")
-	pullCmd.Dir = forkDir
-	pullCmd.Stdout = stdout
-	pullCmd.Stderr = stderr
-	if err := pullCmd.Run(); err != nil {
-		return fmt.Errorf("pulling from upstream: %w", err)
+		pullCmd := exec.Command(doltPath, "pull", "upstream", "main")
+		pullCmd.Dir = forkDir
+		pullCmd.Stdout = stdout
+		pullCmd.Stderr = stderr
+		if err := pullCmd.Run(); err != nil {
+			return fmt.Errorf("pulling from upstream: %w", err)
+		}
 	}
 
 	fmt.Fprintf(stdout, "\n%s Synced with upstream\n", style.Bold.Render("✓"))
@@ -118,5 +179,128 @@ func runSync(cmd *cobra.Command, stdout, stderr io.Writer, dryRun bool) error {
 		}
 	}
 
+	if cfg.ResolveMode() == federation.ModePR {
+		results, err := reconcileBranches(cfg)
+		if err != nil {
+			fmt.Fprintf(stdout, "\n%s branch cleanup skipped: %v\n", style.Warning.Render(style.IconWarn), err)
+		}
+		renderReconciled(stdout, results)
+	}
+
+	return nil
+}
+
+// reconcileBranches removes the rig's branches whose upstream PR was
+// merged or closed.
+func reconcileBranches(cfg *federation.Config) ([]sdk.ReconciledBranch, error) {
+	client, err := newSDKClient(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+	return client.Reconcile()
+}
+
+func renderReconciled(stdout io.Writer, results []sdk.ReconciledBranch) {
+	if len(results) == 0 {
+		return
+	}
+	fmt.Fprintf(stdout, "\nCleaned up branches with a merged or closed PR:\n")
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(stdout, "  %s %s: %v\n", style.Error.Render(style.IconFail), r.Branch, r.Err)
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s %s %s\n", style.Success.Render(style.IconPass), r.Branch, style.Dim.Render("(PR "+r.PRState+")"))
+	}
+}
+
+// syncWasteland pulls upstream into one wasteland's local clone and returns
+// a short summary of the result. Output is captured rather than streamed so
+// concurrent syncs don't interleave.
+// Package-level variable to allow test overrides.
+var syncWasteland = func(cfg *federation.Config) (string, error) {
+	if cfg.LocalDir == "" {
+		return "", fmt.Errorf("no local clone")
+	}
+	if err := commons.PullUpstream(cfg.LocalDir); err != nil {
+		return "", err
+	}
+	summary := "synced"
+	out, err := commons.DoltSQLQuery(cfg.LocalDir, "SELECT COUNT(*) FROM wanted WHERE status = 'open'")
+	if rows := wlParseCSV(out); err == nil && len(rows) >= 2 && len(rows[1]) > 0 {
+		summary = rows[1][0] + " open wanted"
+	}
+	if cfg.ResolveMode() == federation.ModePR {
+		if results, err := reconcileBranches(cfg); err == nil && len(results) > 0 {
+			summary += fmt.Sprintf(", cleaned up %d branch(es)", len(results))
+		}
+	}
+	return summary, nil
+}
+
+type syncAllResult struct {
+	cfg     *federation.Config
+	summary string
+	err     error
+}
+
+// runSyncAll syncs every joined wasteland concurrently, printing a line as
+// each finishes, then records sync timestamps and prints a summary.
+func runSyncAll(stdout io.Writer, store federation.ConfigStore) error {
+	names, err := store.List()
+	if err != nil {
+		return fmt.Errorf("listing wastelands: %w", err)
+	}
+	if len(names) == 0 {
+		return hintWrap(federation.ErrNotJoined)
+	}
+
+	fmt.Fprintf(stdout, "Syncing %d wastelands...\n\n", len(names))
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make([]syncAllResult, len(names))
+	)
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := syncAllResult{}
+			r.cfg, r.err = store.Load(name)
+			if r.err == nil {
+				r.summary, r.err = syncWasteland(r.cfg)
+			}
+			results[i] = r
+
+			mu.Lock()
+			defer mu.Unlock()
+			if r.err != nil {
+				fmt.Fprintf(stdout, "  %s %-30s %v\n", style.Error.Render(style.IconFail), name, r.err)
+			} else {
+				fmt.Fprintf(stdout, "  %s %-30s %s\n", style.Success.Render(style.IconPass), name, style.Dim.Render(r.summary))
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	now := time.Now()
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			continue
+		}
+		r.cfg.LastSyncAt = &now
+		_ = store.Save(r.cfg) // best-effort; errors here are non-fatal
+	}
+
+	fmt.Fprintln(stdout)
+	if failed > 0 {
+		fmt.Fprintf(stdout, "%s Synced %d of %d wastelands (%d failed)\n",
+			style.Warning.Render(style.IconWarn), len(names)-failed, len(names), failed)
+		return &commons.NetworkError{Err: errExit}
+	}
+	fmt.Fprintf(stdout, "%s Synced %d wastelands\n", style.Bold.Render("✓"), len(names))
 	return nil
 }
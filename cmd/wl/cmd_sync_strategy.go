@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/style"
+)
+
+// syncMergeMessage is the commit message of a strategy merge.
+const syncMergeMessage = "wl sync: merge upstream"
+
+// syncMergeOpts holds the flags of 'wl sync' for merging a diverged main.
+type syncMergeOpts struct {
+	strategy string
+	resolve  []string // <table>=ours|theirs
+	cont     bool
+	abort    bool
+}
+
+// resolving reports whether the flags act on a manual merge in progress.
+func (o *syncMergeOpts) resolving() bool {
+	return len(o.resolve) > 0 || o.cont || o.abort
+}
+
+func (o *syncMergeOpts) active() bool {
+	return o.strategy != "" || o.resolving()
+}
+
+func (o *syncMergeOpts) validate(dryRun bool) error {
+	if o.strategy != "" && !commons.ValidSyncStrategy(o.strategy) {
+		return fmt.Errorf("invalid --strategy %q: want ours, theirs or manual", o.strategy)
+	}
+	if o.strategy != "" && o.resolving() {
+		return fmt.Errorf("--strategy starts a merge; --resolve, --continue and --abort finish one")
+	}
+	if dryRun && o.active() {
+		return fmt.Errorf("--dry-run cannot be combined with --strategy, --resolve, --continue or --abort")
+	}
+	if o.abort && (o.cont || len(o.resolve) > 0) {
+		return fmt.Errorf("--abort cannot be combined with --resolve or --continue")
+	}
+	for _, r := range o.resolve {
+		if _, _, err := parseResolve(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseResolve splits a --resolve value into its table and side.
+func parseResolve(v string) (table, side string, err error) {
+	table, side, ok := strings.Cut(v, "=")
+	if !ok || table == "" || (side != commons.SyncOurs && side != commons.SyncTheirs) {
+		return "", "", fmt.Errorf("invalid --resolve %q: want <table>=ours or <table>=theirs", v)
+	}
+	return table, side, nil
+}
+
+// syncMergeDeps holds the dolt operations behind strategy syncs, so tests
+// can substitute fakes.
+type syncMergeDeps struct {
+	divergence func(dbDir string) (*commons.Divergence, error)
+	pull       func(dbDir string) error
+	merge      func(dbDir, strategy, message string) ([]commons.ConflictRow, error)
+	conflicts  func(dbDir string) ([]commons.ConflictRow, error)
+	resolve    func(dbDir, table, side string) error
+	commit     func(dbDir, message string) error
+	abort      func(dbDir string) error
+}
+
+func defaultSyncMergeDeps() *syncMergeDeps {
+	return &syncMergeDeps{
+		divergence: commons.CheckDivergence,
+		pull:       commons.PullUpstream,
+		merge:      commons.MergeUpstream,
+		conflicts:  commons.ListConflicts,
+		resolve:    commons.ResolveConflicts,
+		commit:     commons.ContinueMerge,
+		abort:      commons.AbortMerge,
+	}
+}
+
+// syncWithStrategy reports how local main differs from upstream and
+// merges upstream in, settling rows changed on both sides per strategy.
+// A manual merge that leaves conflicts returns a *commons.ConflictError
+// after listing them.
+func syncWithStrategy(stdout io.Writer, dir, strategy string, deps *syncMergeDeps) error {
+	fmt.Fprintf(stdout, "\nComparing with upstream...\n")
+	d, err := deps.divergence(dir)
+	if err != nil {
+		return err
+	}
+	if d.Ahead == 0 {
+		if d.Behind == 0 {
+			fmt.Fprintln(stdout, "Already up to date.")
+			return nil
+		}
+		// Nothing local to keep: a plain fast-forward.
+		fmt.Fprintf(stdout, "Fast-forwarding %d upstream commit(s)...\n", d.Behind)
+		if err := deps.pull(dir); err != nil {
+			return fmt.Errorf("pulling from upstream: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "Local main is %d commit(s) ahead of upstream and %d behind.\n", d.Ahead, d.Behind)
+	if len(d.Tables) > 0 {
+		fmt.Fprintln(stdout, "\nLocal changes not on upstream:")
+		for _, t := range d.Tables {
+			fmt.Fprintf(stdout, "  %-20s %s\n", t.Table, style.Dim.Render(
+				fmt.Sprintf("%d added, %d modified, %d deleted", t.Added, t.Modified, t.Deleted)))
+		}
+	}
+	if d.Behind == 0 {
+		fmt.Fprintln(stdout, "\nNothing to merge: upstream has no new commits.")
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "\nMerging upstream (strategy: %s)...\n", strategy)
+	conflicts, err := deps.merge(dir, strategy, syncMergeMessage)
+	if len(conflicts) > 0 {
+		fmt.Fprintln(stdout, "\nRows changed on both sides:")
+		renderConflicts(stdout, conflicts)
+	}
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		fmt.Fprintln(stdout, "Merged cleanly: no rows changed on both sides.")
+		return nil
+	}
+	switch strategy {
+	case commons.SyncOurs:
+		fmt.Fprintf(stdout, "\nMerged, keeping your version of %d row(s).\n", len(conflicts))
+	case commons.SyncTheirs:
+		fmt.Fprintf(stdout, "\nMerged, taking upstream's version of %d row(s).\n", len(conflicts))
+	default:
+		printResolveHelp(stdout, dir)
+		return &commons.ConflictError{Message: fmt.Sprintf("%d conflicting row(s) left for manual resolution", len(conflicts))}
+	}
+	return nil
+}
+
+// runSyncResolve works on a manual merge in progress: it aborts it, or
+// settles the --resolve tables and, with --continue, commits the merge.
+func runSyncResolve(stdout io.Writer, dir string, mo *syncMergeOpts, deps *syncMergeDeps) error {
+	if mo.abort {
+		if err := deps.abort(dir); err != nil {
+			return fmt.Errorf("aborting merge: %w", err)
+		}
+		fmt.Fprintf(stdout, "%s Merge aborted; local main is unchanged\n", style.Bold.Render("✓"))
+		return nil
+	}
+	for _, r := range mo.resolve {
+		table, side, _ := parseResolve(r) // checked by validate
+		if err := deps.resolve(dir, table, side); err != nil {
+			return fmt.Errorf("resolving %s: %w", table, err)
+		}
+		fmt.Fprintf(stdout, "%s %s: kept %s\n", style.Success.Render(style.IconPass), table, side)
+	}
+
+	remaining, err := deps.conflicts(dir)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		fmt.Fprintln(stdout, "\nStill conflicting:")
+		renderConflicts(stdout, remaining)
+		printResolveHelp(stdout, dir)
+		if mo.cont {
+			return &commons.ConflictError{Message: fmt.Sprintf("%d conflicting row(s) still unresolved", len(remaining))}
+		}
+		return nil
+	}
+	if !mo.cont {
+		fmt.Fprintf(stdout, "\nNo conflicts left. Finish with: wl sync --continue\n")
+		return nil
+	}
+	if err := deps.commit(dir, syncMergeMessage); err != nil {
+		return fmt.Errorf("committing merge: %w", err)
+	}
+	fmt.Fprintf(stdout, "\n%s Merge committed\n", style.Bold.Render("✓"))
+	return nil
+}
+
+func renderConflicts(stdout io.Writer, rows []commons.ConflictRow) {
+	for _, c := range rows {
+		detail := fmt.Sprintf("yours %s, upstream %s", c.Ours, c.Theirs)
+		if len(c.Columns) > 0 {
+			detail += ": " + strings.Join(c.Columns, ", ")
+		}
+		fmt.Fprintf(stdout, "  %-14s %-24s %s\n", c.Table, c.Key, style.Dim.Render(detail))
+	}
+}
+
+func printResolveHelp(stdout io.Writer, dir string) {
+	fmt.Fprintf(stdout, `
+The merge is left in progress in %s. To finish it:
+  wl sync --resolve <table>=ours|theirs    keep one side for a whole table
+  dolt sql                                 or edit rows and delete them from dolt_conflicts_<table>
+  wl sync --continue                       commit once nothing conflicts
+  wl sync --abort                          or give up and restore local main
+`, dir)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// fakeSyncMergeDeps returns syncMergeDeps reporting d, with merge and the
+// conflict listing answering conflicts, and records the calls made.
+func fakeSyncMergeDeps(d *commons.Divergence, conflicts []commons.ConflictRow) (*syncMergeDeps, *[]string) {
+	var calls []string
+	deps := &syncMergeDeps{
+		divergence: func(string) (*commons.Divergence, error) { return d, nil },
+		pull: func(string) error {
+			calls = append(calls, "pull")
+			return nil
+		},
+		merge: func(_, strategy, _ string) ([]commons.ConflictRow, error) {
+			calls = append(calls, "merge:"+strategy)
+			return conflicts, nil
+		},
+		conflicts: func(string) ([]commons.ConflictRow, error) { return conflicts, nil },
+		resolve: func(_, table, side string) error {
+			calls = append(calls, "resolve:"+table+"="+side)
+			return nil
+		},
+		commit: func(string, string) error {
+			calls = append(calls, "commit")
+			return nil
+		},
+		abort: func(string) error {
+			calls = append(calls, "abort")
+			return nil
+		},
+	}
+	return deps, &calls
+}
+
+var syncConflicts = []commons.ConflictRow{
+	{Table: "wanted", Key: "w-1", Ours: "modified", Theirs: "modified", Columns: []string{"claimed_by", "status"}},
+}
+
+var syncDiverged = &commons.Divergence{
+	Ahead: 2, Behind: 3,
+	Tables: []commons.TableDiff{{Table: "wanted", Modified: 2}, {Table: "completions", Added: 1}},
+}
+
+func TestSyncWithStrategy_Ours(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeSyncMergeDeps(syncDiverged, syncConflicts)
+	var stdout bytes.Buffer
+
+	if err := syncWithStrategy(&stdout, "/tmp/db", commons.SyncOurs, deps); err != nil {
+		t.Fatalf("syncWithStrategy: %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"2 commit(s) ahead of upstream and 3 behind",
+		"completions",
+		"1 added, 0 modified, 0 deleted",
+		"w-1",
+		"yours modified, upstream modified: claimed_by, status",
+		"keeping your version of 1 row(s)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if got := strings.Join(*calls, ","); got != "merge:ours" {
+		t.Errorf("calls = %s, want merge:ours", got)
+	}
+}
+
+func TestSyncWithStrategy_ManualLeavesConflicts(t *testing.T) {
+	t.Parallel()
+	deps, _ := fakeSyncMergeDeps(syncDiverged, syncConflicts)
+	var stdout bytes.Buffer
+
+	err := syncWithStrategy(&stdout, "/tmp/db", commons.SyncManual, deps)
+	var conflict *commons.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want *ConflictError", err)
+	}
+	if !strings.Contains(stdout.String(), "wl sync --continue") {
+		t.Errorf("output missing the resolution help:\n%s", stdout.String())
+	}
+}
+
+func TestSyncWithStrategy_NotAheadFastForwards(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeSyncMergeDeps(&commons.Divergence{Behind: 4}, nil)
+	var stdout bytes.Buffer
+
+	if err := syncWithStrategy(&stdout, "/tmp/db", commons.SyncTheirs, deps); err != nil {
+		t.Fatalf("syncWithStrategy: %v", err)
+	}
+	if got := strings.Join(*calls, ","); got != "pull" {
+		t.Errorf("calls = %s, want pull", got)
+	}
+}
+
+func TestSyncWithStrategy_NothingUpstream(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeSyncMergeDeps(&commons.Divergence{Ahead: 1}, nil)
+	var stdout bytes.Buffer
+
+	if err := syncWithStrategy(&stdout, "/tmp/db", commons.SyncOurs, deps); err != nil {
+		t.Fatalf("syncWithStrategy: %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("calls = %v, want none", *calls)
+	}
+}
+
+func TestRunSyncResolve(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeSyncMergeDeps(nil, nil)
+	var stdout bytes.Buffer
+
+	mo := &syncMergeOpts{resolve: []string{"wanted=theirs"}, cont: true}
+	if err := runSyncResolve(&stdout, "/tmp/db", mo, deps); err != nil {
+		t.Fatalf("runSyncResolve: %v", err)
+	}
+	if got := strings.Join(*calls, ","); got != "resolve:wanted=theirs,commit" {
+		t.Errorf("calls = %s", got)
+	}
+}
+
+func TestRunSyncResolve_ContinueWithConflicts(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeSyncMergeDeps(nil, syncConflicts)
+	var stdout bytes.Buffer
+
+	err := runSyncResolve(&stdout, "/tmp/db", &syncMergeOpts{cont: true}, deps)
+	var conflict *commons.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want *ConflictError", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("calls = %v, want no commit", *calls)
+	}
+	if !strings.Contains(stdout.String(), "w-1") {
+		t.Errorf("output missing the conflicting row:\n%s", stdout.String())
+	}
+}
+
+func TestRunSyncResolve_Abort(t *testing.T) {
+	t.Parallel()
+	deps, calls := fakeSyncMergeDeps(nil, syncConflicts)
+	var stdout bytes.Buffer
+
+	if err := runSyncResolve(&stdout, "/tmp/db", &syncMergeOpts{abort: true}, deps); err != nil {
+		t.Fatalf("runSyncResolve: %v", err)
+	}
+	if got := strings.Join(*calls, ","); got != "abort" {
+		t.Errorf("calls = %s, want abort", got)
+	}
+}
+
+func TestSyncMergeOpts_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    syncMergeOpts
+		dryRun  bool
+		wantErr bool
+	}{
+		{"none", syncMergeOpts{}, false, false},
+		{"strategy", syncMergeOpts{strategy: "theirs"}, false, false},
+		{"bad strategy", syncMergeOpts{strategy: "mine"}, false, true},
+		{"strategy with continue", syncMergeOpts{strategy: "ours", cont: true}, false, true},
+		{"dry run", syncMergeOpts{strategy: "ours"}, true, true},
+		{"abort with resolve", syncMergeOpts{abort: true, resolve: []string{"wanted=ours"}}, false, true},
+		{"resolve", syncMergeOpts{resolve: []string{"wanted=ours", "stamps=theirs"}}, false, false},
+		{"bad resolve side", syncMergeOpts{resolve: []string{"wanted=both"}}, false, true},
+		{"resolve without table", syncMergeOpts{resolve: []string{"=ours"}}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.opts.validate(tt.dryRun); (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
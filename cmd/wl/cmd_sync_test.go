@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+func stubSyncWasteland(t *testing.T, fn func(cfg *federation.Config) (string, error)) {
+	t.Helper()
+	orig := syncWasteland
+	syncWasteland = fn
+	t.Cleanup(func() { syncWasteland = orig })
+}
+
+func TestRunSyncAll(t *testing.T) {
+	stubSyncWasteland(t, func(cfg *federation.Config) (string, error) {
+		if cfg.Upstream == "broken/db" {
+			return "", fmt.Errorf("pull failed")
+		}
+		return "3 open wanted", nil
+	})
+	store := &fakeConfigStore{configs: map[string]*federation.Config{
+		"hop/wl-commons": {Upstream: "hop/wl-commons", LocalDir: "/a"},
+		"acme/board":     {Upstream: "acme/board", LocalDir: "/b"},
+		"broken/db":      {Upstream: "broken/db", LocalDir: "/c"},
+	}}
+
+	var stdout bytes.Buffer
+	err := runSyncAll(&stdout, store)
+	if !errors.Is(err, errExit) {
+		t.Fatalf("expected errExit with a failed sync, got: %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"hop/wl-commons", "acme/board", "pull failed", "Synced 2 of 3 wastelands (1 failed)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if len(store.saved) != 2 {
+		t.Errorf("saved %d configs, want 2 (successful syncs only)", len(store.saved))
+	}
+	for _, cfg := range store.saved {
+		if cfg.LastSyncAt == nil {
+			t.Errorf("%s: LastSyncAt not recorded", cfg.Upstream)
+		}
+	}
+}
+
+func TestRunSyncAll_AllSucceed(t *testing.T) {
+	stubSyncWasteland(t, func(_ *federation.Config) (string, error) { return "synced", nil })
+	store := &fakeConfigStore{configs: map[string]*federation.Config{
+		"hop/wl-commons": {Upstream: "hop/wl-commons"},
+		"acme/board":     {Upstream: "acme/board"},
+	}}
+
+	var stdout bytes.Buffer
+	if err := runSyncAll(&stdout, store); err != nil {
+		t.Fatalf("runSyncAll: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Synced 2 wastelands") {
+		t.Errorf("missing summary:\n%s", stdout.String())
+	}
+}
+
+func TestRunSyncAll_NoneJoined(t *testing.T) {
+	var stdout bytes.Buffer
+	err := runSyncAll(&stdout, &fakeConfigStore{configs: map[string]*federation.Config{}})
+	if err == nil || !strings.Contains(err.Error(), "not joined") {
+		t.Fatalf("expected not joined error, got: %v", err)
+	}
+}
+
+func TestRenderReconciled(t *testing.T) {
+	var buf bytes.Buffer
+	renderReconciled(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("no results should print nothing, got %q", buf.String())
+	}
+
+	renderReconciled(&buf, []sdk.ReconciledBranch{
+		{Branch: "wl/alice/w-1", PRState: "merged"},
+		{Branch: "wl/alice/w-2", PRState: "closed", Err: fmt.Errorf("permission denied")},
+	})
+	for _, want := range []string{"Cleaned up branches", "wl/alice/w-1", "(PR merged)", "wl/alice/w-2: permission denied"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/gastownhall/wasteland/internal/syncd"
+	"github.com/gastownhall/wasteland/internal/xdg"
+	"github.com/spf13/cobra"
+)
+
+// syncdFlags are the daemon settings shared by 'start' and the hidden
+// 'run' it launches.
+type syncdFlags struct {
+	interval time.Duration
+	webhook  string
+	desktop  bool
+}
+
+func (f *syncdFlags) register(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&f.interval, "interval", syncd.DefaultInterval, "Time between sync cycles")
+	cmd.Flags().StringVar(&f.webhook, "webhook", "", "POST a JSON notification to this URL for each event")
+	cmd.Flags().BoolVar(&f.desktop, "desktop", false, "Show a desktop notification for each event")
+}
+
+func (f *syncdFlags) args() []string {
+	args := []string{"--interval", f.interval.String()}
+	if f.webhook != "" {
+		args = append(args, "--webhook", f.webhook)
+	}
+	if f.desktop {
+		args = append(args, "--desktop")
+	}
+	return args
+}
+
+func newSyncdCmd(stdout, _ io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "syncd",
+		Short: "Run a background daemon that keeps joined wastelands in sync",
+		Long: `The sync daemon works through every joined wasteland on an interval
+(default ` + syncd.DefaultInterval.String() + `):
+
+  - pulls upstream into the local clone, unless local main has commits
+    upstream lacks: that divergence is reported for 'wl sync --strategy',
+  - refreshes the state of your PRs, removing branches whose PR was
+    merged or closed (PR mode),
+  - prunes wl/<handle>/* branches already merged into main, and
+  - reports upstream changes to items you posted or claimed.
+
+Those reports can also go out as desktop notifications (--desktop) or as
+JSON POSTs to a webhook (--webhook). The daemon logs to
+` + syncd.LogPath(xdg.DataDir()) + `.
+
+EXAMPLES:
+  wl syncd start                       # Sync every 15 minutes
+  wl syncd start --interval 5m --desktop
+  wl syncd status                      # Show the last cycle's results
+  wl syncd stop`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	var startFlags syncdFlags
+	startCmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start the sync daemon",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runSyncdStart(stdout, &startFlags)
+		},
+	}
+	startFlags.register(startCmd)
+
+	var asJSON bool
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the sync daemon is running and what it last did",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runSyncdStatus(stdout, xdg.DataDir(), asJSON)
+		},
+	}
+	statusCmd.Flags().BoolVar(&asJSON, "json", false, "Output the daemon state as JSON")
+
+	cmd.AddCommand(
+		startCmd,
+		&cobra.Command{
+			Use:   "stop",
+			Short: "Stop the sync daemon",
+			Args:  cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return runSyncdStop(stdout)
+			},
+		},
+		statusCmd,
+		newSyncdRunCmd(),
+	)
+	return cmd
+}
+
+// newSyncdRunCmd is the daemon process started by 'wl syncd start'; it
+// isn't meant to be run by hand.
+func newSyncdRunCmd() *cobra.Command {
+	var flags syncdFlags
+	cmd := &cobra.Command{
+		Use:    "run",
+		Short:  "Run the sync daemon in the foreground",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			store := federation.NewConfigStore()
+			deps := newSyncdDeps(store)
+			return syncd.Run(context.Background(), syncd.Options{
+				Dir:        xdg.DataDir(),
+				Interval:   flags.interval,
+				Wastelands: store.List,
+				Cycle: func(_ context.Context, name string) syncd.Report {
+					return syncdCycle(deps, name)
+				},
+				Notify: syncdNotifier(&flags),
+			})
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func runSyncdStart(stdout io.Writer, flags *syncdFlags) error {
+	if flags.interval < syncd.MinInterval {
+		return fmt.Errorf("--interval must be at least %s", syncd.MinInterval)
+	}
+	if err := requireDolt(); err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		exe = os.Args[0]
+	}
+	run := exec.Command(exe, append([]string{"syncd", "run"}, flags.args()...)...)
+	st, err := syncd.Start(xdg.DataDir(), run)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s syncd started (pid %d), syncing every %s\n",
+		style.Bold.Render("✓"), st.PID, st.Interval)
+	fmt.Fprintf(stdout, "  Log: %s\n", style.Dim.Render(syncd.LogPath(xdg.DataDir())))
+	return nil
+}
+
+func runSyncdStop(stdout io.Writer) error {
+	stopped, err := syncd.Stop(xdg.DataDir())
+	if err != nil {
+		return err
+	}
+	if !stopped {
+		fmt.Fprintln(stdout, "syncd is not running")
+		return nil
+	}
+	fmt.Fprintln(stdout, "Stopped syncd")
+	return nil
+}
+
+func runSyncdStatus(stdout io.Writer, dir string, asJSON bool) error {
+	st, err := syncd.ReadState(dir)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(st) // null when not running
+	}
+	if st == nil {
+		fmt.Fprintln(stdout, "syncd is not running")
+		return nil
+	}
+	fmt.Fprintf(stdout, "syncd running (pid %d)\n", st.PID)
+	fmt.Fprintf(stdout, "  Interval:    %s\n", st.Interval)
+	fmt.Fprintf(stdout, "  Started:     %s\n", st.StartedAt.Local().Format(time.DateTime))
+	if st.LastCycle.IsZero() {
+		fmt.Fprintf(stdout, "  Last cycle:  %s\n", style.Dim.Render("in progress"))
+		return nil
+	}
+	fmt.Fprintf(stdout, "  Last cycle:  %s\n", st.LastCycle.Local().Format(time.DateTime))
+	fmt.Fprintln(stdout)
+	for _, name := range slices.Sorted(maps.Keys(st.Reports)) {
+		r := st.Reports[name]
+		if r.Error != "" {
+			fmt.Fprintf(stdout, "  %s %-30s %s\n", style.Error.Render(style.IconFail), name, r.Error)
+		} else {
+			fmt.Fprintf(stdout, "  %s %-30s %s\n", style.Success.Render(style.IconPass), name, style.Dim.Render(r.Summary))
+		}
+		for _, e := range r.Events {
+			fmt.Fprintf(stdout, "      %s\n", e)
+		}
+	}
+	return nil
+}
+
+// syncdNotifier builds the notifier the daemon's flags ask for, or nil.
+func syncdNotifier(flags *syncdFlags) syncd.Notifier {
+	var ns syncd.Notifiers
+	if flags.webhook != "" {
+		ns = append(ns, &syncd.Webhook{URL: flags.webhook})
+	}
+	if flags.desktop {
+		ns = append(ns, syncd.Desktop{})
+	}
+	if len(ns) == 0 {
+		return nil
+	}
+	return ns
+}
+
+// syncdDeps holds the external operations used by a daemon cycle, so
+// tests can substitute fakes.
+type syncdDeps struct {
+	store      federation.ConfigStore
+	divergence func(dbDir string) (*commons.Divergence, error)
+	pull       func(dbDir string) error
+	reconcile  func(cfg *federation.Config) ([]sdk.ReconciledBranch, error)
+	prune      *pruneDeps // doltQuery also serves the cycle's own queries
+}
+
+func newSyncdDeps(store federation.ConfigStore) *syncdDeps {
+	return &syncdDeps{
+		store:      store,
+		divergence: commons.CheckDivergence,
+		pull:       commons.PullUpstream,
+		reconcile:  reconcileBranches,
+		prune: &pruneDeps{
+			fetch:        commons.FetchRemote,
+			doltQuery:    commons.DoltSQLQuery,
+			prState:      prStateForBranch,
+			deleteLocal:  commons.DeleteBranch,
+			deleteRemote: commons.DeleteRemoteBranch,
+		},
+	}
+}
+
+// syncdCycle does one wasteland's share of a daemon cycle: pull upstream
+// and note changes to the rig's items, clean up branches of merged or
+// closed PRs, and prune branches already merged into main. A local main
+// with commits upstream lacks is not pulled: merging it is left to
+// 'wl sync --strategy', and the divergence is reported as an event.
+// Steps after the pull are best-effort; their failures are noted in the
+// summary.
+func syncdCycle(deps *syncdDeps, name string) syncd.Report {
+	r := syncd.Report{At: time.Now()}
+	cfg, err := deps.store.Load(name)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	local := cfg.ResolveBackend() == federation.BackendLocal && cfg.LocalDir != ""
+	var notes []string
+
+	if local {
+		d, err := deps.divergence(cfg.LocalDir)
+		if err != nil {
+			r.Error = fmt.Sprintf("comparing with upstream: %v", err)
+			return r
+		}
+		if d.Ahead > 0 {
+			notes = append(notes, "diverged from upstream: not pulled")
+			r.Events = append(r.Events, fmt.Sprintf(
+				"local main is %d commit(s) ahead of upstream and %d behind; run 'wl sync --strategy ours|theirs|manual' to merge",
+				d.Ahead, d.Behind))
+		} else {
+			query := deps.prune.doltQuery
+			before := mainHead(query, cfg.LocalDir)
+			if err := deps.pull(cfg.LocalDir); err != nil {
+				r.Error = fmt.Sprintf("pulling upstream: %v", err)
+				return r
+			}
+			after := mainHead(query, cfg.LocalDir)
+			if before == "" || before == after {
+				notes = append(notes, "up to date")
+			} else {
+				events, n, err := upstreamEvents(query, cfg, before)
+				if err != nil {
+					notes = append(notes, "pulled")
+				} else {
+					notes = append(notes, fmt.Sprintf("pulled %d commit(s)", n))
+				}
+				r.Events = append(r.Events, events...)
+			}
+		}
+		now := time.Now()
+		cfg.LastSyncAt = &now
+		_ = deps.store.Save(cfg) // best-effort, as in 'wl sync --all'
+	}
+
+	if cfg.IsReadOnly() {
+		r.Summary = strings.Join(append(notes, "read-only: branches left alone"), ", ")
+		return r
+	}
+
+	if cfg.ResolveMode() == federation.ModePR {
+		results, err := deps.reconcile(cfg)
+		if err != nil {
+			notes = append(notes, "PR refresh failed: "+err.Error())
+		}
+		cleaned := 0
+		for _, b := range results {
+			if b.Err != nil {
+				continue
+			}
+			cleaned++
+			r.Events = append(r.Events, fmt.Sprintf("PR for %s was %s; removed %s", b.WantedID, b.PRState, b.Branch))
+		}
+		if cleaned > 0 {
+			notes = append(notes, fmt.Sprintf("cleaned up %d branch(es)", cleaned))
+		}
+	}
+
+	if local {
+		pruned, err := pruneMerged(cfg, deps.prune)
+		if err != nil {
+			notes = append(notes, "prune failed: "+err.Error())
+		} else if pruned > 0 {
+			notes = append(notes, fmt.Sprintf("pruned %d merged branch(es)", pruned))
+		}
+	}
+
+	if len(notes) == 0 {
+		notes = append(notes, "nothing to sync")
+	}
+	r.Summary = strings.Join(notes, ", ")
+	return r
+}
+
+// mainHead returns the commit hash at the tip of main, or "" if it can't
+// be read.
+func mainHead(query func(dbDir, q string) (string, error), dir string) string {
+	out, err := query(dir, "SELECT commit_hash FROM dolt_log('main') LIMIT 1")
+	if rows := wlParseCSV(out); err == nil && len(rows) >= 2 && len(rows[1]) > 0 {
+		return rows[1][0]
+	}
+	return ""
+}
+
+// upstreamEvents describes the commits pulled since before that touched
+// items the rig posted or claimed, and returns how many commits were
+// pulled.
+func upstreamEvents(query func(dbDir, q string) (string, error), cfg *federation.Config, before string) ([]string, int, error) {
+	out, err := query(cfg.LocalDir, fmt.Sprintf(
+		"SELECT message FROM dolt_log('%s..main')", commons.EscapeSQL(before)))
+	if err != nil {
+		return nil, 0, err
+	}
+	rows := wlParseCSV(out)
+	if len(rows) < 2 {
+		return nil, 0, nil
+	}
+	commits := rows[1:]
+
+	// Newest first, as dolt_log lists them; one event per item and action.
+	type change struct{ action, id string }
+	var changes []change
+	seen := map[change]bool{}
+	ids := map[string]bool{}
+	for _, row := range commits {
+		if len(row) == 0 {
+			continue
+		}
+		action, id := commons.ParseCommitMessage(row[0])
+		c := change{action, id}
+		if id == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		ids[id] = true
+		changes = append(changes, c)
+	}
+	if len(changes) == 0 {
+		return nil, len(commits), nil
+	}
+
+	quoted := make([]string, 0, len(ids))
+	for _, id := range slices.Sorted(maps.Keys(ids)) {
+		quoted = append(quoted, "'"+commons.EscapeSQL(id)+"'")
+	}
+	rig := commons.EscapeSQL(cfg.RigHandle)
+	out, err = query(cfg.LocalDir, fmt.Sprintf(
+		"SELECT id, title FROM wanted WHERE id IN (%s) AND (posted_by = '%s' OR claimed_by = '%s')",
+		strings.Join(quoted, ", "), rig, rig))
+	if err != nil {
+		return nil, len(commits), err
+	}
+	titles := map[string]string{}
+	for i, row := range wlParseCSV(out) {
+		if i == 0 || len(row) < 2 {
+			continue // header
+		}
+		titles[row[0]] = row[1]
+	}
+
+	var events []string
+	for _, c := range changes {
+		if title, ok := titles[c.id]; ok {
+			events = append(events, fmt.Sprintf("upstream %s on your item %s: %s", c.action, c.id, title))
+		}
+	}
+	return events, len(commits), nil
+}
+
+// pruneMerged deletes the rig's branches whose work is already on main:
+// those with a merged PR or no changes against main. Branches prune would
+// drop for other reasons are left for 'wl prune' to confirm.
+func pruneMerged(cfg *federation.Config, deps *pruneDeps) (int, error) {
+	stale, err := findStaleBranches(io.Discard, cfg, deps)
+	if err != nil {
+		return 0, err
+	}
+	pruned := 0
+	for _, sb := range stale {
+		if sb.Reason != "PR merged" && sb.Reason != "no changes against main" {
+			continue
+		}
+		err := deps.deleteLocal(cfg.LocalDir, sb.Branch)
+		if err == nil && sb.OnFork {
+			err = deps.deleteRemote(cfg.LocalDir, "origin", sb.Branch)
+		}
+		if err == nil {
+			pruned++
+		}
+	}
+	return pruned, nil
+}
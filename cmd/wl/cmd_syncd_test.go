@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// fakeSyncdDeps returns syncdDeps whose main head moves from "aaa" to
+// "bbb" across the pull, with other queries answered from results.
+func fakeSyncdDeps(cfg *federation.Config, results map[string]string) (*syncdDeps, *fakeConfigStore, *[]string) {
+	store := &fakeConfigStore{configs: map[string]*federation.Config{cfg.Upstream: cfg}}
+	prune, deleted := fakePruneDeps(results, nil, false)
+	heads := []string{"aaa", "bbb"}
+	query := prune.doltQuery
+	prune.doltQuery = func(dir, q string) (string, error) {
+		if strings.Contains(q, "dolt_log('main')") {
+			h := heads[0]
+			if len(heads) > 1 {
+				heads = heads[1:]
+			}
+			return "commit_hash\n" + h + "\n", nil
+		}
+		return query(dir, q)
+	}
+	deps := &syncdDeps{
+		store:      store,
+		divergence: func(string) (*commons.Divergence, error) { return &commons.Divergence{}, nil },
+		pull:       func(string) error { return nil },
+		reconcile: func(*federation.Config) ([]sdk.ReconciledBranch, error) {
+			return []sdk.ReconciledBranch{
+				{Branch: "wl/alice/w-5", WantedID: "w-5", PRState: "merged"},
+				{Branch: "wl/alice/w-6", WantedID: "w-6", PRState: "closed", Err: errors.New("delete failed")},
+			}, nil
+		},
+		prune: prune,
+	}
+	return deps, store, deleted
+}
+
+var syncdResults = map[string]string{
+	"dolt_log('aaa..main')":                  "message\nwl done: w-1 \"Fix docs\"\nwl claim: w-9 by bob\nwl claim: w-1 by bob\nMerge upstream\n",
+	"FROM wanted WHERE id IN ('w-1', 'w-9')": "id,title\nw-1,Fix docs\n",
+	"dolt_remote_branches":                   "name\n",
+	"dolt_branches":                          "name\nwl/alice/w-2\nwl/alice/w-3\n",
+	"'main...wl/alice/w-2'":                  "table_name\n",
+	"'main...wl/alice/w-3'":                  "table_name\nwanted\n",
+	"id = 'w-3'":                             "status\ncompleted\n",
+}
+
+func TestSyncdCycle(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModeWildWest}
+	deps, store, deleted := fakeSyncdDeps(cfg, syncdResults)
+
+	r := syncdCycle(deps, "hop/wl-commons")
+
+	if r.Error != "" {
+		t.Fatalf("Error = %q", r.Error)
+	}
+	if want := "pulled 4 commit(s), pruned 1 merged branch(es)"; r.Summary != want {
+		t.Errorf("Summary = %q, want %q", r.Summary, want)
+	}
+	wantEvents := []string{
+		"upstream done on your item w-1: Fix docs",
+		"upstream claim on your item w-1: Fix docs",
+	}
+	if strings.Join(r.Events, "\n") != strings.Join(wantEvents, "\n") {
+		t.Errorf("Events = %q, want %q", r.Events, wantEvents)
+	}
+	// w-3's item is completed, but that's left for 'wl prune' to confirm.
+	if got := strings.Join(*deleted, ","); got != "local:wl/alice/w-2" {
+		t.Errorf("deleted = %s, want local:wl/alice/w-2", got)
+	}
+	if len(store.saved) != 1 || store.saved[0].LastSyncAt == nil {
+		t.Error("LastSyncAt not saved")
+	}
+}
+
+func TestSyncdCycle_PRMode(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModePR}
+	deps, _, _ := fakeSyncdDeps(cfg, syncdResults)
+
+	r := syncdCycle(deps, "hop/wl-commons")
+
+	if !strings.Contains(r.Summary, "cleaned up 1 branch(es)") {
+		t.Errorf("Summary = %q, want the cleaned-up branch counted", r.Summary)
+	}
+	var found bool
+	for _, e := range r.Events {
+		if e == "PR for w-5 was merged; removed wl/alice/w-5" {
+			found = true
+		}
+		if strings.Contains(e, "w-6") {
+			t.Errorf("event for a branch that failed to delete: %q", e)
+		}
+	}
+	if !found {
+		t.Errorf("Events = %q, want the merged PR reported", r.Events)
+	}
+}
+
+func TestSyncdCycle_PullError(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModeWildWest}
+	deps, store, deleted := fakeSyncdDeps(cfg, syncdResults)
+	deps.pull = func(string) error { return errors.New("offline") }
+
+	r := syncdCycle(deps, "hop/wl-commons")
+
+	if r.Error != "pulling upstream: offline" {
+		t.Errorf("Error = %q", r.Error)
+	}
+	if len(*deleted) != 0 || len(store.saved) != 0 {
+		t.Error("cycle continued after a failed pull")
+	}
+}
+
+func TestSyncdCycle_Diverged(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModeWildWest}
+	deps, _, _ := fakeSyncdDeps(cfg, syncdResults)
+	deps.divergence = func(string) (*commons.Divergence, error) { return &commons.Divergence{Ahead: 2, Behind: 3}, nil }
+	pulled := false
+	deps.pull = func(string) error { pulled = true; return nil }
+
+	r := syncdCycle(deps, "hop/wl-commons")
+
+	if pulled {
+		t.Error("pulled upstream into a diverged main")
+	}
+	if r.Error != "" || !strings.Contains(r.Summary, "diverged from upstream") {
+		t.Errorf("Error = %q, Summary = %q", r.Error, r.Summary)
+	}
+	if len(r.Events) != 1 || !strings.Contains(r.Events[0], "2 commit(s) ahead of upstream and 3 behind") {
+		t.Errorf("Events = %q, want the divergence reported", r.Events)
+	}
+}
+
+func TestSyncdCycle_ReadOnly(t *testing.T) {
+	t.Parallel()
+	cfg := &federation.Config{Upstream: "hop/wl-commons", RigHandle: "alice", LocalDir: "/tmp/db", Mode: federation.ModeWildWest, ReadOnly: true}
+	deps, _, deleted := fakeSyncdDeps(cfg, syncdResults)
+
+	r := syncdCycle(deps, "hop/wl-commons")
+
+	if len(*deleted) != 0 {
+		t.Errorf("deleted %v in a read-only wasteland", *deleted)
+	}
+	if !strings.Contains(r.Summary, "read-only") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
+
+func TestRunSyncdStatus_NotRunning(t *testing.T) {
+	t.Parallel()
+	var stdout bytes.Buffer
+	if err := runSyncdStatus(&stdout, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "not running") {
+		t.Errorf("output = %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := runSyncdStatus(&stdout, t.TempDir(), true); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(stdout.String()) != "null" {
+		t.Errorf("JSON output = %q, want null", stdout.String())
+	}
+}
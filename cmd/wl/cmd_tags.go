@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newTagsCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "List the wasteland's registered tags",
+		Long: `List the canonical tags in the wasteland's tag registry.
+
+The registry is the optional 'tags' table: each row holds a canonical tag
+and a JSON array of aliases. Tags given to wl post and wl update are
+rewritten to their canonical form (so "golang" and "Go" become "go"), and
+browse tag filters resolve aliases the same way. Tags not in the registry
+pass through unchanged unless the wasteland sets strict_tags in _meta,
+in which case they are rejected.
+
+A wasteland without a tags table accepts any tag.
+
+EXAMPLES:
+  wl tags
+  wl tags --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTags(cmd, stdout, stderr, jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	return cmd
+}
+
+func runTags(cmd *cobra.Command, stdout, _ io.Writer, jsonOut bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+
+	db, err := openDBFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ResolveBackend() == federation.BackendLocal {
+		if err := requireDolt(); err != nil {
+			return err
+		}
+		sp := style.StartSpinner(stdout, "Syncing with upstream...")
+		syncErr := db.Sync()
+		sp.Stop()
+		if syncErr != nil {
+			return fmt.Errorf("syncing with upstream: %w", syncErr)
+		}
+	}
+
+	reg, err := commons.QueryTagRegistry(db)
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		return renderTagsJSON(stdout, reg)
+	}
+	renderTags(stdout, cfg.Upstream, reg)
+	return nil
+}
+
+func renderTags(stdout io.Writer, upstream string, reg *commons.TagRegistry) {
+	if len(reg.Defs) == 0 {
+		fmt.Fprintf(stdout, "%s has no tag registry — any tag is accepted.\n", upstream)
+		return
+	}
+
+	policy := "unregistered tags are kept as given"
+	if reg.Strict {
+		policy = "strict: unregistered tags are rejected"
+	}
+	fmt.Fprintf(stdout, "Tags for %s (%d, %s):\n\n", upstream, len(reg.Defs), policy)
+
+	tbl := style.NewTable(
+		style.Column{Name: "TAG", Width: 20},
+		style.Column{Name: "ALIASES", Width: 30},
+		style.Column{Name: "DESCRIPTION", Width: 40},
+	)
+	for _, d := range reg.Defs {
+		tbl.AddRow(d.Tag, strings.Join(d.Aliases, ", "), d.Description)
+	}
+	fmt.Fprint(stdout, tbl.Render())
+}
+
+func renderTagsJSON(stdout io.Writer, reg *commons.TagRegistry) error {
+	tags := reg.Defs
+	if tags == nil {
+		tags = []commons.TagDef{}
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Tags   []commons.TagDef `json:"tags"`
+		Strict bool             `json:"strict"`
+	}{tags, reg.Strict})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestRenderTags(t *testing.T) {
+	t.Parallel()
+	reg := commons.NewTagRegistry([]commons.TagDef{
+		{Tag: "docs"},
+		{Tag: "go", Aliases: []string{"golang", "Go-lang"}, Description: "The Go language"},
+	}, true)
+
+	var buf bytes.Buffer
+	renderTags(&buf, "hop/wl-commons", reg)
+	out := buf.String()
+	for _, want := range []string{"Tags for hop/wl-commons (2, strict", "golang, Go-lang", "The Go language"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	renderTags(&buf, "hop/wl-commons", commons.NewTagRegistry(nil, false))
+	if !strings.Contains(buf.String(), "no tag registry") {
+		t.Errorf("empty registry output = %q", buf.String())
+	}
+}
+
+func TestRenderTagsJSON_Empty(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := renderTagsJSON(&buf, commons.NewTagRegistry(nil, false)); err != nil {
+		t.Fatalf("renderTagsJSON: %v", err)
+	}
+	var got struct {
+		Tags   []commons.TagDef `json:"tags"`
+		Strict bool             `json:"strict"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", buf.String(), err)
+	}
+	if got.Tags == nil || len(got.Tags) != 0 {
+		t.Errorf("tags = %#v, want empty array", got.Tags)
+	}
+}
@@ -39,7 +39,7 @@ func runTUI(cmd *cobra.Command, _, stderr io.Writer) error {
 		if err := requireDolt(); err != nil {
 			return err
 		}
-		localDB := backend.NewLocalDB(cfg.LocalDir, cfg.ResolveMode())
+		localDB := newLocalDB(cfg)
 		db = localDB
 
 		// Sync before launching the TUI.
@@ -51,7 +51,7 @@ func runTUI(cmd *cobra.Command, _, stderr io.Writer) error {
 		}
 
 		// PR mode: force-push main to origin so it matches upstream.
-		if cfg.ResolveMode() == federation.ModePR {
+		if cfg.ResolveMode() == federation.ModePR && !cfg.IsReadOnly() {
 			if err := localDB.PushMain(io.Discard); err != nil {
 				fmt.Fprintf(stderr, "  warning: could not sync origin/main: %v\n", err)
 			}
@@ -92,13 +92,20 @@ func runTUI(cmd *cobra.Command, _, stderr io.Writer) error {
 		return buf.String(), nil
 	}
 
+	pushOutput, pushProgress := tui.NewPushProgress()
 	client := sdk.New(sdk.ClientConfig{
-		DB:        db,
-		RigHandle: cfg.RigHandle,
-		Mode:      cfg.ResolveMode(),
-		Signing:   cfg.Signing,
-		HopURI:    cfg.HopURI,
-		LoadDiff:  loadDiff,
+		DB:         commons.Chained(db, cfg.RigHandle, cfg.HopURI),
+		RigHandle:  cfg.RigHandle,
+		Mode:       cfg.ResolveMode(),
+		Signing:    cfg.Signing,
+		SigningKey: cfg.SigningKey,
+		HopURI:     cfg.HopURI,
+		ReadOnly:   cfg.IsReadOnly(),
+		LoadDiff:   loadDiff,
+		Hooks:      hookRunner(cfg, nil), // hook output would corrupt the TUI
+
+		AllowSecrets: cfg.AllowSecrets,
+		PushOutput:   pushOutput,
 		CreatePR: func(branch string) (string, error) {
 			if cfg.ResolveBackend() != federation.BackendLocal {
 				return createPRForBranchRemote(cfg, db, branch)
@@ -124,6 +131,13 @@ func runTUI(cmd *cobra.Command, _, stderr io.Writer) error {
 		ListPendingItems: listPendingItemsFromPRs(cfg),
 		BranchURL:        branchURLCallback(cfg),
 		CloseUpstreamPR:  closeUpstreamPRCallback(cfg),
+		SquashBranch:     squashBranchCallback(cfg),
+		UpdateBranch:     updateBranchCallback(cfg),
+		PRState: func(branch string) string {
+			return prStateForBranch(cfg, branch)
+		},
+		RateLimits:  ghRateLimits.RateLimits,
+		PRStatusTTL: prStatusTTL,
 	})
 
 	m := tui.New(tui.Config{
@@ -132,11 +146,22 @@ func runTUI(cmd *cobra.Command, _, stderr io.Writer) error {
 		Upstream:     cfg.Upstream,
 		Mode:         cfg.ResolveMode(),
 		Signing:      cfg.Signing,
+		ConfirmPush:  cfg.ConfirmPush,
+		PushProgress: pushProgress,
 		ProviderType: cfg.ResolveProviderType(),
 		ForkOrg:      cfg.ForkOrg,
 		ForkDB:       cfg.ForkDB,
 		LocalDir:     cfg.LocalDir,
 		JoinedAt:     cfg.JoinedAt.Format("2006-01-02"),
+
+		ReviewComments: reviewCommentsSupported(cfg),
+		CheckNotify:    tuiCheckNotify(cfg, client.Followed),
+		SaveConfirmPush: func(on bool) error {
+			return updateConfig(cmd, "save settings", func(c *federation.Config) error {
+				c.ConfirmPush = on
+				return nil
+			})
+		},
 	})
 
 	p := bubbletea.NewProgram(m, bubbletea.WithAltScreen())
@@ -3,6 +3,7 @@ package main
 import (
 	"io"
 
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -56,7 +57,7 @@ func runUnclaim(cmd *cobra.Command, stdout, _ io.Writer, wantedID string, noPush
 	}
 
 	renderMutationResult(stdout, "Unclaimed", wantedID, result)
-	printNextHint(stdout, "Next: item is back on the board. Browse: wl browse")
+	printNextHint(stdout, i18n.T("next.unclaim"))
 
 	return nil
 }
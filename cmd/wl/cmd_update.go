@@ -1,11 +1,16 @@
 package main
 
 import (
+	"cmp"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/i18n"
+	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +24,8 @@ func newUpdateCmd(stdout, stderr io.Writer) *cobra.Command {
 		effort      string
 		tags        string
 		noPush      bool
+		siblings    bool
+		editor      bool
 	)
 
 	cmd := &cobra.Command{
@@ -28,18 +35,27 @@ func newUpdateCmd(stdout, stderr io.Writer) *cobra.Command {
 
 Only items with status 'open' can be updated — once claimed, the contract is locked.
 
-At least one field must be provided. In wild-west mode any joined rig can update.
+At least one field must be provided, or --editor to edit the item in
+$VISUAL or $EDITOR: its current fields are YAML front matter (with any
+field flags already applied) and its description the markdown below.
+Fields you change are updated; a cleared field is left as it was. In
+wild-west mode any joined rig can update.
 
 In wild-west mode the commit is auto-pushed to upstream and origin.
 Use --no-push to skip pushing (offline work).
 
+Use --siblings to make the same change to the copies of an item that
+'wl post' fanned out to other wastelands.
+
 Examples:
   wl update w-abc123 --title "New title"
   wl update w-abc123 --priority 1 --effort large
-  wl update w-abc123 --type bug --tags "go,auth"`,
+  wl update w-abc123 --type bug --tags "go,auth"
+  wl update w-abc123 --priority 0 --siblings
+  wl update w-abc123 --editor`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(cmd, stdout, stderr, args[0], title, description, project, itemType, priority, effort, tags, noPush)
+			return runUpdate(cmd, stdout, stderr, args[0], title, description, project, itemType, priority, effort, tags, noPush, siblings, editor)
 		},
 	}
 
@@ -51,16 +67,24 @@ Examples:
 	cmd.Flags().StringVar(&effort, "effort", "", "Effort level: trivial, small, medium, large, epic")
 	cmd.Flags().StringVar(&tags, "tags", "", "Comma-separated tags (replaces existing)")
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Skip pushing to remotes (offline work)")
+	cmd.Flags().BoolVar(&editor, "editor", false, "Edit the item's fields and description in $EDITOR")
+	cmd.Flags().BoolVar(&siblings, "siblings", false, "Also update the item's siblings in other wastelands (see 'wl post --all')")
 	_ = cmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	_ = cmd.RegisterFlagCompletionFunc("tags", completeTagNames)
+	_ = cmd.RegisterFlagCompletionFunc("type", completeItemTypes)
+	_ = cmd.RegisterFlagCompletionFunc("effort", completeEffortLevels)
 	cmd.ValidArgsFunction = completeWantedIDs("open")
 
 	return cmd
 }
 
-func runUpdate(cmd *cobra.Command, stdout, _ io.Writer, wantedID, title, description, project, itemType string, priority int, effort, tags string, noPush bool) error {
-	// Validate before building the update struct.
-	if err := validateUpdateInputs(itemType, effort, priority); err != nil {
-		return err
+func runUpdate(cmd *cobra.Command, stdout, _ io.Writer, wantedID, title, description, project, itemType string, priority int, effort, tags string, noPush, siblings, editor bool) error {
+	// Type and effort depend on the wasteland's vocabulary and are checked
+	// once the client is up; priority can be checked now.
+	if priority != -1 {
+		if err := validatePriority(priority); err != nil {
+			return err
+		}
 	}
 
 	fields := &commons.WantedUpdate{
@@ -84,8 +108,8 @@ func runUpdate(cmd *cobra.Command, stdout, _ io.Writer, wantedID, title, descrip
 		fields.TagsSet = true
 	}
 
-	if !hasUpdateFields(fields) {
-		return fmt.Errorf("at least one field must be provided to update")
+	if !editor && !hasUpdateFields(fields) {
+		return fmt.Errorf("at least one field must be provided to update (or use --editor)")
 	}
 
 	wlCfg, err := resolveWasteland(cmd)
@@ -98,47 +122,136 @@ func runUpdate(cmd *cobra.Command, stdout, _ io.Writer, wantedID, title, descrip
 		return err
 	}
 
+	var group siblingGroup
+	if siblings {
+		if group, err = requireSiblings(wlCfg, wantedID); err != nil {
+			return err
+		}
+	}
+
 	client, err := newSDKClient(wlCfg, noPush)
 	if err != nil {
 		return err
 	}
 
+	if editor {
+		if fields, err = editUpdateFields(client, wantedID, fields); err != nil {
+			return err
+		}
+		if !hasUpdateFields(fields) {
+			return fmt.Errorf("nothing changed: %s was not updated", wantedID)
+		}
+		itemType, effort, priority = fields.Type, fields.EffortLevel, fields.Priority
+	}
+
+	if err := validateUpdateInputs(cliVocabulary(client.Vocabulary()), itemType, effort, priority); err != nil {
+		return err
+	}
+
 	result, err := client.Update(wantedID, fields)
 	if err != nil {
 		return err
 	}
 
 	renderMutationResult(stdout, "Updated", wantedID, result)
-	printNextHint(stdout, "Next: wl browse to see the board")
+	if siblings {
+		err := fanOutToSiblings(cmd, stdout, group, "Updated", func(cfg *federation.Config, id string) error {
+			c, err := newSDKClient(cfg, noPush)
+			if err != nil {
+				return err
+			}
+			if err := validateUpdateInputs(cliVocabulary(c.Vocabulary()), itemType, effort, priority); err != nil {
+				return err
+			}
+			_, err = c.Update(id, fields)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	printNextHint(stdout, i18n.T("next.browse"))
 
 	return nil
 }
 
+// editUpdateFields has the user edit wantedID in their editor, starting
+// from its current fields with flags already applied, and returns the
+// fields that differ from the item.
+func editUpdateFields(client *sdk.Client, wantedID string, flags *commons.WantedUpdate) (*commons.WantedUpdate, error) {
+	detail, err := client.Detail(wantedID)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil || detail.Item == nil {
+		return nil, &commons.NotFoundError{Message: fmt.Sprintf("wanted item %q not found", wantedID)}
+	}
+	item := detail.Item
+
+	draft := itemDraft{
+		Title:       cmp.Or(flags.Title, item.Title),
+		Project:     cmp.Or(flags.Project, item.Project),
+		Type:        cmp.Or(flags.Type, item.Type),
+		Priority:    item.Priority,
+		Effort:      cmp.Or(flags.EffortLevel, item.EffortLevel),
+		Tags:        item.Tags,
+		Description: cmp.Or(flags.Description, item.Description),
+	}
+	if flags.Priority >= 0 {
+		draft.Priority = flags.Priority
+	}
+	if flags.TagsSet {
+		draft.Tags = flags.Tags
+	}
+
+	vocab := cliVocabulary(client.Vocabulary())
+	draft, err = editDraft(draft, draftHints(vocab, "update "+wantedID), func(d itemDraft) error {
+		return validateUpdateInputs(vocab, d.Type, d.Effort, d.Priority)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changed := func(edited, current string) string {
+		if edited == current {
+			return ""
+		}
+		return edited
+	}
+	out := &commons.WantedUpdate{
+		Title:       changed(draft.Title, item.Title),
+		Description: changed(draft.Description, strings.TrimSpace(item.Description)),
+		Project:     changed(draft.Project, item.Project),
+		Type:        changed(draft.Type, item.Type),
+		Priority:    -1,
+		EffortLevel: changed(draft.Effort, item.EffortLevel),
+	}
+	if draft.Priority != item.Priority {
+		out.Priority = draft.Priority
+	}
+	if !slices.Equal(draft.Tags, item.Tags) && (len(draft.Tags) > 0 || len(item.Tags) > 0) {
+		out.Tags, out.TagsSet = draft.Tags, true
+	}
+	return out, nil
+}
+
 // hasUpdateFields returns true if at least one field is set.
 func hasUpdateFields(f *commons.WantedUpdate) bool {
 	return f.Title != "" || f.Description != "" || f.Project != "" ||
 		f.Type != "" || f.Priority >= 0 || f.EffortLevel != "" || f.TagsSet
 }
 
-// validateUpdateInputs validates type, effort, and priority if provided.
-func validateUpdateInputs(itemType, effort string, priority int) error {
-	validTypes := map[string]bool{
-		"feature": true, "bug": true, "design": true, "rfc": true, "docs": true,
-	}
-	if itemType != "" && !validTypes[itemType] {
-		return fmt.Errorf("invalid type %q: must be one of feature, bug, design, rfc, docs", itemType)
-	}
-
-	validEfforts := map[string]bool{
-		"trivial": true, "small": true, "medium": true, "large": true, "epic": true,
+// validateUpdateInputs validates type, effort, and priority if provided,
+// checking type and effort against the wasteland's vocabulary.
+func validateUpdateInputs(vocab *commons.Vocabulary, itemType, effort string, priority int) error {
+	if err := vocab.CheckType(itemType); err != nil {
+		return err
 	}
-	if effort != "" && !validEfforts[effort] {
-		return fmt.Errorf("invalid effort %q: must be one of trivial, small, medium, large, epic", effort)
+	if err := vocab.CheckEffort(effort); err != nil {
+		return err
 	}
-
-	if priority != -1 && (priority < 0 || priority > 4) {
-		return fmt.Errorf("invalid priority %d: must be 0-4", priority)
+	if priority != -1 {
+		return validatePriority(priority)
 	}
-
 	return nil
 }
@@ -11,27 +11,34 @@ import (
 
 func TestValidateUpdateInputs(t *testing.T) {
 	t.Parallel()
+	defaults := commons.DefaultVocabulary()
+	custom := &commons.Vocabulary{Types: []string{"quest"}, Efforts: []string{"S", "L"}}
 	tests := []struct {
 		name     string
+		vocab    *commons.Vocabulary
 		itemType string
 		effort   string
 		priority int
 		wantErr  string
 	}{
-		{"all empty", "", "", -1, ""},
-		{"valid type", "bug", "", -1, ""},
-		{"invalid type", "bad", "", -1, "invalid type"},
-		{"valid effort", "", "small", -1, ""},
-		{"invalid effort", "", "huge", -1, "invalid effort"},
-		{"valid priority 0", "", "", 0, ""},
-		{"valid priority 4", "", "", 4, ""},
-		{"invalid priority too high", "", "", 9, "invalid priority"},
-		{"invalid priority negative", "", "", -5, "invalid priority"},
+		{"all empty", defaults, "", "", -1, ""},
+		{"valid type", defaults, "bug", "", -1, ""},
+		{"invalid type", defaults, "bad", "", -1, "invalid type"},
+		{"valid effort", defaults, "", "small", -1, ""},
+		{"invalid effort", defaults, "", "huge", -1, "invalid effort"},
+		{"valid priority 0", defaults, "", "", 0, ""},
+		{"valid priority 4", defaults, "", "", 4, ""},
+		{"invalid priority too high", defaults, "", "", 9, "invalid priority"},
+		{"invalid priority negative", defaults, "", "", -5, "invalid priority"},
+		{"custom type", custom, "quest", "", -1, ""},
+		{"default type not in custom list", custom, "bug", "", -1, "invalid type"},
+		{"custom effort", custom, "", "L", -1, ""},
+		{"default effort not in custom list", custom, "", "small", -1, "invalid effort"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			err := validateUpdateInputs(tt.itemType, tt.effort, tt.priority)
+			err := validateUpdateInputs(tt.vocab, tt.itemType, tt.effort, tt.priority)
 			if tt.wantErr == "" {
 				if err != nil {
 					t.Errorf("validateUpdateInputs() unexpected error: %v", err)
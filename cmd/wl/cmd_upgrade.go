@@ -0,0 +1,377 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+// latestReleaseURL is the GitHub API endpoint for the latest wl release.
+// Package-level variable to allow test overrides.
+var latestReleaseURL = "https://api.github.com/repos/gastownhall/wasteland/releases/latest"
+
+// upgradeHTTPClient is used for release metadata and asset downloads.
+var upgradeHTTPClient = &http.Client{Timeout: 2 * time.Minute}
+
+func newUpgradeCmd(stdout, stderr io.Writer) *cobra.Command {
+	var checkOnly, force, insecureSkipSignature bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade wl to the latest release",
+		Long: `Check the latest GitHub release and replace the running wl binary.
+
+The release archive for this OS and architecture is downloaded and its
+SHA-256 checksum verified against the release's checksums file before
+the binary is replaced. Release builds also verify the checksums file's
+minisign signature against the release key built into wl, so a
+tampered release can't pass by shipping matching checksums. Nothing is
+replaced if verification fails.
+
+A build without the release key (one built from source) can't check the
+signature and refuses to upgrade unless --insecure-skip-signature is
+given, in which case only the checksum is verified.
+
+Development builds (version "dev") are not upgraded unless --force is given.
+
+Examples:
+  wl upgrade
+  wl upgrade --check-only
+  wl upgrade --force`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("locating running binary: %w", err)
+			}
+			if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+				exe = resolved
+			}
+			return runUpgrade(stdout, stderr, upgradeHTTPClient, latestReleaseURL, version, exe, checkOnly, force, insecureSkipSignature)
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "Report whether an upgrade is available without installing it")
+	cmd.Flags().BoolVar(&force, "force", false, "Install the latest release even if it is not newer")
+	cmd.Flags().BoolVar(&insecureSkipSignature, "insecure-skip-signature", false, "Upgrade a build without the release key, verifying only the checksum")
+
+	return cmd
+}
+
+// githubRelease is the subset of the GitHub release API response wl uses.
+type githubRelease struct {
+	TagName string        `json:"tag_name"`
+	HTMLURL string        `json:"html_url"`
+	Assets  []githubAsset `json:"assets"`
+}
+
+type githubAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+func runUpgrade(stdout, _ io.Writer, client *http.Client, releaseURL, current, exePath string, checkOnly, force, insecureSkipSignature bool) error {
+	release, err := fetchLatestRelease(client, releaseURL)
+	if err != nil {
+		return err
+	}
+	latest := release.TagName
+
+	cmp, comparable := compareVersions(current, latest)
+	switch {
+	case !comparable:
+		fmt.Fprintf(stdout, "Current: %s (development build)\n", current)
+		fmt.Fprintf(stdout, "Latest:  %s\n", latest)
+	case cmp >= 0:
+		fmt.Fprintf(stdout, "%s wl %s is up to date\n", style.Success.Render(style.IconPass), current)
+	default:
+		fmt.Fprintf(stdout, "Upgrade available: %s → %s\n", current, style.Bold.Render(latest))
+	}
+	if release.HTMLURL != "" && (!comparable || cmp < 0) {
+		fmt.Fprintf(stdout, "  %s\n", style.Dim.Render(release.HTMLURL))
+	}
+
+	if checkOnly {
+		return nil
+	}
+	if !force && (!comparable || cmp >= 0) {
+		if !comparable {
+			fmt.Fprintf(stdout, "\n  Use --force to replace a development build.\n")
+		}
+		return nil
+	}
+
+	archiveName, archiveURL, checksumsURL, signatureURL := selectReleaseAssets(release, runtime.GOOS, runtime.GOARCH)
+	if archiveURL == "" {
+		return fmt.Errorf("release %s has no archive for %s/%s", latest, runtime.GOOS, runtime.GOARCH)
+	}
+	if checksumsURL == "" {
+		return fmt.Errorf("release %s has no checksums file; refusing to install unverified binary", latest)
+	}
+	if releasePublicKey == "" && !insecureSkipSignature {
+		return fmt.Errorf("this build has no release key to verify the %s signature with; refusing to install unverified binary (use --insecure-skip-signature to verify only the checksum)", latest)
+	}
+	if releasePublicKey != "" && signatureURL == "" {
+		return fmt.Errorf("release %s has no checksums signature; refusing to install unverified binary", latest)
+	}
+
+	fmt.Fprintf(stdout, "\nDownloading %s...\n", archiveName)
+	archive, err := download(client, archiveURL)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", archiveName, err)
+	}
+	sums, err := download(client, checksumsURL)
+	if err != nil {
+		return fmt.Errorf("downloading checksums: %w", err)
+	}
+	if releasePublicKey != "" {
+		sig, err := download(client, signatureURL)
+		if err != nil {
+			return fmt.Errorf("downloading checksums signature: %w", err)
+		}
+		if err := verifyMinisign(releasePublicKey, sums, sig); err != nil {
+			return fmt.Errorf("checksums signature: %w", err)
+		}
+		fmt.Fprintf(stdout, "  %s release signature verified\n", style.Success.Render(style.IconPass))
+	} else {
+		fmt.Fprintf(stdout, "  %s\n", style.Warning.Render("release signature not checked: this build has no release key (--insecure-skip-signature)"))
+	}
+	if err := verifyChecksum(archiveName, archive, sums); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "  %s checksum verified\n", style.Success.Render(style.IconPass))
+
+	bin, err := extractBinary(archive, "wl")
+	if err != nil {
+		return fmt.Errorf("extracting %s: %w", archiveName, err)
+	}
+	if err := replaceBinary(exePath, bin); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "\n%s Upgraded wl to %s\n", style.Bold.Render("✓"), latest)
+	fmt.Fprintf(stdout, "  %s\n", style.Dim.Render(exePath))
+	return nil
+}
+
+func fetchLatestRelease(client *http.Client, url string) (*githubRelease, error) {
+	body, err := download(client, url)
+	if err != nil {
+		return nil, fmt.Errorf("checking latest release: %w", err)
+	}
+	var release githubRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("parsing release metadata: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("release metadata has no tag")
+	}
+	return &release, nil
+}
+
+func download(client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "wl/"+version)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// selectReleaseAssets finds the archive for goos/goarch, the checksums
+// file and its minisign signature among the release assets, following
+// goreleaser's default naming: <project>_<version>_<os>_<arch>.tar.gz,
+// <project>_<version>_checksums.txt and checksums.txt.minisig.
+func selectReleaseAssets(release *githubRelease, goos, goarch string) (archiveName, archiveURL, checksumsURL, signatureURL string) {
+	suffix := fmt.Sprintf("_%s_%s.tar.gz", goos, goarch)
+	for _, a := range release.Assets {
+		switch {
+		case strings.HasSuffix(a.Name, suffix):
+			archiveName, archiveURL = a.Name, a.URL
+		case strings.HasSuffix(a.Name, "checksums.txt"):
+			checksumsURL = a.URL
+		case strings.HasSuffix(a.Name, "checksums.txt.minisig"):
+			signatureURL = a.URL
+		}
+	}
+	return archiveName, archiveURL, checksumsURL, signatureURL
+}
+
+// verifyMinisign checks a minisign signature of message against pubKey,
+// the base64 line of a minisign public key file. Only Ed25519 signatures
+// of the raw message (minisign -S -l) are accepted, since verifying the
+// prehashed format needs BLAKE2b. The trusted comment's global signature
+// is checked too.
+func verifyMinisign(pubKey string, message, sigFile []byte) error {
+	pk, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pubKey))
+	if err != nil || len(pk) != 2+8+ed25519.PublicKeySize || string(pk[:2]) != "Ed" {
+		return fmt.Errorf("invalid release public key")
+	}
+	keyID, key := pk[2:10], ed25519.PublicKey(pk[10:])
+
+	lines := strings.Split(strings.TrimSpace(string(sigFile)), "\n")
+	if len(lines) != 4 {
+		return fmt.Errorf("malformed signature file")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("malformed signature")
+	}
+	switch string(sig[:2]) {
+	case "Ed":
+	case "ED":
+		return fmt.Errorf("prehashed signatures are not supported (sign with minisign -l)")
+	default:
+		return fmt.Errorf("unknown signature algorithm %q", sig[:2])
+	}
+	if !bytes.Equal(sig[2:10], keyID) {
+		return fmt.Errorf("signed by key %X, want %X", sig[2:10], keyID)
+	}
+	if !ed25519.Verify(key, message, sig[10:]) {
+		return fmt.Errorf("signature does not match")
+	}
+
+	trusted, ok := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	if !ok {
+		return fmt.Errorf("malformed trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || !ed25519.Verify(key, append(sig[10:], trusted...), global) {
+		return fmt.Errorf("trusted comment signature does not match")
+	}
+	return nil
+}
+
+// verifyChecksum checks data against the SHA-256 listed for name in a
+// sha256sum-format checksums file.
+func verifyChecksum(name string, data, sums []byte) error {
+	sc := bufio.NewScanner(bytes.NewReader(sums))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, fields[0], got)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum listed for %s", name)
+}
+
+// extractBinary returns the contents of the named file from a tar.gz archive.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceBinary atomically replaces the file at path with data by writing a
+// temp file in the same directory and renaming it into place.
+func replaceBinary(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".wl-upgrade-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s (try running with sufficient permissions): %w", dir, err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0o755); err != nil {
+		return fmt.Errorf("setting permissions: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("replacing %s: %w", path, err)
+	}
+	return nil
+}
+
+// compareVersions compares two release versions like "v1.2.3". Build
+// suffixes from 'git describe' (e.g. "v1.2.3-4-gabc123") are ignored.
+// The second return value is false if either version can't be parsed,
+// as with development builds.
+func compareVersions(a, b string) (int, bool) {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// buildArchive returns a tar.gz containing a single "wl" file.
+func buildArchive(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "wl", Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newReleaseServer serves a fake GitHub release with an archive for the
+// current platform and a checksums file. badSum corrupts the checksum.
+func newReleaseServer(t *testing.T, tag string, archive []byte, badSum bool) *httptest.Server {
+	t.Helper()
+	return newSignedReleaseServer(t, tag, archive, badSum, nil)
+}
+
+// testSigningKey generates a minisign key pair, returning the public key
+// line and a sign function producing a .minisig file.
+func testSigningKey(t *testing.T) (string, func(message []byte) []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte("testkey1")
+	pubLine := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+	sign := func(message []byte) []byte {
+		sig := ed25519.Sign(priv, message)
+		trusted := "wasteland test"
+		global := ed25519.Sign(priv, append(append([]byte{}, sig...), trusted...))
+		return fmt.Appendf(nil, "untrusted comment: test\n%s\ntrusted comment: %s\n%s\n",
+			base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), sig...)),
+			trusted, base64.StdEncoding.EncodeToString(global))
+	}
+	return pubLine, sign
+}
+
+// setReleaseKey builds pubKey into the binary for the test's duration.
+func setReleaseKey(t *testing.T, pubKey string) {
+	t.Helper()
+	old := releasePublicKey
+	releasePublicKey = pubKey
+	t.Cleanup(func() { releasePublicKey = old })
+}
+
+// newSignedReleaseServer is newReleaseServer with a checksums signature
+// made by sign; a nil sign serves no signature.
+func newSignedReleaseServer(t *testing.T, tag string, archive []byte, badSum bool, sign func([]byte) []byte) *httptest.Server {
+	t.Helper()
+	archiveName := fmt.Sprintf("wasteland_%s_%s_%s.tar.gz", strings.TrimPrefix(tag, "v"), runtime.GOOS, runtime.GOARCH)
+	sum := sha256.Sum256(archive)
+	sumHex := hex.EncodeToString(sum[:])
+	if badSum {
+		sumHex = strings.Repeat("0", 64)
+	}
+
+	sums := fmt.Appendf(nil, "%s  %s\n", sumHex, archiveName)
+
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/release", func(w http.ResponseWriter, _ *http.Request) {
+		assets := []githubAsset{
+			{Name: archiveName, URL: srv.URL + "/archive"},
+			{Name: "wasteland_checksums.txt", URL: srv.URL + "/checksums"},
+		}
+		if sign != nil {
+			assets = append(assets, githubAsset{Name: "wasteland_checksums.txt.minisig", URL: srv.URL + "/signature"})
+		}
+		_ = json.NewEncoder(w).Encode(githubRelease{TagName: tag, Assets: assets})
+	})
+	mux.HandleFunc("/archive", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	})
+	mux.HandleFunc("/checksums", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(sums)
+	})
+	if sign != nil {
+		mux.HandleFunc("/signature", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(sign(sums))
+		})
+	}
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeFakeExe(t *testing.T) string {
+	t.Helper()
+	exe := filepath.Join(t.TempDir(), "wl")
+	if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return exe
+}
+
+func TestUpgrade_CheckOnly(t *testing.T) {
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	if err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, true, false, false); err != nil {
+		t.Fatalf("runUpgrade: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "v1.2.0 → v1.3.0") {
+		t.Errorf("expected upgrade notice, got: %s", stdout.String())
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("--check-only should not replace the binary")
+	}
+}
+
+func TestUpgrade_ReplacesBinary(t *testing.T) {
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	if err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, false, false, true); err != nil {
+		t.Fatalf("runUpgrade: %v", err)
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("binary = %q, want %q", data, "new")
+	}
+	if !strings.Contains(stdout.String(), "checksum verified") {
+		t.Errorf("expected checksum confirmation, got: %s", stdout.String())
+	}
+}
+
+func TestUpgrade_ChecksumMismatch(t *testing.T) {
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), true)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, false, false, true)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("binary should not be replaced when verification fails")
+	}
+}
+
+func TestUpgrade_RefusesWithoutReleaseKey(t *testing.T) {
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "--insecure-skip-signature") {
+		t.Fatalf("expected refusal naming --insecure-skip-signature, got: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("binary should not be replaced without a release key")
+	}
+}
+
+func TestUpgrade_UpToDate(t *testing.T) {
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	if err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.3.0", exe, false, false, false); err != nil {
+		t.Fatalf("runUpgrade: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "up to date") {
+		t.Errorf("expected up to date, got: %s", stdout.String())
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("up-to-date binary should not be replaced")
+	}
+}
+
+func TestUpgrade_DevBuildRequiresForce(t *testing.T) {
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	if err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "dev", exe, false, false, false); err != nil {
+		t.Fatalf("runUpgrade: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("dev build should not be replaced without --force")
+	}
+
+	if err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "dev", exe, false, true, true); err != nil {
+		t.Fatalf("runUpgrade --force: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new" {
+		t.Error("--force should replace a dev build")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{"v1.2.3", "v1.2.3", 0, true},
+		{"v1.2.3", "v1.3.0", -1, true},
+		{"1.10.0", "v1.9.9", 1, true},
+		{"v1.2.3-4-gabc123", "v1.2.3", 0, true},
+		{"dev", "v1.0.0", 0, false},
+		{"v1.2", "v1.2.0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := compareVersions(tt.a, tt.b)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("compareVersions(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestUpgrade_VerifiesSignature(t *testing.T) {
+	pubKey, sign := testSigningKey(t)
+	setReleaseKey(t, pubKey)
+	srv := newSignedReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false, sign)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	if err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, false, false, false); err != nil {
+		t.Fatalf("runUpgrade: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new" {
+		t.Errorf("binary = %q, want %q", data, "new")
+	}
+	if !strings.Contains(stdout.String(), "release signature verified") {
+		t.Errorf("expected signature confirmation, got: %s", stdout.String())
+	}
+}
+
+func TestUpgrade_RejectsBadSignature(t *testing.T) {
+	pubKey, _ := testSigningKey(t)
+	_, otherSign := testSigningKey(t)
+	setReleaseKey(t, pubKey)
+	srv := newSignedReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false, otherSign)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "signature does not match") {
+		t.Fatalf("expected signature mismatch, got: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("binary should not be replaced when the signature is bad")
+	}
+}
+
+func TestUpgrade_RequiresSignatureWithReleaseKey(t *testing.T) {
+	pubKey, _ := testSigningKey(t)
+	setReleaseKey(t, pubKey)
+	srv := newReleaseServer(t, "v1.3.0", buildArchive(t, "new"), false)
+	exe := writeFakeExe(t)
+
+	var stdout bytes.Buffer
+	err := runUpgrade(&stdout, &stdout, srv.Client(), srv.URL+"/release", "v1.2.0", exe, false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "no checksums signature") {
+		t.Fatalf("expected missing signature error, got: %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("binary should not be replaced without a signature")
+	}
+}
+
+func TestVerifyMinisign_TamperedMessage(t *testing.T) {
+	pubKey, sign := testSigningKey(t)
+	sig := sign([]byte("abc  wasteland.tar.gz\n"))
+	if err := verifyMinisign(pubKey, []byte("abc  wasteland.tar.gz\n"), sig); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+	if err := verifyMinisign(pubKey, []byte("def  wasteland.tar.gz\n"), sig); err == nil {
+		t.Error("signature over a different message should be rejected")
+	}
+}
@@ -6,38 +6,68 @@ import (
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/style"
 	"github.com/spf13/cobra"
 )
 
 func newVerifyCmd(stdout, stderr io.Writer) *cobra.Command {
-	var last int
+	var (
+		last    int
+		offline bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "verify",
-		Short: "Show GPG signature status of recent Dolt commits",
+		Short: "Show GPG signature status of recent commits and check the hash chain",
 		Long: `Show GPG signature verification for recent commits in the local
 commons clone. Runs 'dolt log --show-signature' under the hood.
 
-Use --last to control how many commits to inspect (default 5).`,
+Then checks the chain_meta hash chain: every wl mutation appends an entry
+whose hash covers the previous entry's and a digest of the rows its
+commit changed, so an edited or deleted entry breaks the chain. Each
+digest is recomputed from the commit's diff, so a rewritten commit is
+caught too. A broken chain makes the command fail. Several heads are
+normal: they come from mutations made concurrently on separate branches.
+
+Use --last to control how many commits to inspect (default 5).
+
+Use --offline to verify the upstream commons as of the last fetch instead
+of the local branch. No network access is needed: the command reads the
+upstream ref cached by the last 'wl sync', and labels the output with
+when that was, so federation state can be reviewed on an air-gapped
+machine.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runVerify(cmd, stdout, stderr, last)
+			return runVerify(cmd, stdout, stderr, last, offline)
 		},
 	}
 
 	cmd.Flags().IntVar(&last, "last", 5, "Number of recent commits to verify")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Verify the cached upstream ref from the last fetch (no network)")
 
 	return cmd
 }
 
-func runVerify(cmd *cobra.Command, stdout, stderr io.Writer, last int) error {
+func runVerify(cmd *cobra.Command, stdout, stderr io.Writer, last int, offline bool) error {
 	wlCfg, err := resolveWasteland(cmd)
 	if err != nil {
 		return hintWrap(err)
 	}
 
 	args := []string{"log", "--show-signature", "-n", strconv.Itoa(last)}
+	if offline {
+		ref, err := cachedUpstreamRef(wlCfg.LocalDir)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s %s\n\n", style.Warning.Render(style.IconWarn),
+			verifyAsOfLabel(ref, wlCfg.LastSyncAt, time.Now()))
+		args = append(args, ref)
+	}
 	dolt := exec.Command("dolt", args...)
 	dolt.Dir = wlCfg.LocalDir
 	dolt.Stdout = stdout
@@ -47,5 +77,116 @@ func runVerify(cmd *cobra.Command, stdout, stderr io.Writer, last int) error {
 	if err := dolt.Run(); err != nil {
 		return fmt.Errorf("dolt log --show-signature: %w", err)
 	}
+	ref := ""
+	if offline {
+		ref = args[len(args)-1]
+	}
+	return verifyChain(stdout, openDB(wlCfg.LocalDir), ref)
+}
+
+// verifyChain checks the chain_meta hash chain at ref ("" for the local
+// branch) and reports what it found.
+func verifyChain(w io.Writer, db commons.DB, ref string) error {
+	fmt.Fprintln(w)
+	out, err := db.Query(commons.ChainEntriesQuery, ref)
+	switch {
+	case commons.IsTableNotFound(err):
+		fmt.Fprintf(w, "%s Hash chain: not available (the schema has no chain_meta table)\n", style.Warning.Render(style.IconWarn))
+		return nil
+	case commons.IsChainUnsupported(err):
+		fmt.Fprintf(w, "%s Hash chain: not recorded yet (run 'wl doctor --fix' to add the chain_meta columns)\n", style.Warning.Render(style.IconWarn))
+		return nil
+	case err != nil:
+		return fmt.Errorf("reading hash chain: %w", err)
+	}
+
+	entries := commons.ParseChainEntries(out)
+	report := commons.VerifyChain(entries)
+	if report.Entries == 0 {
+		fmt.Fprintf(w, "%s Hash chain: no entries yet\n", style.Dim.Render("-"))
+		return nil
+	}
+	digestProblems, unchecked, err := commons.VerifyChainDigests(db, ref, entries)
+	if err != nil {
+		return err
+	}
+	if problems := append(report.Problems, digestProblems...); len(problems) > 0 {
+		fmt.Fprintf(w, "%s Hash chain: %d of %d entries fail verification\n", style.Error.Render(style.IconFail), len(problems), report.Entries)
+		for _, p := range problems {
+			fmt.Fprintf(w, "    %s\n", p)
+		}
+		if len(digestProblems) > 0 {
+			return fmt.Errorf("hash chain broken: history was rewritten or chain_meta was edited outside wl")
+		}
+		return fmt.Errorf("hash chain broken: chain_meta was edited outside wl")
+	}
+	fmt.Fprintf(w, "%s Hash chain: %d entries intact", style.Success.Render(style.IconPass), report.Entries)
+	if report.Heads > 1 {
+		fmt.Fprint(w, style.Dim.Render(fmt.Sprintf(" (%d heads from concurrent mutations)", report.Heads)))
+	}
+	if unchecked > 0 {
+		fmt.Fprint(w, style.Dim.Render(fmt.Sprintf(" (%d digests not checkable: squashed or not in this history)", unchecked)))
+	}
+	fmt.Fprintln(w)
 	return nil
 }
+
+// cachedUpstreamRef returns the remote-tracking ref holding the last-fetched
+// upstream commons: upstream/main in fork mode, origin/main in direct mode
+// (where origin is the upstream). Only local state is read.
+func cachedUpstreamRef(dbDir string) (string, error) {
+	remotes := exec.Command("dolt", "remote", "-v")
+	remotes.Dir = dbDir
+	out, err := remotes.Output()
+	if err != nil {
+		return "", fmt.Errorf("listing dolt remotes: %w", err)
+	}
+	ref := trackingRef(string(out))
+	if ref == "" {
+		return "", fmt.Errorf("no upstream remote configured in %s", dbDir)
+	}
+
+	branches := exec.Command("dolt", "branch", "-r")
+	branches.Dir = dbDir
+	out, err = branches.Output()
+	if err != nil {
+		return "", fmt.Errorf("listing remote branches: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		name := strings.TrimPrefix(strings.TrimSpace(strings.TrimLeft(line, " *")), "remotes/")
+		if name == ref {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("no cached %s ref: run 'wl sync' once while online", ref)
+}
+
+// trackingRef picks the upstream tracking ref from 'dolt remote -v' output.
+func trackingRef(remotes string) string {
+	var hasOrigin bool
+	for _, line := range strings.Split(remotes, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "upstream":
+			return "upstream/main"
+		case "origin":
+			hasOrigin = true
+		}
+	}
+	if hasOrigin {
+		return "origin/main"
+	}
+	return ""
+}
+
+// verifyAsOfLabel describes how fresh the cached ref being verified is.
+func verifyAsOfLabel(ref string, lastSync *time.Time, now time.Time) string {
+	if lastSync == nil {
+		return fmt.Sprintf("Offline: verifying %s as of the last fetch (time unknown)", ref)
+	}
+	return fmt.Sprintf("Offline: verifying %s as of %s (%s ago)", ref,
+		lastSync.Local().Format("2006-01-02 15:04 MST"), formatDuration(now.Sub(*lastSync)))
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestTrackingRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		remotes string
+		want    string
+	}{
+		{"fork mode", "origin https://doltremoteapi.dolthub.com/alice/wl-commons\nupstream https://doltremoteapi.dolthub.com/hop/wl-commons\n", "upstream/main"},
+		{"direct mode", "origin https://doltremoteapi.dolthub.com/hop/wl-commons\n", "origin/main"},
+		{"no remotes", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trackingRef(tt.remotes); got != tt.want {
+				t.Errorf("trackingRef() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAsOfLabel(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	synced := now.Add(-3 * time.Hour)
+
+	got := verifyAsOfLabel("upstream/main", &synced, now)
+	if !strings.Contains(got, "upstream/main as of ") || !strings.Contains(got, "(3h ago)") {
+		t.Errorf("label = %q", got)
+	}
+	if got := verifyAsOfLabel("origin/main", nil, now); !strings.Contains(got, "time unknown") {
+		t.Errorf("label without sync time = %q", got)
+	}
+}
+
+// chainDB answers the chain query with out, or fails with err. Entries
+// were added by commits c1, c2, ... in order, and recomputing a commit's
+// digest returns digests[commit].
+type chainDB struct {
+	noopDB
+	out     string
+	err     error
+	digests map[string]string
+}
+
+func (d chainDB) Query(sql, _ string) (string, error) {
+	switch {
+	case d.err != nil:
+		return "", d.err
+	case strings.Contains(sql, "dolt_diff_chain_meta"):
+		out := "to_chain_id,to_commit\n"
+		for i, e := range commons.ParseChainEntries(d.out) {
+			out += fmt.Sprintf("%s,c%d\n", e.Hash, i+1)
+		}
+		return out, nil
+	case strings.Contains(sql, "DOLT_PATCH"):
+		for commit, digest := range d.digests {
+			if strings.Contains(sql, "'"+commit+"')") {
+				return "digest\n" + digest + "\n", nil
+			}
+		}
+		return "digest\n", nil
+	}
+	return d.out, nil
+}
+
+func TestVerifyChain(t *testing.T) {
+	d1 := strings.Repeat("1", 64)
+	h1 := commons.ChainHash("", d1, "alice")
+	d2 := strings.Repeat("2", 64)
+	h2 := commons.ChainHash(h1, d2, "bob")
+	header := "chain_id,parent_chain_id,digest,rig_handle,created_at\n"
+	row1 := h1 + ",," + d1 + ",alice,2026-01-02 03:04:05\n"
+	digests := map[string]string{"c1": d1, "c2": d2}
+
+	tests := []struct {
+		name    string
+		db      chainDB
+		want    string
+		wantErr bool
+	}{
+		{"intact", chainDB{out: header + row1 + h2 + "," + h1 + "," + d2 + ",bob,2026-01-02 03:05:00\n", digests: digests}, "2 entries intact", false},
+		{"edited", chainDB{out: header + row1 + h2 + "," + h1 + "," + d2 + ",mallory,2026-01-02 03:05:00\n", digests: digests}, "1 of 2 entries fail", true},
+		{"rewritten commit", chainDB{out: header + row1 + h2 + "," + h1 + "," + d2 + ",bob,2026-01-02 03:05:00\n", digests: map[string]string{"c1": d1, "c2": d1}}, "commit c2 changed different rows", true},
+		{"empty", chainDB{out: header}, "no entries yet", false},
+		{"old schema", chainDB{err: errors.New(`column "digest" could not be found in any table in scope`)}, "wl doctor --fix", false},
+		{"minimal schema", chainDB{err: errors.New("table not found: chain_meta")}, "no chain_meta table", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := verifyChain(&buf, tt.db, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyChain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/spf13/cobra"
+)
+
+func newWatchCmd(stdout, _ io.Writer) *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "watch [wanted-id...]",
+		Short: "Follow wanted items for 'wl notify'",
+		Long: `Add wanted items to the watch list 'wl notify' reports on. Items you
+posted or claimed are followed without being watched.
+
+With no arguments, lists the watched items.
+
+EXAMPLES:
+  wl watch w-abc123 w-def456   # Watch two items
+  wl watch --rm w-abc123       # Stop watching one
+  wl watch                     # List watched items`,
+		ValidArgsFunction: completeWantedIDs(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				if remove {
+					return fmt.Errorf("--rm needs the wanted IDs to stop watching")
+				}
+				return runWatchList(cmd, stdout)
+			}
+			return runWatch(cmd, stdout, args, remove)
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "rm", false, "Stop watching the given items")
+
+	return cmd
+}
+
+func runWatchList(cmd *cobra.Command, stdout io.Writer) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	if len(cfg.Watched) == 0 {
+		fmt.Fprintln(stdout, "No watched items. Add one with: wl watch <wanted-id>")
+		return nil
+	}
+	for _, id := range cfg.Watched {
+		fmt.Fprintln(stdout, id)
+	}
+	return nil
+}
+
+func runWatch(cmd *cobra.Command, stdout io.Writer, args []string, remove bool) error {
+	cfg, err := resolveWasteland(cmd)
+	if err != nil {
+		return hintWrap(err)
+	}
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		id := arg
+		// Watched IDs may have since been deleted; only resolve new ones.
+		if !remove || !slices.Contains(cfg.Watched, arg) {
+			if id, err = resolveWantedArg(cfg, arg); err != nil {
+				return err
+			}
+		}
+		ids = append(ids, id)
+	}
+
+	err = updateConfig(cmd, "watch", func(cfg *federation.Config) error {
+		cfg.Watched = applyWatch(cfg.Watched, ids, remove)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	verb := "Watching"
+	if remove {
+		verb = "Stopped watching"
+	}
+	for _, id := range ids {
+		fmt.Fprintf(stdout, "%s %s %s\n", style.Success.Render(style.IconPass), verb, id)
+	}
+	return nil
+}
+
+// applyWatch adds ids to, or with remove drops them from, the watch list,
+// keeping it sorted and free of duplicates.
+func applyWatch(watched, ids []string, remove bool) []string {
+	out := slices.Clone(watched)
+	if remove {
+		out = slices.DeleteFunc(out, func(id string) bool { return slices.Contains(ids, id) })
+	} else {
+		out = append(out, ids...)
+	}
+	slices.Sort(out)
+	out = slices.Compact(out)
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -275,6 +277,74 @@ func completeProjectNames(cmd *cobra.Command, _ []string, _ string) ([]string, c
 	return projects, cobra.ShellCompDirectiveNoFileComp
 }
 
+// completeTagNames provides completion for --tag and --tags flags from the
+// wasteland's tag registry. For comma-separated --tags values, the tags
+// already typed are kept as a prefix.
+func completeTagNames(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cacheKey := "tags"
+	tags := readCompletionCache(cacheKey)
+	if tags == nil {
+		cfg, err := resolveWasteland(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		db, err := openDBFromConfig(cfg)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		reg, err := commons.QueryTagRegistry(db)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tags = reg.Canonical()
+		writeCompletionCache(cacheKey, tags)
+	}
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = prefix + t
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeItemTypes provides completion for --type flags from the
+// wasteland's item type vocabulary.
+func completeItemTypes(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	vocab := completionVocabulary(cmd)
+	return vocab.Types, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEffortLevels provides completion for --effort flags from the
+// wasteland's effort level vocabulary.
+func completeEffortLevels(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	vocab := completionVocabulary(cmd)
+	return vocab.Efforts, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionVocabulary returns the wasteland's vocabulary for completion,
+// falling back to the defaults when the wasteland can't be reached.
+func completionVocabulary(cmd *cobra.Command) *commons.Vocabulary {
+	types := readCompletionCache("types")
+	efforts := readCompletionCache("efforts")
+	if types == nil || efforts == nil {
+		vocab := commons.DefaultVocabulary()
+		if cfg, err := resolveWasteland(cmd); err == nil {
+			if db, err := openDBFromConfig(cfg); err == nil {
+				if v, err := commons.QueryVocabulary(db); err == nil {
+					vocab = v
+					writeCompletionCache("types", v.Types)
+					writeCompletionCache("efforts", v.Efforts)
+				}
+			}
+		}
+		return cliVocabulary(vocab)
+	}
+	return cliVocabulary(&commons.Vocabulary{Types: types, Efforts: efforts})
+}
+
 // completeWastelandNames provides completion for the --wasteland persistent flag.
 func completeWastelandNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 	store := federation.NewConfigStore()
@@ -285,6 +355,16 @@ func completeWastelandNames(_ *cobra.Command, _ []string, _ string) ([]string, c
 	return upstreams, cobra.ShellCompDirectiveNoFileComp
 }
 
+// completeProfileNames returns the rig profiles of the selected wasteland.
+func completeProfileNames(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	explicit, _ := cmd.Flags().GetString("wasteland")
+	cfg, err := federation.ResolveConfig(federation.NewConfigStore(), explicit)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return slices.Sorted(maps.Keys(cfg.Profiles)), cobra.ShellCompDirectiveNoFileComp
+}
+
 // writeCompletionCache writes completions to the cache.
 func writeCompletionCache(key string, items []string) {
 	dir := completionCacheDir()
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"gopkg.in/yaml.v3"
+)
+
+// itemDraft is a wanted item as 'wl post --editor' and 'wl update
+// --editor' edit it: YAML front matter for the fields, with the markdown
+// description below.
+type itemDraft struct {
+	Title       string   `yaml:"title"`
+	Project     string   `yaml:"project"`
+	Type        string   `yaml:"type"`
+	Priority    int      `yaml:"priority"`
+	Effort      string   `yaml:"effort"`
+	Tags        []string `yaml:"tags,flow"`
+	Description string   `yaml:"-"`
+}
+
+const draftFence = "---"
+
+// formatDraft renders d as an editable file. hints become comment lines
+// at the top of the front matter.
+func formatDraft(d itemDraft, hints []string) (string, error) {
+	if d.Tags == nil {
+		d.Tags = []string{}
+	}
+	fields, err := yaml.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(draftFence + "\n")
+	for _, h := range hints {
+		b.WriteString("# " + h + "\n")
+	}
+	b.Write(fields)
+	b.WriteString(draftFence + "\n\n")
+	if d.Description != "" {
+		b.WriteString(d.Description + "\n")
+	}
+	return b.String(), nil
+}
+
+// parseDraft reads a file written by formatDraft back into a draft.
+// Unknown fields are an error, so a typo doesn't silently drop a value.
+func parseDraft(text string) (itemDraft, error) {
+	var d itemDraft
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(strings.TrimLeft(text, "\n"), draftFence+"\n")
+	if !ok {
+		return d, fmt.Errorf("the file must start with a %s line opening the fields", draftFence)
+	}
+	fields, body, ok := strings.Cut(rest, "\n"+draftFence+"\n")
+	if !ok {
+		fields, ok = strings.CutSuffix(rest, "\n"+draftFence)
+		if !ok {
+			return d, fmt.Errorf("no %s line closes the fields", draftFence)
+		}
+	}
+	dec := yaml.NewDecoder(strings.NewReader(fields))
+	dec.KnownFields(true)
+	if err := dec.Decode(&d); err != nil && !errors.Is(err, io.EOF) {
+		return d, fmt.Errorf("reading fields: %w", err)
+	}
+	d.Title = strings.TrimSpace(d.Title)
+	d.Description = strings.TrimSpace(body)
+	return d, nil
+}
+
+// draftHints are the comment lines that explain a draft's fields, with
+// the accepted values from vocab.
+func draftHints(vocab *commons.Vocabulary, action string) []string {
+	return []string{
+		"Edit the fields and the markdown description below, then save and quit",
+		"to " + action + ". Lines starting with # are ignored; an empty title aborts.",
+		"type: " + strings.Join(vocab.Types, ", "),
+		"effort: " + strings.Join(vocab.Efforts, ", "),
+		"priority: 0=critical, 1=high, 2=medium, 3=low, 4=backlog",
+	}
+}
+
+// runEditor opens path in the user's editor and waits for it to exit.
+// Package-level variable to allow test overrides.
+var runEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// $EDITOR may carry arguments, as in "code --wait".
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// errDraftAborted reports an edit the user abandoned by clearing the title.
+var errDraftAborted = errors.New("aborted: the title is empty")
+
+// editDraft has the user edit d and returns the result once validate
+// accepts it. A draft that doesn't parse or validate is reopened with the
+// error at the top; saving it again unchanged gives up with that error.
+func editDraft(d itemDraft, hints []string, validate func(itemDraft) error) (itemDraft, error) {
+	text, err := formatDraft(d, hints)
+	if err != nil {
+		return d, err
+	}
+	f, err := os.CreateTemp("", "wl-item-*.md")
+	if err != nil {
+		return d, err
+	}
+	path := f.Name()
+	defer os.Remove(path) //nolint:errcheck // best-effort cleanup
+	if err := f.Close(); err != nil {
+		return d, err
+	}
+
+	for {
+		if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+			return d, err
+		}
+		if err := runEditor(path); err != nil {
+			return d, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return d, err
+		}
+		edited, err := parseDraft(string(data))
+		if err == nil && edited.Title == "" {
+			return d, errDraftAborted
+		}
+		if err == nil {
+			err = validate(edited)
+		}
+		if err == nil {
+			return edited, nil
+		}
+		if bytes.Equal(data, []byte(text)) && strings.Contains(text, "# error: ") {
+			return d, err
+		}
+		text = markDraftError(string(data), err)
+	}
+}
+
+// markDraftError puts err at the top of a draft's fields, replacing any
+// earlier error, so the reopened editor shows what to fix.
+func markDraftError(text string, err error) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, "# error: ") {
+			kept = append(kept, line)
+		}
+	}
+	text = strings.Join(kept, "\n")
+	mark := "# error: " + strings.ReplaceAll(err.Error(), "\n", " ") + "\n"
+	if rest, ok := strings.CutPrefix(strings.TrimLeft(text, "\n"), draftFence+"\n"); ok {
+		return draftFence + "\n" + mark + rest
+	}
+	return draftFence + "\n" + mark + draftFence + "\n" + text
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// stubEditor replaces the editor with edits, one per time it is opened.
+// Each edit gets the file's text and returns the text to save.
+func stubEditor(t *testing.T, edits ...func(string) string) *int {
+	t.Helper()
+	orig := runEditor
+	t.Cleanup(func() { runEditor = orig })
+	opened := 0
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if opened >= len(edits) {
+			t.Fatalf("editor opened %d times, want %d", opened+1, len(edits))
+		}
+		text := edits[opened](string(data))
+		opened++
+		return os.WriteFile(path, []byte(text), 0o600)
+	}
+	return &opened
+}
+
+func TestDraftRoundTrip(t *testing.T) {
+	t.Parallel()
+	d := itemDraft{Title: "Fix: auth", Project: "gastown", Type: "bug", Priority: 1, Effort: "small", Tags: []string{"go", "auth"}, Description: "## Steps\n\n- one\n- two"}
+	text, err := formatDraft(d, draftHints(commons.DefaultVocabulary(), "post"))
+	if err != nil {
+		t.Fatalf("formatDraft: %v", err)
+	}
+	if !strings.HasPrefix(text, "---\n# Edit the fields") || !strings.Contains(text, "tags: [go, auth]") {
+		t.Errorf("draft =\n%s", text)
+	}
+	got, err := parseDraft(text)
+	if err != nil {
+		t.Fatalf("parseDraft: %v", err)
+	}
+	if !reflect.DeepEqual(got, d) {
+		t.Errorf("round trip = %+v, want %+v", got, d)
+	}
+}
+
+func TestParseDraft_Errors(t *testing.T) {
+	t.Parallel()
+	for name, text := range map[string]string{
+		"no opening fence": "title: x\n---\nbody\n",
+		"no closing fence": "---\ntitle: x\nbody\n",
+		"unknown field":    "---\ntitle: x\nowner: bob\n---\n",
+		"bad priority":     "---\ntitle: x\npriority: high\n---\n",
+	} {
+		if _, err := parseDraft(text); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestEditDraft_ReopensOnError(t *testing.T) {
+	opened := stubEditor(t,
+		func(s string) string {
+			s = strings.Replace(s, `title: ""`, "title: Add sync", 1)
+			return strings.Replace(s, `type: ""`, "type: nonsense", 1)
+		},
+		func(s string) string {
+			if !strings.Contains(s, "# error: invalid type") {
+				t.Errorf("reopened draft should show the error:\n%s", s)
+			}
+			return strings.Replace(s, "type: nonsense", "type: feature", 1) + "Sync the **forks**.\n"
+		},
+	)
+	vocab := commons.DefaultVocabulary()
+	d, err := editDraft(itemDraft{Priority: 2}, nil, func(d itemDraft) error {
+		return validatePostInputs(vocab, d.Type, d.Effort, d.Priority)
+	})
+	if err != nil {
+		t.Fatalf("editDraft: %v", err)
+	}
+	if *opened != 2 || d.Title != "Add sync" || d.Type != "feature" || d.Description != "Sync the **forks**." {
+		t.Errorf("opened %d times, draft = %+v", *opened, d)
+	}
+}
+
+func TestEditDraft_GivesUpWhenErrorLeftUnchanged(t *testing.T) {
+	stubEditor(t,
+		func(s string) string {
+			s = strings.Replace(s, `title: ""`, "title: x", 1)
+			return strings.Replace(s, `effort: ""`, "effort: huge", 1)
+		},
+		func(s string) string { return s },
+	)
+	vocab := commons.DefaultVocabulary()
+	_, err := editDraft(itemDraft{}, nil, func(d itemDraft) error {
+		return validatePostInputs(vocab, d.Type, d.Effort, d.Priority)
+	})
+	if err == nil || !strings.Contains(err.Error(), "huge") {
+		t.Errorf("err = %v, want the effort error", err)
+	}
+}
+
+func TestEditDraft_EmptyTitleAborts(t *testing.T) {
+	stubEditor(t, func(s string) string { return s })
+	if _, err := editDraft(itemDraft{}, nil, func(itemDraft) error { return nil }); err != errDraftAborted {
+		t.Errorf("err = %v, want errDraftAborted", err)
+	}
+}
+
+func TestRunPost_Editor(t *testing.T) {
+	saveWasteland(t)
+	withFakeSDK(t)
+	newSDKClient = func(cfg *federation.Config, _ bool) (*sdk.Client, error) {
+		return sdk.New(sdk.ClientConfig{DB: openItemsDB{}, RigHandle: cfg.RigHandle, Mode: "wild-west", NoPush: true}), nil
+	}
+	stubEditor(t, func(s string) string {
+		if !strings.Contains(s, "project: gastown") {
+			t.Errorf("draft should carry --project:\n%s", s)
+		}
+		s = strings.Replace(s, `title: ""`, "title: Write the sync RFC", 1)
+		s = strings.Replace(s, "tags: []", "tags: [rfc, sync]", 1)
+		return s + "# Goals\n\n- converge forks\n"
+	})
+
+	var stdout, stderr bytes.Buffer
+	err := runPost(wastelandCmd(), &stdout, &stderr, "", "", "gastown", "", 2, "medium", "", "", true, false, true)
+	if err != nil {
+		t.Fatalf("runPost --editor: %v", err)
+	}
+	for _, want := range []string{"Title:    Write the sync RFC", "Project:  gastown", "Tags:     rfc, sync"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, &stdout)
+		}
+	}
+}
+
+func TestEditUpdateFields_OnlyChanges(t *testing.T) {
+	withFakeSDK(t)
+	client := sdk.New(sdk.ClientConfig{DB: openItemsDB{}, RigHandle: "alice", Mode: "wild-west", NoPush: true})
+	stubEditor(t, func(s string) string {
+		s = strings.Replace(s, "\npriority: 0\n", "\npriority: 1\n", 1)
+		return s + "Now with details.\n"
+	})
+
+	fields, err := editUpdateFields(client, "w-1", &commons.WantedUpdate{Priority: -1})
+	if err != nil {
+		t.Fatalf("editUpdateFields: %v", err)
+	}
+	want := &commons.WantedUpdate{Priority: 1, Description: "Now with details."}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %+v, want %+v", fields, want)
+	}
+}
@@ -4,9 +4,46 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/i18n"
 )
 
+// Exit codes. Anything not covered by a specific class exits 1, so scripts
+// can branch on the failure type without parsing stderr.
+const (
+	exitFailure    = 1 // any other error
+	exitNotFound   = 2 // wanted item, branch, stamp or profile doesn't exist
+	exitPermission = 3 // not allowed, read-only mode, or credentials rejected by a remote
+	exitConflict   = 4 // invalid status transition or conflicting change
+	exitNetwork    = 5 // remote unreachable, or push/pull/fetch failed
+)
+
+// exitCode maps err to the exit code for its failure class.
+func exitCode(err error) int {
+	var (
+		notFound   *commons.NotFoundError
+		permission *commons.PermissionError
+		conflict   *commons.ConflictError
+		network    *commons.NetworkError
+		readOnly   *commons.ReadOnlyError
+	)
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &notFound):
+		return exitNotFound
+	case errors.As(err, &permission), errors.As(err, &readOnly):
+		return exitPermission
+	case errors.As(err, &conflict):
+		return exitConflict
+	case errors.As(err, &network):
+		return exitNetwork
+	default:
+		return exitFailure
+	}
+}
+
 // HintedError wraps an error with a user-facing recovery hint.
 type HintedError struct {
 	Err  error
@@ -24,11 +61,11 @@ func hintWrap(err error) error {
 	var hint string
 	switch {
 	case errors.Is(err, federation.ErrNotJoined):
-		hint = "Run 'wl join <org/db>' to join a wasteland, or 'wl create <org/db>' to start one."
+		hint = i18n.T("hint.not_joined")
 	case errors.Is(err, federation.ErrAmbiguous):
-		hint = "Use --wasteland <org/db> to select which wasteland."
+		hint = i18n.T("hint.ambiguous")
 	default:
-		hint = "Run 'wl doctor' to check your setup."
+		hint = i18n.T("hint.doctor")
 	}
 	return &HintedError{Err: fmt.Errorf("loading wasteland config: %w", err), Hint: hint}
 }
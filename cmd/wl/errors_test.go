@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
 )
 
@@ -77,3 +78,27 @@ func TestHintWrap_WrappedNotJoined(t *testing.T) {
 		t.Errorf("unexpected hint for wrapped ErrNotJoined: %s", h.Hint)
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"generic", fmt.Errorf("boom"), exitFailure},
+		{"sentinel", errExit, exitFailure},
+		{"not found", &commons.NotFoundError{Message: "wanted item \"w-1\" not found"}, exitNotFound},
+		{"permission", &commons.PermissionError{Message: "cannot accept your own completion"}, exitPermission},
+		{"conflict", &commons.ConflictError{Message: "cannot claim: item is claimed, not open"}, exitConflict},
+		{"network", &commons.NetworkError{Err: fmt.Errorf("dial tcp: timeout")}, exitNetwork},
+		{"read-only", &commons.ReadOnlyError{Op: "merge"}, exitPermission},
+		{"wrapped", fmt.Errorf("querying: %w", &commons.NotFoundError{Message: "x"}), exitNotFound},
+		{"classified sentinel", &commons.NetworkError{Err: errExit}, exitNetwork},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("exitCode(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// errCannotVerify marks evidence that could not be checked from here (no
+// git repository, no git binary). Such entries are submitted unverified
+// rather than rejected.
+var errCannotVerify = errors.New("cannot verify")
+
+var evidenceHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+var githubPRURL = regexp.MustCompile(`^https://github\.com/[^/]+/[^/]+/pull/\d+`)
+
+// checkEvidenceURL reports whether url is reachable. GitHub pull requests
+// are looked up through gh when it is installed, so private repos work;
+// anything else gets an HTTP HEAD. It is a variable so tests can replace it.
+var checkEvidenceURL = func(url string) error {
+	if githubPRURL.MatchString(url) {
+		if ghPath, err := exec.LookPath("gh"); err == nil {
+			out, err := exec.Command(ghPath, "pr", "view", url, "--json", "number").CombinedOutput()
+			if err != nil {
+				return fmt.Errorf("pull request not found: %s", strings.TrimSpace(string(out)))
+			}
+			return nil
+		}
+	}
+
+	resp, err := evidenceHTTPClient.Head(url)
+	if err == nil && resp.StatusCode == http.StatusMethodNotAllowed {
+		_ = resp.Body.Close()
+		resp, err = evidenceHTTPClient.Get(url)
+	}
+	if err != nil {
+		return fmt.Errorf("unreachable: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unreachable: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// checkEvidenceCommit reports whether sha names a commit in the git
+// repository of the working directory. It is a variable so tests can
+// replace it.
+var checkEvidenceCommit = func(sha string) error {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return errCannotVerify
+	}
+	if err := exec.Command(gitPath, "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+		return errCannotVerify
+	}
+	if err := exec.Command(gitPath, "cat-file", "-e", sha+"^{commit}").Run(); err != nil {
+		return fmt.Errorf("commit not found in this repository")
+	}
+	return nil
+}
+
+// verifyEvidence checks every URL and commit entry and returns the entries
+// with Verified set on those that passed. Text entries, and entries that
+// cannot be checked from here, are returned unverified. Any entry that is
+// checked and fails makes the whole submission fail.
+func verifyEvidence(entries []commons.Evidence) ([]commons.Evidence, error) {
+	out := make([]commons.Evidence, len(entries))
+	var failures []string
+	for i, e := range entries {
+		out[i] = e
+		var err error
+		switch {
+		case e.Kind == commons.EvidenceCommit:
+			err = checkEvidenceCommit(e.Value)
+		case strings.HasPrefix(e.Value, "https://") || strings.HasPrefix(e.Value, "http://"):
+			err = checkEvidenceURL(e.Value)
+		default:
+			continue
+		}
+		switch {
+		case err == nil:
+			out[i].Verified = true
+		case errors.Is(err, errCannotVerify):
+		default:
+			failures = append(failures, fmt.Sprintf("  %s %s: %v", e.Kind, e.Value, err))
+		}
+	}
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("evidence could not be verified:\n%s\nfix the evidence, or pass --no-verify to submit it anyway", strings.Join(failures, "\n"))
+	}
+	return out, nil
+}
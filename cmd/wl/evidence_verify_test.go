@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func stubEvidenceChecks(t *testing.T, urlErr, commitErr error) (urls, commits *[]string) {
+	t.Helper()
+	origURL, origCommit := checkEvidenceURL, checkEvidenceCommit
+	t.Cleanup(func() { checkEvidenceURL, checkEvidenceCommit = origURL, origCommit })
+
+	urls, commits = &[]string{}, &[]string{}
+	checkEvidenceURL = func(url string) error {
+		*urls = append(*urls, url)
+		return urlErr
+	}
+	checkEvidenceCommit = func(sha string) error {
+		*commits = append(*commits, sha)
+		return commitErr
+	}
+	return urls, commits
+}
+
+func TestVerifyEvidence_MarksChecked(t *testing.T) {
+	urls, commits := stubEvidenceChecks(t, nil, nil)
+
+	got, err := verifyEvidence(commons.ParseEvidenceList([]string{
+		"https://github.com/org/repo/pull/1",
+		"abc1234def",
+		"artifact:https://ci.example/run/9",
+		"benchmarks attached",
+	}))
+	if err != nil {
+		t.Fatalf("verifyEvidence: %v", err)
+	}
+	want := []bool{true, true, true, false}
+	for i, e := range got {
+		if e.Verified != want[i] {
+			t.Errorf("entry %d (%s) verified = %v, want %v", i, e.Value, e.Verified, want[i])
+		}
+	}
+	if len(*urls) != 2 || len(*commits) != 1 {
+		t.Errorf("checked urls=%v commits=%v", *urls, *commits)
+	}
+}
+
+func TestVerifyEvidence_FailureAborts(t *testing.T) {
+	stubEvidenceChecks(t, errors.New("unreachable: HTTP 404"), nil)
+
+	_, err := verifyEvidence(commons.ParseEvidenceList([]string{"https://github.com/org/repo/pull/404", "abc1234def"}))
+	if err == nil {
+		t.Fatal("expected error for unreachable URL")
+	}
+	for _, want := range []string{"pull/404", "HTTP 404", "--no-verify"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q: %v", want, err)
+		}
+	}
+}
+
+func TestVerifyEvidence_CannotVerifySkips(t *testing.T) {
+	stubEvidenceChecks(t, nil, errCannotVerify)
+
+	got, err := verifyEvidence(commons.ParseEvidenceList([]string{"abc1234def"}))
+	if err != nil {
+		t.Fatalf("verifyEvidence: %v", err)
+	}
+	if got[0].Verified {
+		t.Error("commit outside a repository should stay unverified")
+	}
+}
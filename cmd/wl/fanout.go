@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/gastownhall/wasteland/internal/xdg"
+	"github.com/spf13/cobra"
+)
+
+// wastelandFlag is the --wasteland flag. It reads as a plain string (the
+// last value given) everywhere, but remembers every value so 'wl post'
+// can fan out to several wastelands.
+type wastelandFlag struct {
+	values []string
+}
+
+func (f *wastelandFlag) String() string {
+	if len(f.values) == 0 {
+		return ""
+	}
+	return f.values[len(f.values)-1]
+}
+
+func (f *wastelandFlag) Set(v string) error {
+	f.values = append(f.values, v)
+	return nil
+}
+
+// Type reports "string" so cmd.Flags().GetString keeps working.
+func (f *wastelandFlag) Type() string { return "string" }
+
+// requestedWastelands returns every --wasteland value given, in order and
+// without duplicates.
+func requestedWastelands(cmd *cobra.Command) []string {
+	flag := cmd.Flags().Lookup("wasteland")
+	if flag == nil {
+		return nil
+	}
+	var names []string
+	if wf, ok := flag.Value.(*wastelandFlag); ok {
+		names = wf.values
+	} else if v := flag.Value.String(); v != "" {
+		names = []string{v}
+	}
+	var out []string
+	for _, n := range names {
+		if !slices.Contains(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// fanoutTargets returns the configs a fan-out command acts on: every
+// joined wasteland with all set, otherwise each --wasteland given, or the
+// usual single wasteland when at most one is named. Every config is
+// loaded and checked before anything is written.
+func fanoutTargets(cmd *cobra.Command, store federation.ConfigStore, all bool) ([]*federation.Config, error) {
+	names := requestedWastelands(cmd)
+	if all {
+		if len(names) > 0 {
+			return nil, fmt.Errorf("--all and --wasteland are mutually exclusive")
+		}
+		var err error
+		names, err = store.List()
+		if err != nil {
+			return nil, fmt.Errorf("listing wastelands: %w", err)
+		}
+		if len(names) == 0 {
+			return nil, hintWrap(federation.ErrNotJoined)
+		}
+	}
+	if len(names) <= 1 && !all {
+		cfg, err := resolveWasteland(cmd)
+		if err != nil {
+			return nil, hintWrap(err)
+		}
+		return []*federation.Config{cfg}, nil
+	}
+	cfgs := make([]*federation.Config, 0, len(names))
+	for _, name := range names {
+		cfg, err := store.Load(name)
+		if err != nil {
+			return nil, hintWrap(fmt.Errorf("loading config for %s: %w", name, err))
+		}
+		if err := configureWasteland(cmd, cfg); err != nil {
+			return nil, err
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, nil
+}
+
+// siblingsPath is where groups of sibling items (the same item fanned
+// out to several wastelands) are recorded.
+func siblingsPath() string {
+	return filepath.Join(xdg.DataDir(), "siblings.json")
+}
+
+// siblingGroup maps each wasteland (org/db) to the ID of one fanned-out
+// item there.
+type siblingGroup map[string]string
+
+// loadSiblings reads the sibling groups at path. A missing file has none.
+func loadSiblings(path string) ([]siblingGroup, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var groups []siblingGroup
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return groups, nil
+}
+
+// recordSiblings appends group to the sibling groups at path.
+func recordSiblings(path string, group siblingGroup) error {
+	groups, err := loadSiblings(path)
+	if err != nil {
+		return err
+	}
+	groups = append(groups, group)
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// findSiblings returns the siblings of wantedID in upstream, keyed by
+// wasteland and excluding upstream itself. Nil means the item was not
+// fanned out.
+func findSiblings(path, upstream, wantedID string) (siblingGroup, error) {
+	groups, err := loadSiblings(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if g[upstream] != wantedID {
+			continue
+		}
+		others := make(siblingGroup, len(g)-1)
+		for u, id := range g {
+			if u != upstream {
+				others[u] = id
+			}
+		}
+		return others, nil
+	}
+	return nil, nil
+}
+
+// requireSiblings returns the siblings of wantedID in cfg's wasteland,
+// for --siblings: an item that was never fanned out is an error.
+func requireSiblings(cfg *federation.Config, wantedID string) (siblingGroup, error) {
+	siblings, err := findSiblings(siblingsPath(), cfg.Upstream, wantedID)
+	if err != nil {
+		return nil, err
+	}
+	if len(siblings) == 0 {
+		return nil, fmt.Errorf("%s has no recorded siblings: only items posted with several --wasteland flags or --all have them", wantedID)
+	}
+	return siblings, nil
+}
+
+// fanOutToSiblings applies fn to each sibling in wasteland order,
+// reporting each outcome on stdout. Every sibling is tried; the error, if
+// any, names the wastelands that failed.
+func fanOutToSiblings(cmd *cobra.Command, stdout io.Writer, siblings siblingGroup, verb string, fn func(cfg *federation.Config, id string) error) error {
+	store := federation.NewConfigStore()
+	var failed []string
+	for _, upstream := range slices.Sorted(maps.Keys(siblings)) {
+		id := siblings[upstream]
+		cfg, err := store.Load(upstream)
+		if err == nil {
+			err = configureWasteland(cmd, cfg)
+		}
+		if err == nil {
+			err = fn(cfg, id)
+		}
+		if err != nil {
+			fmt.Fprintf(stdout, "  %s %s in %s: %v\n", style.Error.Render(style.IconFail), id, upstream, err)
+			failed = append(failed, upstream)
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s %s %s in %s\n", style.Success.Render(style.IconPass), verb, id, upstream)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("siblings in %s were not %s", strings.Join(failed, ", "), strings.ToLower(verb))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/spf13/cobra"
+)
+
+func TestWastelandFlag(t *testing.T) {
+	t.Parallel()
+	cmd := &cobra.Command{}
+	cmd.Flags().Var(&wastelandFlag{}, "wasteland", "")
+	if err := cmd.Flags().Parse([]string{"--wasteland", "hop/wl-commons", "--wasteland", "acme/wl", "--wasteland", "hop/wl-commons"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := cmd.Flags().GetString("wasteland"); got != "hop/wl-commons" {
+		t.Errorf("GetString = %q, want the last value", got)
+	}
+	if got := requestedWastelands(cmd); !reflect.DeepEqual(got, []string{"hop/wl-commons", "acme/wl"}) {
+		t.Errorf("requestedWastelands = %v", got)
+	}
+	if got := requestedWastelands(wastelandCmd()); got != nil {
+		t.Errorf("requestedWastelands(unset) = %v, want none", got)
+	}
+}
+
+func TestSiblings(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "wasteland", "siblings.json")
+	if got, err := findSiblings(path, "hop/wl-commons", "w-1"); err != nil || got != nil {
+		t.Fatalf("findSiblings(no file) = %v, %v", got, err)
+	}
+	for _, g := range []siblingGroup{
+		{"hop/wl-commons": "w-1", "acme/wl": "w-2", "beta/wl": "w-3"},
+		{"hop/wl-commons": "w-4", "acme/wl": "w-5"},
+	} {
+		if err := recordSiblings(path, g); err != nil {
+			t.Fatalf("recordSiblings: %v", err)
+		}
+	}
+	got, err := findSiblings(path, "acme/wl", "w-5")
+	if err != nil {
+		t.Fatalf("findSiblings: %v", err)
+	}
+	if want := (siblingGroup{"hop/wl-commons": "w-4"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("siblings of w-5 = %v, want %v", got, want)
+	}
+	if got, _ := findSiblings(path, "acme/wl", "w-1"); got != nil {
+		t.Errorf("w-1 is in hop/wl-commons, not acme/wl: got %v", got)
+	}
+}
+
+// saveWastelands joins the given upstreams in a fresh config dir, all as
+// rig alice.
+func saveWastelands(t *testing.T, upstreams ...string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	store := federation.NewConfigStore()
+	for _, u := range upstreams {
+		org, db, _ := strings.Cut(u, "/")
+		if err := store.Save(&federation.Config{
+			Upstream:  u,
+			ForkOrg:   "alice",
+			ForkDB:    db,
+			LocalDir:  filepath.Join("/tmp/test", org, db),
+			RigHandle: "alice",
+			JoinedAt:  time.Now(),
+		}); err != nil {
+			t.Fatalf("saving config: %v", err)
+		}
+	}
+}
+
+// openItemsDB is a noopDB that reads back every wanted item as open, so
+// mutations can refresh the item they wrote. New IDs are always free.
+type openItemsDB struct{ noopDB }
+
+func (openItemsDB) Query(sql, _ string) (string, error) {
+	if strings.HasPrefix(sql, "SELECT id FROM wanted WHERE id=") {
+		return "id\n", nil
+	}
+	_, rest, ok := strings.Cut(sql, "FROM wanted WHERE id='")
+	if !ok {
+		return "", nil
+	}
+	id, _, _ := strings.Cut(rest, "'")
+	return "id,title,status,posted_by\n" + id + ",Audit TLS config,open,alice\n", nil
+}
+
+func TestRunPost_FanoutAndSiblings(t *testing.T) {
+	saveWastelands(t, "hop/wl-commons", "acme/wl")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	withFakeSDK(t)
+	newSDKClient = func(cfg *federation.Config, _ bool) (*sdk.Client, error) {
+		return sdk.New(sdk.ClientConfig{DB: openItemsDB{}, RigHandle: cfg.RigHandle, Mode: "wild-west", NoPush: true}), nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.Flags().Var(&wastelandFlag{}, "wasteland", "")
+	if err := runPost(cmd, &stdout, &stderr, "Audit TLS config", "", "", "", 2, "medium", "", "", true, true, false); err != nil {
+		t.Fatalf("runPost --all: %v\n%s", err, &stdout)
+	}
+	out := stdout.String()
+	for _, want := range []string{"to 2 wastelands", "hop/wl-commons", "acme/wl", "--siblings"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	groups, err := loadSiblings(siblingsPath())
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("sibling groups = %v, %v", groups, err)
+	}
+	hopID, acmeID := groups[0]["hop/wl-commons"], groups[0]["acme/wl"]
+	if hopID == "" || acmeID == "" {
+		t.Fatalf("group = %v, want an ID per wasteland", groups[0])
+	}
+
+	stdout.Reset()
+	del := &cobra.Command{}
+	del.Flags().Var(&wastelandFlag{}, "wasteland", "")
+	_ = del.Flags().Set("wasteland", "hop/wl-commons")
+	if err := runDelete(del, &stdout, &stderr, hopID, true, true); err != nil {
+		t.Fatalf("runDelete --siblings: %v", err)
+	}
+	if want := "Withdrawn " + acmeID + " in acme/wl"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, stdout.String())
+	}
+}
+
+func TestFanoutTargets(t *testing.T) {
+	saveWastelands(t, "hop/wl-commons", "acme/wl")
+	store := federation.NewConfigStore()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Var(&wastelandFlag{}, "wasteland", "")
+	_ = cmd.Flags().Set("wasteland", "acme/wl")
+	cfgs, err := fanoutTargets(cmd, store, false)
+	if err != nil || len(cfgs) != 1 || cfgs[0].Upstream != "acme/wl" {
+		t.Fatalf("single --wasteland: %v, %v", cfgs, err)
+	}
+	if _, err := fanoutTargets(cmd, store, true); err == nil {
+		t.Error("--all with --wasteland: expected error")
+	}
+	_ = cmd.Flags().Set("wasteland", "nope/missing")
+	if _, err := fanoutTargets(cmd, store, false); err == nil || !strings.Contains(err.Error(), "nope/missing") {
+		t.Errorf("unknown wasteland: err = %v", err)
+	}
+
+	if _, err := requireSiblings(cfgs[0], "w-none"); err == nil {
+		t.Error("requireSiblings: expected error for an item never fanned out")
+	}
+}
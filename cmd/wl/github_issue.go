@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// githubIssue is the part of a GitHub issue that maps onto a wanted item.
+type githubIssue struct {
+	URL    string
+	Title  string
+	Body   string
+	Labels []string
+}
+
+var githubIssueURL = regexp.MustCompile(`^https://github\.com/([^/]+/[^/]+)/issues/(\d+)/?$`)
+
+// parseGitHubIssueURL splits an issue URL into its owner/repo and number.
+func parseGitHubIssueURL(url string) (repo, number string, err error) {
+	m := githubIssueURL.FindStringSubmatch(strings.TrimSpace(url))
+	if m == nil {
+		return "", "", fmt.Errorf("not a GitHub issue URL: %q (want https://github.com/<owner>/<repo>/issues/<n>)", url)
+	}
+	return m[1], m[2], nil
+}
+
+// GetIssue fetches an issue's title, body and labels.
+func (c *ghCLIClient) GetIssue(repo, number string) (*githubIssue, error) {
+	data, err := ghAPICall(c.ghPath, "GET", fmt.Sprintf("repos/%s/issues/%s", repo, number), "")
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		HTMLURL     string          `json:"html_url"`
+		Title       string          `json:"title"`
+		Body        string          `json:"body"`
+		PullRequest json.RawMessage `json:"pull_request"`
+		Labels      []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parsing issue response: %w", err)
+	}
+	if len(result.PullRequest) > 0 {
+		return nil, fmt.Errorf("%s/%s is a pull request, not an issue", repo, number)
+	}
+	issue := &githubIssue{URL: result.HTMLURL, Title: result.Title, Body: result.Body}
+	for _, l := range result.Labels {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	return issue, nil
+}
+
+// fetchGitHubIssue loads the issue at url through the gh CLI. It is a
+// variable so tests can replace it.
+var fetchGitHubIssue = func(url string) (*githubIssue, error) {
+	repo, number, err := parseGitHubIssueURL(url)
+	if err != nil {
+		return nil, err
+	}
+	ghPath, err := exec.LookPath("gh")
+	if err != nil {
+		return nil, fmt.Errorf("gh not found in PATH — install from https://cli.github.com")
+	}
+	issue, err := newGHClient(ghPath).GetIssue(repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if issue.URL == "" {
+		issue.URL = url
+	}
+	return issue, nil
+}
+
+// issueDescription is the issue body followed by a back-link to the issue.
+func issueDescription(issue *githubIssue) string {
+	link := "Mirrored from " + issue.URL
+	body := strings.TrimSpace(issue.Body)
+	if body == "" {
+		return link
+	}
+	return body + "\n\n" + link
+}
+
+// issueTags turns issue labels into tags: lowercased, with spaces replaced
+// by dashes.
+func issueTags(issue *githubIssue) []string {
+	var tags []string
+	for _, l := range issue.Labels {
+		t := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(l)), " ", "-")
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// issueLabelTypes maps common GitHub labels onto wanted item types.
+var issueLabelTypes = map[string]string{
+	"bug":           "bug",
+	"enhancement":   "feature",
+	"feature":       "feature",
+	"documentation": "docs",
+	"docs":          "docs",
+	"design":        "design",
+	"rfc":           "rfc",
+}
+
+// issueType picks the item type from the first label that maps onto a type
+// the wasteland accepts, or "" when none does.
+func issueType(vocab *commons.Vocabulary, issue *githubIssue) string {
+	for _, tag := range issueTags(issue) {
+		if typ, ok := issueLabelTypes[tag]; ok && vocab.CheckType(typ) == nil {
+			return typ
+		}
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestParseGitHubIssueURL(t *testing.T) {
+	t.Parallel()
+	repo, number, err := parseGitHubIssueURL("https://github.com/org/repo/issues/42")
+	if err != nil || repo != "org/repo" || number != "42" {
+		t.Errorf("parseGitHubIssueURL = %q, %q, %v", repo, number, err)
+	}
+	for _, bad := range []string{"https://github.com/org/repo/pull/42", "org/repo#42", "https://gitlab.com/org/repo/issues/1"} {
+		if _, _, err := parseGitHubIssueURL(bad); err == nil {
+			t.Errorf("parseGitHubIssueURL(%q) expected error", bad)
+		}
+	}
+}
+
+func TestIssueMapping(t *testing.T) {
+	t.Parallel()
+	issue := &githubIssue{
+		URL:    "https://github.com/org/repo/issues/7",
+		Title:  "Crash on startup",
+		Body:   "Steps to reproduce...\n",
+		Labels: []string{"good first issue", "Bug"},
+	}
+
+	if got, want := issueDescription(issue), "Steps to reproduce...\n\nMirrored from https://github.com/org/repo/issues/7"; got != want {
+		t.Errorf("issueDescription = %q, want %q", got, want)
+	}
+	if got := issueDescription(&githubIssue{URL: issue.URL}); got != "Mirrored from "+issue.URL {
+		t.Errorf("issueDescription(empty body) = %q", got)
+	}
+	if got := strings.Join(issueTags(issue), ","); got != "good-first-issue,bug" {
+		t.Errorf("issueTags = %q", got)
+	}
+	if got := issueType(commons.DefaultVocabulary(), issue); got != "bug" {
+		t.Errorf("issueType = %q, want bug", got)
+	}
+	if got := issueType(commons.DefaultVocabulary(), &githubIssue{Labels: []string{"question"}}); got != "" {
+		t.Errorf("issueType(unmapped) = %q, want empty", got)
+	}
+}
+
+func stubGitHubIssue(t *testing.T, issue *githubIssue, err error) {
+	t.Helper()
+	orig := fetchGitHubIssue
+	t.Cleanup(func() { fetchGitHubIssue = orig })
+	fetchGitHubIssue = func(string) (*githubIssue, error) { return issue, err }
+}
+
+func TestRunPost_FromGitHubIssueError(t *testing.T) {
+	saveWasteland(t)
+	withFakeSDK(t)
+	stubGitHubIssue(t, nil, errors.New("gh api GET repos/org/repo/issues/7: exit status 1"))
+
+	var stdout, stderr bytes.Buffer
+	err := runPost(wastelandCmd(), &stdout, &stderr, "", "", "", "", 2, "medium", "", "https://github.com/org/repo/issues/7", true, false, false)
+	if err == nil || !strings.Contains(err.Error(), "issues/7") {
+		t.Fatalf("runPost error = %v", err)
+	}
+}
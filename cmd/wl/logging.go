@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logLevel is the active level for the CLI's slog handlers. It is shared
+// with 'wl serve' so --verbose/--quiet apply to server logs too.
+var logLevel = new(slog.LevelVar)
+
+// logFile is the open --log-file, closed by closeLogging.
+var logFile *os.File
+
+// parseLogLevel maps a WL_LOG value to a slog level.
+func parseLogLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn, or error", s)
+	}
+}
+
+// resolveLogLevel picks the level from flags, then WL_LOG, defaulting to
+// warn so routine CLI output stays clean.
+func resolveLogLevel(verbose, quiet bool, env string) (slog.Level, error) {
+	switch {
+	case verbose && quiet:
+		return 0, fmt.Errorf("--verbose and --quiet are mutually exclusive")
+	case verbose:
+		return slog.LevelDebug, nil
+	case quiet:
+		return slog.LevelError, nil
+	case env != "":
+		return parseLogLevel(env)
+	default:
+		return slog.LevelWarn, nil
+	}
+}
+
+// parseSlowQueryThreshold maps a WL_SLOW_QUERY_MS value to a duration.
+// An empty value keeps def; 0 disables slow-query logging.
+func parseSlowQueryThreshold(env string, def time.Duration) (time.Duration, error) {
+	return parseMillisEnv("WL_SLOW_QUERY_MS", env, def)
+}
+
+// parseQueryTimeout maps a WL_QUERY_TIMEOUT_MS value to a duration.
+// An empty value keeps def; 0 disables the timeout.
+func parseQueryTimeout(env string, def time.Duration) (time.Duration, error) {
+	return parseMillisEnv("WL_QUERY_TIMEOUT_MS", env, def)
+}
+
+// parseMillisEnv parses the milliseconds value env of the variable name.
+func parseMillisEnv(name, env string, def time.Duration) (time.Duration, error) {
+	env = strings.TrimSpace(env)
+	if env == "" {
+		return def, nil
+	}
+	ms, err := strconv.Atoi(env)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative number of milliseconds", name, env)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// setupLogging installs the default slog logger: human-readable text on
+// stderr at the resolved level and, with a log file, JSON records at debug
+// level appended to that file.
+func setupLogging(stderr io.Writer, verbose, quiet bool, env, path string) error {
+	level, err := resolveLogLevel(verbose, quiet, env)
+	if err != nil {
+		return err
+	}
+	logLevel.Set(level)
+
+	var handler slog.Handler = slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: logLevel})
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("opening log file: %w", err)
+		}
+		logFile = f
+		fileHandler := slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})
+		handler = slog.NewMultiHandler(handler, fileHandler)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// closeLogging flushes and closes the log file, if any.
+func closeLogging() {
+	if logFile != nil {
+		_ = logFile.Close()
+		logFile = nil
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveLogLevel(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		verbose, quiet bool
+		env            string
+		want           slog.Level
+		wantErr        bool
+	}{
+		{false, false, "", slog.LevelWarn, false},
+		{true, false, "", slog.LevelDebug, false},
+		{false, true, "debug", slog.LevelError, false},
+		{false, false, "INFO", slog.LevelInfo, false},
+		{false, false, "loud", 0, true},
+		{true, true, "", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := resolveLogLevel(tt.verbose, tt.quiet, tt.env)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveLogLevel(%v, %v, %q) error = %v, wantErr %v", tt.verbose, tt.quiet, tt.env, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("resolveLogLevel(%v, %v, %q) = %v, want %v", tt.verbose, tt.quiet, tt.env, got, tt.want)
+		}
+	}
+}
+
+// setupLogging replaces the process-wide default logger, so this test is
+// not parallel.
+func TestSetupLogging_LogFile(t *testing.T) {
+	orig := slog.Default()
+	t.Cleanup(func() {
+		closeLogging()
+		slog.SetDefault(orig)
+	})
+
+	path := filepath.Join(t.TempDir(), "wl.log")
+	var stderr bytes.Buffer
+	if err := setupLogging(&stderr, false, false, "", path); err != nil {
+		t.Fatalf("setupLogging: %v", err)
+	}
+
+	slog.Debug("debug detail", "k", "v")
+	slog.Warn("something odd")
+	closeLogging()
+
+	if strings.Contains(stderr.String(), "debug detail") {
+		t.Errorf("debug record should not reach stderr at default level:\n%s", stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "something odd") {
+		t.Errorf("warn record missing from stderr:\n%s", stderr.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"msg":"debug detail"`) {
+		t.Errorf("log file missing debug record:\n%s", data)
+	}
+}
+
+func TestParseSlowQueryThreshold(t *testing.T) {
+	t.Parallel()
+	def := 2 * time.Second
+	tests := []struct {
+		env     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", def, false},
+		{"500", 500 * time.Millisecond, false},
+		{" 0 ", 0, false},
+		{"-1", 0, true},
+		{"2s", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSlowQueryThreshold(tt.env, def)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSlowQueryThreshold(%q) error = %v, wantErr %v", tt.env, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("parseSlowQueryThreshold(%q) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
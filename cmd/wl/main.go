@@ -5,10 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strconv"
 
+	"github.com/gastownhall/wasteland/internal/backend"
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/i18n"
 	"github.com/gastownhall/wasteland/internal/style"
+	"github.com/gastownhall/wasteland/internal/xdg"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +25,12 @@ var (
 	commit       = "unknown"
 	date         = "unknown"
 	inferEnabled = "true" // set to "false" via ldflags to hide inference UI
+
+	// releasePublicKey is the minisign public key (the base64 line of the
+	// .pub file) that signs release checksums. Release builds set it; wl
+	// upgrade then refuses releases without a valid signature. Builds
+	// without it refuse to upgrade unless --insecure-skip-signature.
+	releasePublicKey = ""
 )
 
 func inferGateEnabled() bool {
@@ -30,6 +43,7 @@ func main() {
 
 // errExit is a sentinel error returned by cobra RunE functions to signal
 // non-zero exit. The command has already written its own error to stderr.
+// Wrap it in a commons error type to exit with that class's code.
 var errExit = errors.New("exit")
 
 // run executes the wl CLI with the given args.
@@ -38,18 +52,30 @@ func run(args []string, stdout, stderr io.Writer) int {
 	if args == nil {
 		args = []string{}
 	}
+	expanded, err := expandAlias(root, args, federation.NewConfigStore())
+	if err != nil {
+		fmt.Fprintf(stderr, "wl: %v\n", err)
+		return exitCode(err)
+	}
+	args = expanded
 	root.SetArgs(args)
 	root.SetOut(stdout)
 	root.SetErr(stderr)
+	defer closeLogging()
 	if err := root.Execute(); err != nil {
+		slog.Debug("command failed", "args", args, "error", err)
 		if !errors.Is(err, errExit) {
 			fmt.Fprintf(stderr, "wl: %v\n", err)
 			var hinted *HintedError
-			if errors.As(err, &hinted) {
-				fmt.Fprintf(stderr, "\n  Hint: %s\n", hinted.Hint)
+			var secret *commons.SecretError
+			switch {
+			case errors.As(err, &hinted):
+				fmt.Fprintf(stderr, "\n  %s: %s\n", i18n.T("hint.label"), hinted.Hint)
+			case errors.As(err, &secret):
+				fmt.Fprintf(stderr, "\n  %s: %s\n", i18n.T("hint.label"), i18n.T("hint.secret"))
 			}
 		}
-		return 1
+		return exitCode(err)
 	}
 	return 0
 }
@@ -70,26 +96,54 @@ func newRootCmd(stdout, stderr io.Writer) *cobra.Command {
 			return errExit
 		},
 	}
-	root.PersistentFlags().String("wasteland", "", "Upstream wasteland to use (e.g., org/db); required when multiple are joined")
+	root.PersistentFlags().Var(&wastelandFlag{}, "wasteland", "Upstream wasteland to use (e.g., org/db); required when multiple are joined")
 	_ = root.RegisterFlagCompletionFunc("wasteland", completeWastelandNames)
+	root.PersistentFlags().String("as", "", "Act as this rig profile (default: $WL_PROFILE)")
+	_ = root.RegisterFlagCompletionFunc("as", completeProfileNames)
 	root.PersistentFlags().Bool("local-db", false, "Use local dolt database instead of DoltHub API")
-	root.PersistentFlags().String("color", "auto", "Color output: always, auto, never")
+	root.PersistentFlags().Bool("read-only", false, "Refuse all mutations (default: $WL_READ_ONLY)")
+	root.PersistentFlags().Bool("allow-secrets", false, "Commit text that looks like a credential instead of refusing")
+	root.PersistentFlags().String("color", "auto", "Color output: auto, always, never, 16, 256, truecolor (auto honors NO_COLOR and CLICOLOR)")
+	_ = root.RegisterFlagCompletionFunc("color", cobra.FixedCompletions(style.ColorModes, cobra.ShellCompDirectiveNoFileComp))
+	root.PersistentFlags().BoolP("verbose", "v", false, "Enable debug logging on stderr")
+	root.PersistentFlags().BoolP("quiet", "q", false, "Only log errors on stderr")
+	root.PersistentFlags().String("log-file", "", "Append debug-level JSON logs to this file (default: $WL_LOG_FILE)")
 	root.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
 		colorMode, _ := cmd.Flags().GetString("color")
-		switch colorMode {
-		case "always", "auto", "never":
-			style.SetColorMode(colorMode)
-			return nil
-		default:
-			return fmt.Errorf("invalid --color value %q: must be always, auto, or never", colorMode)
+		if err := style.SetColorMode(colorMode); err != nil {
+			return err
+		}
+
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		logPath, _ := cmd.Flags().GetString("log-file")
+		if logPath == "" {
+			logPath = os.Getenv("WL_LOG_FILE")
+		}
+		threshold, err := parseSlowQueryThreshold(os.Getenv("WL_SLOW_QUERY_MS"), backend.SlowQueryThreshold)
+		if err != nil {
+			return err
+		}
+		backend.SlowQueryThreshold = threshold
+		timeout, err := parseQueryTimeout(os.Getenv("WL_QUERY_TIMEOUT_MS"), backend.ServerQueryTimeout)
+		if err != nil {
+			return err
 		}
+		backend.ServerQueryTimeout = timeout
+		if err := i18n.LoadDir(filepath.Join(xdg.ConfigDir(), "locales")); err != nil {
+			slog.Warn("loading locale files", "error", err)
+		}
+		i18n.SetLocale(i18n.Detect("", os.Getenv))
+		return setupLogging(stderr, verbose, quiet, os.Getenv("WL_LOG"), logPath)
 	}
 	root.AddCommand(
 		newCreateCmd(stdout, stderr),
 		newJoinCmd(stdout, stderr),
+		newInviteCmd(stdout, stderr),
 		newPostCmd(stdout, stderr),
 		newClaimCmd(stdout, stderr),
 		newUnclaimCmd(stdout, stderr),
+		newQueueCmd(stdout, stderr),
 		newDoneCmd(stdout, stderr),
 		newAcceptCmd(stdout, stderr),
 		newRejectCmd(stdout, stderr),
@@ -99,10 +153,16 @@ func newRootCmd(stdout, stderr io.Writer) *cobra.Command {
 		newBrowseCmd(stdout, stderr),
 		newMeCmd(stdout, stderr),
 		newStatusCmd(stdout, stderr),
+		newBlameCmd(stdout, stderr),
+		newDiffCmd(stdout, stderr),
 		newSyncCmd(stdout, stderr),
+		newPruneCmd(stdout, stderr),
+		newSweepCmd(stdout, stderr),
+		newBranchesCmd(stdout, stderr),
 		newLeaveCmd(stdout, stderr),
 		newListCmd(stdout, stderr),
 		newConfigCmd(stdout, stderr),
+		newAliasCmd(stdout, stderr),
 		newReviewCmd(stdout, stderr),
 		newApproveCmd(stdout, stderr),
 		newRequestChangesCmd(stdout, stderr),
@@ -112,8 +172,19 @@ func newRootCmd(stdout, stderr io.Writer) *cobra.Command {
 		newServeCmd(stdout, stderr),
 		newDoctorCmd(stdout, stderr),
 		newLeaderboardCmd(stdout, stderr),
+		newStatsCmd(stdout, stderr),
+		newSQLServerCmd(stdout, stderr),
+		newSyncdCmd(stdout, stderr),
+		newWatchCmd(stdout, stderr),
+		newNotifyCmd(stdout, stderr),
+		newTagsCmd(stdout, stderr),
+		newExportCmd(stdout, stderr),
+		newSearchCmd(stdout, stderr),
+		newStampCmd(stdout, stderr),
+		newBadgeCmd(stdout, stderr),
 		newProfileCmd(stdout, stderr),
 		newVersionCmd(stdout),
+		newUpgradeCmd(stdout, stderr),
 	)
 	if inferGateEnabled() {
 		root.AddCommand(newInferCmd(stdout, stderr))
@@ -130,10 +201,58 @@ func resolveWasteland(cmd *cobra.Command) (*federation.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := configureWasteland(cmd, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// configureWasteland applies the process-wide flags and environment
+// (locale, --as, --read-only, --allow-secrets, --local-db) to a loaded
+// wasteland config.
+func configureWasteland(cmd *cobra.Command, cfg *federation.Config) error {
+	if cfg.Locale != "" {
+		i18n.SetLocale(i18n.Detect(cfg.Locale, os.Getenv))
+	}
+	if err := cfg.UseProfile(requestedProfile(cmd)); err != nil {
+		return err
+	}
+	if readOnlyRequested(cmd) {
+		cfg.ForceReadOnly = true
+	}
+	cfg.AllowSecrets, _ = cmd.Flags().GetBool("allow-secrets")
 	if localDB, _ := cmd.Flags().GetBool("local-db"); localDB {
 		cfg.Backend = federation.BackendLocal
 	} else {
 		cfg.Backend = federation.BackendRemote
 	}
-	return cfg, nil
+	return nil
+}
+
+// requestedProfile returns the rig profile named by --as or WL_PROFILE.
+func requestedProfile(cmd *cobra.Command) string {
+	if name, _ := cmd.Flags().GetString("as"); name != "" {
+		return name
+	}
+	return os.Getenv("WL_PROFILE")
+}
+
+// readOnlyRequested reports whether --read-only or WL_READ_ONLY asks for
+// read-only mode for this process.
+func readOnlyRequested(cmd *cobra.Command) bool {
+	if readOnly, _ := cmd.Flags().GetBool("read-only"); readOnly {
+		return true
+	}
+	v, _ := strconv.ParseBool(os.Getenv("WL_READ_ONLY"))
+	return v
+}
+
+// requireWritable refuses op when cfg is read-only. Mutations through the
+// SDK or a DB from openDBFromConfig are refused below the CLI; this guards
+// the commands that drive dolt or a provider directly.
+func requireWritable(cfg *federation.Config, op string) error {
+	if cfg.IsReadOnly() {
+		return &commons.ReadOnlyError{Op: op}
+	}
+	return nil
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+// ghRateLimits records GitHub rate limiting reported by gh calls made in
+// this process, so the SDK and TUI can explain why PR operations fail.
+var ghRateLimits commons.RateLimitTracker
+
+// noteGHRateLimit records a refusal when gh's output says GitHub rate
+// limited the request. gh doesn't expose response headers, so the time
+// until the limit lifts is unknown.
+func noteGHRateLimit(out []byte) {
+	msg := strings.ToLower(string(out))
+	if strings.Contains(msg, "rate limit") || strings.Contains(msg, "http 429") {
+		ghRateLimits.Record(commons.RateLimit{Provider: "github", Limited: true, ObservedAt: time.Now()})
+	}
+}
+
+// fetchGHRateLimit returns the core REST API quota of the account gh is
+// logged in as. Querying it doesn't count against the quota.
+func fetchGHRateLimit(ghPath string) (commons.RateLimit, error) {
+	out, err := exec.Command(ghPath, "api", "rate_limit").Output()
+	if err != nil {
+		return commons.RateLimit{}, fmt.Errorf("gh api rate_limit: %w", err)
+	}
+	return parseGHRateLimit(out, time.Now())
+}
+
+// parseGHRateLimit reads the core quota from a GET /rate_limit response.
+func parseGHRateLimit(out []byte, now time.Time) (commons.RateLimit, error) {
+	var resp struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return commons.RateLimit{}, fmt.Errorf("parsing rate_limit response: %w", err)
+	}
+	core := resp.Resources.Core
+	rl := commons.RateLimit{
+		Provider:   "github",
+		Limit:      core.Limit,
+		Remaining:  core.Remaining,
+		Limited:    core.Limit > 0 && core.Remaining == 0,
+		ObservedAt: now,
+	}
+	if core.Reset > 0 {
+		rl.Reset = time.Unix(core.Reset, 0)
+	}
+	return rl, nil
+}
+
+// probeRateLimits reports the quota of the APIs cfg talks to: GitHub for
+// a github provider (via gh), and DoltHub for the remote backend, whose
+// state comes from the headers of a trivial query. Providers that can't
+// be reached or don't report a quota are omitted.
+func probeRateLimits(cfg *federation.Config) []commons.RateLimit {
+	var out []commons.RateLimit
+	if cfg.ResolveProviderType() == "github" {
+		if ghPath, err := exec.LookPath("gh"); err == nil {
+			if rl, err := fetchGHRateLimit(ghPath); err == nil {
+				out = append(out, rl)
+			}
+		}
+	}
+	if cfg.ResolveBackend() == federation.BackendRemote {
+		if db, err := openBackend(cfg); err == nil {
+			if r, ok := db.(commons.RateLimitReporter); ok {
+				_, _ = db.Query("SELECT 1 AS ok", "")
+				out = append(out, r.RateLimits()...)
+			}
+		}
+	}
+	return out
+}
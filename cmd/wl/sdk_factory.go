@@ -1,10 +1,28 @@
 package main
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/hooks"
 	"github.com/gastownhall/wasteland/internal/sdk"
 )
 
+// prStatusTTL is how long the interactive frontends (TUI, wl serve) trust a
+// cached PR lookup before refreshing it in the background. One-shot CLI
+// commands check synchronously so their output is always current.
+const prStatusTTL = 30 * time.Second
+
+// commitSigning returns how cfg's commits are signed, for the writes wl
+// makes outside an sdk.Client.
+func commitSigning(cfg *federation.Config) commons.Signing {
+	return commons.Signing{Enabled: cfg.Signing, Key: cfg.SigningKey}
+}
+
 // newSDKClient creates an SDK client from a federation config with all mutation
 // callbacks wired up. Package-level variable to allow test overrides.
 var newSDKClient = func(cfg *federation.Config, noPush bool) (*sdk.Client, error) {
@@ -13,12 +31,17 @@ var newSDKClient = func(cfg *federation.Config, noPush bool) (*sdk.Client, error
 		return nil, err
 	}
 	return sdk.New(sdk.ClientConfig{
-		DB:        db,
-		RigHandle: cfg.RigHandle,
-		Mode:      cfg.ResolveMode(),
-		Signing:   cfg.Signing,
-		HopURI:    cfg.HopURI,
-		NoPush:    noPush,
+		DB:         db,
+		RigHandle:  cfg.RigHandle,
+		Mode:       cfg.ResolveMode(),
+		Signing:    cfg.Signing,
+		SigningKey: cfg.SigningKey,
+		HopURI:     cfg.HopURI,
+		NoPush:     noPush,
+		ReadOnly:   cfg.IsReadOnly(),
+
+		AllowSecrets: cfg.AllowSecrets,
+		ConfirmPush:  pushConfirm(cfg),
 		CreatePR: func(branch string) (string, error) {
 			if cfg.ResolveBackend() != federation.BackendLocal {
 				return createPRForBranchRemote(cfg, db, branch)
@@ -34,5 +57,34 @@ var newSDKClient = func(cfg *federation.Config, noPush bool) (*sdk.Client, error
 		ListPendingItems: listPendingItemsFromPRs(cfg),
 		BranchURL:        branchURLCallback(cfg),
 		CloseUpstreamPR:  closeUpstreamPRCallback(cfg),
+		SquashBranch:     squashBranchCallback(cfg),
+		UpdateBranch:     updateBranchCallback(cfg),
+		PRState: func(branch string) string {
+			return prStateForBranch(cfg, branch)
+		},
+		RateLimits: ghRateLimits.RateLimits,
+		Hooks:      hookRunner(cfg, os.Stderr),
 	}), nil
 }
+
+// pushConfirm returns the prompt asked before each wild-west push when
+// confirm-push is set, or nil. It writes to stderr so --json output stays
+// clean; without a terminal to answer, the push is declined.
+func pushConfirm(cfg *federation.Config) func(string) bool {
+	if !cfg.ConfirmPush {
+		return nil
+	}
+	confirm := newStdinConfirm(os.Stdin, os.Stderr)
+	return func(commitMsg string) bool {
+		return confirm(fmt.Sprintf("Push %q to upstream?", commitMsg))
+	}
+}
+
+// hookRunner returns the lifecycle hooks configured for cfg, or nil when
+// none are set. Hook output goes to output (nil discards it).
+func hookRunner(cfg *federation.Config, output io.Writer) sdk.HookRunner {
+	if len(cfg.Hooks) == 0 {
+		return nil
+	}
+	return &hooks.Runner{Commands: cfg.Hooks, Output: output}
+}
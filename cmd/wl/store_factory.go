@@ -14,11 +14,37 @@ var openDB = func(localDir string) commons.DB {
 	return backend.NewLocalDB(localDir, "")
 }
 
-// openDBFromConfig creates a commons.DB using the resolved backend from config.
+// openDBFromConfig creates a commons.DB using the resolved backend from config,
+// wrapped by commons.ReadOnly when cfg is read-only and otherwise by
+// commons.Chained, so every commit extends the chain_meta hash chain.
 // Package-level variable to allow test overrides.
 var openDBFromConfig = func(cfg *federation.Config) (commons.DB, error) {
+	db, err := openBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return wrapDB(cfg, db), nil
+}
+
+// openLocalClone returns cfg's local clone as a commons.DB, wrapped like
+// openDBFromConfig, for commands that work on the clone whatever backend
+// is configured. Their branch merges, deletes and pushes then go through
+// the read-only guard too.
+func openLocalClone(cfg *federation.Config) commons.DB {
+	return wrapDB(cfg, newLocalDB(cfg))
+}
+
+func wrapDB(cfg *federation.Config, db commons.DB) commons.DB {
+	if cfg.IsReadOnly() {
+		return commons.ReadOnly(db)
+	}
+	return commons.Chained(db, cfg.RigHandle, cfg.HopURI)
+}
+
+// openBackend creates the commons.DB for cfg's resolved backend.
+func openBackend(cfg *federation.Config) (commons.DB, error) {
 	if cfg.ResolveBackend() == federation.BackendLocal {
-		return backend.NewLocalDB(cfg.LocalDir, cfg.ResolveMode()), nil
+		return newLocalDB(cfg), nil
 	}
 	if cfg.IsGitHub() {
 		return nil, fmt.Errorf("GitHub backend requires local dolt\n\n  Install: https://docs.dolthub.com/introduction/installation\n  Then: wl join --github %s --local-db", cfg.Upstream)
@@ -33,3 +59,13 @@ var openDBFromConfig = func(cfg *federation.Config) (commons.DB, error) {
 	}
 	return backend.NewRemoteDB(token, upOrg, upDB, cfg.ForkOrg, cfg.ForkDB, cfg.ResolveMode()), nil
 }
+
+// asRemoteDB returns db as a *backend.RemoteDB, looking through a read-only
+// or chain wrapper.
+func asRemoteDB(db commons.DB) (*backend.RemoteDB, bool) {
+	if u, ok := db.(interface{ Unwrap() commons.DB }); ok {
+		db = u.Unwrap()
+	}
+	rdb, ok := db.(*backend.RemoteDB)
+	return rdb, ok
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -16,17 +17,17 @@ import (
 // noopDB is a minimal commons.DB implementation for tests.
 type noopDB struct{}
 
-func (noopDB) Query(string, string) (string, error)       { return "", nil }
-func (noopDB) Exec(string, string, bool, ...string) error { return nil }
-func (noopDB) Branches(string) ([]string, error)          { return nil, nil }
-func (noopDB) DeleteBranch(string) error                  { return nil }
-func (noopDB) PushBranch(string, io.Writer) error         { return nil }
-func (noopDB) PushMain(io.Writer) error                   { return nil }
-func (noopDB) Sync() error                                { return nil }
-func (noopDB) MergeBranch(string) error                   { return nil }
-func (noopDB) DeleteRemoteBranch(string) error            { return nil }
-func (noopDB) PushWithSync(io.Writer) error               { return nil }
-func (noopDB) CanWildWest() error                         { return nil }
+func (noopDB) Query(string, string) (string, error)                  { return "", nil }
+func (noopDB) Exec(string, string, commons.Signing, ...string) error { return nil }
+func (noopDB) Branches(string) ([]string, error)                     { return nil, nil }
+func (noopDB) DeleteBranch(string) error                             { return nil }
+func (noopDB) PushBranch(string, io.Writer) error                    { return nil }
+func (noopDB) PushMain(io.Writer) error                              { return nil }
+func (noopDB) Sync() error                                           { return nil }
+func (noopDB) MergeBranch(string) error                              { return nil }
+func (noopDB) DeleteRemoteBranch(string) error                       { return nil }
+func (noopDB) PushWithSync(io.Writer) error                          { return nil }
+func (noopDB) CanWildWest() error                                    { return nil }
 
 // withFakeSDK overrides newSDKClient and resolveWantedArg for test isolation.
 // The returned SDK client uses a noopDB that succeeds on all mutations.
@@ -104,3 +105,18 @@ func TestRunStatus_Handler_NotJoined(t *testing.T) {
 		t.Fatal("runStatus() expected error when not joined")
 	}
 }
+
+func TestOpenLocalClone_ReadOnly(t *testing.T) {
+	cfg := &federation.Config{Upstream: "hop/wl-commons", LocalDir: t.TempDir(), RigHandle: "alice", ForceReadOnly: true}
+	db := openLocalClone(cfg)
+	if !commons.IsReadOnly(db) {
+		t.Fatal("openLocalClone should wrap a read-only config's clone")
+	}
+	var ro *commons.ReadOnlyError
+	if err := db.MergeBranch("wl/alice/w-1"); !errors.As(err, &ro) {
+		t.Errorf("MergeBranch err = %v, want ReadOnlyError", err)
+	}
+	if err := db.DeleteRemoteBranch("wl/alice/w-1"); !errors.As(err, &ro) {
+		t.Errorf("DeleteRemoteBranch err = %v, want ReadOnlyError", err)
+	}
+}
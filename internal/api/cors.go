@@ -1,6 +1,15 @@
 package api
 
-import "net/http"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // CORSMiddleware wraps a handler with permissive CORS headers for development.
 func CORSMiddleware(next http.Handler) http.Handler {
@@ -17,3 +26,151 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// defaultCORSMethods are allowed on routes without a RouteMethods entry.
+var defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// defaultCORSHeaders are the request headers the web UI sends.
+var defaultCORSHeaders = []string{"Content-Type", "X-Wasteland", "Authorization"}
+
+// CORSConfig is a cross-origin policy for NewCORS.
+type CORSConfig struct {
+	// AllowedOrigins are exact origins (scheme://host[:port]) allowed to
+	// call the API, or "*" for any origin.
+	AllowedOrigins []string
+	// AllowCredentials lets browsers send cookies with cross-origin
+	// requests. It cannot be combined with the "*" origin.
+	AllowCredentials bool
+	// RouteMethods maps a path prefix to the methods allowed under it; the
+	// longest matching prefix wins. Routes without an entry allow
+	// defaultCORSMethods.
+	RouteMethods map[string][]string
+	// AllowedHeaders overrides defaultCORSHeaders when non-empty.
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache a preflight result. Zero
+	// omits the header.
+	MaxAge time.Duration
+}
+
+// ParseRouteMethods parses "prefix=METHOD,METHOD" entries, as given to
+// the --cors-methods flag, into a RouteMethods map.
+func ParseRouteMethods(entries []string) (map[string][]string, error) {
+	routes := make(map[string][]string, len(entries))
+	for _, e := range entries {
+		prefix, list, ok := strings.Cut(e, "=")
+		if !ok || !strings.HasPrefix(prefix, "/") || list == "" {
+			return nil, fmt.Errorf("invalid route methods %q, expected /path=METHOD[,METHOD]", e)
+		}
+		var methods []string
+		for _, m := range strings.Split(list, ",") {
+			if m = strings.ToUpper(strings.TrimSpace(m)); m != "" {
+				methods = append(methods, m)
+			}
+		}
+		routes[prefix] = methods
+	}
+	return routes, nil
+}
+
+// NewCORS returns middleware enforcing cfg. Requests from allowed origins
+// get Access-Control-* response headers; other requests pass through
+// without them, so browsers block cross-origin reads. Any cross-origin
+// request, preflight or not and whatever its origin, gets a 403 for a
+// method the route does not allow, checked before the origin; so does a
+// preflight from a disallowed origin. A request whose Origin names the
+// server's own host is same-origin and is not checked. Routes are matched
+// on the path the middleware sees, so it belongs inside any path rewriting
+// such as WastelandPrefix.
+func NewCORS(cfg CORSConfig) (func(http.Handler) http.Handler, error) {
+	if len(cfg.AllowedOrigins) == 0 {
+		return nil, errors.New("cors: at least one allowed origin is required")
+	}
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	if anyOrigin && cfg.AllowCredentials {
+		return nil, errors.New("cors: credentials cannot be allowed for origin \"*\"")
+	}
+	headers := cfg.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	allowHeaders := strings.Join(headers, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || sameOrigin(r, origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+			allowed := anyOrigin || slices.Contains(cfg.AllowedOrigins, origin)
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if allowed {
+				if anyOrigin {
+					w.Header().Set("Access-Control-Allow-Origin", "*")
+				} else {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+				}
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			methods := cfg.methodsFor(r.URL.Path)
+			method := r.Method
+			if preflight {
+				method = r.Header.Get("Access-Control-Request-Method")
+			}
+			if !methodAllowed(methods, method) {
+				writeError(w, http.StatusForbidden, "method not allowed for this route")
+				return
+			}
+			if !allowed && preflight {
+				writeError(w, http.StatusForbidden, "origin not allowed")
+				return
+			}
+			if !preflight {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(append(slices.Clone(methods), "OPTIONS"), ", "))
+			w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			if cfg.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}, nil
+}
+
+// methodsFor returns the methods allowed for path: the longest matching
+// RouteMethods prefix, else defaultCORSMethods.
+func (cfg CORSConfig) methodsFor(path string) []string {
+	best, methods := -1, defaultCORSMethods
+	for prefix, m := range cfg.RouteMethods {
+		if strings.HasPrefix(path, prefix) && len(prefix) > best {
+			best, methods = len(prefix), m
+		}
+	}
+	return methods
+}
+
+// sameOrigin reports whether origin names the host r was sent to, as
+// browsers send Origin on same-origin writes too.
+func sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
+
+// methodAllowed reports whether method is in methods. HEAD goes wherever
+// GET does, and OPTIONS is always allowed.
+func methodAllowed(methods []string, method string) bool {
+	switch method {
+	case http.MethodOptions:
+		return true
+	case http.MethodHead:
+		method = http.MethodGet
+	}
+	return slices.Contains(methods, method)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestCORS(t *testing.T, cfg CORSConfig) http.Handler {
+	t.Helper()
+	mw, err := NewCORS(cfg)
+	if err != nil {
+		t.Fatalf("NewCORS: %v", err)
+	}
+	return mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func corsRequest(method, path, origin, requestMethod string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if requestMethod != "" {
+		req.Header.Set("Access-Control-Request-Method", requestMethod)
+	}
+	return req
+}
+
+func TestNewCORS_Allowlist(t *testing.T) {
+	h := newTestCORS(t, CORSConfig{
+		AllowedOrigins:   []string{"https://board.example.com"},
+		AllowCredentials: true,
+		RouteMethods:     map[string][]string{"/api/scoreboard": {"GET"}},
+		MaxAge:           10 * time.Minute,
+	})
+
+	tests := []struct {
+		name        string
+		req         *http.Request
+		wantStatus  int
+		wantOrigin  string
+		wantMethods string
+	}{
+		{"same-origin request", corsRequest("GET", "/api/wanted", "", ""), 200, "", ""},
+		{"allowed origin", corsRequest("GET", "/api/wanted", "https://board.example.com", ""), 200, "https://board.example.com", ""},
+		{"disallowed origin passes without headers", corsRequest("GET", "/api/wanted", "https://evil.example", ""), 200, "", ""},
+		{"preflight", corsRequest("OPTIONS", "/api/wanted/w-1", "https://board.example.com", "PATCH"), 204, "https://board.example.com", "GET, POST, PUT, PATCH, DELETE, OPTIONS"},
+		{"preflight disallowed origin", corsRequest("OPTIONS", "/api/wanted", "https://evil.example", "GET"), 403, "", ""},
+		{"preflight route method allowed", corsRequest("OPTIONS", "/api/scoreboard/dump", "https://board.example.com", "GET"), 204, "https://board.example.com", "GET, OPTIONS"},
+		{"preflight route method denied", corsRequest("OPTIONS", "/api/scoreboard", "https://board.example.com", "POST"), 403, "https://board.example.com", ""},
+		{"route method allowed", corsRequest("GET", "/api/scoreboard", "https://board.example.com", ""), 200, "https://board.example.com", ""},
+		{"route HEAD follows GET", corsRequest("HEAD", "/api/scoreboard", "https://board.example.com", ""), 200, "https://board.example.com", ""},
+		{"route method denied", corsRequest("POST", "/api/scoreboard", "https://board.example.com", ""), 403, "https://board.example.com", ""},
+		{"route method denied for disallowed origin", corsRequest("POST", "/api/scoreboard", "https://evil.example", ""), 403, "", ""},
+		{"same-origin write is not checked", corsRequest("POST", "/api/scoreboard", "http://example.com", ""), 200, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Methods"); got != tt.wantMethods {
+				t.Errorf("Allow-Methods = %q, want %q", got, tt.wantMethods)
+			}
+			if tt.wantOrigin != "" && rec.Header().Get("Access-Control-Allow-Credentials") != "true" {
+				t.Error("missing Allow-Credentials")
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, corsRequest("OPTIONS", "/api/wanted", "https://board.example.com", "GET"))
+	if got := rec.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Max-Age = %q, want 600", got)
+	}
+	if got := rec.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("Vary = %q, want Origin", got)
+	}
+}
+
+func TestNewCORS_InsideWastelandPrefix(t *testing.T) {
+	h := WastelandPrefix(newTestCORS(t, CORSConfig{
+		AllowedOrigins: []string{"https://board.example.com"},
+		RouteMethods:   map[string][]string{"/api/wanted": {"GET"}},
+	}))
+
+	for _, req := range []*http.Request{
+		corsRequest("OPTIONS", "/w/hop/wl-commons/api/wanted", "https://board.example.com", "POST"),
+		corsRequest("POST", "/w/hop/wl-commons/api/wanted", "https://board.example.com", ""),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s %s: status = %d, want 403 from the /api/wanted rule", req.Method, req.URL.Path, rec.Code)
+		}
+	}
+}
+
+func TestNewCORS_Wildcard(t *testing.T) {
+	h := newTestCORS(t, CORSConfig{AllowedOrigins: []string{"*"}})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, corsRequest("GET", "/api/wanted", "https://anywhere.example", ""))
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Allow-Origin = %q, want *", got)
+	}
+	if rec.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Error("wildcard policy must not allow credentials")
+	}
+}
+
+func TestNewCORS_InvalidConfig(t *testing.T) {
+	if _, err := NewCORS(CORSConfig{}); err == nil {
+		t.Error("expected error with no origins")
+	}
+	if _, err := NewCORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}); err == nil {
+		t.Error("expected error for credentials with wildcard origin")
+	}
+}
+
+func TestParseRouteMethods(t *testing.T) {
+	got, err := ParseRouteMethods([]string{"/api/scoreboard=get, options", "/api/wanted=GET,POST"})
+	if err != nil {
+		t.Fatalf("ParseRouteMethods: %v", err)
+	}
+	if m := got["/api/scoreboard"]; len(m) != 2 || m[0] != "GET" || m[1] != "OPTIONS" {
+		t.Errorf("/api/scoreboard = %v", m)
+	}
+	if m := got["/api/wanted"]; len(m) != 2 || m[1] != "POST" {
+		t.Errorf("/api/wanted = %v", m)
+	}
+	for _, bad := range []string{"api=GET", "/api", "/api="} {
+		if _, err := ParseRouteMethods([]string{bad}); err == nil {
+			t.Errorf("ParseRouteMethods(%q) succeeded, want error", bad)
+		}
+	}
+}
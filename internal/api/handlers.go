@@ -3,10 +3,14 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/sdk"
@@ -19,6 +23,10 @@ import (
 func (s *Server) resolveClient(w http.ResponseWriter, r *http.Request) (*sdk.Client, bool) {
 	client, err := s.clientFunc(r)
 	if err != nil {
+		if errors.Is(err, errUnknownWasteland) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return nil, false
+		}
 		if r.Method == http.MethodGet && s.publicClient != nil {
 			c := s.publicClient
 			// Staging impersonation: if the user isn't authenticated but
@@ -42,9 +50,21 @@ func (s *Server) handleBrowse(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-	key := client.RigHandle() + ":" + canonicalBrowseKey(r)
+	filter, err := parseQueryFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid q: "+err.Error())
+		return
+	}
+	if format := r.URL.Query().Get("format"); format != "" && format != "json" {
+		if _, ok := streamFormats[format]; !ok {
+			writeError(w, http.StatusBadRequest, "format must be json, ndjson, or csv")
+			return
+		}
+		s.streamBrowse(w, client, filter, format)
+		return
+	}
+	key := cacheScope(r, client) + ":" + canonicalBrowseKey(r)
 	data, err := s.browseCache.GetOrFetch(key, func() ([]byte, error) {
-		filter := parseQueryFilter(r)
 		result, err := client.Browse(filter)
 		if err != nil {
 			return nil, err
@@ -52,6 +72,9 @@ func (s *Server) handleBrowse(w http.ResponseWriter, r *http.Request) {
 		return json.Marshal(toBrowseResponse(result))
 	})
 	if err != nil {
+		if s.handledError(w, err) {
+			return
+		}
 		// Auth errors should not serve stale data — the user needs to reconnect.
 		if isUpstreamAuthError(err) {
 			writeError(w, http.StatusUnauthorized, "DoltHub credentials expired — please reconnect.")
@@ -101,7 +124,7 @@ func (s *Server) handleDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	id := r.PathValue("id")
-	key := client.RigHandle() + ":" + id
+	key := cacheScope(r, client) + ":" + id
 	data, err := s.detailCache.GetOrFetch(key, func() ([]byte, error) {
 		result, err := client.Detail(id)
 		if err != nil {
@@ -110,6 +133,9 @@ func (s *Server) handleDetail(w http.ResponseWriter, r *http.Request) {
 		return json.Marshal(toDetailResponse(result, client.Mode()))
 	})
 	if err != nil {
+		if s.handledError(w, err) {
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			writeError(w, http.StatusNotFound, err.Error())
 			return
@@ -163,7 +189,7 @@ func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 	data, err := client.Dashboard()
 	if err != nil {
-		writeUpstreamError(w, err, "dashboard")
+		s.writeUpstreamError(w, err, "dashboard")
 		return
 	}
 	writeJSON(w, http.StatusOK, toDashboardResponse(data))
@@ -174,15 +200,164 @@ func (s *Server) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-	limit := parseIntParam(r, "limit", 20)
-	entries, err := client.Leaderboard(limit)
+	f := commons.LeaderboardFilter{
+		Limit:   parseIntParam(r, "limit", 20),
+		Project: r.URL.Query().Get("project"),
+		Skill:   r.URL.Query().Get("skill"),
+	}
+	if since := r.URL.Query().Get("since"); since != "" {
+		d, err := commons.ParsePeriod(since)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		f.Since = d
+	}
+	entries, err := client.Leaderboard(f)
 	if err != nil {
-		writeUpstreamError(w, err, "leaderboard")
+		s.writeUpstreamError(w, err, "leaderboard")
 		return
 	}
 	writeJSON(w, http.StatusOK, toLeaderboardResponse(entries))
 }
 
+func (s *Server) handleRigBadges(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	handle := r.PathValue("handle")
+	badges, err := client.Badges(handle)
+	if err != nil {
+		s.writeUpstreamError(w, err, "badges")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRigBadgesResponse(handle, badges))
+}
+
+func (s *Server) handleBadgeCatalog(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	catalog, err := client.BadgeCatalog()
+	if err != nil {
+		s.writeUpstreamError(w, err, "badge catalog")
+		return
+	}
+	writeJSON(w, http.StatusOK, toBadgeCatalogResponse(catalog))
+}
+
+func (s *Server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	f := commons.AuditFilter{
+		WantedID: q.Get("item"),
+		Rig:      q.Get("rig"),
+		Limit:    parseIntParam(r, "limit", 50),
+		Offset:   parseIntParam(r, "offset", 0),
+	}
+	if since := q.Get("since"); since != "" {
+		t, err := parseSince(since, time.Now())
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		f.Since = t
+	}
+	page, err := client.Audit(f)
+	if err != nil {
+		s.writeUpstreamError(w, err, "audit")
+		return
+	}
+	writeJSON(w, http.StatusOK, toAuditResponse(page))
+}
+
+// handleSearch serves GET /api/search?q=...&type=wanted,stamp&limit=N:
+// wanted items, completions and stamps matching q, best first.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f := commons.SearchFilter{Query: q.Get("q"), Limit: parseIntParam(r, "limit", 20)}
+	if len(commons.SearchTerms(f.Query)) == 0 {
+		writeError(w, http.StatusBadRequest, "q parameter is required")
+		return
+	}
+	for _, v := range q["type"] {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t == "" {
+				continue
+			}
+			if !commons.ValidSearchType(t) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid type %q: want %s", t, strings.Join(commons.SearchTypes, ", ")))
+				return
+			}
+			f.Types = append(f.Types, t)
+		}
+	}
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	results, err := client.Search(f)
+	if err != nil {
+		s.writeUpstreamError(w, err, "search")
+		return
+	}
+	writeJSON(w, http.StatusOK, toSearchResponse(f.Query, results))
+}
+
+// parseSince reads a ?since= value: a period back from now such as "7d",
+// an RFC 3339 timestamp, or a YYYY-MM-DD date.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := commons.ParsePeriod(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid since %q: use a period like 7d, an RFC 3339 time or a YYYY-MM-DD date", s)
+}
+
+func (s *Server) handleTags(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	reg, err := client.Tags()
+	if err != nil {
+		s.writeUpstreamError(w, err, "tags")
+		return
+	}
+	writeJSON(w, http.StatusOK, toTagsResponse(reg))
+}
+
+func (s *Server) handleVocabulary(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	v := client.Vocabulary()
+	writeJSON(w, http.StatusOK, VocabularyResponse{Types: v.Types, Efforts: v.Efforts})
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	projects, err := client.Projects()
+	if err != nil {
+		s.writeUpstreamError(w, err, "projects")
+		return
+	}
+	writeJSON(w, http.StatusOK, toProjectsResponse(projects))
+}
+
 func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 	client, ok := s.resolveClient(w, r)
 	if !ok {
@@ -191,6 +366,7 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 	resp := ConfigResponse{
 		RigHandle: client.RigHandle(),
 		Mode:      client.Mode(),
+		ReadOnly:  client.ReadOnly(),
 		Hosted:    s.hosted,
 		Connected: s.hosted, // in hosted mode, reaching this handler means connected
 	}
@@ -212,29 +388,36 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Include active upstream from header if present.
-	if upstream := r.Header.Get("X-Wasteland"); upstream != "" {
-		resp.Upstream = upstream
-	}
+	// Include the active upstream: the header if present, else the default.
+	resp.Upstream = requestUpstream(r, s.defaultUpstream)
 
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// cacheScope prefixes read-cache keys so entries are never shared across
+// rigs or across wastelands served by the same Server.
+func cacheScope(r *http.Request, client *sdk.Client) string {
+	return client.RigHandle() + "@" + r.Header.Get("X-Wasteland")
+}
+
 // canonicalBrowseKey produces a stable cache key from the known browse filter
 // query params. url.Values.Encode() sorts keys alphabetically.
 func canonicalBrowseKey(r *http.Request) string {
 	q := r.URL.Query()
 	canon := url.Values{}
-	for _, k := range []string{"status", "type", "priority", "project", "search", "sort", "limit", "view", "long"} {
+	for _, k := range []string{"status", "type", "priority", "project", "search", "sort", "limit", "view", "long", "q"} {
 		if v := q.Get(k); v != "" {
 			canon.Set(k, v)
 		}
 	}
+	if tags := q["tag"]; len(tags) > 0 {
+		canon["tag"] = tags
+	}
 	return canon.Encode()
 }
 
 // invalidateReadCaches busts browse and detail caches after a mutation.
-// Detail cache keys are prefixed with RigHandle (e.g. "rig:itemID"), so
+// Detail cache keys are prefixed with cacheScope (e.g. "rig@org/db:itemID"), so
 // we invalidate the entire detail cache to cover all user-specific entries.
 func (s *Server) invalidateReadCaches(_ string) {
 	s.browseCache.Invalidate()
@@ -270,7 +453,12 @@ func isTokenPermissionError(err error) bool {
 // writeUpstreamError classifies DoltHub errors and writes an appropriate response:
 //   - "invalid authorization" → 401 (triggers frontend re-auth)
 //   - other upstream errors → 503 with sanitized message + Sentry capture
-func writeUpstreamError(w http.ResponseWriter, err error, label string) {
+//
+// Errors the error handler answers are not classified.
+func (s *Server) writeUpstreamError(w http.ResponseWriter, err error, label string) {
+	if s.handledError(w, err) {
+		return
+	}
 	if isUpstreamAuthError(err) {
 		writeError(w, http.StatusUnauthorized, "DoltHub credentials expired — please reconnect.")
 		return
@@ -286,14 +474,27 @@ func writeUpstreamError(w http.ResponseWriter, err error, label string) {
 	writeError(w, http.StatusServiceUnavailable, msg)
 }
 
-// writeMutationError writes a 409 for ConflictError, 400 for everything else.
-func writeMutationError(w http.ResponseWriter, err error) {
+// writeMutationError writes a 409 for ConflictError, 403 for ReadOnlyError,
+// 400 for everything else, unless the error handler answers err.
+func (s *Server) writeMutationError(w http.ResponseWriter, err error) {
+	if s.handledError(w, err) {
+		return
+	}
+	status, msg := mutationErrorStatus(err)
+	writeError(w, status, msg)
+}
+
+// mutationErrorStatus maps a mutation error to its HTTP status and message.
+func mutationErrorStatus(err error) (int, string) {
 	var conflict *commons.ConflictError
 	if errors.As(err, &conflict) {
-		writeError(w, http.StatusConflict, conflict.Message)
-		return
+		return http.StatusConflict, conflict.Message
 	}
-	writeError(w, http.StatusBadRequest, err.Error())
+	var readOnly *commons.ReadOnlyError
+	if errors.As(err, &readOnly) {
+		return http.StatusForbidden, readOnly.Error()
+	}
+	return http.StatusBadRequest, err.Error()
 }
 
 // --- Mutation handlers ---
@@ -322,7 +523,7 @@ func (s *Server) handlePost(w http.ResponseWriter, r *http.Request) {
 		Tags:        req.Tags,
 	})
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.browseCache.Invalidate()
@@ -355,7 +556,7 @@ func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	}
 	result, err := client.Update(id, fields)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -370,7 +571,7 @@ func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	result, err := client.Delete(id)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -385,7 +586,7 @@ func (s *Server) handleClaim(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	result, err := client.Claim(id)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -400,7 +601,42 @@ func (s *Server) handleUnclaim(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	result, err := client.Unclaim(id)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
+		return
+	}
+	s.invalidateReadCaches(id)
+	writeJSON(w, http.StatusOK, toMutationResponse(result, client.Mode()))
+}
+
+func (s *Server) handleQueue(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	var req QueueRequest
+	if err := decodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	result, err := client.Queue(id, req.AutoClaim)
+	if err != nil {
+		s.writeMutationError(w, err)
+		return
+	}
+	s.invalidateReadCaches(id)
+	writeJSON(w, http.StatusOK, toMutationResponse(result, client.Mode()))
+}
+
+func (s *Server) handleUnqueue(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	result, err := client.Unqueue(id)
+	if err != nil {
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -418,13 +654,24 @@ func (s *Server) handleDone(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
 		return
 	}
-	if req.Evidence == "" {
+	var result *sdk.MutationResult
+	var err error
+	switch {
+	case len(req.Entries) > 0:
+		entries := commons.ParseEvidenceList(req.Entries)
+		if verr := commons.ValidateEvidence(entries); verr != nil {
+			writeError(w, http.StatusBadRequest, verr.Error())
+			return
+		}
+		result, err = client.DoneEvidence(id, entries)
+	case req.Evidence == "":
 		writeError(w, http.StatusBadRequest, "evidence is required")
 		return
+	default:
+		result, err = client.Done(id, req.Evidence)
 	}
-	result, err := client.Done(id, req.Evidence)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -442,15 +689,19 @@ func (s *Server) handleAccept(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
 		return
 	}
-	result, err := client.Accept(id, sdk.AcceptInput{
+	input := sdk.AcceptInput{
 		Quality:     req.Quality,
 		Reliability: req.Reliability,
 		Severity:    req.Severity,
 		SkillTags:   req.SkillTags,
 		Message:     req.Message,
-	})
+	}
+	if req.FollowUpTitle != "" {
+		input.FollowUp = &sdk.FollowUpInput{Title: req.FollowUpTitle, Description: req.FollowUpDescription}
+	}
+	result, err := client.Accept(id, input)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -480,7 +731,7 @@ func (s *Server) handleAcceptUpstream(w http.ResponseWriter, r *http.Request) {
 		Message:     req.Message,
 	})
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -503,7 +754,7 @@ func (s *Server) handleRejectUpstream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := client.RejectUpstream(id, req.RigHandle); err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -527,7 +778,7 @@ func (s *Server) handleCloseUpstream(w http.ResponseWriter, r *http.Request) {
 	}
 	result, err := client.CloseUpstream(id, req.RigHandle)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -547,7 +798,7 @@ func (s *Server) handleReject(w http.ResponseWriter, r *http.Request) {
 	}
 	result, err := client.Reject(id, req.Reason)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
@@ -562,13 +813,85 @@ func (s *Server) handleClose(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	result, err := client.Close(id)
 	if err != nil {
-		writeMutationError(w, err)
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateReadCaches(id)
 	writeJSON(w, http.StatusOK, toMutationResponse(result, client.Mode()))
 }
 
+// maxBulkItems caps the number of items in one bulk request.
+const maxBulkItems = 100
+
+// handleBulk applies {id, action} pairs with one SDK Bulk call per action,
+// in the order each action first appears, and reports every item's outcome.
+// Item failures do not fail the request; only a malformed request does.
+func (s *Server) handleBulk(w http.ResponseWriter, r *http.Request) {
+	client, ok := s.resolveClient(w, r)
+	if !ok {
+		return
+	}
+	var req BulkRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if len(req.Items) == 0 {
+		writeError(w, http.StatusBadRequest, "items is required")
+		return
+	}
+	if len(req.Items) > maxBulkItems {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("too many items (max %d)", maxBulkItems))
+		return
+	}
+
+	// Group item indexes by action, keeping first-seen action order.
+	var actions []string
+	byAction := make(map[string][]int)
+	for i, item := range req.Items {
+		if item.ID == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("items[%d]: id is required", i))
+			return
+		}
+		if !slices.Contains(sdk.BulkActions(), item.Action) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("items[%d]: unsupported action %q (valid: %s)",
+				i, item.Action, strings.Join(sdk.BulkActions(), ", ")))
+			return
+		}
+		if _, seen := byAction[item.Action]; !seen {
+			actions = append(actions, item.Action)
+		}
+		byAction[item.Action] = append(byAction[item.Action], i)
+	}
+
+	results := make([]BulkItemResult, len(req.Items))
+	for _, action := range actions {
+		indexes := byAction[action]
+		ids := make([]string, len(indexes))
+		for j, i := range indexes {
+			ids[j] = req.Items[i].ID
+		}
+		outcomes, err := client.Bulk(action, ids)
+		if err != nil {
+			s.writeMutationError(w, err)
+			return
+		}
+		for j, i := range indexes {
+			res := BulkItemResult{ID: ids[j], Action: action}
+			if oc := outcomes[j]; oc.Err != nil {
+				res.Status, res.Error = mutationErrorStatus(oc.Err)
+			} else {
+				res.OK = true
+				res.Result = toMutationResponse(oc.Result, client.Mode())
+			}
+			results[i] = res
+		}
+	}
+
+	s.invalidateAllCaches()
+	writeJSON(w, http.StatusOK, BulkResponse{Results: results})
+}
+
 // --- Branch handlers ---
 
 func (s *Server) handleApplyBranch(w http.ResponseWriter, r *http.Request) {
@@ -578,7 +901,7 @@ func (s *Server) handleApplyBranch(w http.ResponseWriter, r *http.Request) {
 	}
 	branch := r.PathValue("branch")
 	if err := client.ApplyBranch(branch); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateAllCaches()
@@ -592,7 +915,7 @@ func (s *Server) handleDiscardBranch(w http.ResponseWriter, r *http.Request) {
 	}
 	branch := r.PathValue("branch")
 	if err := client.DiscardBranch(branch); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		s.writeMutationError(w, err)
 		return
 	}
 	s.invalidateAllCaches()
@@ -607,7 +930,7 @@ func (s *Server) handleSubmitPR(w http.ResponseWriter, r *http.Request) {
 	branch := r.PathValue("branch")
 	url, err := client.SubmitPR(branch)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		s.writeMutationError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, PRResponse{URL: url})
@@ -644,7 +967,7 @@ func (s *Server) handleSaveSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := client.SaveSettings(req.Mode, req.Signing); err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		s.writeMutationError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "saved"})
@@ -656,6 +979,9 @@ func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := client.Sync(); err != nil {
+		if s.handledError(w, err) {
+			return
+		}
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
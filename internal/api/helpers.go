@@ -42,8 +42,10 @@ func parseIntParam(r *http.Request, key string, defaultVal int) int {
 	return v
 }
 
-// parseQueryFilter extracts browse filter parameters from the request query string.
-func parseQueryFilter(r *http.Request) commons.BrowseFilter {
+// parseQueryFilter extracts browse filter parameters from the request query
+// string. A ?q= filter expression (see commons.ParseFilterExpr) is applied on
+// top of the individual parameters; a malformed one is an error.
+func parseQueryFilter(r *http.Request) (commons.BrowseFilter, error) {
 	q := r.URL.Query()
 
 	sort := commons.SortPriority
@@ -59,7 +61,7 @@ func parseQueryFilter(r *http.Request) commons.BrowseFilter {
 		view = "all"
 	}
 
-	return commons.BrowseFilter{
+	f := commons.BrowseFilter{
 		Status:   q.Get("status"),
 		Project:  q.Get("project"),
 		Type:     q.Get("type"),
@@ -70,4 +72,15 @@ func parseQueryFilter(r *http.Request) commons.BrowseFilter {
 		View:     view,
 		Long:     q.Get("long") == "true",
 	}
+	for _, tag := range q["tag"] {
+		if tag != "" {
+			f.Tags = append(f.Tags, tag)
+		}
+	}
+	if expr := q.Get("q"); expr != "" {
+		if err := commons.ParseFilterExpr(expr, &f); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
 }
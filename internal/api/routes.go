@@ -8,13 +8,23 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("GET /api/dashboard", s.handleDashboard)
 	s.mux.HandleFunc("GET /api/config", s.handleConfig)
 	s.mux.HandleFunc("GET /api/leaderboard", s.handleLeaderboard)
+	s.mux.HandleFunc("GET /api/badges", s.handleBadgeCatalog)
+	s.mux.HandleFunc("GET /api/rigs/{handle}/badges", s.handleRigBadges)
+	s.mux.HandleFunc("GET /api/tags", s.handleTags)
+	s.mux.HandleFunc("GET /api/vocabulary", s.handleVocabulary)
+	s.mux.HandleFunc("GET /api/projects", s.handleProjects)
+	s.mux.HandleFunc("GET /api/audit", s.handleAudit)
+	s.mux.HandleFunc("GET /api/search", s.handleSearch)
 
 	// Mutation endpoints.
 	s.mux.HandleFunc("POST /api/wanted", s.handlePost)
+	s.mux.HandleFunc("POST /api/wanted/bulk", s.handleBulk)
 	s.mux.HandleFunc("PATCH /api/wanted/{id}", s.handleUpdate)
 	s.mux.HandleFunc("DELETE /api/wanted/{id}", s.handleDelete)
 	s.mux.HandleFunc("POST /api/wanted/{id}/claim", s.handleClaim)
 	s.mux.HandleFunc("POST /api/wanted/{id}/unclaim", s.handleUnclaim)
+	s.mux.HandleFunc("POST /api/wanted/{id}/queue", s.handleQueue)
+	s.mux.HandleFunc("DELETE /api/wanted/{id}/queue", s.handleUnqueue)
 	s.mux.HandleFunc("POST /api/wanted/{id}/done", s.handleDone)
 	s.mux.HandleFunc("POST /api/wanted/{id}/accept", s.handleAccept)
 	s.mux.HandleFunc("POST /api/wanted/{id}/accept-upstream", s.handleAcceptUpstream)
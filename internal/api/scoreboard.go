@@ -155,12 +155,17 @@ func (s *Server) handleScoreboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if s.scoreboard == nil {
+	caches, ok := s.scoreboardsFor(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no scoreboard for this wasteland")
+		return
+	}
+	if caches.Scoreboard == nil {
 		writeError(w, http.StatusServiceUnavailable, "scoreboard not configured")
 		return
 	}
 
-	data := s.scoreboard.Get()
+	data := caches.Scoreboard.Get()
 	if data == nil {
 		writeError(w, http.StatusServiceUnavailable, "scoreboard data unavailable")
 		return
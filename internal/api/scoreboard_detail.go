@@ -77,12 +77,17 @@ func (s *Server) handleScoreboardDetail(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if s.scoreboardDetail == nil {
+	caches, ok := s.scoreboardsFor(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no scoreboard detail for this wasteland")
+		return
+	}
+	if caches.Detail == nil {
 		writeError(w, http.StatusServiceUnavailable, "scoreboard detail not configured")
 		return
 	}
 
-	data := s.scoreboardDetail.Get()
+	data := caches.Detail.Get()
 	if data == nil {
 		writeError(w, http.StatusServiceUnavailable, "scoreboard detail data unavailable")
 		return
@@ -40,12 +40,17 @@ func (s *Server) handleScoreboardDump(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if s.scoreboardDump == nil {
+	caches, ok := s.scoreboardsFor(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "no scoreboard dump for this wasteland")
+		return
+	}
+	if caches.Dump == nil {
 		writeError(w, http.StatusServiceUnavailable, "scoreboard dump not configured")
 		return
 	}
 
-	data := s.scoreboardDump.Get()
+	data := caches.Dump.Get()
 	if data == nil {
 		writeError(w, http.StatusServiceUnavailable, "scoreboard dump data unavailable")
 		return
@@ -27,11 +27,14 @@ type Server struct {
 	scoreboard       *CachedEndpoint
 	scoreboardDetail *CachedEndpoint
 	scoreboardDump   *CachedEndpoint
-	publicClient     *sdk.Client // anonymous fallback for public reads (hosted mode)
-	browseCache      *ReadCache  // keyed by canonicalized query string
-	detailCache      *ReadCache  // keyed by item ID
+	wastelandBoards  map[string]ScoreboardCaches // per-upstream scoreboards (NewWorkspace)
+	publicClient     *sdk.Client                 // anonymous fallback for public reads (hosted mode)
+	defaultUpstream  string                      // wasteland used when a request names none (NewWorkspace)
+	browseCache      *ReadCache                  // keyed by canonicalized query string
+	detailCache      *ReadCache                  // keyed by item ID
 	mux              *http.ServeMux
 	hosted           bool // true when running in multi-tenant hosted mode
+	errorHandler     func(w http.ResponseWriter, err error) bool
 }
 
 // New creates a Server backed by the given SDK client.
@@ -104,6 +107,46 @@ func (s *Server) SetScoreboardDump(ce *CachedEndpoint) {
 	s.scoreboardDump = ce
 }
 
+// ScoreboardCaches are the cached scoreboard endpoints for one wasteland.
+type ScoreboardCaches struct {
+	Scoreboard *CachedEndpoint
+	Detail     *CachedEndpoint
+	Dump       *CachedEndpoint
+}
+
+// SetWastelandScoreboard sets the scoreboard caches for one served
+// wasteland. Once any is set, scoreboard requests are answered from the
+// caches of the wasteland they name (see NewWorkspace), and requests for a
+// wasteland without caches get a 404 instead of another wasteland's data.
+func (s *Server) SetWastelandScoreboard(upstream string, c ScoreboardCaches) {
+	if s.wastelandBoards == nil {
+		s.wastelandBoards = make(map[string]ScoreboardCaches)
+	}
+	s.wastelandBoards[upstream] = c
+}
+
+// scoreboardsFor returns the scoreboard caches serving r, and false if r
+// names a wasteland that has none.
+func (s *Server) scoreboardsFor(r *http.Request) (ScoreboardCaches, bool) {
+	if s.wastelandBoards == nil {
+		return ScoreboardCaches{Scoreboard: s.scoreboard, Detail: s.scoreboardDetail, Dump: s.scoreboardDump}, true
+	}
+	c, ok := s.wastelandBoards[requestUpstream(r, s.defaultUpstream)]
+	return c, ok
+}
+
+// SetErrorHandler installs fn to answer a request's error before the API's
+// own mapping; fn returns false to leave err to the API. Hosted mode uses
+// it to answer a DoltHub quota that ran out mid-request with a 429.
+func (s *Server) SetErrorHandler(fn func(w http.ResponseWriter, err error) bool) {
+	s.errorHandler = fn
+}
+
+// handledError reports whether the error handler answered err.
+func (s *Server) handledError(w http.ResponseWriter, err error) bool {
+	return s.errorHandler != nil && s.errorHandler(w, err)
+}
+
 // SetPublicClient sets an anonymous SDK client for unauthenticated public reads.
 func (s *Server) SetPublicClient(c *sdk.Client) {
 	s.publicClient = c
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -53,6 +55,10 @@ func (f *fakeDB) Query(sql, ref string) (string, error) {
 	}
 
 	switch {
+	case strings.Contains(sql, " UNION ALL "):
+		return f.queryUnion(sql, ref)
+	case strings.Contains(sql, "FROM wanted") && strings.Contains(sql, "WHERE id IN"):
+		return f.queryIn(sql, ref), nil
 	case strings.Contains(sql, "FROM wanted") && strings.Contains(sql, "WHERE id"):
 		return f.queryByID(sql, ref)
 	case strings.Contains(sql, "FROM wanted"):
@@ -76,6 +82,21 @@ func (f *fakeDB) Query(sql, ref string) (string, error) {
 	}
 }
 
+// queryIn answers a batched "WHERE id IN (...)" read of wanted rows.
+func (f *fakeDB) queryIn(sql, ref string) string {
+	_, list, _ := strings.Cut(sql, "IN (")
+	list, _, _ = strings.Cut(list, ")")
+	var b strings.Builder
+	b.WriteString("id,title,project,type,priority,posted_by,claimed_by,status,effort_level\n")
+	for _, id := range strings.Split(list, ",") {
+		if it := f.resolve(strings.Trim(strings.TrimSpace(id), "'"), ref); it != nil {
+			fmt.Fprintf(&b, "%s,%s,%s,%s,%d,%s,%s,%s,%s\n",
+				it.id, it.title, it.project, it.typ, it.priority, it.postedBy, it.claimedBy, it.status, it.effortLevel)
+		}
+	}
+	return b.String()
+}
+
 func (f *fakeDB) queryByID(sql, ref string) (string, error) { //nolint:unparam // error return needed by caller
 	id := extractVal(sql, "id='")
 	item := f.resolve(id, ref)
@@ -120,6 +141,30 @@ func (f *fakeDB) queryBrowse(sql, ref string) (string, error) { //nolint:unparam
 	return hdr + "\n" + strings.Join(rows, "\n") + "\n", nil
 }
 
+// queryUnion answers the dashboard's UNION ALL of tagged queries by running
+// each wanted part and prefixing its rows with the section literal. The
+// up-next, stamp and badge parts come back empty.
+func (f *fakeDB) queryUnion(sql, ref string) (string, error) { //nolint:unparam // error return needed by caller
+	header := "section,id,title,project,type,priority,posted_by,claimed_by,status,effort_level"
+	var rows []string
+	for _, part := range strings.Split(sql, " UNION ALL ") {
+		part = strings.TrimSuffix(strings.TrimPrefix(part, "("), ")")
+		section := part[len("SELECT '"):strings.Index(part, "' AS section")]
+		if section == "up_next" || section == "stamp_totals" || section == "stamp" || section == "badge" {
+			continue
+		}
+		out, _ := f.queryBrowse(part, ref)
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		for _, line := range lines[1:] {
+			rows = append(rows, section+","+line)
+		}
+	}
+	if len(rows) == 0 {
+		return header + "\n", nil
+	}
+	return header + "\n" + strings.Join(rows, "\n") + "\n", nil
+}
+
 func (f *fakeDB) queryCompletion(sql string) (string, error) { //nolint:unparam // error return needed by caller
 	wid := extractVal(sql, "wanted_id='")
 	cid, ok := f.completions[wid]
@@ -136,7 +181,7 @@ func (f *fakeDB) detailCSV(it *fakeItem) string {
 	return hdr + "\n" + row + "\n"
 }
 
-func (f *fakeDB) Exec(branch, _ string, _ bool, stmts ...string) error {
+func (f *fakeDB) Exec(branch, _ string, _ commons.Signing, stmts ...string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -357,6 +402,59 @@ func TestBrowse(t *testing.T) {
 	}
 }
 
+func TestBrowseStream(t *testing.T) {
+	db := newFakeDB()
+	db.items["w-1"] = &fakeItem{id: "w-1", title: "Fix bug", status: "open", priority: 1, postedBy: "alice", effortLevel: "medium"}
+	db.items["w-2"] = &fakeItem{id: "w-2", title: "Add feature", status: "open", priority: 2, postedBy: "alice", effortLevel: "medium"}
+
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	tests := []struct {
+		format      string
+		contentType string
+		wantLines   int
+		wantInBody  string
+	}{
+		{"ndjson", "application/x-ndjson", 2, `"id":"w-1"`},
+		{"csv", "text/csv; charset=utf-8", 3, "w-2,Add feature,"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + "/api/wanted?status=open&format=" + tt.format)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close() //nolint:errcheck // test cleanup
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d: %s", resp.StatusCode, body)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.contentType)
+			}
+			lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+			if len(lines) != tt.wantLines {
+				t.Errorf("got %d lines, want %d:\n%s", len(lines), tt.wantLines, body)
+			}
+			if !strings.Contains(string(body), tt.wantInBody) {
+				t.Errorf("body missing %s:\n%s", tt.wantInBody, body)
+			}
+		})
+	}
+}
+
+func TestBrowseStream_BadFormat(t *testing.T) {
+	ts := newTestServer(newFakeDB(), "wild-west")
+	defer ts.Close()
+
+	var resp ErrorResponse
+	r := getJSON(t, ts, "/api/wanted?format=xml", &resp)
+	if r.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", r.StatusCode)
+	}
+}
+
 func TestBrowseWithFilter(t *testing.T) {
 	db := newFakeDB()
 	db.items["w-1"] = &fakeItem{id: "w-1", title: "Fix bug", status: "open", priority: 1, effortLevel: "medium"}
@@ -378,6 +476,102 @@ func TestBrowseWithFilter(t *testing.T) {
 	}
 }
 
+func TestBrowseWithFilterExpr(t *testing.T) {
+	db := newFakeDB()
+	db.items["w-1"] = &fakeItem{id: "w-1", title: "Fix bug", status: "open", priority: 1, postedBy: "alice", effortLevel: "medium"}
+	db.items["w-2"] = &fakeItem{id: "w-2", title: "Add feature", status: "claimed", priority: 2, claimedBy: "bob", postedBy: "alice", effortLevel: "medium"}
+
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp BrowseResponse
+	r := getJSON(t, ts, "/api/wanted?q="+url.QueryEscape("status:claimed posted_by:alice"), &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].ID != "w-2" {
+		t.Fatalf("expected only w-2, got %+v", resp.Items)
+	}
+
+	var errResp ErrorResponse
+	r = getJSON(t, ts, "/api/wanted?q="+url.QueryEscape("color:red"), &errResp)
+	if r.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad q: status = %d, want 400", r.StatusCode)
+	}
+	if !strings.Contains(errResp.Error, "unknown filter key") {
+		t.Errorf("bad q: error = %q", errResp.Error)
+	}
+}
+
+func TestTags(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"FROM tags":   "tag,aliases,description\ngo,\"[\"\"golang\"\"]\",The Go language\n",
+		"strict_tags": "value\ntrue\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp TagsResponse
+	r := getJSON(t, ts, "/api/tags", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if !resp.Strict || len(resp.Tags) != 1 || resp.Tags[0].Tag != "go" || resp.Tags[0].Aliases[0] != "golang" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	var errResp ErrorResponse
+	r = postJSON(t, ts, "/api/wanted", `{"title":"Rusty","priority":2,"effort_level":"small","tags":["rust"]}`, &errResp)
+	if r.StatusCode != http.StatusBadRequest || !strings.Contains(errResp.Error, "unknown tag") {
+		t.Errorf("strict post: status %d, error %q", r.StatusCode, errResp.Error)
+	}
+}
+
+func TestTags_NoRegistry(t *testing.T) {
+	ts := newTestServer(newFakeDB(), "wild-west")
+	defer ts.Close()
+
+	var resp TagsResponse
+	r := getJSON(t, ts, "/api/tags", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if resp.Tags == nil || len(resp.Tags) != 0 || resp.Strict {
+		t.Errorf("response = %+v, want empty tag list", resp)
+	}
+}
+
+func TestVocabulary(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"item_types": "key,value\nitem_types,\"[\"\"feature\"\",\"\"chore\"\"]\"\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp VocabularyResponse
+	r := getJSON(t, ts, "/api/vocabulary", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if !slices.Equal(resp.Types, []string{"feature", "chore"}) || len(resp.Efforts) == 0 {
+		t.Errorf("response = %+v, want the wasteland's types and the default efforts", resp)
+	}
+}
+
+func TestCanonicalBrowseKey_Tags(t *testing.T) {
+	a := canonicalBrowseKey(httptest.NewRequest(http.MethodGet, "/api/wanted?tag=go&status=open&tag=docs", nil))
+	b := canonicalBrowseKey(httptest.NewRequest(http.MethodGet, "/api/wanted?status=open&tag=go&tag=docs", nil))
+	c := canonicalBrowseKey(httptest.NewRequest(http.MethodGet, "/api/wanted?status=open&tag=go", nil))
+	if a != b {
+		t.Errorf("keys differ for the same filter: %q vs %q", a, b)
+	}
+	if a == c {
+		t.Errorf("tag filters should be part of the key: %q", a)
+	}
+}
+
 func TestDetail(t *testing.T) {
 	db := newFakeDB()
 	db.items["w-1"] = &fakeItem{id: "w-1", title: "Fix bug", status: "open", priority: 1, postedBy: "bob", effortLevel: "medium"}
@@ -433,6 +627,38 @@ func TestDashboard(t *testing.T) {
 	}
 }
 
+func TestDashboard_Reputation(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"UNION ALL": "section,id,title,project,type,priority,posted_by,claimed_by,status,effort_level," +
+			"stamp_count,quality,reliability,author,severity,skill_tags,message,created_at,badge_type\n" +
+			"stamp_totals,,,,,0,,,,,2,4.5,4,,,,,,\n" +
+			"stamp,,,,,0,,,,,,5,4,bob,leaf,,nice,2026-03-02,\n",
+	}
+
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp DashboardResponse
+	r := getJSON(t, ts, "/api/dashboard", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	rep := resp.Reputation
+	if rep == nil {
+		t.Fatal("expected reputation")
+	}
+	if rep.StampCount != 2 || rep.AvgQuality != 4.5 || rep.AvgReliability != 4 {
+		t.Errorf("reputation totals = %+v", rep)
+	}
+	if len(rep.RecentStamps) != 1 || rep.RecentStamps[0].Author != "bob" {
+		t.Errorf("recent stamps = %+v", rep.RecentStamps)
+	}
+	if rep.Badges == nil {
+		t.Error("badges should be an empty list, not null")
+	}
+}
+
 func TestConfig(t *testing.T) {
 	db := newFakeDB()
 	ts := newTestServer(db, "pr")
@@ -471,6 +697,25 @@ func TestClaim(t *testing.T) {
 	}
 }
 
+func TestErrorHandler(t *testing.T) {
+	client := sdk.New(sdk.ClientConfig{DB: newFakeDB(), RigHandle: "alice", Mode: "wild-west"})
+	srv := New(client)
+	var handled error
+	srv.SetErrorHandler(func(w http.ResponseWriter, err error) bool {
+		handled = err
+		writeError(w, http.StatusTooManyRequests, "quota")
+		return true
+	})
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	var resp ErrorResponse
+	r := postJSON(t, ts, "/api/wanted/w-404/claim", "", &resp)
+	if r.StatusCode != http.StatusTooManyRequests || handled == nil {
+		t.Errorf("status = %d, handled = %v; want the error handler to answer", r.StatusCode, handled)
+	}
+}
+
 func TestUnclaim(t *testing.T) {
 	db := newFakeDB()
 	db.items["w-1"] = &fakeItem{id: "w-1", title: "Fix bug", status: "claimed", claimedBy: "alice", postedBy: "bob", effortLevel: "medium"}
@@ -635,6 +880,123 @@ func TestLeaderboard(t *testing.T) {
 	}
 }
 
+func TestLeaderboard_Since(t *testing.T) {
+	db := newFakeDB()
+	db.leaderboardCSV = "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,5,4.2,3.8,3.0\n"
+
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp LeaderboardResponse
+	r := getJSON(t, ts, "/api/leaderboard?since=30d&project=gastown", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	// The fake answers both periods alike, so alice held rank 1.
+	if len(resp.Entries) != 1 || resp.Entries[0].Rank != 1 || resp.Entries[0].PrevRank != 1 {
+		t.Errorf("entries = %+v", resp.Entries)
+	}
+
+	r = getJSON(t, ts, "/api/leaderboard?since=soon", &resp)
+	if r.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad since: expected 400, got %d", r.StatusCode)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"GROUP BY project, status":           "project,status,n,last_activity\ngt,open,2,2026-03-01 10:00:00\ngt,claimed,1,2026-03-02 10:00:00\n",
+		"GROUP BY w.project, c.completed_by": "project,completed_by,n\ngt,bob,4\n",
+		"ORDER BY updated_at DESC":           "id,title,project,status,updated_at\nw-1,Fix bug,gt,claimed,2026-03-02 10:00:00\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp ProjectsResponse
+	r := getJSON(t, ts, "/api/projects", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if len(resp.Projects) != 1 {
+		t.Fatalf("expected 1 project, got %+v", resp.Projects)
+	}
+	p := resp.Projects[0]
+	if p.Project != "gt" || p.Open != 2 || p.Claimed != 1 || p.LastActivity != "2026-03-02T10:00:00Z" {
+		t.Errorf("project = %+v", p)
+	}
+	if len(p.TopContributors) != 1 || p.TopContributors[0].RigHandle != "bob" || p.TopContributors[0].Completions != 4 {
+		t.Errorf("top contributors = %+v", p.TopContributors)
+	}
+	if len(p.Recent) != 1 || p.Recent[0].ID != "w-1" || p.Recent[0].UpdatedAt == "" {
+		t.Errorf("recent = %+v", p.Recent)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"FROM dolt_log": "commit_hash,rig_handle,date,message\nc2,alice,2026-03-02 10:00:00,wl claim: w-1\nc1,bob,2026-03-01 10:00:00,wl post: w-1\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp AuditResponse
+	r := getJSON(t, ts, "/api/audit?item=w-1&since=2026-03-01&limit=1", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if len(resp.Entries) != 1 || resp.NextOffset != 1 {
+		t.Fatalf("response = %+v, want 1 entry and next_offset 1", resp)
+	}
+	e := resp.Entries[0]
+	if e.RigHandle != "alice" || e.Action != "claim" || e.WantedID != "w-1" || e.Date != "2026-03-02T10:00:00Z" {
+		t.Errorf("entry = %+v", e)
+	}
+
+	r = getJSON(t, ts, "/api/audit?since=lately", &resp)
+	if r.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad since: expected 400, got %d", r.StatusCode)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"FROM wanted w":      "id,title,description,tags,status\nw-1,Fix auth refresh,,,open\n",
+		"FROM completions c": "id,wanted_id,completed_by,evidence,title\n",
+		"FROM stamps s":      "id,author,message,skills,wanted_id,title\ns-1,alice,nice auth work,,w-1,Fix auth refresh\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp SearchResponse
+	r := getJSON(t, ts, "/api/search?q=auth", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("results = %+v, want 2", resp.Results)
+	}
+	if first := resp.Results[0]; first.Type != "wanted" || first.ID != "w-1" || first.Field != "title" {
+		t.Errorf("first result = %+v", first)
+	}
+	if second := resp.Results[1]; second.Type != "stamp" || second.WantedID != "w-1" || second.Rig != "alice" {
+		t.Errorf("second result = %+v", second)
+	}
+
+	r = getJSON(t, ts, "/api/search?q=auth&type=stamp", &resp)
+	if r.StatusCode != http.StatusOK || len(resp.Results) != 1 || resp.Results[0].Type != "stamp" {
+		t.Errorf("type=stamp: status %d, results %+v", r.StatusCode, resp.Results)
+	}
+
+	for _, path := range []string{"/api/search", "/api/search?q=auth&type=rigs"} {
+		if r := getJSON(t, ts, path, &resp); r.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, r.StatusCode)
+		}
+	}
+}
+
 func TestLeaderboard_Empty(t *testing.T) {
 	db := newFakeDB()
 	ts := newTestServer(db, "wild-west")
@@ -663,6 +1025,58 @@ func TestLeaderboard_InvalidLimit(t *testing.T) {
 	}
 }
 
+func TestRigBadges(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"rig_handle = 'bob'": "id,rig_handle,badge_type,awarded_at,evidence\nb-1,bob,mentor,2026-03-02 10:00:00,helped three newcomers\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp RigBadgesResponse
+	r := getJSON(t, ts, "/api/rigs/bob/badges", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if resp.RigHandle != "bob" || len(resp.Badges) != 1 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if b := resp.Badges[0]; b.BadgeType != "mentor" || b.Reason != "helped three newcomers" {
+		t.Errorf("badge = %+v", b)
+	}
+
+	var empty RigBadgesResponse
+	getJSON(t, ts, "/api/rigs/carol/badges", &empty)
+	if empty.Badges == nil || len(empty.Badges) != 0 {
+		t.Errorf("badges for carol = %#v, want empty list", empty.Badges)
+	}
+}
+
+func TestBadgeCatalog(t *testing.T) {
+	db := newFakeDB()
+	db.results = map[string]string{
+		"badge_catalog":              "value\n\"{\"\"mentor\"\":\"\"Helped three newcomers\"\"}\"\n",
+		"COUNT(DISTINCT rig_handle)": "badge_type,holders,last_awarded\nbug-hunter,1,2026-02-10 09:00:00\nmentor,2,2026-03-02 10:00:00\n",
+	}
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp BadgeCatalogResponse
+	r := getJSON(t, ts, "/api/badges", &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if len(resp.Badges) != 2 {
+		t.Fatalf("badges = %+v", resp.Badges)
+	}
+	if b := resp.Badges[0]; b.Type != "bug-hunter" || b.Criteria != "" || b.Holders != 1 {
+		t.Errorf("badges[0] = %+v", b)
+	}
+	if b := resp.Badges[1]; b.Type != "mentor" || b.Criteria != "Helped three newcomers" || b.Holders != 2 {
+		t.Errorf("badges[1] = %+v", b)
+	}
+}
+
 func TestAcceptUpstream_Handler(t *testing.T) {
 	db := newFakeDB()
 	db.items["w-1"] = &fakeItem{id: "w-1", title: "Fix bug", status: "open", priority: 1, postedBy: "bob", effortLevel: "medium"}
@@ -731,3 +1145,76 @@ func TestAcceptUpstream_Handler_NotFound(t *testing.T) {
 		t.Fatalf("expected 400, got %d", r.StatusCode)
 	}
 }
+
+func TestBulk(t *testing.T) {
+	db := newFakeDB()
+	db.items["w-1"] = &fakeItem{id: "w-1", title: "One", status: "open", priority: 1, postedBy: "bob", effortLevel: "medium"}
+	db.items["w-3"] = &fakeItem{id: "w-3", title: "Three", status: "in_review", claimedBy: "bob", postedBy: "alice", effortLevel: "medium"}
+
+	ts := newTestServer(db, "wild-west")
+	defer ts.Close()
+
+	var resp BulkResponse
+	r := postJSON(t, ts, "/api/wanted/bulk", `{"items":[
+		{"id":"w-1","action":"claim"},
+		{"id":"w-3","action":"close"},
+		{"id":"w-404","action":"claim"}
+	]}`, &resp)
+	if r.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", r.StatusCode)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.Results))
+	}
+
+	// Results come back in request order even though claims run together.
+	want := []struct {
+		id, action, status string
+		ok                 bool
+		code               int
+	}{
+		{"w-1", "claim", "claimed", true, 0},
+		{"w-3", "close", "completed", true, 0},
+		{"w-404", "claim", "", false, http.StatusBadRequest},
+	}
+	for i, w := range want {
+		got := resp.Results[i]
+		if got.ID != w.id || got.Action != w.action || got.OK != w.ok || got.Status != w.code {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, w)
+			continue
+		}
+		if w.ok && (got.Result == nil || got.Result.Detail.Item.Status != w.status) {
+			t.Errorf("results[%d] status = %+v, want %s", i, got.Result, w.status)
+		}
+		if !w.ok && got.Error == "" {
+			t.Errorf("results[%d] has no error message", i)
+		}
+	}
+}
+
+func TestBulk_BadRequest(t *testing.T) {
+	ts := newTestServer(newFakeDB(), "wild-west")
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid JSON", `{`},
+		{"no items", `{"items":[]}`},
+		{"missing id", `{"items":[{"action":"claim"}]}`},
+		{"unsupported action", `{"items":[{"id":"w-1","action":"done"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ErrorResponse
+			r := postJSON(t, ts, "/api/wanted/bulk", tt.body, &resp)
+			if r.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", r.StatusCode)
+			}
+			if resp.Error == "" {
+				t.Error("expected error message")
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"io/fs"
 	"net/http"
 	"strings"
@@ -8,6 +10,12 @@ import (
 
 // SPAHandler serves static files from the embedded filesystem with
 // index.html fallback for client-side routing. API routes are excluded.
+//
+// Embedded files have no modification time, so every file gets a content
+// ETag and revalidation of index.html is a cheap 304. Missing files under
+// /assets/ are a 404 rather than the index fallback, so a stale page asking
+// for a chunk from a previous deploy fails loudly instead of parsing HTML
+// as JavaScript.
 func SPAHandler(apiHandler http.Handler, assets fs.FS) http.Handler {
 	// Try to access the dist directory from the embed.
 	distFS, err := fs.Sub(assets, "dist")
@@ -22,6 +30,7 @@ func SPAHandler(apiHandler http.Handler, assets fs.FS) http.Handler {
 	}
 
 	fileServer := http.FileServer(http.FS(distFS))
+	etags := assetETags(distFS)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// API routes go to the API handler.
@@ -41,13 +50,24 @@ func SPAHandler(apiHandler http.Handler, assets fs.FS) http.Handler {
 		if err == nil {
 			_ = f.Close()
 			setCacheHeaders(w, path)
+			if etag, ok := etags[path]; ok {
+				w.Header().Set("ETag", etag)
+			}
 			fileServer.ServeHTTP(w, r)
 			return
 		}
 
+		if strings.HasPrefix(path, "/assets/") {
+			http.NotFound(w, r)
+			return
+		}
+
 		// Fallback to index.html for client-side routing.
 		// No cache — must revalidate to pick up new deploys.
 		w.Header().Set("Cache-Control", "no-cache")
+		if etag, ok := etags["/index.html"]; ok {
+			w.Header().Set("ETag", etag)
+		}
 		r.URL.Path = "/"
 		fileServer.ServeHTTP(w, r)
 	})
@@ -65,6 +85,25 @@ func setCacheHeaders(w http.ResponseWriter, path string) {
 	}
 }
 
+// assetETags hashes every file in the built UI once at startup, keyed by
+// URL path.
+func assetETags(distFS fs.FS) map[string]string {
+	etags := make(map[string]string)
+	_ = fs.WalkDir(distFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		data, err := fs.ReadFile(distFS, name)
+		if err != nil {
+			return nil
+		}
+		sum := sha256.Sum256(data)
+		etags["/"+name] = `"` + hex.EncodeToString(sum[:8]) + `"`
+		return nil
+	})
+	return etags
+}
+
 // spaFallback returns a handler that serves the API and shows a "not built"
 // message for all non-API routes.
 func spaFallback(apiHandler http.Handler) http.Handler {
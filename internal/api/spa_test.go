@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestSPA() http.Handler {
+	assets := fstest.MapFS{
+		"dist/index.html":         {Data: []byte("<!doctype html><div id=root></div>")},
+		"dist/assets/app-1a2b.js": {Data: []byte("console.log('app')")},
+		"dist/favicon.svg":        {Data: []byte("<svg/>")},
+	}
+	api := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	return SPAHandler(api, assets)
+}
+
+func TestSPAHandler(t *testing.T) {
+	h := newTestSPA()
+
+	tests := []struct {
+		name      string
+		path      string
+		wantCode  int
+		wantCache string
+	}{
+		{"api passthrough", "/api/browse", http.StatusTeapot, ""},
+		{"index", "/", http.StatusOK, "no-cache"},
+		{"hashed asset", "/assets/app-1a2b.js", http.StatusOK, "public, max-age=31536000, immutable"},
+		{"root file", "/favicon.svg", http.StatusOK, "no-cache"},
+		{"client route", "/wanted/w-abc123", http.StatusOK, "no-cache"},
+		{"missing asset", "/assets/app-old.js", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.wantCache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCache)
+			}
+		})
+	}
+}
+
+func TestSPAHandler_IndexRevalidates(t *testing.T) {
+	h := newTestSPA()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/wanted/w-abc123", nil))
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("index fallback has no ETag")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("revalidation status = %d, want 304", rec.Code)
+	}
+}
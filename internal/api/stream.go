@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// streamFormats maps the browse ?format= values that stream rows to their
+// content types. Streamed responses bypass the browse cache.
+var streamFormats = map[string]string{
+	"ndjson": "application/x-ndjson",
+	"csv":    "text/csv; charset=utf-8",
+}
+
+// streamBrowse writes the browse result one row at a time as NDJSON or CSV,
+// flushing as it goes. Headers are sent with the first row, so a query that
+// fails up front still gets a proper error status; a failure mid-stream can
+// only truncate the response.
+func (s *Server) streamBrowse(w http.ResponseWriter, client *sdk.Client, filter commons.BrowseFilter, format string) {
+	rc := http.NewResponseController(w)
+
+	var (
+		started bool
+		enc     = json.NewEncoder(w)
+		cw      = csv.NewWriter(w)
+		rows    int
+	)
+	start := func() {
+		started = true
+		w.Header().Set("Content-Type", streamFormats[format])
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if format == "csv" {
+			_ = cw.Write(browseCSVHeader(filter.Long))
+		}
+	}
+
+	err := client.BrowseEach(filter, func(row sdk.BrowseRow) error {
+		if !started {
+			start()
+		}
+		var err error
+		if format == "csv" {
+			err = cw.Write(browseCSVRecord(row, filter.Long))
+		} else {
+			err = enc.Encode(toSummaryJSON(row.Item, row.Pending, row.UpstreamPending))
+		}
+		if err != nil {
+			return err
+		}
+		if rows++; rows%100 == 0 {
+			cw.Flush()
+			_ = rc.Flush()
+		}
+		return nil
+	})
+	if err != nil && !started {
+		if s.handledError(w, err) {
+			return
+		}
+		if isUpstreamAuthError(err) {
+			writeError(w, http.StatusUnauthorized, "DoltHub credentials expired — please reconnect.")
+			return
+		}
+		slog.Error("streaming browse failed", "error", err)
+		writeError(w, http.StatusServiceUnavailable, "Upstream database is temporarily unavailable — please try again in a moment.")
+		return
+	}
+	if err != nil {
+		slog.Warn("streaming browse aborted", "rows", rows, "error", err)
+		return
+	}
+	if !started {
+		start()
+	}
+	cw.Flush()
+}
+
+func browseCSVHeader(long bool) []string {
+	if long {
+		return []string{"id", "title", "description", "project", "type", "priority", "posted_by", "claimed_by", "status", "effort_level", "pending_count"}
+	}
+	return []string{"id", "title", "project", "type", "priority", "posted_by", "claimed_by", "status", "effort_level", "pending_count"}
+}
+
+func browseCSVRecord(row sdk.BrowseRow, long bool) []string {
+	it := row.Item
+	if long {
+		return []string{it.ID, it.Title, it.Description, it.Project, it.Type, strconv.Itoa(it.Priority),
+			it.PostedBy, it.ClaimedBy, it.Status, it.EffortLevel, strconv.Itoa(row.Pending)}
+	}
+	return []string{it.ID, it.Title, it.Project, it.Type, strconv.Itoa(it.Priority),
+		it.PostedBy, it.ClaimedBy, it.Status, it.EffortLevel, strconv.Itoa(row.Pending)}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/sdk"
 )
@@ -50,10 +52,25 @@ type WantedItemJSON struct {
 	ClaimedBy   string   `json:"claimed_by,omitempty"`
 	Status      string   `json:"status"`
 	EffortLevel string   `json:"effort_level"`
+	ParentID    string   `json:"parent_id,omitempty"`
 	CreatedAt   string   `json:"created_at,omitempty"`
 	UpdatedAt   string   `json:"updated_at,omitempty"`
 }
 
+// EvidenceJSON is one typed completion evidence entry.
+type EvidenceJSON struct {
+	Kind     string `json:"kind"`
+	Value    string `json:"value"`
+	Verified bool   `json:"verified,omitempty"` // checked to exist at submission
+}
+
+// QueueEntryJSON is one rig waiting in an item's claim queue.
+type QueueEntryJSON struct {
+	RigHandle string `json:"rig_handle"`
+	AutoClaim bool   `json:"auto_claim,omitempty"`
+	QueuedAt  string `json:"queued_at,omitempty"`
+}
+
 // CompletionJSON is the JSON representation of a completion record.
 type CompletionJSON struct {
 	ID          string `json:"id"`
@@ -105,6 +122,11 @@ type DetailResponse struct {
 	BranchActions []string         `json:"branch_actions"`
 	Mode          string           `json:"mode"`
 	UpstreamPRs   []UpstreamPRJSON `json:"upstream_prs,omitempty"`
+	Quorum        int              `json:"quorum,omitempty"`     // accepts needed to complete (> 1 only)
+	Approvals     []string         `json:"approvals,omitempty"`  // rigs that have accepted toward Quorum
+	FollowUps     []WantedItemJSON `json:"follow_ups,omitempty"` // items posted by partial accepts
+	Evidence      []EvidenceJSON   `json:"evidence,omitempty"`   // typed completion evidence
+	Queue         []QueueEntryJSON `json:"queue,omitempty"`      // rigs waiting for the claim, first in line first
 }
 
 // MutationResponse is the JSON response for mutation endpoints.
@@ -116,9 +138,20 @@ type MutationResponse struct {
 
 // DashboardResponse is the JSON response for GET /api/dashboard.
 type DashboardResponse struct {
-	Claimed   []WantedSummaryJSON `json:"claimed"`
-	InReview  []WantedSummaryJSON `json:"in_review"`
-	Completed []WantedSummaryJSON `json:"completed"`
+	Claimed    []WantedSummaryJSON `json:"claimed"`
+	InReview   []WantedSummaryJSON `json:"in_review"`
+	Completed  []WantedSummaryJSON `json:"completed"`
+	UpNext     []WantedSummaryJSON `json:"up_next"`
+	Reputation *ReputationJSON     `json:"reputation,omitempty"`
+}
+
+// ReputationJSON is the JSON representation of a rig's stamps and badges.
+type ReputationJSON struct {
+	StampCount     int                   `json:"stamp_count"`
+	AvgQuality     float64               `json:"avg_quality"`
+	AvgReliability float64               `json:"avg_reliability"`
+	RecentStamps   []commons.StampDetail `json:"recent_stamps"`
+	Badges         []commons.BadgeDetail `json:"badges"`
 }
 
 // UpstreamInfoJSON is the JSON representation of an upstream in the config response.
@@ -133,6 +166,7 @@ type UpstreamInfoJSON struct {
 type ConfigResponse struct {
 	RigHandle string             `json:"rig_handle"`
 	Mode      string             `json:"mode"`
+	ReadOnly  bool               `json:"read_only,omitempty"`
 	Hosted    bool               `json:"hosted,omitempty"`
 	Connected bool               `json:"connected,omitempty"`
 	Upstream  string             `json:"upstream,omitempty"`
@@ -140,13 +174,19 @@ type ConfigResponse struct {
 }
 
 // LeaderboardEntryJSON is the JSON representation of a leaderboard entry.
+// PrevRank and RankDelta are only set for ?since= queries; a zero
+// prev_rank means the rig is new to the leaderboard this period.
 type LeaderboardEntryJSON struct {
 	RigHandle     string   `json:"rig_handle"`
+	Rank          int      `json:"rank"`
 	Completions   int      `json:"completions"`
 	AvgQuality    float64  `json:"avg_quality"`
 	AvgReliab     float64  `json:"avg_reliability"`
 	AvgCreativity float64  `json:"avg_creativity"`
 	TopSkills     []string `json:"top_skills,omitempty"`
+	Badges        []string `json:"badges,omitempty"`
+	PrevRank      int      `json:"prev_rank,omitempty"`
+	RankDelta     int      `json:"rank_delta,omitempty"`
 }
 
 // LeaderboardResponse is the JSON response for GET /api/leaderboard.
@@ -154,6 +194,118 @@ type LeaderboardResponse struct {
 	Entries []LeaderboardEntryJSON `json:"entries"`
 }
 
+// BadgeJSON is the JSON representation of one awarded badge.
+type BadgeJSON struct {
+	ID        string `json:"id"`
+	BadgeType string `json:"badge_type"`
+	AwardedAt string `json:"awarded_at"`
+	Reason    string `json:"reason"`
+}
+
+// RigBadgesResponse is the JSON response for GET /api/rigs/{handle}/badges.
+// Badges are newest first.
+type RigBadgesResponse struct {
+	RigHandle string      `json:"rig_handle"`
+	Badges    []BadgeJSON `json:"badges"`
+}
+
+// BadgeCatalogEntryJSON is the JSON representation of one badge type.
+// Criteria is empty for types awarded without a badge_catalog entry.
+type BadgeCatalogEntryJSON struct {
+	Type        string `json:"type"`
+	Criteria    string `json:"criteria,omitempty"`
+	Holders     int    `json:"holders"`
+	LastAwarded string `json:"last_awarded,omitempty"`
+}
+
+// BadgeCatalogResponse is the JSON response for GET /api/badges.
+type BadgeCatalogResponse struct {
+	Badges []BadgeCatalogEntryJSON `json:"badges"`
+}
+
+// ProjectSummaryJSON is the JSON representation of one project's summary.
+// Project is "" for items without a project.
+type ProjectSummaryJSON struct {
+	Project         string                `json:"project"`
+	Open            int                   `json:"open"`
+	Claimed         int                   `json:"claimed"`
+	InReview        int                   `json:"in_review"`
+	Completed       int                   `json:"completed"`
+	TopContributors []ContributorJSON     `json:"top_contributors"`
+	Recent          []ProjectActivityJSON `json:"recent"`
+	LastActivity    string                `json:"last_activity,omitempty"`
+}
+
+// ContributorJSON is a rig's validated completion count within a project.
+type ContributorJSON struct {
+	RigHandle   string `json:"rig_handle"`
+	Completions int    `json:"completions"`
+}
+
+// ProjectActivityJSON is a recently updated item of a project.
+type ProjectActivityJSON struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// ProjectsResponse is the JSON response for GET /api/projects.
+type ProjectsResponse struct {
+	Projects []ProjectSummaryJSON `json:"projects"`
+}
+
+// AuditEntryJSON is one commit of the audit log. Action and WantedID are
+// "" for commits not made by a wl mutation.
+type AuditEntryJSON struct {
+	CommitHash string `json:"commit_hash"`
+	RigHandle  string `json:"rig_handle"`
+	Action     string `json:"action,omitempty"`
+	WantedID   string `json:"wanted_id,omitempty"`
+	Message    string `json:"message"`
+	Date       string `json:"date,omitempty"`
+}
+
+// AuditResponse is the JSON response for GET /api/audit. NextOffset is
+// the offset of the next page, omitted on the last page.
+type AuditResponse struct {
+	Entries    []AuditEntryJSON `json:"entries"`
+	NextOffset int              `json:"next_offset,omitempty"`
+}
+
+// SearchResultJSON is one result of GET /api/search. Type is "wanted",
+// "completion" or "stamp"; WantedID links every type to its item.
+type SearchResultJSON struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	WantedID string `json:"wanted_id,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Rig      string `json:"rig,omitempty"`
+	Field    string `json:"field"`
+	Snippet  string `json:"snippet,omitempty"`
+	Score    int    `json:"score"`
+}
+
+// SearchResponse is the JSON response for GET /api/search.
+type SearchResponse struct {
+	Query   string             `json:"query"`
+	Results []SearchResultJSON `json:"results"`
+}
+
+// TagsResponse is the JSON response for GET /api/tags.
+type TagsResponse struct {
+	Tags   []commons.TagDef `json:"tags"`
+	Strict bool             `json:"strict"`
+}
+
+// VocabularyResponse is the JSON response for GET /api/vocabulary: the
+// item types and effort levels the wasteland accepts.
+type VocabularyResponse struct {
+	Types   []string `json:"types"`
+	Efforts []string `json:"efforts"`
+}
+
 // ErrorResponse is the JSON error envelope.
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -184,9 +336,17 @@ type UpdateRequest struct {
 	TagsSet     bool     `json:"tags_set"`
 }
 
+// QueueRequest is the optional JSON body for POST /api/wanted/{id}/queue.
+type QueueRequest struct {
+	AutoClaim bool `json:"auto_claim"` // take the claim automatically when it is released
+}
+
 // DoneRequest is the JSON body for POST /api/wanted/{id}/done.
 type DoneRequest struct {
 	Evidence string `json:"evidence"`
+	// Entries submits several evidence entries, typed like 'wl done
+	// --evidence'. When set, Evidence is ignored.
+	Entries []string `json:"entries,omitempty"`
 }
 
 // AcceptRequest is the JSON body for POST /api/wanted/{id}/accept.
@@ -196,6 +356,9 @@ type AcceptRequest struct {
 	Severity    string   `json:"severity"`
 	SkillTags   []string `json:"skill_tags"`
 	Message     string   `json:"message"`
+	// FollowUpTitle makes the accept partial, posting a linked follow-up item.
+	FollowUpTitle       string `json:"follow_up_title,omitempty"`
+	FollowUpDescription string `json:"follow_up_description,omitempty"`
 }
 
 // AcceptUpstreamRequest is the JSON body for POST /api/wanted/{id}/accept-upstream.
@@ -223,6 +386,34 @@ type RejectRequest struct {
 	Reason string `json:"reason"`
 }
 
+// BulkRequest is the JSON body for POST /api/wanted/bulk.
+type BulkRequest struct {
+	Items []BulkItemRequest `json:"items"`
+}
+
+// BulkItemRequest is one {id, action} pair in a bulk request.
+type BulkItemRequest struct {
+	ID     string `json:"id"`
+	Action string `json:"action"`
+}
+
+// BulkResponse is the JSON response for POST /api/wanted/bulk. Results are
+// in request order.
+type BulkResponse struct {
+	Results []BulkItemResult `json:"results"`
+}
+
+// BulkItemResult is the outcome of one item in a bulk request. On failure,
+// Status is the HTTP status the single-item endpoint would have returned.
+type BulkItemResult struct {
+	ID     string            `json:"id"`
+	Action string            `json:"action"`
+	OK     bool              `json:"ok"`
+	Status int               `json:"status,omitempty"`
+	Error  string            `json:"error,omitempty"`
+	Result *MutationResponse `json:"result,omitempty"`
+}
+
 // SettingsRequest is the JSON body for PUT /api/settings.
 type SettingsRequest struct {
 	Mode    string `json:"mode"`
@@ -257,6 +448,7 @@ func toWantedItemJSON(item *commons.WantedItem) *WantedItemJSON {
 		ClaimedBy:   item.ClaimedBy,
 		Status:      item.Status,
 		EffortLevel: item.EffortLevel,
+		ParentID:    item.ParentID,
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
 	}
@@ -362,7 +554,45 @@ func toDetailResponse(d *sdk.DetailResult, mode string) *DetailResponse {
 		BranchActions: d.BranchActions,
 		Mode:          mode,
 		UpstreamPRs:   upstreamPRs,
+		Quorum:        d.Quorum,
+		Approvals:     commons.Approvers(d.Approvals),
+		FollowUps:     toFollowUpsJSON(d.FollowUps),
+		Evidence:      toEvidenceJSON(d.Evidence),
+		Queue:         toQueueJSON(d.Queue),
+	}
+}
+
+func toQueueJSON(queue []commons.QueueEntry) []QueueEntryJSON {
+	if len(queue) == 0 {
+		return nil
+	}
+	out := make([]QueueEntryJSON, len(queue))
+	for i, e := range queue {
+		out[i] = QueueEntryJSON{RigHandle: e.RigHandle, AutoClaim: e.AutoClaim, QueuedAt: e.QueuedAt}
+	}
+	return out
+}
+
+func toEvidenceJSON(entries []commons.Evidence) []EvidenceJSON {
+	if len(entries) == 0 {
+		return nil
 	}
+	out := make([]EvidenceJSON, len(entries))
+	for i, e := range entries {
+		out[i] = EvidenceJSON{Kind: e.Kind, Value: e.Value, Verified: e.Verified}
+	}
+	return out
+}
+
+func toFollowUpsJSON(items []commons.WantedItem) []WantedItemJSON {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]WantedItemJSON, len(items))
+	for i := range items {
+		out[i] = *toWantedItemJSON(&items[i])
+	}
+	return out
 }
 
 func toMutationResponse(r *sdk.MutationResult, mode string) *MutationResponse {
@@ -410,21 +640,124 @@ func toBrowseResponse(r *sdk.BrowseResult) *BrowseResponse {
 	return &BrowseResponse{Items: items}
 }
 
+func toTagsResponse(reg *commons.TagRegistry) *TagsResponse {
+	tags := reg.Defs
+	if tags == nil {
+		tags = []commons.TagDef{}
+	}
+	return &TagsResponse{Tags: tags, Strict: reg.Strict}
+}
+
+func toProjectsResponse(projects []commons.ProjectSummary) *ProjectsResponse {
+	out := make([]ProjectSummaryJSON, len(projects))
+	for i, p := range projects {
+		pj := ProjectSummaryJSON{
+			Project:         p.Project,
+			Open:            p.Open,
+			Claimed:         p.Claimed,
+			InReview:        p.InReview,
+			Completed:       p.Completed,
+			TopContributors: make([]ContributorJSON, len(p.TopContributors)),
+			Recent:          make([]ProjectActivityJSON, len(p.Recent)),
+			LastActivity:    formatTime(p.LastActivity),
+		}
+		for j, c := range p.TopContributors {
+			pj.TopContributors[j] = ContributorJSON{RigHandle: c.RigHandle, Completions: c.Completions}
+		}
+		for j, a := range p.Recent {
+			pj.Recent[j] = ProjectActivityJSON{ID: a.ID, Title: a.Title, Status: a.Status, UpdatedAt: formatTime(a.UpdatedAt)}
+		}
+		out[i] = pj
+	}
+	return &ProjectsResponse{Projects: out}
+}
+
+func toAuditResponse(page *commons.AuditPage) *AuditResponse {
+	entries := make([]AuditEntryJSON, len(page.Entries))
+	for i, e := range page.Entries {
+		entries[i] = AuditEntryJSON{
+			CommitHash: e.CommitHash,
+			RigHandle:  e.RigHandle,
+			Action:     e.Action,
+			WantedID:   e.WantedID,
+			Message:    e.Message,
+			Date:       formatTime(e.Date),
+		}
+	}
+	return &AuditResponse{Entries: entries, NextOffset: page.NextOffset}
+}
+
+func toSearchResponse(query string, results []commons.SearchResult) *SearchResponse {
+	out := make([]SearchResultJSON, len(results))
+	for i, r := range results {
+		out[i] = SearchResultJSON{
+			Type:     r.Type,
+			ID:       r.ID,
+			WantedID: r.WantedID,
+			Title:    r.Title,
+			Status:   r.Status,
+			Rig:      r.Rig,
+			Field:    r.Field,
+			Snippet:  r.Snippet,
+			Score:    r.Score,
+		}
+	}
+	return &SearchResponse{Query: query, Results: out}
+}
+
+// formatTime renders t as RFC 3339 in UTC, or "" when t is zero.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func toLeaderboardResponse(entries []commons.LeaderboardEntry) *LeaderboardResponse {
 	items := make([]LeaderboardEntryJSON, len(entries))
 	for i, e := range entries {
 		items[i] = LeaderboardEntryJSON{
 			RigHandle:     e.RigHandle,
+			Rank:          e.Rank,
 			Completions:   e.Completions,
 			AvgQuality:    e.AvgQuality,
 			AvgReliab:     e.AvgReliab,
 			AvgCreativity: e.AvgCreativity,
 			TopSkills:     e.TopSkills,
+			Badges:        e.Badges,
+			PrevRank:      e.PrevRank,
+			RankDelta:     e.RankDelta(),
 		}
 	}
 	return &LeaderboardResponse{Entries: items}
 }
 
+func toRigBadgesResponse(handle string, badges []commons.BadgeRow) *RigBadgesResponse {
+	items := make([]BadgeJSON, len(badges))
+	for i, b := range badges {
+		items[i] = BadgeJSON{
+			ID:        b.ID,
+			BadgeType: b.BadgeType,
+			AwardedAt: b.AwardedAt,
+			Reason:    b.Evidence,
+		}
+	}
+	return &RigBadgesResponse{RigHandle: handle, Badges: items}
+}
+
+func toBadgeCatalogResponse(catalog []commons.BadgeCatalogEntry) *BadgeCatalogResponse {
+	items := make([]BadgeCatalogEntryJSON, len(catalog))
+	for i, e := range catalog {
+		items[i] = BadgeCatalogEntryJSON{
+			Type:        e.Type,
+			Criteria:    e.Criteria,
+			Holders:     e.Holders,
+			LastAwarded: e.LastAwarded,
+		}
+	}
+	return &BadgeCatalogResponse{Badges: items}
+}
+
 func toDashboardResponse(d *commons.DashboardData) *DashboardResponse {
 	convert := func(items []commons.WantedSummary) []WantedSummaryJSON {
 		result := make([]WantedSummaryJSON, len(items))
@@ -433,9 +766,26 @@ func toDashboardResponse(d *commons.DashboardData) *DashboardResponse {
 		}
 		return result
 	}
-	return &DashboardResponse{
+	resp := &DashboardResponse{
 		Claimed:   convert(d.Claimed),
 		InReview:  convert(d.InReview),
 		Completed: convert(d.Completed),
+		UpNext:    convert(d.UpNext),
+	}
+	if r := d.Reputation; r != nil {
+		resp.Reputation = &ReputationJSON{
+			StampCount:     r.StampCount,
+			AvgQuality:     r.AvgQuality,
+			AvgReliability: r.AvgReliability,
+			RecentStamps:   r.RecentStamps,
+			Badges:         r.Badges,
+		}
+		if resp.Reputation.RecentStamps == nil {
+			resp.Reputation.RecentStamps = []commons.StampDetail{}
+		}
+		if resp.Reputation.Badges == nil {
+			resp.Reputation.Badges = []commons.BadgeDetail{}
+		}
 	}
+	return resp
 }
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/federation"
+	"github.com/gastownhall/wasteland/internal/pile"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// wastelandPathPrefix is the path prefix that selects a wasteland by URL,
+// e.g. /w/hop/wl-commons/api/wanted.
+const wastelandPathPrefix = "/w/"
+
+// errUnknownWasteland is returned when a request names a wasteland that is
+// not joined; resolveClient reports it as a 400 rather than a 401.
+var errUnknownWasteland = errors.New("unknown wasteland")
+
+// NewWorkspace creates a self-sovereign Server that exposes every client in
+// ws. Requests pick a wasteland with the X-Wasteland header (as in hosted
+// mode) or a /w/{org}/{db} path prefix (see WastelandPrefix); requests
+// naming neither use defaultUpstream.
+func NewWorkspace(ws *sdk.Workspace, defaultUpstream string) *Server {
+	s := &Server{
+		clientFunc: func(r *http.Request) (*sdk.Client, error) {
+			upstream := requestUpstream(r, defaultUpstream)
+			client, err := ws.Client(upstream)
+			if err != nil {
+				return nil, fmt.Errorf("%w %q", errUnknownWasteland, upstream)
+			}
+			return client, nil
+		},
+		workspaceFunc: func(_ *http.Request) (*sdk.Workspace, error) {
+			return ws, nil
+		},
+		defaultUpstream: defaultUpstream,
+		browseCache:     NewReadCache(30*time.Second, 64),
+		detailCache:     NewReadCache(30*time.Second, 256),
+		mux:             http.NewServeMux(),
+	}
+	s.pile = pile.NewDefault()
+	s.registerRoutes()
+	return s
+}
+
+// requestUpstream returns the wasteland named by the X-Wasteland header, or
+// fallback when the header is absent.
+func requestUpstream(r *http.Request, fallback string) string {
+	if upstream := r.Header.Get("X-Wasteland"); upstream != "" {
+		return upstream
+	}
+	return fallback
+}
+
+// WastelandPrefix rewrites /w/{org}/{db}/... requests to /... with the
+// X-Wasteland header set to org/db, so a wasteland can be selected by URL
+// (bookmarks, curl) as well as by header. Other requests pass through
+// unchanged. A prefix whose org/db is malformed gets a 400.
+func WastelandPrefix(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, wastelandPathPrefix)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		org, rest, _ := strings.Cut(rest, "/")
+		db, rest, _ := strings.Cut(rest, "/")
+		upstream := org + "/" + db
+		if _, _, err := federation.ParseUpstream(upstream); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid wasteland path, expected /w/{org}/{db}/...")
+			return
+		}
+
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = "/" + rest
+		r2.URL.RawPath = ""
+		r2.Header.Set("X-Wasteland", upstream)
+		next.ServeHTTP(w, r2)
+	})
+}
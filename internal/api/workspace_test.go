@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+func newTestWorkspaceServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	commonsDB := newFakeDB()
+	commonsDB.items["w-1"] = &fakeItem{id: "w-1", title: "Commons item", status: "open", priority: 1, effortLevel: "medium"}
+	gasDB := newFakeDB()
+	gasDB.items["w-9"] = &fakeItem{id: "w-9", title: "Gastown item", status: "open", priority: 2, effortLevel: "medium"}
+
+	ws := sdk.NewWorkspace("alice")
+	ws.Add(sdk.UpstreamInfo{Upstream: "hop/wl-commons", Mode: "wild-west"}, newTestClient(commonsDB))
+	ws.Add(sdk.UpstreamInfo{Upstream: "gastown/wl", Mode: "wild-west"}, newTestClient(gasDB))
+
+	ts := httptest.NewServer(WastelandPrefix(NewWorkspace(ws, "hop/wl-commons")))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestWorkspace_RoutesByHeaderAndPrefix(t *testing.T) {
+	ts := newTestWorkspaceServer(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		wantID string
+	}{
+		{"default", "/api/wanted", "", "w-1"},
+		{"header", "/api/wanted", "gastown/wl", "w-9"},
+		{"path prefix", "/w/gastown/wl/api/wanted", "", "w-9"},
+		{"path prefix wins over header", "/w/hop/wl-commons/api/wanted", "gastown/wl", "w-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, ts.URL+tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("X-Wasteland", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close() //nolint:errcheck // test cleanup
+			var body BrowseResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.StatusCode != http.StatusOK || len(body.Items) != 1 || body.Items[0].ID != tt.wantID {
+				t.Errorf("status %d, items %+v, want only %s", resp.StatusCode, body.Items, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestWorkspace_Config(t *testing.T) {
+	ts := newTestWorkspaceServer(t)
+
+	var resp ConfigResponse
+	getJSON(t, ts, "/w/gastown/wl/api/config", &resp)
+	if resp.Upstream != "gastown/wl" {
+		t.Errorf("Upstream = %q, want gastown/wl", resp.Upstream)
+	}
+	if len(resp.Upstreams) != 2 || resp.Upstreams[0].Upstream != "gastown/wl" {
+		t.Errorf("Upstreams = %+v, want both joined wastelands", resp.Upstreams)
+	}
+
+	getJSON(t, ts, "/api/config", &resp)
+	if resp.Upstream != "hop/wl-commons" {
+		t.Errorf("default Upstream = %q, want hop/wl-commons", resp.Upstream)
+	}
+}
+
+func TestWorkspace_UnknownOrMalformedWasteland(t *testing.T) {
+	ts := newTestWorkspaceServer(t)
+
+	for _, path := range []string{"/w/nobody/here/api/wanted", "/w/onlyorg"} {
+		var resp ErrorResponse
+		r := getJSON(t, ts, path, &resp)
+		if r.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET %s: status = %d, want 400", path, r.StatusCode)
+		}
+	}
+}
+
+func TestWorkspace_PerWastelandScoreboards(t *testing.T) {
+	ws := sdk.NewWorkspace("alice")
+	srv := NewWorkspace(ws, "hop/wl-commons")
+	for upstream, body := range map[string]string{"hop/wl-commons": `{"board":"commons"}`, "gastown/wl": `{"board":"gastown"}`} {
+		ce := NewCachedEndpoint(func() ([]byte, error) { return []byte(body), nil }, time.Hour)
+		ce.refresh()
+		srv.SetWastelandScoreboard(upstream, ScoreboardCaches{Scoreboard: ce})
+	}
+	ts := httptest.NewServer(WastelandPrefix(srv))
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBoard  string
+	}{
+		{"/api/scoreboard", http.StatusOK, "commons"},
+		{"/w/gastown/wl/api/scoreboard", http.StatusOK, "gastown"},
+		{"/w/someone/else/api/scoreboard", http.StatusNotFound, ""},
+		{"/w/gastown/wl/api/scoreboard/dump", http.StatusServiceUnavailable, ""},
+	}
+	for _, tt := range tests {
+		var resp struct {
+			Board string `json:"board"`
+		}
+		r := getJSON(t, ts, tt.path, &resp)
+		if r.StatusCode != tt.wantStatus || resp.Board != tt.wantBoard {
+			t.Errorf("GET %s: status %d, board %q; want %d, %q", tt.path, r.StatusCode, resp.Board, tt.wantStatus, tt.wantBoard)
+		}
+	}
+}
@@ -4,7 +4,11 @@
 // parsers in commons work unchanged.
 package backend
 
-import "io"
+import (
+	"io"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
 
 // DB abstracts SQL execution against a dolt database.
 type DB interface {
@@ -17,7 +21,7 @@ type DB interface {
 	// branch: "" = main, "name" = named branch (created from main if needed).
 	// Callers pass pure DML only — no DOLT_ADD or DOLT_COMMIT.
 	// The implementation handles commit semantics internally.
-	Exec(branch, commitMsg string, signed bool, stmts ...string) error
+	Exec(branch, commitMsg string, sign commons.Signing, stmts ...string) error
 
 	// Branches returns branch names matching prefix.
 	Branches(prefix string) ([]string, error)
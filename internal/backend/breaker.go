@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// Circuit breaker defaults for RemoteDB.
+const (
+	breakerThreshold = 5                // consecutive failures that open the circuit
+	breakerCooldown  = 30 * time.Second // how long an open circuit fails fast
+)
+
+// circuitBreaker stops RemoteDB from waiting on timeouts against a DoltHub
+// that is down. After threshold consecutive network failures it opens:
+// calls fail immediately with *commons.UnavailableError until cooldown
+// passes. Then one trial call is let through; success closes the circuit,
+// failure reopens it for another cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool  // a half-open trial call is in flight
+	lastErr   error // the failure that opened the circuit
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns nil if a call may proceed, or an *commons.UnavailableError
+// while the circuit is open.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return nil
+	}
+	now := b.now()
+	if now.Before(b.openUntil) || b.trial {
+		retryIn := b.openUntil.Sub(now)
+		if retryIn < time.Second {
+			retryIn = time.Second
+		}
+		return &commons.UnavailableError{RetryIn: retryIn, Err: b.lastErr}
+	}
+	b.trial = true
+	return nil
+}
+
+// record updates the breaker with a call's outcome. Only network failures
+// (unreachable, timeouts, 429 and 5xx) count; any other response means the
+// backend is up.
+func (b *circuitBreaker) record(err error) {
+	var netErr *commons.NetworkError
+	failed := err != nil && errors.As(err, &netErr)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		b.lastErr = err
+	}
+}
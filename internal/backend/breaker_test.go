@@ -0,0 +1,111 @@
+package backend
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(3, 30*time.Second)
+	b.now = func() time.Time { return now }
+	netErr := &commons.NetworkError{Err: errors.New("HTTP 503")}
+
+	// Non-network errors never count.
+	for range 5 {
+		b.record(&commons.NotFoundError{Message: "HTTP 404"})
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow after 404s = %v, want nil", err)
+	}
+
+	// A success resets the count.
+	b.record(netErr)
+	b.record(netErr)
+	b.record(nil)
+	b.record(netErr)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow after reset = %v, want nil", err)
+	}
+
+	b.record(netErr)
+	b.record(netErr) // third consecutive failure opens the circuit
+	err := b.allow()
+	var unavail *commons.UnavailableError
+	if !errors.As(err, &unavail) {
+		t.Fatalf("allow while open = %v, want UnavailableError", err)
+	}
+	if unavail.Error() != "backend unavailable, retry in 30s" {
+		t.Errorf("Error() = %q", unavail.Error())
+	}
+	var wrapped *commons.NetworkError
+	if !errors.As(err, &wrapped) {
+		t.Error("UnavailableError should wrap the NetworkError that opened the circuit")
+	}
+
+	now = now.Add(20 * time.Second)
+	if err := b.allow(); err == nil || err.Error() != "backend unavailable, retry in 10s" {
+		t.Errorf("allow 20s later = %v", err)
+	}
+
+	// After the cooldown one trial call goes through; others still fail fast.
+	now = now.Add(10 * time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("trial allow = %v, want nil", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Error("second call during trial should fail fast")
+	}
+
+	// A failed trial reopens the circuit; a successful one closes it.
+	b.record(netErr)
+	if err := b.allow(); err == nil {
+		t.Error("circuit should reopen after a failed trial")
+	}
+	now = now.Add(30 * time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("trial allow = %v", err)
+	}
+	b.record(nil)
+	if err := b.allow(); err != nil {
+		t.Errorf("allow after successful trial = %v, want nil", err)
+	}
+}
+
+func TestRemoteDB_CircuitBreakerFailsFast(t *testing.T) {
+	var hits atomic.Int32
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+
+	var err error
+	for range 10 {
+		if _, err = db.Query("SELECT 1", ""); errors.As(err, new(*commons.UnavailableError)) {
+			break
+		}
+	}
+	if !errors.As(err, new(*commons.UnavailableError)) {
+		t.Fatalf("Query error = %v, want UnavailableError after repeated failures", err)
+	}
+
+	before := hits.Load()
+	if _, err := db.Query("SELECT 1", ""); !errors.As(err, new(*commons.UnavailableError)) {
+		t.Errorf("Query while open = %v", err)
+	}
+	if hits.Load() != before {
+		t.Error("open circuit should not contact the server")
+	}
+	if before > breakerThreshold {
+		t.Errorf("server hit %d times, want at most %d before the circuit opened", before, breakerThreshold)
+	}
+}
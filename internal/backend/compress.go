@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxResponseBytes caps how much of one DoltHub response body is read,
+// after decompression, so an unexpectedly large result (or a hostile gzip
+// stream) fails instead of exhausting memory. Var so tests can override.
+var maxResponseBytes int64 = 64 << 20
+
+// responseBody returns resp's body, gunzipped if the server compressed it
+// and capped at maxResponseBytes.
+func responseBody(resp *http.Response) (io.Reader, error) {
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading gzipped response: %w", err)
+		}
+		body = zr
+	}
+	return &cappedReader{r: body, left: maxResponseBytes}, nil
+}
+
+// cappedReader reads from r until more than left bytes have been read,
+// then fails.
+type cappedReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (c *cappedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > c.left+1 {
+		p = p[:c.left+1]
+	}
+	n, err := c.r.Read(p)
+	c.left -= int64(n)
+	if c.left < 0 {
+		return n, fmt.Errorf("response is larger than %d MiB; narrow the query", maxResponseBytes>>20)
+	}
+	return n, err
+}
@@ -1,80 +1,212 @@
 package backend
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 )
 
-// queryResponse is the JSON shape returned by the DoltHub SQL API.
-type queryResponse struct {
-	QueryExecutionStatus  string            `json:"query_execution_status"`
-	QueryExecutionMessage string            `json:"query_execution_message"`
-	RepositoryOwner       string            `json:"repository_owner"`
-	RepositoryName        string            `json:"repository_name"`
-	CommitRef             string            `json:"commit_ref"`
-	SQLQuery              string            `json:"sql_query"`
-	SchemaFragment        json.RawMessage   `json:"schema_fragment"`
-	Rows                  []json.RawMessage `json:"rows"`
-}
-
 // JSONToCSV converts a DoltHub API JSON response to CSV format matching
 // dolt sql -r csv output. Column order comes from schema_fragment.
 func JSONToCSV(jsonResp []byte) (string, error) {
-	var resp queryResponse
-	if err := json.Unmarshal(jsonResp, &resp); err != nil {
-		return "", fmt.Errorf("parsing API response: %w", err)
+	var b strings.Builder
+	if err := StreamJSONToCSV(bytes.NewReader(jsonResp), &b); err != nil {
+		return "", err
 	}
+	return b.String(), nil
+}
 
-	if resp.QueryExecutionStatus == "Error" {
-		return "", fmt.Errorf("query error: %s", resp.QueryExecutionMessage)
+// StreamJSONToCSV is JSONToCSV for a response being read from r: each row
+// is written to w as soon as it is parsed, so only one row is held in
+// memory at a time. The response is an object with
+// query_execution_status, query_execution_message, schema_fragment and
+// rows, among fields that are ignored. Rows that arrive before schema_fragment are held until
+// the column order is known. On error, w may have received partial output.
+func StreamJSONToCSV(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return fmt.Errorf("parsing API response: %w", err)
 	}
 
-	// Extract column order from schema_fragment.
-	columns, err := extractColumns(resp.SchemaFragment)
-	if err != nil {
-		// Fall back to extracting column order from first row.
-		if len(resp.Rows) > 0 {
-			columns, err = extractColumnsFromRow(resp.Rows[0])
-			if err != nil {
-				return "", fmt.Errorf("cannot determine column order: %w", err)
+	out := bufio.NewWriter(w)
+	var (
+		status, message string
+		columns         []string
+		schemaSeen      bool
+		headerDone      bool
+		pending         []json.RawMessage // rows read before the schema
+	)
+	// header settles the column order from the schema or, failing that, the
+	// first row, and writes the header line once.
+	header := func(first json.RawMessage) error {
+		if headerDone {
+			return nil
+		}
+		if columns == nil && first != nil {
+			var err error
+			if columns, err = extractColumnsFromRow(first); err != nil {
+				return fmt.Errorf("cannot determine column order: %w", err)
 			}
-		} else {
-			return "", nil // no schema, no rows = empty result
+		}
+		headerDone = true
+		if len(columns) > 0 {
+			writeCSVHeader(out, columns)
+		}
+		return nil
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("parsing API response: %w", err)
+		}
+		switch key {
+		case "query_execution_status":
+			err = dec.Decode(&status)
+		case "query_execution_message":
+			err = dec.Decode(&message)
+		case "schema_fragment":
+			var raw json.RawMessage
+			if err = dec.Decode(&raw); err == nil {
+				schemaSeen = true
+				columns, _ = extractColumns(raw) // nil falls back to the first row
+			}
+		case "rows":
+			err = streamRows(dec, func(raw json.RawMessage) error {
+				if !schemaSeen {
+					pending = append(pending, raw)
+					return nil
+				}
+				if err := header(raw); err != nil {
+					return err
+				}
+				writeJSONRow(out, columns, raw)
+				return nil
+			})
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return fmt.Errorf("parsing API response: %w", err)
 		}
 	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return fmt.Errorf("parsing API response: %w", err)
+	}
 
-	if len(columns) == 0 {
-		return "", nil
+	if status == "Error" {
+		return fmt.Errorf("query error: %s", message)
+	}
+	switch {
+	case len(pending) > 0:
+		if err := header(pending[0]); err != nil {
+			return err
+		}
+		for _, raw := range pending {
+			writeJSONRow(out, columns, raw)
+		}
+	case columns != nil:
+		_ = header(nil)
 	}
+	return out.Flush()
+}
 
+// streamRows calls fn with each element of the JSON array (or null) next
+// in dec.
+func streamRows(dec *json.Decoder, fn func(json.RawMessage) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("rows: expected array, got %v", tok)
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing ]
+	return err
+}
+
+// expectDelim reads the next token from dec and checks that it is want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+	return nil
+}
+
+// writeJSONRow writes the JSON object raw as a CSV row in columns order.
+// Rows that aren't objects are skipped.
+func writeJSONRow(w csvWriter, columns []string, raw json.RawMessage) {
+	var row map[string]any
+	if err := json.Unmarshal(raw, &row); err != nil {
+		return
+	}
+	values := make([]any, len(columns))
+	for i, col := range columns {
+		values[i] = row[col] // missing columns are NULL
+	}
+	writeCSVRow(w, values)
+}
+
+// csvWriter is the destination of the CSV helpers: a strings.Builder or a
+// bufio.Writer, whose writes can't fail until they are flushed.
+type csvWriter interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+}
+
+// writeCSV renders a header and rows in dolt sql -r csv format. It is the
+// shared normalization for every DoltHub response shape (REST and GraphQL),
+// so parsers in commons see identical output either way. Nil values are
+// NULL and render as empty fields.
+func writeCSV(columns []string, rows [][]any) string {
 	var b strings.Builder
+	writeCSVHeader(&b, columns)
+	for _, row := range rows {
+		writeCSVRow(&b, row)
+	}
+	return b.String()
+}
 
-	// Header line.
-	b.WriteString(strings.Join(columns, ","))
-	b.WriteByte('\n')
+// writeCSVHeader writes the header line.
+func writeCSVHeader(w csvWriter, columns []string) {
+	_, _ = w.WriteString(strings.Join(columns, ","))
+	_ = w.WriteByte('\n')
+}
 
-	// Data rows.
-	for _, rawRow := range resp.Rows {
-		var row map[string]any
-		if err := json.Unmarshal(rawRow, &row); err != nil {
-			continue
+// writeCSVRow writes one data row.
+func writeCSVRow(w csvWriter, row []any) {
+	for i, val := range row {
+		if i > 0 {
+			_ = w.WriteByte(',')
 		}
-		for i, col := range columns {
-			if i > 0 {
-				b.WriteByte(',')
-			}
-			val, ok := row[col]
-			if !ok || val == nil {
-				// Empty string for NULL (matches dolt CSV output).
-				continue
-			}
-			b.WriteString(formatCSVField(val))
+		if val == nil {
+			// Empty string for NULL (matches dolt CSV output).
+			continue
 		}
-		b.WriteByte('\n')
+		_, _ = w.WriteString(formatCSVField(val))
 	}
-
-	return b.String(), nil
+	_ = w.WriteByte('\n')
 }
 
 // extractColumns parses the schema_fragment to get ordered column names.
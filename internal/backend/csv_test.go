@@ -1,8 +1,12 @@
 package backend
 
 import (
+	"bytes"
+	"io"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestJSONToCSV_SingleRow(t *testing.T) {
@@ -213,3 +217,114 @@ func TestJSONToCSV_JSONFieldValues(t *testing.T) {
 		t.Errorf("row = %q, expected JSON array to be quoted CSV field", lines[1])
 	}
 }
+
+func TestJSONToCSV_RowsBeforeSchema(t *testing.T) {
+	t.Parallel()
+	// Encoders that sort keys put rows ahead of schema_fragment.
+	input := `{
+		"query_execution_status": "Success",
+		"rows": [
+			{"status": "open", "id": "w-001"},
+			{"status": "claimed", "id": "w-002"}
+		],
+		"schema_fragment": [
+			{"columnName": "id", "columnType": "varchar(20)"},
+			{"columnName": "status", "columnType": "varchar(20)"}
+		]
+	}`
+
+	got, err := JSONToCSV([]byte(input))
+	if err != nil {
+		t.Fatalf("JSONToCSV error: %v", err)
+	}
+	if want := "id,status\nw-001,open\nw-002,claimed\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestJSONToCSV_NullRows(t *testing.T) {
+	t.Parallel()
+	input := `{
+		"query_execution_status": "Success",
+		"schema_fragment": [{"columnName": "id", "columnType": "varchar(20)"}],
+		"rows": null
+	}`
+
+	got, err := JSONToCSV([]byte(input))
+	if err != nil {
+		t.Fatalf("JSONToCSV error: %v", err)
+	}
+	if got != "id\n" {
+		t.Errorf("got %q, want header only", got)
+	}
+}
+
+func TestJSONToCSV_Truncated(t *testing.T) {
+	t.Parallel()
+	input := `{
+		"query_execution_status": "Success",
+		"schema_fragment": [{"columnName": "id", "columnType": "varchar(20)"}],
+		"rows": [{"id": "w-001"}, {"id": "w-0`
+
+	if _, err := JSONToCSV([]byte(input)); err == nil {
+		t.Fatal("expected error for truncated response")
+	}
+}
+
+func TestStreamJSONToCSV_WritesRowsAsParsed(t *testing.T) {
+	t.Parallel()
+	pr, pw := io.Pipe()
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- StreamJSONToCSV(pr, &out) }()
+
+	_, _ = io.WriteString(pw, `{"schema_fragment": [{"columnName": "id"}], "rows": [`)
+	for i := range 200 {
+		if i > 0 {
+			_, _ = io.WriteString(pw, ",")
+		}
+		_, _ = io.WriteString(pw, `{"id": "w-`+strings.Repeat("x", 64)+`"}`)
+	}
+	// The response isn't finished, but rows are already out once the
+	// writer's buffer fills.
+	deadline := time.Now().Add(5 * time.Second)
+	for out.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if out.Len() == 0 {
+		t.Fatal("no output before the response finished")
+	}
+
+	_, _ = io.WriteString(pw, `]}`)
+	_ = pw.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("StreamJSONToCSV error: %v", err)
+	}
+	if n := strings.Count(out.String(), "\n"); n != 201 {
+		t.Errorf("got %d lines, want header and 200 rows", n)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to read while another goroutine writes.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
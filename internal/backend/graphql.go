@@ -0,0 +1,126 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// DoltHubGraphQLURL is the DoltHub GraphQL endpoint used as a read fallback.
+// Var so tests can override.
+var DoltHubGraphQLURL = "https://www.dolthub.com/graphql"
+
+// sqlSelectQuery runs a read-only query through DoltHub's GraphQL API, the
+// same backend the DoltHub web UI uses for its SQL console.
+const sqlSelectQuery = `query SqlSelect($ownerName: String!, $repoName: String!, $refName: String!, $queryString: String!) {
+  sqlSelect(ownerName: $ownerName, repoName: $repoName, refName: $refName, queryString: $queryString) {
+    queryExecutionStatus
+    queryExecutionMessage
+    columns { name }
+    rows { columnValues { displayValue } }
+  }
+}`
+
+// graphqlSelectResponse is the JSON shape of a sqlSelect response.
+type graphqlSelectResponse struct {
+	Data struct {
+		SQLSelect struct {
+			QueryExecutionStatus  string `json:"queryExecutionStatus"`
+			QueryExecutionMessage string `json:"queryExecutionMessage"`
+			Columns               []struct {
+				Name string `json:"name"`
+			} `json:"columns"`
+			Rows []struct {
+				ColumnValues []struct {
+					DisplayValue *string `json:"displayValue"`
+				} `json:"columnValues"`
+			} `json:"rows"`
+		} `json:"sqlSelect"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// readSQL runs a read-only query against owner/db at branch and returns CSV.
+// Reads go to the REST SQL endpoint; when it is rate-limited, times out or
+// is otherwise unavailable (a *commons.NetworkError), the query is retried
+// once over GraphQL. If the fallback also fails, the REST error is returned.
+func (r *RemoteDB) readSQL(owner, db, branch, sql string) (string, error) {
+	csv, err := r.doQuery(restQueryURL(owner, db, branch, sql))
+	if err == nil {
+		return csv, nil
+	}
+	var netErr *commons.NetworkError
+	if !errors.As(err, &netErr) {
+		return "", err
+	}
+
+	csv, gqlErr := r.graphqlSelect(owner, db, branch, sql)
+	if gqlErr != nil {
+		slog.Debug("dolthub graphql fallback failed", "owner", owner, "db", db, "branch", branch, "error", gqlErr)
+		return "", err
+	}
+	slog.Debug("dolthub read served by graphql fallback", "owner", owner, "db", db, "branch", branch, "rest_error", err)
+	return csv, nil
+}
+
+// graphqlSelect runs sql through the GraphQL sqlSelect query and normalizes
+// the result to the same CSV as JSONToCSV.
+func (r *RemoteDB) graphqlSelect(owner, db, branch, sql string) (string, error) {
+	payload, err := json.Marshal(map[string]any{
+		"query": sqlSelectQuery,
+		"variables": map[string]string{
+			"ownerName":   owner,
+			"repoName":    db,
+			"refName":     branch,
+			"queryString": sql,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling graphql query: %w", err)
+	}
+	body, err := r.doPost(DoltHubGraphQLURL, payload)
+	if err != nil {
+		return "", err
+	}
+	return graphqlToCSV(body)
+}
+
+// graphqlToCSV converts a sqlSelect response to dolt CSV format. Column
+// order comes from the response's columns list.
+func graphqlToCSV(body []byte) (string, error) {
+	var resp graphqlSelectResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("parsing graphql response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return "", fmt.Errorf("graphql error: %s", resp.Errors[0].Message)
+	}
+	sel := resp.Data.SQLSelect
+	if sel.QueryExecutionStatus == "Error" {
+		return "", fmt.Errorf("query error: %s", sel.QueryExecutionMessage)
+	}
+	if len(sel.Columns) == 0 {
+		return "", nil
+	}
+
+	columns := make([]string, len(sel.Columns))
+	for i, c := range sel.Columns {
+		columns[i] = c.Name
+	}
+	rows := make([][]any, len(sel.Rows))
+	for i, row := range sel.Rows {
+		values := make([]any, len(columns))
+		for j := range columns {
+			if j < len(row.ColumnValues) && row.ColumnValues[j].DisplayValue != nil {
+				values[j] = *row.ColumnValues[j].DisplayValue
+			}
+		}
+		rows[i] = values
+	}
+	return writeCSV(columns, rows), nil
+}
@@ -0,0 +1,139 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// graphqlSelectBody builds a sqlSelect response; a nil cell is SQL NULL.
+func graphqlSelectBody(columns []string, rows [][]*string) map[string]any {
+	cols := make([]map[string]string, len(columns))
+	for i, c := range columns {
+		cols[i] = map[string]string{"name": c}
+	}
+	gqlRows := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		values := make([]map[string]*string, len(row))
+		for j, v := range row {
+			values[j] = map[string]*string{"displayValue": v}
+		}
+		gqlRows[i] = map[string]any{"columnValues": values}
+	}
+	return map[string]any{"data": map[string]any{"sqlSelect": map[string]any{
+		"queryExecutionStatus": "Success",
+		"columns":              cols,
+		"rows":                 gqlRows,
+	}}}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestGraphQLToCSV_MatchesRESTNormalization(t *testing.T) {
+	rest, err := JSONToCSV([]byte(`{
+		"query_execution_status": "Success",
+		"schema_fragment": [{"columnName": "id"}, {"columnName": "title"}, {"columnName": "claimed_by"}],
+		"rows": [{"id": "w-1", "title": "Fix \"auth\", fast", "claimed_by": null}]
+	}`))
+	if err != nil {
+		t.Fatalf("JSONToCSV: %v", err)
+	}
+
+	body, _ := json.Marshal(graphqlSelectBody(
+		[]string{"id", "title", "claimed_by"},
+		[][]*string{{strPtr("w-1"), strPtr(`Fix "auth", fast`), nil}},
+	))
+	gql, err := graphqlToCSV(body)
+	if err != nil {
+		t.Fatalf("graphqlToCSV: %v", err)
+	}
+	if gql != rest {
+		t.Errorf("graphql CSV = %q, REST CSV = %q", gql, rest)
+	}
+}
+
+func TestGraphQLToCSV_Errors(t *testing.T) {
+	for name, body := range map[string]string{
+		"graphql error": `{"errors": [{"message": "rate limited"}]}`,
+		"query error":   `{"data": {"sqlSelect": {"queryExecutionStatus": "Error", "queryExecutionMessage": "no such table"}}}`,
+		"bad json":      `{`,
+	} {
+		if _, err := graphqlToCSV([]byte(body)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRemoteDB_Query_GraphQLFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		restStatus   int
+		graphqlOK    bool
+		wantGraphQL  bool
+		wantErrClass func(error) bool
+	}{
+		{name: "rate limited", restStatus: http.StatusTooManyRequests, graphqlOK: true, wantGraphQL: true},
+		{name: "gateway timeout", restStatus: http.StatusGatewayTimeout, graphqlOK: true, wantGraphQL: true},
+		{
+			name: "not found is not retried", restStatus: http.StatusNotFound, graphqlOK: true,
+			wantErrClass: func(err error) bool { var e *commons.NotFoundError; return errors.As(err, &e) },
+		},
+		{
+			name: "fallback failure returns REST error", restStatus: http.StatusTooManyRequests, wantGraphQL: true,
+			wantErrClass: func(err error) bool {
+				var e *commons.NetworkError
+				return errors.As(err, &e) && strings.Contains(err.Error(), "HTTP 429")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var graphqlCalls int
+			srv, cleanup := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/graphql" {
+					w.WriteHeader(tt.restStatus)
+					return
+				}
+				graphqlCalls++
+				var req struct {
+					Variables map[string]string `json:"variables"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				if req.Variables["ownerName"] != "upstream-org" || req.Variables["refName"] != "main" ||
+					req.Variables["queryString"] != "SELECT id FROM wanted" {
+					t.Errorf("graphql variables = %v", req.Variables)
+				}
+				if !tt.graphqlOK {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(graphqlSelectBody([]string{"id"}, [][]*string{{strPtr("w-1")}}))
+			})
+			defer cleanup()
+
+			db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+			db.client = srv.Client()
+
+			csv, err := db.Query("SELECT id FROM wanted", "")
+			if (graphqlCalls > 0) != tt.wantGraphQL {
+				t.Errorf("graphql calls = %d, want fallback %v", graphqlCalls, tt.wantGraphQL)
+			}
+			if tt.wantErrClass != nil {
+				if err == nil || !tt.wantErrClass(err) {
+					t.Errorf("err = %T (%v)", err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			if csv != "id\nw-1\n" {
+				t.Errorf("csv = %q", csv)
+			}
+		})
+	}
+}
@@ -5,14 +5,16 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/gastownhall/wasteland/internal/commons"
 )
 
 // LocalDB implements DB using the local dolt CLI.
 type LocalDB struct {
-	dir  string
-	mode string // "pr" or "wild-west"
+	dir    string
+	mode   string     // "pr" or "wild-west"
+	server *sqlServer // nil unless UseSQLServer was called
 }
 
 // NewLocalDB creates a DB backed by a local dolt database directory.
@@ -26,30 +28,59 @@ func (l *LocalDB) Dir() string { return l.dir }
 
 // Query runs a read-only SQL SELECT, injecting AS OF for non-empty refs.
 func (l *LocalDB) Query(sql, ref string) (string, error) {
+	start := time.Now()
 	if ref != "" {
 		sql = injectAsOf(sql, ref)
 	}
-	return commons.DoltSQLQuery(l.dir, sql)
+	var out string
+	var err error
+	if db := l.sqlDB(); db != nil {
+		out, err = serverQuery(db, sql)
+	} else {
+		out, err = commons.DoltSQLQuery(l.dir, sql)
+	}
+	observe("local", "query", sql, start, err)
+	return out, err
+}
+
+// QueryStream is Query without buffering: the CSV output is read straight
+// from dolt (or rendered row by row from the sql-server) as the caller
+// consumes it.
+func (l *LocalDB) QueryStream(sql, ref string) (io.ReadCloser, error) {
+	if ref != "" {
+		sql = injectAsOf(sql, ref)
+	}
+	if db := l.sqlDB(); db != nil {
+		return serverQueryStream(db, sql)
+	}
+	return commons.DoltSQLStream(l.dir, sql)
 }
 
 // Exec runs DML on a branch (or main if branch is ""), then auto-commits.
-func (l *LocalDB) Exec(branch, commitMsg string, signed bool, stmts ...string) error {
+// The statements run in a single SQL transaction, and the working set is
+// reset if the script fails, so a failing statement never leaves earlier
+// ones half-applied.
+func (l *LocalDB) Exec(branch, commitMsg string, sign commons.Signing, stmts ...string) error {
+	if db := l.sqlDB(); db != nil {
+		start := time.Now()
+		err := serverExec(db, branch, commitMsg, sign, stmts)
+		observe("local", "exec", strings.Join(stmts, "; "), start, err)
+		return err
+	}
 	if branch != "" {
 		if err := commons.CheckoutBranchFrom(l.dir, branch, "main"); err != nil {
 			return fmt.Errorf("checkout branch %s: %w", branch, err)
 		}
 	}
 
-	// Ensure each statement ends with a semicolon before joining.
-	for i, s := range stmts {
-		s = strings.TrimRight(s, "; \t\n")
-		stmts[i] = s + ";"
+	start := time.Now()
+	err := commons.DoltSQLScript(l.dir, execScript(commitMsg, sign, stmts))
+	observe("local", "exec", strings.Join(stmts, "; "), start, err)
+	if err != nil && !commons.IsNothingToCommit(err) {
+		if resetErr := commons.ResetHard(l.dir); resetErr != nil {
+			err = errors.Join(err, fmt.Errorf("rollback: %w", resetErr))
+		}
 	}
-	script := strings.Join(stmts, "\n") + "\n"
-	script += "CALL DOLT_ADD('-A');\n"
-	script += commons.CommitSQL(commitMsg, signed)
-
-	err := commons.DoltSQLScript(l.dir, script)
 
 	if branch != "" {
 		if checkoutErr := commons.CheckoutMain(l.dir); checkoutErr != nil {
@@ -63,33 +94,74 @@ func (l *LocalDB) Exec(branch, commitMsg string, signed bool, stmts ...string) e
 	return err
 }
 
+// execScript wraps stmts in a transaction that stages and commits them.
+// DOLT_COMMIT also commits the SQL transaction; if any statement fails the
+// script stops before it and the transaction is discarded.
+func execScript(commitMsg string, sign commons.Signing, stmts []string) string {
+	var b strings.Builder
+	b.WriteString("START TRANSACTION;\n")
+	for _, s := range stmts {
+		// Ensure each statement ends with a semicolon before joining.
+		b.WriteString(strings.TrimRight(s, "; \t\n") + ";\n")
+	}
+	b.WriteString("CALL DOLT_ADD('-A');\n")
+	b.WriteString(commons.CommitSQL(commitMsg, sign))
+	return b.String()
+}
+
 // Branches returns branch names matching the given prefix.
 func (l *LocalDB) Branches(prefix string) ([]string, error) {
+	if db := l.sqlDB(); db != nil {
+		return serverBranches(db, prefix)
+	}
 	return commons.ListBranches(l.dir, prefix)
 }
 
 // DeleteBranch removes a local branch.
 func (l *LocalDB) DeleteBranch(name string) error {
+	if db := l.sqlDB(); db != nil {
+		return serverCall(db, 30*time.Second, fmt.Sprintf("CALL DOLT_BRANCH('-D', '%s')", commons.EscapeSQL(name)))
+	}
 	return commons.DeleteBranch(l.dir, name)
 }
 
 // DeleteRemoteBranch removes a branch on the origin remote.
 func (l *LocalDB) DeleteRemoteBranch(branch string) error {
+	if db := l.sqlDB(); db != nil {
+		return serverRemote(db, "dolt push origin :"+branch,
+			fmt.Sprintf("CALL DOLT_PUSH('origin', ':%s')", commons.EscapeSQL(branch)))
+	}
 	return commons.DeleteRemoteBranch(l.dir, "origin", branch)
 }
 
 // PushBranch force-pushes a branch to origin.
 func (l *LocalDB) PushBranch(branch string, stdout io.Writer) error {
+	if db := l.sqlDB(); db != nil {
+		err := serverRemote(db, "push branch "+branch,
+			fmt.Sprintf("CALL DOLT_PUSH('--force', 'origin', '%s')", commons.EscapeSQL(branch)))
+		if err != nil {
+			fmt.Fprintf(stdout, "  warning: %v\n", err)
+			return err
+		}
+		fmt.Fprintf(stdout, "  Pushed branch %s to origin\n", branch)
+		return nil
+	}
 	return commons.PushBranch(l.dir, branch, stdout)
 }
 
 // PushMain force-pushes local main to origin.
 func (l *LocalDB) PushMain(stdout io.Writer) error {
+	if l.sqlDB() != nil {
+		return l.PushBranch("main", stdout)
+	}
 	return commons.PushOriginMain(l.dir, stdout)
 }
 
 // PushWithSync pushes to both upstream and origin with sync retry.
 func (l *LocalDB) PushWithSync(stdout io.Writer) error {
+	if db := l.sqlDB(); db != nil {
+		return serverPushWithSync(db, stdout)
+	}
 	return commons.PushWithSync(l.dir, stdout)
 }
 
@@ -99,6 +171,9 @@ func (l *LocalDB) CanWildWest() error { return nil }
 // Sync pulls latest from upstream. In PR mode, resets main to upstream
 // and fetches origin branches so PR mutations are visible via AS OF.
 func (l *LocalDB) Sync() error {
+	if db := l.sqlDB(); db != nil {
+		return l.serverSync(db)
+	}
 	if l.mode == "pr" {
 		if err := commons.ResetMainToUpstream(l.dir); err != nil {
 			return err
@@ -112,6 +187,9 @@ func (l *LocalDB) Sync() error {
 
 // MergeBranch merges a branch into main.
 func (l *LocalDB) MergeBranch(branch string) error {
+	if db := l.sqlDB(); db != nil {
+		return serverMerge(db, branch)
+	}
 	return commons.MergeBranch(l.dir, branch)
 }
 
@@ -144,6 +222,10 @@ func injectAsOf(sql, ref string) string {
 	}
 
 	rest := afterFrom[leadingSpace+len(tableName):]
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(rest)), "AS OF ") {
+		// The query already pins its own ref; the caller's ref only routes it.
+		return sql
+	}
 	return sql[:fromIdx+6] + tableName + fmt.Sprintf(" AS OF '%s'", escaped) + rest
 }
 
@@ -0,0 +1,427 @@
+package backend
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	_ "github.com/go-sql-driver/mysql" // dolt sql-server speaks the MySQL protocol
+)
+
+// sqlServer is LocalDB's connection to the clone's managed dolt sql-server.
+// The server is started lazily on first use; if it can't be started, the
+// LocalDB falls back to the dolt CLI for the rest of its life.
+type sqlServer struct {
+	dir  string
+	once sync.Once
+	db   *sql.DB
+}
+
+// UseSQLServer routes l's operations through a managed `dolt sql-server`
+// for its clone instead of spawning dolt per call. The server is started on
+// first use and shared by every wl process working on the same clone; dolt
+// CLI commands run against the clone meanwhile are forwarded to it by dolt
+// itself.
+func (l *LocalDB) UseSQLServer() *LocalDB {
+	l.server = &sqlServer{dir: l.dir}
+	return l
+}
+
+// sqlDB returns the managed server's connection pool, or nil when l uses
+// the dolt CLI.
+func (l *LocalDB) sqlDB() *sql.DB {
+	if l.server == nil {
+		return nil
+	}
+	s := l.server
+	s.once.Do(func() {
+		db, err := openSQLServer(s.dir)
+		if err != nil {
+			slog.Warn("sql-server unavailable, using dolt CLI", "dir", s.dir, "error", err)
+			return
+		}
+		s.db = db
+	})
+	if s.db != nil {
+		touchSQLServer(s.dir)
+	}
+	return s.db
+}
+
+// Close releases l's connections to its managed sql-server, if any. The
+// server itself keeps running until it goes idle.
+func (l *LocalDB) Close() error {
+	if l.server == nil || l.server.db == nil {
+		return nil
+	}
+	return l.server.db.Close()
+}
+
+// openSQLServer ensures dir's server is running and connects to the
+// clone's database on it.
+func openSQLServer(dir string) (*sql.DB, error) {
+	st, err := EnsureSQLServer(dir)
+	if err != nil {
+		return nil, err
+	}
+	name, err := serverDatabase(st.Addr())
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("mysql", fmt.Sprintf("root@tcp(%s)/%s", st.Addr(), name))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxIdleConns(4)
+	db.SetConnMaxIdleTime(time.Minute)
+	return db, nil
+}
+
+// serverDatabase returns the name the server gives the clone's database.
+// Dolt derives it from the directory name, with version-dependent rules
+// for characters such as '-', so ask rather than guess.
+func serverDatabase(addr string) (string, error) {
+	db, err := sql.Open("mysql", fmt.Sprintf("root@tcp(%s)/", addr))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = db.Close() }()
+	rows, err := db.Query("SHOW DATABASES")
+	if err != nil {
+		return "", fmt.Errorf("listing sql-server databases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return "", err
+		}
+		if !isSystemDatabase(name) {
+			return name, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("sql-server at %s serves no database", addr)
+}
+
+func isSystemDatabase(name string) bool {
+	switch strings.ToLower(name) {
+	case "information_schema", "mysql", "performance_schema", "sys":
+		return true
+	}
+	return false
+}
+
+// ServerQueryTimeout bounds a buffered query over the managed sql-server.
+// Zero means no limit. Streamed queries are bounded by their reader
+// instead: closing it cancels the query. Var so the CLI
+// (WL_QUERY_TIMEOUT_MS) and tests can override.
+var ServerQueryTimeout = 2 * time.Minute
+
+// serverQuery runs query and renders the result in dolt sql -r csv format.
+func serverQuery(db *sql.DB, query string) (string, error) {
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if ServerQueryTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, ServerQueryTimeout)
+	}
+	defer cancel()
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("sql-server query failed: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var b strings.Builder
+	if err := writeServerRows(&b, rows); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// serverQueryStream is serverQuery without buffering: rows are rendered to
+// CSV as the caller reads them, so only one row is held in memory at a
+// time. Closing the reader early cancels the query.
+func serverQueryStream(db *sql.DB, query string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("sql-server query failed: %w", err)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		defer cancel()
+		defer func() { _ = rows.Close() }()
+		out := bufio.NewWriter(pw)
+		err := writeServerRows(out, rows)
+		if err == nil {
+			err = out.Flush()
+		}
+		pw.CloseWithError(err)
+	}()
+	return &serverStream{PipeReader: pr, cancel: cancel}, nil
+}
+
+// serverStream is the reader returned by serverQueryStream.
+type serverStream struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (s *serverStream) Close() error {
+	s.cancel()
+	return s.PipeReader.Close()
+}
+
+// writeServerRows renders rows to w in dolt sql -r csv format, one row at
+// a time.
+func writeServerRows(w csvWriter, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	writeCSVHeader(w, columns)
+	vals := make([]sql.NullString, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	row := make([]any, len(columns))
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return fmt.Errorf("sql-server query failed: %w", err)
+		}
+		for i, v := range vals {
+			row[i] = nil
+			if v.Valid {
+				row[i] = v.String
+			}
+		}
+		writeCSVRow(w, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sql-server query failed: %w", err)
+	}
+	return nil
+}
+
+// serverCall runs a stored procedure call (or other statement) outside any
+// branch-scoped session.
+func serverCall(db *sql.DB, timeout time.Duration, stmt string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := db.ExecContext(ctx, stmt)
+	return err
+}
+
+// serverExec is Exec over the server. The branch checkout is scoped to a
+// dedicated session, which is returned to main (or discarded) afterwards.
+func serverExec(db *sql.DB, branch, commitMsg string, sign commons.Signing, stmts []string) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("sql-server connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if branch != "" {
+		escaped := commons.EscapeSQL(branch)
+		var n int
+		if err := conn.QueryRowContext(ctx, fmt.Sprintf(
+			"SELECT COUNT(*) FROM dolt_branches WHERE name = '%s'", escaped)).Scan(&n); err != nil {
+			return fmt.Errorf("checking branch %s: %w", branch, err)
+		}
+		if n == 0 {
+			if _, err := conn.ExecContext(ctx, fmt.Sprintf("CALL DOLT_BRANCH('%s', 'main')", escaped)); err != nil {
+				return fmt.Errorf("creating branch %s from main: %w", branch, err)
+			}
+		}
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("CALL DOLT_CHECKOUT('%s')", escaped)); err != nil {
+			return fmt.Errorf("checkout branch %s: %w", branch, err)
+		}
+		defer func() {
+			if _, checkoutErr := conn.ExecContext(context.Background(), "CALL DOLT_CHECKOUT('main')"); checkoutErr != nil {
+				// Don't hand a session stuck on the branch back to the pool.
+				_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			}
+		}()
+	}
+
+	script := append([]string{"START TRANSACTION"}, stmts...)
+	script = append(script, "CALL DOLT_ADD('-A')", strings.TrimSpace(commons.CommitSQL(commitMsg, sign)))
+	for _, s := range script {
+		s = strings.TrimRight(s, "; \t\n")
+		if _, err = conn.ExecContext(ctx, s); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		// DOLT_COMMIT commits the SQL transaction only when it succeeds, so
+		// every failure, nothing-to-commit included, leaves it open. Close
+		// it before the session goes back to the pool, or drop the session
+		// if that fails.
+		if _, rollbackErr := conn.ExecContext(context.Background(), "ROLLBACK"); rollbackErr != nil {
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			if !commons.IsNothingToCommit(err) {
+				err = errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+			}
+		}
+	}
+	return err
+}
+
+// serverBranches is Branches over the server.
+func serverBranches(db *sql.DB, prefix string) ([]string, error) {
+	out, err := serverQuery(db, fmt.Sprintf(
+		"SELECT name FROM dolt_branches WHERE name LIKE '%s%%' ORDER BY name",
+		commons.EscapeLIKE(prefix),
+	))
+	if err != nil {
+		return nil, err
+	}
+	var branches []string
+	for _, row := range csvRows(out) {
+		branches = append(branches, row[0])
+	}
+	return branches, nil
+}
+
+// serverMerge is MergeBranch over the server.
+func serverMerge(db *sql.DB, branch string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("sql-server connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "CALL DOLT_CHECKOUT('main')"); err != nil {
+		return fmt.Errorf("merging branch %s: %w", branch, err)
+	}
+	// With autocommit on, a conflicting merge fails and rolls back.
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("CALL DOLT_MERGE('%s')", commons.EscapeSQL(branch)))
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "conflict") {
+			_, _ = conn.ExecContext(ctx, "CALL DOLT_MERGE('--abort')")
+			return fmt.Errorf("merge conflict on branch %s: resolve manually or delete the branch", branch)
+		}
+		return fmt.Errorf("merging branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// serverRemote runs a remote operation (push, pull, fetch) through the
+// server, classifying failures like the CLI helpers do.
+func serverRemote(db *sql.DB, what, stmt string) error {
+	err := serverCall(db, 60*time.Second, stmt)
+	if err != nil {
+		return commons.RemoteError(fmt.Errorf("%s: %w", what, err), err.Error())
+	}
+	return nil
+}
+
+// serverSync is Sync over the server.
+func (l *LocalDB) serverSync(db *sql.DB) error {
+	if l.mode == "pr" {
+		if err := serverRemote(db, "dolt fetch upstream", "CALL DOLT_FETCH('upstream')"); err != nil {
+			return err
+		}
+		if err := serverCall(db, 30*time.Second, "CALL DOLT_RESET('--hard', 'upstream/main')"); err != nil {
+			return fmt.Errorf("dolt reset --hard upstream/main: %w", err)
+		}
+		_ = serverRemote(db, "dolt fetch origin", "CALL DOLT_FETCH('origin')")
+		serverTrackOriginBranches(db, "wl/")
+		return nil
+	}
+	return serverRemote(db, "dolt pull upstream main", "CALL DOLT_PULL('upstream', 'main')")
+}
+
+// serverPushWithSync is PushWithSync over the server.
+func serverPushWithSync(db *sql.DB, stdout io.Writer) error {
+	var failures []string
+	for _, remote := range []string{"upstream", "origin"} {
+		push := fmt.Sprintf("CALL DOLT_PUSH('%s', 'main')", remote)
+		if err := serverRemote(db, "dolt push "+remote+" main", push); err != nil {
+			fmt.Fprintf(stdout, "  Syncing with %s...\n", remote)
+			pull := fmt.Sprintf("CALL DOLT_PULL('%s', 'main')", remote)
+			if pullErr := serverRemote(db, "dolt pull "+remote+" main", pull); pullErr != nil {
+				fmt.Fprintf(stdout, "  warning: sync from %s failed: %v\n", remote, pullErr)
+				failures = append(failures, remote)
+				continue
+			}
+			if err := serverRemote(db, "dolt push "+remote+" main", push); err != nil {
+				fmt.Fprintf(stdout, "  warning: push to %s failed after sync: %v\n", remote, err)
+				failures = append(failures, remote)
+				continue
+			}
+		}
+		fmt.Fprintf(stdout, "  Pushed to %s\n", remote)
+	}
+	if len(failures) > 0 {
+		return &commons.NetworkError{Err: fmt.Errorf("push failed for remotes: %s", strings.Join(failures, ", "))}
+	}
+	return nil
+}
+
+// serverTrackOriginBranches is commons.TrackOriginBranches over the server:
+// it mirrors origin's prefix* branches locally and prunes the ones origin
+// no longer has. Best-effort.
+func serverTrackOriginBranches(db *sql.DB, prefix string) {
+	out, err := serverQuery(db, fmt.Sprintf(
+		"SELECT name FROM dolt_remote_branches WHERE name LIKE '%s%%' ORDER BY name",
+		commons.EscapeLIKE("remotes/origin/"+prefix),
+	))
+	if err != nil {
+		return
+	}
+	remote := make(map[string]bool)
+	for _, row := range csvRows(out) {
+		remote[strings.TrimPrefix(row[0], "remotes/origin/")] = true
+	}
+	if len(remote) == 0 {
+		return
+	}
+	local, _ := serverBranches(db, prefix)
+	localSet := make(map[string]bool, len(local))
+	for _, b := range local {
+		localSet[b] = true
+		if !remote[b] {
+			_ = serverCall(db, 30*time.Second, fmt.Sprintf("CALL DOLT_BRANCH('-D', '%s')", commons.EscapeSQL(b)))
+		}
+	}
+	for b := range remote {
+		if !localSet[b] {
+			_ = serverCall(db, 30*time.Second, fmt.Sprintf("CALL DOLT_BRANCH('%s', 'remotes/origin/%s')",
+				commons.EscapeSQL(b), commons.EscapeSQL(b)))
+		}
+	}
+}
+
+// csvRows returns the data rows of simple CSV output whose fields need no
+// unquoting (branch names).
+func csvRows(out string) [][]string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+	var rows [][]string
+	for _, line := range lines[1:] {
+		if line = strings.TrimSpace(line); line != "" {
+			rows = append(rows, strings.Split(line, ","))
+		}
+	}
+	return rows
+}
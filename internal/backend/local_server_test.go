@@ -0,0 +1,186 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// scriptDriver is a database/sql driver that records the statements run on
+// its connections, fails any statement containing failOn, and answers
+// every query with rows.
+type scriptDriver struct {
+	mu      sync.Mutex
+	stmts   []string
+	failOn  string
+	failErr error
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d *scriptDriver) Open(string) (driver.Conn, error) { return &scriptConn{d: d}, nil }
+
+func (d *scriptDriver) ran() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.stmts...)
+}
+
+type scriptConn struct{ d *scriptDriver }
+
+func (c *scriptConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *scriptConn) Close() error              { return nil }
+func (c *scriptConn) Begin() (driver.Tx, error) { return nil, errors.New("begin not supported") }
+
+func (c *scriptConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.stmts = append(c.d.stmts, query)
+	if c.d.failOn != "" && strings.Contains(query, c.d.failOn) {
+		return nil, c.d.failErr
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *scriptConn) QueryContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	return &scriptRows{ctx: ctx, columns: c.d.columns, rows: c.d.rows}, nil
+}
+
+type scriptRows struct {
+	ctx     context.Context
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *scriptRows) Columns() []string { return r.columns }
+func (r *scriptRows) Close() error      { return nil }
+
+func (r *scriptRows) Next(dest []driver.Value) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var registerScriptDriver sync.Once
+
+// openScriptDB returns a *sql.DB backed by d.
+func openScriptDB(t *testing.T, d *scriptDriver) *sql.DB {
+	t.Helper()
+	registerScriptDriver.Do(func() { sql.Register("wl-script", &scriptDriverRouter{}) })
+	name := t.Name()
+	scriptDrivers.Store(name, d)
+	db, err := sql.Open("wl-script", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close(); scriptDrivers.Delete(name) })
+	return db
+}
+
+// scriptDrivers maps a DSN to its test's driver, so tests can share the
+// one registered driver name.
+var scriptDrivers sync.Map
+
+type scriptDriverRouter struct{}
+
+func (scriptDriverRouter) Open(dsn string) (driver.Conn, error) {
+	d, ok := scriptDrivers.Load(dsn)
+	if !ok {
+		return nil, errors.New("unknown script DSN " + dsn)
+	}
+	return d.(*scriptDriver).Open(dsn)
+}
+
+func TestServerExec_RollsBackNothingToCommit(t *testing.T) {
+	d := &scriptDriver{failOn: "DOLT_COMMIT", failErr: errors.New("nothing to commit")}
+	db := openScriptDB(t, d)
+
+	err := serverExec(db, "", "wl: noop", commons.Signing{}, []string{"UPDATE wanted SET title = title WHERE id = 'w-1'"})
+	if err == nil || !strings.Contains(err.Error(), "nothing to commit") {
+		t.Fatalf("serverExec() = %v, want the nothing-to-commit error", err)
+	}
+	ran := d.ran()
+	if last := ran[len(ran)-1]; last != "ROLLBACK" {
+		t.Errorf("last statement = %q, want ROLLBACK; ran %q", last, ran)
+	}
+}
+
+func TestServerExec_RollsBackFailedStatement(t *testing.T) {
+	d := &scriptDriver{failOn: "INSERT", failErr: errors.New("duplicate key")}
+	db := openScriptDB(t, d)
+
+	err := serverExec(db, "", "wl: post", commons.Signing{}, []string{"INSERT INTO wanted (id) VALUES ('w-1')", "UPDATE wanted SET status = 'open'"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := []string{"START TRANSACTION", "INSERT INTO wanted (id) VALUES ('w-1')", "ROLLBACK"}
+	if got := d.ran(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+}
+
+func TestServerQueryStream(t *testing.T) {
+	d := &scriptDriver{
+		columns: []string{"id", "title"},
+		rows:    [][]driver.Value{{"w-1", "One, two"}, {"w-2", nil}},
+	}
+	db := openScriptDB(t, d)
+
+	rc, err := serverQueryStream(db, "SELECT id, title FROM wanted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	want := "id,title\nw-1,\"One, two\"\nw-2,\n"
+	if string(out) != want {
+		t.Errorf("stream = %q, want %q", out, want)
+	}
+	buffered, err := serverQuery(db, "SELECT id, title FROM wanted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffered != want {
+		t.Errorf("serverQuery = %q, want the same output as the stream", buffered)
+	}
+}
+
+func TestServerQueryStream_CloseEarly(t *testing.T) {
+	rows := make([][]driver.Value, 10000)
+	for i := range rows {
+		rows[i] = []driver.Value{"w-" + strings.Repeat("x", 64)}
+	}
+	db := openScriptDB(t, &scriptDriver{columns: []string{"id"}, rows: rows})
+
+	rc, err := serverQueryStream(db, "SELECT id FROM wanted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 16)
+	if _, err := rc.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestExecScript_Transaction(t *testing.T) {
+	stmts := []string{
+		"UPDATE wanted SET status='completed' WHERE id='w-1';",
+		"INSERT INTO stamps (id) VALUES ('s-1')",
+	}
+	got := execScript("wl accept: w-1", commons.Signing{}, stmts)
+	want := "START TRANSACTION;\n" +
+		"UPDATE wanted SET status='completed' WHERE id='w-1';\n" +
+		"INSERT INTO stamps (id) VALUES ('s-1');\n" +
+		"CALL DOLT_ADD('-A');\n" +
+		"CALL DOLT_COMMIT('-m', 'wl accept: w-1');\n"
+	if got != want {
+		t.Errorf("execScript =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.HasSuffix(stmts[1], "('s-1')") {
+		t.Errorf("execScript modified caller's statements: %q", stmts[1])
+	}
+}
+
+func TestInjectAsOf(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			name: "single table",
+			sql:  "SELECT status FROM wanted WHERE id = 'w-1'",
+			want: "SELECT status FROM wanted AS OF 'wl/rig/w-1' WHERE id = 'w-1'",
+		},
+		{
+			name: "explicit AS OF kept",
+			sql:  "(SELECT status FROM wanted AS OF 'wl/a/w-2' WHERE id = 'w-2')",
+			want: "(SELECT status FROM wanted AS OF 'wl/a/w-2' WHERE id = 'w-2')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := injectAsOf(tt.sql, "wl/rig/w-1"); got != tt.want {
+				t.Errorf("injectAsOf = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var _ commons.RowStreamer = (*LocalDB)(nil)
@@ -1,9 +1,11 @@
 package backend
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -21,6 +23,7 @@ var DoltHubAPIBase = "https://www.dolthub.com/api/v1alpha1"
 // RemoteDB implements DB using the DoltHub REST API.
 // Reads from main go to the upstream (shared) database.
 // Branch reads and all writes go to the fork (user's) database.
+// Reads fall back to the GraphQL API when the SQL endpoint is unavailable.
 type RemoteDB struct {
 	token      string
 	readOwner  string // upstream org
@@ -29,6 +32,8 @@ type RemoteDB struct {
 	writeDB    string // fork db name
 	mode       string // "pr" or "wild-west"
 	client     *http.Client
+	breaker    *circuitBreaker
+	rateLimits commons.RateLimitTracker // DoltHub quota from response headers
 }
 
 // NewRemoteDB creates a DB backed by the DoltHub REST API.
@@ -41,6 +46,7 @@ func NewRemoteDB(token, readOwner, readDB, writeOwner, writeDB, mode string) *Re
 		writeDB:    writeDB,
 		mode:       mode,
 		client:     &http.Client{Timeout: 60 * time.Second},
+		breaker:    newCircuitBreaker(breakerThreshold, breakerCooldown),
 	}
 }
 
@@ -55,38 +61,65 @@ func NewRemoteDBWithClient(client *http.Client, readOwner, readDB, writeOwner, w
 		writeDB:    writeDB,
 		mode:       mode,
 		client:     client,
+		breaker:    newCircuitBreaker(breakerThreshold, breakerCooldown),
 	}
 }
 
 // Query runs a read-only SQL SELECT via the DoltHub API.
 func (r *RemoteDB) Query(sql, ref string) (string, error) {
-	owner := r.readOwner
-	db := r.readDB
-	branch := "main"
+	owner, db, branch := r.readTarget(ref)
+	start := time.Now()
+	csv, err := r.readSQL(owner, db, branch, sql)
+	observe("remote", "query", sql, start, err)
+	if err != nil {
+		return "", fmt.Errorf("query failed: %w", err)
+	}
+	return csv, nil
+}
+
+// QueryStream is Query without buffering the result: the JSON response is
+// converted to CSV as the caller reads it. A request that fails before
+// any output fails here, falling back to GraphQL as Query does; the
+// fallback's result is buffered.
+func (r *RemoteDB) QueryStream(sql, ref string) (io.ReadCloser, error) {
+	owner, db, branch := r.readTarget(ref)
+	start := time.Now()
+	rc, err := r.streamQuery(restQueryURL(owner, db, branch, sql))
+	var netErr *commons.NetworkError
+	if errors.As(err, &netErr) {
+		if csv, gqlErr := r.graphqlSelect(owner, db, branch, sql); gqlErr == nil {
+			slog.Debug("dolthub read served by graphql fallback", "owner", owner, "db", db, "branch", branch, "rest_error", err)
+			rc, err = io.NopCloser(strings.NewReader(csv)), nil
+		}
+	}
+	observe("remote", "query", sql, start, err)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	return rc, nil
+}
 
+// readTarget returns the database and branch a read at ref goes to: main
+// of the read database, or the ref on the fork.
+func (r *RemoteDB) readTarget(ref string) (owner, db, branch string) {
 	if ref != "" {
 		// Branch refs read from the fork database.
-		owner = r.writeOwner
-		db = r.writeDB
-		branch = ref
+		return r.writeOwner, r.writeDB, ref
 	}
+	return r.readOwner, r.readDB, "main"
+}
 
-	apiURL := fmt.Sprintf("%s/%s/%s/%s?q=%s",
+// restQueryURL returns the REST SQL endpoint URL for a read on owner/db.
+func restQueryURL(owner, db, branch, sql string) string {
+	return fmt.Sprintf("%s/%s/%s/%s?q=%s",
 		DoltHubAPIBase, owner, db, url.PathEscape(branch), url.QueryEscape(sql))
-
-	body, err := r.doGet(apiURL)
-	if err != nil {
-		return "", fmt.Errorf("query failed: %w", err)
-	}
-
-	return JSONToCSV(body)
 }
 
 // Exec runs DML via the DoltHub write API on the given branch.
 // The write API accepts only a single statement per call, so multi-statement
 // mutations are sent sequentially. After the first write the branch exists,
 // so subsequent statements read from the branch (not main) to see prior changes.
-func (r *RemoteDB) Exec(branch, _ string, _ bool, stmts ...string) error {
+func (r *RemoteDB) Exec(branch, _ string, _ commons.Signing, stmts ...string) error {
 	if branch == "" {
 		branch = "main"
 	}
@@ -100,7 +133,10 @@ func (r *RemoteDB) Exec(branch, _ string, _ bool, stmts ...string) error {
 	}
 
 	for _, stmt := range stmts {
-		if err := r.execOne(fromBranch, branch, stmt); err != nil {
+		start := time.Now()
+		err := r.execOne(fromBranch, branch, stmt)
+		observe("remote", "exec", stmt, start, err)
+		if err != nil {
 			return err
 		}
 		// After the first successful write, the branch has data — subsequent
@@ -147,19 +183,11 @@ func (r *RemoteDB) Branches(prefix string) ([]string, error) {
 		commons.EscapeLIKE(prefix))
 
 	// Query branches on the fork database.
-	apiURL := fmt.Sprintf("%s/%s/%s/main?q=%s",
-		DoltHubAPIBase, r.writeOwner, r.writeDB, url.QueryEscape(sql))
-
-	body, err := r.doGet(apiURL)
+	csv, err := r.readSQL(r.writeOwner, r.writeDB, "main", sql)
 	if err != nil {
 		return nil, fmt.Errorf("branches query failed: %w", err)
 	}
 
-	csv, err := JSONToCSV(body)
-	if err != nil {
-		return nil, err
-	}
-
 	lines := strings.Split(strings.TrimSpace(csv), "\n")
 	if len(lines) < 2 {
 		return nil, nil
@@ -197,7 +225,7 @@ func (r *RemoteDB) PushWithSync(_ io.Writer) error { return nil }
 // CanWildWest returns an error — the DoltHub REST API cannot push from a fork
 // to the upstream, so wild-west mode is not supported.
 func (r *RemoteDB) CanWildWest() error {
-	return fmt.Errorf("wild-west mode requires direct upstream access; switch to PR mode in settings")
+	return &commons.PermissionError{Message: "wild-west mode requires direct upstream access; switch to PR mode in settings"}
 }
 
 // Sync is a no-op for remote — reads always go to the upstream API and are
@@ -301,15 +329,11 @@ func (r *RemoteDB) execOnMain(sql string) error {
 
 // queryForkBranch runs a read-only SELECT against a specific branch on the fork.
 func (r *RemoteDB) queryForkBranch(sql, branch string) (string, error) {
-	apiURL := fmt.Sprintf("%s/%s/%s/%s?q=%s",
-		DoltHubAPIBase, r.writeOwner, r.writeDB, url.PathEscape(branch), url.QueryEscape(sql))
-
-	body, err := r.doGet(apiURL)
+	csv, err := r.readSQL(r.writeOwner, r.writeDB, branch, sql)
 	if err != nil {
 		return "", fmt.Errorf("queryForkBranch failed: %w", err)
 	}
-
-	return JSONToCSV(body)
+	return csv, nil
 }
 
 // parseDiffTables extracts table names from a dolt_diff CSV result.
@@ -436,33 +460,42 @@ func (r *RemoteDB) branchExists(branch string) bool {
 
 // --- HTTP helpers ---
 
+// doGet and doPost go through the circuit breaker: while it is open they
+// fail fast with *commons.UnavailableError instead of sending the request.
 func (r *RemoteDB) doGet(apiURL string) ([]byte, error) {
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
+	if err := r.breaker.allow(); err != nil {
 		return nil, err
 	}
-	if r.token != "" {
-		req.Header.Set("authorization", "token "+r.token)
-	}
+	body, err := r.sendGet(apiURL)
+	r.breaker.record(err)
+	return body, err
+}
 
-	resp, err := r.client.Do(req)
-	if err != nil {
+func (r *RemoteDB) doPost(apiURL string, payload []byte) ([]byte, error) {
+	if err := r.breaker.allow(); err != nil {
 		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
+	body, err := r.sendPost(apiURL, payload)
+	r.breaker.record(err)
+	return body, err
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (r *RemoteDB) sendGet(apiURL string) ([]byte, error) {
+	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, truncate(string(body), 200))
-	}
-	return body, nil
+	var body []byte
+	err = r.send(req, func(resp io.Reader) error {
+		body, err = io.ReadAll(resp)
+		return err
+	})
+	return body, err
 }
 
-func (r *RemoteDB) doPost(apiURL string, payload []byte) ([]byte, error) {
+func (r *RemoteDB) sendPost(apiURL string, payload []byte) ([]byte, error) {
+	// Request bodies go out uncompressed: DoltHub doesn't document
+	// accepting a gzipped request, and the payloads are small.
 	var bodyReader io.Reader
 	if payload != nil {
 		bodyReader = bytes.NewReader(payload)
@@ -472,28 +505,132 @@ func (r *RemoteDB) doPost(apiURL string, payload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if r.token != "" {
-		req.Header.Set("authorization", "token "+r.token)
-	}
 	if payload != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	var body []byte
+	err = r.send(req, func(resp io.Reader) error {
+		body, err = io.ReadAll(resp)
+		return err
+	})
+	return body, err
+}
 
-	resp, err := r.client.Do(req)
+// doQuery is doGet for a REST SQL read. The JSON response is converted
+// to CSV as it is read, into one buffer returned as a string, so the whole
+// result is held in memory; responseBody caps it at maxResponseBytes.
+// streamQuery is the unbuffered form.
+func (r *RemoteDB) doQuery(apiURL string) (string, error) {
+	if err := r.breaker.allow(); err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	defer func() { _ = resp.Body.Close() }()
+	var b strings.Builder
+	err = r.send(req, func(resp io.Reader) error {
+		return StreamJSONToCSV(resp, &b)
+	})
+	r.breaker.record(err)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// streamQuery is doQuery without the buffer: the response is converted to
+// CSV in a goroutine and piped to the returned reader, so memory use does
+// not grow with the result. It waits for the first output, so a request
+// that fails outright returns its error here rather than from Read.
+func (r *RemoteDB) streamQuery(apiURL string) (io.ReadCloser, error) {
+	if err := r.breaker.allow(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
+	pr, pw := io.Pipe()
+	go func() {
+		err := r.send(req, func(resp io.Reader) error {
+			return StreamJSONToCSV(resp, pw)
+		})
+		if errors.Is(err, io.ErrClosedPipe) {
+			err = nil // the caller closed the stream early
+		}
+		r.breaker.record(err)
+		_ = pw.CloseWithError(err)
+	}()
+	s := &remoteStream{Reader: bufio.NewReader(pr), pipe: pr}
+	if _, err := s.Peek(1); err != nil && err != io.EOF {
+		_ = pr.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// remoteStream is the reader streamQuery returns. Closing it stops the
+// conversion and releases the response.
+type remoteStream struct {
+	*bufio.Reader
+	pipe *io.PipeReader
+}
+
+func (s *remoteStream) Close() error { return s.pipe.Close() }
+
+// send performs req and passes a 2xx response body to read, gunzipped if
+// the server compressed it and capped at maxResponseBytes. A non-2xx
+// response is classified by httpError instead.
+func (r *RemoteDB) send(req *http.Request, read func(io.Reader) error) error {
+	if r.token != "" {
+		req.Header.Set("authorization", "token "+r.token)
+	}
+	// Asked for explicitly (rather than left to the transport) so responses
+	// are compressed through custom transports such as the Nango proxy too.
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		slog.Debug("dolthub request failed", "method", req.Method, "url", req.URL.String(), "duration", time.Since(start), "error", err)
+		return &commons.NetworkError{Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	r.rateLimits.Observe("dolthub", resp.Header, resp.StatusCode)
+
+	body, err := responseBody(resp)
+	if err == nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			msg, _ := io.ReadAll(io.LimitReader(body, 4096))
+			err = httpError(resp.StatusCode, msg)
+		} else {
+			err = read(body)
+		}
+	}
+	slog.Debug("dolthub request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", time.Since(start))
+	return err
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, truncate(string(body), 200))
+// RateLimits returns DoltHub's rate-limit state as of the last response
+// that reported one.
+func (r *RemoteDB) RateLimits() []commons.RateLimit { return r.rateLimits.RateLimits() }
+
+// httpError classifies a non-2xx DoltHub response by status code.
+func httpError(status int, body []byte) error {
+	msg := fmt.Sprintf("HTTP %d: %s", status, truncate(string(body), 200))
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &commons.PermissionError{Message: msg}
+	case status == http.StatusNotFound:
+		return &commons.NotFoundError{Message: msg}
+	case status == http.StatusConflict:
+		return &commons.ConflictError{Message: msg}
+	case status == http.StatusTooManyRequests || status >= 500:
+		return &commons.NetworkError{Err: errors.New(msg)}
+	default:
+		return errors.New(msg)
 	}
-	return body, nil
 }
 
 // pollOperation polls a DoltHub async write operation until it completes.
@@ -523,6 +660,7 @@ func (r *RemoteDB) pollOperation(operationName string) error {
 			}
 			lastErr = err
 			consecutiveErrors++
+			slog.Debug("polling write operation failed", "operation", operationName, "attempt", consecutiveErrors, "error", err)
 			// Fail fast: if every poll attempt errors, don't wait the full 2 minutes.
 			if consecutiveErrors >= 5 {
 				return fmt.Errorf("polling write operation %q: %w", operationName, lastErr)
@@ -574,9 +712,9 @@ func (r *RemoteDB) pollOperation(operationName string) error {
 	}
 
 	if lastErr != nil {
-		return fmt.Errorf("timed out waiting for write operation %q (last error: %w)", operationName, lastErr)
+		return &commons.NetworkError{Err: fmt.Errorf("timed out waiting for write operation %q (last error: %w)", operationName, lastErr)}
 	}
-	return fmt.Errorf("timed out waiting for write operation %q", operationName)
+	return &commons.NetworkError{Err: fmt.Errorf("timed out waiting for write operation %q", operationName)}
 }
 
 func truncate(s string, n int) string {
@@ -1,21 +1,28 @@
 package backend
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/httpfixture"
 )
 
 func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, func()) {
 	t.Helper()
 	srv := httptest.NewServer(handler)
-	old := DoltHubAPIBase
+	old, oldGraphQL := DoltHubAPIBase, DoltHubGraphQLURL
 	DoltHubAPIBase = srv.URL
+	DoltHubGraphQLURL = srv.URL + "/graphql"
 	return srv, func() {
-		DoltHubAPIBase = old
+		DoltHubAPIBase, DoltHubGraphQLURL = old, oldGraphQL
 		srv.Close()
 	}
 }
@@ -126,7 +133,7 @@ func TestRemoteDB_Exec(t *testing.T) {
 	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
 	db.client = srv.Client()
 
-	err := db.Exec("wl/alice/w-001", "wl claim: w-001", false,
+	err := db.Exec("wl/alice/w-001", "wl claim: w-001", commons.Signing{},
 		"UPDATE wanted SET status='claimed' WHERE id='w-001'")
 	if err != nil {
 		t.Fatalf("Exec error: %v", err)
@@ -167,7 +174,7 @@ func TestRemoteDB_Exec_SequentialMutation(t *testing.T) {
 	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
 	db.client = srv.Client()
 
-	err := db.Exec("wl/alice/w-001", "wl done: w-001", false,
+	err := db.Exec("wl/alice/w-001", "wl done: w-001", commons.Signing{},
 		"UPDATE wanted SET status='in_review' WHERE id='w-001'")
 	if err != nil {
 		t.Fatalf("Exec sequential error: %v", err)
@@ -190,7 +197,7 @@ func TestRemoteDB_Exec_MainBranch(t *testing.T) {
 	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
 	db.client = srv.Client()
 
-	err := db.Exec("", "wl claim: w-001", false,
+	err := db.Exec("", "wl claim: w-001", commons.Signing{},
 		"UPDATE wanted SET status='claimed' WHERE id='w-001'")
 	if err != nil {
 		t.Fatalf("Exec error: %v", err)
@@ -290,7 +297,7 @@ func TestRemoteDB_Exec_Poll(t *testing.T) {
 	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
 	db.client = srv.Client()
 
-	err := db.Exec("some-branch", "test", false, "UPDATE wanted SET status='open'")
+	err := db.Exec("some-branch", "test", commons.Signing{}, "UPDATE wanted SET status='open'")
 	if err != nil {
 		t.Fatalf("Exec with poll error: %v", err)
 	}
@@ -345,7 +352,7 @@ func TestRemoteDB_Exec_Poll_DoneResDetails(t *testing.T) {
 	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
 	db.client = srv.Client()
 
-	err := db.Exec("some-branch", "test", false, "INSERT INTO wanted VALUES (...)")
+	err := db.Exec("some-branch", "test", commons.Signing{}, "INSERT INTO wanted VALUES (...)")
 	if err != nil {
 		t.Fatalf("Exec with done+res_details poll error: %v", err)
 	}
@@ -370,6 +377,32 @@ func TestRemoteDB_Query_Error(t *testing.T) {
 	}
 }
 
+func TestRemoteDB_Query_ErrorClass(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusUnauthorized, func(err error) bool { var e *commons.PermissionError; return errors.As(err, &e) }},
+		{http.StatusForbidden, func(err error) bool { var e *commons.PermissionError; return errors.As(err, &e) }},
+		{http.StatusNotFound, func(err error) bool { var e *commons.NotFoundError; return errors.As(err, &e) }},
+		{http.StatusConflict, func(err error) bool { var e *commons.ConflictError; return errors.As(err, &e) }},
+		{http.StatusBadGateway, func(err error) bool { var e *commons.NetworkError; return errors.As(err, &e) }},
+	}
+	for _, tt := range tests {
+		srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(tt.status)
+		})
+		db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+		db.client = srv.Client()
+
+		_, err := db.Query("SELECT 1", "")
+		cleanup()
+		if err == nil || !tt.check(err) {
+			t.Errorf("HTTP %d: got %T (%v)", tt.status, err, err)
+		}
+	}
+}
+
 func TestRemoteDB_Sync_NoOp(t *testing.T) {
 	// Sync is a no-op for remote — DoltHub's hosted SQL API does not support
 	// remote operations (dolt_remotes, DOLT_FETCH). Reads go directly to the
@@ -660,3 +693,235 @@ func TestRemoteDB_Diff_NoChanges(t *testing.T) {
 		t.Errorf("expected 'no changes' message, got: %q", diff)
 	}
 }
+
+// TestRemoteDB_Replay runs RemoteDB against recorded DoltHub responses.
+// Re-record with WL_RECORD_FIXTURES=1 DOLTHUB_TOKEN=... against a fork
+// the token can write to.
+func TestRemoteDB_Replay(t *testing.T) {
+	client := httpfixture.Client(t, "testdata/remote_replay.json")
+	db := NewRemoteDBWithClient(client, "hop", "wl-commons", "wl-fixtures", "wl-commons", "pr")
+
+	csv, err := db.Query("SELECT id, title, status FROM wanted WHERE id = 'w-fx01'", "")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if want := "id,title,status\nw-fx01,\"Replay, record, repeat\",open\n"; csv != want {
+		t.Errorf("Query = %q, want %q", csv, want)
+	}
+
+	branches, err := db.Branches("wl/fixture-rig/")
+	if err != nil {
+		t.Fatalf("Branches: %v", err)
+	}
+	if want := []string{"wl/fixture-rig/w-fx01", "wl/fixture-rig/w-fx02"}; fmt.Sprint(branches) != fmt.Sprint(want) {
+		t.Errorf("Branches = %v, want %v", branches, want)
+	}
+
+	if err := db.Exec("main", "", commons.Signing{}, "UPDATE wanted SET status='claimed', claimed_by='fixture-rig' WHERE id='w-fx01'"); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+
+	missing := NewRemoteDBWithClient(client, "hop", "no-such-db", "wl-fixtures", "no-such-db", "pr")
+	_, err = missing.Query("SELECT id FROM wanted", "")
+	var nf *commons.NotFoundError
+	if !errors.As(err, &nf) {
+		t.Errorf("Query on missing database: err = %v, want NotFoundError", err)
+	}
+}
+
+func TestRemoteDB_RateLimits(t *testing.T) {
+	limited := false
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if limited {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "998")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"query_execution_status": "Success",
+			"schema_fragment":        []map[string]string{{"columnName": "ok", "columnType": "int"}},
+			"rows":                   []map[string]string{{"ok": "1"}},
+		})
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+	if got := db.RateLimits(); len(got) != 0 {
+		t.Fatalf("RateLimits before any request = %+v", got)
+	}
+
+	if _, err := db.Query("SELECT 1 AS ok", ""); err != nil {
+		t.Fatalf("Query error: %v", err)
+	}
+	got := db.RateLimits()
+	if len(got) != 1 || got[0].Provider != "dolthub" || got[0].Limit != 1000 || got[0].Remaining != 998 || got[0].Limited {
+		t.Fatalf("RateLimits = %+v, want dolthub 998/1000", got)
+	}
+
+	limited = true
+	if _, err := db.Query("SELECT 1 AS ok", ""); err == nil {
+		t.Fatal("expected error for 429 response")
+	}
+	got = db.RateLimits()
+	if len(got) != 1 || !got[0].Limited || got[0].Reset.IsZero() {
+		t.Errorf("RateLimits after 429 = %+v, want limited with a retry time", got)
+	}
+}
+
+func TestRemoteDB_Query_GzipResponse(t *testing.T) {
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_ = json.NewEncoder(zw).Encode(map[string]any{
+			"query_execution_status": "Success",
+			"schema_fragment":        []map[string]string{{"columnName": "id", "columnType": "varchar(20)"}},
+			"rows":                   []map[string]string{{"id": "w-001"}, {"id": "w-002"}},
+		})
+		_ = zw.Close()
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+	csv, err := db.Query("SELECT id FROM wanted", "")
+	if err != nil {
+		t.Fatalf("Query error: %v", err)
+	}
+	if csv != "id\nw-001\nw-002\n" {
+		t.Errorf("csv = %q", csv)
+	}
+}
+
+func TestRemoteDB_Query_ResponseTooLarge(t *testing.T) {
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		rows := make([]map[string]string, 100)
+		for i := range rows {
+			rows[i] = map[string]string{"id": fmt.Sprintf("w-%03d", i)}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"query_execution_status": "Success",
+			"schema_fragment":        []map[string]string{{"columnName": "id", "columnType": "varchar(20)"}},
+			"rows":                   rows,
+		})
+	})
+	defer cleanup()
+	old := maxResponseBytes
+	maxResponseBytes = 1 << 10
+	defer func() { maxResponseBytes = old }()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+	_, err := db.Query("SELECT id FROM wanted", "")
+	if err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Fatalf("err = %v, want a response size error", err)
+	}
+	var netErr *commons.NetworkError
+	if errors.As(err, &netErr) {
+		t.Error("an oversized response must not fall back to GraphQL")
+	}
+}
+
+func TestRemoteDB_SendPost_Uncompressed(t *testing.T) {
+	var got []string
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		got = append(got, r.Header.Get("Content-Encoding")+":"+strings.TrimSpace(string(data))[:9])
+		_, _ = w.Write([]byte(`{}`))
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+	large := []byte(`{"query":"` + strings.Repeat("x", 64<<10) + `"}`)
+	if _, err := db.doPost(srv.URL+"/graphql", large); err != nil {
+		t.Fatalf("doPost: %v", err)
+	}
+	if want := []string{`:{"query":`}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
+
+func TestRemoteDB_QueryStream(t *testing.T) {
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/fork-org/wl-commons/") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"query_execution_status": "Success",
+			"schema_fragment":        []map[string]string{{"columnName": "id", "columnType": "varchar(20)"}},
+			"rows":                   []map[string]string{{"id": "w-001"}, {"id": "w-002"}},
+		})
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+	var _ commons.RowStreamer = db
+
+	rc, err := db.QueryStream("SELECT id FROM wanted", "wl/alice/w-1")
+	if err != nil {
+		t.Fatalf("QueryStream error: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	out, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+	if string(out) != "id\nw-001\nw-002\n" {
+		t.Errorf("csv = %q", out)
+	}
+}
+
+func TestRemoteDB_QueryStream_Error(t *testing.T) {
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+
+	rc, err := db.QueryStream("SELECT id FROM wanted", "")
+	var notFound *commons.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("err = %v, want NotFoundError before any read", err)
+	}
+	if rc != nil {
+		t.Error("expected no stream on error")
+	}
+}
+
+func TestRemoteDB_QueryStream_CloseEarly(t *testing.T) {
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		rows := make([]map[string]string, 5000)
+		for i := range rows {
+			rows[i] = map[string]string{"id": fmt.Sprintf("w-%04d", i)}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"query_execution_status": "Success",
+			"schema_fragment":        []map[string]string{{"columnName": "id", "columnType": "varchar(20)"}},
+			"rows":                   rows,
+		})
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+
+	rows, err := commons.QueryRows(db, "SELECT id FROM wanted", "")
+	if err != nil {
+		t.Fatalf("QueryRows error: %v", err)
+	}
+	if !rows.Next() || rows.Map()["id"] != "w-0000" {
+		t.Fatalf("first row = %v, err = %v", rows.Map(), rows.Err())
+	}
+	if err := rows.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
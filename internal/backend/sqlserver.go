@@ -0,0 +1,295 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// DefaultSQLServerIdle is how long a managed sql-server may go unused before
+// its supervisor stops it.
+const DefaultSQLServerIdle = 10 * time.Minute
+
+// SQLServerLauncher returns the command that supervises a managed
+// `dolt sql-server` for dir on port (see RunSQLServer). The CLI sets it to
+// re-execute itself; while nil, LocalDB never starts a server.
+var SQLServerLauncher func(dir string, port int) *exec.Cmd
+
+const (
+	sqlServerStartTimeout = 30 * time.Second
+	sqlServerLockStale    = time.Minute
+)
+
+// SQLServerState is what a running supervisor records about its server in
+// the clone's .dolt directory.
+type SQLServerState struct {
+	PID       int       `json:"pid"`        // supervisor process
+	ServerPID int       `json:"server_pid"` // dolt sql-server process
+	Port      int       `json:"port"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Addr returns the server's listen address.
+func (s SQLServerState) Addr() string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(s.Port))
+}
+
+// sqlServerFile returns the path of one of the managed server's bookkeeping
+// files ("state", "lock", "used" or "log") inside dir's .dolt directory.
+func sqlServerFile(dir, name string) string {
+	return filepath.Join(dir, ".dolt", "wl-sql-server."+name)
+}
+
+// ReadSQLServerState returns the recorded state of dir's managed server, or
+// nil if none is recorded.
+func ReadSQLServerState(dir string) (*SQLServerState, error) {
+	data, err := os.ReadFile(sqlServerFile(dir, "state"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var st SQLServerState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("parsing sql-server state: %w", err)
+	}
+	return &st, nil
+}
+
+func writeSQLServerState(dir string, st SQLServerState) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	tmp := sqlServerFile(dir, "state.tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, sqlServerFile(dir, "state"))
+}
+
+// SQLServerLastUsed returns when dir's managed server was last used.
+func SQLServerLastUsed(dir string) time.Time {
+	info, err := os.Stat(sqlServerFile(dir, "used"))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// touchSQLServer marks dir's managed server as used now, resetting its idle
+// timer.
+func touchSQLServer(dir string) {
+	path := sqlServerFile(dir, "used")
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		_ = os.WriteFile(path, nil, 0o600)
+	}
+}
+
+// reachable reports whether something accepts connections at addr.
+func reachable(addr string) bool {
+	conn, err := net.DialTimeout("tcp", addr, 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// acquireSQLServerLock takes the lockfile that serializes server startup
+// for dir. A lock older than sqlServerLockStale is assumed abandoned.
+func acquireSQLServerLock(dir string) (release func(), err error) {
+	path := sqlServerFile(dir, "lock")
+	deadline := time.Now().Add(sqlServerStartTimeout + 5*time.Second)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, _ = fmt.Fprintf(f, "%d\n", os.Getpid())
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("creating sql-server lock: %w", err)
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > sqlServerLockStale {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for sql-server lock %s", path)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// freePort asks the kernel for an unused loopback port.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = l.Close() }()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// EnsureSQLServer returns the state of dir's managed server, starting one
+// through SQLServerLauncher if none is running. Startup is serialized by a
+// lockfile so concurrent wl processes share a single server.
+func EnsureSQLServer(dir string) (*SQLServerState, error) {
+	if st, err := ReadSQLServerState(dir); err == nil && st != nil && reachable(st.Addr()) {
+		touchSQLServer(dir)
+		return st, nil
+	}
+	if SQLServerLauncher == nil {
+		return nil, fmt.Errorf("managed sql-server is not available in this build")
+	}
+
+	release, err := acquireSQLServerLock(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Another process may have finished starting one while we waited.
+	if st, err := ReadSQLServerState(dir); err == nil && st != nil && reachable(st.Addr()) {
+		touchSQLServer(dir)
+		return st, nil
+	}
+	_ = os.Remove(sqlServerFile(dir, "state"))
+
+	port, err := freePort()
+	if err != nil {
+		return nil, fmt.Errorf("choosing sql-server port: %w", err)
+	}
+	cmd := SQLServerLauncher(dir, port)
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting sql-server supervisor: %w", err)
+	}
+	// The supervisor outlives us; don't leave a zombie if it exits early.
+	go func() { _ = cmd.Wait() }()
+
+	deadline := time.Now().Add(sqlServerStartTimeout)
+	for time.Now().Before(deadline) {
+		if st, err := ReadSQLServerState(dir); err == nil && st != nil && reachable(st.Addr()) {
+			touchSQLServer(dir)
+			return st, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	return nil, fmt.Errorf("sql-server did not start within %s (see %s)",
+		sqlServerStartTimeout, sqlServerFile(dir, "log"))
+}
+
+// StopSQLServer asks dir's managed server to shut down and waits for it to
+// go away. It reports whether a server was running.
+func StopSQLServer(dir string) (bool, error) {
+	st, err := ReadSQLServerState(dir)
+	if err != nil || st == nil {
+		return false, err
+	}
+	if proc, err := os.FindProcess(st.PID); err == nil {
+		_ = proc.Signal(syscall.SIGTERM)
+	}
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		if !reachable(st.Addr()) {
+			_ = os.Remove(sqlServerFile(dir, "state"))
+			return true, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return true, fmt.Errorf("sql-server on %s did not stop", st.Addr())
+}
+
+// RunSQLServer runs `dolt sql-server` for dir on port in the foreground,
+// records its state, and stops it once it has gone idle longer than idle or
+// the process receives SIGINT/SIGTERM. It is the body of the supervisor
+// process started by EnsureSQLServer.
+func RunSQLServer(dir string, port int, idle time.Duration) error {
+	logFile, err := os.OpenFile(sqlServerFile(dir, "log"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening sql-server log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	server := exec.Command("dolt", "sql-server", "--host", "127.0.0.1", "--port", strconv.Itoa(port))
+	server.Dir = dir
+	server.Stdout = logFile
+	server.Stderr = logFile
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("starting dolt sql-server: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- server.Wait() }()
+
+	st := SQLServerState{PID: os.Getpid(), ServerPID: server.Process.Pid, Port: port, StartedAt: time.Now()}
+	deadline := time.Now().Add(sqlServerStartTimeout)
+	for !reachable(st.Addr()) {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("dolt sql-server exited during startup: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			_ = server.Process.Kill()
+			return fmt.Errorf("dolt sql-server did not listen on %s", st.Addr())
+		}
+	}
+	touchSQLServer(dir)
+	if err := writeSQLServerState(dir, st); err != nil {
+		_ = server.Process.Kill()
+		return fmt.Errorf("recording sql-server state: %w", err)
+	}
+	defer func() {
+		if cur, _ := ReadSQLServerState(dir); cur != nil && cur.PID == st.PID {
+			_ = os.Remove(sqlServerFile(dir, "state"))
+		}
+	}()
+	slog.Info("sql-server started", "dir", dir, "addr", st.Addr(), "idle", idle)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(quit)
+	ticker := time.NewTicker(max(idle/10, time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("dolt sql-server exited: %v", err)
+		case <-quit:
+			return stopServerProcess(server, exited)
+		case <-ticker.C:
+			if time.Since(SQLServerLastUsed(dir)) > idle {
+				slog.Info("sql-server idle, stopping", "dir", dir, "addr", st.Addr())
+				return stopServerProcess(server, exited)
+			}
+		}
+	}
+}
+
+// stopServerProcess interrupts dolt sql-server, killing it if it hasn't
+// exited after a grace period.
+func stopServerProcess(server *exec.Cmd, exited <-chan error) error {
+	_ = server.Process.Signal(os.Interrupt)
+	select {
+	case <-exited:
+	case <-time.After(10 * time.Second):
+		_ = server.Process.Kill()
+		<-exited
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package backend
+
+import "os/exec"
+
+// detach is a no-op where sessions don't exist.
+func detach(*exec.Cmd) {}
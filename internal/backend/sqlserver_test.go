@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newCloneDir returns a temp directory shaped like a dolt clone.
+func newCloneDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".dolt"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSQLServerState_RoundTrip(t *testing.T) {
+	dir := newCloneDir(t)
+
+	st, err := ReadSQLServerState(dir)
+	if err != nil || st != nil {
+		t.Fatalf("ReadSQLServerState() = %v, %v; want nil, nil with no server", st, err)
+	}
+
+	want := SQLServerState{PID: 10, ServerPID: 11, Port: 3307, StartedAt: time.Now().Truncate(time.Second)}
+	if err := writeSQLServerState(dir, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadSQLServerState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PID != want.PID || got.ServerPID != want.ServerPID || got.Port != want.Port || !got.StartedAt.Equal(want.StartedAt) {
+		t.Errorf("state = %+v, want %+v", got, want)
+	}
+	if got.Addr() != "127.0.0.1:3307" {
+		t.Errorf("Addr() = %q", got.Addr())
+	}
+}
+
+func TestAcquireSQLServerLock(t *testing.T) {
+	dir := newCloneDir(t)
+
+	release, err := acquireSQLServerLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(sqlServerFile(dir, "lock")); err != nil {
+		t.Fatalf("lock file not created: %v", err)
+	}
+	release()
+	if _, err := os.Stat(sqlServerFile(dir, "lock")); !os.IsNotExist(err) {
+		t.Errorf("lock file still present after release: %v", err)
+	}
+}
+
+func TestAcquireSQLServerLock_Stale(t *testing.T) {
+	dir := newCloneDir(t)
+	path := sqlServerFile(dir, "lock")
+	if err := os.WriteFile(path, []byte("999999\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * sqlServerLockStale)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := acquireSQLServerLock(dir)
+	if err != nil {
+		t.Fatalf("expected an abandoned lock to be taken over: %v", err)
+	}
+	release()
+}
+
+func TestTouchSQLServer(t *testing.T) {
+	dir := newCloneDir(t)
+	if !SQLServerLastUsed(dir).IsZero() {
+		t.Fatal("expected no last-used time before first use")
+	}
+	touchSQLServer(dir)
+	if since := time.Since(SQLServerLastUsed(dir)); since > time.Minute {
+		t.Errorf("last used %s ago, want just now", since)
+	}
+}
+
+func TestStopSQLServer_NotRunning(t *testing.T) {
+	stopped, err := StopSQLServer(newCloneDir(t))
+	if err != nil || stopped {
+		t.Errorf("StopSQLServer() = %v, %v; want false, nil", stopped, err)
+	}
+}
+
+func TestEnsureSQLServer_NoLauncher(t *testing.T) {
+	old := SQLServerLauncher
+	SQLServerLauncher = nil
+	t.Cleanup(func() { SQLServerLauncher = old })
+
+	_, err := EnsureSQLServer(newCloneDir(t))
+	if err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Errorf("EnsureSQLServer() error = %v, want not available", err)
+	}
+}
+
+func TestLocalDB_SQLServerFallsBackToCLI(t *testing.T) {
+	old := SQLServerLauncher
+	SQLServerLauncher = nil
+	t.Cleanup(func() { SQLServerLauncher = old })
+
+	db := NewLocalDB(newCloneDir(t), "pr").UseSQLServer()
+	if db.sqlDB() != nil {
+		t.Error("expected no server connection when the server can't start")
+	}
+	if err := db.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+}
+
+func TestCSVRows(t *testing.T) {
+	got := csvRows("name\nwl/alice/w-1\nwl/alice/w-2\n")
+	if len(got) != 2 || got[0][0] != "wl/alice/w-1" || got[1][0] != "wl/alice/w-2" {
+		t.Errorf("csvRows = %v", got)
+	}
+	if got := csvRows("name\n"); got != nil {
+		t.Errorf("csvRows(header only) = %v, want nil", got)
+	}
+}
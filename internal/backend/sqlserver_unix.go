@@ -0,0 +1,14 @@
+//go:build unix
+
+package backend
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detach starts cmd in its own session so the supervisor survives the wl
+// process that launched it and isn't hit by the terminal's Ctrl-C.
+func detach(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,164 @@
+package backend
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// SlowQueryThreshold is the duration above which a query or exec is logged
+// as slow. Zero disables slow-query logging. Var so the CLI
+// (WL_SLOW_QUERY_MS) and tests can override.
+var SlowQueryThreshold = 2 * time.Second
+
+// DefaultStats collects timings for every query and exec run by LocalDB and
+// RemoteDB in this process.
+var DefaultStats = NewStats()
+
+// QueryStat aggregates the timings of one normalized statement.
+type QueryStat struct {
+	Backend string        // "local" or "remote"
+	Kind    string        // "query" or "exec"
+	SQL     string        // statement with literals replaced by ?
+	Count   int           // number of runs
+	Errors  int           // runs that returned an error
+	Total   time.Duration // summed duration of all runs
+	Max     time.Duration // slowest run
+}
+
+// Mean returns the average duration per run.
+func (s QueryStat) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Stats is a concurrency-safe collection of QueryStats keyed by backend,
+// kind and normalized SQL.
+type Stats struct {
+	mu    sync.Mutex
+	stats map[string]*QueryStat
+}
+
+// NewStats returns an empty Stats.
+func NewStats() *Stats {
+	return &Stats{stats: make(map[string]*QueryStat)}
+}
+
+// Record adds one run of sql to the statistics.
+func (s *Stats) Record(backend, kind, sql string, d time.Duration, err error) {
+	norm := NormalizeSQL(sql)
+	key := backend + "\x00" + kind + "\x00" + norm
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.stats[key]
+	if !ok {
+		st = &QueryStat{Backend: backend, Kind: kind, SQL: norm}
+		s.stats[key] = st
+	}
+	st.Count++
+	st.Total += d
+	if d > st.Max {
+		st.Max = d
+	}
+	if err != nil {
+		st.Errors++
+	}
+}
+
+// Top returns up to n statements ordered by total time, slowest first.
+// n <= 0 returns all of them.
+func (s *Stats) Top(n int) []QueryStat {
+	s.mu.Lock()
+	out := make([]QueryStat, 0, len(s.stats))
+	for _, st := range s.stats {
+		out = append(out, *st)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].SQL < out[j].SQL
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Reset discards all collected statistics.
+func (s *Stats) Reset() {
+	s.mu.Lock()
+	s.stats = make(map[string]*QueryStat)
+	s.mu.Unlock()
+}
+
+// observe records a finished query or exec in DefaultStats and logs it if
+// it exceeded SlowQueryThreshold.
+func observe(backend, kind, sql string, start time.Time, err error) {
+	d := time.Since(start)
+	DefaultStats.Record(backend, kind, sql, d, err)
+	if SlowQueryThreshold > 0 && d >= SlowQueryThreshold {
+		slog.Warn("slow query", "backend", backend, "kind", kind, "duration", d.Round(time.Millisecond), "sql", truncate(NormalizeSQL(sql), 300))
+	}
+}
+
+// NormalizeSQL collapses whitespace and replaces string and numeric
+// literals with ?, so runs of the same statement with different values
+// aggregate together.
+func NormalizeSQL(sql string) string {
+	var b strings.Builder
+	runes := []rune(sql)
+	space := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"':
+			// Skip to the closing quote, honoring '' and backslash escapes.
+			quote := r
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' {
+					i++
+					continue
+				}
+				if runes[i] == quote {
+					if i+1 < len(runes) && runes[i+1] == quote {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			writeNormalized(&b, "?", &space)
+		case unicode.IsDigit(r) && (i == 0 || !isIdentRune(runes[i-1])):
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+			writeNormalized(&b, "?", &space)
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+		default:
+			writeNormalized(&b, string(r), &space)
+		}
+	}
+	return b.String()
+}
+
+func writeNormalized(b *strings.Builder, s string, space *bool) {
+	if *space {
+		b.WriteByte(' ')
+		*space = false
+	}
+	b.WriteString(s)
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,118 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"SELECT id FROM wanted WHERE id = 'w-123'", "SELECT id FROM wanted WHERE id = ?"},
+		{"SELECT *\n  FROM wanted\n  LIMIT 50 OFFSET 100", "SELECT * FROM wanted LIMIT ? OFFSET ?"},
+		{"UPDATE wanted SET title = 'it''s \\'quoted\\'' WHERE priority >= 2.5", "UPDATE wanted SET title = ? WHERE priority >= ?"},
+		{"SELECT v2_col, t1.id FROM t1", "SELECT v2_col, t1.id FROM t1"},
+		{`SELECT "double" AS x`, "SELECT ? AS x"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeSQL(tt.in); got != tt.want {
+			t.Errorf("NormalizeSQL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStats_RecordAndTop(t *testing.T) {
+	s := NewStats()
+	s.Record("remote", "query", "SELECT * FROM wanted WHERE id = 'w-1'", 100*time.Millisecond, nil)
+	s.Record("remote", "query", "SELECT * FROM wanted WHERE id = 'w-2'", 300*time.Millisecond, errors.New("boom"))
+	s.Record("remote", "query", "SELECT COUNT(*) FROM rigs", 250*time.Millisecond, nil)
+	s.Record("local", "query", "SELECT COUNT(*) FROM rigs", 10*time.Millisecond, nil)
+
+	top := s.Top(0)
+	if len(top) != 3 {
+		t.Fatalf("Top(0) returned %d stats, want 3: %+v", len(top), top)
+	}
+	first := top[0]
+	if first.SQL != "SELECT * FROM wanted WHERE id = ?" || first.Count != 2 || first.Errors != 1 {
+		t.Errorf("top stat = %+v", first)
+	}
+	if first.Total != 400*time.Millisecond || first.Max != 300*time.Millisecond || first.Mean() != 200*time.Millisecond {
+		t.Errorf("top timings = total %v max %v mean %v", first.Total, first.Max, first.Mean())
+	}
+	if top[2].Backend != "local" {
+		t.Errorf("backends should aggregate separately, last = %+v", top[2])
+	}
+
+	if got := s.Top(1); len(got) != 1 || got[0].SQL != first.SQL {
+		t.Errorf("Top(1) = %+v", got)
+	}
+	s.Reset()
+	if got := s.Top(0); len(got) != 0 {
+		t.Errorf("after Reset, Top = %+v", got)
+	}
+}
+
+// observe swaps the default logger and threshold, so this test is not
+// parallel.
+func TestObserve_LogsSlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	oldLogger, oldThreshold := slog.Default(), SlowQueryThreshold
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer func() { slog.SetDefault(oldLogger); SlowQueryThreshold = oldThreshold }()
+
+	SlowQueryThreshold = time.Hour
+	observe("local", "query", "SELECT 'fast'", time.Now(), nil)
+	if buf.Len() != 0 {
+		t.Errorf("fast query was logged: %s", buf.String())
+	}
+
+	SlowQueryThreshold = time.Millisecond
+	observe("local", "query", "SELECT 'slow'", time.Now().Add(-time.Second), nil)
+	if out := buf.String(); !strings.Contains(out, "slow query") || !strings.Contains(out, "SELECT ?") {
+		t.Errorf("slow query log = %q", out)
+	}
+
+	SlowQueryThreshold = 0
+	buf.Reset()
+	observe("local", "query", "SELECT 'slow'", time.Now().Add(-time.Second), nil)
+	if buf.Len() != 0 {
+		t.Errorf("threshold 0 should disable logging: %s", buf.String())
+	}
+}
+
+func TestRemoteDB_RecordsQueryStats(t *testing.T) {
+	srv, cleanup := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"query_execution_status": "Success",
+			"schema_fragment":        []map[string]string{{"columnName": "n", "columnType": "int"}},
+			"rows":                   []map[string]any{{"n": "1"}},
+		})
+	})
+	defer cleanup()
+
+	db := NewRemoteDB("test-token", "upstream-org", "wl-commons", "fork-org", "wl-commons", "pr")
+	db.client = srv.Client()
+
+	const sql = "SELECT 7 AS stats_probe"
+	for range 2 {
+		if _, err := db.Query(sql, ""); err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+	}
+	for _, st := range DefaultStats.Top(0) {
+		if st.SQL == NormalizeSQL(sql) {
+			if st.Backend != "remote" || st.Kind != "query" || st.Count < 2 {
+				t.Errorf("stat = %+v", st)
+			}
+			return
+		}
+	}
+	t.Errorf("no stat recorded for %q", sql)
+}
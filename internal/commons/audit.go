@@ -0,0 +1,124 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxAuditLimit caps one page of the audit log.
+const maxAuditLimit = 200
+
+// AuditEntry is one commit of the audit log: who changed what, and when.
+type AuditEntry struct {
+	CommitHash string
+	RigHandle  string // the rig that made the change, per its chain entry, else the committer
+	Action     string // e.g. "claim", from a "wl claim: w-1" message; "" for other commits
+	WantedID   string // the item the commit touched; "" if the message names none
+	Message    string
+	Date       time.Time
+}
+
+// AuditFilter narrows and pages the audit log. The zero value returns the
+// first page of all commits, newest first.
+type AuditFilter struct {
+	WantedID string    // only commits touching this item
+	Rig      string    // only commits by this rig
+	Since    time.Time // only commits at or after Since; zero = all time
+	Limit    int       // page size; defaults to 50, capped at 200
+	Offset   int
+}
+
+// AuditPage is one page of the audit log.
+type AuditPage struct {
+	Entries []AuditEntry
+	// NextOffset is the offset of the next page, or 0 on the last page.
+	NextOffset int
+}
+
+// auditRigs maps each commit that appended a chain_meta entry to the rig
+// recorded in it.
+const auditRigs = "LEFT JOIN (SELECT to_commit, to_rig_handle FROM dolt_diff_chain_meta WHERE diff_type = 'added') m ON m.to_commit = l.commit_hash"
+
+// QueryAudit reads a page of the commit history, newest first. Rigs come
+// from the chain_meta entries of schema 1.3; databases without them fall
+// back to the dolt committer.
+func QueryAudit(db DB, f AuditFilter) (*AuditPage, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	offset := max(f.Offset, 0)
+
+	output, err := db.Query(auditQuery(f, auditRigs, "COALESCE(m.to_rig_handle, l.committer)", limit+1, offset), "")
+	if IsChainUnsupported(err) {
+		output, err = db.Query(auditQuery(f, "", "l.committer", limit+1, offset), "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying audit log: %w", err)
+	}
+
+	page := &AuditPage{}
+	for _, row := range parseSimpleCSV(output) {
+		e := AuditEntry{
+			CommitHash: row["commit_hash"],
+			RigHandle:  row["rig_handle"],
+			Message:    row["message"],
+		}
+		e.Action, e.WantedID = ParseCommitMessage(e.Message)
+		e.Date, _ = ParseDoltTime(row["date"])
+		page.Entries = append(page.Entries, e)
+	}
+	if len(page.Entries) > limit {
+		page.Entries = page.Entries[:limit]
+		page.NextOffset = offset + limit
+	}
+	return page, nil
+}
+
+// auditQuery builds the dolt_log query for f, attributing commits with
+// the rig expression over join.
+func auditQuery(f AuditFilter, join, rig string, limit, offset int) string {
+	var conds []string
+	if f.WantedID != "" {
+		id := EscapeLIKE(f.WantedID)
+		conds = append(conds, fmt.Sprintf("(l.message LIKE 'wl %%: %s' OR l.message LIKE 'wl %%: %s %%')", id, id))
+	}
+	if f.Rig != "" {
+		conds = append(conds, fmt.Sprintf("%s = '%s'", rig, EscapeSQL(f.Rig)))
+	}
+	if !f.Since.IsZero() {
+		conds = append(conds, fmt.Sprintf("l.date >= '%s'", f.Since.UTC().Format(time.DateTime)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	if join != "" {
+		join = "\n" + join
+	}
+	return fmt.Sprintf(`SELECT l.commit_hash, %s AS rig_handle, l.date, l.message
+FROM dolt_log l%s%s
+ORDER BY l.date DESC, l.commit_hash
+LIMIT %d OFFSET %d`, rig, join, where, limit, offset)
+}
+
+// ParseCommitMessage splits a "wl <action>: <wanted-id> ..." commit message
+// into its action and wanted ID. Other messages yield empty strings.
+func ParseCommitMessage(msg string) (action, wantedID string) {
+	rest, ok := strings.CutPrefix(msg, "wl ")
+	if !ok {
+		return "", ""
+	}
+	action, subject, ok := strings.Cut(rest, ": ")
+	if !ok || strings.Contains(action, " ") {
+		return "", ""
+	}
+	if id, _, _ := strings.Cut(subject, " "); IsWantedID(id) {
+		wantedID = id
+	}
+	return action, wantedID
+}
@@ -0,0 +1,96 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryAudit(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM dolt_log": "commit_hash,rig_handle,date,message\n" +
+			"c3,bob,2026-03-03 10:00:00,wl accept: w-1 (approval 1/2)\n" +
+			"c2,alice,2026-03-02 10:00:00,wl claim: w-1\n" +
+			"c1,root,2026-03-01 10:00:00,Initialize data repository\n",
+	}}
+	page, err := QueryAudit(db, AuditFilter{WantedID: "w-1", Rig: "bob", Limit: 2, Offset: 4})
+	if err != nil {
+		t.Fatalf("QueryAudit: %v", err)
+	}
+	if len(page.Entries) != 2 || page.NextOffset != 6 {
+		t.Fatalf("page = %+v, want 2 entries and next offset 6", page)
+	}
+	e := page.Entries[0]
+	if e.CommitHash != "c3" || e.RigHandle != "bob" || e.Action != "accept" || e.WantedID != "w-1" || e.Date.IsZero() {
+		t.Errorf("entry = %+v", e)
+	}
+	q := db.queries[0]
+	for _, want := range []string{"dolt_diff_chain_meta", "LIKE 'wl %: w-1'", "COALESCE(m.to_rig_handle, l.committer) = 'bob'", "LIMIT 3 OFFSET 4"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query missing %q:\n%s", want, q)
+		}
+	}
+}
+
+func TestQueryAudit_LastPage(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM dolt_log": "commit_hash,rig_handle,date,message\nc1,alice,2026-03-01 10:00:00,wl claim: w-1\n",
+	}}
+	page, err := QueryAudit(db, AuditFilter{})
+	if err != nil {
+		t.Fatalf("QueryAudit: %v", err)
+	}
+	if len(page.Entries) != 1 || page.NextOffset != 0 {
+		t.Errorf("page = %+v, want 1 entry and no next page", page)
+	}
+	if !strings.Contains(db.queries[0], "LIMIT 51 OFFSET 0") {
+		t.Errorf("query should default to 50 per page:\n%s", db.queries[0])
+	}
+}
+
+// noChainDB fails queries over chain_meta the way a pre-1.3 schema does.
+type noChainDB struct{ *fakeDB }
+
+func (d noChainDB) Query(sql, ref string) (string, error) {
+	if strings.Contains(sql, "chain_meta") {
+		d.queries = append(d.queries, sql)
+		return "", errors.New("table not found: dolt_diff_chain_meta")
+	}
+	return d.fakeDB.Query(sql, ref)
+}
+
+func TestQueryAudit_NoChainFallsBackToCommitter(t *testing.T) {
+	db := noChainDB{&fakeDB{results: map[string]string{
+		"FROM dolt_log": "commit_hash,rig_handle,date,message\nc1,alice,2026-03-01 10:00:00,wl claim: w-1\n",
+	}}}
+	page, err := QueryAudit(db, AuditFilter{Rig: "alice"})
+	if err != nil {
+		t.Fatalf("QueryAudit: %v", err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].RigHandle != "alice" {
+		t.Errorf("page = %+v", page)
+	}
+	if len(db.queries) != 2 || !strings.Contains(db.queries[1], "WHERE l.committer = 'alice'") {
+		t.Errorf("queries = %q, want a retry attributing by committer", db.queries)
+	}
+}
+
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		msg, action, id string
+	}{
+		{"wl claim: w-abc", "claim", "w-abc"},
+		{"wl accept: w-abc (partial, follow-up w-def)", "accept", "w-abc"},
+		{"wl post: Fix the bug", "post", ""},
+		{"wl accept-upstream: w-abc", "accept-upstream", "w-abc"},
+		{"Initialize data repository", "", ""},
+		{"wl config: tags registry", "config", ""},
+		{"wl claim: gt-0a1b2c3d", "claim", "gt-0a1b2c3d"},
+	}
+	for _, tt := range tests {
+		action, id := ParseCommitMessage(tt.msg)
+		if action != tt.action || id != tt.id {
+			t.Errorf("ParseCommitMessage(%q) = %q, %q; want %q, %q", tt.msg, action, id, tt.action, tt.id)
+		}
+	}
+}
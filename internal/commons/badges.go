@@ -0,0 +1,165 @@
+package commons
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// badgeTypeRe is the form of a badge type: a lower-case slug such as
+// "first-blood" or "security_champion".
+var badgeTypeRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ValidateBadgeType reports whether t can name a badge.
+func ValidateBadgeType(t string) error {
+	if !badgeTypeRe.MatchString(t) {
+		return fmt.Errorf("invalid badge type %q: use lower-case letters, digits, '-' and '_' (at most 64)", t)
+	}
+	return nil
+}
+
+// InsertBadgeDML returns the pure DML for awarding b. Evidence holds the
+// reason the badge was awarded.
+func InsertBadgeDML(b *BadgeRow) (string, error) {
+	switch {
+	case b.ID == "":
+		return "", fmt.Errorf("badge ID cannot be empty")
+	case b.RigHandle == "":
+		return "", fmt.Errorf("badge rig cannot be empty")
+	case strings.TrimSpace(b.Evidence) == "":
+		return "", fmt.Errorf("badge reason cannot be empty")
+	}
+	if err := ValidateBadgeType(b.BadgeType); err != nil {
+		return "", err
+	}
+
+	awardedAt := b.AwardedAt
+	if awardedAt == "" {
+		awardedAt = time.Now().UTC().Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprintf(`INSERT INTO badges (id, rig_handle, badge_type, awarded_at, evidence)
+VALUES ('%s', '%s', '%s', '%s', '%s')`,
+		EscapeSQL(b.ID), EscapeSQL(b.RigHandle), EscapeSQL(b.BadgeType), EscapeSQL(awardedAt),
+		EscapeSQL(b.Evidence)), nil
+}
+
+// QueryBadges returns the badges held by handle, newest first. A database
+// without a badges table has none.
+func QueryBadges(db DB, handle string) ([]BadgeRow, error) {
+	out, err := db.Query(fmt.Sprintf(`SELECT id, rig_handle, badge_type, COALESCE(awarded_at, '') AS awarded_at, COALESCE(evidence, '') AS evidence
+FROM badges
+WHERE rig_handle = '%s'
+ORDER BY awarded_at DESC, id`, EscapeSQL(handle)), "")
+	if err != nil {
+		if IsTableNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying badges: %w", err)
+	}
+	var badges []BadgeRow
+	for _, row := range parseSimpleCSV(out) {
+		badges = append(badges, BadgeRow{
+			ID:        row["id"],
+			RigHandle: row["rig_handle"],
+			BadgeType: row["badge_type"],
+			AwardedAt: row["awarded_at"],
+			Evidence:  row["evidence"],
+		})
+	}
+	return badges, nil
+}
+
+// BadgeCatalogEntry describes one badge type: what earns it and how many
+// rigs hold it.
+type BadgeCatalogEntry struct {
+	Type        string
+	Criteria    string // "" for a type awarded without a catalog entry
+	Holders     int
+	LastAwarded string
+}
+
+// QueryBadgeCatalog lists the badge types a wasteland knows about, sorted
+// by type: those described in the badge_catalog _meta key (a JSON object
+// mapping each type to its criteria) plus any type already awarded.
+func QueryBadgeCatalog(db DB) ([]BadgeCatalogEntry, error) {
+	out, err := db.Query("SELECT value FROM _meta WHERE `key` = 'badge_catalog'", "")
+	if err != nil {
+		return nil, fmt.Errorf("querying badge_catalog: %w", err)
+	}
+	byType := make(map[string]*BadgeCatalogEntry)
+	if rows := parseSimpleCSV(out); len(rows) > 0 && strings.TrimSpace(rows[0]["value"]) != "" {
+		var criteria map[string]string
+		if err := json.Unmarshal([]byte(rows[0]["value"]), &criteria); err != nil {
+			return nil, fmt.Errorf("parsing badge_catalog: want a JSON object of type to criteria: %w", err)
+		}
+		for t, c := range criteria {
+			byType[t] = &BadgeCatalogEntry{Type: t, Criteria: c}
+		}
+	}
+
+	out, err = db.Query(`SELECT badge_type, COUNT(DISTINCT rig_handle) AS holders, COALESCE(MAX(awarded_at), '') AS last_awarded
+FROM badges
+GROUP BY badge_type`, "")
+	if err != nil && !IsTableNotFound(err) {
+		return nil, fmt.Errorf("querying badge holders: %w", err)
+	}
+	for _, row := range parseSimpleCSV(out) {
+		e := byType[row["badge_type"]]
+		if e == nil {
+			e = &BadgeCatalogEntry{Type: row["badge_type"]}
+			byType[e.Type] = e
+		}
+		e.Holders, _ = strconv.Atoi(row["holders"])
+		e.LastAwarded = row["last_awarded"]
+	}
+
+	catalog := make([]BadgeCatalogEntry, 0, len(byType))
+	for _, t := range slices.Sorted(maps.Keys(byType)) {
+		catalog = append(catalog, *byType[t])
+	}
+	return catalog, nil
+}
+
+// RigRegistered reports whether handle has a row in the rigs table.
+func RigRegistered(db DB, handle string) (bool, error) {
+	out, err := db.Query(fmt.Sprintf("SELECT handle FROM rigs WHERE handle = '%s'", EscapeSQL(handle)), "")
+	if err != nil {
+		return false, fmt.Errorf("looking up rig %s: %w", handle, err)
+	}
+	return len(parseSimpleCSV(out)) > 0, nil
+}
+
+// populateBadges sets the badge types each leaderboard rig holds, oldest
+// first. A database without a badges table leaves them empty.
+func populateBadges(db DB, entries []LeaderboardEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	handles := make([]string, len(entries))
+	for i, e := range entries {
+		handles[i] = fmt.Sprintf("'%s'", EscapeSQL(e.RigHandle))
+	}
+	out, err := db.Query(fmt.Sprintf(`SELECT rig_handle, badge_type
+FROM badges
+WHERE rig_handle IN (%s)
+ORDER BY rig_handle, awarded_at`, strings.Join(handles, ",")), "")
+	if err != nil {
+		if IsTableNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	perRig := make(map[string][]string)
+	for _, row := range parseSimpleCSV(out) {
+		perRig[row["rig_handle"]] = append(perRig[row["rig_handle"]], row["badge_type"])
+	}
+	for i := range entries {
+		entries[i].Badges = perRig[entries[i].RigHandle]
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBadgeType(t *testing.T) {
+	t.Parallel()
+	for _, ok := range []string{"first-blood", "security_champion", "x", "v2"} {
+		if err := ValidateBadgeType(ok); err != nil {
+			t.Errorf("ValidateBadgeType(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "First", "-lead", "has space", "a'b", strings.Repeat("a", 65)} {
+		if err := ValidateBadgeType(bad); err == nil {
+			t.Errorf("ValidateBadgeType(%q) = nil, want error", bad)
+		}
+	}
+}
+
+func TestInsertBadgeDML(t *testing.T) {
+	t.Parallel()
+	dml, err := InsertBadgeDML(&BadgeRow{ID: "b-1", RigHandle: "alice", BadgeType: "bug-hunter", AwardedAt: "2026-03-01 10:00:00", Evidence: "found o'brien's race"})
+	if err != nil {
+		t.Fatalf("InsertBadgeDML: %v", err)
+	}
+	for _, want := range []string{"INSERT INTO badges", "'b-1'", "'alice'", "'bug-hunter'", "'2026-03-01 10:00:00'", "o''brien''s"} {
+		if !strings.Contains(dml, want) {
+			t.Errorf("DML missing %q:\n%s", want, dml)
+		}
+	}
+
+	for name, b := range map[string]BadgeRow{
+		"no id":     {RigHandle: "alice", BadgeType: "x", Evidence: "why"},
+		"no rig":    {ID: "b-1", BadgeType: "x", Evidence: "why"},
+		"no reason": {ID: "b-1", RigHandle: "alice", BadgeType: "x", Evidence: "  "},
+		"bad type":  {ID: "b-1", RigHandle: "alice", BadgeType: "Bad Type", Evidence: "why"},
+	} {
+		if _, err := InsertBadgeDML(&b); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestQueryBadges(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"FROM badges": "id,rig_handle,badge_type,awarded_at,evidence\nb-2,alice,mentor,2026-03-02,helped three newcomers\nb-1,alice,first-blood,2026-02-01,\n",
+	}}
+	badges, err := QueryBadges(db, "alice")
+	if err != nil {
+		t.Fatalf("QueryBadges: %v", err)
+	}
+	if len(badges) != 2 || badges[0].BadgeType != "mentor" || badges[0].Evidence != "helped three newcomers" {
+		t.Errorf("badges = %+v", badges)
+	}
+	if !strings.Contains(db.queries[0], "rig_handle = 'alice'") {
+		t.Errorf("query = %s", db.queries[0])
+	}
+}
+
+func TestQueryLeaderboard_Badges(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"GROUP BY":   "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,3,4.0,3.5,3.0\nbob,2,3.0,3.0,2.5\n",
+		"badge_type": "rig_handle,badge_type\nalice,first-blood\nalice,mentor\n",
+	}}
+	entries, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("QueryLeaderboard: %v", err)
+	}
+	if got := strings.Join(entries[0].Badges, ","); got != "first-blood,mentor" {
+		t.Errorf("alice badges = %q", got)
+	}
+	if len(entries[1].Badges) != 0 {
+		t.Errorf("bob badges = %v, want none", entries[1].Badges)
+	}
+}
+
+func TestQueryBadgeCatalog(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"badge_catalog":              "value\n\"{\"\"mentor\"\":\"\"Helped three newcomers land a first completion\"\",\"\"first-blood\"\":\"\"First validated completion\"\"}\"\n",
+		"COUNT(DISTINCT rig_handle)": "badge_type,holders,last_awarded\nmentor,2,2026-03-02 10:00:00\nbug-hunter,1,2026-02-10 09:00:00\n",
+	}}
+	catalog, err := QueryBadgeCatalog(db)
+	if err != nil {
+		t.Fatalf("QueryBadgeCatalog: %v", err)
+	}
+	want := []BadgeCatalogEntry{
+		{Type: "bug-hunter", Holders: 1, LastAwarded: "2026-02-10 09:00:00"},
+		{Type: "first-blood", Criteria: "First validated completion"},
+		{Type: "mentor", Criteria: "Helped three newcomers land a first completion", Holders: 2, LastAwarded: "2026-03-02 10:00:00"},
+	}
+	if len(catalog) != len(want) {
+		t.Fatalf("catalog = %+v", catalog)
+	}
+	for i := range want {
+		if catalog[i] != want[i] {
+			t.Errorf("catalog[%d] = %+v, want %+v", i, catalog[i], want[i])
+		}
+	}
+}
+
+func TestQueryBadgeCatalog_BadMeta(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"badge_catalog": "value\n[\"mentor\"]\n",
+	}}
+	if _, err := QueryBadgeCatalog(db); err == nil || !strings.Contains(err.Error(), "badge_catalog") {
+		t.Errorf("err = %v, want badge_catalog parse error", err)
+	}
+}
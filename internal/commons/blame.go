@@ -0,0 +1,79 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+)
+
+// blameFields lists the wanted columns attributed by BlameWanted, in
+// display order.
+var blameFields = []string{
+	"title", "description", "project", "type", "priority", "tags",
+	"posted_by", "claimed_by", "status", "effort_level", "evidence_url",
+	"sandbox_required",
+}
+
+// FieldBlame attributes the current value of one wanted field to the commit
+// that last changed it.
+type FieldBlame struct {
+	Field      string `json:"field"`
+	Value      string `json:"value"`
+	CommitHash string `json:"commit_hash"`
+	Committer  string `json:"committer"`
+	CommitDate string `json:"commit_date"`
+	Message    string `json:"message"`
+}
+
+// BlameWanted walks dolt_history_wanted for a wanted item and reports, for
+// each field, the commit that last changed its value.
+func BlameWanted(db DB, wantedID string) ([]FieldBlame, error) {
+	cols := make([]string, len(blameFields))
+	for i, f := range blameFields {
+		cols[i] = "h." + f
+	}
+	query := fmt.Sprintf(`SELECT %s, h.commit_hash, h.committer, h.commit_date, COALESCE(l.message, '') AS message
+FROM dolt_history_wanted h
+LEFT JOIN dolt_log l ON h.commit_hash = l.commit_hash
+WHERE h.id = '%s'
+ORDER BY h.commit_date ASC`, strings.Join(cols, ", "), EscapeSQL(wantedID))
+
+	output, err := db.Query(query, "")
+	if err != nil {
+		return nil, fmt.Errorf("querying history: %w", err)
+	}
+	rows := parseSimpleCSV(output)
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no history found for wanted item %s", wantedID)
+	}
+	return blameFromHistory(rows), nil
+}
+
+// blameFromHistory computes per-field attribution from history rows
+// ordered oldest first. A field is attributed to the first commit in which
+// it took its current value.
+func blameFromHistory(rows []map[string]string) []FieldBlame {
+	blame := make(map[string]FieldBlame, len(blameFields))
+	var prev map[string]string
+	for _, row := range rows {
+		for _, f := range blameFields {
+			if prev != nil && row[f] == prev[f] {
+				continue
+			}
+			blame[f] = FieldBlame{
+				Field:      f,
+				Value:      row[f],
+				CommitHash: row["commit_hash"],
+				Committer:  row["committer"],
+				CommitDate: row["commit_date"],
+				Message:    row["message"],
+			}
+		}
+		prev = row
+	}
+
+	result := make([]FieldBlame, 0, len(blameFields))
+	for _, f := range blameFields {
+		result = append(result, blame[f])
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBlameWanted(t *testing.T) {
+	t.Parallel()
+	header := strings.Join(blameFields, ",") + ",commit_hash,committer,commit_date,message\n"
+	db := &fakeDB{results: map[string]string{
+		"dolt_history_wanted": header +
+			"Fix bug,,gastown,bug,2,,alice,,open,medium,,0,aaa,alice,2026-01-01 10:00:00,wl post: w-1\n" +
+			"Fix bug,,gastown,bug,2,,alice,bob,claimed,medium,,0,bbb,bob,2026-01-02 10:00:00,wl claim: w-1\n" +
+			"Fix login bug,,gastown,bug,1,,alice,bob,claimed,medium,,0,ccc,mallory,2026-01-03 10:00:00,edit\n",
+	}}
+
+	blame, err := BlameWanted(db, "w-1")
+	if err != nil {
+		t.Fatalf("BlameWanted: %v", err)
+	}
+	if len(blame) != len(blameFields) {
+		t.Fatalf("got %d fields, want %d", len(blame), len(blameFields))
+	}
+
+	byField := map[string]FieldBlame{}
+	for _, b := range blame {
+		byField[b.Field] = b
+	}
+	tests := []struct {
+		field, value, commit, committer string
+	}{
+		{"title", "Fix login bug", "ccc", "mallory"},
+		{"priority", "1", "ccc", "mallory"},
+		{"claimed_by", "bob", "bbb", "bob"},
+		{"status", "claimed", "bbb", "bob"},
+		{"project", "gastown", "aaa", "alice"},
+	}
+	for _, tt := range tests {
+		got := byField[tt.field]
+		if got.Value != tt.value || got.CommitHash != tt.commit || got.Committer != tt.committer {
+			t.Errorf("%s = %+v, want value=%q commit=%q committer=%q", tt.field, got, tt.value, tt.commit, tt.committer)
+		}
+	}
+	if byField["status"].Message != "wl claim: w-1" {
+		t.Errorf("status message = %q", byField["status"].Message)
+	}
+	if !strings.Contains(db.queries[0], "h.id = 'w-1'") {
+		t.Errorf("query missing id filter: %s", db.queries[0])
+	}
+}
+
+func TestBlameWanted_NoHistory(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{}}
+	if _, err := BlameWanted(db, "w-missing"); err == nil || !strings.Contains(err.Error(), "no history") {
+		t.Fatalf("expected no history error, got: %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package commons
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// ChainTypeMutation marks chain_meta rows appended by mutations.
+const ChainTypeMutation = "mutation"
+
+// ChainEntry is one link of the mutation hash chain kept in chain_meta.
+// Every commit made through a Chained DB appends one, in the same commit,
+// whose Hash covers its parent's hash, so editing or deleting an earlier
+// entry breaks every later one.
+type ChainEntry struct {
+	Hash      string // chain_id: ChainHash(Parent, Digest, RigHandle)
+	Parent    string // parent_chain_id; "" for a root entry
+	Digest    string // digest of the rows the commit changed; see chainDigestSQL
+	RigHandle string
+	CreatedAt string
+}
+
+// chainDigestSQL returns a scalar subquery computing the digest of the
+// rows changed between two revisions: the SHA-256 of the DOLT_PATCH
+// statements dolt generates for the change, in order, leaving out
+// chain_meta itself. Dolt computes it both when the entry is written and
+// when wl verify recomputes it, so the two render rows the same way.
+func chainDigestSQL(from, to string) string {
+	return "(SELECT SHA2(COALESCE(CAST(JSON_ARRAYAGG(p.statement) AS CHAR), '[]'), 256) FROM " +
+		"(SELECT statement FROM DOLT_PATCH('" + EscapeSQL(from) + "', '" + EscapeSQL(to) + "') " +
+		"WHERE table_name <> 'chain_meta' ORDER BY statement_order) p)"
+}
+
+// ChainHash returns the hash identifying an entry.
+func ChainHash(parent, digest, rigHandle string) string {
+	sum := sha256.Sum256([]byte(parent + "\n" + digest + "\n" + rigHandle))
+	return hex.EncodeToString(sum[:])
+}
+
+// chainHeadQuery finds the newest entry nothing descends from. After
+// concurrent mutations are merged there can be several; new entries
+// continue from the newest.
+const chainHeadQuery = "SELECT c.chain_id FROM chain_meta c WHERE c.chain_type = '" + ChainTypeMutation + "' " +
+	"AND NOT EXISTS (SELECT 1 FROM chain_meta d WHERE d.parent_chain_id = c.chain_id) " +
+	"ORDER BY c.created_at DESC, c.chain_id LIMIT 1"
+
+// ChainEntriesQuery reads every mutation entry, oldest first.
+const ChainEntriesQuery = "SELECT chain_id, parent_chain_id, digest, rig_handle, created_at FROM chain_meta " +
+	"WHERE chain_type = '" + ChainTypeMutation + "' ORDER BY created_at, chain_id"
+
+// AppendChainDML returns the INSERT that records a new entry after
+// parent. It runs last in the commit's transaction: the digest covers the
+// working set's changes since HEAD, and chain_id is ChainHash of it,
+// computed by dolt. Backends that commit each statement on its own (the
+// DoltHub API) have no other changes left in the working set, so their
+// entries digest an empty change.
+func AppendChainDML(parent, rigHandle, hopURI string) string {
+	parentSQL := "NULL"
+	if parent != "" {
+		parentSQL = "'" + EscapeSQL(parent) + "'"
+	}
+	return fmt.Sprintf("INSERT INTO chain_meta (chain_id, chain_type, parent_chain_id, hop_uri, dolt_database, digest, rig_handle, created_at) "+
+		"SELECT SHA2(CONCAT('%s', '\\n', d.digest, '\\n', '%s'), 256), '%s', %s, '%s', DATABASE(), d.digest, '%s', NOW() "+
+		"FROM (SELECT %s AS digest) d",
+		EscapeSQL(parent), EscapeSQL(rigHandle), ChainTypeMutation, parentSQL, EscapeSQL(hopURI), EscapeSQL(rigHandle),
+		chainDigestSQL("HEAD", "WORKING"))
+}
+
+// IsChainUnsupported reports whether err means the database can't hold
+// the chain: no chain_meta table (minimal and custom schemas) or a
+// chain_meta from before schema 1.3 without the digest columns.
+func IsChainUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return IsTableNotFound(err) || strings.Contains(msg, "could not be found") || strings.Contains(msg, "unknown column")
+}
+
+// Chained wraps db so every Exec also appends a chain_meta entry for its
+// statements, in the same commit. Databases that can't hold the chain are
+// written to as before.
+func Chained(db DB, rigHandle, hopURI string) DB {
+	switch db.(type) {
+	case *chainedDB, *chainedStreamDB:
+		return db
+	}
+	c := &chainedDB{DB: db, rigHandle: rigHandle, hopURI: hopURI}
+	if s, ok := db.(RowStreamer); ok {
+		return &chainedStreamDB{chainedDB: c, RowStreamer: s}
+	}
+	return c
+}
+
+type chainedDB struct {
+	DB
+	rigHandle, hopURI string
+	unsupported       atomic.Bool // set once the database turns out not to hold the chain
+}
+
+// Unwrap returns the wrapped DB.
+func (d *chainedDB) Unwrap() DB { return d.DB }
+
+func (d *chainedDB) Exec(branch, commitMsg string, sign Signing, stmts ...string) error {
+	if d.unsupported.Load() || len(stmts) == 0 {
+		return d.DB.Exec(branch, commitMsg, sign, stmts...)
+	}
+	parent, err := d.head(branch)
+	if IsChainUnsupported(err) {
+		slog.Debug("hash chain unsupported by schema; committing without it", "error", err)
+		d.unsupported.Store(true)
+		return d.DB.Exec(branch, commitMsg, sign, stmts...)
+	}
+	if err != nil {
+		return fmt.Errorf("reading hash chain head: %w", err)
+	}
+	return d.DB.Exec(branch, commitMsg, sign, append(slices.Clone(stmts), AppendChainDML(parent, d.rigHandle, d.hopURI))...)
+}
+
+// head returns the chain head the commit will land on: the branch's if it
+// exists, otherwise main's, from which Exec creates the branch.
+func (d *chainedDB) head(branch string) (string, error) {
+	ref := ""
+	if branch != "" {
+		names, err := d.DB.Branches(branch)
+		if err != nil {
+			return "", err
+		}
+		if slices.Contains(names, branch) {
+			ref = branch
+		}
+	}
+	out, err := d.DB.Query(chainHeadQuery, ref)
+	if err != nil {
+		return "", err
+	}
+	rows := parseSimpleCSV(out)
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return rows[0]["chain_id"], nil
+}
+
+// chainedStreamDB is a chainedDB over a backend that can stream.
+type chainedStreamDB struct {
+	*chainedDB
+	RowStreamer
+}
+
+// ChainReport is the result of checking a hash chain.
+type ChainReport struct {
+	Entries  int
+	Roots    int      // entries without a parent; more than one means chains started concurrently
+	Heads    int      // entries nothing descends from; more than one means concurrent mutations
+	Problems []string // tampering: entries whose hash doesn't match, or whose parent is gone
+}
+
+// VerifyChain recomputes every entry's hash and checks that every parent
+// exists.
+func VerifyChain(entries []ChainEntry) *ChainReport {
+	r := &ChainReport{Entries: len(entries)}
+	byHash := make(map[string]bool, len(entries))
+	hasChild := map[string]bool{}
+	for _, e := range entries {
+		byHash[e.Hash] = true
+		if e.Parent != "" {
+			hasChild[e.Parent] = true
+		}
+	}
+	for _, e := range entries {
+		switch {
+		case ChainHash(e.Parent, e.Digest, e.RigHandle) != e.Hash:
+			r.Problems = append(r.Problems, fmt.Sprintf("entry %s (%s, %s): hash doesn't match its contents", short(e.Hash), e.RigHandle, e.CreatedAt))
+		case e.Parent != "" && !byHash[e.Parent]:
+			r.Problems = append(r.Problems, fmt.Sprintf("entry %s (%s, %s): parent %s is missing", short(e.Hash), e.RigHandle, e.CreatedAt, short(e.Parent)))
+		}
+		if e.Parent == "" {
+			r.Roots++
+		}
+		if !hasChild[e.Hash] {
+			r.Heads++
+		}
+	}
+	return r
+}
+
+// chainCommitsQuery finds the commits that added each entry, oldest first.
+// An entry shows up again in merge commits that bring it into a branch;
+// the oldest is the one that wrote it.
+const chainCommitsQuery = "SELECT to_chain_id, to_commit FROM dolt_diff_chain_meta " +
+	"WHERE diff_type = 'added' ORDER BY to_commit_date, to_commit"
+
+// VerifyChainDigests recomputes each entry's digest from the rows its
+// commit changed and returns the entries that don't match, and how many
+// couldn't be checked. An entry can't be checked when its commit isn't in
+// the history at ref, or holds several entries because a branch was
+// squashed, which merges their changes into one diff.
+func VerifyChainDigests(db DB, ref string, entries []ChainEntry) (problems []string, unchecked int, err error) {
+	out, err := db.Query(chainCommitsQuery, ref)
+	if err != nil {
+		return nil, 0, fmt.Errorf("finding chain entry commits: %w", err)
+	}
+	commitOf := map[string]string{}
+	perCommit := map[string]int{}
+	for _, row := range parseSimpleCSV(out) {
+		id, commit := row["to_chain_id"], row["to_commit"]
+		if _, seen := commitOf[id]; seen || commit == "" {
+			continue
+		}
+		commitOf[id] = commit
+		perCommit[commit]++
+	}
+
+	for _, e := range entries {
+		commit := commitOf[e.Hash]
+		if commit == "" || perCommit[commit] != 1 {
+			unchecked++
+			continue
+		}
+		out, err := db.Query("SELECT "+chainDigestSQL(commit+"~1", commit)+" AS digest", ref)
+		if err != nil {
+			return nil, 0, fmt.Errorf("recomputing digest of %s: %w", short(commit), err)
+		}
+		rows := parseSimpleCSV(out)
+		if len(rows) == 0 || rows[0]["digest"] != e.Digest {
+			problems = append(problems, fmt.Sprintf("entry %s (%s, %s): commit %s changed different rows than recorded",
+				short(e.Hash), e.RigHandle, e.CreatedAt, short(commit)))
+		}
+	}
+	return problems, unchecked, nil
+}
+
+// ParseChainEntries parses ChainEntriesQuery output.
+func ParseChainEntries(csvOut string) []ChainEntry {
+	var entries []ChainEntry
+	for _, row := range parseSimpleCSV(csvOut) {
+		entries = append(entries, ChainEntry{
+			Hash: row["chain_id"], Parent: row["parent_chain_id"], Digest: row["digest"],
+			RigHandle: row["rig_handle"], CreatedAt: row["created_at"],
+		})
+	}
+	return entries
+}
+
+func short(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
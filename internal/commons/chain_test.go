@@ -0,0 +1,186 @@
+package commons
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// chainRecorder is a fakeDB that records the refs queried and the
+// statements committed.
+type chainRecorder struct {
+	*fakeDB
+	refs  []string
+	execs [][]string
+}
+
+func (r *chainRecorder) Query(sql, ref string) (string, error) {
+	r.refs = append(r.refs, ref)
+	return r.fakeDB.Query(sql, ref)
+}
+
+func (r *chainRecorder) Exec(_, _ string, _ Signing, stmts ...string) error {
+	r.execs = append(r.execs, stmts)
+	return nil
+}
+
+func TestChained_AppendsEntry(t *testing.T) {
+	t.Parallel()
+	inner := &chainRecorder{fakeDB: &fakeDB{results: map[string]string{"FROM chain_meta": "chain_id\nabc123\n"}}}
+	db := Chained(inner, "alice", "hop://alice@example.com/alice/")
+
+	stmt := "UPDATE wanted SET status = 'claimed' WHERE id = 'w-1'"
+	if err := db.Exec("", "wl claim: w-1", Signing{}, stmt); err != nil {
+		t.Fatalf("Exec() error: %v", err)
+	}
+	if len(inner.execs) != 1 || len(inner.execs[0]) != 2 || inner.execs[0][0] != stmt {
+		t.Fatalf("execs = %q, want the statement plus a chain entry", inner.execs)
+	}
+	entry := inner.execs[0][1]
+	if want := AppendChainDML("abc123", "alice", "hop://alice@example.com/alice/"); entry != want {
+		t.Errorf("chain entry = %s, want %s", entry, want)
+	}
+	if !strings.Contains(entry, "DOLT_PATCH('HEAD', 'WORKING')") || !strings.Contains(entry, "CONCAT('abc123', '\\n', d.digest, '\\n', 'alice')") {
+		t.Errorf("entry should digest the working set's changes and hash them after its parent: %s", entry)
+	}
+}
+
+func TestChained_BranchHead(t *testing.T) {
+	t.Parallel()
+	inner := &chainRecorder{fakeDB: &fakeDB{branches: []string{"wl/alice/w-1"}}}
+	db := Chained(inner, "alice", "")
+
+	_ = db.Exec("wl/alice/w-1", "msg", Signing{}, "UPDATE wanted SET title = 'x'")
+	_ = db.Exec("wl/alice/w-2", "msg", Signing{}, "UPDATE wanted SET title = 'y'")
+	if strings.Join(inner.refs, ",") != "wl/alice/w-1," {
+		t.Errorf("head refs = %q, want the existing branch, then main for a new one", inner.refs)
+	}
+	if !strings.Contains(inner.execs[1][1], "parent_chain_id") || !strings.Contains(inner.execs[1][1], ", NULL,") {
+		t.Errorf("first entry should be a root: %s", inner.execs[1][1])
+	}
+}
+
+func TestChained_UnsupportedSchema(t *testing.T) {
+	t.Parallel()
+	inner := &chainRecorder{fakeDB: &fakeDB{err: errors.New("table not found: chain_meta")}}
+	db := Chained(inner, "alice", "")
+
+	for range 2 {
+		if err := db.Exec("", "msg", Signing{}, "DELETE FROM wanted WHERE id = 'w-1'"); err != nil {
+			t.Fatalf("Exec() error: %v", err)
+		}
+	}
+	if len(inner.refs) != 1 {
+		t.Errorf("head queried %d times, want once before giving up", len(inner.refs))
+	}
+	for _, stmts := range inner.execs {
+		if len(stmts) != 1 {
+			t.Errorf("execs = %q, want no chain entry", stmts)
+		}
+	}
+}
+
+func TestChained_HeadError(t *testing.T) {
+	t.Parallel()
+	inner := &chainRecorder{fakeDB: &fakeDB{err: errors.New("connection refused")}}
+	if err := Chained(inner, "alice", "").Exec("", "msg", Signing{}, "DELETE FROM wanted"); err == nil {
+		t.Fatal("expected error when the chain head can't be read")
+	}
+	if len(inner.execs) != 0 {
+		t.Error("nothing should be committed when the chain head can't be read")
+	}
+}
+
+func TestChained_Unwrap(t *testing.T) {
+	t.Parallel()
+	inner := &fakeDB{}
+	db := Chained(inner, "alice", "")
+	if u, ok := db.(interface{ Unwrap() DB }); !ok || u.Unwrap() != inner {
+		t.Error("Chained should unwrap to the inner DB")
+	}
+	if Chained(db, "bob", "") != db {
+		t.Error("Chained should not wrap twice")
+	}
+}
+
+func chainOf(rigs ...string) []ChainEntry {
+	var entries []ChainEntry
+	parent := ""
+	for i, rig := range rigs {
+		digest := fmt.Sprintf("%064x", i+1)
+		e := ChainEntry{Hash: ChainHash(parent, digest, rig), Parent: parent, Digest: digest, RigHandle: rig}
+		entries = append(entries, e)
+		parent = e.Hash
+	}
+	return entries
+}
+
+func TestVerifyChain(t *testing.T) {
+	t.Parallel()
+	intact := chainOf("alice", "bob", "alice")
+	if r := VerifyChain(intact); len(r.Problems) != 0 || r.Entries != 3 || r.Roots != 1 || r.Heads != 1 {
+		t.Errorf("intact chain report = %+v", r)
+	}
+
+	edited := chainOf("alice", "bob", "alice")
+	edited[1].RigHandle = "mallory"
+	if r := VerifyChain(edited); len(r.Problems) != 1 || !strings.Contains(r.Problems[0], "hash doesn't match") {
+		t.Errorf("edited entry report = %+v", r)
+	}
+
+	deleted := chainOf("alice", "bob", "alice")
+	deleted = append(deleted[:1], deleted[2:]...)
+	if r := VerifyChain(deleted); len(r.Problems) != 1 || !strings.Contains(r.Problems[0], "is missing") {
+		t.Errorf("deleted entry report = %+v", r)
+	}
+
+	forked := chainOf("alice", "bob")
+	digest := fmt.Sprintf("%064x", 99)
+	forked = append(forked, ChainEntry{Hash: ChainHash(forked[0].Hash, digest, "carol"), Parent: forked[0].Hash, Digest: digest, RigHandle: "carol"})
+	if r := VerifyChain(forked); len(r.Problems) != 0 || r.Heads != 2 {
+		t.Errorf("forked chain report = %+v, want 2 heads and no problems", r)
+	}
+}
+
+func TestVerifyChainDigests(t *testing.T) {
+	t.Parallel()
+	entries := chainOf("alice", "bob", "carol", "dave", "erin")
+	commits := "to_chain_id,to_commit\n" +
+		entries[0].Hash + ",c1\n" +
+		entries[1].Hash + ",c2\n" +
+		entries[2].Hash + ",c3\n" +
+		entries[3].Hash + ",c4\n" + entries[4].Hash + ",c4\n" + // squashed together
+		entries[0].Hash + ",c9\n" // a later merge bringing c1 in
+	db := &fakeDB{results: map[string]string{
+		"FROM dolt_diff_chain_meta": commits,
+		"DOLT_PATCH('c1~1', 'c1')":  "digest\n" + entries[0].Digest + "\n",
+		"DOLT_PATCH('c2~1', 'c2')":  "digest\n" + entries[1].Digest + "\n",
+		"DOLT_PATCH('c3~1', 'c3')":  "digest\n" + strings.Repeat("f", 64) + "\n", // rewritten
+	}}
+
+	problems, unchecked, err := VerifyChainDigests(db, "", entries)
+	if err != nil {
+		t.Fatalf("VerifyChainDigests() error: %v", err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "commit c3 changed different rows") {
+		t.Errorf("problems = %q, want only c3's entry", problems)
+	}
+	if unchecked != 2 {
+		t.Errorf("unchecked = %d, want the 2 squashed entries", unchecked)
+	}
+	for _, q := range db.queries {
+		if strings.Contains(q, "c9") {
+			t.Errorf("the merge commit should not be checked: %s", q)
+		}
+	}
+}
+
+func TestParseChainEntries(t *testing.T) {
+	t.Parallel()
+	out := "chain_id,parent_chain_id,digest,rig_handle,created_at\nh1,,d1,alice,2026-01-02 03:04:05\nh2,h1,d2,bob,2026-01-02 03:05:00\n"
+	got := ParseChainEntries(out)
+	if len(got) != 2 || got[0].Parent != "" || got[1].Parent != "h1" || got[1].RigHandle != "bob" {
+		t.Errorf("ParseChainEntries() = %+v", got)
+	}
+}
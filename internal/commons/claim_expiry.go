@@ -0,0 +1,164 @@
+package commons
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StaleClaim is a claimed wanted item whose last activity is older than
+// the wasteland's claim expiry.
+type StaleClaim struct {
+	ID        string
+	Title     string
+	PostedBy  string
+	ClaimedBy string
+	ActiveAt  time.Time // the latest of updated_at, its review comments and its completion
+}
+
+// QueryClaimExpiry reads how long a claim may go without activity from the
+// claim_expiry_days _meta key. A missing or invalid value means claims
+// never expire and is returned as 0.
+func QueryClaimExpiry(db DB) (time.Duration, error) {
+	output, err := db.Query("SELECT value FROM _meta WHERE `key` = 'claim_expiry_days'", "")
+	if err != nil {
+		return 0, fmt.Errorf("querying claim_expiry_days: %w", err)
+	}
+	rows := parseSimpleCSV(output)
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSpace(rows[0]["value"]))
+	if err != nil || days < 1 {
+		return 0, nil
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// QueryStaleClaims returns the claimed items on main whose last activity
+// is at least expiry before now, oldest first. Activity is the item's
+// updated_at, the newest review comment about it, and its completion
+// evidence, so a claimer who keeps commenting is not swept. Items without
+// a readable updated_at are left alone.
+func QueryStaleClaims(db DB, expiry time.Duration, now time.Time) ([]StaleClaim, error) {
+	if expiry <= 0 {
+		return nil, nil
+	}
+	output, err := db.Query("SELECT id, title, COALESCE(posted_by, '') AS posted_by, COALESCE(claimed_by, '') AS claimed_by, "+
+		"COALESCE(updated_at, '') AS updated_at FROM wanted WHERE status = 'claimed' ORDER BY updated_at, id", "")
+	if err != nil {
+		return nil, fmt.Errorf("querying claimed items: %w", err)
+	}
+	var candidates []StaleClaim
+	for _, row := range parseSimpleCSV(output) {
+		updated, ok := ParseDoltTime(row["updated_at"])
+		if !ok || now.Sub(updated) < expiry {
+			continue
+		}
+		candidates = append(candidates, StaleClaim{
+			ID:        row["id"],
+			Title:     row["title"],
+			PostedBy:  row["posted_by"],
+			ClaimedBy: row["claimed_by"],
+			ActiveAt:  updated,
+		})
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.ID
+	}
+	activity, err := queryClaimActivity(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	var out []StaleClaim
+	for _, c := range candidates {
+		if at, ok := activity[c.ID]; ok && at.After(c.ActiveAt) {
+			c.ActiveAt = at
+		}
+		if now.Sub(c.ActiveAt) >= expiry {
+			out = append(out, c)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b StaleClaim) int { return a.ActiveAt.Compare(b.ActiveAt) })
+	return out, nil
+}
+
+// queryClaimActivity returns the newest review comment or completion of
+// each of ids. Tables a minimal wasteland doesn't have are skipped.
+func queryClaimActivity(db DB, ids []string) (map[string]time.Time, error) {
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		quoted[i] = "'" + EscapeSQL(id) + "'"
+	}
+	in := strings.Join(quoted, ", ")
+	activity := make(map[string]time.Time)
+	for _, q := range []string{
+		"SELECT wanted_id, MAX(completed_at) AS active_at FROM completions WHERE wanted_id IN (" + in + ") GROUP BY wanted_id",
+		"SELECT wanted_id, MAX(created_at) AS active_at FROM review_comments WHERE wanted_id IN (" + in + ") GROUP BY wanted_id",
+	} {
+		output, err := db.Query(q, "")
+		if IsTableNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("querying claim activity: %w", err)
+		}
+		for _, row := range parseSimpleCSV(output) {
+			at, ok := ParseDoltTime(row["active_at"])
+			if !ok {
+				continue
+			}
+			if prev, seen := activity[row["wanted_id"]]; !seen || at.After(prev) {
+				activity[row["wanted_id"]] = at
+			}
+		}
+	}
+	return activity, nil
+}
+
+// ExpireClaimDML reverts a stale claim to open. It only matches while the
+// item is still claimed by the same rig, so a claim that changed hands
+// since it was read is left alone.
+func ExpireClaimDML(wantedID, claimedBy string) string {
+	return fmt.Sprintf("UPDATE wanted SET claimed_by=NULL, status='open', updated_at=NOW() WHERE id='%s' AND status='claimed' AND claimed_by='%s'",
+		EscapeSQL(wantedID), EscapeSQL(claimedBy))
+}
+
+// ClaimNearExpiry reports whether a claim of the given age is in the last
+// quarter of its expiry window, or past it. It is always false when claims
+// don't expire.
+func ClaimNearExpiry(age, expiry time.Duration) bool {
+	return expiry > 0 && age >= expiry-expiry/4
+}
+
+// ClaimAgeLabel renders how long a claim has gone without activity and,
+// when claims expire, how long it has left, e.g. "12d idle, expires in 2d".
+func ClaimAgeLabel(age, expiry time.Duration) string {
+	label := formatIdle(age) + " idle"
+	switch {
+	case expiry <= 0:
+		return label
+	case age >= expiry:
+		return label + ", expired"
+	default:
+		return label + ", expires in " + formatIdle(expiry-age)
+	}
+}
+
+func formatIdle(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
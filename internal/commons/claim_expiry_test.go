@@ -0,0 +1,114 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryClaimExpiry(t *testing.T) {
+	tests := []struct {
+		csv  string
+		want time.Duration
+	}{
+		{csv: "value\n", want: 0},
+		{csv: "value\n14\n", want: 14 * 24 * time.Hour},
+		{csv: "value\n0\n", want: 0},
+		{csv: "value\nsoon\n", want: 0},
+	}
+	for _, tc := range tests {
+		db := &fakeDB{results: map[string]string{"claim_expiry_days": tc.csv}}
+		got, err := QueryClaimExpiry(db)
+		if err != nil || got != tc.want {
+			t.Errorf("QueryClaimExpiry(%q) = %v, %v; want %v", tc.csv, got, err, tc.want)
+		}
+	}
+}
+
+func TestQueryStaleClaims(t *testing.T) {
+	db := &fakeDB{results: map[string]string{"FROM wanted WHERE status = 'claimed'": "id,title,posted_by,claimed_by,updated_at\n" +
+		"w-old,Old work,alice,bob,2026-01-01 00:00:00\n" +
+		"w-new,New work,alice,carol,2026-01-14 00:00:00\n" +
+		"w-bad,No date,alice,dave,\n"}}
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	got, err := QueryStaleClaims(db, 7*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("QueryStaleClaims: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "w-old" || got[0].ClaimedBy != "bob" || got[0].PostedBy != "alice" {
+		t.Errorf("stale claims = %+v, want only w-old", got)
+	}
+
+	if got, err := QueryStaleClaims(db, 0, now); err != nil || got != nil {
+		t.Errorf("no expiry: %v, %v; want nothing", got, err)
+	}
+}
+
+func TestQueryStaleClaims_Activity(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM wanted WHERE status = 'claimed'": "id,title,posted_by,claimed_by,updated_at\n" +
+			"w-talk,Commented,alice,bob,2026-01-01 00:00:00\n" +
+			"w-done,Evidence,alice,carol,2026-01-01 00:00:00\n" +
+			"w-idle,Idle,alice,dave,2026-01-02 00:00:00\n",
+		"FROM review_comments": "wanted_id,active_at\nw-talk,2026-01-13 00:00:00\nw-idle,2026-01-03 00:00:00\n",
+		"FROM completions":     "wanted_id,active_at\nw-done,2026-01-12 00:00:00\n",
+	}}
+	now := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	got, err := QueryStaleClaims(db, 7*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("QueryStaleClaims: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "w-idle" {
+		t.Fatalf("stale claims = %+v, want only w-idle", got)
+	}
+	if want := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC); !got[0].ActiveAt.Equal(want) {
+		t.Errorf("active at = %v, want the newest comment %v", got[0].ActiveAt, want)
+	}
+}
+
+func TestExpireClaimDML(t *testing.T) {
+	got := ExpireClaimDML("w-1", "bob")
+	for _, want := range []string{"status='open'", "claimed_by=NULL", "id='w-1'", "status='claimed'", "claimed_by='bob'"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ExpireClaimDML missing %q: %s", want, got)
+		}
+	}
+}
+
+func TestClaimNearExpiry(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		age, expiry time.Duration
+		want        bool
+	}{
+		{age: 5 * day, expiry: 0, want: false},
+		{age: 5 * day, expiry: 14 * day, want: false},
+		{age: 11 * day, expiry: 14 * day, want: true},
+		{age: 20 * day, expiry: 14 * day, want: true},
+	}
+	for _, tc := range tests {
+		if got := ClaimNearExpiry(tc.age, tc.expiry); got != tc.want {
+			t.Errorf("ClaimNearExpiry(%v, %v) = %v, want %v", tc.age, tc.expiry, got, tc.want)
+		}
+	}
+}
+
+func TestClaimAgeLabel(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		age, expiry time.Duration
+		want        string
+	}{
+		{age: 3 * day, expiry: 0, want: "3d idle"},
+		{age: 12 * day, expiry: 14 * day, want: "12d idle, expires in 2d"},
+		{age: 13*day + 20*time.Hour, expiry: 14 * day, want: "13d idle, expires in 4h"},
+		{age: 20 * day, expiry: 14 * day, want: "20d idle, expired"},
+	}
+	for _, tc := range tests {
+		if got := ClaimAgeLabel(tc.age, tc.expiry); got != tc.want {
+			t.Errorf("ClaimAgeLabel(%v, %v) = %q, want %q", tc.age, tc.expiry, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QueueEntry is one rig waiting in an item's claim queue, recorded in the
+// optional claim_queue table. When the claim is released the first entry
+// is up next; with AutoClaim set the claim passes to it directly.
+type QueueEntry struct {
+	WantedID  string
+	RigHandle string
+	AutoClaim bool
+	QueuedAt  string
+}
+
+// QueryClaimQueue returns the claim queue of a wanted item at ref, first
+// in line first. A database without the claim_queue table has no queues.
+func QueryClaimQueue(db DB, wantedID, ref string) ([]QueueEntry, error) {
+	query := fmt.Sprintf("SELECT wanted_id, rig_handle, COALESCE(auto_claim, 0) AS auto_claim, COALESCE(queued_at, '') AS queued_at "+
+		"FROM claim_queue WHERE wanted_id = '%s' ORDER BY queued_at, rig_handle", EscapeSQL(wantedID))
+	output, err := db.Query(query, ref)
+	if err != nil {
+		if IsTableNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying claim queue: %w", err)
+	}
+	var out []QueueEntry
+	for _, row := range parseSimpleCSV(output) {
+		if row["rig_handle"] == "" {
+			continue
+		}
+		auto := row["auto_claim"]
+		out = append(out, QueueEntry{
+			WantedID:  row["wanted_id"],
+			RigHandle: row["rig_handle"],
+			AutoClaim: auto == "1" || strings.EqualFold(auto, "true"),
+			QueuedAt:  row["queued_at"],
+		})
+	}
+	return out, nil
+}
+
+// upNextColumns is dashboardColumns qualified for a claim_queue join.
+const upNextColumns = "w.id, w.title, COALESCE(w.project,'') as project, COALESCE(w.type,'') as type, w.priority, " +
+	"COALESCE(w.posted_by,'') as posted_by, COALESCE(w.claimed_by,'') as claimed_by, w.status, COALESCE(w.effort_level,'medium') as effort_level"
+
+// QueryUpNext returns the open items whose claim queue handle is first in,
+// i.e. claims that were released while handle was waiting for them.
+func QueryUpNext(db DB, handle string) ([]WantedSummary, error) {
+	query := fmt.Sprintf("SELECT "+upNextColumns+" "+
+		"FROM claim_queue q JOIN wanted w ON w.id = q.wanted_id "+
+		"WHERE q.rig_handle = '%[1]s' AND w.status = 'open' AND NOT EXISTS ("+
+		"SELECT 1 FROM claim_queue q2 WHERE q2.wanted_id = q.wanted_id AND "+
+		"(q2.queued_at < q.queued_at OR (q2.queued_at = q.queued_at AND q2.rig_handle < q.rig_handle))) "+
+		"ORDER BY w.priority ASC, w.id", EscapeSQL(handle))
+	output, err := db.Query(query, "")
+	if err != nil {
+		if IsTableNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying up-next items: %w", err)
+	}
+	var out []WantedSummary
+	for _, row := range parseSimpleCSV(output) {
+		out = append(out, wantedSummaryFromRow(row))
+	}
+	return out, nil
+}
+
+// EnqueueClaimDML adds rigHandle to the claim queue of wantedID. It only
+// inserts while the item is claimed by another rig.
+func EnqueueClaimDML(wantedID, rigHandle string, autoClaim bool) string {
+	auto := 0
+	if autoClaim {
+		auto = 1
+	}
+	return fmt.Sprintf("INSERT INTO claim_queue (wanted_id, rig_handle, auto_claim, queued_at) SELECT '%s', '%s', %d, NOW() FROM wanted WHERE id='%s' AND status='claimed' AND claimed_by <> '%s'",
+		EscapeSQL(wantedID), EscapeSQL(rigHandle), auto, EscapeSQL(wantedID), EscapeSQL(rigHandle))
+}
+
+// LeaveClaimQueueDML removes rigHandle from the claim queue of wantedID.
+func LeaveClaimQueueDML(wantedID, rigHandle string) string {
+	return fmt.Sprintf("DELETE FROM claim_queue WHERE wanted_id='%s' AND rig_handle='%s'",
+		EscapeSQL(wantedID), EscapeSQL(rigHandle))
+}
+
+// PassClaimDML hands a just-released claim to the first queued rig: it
+// claims the item for next and takes next out of the queue. It must run
+// after the statement that reopens the item, in the same commit.
+func PassClaimDML(wantedID string, next QueueEntry) []string {
+	return []string{
+		ClaimWantedDML(wantedID, next.RigHandle),
+		LeaveClaimQueueDML(wantedID, next.RigHandle),
+	}
+}
+
+// QueueSummary renders a claim queue as "bob (auto), carol".
+func QueueSummary(queue []QueueEntry) string {
+	names := make([]string, len(queue))
+	for i, e := range queue {
+		names[i] = e.RigHandle
+		if e.AutoClaim {
+			names[i] += " (auto)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,67 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryClaimQueue(t *testing.T) {
+	db := &fakeDB{results: map[string]string{"FROM claim_queue": "wanted_id,rig_handle,auto_claim,queued_at\n" +
+		"w-1,bob,1,2026-01-01 00:00:00\n" +
+		"w-1,carol,0,2026-01-02 00:00:00\n"}}
+
+	got, err := QueryClaimQueue(db, "w-1", "")
+	if err != nil {
+		t.Fatalf("QueryClaimQueue: %v", err)
+	}
+	if len(got) != 2 || got[0].RigHandle != "bob" || !got[0].AutoClaim || got[1].AutoClaim {
+		t.Errorf("queue = %+v", got)
+	}
+	if !strings.Contains(db.queries[0], "wanted_id = 'w-1'") {
+		t.Errorf("query = %q", db.queries[0])
+	}
+	if s := QueueSummary(got); s != "bob (auto), carol" {
+		t.Errorf("QueueSummary = %q", s)
+	}
+}
+
+func TestQueryClaimQueue_NoTable(t *testing.T) {
+	db := &fakeDB{err: errors.New("table not found: claim_queue")}
+	if got, err := QueryClaimQueue(db, "w-1", ""); err != nil || got != nil {
+		t.Errorf("QueryClaimQueue = %v, %v; want nothing", got, err)
+	}
+	if got, err := QueryUpNext(db, "bob"); err != nil || got != nil {
+		t.Errorf("QueryUpNext = %v, %v; want nothing", got, err)
+	}
+}
+
+func TestQueryUpNext(t *testing.T) {
+	db := &fakeDB{results: map[string]string{"FROM claim_queue q JOIN wanted": "id,title,project,type,priority,posted_by,claimed_by,status,effort_level\n" +
+		"w-1,Busy,gt,feature,1,alice,,open,medium\n"}}
+
+	got, err := QueryUpNext(db, "bob")
+	if err != nil {
+		t.Fatalf("QueryUpNext: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "w-1" || got[0].Priority != 1 {
+		t.Errorf("up next = %+v", got)
+	}
+	if !strings.Contains(db.queries[0], "q.rig_handle = 'bob'") || !strings.Contains(db.queries[0], "w.status = 'open'") {
+		t.Errorf("query = %q", db.queries[0])
+	}
+}
+
+func TestClaimQueueDML(t *testing.T) {
+	enqueue := EnqueueClaimDML("w-1", "bob", true)
+	for _, want := range []string{"INSERT INTO claim_queue", "'w-1', 'bob', 1", "status='claimed'", "claimed_by <> 'bob'"} {
+		if !strings.Contains(enqueue, want) {
+			t.Errorf("EnqueueClaimDML missing %q: %s", want, enqueue)
+		}
+	}
+
+	pass := PassClaimDML("w-1", QueueEntry{RigHandle: "bob"})
+	if len(pass) != 2 || pass[0] != ClaimWantedDML("w-1", "bob") || pass[1] != LeaveClaimQueueDML("w-1", "bob") {
+		t.Errorf("PassClaimDML = %v", pass)
+	}
+}
@@ -4,13 +4,12 @@
 package commons
 
 import (
-	"crypto/rand"
 	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -25,7 +24,7 @@ type DB interface {
 
 	// Exec runs DML statements and auto-commits on the given branch.
 	// branch: "" = main, "name" = named branch (created from main if needed).
-	Exec(branch, commitMsg string, signed bool, stmts ...string) error
+	Exec(branch, commitMsg string, sign Signing, stmts ...string) error
 
 	// Branches returns branch names matching prefix.
 	Branches(prefix string) ([]string, error)
@@ -167,6 +166,7 @@ type WantedItem struct {
 	Status          string
 	EffortLevel     string
 	SandboxRequired bool
+	ParentID        string // item this one follows up after a partial accept ("" if none)
 	CreatedAt       string
 	UpdatedAt       string
 }
@@ -215,10 +215,63 @@ type ConflictError struct{ Message string }
 
 func (e *ConflictError) Error() string { return e.Message }
 
-// isNothingToCommit returns true if the error indicates DOLT_COMMIT found no
+// NotFoundError indicates that a wanted item, stamp, branch or other named
+// record does not exist.
+type NotFoundError struct{ Message string }
+
+func (e *NotFoundError) Error() string { return e.Message }
+
+// PermissionError indicates the rig may not perform an operation, either by
+// protocol rule (e.g. accepting its own completion) or because a remote
+// rejected its credentials.
+type PermissionError struct{ Message string }
+
+func (e *PermissionError) Error() string { return e.Message }
+
+// NetworkError indicates a failed exchange with a remote: an unreachable
+// API, or a dolt push, pull or fetch that did not complete.
+type NetworkError struct{ Err error }
+
+func (e *NetworkError) Error() string { return e.Err.Error() }
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+// UnavailableError is returned without contacting a remote whose recent
+// calls kept failing: the backend's circuit breaker is open for RetryIn.
+// It wraps the failure that opened the circuit, so it also matches
+// *NetworkError.
+type UnavailableError struct {
+	RetryIn time.Duration
+	Err     error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("backend unavailable, retry in %ds", int(math.Ceil(e.RetryIn.Seconds())))
+}
+func (e *UnavailableError) Unwrap() error { return e.Err }
+
+// RemoteError classifies err from a failed remote operation using the
+// operation's output: authentication and authorization failures become a
+// *PermissionError, merge conflicts and rejected non-fast-forward pushes a
+// *ConflictError, and everything else a *NetworkError.
+func RemoteError(err error, output string) error {
+	lower := strings.ToLower(output)
+	for _, s := range []string{"permission denied", "unauthorized", "forbidden", "not authorized", "authentication"} {
+		if strings.Contains(lower, s) {
+			return &PermissionError{Message: err.Error()}
+		}
+	}
+	for _, s := range []string{"conflict", "non-fast-forward"} {
+		if strings.Contains(lower, s) {
+			return &ConflictError{Message: err.Error()}
+		}
+	}
+	return &NetworkError{Err: err}
+}
+
+// IsNothingToCommit reports whether err indicates DOLT_COMMIT found no
 // changes to commit. Also matches the DoltHub write API variant where a
 // no-change write returns a GraphQL error about sqlwrite.tocommitid being null.
-func isNothingToCommit(err error) bool {
+func IsNothingToCommit(err error) bool {
 	if err == nil {
 		return false
 	}
@@ -242,24 +295,30 @@ func EscapeLIKE(s string) string {
 	return s
 }
 
-// CommitSQL returns the DOLT_COMMIT SQL statement, optionally with -S for GPG signing.
-func CommitSQL(msg string, signed bool) string {
-	if signed {
+// Signing says whether a commit is GPG-signed and with which key. The zero
+// value commits unsigned; an empty Key signs with dolt's configured
+// user.signingkey.
+type Signing struct {
+	Enabled bool
+	Key     string // GPG key ID, e.g. from the active rig profile
+}
+
+// CommitSQL returns the DOLT_COMMIT SQL statement, signed as sign says.
+func CommitSQL(msg string, sign Signing) string {
+	if sign.Enabled && sign.Key != "" {
+		return fmt.Sprintf("CALL DOLT_COMMIT('--gpg-sign=%s', '-m', '%s');\n", EscapeSQL(sign.Key), EscapeSQL(msg))
+	}
+	if sign.Enabled {
 		return fmt.Sprintf("CALL DOLT_COMMIT('-S', '-m', '%s');\n", EscapeSQL(msg))
 	}
 	return fmt.Sprintf("CALL DOLT_COMMIT('-m', '%s');\n", EscapeSQL(msg))
 }
 
-// GenerateWantedID generates a unique wanted item ID in the format w-<10-char-hash>.
+// GenerateWantedID generates a wanted item ID in the default scheme,
+// w-<10-char-hash>, without checking that it is free. Clients that insert
+// items use NewWantedID, which honors the wasteland's IDScheme.
 func GenerateWantedID(title string) string {
-	randomBytes := make([]byte, 8)
-	_, _ = rand.Read(randomBytes)
-
-	input := fmt.Sprintf("%s:%d:%x", title, time.Now().UnixNano(), randomBytes)
-	hash := sha256.Sum256([]byte(input))
-	hashStr := hex.EncodeToString(hash[:])[:10]
-
-	return fmt.Sprintf("w-%s", hashStr)
+	return DefaultIDScheme().Generate(title)
 }
 
 // GeneratePrefixedID generates a unique ID in the format <prefix>-<16 hex chars>
@@ -308,6 +367,16 @@ func InsertWantedDML(item *WantedItem) (string, error) {
 		status = fmt.Sprintf("'%s'", EscapeSQL(item.Status))
 	}
 
+	// parent_id is only written when set, so plain posts still work against
+	// databases created before the column existed.
+	if item.ParentID != "" {
+		return fmt.Sprintf(`INSERT INTO wanted (id, title, description, project, type, priority, tags, posted_by, status, effort_level, parent_id, created_at, updated_at)
+VALUES ('%s', '%s', %s, %s, %s, %d, %s, %s, %s, %s, '%s', '%s', '%s')`,
+			EscapeSQL(item.ID), EscapeSQL(item.Title), descField, projectField, typeField,
+			item.Priority, tagsJSON, postedByField, status, effortField,
+			EscapeSQL(item.ParentID), now, now), nil
+	}
+
 	return fmt.Sprintf(`INSERT INTO wanted (id, title, description, project, type, priority, tags, posted_by, status, effort_level, created_at, updated_at)
 VALUES ('%s', '%s', %s, %s, %s, %d, %s, %s, %s, %s, '%s', '%s')`,
 		EscapeSQL(item.ID), EscapeSQL(item.Title), descField, projectField, typeField,
@@ -321,7 +390,7 @@ func InsertWanted(db DB, item *WantedItem, signed bool) error {
 	if err != nil {
 		return err
 	}
-	return db.Exec("", "wl post: "+item.Title, signed, dml)
+	return db.Exec("", "wl post: "+item.Title, Signing{Enabled: signed}, dml)
 }
 
 // ClaimWantedDML returns the pure DML for claiming a wanted item.
@@ -332,11 +401,11 @@ func ClaimWantedDML(wantedID, rigHandle string) string {
 
 // ClaimWanted updates a wanted item's status to claimed.
 func ClaimWanted(db DB, wantedID, rigHandle string, signed bool) error {
-	err := db.Exec("", "wl claim: "+wantedID, signed, ClaimWantedDML(wantedID, rigHandle))
+	err := db.Exec("", "wl claim: "+wantedID, Signing{Enabled: signed}, ClaimWantedDML(wantedID, rigHandle))
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not open or does not exist", wantedID)}
 	}
 	return fmt.Errorf("claim failed: %w", err)
@@ -350,11 +419,11 @@ func UnclaimWantedDML(wantedID string) string {
 
 // UnclaimWanted reverts a claimed wanted item to open.
 func UnclaimWanted(db DB, wantedID string, signed bool) error {
-	err := db.Exec("", "wl unclaim: "+wantedID, signed, UnclaimWantedDML(wantedID))
+	err := db.Exec("", "wl unclaim: "+wantedID, Signing{Enabled: signed}, UnclaimWantedDML(wantedID))
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not claimed or does not exist", wantedID)}
 	}
 	return fmt.Errorf("unclaim failed: %w", err)
@@ -381,11 +450,11 @@ func SubmitCompletionDML(completionID, wantedID, rigHandle, evidence, hopURI str
 // SubmitCompletion inserts a completion record and updates the wanted status.
 func SubmitCompletion(db DB, completionID, wantedID, rigHandle, evidence, hopURI string, signed bool) error {
 	stmts := SubmitCompletionDML(completionID, wantedID, rigHandle, evidence, hopURI)
-	err := db.Exec("", "wl done: "+wantedID, signed, stmts...)
+	err := db.Exec("", "wl done: "+wantedID, Signing{Enabled: signed}, stmts...)
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not claimed by %q or does not exist", wantedID, rigHandle)}
 	}
 	return fmt.Errorf("completion failed: %w", err)
@@ -403,7 +472,7 @@ func QueryWanted(db DB, wantedID string) (*WantedItem, error) {
 
 	rows := parseSimpleCSV(output)
 	if len(rows) == 0 {
-		return nil, fmt.Errorf("wanted item %q not found", wantedID)
+		return nil, &NotFoundError{Message: fmt.Sprintf("wanted item %q not found", wantedID)}
 	}
 
 	row := rows[0]
@@ -537,9 +606,9 @@ func queryWantedDetailRef(db DB, wantedID, ref string) (*WantedItem, error) {
 	rows := parseSimpleCSV(output)
 	if len(rows) == 0 {
 		if ref != "" {
-			return nil, fmt.Errorf("wanted item %q not found on ref %s", wantedID, ref)
+			return nil, &NotFoundError{Message: fmt.Sprintf("wanted item %q not found on ref %s", wantedID, ref)}
 		}
-		return nil, fmt.Errorf("wanted item %q not found", wantedID)
+		return nil, &NotFoundError{Message: fmt.Sprintf("wanted item %q not found", wantedID)}
 	}
 
 	row := rows[0]
@@ -582,9 +651,9 @@ func queryStampRef(db DB, stampID, ref string) (*Stamp, error) {
 	rows := parseSimpleCSV(output)
 	if len(rows) == 0 {
 		if ref != "" {
-			return nil, fmt.Errorf("stamp %q not found on ref %s", stampID, ref)
+			return nil, &NotFoundError{Message: fmt.Sprintf("stamp %q not found on ref %s", stampID, ref)}
 		}
-		return nil, fmt.Errorf("stamp %q not found", stampID)
+		return nil, &NotFoundError{Message: fmt.Sprintf("stamp %q not found", stampID)}
 	}
 
 	row := rows[0]
@@ -626,6 +695,20 @@ func parseTagsJSON(s string) []string {
 
 // AcceptCompletionDML returns the pure DML statements for accepting a completion.
 func AcceptCompletionDML(wantedID, completionID, rigHandle, hopURI string, stamp *Stamp) []string {
+	insertStamp := insertCompletionStampDML(completionID, rigHandle, hopURI, stamp)
+
+	updateCompletion := fmt.Sprintf(`UPDATE completions SET validated_by='%s', stamp_id='%s', validated_at=NOW() WHERE id='%s'`,
+		EscapeSQL(rigHandle), EscapeSQL(stamp.ID), EscapeSQL(completionID))
+
+	updateWanted := fmt.Sprintf(`UPDATE wanted SET status='completed', updated_at=NOW() WHERE id='%s' AND status='in_review'`,
+		EscapeSQL(wantedID))
+
+	return []string{insertStamp, updateCompletion, updateWanted}
+}
+
+// insertCompletionStampDML returns the INSERT for a stamp by rigHandle on
+// a completion.
+func insertCompletionStampDML(completionID, rigHandle, hopURI string, stamp *Stamp) string {
 	tagsField := formatTagsJSON(stamp.SkillTags)
 
 	msgField := "NULL"
@@ -640,18 +723,10 @@ func AcceptCompletionDML(wantedID, completionID, rigHandle, hopURI string, stamp
 
 	valence := fmt.Sprintf(`{"quality": %d, "reliability": %d}`, stamp.Quality, stamp.Reliability)
 
-	insertStamp := fmt.Sprintf(`INSERT INTO stamps (id, author, subject, valence, confidence, severity, context_id, context_type, skill_tags, message, hop_uri, created_at) VALUES ('%s', '%s', '%s', '%s', 1.0, '%s', '%s', 'completion', %s, %s, %s, NOW())`,
+	return fmt.Sprintf(`INSERT INTO stamps (id, author, subject, valence, confidence, severity, context_id, context_type, skill_tags, message, hop_uri, created_at) VALUES ('%s', '%s', '%s', '%s', 1.0, '%s', '%s', 'completion', %s, %s, %s, NOW())`,
 		EscapeSQL(stamp.ID), EscapeSQL(rigHandle), EscapeSQL(stamp.Subject),
 		EscapeSQL(valence), EscapeSQL(stamp.Severity),
 		EscapeSQL(completionID), tagsField, msgField, hopField)
-
-	updateCompletion := fmt.Sprintf(`UPDATE completions SET validated_by='%s', stamp_id='%s', validated_at=NOW() WHERE id='%s'`,
-		EscapeSQL(rigHandle), EscapeSQL(stamp.ID), EscapeSQL(completionID))
-
-	updateWanted := fmt.Sprintf(`UPDATE wanted SET status='completed', updated_at=NOW() WHERE id='%s' AND status='in_review'`,
-		EscapeSQL(wantedID))
-
-	return []string{insertStamp, updateCompletion, updateWanted}
 }
 
 // AcceptUpstreamDML returns the pure DML statements for accepting a fork submission.
@@ -717,11 +792,11 @@ func CloseUpstreamDML(wantedID, completionID, completedBy, evidence, hopURI stri
 // AcceptCompletion validates a completion, creates a stamp, and marks the item completed.
 func AcceptCompletion(db DB, wantedID, completionID, rigHandle, hopURI string, stamp *Stamp, signed bool) error {
 	stmts := AcceptCompletionDML(wantedID, completionID, rigHandle, hopURI, stamp)
-	err := db.Exec("", "wl accept: "+wantedID, signed, stmts...)
+	err := db.Exec("", "wl accept: "+wantedID, Signing{Enabled: signed}, stmts...)
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not in_review or does not exist", wantedID)}
 	}
 	return fmt.Errorf("accept failed: %w", err)
@@ -770,11 +845,11 @@ func UpdateWanted(db DB, wantedID string, fields *WantedUpdate, signed bool) err
 		return err
 	}
 
-	err = db.Exec("", "wl update: "+wantedID, signed, dml)
+	err = db.Exec("", "wl update: "+wantedID, Signing{Enabled: signed}, dml)
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not open or does not exist", wantedID)}
 	}
 	return fmt.Errorf("update failed: %w", err)
@@ -788,16 +863,23 @@ func CloseWantedDML(wantedID string) string {
 
 // CloseWanted marks an in_review wanted item as completed without a stamp.
 func CloseWanted(db DB, wantedID string, signed bool) error {
-	err := db.Exec("", "wl close: "+wantedID, signed, CloseWantedDML(wantedID))
+	err := db.Exec("", "wl close: "+wantedID, Signing{Enabled: signed}, CloseWantedDML(wantedID))
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not in_review or does not exist", wantedID)}
 	}
 	return fmt.Errorf("close failed: %w", err)
 }
 
+// ReopenWantedDML returns the pure DML for reverting a closed wanted item
+// from completed back to in_review. Used to undo a close.
+func ReopenWantedDML(wantedID string) string {
+	return fmt.Sprintf("UPDATE wanted SET status='in_review', updated_at=NOW() WHERE id='%s' AND status='completed'",
+		EscapeSQL(wantedID))
+}
+
 // formatTagsJSON formats a string slice as a JSON array SQL literal.
 func formatTagsJSON(tags []string) string {
 	if len(tags) == 0 {
@@ -821,16 +903,23 @@ func DeleteWantedDML(wantedID string) string {
 
 // DeleteWanted soft-deletes a wanted item by setting status=withdrawn.
 func DeleteWanted(db DB, wantedID string, signed bool) error {
-	err := db.Exec("", "wl delete: "+wantedID, signed, DeleteWantedDML(wantedID))
+	err := db.Exec("", "wl delete: "+wantedID, Signing{Enabled: signed}, DeleteWantedDML(wantedID))
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not open or does not exist", wantedID)}
 	}
 	return fmt.Errorf("delete failed: %w", err)
 }
 
+// RestoreWantedDML returns the pure DML for restoring a withdrawn wanted
+// item to open. Used to undo a delete.
+func RestoreWantedDML(wantedID string) string {
+	return fmt.Sprintf("UPDATE wanted SET status='open', updated_at=NOW() WHERE id='%s' AND status='withdrawn'",
+		EscapeSQL(wantedID))
+}
+
 // RejectCompletionDML returns the pure DML statements for rejecting a completion.
 func RejectCompletionDML(wantedID string) []string {
 	return []string{
@@ -850,11 +939,11 @@ func RejectCompletion(db DB, wantedID, rigHandle, reason string, signed bool) er
 	}
 
 	stmts := RejectCompletionDML(wantedID)
-	err := db.Exec("", commitMsg, signed, stmts...)
+	err := db.Exec("", commitMsg, Signing{Enabled: signed}, stmts...)
 	if err == nil {
 		return nil
 	}
-	if isNothingToCommit(err) {
+	if IsNothingToCommit(err) {
 		return &ConflictError{Message: fmt.Sprintf("wanted item %q is not in_review or does not exist", wantedID)}
 	}
 	return fmt.Errorf("reject failed: %w", err)
@@ -1,6 +1,7 @@
 package commons
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -155,31 +156,53 @@ func TestGenerateWantedID_Format(t *testing.T) {
 func TestIsNothingToCommit_MatchingError(t *testing.T) {
 	t.Parallel()
 	err := fmt.Errorf("error: Nothing to commit")
-	if !isNothingToCommit(err) {
-		t.Error("isNothingToCommit should return true for matching error")
+	if !IsNothingToCommit(err) {
+		t.Error("IsNothingToCommit should return true for matching error")
 	}
 }
 
 func TestIsNothingToCommit_NonMatchingError(t *testing.T) {
 	t.Parallel()
 	err := fmt.Errorf("some other database error")
-	if isNothingToCommit(err) {
-		t.Error("isNothingToCommit should return false for non-matching error")
+	if IsNothingToCommit(err) {
+		t.Error("IsNothingToCommit should return false for non-matching error")
 	}
 }
 
 func TestIsNothingToCommit_Nil(t *testing.T) {
 	t.Parallel()
-	if isNothingToCommit(nil) {
-		t.Error("isNothingToCommit should return false for nil error")
+	if IsNothingToCommit(nil) {
+		t.Error("IsNothingToCommit should return false for nil error")
 	}
 }
 
 func TestIsNothingToCommit_DoltHubToCommitID(t *testing.T) {
 	t.Parallel()
 	err := fmt.Errorf(`polling write operation "op-123": HTTP 400: cannot return null for non-nullable field sqlwrite.tocommitid`)
-	if !isNothingToCommit(err) {
-		t.Error("isNothingToCommit should return true for DoltHub tocommitid error")
+	if !IsNothingToCommit(err) {
+		t.Error("IsNothingToCommit should return true for DoltHub tocommitid error")
+	}
+}
+
+func TestRemoteError(t *testing.T) {
+	t.Parallel()
+	base := fmt.Errorf("dolt push origin main: exit status 1")
+
+	var perm *PermissionError
+	if err := RemoteError(base, "error: permission denied for fork-org/wl-commons"); !errors.As(err, &perm) {
+		t.Errorf("permission output: got %T", err)
+	}
+	var conflict *ConflictError
+	if err := RemoteError(base, "Merge conflict in wanted"); !errors.As(err, &conflict) {
+		t.Errorf("conflict output: got %T", err)
+	}
+	var network *NetworkError
+	err := RemoteError(base, "dial tcp: i/o timeout")
+	if !errors.As(err, &network) {
+		t.Fatalf("other output: got %T", err)
+	}
+	if err.Error() != base.Error() || !errors.Is(err, base) {
+		t.Errorf("NetworkError should preserve message and unwrap, got %v", err)
 	}
 }
 
@@ -197,7 +220,7 @@ func TestGenerateWantedID_Uniqueness(t *testing.T) {
 
 func TestCommitSQL_Unsigned(t *testing.T) {
 	t.Parallel()
-	got := CommitSQL("wl post: Fix bug", false)
+	got := CommitSQL("wl post: Fix bug", Signing{})
 	want := "CALL DOLT_COMMIT('-m', 'wl post: Fix bug');\n"
 	if got != want {
 		t.Errorf("CommitSQL(unsigned) = %q, want %q", got, want)
@@ -206,16 +229,28 @@ func TestCommitSQL_Unsigned(t *testing.T) {
 
 func TestCommitSQL_Signed(t *testing.T) {
 	t.Parallel()
-	got := CommitSQL("wl post: Fix bug", true)
+	got := CommitSQL("wl post: Fix bug", Signing{Enabled: true})
 	want := "CALL DOLT_COMMIT('-S', '-m', 'wl post: Fix bug');\n"
 	if got != want {
 		t.Errorf("CommitSQL(signed) = %q, want %q", got, want)
 	}
 }
 
+func TestCommitSQL_SigningKey(t *testing.T) {
+	t.Parallel()
+	got := CommitSQL("wl post: Fix bug", Signing{Enabled: true, Key: "ABCD1234"})
+	want := "CALL DOLT_COMMIT('--gpg-sign=ABCD1234', '-m', 'wl post: Fix bug');\n"
+	if got != want {
+		t.Errorf("CommitSQL(signed, key) = %q, want %q", got, want)
+	}
+	if got := CommitSQL("wl post: Fix bug", Signing{Key: "ABCD1234"}); strings.Contains(got, "gpg-sign") {
+		t.Errorf("CommitSQL(unsigned, key) should not sign: %q", got)
+	}
+}
+
 func TestCommitSQL_EscapesQuotes(t *testing.T) {
 	t.Parallel()
-	got := CommitSQL("wl post: it's a test", true)
+	got := CommitSQL("wl post: it's a test", Signing{Enabled: true})
 	if !strings.Contains(got, "it''s a test") {
 		t.Errorf("commitSQL did not escape single quotes: %q", got)
 	}
@@ -283,6 +318,17 @@ func TestBuildBrowseQuery_SortPriority(t *testing.T) {
 	}
 }
 
+func TestBuildBrowseQuery_Offset(t *testing.T) {
+	t.Parallel()
+	q := BuildBrowseQuery(BrowseFilter{Priority: -1, Limit: 20, Offset: 40})
+	if !strings.HasSuffix(q, "LIMIT 20 OFFSET 40") {
+		t.Errorf("expected LIMIT/OFFSET suffix, got:\n%s", q)
+	}
+	if q := BuildBrowseQuery(BrowseFilter{Priority: -1}); strings.Contains(q, "OFFSET") {
+		t.Errorf("zero offset should be omitted, got:\n%s", q)
+	}
+}
+
 func TestBuildBrowseQuery_SortNewest(t *testing.T) {
 	t.Parallel()
 	f := BrowseFilter{Priority: -1, Sort: SortNewest}
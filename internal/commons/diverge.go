@@ -0,0 +1,221 @@
+package commons
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sync strategies for merging upstream into a local main that has
+// diverged from it.
+const (
+	SyncOurs   = "ours"   // conflicting rows keep the local version
+	SyncTheirs = "theirs" // conflicting rows take the upstream version
+	SyncManual = "manual" // conflicts are left in the clone to resolve by hand
+)
+
+// ValidSyncStrategy reports whether s names a sync strategy.
+func ValidSyncStrategy(s string) bool {
+	return s == SyncOurs || s == SyncTheirs || s == SyncManual
+}
+
+// Divergence describes how local main and upstream/main differ.
+type Divergence struct {
+	Ahead  int         // local commits not on upstream
+	Behind int         // upstream commits not on local main
+	Tables []TableDiff // local changes since the merge base, by table
+}
+
+// TableDiff counts the rows one side changed in a table.
+type TableDiff struct {
+	Table    string
+	Added    int
+	Modified int
+	Deleted  int
+}
+
+// ConflictRow is a row both local main and upstream changed.
+type ConflictRow struct {
+	Table   string
+	Key     string   // primary key value; "/"-joined for composite keys
+	Ours    string   // what local main did to the row: added, modified or removed
+	Theirs  string   // what upstream did to it
+	Columns []string // columns whose local and upstream values differ
+}
+
+// CheckDivergence fetches upstream and compares it with local main.
+func CheckDivergence(dbDir string) (*Divergence, error) {
+	if err := FetchRemote(dbDir, "upstream"); err != nil {
+		return nil, err
+	}
+	d := &Divergence{}
+	for _, c := range []struct {
+		n     *int
+		since string
+	}{{&d.Ahead, "upstream/main..main"}, {&d.Behind, "main..upstream/main"}} {
+		out, err := DoltSQLQuery(dbDir, fmt.Sprintf("SELECT COUNT(*) AS n FROM dolt_log('%s')", c.since))
+		if err != nil {
+			return nil, fmt.Errorf("comparing with upstream: %w", err)
+		}
+		if rows := parseSimpleCSV(out); len(rows) > 0 {
+			*c.n, _ = strconv.Atoi(rows[0]["n"])
+		}
+	}
+	if d.Ahead == 0 {
+		return d, nil
+	}
+	out, err := DoltSQLQuery(dbDir,
+		"SELECT table_name, rows_added, rows_modified, rows_deleted FROM dolt_diff_stat('upstream/main...main')")
+	if err != nil {
+		return nil, fmt.Errorf("diffing local changes: %w", err)
+	}
+	d.Tables = parseDiffStat(out)
+	return d, nil
+}
+
+func parseDiffStat(out string) []TableDiff {
+	var tables []TableDiff
+	for _, row := range parseSimpleCSV(out) {
+		t := TableDiff{Table: row["table_name"]}
+		t.Added, _ = strconv.Atoi(row["rows_added"])
+		t.Modified, _ = strconv.Atoi(row["rows_modified"])
+		t.Deleted, _ = strconv.Atoi(row["rows_deleted"])
+		if t.Added+t.Modified+t.Deleted > 0 {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// MergeUpstream merges the fetched upstream/main into local main. Rows
+// changed on both sides are settled per strategy: SyncOurs and SyncTheirs
+// resolve them and commit the merge with message; SyncManual leaves the
+// merge in progress for ResolveConflicts, ContinueMerge or AbortMerge.
+// Either way the conflicting rows are returned for reporting.
+func MergeUpstream(dbDir, strategy, message string) ([]ConflictRow, error) {
+	if !ValidSyncStrategy(strategy) {
+		return nil, fmt.Errorf("unknown sync strategy %q (want ours, theirs or manual)", strategy)
+	}
+	// Not retried: a conflicting merge stays in progress and a second
+	// attempt would only fail on that.
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "dolt", "merge", "-m", message, "upstream/main")
+	cmd.Dir = dbDir
+	start := time.Now()
+	output, mergeErr := cmd.CombinedOutput()
+	logDoltRun(dbDir, cmd.Args[1:], start, mergeErr)
+
+	conflicts, err := ListConflicts(dbDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) == 0 {
+		if mergeErr != nil {
+			return nil, fmt.Errorf("dolt merge upstream/main: %w (%s)", mergeErr, strings.TrimSpace(string(output)))
+		}
+		return nil, nil
+	}
+	if strategy == SyncManual {
+		return conflicts, nil
+	}
+	if err := ResolveConflicts(dbDir, ".", strategy); err != nil {
+		return conflicts, err
+	}
+	return conflicts, ContinueMerge(dbDir, message)
+}
+
+// ListConflicts returns the rows of an in-progress merge that still
+// conflict.
+func ListConflicts(dbDir string) ([]ConflictRow, error) {
+	out, err := DoltSQLQuery(dbDir, "SELECT `table` FROM dolt_conflicts ORDER BY `table`")
+	if err != nil {
+		return nil, fmt.Errorf("listing conflicts: %w", err)
+	}
+	var rows []ConflictRow
+	for _, t := range parseSimpleCSV(out) {
+		table := t["table"]
+		pkOut, err := DoltSQLQuery(dbDir, fmt.Sprintf(
+			"SELECT column_name FROM information_schema.key_column_usage WHERE table_schema = DATABASE() AND table_name = '%s' AND constraint_name = 'PRIMARY' ORDER BY ordinal_position",
+			EscapeSQL(table)))
+		if err != nil {
+			return nil, fmt.Errorf("reading primary key of %s: %w", table, err)
+		}
+		var pk []string
+		for _, r := range parseSimpleCSV(pkOut) {
+			pk = append(pk, r["column_name"])
+		}
+		out, err := DoltSQLQuery(dbDir, fmt.Sprintf("SELECT * FROM `dolt_conflicts_%s`", strings.ReplaceAll(table, "`", "")))
+		if err != nil {
+			return nil, fmt.Errorf("reading conflicts in %s: %w", table, err)
+		}
+		rows = append(rows, parseConflicts(table, pk, out)...)
+	}
+	return rows, nil
+}
+
+// parseConflicts turns the CSV of a dolt_conflicts_<table> query into
+// rows keyed by the table's primary key columns pk.
+func parseConflicts(table string, pk []string, out string) []ConflictRow {
+	var rows []ConflictRow
+	for _, r := range parseSimpleCSV(out) {
+		c := ConflictRow{Table: table, Ours: r["our_diff_type"], Theirs: r["their_diff_type"]}
+		var key []string
+		for _, col := range pk {
+			v := r["our_"+col]
+			if v == "" {
+				v = r["their_"+col]
+			}
+			if v == "" {
+				v = r["base_"+col]
+			}
+			key = append(key, v)
+		}
+		c.Key = strings.Join(key, "/")
+		for name, ours := range r {
+			col, ok := strings.CutPrefix(name, "our_")
+			if !ok || col == "diff_type" {
+				continue
+			}
+			if ours != r["their_"+col] {
+				c.Columns = append(c.Columns, col)
+			}
+		}
+		slices.Sort(c.Columns)
+		rows = append(rows, c)
+	}
+	return rows
+}
+
+// ResolveConflicts settles the conflicting rows of table ("." for all
+// tables) by taking side: SyncOurs or SyncTheirs.
+func ResolveConflicts(dbDir, table, side string) error {
+	if side != SyncOurs && side != SyncTheirs {
+		return fmt.Errorf("unknown side %q (want ours or theirs)", side)
+	}
+	return doltExec(dbDir, "conflicts", "resolve", "--"+side, table)
+}
+
+// ContinueMerge commits an in-progress merge once no conflicts remain.
+func ContinueMerge(dbDir, message string) error {
+	conflicts, err := ListConflicts(dbDir)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Message: fmt.Sprintf("%d conflicting row(s) still unresolved", len(conflicts))}
+	}
+	if err := doltExec(dbDir, "add", "."); err != nil {
+		return err
+	}
+	return doltExec(dbDir, "commit", "-m", message)
+}
+
+// AbortMerge abandons an in-progress merge, restoring local main.
+func AbortMerge(dbDir string) error {
+	return doltExec(dbDir, "merge", "--abort")
+}
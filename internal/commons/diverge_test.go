@@ -0,0 +1,59 @@
+package commons
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseConflicts(t *testing.T) {
+	t.Parallel()
+	out := "from_root_ish,base_id,base_status,base_claimed_by,our_id,our_status,our_claimed_by,our_diff_type,their_id,their_status,their_claimed_by,their_diff_type,dolt_conflict_id\n" +
+		"abc,w-1,open,,w-1,claimed,alice,modified,w-1,claimed,bob,modified,c1\n" +
+		"abc,w-2,open,,,,,removed,w-2,done,bob,modified,c2\n"
+
+	rows := parseConflicts("wanted", []string{"id"}, out)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if r := rows[0]; r.Table != "wanted" || r.Key != "w-1" || r.Ours != "modified" || r.Theirs != "modified" ||
+		!slices.Equal(r.Columns, []string{"claimed_by"}) {
+		t.Errorf("rows[0] = %+v", r)
+	}
+	if r := rows[1]; r.Key != "w-2" || r.Ours != "removed" || !slices.Equal(r.Columns, []string{"claimed_by", "id", "status"}) {
+		t.Errorf("rows[1] = %+v", r)
+	}
+}
+
+func TestParseConflicts_CompositeKey(t *testing.T) {
+	t.Parallel()
+	out := "base_wanted_id,base_rig_handle,our_wanted_id,our_rig_handle,our_diff_type,their_wanted_id,their_rig_handle,their_diff_type\n" +
+		",,w-1,bob,added,w-1,bob,added\n"
+
+	rows := parseConflicts("claim_queue", []string{"wanted_id", "rig_handle"}, out)
+	if len(rows) != 1 || rows[0].Key != "w-1/bob" || len(rows[0].Columns) != 0 {
+		t.Fatalf("rows = %+v", rows)
+	}
+}
+
+func TestParseDiffStat(t *testing.T) {
+	t.Parallel()
+	out := "table_name,rows_added,rows_modified,rows_deleted\nwanted,1,2,0\nstamps,0,0,0\ncompletions,1,0,0\n"
+
+	got := parseDiffStat(out)
+	want := []TableDiff{{Table: "wanted", Added: 1, Modified: 2}, {Table: "completions", Added: 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseDiffStat = %+v, want %+v", got, want)
+	}
+}
+
+func TestValidSyncStrategy(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{SyncOurs, SyncTheirs, SyncManual} {
+		if !ValidSyncStrategy(s) {
+			t.Errorf("ValidSyncStrategy(%q) = false", s)
+		}
+	}
+	if ValidSyncStrategy("mine") {
+		t.Error(`ValidSyncStrategy("mine") = true`)
+	}
+}
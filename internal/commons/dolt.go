@@ -1,9 +1,12 @@
 package commons
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"strings"
@@ -15,6 +18,7 @@ func doltRetry(fn func() error) error {
 	var err error
 	for i := 0; i < 3; i++ {
 		if i > 0 {
+			slog.Info("retrying dolt command", "attempt", i+1, "error", err)
 			time.Sleep(time.Duration(i) * time.Second)
 		}
 		if err = fn(); err == nil {
@@ -24,6 +28,15 @@ func doltRetry(fn func() error) error {
 	return err
 }
 
+// logDoltRun records a dolt CLI invocation at debug level.
+func logDoltRun(dbDir string, args []string, start time.Time, err error) {
+	if err != nil {
+		slog.Debug("dolt command failed", "dir", dbDir, "args", args, "duration", time.Since(start), "error", err)
+		return
+	}
+	slog.Debug("dolt command", "dir", dbDir, "args", args, "duration", time.Since(start))
+}
+
 // DoltHubToken returns the DoltHub API token from the environment.
 func DoltHubToken() string {
 	return os.Getenv("DOLTHUB_TOKEN")
@@ -56,7 +69,7 @@ func PushWithSync(dbDir string, stdout io.Writer) error {
 		fmt.Fprintf(stdout, "  Pushed to %s\n", remote)
 	}
 	if len(failures) > 0 {
-		return fmt.Errorf("push failed for remotes: %s", strings.Join(failures, ", "))
+		return &NetworkError{Err: fmt.Errorf("push failed for remotes: %s", strings.Join(failures, ", "))}
 	}
 	return nil
 }
@@ -67,9 +80,11 @@ func pushRemote(dbDir, remote string) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "push", remote, "main")
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
-			return fmt.Errorf("dolt push %s main: %w (%s)", remote, err, strings.TrimSpace(string(output)))
+			return RemoteError(fmt.Errorf("dolt push %s main: %w (%s)", remote, err, strings.TrimSpace(string(output))), string(output))
 		}
 		return nil
 	})
@@ -81,9 +96,11 @@ func pullRemote(dbDir, remote string) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "pull", remote, "main")
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
-			return fmt.Errorf("dolt pull %s main: %w (%s)", remote, err, strings.TrimSpace(string(output)))
+			return RemoteError(fmt.Errorf("dolt pull %s main: %w (%s)", remote, err, strings.TrimSpace(string(output))), string(output))
 		}
 		return nil
 	})
@@ -110,9 +127,11 @@ func FetchRemote(dbDir, remote string) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "fetch", remote)
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
-			return fmt.Errorf("dolt fetch %s: %w (%s)", remote, err, strings.TrimSpace(string(output)))
+			return RemoteError(fmt.Errorf("dolt fetch %s: %w (%s)", remote, err, strings.TrimSpace(string(output))), string(output))
 		}
 		return nil
 	})
@@ -137,7 +156,9 @@ func DoltSQLScript(dbDir, script string) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "sql", "--file", tmpFile.Name())
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
 			return fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
 		}
@@ -241,6 +262,12 @@ func CheckoutBranchFrom(dbDir, branch, startPoint string) error {
 	return doltExec(dbDir, "checkout", branch)
 }
 
+// ResetHard discards uncommitted changes in the working set of the
+// currently checked-out branch.
+func ResetHard(dbDir string) error {
+	return doltExec(dbDir, "reset", "--hard")
+}
+
 // CheckoutMain switches the working directory back to the main branch.
 func CheckoutMain(dbDir string) error {
 	return doltExec(dbDir, "checkout", "main")
@@ -253,7 +280,9 @@ func doltExec(dbDir string, args ...string) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", args...)
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
 			return fmt.Errorf("dolt %s: %w (%s)", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
 		}
@@ -270,9 +299,11 @@ func PushBranch(dbDir, branch string, stdout io.Writer) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "push", "--force", "origin", branch)
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
-			return fmt.Errorf("push branch %s: %w (%s)", branch, err, strings.TrimSpace(string(output)))
+			return RemoteError(fmt.Errorf("push branch %s: %w (%s)", branch, err, strings.TrimSpace(string(output))), string(output))
 		}
 		return nil
 	})
@@ -385,6 +416,55 @@ func MergeBranch(dbDir, branch string) error {
 	return nil
 }
 
+// UpdateBranchFromUpstream brings a PR-mode branch up to date with the
+// latest upstream: main is reset to upstream/main (as a PR-mode sync does)
+// and then merged into branch. Changes to different rows merge cleanly; a
+// conflicting merge is aborted, leaving branch unchanged, and reported as a
+// *ConflictError. The caller must already be on main.
+func UpdateBranchFromUpstream(dbDir, branch string) error {
+	if err := ResetMainToUpstream(dbDir); err != nil {
+		return fmt.Errorf("updating main from upstream: %w", err)
+	}
+	escaped := EscapeSQL(branch)
+	err := DoltSQLScript(dbDir, fmt.Sprintf(
+		"CALL DOLT_CHECKOUT('%s');\nCALL DOLT_MERGE('main');\nCALL DOLT_CHECKOUT('main');\n", escaped,
+	))
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "conflict") {
+			_ = DoltSQLScript(dbDir, fmt.Sprintf("CALL DOLT_CHECKOUT('%s');\nCALL DOLT_MERGE('--abort');\n", escaped))
+			return &ConflictError{Message: fmt.Sprintf(
+				"branch %s conflicts with upstream main: the same rows changed on both; discard the branch or resolve with dolt", branch)}
+		}
+		return fmt.Errorf("merging main into %s: %w", branch, err)
+	}
+	return nil
+}
+
+// SquashBranch collapses the commits branch has on top of its merge base
+// with main into a single commit with message. The branch's contents are
+// unchanged; only its history is rewritten, so a pushed branch needs a
+// force push afterwards.
+func SquashBranch(dbDir, branch, message string, sign Signing) error {
+	escaped := EscapeSQL(branch)
+	out, err := DoltSQLQuery(dbDir, fmt.Sprintf("SELECT DOLT_MERGE_BASE('main', '%s') AS base", escaped))
+	if err != nil {
+		return fmt.Errorf("finding merge base of %s: %w", branch, err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[1]) == "" {
+		return fmt.Errorf("unexpected merge base output: %s", out)
+	}
+	base := strings.TrimSpace(lines[1])
+
+	script := fmt.Sprintf("CALL DOLT_CHECKOUT('%s');\nCALL DOLT_RESET('--soft', '%s');\nCALL DOLT_ADD('-A');\n", escaped, EscapeSQL(base)) +
+		CommitSQL(message, sign) +
+		"CALL DOLT_CHECKOUT('main');\n"
+	if err := DoltSQLScript(dbDir, script); err != nil {
+		return fmt.Errorf("squashing branch %s: %w", branch, err)
+	}
+	return nil
+}
+
 // DeleteBranch deletes a local branch.
 func DeleteBranch(dbDir, branch string) error {
 	escaped := strings.ReplaceAll(branch, "'", "''")
@@ -398,9 +478,11 @@ func DeleteRemoteBranch(dbDir, remote, branch string) error {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "push", remote, ":"+branch)
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
-			return fmt.Errorf("dolt push %s :%s: %w (%s)", remote, branch, err, strings.TrimSpace(string(output)))
+			return RemoteError(fmt.Errorf("dolt push %s :%s: %w (%s)", remote, branch, err, strings.TrimSpace(string(output))), string(output))
 		}
 		return nil
 	})
@@ -455,9 +537,11 @@ func PushBranchToRemoteForce(dbDir, remote, branch string, force bool, stdout io
 		args = append(args, remote, branch)
 		cmd := exec.CommandContext(ctx, "dolt", args...)
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
-			return fmt.Errorf("dolt push %s %s: %w (%s)", remote, branch, err, strings.TrimSpace(string(output)))
+			return RemoteError(fmt.Errorf("dolt push %s %s: %w (%s)", remote, branch, err, strings.TrimSpace(string(output))), string(output))
 		}
 		return nil
 	})
@@ -502,7 +586,7 @@ func ResolveWantedID(db DB, idOrPrefix string) (string, error) {
 	}
 	lines := strings.Split(strings.TrimSpace(out), "\n")
 	if len(lines) < 2 {
-		return "", fmt.Errorf("no wanted item matching %q", idOrPrefix)
+		return "", &NotFoundError{Message: fmt.Sprintf("no wanted item matching %q", idOrPrefix)}
 	}
 	var matches []string
 	for _, line := range lines[1:] {
@@ -512,7 +596,7 @@ func ResolveWantedID(db DB, idOrPrefix string) (string, error) {
 		}
 	}
 	if len(matches) == 0 {
-		return "", fmt.Errorf("no wanted item matching %q", idOrPrefix)
+		return "", &NotFoundError{Message: fmt.Sprintf("no wanted item matching %q", idOrPrefix)}
 	}
 	if len(matches) > 1 {
 		return "", fmt.Errorf("ambiguous prefix %q matches: %s", idOrPrefix, strings.Join(matches, ", "))
@@ -548,6 +632,60 @@ func QueryItemStatusAsOf(db DB, wantedID, ref string) string {
 	return status
 }
 
+// DoltSQLStream executes a SQL query and streams its CSV output. Unlike
+// DoltSQLQuery there is no timeout or retry: a large result may take a while
+// to read, and a retry would replay rows the caller has already consumed.
+// Close reports a failed query once the output has been read to the end;
+// closing earlier stops dolt.
+func DoltSQLStream(dbDir, query string) (io.ReadCloser, error) {
+	cmd := exec.Command("dolt", "sql", "-r", "csv", "-q", query)
+	cmd.Dir = dbDir
+	s := &doltStream{cmd: cmd, dir: dbDir, start: time.Now()}
+	cmd.Stderr = &s.stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	s.stdout = stdout
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("dolt sql query failed: %w", err)
+	}
+	return s, nil
+}
+
+// doltStream is the reader returned by DoltSQLStream.
+type doltStream struct {
+	cmd    *exec.Cmd
+	stdout io.ReadCloser
+	stderr bytes.Buffer
+	dir    string
+	start  time.Time
+	eof    bool
+}
+
+func (s *doltStream) Read(p []byte) (int, error) {
+	n, err := s.stdout.Read(p)
+	if errors.Is(err, io.EOF) {
+		s.eof = true
+	}
+	return n, err
+}
+
+func (s *doltStream) Close() error {
+	if !s.eof {
+		_ = s.cmd.Process.Kill()
+		_ = s.cmd.Wait()
+		logDoltRun(s.dir, s.cmd.Args[1:], s.start, nil)
+		return nil
+	}
+	err := s.cmd.Wait()
+	logDoltRun(s.dir, s.cmd.Args[1:], s.start, err)
+	if err != nil {
+		return fmt.Errorf("dolt sql query failed: %w (%s)", err, strings.TrimSpace(s.stderr.String()))
+	}
+	return nil
+}
+
 // DoltSQLQuery executes a SQL query and returns the raw CSV output.
 func DoltSQLQuery(dbDir, query string) (string, error) {
 	var result string
@@ -556,7 +694,9 @@ func DoltSQLQuery(dbDir, query string) (string, error) {
 		defer cancel()
 		cmd := exec.CommandContext(ctx, "dolt", "sql", "-r", "csv", "-q", query)
 		cmd.Dir = dbDir
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logDoltRun(dbDir, cmd.Args[1:], start, err)
 		if err != nil {
 			return fmt.Errorf("dolt sql query failed: %w (%s)", err, strings.TrimSpace(string(output)))
 		}
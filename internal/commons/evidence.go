@@ -0,0 +1,166 @@
+package commons
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Evidence kinds for completion evidence entries.
+const (
+	EvidencePR       = "pr"
+	EvidenceCommit   = "commit"
+	EvidenceArtifact = "artifact"
+	EvidenceText     = "text"
+)
+
+// EvidenceKinds lists the valid evidence kinds.
+var EvidenceKinds = []string{EvidencePR, EvidenceCommit, EvidenceArtifact, EvidenceText}
+
+// Evidence is one typed entry of a completion's evidence. Verified is set
+// when the submitter checked that the URL or commit exists before
+// submitting.
+type Evidence struct {
+	Kind     string
+	Value    string
+	Verified bool
+}
+
+var (
+	commitSHARe = regexp.MustCompile(`^(?i:commit\s+)?([0-9a-f]{7,40})$`)
+	prURLRe     = regexp.MustCompile(`^https?://\S+/(pull|pulls|merge_requests)/\d+`)
+)
+
+// ParseEvidence parses one evidence entry. An explicit "kind:" prefix
+// ("pr:", "commit:", "artifact:", "text:") sets the kind; otherwise it is
+// inferred: pull/merge request URLs are pr, bare or "commit "-prefixed hex
+// SHAs are commit, other URLs are artifact, and anything else is text.
+func ParseEvidence(s string) Evidence {
+	s = strings.TrimSpace(s)
+	if kind, value, ok := strings.Cut(s, ":"); ok {
+		for _, k := range EvidenceKinds {
+			if strings.EqualFold(kind, k) {
+				return Evidence{Kind: k, Value: strings.TrimSpace(value)}
+			}
+		}
+	}
+	switch {
+	case prURLRe.MatchString(s):
+		return Evidence{Kind: EvidencePR, Value: s}
+	case commitSHARe.MatchString(s):
+		return Evidence{Kind: EvidenceCommit, Value: commitSHARe.FindStringSubmatch(s)[1]}
+	case strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://"):
+		return Evidence{Kind: EvidenceArtifact, Value: s}
+	}
+	return Evidence{Kind: EvidenceText, Value: s}
+}
+
+// ParseEvidenceList parses evidence entries, dropping empty ones.
+func ParseEvidenceList(raw []string) []Evidence {
+	var out []Evidence
+	for _, s := range raw {
+		if e := ParseEvidence(s); e.Value != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// String renders the entry so ParseEvidence reads it back: the bare value
+// when its kind would be inferred, otherwise "kind:value".
+func (e Evidence) String() string {
+	if p := ParseEvidence(e.Value); p.Kind == e.Kind && p.Value == e.Value {
+		return e.Value
+	}
+	return e.Kind + ":" + e.Value
+}
+
+// ValidateEvidence checks that entries is non-empty and each entry has a
+// known kind and a value.
+func ValidateEvidence(entries []Evidence) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("evidence is required")
+	}
+	for _, e := range entries {
+		if strings.TrimSpace(e.Value) == "" {
+			return fmt.Errorf("evidence entry cannot be empty")
+		}
+		valid := false
+		for _, k := range EvidenceKinds {
+			valid = valid || e.Kind == k
+		}
+		if !valid {
+			return fmt.Errorf("invalid evidence kind %q: must be one of %s", e.Kind, strings.Join(EvidenceKinds, ", "))
+		}
+	}
+	return nil
+}
+
+// EvidenceSummary renders entries as the single string stored in
+// completions.evidence, which older readers and hooks display: a single
+// entry as its String form, several as their values joined by "; ".
+func EvidenceSummary(entries []Evidence) string {
+	if len(entries) == 1 {
+		return entries[0].String()
+	}
+	values := make([]string, len(entries))
+	for i, e := range entries {
+		values[i] = e.Value
+	}
+	return strings.Join(values, "; ")
+}
+
+// InsertEvidenceDML returns the inserts recording entries in the optional
+// completion_evidence table. Each insert only applies while the completion
+// exists, so a submission that lost the completion race leaves no rows.
+func InsertEvidenceDML(completionID string, entries []Evidence) []string {
+	stmts := make([]string, 0, len(entries))
+	for i, e := range entries {
+		verified := 0
+		if e.Verified {
+			verified = 1
+		}
+		stmts = append(stmts, fmt.Sprintf(`INSERT IGNORE INTO completion_evidence (completion_id, position, kind, value, verified) SELECT '%s', %d, '%s', '%s', %d FROM completions WHERE id='%s'`,
+			EscapeSQL(completionID), i, EscapeSQL(e.Kind), EscapeSQL(e.Value), verified, EscapeSQL(completionID)))
+	}
+	return stmts
+}
+
+// QueryEvidence returns the evidence entries of a completion at ref
+// ("" = main), in submission order. When the completion has no rows in
+// completion_evidence, or the table does not exist, the legacy evidence
+// string is parsed as a single entry.
+func QueryEvidence(db DB, completion *CompletionRecord, ref string) ([]Evidence, error) {
+	query := fmt.Sprintf("SELECT position, kind, value, COALESCE(verified, 0) AS verified FROM completion_evidence WHERE completion_id='%s' ORDER BY position",
+		EscapeSQL(completion.ID))
+	output, err := db.Query(query, ref)
+	if err != nil && !IsTableNotFound(err) {
+		return nil, fmt.Errorf("querying evidence: %w", err)
+	}
+	var out []Evidence
+	if err == nil {
+		for _, row := range parseSimpleCSV(output) {
+			if row["value"] == "" {
+				continue
+			}
+			out = append(out, Evidence{Kind: row["kind"], Value: row["value"], Verified: row["verified"] == "1" || row["verified"] == "true"})
+		}
+	}
+	if len(out) == 0 && strings.TrimSpace(completion.Evidence) != "" {
+		out = []Evidence{ParseEvidence(completion.Evidence)}
+	}
+	return out, nil
+}
+
+// HasEvidenceTable reports whether the database has the optional
+// completion_evidence table.
+func HasEvidenceTable(db DB) (bool, error) {
+	_, err := db.Query("SELECT completion_id FROM completion_evidence WHERE 1=0", "")
+	if err == nil {
+		return true, nil
+	}
+	if IsTableNotFound(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("checking for completion_evidence: %w", err)
+}
@@ -0,0 +1,83 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseEvidence(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Evidence
+	}{
+		{"https://github.com/org/repo/pull/123", Evidence{Kind: EvidencePR, Value: "https://github.com/org/repo/pull/123"}},
+		{"https://gitlab.com/org/repo/-/merge_requests/9", Evidence{Kind: EvidencePR, Value: "https://gitlab.com/org/repo/-/merge_requests/9"}},
+		{"abc123def", Evidence{Kind: EvidenceCommit, Value: "abc123def"}},
+		{"commit abc123def", Evidence{Kind: EvidenceCommit, Value: "abc123def"}},
+		{"https://example.com/build/42.tar.gz", Evidence{Kind: EvidenceArtifact, Value: "https://example.com/build/42.tar.gz"}},
+		{"fixed it by hand", Evidence{Kind: EvidenceText, Value: "fixed it by hand"}},
+		{"artifact:https://github.com/org/repo/pull/1", Evidence{Kind: EvidenceArtifact, Value: "https://github.com/org/repo/pull/1"}},
+		{"TEXT: deadbeef", Evidence{Kind: EvidenceText, Value: "deadbeef"}},
+		{"note: see thread", Evidence{Kind: EvidenceText, Value: "note: see thread"}},
+	}
+	for _, tc := range tests {
+		if got := ParseEvidence(tc.in); got != tc.want {
+			t.Errorf("ParseEvidence(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestEvidenceStringRoundTrips(t *testing.T) {
+	for _, e := range []Evidence{
+		{Kind: EvidencePR, Value: "https://github.com/org/repo/pull/1"},
+		{Kind: EvidenceArtifact, Value: "https://github.com/org/repo/pull/1"},
+		{Kind: EvidenceText, Value: "abc1234"},
+		{Kind: EvidenceCommit, Value: "abc1234"},
+	} {
+		if got := ParseEvidence(e.String()); got != e {
+			t.Errorf("ParseEvidence(%q) = %+v, want %+v", e.String(), got, e)
+		}
+	}
+}
+
+func TestInsertEvidenceDML(t *testing.T) {
+	stmts := InsertEvidenceDML("c-1", []Evidence{{Kind: EvidencePR, Value: "https://x/pull/1", Verified: true}, {Kind: EvidenceText, Value: "it's done"}})
+	if len(stmts) != 2 {
+		t.Fatalf("stmts = %d, want 2", len(stmts))
+	}
+	if !strings.Contains(stmts[0], "SELECT 'c-1', 0, 'pr', 'https://x/pull/1', 1 FROM completions") {
+		t.Errorf("stmt = %s", stmts[0])
+	}
+	if !strings.Contains(stmts[1], "SELECT 'c-1', 1, 'text', 'it''s done', 0 FROM completions WHERE id='c-1'") {
+		t.Errorf("stmt = %s", stmts[1])
+	}
+}
+
+func TestQueryEvidence(t *testing.T) {
+	completion := &CompletionRecord{ID: "c-1", Evidence: "https://x/pull/1; abc1234"}
+	db := &fakeDB{results: map[string]string{"FROM completion_evidence": "position,kind,value,verified\n0,pr,https://x/pull/1,1\n1,commit,abc1234,0\n"}}
+	got, err := QueryEvidence(db, completion, "")
+	if err != nil {
+		t.Fatalf("QueryEvidence: %v", err)
+	}
+	if len(got) != 2 || !got[0].Verified || got[1] != (Evidence{Kind: EvidenceCommit, Value: "abc1234"}) {
+		t.Errorf("evidence = %+v", got)
+	}
+
+	legacy := &CompletionRecord{ID: "c-2", Evidence: "commit abc1234"}
+	missing := &fakeDB{err: errors.New("table not found: completion_evidence")}
+	got, err = QueryEvidence(missing, legacy, "")
+	if err != nil || len(got) != 1 || got[0] != (Evidence{Kind: EvidenceCommit, Value: "abc1234"}) {
+		t.Errorf("legacy fallback = %+v, %v", got, err)
+	}
+}
+
+func TestHasEvidenceTable(t *testing.T) {
+	if ok, err := HasEvidenceTable(&fakeDB{}); !ok || err != nil {
+		t.Errorf("with table = %v, %v", ok, err)
+	}
+	if ok, err := HasEvidenceTable(&fakeDB{err: errors.New("table not found: completion_evidence")}); ok || err != nil {
+		t.Errorf("without table = %v, %v", ok, err)
+	}
+}
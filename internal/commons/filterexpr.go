@@ -0,0 +1,209 @@
+package commons
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FilterExclude lists field values that rule an item out of browse results,
+// written with a leading '-' in a filter expression.
+type FilterExclude struct {
+	Statuses  []string
+	Projects  []string
+	Types     []string
+	PostedBy  []string
+	ClaimedBy []string
+	Tags      []string
+}
+
+// IsZero reports whether no exclusions are set.
+func (e FilterExclude) IsZero() bool {
+	return len(e.Statuses) == 0 && len(e.Projects) == 0 && len(e.Types) == 0 &&
+		len(e.PostedBy) == 0 && len(e.ClaimedBy) == 0 && len(e.Tags) == 0
+}
+
+// FilterExprKeys are the keys a filter expression accepts, in help order.
+var FilterExprKeys = []string{"status", "project", "type", "priority", "posted_by", "claimed_by", "tag", "sort"}
+
+// ParseFilterExpr applies a compact filter expression to f, e.g.
+//
+//	status:open type:bug tag:go -project:infra "login page"
+//
+// Terms are separated by spaces. key:value sets a filter; -key:value
+// excludes items with that value (status, project, type, posted_by,
+// claimed_by and tag only). tag: may repeat and requires every tag. Any
+// other word is title search text. Values containing spaces are quoted:
+// project:"big thing". Setting the same key twice to different values is
+// an error, as is an unknown key. Both `wl browse --query` and the API's
+// ?q= parameter use this syntax.
+func ParseFilterExpr(expr string, f *BrowseFilter) error {
+	terms, err := splitFilterTerms(expr)
+	if err != nil {
+		return err
+	}
+
+	var search []string
+	for _, term := range terms {
+		negate := strings.HasPrefix(term, "-") && len(term) > 1
+		body := term
+		if negate {
+			body = term[1:]
+		}
+		key, value, ok := strings.Cut(body, ":")
+		if !ok || key == "" {
+			search = append(search, term)
+			continue
+		}
+		key = strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+		if value == "" {
+			return fmt.Errorf("filter %q: missing value", term)
+		}
+		if negate {
+			if err := f.Exclude.add(key, value); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := f.setFilterKey(key, value); err != nil {
+			return err
+		}
+	}
+	if len(search) > 0 {
+		text := strings.Join(search, " ")
+		if f.Search != "" && f.Search != text {
+			return fmt.Errorf("search text given twice (%q and %q)", f.Search, text)
+		}
+		f.Search = text
+	}
+	return nil
+}
+
+func (f *BrowseFilter) setFilterKey(key, value string) error {
+	set := func(field *string) error {
+		if *field != "" && *field != value {
+			return fmt.Errorf("filter %s: given twice (%q and %q)", key, *field, value)
+		}
+		*field = value
+		return nil
+	}
+	switch key {
+	case "status":
+		return set(&f.Status)
+	case "project":
+		return set(&f.Project)
+	case "type":
+		return set(&f.Type)
+	case "posted_by":
+		return set(&f.PostedBy)
+	case "claimed_by":
+		return set(&f.ClaimedBy)
+	case "tag":
+		f.Tags = append(f.Tags, value)
+		return nil
+	case "priority":
+		p, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(value), "p"))
+		if err != nil || p < 0 || p > 4 {
+			return fmt.Errorf("filter priority: %q is not 0-4 (or p0-p4)", value)
+		}
+		if f.Priority >= 0 && f.Priority != p {
+			return fmt.Errorf("filter priority: given twice (%d and %d)", f.Priority, p)
+		}
+		f.Priority = p
+		return nil
+	case "sort":
+		switch value {
+		case "priority":
+			f.Sort = SortPriority
+		case "newest":
+			f.Sort = SortNewest
+		case "alpha":
+			f.Sort = SortAlpha
+		default:
+			return fmt.Errorf("filter sort: %q is not priority, newest or alpha", value)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown filter key %q (valid: %s)", key, strings.Join(FilterExprKeys, ", "))
+}
+
+func (e *FilterExclude) add(key, value string) error {
+	switch key {
+	case "status":
+		e.Statuses = append(e.Statuses, value)
+	case "project":
+		e.Projects = append(e.Projects, value)
+	case "type":
+		e.Types = append(e.Types, value)
+	case "posted_by":
+		e.PostedBy = append(e.PostedBy, value)
+	case "claimed_by":
+		e.ClaimedBy = append(e.ClaimedBy, value)
+	case "tag":
+		e.Tags = append(e.Tags, value)
+	default:
+		return fmt.Errorf("filter key %q cannot be negated", key)
+	}
+	return nil
+}
+
+// splitFilterTerms splits on whitespace, keeping double-quoted runs
+// together and dropping the quotes.
+func splitFilterTerms(expr string) ([]string, error) {
+	var (
+		terms   []string
+		cur     strings.Builder
+		inQuote bool
+		hasTerm bool
+	)
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			hasTerm = true
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n'):
+			if hasTerm {
+				terms = append(terms, cur.String())
+				cur.Reset()
+				hasTerm = false
+			}
+		default:
+			cur.WriteRune(r)
+			hasTerm = true
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("filter expression has an unclosed quote")
+	}
+	if hasTerm && cur.Len() > 0 {
+		terms = append(terms, cur.String())
+	}
+	return terms, nil
+}
+
+// MatchesStatus reports whether status passes the filter's status and
+// excluded-status constraints.
+func (f BrowseFilter) MatchesStatus(status string) bool {
+	if f.Status != "" && status != f.Status {
+		return false
+	}
+	return !slices.Contains(f.Exclude.Statuses, status)
+}
+
+// sqlStringList renders values as a quoted, escaped SQL list body.
+func sqlStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + EscapeSQL(v) + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// sqlJSONString renders s as an escaped SQL literal holding a JSON string,
+// for JSON_CONTAINS.
+func sqlJSONString(s string) string {
+	b, _ := json.Marshal(s)
+	return "'" + EscapeSQL(string(b)) + "'"
+}
@@ -0,0 +1,109 @@
+package commons
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		expr string
+		want BrowseFilter
+	}{
+		{
+			name: "keys and exclusions",
+			expr: "status:open type:bug tag:go -project:infra",
+			want: BrowseFilter{Status: "open", Type: "bug", Tags: []string{"go"}, Priority: -1,
+				Exclude: FilterExclude{Projects: []string{"infra"}}},
+		},
+		{
+			name: "search words and quoted values",
+			expr: `project:"big thing" login   page`,
+			want: BrowseFilter{Project: "big thing", Search: "login page", Priority: -1},
+		},
+		{
+			name: "priority and sort",
+			expr: "priority:p1 sort:newest posted-by:alice",
+			want: BrowseFilter{Priority: 1, Sort: SortNewest, PostedBy: "alice"},
+		},
+		{
+			name: "repeated tags and negated status",
+			expr: "tag:go tag:cli -status:completed -tag:wip",
+			want: BrowseFilter{Tags: []string{"go", "cli"}, Priority: -1,
+				Exclude: FilterExclude{Statuses: []string{"completed"}, Tags: []string{"wip"}}},
+		},
+		{
+			name: "empty",
+			expr: "   ",
+			want: BrowseFilter{Priority: -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := BrowseFilter{Priority: -1}
+			if err := ParseFilterExpr(tt.expr, &got); err != nil {
+				t.Fatalf("ParseFilterExpr(%q): %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilterExpr(%q) = %+v, want %+v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterExpr_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"color:red", "unknown filter key"},
+		{"status:", "missing value"},
+		{"priority:7", "not 0-4"},
+		{"sort:random", "not priority, newest or alpha"},
+		{"-priority:1", "cannot be negated"},
+		{`project:"open`, "unclosed quote"},
+	}
+	for _, tt := range tests {
+		f := BrowseFilter{Priority: -1}
+		err := ParseFilterExpr(tt.expr, &f)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseFilterExpr(%q) error = %v, want containing %q", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseFilterExpr_ConflictsWithExistingFilter(t *testing.T) {
+	t.Parallel()
+	f := BrowseFilter{Status: "claimed", Priority: -1}
+	if err := ParseFilterExpr("status:open", &f); err == nil {
+		t.Error("expected error for status set twice")
+	}
+	f = BrowseFilter{Status: "open", Priority: -1}
+	if err := ParseFilterExpr("status:open", &f); err != nil {
+		t.Errorf("same value twice should be accepted: %v", err)
+	}
+}
+
+func TestBuildBrowseQuery_TagsAndExclusions(t *testing.T) {
+	t.Parallel()
+	f := BrowseFilter{Priority: -1}
+	if err := ParseFilterExpr("tag:go -project:infra -tag:wip -status:completed", &f); err != nil {
+		t.Fatal(err)
+	}
+	q := BuildBrowseQuery(f)
+	for _, want := range []string{
+		`JSON_CONTAINS(tags, '"go"')`,
+		"COALESCE(project,'') NOT IN ('infra')",
+		`(tags IS NULL OR NOT JSON_CONTAINS(tags, '"wip"'))`,
+		"COALESCE(status,'') NOT IN ('completed')",
+	} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query missing %s:\n%s", want, q)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewFollowUpItem builds the open wanted item a partial accept spawns for
+// the work left on parent. It inherits the parent's project, type,
+// priority, tags and effort, and records the parent in ParentID.
+func NewFollowUpItem(parent *WantedItem, title, description, postedBy string) (*WantedItem, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("follow-up title cannot be empty")
+	}
+	return &WantedItem{
+		ID:          GenerateWantedID(title),
+		Title:       title,
+		Description: strings.TrimSpace(description),
+		Project:     parent.Project,
+		Type:        parent.Type,
+		Priority:    parent.Priority,
+		Tags:        parent.Tags,
+		EffortLevel: parent.EffortLevel,
+		PostedBy:    postedBy,
+		ParentID:    parent.ID,
+	}, nil
+}
+
+// QueryParentID returns the item wantedID follows up at ref ("" = main),
+// or "" if it has none.
+func QueryParentID(db DB, wantedID, ref string) (string, error) {
+	query := fmt.Sprintf("SELECT COALESCE(parent_id, '') AS parent_id FROM wanted WHERE id='%s'", EscapeSQL(wantedID))
+	output, err := db.Query(query, ref)
+	if err != nil {
+		return "", fmt.Errorf("querying parent item: %w", err)
+	}
+	rows := parseSimpleCSV(output)
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return rows[0]["parent_id"], nil
+}
+
+// QueryFollowUps returns the items spawned by partial accepts of parentID
+// at ref ("" = main), oldest first. Only ID, title and status are set.
+func QueryFollowUps(db DB, parentID, ref string) ([]WantedItem, error) {
+	query := fmt.Sprintf("SELECT id, title, status FROM wanted WHERE parent_id='%s' ORDER BY created_at, id", EscapeSQL(parentID))
+	output, err := db.Query(query, ref)
+	if err != nil {
+		return nil, fmt.Errorf("querying follow-up items: %w", err)
+	}
+	var out []WantedItem
+	for _, row := range parseSimpleCSV(output) {
+		if row["id"] == "" {
+			continue
+		}
+		out = append(out, WantedItem{ID: row["id"], Title: row["title"], Status: row["status"], ParentID: parentID})
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewFollowUpItem(t *testing.T) {
+	parent := &WantedItem{ID: "w-1", Project: "gastown", Type: "bug", Priority: 1, Tags: []string{"go"}, EffortLevel: "large", PostedBy: "carol"}
+	item, err := NewFollowUpItem(parent, " Finish docs ", "the rest", "alice")
+	if err != nil {
+		t.Fatalf("NewFollowUpItem: %v", err)
+	}
+	if item.Title != "Finish docs" || item.ParentID != "w-1" || item.PostedBy != "alice" ||
+		item.Project != "gastown" || item.Type != "bug" || item.Priority != 1 || item.EffortLevel != "large" {
+		t.Errorf("item = %+v", item)
+	}
+	if !strings.HasPrefix(item.ID, "w-") || item.ID == parent.ID {
+		t.Errorf("ID = %q, want a fresh wanted ID", item.ID)
+	}
+
+	dml, err := InsertWantedDML(item)
+	if err != nil {
+		t.Fatalf("InsertWantedDML: %v", err)
+	}
+	if !strings.Contains(dml, "effort_level, parent_id, created_at") || !strings.Contains(dml, "'large', 'w-1',") {
+		t.Errorf("DML missing parent_id:\n%s", dml)
+	}
+
+	if _, err := NewFollowUpItem(parent, "  ", "", "alice"); err == nil {
+		t.Error("expected error for empty title")
+	}
+}
+
+func TestInsertWantedDML_OmitsParentWhenUnset(t *testing.T) {
+	dml, err := InsertWantedDML(&WantedItem{ID: "w-2", Title: "Plain post"})
+	if err != nil {
+		t.Fatalf("InsertWantedDML: %v", err)
+	}
+	if strings.Contains(dml, "parent_id") {
+		t.Errorf("plain post should not write parent_id:\n%s", dml)
+	}
+}
+
+func TestQueryFollowUps(t *testing.T) {
+	db := &fakeDB{results: map[string]string{"WHERE parent_id=": "id,title,status\nw-2,Finish docs,open\n"}}
+	got, err := QueryFollowUps(db, "w-1", "")
+	if err != nil {
+		t.Fatalf("QueryFollowUps: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "w-2" || got[0].Status != "open" || got[0].ParentID != "w-1" {
+		t.Errorf("follow-ups = %+v", got)
+	}
+}
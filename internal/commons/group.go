@@ -0,0 +1,131 @@
+package commons
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GroupBy selects the field browse results are grouped under.
+type GroupBy string
+
+// Group-by modes for browse output.
+const (
+	GroupNone     GroupBy = ""
+	GroupProject  GroupBy = "project"
+	GroupType     GroupBy = "type"
+	GroupPriority GroupBy = "priority"
+	GroupStatus   GroupBy = "status"
+)
+
+// ValidGroupBys returns the group-by modes in TUI cycle order, ungrouped
+// first.
+func ValidGroupBys() []GroupBy {
+	return []GroupBy{GroupNone, GroupProject, GroupType, GroupPriority, GroupStatus}
+}
+
+// ParseGroupBy validates a --group-by value. "" and "none" mean ungrouped.
+func ParseGroupBy(s string) (GroupBy, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "none" {
+		return GroupNone, nil
+	}
+	for _, g := range ValidGroupBys() {
+		if string(g) == s {
+			return g, nil
+		}
+	}
+	return GroupNone, fmt.Errorf("invalid group-by %q: use project, type, priority, or status", s)
+}
+
+// SummaryGroup is one section of grouped browse results. Key is the shared
+// value of the grouped field ("" when items don't set it).
+type SummaryGroup struct {
+	Key   string
+	Items []WantedSummary
+}
+
+// Label returns the section heading for a group, e.g. "P1" or "(no project)".
+func (g SummaryGroup) Label(by GroupBy) string {
+	switch {
+	case by == GroupPriority:
+		return "P" + g.Key
+	case g.Key == "":
+		return "(no " + string(by) + ")"
+	default:
+		return g.Key
+	}
+}
+
+// statusOrder is the lifecycle order status groups are listed in.
+var statusOrder = []string{"open", "claimed", "in_review", "completed", "withdrawn"}
+
+// GroupSummaries splits items into sections by the given field, keeping
+// the items' order within each section. Priorities are listed most urgent
+// first, statuses in lifecycle order, and projects and types by name with
+// the unset group last. GroupNone yields a single unnamed group.
+func GroupSummaries(items []WantedSummary, by GroupBy) []SummaryGroup {
+	if by == GroupNone {
+		return []SummaryGroup{{Items: items}}
+	}
+	index := make(map[string]int)
+	var groups []SummaryGroup
+	for _, item := range items {
+		key := groupKey(item, by)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, SummaryGroup{Key: key})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+	slices.SortStableFunc(groups, func(a, b SummaryGroup) int {
+		return compareGroupKeys(a.Key, b.Key, by)
+	})
+	return groups
+}
+
+func groupKey(item WantedSummary, by GroupBy) string {
+	switch by {
+	case GroupProject:
+		return item.Project
+	case GroupType:
+		return item.Type
+	case GroupPriority:
+		return strconv.Itoa(item.Priority)
+	case GroupStatus:
+		return item.Status
+	}
+	return ""
+}
+
+func compareGroupKeys(a, b string, by GroupBy) int {
+	switch by {
+	case GroupPriority:
+		pa, _ := strconv.Atoi(a)
+		pb, _ := strconv.Atoi(b)
+		return cmp.Compare(pa, pb)
+	case GroupStatus:
+		return cmp.Compare(statusRank(a), statusRank(b))
+	}
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// statusRank orders statuses by lifecycle; unknown statuses sort last.
+func statusRank(status string) int {
+	if i := slices.Index(statusOrder, status); i >= 0 {
+		return i
+	}
+	return len(statusOrder)
+}
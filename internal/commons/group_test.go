@@ -0,0 +1,53 @@
+package commons
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroupSummaries(t *testing.T) {
+	items := []WantedSummary{
+		{ID: "w-1", Priority: 2, Status: "claimed", Type: "bug"},
+		{ID: "w-2", Priority: 10, Status: "open"},
+		{ID: "w-3", Priority: 0, Status: "open", Type: "bug"},
+		{ID: "w-4", Priority: 2, Status: "completed", Type: "docs"},
+	}
+	keys := func(groups []SummaryGroup) []string {
+		var out []string
+		for _, g := range groups {
+			out = append(out, g.Key)
+		}
+		return out
+	}
+
+	if got := keys(GroupSummaries(items, GroupPriority)); !slices.Equal(got, []string{"0", "2", "10"}) {
+		t.Errorf("priority groups = %v", got)
+	}
+	if got := keys(GroupSummaries(items, GroupStatus)); !slices.Equal(got, []string{"open", "claimed", "completed"}) {
+		t.Errorf("status groups = %v", got)
+	}
+	types := GroupSummaries(items, GroupType)
+	if got := keys(types); !slices.Equal(got, []string{"bug", "docs", ""}) {
+		t.Errorf("type groups = %v", got)
+	}
+	if ids := []string{types[0].Items[0].ID, types[0].Items[1].ID}; !slices.Equal(ids, []string{"w-1", "w-3"}) {
+		t.Errorf("bug group = %v, want input order", ids)
+	}
+	if label := types[2].Label(GroupType); label != "(no type)" {
+		t.Errorf("unset label = %q", label)
+	}
+	if all := GroupSummaries(items, GroupNone); len(all) != 1 || len(all[0].Items) != 4 {
+		t.Errorf("ungrouped = %+v", all)
+	}
+}
+
+func TestParseGroupBy(t *testing.T) {
+	for in, want := range map[string]GroupBy{"": GroupNone, "none": GroupNone, "Project": GroupProject, "priority": GroupPriority} {
+		if got, err := ParseGroupBy(in); err != nil || got != want {
+			t.Errorf("ParseGroupBy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseGroupBy("owner"); err == nil {
+		t.Error("ParseGroupBy(owner) should fail")
+	}
+}
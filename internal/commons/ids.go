@@ -0,0 +1,113 @@
+package commons
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IDScheme is how a wasteland names new wanted items: Prefix, a dash, and
+// Length hex characters, as in w-1a2b3c4d5e. A wasteland sets its own with
+// the id_prefix and id_length _meta keys.
+type IDScheme struct {
+	Prefix string
+	Length int
+}
+
+// Defaults and bounds for IDScheme.
+const (
+	DefaultIDPrefix = "w"
+	DefaultIDLength = 10
+	MinIDLength     = 6
+	MaxIDLength     = 32
+)
+
+// maxIDAttempts is how many fresh IDs NewWantedID tries before giving up.
+const maxIDAttempts = 5
+
+var (
+	idPrefixRe = regexp.MustCompile(`^[a-z][a-z0-9]{0,7}$`)
+	wantedIDRe = regexp.MustCompile(`^[a-z][a-z0-9]{0,7}-[0-9a-f]+$`)
+)
+
+// DefaultIDScheme returns the scheme of a wasteland that doesn't set one.
+func DefaultIDScheme() IDScheme {
+	return IDScheme{Prefix: DefaultIDPrefix, Length: DefaultIDLength}
+}
+
+// Validate checks that the prefix is one to eight lowercase letters and
+// digits, starting with a letter, and that the length is within
+// MinIDLength and MaxIDLength.
+func (s IDScheme) Validate() error {
+	if !idPrefixRe.MatchString(s.Prefix) {
+		return fmt.Errorf("invalid id_prefix %q: use 1-8 lowercase letters and digits, starting with a letter", s.Prefix)
+	}
+	if s.Length < MinIDLength || s.Length > MaxIDLength {
+		return fmt.Errorf("invalid id_length %d: must be between %d and %d", s.Length, MinIDLength, MaxIDLength)
+	}
+	return nil
+}
+
+// Generate returns a fresh ID in the scheme, hashed from title, the time
+// and random bytes. It doesn't check that the ID is free; NewWantedID does.
+func (s IDScheme) Generate(title string) string {
+	randomBytes := make([]byte, 8)
+	_, _ = rand.Read(randomBytes)
+
+	input := fmt.Sprintf("%s:%d:%x", title, time.Now().UnixNano(), randomBytes)
+	hash := sha256.Sum256([]byte(input))
+	return s.Prefix + "-" + hex.EncodeToString(hash[:])[:s.Length]
+}
+
+// IsWantedID reports whether s looks like a wanted item ID in any scheme.
+func IsWantedID(s string) bool {
+	return wantedIDRe.MatchString(s)
+}
+
+// QueryIDScheme reads the id_prefix and id_length _meta keys from main.
+// Keys that are absent or invalid keep their defaults.
+func QueryIDScheme(db DB) (IDScheme, error) {
+	s := DefaultIDScheme()
+	output, err := db.Query("SELECT `key`, value FROM _meta WHERE `key` IN ('id_prefix', 'id_length')", "")
+	if err != nil {
+		return s, fmt.Errorf("querying id scheme: %w", err)
+	}
+	for _, row := range parseSimpleCSV(output) {
+		value := strings.TrimSpace(row["value"])
+		switch row["key"] {
+		case "id_prefix":
+			if idPrefixRe.MatchString(value) {
+				s.Prefix = value
+			}
+		case "id_length":
+			if n, err := strconv.Atoi(value); err == nil && n >= MinIDLength && n <= MaxIDLength {
+				s.Length = n
+			}
+		}
+	}
+	return s, nil
+}
+
+// NewWantedID returns an ID in scheme for a new item titled title that no
+// item on main already has, generating a fresh one on each collision.
+func NewWantedID(db DB, scheme IDScheme, title string) (string, error) {
+	if err := scheme.Validate(); err != nil {
+		return "", err
+	}
+	for range maxIDAttempts {
+		id := scheme.Generate(title)
+		output, err := db.Query(fmt.Sprintf("SELECT id FROM wanted WHERE id='%s'", EscapeSQL(id)), "")
+		if err != nil {
+			return "", fmt.Errorf("checking wanted ID %s: %w", id, err)
+		}
+		if len(parseSimpleCSV(output)) == 0 {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("no free wanted ID after %d attempts; raise id_length in _meta", maxIDAttempts)
+}
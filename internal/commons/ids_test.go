@@ -0,0 +1,113 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIDScheme_Generate(t *testing.T) {
+	t.Parallel()
+	id := IDScheme{Prefix: "gt", Length: 16}.Generate("Title")
+	if !strings.HasPrefix(id, "gt-") || len(id) != len("gt-")+16 {
+		t.Errorf("Generate() = %q, want gt- and 16 hex chars", id)
+	}
+	if !IsWantedID(id) {
+		t.Errorf("IsWantedID(%q) = false", id)
+	}
+}
+
+func TestIDScheme_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		scheme IDScheme
+		ok     bool
+	}{
+		{DefaultIDScheme(), true},
+		{IDScheme{Prefix: "q2", Length: MinIDLength}, true},
+		{IDScheme{Prefix: "gt", Length: MaxIDLength}, true},
+		{IDScheme{Prefix: "", Length: 10}, false},
+		{IDScheme{Prefix: "GT", Length: 10}, false},
+		{IDScheme{Prefix: "2w", Length: 10}, false},
+		{IDScheme{Prefix: "toolongpfx", Length: 10}, false},
+		{IDScheme{Prefix: "w", Length: MinIDLength - 1}, false},
+		{IDScheme{Prefix: "w", Length: MaxIDLength + 1}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.scheme.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate(%+v) = %v, want ok=%v", tt.scheme, err, tt.ok)
+		}
+	}
+}
+
+func TestQueryIDScheme(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		csv  string
+		want IDScheme
+	}{
+		{"unset", "key,value\n", DefaultIDScheme()},
+		{"both", "key,value\nid_prefix,gt\nid_length,16\n", IDScheme{Prefix: "gt", Length: 16}},
+		{"invalid values keep defaults", "key,value\nid_prefix,Gas Town\nid_length,3\n", DefaultIDScheme()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			db := &fakeDB{results: map[string]string{"'id_prefix', 'id_length'": tt.csv}}
+			got, err := QueryIDScheme(db)
+			if err != nil {
+				t.Fatalf("QueryIDScheme: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QueryIDScheme = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// takenIDsDB reports the first taken IDs it is asked about as existing.
+type takenIDsDB struct {
+	fakeDB
+	taken int
+}
+
+func (d *takenIDsDB) Query(sql, _ string) (string, error) {
+	d.queries = append(d.queries, sql)
+	if d.taken > 0 {
+		d.taken--
+		return "id\nw-taken\n", nil
+	}
+	return "id\n", nil
+}
+
+func TestNewWantedID_RetriesOnCollision(t *testing.T) {
+	t.Parallel()
+	db := &takenIDsDB{taken: 2}
+	id, err := NewWantedID(db, DefaultIDScheme(), "Title")
+	if err != nil {
+		t.Fatalf("NewWantedID: %v", err)
+	}
+	if len(db.queries) != 3 {
+		t.Errorf("checked %d IDs, want 3", len(db.queries))
+	}
+	if !strings.Contains(db.queries[2], "'"+id+"'") {
+		t.Errorf("returned %s, but the last check was %s", id, db.queries[2])
+	}
+}
+
+func TestNewWantedID_GivesUp(t *testing.T) {
+	t.Parallel()
+	db := &takenIDsDB{taken: maxIDAttempts}
+	if _, err := NewWantedID(db, DefaultIDScheme(), "Title"); err == nil || !strings.Contains(err.Error(), "id_length") {
+		t.Fatalf("err = %v, want a hint to raise id_length", err)
+	}
+}
+
+func TestNewWantedID_QueryError(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{err: errors.New("connection refused")}
+	if _, err := NewWantedID(db, DefaultIDScheme(), "Title"); err == nil {
+		t.Fatal("expected error when the check fails")
+	}
+}
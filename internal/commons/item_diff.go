@@ -0,0 +1,161 @@
+package commons
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldChange is one column whose value differs between main and a branch.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// RowChange describes how one row differs between main and a branch.
+type RowChange struct {
+	Table    string        `json:"table"`
+	ID       string        `json:"id"`
+	DiffType string        `json:"diff_type"` // "added", "modified", or "removed"
+	Fields   []FieldChange `json:"fields"`
+}
+
+// DiffWantedItem compares a wanted item, its completions, and their stamps
+// between main and branch, returning one RowChange per differing row.
+// An empty result means the branch carries no changes for the item.
+func DiffWantedItem(db DB, wantedID, branch string) ([]RowChange, error) {
+	var changes []RowChange
+
+	wantedQuery := fmt.Sprintf("SELECT * FROM wanted WHERE id = '%s'", EscapeSQL(wantedID))
+	wc, err := diffRows(db, "wanted", wantedQuery, branch)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, wc...)
+
+	completionQuery := fmt.Sprintf("SELECT * FROM completions WHERE wanted_id = '%s' ORDER BY id", EscapeSQL(wantedID))
+	mainCompletions, err := queryRowsByID(db, completionQuery, "")
+	if err != nil {
+		return nil, fmt.Errorf("querying completions on main: %w", err)
+	}
+	branchCompletions, err := queryRowsByID(db, completionQuery, branch)
+	if err != nil {
+		return nil, fmt.Errorf("querying completions on %s: %w", branch, err)
+	}
+	changes = append(changes, compareRows("completions", mainCompletions, branchCompletions)...)
+
+	// Stamps hang off completions via context_id.
+	ids := map[string]bool{}
+	for id := range mainCompletions {
+		ids[id] = true
+	}
+	for id := range branchCompletions {
+		ids[id] = true
+	}
+	if len(ids) > 0 {
+		quoted := make([]string, 0, len(ids))
+		for id := range ids {
+			quoted = append(quoted, "'"+EscapeSQL(id)+"'")
+		}
+		sort.Strings(quoted)
+		stampQuery := fmt.Sprintf("SELECT * FROM stamps WHERE context_id IN (%s) ORDER BY id", strings.Join(quoted, ", "))
+		sc, err := diffRows(db, "stamps", stampQuery, branch)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, sc...)
+	}
+
+	return changes, nil
+}
+
+// diffRows runs query against main and branch and compares the results.
+func diffRows(db DB, table, query, branch string) ([]RowChange, error) {
+	mainRows, err := queryRowsByID(db, query, "")
+	if err != nil {
+		return nil, fmt.Errorf("querying %s on main: %w", table, err)
+	}
+	branchRows, err := queryRowsByID(db, query, branch)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s on %s: %w", table, branch, err)
+	}
+	return compareRows(table, mainRows, branchRows), nil
+}
+
+// orderedRow is a CSV row with its column order preserved.
+type orderedRow struct {
+	columns []string
+	values  map[string]string
+}
+
+func queryRowsByID(db DB, query, ref string) (map[string]orderedRow, error) {
+	out, err := db.Query(query, ref)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return map[string]orderedRow{}, nil
+	}
+	var columns []string
+	for _, h := range parseCSVLine(lines[0]) {
+		columns = append(columns, strings.TrimSpace(h))
+	}
+	rows := make(map[string]orderedRow)
+	for _, row := range parseSimpleCSV(out) {
+		if id := row["id"]; id != "" {
+			rows[id] = orderedRow{columns: columns, values: row}
+		}
+	}
+	return rows, nil
+}
+
+// compareRows diffs two ID-keyed row sets, returning changes sorted by ID.
+func compareRows(table string, from, to map[string]orderedRow) []RowChange {
+	ids := make([]string, 0, len(from)+len(to))
+	for id := range from {
+		ids = append(ids, id)
+	}
+	for id := range to {
+		if _, ok := from[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var changes []RowChange
+	for _, id := range ids {
+		f, inFrom := from[id]
+		t, inTo := to[id]
+		change := RowChange{Table: table, ID: id}
+		switch {
+		case inFrom && inTo:
+			change.DiffType = "modified"
+			for _, col := range t.columns {
+				if f.values[col] != t.values[col] {
+					change.Fields = append(change.Fields, FieldChange{Field: col, From: f.values[col], To: t.values[col]})
+				}
+			}
+			if len(change.Fields) == 0 {
+				continue
+			}
+		case inTo:
+			change.DiffType = "added"
+			for _, col := range t.columns {
+				if v := t.values[col]; v != "" {
+					change.Fields = append(change.Fields, FieldChange{Field: col, To: v})
+				}
+			}
+		default:
+			change.DiffType = "removed"
+			for _, col := range f.columns {
+				if v := f.values[col]; v != "" {
+					change.Fields = append(change.Fields, FieldChange{Field: col, From: v})
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
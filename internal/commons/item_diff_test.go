@@ -0,0 +1,93 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+// refDB is a fakeDB whose results depend on the query ref.
+type refDB struct {
+	fakeDB
+	byRef map[string]map[string]string // ref -> sql substring -> CSV output
+}
+
+func (r *refDB) Query(sql, ref string) (string, error) {
+	r.queries = append(r.queries, sql)
+	for key, val := range r.byRef[ref] {
+		if strings.Contains(sql, key) {
+			return val, nil
+		}
+	}
+	return "", nil
+}
+
+func TestDiffWantedItem(t *testing.T) {
+	t.Parallel()
+	db := &refDB{byRef: map[string]map[string]string{
+		"": {
+			"FROM wanted":      "id,title,status,claimed_by\nw-1,Fix bug,claimed,bob\n",
+			"FROM completions": "id,wanted_id,completed_by\n",
+		},
+		"wl/bob/w-1": {
+			"FROM wanted":      "id,title,status,claimed_by\nw-1,Fix bug,in_review,bob\n",
+			"FROM completions": "id,wanted_id,completed_by,evidence\nc-1,w-1,bob,https://example.com/pr/1\n",
+		},
+	}}
+
+	changes, err := DiffWantedItem(db, "w-1", "wl/bob/w-1")
+	if err != nil {
+		t.Fatalf("DiffWantedItem: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(changes), changes)
+	}
+
+	w := changes[0]
+	if w.Table != "wanted" || w.DiffType != "modified" || len(w.Fields) != 1 {
+		t.Fatalf("wanted change = %+v", w)
+	}
+	if f := w.Fields[0]; f.Field != "status" || f.From != "claimed" || f.To != "in_review" {
+		t.Errorf("wanted field = %+v", f)
+	}
+
+	c := changes[1]
+	if c.Table != "completions" || c.DiffType != "added" || c.ID != "c-1" {
+		t.Fatalf("completion change = %+v", c)
+	}
+	if len(c.Fields) != 4 || c.Fields[3].To != "https://example.com/pr/1" {
+		t.Errorf("completion fields = %+v", c.Fields)
+	}
+
+	var stampQuery string
+	for _, q := range db.queries {
+		if strings.Contains(q, "FROM stamps") {
+			stampQuery = q
+		}
+	}
+	if !strings.Contains(stampQuery, "context_id IN ('c-1')") {
+		t.Errorf("stamp query = %q, want completion IDs", stampQuery)
+	}
+}
+
+func TestDiffWantedItem_NoChanges(t *testing.T) {
+	t.Parallel()
+	rows := map[string]string{"FROM wanted": "id,title,status\nw-1,Fix bug,open\n"}
+	db := &refDB{byRef: map[string]map[string]string{"": rows, "wl/bob/w-1": rows}}
+
+	changes, err := DiffWantedItem(db, "w-1", "wl/bob/w-1")
+	if err != nil {
+		t.Fatalf("DiffWantedItem: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestCompareRows_Removed(t *testing.T) {
+	t.Parallel()
+	from := map[string]orderedRow{"s-1": {columns: []string{"id", "author"}, values: map[string]string{"id": "s-1", "author": "alice"}}}
+	changes := compareRows("stamps", from, map[string]orderedRow{})
+	if len(changes) != 1 || changes[0].DiffType != "removed" || changes[0].Fields[1].From != "alice" {
+		t.Errorf("changes = %+v", changes)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // maxLeaderboardLimit is the hard ceiling for leaderboard queries to prevent
@@ -13,22 +14,142 @@ const maxLeaderboardLimit = 100
 // LeaderboardEntry holds aggregated stats for one rig on the leaderboard.
 type LeaderboardEntry struct {
 	RigHandle     string
+	Rank          int // 1-based position on this leaderboard
 	Completions   int
 	AvgQuality    float64
 	AvgReliab     float64
 	AvgCreativity float64
 	TopSkills     []string // up to 5 most frequent skill tags
+	Badges        []string // badge types the rig holds, oldest first
+	// PrevRank is the rig's rank over the previous period of the same
+	// length, 0 if it wasn't ranked then. Only set when filtering by Since.
+	PrevRank int
+}
+
+// RankDelta returns how many places the rig climbed since the previous
+// period (negative when it fell). It is 0 for rigs new to the leaderboard.
+func (e LeaderboardEntry) RankDelta() int {
+	if e.PrevRank == 0 {
+		return 0
+	}
+	return e.PrevRank - e.Rank
+}
+
+// LeaderboardFilter narrows the completions a leaderboard is built from.
+// The zero value ranks every validated completion of all time.
+type LeaderboardFilter struct {
+	Limit   int
+	Since   time.Duration // only completions within Since of Now; 0 = all time
+	Now     time.Time     // end of the period; zero means time.Now()
+	Project string        // only completions of items in this project
+	Skill   string        // only completions stamped with this skill tag
+}
+
+// ParsePeriod parses a leaderboard timeframe such as "30d", "2w" or "12h".
+func ParsePeriod(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	var err error
+	if n, ok := strings.CutSuffix(s, "d"); ok {
+		var days int
+		days, err = strconv.Atoi(n)
+		d = time.Duration(days) * 24 * time.Hour
+	} else if n, ok := strings.CutSuffix(s, "w"); ok {
+		var weeks int
+		weeks, err = strconv.Atoi(n)
+		d = time.Duration(weeks) * 7 * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid period %q: use a positive duration like 30d, 2w or 12h", s)
+	}
+	return d, nil
 }
 
 // QueryLeaderboard aggregates completions and stamps into a ranked leaderboard.
 // Rigs are ranked by number of validated completions (those with a stamp_id).
-func QueryLeaderboard(db DB, limit int) ([]LeaderboardEntry, error) {
+// With f.Since set, each entry also carries its rank over the preceding
+// period of the same length.
+func QueryLeaderboard(db DB, f LeaderboardFilter) ([]LeaderboardEntry, error) {
+	limit := f.Limit
 	if limit <= 0 {
 		limit = 20
 	}
 	if limit > maxLeaderboardLimit {
 		limit = maxLeaderboardLimit
 	}
+	now := f.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var start time.Time
+	if f.Since > 0 {
+		start = now.Add(-f.Since)
+	}
+
+	join, conds := leaderboardSource(f, start, now)
+	entries, err := queryLeaderboardRanks(db, join, conds, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	// Fetch top skills for all rigs in a single query to avoid N+1.
+	if err := populateTopSkills(db, entries, join, conds); err != nil {
+		return nil, fmt.Errorf("querying top skills: %w", err)
+	}
+
+	if f.Since > 0 {
+		join, conds := leaderboardSource(f, start.Add(-f.Since), start)
+		prev, err := queryLeaderboardRanks(db, join, conds, maxLeaderboardLimit)
+		if err != nil {
+			return nil, fmt.Errorf("querying previous period: %w", err)
+		}
+		prevRank := make(map[string]int, len(prev))
+		for _, p := range prev {
+			prevRank[p.RigHandle] = p.Rank
+		}
+		for i := range entries {
+			entries[i].PrevRank = prevRank[entries[i].RigHandle]
+		}
+	}
+
+	if err := populateBadges(db, entries); err != nil {
+		return nil, fmt.Errorf("querying badges: %w", err)
+	}
+	return entries, nil
+}
+
+// leaderboardSource returns the join and filter conditions shared by the
+// ranking and skills queries, for completions in [start, end). A zero
+// start means all time. wanted is joined only when filtering by project.
+func leaderboardSource(f LeaderboardFilter, start, end time.Time) (string, []string) {
+	join := "FROM completions c\nJOIN stamps s ON c.stamp_id = s.id"
+	var conds []string
+	if !start.IsZero() {
+		conds = append(conds,
+			fmt.Sprintf("c.completed_at >= '%s'", start.UTC().Format(time.DateTime)),
+			fmt.Sprintf("c.completed_at < '%s'", end.UTC().Format(time.DateTime)))
+	}
+	if f.Project != "" {
+		join += "\nJOIN wanted w ON w.id = c.wanted_id"
+		conds = append(conds, fmt.Sprintf("w.project = '%s'", EscapeSQL(f.Project)))
+	}
+	if f.Skill != "" {
+		conds = append(conds, fmt.Sprintf("JSON_CONTAINS(s.skill_tags, %s)", sqlJSONString(f.Skill)))
+	}
+	return join, conds
+}
+
+// queryLeaderboardRanks runs the ranking query and numbers the rows.
+func queryLeaderboardRanks(db DB, join string, conds []string, limit int) ([]LeaderboardEntry, error) {
+	where := ""
+	if len(conds) > 0 {
+		where = "\nWHERE " + strings.Join(conds, " AND ")
+	}
 
 	// Join completions with stamps to get per-rig aggregates.
 	// Only count completions that have been validated (stamp_id IS NOT NULL).
@@ -38,11 +159,10 @@ func QueryLeaderboard(db DB, limit int) ([]LeaderboardEntry, error) {
   COALESCE(AVG(JSON_EXTRACT(s.valence, '$.quality')), 0) AS avg_quality,
   COALESCE(AVG(JSON_EXTRACT(s.valence, '$.reliability')), 0) AS avg_reliability,
   COALESCE(AVG(JSON_EXTRACT(s.valence, '$.creativity')), 0) AS avg_creativity
-FROM completions c
-JOIN stamps s ON c.stamp_id = s.id
+%s%s
 GROUP BY c.completed_by
 ORDER BY completions DESC, avg_quality DESC, c.completed_by ASC
-LIMIT %d`, limit)
+LIMIT %d`, join, where, limit)
 
 	output, err := db.Query(query, "")
 	if err != nil {
@@ -55,7 +175,7 @@ LIMIT %d`, limit)
 	}
 
 	entries := make([]LeaderboardEntry, 0, len(rows))
-	for _, row := range rows {
+	for i, row := range rows {
 		completions, err := strconv.Atoi(row["completions"])
 		if err != nil {
 			return nil, fmt.Errorf("parsing completions for %q: %w", row["completed_by"], err)
@@ -75,24 +195,20 @@ LIMIT %d`, limit)
 
 		entries = append(entries, LeaderboardEntry{
 			RigHandle:     row["completed_by"],
+			Rank:          i + 1,
 			Completions:   completions,
 			AvgQuality:    avgQ,
 			AvgReliab:     avgR,
 			AvgCreativity: avgC,
 		})
 	}
-
-	// Fetch top skills for all rigs in a single query to avoid N+1.
-	if err := populateTopSkills(db, entries); err != nil {
-		return nil, fmt.Errorf("querying top skills: %w", err)
-	}
-
 	return entries, nil
 }
 
 // populateTopSkills fetches skill tags for all rigs in a single query and
-// assigns the top 5 most frequent tags to each entry.
-func populateTopSkills(db DB, entries []LeaderboardEntry) error {
+// assigns the top 5 most frequent tags to each entry. join and conds are
+// the ranking query's, so skills come from the same completions.
+func populateTopSkills(db DB, entries []LeaderboardEntry, join string, conds []string) error {
 	if len(entries) == 0 {
 		return nil
 	}
@@ -106,12 +222,15 @@ func populateTopSkills(db DB, entries []LeaderboardEntry) error {
 	// Use the same join path as the main query (completions.stamp_id → stamps.id)
 	// to ensure consistency between which stamps count for ranking vs skills.
 	// No global LIMIT — the IN clause is bounded by the main query's limit (≤100 rigs).
+	conds = append([]string{
+		fmt.Sprintf("c.completed_by IN (%s)", strings.Join(handles, ",")),
+		"s.skill_tags IS NOT NULL AND s.skill_tags != ''",
+	}, conds...)
 	query := fmt.Sprintf(`SELECT c.completed_by, s.skill_tags
-FROM completions c
-JOIN stamps s ON c.stamp_id = s.id
-WHERE c.completed_by IN (%s) AND s.skill_tags IS NOT NULL AND s.skill_tags != ''
+%s
+WHERE %s
 ORDER BY c.completed_by`,
-		strings.Join(handles, ","))
+		join, strings.Join(conds, " AND "))
 
 	output, err := db.Query(query, "")
 	if err != nil {
@@ -5,13 +5,15 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 )
 
 // fakeDB implements DB for leaderboard tests.
 type fakeDB struct {
-	queries []string
-	results map[string]string // sql substring -> CSV output
-	err     error
+	queries  []string
+	results  map[string]string // sql substring -> CSV output
+	branches []string
+	err      error
 }
 
 func (f *fakeDB) Query(sql, _ string) (string, error) {
@@ -27,16 +29,24 @@ func (f *fakeDB) Query(sql, _ string) (string, error) {
 	return "", nil
 }
 
-func (f *fakeDB) Exec(_, _ string, _ bool, _ ...string) error { return nil }
-func (f *fakeDB) Branches(_ string) ([]string, error)         { return nil, nil }
-func (f *fakeDB) DeleteBranch(_ string) error                 { return nil }
-func (f *fakeDB) PushBranch(_ string, _ io.Writer) error      { return nil }
-func (f *fakeDB) PushMain(_ io.Writer) error                  { return nil }
-func (f *fakeDB) Sync() error                                 { return nil }
-func (f *fakeDB) MergeBranch(_ string) error                  { return nil }
-func (f *fakeDB) DeleteRemoteBranch(_ string) error           { return nil }
-func (f *fakeDB) PushWithSync(_ io.Writer) error              { return nil }
-func (f *fakeDB) CanWildWest() error                          { return nil }
+func (f *fakeDB) Exec(_, _ string, _ Signing, _ ...string) error { return nil }
+func (f *fakeDB) Branches(prefix string) ([]string, error) {
+	var out []string
+	for _, b := range f.branches {
+		if strings.HasPrefix(b, prefix) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+func (f *fakeDB) DeleteBranch(_ string) error            { return nil }
+func (f *fakeDB) PushBranch(_ string, _ io.Writer) error { return nil }
+func (f *fakeDB) PushMain(_ io.Writer) error             { return nil }
+func (f *fakeDB) Sync() error                            { return nil }
+func (f *fakeDB) MergeBranch(_ string) error             { return nil }
+func (f *fakeDB) DeleteRemoteBranch(_ string) error      { return nil }
+func (f *fakeDB) PushWithSync(_ io.Writer) error         { return nil }
+func (f *fakeDB) CanWildWest() error                     { return nil }
 
 func TestQueryLeaderboard_BasicRanking(t *testing.T) {
 	t.Parallel()
@@ -44,7 +54,7 @@ func TestQueryLeaderboard_BasicRanking(t *testing.T) {
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,5,4.2,3.8,3.0\nbob,3,4.0,4.5,2.5\n",
 		"IN (":     "completed_by,skill_tags\n",
 	}}
-	entries, err := QueryLeaderboard(db, 20)
+	entries, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 20})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -67,7 +77,7 @@ func TestQueryLeaderboard_Empty(t *testing.T) {
 	db := &fakeDB{results: map[string]string{
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\n",
 	}}
-	entries, err := QueryLeaderboard(db, 10)
+	entries, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -81,7 +91,7 @@ func TestQueryLeaderboard_DefaultLimit(t *testing.T) {
 	db := &fakeDB{results: map[string]string{
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\n",
 	}}
-	_, _ = QueryLeaderboard(db, 0)
+	_, _ = QueryLeaderboard(db, LeaderboardFilter{Limit: 0})
 	if len(db.queries) == 0 {
 		t.Fatal("no queries executed")
 	}
@@ -95,7 +105,7 @@ func TestQueryLeaderboard_CapsLimit(t *testing.T) {
 	db := &fakeDB{results: map[string]string{
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\n",
 	}}
-	_, _ = QueryLeaderboard(db, 99999)
+	_, _ = QueryLeaderboard(db, LeaderboardFilter{Limit: 99999})
 	if len(db.queries) == 0 {
 		t.Fatal("no queries executed")
 	}
@@ -107,7 +117,7 @@ func TestQueryLeaderboard_CapsLimit(t *testing.T) {
 func TestQueryLeaderboard_QueryError(t *testing.T) {
 	t.Parallel()
 	db := &fakeDB{err: fmt.Errorf("db down")}
-	_, err := QueryLeaderboard(db, 10)
+	_, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -121,7 +131,7 @@ func TestQueryLeaderboard_ParseError(t *testing.T) {
 	db := &fakeDB{results: map[string]string{
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,not-a-number,4.0,3.0,2.0\n",
 	}}
-	_, err := QueryLeaderboard(db, 10)
+	_, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	if err == nil {
 		t.Fatal("expected parse error, got nil")
 	}
@@ -136,7 +146,7 @@ func TestQueryLeaderboard_WithSkills(t *testing.T) {
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,3,4.0,3.5,3.0\n",
 		"IN (":     "completed_by,skill_tags\nalice,\"[\"\"go\"\",\"\"sql\"\"]\"\nalice,\"[\"\"go\"\",\"\"testing\"\"]\"\n",
 	}}
-	entries, err := QueryLeaderboard(db, 10)
+	entries, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -158,13 +168,13 @@ func TestQueryLeaderboard_BulkSkillQuery(t *testing.T) {
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,3,4.0,3.5,3.0\nbob,2,3.0,3.0,2.5\n",
 		"IN (":     "completed_by,skill_tags\n",
 	}}
-	_, err := QueryLeaderboard(db, 10)
+	_, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	// Should be exactly 2 queries: one for leaderboard, one for skills (bulk).
-	if len(db.queries) != 2 {
-		t.Errorf("expected 2 queries (bulk), got %d", len(db.queries))
+	// Should be exactly 3 queries: leaderboard, then skills and badges in bulk.
+	if len(db.queries) != 3 {
+		t.Errorf("expected 3 queries (bulk), got %d", len(db.queries))
 	}
 }
 
@@ -175,7 +185,7 @@ func TestQueryLeaderboard_MalformedSkillTags(t *testing.T) {
 		"IN (":     "completed_by,skill_tags\nalice,not-valid-json\n",
 	}}
 	// Malformed skill_tags should be silently skipped, not cause an error.
-	entries, err := QueryLeaderboard(db, 10)
+	entries, err := QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -193,7 +203,7 @@ func TestQueryLeaderboard_SkillsUseSameJoinPath(t *testing.T) {
 		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,3,4.0,3.5,3.0\n",
 		"IN (":     "completed_by,skill_tags\n",
 	}}
-	_, _ = QueryLeaderboard(db, 10)
+	_, _ = QueryLeaderboard(db, LeaderboardFilter{Limit: 10})
 	// The skills query should use stamp_id join (same as main), not context_id.
 	if len(db.queries) < 2 {
 		t.Fatal("expected at least 2 queries")
@@ -260,3 +270,103 @@ func TestTopNKeys_FewerThanN(t *testing.T) {
 		t.Errorf("first = %q, want 'b'", result[0])
 	}
 }
+
+func TestQueryLeaderboard_Filters(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,3,4.0,3.5,3.0\n",
+		"IN (":     "completed_by,skill_tags\n",
+	}}
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	_, err := QueryLeaderboard(db, LeaderboardFilter{Since: 30 * 24 * time.Hour, Now: now, Project: "gas'town", Skill: "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(db.queries) != 4 {
+		t.Fatalf("expected ranking, skills, previous-period and badge queries, got %d", len(db.queries))
+	}
+	for i, q := range db.queries[:3] {
+		for _, want := range []string{"JOIN wanted w ON w.id = c.wanted_id", "w.project = 'gas''town'", `JSON_CONTAINS(s.skill_tags, '"go"')`} {
+			if !strings.Contains(q, want) {
+				t.Errorf("query %d missing %q: %s", i, want, q)
+			}
+		}
+	}
+	if !strings.Contains(db.queries[0], "c.completed_at >= '2026-03-01 12:00:00' AND c.completed_at < '2026-03-31 12:00:00'") {
+		t.Errorf("ranking query window wrong: %s", db.queries[0])
+	}
+	if !strings.Contains(db.queries[2], "c.completed_at >= '2026-01-30 12:00:00' AND c.completed_at < '2026-03-01 12:00:00'") {
+		t.Errorf("previous-period query window wrong: %s", db.queries[2])
+	}
+}
+
+func TestQueryLeaderboard_NoFiltersNoWhere(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"GROUP BY": "completed_by,completions,avg_quality,avg_reliability,avg_creativity\n",
+	}}
+	_, _ = QueryLeaderboard(db, LeaderboardFilter{})
+	if strings.Contains(db.queries[0], "WHERE") || strings.Contains(db.queries[0], "JOIN wanted") {
+		t.Errorf("unfiltered query should not filter: %s", db.queries[0])
+	}
+}
+
+func TestQueryLeaderboard_PrevRank(t *testing.T) {
+	t.Parallel()
+	db := &multiResultDB{results: []string{
+		"completed_by,completions,avg_quality,avg_reliability,avg_creativity\nalice,5,4.0,4.0,4.0\nbob,4,4.0,4.0,4.0\ncarol,1,4.0,4.0,4.0\n",
+		"completed_by,skill_tags\n",
+		"completed_by,completions,avg_quality,avg_reliability,avg_creativity\nbob,6,4.0,4.0,4.0\nalice,2,4.0,4.0,4.0\n",
+	}}
+	entries, err := QueryLeaderboard(db, LeaderboardFilter{Since: 7 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string][2]int{}
+	for _, e := range entries {
+		got[e.RigHandle] = [2]int{e.Rank, e.RankDelta()}
+	}
+	want := map[string][2]int{"alice": {1, 1}, "bob": {2, -1}, "carol": {3, 0}}
+	for rig, w := range want {
+		if got[rig] != w {
+			t.Errorf("%s rank/delta = %v, want %v", rig, got[rig], w)
+		}
+	}
+	if entries[2].PrevRank != 0 {
+		t.Errorf("carol PrevRank = %d, want 0 (new)", entries[2].PrevRank)
+	}
+}
+
+// multiResultDB answers queries with results in call order.
+type multiResultDB struct {
+	fakeDB
+	results []string
+}
+
+func (m *multiResultDB) Query(sql, _ string) (string, error) {
+	m.queries = append(m.queries, sql)
+	if len(m.results) == 0 {
+		return "", nil
+	}
+	out := m.results[0]
+	m.results = m.results[1:]
+	return out, nil
+}
+
+func TestParsePeriod(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	} {
+		if got, err := ParsePeriod(in); err != nil || got != want {
+			t.Errorf("ParsePeriod(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "0d", "-3d", "xd", "month"} {
+		if _, err := ParsePeriod(in); err == nil {
+			t.Errorf("ParsePeriod(%q) should fail", in)
+		}
+	}
+}
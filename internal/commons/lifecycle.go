@@ -46,7 +46,7 @@ func ValidateTransition(currentStatus string, t Transition) (string, error) {
 		return "", fmt.Errorf("unknown transition %d", t)
 	}
 	if currentStatus != rule.from {
-		return "", fmt.Errorf("cannot %s: item is %s, not %s", rule.name, currentStatus, rule.from)
+		return "", &ConflictError{Message: fmt.Sprintf("cannot %s: item is %s, not %s", rule.name, currentStatus, rule.from)}
 	}
 	return rule.to, nil
 }
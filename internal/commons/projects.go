@@ -0,0 +1,136 @@
+package commons
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Limits on the per-project lists in a ProjectSummary.
+const (
+	projectTopContributors = 3
+	projectRecentItems     = 5
+	// projectRecentScan bounds how many recently updated items are read
+	// to fill every project's recent list.
+	projectRecentScan = 200
+)
+
+// ProjectSummary aggregates the wanted board for one project.
+type ProjectSummary struct {
+	Project         string // "" for items without a project
+	Open            int
+	Claimed         int
+	InReview        int
+	Completed       int
+	TopContributors []Contributor     // most validated completions first
+	Recent          []ProjectActivity // most recently updated first
+	LastActivity    time.Time         // zero if unknown
+}
+
+// Contributor is a rig's validated completion count within a project.
+type Contributor struct {
+	RigHandle   string
+	Completions int
+}
+
+// ProjectActivity is a recently updated item of a project.
+type ProjectActivity struct {
+	ID        string
+	Title     string
+	Status    string
+	UpdatedAt time.Time
+}
+
+// QueryProjectSummaries returns per-project item counts, top contributors
+// and recent activity, sorted by project name with the unset project last.
+// Withdrawn items are left out.
+func QueryProjectSummaries(db DB) ([]ProjectSummary, error) {
+	output, err := db.Query(`SELECT project, status, COUNT(*) AS n, MAX(updated_at) AS last_activity
+FROM wanted
+WHERE status <> 'withdrawn'
+GROUP BY project, status`, "")
+	if err != nil {
+		return nil, fmt.Errorf("counting project items: %w", err)
+	}
+
+	byProject := make(map[string]*ProjectSummary)
+	var projects []*ProjectSummary
+	summary := func(project string) *ProjectSummary {
+		if p, ok := byProject[project]; ok {
+			return p
+		}
+		p := &ProjectSummary{Project: project}
+		byProject[project] = p
+		projects = append(projects, p)
+		return p
+	}
+
+	for _, row := range parseSimpleCSV(output) {
+		n, err := strconv.Atoi(row["n"])
+		if err != nil {
+			return nil, fmt.Errorf("parsing count for project %q: %w", row["project"], err)
+		}
+		p := summary(row["project"])
+		switch row["status"] {
+		case "open":
+			p.Open += n
+		case "claimed":
+			p.Claimed += n
+		case "in_review":
+			p.InReview += n
+		case "completed":
+			p.Completed += n
+		}
+		if t, ok := ParseDoltTime(row["last_activity"]); ok && t.After(p.LastActivity) {
+			p.LastActivity = t
+		}
+	}
+	if len(projects) == 0 {
+		return nil, nil
+	}
+
+	output, err = db.Query(`SELECT w.project, c.completed_by, COUNT(*) AS n
+FROM completions c
+JOIN wanted w ON w.id = c.wanted_id
+WHERE c.stamp_id IS NOT NULL
+GROUP BY w.project, c.completed_by
+ORDER BY n DESC, c.completed_by ASC`, "")
+	if err != nil {
+		return nil, fmt.Errorf("querying project contributors: %w", err)
+	}
+	for _, row := range parseSimpleCSV(output) {
+		p, ok := byProject[row["project"]]
+		if !ok || len(p.TopContributors) >= projectTopContributors {
+			continue
+		}
+		n, _ := strconv.Atoi(row["n"])
+		p.TopContributors = append(p.TopContributors, Contributor{RigHandle: row["completed_by"], Completions: n})
+	}
+
+	output, err = db.Query(fmt.Sprintf(`SELECT id, title, project, status, updated_at
+FROM wanted
+WHERE status <> 'withdrawn'
+ORDER BY updated_at DESC, id
+LIMIT %d`, projectRecentScan), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying recent project activity: %w", err)
+	}
+	for _, row := range parseSimpleCSV(output) {
+		p, ok := byProject[row["project"]]
+		if !ok || len(p.Recent) >= projectRecentItems {
+			continue
+		}
+		updated, _ := ParseDoltTime(row["updated_at"])
+		p.Recent = append(p.Recent, ProjectActivity{ID: row["id"], Title: row["title"], Status: row["status"], UpdatedAt: updated})
+	}
+
+	out := make([]ProjectSummary, len(projects))
+	for i, p := range projects {
+		out[i] = *p
+	}
+	slices.SortFunc(out, func(a, b ProjectSummary) int {
+		return compareGroupKeys(a.Project, b.Project, GroupProject)
+	})
+	return out, nil
+}
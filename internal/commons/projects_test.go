@@ -0,0 +1,55 @@
+package commons
+
+import "testing"
+
+func TestQueryProjectSummaries(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"GROUP BY project, status": "project,status,n,last_activity\n" +
+			"gt,open,3,2026-03-01 10:00:00\n" +
+			"gt,completed,2,2026-03-05 10:00:00\n" +
+			",open,1,2026-02-01 10:00:00\n" +
+			"alpha,in_review,1,2026-03-02 10:00:00\n",
+		"GROUP BY w.project, c.completed_by": "project,completed_by,n\n" +
+			"gt,bob,2\ngt,alice,1\ngt,carol,1\ngt,dave,1\n",
+		"ORDER BY updated_at DESC": "id,title,project,status,updated_at\n" +
+			"w-2,Two,gt,completed,2026-03-05 10:00:00\n" +
+			"w-1,One,gt,open,2026-03-01 10:00:00\n",
+	}}
+
+	got, err := QueryProjectSummaries(db)
+	if err != nil {
+		t.Fatalf("QueryProjectSummaries: %v", err)
+	}
+	if len(got) != 3 || got[0].Project != "alpha" || got[1].Project != "gt" || got[2].Project != "" {
+		t.Fatalf("projects = %+v, want alpha, gt, then unset", got)
+	}
+	gt := got[1]
+	if gt.Open != 3 || gt.Completed != 2 || gt.Claimed != 0 {
+		t.Errorf("gt counts = %+v", gt)
+	}
+	if len(gt.TopContributors) != 3 || gt.TopContributors[0] != (Contributor{RigHandle: "bob", Completions: 2}) {
+		t.Errorf("gt contributors = %+v, want top 3 led by bob", gt.TopContributors)
+	}
+	if len(gt.Recent) != 2 || gt.Recent[0].ID != "w-2" {
+		t.Errorf("gt recent = %+v", gt.Recent)
+	}
+	if gt.LastActivity.Day() != 5 {
+		t.Errorf("gt last activity = %v", gt.LastActivity)
+	}
+	if got[0].InReview != 1 || len(got[0].Recent) != 0 {
+		t.Errorf("alpha = %+v", got[0])
+	}
+}
+
+func TestQueryProjectSummaries_Empty(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{}
+	got, err := QueryProjectSummaries(db)
+	if err != nil || got != nil {
+		t.Errorf("QueryProjectSummaries = %v, %v; want nothing", got, err)
+	}
+	if len(db.queries) != 1 {
+		t.Errorf("expected to stop after the count query, ran %d", len(db.queries))
+	}
+}
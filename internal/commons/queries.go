@@ -2,7 +2,11 @@ package commons
 
 import (
 	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // SortOrder defines browse result ordering.
@@ -41,13 +45,16 @@ type BrowseFilter struct {
 	Type      string
 	Priority  int // -1 means unset
 	Limit     int
+	Offset    int // rows to skip before Limit (paging)
 	PostedBy  string
 	ClaimedBy string
 	Search    string
-	MyItems   string    // rig handle for OR filter (posted_by OR claimed_by); empty = disabled
-	Sort      SortOrder // result ordering
-	View      string    // "all" (default), "mine", or "upstream"
-	Long      bool      // include description and other detail fields
+	Tags      []string      // items must carry every listed tag
+	Exclude   FilterExclude // values that rule an item out
+	MyItems   string        // rig handle for OR filter (posted_by OR claimed_by); empty = disabled
+	Sort      SortOrder     // result ordering
+	View      string        // "all" (default), "mine", or "upstream"
+	Long      bool          // include description and other detail fields
 }
 
 // WantedSummary holds the columns returned by BrowseWanted.
@@ -64,6 +71,25 @@ type WantedSummary struct {
 	EffortLevel string `json:"effort_level"`
 }
 
+// EachWanted streams the wanted board rows matching f to fn, one at a time.
+// Iteration stops at the first error fn returns.
+func EachWanted(db DB, f BrowseFilter, fn func(WantedSummary) error) error {
+	rows, err := QueryRows(db, BuildBrowseQuery(f), "")
+	if err != nil {
+		return fmt.Errorf("querying wanted board: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		if err := fn(wantedSummaryFromRow(rows.Map())); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("querying wanted board: %w", err)
+	}
+	return nil
+}
+
 // BrowseWanted queries the wanted board with the given filters.
 func BrowseWanted(db DB, f BrowseFilter) ([]WantedSummary, error) {
 	query := BuildBrowseQuery(f)
@@ -104,6 +130,24 @@ func BuildBrowseQuery(f BrowseFilter) string {
 	if f.Search != "" {
 		conditions = append(conditions, fmt.Sprintf("title LIKE '%%%s%%'", EscapeLIKE(f.Search)))
 	}
+	for _, tag := range f.Tags {
+		conditions = append(conditions, fmt.Sprintf("JSON_CONTAINS(tags, %s)", sqlJSONString(tag)))
+	}
+	if ex := f.Exclude; !ex.IsZero() {
+		notIn := func(col string, values []string) {
+			if len(values) > 0 {
+				conditions = append(conditions, fmt.Sprintf("COALESCE(%s,'') NOT IN (%s)", col, sqlStringList(values)))
+			}
+		}
+		notIn("status", ex.Statuses)
+		notIn("project", ex.Projects)
+		notIn("type", ex.Types)
+		notIn("posted_by", ex.PostedBy)
+		notIn("claimed_by", ex.ClaimedBy)
+		for _, tag := range ex.Tags {
+			conditions = append(conditions, fmt.Sprintf("(tags IS NULL OR NOT JSON_CONTAINS(tags, %s))", sqlJSONString(tag)))
+		}
+	}
 
 	cols := "id, title, COALESCE(project,'') as project, COALESCE(type,'') as type, priority, COALESCE(posted_by,'') as posted_by, COALESCE(claimed_by,'') as claimed_by, status, COALESCE(effort_level,'medium') as effort_level"
 	if f.Long {
@@ -127,6 +171,9 @@ func BuildBrowseQuery(f BrowseFilter) string {
 		limit = 50
 	}
 	query += fmt.Sprintf(" LIMIT %d", limit)
+	if f.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", f.Offset)
+	}
 
 	return query
 }
@@ -139,8 +186,8 @@ type BranchOverride struct {
 	ClaimedBy string
 }
 
-// DetectBranchOverrides lists wl/<rigHandle>/* branches and queries
-// each item's status via AS OF. Returns overrides for items whose branch
+// DetectBranchOverrides lists wl/<rigHandle>/* branches and reads each
+// item's branch status via AS OF. Returns overrides for items whose branch
 // status differs from their main status.
 func DetectBranchOverrides(db DB, rigHandle string) []BranchOverride {
 	prefix := fmt.Sprintf("wl/%s/", rigHandle)
@@ -149,22 +196,100 @@ func DetectBranchOverrides(db DB, rigHandle string) []BranchOverride {
 		return nil
 	}
 
-	var overrides []BranchOverride
+	refs := make([]branchRef, 0, len(branches))
 	for _, branch := range branches {
-		wantedID := strings.TrimPrefix(branch, prefix)
-		branchStatus, branchClaimedBy := queryItemBranchState(db, wantedID, branch)
-		if branchStatus == "" {
+		refs = append(refs, branchRef{branch: branch, wantedID: strings.TrimPrefix(branch, prefix)})
+	}
+	return detectOverrides(db, refs)
+}
+
+// branchRef pairs a mutation branch with the wanted item it mutates.
+type branchRef struct {
+	branch   string
+	wantedID string
+}
+
+// overrideBatch is the most branches read by one detectOverrides query,
+// keeping the UNION ALL (and the IN list of main statuses) bounded however
+// many wl/* branches a database collects.
+const overrideBatch = 50
+
+// detectOverrides compares each branch's copy of its item with main. The
+// branch states are read overrideBatch branches at a time, in one UNION ALL
+// query with a per-branch AS OF, and the main statuses in IN queries of the
+// same size, so the number of queries grows with the batches rather than
+// the branches. Each batch's query is routed to its first branch so
+// backends that keep branches apart from main (the DoltHub fork) can
+// resolve every AS OF; if it fails, that batch's branches are read one by
+// one. A failing main IN query is logged and its items' main statuses are
+// read one by one the same way, rather than dropping every override.
+func detectOverrides(db DB, refs []branchRef) []BranchOverride {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	type branchState struct{ status, claimedBy string }
+	states := make(map[string]branchState, len(refs))
+	for batch := range slices.Chunk(refs, overrideBatch) {
+		parts := make([]string, 0, len(batch))
+		for _, r := range batch {
+			parts = append(parts, fmt.Sprintf(
+				"(SELECT '%s' AS branch, status, COALESCE(claimed_by,'') AS claimed_by FROM wanted AS OF '%s' WHERE id = '%s')",
+				EscapeSQL(r.branch), EscapeSQL(r.branch), EscapeSQL(r.wantedID)))
+		}
+		if out, err := db.Query(strings.Join(parts, " UNION ALL "), batch[0].branch); err == nil {
+			for _, row := range parseSimpleCSV(out) {
+				states[row["branch"]] = branchState{row["status"], row["claimed_by"]}
+			}
 			continue
 		}
-		mainStatus, _, _ := QueryItemStatus(db, wantedID, "")
-		if branchStatus != mainStatus {
-			overrides = append(overrides, BranchOverride{
-				WantedID:  wantedID,
-				Branch:    branch,
-				Status:    branchStatus,
-				ClaimedBy: branchClaimedBy,
-			})
+		for _, r := range batch {
+			status, claimedBy := queryItemBranchState(db, r.wantedID, r.branch)
+			states[r.branch] = branchState{status, claimedBy}
+		}
+	}
+
+	var ids []string
+	for _, r := range refs {
+		if states[r.branch].status != "" {
+			ids = append(ids, r.wantedID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	mainStatus := make(map[string]string, len(ids))
+	for batch := range slices.Chunk(ids, overrideBatch) {
+		quoted := make([]string, len(batch))
+		for i, id := range batch {
+			quoted[i] = "'" + EscapeSQL(id) + "'"
+		}
+		query := fmt.Sprintf("SELECT id, status FROM wanted WHERE id IN (%s)", strings.Join(quoted, ", "))
+		out, err := db.Query(query, "")
+		if err != nil {
+			slog.Warn("reading main statuses for branch overrides failed, reading them one by one", "items", len(batch), "error", err)
+			for _, id := range batch {
+				mainStatus[id], _ = queryItemBranchState(db, id, "")
+			}
+			continue
 		}
+		for _, row := range parseSimpleCSV(out) {
+			mainStatus[row["id"]] = row["status"]
+		}
+	}
+
+	var overrides []BranchOverride
+	for _, r := range refs {
+		s := states[r.branch]
+		if s.status == "" || s.status == mainStatus[r.wantedID] {
+			continue
+		}
+		overrides = append(overrides, BranchOverride{
+			WantedID:  r.wantedID,
+			Branch:    r.branch,
+			Status:    s.status,
+			ClaimedBy: s.claimedBy,
+		})
 	}
 	return overrides
 }
@@ -196,8 +321,7 @@ func DetectAllBranchOverrides(db DB) ([]BranchOverride, map[string]int) {
 	}
 
 	counts := make(map[string]int)
-	var overrides []BranchOverride
-	seen := make(map[string]bool) // track first override per wanted ID
+	var refs []branchRef
 	for _, branch := range branches {
 		// Branch format: wl/{rigHandle}/{wantedID}
 		rest := strings.TrimPrefix(branch, "wl/")
@@ -210,25 +334,18 @@ func DetectAllBranchOverrides(db DB) ([]BranchOverride, map[string]int) {
 			continue
 		}
 		counts[wantedID]++
+		refs = append(refs, branchRef{branch: branch, wantedID: wantedID})
+	}
 
-		if seen[wantedID] {
-			continue // already have an override for this item
-		}
-
-		branchStatus, branchClaimedBy := queryItemBranchState(db, wantedID, branch)
-		if branchStatus == "" {
+	// Keep only the first override per wanted ID.
+	var overrides []BranchOverride
+	seen := make(map[string]bool)
+	for _, o := range detectOverrides(db, refs) {
+		if seen[o.WantedID] {
 			continue
 		}
-		mainStatus, _, _ := QueryItemStatus(db, wantedID, "")
-		if branchStatus != mainStatus {
-			seen[wantedID] = true
-			overrides = append(overrides, BranchOverride{
-				WantedID:  wantedID,
-				Branch:    branch,
-				Status:    branchStatus,
-				ClaimedBy: branchClaimedBy,
-			})
-		}
+		seen[o.WantedID] = true
+		overrides = append(overrides, o)
 	}
 	return overrides, counts
 }
@@ -253,7 +370,7 @@ func ApplyBranchOverrides(db DB, items []WantedSummary, overrides []BranchOverri
 			if o.ClaimedBy != "" {
 				item.ClaimedBy = o.ClaimedBy
 			}
-			if f.Status != "" && item.Status != f.Status {
+			if !f.MatchesStatus(item.Status) {
 				continue // override made it not match the filter
 			}
 		}
@@ -265,7 +382,7 @@ func ApplyBranchOverrides(db DB, items []WantedSummary, overrides []BranchOverri
 		if applied[o.WantedID] {
 			continue
 		}
-		if f.Status != "" && o.Status != f.Status {
+		if !f.MatchesStatus(o.Status) {
 			continue
 		}
 		// Try main first; fall back to branch only if item not found on main.
@@ -313,6 +430,21 @@ func matchesBrowseFilter(item *WantedItem, f BrowseFilter) bool {
 	if f.Search != "" && !strings.Contains(strings.ToLower(item.Title), strings.ToLower(f.Search)) {
 		return false
 	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(item.Tags, tag) {
+			return false
+		}
+	}
+	ex := f.Exclude
+	if slices.Contains(ex.Projects, item.Project) || slices.Contains(ex.Types, item.Type) ||
+		slices.Contains(ex.PostedBy, item.PostedBy) || slices.Contains(ex.ClaimedBy, item.ClaimedBy) {
+		return false
+	}
+	for _, tag := range ex.Tags {
+		if slices.Contains(item.Tags, tag) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -337,14 +469,97 @@ func FindBranchForItem(db DB, rigHandle, wantedID string) string {
 	return ""
 }
 
+// QueryBranchCommitDates returns the time of the latest commit on each
+// branch whose name starts with prefix. Branches with an unparseable date
+// are omitted.
+func QueryBranchCommitDates(db DB, prefix string) (map[string]time.Time, error) {
+	rows, err := QueryRows(db, fmt.Sprintf(
+		"SELECT name, latest_commit_date FROM dolt_branches WHERE name LIKE '%s%%'", EscapeLIKE(prefix)), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying branch dates: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are checked below
+
+	dates := map[string]time.Time{}
+	for rows.Next() {
+		m := rows.Map()
+		if t, ok := ParseDoltTime(m["latest_commit_date"]); ok {
+			dates[m["name"]] = t
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading branch dates: %w", err)
+	}
+	return dates, nil
+}
+
+// QueryBranchCommits returns the messages of the commits branch has that
+// main does not, oldest first.
+func QueryBranchCommits(db DB, branch string) ([]string, error) {
+	rows, err := QueryRows(db, fmt.Sprintf(
+		"SELECT message FROM dolt_log('main..%s')", EscapeSQL(branch)), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying commits on %s: %w", branch, err)
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are checked below
+
+	var messages []string
+	for rows.Next() {
+		messages = append(messages, rows.Map()["message"])
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading commits on %s: %w", branch, err)
+	}
+	slices.Reverse(messages) // dolt_log lists newest first
+	return messages, nil
+}
+
+// SquashMessage composes one commit message from a branch's commit
+// messages. Messages of the form "wl <verb>: <id>" for a single item are
+// joined by verb ("wl claim + done: w-1"); anything else is joined with
+// "; ". Merge commits from updating the branch with main are left out.
+func SquashMessage(messages []string) string {
+	messages = slices.DeleteFunc(slices.Clone(messages), func(msg string) bool {
+		return strings.HasPrefix(msg, "Merge ")
+	})
+	var verbs []string
+	id := ""
+	for _, msg := range messages {
+		verb, rest, ok := strings.Cut(strings.TrimPrefix(msg, "wl "), ": ")
+		if !ok || !strings.HasPrefix(msg, "wl ") || (id != "" && rest != id) {
+			return strings.Join(messages, "; ")
+		}
+		id = rest
+		if !slices.Contains(verbs, verb) {
+			verbs = append(verbs, verb)
+		}
+	}
+	if id == "" {
+		return ""
+	}
+	return "wl " + strings.Join(verbs, " + ") + ": " + id
+}
+
+// ParseDoltTime parses a DATETIME as returned by the dolt CLI's CSV output
+// or the DoltHub API.
+func ParseDoltTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.DateTime, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // ValidStatuses returns the browse filter status cycle.
 func ValidStatuses() []string {
 	return []string{"open", "claimed", "in_review", "completed", ""}
 }
 
-// ValidTypes returns the browse filter type cycle.
+// ValidTypes returns the default browse filter type cycle. Wastelands
+// with their own item_types use Vocabulary.TypeCycle instead.
 func ValidTypes() []string {
-	return []string{"", "feature", "bug", "design", "rfc", "docs", "inference"}
+	return DefaultVocabulary().TypeCycle()
 }
 
 // StatusLabel returns a human-readable label for a status filter value.
@@ -379,47 +594,132 @@ func PriorityLabel(pri int) string {
 
 // DashboardData holds the sections for the "me" dashboard view.
 type DashboardData struct {
-	Claimed   []WantedSummary // status=claimed, claimed_by=me
-	InReview  []WantedSummary // status=in_review, posted_by=me OR claimed_by=me
-	Completed []WantedSummary // status=completed, claimed_by=me, limit 5
+	Claimed    []WantedSummary // status=claimed, claimed_by=me
+	InReview   []WantedSummary // status=in_review, posted_by=me OR claimed_by=me
+	Completed  []WantedSummary // status=completed, claimed_by=me, limit 5
+	UpNext     []WantedSummary // status=open, me first in its claim queue
+	Reputation *Reputation     // stamps and badges received; nil if unavailable
 }
 
+// dashboardColumns is the wanted projection shared by dashboard sections.
+const dashboardColumns = "id, title, COALESCE(project,'') as project, COALESCE(type,'') as type, priority, COALESCE(posted_by,'') as posted_by, COALESCE(claimed_by,'') as claimed_by, status, COALESCE(effort_level,'medium') as effort_level"
+
+// noReputationColumns blanks the stamp and badge columns in the
+// dashboard's wanted rows, and noWantedColumns blanks the wanted columns
+// in its reputation rows, so every part of the UNION ALL has the same
+// shape. Reputation values are cast to strings so the column types agree.
+const (
+	noReputationColumns = "'' AS stamp_count, '' AS quality, '' AS reliability, '' AS author, '' AS severity, '' AS skill_tags, '' AS message, '' AS created_at, '' AS badge_type"
+	noWantedColumns     = "'' AS id, '' AS title, '' AS project, '' AS type, 0 AS priority, '' AS posted_by, '' AS claimed_by, '' AS status, '' AS effort_level"
+)
+
 // QueryMyDashboard fetches personal dashboard data for the given handle.
+// Every section — the rig's claimed, in-review and completed items, the
+// released claims it is first in the queue for, and its stamps and badges
+// — comes back from one UNION ALL query, each row tagged with its
+// section, so the dashboard costs a single round trip (one HTTP call on
+// RemoteDB). A commons without the optional claim_queue or badges table
+// is asked again without those sections.
 func QueryMyDashboard(db DB, handle string) (*DashboardData, error) {
 	escaped := EscapeSQL(handle)
-	data := &DashboardData{}
-
-	// Claimed items.
-	claimedQ := fmt.Sprintf(
-		"SELECT id, title, COALESCE(project,'') as project, COALESCE(type,'') as type, priority, COALESCE(posted_by,'') as posted_by, COALESCE(claimed_by,'') as claimed_by, status, COALESCE(effort_level,'medium') as effort_level FROM wanted WHERE status = 'claimed' AND claimed_by = '%s' ORDER BY priority ASC, created_at DESC LIMIT 50",
-		escaped)
-	csv, err := db.Query(claimedQ, "")
+	parts := []string{
+		fmt.Sprintf("(SELECT 'claimed' AS section, %s, %s FROM wanted WHERE status = 'claimed' AND claimed_by = '%s' ORDER BY priority ASC, created_at DESC LIMIT 50)", dashboardColumns, noReputationColumns, escaped),
+		fmt.Sprintf("(SELECT 'in_review' AS section, %s, %s FROM wanted WHERE status = 'in_review' AND (posted_by = '%[3]s' OR claimed_by = '%[3]s') ORDER BY priority ASC, created_at DESC LIMIT 50)", dashboardColumns, noReputationColumns, escaped),
+		fmt.Sprintf("(SELECT 'completed' AS section, %s, %s FROM wanted WHERE status = 'completed' AND claimed_by = '%s' ORDER BY updated_at DESC LIMIT 5)", dashboardColumns, noReputationColumns, escaped),
+		fmt.Sprintf("(SELECT 'stamp_totals' AS section, %s, CAST(COUNT(*) AS CHAR), "+
+			"CAST(COALESCE(AVG(JSON_EXTRACT(valence, '$.quality')), 0) AS CHAR), CAST(COALESCE(AVG(JSON_EXTRACT(valence, '$.reliability')), 0) AS CHAR), "+
+			"'', '', '', '', '', '' FROM stamps WHERE subject = '%s')", noWantedColumns, escaped),
+		fmt.Sprintf("(SELECT 'stamp' AS section, %s, '', "+
+			"CAST(COALESCE(JSON_EXTRACT(valence, '$.quality'), 0) AS CHAR), CAST(COALESCE(JSON_EXTRACT(valence, '$.reliability'), 0) AS CHAR), "+
+			"author, COALESCE(severity, ''), COALESCE(CAST(skill_tags AS CHAR), ''), COALESCE(message, ''), COALESCE(CAST(created_at AS CHAR), ''), '' "+
+			"FROM stamps WHERE subject = '%s' ORDER BY created_at DESC LIMIT %d)", noWantedColumns, escaped, recentStampLimit),
+	}
+	optional := []string{
+		fmt.Sprintf("(SELECT 'up_next' AS section, %s, %s FROM claim_queue q JOIN wanted w ON w.id = q.wanted_id "+
+			"WHERE q.rig_handle = '%s' AND w.status = 'open' AND NOT EXISTS ("+
+			"SELECT 1 FROM claim_queue q2 WHERE q2.wanted_id = q.wanted_id AND "+
+			"(q2.queued_at < q.queued_at OR (q2.queued_at = q.queued_at AND q2.rig_handle < q.rig_handle))) "+
+			"ORDER BY w.priority ASC, w.id LIMIT 50)", upNextColumns, noReputationColumns, escaped),
+		fmt.Sprintf("(SELECT 'badge' AS section, %s, '', '', '', '', '', '', '', COALESCE(CAST(awarded_at AS CHAR), ''), badge_type "+
+			"FROM badges WHERE rig_handle = '%s' ORDER BY awarded_at DESC LIMIT 50)", noWantedColumns, escaped),
+	}
+	csv, err := db.Query(strings.Join(append(parts, optional...), " UNION ALL "), "")
+	if err != nil && IsTableNotFound(err) {
+		csv, err = db.Query(strings.Join(parts, " UNION ALL "), "")
+	}
 	if err != nil {
-		return nil, fmt.Errorf("dashboard claimed: %w", err)
+		return nil, fmt.Errorf("dashboard: %w", err)
 	}
-	data.Claimed = parseWantedSummaries(csv)
 
-	// In-review items (posted by me or claimed by me).
-	reviewQ := fmt.Sprintf(
-		"SELECT id, title, COALESCE(project,'') as project, COALESCE(type,'') as type, priority, COALESCE(posted_by,'') as posted_by, COALESCE(claimed_by,'') as claimed_by, status, COALESCE(effort_level,'medium') as effort_level FROM wanted WHERE status = 'in_review' AND (posted_by = '%s' OR claimed_by = '%s') ORDER BY priority ASC, created_at DESC LIMIT 50",
-		escaped, escaped)
-	csv, err = db.Query(reviewQ, "")
-	if err != nil {
-		return nil, fmt.Errorf("dashboard in_review: %w", err)
+	data := &DashboardData{Reputation: &Reputation{}}
+	for _, row := range parseSimpleCSV(csv) {
+		switch row["section"] {
+		case "claimed":
+			data.Claimed = append(data.Claimed, wantedSummaryFromRow(row))
+		case "in_review":
+			data.InReview = append(data.InReview, wantedSummaryFromRow(row))
+		case "completed":
+			data.Completed = append(data.Completed, wantedSummaryFromRow(row))
+		case "up_next":
+			data.UpNext = append(data.UpNext, wantedSummaryFromRow(row))
+		case "stamp_totals":
+			data.Reputation.StampCount, _ = strconv.Atoi(row["stamp_count"])
+			data.Reputation.AvgQuality, _ = strconv.ParseFloat(row["quality"], 64)
+			data.Reputation.AvgReliability, _ = strconv.ParseFloat(row["reliability"], 64)
+		case "stamp":
+			data.Reputation.RecentStamps = append(data.Reputation.RecentStamps, stampDetailFromRow(row))
+		case "badge":
+			data.Reputation.Badges = append(data.Reputation.Badges, BadgeDetail{
+				BadgeType: row["badge_type"],
+				AwardedAt: row["created_at"],
+			})
+		}
 	}
-	data.InReview = parseWantedSummaries(csv)
+	return data, nil
+}
 
-	// Recent completions.
-	completedQ := fmt.Sprintf(
-		"SELECT id, title, COALESCE(project,'') as project, COALESCE(type,'') as type, priority, COALESCE(posted_by,'') as posted_by, COALESCE(claimed_by,'') as claimed_by, status, COALESCE(effort_level,'medium') as effort_level FROM wanted WHERE status = 'completed' AND claimed_by = '%s' ORDER BY updated_at DESC LIMIT 5",
-		escaped)
-	csv, err = db.Query(completedQ, "")
+// QueryFollowed returns the wanted items a rig follows: those in ids, plus
+// the open, claimed and in-review items it posted or claimed.
+func QueryFollowed(db DB, handle string, ids []string) ([]WantedSummary, error) {
+	escaped := EscapeSQL(handle)
+	where := fmt.Sprintf("(posted_by = '%[1]s' OR claimed_by = '%[1]s') AND status IN ('open', 'claimed', 'in_review')", escaped)
+	if len(ids) > 0 {
+		quoted := make([]string, len(ids))
+		for i, id := range ids {
+			quoted[i] = "'" + EscapeSQL(id) + "'"
+		}
+		where = fmt.Sprintf("id IN (%s) OR (%s)", strings.Join(quoted, ", "), where)
+	}
+	csv, err := db.Query(fmt.Sprintf("SELECT %s FROM wanted WHERE %s ORDER BY id", dashboardColumns, where), "")
 	if err != nil {
-		return nil, fmt.Errorf("dashboard completed: %w", err)
+		return nil, fmt.Errorf("followed items: %w", err)
 	}
-	data.Completed = parseWantedSummaries(csv)
+	var items []WantedSummary
+	for _, row := range parseSimpleCSV(csv) {
+		items = append(items, wantedSummaryFromRow(row))
+	}
+	return items, nil
+}
 
-	return data, nil
+// QueryWantedSummaries reads the wanted items with the given IDs from main
+// in one query, keyed by ID. IDs with no row are absent from the map.
+func QueryWantedSummaries(db DB, ids []string) (map[string]WantedSummary, error) {
+	out := make(map[string]WantedSummary, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		quoted[i] = "'" + EscapeSQL(id) + "'"
+	}
+	csv, err := db.Query(fmt.Sprintf("SELECT %s FROM wanted WHERE id IN (%s)", dashboardColumns, strings.Join(quoted, ", ")), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying wanted items: %w", err)
+	}
+	for _, row := range parseSimpleCSV(csv) {
+		out[row["id"]] = wantedSummaryFromRow(row)
+	}
+	return out, nil
 }
 
 // QueryMyDashboardBranchAware wraps QueryMyDashboard with branch overlay in PR mode.
@@ -437,16 +737,72 @@ func QueryMyDashboardBranchAware(db DB, mode, rigHandle string) (*DashboardData,
 		return data, nil
 	}
 
+	// Items that only enter the dashboard via a branch are fetched in one
+	// batch rather than once per section.
+	extra := queryBranchOnlySummaries(db, data, overrides)
+
 	// Apply overrides to each section with its status+person filter.
-	data.Claimed = applyDashboardOverrides(db, data.Claimed, overrides, "claimed", "claimed_by", rigHandle)
-	data.InReview = applyDashboardOverrides(db, data.InReview, overrides, "in_review", "either", rigHandle)
-	data.Completed = applyDashboardOverrides(db, data.Completed, overrides, "completed", "claimed_by", rigHandle)
+	data.Claimed = applyDashboardOverrides(data.Claimed, overrides, extra, "claimed", "claimed_by", rigHandle)
+	data.InReview = applyDashboardOverrides(data.InReview, overrides, extra, "in_review", "either", rigHandle)
+	data.Completed = applyDashboardOverrides(data.Completed, overrides, extra, "completed", "claimed_by", rigHandle)
 
 	return data, nil
 }
 
+// queryBranchOnlySummaries loads the overridden items that aren't already in
+// any dashboard section, keyed by ID. They are read from main in a single
+// query; items that don't exist on main yet fall back to their branch.
+func queryBranchOnlySummaries(db DB, data *DashboardData, overrides []BranchOverride) map[string]WantedSummary {
+	present := make(map[string]bool)
+	for _, section := range [][]WantedSummary{data.Claimed, data.InReview, data.Completed} {
+		for _, item := range section {
+			present[item.ID] = true
+		}
+	}
+	var ids []string
+	for _, o := range overrides {
+		if !present[o.WantedID] {
+			ids = append(ids, o.WantedID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	extra, err := QueryWantedSummaries(db, ids)
+	if err != nil {
+		extra = make(map[string]WantedSummary, len(ids))
+	}
+	for _, o := range overrides {
+		if present[o.WantedID] {
+			continue
+		}
+		if _, ok := extra[o.WantedID]; ok {
+			continue
+		}
+		item, err := QueryWantedDetailAsOf(db, o.WantedID, o.Branch)
+		if err != nil {
+			continue
+		}
+		extra[o.WantedID] = WantedSummary{
+			ID:          item.ID,
+			Title:       item.Title,
+			Description: item.Description,
+			Project:     item.Project,
+			Type:        item.Type,
+			Priority:    item.Priority,
+			PostedBy:    item.PostedBy,
+			ClaimedBy:   item.ClaimedBy,
+			Status:      item.Status,
+			EffortLevel: item.EffortLevel,
+		}
+	}
+	return extra
+}
+
 // applyDashboardOverrides applies branch overrides to a dashboard section.
-func applyDashboardOverrides(db DB, items []WantedSummary, overrides []BranchOverride, statusFilter, personField, personValue string) []WantedSummary {
+// extra holds items not on the dashboard's main query, by ID.
+func applyDashboardOverrides(items []WantedSummary, overrides []BranchOverride, extra map[string]WantedSummary, statusFilter, personField, personValue string) []WantedSummary {
 	if len(overrides) == 0 {
 		return items
 	}
@@ -477,11 +833,8 @@ func applyDashboardOverrides(db DB, items []WantedSummary, overrides []BranchOve
 		if applied[o.WantedID] || o.Status != statusFilter {
 			continue
 		}
-		item, err := QueryWantedDetail(db, o.WantedID)
-		if err != nil {
-			item, err = QueryWantedDetailAsOf(db, o.WantedID, o.Branch)
-		}
-		if err != nil {
+		item, ok := extra[o.WantedID]
+		if !ok {
 			continue
 		}
 		// Check person filter.
@@ -497,18 +850,8 @@ func applyDashboardOverrides(db DB, items []WantedSummary, overrides []BranchOve
 		if !match {
 			continue
 		}
-		result = append(result, WantedSummary{
-			ID:          item.ID,
-			Title:       item.Title,
-			Description: item.Description,
-			Project:     item.Project,
-			Type:        item.Type,
-			Priority:    item.Priority,
-			PostedBy:    item.PostedBy,
-			ClaimedBy:   item.ClaimedBy,
-			Status:      o.Status,
-			EffortLevel: item.EffortLevel,
-		})
+		item.Status = o.Status
+		result = append(result, item)
 	}
 
 	return result
@@ -526,9 +869,60 @@ func BrowseWantedBranchAware(db DB, mode, rigHandle string, f BrowseFilter) ([]W
 	if err != nil {
 		return nil, nil, err
 	}
+	overrides, pendingIDs := browseOverrides(db, mode, rigHandle, f)
+	items = ApplyBranchOverrides(db, items, overrides, f)
+	return items, pendingIDs, nil
+}
+
+// EachWantedBranchAware is the streaming form of BrowseWantedBranchAware:
+// rows are read from the database one at a time and passed to fn with
+// their pending count, so the full result is never held in memory. Items
+// that only match the filter because of a branch come last. Iteration stops
+// at the first error fn returns.
+func EachWantedBranchAware(db DB, mode, rigHandle string, f BrowseFilter, fn func(item WantedSummary, pending int) error) error {
+	overrides, pendingIDs := browseOverrides(db, mode, rigHandle, f)
+	byID := make(map[string]BranchOverride, len(overrides))
+	for _, o := range overrides {
+		byID[o.WantedID] = o
+	}
+
+	err := EachWanted(db, f, func(item WantedSummary) error {
+		if o, ok := byID[item.ID]; ok {
+			delete(byID, item.ID)
+			item.Status = o.Status
+			if o.ClaimedBy != "" {
+				item.ClaimedBy = o.ClaimedBy
+			}
+			if !f.MatchesStatus(item.Status) {
+				return nil // override made it not match the filter
+			}
+		}
+		return fn(item, pendingIDs[item.ID])
+	})
+	if err != nil {
+		return err
+	}
+
+	var rest []BranchOverride
+	for _, o := range overrides {
+		if _, ok := byID[o.WantedID]; ok {
+			rest = append(rest, o)
+		}
+	}
+	for _, item := range ApplyBranchOverrides(db, nil, rest, f) {
+		if err := fn(item, pendingIDs[item.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// browseOverrides returns the branch overrides for a browse in the given
+// mode and view, with pending counts per wanted ID.
+func browseOverrides(db DB, mode, rigHandle string, f BrowseFilter) ([]BranchOverride, map[string]int) {
 	pendingIDs := make(map[string]int)
 	if mode != "pr" || f.View == "upstream" {
-		return items, pendingIDs, nil
+		return nil, pendingIDs
 	}
 
 	view := f.View
@@ -541,16 +935,14 @@ func BrowseWantedBranchAware(db DB, mode, rigHandle string, f BrowseFilter) ([]W
 		for id, c := range counts {
 			pendingIDs[id] = c
 		}
-		items = ApplyBranchOverrides(db, items, overrides, f)
-	} else {
-		// "mine": existing behavior
-		overrides := DetectBranchOverrides(db, rigHandle)
-		for _, o := range overrides {
-			pendingIDs[o.WantedID] = 1
-		}
-		items = ApplyBranchOverrides(db, items, overrides, f)
+		return overrides, pendingIDs
 	}
-	return items, pendingIDs, nil
+	// "mine": existing behavior
+	overrides := DetectBranchOverrides(db, rigHandle)
+	for _, o := range overrides {
+		pendingIDs[o.WantedID] = 1
+	}
+	return overrides, pendingIDs
 }
 
 // QueryFullDetail fetches a wanted item with all related records.
@@ -667,22 +1059,27 @@ func parseWantedSummaries(csvData string) []WantedSummary {
 	rows := parseSimpleCSV(csvData)
 	var results []WantedSummary
 	for _, row := range rows {
-		pri := 2
-		if v, ok := row["priority"]; ok {
-			_, _ = fmt.Sscanf(v, "%d", &pri)
-		}
-		results = append(results, WantedSummary{
-			ID:          row["id"],
-			Title:       row["title"],
-			Description: row["description"],
-			Project:     row["project"],
-			Type:        row["type"],
-			Priority:    pri,
-			PostedBy:    row["posted_by"],
-			ClaimedBy:   row["claimed_by"],
-			Status:      row["status"],
-			EffortLevel: row["effort_level"],
-		})
+		results = append(results, wantedSummaryFromRow(row))
 	}
 	return results
 }
+
+// wantedSummaryFromRow converts a parsed CSV row into a WantedSummary.
+func wantedSummaryFromRow(row map[string]string) WantedSummary {
+	pri := 2
+	if v, ok := row["priority"]; ok {
+		_, _ = fmt.Sscanf(v, "%d", &pri)
+	}
+	return WantedSummary{
+		ID:          row["id"],
+		Title:       row["title"],
+		Description: row["description"],
+		Project:     row["project"],
+		Type:        row["type"],
+		Priority:    pri,
+		PostedBy:    row["posted_by"],
+		ClaimedBy:   row["claimed_by"],
+		Status:      row["status"],
+		EffortLevel: row["effort_level"],
+	}
+}
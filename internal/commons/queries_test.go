@@ -0,0 +1,296 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestQueryMyDashboard_SingleQuery(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"UNION ALL": "section,id,title,project,type,priority,posted_by,claimed_by,status,effort_level," +
+			"stamp_count,quality,reliability,author,severity,skill_tags,message,created_at,badge_type\n" +
+			"claimed,w-1,Fix bug,gastown,bug,1,bob,alice,claimed,small,,,,,,,,,\n" +
+			"in_review,w-2,Docs,,docs,2,alice,carol,in_review,medium,,,,,,,,,\n" +
+			"completed,w-3,Ship it,,feature,0,bob,alice,completed,large,,,,,,,,,\n" +
+			"up_next,w-4,Released,,feature,2,bob,,open,medium,,,,,,,,,\n" +
+			"stamp_totals,,,,,0,,,,,3,4.5,3.75,,,,,,\n" +
+			"stamp,,,,,0,,,,,,5,4,bob,leaf,\"[\"\"go\"\"]\",solid work,2026-03-02,\n" +
+			"badge,,,,,0,,,,,,,,,,,,2026-02-01,first_blood\n",
+	}}
+
+	data, err := QueryMyDashboard(db, "alice")
+	if err != nil {
+		t.Fatalf("QueryMyDashboard: %v", err)
+	}
+	if len(db.queries) != 1 {
+		t.Fatalf("issued %d queries, want 1", len(db.queries))
+	}
+	for _, want := range []string{"claimed_by = 'alice'", "q.rig_handle = 'alice'", "subject = 'alice'", "FROM badges WHERE rig_handle = 'alice'"} {
+		if !strings.Contains(db.queries[0], want) {
+			t.Errorf("query missing %q: %s", want, db.queries[0])
+		}
+	}
+	if len(data.Claimed) != 1 || data.Claimed[0].ID != "w-1" || data.Claimed[0].Priority != 1 {
+		t.Errorf("Claimed = %+v", data.Claimed)
+	}
+	if len(data.InReview) != 1 || data.InReview[0].ID != "w-2" {
+		t.Errorf("InReview = %+v", data.InReview)
+	}
+	if len(data.Completed) != 1 || data.Completed[0].ID != "w-3" || data.Completed[0].EffortLevel != "large" {
+		t.Errorf("Completed = %+v", data.Completed)
+	}
+	if len(data.UpNext) != 1 || data.UpNext[0].ID != "w-4" {
+		t.Errorf("UpNext = %+v", data.UpNext)
+	}
+	rep := data.Reputation
+	if rep.StampCount != 3 || rep.AvgQuality != 4.5 || rep.AvgReliability != 3.75 {
+		t.Errorf("totals = %d/%v/%v, want 3/4.5/3.75", rep.StampCount, rep.AvgQuality, rep.AvgReliability)
+	}
+	if len(rep.RecentStamps) != 1 || rep.RecentStamps[0].Author != "bob" || len(rep.RecentStamps[0].SkillTags) != 1 {
+		t.Errorf("recent stamps = %+v", rep.RecentStamps)
+	}
+	if len(rep.Badges) != 1 || rep.Badges[0].BadgeType != "first_blood" || rep.Badges[0].AwardedAt != "2026-02-01" {
+		t.Errorf("badges = %+v", rep.Badges)
+	}
+}
+
+// missingTableDB fails any query that mentions table, as dolt does for a
+// table the schema lacks.
+type missingTableDB struct {
+	fakeDB
+	table string
+}
+
+func (d *missingTableDB) Query(sql, ref string) (string, error) {
+	if strings.Contains(sql, "FROM "+d.table+" ") {
+		d.queries = append(d.queries, sql)
+		return "", fmt.Errorf("table not found: %s", d.table)
+	}
+	return d.fakeDB.Query(sql, ref)
+}
+
+func TestQueryMyDashboard_WithoutOptionalTables(t *testing.T) {
+	t.Parallel()
+	db := &missingTableDB{table: "claim_queue", fakeDB: fakeDB{results: map[string]string{
+		"UNION ALL": "section,id,title,project,type,priority,posted_by,claimed_by,status,effort_level\n" +
+			"claimed,w-1,Fix bug,,bug,1,bob,alice,claimed,small\n",
+	}}}
+
+	data, err := QueryMyDashboard(db, "alice")
+	if err != nil {
+		t.Fatalf("QueryMyDashboard: %v", err)
+	}
+	if len(db.queries) != 2 {
+		t.Fatalf("issued %d queries, want 2", len(db.queries))
+	}
+	if strings.Contains(db.queries[1], "claim_queue") || strings.Contains(db.queries[1], "FROM badges") {
+		t.Errorf("retry still reads the optional tables: %s", db.queries[1])
+	}
+	if len(data.Claimed) != 1 || data.UpNext != nil || data.Reputation == nil {
+		t.Errorf("data = %+v", data)
+	}
+}
+
+func TestDetectAllBranchOverrides_BatchedQueries(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{
+		branches: []string{"wl/alice/w-1", "wl/bob/w-1", "wl/bob/w-2", "wl/carol/w-3"},
+		results: map[string]string{
+			"AS branch": "branch,status,claimed_by\n" +
+				"wl/alice/w-1,claimed,alice\n" +
+				"wl/bob/w-1,claimed,bob\n" +
+				"wl/bob/w-2,open,\n" +
+				"wl/carol/w-3,open,carol\n",
+			"WHERE id IN": "id,status\nw-1,open\nw-2,open\n",
+		},
+	}
+
+	overrides, counts := DetectAllBranchOverrides(db)
+	if len(db.queries) != 2 {
+		t.Fatalf("issued %d queries, want 2: %q", len(db.queries), db.queries)
+	}
+	for _, b := range db.branches {
+		if !strings.Contains(db.queries[0], "AS OF '"+b+"'") {
+			t.Errorf("branch query missing AS OF %s: %s", b, db.queries[0])
+		}
+	}
+	if counts["w-1"] != 2 || counts["w-2"] != 1 || counts["w-3"] != 1 {
+		t.Errorf("counts = %v", counts)
+	}
+	// w-1 keeps its first branch; w-2 matches main; w-3 is new on its branch.
+	want := []BranchOverride{
+		{WantedID: "w-1", Branch: "wl/alice/w-1", Status: "claimed", ClaimedBy: "alice"},
+		{WantedID: "w-3", Branch: "wl/carol/w-3", Status: "open", ClaimedBy: "carol"},
+	}
+	if len(overrides) != len(want) {
+		t.Fatalf("overrides = %+v, want %+v", overrides, want)
+	}
+	for i := range want {
+		if overrides[i] != want[i] {
+			t.Errorf("overrides[%d] = %+v, want %+v", i, overrides[i], want[i])
+		}
+	}
+}
+
+func TestDetectAllBranchOverrides_BoundedBatches(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"AS branch":   "branch,status,claimed_by\nwl/rig0/w-0,claimed,rig0\n",
+		"WHERE id IN": "id,status\nw-0,open\n",
+	}}
+	for i := range 2*overrideBatch + 1 {
+		db.branches = append(db.branches, fmt.Sprintf("wl/rig%d/w-%d", i, i))
+	}
+
+	overrides, _ := DetectAllBranchOverrides(db)
+	var branchQueries int
+	for _, q := range db.queries {
+		if strings.Contains(q, "AS branch") {
+			branchQueries++
+			if n := strings.Count(q, " UNION ALL ") + 1; n > overrideBatch {
+				t.Errorf("branch query reads %d branches, want at most %d", n, overrideBatch)
+			}
+		}
+	}
+	if branchQueries != 3 {
+		t.Errorf("issued %d branch queries, want 3 for %d branches", branchQueries, len(db.branches))
+	}
+	if len(overrides) != 1 || overrides[0].WantedID != "w-0" {
+		t.Errorf("overrides = %+v", overrides)
+	}
+}
+
+// failingINDB fails the main-status IN queries of detectOverrides.
+type failingINDB struct{ fakeDB }
+
+func (d *failingINDB) Query(sql, ref string) (string, error) {
+	if strings.Contains(sql, "WHERE id IN") {
+		d.queries = append(d.queries, sql)
+		return "", fmt.Errorf("query timed out")
+	}
+	return d.fakeDB.Query(sql, ref)
+}
+
+func TestDetectAllBranchOverrides_MainQueryFails(t *testing.T) {
+	t.Parallel()
+	db := &failingINDB{fakeDB{
+		branches: []string{"wl/alice/w-1", "wl/bob/w-2"},
+		results: map[string]string{
+			"AS branch": "branch,status,claimed_by\n" +
+				"wl/alice/w-1,claimed,alice\n" +
+				"wl/bob/w-2,open,\n",
+			"claimed_by FROM wanted WHERE id = 'w-1'": "status,claimed_by\nopen,\n",
+			"claimed_by FROM wanted WHERE id = 'w-2'": "status,claimed_by\nopen,\n",
+		},
+	}}
+
+	overrides, _ := DetectAllBranchOverrides(db)
+	want := BranchOverride{WantedID: "w-1", Branch: "wl/alice/w-1", Status: "claimed", ClaimedBy: "alice"}
+	if len(overrides) != 1 || overrides[0] != want {
+		t.Errorf("overrides = %+v, want only %+v read against per-item main statuses", overrides, want)
+	}
+}
+
+func TestQueryRows(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"FROM wanted": "id,title\nw-1,\"multi\nline\"\nw-2, padded \n",
+	}}
+
+	rows, err := QueryRows(db, "SELECT id, title FROM wanted", "")
+	if err != nil {
+		t.Fatalf("QueryRows: %v", err)
+	}
+	if got := strings.Join(rows.Columns(), ","); got != "id,title" {
+		t.Errorf("Columns = %s", got)
+	}
+	var got []map[string]string
+	for rows.Next() {
+		got = append(got, rows.Map())
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+	if len(got) != 2 || got[0]["title"] != "multi\nline" || got[1]["title"] != "padded" {
+		t.Errorf("rows = %v", got)
+	}
+
+	empty, err := QueryRows(&fakeDB{}, "SELECT id FROM wanted", "")
+	if err != nil {
+		t.Fatalf("QueryRows(empty): %v", err)
+	}
+	if empty.Next() {
+		t.Error("empty result yielded a row")
+	}
+}
+
+func TestQueryBranchCommits(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"dolt_log": "message\nwl done: w-1\nwl claim: w-1\n",
+	}}
+
+	got, err := QueryBranchCommits(db, "wl/alice/w-1")
+	if err != nil {
+		t.Fatalf("QueryBranchCommits: %v", err)
+	}
+	if strings.Join(got, ",") != "wl claim: w-1,wl done: w-1" {
+		t.Errorf("commits = %q, want oldest first", got)
+	}
+	if !strings.Contains(db.queries[0], "dolt_log('main..wl/alice/w-1')") {
+		t.Errorf("query = %s", db.queries[0])
+	}
+}
+
+func TestSquashMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		messages []string
+		want     string
+	}{
+		{[]string{"wl claim: w-1", "wl done: w-1"}, "wl claim + done: w-1"},
+		{[]string{"wl update: w-1", "wl update: w-1", "wl claim: w-1"}, "wl update + claim: w-1"},
+		{[]string{"wl claim: w-1"}, "wl claim: w-1"},
+		{[]string{"wl claim: w-1", "wl done: w-2"}, "wl claim: w-1; wl done: w-2"},
+		{[]string{"wl claim: w-1", "Merge branch 'main' into wl/alice/w-1", "wl done: w-1"}, "wl claim + done: w-1"},
+		{[]string{"wl claim: w-1", "fix typo"}, "wl claim: w-1; fix typo"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := SquashMessage(tt.messages); got != tt.want {
+			t.Errorf("SquashMessage(%q) = %q, want %q", tt.messages, got, tt.want)
+		}
+	}
+}
+
+func TestQueryFollowed(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"FROM wanted": "id,title,project,type,priority,posted_by,claimed_by,status,effort_level\n" +
+			"w-1,Fix bug,,bug,1,bob,alice,claimed,small\n" +
+			"w-9,Watched,,,2,bob,,open,medium\n",
+	}}
+
+	items, err := QueryFollowed(db, "alice", []string{"w-9", "w-'x"})
+	if err != nil {
+		t.Fatalf("QueryFollowed: %v", err)
+	}
+	q := db.queries[0]
+	for _, want := range []string{"id IN ('w-9', 'w-''x')", "posted_by = 'alice' OR claimed_by = 'alice'", "status IN ('open', 'claimed', 'in_review')"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query missing %q: %s", want, q)
+		}
+	}
+	if len(items) != 2 || items[0].ClaimedBy != "alice" || items[1].ID != "w-9" {
+		t.Errorf("items = %+v", items)
+	}
+
+	if _, err := QueryFollowed(db, "alice", nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(db.queries[1], "id IN") {
+		t.Errorf("query without watched IDs filters on them: %s", db.queries[1])
+	}
+}
@@ -0,0 +1,103 @@
+package commons
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Approval is one reviewer's accept of a completion, recorded in the
+// optional accept_approvals table when the wasteland requires more than
+// one accept (the accept_quorum _meta key).
+type Approval struct {
+	CompletionID string
+	WantedID     string
+	Approver     string
+	StampID      string
+	ApprovedAt   string
+}
+
+// QueryAcceptQuorum reads the number of distinct accepts a completion needs
+// from the accept_quorum _meta key. A missing or invalid value means 1: the
+// first accept completes the item.
+func QueryAcceptQuorum(db DB) (int, error) {
+	output, err := db.Query("SELECT value FROM _meta WHERE `key` = 'accept_quorum'", "")
+	if err != nil {
+		return 0, fmt.Errorf("querying accept_quorum: %w", err)
+	}
+	rows := parseSimpleCSV(output)
+	if len(rows) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(rows[0]["value"]))
+	if err != nil || n < 1 {
+		return 1, nil
+	}
+	return n, nil
+}
+
+// QueryApprovals returns the recorded approvals of a completion at ref,
+// oldest first. A database without the accept_approvals table has none.
+func QueryApprovals(db DB, completionID, ref string) ([]Approval, error) {
+	query := fmt.Sprintf("SELECT completion_id, COALESCE(wanted_id, '') AS wanted_id, approver, "+
+		"COALESCE(stamp_id, '') AS stamp_id, COALESCE(approved_at, '') AS approved_at "+
+		"FROM accept_approvals WHERE completion_id = '%s' ORDER BY approved_at, approver", EscapeSQL(completionID))
+	output, err := db.Query(query, ref)
+	if err != nil {
+		if IsTableNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying approvals: %w", err)
+	}
+	var out []Approval
+	for _, row := range parseSimpleCSV(output) {
+		if row["approver"] == "" {
+			continue
+		}
+		out = append(out, Approval{
+			CompletionID: row["completion_id"],
+			WantedID:     row["wanted_id"],
+			Approver:     row["approver"],
+			StampID:      row["stamp_id"],
+			ApprovedAt:   row["approved_at"],
+		})
+	}
+	return out, nil
+}
+
+// AcceptApprovalDML returns the DML for one reviewer's accept under a
+// quorum: the reviewer's stamp and approval row, and, when final (the
+// approval reaches quorum), the completion validation and the status flip
+// to completed.
+func AcceptApprovalDML(wantedID, completionID, rigHandle, hopURI string, stamp *Stamp, final bool) []string {
+	stmts := []string{
+		insertCompletionStampDML(completionID, rigHandle, hopURI, stamp),
+		fmt.Sprintf(`INSERT INTO accept_approvals (completion_id, approver, wanted_id, stamp_id, approved_at) VALUES ('%s', '%s', '%s', '%s', NOW())`,
+			EscapeSQL(completionID), EscapeSQL(rigHandle), EscapeSQL(wantedID), EscapeSQL(stamp.ID)),
+	}
+	if !final {
+		return stmts
+	}
+	return append(stmts, AcceptCompletionDML(wantedID, completionID, rigHandle, hopURI, stamp)[1:]...)
+}
+
+// ApprovalProgress renders approval progress such as "1/2 approvals", or
+// "" when the quorum is a single accept.
+func ApprovalProgress(approvals []Approval, quorum int) string {
+	if quorum <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d approvals", len(approvals), quorum)
+}
+
+// Approvers lists the rigs behind approvals, in order.
+func Approvers(approvals []Approval) []string {
+	if len(approvals) == 0 {
+		return nil
+	}
+	out := make([]string, len(approvals))
+	for i, a := range approvals {
+		out[i] = a.Approver
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryAcceptQuorum(t *testing.T) {
+	tests := []struct {
+		csv  string
+		want int
+	}{
+		{csv: "value\n", want: 1},
+		{csv: "value\n3\n", want: 3},
+		{csv: "value\n0\n", want: 1},
+		{csv: "value\nlots\n", want: 1},
+	}
+	for _, tc := range tests {
+		db := &fakeDB{results: map[string]string{"accept_quorum": tc.csv}}
+		got, err := QueryAcceptQuorum(db)
+		if err != nil || got != tc.want {
+			t.Errorf("QueryAcceptQuorum(%q) = %d, %v; want %d", tc.csv, got, err, tc.want)
+		}
+	}
+}
+
+func TestQueryApprovals(t *testing.T) {
+	db := &fakeDB{results: map[string]string{"FROM accept_approvals": "completion_id,wanted_id,approver,stamp_id,approved_at\n" +
+		"c-1,w-1,bob,s-1,2026-01-01 00:00:00\n"}}
+	got, err := QueryApprovals(db, "c-1", "")
+	if err != nil {
+		t.Fatalf("QueryApprovals: %v", err)
+	}
+	if len(got) != 1 || got[0].Approver != "bob" || got[0].StampID != "s-1" {
+		t.Errorf("approvals = %+v", got)
+	}
+	if !strings.Contains(db.queries[0], "completion_id = 'c-1'") {
+		t.Errorf("query = %s", db.queries[0])
+	}
+
+	missing := &fakeDB{err: errors.New("table not found: accept_approvals")}
+	if got, err := QueryApprovals(missing, "c-1", ""); err != nil || got != nil {
+		t.Errorf("missing table: %v, %v; want no approvals", got, err)
+	}
+}
+
+func TestAcceptApprovalDML(t *testing.T) {
+	stamp := &Stamp{ID: "s-1", Subject: "bob", Quality: 4, Reliability: 4, Severity: "leaf"}
+
+	partial := AcceptApprovalDML("w-1", "c-1", "alice", "", stamp, false)
+	if len(partial) != 2 {
+		t.Fatalf("non-final stmts = %d, want 2: %v", len(partial), partial)
+	}
+	if !strings.HasPrefix(partial[0], "INSERT INTO stamps") ||
+		!strings.Contains(partial[1], "INSERT INTO accept_approvals") ||
+		!strings.Contains(partial[1], "'c-1', 'alice', 'w-1', 's-1'") {
+		t.Errorf("non-final stmts = %v", partial)
+	}
+
+	final := AcceptApprovalDML("w-1", "c-1", "alice", "", stamp, true)
+	if len(final) != 4 {
+		t.Fatalf("final stmts = %d, want 4: %v", len(final), final)
+	}
+	if !strings.Contains(final[3], "status='completed'") {
+		t.Errorf("final stmts = %v, want completion", final)
+	}
+}
+
+func TestApprovalProgress(t *testing.T) {
+	if got := ApprovalProgress(nil, 1); got != "" {
+		t.Errorf("quorum 1 = %q, want empty", got)
+	}
+	if got := ApprovalProgress([]Approval{{Approver: "bob"}}, 2); got != "1/2 approvals" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,156 @@
+package commons
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a remote API's rate-limit state as of its last response.
+type RateLimit struct {
+	Provider   string    `json:"provider"`             // "dolthub" or "github"
+	Limit      int       `json:"limit,omitempty"`      // requests allowed per window; 0 if unknown
+	Remaining  int       `json:"remaining"`            // requests left in the window
+	Reset      time.Time `json:"reset,omitzero"`       // when the window resets or a 429 may be retried
+	Limited    bool      `json:"limited,omitempty"`    // the last response was refused for rate limiting
+	ObservedAt time.Time `json:"observed_at,omitzero"` // when the state was reported
+}
+
+// Active reports whether the state still applies at now: its window
+// hasn't reset yet.
+func (r RateLimit) Active(now time.Time) bool {
+	return r.Reset.IsZero() || now.Before(r.Reset)
+}
+
+// Low reports whether requests are being refused, or fewer than a tenth
+// of the window's quota is left, at now.
+func (r RateLimit) Low(now time.Time) bool {
+	if !r.Active(now) {
+		return false
+	}
+	return r.Limited || (r.Limit > 0 && r.Remaining*10 < r.Limit)
+}
+
+// Describe summarizes the state at now, e.g.
+// "dolthub: 42/5000 requests left, resets in 12m" or
+// "github: rate limited, retry in 30s".
+func (r RateLimit) Describe(now time.Time) string {
+	var s string
+	switch {
+	case !r.Active(now):
+		return r.Provider + ": quota reset"
+	case r.Limited:
+		s = r.Provider + ": rate limited"
+	case r.Limit > 0:
+		s = fmt.Sprintf("%s: %d/%d requests left", r.Provider, r.Remaining, r.Limit)
+	default:
+		s = fmt.Sprintf("%s: %d requests left", r.Provider, r.Remaining)
+	}
+	if !r.Reset.IsZero() {
+		verb := "resets"
+		if r.Limited {
+			verb = "retry"
+		}
+		s += ", " + verb + " in " + formatWait(r.Reset.Sub(now))
+	}
+	return s
+}
+
+// formatWait renders a wait in seconds under a minute and like
+// formatIdle otherwise.
+func formatWait(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", max(int(d.Round(time.Second).Seconds()), 1))
+	}
+	return formatIdle(d.Round(time.Minute))
+}
+
+// ParseRateLimit reads rate-limit state from an HTTP response's headers
+// and status. It understands the X-RateLimit-* headers (GitHub, most
+// APIs), the IETF RateLimit-* headers and Retry-After. ok is false when the
+// response carries no rate-limit information.
+func ParseRateLimit(provider string, h http.Header, status int, now time.Time) (rl RateLimit, ok bool) {
+	rl = RateLimit{Provider: provider, ObservedAt: now}
+	if v, found := headerInt(h, "X-RateLimit-Remaining", "RateLimit-Remaining"); found {
+		rl.Remaining, ok = v, true
+		rl.Limit, _ = headerInt(h, "X-RateLimit-Limit", "RateLimit-Limit")
+	}
+	if v, found := headerInt(h, "X-RateLimit-Reset", "RateLimit-Reset"); found {
+		// X-RateLimit-Reset is usually epoch seconds; RateLimit-Reset
+		// (and some X- variants) count seconds from now.
+		if v > 1_000_000_000 {
+			rl.Reset = time.Unix(int64(v), 0)
+		} else {
+			rl.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+	}
+	if status == http.StatusTooManyRequests || (status == http.StatusForbidden && ok && rl.Remaining == 0) {
+		rl.Limited, ok = true, true
+	}
+	if retry := h.Get("Retry-After"); retry != "" && rl.Limited {
+		if secs, err := strconv.Atoi(retry); err == nil {
+			rl.Reset = now.Add(time.Duration(secs) * time.Second)
+		} else if t, err := http.ParseTime(retry); err == nil {
+			rl.Reset = t
+		}
+	}
+	return rl, ok
+}
+
+func headerInt(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if v := strings.TrimSpace(h.Get(name)); v != "" {
+			n, err := strconv.Atoi(v)
+			if err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// RateLimitReporter is implemented by backends that track the rate-limit
+// state of the API they call.
+type RateLimitReporter interface {
+	RateLimits() []RateLimit
+}
+
+// RateLimitTracker keeps the latest rate-limit state per provider. The
+// zero value is ready to use and safe for concurrent use.
+type RateLimitTracker struct {
+	mu   sync.Mutex
+	last map[string]RateLimit
+}
+
+// Observe records the rate-limit state carried by a response, if any.
+func (t *RateLimitTracker) Observe(provider string, h http.Header, status int) {
+	if rl, ok := ParseRateLimit(provider, h, status, time.Now()); ok {
+		t.Record(rl)
+	}
+}
+
+// Record stores rl as its provider's latest state.
+func (t *RateLimitTracker) Record(rl RateLimit) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last == nil {
+		t.last = make(map[string]RateLimit)
+	}
+	t.last[rl.Provider] = rl
+}
+
+// RateLimits returns the latest state of each provider, sorted by provider.
+func (t *RateLimitTracker) RateLimits() []RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]RateLimit, 0, len(t.last))
+	for _, rl := range t.last {
+		out = append(out, rl)
+	}
+	slices.SortFunc(out, func(a, b RateLimit) int { return strings.Compare(a.Provider, b.Provider) })
+	return out
+}
@@ -0,0 +1,137 @@
+package commons
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+		want    RateLimit
+		wantOK  bool
+	}{
+		{
+			name:   "no headers",
+			status: http.StatusOK,
+		},
+		{
+			name: "github style epoch reset",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "4321",
+				"X-RateLimit-Reset":     "1772367300", // 12:15 UTC
+			},
+			status: http.StatusOK,
+			want:   RateLimit{Limit: 5000, Remaining: 4321, Reset: now.Add(15 * time.Minute)},
+			wantOK: true,
+		},
+		{
+			name: "ietf style delta reset",
+			headers: map[string]string{
+				"RateLimit-Limit":     "100",
+				"RateLimit-Remaining": "5",
+				"RateLimit-Reset":     "60",
+			},
+			status: http.StatusOK,
+			want:   RateLimit{Limit: 100, Remaining: 5, Reset: now.Add(time.Minute)},
+			wantOK: true,
+		},
+		{
+			name:    "429 with retry-after",
+			headers: map[string]string{"Retry-After": "30"},
+			status:  http.StatusTooManyRequests,
+			want:    RateLimit{Limited: true, Reset: now.Add(30 * time.Second)},
+			wantOK:  true,
+		},
+		{
+			name:    "403 with exhausted quota",
+			headers: map[string]string{"X-RateLimit-Limit": "60", "X-RateLimit-Remaining": "0"},
+			status:  http.StatusForbidden,
+			want:    RateLimit{Limit: 60, Remaining: 0, Limited: true},
+			wantOK:  true,
+		},
+		{
+			name:    "403 for other reasons",
+			headers: map[string]string{"X-RateLimit-Limit": "60", "X-RateLimit-Remaining": "12"},
+			status:  http.StatusForbidden,
+			want:    RateLimit{Limit: 60, Remaining: 12},
+			wantOK:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got, ok := ParseRateLimit("github", h, tt.status, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			tt.want.Provider, tt.want.ObservedAt = "github", now
+			if !got.Reset.Equal(tt.want.Reset) {
+				t.Errorf("Reset = %v, want %v", got.Reset, tt.want.Reset)
+			}
+			got.Reset, tt.want.Reset = time.Time{}, time.Time{}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitDescribeAndLow(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rl      RateLimit
+		want    string
+		wantLow bool
+	}{
+		{RateLimit{Provider: "dolthub", Limit: 5000, Remaining: 4200, Reset: now.Add(12 * time.Minute)}, "dolthub: 4200/5000 requests left, resets in 12m", false},
+		{RateLimit{Provider: "dolthub", Limit: 5000, Remaining: 42}, "dolthub: 42/5000 requests left", true},
+		{RateLimit{Provider: "github", Limited: true, Reset: now.Add(30 * time.Second)}, "github: rate limited, retry in 30s", true},
+		{RateLimit{Provider: "github", Limited: true, Reset: now.Add(-time.Second)}, "github: quota reset", false},
+		{RateLimit{Provider: "github", Remaining: 3}, "github: 3 requests left", false},
+	}
+	for _, tt := range tests {
+		if got := tt.rl.Describe(now); got != tt.want {
+			t.Errorf("Describe() = %q, want %q", got, tt.want)
+		}
+		if got := tt.rl.Low(now); got != tt.wantLow {
+			t.Errorf("%s: Low() = %v, want %v", tt.want, got, tt.wantLow)
+		}
+	}
+}
+
+func TestRateLimitTracker(t *testing.T) {
+	t.Parallel()
+	var tr RateLimitTracker
+	if got := tr.RateLimits(); len(got) != 0 {
+		t.Fatalf("zero tracker reported %v", got)
+	}
+	tr.Observe("github", http.Header{}, http.StatusOK) // no rate-limit info: ignored
+	tr.Record(RateLimit{Provider: "github", Remaining: 10})
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", "7")
+	tr.Observe("dolthub", h, http.StatusOK)
+	tr.Record(RateLimit{Provider: "github", Remaining: 9})
+
+	got := tr.RateLimits()
+	if len(got) != 2 || got[0].Provider != "dolthub" || got[1].Provider != "github" {
+		t.Fatalf("RateLimits() = %+v, want dolthub then github", got)
+	}
+	if got[0].Remaining != 7 || got[1].Remaining != 9 {
+		t.Errorf("RateLimits() = %+v, want latest state per provider", got)
+	}
+}
@@ -0,0 +1,132 @@
+package commons
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadOnlyError is returned for a write attempted while read-only mode is
+// on. Op names the refused operation.
+type ReadOnlyError struct{ Op string }
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("read-only mode: %s refused", e.Op)
+}
+
+// ReadOnly wraps db so every operation that would change the database or
+// a remote fails with *ReadOnlyError. Queries must be plain reads; Sync,
+// which only brings the local view up to date with upstream, still works.
+func ReadOnly(db DB) DB {
+	switch db.(type) {
+	case *readOnlyDB, *readOnlyStreamDB:
+		return db
+	}
+	ro := &readOnlyDB{inner: db}
+	if s, ok := db.(RowStreamer); ok {
+		return &readOnlyStreamDB{readOnlyDB: ro, stream: s}
+	}
+	return ro
+}
+
+// IsReadOnly reports whether db was wrapped by ReadOnly.
+func IsReadOnly(db DB) bool {
+	switch db.(type) {
+	case *readOnlyDB, *readOnlyStreamDB:
+		return true
+	}
+	return false
+}
+
+type readOnlyDB struct{ inner DB }
+
+// Unwrap returns the wrapped DB, for callers that need a backend's own
+// read methods (e.g. RemoteDB.Diff).
+func (d *readOnlyDB) Unwrap() DB { return d.inner }
+
+func (d *readOnlyDB) Query(sql, ref string) (string, error) {
+	if !isReadStatement(sql) {
+		return "", &ReadOnlyError{Op: "write query"}
+	}
+	return d.inner.Query(sql, ref)
+}
+
+func (d *readOnlyDB) Exec(string, string, Signing, ...string) error {
+	return &ReadOnlyError{Op: "commit"}
+}
+
+func (d *readOnlyDB) Branches(prefix string) ([]string, error) { return d.inner.Branches(prefix) }
+
+func (d *readOnlyDB) DeleteBranch(string) error { return &ReadOnlyError{Op: "delete branch"} }
+
+func (d *readOnlyDB) PushBranch(string, io.Writer) error { return &ReadOnlyError{Op: "push"} }
+
+func (d *readOnlyDB) PushMain(io.Writer) error { return &ReadOnlyError{Op: "push"} }
+
+func (d *readOnlyDB) Sync() error { return d.inner.Sync() }
+
+func (d *readOnlyDB) MergeBranch(string) error { return &ReadOnlyError{Op: "merge"} }
+
+func (d *readOnlyDB) DeleteRemoteBranch(string) error { return &ReadOnlyError{Op: "delete branch"} }
+
+func (d *readOnlyDB) PushWithSync(io.Writer) error { return &ReadOnlyError{Op: "push"} }
+
+func (d *readOnlyDB) CanWildWest() error { return d.inner.CanWildWest() }
+
+// readOnlyStreamDB is a readOnlyDB over a backend that can stream.
+type readOnlyStreamDB struct {
+	*readOnlyDB
+	stream RowStreamer
+}
+
+func (d *readOnlyStreamDB) QueryStream(sql, ref string) (io.ReadCloser, error) {
+	if !isReadStatement(sql) {
+		return nil, &ReadOnlyError{Op: "write query"}
+	}
+	return d.stream.QueryStream(sql, ref)
+}
+
+// isReadStatement reports whether sql is a single statement that starts
+// with a read-only keyword. Multi-statement strings are refused, so a
+// write can't ride along behind a SELECT.
+func isReadStatement(sql string) bool {
+	s := strings.TrimSpace(sql)
+	s = strings.TrimRight(s, "; \t\r\n")
+	if !semicolonsQuoted(s) {
+		return false
+	}
+	s = strings.TrimLeft(s, "( \t\r\n")
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	switch strings.ToUpper(s[:end]) {
+	case "SELECT", "WITH", "SHOW", "DESCRIBE", "DESC", "EXPLAIN":
+		return true
+	}
+	return false
+}
+
+// semicolonsQuoted reports whether every ';' in s is inside a quoted
+// string literal or identifier.
+func semicolonsQuoted(s string) bool {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == ';':
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package commons
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReadOnly_RefusesWrites(t *testing.T) {
+	t.Parallel()
+	db := ReadOnly(&fakeDB{})
+
+	ops := map[string]func() error{
+		"commit":        func() error { return db.Exec("", "msg", Signing{}, "DELETE FROM wanted") },
+		"delete branch": func() error { return db.DeleteBranch("wl/alice/w-1") },
+		"remote delete": func() error { return db.DeleteRemoteBranch("wl/alice/w-1") },
+		"push branch":   func() error { return db.PushBranch("wl/alice/w-1", io.Discard) },
+		"push main":     func() error { return db.PushMain(io.Discard) },
+		"push sync":     func() error { return db.PushWithSync(io.Discard) },
+		"merge":         func() error { return db.MergeBranch("wl/alice/w-1") },
+		"write query": func() error {
+			_, err := db.Query("UPDATE wanted SET status='done'", "")
+			return err
+		},
+	}
+	for name, op := range ops {
+		var roErr *ReadOnlyError
+		if err := op(); !errors.As(err, &roErr) {
+			t.Errorf("%s: error = %v, want *ReadOnlyError", name, err)
+		}
+	}
+}
+
+func TestReadOnly_AllowsReads(t *testing.T) {
+	t.Parallel()
+	inner := &fakeDB{
+		results:  map[string]string{"FROM wanted": "id\nw-1\n"},
+		branches: []string{"wl/alice/w-1"},
+	}
+	db := ReadOnly(inner)
+
+	out, err := db.Query("SELECT id FROM wanted", "")
+	if err != nil || out != "id\nw-1\n" {
+		t.Errorf("Query() = %q, %v", out, err)
+	}
+	if len(inner.queries) != 1 {
+		t.Errorf("inner queries = %d, want 1", len(inner.queries))
+	}
+	if got, err := db.Branches("wl/"); err != nil || len(got) != 1 {
+		t.Errorf("Branches() = %v, %v", got, err)
+	}
+	if err := db.Sync(); err != nil {
+		t.Errorf("Sync() = %v", err)
+	}
+}
+
+func TestReadOnly_Idempotent(t *testing.T) {
+	t.Parallel()
+	inner := &fakeDB{}
+	db := ReadOnly(inner)
+	if ReadOnly(db) != db {
+		t.Error("ReadOnly(ReadOnly(db)) should return the same wrapper")
+	}
+	if !IsReadOnly(db) || IsReadOnly(inner) {
+		t.Error("IsReadOnly should report only wrapped DBs")
+	}
+	if u, ok := db.(interface{ Unwrap() DB }); !ok || u.Unwrap() != inner {
+		t.Error("Unwrap() should return the wrapped DB")
+	}
+}
+
+func TestIsReadStatement(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		sql  string
+		want bool
+	}{
+		{"SELECT * FROM wanted", true},
+		{"  select id from wanted;", true},
+		{"(SELECT 1) UNION (SELECT 2)", true},
+		{"WITH x AS (SELECT 1) SELECT * FROM x", true},
+		{"SHOW TABLES", true},
+		{"DESCRIBE wanted", true},
+		{"EXPLAIN SELECT 1", true},
+		{"SELECT * FROM wanted WHERE title = 'a;b'", true},
+		{`SELECT * FROM wanted WHERE title = 'it\'s; fine'`, true},
+		{"INSERT INTO wanted VALUES ('w-1')", false},
+		{"UPDATE wanted SET status = 'open'", false},
+		{"CALL DOLT_COMMIT('-m', 'x')", false},
+		{"SELECT 1; DELETE FROM wanted", false},
+		{"SELECTED", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isReadStatement(tt.sql); got != tt.want {
+			t.Errorf("isReadStatement(%q) = %v, want %v", tt.sql, got, tt.want)
+		}
+	}
+}
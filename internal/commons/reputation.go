@@ -0,0 +1,93 @@
+package commons
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// recentStampLimit caps the stamps a reputation summary lists.
+const recentStampLimit = 5
+
+// Reputation summarizes the standing a rig has earned: the stamps it has
+// received and the badges it holds.
+type Reputation struct {
+	StampCount     int
+	AvgQuality     float64
+	AvgReliability float64
+	RecentStamps   []StampDetail // newest first, at most 5
+	Badges         []BadgeDetail // newest first
+}
+
+// QueryReputation returns the stamp totals, recent stamps and badges for
+// handle. A rig nobody has stamped gets a zero Reputation, not an error.
+func QueryReputation(db DB, handle string) (*Reputation, error) {
+	escaped := EscapeSQL(handle)
+	rep := &Reputation{}
+
+	out, err := db.Query(fmt.Sprintf(`SELECT COUNT(*) AS stamp_count,
+  COALESCE(AVG(JSON_EXTRACT(valence, '$.quality')), 0) AS avg_quality,
+  COALESCE(AVG(JSON_EXTRACT(valence, '$.reliability')), 0) AS avg_reliability
+FROM stamps
+WHERE subject = '%s'`, escaped), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying stamp totals: %w", err)
+	}
+	if rows := parseSimpleCSV(out); len(rows) > 0 {
+		rep.StampCount, _ = strconv.Atoi(rows[0]["stamp_count"])
+		rep.AvgQuality, _ = strconv.ParseFloat(rows[0]["avg_quality"], 64)
+		rep.AvgReliability, _ = strconv.ParseFloat(rows[0]["avg_reliability"], 64)
+	}
+
+	if rep.StampCount > 0 {
+		out, err = db.Query(fmt.Sprintf(`SELECT author, severity,
+  COALESCE(JSON_EXTRACT(valence, '$.quality'), 0) AS quality,
+  COALESCE(JSON_EXTRACT(valence, '$.reliability'), 0) AS reliability,
+  COALESCE(skill_tags, '') AS skill_tags,
+  COALESCE(message, '') AS message,
+  COALESCE(created_at, '') AS created_at
+FROM stamps
+WHERE subject = '%s'
+ORDER BY created_at DESC
+LIMIT %d`, escaped, recentStampLimit), "")
+		if err != nil {
+			return nil, fmt.Errorf("querying recent stamps: %w", err)
+		}
+		for _, row := range parseSimpleCSV(out) {
+			rep.RecentStamps = append(rep.RecentStamps, stampDetailFromRow(row))
+		}
+	}
+
+	out, err = db.Query(fmt.Sprintf(`SELECT badge_type, COALESCE(awarded_at, '') AS awarded_at
+FROM badges
+WHERE rig_handle = '%s'
+ORDER BY awarded_at DESC`, escaped), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying badges: %w", err)
+	}
+	for _, row := range parseSimpleCSV(out) {
+		rep.Badges = append(rep.Badges, BadgeDetail{
+			BadgeType: row["badge_type"],
+			AwardedAt: row["awarded_at"],
+		})
+	}
+	return rep, nil
+}
+
+// stampDetailFromRow converts a recent-stamps row (author, severity,
+// quality, reliability, skill_tags, message, created_at) to a StampDetail.
+func stampDetailFromRow(row map[string]string) StampDetail {
+	q, _ := strconv.ParseFloat(row["quality"], 64)
+	r, _ := strconv.ParseFloat(row["reliability"], 64)
+	sd := StampDetail{
+		Author:      row["author"],
+		Severity:    row["severity"],
+		Quality:     q,
+		Reliability: r,
+		Message:     row["message"],
+		CreatedAt:   row["created_at"],
+	}
+	if tags := parseTagsJSON(row["skill_tags"]); len(tags) > 0 {
+		sd.SkillTags = tags
+	}
+	return sd
+}
@@ -0,0 +1,55 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryReputation(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"AVG(JSON_EXTRACT": "stamp_count,avg_quality,avg_reliability\n3,4.5,3.75\n",
+		"ORDER BY created_at DESC": "author,severity,quality,reliability,skill_tags,message,created_at\n" +
+			"bob,leaf,5,4,\"[\"\"go\"\"]\",solid work,2026-03-02\n" +
+			"carol,branch,4,3.5,,,2026-03-01\n",
+		"FROM badges": "badge_type,awarded_at\nfirst_blood,2026-02-01\n",
+	}}
+
+	rep, err := QueryReputation(db, "alice")
+	if err != nil {
+		t.Fatalf("QueryReputation: %v", err)
+	}
+	if rep.StampCount != 3 || rep.AvgQuality != 4.5 || rep.AvgReliability != 3.75 {
+		t.Errorf("totals = %d/%v/%v, want 3/4.5/3.75", rep.StampCount, rep.AvgQuality, rep.AvgReliability)
+	}
+	if len(rep.RecentStamps) != 2 {
+		t.Fatalf("recent stamps = %d, want 2", len(rep.RecentStamps))
+	}
+	if s := rep.RecentStamps[0]; s.Author != "bob" || s.Quality != 5 || len(s.SkillTags) != 1 || s.SkillTags[0] != "go" {
+		t.Errorf("first stamp = %+v", s)
+	}
+	if len(rep.Badges) != 1 || rep.Badges[0].BadgeType != "first_blood" {
+		t.Errorf("badges = %+v", rep.Badges)
+	}
+	for _, q := range db.queries {
+		if !strings.Contains(q, "'alice'") {
+			t.Errorf("query not scoped to the rig: %s", q)
+		}
+	}
+}
+
+func TestQueryReputation_NoStamps(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"AVG(JSON_EXTRACT": "stamp_count,avg_quality,avg_reliability\n0,0,0\n",
+	}}
+
+	rep, err := QueryReputation(db, "alice")
+	if err != nil {
+		t.Fatalf("QueryReputation: %v", err)
+	}
+	if rep.StampCount != 0 || len(rep.RecentStamps) != 0 || len(rep.Badges) != 0 {
+		t.Errorf("rep = %+v, want zero", rep)
+	}
+	if len(db.queries) != 2 {
+		t.Errorf("queries = %d, want 2 (recent stamps skipped)", len(db.queries))
+	}
+}
@@ -0,0 +1,133 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReviewComment is a review note on a branch, stored in the optional
+// review_comments table so it travels with the database. It is used by
+// wastelands without a PR provider, where there is no PR to comment on.
+// A comment with a Table is line-level: it points at a row (and optionally
+// a column) of the branch's diff. Otherwise it is about the item as a whole.
+type ReviewComment struct {
+	ID        string
+	Branch    string
+	WantedID  string
+	Table     string
+	RowKey    string
+	Column    string
+	Author    string
+	Body      string
+	CreatedAt string
+}
+
+// Target returns the line-level target as "table/row[/column]", or "" for
+// an item-level comment.
+func (c *ReviewComment) Target() string {
+	if c.Table == "" {
+		return ""
+	}
+	t := c.Table + "/" + c.RowKey
+	if c.Column != "" {
+		t += "/" + c.Column
+	}
+	return t
+}
+
+// ParseReviewTarget parses a "table/row[/column]" target such as
+// "wanted/w-abc123/description".
+func ParseReviewTarget(s string) (table, rowKey, column string, err error) {
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid target %q: expected table/row or table/row/column", s)
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return "", "", "", fmt.Errorf("invalid target %q: empty column", s)
+		}
+		column = parts[2]
+	}
+	return parts[0], parts[1], column, nil
+}
+
+// InsertReviewCommentDML returns the pure DML for storing a review comment.
+func InsertReviewCommentDML(c *ReviewComment) (string, error) {
+	switch {
+	case c.ID == "":
+		return "", fmt.Errorf("review comment ID cannot be empty")
+	case c.Branch == "":
+		return "", fmt.Errorf("review comment branch cannot be empty")
+	case c.Author == "":
+		return "", fmt.Errorf("review comment author cannot be empty")
+	case strings.TrimSpace(c.Body) == "":
+		return "", fmt.Errorf("review comment cannot be empty")
+	}
+
+	createdAt := c.CreatedAt
+	if createdAt == "" {
+		createdAt = time.Now().UTC().Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprintf(`INSERT INTO review_comments (id, branch, wanted_id, table_name, row_key, column_name, author, body, created_at)
+VALUES ('%s', '%s', %s, %s, %s, %s, '%s', '%s', '%s')`,
+		EscapeSQL(c.ID), EscapeSQL(c.Branch), nullableString(c.WantedID), nullableString(c.Table),
+		nullableString(c.RowKey), nullableString(c.Column), EscapeSQL(c.Author), EscapeSQL(c.Body),
+		EscapeSQL(createdAt)), nil
+}
+
+// QueryReviewComments returns the review comments on branch, oldest first.
+// A database created before the review_comments table existed has none.
+func QueryReviewComments(db DB, branch string) ([]ReviewComment, error) {
+	return queryReviewComments(db, fmt.Sprintf("branch = '%s'", EscapeSQL(branch)))
+}
+
+// QueryItemReviewComments returns the review comments about wantedID on
+// any branch, oldest first.
+func QueryItemReviewComments(db DB, wantedID string) ([]ReviewComment, error) {
+	return queryReviewComments(db, fmt.Sprintf("wanted_id = '%s'", EscapeSQL(wantedID)))
+}
+
+func queryReviewComments(db DB, where string) ([]ReviewComment, error) {
+	query := "SELECT id, branch, COALESCE(wanted_id, '') AS wanted_id, COALESCE(table_name, '') AS table_name, " +
+		"COALESCE(row_key, '') AS row_key, COALESCE(column_name, '') AS column_name, author, body, " +
+		"COALESCE(created_at, '') AS created_at FROM review_comments WHERE " + where + " ORDER BY created_at, id"
+	rows, err := QueryRows(db, query, "")
+	if err != nil {
+		if IsTableNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("querying review comments: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are checked below
+
+	var out []ReviewComment
+	for rows.Next() {
+		m := rows.Map()
+		if m["id"] == "" {
+			continue
+		}
+		out = append(out, ReviewComment{
+			ID:        m["id"],
+			Branch:    m["branch"],
+			WantedID:  m["wanted_id"],
+			Table:     m["table_name"],
+			RowKey:    m["row_key"],
+			Column:    m["column_name"],
+			Author:    m["author"],
+			Body:      m["body"],
+			CreatedAt: m["created_at"],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading review comments: %w", err)
+	}
+	return out, nil
+}
+
+func nullableString(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return "'" + EscapeSQL(s) + "'"
+}
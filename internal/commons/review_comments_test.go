@@ -0,0 +1,82 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseReviewTarget(t *testing.T) {
+	tests := []struct {
+		in                 string
+		table, row, column string
+		wantErr            bool
+	}{
+		{in: "wanted/w-1", table: "wanted", row: "w-1"},
+		{in: "wanted/w-1/description", table: "wanted", row: "w-1", column: "description"},
+		{in: "wanted", wantErr: true},
+		{in: "wanted//title", wantErr: true},
+		{in: "wanted/w-1/", wantErr: true},
+		{in: "a/b/c/d", wantErr: true},
+	}
+	for _, tc := range tests {
+		table, row, column, err := ParseReviewTarget(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ParseReviewTarget(%q) succeeded, want error", tc.in)
+			}
+			continue
+		}
+		if err != nil || table != tc.table || row != tc.row || column != tc.column {
+			t.Errorf("ParseReviewTarget(%q) = %q, %q, %q, %v", tc.in, table, row, column, err)
+		}
+	}
+}
+
+func TestInsertReviewCommentDML(t *testing.T) {
+	dml, err := InsertReviewCommentDML(&ReviewComment{
+		ID: "rc-1", Branch: "wl/alice/w-1", WantedID: "w-1", Author: "bob",
+		Body: "don't merge yet", CreatedAt: "2026-01-02 03:04:05",
+	})
+	if err != nil {
+		t.Fatalf("InsertReviewCommentDML: %v", err)
+	}
+	for _, want := range []string{"'rc-1'", "'wl/alice/w-1'", "'w-1', NULL, NULL, NULL", "'don''t merge yet'", "'2026-01-02 03:04:05'"} {
+		if !strings.Contains(dml, want) {
+			t.Errorf("DML missing %s:\n%s", want, dml)
+		}
+	}
+
+	if _, err := InsertReviewCommentDML(&ReviewComment{ID: "rc-1", Branch: "b", Author: "bob", Body: "  "}); err == nil {
+		t.Error("empty body should be rejected")
+	}
+}
+
+func TestQueryReviewComments(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM review_comments": "id,branch,wanted_id,table_name,row_key,column_name,author,body,created_at\n" +
+			"rc-1,wl/alice/w-1,w-1,,,,bob,Looks good,2026-01-02 03:04:05\n" +
+			"rc-2,wl/alice/w-1,w-1,wanted,w-1,title,bob,\"Typo in\ntitle\",2026-01-02 03:05:00\n",
+	}}
+	comments, err := QueryReviewComments(db, "wl/alice/w-1")
+	if err != nil {
+		t.Fatalf("QueryReviewComments: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("comments = %+v, want 2", comments)
+	}
+	if comments[0].Target() != "" || comments[1].Target() != "wanted/w-1/title" || comments[1].Body != "Typo in\ntitle" {
+		t.Errorf("comments = %+v", comments)
+	}
+	if !strings.Contains(db.queries[0], "branch = 'wl/alice/w-1'") {
+		t.Errorf("query = %s", db.queries[0])
+	}
+}
+
+func TestQueryReviewComments_NoTable(t *testing.T) {
+	db := &fakeDB{err: errors.New("table not found: review_comments")}
+	comments, err := QueryItemReviewComments(db, "w-1")
+	if err != nil || comments != nil {
+		t.Errorf("QueryItemReviewComments = %v, %v; want nil, nil", comments, err)
+	}
+}
@@ -0,0 +1,123 @@
+package commons
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// RowStreamer is implemented by backends that can stream a query's CSV
+// output instead of buffering it. QueryRows uses it when available.
+type RowStreamer interface {
+	// QueryStream runs a read-only SQL SELECT and returns its output in the
+	// same CSV format as Query. Closing the reader releases the query.
+	QueryStream(sql, ref string) (io.ReadCloser, error)
+}
+
+// Rows iterates over a query result one row at a time:
+//
+//	rows, err := QueryRows(db, sql, "")
+//	if err != nil { ... }
+//	defer rows.Close()
+//	for rows.Next() {
+//		use(rows.Map())
+//	}
+//	if err := rows.Err(); err != nil { ... }
+type Rows struct {
+	src     io.ReadCloser
+	r       *csv.Reader
+	columns []string
+	values  []string
+	err     error
+	closed  bool
+}
+
+// QueryRows runs sql at ref and returns an iterator over its rows. Backends
+// implementing RowStreamer stream the result; others are read with Query
+// and iterated from memory.
+func QueryRows(db DB, sql, ref string) (*Rows, error) {
+	var src io.ReadCloser
+	if s, ok := db.(RowStreamer); ok {
+		rc, err := s.QueryStream(sql, ref)
+		if err != nil {
+			return nil, err
+		}
+		src = rc
+	} else {
+		out, err := db.Query(sql, ref)
+		if err != nil {
+			return nil, err
+		}
+		src = io.NopCloser(strings.NewReader(out))
+	}
+
+	rows := &Rows{src: src, r: csv.NewReader(src)}
+	rows.r.FieldsPerRecord = -1
+	rows.r.ReuseRecord = true
+	header, err := rows.r.Read()
+	switch {
+	case errors.Is(err, io.EOF):
+		// No output at all: an empty result without a header.
+		return rows, rows.Close()
+	case err != nil:
+		_ = rows.Close()
+		return nil, err
+	}
+	rows.columns = make([]string, len(header))
+	for i, h := range header {
+		rows.columns[i] = strings.TrimSpace(h)
+	}
+	return rows, nil
+}
+
+// Columns returns the result's column names.
+func (r *Rows) Columns() []string { return r.columns }
+
+// Next advances to the next row, returning false at the end of the result
+// or on error. The source is closed once the last row has been read.
+func (r *Rows) Next() bool {
+	if r.closed {
+		return false
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.err = err
+		}
+		if cerr := r.Close(); r.err == nil {
+			r.err = cerr
+		}
+		return false
+	}
+	r.values = record
+	return true
+}
+
+// Values returns the current row's fields in column order. The slice is
+// reused by the next call to Next.
+func (r *Rows) Values() []string { return r.values }
+
+// Map returns the current row keyed by column name, with the same
+// whitespace trimming as the buffered query helpers.
+func (r *Rows) Map() map[string]string {
+	row := make(map[string]string, len(r.columns))
+	for i, c := range r.columns {
+		if i < len(r.values) {
+			row[c] = strings.TrimSpace(r.values[i])
+		}
+	}
+	return row
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (r *Rows) Err() error { return r.err }
+
+// Close releases the underlying query. It is safe to call more than once.
+func (r *Rows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.src.Close()
+}
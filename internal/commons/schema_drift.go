@@ -0,0 +1,150 @@
+package commons
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/schema"
+)
+
+// SchemaMetaQuery reads the schema version and template recorded in _meta.
+const SchemaMetaQuery = "SELECT `key`, value FROM _meta WHERE `key` IN ('schema_version', 'schema_template')"
+
+// SchemaColumnsQuery lists every column of every table in the database.
+const SchemaColumnsQuery = "SELECT table_name AS table_name, column_name AS column_name FROM information_schema.columns WHERE table_schema = DATABASE()"
+
+// SchemaDrift describes how a database's schema differs from the one this
+// release of wl expects for the database's schema template.
+type SchemaDrift struct {
+	Template       string              // template recorded in _meta (full if unset)
+	Version        string              // schema_version recorded in _meta, "" if unset
+	Expected       string              // schema.Version
+	MissingTables  []string            // tables the template defines that don't exist
+	MissingColumns map[string][]string // table -> columns the template defines that don't exist
+}
+
+// VersionDiff compares the recorded schema version against the expected
+// one: -1 if the database is older, 1 if newer, 0 if they match or the
+// version can't be compared.
+func (d *SchemaDrift) VersionDiff() int {
+	if d.Version == "" || d.Template == schema.TemplateCustom {
+		return 0
+	}
+	return schema.CompareVersions(d.Version, d.Expected)
+}
+
+// Empty reports whether the database matches the expected schema.
+func (d *SchemaDrift) Empty() bool {
+	return d.VersionDiff() == 0 && len(d.MissingTables) == 0 && len(d.MissingColumns) == 0
+}
+
+// String summarizes the drift, e.g.
+// "schema version 1.1, wl expects 1.2; missing columns: wanted.effort_level".
+func (d *SchemaDrift) String() string {
+	var parts []string
+	switch d.VersionDiff() {
+	case -1:
+		parts = append(parts, fmt.Sprintf("schema version %s, wl expects %s", d.Version, d.Expected))
+	case 1:
+		parts = append(parts, fmt.Sprintf("schema version %s is newer than %s (upgrade wl)", d.Version, d.Expected))
+	}
+	if len(d.MissingTables) > 0 {
+		parts = append(parts, "missing tables: "+strings.Join(d.MissingTables, ", "))
+	}
+	if len(d.MissingColumns) > 0 {
+		var cols []string
+		for _, table := range slices.Sorted(maps.Keys(d.MissingColumns)) {
+			for _, col := range d.MissingColumns[table] {
+				cols = append(cols, table+"."+col)
+			}
+		}
+		parts = append(parts, "missing columns: "+strings.Join(cols, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// AddColumnSQL returns the ALTER TABLE statements that add the missing
+// columns, using the template's column definitions.
+func (d *SchemaDrift) AddColumnSQL() []string {
+	ddl, err := schema.Template(d.Template)
+	if err != nil {
+		return nil
+	}
+	defs := schema.Columns(ddl)
+	var stmts []string
+	for _, table := range slices.Sorted(maps.Keys(d.MissingColumns)) {
+		for _, col := range defs[table] {
+			if slices.Contains(d.MissingColumns[table], col.Name) {
+				stmts = append(stmts, fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s", table, col.Def))
+			}
+		}
+	}
+	return stmts
+}
+
+// ParseSchemaDrift compares the output of SchemaMetaQuery and
+// SchemaColumnsQuery against the expected schema. It returns nil when the
+// column listing is empty (information_schema unavailable), since every
+// table would otherwise look missing. Custom templates are never drifted.
+func ParseSchemaDrift(metaCSV, columnsCSV string) *SchemaDrift {
+	have := map[string]map[string]bool{}
+	for _, row := range parseSimpleCSV(columnsCSV) {
+		table := row["table_name"]
+		if have[table] == nil {
+			have[table] = map[string]bool{}
+		}
+		have[table][strings.ToLower(row["column_name"])] = true
+	}
+	if len(have) == 0 {
+		return nil
+	}
+
+	d := &SchemaDrift{Template: schema.TemplateFull, Expected: schema.Version}
+	for _, row := range parseSimpleCSV(metaCSV) {
+		switch row["key"] {
+		case "schema_version":
+			d.Version = row["value"]
+		case "schema_template":
+			if row["value"] != "" {
+				d.Template = row["value"]
+			}
+		}
+	}
+	ddl, err := schema.Template(d.Template)
+	if err != nil {
+		return d // custom or unknown template: nothing to compare
+	}
+	for table, cols := range schema.Columns(ddl) {
+		got, ok := have[table]
+		if !ok {
+			d.MissingTables = append(d.MissingTables, table)
+			continue
+		}
+		for _, col := range cols {
+			if !got[strings.ToLower(col.Name)] {
+				if d.MissingColumns == nil {
+					d.MissingColumns = map[string][]string{}
+				}
+				d.MissingColumns[table] = append(d.MissingColumns[table], col.Name)
+			}
+		}
+	}
+	slices.Sort(d.MissingTables)
+	return d
+}
+
+// CheckSchemaDrift compares db's schema against the one this release of wl
+// expects. It returns nil drift when the schema can't be inspected.
+func CheckSchemaDrift(db DB) (*SchemaDrift, error) {
+	cols, err := db.Query(SchemaColumnsQuery, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing columns: %w", err)
+	}
+	meta, err := db.Query(SchemaMetaQuery, "")
+	if err != nil {
+		return nil, fmt.Errorf("reading schema version: %w", err)
+	}
+	return ParseSchemaDrift(meta, cols), nil
+}
@@ -0,0 +1,111 @@
+package commons
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/schema"
+)
+
+// columnsCSV returns SchemaColumnsQuery output for every column of ddl,
+// leaving out the given "table.column" entries.
+func columnsCSV(ddl string, omit ...string) string {
+	var b strings.Builder
+	b.WriteString("table_name,column_name\n")
+	for table, cols := range schema.Columns(ddl) {
+		for _, col := range cols {
+			if !slices.Contains(omit, table+"."+col.Name) {
+				b.WriteString(table + "," + col.Name + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+func TestParseSchemaDrift_Current(t *testing.T) {
+	t.Parallel()
+	d := ParseSchemaDrift("key,value\nschema_version,"+schema.Version+"\n", columnsCSV(schema.SQL))
+	if d == nil || !d.Empty() {
+		t.Fatalf("drift = %+v, want empty", d)
+	}
+}
+
+func TestParseSchemaDrift_MissingColumns(t *testing.T) {
+	t.Parallel()
+	cols := columnsCSV(schema.SQL, "wanted.effort_level", "wanted.sandbox_required", "stamps.confidence")
+	d := ParseSchemaDrift("key,value\nschema_version,1.1\n", cols)
+	if d == nil || d.Empty() {
+		t.Fatal("expected drift")
+	}
+	if got := d.MissingColumns["wanted"]; !slices.Equal(got, []string{"effort_level", "sandbox_required"}) {
+		t.Errorf("wanted missing = %v", got)
+	}
+	want := "schema version 1.1, wl expects " + schema.Version +
+		"; missing columns: stamps.confidence, wanted.effort_level, wanted.sandbox_required"
+	if d.String() != want {
+		t.Errorf("String() = %q\nwant %q", d.String(), want)
+	}
+	stmts := d.AddColumnSQL()
+	if len(stmts) != 3 || stmts[1] != "ALTER TABLE `wanted` ADD COLUMN effort_level VARCHAR(16) DEFAULT 'medium'" {
+		t.Errorf("AddColumnSQL = %q", stmts)
+	}
+}
+
+func TestParseSchemaDrift_Templates(t *testing.T) {
+	t.Parallel()
+	minimal, err := schema.Template(schema.TemplateMinimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := ParseSchemaDrift("key,value\nschema_template,minimal\n", columnsCSV(minimal))
+	if d == nil || !d.Empty() {
+		t.Errorf("minimal drift = %+v, want empty", d)
+	}
+
+	d = ParseSchemaDrift("key,value\nschema_version,custom\nschema_template,custom\n", "table_name,column_name\nitems,id\n")
+	if d == nil || !d.Empty() {
+		t.Errorf("custom drift = %+v, want empty", d)
+	}
+}
+
+func TestParseSchemaDrift_NewerVersionAndMissingTable(t *testing.T) {
+	t.Parallel()
+	minimal, _ := schema.Template(schema.TemplateMinimal)
+	d := ParseSchemaDrift("key,value\nschema_version,9.0\n", columnsCSV(minimal))
+	if d.VersionDiff() != 1 {
+		t.Errorf("VersionDiff = %d, want 1", d.VersionDiff())
+	}
+	if !slices.Contains(d.MissingTables, "stamps") || slices.Contains(d.MissingTables, "wanted") {
+		t.Errorf("MissingTables = %v", d.MissingTables)
+	}
+	if !strings.Contains(d.String(), "upgrade wl") {
+		t.Errorf("String() = %q, want upgrade hint", d.String())
+	}
+}
+
+func TestCheckSchemaDrift(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{
+		"information_schema": columnsCSV(schema.SQL, "rigs.owner_email"),
+		"FROM _meta":         "key,value\nschema_version," + schema.Version + "\n",
+	}}
+	d, err := CheckSchemaDrift(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d == nil || !slices.Equal(d.MissingColumns["rigs"], []string{"owner_email"}) {
+		t.Errorf("drift = %+v", d)
+	}
+
+	// No column listing: can't tell, so no drift is reported.
+	d, err = CheckSchemaDrift(&fakeDB{})
+	if err != nil || d != nil {
+		t.Errorf("empty listing = %+v, %v; want nil, nil", d, err)
+	}
+
+	if _, err := CheckSchemaDrift(&fakeDB{err: errors.New("boom")}); err == nil {
+		t.Error("expected query error")
+	}
+}
@@ -0,0 +1,257 @@
+package commons
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Search result types.
+const (
+	SearchWanted     = "wanted"
+	SearchCompletion = "completion"
+	SearchStamp      = "stamp"
+)
+
+// SearchTypes lists the result types in the order ties are ranked.
+var SearchTypes = []string{SearchWanted, SearchCompletion, SearchStamp}
+
+// maxSearchLimit caps the results of one search.
+const maxSearchLimit = 100
+
+// searchCandidates caps the rows read per type before ranking.
+const searchCandidates = 200
+
+// SearchFilter is a cross-table search. Query is split into
+// whitespace-separated terms; a row matches when every term appears in at
+// least one of its searched fields, ignoring case.
+type SearchFilter struct {
+	Query string
+	Types []string // result types to search; empty means all
+	Limit int      // defaults to 20, capped at 100
+}
+
+// SearchResult is one matching row, tagged with its type.
+type SearchResult struct {
+	Type     string // SearchWanted, SearchCompletion or SearchStamp
+	ID       string // the wanted, completion or stamp ID
+	WantedID string // the wanted item the row belongs to; "" if unknown
+	Title    string // that item's title
+	Status   string // that item's status (wanted results only)
+	Rig      string // completer for completions, author for stamps
+	Field    string // the field that matched best, e.g. "title" or "evidence"
+	Snippet  string // text around the match in Field
+	Score    int    // higher ranks first
+}
+
+// searchField is one searched column of a result type, weighted by how
+// much a match in it says about relevance.
+type searchField struct {
+	name   string // field name reported in SearchResult.Field
+	column string // SQL expression over the query's aliases
+	weight int
+}
+
+var searchFields = map[string][]searchField{
+	SearchWanted: {
+		{"title", "w.title", 10},
+		{"tags", "CAST(w.tags AS CHAR)", 6},
+		{"description", "w.description", 3},
+	},
+	SearchCompletion: {
+		{"evidence", "c.evidence", 4},
+	},
+	SearchStamp: {
+		{"skills", "CAST(s.skill_tags AS CHAR)", 6},
+		{"message", "s.message", 3},
+	},
+}
+
+// ValidSearchType reports whether t names a search result type.
+func ValidSearchType(t string) bool {
+	return slices.Contains(SearchTypes, t)
+}
+
+// SearchTerms splits a query into lower-cased terms.
+func SearchTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// Search finds wanted items (title, description, tags), completions
+// (evidence) and stamps (message, skills) matching f.Query, best matches
+// first. Matches in titles, tags and skills outrank matches in longer
+// text, and an item whose ID is the query ranks above everything.
+func Search(db DB, f SearchFilter) ([]SearchResult, error) {
+	terms := SearchTerms(f.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty search query")
+	}
+	types := f.Types
+	if len(types) == 0 {
+		types = SearchTypes
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	limit = min(limit, maxSearchLimit)
+
+	var results []SearchResult
+	for _, t := range types {
+		if !ValidSearchType(t) {
+			return nil, fmt.Errorf("unknown search type %q (want %s)", t, strings.Join(SearchTypes, ", "))
+		}
+		out, err := db.Query(searchQuery(t, terms), "")
+		if err != nil {
+			return nil, fmt.Errorf("searching %s: %w", t, err)
+		}
+		for _, row := range parseSimpleCSV(out) {
+			if r, ok := scoreSearchRow(t, row, terms); ok {
+				results = append(results, r)
+			}
+		}
+	}
+
+	rank := func(t string) int { return slices.Index(SearchTypes, t) }
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(rank(a.Type), rank(b.Type)),
+			strings.Compare(a.ID, b.ID),
+		)
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// searchQuery builds the query for result type t: rows where every term
+// appears in one of the type's fields.
+func searchQuery(t string, terms []string) string {
+	var conds []string
+	for _, term := range terms {
+		var alts []string
+		for _, f := range searchFields[t] {
+			alts = append(alts, fmt.Sprintf("LOWER(COALESCE(%s, '')) LIKE '%%%s%%'", f.column, EscapeLIKE(term)))
+		}
+		if t == SearchWanted {
+			alts = append(alts, fmt.Sprintf("LOWER(w.id) = '%s'", EscapeSQL(term)))
+		}
+		conds = append(conds, "("+strings.Join(alts, " OR ")+")")
+	}
+	where := strings.Join(conds, "\n  AND ")
+	switch t {
+	case SearchWanted:
+		return fmt.Sprintf(`SELECT w.id, w.title, w.description, CAST(w.tags AS CHAR) AS tags, w.status
+FROM wanted w
+WHERE %s
+ORDER BY w.updated_at DESC
+LIMIT %d`, where, searchCandidates)
+	case SearchCompletion:
+		return fmt.Sprintf(`SELECT c.id, c.wanted_id, c.completed_by, c.evidence, w.title
+FROM completions c LEFT JOIN wanted w ON w.id = c.wanted_id
+WHERE %s
+ORDER BY c.completed_at DESC
+LIMIT %d`, where, searchCandidates)
+	default:
+		return fmt.Sprintf(`SELECT s.id, s.author, s.message, CAST(s.skill_tags AS CHAR) AS skills, c.wanted_id, w.title
+FROM stamps s
+LEFT JOIN completions c ON s.context_type = 'completion' AND c.id = s.context_id
+LEFT JOIN wanted w ON w.id = c.wanted_id
+WHERE %s
+ORDER BY s.created_at DESC
+LIMIT %d`, where, searchCandidates)
+	}
+}
+
+// scoreSearchRow turns a row of searchQuery's output into a result. Each
+// term scores the weight of the best field it appears in; rows missing a
+// term (which the SQL filter should already exclude) are dropped.
+func scoreSearchRow(t string, row map[string]string, terms []string) (SearchResult, bool) {
+	r := SearchResult{Type: t, ID: row["id"], WantedID: row["wanted_id"], Title: row["title"]}
+	values := map[string]string{}
+	switch t {
+	case SearchWanted:
+		r.WantedID = r.ID
+		r.Status = row["status"]
+		values["title"] = row["title"]
+		values["tags"] = strings.Join(parseTagsJSON(row["tags"]), ", ")
+		values["description"] = row["description"]
+	case SearchCompletion:
+		r.Rig = row["completed_by"]
+		values["evidence"] = row["evidence"]
+	case SearchStamp:
+		r.Rig = row["author"]
+		values["skills"] = strings.Join(parseTagsJSON(row["skills"]), ", ")
+		values["message"] = row["message"]
+	}
+
+	query := strings.Join(terms, " ")
+	if t == SearchWanted && strings.ToLower(r.ID) == query {
+		r.Score = 100
+		r.Field = "id"
+		r.Snippet = r.Title
+		return r, true
+	}
+
+	bestWeight := 0
+	for _, term := range terms {
+		w := 0
+		for _, f := range searchFields[t] {
+			if f.weight > w && strings.Contains(strings.ToLower(values[f.name]), term) {
+				w = f.weight
+				if w > bestWeight {
+					bestWeight = w
+					r.Field = f.name
+				}
+			}
+		}
+		if w == 0 {
+			return SearchResult{}, false
+		}
+		r.Score += w
+	}
+	// The whole query as a phrase, or at the start of a title, is a
+	// stronger match than its terms scattered about.
+	if len(terms) > 1 && strings.Contains(strings.ToLower(values[r.Field]), query) {
+		r.Score += 5
+	}
+	if t == SearchWanted && strings.HasPrefix(strings.ToLower(r.Title), query) {
+		r.Score += 3
+	}
+	field := strings.ToLower(values[r.Field])
+	i := slices.IndexFunc(terms, func(term string) bool { return strings.Contains(field, term) })
+	r.Snippet = searchSnippet(values[r.Field], terms[max(i, 0)])
+	return r, true
+}
+
+// snippetRadius is how many characters of context a snippet keeps on
+// each side of the match.
+const snippetRadius = 60
+
+// searchSnippet returns the text around the first occurrence of term in
+// s, on one line, with "…" marking cut ends.
+func searchSnippet(s, term string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	at := strings.Index(strings.ToLower(s), term)
+	if at < 0 || at > len(s) || len(runes) <= 2*snippetRadius {
+		if len(runes) > 2*snippetRadius {
+			return string(runes[:2*snippetRadius]) + "…"
+		}
+		return s
+	}
+	pos := len([]rune(s[:at]))
+	start := max(pos-snippetRadius, 0)
+	end := min(pos+len([]rune(term))+snippetRadius, len(runes))
+	out := string(runes[start:end])
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
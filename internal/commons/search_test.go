@@ -0,0 +1,103 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM wanted w": "id,title,description,tags,status\n" +
+			"w-2,Improve docs,Explain the auth flow,\"[\"\"docs\"\"]\",open\n" +
+			"w-1,Fix auth token refresh,,\"[\"\"go\"\"]\",claimed\n",
+		"FROM completions c": "id,wanted_id,completed_by,evidence,title\n" +
+			"c-1,w-9,bob,https://github.com/org/repo/pull/7 fixes auth,Harden login\n",
+		"FROM stamps s": "id,author,message,skills,wanted_id,title\n" +
+			"s-1,alice,great work,\"[\"\"auth\"\",\"\"go\"\"]\",w-9,Harden login\n",
+	}}
+	results, err := Search(db, SearchFilter{Query: "Auth"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Type+":"+r.ID+":"+r.Field)
+	}
+	want := []string{"wanted:w-1:title", "stamp:s-1:skills", "completion:c-1:evidence", "wanted:w-2:description"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+	if results[1].WantedID != "w-9" || results[1].Rig != "alice" || results[1].Title != "Harden login" {
+		t.Errorf("stamp result = %+v", results[1])
+	}
+	if results[0].Status != "claimed" || results[0].WantedID != "w-1" {
+		t.Errorf("wanted result = %+v", results[0])
+	}
+
+	if len(db.queries) != 3 {
+		t.Fatalf("queries = %d, want one per type", len(db.queries))
+	}
+	if !strings.Contains(db.queries[0], "LOWER(COALESCE(w.title, '')) LIKE '%auth%'") {
+		t.Errorf("wanted query should match terms case-insensitively:\n%s", db.queries[0])
+	}
+}
+
+func TestSearch_TypesAndLimit(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM stamps s": "id,author,message,skills,wanted_id,title\n" +
+			"s-1,alice,solid fix,,w-1,A\n" +
+			"s-2,bob,quick fix,,w-2,B\n",
+	}}
+	results, err := Search(db, SearchFilter{Query: "fix", Types: []string{SearchStamp}, Limit: 1})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "s-1" {
+		t.Errorf("results = %+v, want only s-1", results)
+	}
+	if len(db.queries) != 1 || !strings.Contains(db.queries[0], "FROM stamps s") {
+		t.Errorf("queries = %q, want only the stamps query", db.queries)
+	}
+
+	if _, err := Search(db, SearchFilter{Query: "fix", Types: []string{"rigs"}}); err == nil {
+		t.Error("unknown type should fail")
+	}
+	if _, err := Search(db, SearchFilter{Query: "  "}); err == nil {
+		t.Error("empty query should fail")
+	}
+}
+
+func TestSearch_IDAndPhrase(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM wanted w": "id,title,description,tags,status\n" +
+			"w-abc,Unrelated,,,open\n" +
+			"w-x,Cache warmup for the board,,,open\n" +
+			"w-y,Board cache,,,open\n",
+	}}
+	results, err := Search(db, SearchFilter{Query: "W-ABC", Types: []string{SearchWanted}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "w-abc" || results[0].Field != "id" {
+		t.Errorf("results = %+v, want w-abc first by id", results)
+	}
+
+	results, err = Search(db, SearchFilter{Query: "board cache", Types: []string{SearchWanted}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "w-y" {
+		t.Errorf("results = %+v, want the phrase match w-y first", results)
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	long := strings.Repeat("a ", 50) + "needle" + strings.Repeat(" b", 50)
+	got := searchSnippet(long, "needle")
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "needle") {
+		t.Errorf("snippet = %q", got)
+	}
+	if got := searchSnippet("short\ntext", "text"); got != "short text" {
+		t.Errorf("snippet = %q, want whole text on one line", got)
+	}
+}
@@ -0,0 +1,87 @@
+package commons
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SecretFinding is one likely credential found in text about to be
+// committed. Sample is redacted so reporting it doesn't leak it again.
+type SecretFinding struct {
+	Kind   string
+	Sample string
+}
+
+// SecretError is returned when a mutation would commit something that
+// looks like a credential. Wasteland databases are shared (and usually
+// public), so anything committed should be assumed published.
+type SecretError struct{ Findings []SecretFinding }
+
+func (e *SecretError) Error() string {
+	parts := make([]string, len(e.Findings))
+	for i, f := range e.Findings {
+		parts[i] = fmt.Sprintf("%s (%s)", f.Kind, f.Sample)
+	}
+	return "refusing to commit what looks like a secret: " + strings.Join(parts, ", ")
+}
+
+// secretPatterns are high-confidence credential formats. Generic
+// "password=..." heuristics are left out: they flag too much ordinary
+// prose to block a commit on.
+var secretPatterns = []struct {
+	kind string
+	re   *regexp.Regexp
+}{
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"Stripe key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{20,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"Anthropic API key", regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{"OpenAI API key", regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,}`)},
+	{"DoltHub token", regexp.MustCompile(`\bdhat\.v1\.[a-z0-9]{30,}\b`)},
+}
+
+// ScanSecrets returns the likely credentials in text, at most one finding
+// per distinct match.
+func ScanSecrets(text string) []SecretFinding {
+	var findings []SecretFinding
+	seen := map[string]bool{}
+	for _, p := range secretPatterns {
+		for _, m := range p.re.FindAllString(text, -1) {
+			if seen[m] {
+				continue
+			}
+			seen[m] = true
+			findings = append(findings, SecretFinding{Kind: p.kind, Sample: redactSecret(m)})
+		}
+	}
+	return findings
+}
+
+// CheckSecrets returns a *SecretError when any of texts holds a likely
+// credential.
+func CheckSecrets(texts ...string) error {
+	var findings []SecretFinding
+	for _, t := range texts {
+		findings = append(findings, ScanSecrets(t)...)
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	return &SecretError{Findings: findings}
+}
+
+// redactSecret keeps enough of s to recognize it.
+func redactSecret(s string) string {
+	if strings.HasPrefix(s, "-----BEGIN") {
+		return s
+	}
+	if len(s) <= 8 {
+		return "****"
+	}
+	return s[:6] + "…" + s[len(s)-2:]
+}
@@ -0,0 +1,167 @@
+package commons
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Seed is sample data loaded into a new wasteland by wl create --seed, so
+// demos and tests start with a populated board.
+type Seed struct {
+	Rigs   []SeedRig    `yaml:"rigs"`
+	Wanted []SeedWanted `yaml:"wanted"`
+}
+
+// SeedRig is a sample rig registration.
+type SeedRig struct {
+	Handle      string `yaml:"handle"`
+	DisplayName string `yaml:"display_name"`
+	DoltHubOrg  string `yaml:"dolthub_org"`
+	Email       string `yaml:"email"`
+	TrustLevel  int    `yaml:"trust_level"`
+	RigType     string `yaml:"rig_type"`
+}
+
+// SeedWanted is a sample wanted item. Priority defaults to 2, PostedBy to
+// the wasteland's creator and Status to "claimed" when ClaimedBy is set,
+// otherwise "open". An empty ID is generated from the title.
+type SeedWanted struct {
+	ID          string   `yaml:"id"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Project     string   `yaml:"project"`
+	Type        string   `yaml:"type"`
+	Priority    *int     `yaml:"priority"`
+	Tags        []string `yaml:"tags"`
+	Effort      string   `yaml:"effort"`
+	PostedBy    string   `yaml:"posted_by"`
+	ClaimedBy   string   `yaml:"claimed_by"`
+	Status      string   `yaml:"status"`
+}
+
+// ParseSeed decodes and validates a YAML seed file. Unknown keys are
+// rejected so a typo doesn't silently drop a field.
+func ParseSeed(data []byte) (*Seed, error) {
+	var s Seed
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing seed: %w", err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Seed) validate() error {
+	vocab := DefaultVocabulary()
+	handles := map[string]bool{}
+	for i, r := range s.Rigs {
+		if r.Handle == "" {
+			return fmt.Errorf("seed rig %d: handle is required", i+1)
+		}
+		if handles[r.Handle] {
+			return fmt.Errorf("seed rig %q listed twice", r.Handle)
+		}
+		handles[r.Handle] = true
+	}
+	ids := map[string]bool{}
+	for i, w := range s.Wanted {
+		if w.Title == "" {
+			return fmt.Errorf("seed wanted %d: title is required", i+1)
+		}
+		if w.ID != "" {
+			if ids[w.ID] {
+				return fmt.Errorf("seed wanted %q listed twice", w.ID)
+			}
+			ids[w.ID] = true
+		}
+		if w.Priority != nil && (*w.Priority < 0 || *w.Priority > 4) {
+			return fmt.Errorf("seed wanted %q: priority must be 0-4", w.Title)
+		}
+		if err := vocab.CheckType(w.Type); err != nil {
+			return fmt.Errorf("seed wanted %q: %w", w.Title, err)
+		}
+		if err := vocab.CheckEffort(w.Effort); err != nil {
+			return fmt.Errorf("seed wanted %q: %w", w.Title, err)
+		}
+		if w.Status != "" && !slices.Contains(statusOrder, w.Status) {
+			return fmt.Errorf("seed wanted %q: invalid status %q", w.Title, w.Status)
+		}
+	}
+	return nil
+}
+
+// DML returns the statements that insert the seed's rigs and wanted
+// items. Items without a poster are attributed to creator. Rigs already
+// registered (such as the creator) are left as they are.
+func (s *Seed) DML(creator string) ([]string, error) {
+	var stmts []string
+	for _, r := range s.Rigs {
+		rigType := r.RigType
+		if rigType == "" {
+			rigType = "human"
+		}
+		stmts = append(stmts, fmt.Sprintf(
+			"INSERT IGNORE INTO rigs (handle, display_name, dolthub_org, hop_uri, owner_email, trust_level, registered_at, last_seen, rig_type) "+
+				"VALUES ('%s', '%s', '%s', '%s', '%s', %d, NOW(), NOW(), '%s')",
+			EscapeSQL(r.Handle), EscapeSQL(r.DisplayName), EscapeSQL(r.DoltHubOrg),
+			EscapeSQL(fmt.Sprintf("hop://%s/%s/", r.Email, r.Handle)), EscapeSQL(r.Email),
+			r.TrustLevel, EscapeSQL(rigType)))
+	}
+	for _, w := range s.Wanted {
+		item := &WantedItem{
+			ID:          w.ID,
+			Title:       w.Title,
+			Description: w.Description,
+			Project:     w.Project,
+			Type:        w.Type,
+			Priority:    2,
+			Tags:        w.Tags,
+			PostedBy:    w.PostedBy,
+			Status:      w.Status,
+			EffortLevel: w.Effort,
+		}
+		if item.ID == "" {
+			item.ID = GenerateWantedID(w.Title)
+		}
+		if w.Priority != nil {
+			item.Priority = *w.Priority
+		}
+		if item.PostedBy == "" {
+			item.PostedBy = creator
+		}
+		if item.Status == "" && w.ClaimedBy != "" {
+			item.Status = "claimed"
+		}
+		dml, err := InsertWantedDML(item)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, dml)
+		if w.ClaimedBy != "" {
+			stmts = append(stmts, fmt.Sprintf("UPDATE wanted SET claimed_by='%s' WHERE id='%s'",
+				EscapeSQL(w.ClaimedBy), EscapeSQL(item.ID)))
+		}
+	}
+	return stmts, nil
+}
+
+// Script joins the seed's DML into a SQL script for dolt sql.
+func (s *Seed) Script(creator string) (string, error) {
+	stmts, err := s.DML(creator)
+	if err != nil {
+		return "", err
+	}
+	if len(stmts) == 0 {
+		return "", nil
+	}
+	return strings.Join(stmts, ";\n") + ";\n", nil
+}
@@ -0,0 +1,98 @@
+package commons
+
+import (
+	"strings"
+	"testing"
+)
+
+const seedYAML = `
+rigs:
+  - handle: bob
+    display_name: Bob
+    email: bob@example.com
+    trust_level: 2
+wanted:
+  - id: w-seed1
+    title: Fix the docs
+    type: docs
+    priority: 0
+    tags: [docs, onboarding]
+  - title: "Add dark mode"
+    posted_by: bob
+    claimed_by: alice
+`
+
+func TestParseSeed(t *testing.T) {
+	t.Parallel()
+	s, err := ParseSeed([]byte(seedYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Rigs) != 1 || s.Rigs[0].TrustLevel != 2 {
+		t.Errorf("rigs = %+v", s.Rigs)
+	}
+	if len(s.Wanted) != 2 || *s.Wanted[0].Priority != 0 || len(s.Wanted[0].Tags) != 2 {
+		t.Errorf("wanted = %+v", s.Wanted)
+	}
+}
+
+func TestParseSeed_Empty(t *testing.T) {
+	t.Parallel()
+	s, err := ParseSeed(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if script, err := s.Script("alice"); script != "" || err != nil {
+		t.Errorf("Script() = %q, %v; want empty", script, err)
+	}
+}
+
+func TestParseSeed_Invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unknown key", "wanted:\n  - title: x\n    priorty: 1\n", "priorty"},
+		{"no handle", "rigs:\n  - display_name: Bob\n", "handle is required"},
+		{"duplicate rig", "rigs:\n  - handle: bob\n  - handle: bob\n", "listed twice"},
+		{"no title", "wanted:\n  - id: w-1\n", "title is required"},
+		{"duplicate id", "wanted:\n  - {id: w-1, title: a}\n  - {id: w-1, title: b}\n", "listed twice"},
+		{"priority", "wanted:\n  - {title: a, priority: 9}\n", "priority must be 0-4"},
+		{"type", "wanted:\n  - {title: a, type: chore}\n", "invalid type"},
+		{"effort", "wanted:\n  - {title: a, effort: huge}\n", "invalid effort"},
+		{"status", "wanted:\n  - {title: a, status: done}\n", "invalid status"},
+	}
+	for _, tt := range tests {
+		_, err := ParseSeed([]byte(tt.yaml))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSeedDML(t *testing.T) {
+	t.Parallel()
+	s, err := ParseSeed([]byte(seedYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts, err := s.DML("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 4 {
+		t.Fatalf("got %d statements, want 4: %v", len(stmts), stmts)
+	}
+	if !strings.HasPrefix(stmts[0], "INSERT IGNORE INTO rigs") || !strings.Contains(stmts[0], "'hop://bob@example.com/bob/'") {
+		t.Errorf("rig insert = %q", stmts[0])
+	}
+	if !strings.Contains(stmts[1], "'w-seed1'") || !strings.Contains(stmts[1], "'alice'") || !strings.Contains(stmts[1], ", 0, ") {
+		t.Errorf("first item should default the poster and keep priority 0: %q", stmts[1])
+	}
+	if !strings.Contains(stmts[2], "'bob'") || !strings.Contains(stmts[2], "'claimed'") {
+		t.Errorf("claimed item insert = %q", stmts[2])
+	}
+	if !strings.Contains(stmts[3], "claimed_by='alice'") {
+		t.Errorf("claim update = %q", stmts[3])
+	}
+}
@@ -0,0 +1,141 @@
+package commons
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// StampRecord is a stamp with its valence parsed and its context resolved
+// to the wanted item it was issued for.
+type StampRecord struct {
+	ID          string             `json:"id"`
+	Author      string             `json:"author"`
+	Subject     string             `json:"subject"`
+	Valence     map[string]float64 `json:"valence"`
+	Severity    string             `json:"severity"`
+	SkillTags   []string           `json:"skill_tags,omitempty"`
+	Message     string             `json:"message,omitempty"`
+	ContextType string             `json:"context_type,omitempty"`
+	ContextID   string             `json:"context_id,omitempty"`
+	WantedID    string             `json:"wanted_id,omitempty"`
+	WantedTitle string             `json:"wanted_title,omitempty"`
+	CreatedAt   string             `json:"created_at"`
+	Commit      *StampCommit       `json:"commit,omitempty"`
+}
+
+// StampCommit is the commit that added a stamp.
+type StampCommit struct {
+	Hash      string `json:"hash"`
+	Committer string `json:"committer"`
+	Date      string `json:"date"`
+	Message   string `json:"message"`
+}
+
+// StampFilter selects stamps for QueryStamps. Author and Subject narrow
+// to stamps given and received; Rig matches either side.
+type StampFilter struct {
+	Author  string
+	Subject string
+	Rig     string
+	Limit   int // defaults to 50
+}
+
+const stampColumns = `s.id, s.author, s.subject, CAST(s.valence AS CHAR) AS valence,
+  COALESCE(s.severity, '') AS severity, COALESCE(CAST(s.skill_tags AS CHAR), '') AS skill_tags,
+  COALESCE(s.message, '') AS message, COALESCE(s.context_type, '') AS context_type,
+  COALESCE(s.context_id, '') AS context_id, COALESCE(c.wanted_id, '') AS wanted_id,
+  COALESCE(w.title, '') AS wanted_title, COALESCE(s.created_at, '') AS created_at
+FROM stamps s
+LEFT JOIN completions c ON s.context_type = 'completion' AND c.id = s.context_id
+LEFT JOIN wanted w ON w.id = c.wanted_id`
+
+// QueryStamps lists stamps matching f, newest first.
+func QueryStamps(db DB, f StampFilter) ([]StampRecord, error) {
+	var conds []string
+	if f.Author != "" {
+		conds = append(conds, fmt.Sprintf("s.author = '%s'", EscapeSQL(f.Author)))
+	}
+	if f.Subject != "" {
+		conds = append(conds, fmt.Sprintf("s.subject = '%s'", EscapeSQL(f.Subject)))
+	}
+	if f.Rig != "" {
+		conds = append(conds, fmt.Sprintf("(s.author = '%[1]s' OR s.subject = '%[1]s')", EscapeSQL(f.Rig)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	out, err := db.Query(fmt.Sprintf("SELECT %s%s\nORDER BY s.created_at DESC, s.id\nLIMIT %d", stampColumns, where, limit), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying stamps: %w", err)
+	}
+	var stamps []StampRecord
+	for _, row := range parseSimpleCSV(out) {
+		stamps = append(stamps, stampRecordFromRow(row))
+	}
+	return stamps, nil
+}
+
+// QueryStampRecord fetches one stamp and the commit that added it. The
+// commit is left nil when the history can't be read.
+func QueryStampRecord(db DB, stampID string) (*StampRecord, error) {
+	out, err := db.Query(fmt.Sprintf("SELECT %s\nWHERE s.id = '%s'", stampColumns, EscapeSQL(stampID)), "")
+	if err != nil {
+		return nil, fmt.Errorf("querying stamp: %w", err)
+	}
+	rows := parseSimpleCSV(out)
+	if len(rows) == 0 {
+		return nil, &NotFoundError{Message: fmt.Sprintf("stamp %q not found", stampID)}
+	}
+	s := stampRecordFromRow(rows[0])
+
+	out, err = db.Query(fmt.Sprintf(`SELECT h.commit_hash, h.committer, h.commit_date, COALESCE(l.message, '') AS message
+FROM dolt_history_stamps h
+LEFT JOIN dolt_log l ON h.commit_hash = l.commit_hash
+WHERE h.id = '%s'
+ORDER BY h.commit_date ASC
+LIMIT 1`, EscapeSQL(stampID)), "")
+	if err != nil {
+		slog.Debug("loading stamp history failed", "stamp", stampID, "error", err)
+		return &s, nil
+	}
+	if rows := parseSimpleCSV(out); len(rows) > 0 {
+		s.Commit = &StampCommit{
+			Hash:      rows[0]["commit_hash"],
+			Committer: rows[0]["committer"],
+			Date:      rows[0]["commit_date"],
+			Message:   rows[0]["message"],
+		}
+	}
+	return &s, nil
+}
+
+func stampRecordFromRow(row map[string]string) StampRecord {
+	s := StampRecord{
+		ID:          row["id"],
+		Author:      row["author"],
+		Subject:     row["subject"],
+		Valence:     map[string]float64{},
+		Severity:    row["severity"],
+		SkillTags:   parseTagsJSON(row["skill_tags"]),
+		Message:     row["message"],
+		ContextType: row["context_type"],
+		ContextID:   row["context_id"],
+		WantedID:    row["wanted_id"],
+		WantedTitle: row["wanted_title"],
+		CreatedAt:   row["created_at"],
+	}
+	if v := row["valence"]; v != "" {
+		if err := json.Unmarshal([]byte(v), &s.Valence); err != nil {
+			slog.Debug("malformed stamp valence", "stamp", s.ID, "value", v)
+		}
+	}
+	return s
+}
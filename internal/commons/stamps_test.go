@@ -0,0 +1,69 @@
+package commons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const stampRecordCSV = "id,author,subject,valence,severity,skill_tags,message,context_type,context_id,wanted_id,wanted_title,created_at\n" +
+	"s-1,bob,alice,\"{\"\"quality\"\": 4, \"\"reliability\"\": 5, \"\"creativity\"\": 3}\",leaf,\"[\"\"go\"\"]\",nice,completion,c-1,w-1,Fix auth,2026-03-02 10:00:00\n"
+
+func TestQueryStamps_Filters(t *testing.T) {
+	db := &fakeDB{results: map[string]string{"FROM stamps s": stampRecordCSV}}
+
+	stamps, err := QueryStamps(db, StampFilter{Author: "bob", Subject: "alice"})
+	if err != nil {
+		t.Fatalf("QueryStamps: %v", err)
+	}
+	if len(stamps) != 1 {
+		t.Fatalf("stamps = %d, want 1", len(stamps))
+	}
+	s := stamps[0]
+	if s.Valence["quality"] != 4 || s.Valence["reliability"] != 5 || s.Valence["creativity"] != 3 {
+		t.Errorf("valence = %v", s.Valence)
+	}
+	if s.WantedID != "w-1" || s.WantedTitle != "Fix auth" || len(s.SkillTags) != 1 {
+		t.Errorf("stamp = %+v", s)
+	}
+	q := db.queries[0]
+	for _, want := range []string{"s.author = 'bob'", "s.subject = 'alice'", "LIMIT 50"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query missing %q:\n%s", want, q)
+		}
+	}
+}
+
+func TestQueryStamps_Rig(t *testing.T) {
+	db := &fakeDB{}
+	if _, err := QueryStamps(db, StampFilter{Rig: "alice", Limit: 5}); err != nil {
+		t.Fatalf("QueryStamps: %v", err)
+	}
+	q := db.queries[0]
+	if !strings.Contains(q, "(s.author = 'alice' OR s.subject = 'alice')") || !strings.Contains(q, "LIMIT 5") {
+		t.Errorf("query = %s", q)
+	}
+}
+
+func TestQueryStampRecord(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM stamps s":       stampRecordCSV,
+		"dolt_history_stamps": "commit_hash,committer,commit_date,message\nabc123,bob,2026-03-02 10:00:01,wl accept: w-1\n",
+	}}
+
+	s, err := QueryStampRecord(db, "s-1")
+	if err != nil {
+		t.Fatalf("QueryStampRecord: %v", err)
+	}
+	if s.Commit == nil || s.Commit.Hash != "abc123" || s.Commit.Message != "wl accept: w-1" {
+		t.Errorf("commit = %+v", s.Commit)
+	}
+}
+
+func TestQueryStampRecord_NotFound(t *testing.T) {
+	_, err := QueryStampRecord(&fakeDB{}, "s-missing")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Errorf("err = %v, want NotFoundError", err)
+	}
+}
@@ -0,0 +1,188 @@
+package commons
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsFilter narrows the wanted items a stats report covers. The wanted
+// table has no milestone column, so a milestone is the tag its items carry.
+type StatsFilter struct {
+	Project   string
+	Milestone string
+}
+
+// conditions returns the filter as SQL conditions on the wanted columns
+// under alias (e.g. "h." for dolt_history_wanted h).
+func (f StatsFilter) conditions(alias string) []string {
+	var conds []string
+	if f.Project != "" {
+		conds = append(conds, fmt.Sprintf("%sproject = '%s'", alias, EscapeSQL(f.Project)))
+	}
+	if f.Milestone != "" {
+		conds = append(conds, fmt.Sprintf("JSON_CONTAINS(%stags, %s)", alias, sqlJSONString(f.Milestone)))
+	}
+	return conds
+}
+
+// StatusCount is the number of wanted items in one status.
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// QueryStatusCounts counts the wanted items on main by status, in
+// lifecycle order.
+func QueryStatusCounts(db DB, f StatsFilter) ([]StatusCount, error) {
+	query := "SELECT status, COUNT(*) AS n FROM wanted"
+	if conds := f.conditions(""); len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " GROUP BY status"
+
+	output, err := db.Query(query, "")
+	if err != nil {
+		return nil, fmt.Errorf("counting wanted items: %w", err)
+	}
+	var out []StatusCount
+	for _, row := range parseSimpleCSV(output) {
+		n, err := strconv.Atoi(row["n"])
+		if err != nil {
+			return nil, fmt.Errorf("parsing count for %q: %w", row["status"], err)
+		}
+		out = append(out, StatusCount{Status: row["status"], Count: n})
+	}
+	slices.SortStableFunc(out, func(a, b StatusCount) int {
+		return cmp.Compare(statusRank(a.Status), statusRank(b.Status))
+	})
+	return out, nil
+}
+
+// StatusChange is one status transition of a wanted item.
+type StatusChange struct {
+	Status string
+	At     time.Time
+}
+
+// QueryStatusHistory walks dolt_history_wanted and returns each item's
+// status transitions, oldest first. Commits that leave the status
+// unchanged are collapsed.
+func QueryStatusHistory(db DB, f StatsFilter) (map[string][]StatusChange, error) {
+	query := "SELECT h.id, h.status, h.commit_date FROM dolt_history_wanted h"
+	if conds := f.conditions("h."); len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY h.commit_date ASC, h.id"
+
+	output, err := db.Query(query, "")
+	if err != nil {
+		return nil, fmt.Errorf("querying history: %w", err)
+	}
+	history := make(map[string][]StatusChange)
+	for _, row := range parseSimpleCSV(output) {
+		at, ok := ParseDoltTime(row["commit_date"])
+		if !ok {
+			continue
+		}
+		id := row["id"]
+		changes := history[id]
+		if n := len(changes); n > 0 && changes[n-1].Status == row["status"] {
+			continue
+		}
+		history[id] = append(changes, StatusChange{Status: row["status"], At: at})
+	}
+	return history, nil
+}
+
+// statusAt returns an item's status as of t, or "" if it didn't exist yet.
+func statusAt(changes []StatusChange, t time.Time) string {
+	status := ""
+	for _, c := range changes {
+		if c.At.After(t) {
+			break
+		}
+		status = c.Status
+	}
+	return status
+}
+
+// BurndownPoint is the number of unfinished items at the end of one day.
+type BurndownPoint struct {
+	Date time.Time
+	Open int // items open, claimed or in review
+}
+
+// Burndown samples the unfinished-item count at the end of each day from
+// from through to (the last sample is taken at to itself).
+func Burndown(history map[string][]StatusChange, from, to time.Time) []BurndownPoint {
+	var points []BurndownPoint
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for !day.After(to) {
+		cutoff := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if cutoff.After(to) {
+			cutoff = to
+		}
+		open := 0
+		for _, changes := range history {
+			switch statusAt(changes, cutoff) {
+			case "open", "claimed", "in_review":
+				open++
+			}
+		}
+		points = append(points, BurndownPoint{Date: day, Open: open})
+		day = day.AddDate(0, 0, 1)
+	}
+	return points
+}
+
+// CycleTime summarizes how long completed items took from claim to
+// completion.
+type CycleTime struct {
+	Completed     int
+	P50, P75, P90 time.Duration
+}
+
+// CycleTimes measures items completed between from and to, from their
+// first claim (or their creation, if never claimed) to their completion.
+func CycleTimes(history map[string][]StatusChange, from, to time.Time) CycleTime {
+	var durations []time.Duration
+	for _, changes := range history {
+		var done time.Time
+		for _, c := range changes {
+			if c.Status == "completed" {
+				done = c.At
+			}
+		}
+		if done.IsZero() || done.Before(from) || done.After(to) {
+			continue
+		}
+		start := changes[0].At
+		for _, c := range changes {
+			if c.Status == "claimed" {
+				start = c.At
+				break
+			}
+		}
+		durations = append(durations, done.Sub(start))
+	}
+	slices.Sort(durations)
+	return CycleTime{
+		Completed: len(durations),
+		P50:       percentile(durations, 50),
+		P75:       percentile(durations, 75),
+		P90:       percentile(durations, 90),
+	}
+}
+
+// percentile returns the nearest-rank pth percentile of sorted values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
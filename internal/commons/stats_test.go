@@ -0,0 +1,82 @@
+package commons
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryStatusHistory(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{"dolt_history_wanted": "id,status,commit_date\n" +
+		"w-1,open,2026-03-01 10:00:00\n" +
+		"w-1,open,2026-03-01 11:00:00.123\n" +
+		"w-1,claimed,2026-03-02 09:00:00\n" +
+		"w-2,open,2026-03-02 12:00:00\n"}}
+
+	history, err := QueryStatusHistory(db, StatsFilter{Project: "gt", Milestone: "v1"})
+	if err != nil {
+		t.Fatalf("QueryStatusHistory: %v", err)
+	}
+	if got := history["w-1"]; len(got) != 2 || got[0].Status != "open" || got[1].Status != "claimed" {
+		t.Errorf("w-1 history = %+v, want open then claimed", got)
+	}
+	if len(history["w-2"]) != 1 {
+		t.Errorf("w-2 history = %+v", history["w-2"])
+	}
+	for _, want := range []string{"h.project = 'gt'", `JSON_CONTAINS(h.tags, '"v1"')`} {
+		if !strings.Contains(db.queries[0], want) {
+			t.Errorf("query missing %q: %s", want, db.queries[0])
+		}
+	}
+}
+
+func TestQueryStatusCounts(t *testing.T) {
+	t.Parallel()
+	db := &fakeDB{results: map[string]string{"GROUP BY status": "status,n\ncompleted,4\nopen,7\nclaimed,2\n"}}
+	counts, err := QueryStatusCounts(db, StatsFilter{})
+	if err != nil {
+		t.Fatalf("QueryStatusCounts: %v", err)
+	}
+	if len(counts) != 3 || counts[0].Status != "open" || counts[1].Status != "claimed" || counts[2].Count != 4 {
+		t.Errorf("counts = %+v, want lifecycle order", counts)
+	}
+	if strings.Contains(db.queries[0], "WHERE") {
+		t.Errorf("unfiltered query should not filter: %s", db.queries[0])
+	}
+}
+
+func day(d, h int) time.Time {
+	return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC)
+}
+
+func TestBurndownAndCycleTimes(t *testing.T) {
+	t.Parallel()
+	history := map[string][]StatusChange{
+		"w-1": {{"open", day(1, 9)}, {"claimed", day(2, 9)}, {"completed", day(4, 9)}},
+		"w-2": {{"open", day(1, 9)}, {"claimed", day(3, 9)}, {"completed", day(3, 21)}},
+		"w-3": {{"open", day(2, 9)}},
+		"w-4": {{"open", day(1, 9)}, {"withdrawn", day(2, 9)}},
+	}
+
+	points := Burndown(history, day(1, 12), day(4, 12))
+	var got []int
+	for _, p := range points {
+		got = append(got, p.Open)
+	}
+	if want := []int{3, 3, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("burndown = %v, want %v", got, want)
+	}
+	if !points[0].Date.Equal(day(1, 0)) {
+		t.Errorf("first point date = %v", points[0].Date)
+	}
+
+	cycle := CycleTimes(history, day(1, 0), day(4, 12))
+	if cycle.Completed != 2 || cycle.P50 != 12*time.Hour || cycle.P90 != 48*time.Hour {
+		t.Errorf("cycle = %+v, want 2 completed, p50 12h, p90 48h", cycle)
+	}
+	if c := CycleTimes(history, day(5, 0), day(6, 0)); c.Completed != 0 || c.P50 != 0 {
+		t.Errorf("empty period cycle = %+v", c)
+	}
+}
@@ -0,0 +1,169 @@
+package commons
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagDef is one entry of the tags registry: a canonical tag and the
+// spellings that normalize to it.
+type TagDef struct {
+	Tag         string   `json:"tag"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// TagRegistry maps free-form tags to the canonical casing and spelling a
+// wasteland has agreed on, so "go", "Go" and "golang" don't fragment the
+// board. The registry is optional: a wasteland without a tags table (or
+// with an empty one) gets a zero registry that accepts any tag.
+type TagRegistry struct {
+	Defs []TagDef
+	// Strict rejects tags that are not in the registry instead of passing
+	// them through. It has no effect on an empty registry.
+	Strict bool
+
+	lookup map[string]string // lowercased tag or alias → canonical tag
+}
+
+// UnknownTagsError is returned by Normalize in strict mode when tags are
+// not in the registry.
+type UnknownTagsError struct {
+	Tags  []string
+	Known []string
+}
+
+func (e *UnknownTagsError) Error() string {
+	return fmt.Sprintf("unknown tag(s) %s: this wasteland only accepts registered tags (%s)",
+		strings.Join(e.Tags, ", "), strings.Join(e.Known, ", "))
+}
+
+// NewTagRegistry builds a registry from defs.
+func NewTagRegistry(defs []TagDef, strict bool) *TagRegistry {
+	r := &TagRegistry{Defs: defs, Strict: strict, lookup: make(map[string]string)}
+	for _, d := range defs {
+		r.lookup[strings.ToLower(d.Tag)] = d.Tag
+	}
+	// Aliases never shadow a canonical tag.
+	for _, d := range defs {
+		for _, a := range d.Aliases {
+			key := strings.ToLower(strings.TrimSpace(a))
+			if _, ok := r.lookup[key]; !ok && key != "" {
+				r.lookup[key] = d.Tag
+			}
+		}
+	}
+	return r
+}
+
+// Canonical returns the registered tags in sorted order.
+func (r *TagRegistry) Canonical() []string {
+	if r == nil {
+		return nil
+	}
+	tags := make([]string, len(r.Defs))
+	for i, d := range r.Defs {
+		tags[i] = d.Tag
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Resolve returns the canonical form of tag and whether it is registered.
+// Unregistered tags are returned trimmed but otherwise unchanged.
+func (r *TagRegistry) Resolve(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	if r == nil {
+		return tag, false
+	}
+	canon, ok := r.lookup[strings.ToLower(tag)]
+	if !ok {
+		return tag, false
+	}
+	return canon, true
+}
+
+// Normalize maps tags to their canonical forms and drops empties and
+// duplicates, keeping first-seen order. In strict mode, with a non-empty
+// registry, any unregistered tag fails with an *UnknownTagsError.
+func (r *TagRegistry) Normalize(tags []string) ([]string, error) {
+	var out, unknown []string
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		canon, ok := r.Resolve(t)
+		if canon == "" {
+			continue
+		}
+		if !ok && r != nil && r.Strict && len(r.Defs) > 0 {
+			unknown = append(unknown, canon)
+			continue
+		}
+		key := strings.ToLower(canon)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, canon)
+	}
+	if len(unknown) > 0 {
+		return nil, &UnknownTagsError{Tags: unknown, Known: r.Canonical()}
+	}
+	return out, nil
+}
+
+// QueryTagRegistry reads the tags registry and the strict_tags _meta flag
+// from main. A database created before the tags table existed yields an
+// empty registry rather than an error.
+func QueryTagRegistry(db DB) (*TagRegistry, error) {
+	rows, err := QueryRows(db, "SELECT tag, COALESCE(aliases, '') AS aliases, COALESCE(description, '') AS description FROM tags ORDER BY tag", "")
+	if err != nil {
+		if IsTableNotFound(err) {
+			return NewTagRegistry(nil, false), nil
+		}
+		return nil, fmt.Errorf("querying tags: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are checked below
+
+	var defs []TagDef
+	for rows.Next() {
+		m := rows.Map()
+		if m["tag"] == "" {
+			continue
+		}
+		defs = append(defs, TagDef{
+			Tag:         m["tag"],
+			Aliases:     parseTagsJSON(m["aliases"]),
+			Description: m["description"],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading tags: %w", err)
+	}
+	if len(defs) == 0 {
+		return NewTagRegistry(nil, false), nil
+	}
+
+	strict := false
+	output, err := db.Query("SELECT value FROM _meta WHERE `key` = 'strict_tags'", "")
+	if err != nil {
+		return nil, fmt.Errorf("querying strict_tags: %w", err)
+	}
+	if rows := parseSimpleCSV(output); len(rows) > 0 {
+		switch strings.ToLower(strings.TrimSpace(rows[0]["value"])) {
+		case "1", "true", "yes", "on":
+			strict = true
+		}
+	}
+	return NewTagRegistry(defs, strict), nil
+}
+
+// IsTableNotFound reports whether err is a query against a table the
+// database doesn't have, as returned by dolt and the DoltHub API.
+func IsTableNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "table not found") || strings.Contains(msg, "doesn't exist")
+}
@@ -0,0 +1,78 @@
+package commons
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTagRegistry_Normalize(t *testing.T) {
+	reg := NewTagRegistry([]TagDef{
+		{Tag: "go", Aliases: []string{"golang", "Go-Lang"}},
+		{Tag: "JavaScript", Aliases: []string{"js", "go"}}, // alias can't steal a canonical tag
+	}, false)
+
+	got, err := reg.Normalize([]string{"Golang", " GO ", "JS", "javascript", "", "new-tag", "New-Tag"})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	want := []string{"go", "JavaScript", "new-tag"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalize = %v, want %v", got, want)
+	}
+	if c := reg.Canonical(); !reflect.DeepEqual(c, []string{"JavaScript", "go"}) {
+		t.Errorf("Canonical = %v", c)
+	}
+}
+
+func TestTagRegistry_Strict(t *testing.T) {
+	reg := NewTagRegistry([]TagDef{{Tag: "go"}, {Tag: "docs"}}, true)
+
+	_, err := reg.Normalize([]string{"go", "rust", "zig"})
+	var unknown *UnknownTagsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Normalize error = %v, want UnknownTagsError", err)
+	}
+	if !reflect.DeepEqual(unknown.Tags, []string{"rust", "zig"}) || !reflect.DeepEqual(unknown.Known, []string{"docs", "go"}) {
+		t.Errorf("error = %+v", unknown)
+	}
+
+	// Strict has nothing to enforce without registered tags.
+	empty := NewTagRegistry(nil, true)
+	if got, err := empty.Normalize([]string{"rust"}); err != nil || !reflect.DeepEqual(got, []string{"rust"}) {
+		t.Errorf("empty strict registry Normalize = %v, %v", got, err)
+	}
+}
+
+func TestQueryTagRegistry(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"FROM tags":   "tag,aliases,description\ngo,\"[\"\"golang\"\"]\",\"The Go\nlanguage\"\n",
+		"strict_tags": "value\ntrue\n",
+	}}
+	reg, err := QueryTagRegistry(db)
+	if err != nil {
+		t.Fatalf("QueryTagRegistry: %v", err)
+	}
+	if !reg.Strict || len(reg.Defs) != 1 || reg.Defs[0].Description != "The Go\nlanguage" {
+		t.Errorf("registry = %+v", reg)
+	}
+	if canon, ok := reg.Resolve("GOLANG"); !ok || canon != "go" {
+		t.Errorf("Resolve(GOLANG) = %q, %v", canon, ok)
+	}
+}
+
+func TestQueryTagRegistry_MissingTable(t *testing.T) {
+	db := &fakeDB{err: errors.New("query failed: table not found: tags")}
+	reg, err := QueryTagRegistry(db)
+	if err != nil {
+		t.Fatalf("QueryTagRegistry: %v", err)
+	}
+	if len(reg.Defs) != 0 || reg.Strict {
+		t.Errorf("registry = %+v, want empty", reg)
+	}
+
+	db.err = errors.New("connection refused")
+	if _, err := QueryTagRegistry(db); err == nil {
+		t.Error("other query errors should be returned")
+	}
+}
@@ -0,0 +1,128 @@
+package commons
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultItemTypes are the wanted item types a wasteland accepts when its
+// _meta table doesn't define item_types.
+var DefaultItemTypes = []string{"feature", "bug", "design", "rfc", "docs", "inference"}
+
+// DefaultEffortLevels are the effort levels a wasteland accepts when its
+// _meta table doesn't define effort_levels.
+var DefaultEffortLevels = []string{"trivial", "small", "medium", "large", "epic"}
+
+// Vocabulary is the set of item types and effort levels a wasteland
+// accepts. An upstream can replace either list with a JSON array (or
+// comma-separated list) in the item_types and effort_levels _meta keys.
+type Vocabulary struct {
+	Types   []string `json:"types"`
+	Efforts []string `json:"efforts"`
+}
+
+// DefaultVocabulary returns the built-in types and effort levels.
+func DefaultVocabulary() *Vocabulary {
+	return &Vocabulary{
+		Types:   slices.Clone(DefaultItemTypes),
+		Efforts: slices.Clone(DefaultEffortLevels),
+	}
+}
+
+// ValidType reports whether typ is an accepted item type.
+func (v *Vocabulary) ValidType(typ string) bool {
+	return slices.Contains(v.Types, typ)
+}
+
+// ValidEffort reports whether effort is an accepted effort level.
+func (v *Vocabulary) ValidEffort(effort string) bool {
+	return slices.Contains(v.Efforts, effort)
+}
+
+// TypeCycle returns the browse filter type cycle: "" (all) followed by
+// the accepted types.
+func (v *Vocabulary) TypeCycle() []string {
+	return append([]string{""}, v.Types...)
+}
+
+// Without returns a copy of v with typ removed from its types.
+func (v *Vocabulary) Without(typ string) *Vocabulary {
+	out := &Vocabulary{Efforts: slices.Clone(v.Efforts)}
+	for _, t := range v.Types {
+		if t != typ {
+			out.Types = append(out.Types, t)
+		}
+	}
+	return out
+}
+
+// CheckType returns an error naming the accepted types if typ is set and
+// not one of them.
+func (v *Vocabulary) CheckType(typ string) error {
+	if typ != "" && !v.ValidType(typ) {
+		return fmt.Errorf("invalid type %q: must be one of %s", typ, strings.Join(v.Types, ", "))
+	}
+	return nil
+}
+
+// CheckEffort returns an error naming the accepted effort levels if effort
+// is set and not one of them.
+func (v *Vocabulary) CheckEffort(effort string) error {
+	if effort != "" && !v.ValidEffort(effort) {
+		return fmt.Errorf("invalid effort %q: must be one of %s", effort, strings.Join(v.Efforts, ", "))
+	}
+	return nil
+}
+
+// QueryVocabulary reads the item_types and effort_levels _meta keys from
+// main. Keys that are absent or empty keep their defaults.
+func QueryVocabulary(db DB) (*Vocabulary, error) {
+	rows, err := QueryRows(db, "SELECT `key`, value FROM _meta WHERE `key` IN ('item_types', 'effort_levels')", "")
+	if err != nil {
+		return nil, fmt.Errorf("querying vocabulary: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are checked below
+
+	v := DefaultVocabulary()
+	for rows.Next() {
+		m := rows.Map()
+		values := parseVocabList(m["value"])
+		if len(values) == 0 {
+			continue
+		}
+		switch m["key"] {
+		case "item_types":
+			v.Types = values
+		case "effort_levels":
+			v.Efforts = values
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading vocabulary: %w", err)
+	}
+	return v, nil
+}
+
+// parseVocabList parses a JSON string array or a comma-separated list,
+// dropping blanks and duplicates.
+func parseVocabList(s string) []string {
+	s = strings.TrimSpace(s)
+	var raw []string
+	if strings.HasPrefix(s, "[") {
+		if err := json.Unmarshal([]byte(s), &raw); err != nil {
+			return nil
+		}
+	} else {
+		raw = strings.Split(s, ",")
+	}
+	var out []string
+	for _, r := range raw {
+		r = strings.TrimSpace(r)
+		if r != "" && !slices.Contains(out, r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package commons
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueryVocabulary(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"item_types": "key,value\nitem_types,\"[\"\"quest\"\",\"\"bug\"\",\"\"quest\"\"]\"\neffort_levels,\" S , M ,,L \"\n",
+	}}
+	v, err := QueryVocabulary(db)
+	if err != nil {
+		t.Fatalf("QueryVocabulary: %v", err)
+	}
+	if !reflect.DeepEqual(v.Types, []string{"quest", "bug"}) {
+		t.Errorf("Types = %v", v.Types)
+	}
+	if !reflect.DeepEqual(v.Efforts, []string{"S", "M", "L"}) {
+		t.Errorf("Efforts = %v", v.Efforts)
+	}
+	if !reflect.DeepEqual(v.TypeCycle(), []string{"", "quest", "bug"}) {
+		t.Errorf("TypeCycle = %v", v.TypeCycle())
+	}
+}
+
+func TestQueryVocabulary_Defaults(t *testing.T) {
+	db := &fakeDB{results: map[string]string{
+		"item_types": "key,value\nitem_types,\n",
+	}}
+	v, err := QueryVocabulary(db)
+	if err != nil {
+		t.Fatalf("QueryVocabulary: %v", err)
+	}
+	if !reflect.DeepEqual(v, DefaultVocabulary()) {
+		t.Errorf("vocabulary = %+v, want defaults", v)
+	}
+
+	failing := &fakeDB{err: errors.New("boom")}
+	if _, err := QueryVocabulary(failing); err == nil {
+		t.Error("QueryVocabulary should surface query errors")
+	}
+}
+
+func TestVocabulary_Checks(t *testing.T) {
+	v := DefaultVocabulary().Without("inference")
+	if v.ValidType("inference") || !DefaultVocabulary().ValidType("inference") {
+		t.Error("Without should drop the type from the copy only")
+	}
+	if err := v.CheckType(""); err != nil {
+		t.Errorf("CheckType(\"\") = %v", err)
+	}
+	err := v.CheckType("quest")
+	if err == nil || !strings.Contains(err.Error(), "feature, bug, design, rfc, docs") {
+		t.Errorf("CheckType(quest) = %v", err)
+	}
+	if err := v.CheckEffort("epic"); err != nil {
+		t.Errorf("CheckEffort(epic) = %v", err)
+	}
+	if err := v.CheckEffort("huge"); err == nil {
+		t.Error("CheckEffort(huge) should fail")
+	}
+}
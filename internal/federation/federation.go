@@ -72,15 +72,83 @@ type Config struct {
 	// Signing enables GPG-signed Dolt commits when true.
 	Signing bool `json:"signing,omitempty"`
 
+	// ConfirmPush asks before each wild-west mutation is pushed to
+	// upstream, on top of any per-action confirmation.
+	ConfirmPush bool `json:"confirm_push,omitempty"`
+
+	// SigningKey is the GPG key ID signed commits use; empty uses dolt's
+	// configured user.signingkey.
+	SigningKey string `json:"signing_key,omitempty"`
+
+	// Profiles maps profile names to alternate rig identities selectable
+	// with --as or WL_PROFILE.
+	Profiles map[string]*RigProfile `json:"profiles,omitempty"`
+
+	// Profile is the profile UseProfile switched to for the current
+	// process; it is never saved.
+	Profile string `json:"-"`
+
+	// base holds the config's own identity while a profile is in use.
+	base *identity
+
+	// SQLServer serves the local clone from a managed dolt sql-server
+	// instead of spawning dolt per operation. Local backend only.
+	SQLServer bool `json:"sql_server,omitempty"`
+
+	// ReadOnly refuses every mutation (claims, posts, pushes, merges, PRs)
+	// for this wasteland.
+	ReadOnly bool `json:"read_only,omitempty"`
+
+	// ForceReadOnly is ReadOnly for the current process only (--read-only,
+	// WL_READ_ONLY); it is never saved.
+	ForceReadOnly bool `json:"-"`
+
+	// AllowSecrets lets the current process commit text that looks like a
+	// credential (--allow-secrets); it is never saved.
+	AllowSecrets bool `json:"-"`
+
 	// LastSyncAt records when the local clone was last synced with upstream.
 	LastSyncAt *time.Time `json:"last_sync_at,omitempty"`
 
+	// Defaults holds default browse filters applied when no flag overrides them.
+	Defaults *BrowseDefaults `json:"defaults,omitempty"`
+
+	// Locale selects the language for CLI and TUI labels (e.g., "de").
+	// WL_LOCALE overrides it; empty falls back to LC_ALL/LANG, then English.
+	Locale string `json:"locale,omitempty"`
+
+	// Hooks maps lifecycle events ("pre-claim", "post-done", ...) to shell
+	// commands run around mutations. See internal/hooks.
+	Hooks map[string]string `json:"hooks,omitempty"`
+
+	// Aliases maps user-defined command names to the wl command lines they
+	// expand to (e.g., "mine": "browse --claimed-by $RIG").
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Watched lists wanted IDs 'wl notify' reports on, on top of the
+	// items the rig posted or claimed.
+	Watched []string `json:"watched,omitempty"`
+
+	// DesktopNotify makes 'wl tui' pop desktop notifications when watched
+	// or own items change state.
+	DesktopNotify bool `json:"desktop_notify,omitempty"`
+
 	// GitHubRepo is the upstream GitHub repo for PR shells (e.g., "steveyegge/wl-commons").
 	//
 	// Deprecated: use ProviderType == "github" instead.
 	GitHubRepo string `json:"github_repo,omitempty"`
 }
 
+// BrowseDefaults holds per-wasteland default filters for 'wl browse'.
+// Zero values mean "no default".
+type BrowseDefaults struct {
+	Project  string `json:"project,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Priority *int   `json:"priority,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
 // ResolveMode returns the effective mode, defaulting to PR mode.
 func (c *Config) ResolveMode() string {
 	if c.Mode == "" || c.Mode == ModePR {
@@ -95,6 +163,12 @@ const (
 	BackendLocal  = "local"
 )
 
+// IsReadOnly reports whether mutations are refused, by config or for the
+// current process.
+func (c *Config) IsReadOnly() bool {
+	return c.ReadOnly || c.ForceReadOnly
+}
+
 // ResolveBackend returns the effective backend.
 // Explicit "local" or "remote" values are returned as-is.
 // When unset, defaults to "local" if LocalDir is configured (backward compat),
@@ -336,9 +410,12 @@ type CreateOptions struct {
 	Handle      string // rig handle for creator
 	DisplayName string
 	OwnerEmail  string
-	Version     string // wl version
-	SchemaSQL   string // DDL passed in by caller (avoids federation→schema import)
-	Name        string // optional wasteland display name for _meta
+	Version     string              // wl version
+	SchemaSQL   string              // DDL passed in by caller (avoids federation→schema import)
+	Schema      string              // schema template SchemaSQL came from, recorded in _meta
+	Name        string              // optional wasteland display name for _meta
+	SeedSQL     string              // optional sample data applied after the creator registers
+	Repo        *remote.RepoOptions // when set, create the upstream repository first
 	LocalOnly   bool
 	Signed      bool
 }
@@ -348,7 +425,7 @@ type CreateResult struct {
 	Config *Config
 }
 
-// Create orchestrates creating a new wasteland commons: init -> schema -> commit -> register -> push -> save config.
+// Create orchestrates creating a new wasteland commons: [create repo] -> init -> schema -> commit -> register -> seed -> push -> save config.
 func (s *Service) Create(opts CreateOptions) (*CreateResult, error) {
 	org, db, err := ParseUpstream(opts.Upstream)
 	if err != nil {
@@ -366,6 +443,17 @@ func (s *Service) Create(opts CreateOptions) (*CreateResult, error) {
 		progress = func(string) {}
 	}
 
+	if opts.Repo != nil && !opts.LocalOnly {
+		creator, ok := s.Remote.(remote.RepoCreator)
+		if !ok {
+			return nil, fmt.Errorf("the %s provider can't create repositories; create %s first", s.Remote.Type(), opts.Upstream)
+		}
+		progress("Creating upstream repository...")
+		if err := creator.CreateRepo(org, db, *opts.Repo); err != nil {
+			return nil, fmt.Errorf("creating upstream repository: %w", err)
+		}
+	}
+
 	progress("Initializing dolt database...")
 	if err := s.CLI.Init(localDir); err != nil {
 		return nil, fmt.Errorf("initializing database: %w", err)
@@ -376,6 +464,10 @@ func (s *Service) Create(opts CreateOptions) (*CreateResult, error) {
 		sqlScript += fmt.Sprintf("\nINSERT IGNORE INTO _meta (`key`, value) VALUES ('wasteland_name', '%s');\n",
 			escapeSQLString(opts.Name))
 	}
+	if opts.Schema != "" {
+		sqlScript += fmt.Sprintf("\nREPLACE INTO _meta (`key`, value) VALUES ('schema_template', '%s');\n",
+			escapeSQLString(opts.Schema))
+	}
 
 	progress("Applying commons schema...")
 	if err := s.CLI.SQLExec(localDir, sqlScript); err != nil {
@@ -392,6 +484,16 @@ func (s *Service) Create(opts CreateOptions) (*CreateResult, error) {
 		return nil, fmt.Errorf("registering rig: %w", err)
 	}
 
+	if opts.SeedSQL != "" {
+		progress("Seeding sample data...")
+		if err := s.CLI.SQLExec(localDir, opts.SeedSQL); err != nil {
+			return nil, fmt.Errorf("seeding: %w", err)
+		}
+		if err := s.CLI.StageAndCommit(localDir, "Seed sample data", opts.Signed); err != nil {
+			return nil, fmt.Errorf("committing seed data: %w", err)
+		}
+	}
+
 	if !opts.LocalOnly {
 		remoteURL := s.Remote.DatabaseURL(org, db)
 
@@ -746,7 +848,7 @@ func (f *fileConfigStore) Save(cfg *Config) error {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
 	}
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	data, err := json.MarshalIndent(cfg.persisted(), "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling wasteland config: %w", err)
 	}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+
+	"github.com/gastownhall/wasteland/internal/remote"
 )
 
 // CallLog is a shared ordered log for recording cross-component call sequences.
@@ -25,18 +27,21 @@ func (l *CallLog) Record(call string) {
 // FakeProvider is a test double for remote.Provider.
 type FakeProvider struct {
 	mu          sync.Mutex
-	Forked      map[string]bool // "fromOrg/fromDB->toOrg" => true
-	PRs         []string        // URLs of created PRs
+	Forked      map[string]bool               // "fromOrg/fromDB->toOrg" => true
+	Repos       map[string]remote.RepoOptions // "org/db" => options, from CreateRepo
+	PRs         []string                      // URLs of created PRs
 	Calls       []string
 	Log         *CallLog // shared ordered log (optional)
 	ForkErr     error
 	CreatePRErr error
+	RepoErr     error
 	BaseURL     string // returned by DatabaseURL (default "https://fake-remote")
 }
 
 func NewFakeProvider() *FakeProvider {
 	return &FakeProvider{
 		Forked:  make(map[string]bool),
+		Repos:   make(map[string]remote.RepoOptions),
 		BaseURL: "https://fake-remote",
 	}
 }
@@ -76,6 +81,21 @@ func (f *FakeProvider) CreatePR(forkOrg, upstreamOrg, db, fromBranch, title, _ s
 	return url, nil
 }
 
+func (f *FakeProvider) CreateRepo(org, db string, opts remote.RepoOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	call := fmt.Sprintf("CreateRepo(%s, %s)", org, db)
+	f.Calls = append(f.Calls, call)
+	if f.Log != nil {
+		f.Log.Record(call)
+	}
+	if f.RepoErr != nil {
+		return f.RepoErr
+	}
+	f.Repos[org+"/"+db] = opts
+	return nil
+}
+
 func (f *FakeProvider) Type() string { return "fake" }
 
 // FakeDoltCLI is a test double for DoltCLI.
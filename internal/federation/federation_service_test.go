@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/gastownhall/wasteland/internal/remote"
 )
 
 func TestJoin_Success(t *testing.T) {
@@ -433,6 +435,117 @@ func TestCreate_WithName(t *testing.T) {
 	}
 }
 
+func TestCreate_RecordsSchemaTemplate(t *testing.T) {
+	t.Parallel()
+	cli := NewFakeDoltCLI()
+
+	svc := &Service{Remote: NewFakeProvider(), CLI: cli, Config: NewFakeConfigStore()}
+
+	_, err := svc.Create(CreateOptions{
+		Upstream:  "myorg/wl-commons",
+		Handle:    "myrig",
+		SchemaSQL: "CREATE TABLE test (id INT PRIMARY KEY);",
+		Schema:    "minimal",
+		LocalOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if len(cli.SQLExecs) != 1 {
+		t.Fatalf("expected 1 SQL exec, got %d", len(cli.SQLExecs))
+	}
+	if !strings.Contains(cli.SQLExecs[0], "('schema_template', 'minimal')") {
+		t.Errorf("SQL = %q, want the schema template recorded in _meta", cli.SQLExecs[0])
+	}
+}
+
+func TestCreate_Seed(t *testing.T) {
+	t.Parallel()
+	log := NewCallLog()
+	cli := NewFakeDoltCLI()
+	cli.Log = log
+
+	svc := &Service{Remote: NewFakeProvider(), CLI: cli, Config: NewFakeConfigStore()}
+
+	_, err := svc.Create(CreateOptions{
+		Upstream:  "myorg/wl-commons",
+		Handle:    "myrig",
+		SchemaSQL: "CREATE TABLE test (id INT PRIMARY KEY);",
+		SeedSQL:   "INSERT INTO test VALUES (1);",
+		LocalOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+
+	expectedOrder := []string{"Init", "SQLExec", "StageAndCommit", "RegisterRig", "SQLExec", "StageAndCommit"}
+	if len(log.Calls) != len(expectedOrder) {
+		t.Fatalf("expected %d calls, got %d: %v", len(expectedOrder), len(log.Calls), log.Calls)
+	}
+	for i, want := range expectedOrder {
+		if !strings.HasPrefix(log.Calls[i], want) {
+			t.Errorf("log[%d] = %q, want prefix %q", i, log.Calls[i], want)
+		}
+	}
+	if len(cli.SQLExecs) != 2 || cli.SQLExecs[1] != "INSERT INTO test VALUES (1);" {
+		t.Errorf("SQLExecs = %v, want the seed applied second", cli.SQLExecs)
+	}
+}
+
+func TestCreate_CreatesUpstreamRepo(t *testing.T) {
+	t.Parallel()
+	log := NewCallLog()
+	provider := NewFakeProvider()
+	provider.Log = log
+	cli := NewFakeDoltCLI()
+	cli.Log = log
+
+	svc := &Service{Remote: provider, CLI: cli, Config: NewFakeConfigStore()}
+
+	_, err := svc.Create(CreateOptions{
+		Upstream:  "myorg/wl-commons",
+		Handle:    "myrig",
+		SchemaSQL: "CREATE TABLE test (id INT PRIMARY KEY);",
+		Repo:      &remote.RepoOptions{Description: "Our wanted board", Private: true},
+	})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if len(log.Calls) == 0 || log.Calls[0] != "CreateRepo(myorg, wl-commons)" {
+		t.Errorf("calls = %v, want the repository created first", log.Calls)
+	}
+	if got := provider.Repos["myorg/wl-commons"]; got.Description != "Our wanted board" || !got.Private {
+		t.Errorf("repo options = %+v", got)
+	}
+}
+
+func TestCreate_CreateRepoErrors(t *testing.T) {
+	t.Parallel()
+	opts := CreateOptions{
+		Upstream:  "myorg/wl-commons",
+		Handle:    "myrig",
+		SchemaSQL: "CREATE TABLE test (id INT PRIMARY KEY);",
+		Repo:      &remote.RepoOptions{},
+	}
+
+	provider := NewFakeProvider()
+	provider.RepoErr = errors.New("HTTP 401")
+	cli := NewFakeDoltCLI()
+	svc := &Service{Remote: provider, CLI: cli, Config: NewFakeConfigStore()}
+	if _, err := svc.Create(opts); err == nil || !strings.Contains(err.Error(), "creating upstream repository") {
+		t.Errorf("Create() error = %v, want repository error", err)
+	}
+	if len(cli.Calls) != 0 {
+		t.Errorf("nothing should happen locally after a failed repo create, calls = %v", cli.Calls)
+	}
+
+	// A provider without RepoCreator can't honor the request.
+	svc = &Service{Remote: struct{ remote.Provider }{NewFakeProvider()}, CLI: NewFakeDoltCLI(), Config: NewFakeConfigStore()}
+	if _, err := svc.Create(opts); err == nil || !strings.Contains(err.Error(), "can't create repositories") {
+		t.Errorf("Create() error = %v, want unsupported provider", err)
+	}
+}
+
 func TestCreate_AlreadyExists(t *testing.T) {
 	t.Parallel()
 	cli := NewFakeDoltCLI()
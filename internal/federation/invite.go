@@ -0,0 +1,134 @@
+package federation
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// InviteScheme is the URL scheme of wasteland invites.
+const InviteScheme = "wl+invite"
+
+// Invite is everything a newcomer needs to join a wasteland, packed into a
+// wl+invite:// URL by 'wl invite' and unpacked by 'wl join':
+//
+//	wl+invite://<org>/<db>?provider=<type>&mode=<mode>[&base=<dir>]
+type Invite struct {
+	// Upstream is the upstream commons path ("org/db").
+	Upstream string
+
+	// ProviderType is the upstream provider ("dolthub", "github", "file", "git").
+	ProviderType string
+
+	// Mode is the workflow mode the wasteland suggests ("pr" or "wild-west").
+	Mode string
+
+	// Base is the remote base directory for the file and git providers,
+	// whose remotes live on a shared filesystem rather than a hosted service.
+	Base string
+}
+
+// IsInviteURL reports whether s looks like a wl+invite:// URL.
+func IsInviteURL(s string) bool {
+	return strings.HasPrefix(strings.ToLower(s), InviteScheme+"://")
+}
+
+// NewInvite builds an invite for the wasteland cfg belongs to. An empty
+// mode suggests the wasteland's current mode.
+func NewInvite(cfg *Config, mode string) (*Invite, error) {
+	if mode == "" {
+		mode = cfg.ResolveMode()
+	}
+	inv := &Invite{
+		Upstream:     cfg.Upstream,
+		ProviderType: cfg.ResolveProviderType(),
+		Mode:         mode,
+	}
+	if inv.ProviderType == "file" || inv.ProviderType == "git" {
+		base, err := remoteBaseDir(cfg.UpstreamURL, cfg.Upstream)
+		if err != nil {
+			return nil, err
+		}
+		inv.Base = base
+	}
+	if err := inv.validate(); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// ParseInvite parses and validates a wl+invite:// URL.
+func ParseInvite(raw string) (*Invite, error) {
+	if !IsInviteURL(raw) {
+		return nil, fmt.Errorf("invalid invite %q: expected a %s:// URL", raw, InviteScheme)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invite: %w", err)
+	}
+	q := u.Query()
+	inv := &Invite{
+		Upstream:     u.Host + strings.TrimSuffix(u.Path, "/"),
+		ProviderType: q.Get("provider"),
+		Mode:         q.Get("mode"),
+		Base:         q.Get("base"),
+	}
+	if inv.ProviderType == "" {
+		inv.ProviderType = "dolthub"
+	}
+	if inv.Mode == "" {
+		inv.Mode = ModePR
+	}
+	if err := inv.validate(); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// URL returns the invite as a wl+invite:// URL.
+func (i *Invite) URL() string {
+	org, db, _ := strings.Cut(i.Upstream, "/")
+	q := url.Values{}
+	q.Set("provider", i.ProviderType)
+	q.Set("mode", i.Mode)
+	if i.Base != "" {
+		q.Set("base", i.Base)
+	}
+	u := url.URL{Scheme: InviteScheme, Host: org, Path: "/" + db, RawQuery: q.Encode()}
+	return u.String()
+}
+
+func (i *Invite) validate() error {
+	if _, _, err := ParseUpstream(i.Upstream); err != nil {
+		return fmt.Errorf("invalid invite: %w", err)
+	}
+	switch i.ProviderType {
+	case "dolthub", "github":
+	case "file", "git":
+		if i.Base == "" {
+			return fmt.Errorf("invalid invite: %s provider requires a base directory", i.ProviderType)
+		}
+	default:
+		return fmt.Errorf("invalid invite: unknown provider %q", i.ProviderType)
+	}
+	if i.Mode != ModePR && i.Mode != ModeWildWest {
+		return fmt.Errorf("invalid invite: unknown mode %q (must be %s or %s)", i.Mode, ModePR, ModeWildWest)
+	}
+	return nil
+}
+
+// remoteBaseDir recovers the --remote-base/--git-remote directory from a
+// file:// upstream URL of the form file://<base>/<org>/<db>[.git].
+func remoteBaseDir(upstreamURL, upstream string) (string, error) {
+	path, ok := strings.CutPrefix(upstreamURL, "file://")
+	if !ok {
+		return "", fmt.Errorf("cannot derive remote base from upstream URL %q", upstreamURL)
+	}
+	path = strings.TrimSuffix(filepath.Clean(path), ".git")
+	base, ok := strings.CutSuffix(path, string(filepath.Separator)+filepath.FromSlash(upstream))
+	if !ok || base == "" {
+		return "", fmt.Errorf("cannot derive remote base from upstream URL %q", upstreamURL)
+	}
+	return base, nil
+}
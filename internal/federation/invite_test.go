@@ -0,0 +1,86 @@
+package federation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInvite_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		inv  Invite
+	}{
+		{"dolthub", Invite{Upstream: "hop/wl-commons", ProviderType: "dolthub", Mode: ModePR}},
+		{"github wild-west", Invite{Upstream: "acme/board", ProviderType: "github", Mode: ModeWildWest}},
+		{"file with base", Invite{Upstream: "acme/board", ProviderType: "file", Mode: ModePR, Base: "/srv/wasteland remotes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := tt.inv.URL()
+			if !IsInviteURL(raw) {
+				t.Fatalf("URL() = %q, not an invite URL", raw)
+			}
+			got, err := ParseInvite(raw)
+			if err != nil {
+				t.Fatalf("ParseInvite(%q): %v", raw, err)
+			}
+			if *got != tt.inv {
+				t.Errorf("round trip = %+v, want %+v", *got, tt.inv)
+			}
+		})
+	}
+}
+
+func TestParseInvite_Defaults(t *testing.T) {
+	inv, err := ParseInvite("wl+invite://hop/wl-commons")
+	if err != nil {
+		t.Fatalf("ParseInvite: %v", err)
+	}
+	if inv.Upstream != "hop/wl-commons" || inv.ProviderType != "dolthub" || inv.Mode != ModePR {
+		t.Errorf("invite = %+v", inv)
+	}
+}
+
+func TestParseInvite_Invalid(t *testing.T) {
+	tests := []struct {
+		raw, wantErr string
+	}{
+		{"hop/wl-commons", "expected a wl+invite:// URL"},
+		{"wl+invite://hop", "invalid upstream path"},
+		{"wl+invite://hop/wl-commons?provider=ftp", "unknown provider"},
+		{"wl+invite://hop/wl-commons?mode=anarchy", "unknown mode"},
+		{"wl+invite://hop/wl-commons?provider=git", "requires a base directory"},
+	}
+	for _, tt := range tests {
+		_, err := ParseInvite(tt.raw)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseInvite(%q) error = %v, want containing %q", tt.raw, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewInvite(t *testing.T) {
+	cfg := &Config{Upstream: "acme/board", ProviderType: "git", Mode: ModeWildWest, UpstreamURL: "file:///srv/remotes/acme/board.git"}
+	inv, err := NewInvite(cfg, "")
+	if err != nil {
+		t.Fatalf("NewInvite: %v", err)
+	}
+	if inv.Mode != ModeWildWest || inv.Base != "/srv/remotes" || inv.ProviderType != "git" {
+		t.Errorf("invite = %+v", inv)
+	}
+
+	inv, err = NewInvite(&Config{Upstream: "hop/wl-commons"}, ModeWildWest)
+	if err != nil {
+		t.Fatalf("NewInvite: %v", err)
+	}
+	if inv.ProviderType != "dolthub" || inv.Mode != ModeWildWest || inv.Base != "" {
+		t.Errorf("dolthub invite = %+v", inv)
+	}
+
+	if _, err := NewInvite(&Config{Upstream: "hop/wl-commons"}, "chaos"); err == nil {
+		t.Error("NewInvite with an unknown mode should fail")
+	}
+	if _, err := NewInvite(&Config{Upstream: "acme/board", ProviderType: "file", UpstreamURL: "https://example.com/x"}, ""); err == nil {
+		t.Error("NewInvite should fail when the file remote base can't be derived")
+	}
+}
@@ -0,0 +1,77 @@
+package federation
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownProfile indicates --as or WL_PROFILE named a profile the
+// wasteland's config doesn't define.
+var ErrUnknownProfile = errors.New("unknown rig profile")
+
+// RigProfile is an alternate rig identity for a wasteland, for when
+// several rigs (say, a human and an agent) share one machine and clone.
+// Selecting it with --as or WL_PROFILE replaces the config's rig handle,
+// HOP URI and signing key for that process.
+type RigProfile struct {
+	// Handle is the rig handle the profile posts, claims and branches as.
+	Handle string `json:"handle"`
+
+	// HopURI is the profile's HOP protocol URI; empty keeps the config's.
+	HopURI string `json:"hop_uri,omitempty"`
+
+	// SigningKey is the GPG key ID signed commits use; empty keeps the
+	// config's.
+	SigningKey string `json:"signing_key,omitempty"`
+}
+
+// identity is the part of a Config a profile overrides.
+type identity struct {
+	rigHandle, hopURI, signingKey string
+}
+
+// UseProfile switches c to the named profile's identity. An empty name is
+// a no-op. The config's own identity is kept aside so saving c doesn't
+// persist the profile's.
+func (c *Config) UseProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		known := "none are defined"
+		if len(c.Profiles) > 0 {
+			known = "known: " + strings.Join(slices.Sorted(maps.Keys(c.Profiles)), ", ")
+		}
+		return fmt.Errorf("%w %q for %s (%s)", ErrUnknownProfile, name, c.Upstream, known)
+	}
+	if c.base == nil {
+		c.base = &identity{rigHandle: c.RigHandle, hopURI: c.HopURI, signingKey: c.SigningKey}
+	}
+	c.RigHandle = p.Handle
+	c.HopURI = c.base.hopURI
+	if p.HopURI != "" {
+		c.HopURI = p.HopURI
+	}
+	c.SigningKey = c.base.signingKey
+	if p.SigningKey != "" {
+		c.SigningKey = p.SigningKey
+	}
+	c.Profile = name
+	return nil
+}
+
+// persisted returns c as it should be saved: with the config's own
+// identity rather than an active profile's.
+func (c *Config) persisted() *Config {
+	if c.base == nil {
+		return c
+	}
+	saved := *c
+	saved.RigHandle, saved.HopURI, saved.SigningKey = c.base.rigHandle, c.base.hopURI, c.base.signingKey
+	saved.Profile, saved.base = "", nil
+	return &saved
+}
@@ -0,0 +1,75 @@
+package federation
+
+import (
+	"errors"
+	"testing"
+)
+
+func profileConfig() *Config {
+	return &Config{
+		Upstream:   "hop/wl-commons",
+		ForkOrg:    "alice",
+		ForkDB:     "wl-commons",
+		RigHandle:  "alice",
+		HopURI:     "hop://alice@example.com/alice/",
+		SigningKey: "AAAA",
+		Profiles: map[string]*RigProfile{
+			"agent": {Handle: "alice-bot", SigningKey: "BBBB"},
+		},
+	}
+}
+
+func TestUseProfile(t *testing.T) {
+	cfg := profileConfig()
+	if err := cfg.UseProfile("agent"); err != nil {
+		t.Fatalf("UseProfile() error: %v", err)
+	}
+	if cfg.RigHandle != "alice-bot" || cfg.SigningKey != "BBBB" || cfg.Profile != "agent" {
+		t.Errorf("identity = %q/%q/%q, want alice-bot/BBBB/agent", cfg.RigHandle, cfg.SigningKey, cfg.Profile)
+	}
+	if cfg.HopURI != "hop://alice@example.com/alice/" {
+		t.Errorf("HopURI = %q, want the config's when the profile has none", cfg.HopURI)
+	}
+
+	if err := cfg.UseProfile(""); err != nil || cfg.RigHandle != "alice-bot" {
+		t.Errorf("UseProfile(\"\") should be a no-op, got %q, %v", cfg.RigHandle, err)
+	}
+}
+
+func TestUseProfile_Unknown(t *testing.T) {
+	cfg := profileConfig()
+	err := cfg.UseProfile("ghost")
+	if !errors.Is(err, ErrUnknownProfile) {
+		t.Fatalf("UseProfile(ghost) error = %v, want ErrUnknownProfile", err)
+	}
+	if cfg.RigHandle != "alice" {
+		t.Errorf("failed UseProfile changed RigHandle to %q", cfg.RigHandle)
+	}
+}
+
+func TestSave_KeepsOwnIdentityUnderProfile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	store := NewConfigStore()
+	cfg := profileConfig()
+	if err := cfg.UseProfile("agent"); err != nil {
+		t.Fatalf("UseProfile() error: %v", err)
+	}
+	cfg.Mode = ModeWildWest
+	if err := store.Save(cfg); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got, err := store.Load(cfg.Upstream)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got.RigHandle != "alice" || got.SigningKey != "AAAA" || got.Profile != "" {
+		t.Errorf("saved identity = %q/%q/%q, want alice/AAAA/\"\"", got.RigHandle, got.SigningKey, got.Profile)
+	}
+	if got.Mode != ModeWildWest {
+		t.Errorf("saved Mode = %q, want other changes kept", got.Mode)
+	}
+	if cfg.RigHandle != "alice-bot" {
+		t.Errorf("Save() reset the in-memory profile identity to %q", cfg.RigHandle)
+	}
+}
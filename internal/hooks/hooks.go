@@ -0,0 +1,88 @@
+// Package hooks runs user-configured lifecycle hook commands.
+//
+// A hook is a shell command bound to an event such as "pre-claim". It runs
+// with the event's JSON payload on stdin and WL_HOOK_EVENT in its
+// environment. A non-zero exit from a pre-* hook aborts the mutation.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Lifecycle events that can carry a hook.
+const (
+	PreClaim   = "pre-claim"
+	PostClaim  = "post-claim"
+	PreDone    = "pre-done"
+	PostDone   = "post-done"
+	PreAccept  = "pre-accept"
+	PostAccept = "post-accept"
+)
+
+// Events lists every supported event in display order.
+var Events = []string{PreClaim, PostClaim, PreDone, PostDone, PreAccept, PostAccept}
+
+// DefaultTimeout bounds how long a single hook may run.
+const DefaultTimeout = 60 * time.Second
+
+// Valid reports whether event is a supported hook event.
+func Valid(event string) bool {
+	return slices.Contains(Events, event)
+}
+
+// Runner executes hook commands via "sh -c".
+type Runner struct {
+	Commands map[string]string // event → shell command
+	Dir      string            // working directory; "" = current
+	Output   io.Writer         // receives hook stdout and stderr; nil = discard
+	Timeout  time.Duration     // per-hook limit; 0 = DefaultTimeout
+}
+
+// Has reports whether a command is configured for event.
+func (r *Runner) Has(event string) bool {
+	return r != nil && strings.TrimSpace(r.Commands[event]) != ""
+}
+
+// Run executes the hook for event with payload on stdin. It is a no-op when
+// no hook is configured.
+func (r *Runner) Run(event string, payload []byte) error {
+	if !r.Has(event) {
+		return nil
+	}
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", r.Commands[event])
+	cmd.Dir = r.Dir
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "WL_HOOK_EVENT="+event)
+	out := r.Output
+	if out == nil {
+		out = io.Discard
+	}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	// Don't let a backgrounded grandchild holding the output pipe keep us
+	// waiting past the timeout.
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s hook timed out after %s", event, timeout)
+		}
+		return fmt.Errorf("%s hook failed: %w", event, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunner_PassesPayloadAndEvent(t *testing.T) {
+	t.Parallel()
+	out := filepath.Join(t.TempDir(), "out")
+	r := &Runner{Commands: map[string]string{
+		PreClaim: `cat > "` + out + `"; echo "$WL_HOOK_EVENT" >> "` + out + `"`,
+	}}
+
+	if err := r.Run(PreClaim, []byte(`{"wanted_id":"w-1"}`)); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "{\"wanted_id\":\"w-1\"}pre-claim\n" {
+		t.Errorf("hook saw %q", got)
+	}
+}
+
+func TestRunner_NonZeroExit(t *testing.T) {
+	t.Parallel()
+	var output bytes.Buffer
+	r := &Runner{Commands: map[string]string{PreDone: "echo denied by policy; exit 3"}, Output: &output}
+
+	err := r.Run(PreDone, nil)
+	if err == nil || !strings.Contains(err.Error(), "pre-done hook failed") {
+		t.Fatalf("expected hook failure, got: %v", err)
+	}
+	if !strings.Contains(output.String(), "denied by policy") {
+		t.Errorf("hook output not forwarded: %q", output.String())
+	}
+}
+
+func TestRunner_Unconfigured(t *testing.T) {
+	t.Parallel()
+	var r *Runner
+	if r.Has(PreClaim) {
+		t.Error("nil runner should have no hooks")
+	}
+	if err := r.Run(PreClaim, nil); err != nil {
+		t.Errorf("nil runner Run = %v, want nil", err)
+	}
+	r = &Runner{Commands: map[string]string{PostAccept: "  "}}
+	if r.Has(PostAccept) {
+		t.Error("blank command should count as unconfigured")
+	}
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	t.Parallel()
+	r := &Runner{Commands: map[string]string{PreAccept: "sleep 5"}, Timeout: 50 * time.Millisecond}
+	err := r.Run(PreAccept, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got: %v", err)
+	}
+}
+
+func TestValid(t *testing.T) {
+	t.Parallel()
+	if !Valid("post-done") || Valid("pre-delete") {
+		t.Error("Valid mismatch")
+	}
+}
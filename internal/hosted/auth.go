@@ -67,7 +67,7 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		// Read and verify session cookie.
-		sessionID, connectionID, ok := ReadSessionCookie(r, s.sessionSecret)
+		sessionID, connectionID, issuedAt, ok := ReadSessionCookie(r, s.sessionSecret)
 		if !ok {
 			passOrBlock(w, r, http.StatusUnauthorized, "not authenticated")
 			return
@@ -76,9 +76,10 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 		session, ok := s.sessions.Get(sessionID)
 		if !ok {
 			// Session not in memory — try to re-hydrate from Nango.
-			if connectionID == "" {
-				// Old-format cookie without connectionID — can't re-hydrate.
-				slog.Warn("auth: session expired (no connection_id)", "path", r.URL.Path)
+			if connectionID == "" || issuedAt.IsZero() {
+				// Old-format cookie without connectionID or issued-at time —
+				// can't re-hydrate without knowing the session's age.
+				slog.Warn("auth: session expired (old-format cookie)", "path", r.URL.Path)
 				passOrBlock(w, r, http.StatusUnauthorized, "session expired")
 				return
 			}
@@ -88,7 +89,12 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 				passOrBlock(w, r, http.StatusUnauthorized, "session expired")
 				return
 			}
-			s.sessions.Restore(sessionID, connectionID)
+			if !s.sessions.Restore(sessionID, connectionID, issuedAt) {
+				// Revoked (rotated or logged out) or past its max age.
+				slog.Warn("auth: session expired (revoked or past max age)", "path", r.URL.Path)
+				passOrBlock(w, r, http.StatusUnauthorized, "session expired")
+				return
+			}
 			session, ok = s.sessions.Get(sessionID)
 			if !ok {
 				passOrBlock(w, r, http.StatusUnauthorized, "session restoration failed")
@@ -101,6 +107,14 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// A connection that has used up its daily DoltHub quota gets no
+		// further work, reads included: they would spend the same budget.
+		if usage := s.resolver.usage.Usage(session.ConnectionID); usage.Exceeded() {
+			slog.Warn("auth: daily quota exceeded", "connection_id", session.ConnectionID, "used", usage.Used, "path", r.URL.Path)
+			writeQuotaExceeded(w, usage)
+			return
+		}
+
 		// Resolve the per-user Workspace.
 		workspace, err := s.resolver.Resolve(session)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 const testSecret = "test-session-secret"
@@ -87,6 +88,7 @@ func setupHostedTestServer(t *testing.T) (*SessionStore, *httptest.Server) {
 	mux.HandleFunc("POST /api/auth/connect-session", server.handleConnectSession)
 	mux.HandleFunc("POST /api/auth/join", server.handleJoin)
 	mux.HandleFunc("DELETE /api/auth/wastelands/{upstream...}", server.handleLeaveWasteland)
+	mux.HandleFunc("PUT /api/auth/wastelands/{org}/{db}/settings", server.handleWastelandSettings)
 	mux.Handle("/", server.AuthMiddleware(inner))
 
 	ts := httptest.NewServer(mux)
@@ -198,7 +200,7 @@ func TestAuthMiddleware_ValidSession_SingleWasteland(t *testing.T) {
 	req, _ := http.NewRequest("GET", ts.URL+"/api/wanted", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -221,7 +223,7 @@ func TestAuthMiddleware_ValidSession_WithHeader(t *testing.T) {
 	req, _ := http.NewRequest("GET", ts.URL+"/api/wanted", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 	req.Header.Set("X-Wasteland", "wasteland/wl-commons")
 
@@ -246,7 +248,7 @@ func TestAuthMiddleware_MultiWasteland_NoHeader_Returns400(t *testing.T) {
 	req, _ := http.NewRequest("POST", ts.URL+"/api/wanted", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -269,7 +271,7 @@ func TestAuthMiddleware_MultiWasteland_WithHeader(t *testing.T) {
 	req, _ := http.NewRequest("GET", ts.URL+"/api/wanted", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 	req.Header.Set("X-Wasteland", "hop/wl-commons")
 
@@ -294,7 +296,7 @@ func TestAuthMiddleware_UnknownUpstream(t *testing.T) {
 	req, _ := http.NewRequest("POST", ts.URL+"/api/wanted", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 	req.Header.Set("X-Wasteland", "nonexistent/repo")
 
@@ -419,6 +421,40 @@ func TestHandleConnect(t *testing.T) {
 	}
 }
 
+func TestHandleConnect_DropsPriorSession(t *testing.T) {
+	sessions, ts := setupHostedTestServer(t)
+
+	priorID, _ := sessions.Create("conn-old")
+
+	body := `{
+		"connection_id": "conn-1",
+		"rig_handle": "alice",
+		"fork_org": "alice-org",
+		"fork_db": "wl-commons",
+		"upstream": "wasteland/wl-commons"
+	}`
+	req, _ := http.NewRequest("POST", ts.URL+"/api/auth/connect", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{
+		Name:  cookieName,
+		Value: SignSessionCookie(priorID, "conn-old", time.Now(), testSecret),
+	})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, string(respBody))
+	}
+	if _, ok := sessions.Get(priorID); ok {
+		t.Error("expected prior session to be dropped on connect")
+	}
+}
+
 func TestHandleConnect_MissingFields(t *testing.T) {
 	_, ts := setupHostedTestServer(t)
 
@@ -458,7 +494,7 @@ func TestHandleAuthStatus_Authenticated(t *testing.T) {
 	req, _ := http.NewRequest("GET", ts.URL+"/api/auth/status", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -494,7 +530,7 @@ func TestHandleLogout(t *testing.T) {
 	req, _ := http.NewRequest("POST", ts.URL+"/api/auth/logout", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -568,7 +604,7 @@ func TestHandleJoinWasteland(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -587,6 +623,23 @@ func TestHandleJoinWasteland(t *testing.T) {
 	if result["status"] != "joined" {
 		t.Errorf("expected status=joined, got %s", result["status"])
 	}
+
+	// Joining rotates the session: the old ID is dead, the cookie carries a new one.
+	if _, ok := sessions.Get(sessionID); ok {
+		t.Error("expected old session ID to be invalidated")
+	}
+	var rotated string
+	for _, c := range resp.Cookies() {
+		if c.Name == cookieName {
+			rotated, _, _, _ = VerifySessionCookie(c.Value, testSecret)
+		}
+	}
+	if rotated == "" || rotated == sessionID {
+		t.Fatalf("expected a rotated session cookie, got %q", rotated)
+	}
+	if sess, ok := sessions.Get(rotated); !ok || sess.ConnectionID != "conn-1" {
+		t.Errorf("rotated session = %+v, %v; want conn-1", sess, ok)
+	}
 }
 
 func TestHandleJoinWasteland_MissingFields(t *testing.T) {
@@ -599,7 +652,7 @@ func TestHandleJoinWasteland_MissingFields(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -674,7 +727,7 @@ func TestHandleLeaveWasteland(t *testing.T) {
 	req, _ := http.NewRequest("DELETE", ts.URL+"/api/auth/wastelands/hop/wl-commons", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -698,7 +751,7 @@ func TestHandleLeaveWasteland_CannotRemoveLast(t *testing.T) {
 	req, _ := http.NewRequest("DELETE", ts.URL+"/api/auth/wastelands/wasteland/wl-commons", nil)
 	req.AddCookie(&http.Cookie{
 		Name:  cookieName,
-		Value: SignSessionCookie(sessionID, "conn-1", testSecret),
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
 	})
 
 	resp, err := http.DefaultClient.Do(req)
@@ -713,13 +766,138 @@ func TestHandleLeaveWasteland_CannotRemoveLast(t *testing.T) {
 	}
 }
 
+func TestHandleWastelandSettings(t *testing.T) {
+	meta := &UserMetadata{
+		RigHandle: "alice",
+		Wastelands: []WastelandConfig{
+			{Upstream: "hop/wl-commons", ForkOrg: "alice-org", ForkDB: "wl-commons", Mode: "wild-west"},
+			{Upstream: "gastownhall/gascity", ForkOrg: "alice-org", ForkDB: "gascity", Mode: "pr"},
+		},
+	}
+
+	var patched UserMetadata
+	nangoTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/connection/conn-1":
+			resp := nangoConnectionResponse{ConnectionID: "conn-1"}
+			resp.Credentials.APIKey = "test-token"
+			b, _ := json.Marshal(meta)
+			resp.Metadata = json.RawMessage(b)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+		case r.Method == "PATCH" && strings.HasPrefix(r.URL.Path, "/connection/"):
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(nangoTS.Close)
+
+	nango := NewNangoClient(NangoConfig{
+		BaseURL:       nangoTS.URL,
+		SecretKey:     "nango-secret",
+		IntegrationID: "dolthub",
+	})
+	sessions := NewSessionStore()
+	resolver := NewWorkspaceResolver(nango, sessions)
+	server := NewServer(resolver, sessions, nango, testSecret, "")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/auth/wastelands/{org}/{db}/settings", server.handleWastelandSettings)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	sessionID, _ := sessions.Create("conn-1")
+
+	req, _ := http.NewRequest("PUT", ts.URL+"/api/auth/wastelands/gastownhall/gascity/settings",
+		strings.NewReader(`{"mode":"wild-west"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{
+		Name:  cookieName,
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
+	})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, string(body))
+	}
+
+	var got WastelandConfig
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Upstream != "gastownhall/gascity" || got.Mode != "wild-west" {
+		t.Errorf("response = %+v, want gascity in wild-west mode", got)
+	}
+
+	wl := patched.FindWasteland("gastownhall/gascity")
+	if wl == nil || wl.Mode != "wild-west" || wl.ForkDB != "gascity" {
+		t.Errorf("patched gascity = %+v, want mode wild-west with fork preserved", wl)
+	}
+	if other := patched.FindWasteland("hop/wl-commons"); other == nil || other.Mode != "wild-west" {
+		t.Errorf("patched wl-commons = %+v, want unchanged", other)
+	}
+}
+
+func TestHandleWastelandSettings_Errors(t *testing.T) {
+	sessions, ts := setupHostedTestServer(t)
+
+	sessionID, _ := sessions.Create("conn-1")
+	cookie := &http.Cookie{
+		Name:  cookieName,
+		Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret),
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		cookie bool
+		want   int
+	}{
+		{"not authenticated", "/api/auth/wastelands/wasteland/wl-commons/settings", `{"mode":"pr"}`, false, http.StatusUnauthorized},
+		{"invalid mode", "/api/auth/wastelands/wasteland/wl-commons/settings", `{"mode":"yolo"}`, true, http.StatusBadRequest},
+		{"no settings", "/api/auth/wastelands/wasteland/wl-commons/settings", `{}`, true, http.StatusBadRequest},
+		{"not joined", "/api/auth/wastelands/other/db/settings", `{"mode":"pr"}`, true, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("PUT", ts.URL+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.cookie {
+				req.AddCookie(cookie)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+			if resp.StatusCode != tt.want {
+				body, _ := io.ReadAll(resp.Body)
+				t.Errorf("expected %d, got %d: %s", tt.want, resp.StatusCode, string(body))
+			}
+		})
+	}
+}
+
 func TestAuthMiddleware_RehydrateAfterRestart(t *testing.T) {
 	sessions, ts := setupHostedTestServer(t)
 
-	// Create session, get the cookie, then delete from store (simulating restart).
+	// Create session, get the cookie, then drop it from the store (simulating restart).
 	sessionID, _ := sessions.Create("conn-1")
-	signed := SignSessionCookie(sessionID, "conn-1", testSecret)
-	sessions.Delete(sessionID)
+	signed := SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)
+	sessions.mu.Lock()
+	delete(sessions.sessions, sessionID)
+	sessions.mu.Unlock()
 
 	// Verify session is gone from store.
 	if _, ok := sessions.Get(sessionID); ok {
@@ -751,6 +929,49 @@ func TestAuthMiddleware_RehydrateAfterRestart(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_RevokedSessionNotRehydrated(t *testing.T) {
+	sessions, ts := setupHostedTestServer(t)
+
+	// A logged-out session's cookie is still validly signed and its Nango
+	// connection is live, but the ID must not come back.
+	sessionID, _ := sessions.Create("conn-1")
+	signed := SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)
+	sessions.Delete(sessionID)
+
+	req, _ := http.NewRequest("POST", ts.URL+"/api/wanted", nil)
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: signed})
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a revoked session, got %d", resp.StatusCode)
+	}
+	if _, ok := sessions.Get(sessionID); ok {
+		t.Error("expected revoked session to stay gone")
+	}
+}
+
+func TestAuthMiddleware_ExpiredCookieNotRehydrated(t *testing.T) {
+	_, ts := setupHostedTestServer(t)
+
+	// A cookie issued longer ago than sessionMaxAge can't restore a session.
+	signed := SignSessionCookie("sess-stale", "conn-1", time.Now().Add(-sessionMaxAge-time.Hour), testSecret)
+	req, _ := http.NewRequest("POST", ts.URL+"/api/wanted", nil)
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: signed})
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a cookie past its max age, got %d", resp.StatusCode)
+	}
+}
+
 func TestAuthMiddleware_RehydrateFails_InvalidConnection(t *testing.T) {
 	// Set up a Nango server that returns 404 for unknown connections.
 	nangoTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -777,7 +998,7 @@ func TestAuthMiddleware_RehydrateFails_InvalidConnection(t *testing.T) {
 	t.Cleanup(ts.Close)
 
 	// Cookie with connectionID that Nango rejects — mutations get 401.
-	signed := SignSessionCookie("sess-revoked", "conn-revoked", testSecret)
+	signed := SignSessionCookie("sess-revoked", "conn-revoked", time.Now(), testSecret)
 	req, _ := http.NewRequest("POST", ts.URL+"/api/wanted", nil)
 	req.AddCookie(&http.Cookie{Name: cookieName, Value: signed})
 
@@ -869,7 +1090,7 @@ func TestAuthMiddleware_Impersonate_Staging_GET(t *testing.T) {
 	sessionID, _ := sessions.Create("conn-1")
 
 	req, _ := http.NewRequest("GET", ts.URL+"/api/wanted", nil)
-	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", testSecret)})
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)})
 	req.Header.Set("X-Impersonate", "bob")
 
 	resp, err := http.DefaultClient.Do(req)
@@ -895,7 +1116,7 @@ func TestAuthMiddleware_Impersonate_Staging_POST_Blocked(t *testing.T) {
 	sessionID, _ := sessions.Create("conn-1")
 
 	req, _ := http.NewRequest("POST", ts.URL+"/api/wanted", nil)
-	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", testSecret)})
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)})
 	req.Header.Set("X-Wasteland", "wasteland/wl-commons")
 	req.Header.Set("X-Impersonate", "bob")
 
@@ -917,7 +1138,7 @@ func TestAuthMiddleware_Impersonate_NonStaging_Ignored(t *testing.T) {
 	sessionID, _ := sessions.Create("conn-1")
 
 	req, _ := http.NewRequest("GET", ts.URL+"/api/wanted", nil)
-	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", testSecret)})
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)})
 	req.Header.Set("X-Impersonate", "bob")
 
 	resp, err := http.DefaultClient.Do(req)
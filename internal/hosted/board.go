@@ -0,0 +1,316 @@
+package hosted
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/backend"
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/federation"
+)
+
+// boardPollInterval is how often a watched wasteland's board is re-read.
+const boardPollInterval = 15 * time.Second
+
+// boardQuery selects the columns whose changes are pushed to browsers.
+const boardQuery = "SELECT id, title, status, COALESCE(claimed_by, '') AS claimed_by, updated_at FROM wanted"
+
+// BoardEvent is one item change pushed over /ws.
+type BoardEvent struct {
+	Type      string `json:"type"` // "added", "changed", or "removed"
+	ID        string `json:"id"`
+	Title     string `json:"title,omitempty"`
+	Status    string `json:"status,omitempty"`
+	ClaimedBy string `json:"claimed_by,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// boardMessage is the JSON frame sent to browsers: all changes seen by one
+// poll of one wasteland.
+type boardMessage struct {
+	Upstream string       `json:"upstream"`
+	Events   []BoardEvent `json:"events"`
+}
+
+// boardRow is the polled state of one wanted item.
+type boardRow struct {
+	title, status, claimedBy, updatedAt string
+}
+
+// boardPoller watches one wasteland's upstream board and fans changes out
+// to subscribers. It polls only while someone is subscribed.
+type boardPoller struct {
+	upstream string
+	db       commons.DB
+	interval time.Duration
+
+	mu     sync.Mutex
+	subs   map[chan []BoardEvent]struct{}
+	stop   chan struct{}
+	last   map[string]boardRow // nil until the first poll after (re)start
+	pollWG sync.WaitGroup
+}
+
+func newBoardPoller(upstream string, db commons.DB, interval time.Duration) *boardPoller {
+	return &boardPoller{
+		upstream: upstream,
+		db:       db,
+		interval: interval,
+		subs:     make(map[chan []BoardEvent]struct{}),
+	}
+}
+
+// subscribe registers a listener and starts polling if it is the first.
+// The returned cancel func must be called when the listener goes away.
+// A listener that falls behind misses batches rather than stalling others.
+func (p *boardPoller) subscribe() (<-chan []BoardEvent, func()) {
+	ch := make(chan []BoardEvent, 8)
+	p.mu.Lock()
+	p.subs[ch] = struct{}{}
+	if p.stop == nil {
+		p.stop = make(chan struct{})
+		p.last = nil
+		p.pollWG.Add(1)
+		go p.run(p.stop)
+	}
+	p.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			p.mu.Lock()
+			delete(p.subs, ch)
+			if len(p.subs) == 0 && p.stop != nil {
+				close(p.stop)
+				p.stop = nil
+			}
+			p.mu.Unlock()
+		})
+	}
+}
+
+// idle reports whether the poller has no subscribers.
+func (p *boardPoller) idle() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.subs) == 0
+}
+
+func (p *boardPoller) run(stop chan struct{}) {
+	defer p.pollWG.Done()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.poll()
+	for {
+		select {
+		case <-ticker.C:
+			p.poll()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// poll reads the board, diffs it against the previous poll, and sends any
+// changes to subscribers. The first poll only records a baseline.
+func (p *boardPoller) poll() {
+	current, err := p.readBoard()
+	if err != nil {
+		slog.Warn("board poll failed", "upstream", p.upstream, "error", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	prev := p.last
+	p.last = current
+	if prev == nil {
+		return
+	}
+	events := diffBoard(prev, current)
+	if len(events) == 0 {
+		return
+	}
+	for ch := range p.subs {
+		select {
+		case ch <- events:
+		default:
+		}
+	}
+}
+
+func (p *boardPoller) readBoard() (map[string]boardRow, error) {
+	rows, err := commons.QueryRows(p.db, boardQuery, "")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck // iteration errors are checked below
+
+	board := make(map[string]boardRow)
+	for rows.Next() {
+		m := rows.Map()
+		board[m["id"]] = boardRow{
+			title:     m["title"],
+			status:    m["status"],
+			claimedBy: m["claimed_by"],
+			updatedAt: m["updated_at"],
+		}
+	}
+	return board, rows.Err()
+}
+
+// diffBoard returns the events that turn prev into current, sorted by kind
+// then ID for stable output.
+func diffBoard(prev, current map[string]boardRow) []BoardEvent {
+	var events []BoardEvent
+	for id, row := range current {
+		old, ok := prev[id]
+		switch {
+		case !ok:
+			events = append(events, row.event("added", id))
+		case old != row:
+			events = append(events, row.event("changed", id))
+		}
+	}
+	for id := range prev {
+		if _, ok := current[id]; !ok {
+			events = append(events, BoardEvent{Type: "removed", ID: id})
+		}
+	}
+	sortBoardEvents(events)
+	return events
+}
+
+func (r boardRow) event(kind, id string) BoardEvent {
+	return BoardEvent{
+		Type:      kind,
+		ID:        id,
+		Title:     r.title,
+		Status:    r.status,
+		ClaimedBy: r.claimedBy,
+		UpdatedAt: r.updatedAt,
+	}
+}
+
+func sortBoardEvents(events []BoardEvent) {
+	rank := map[string]int{"added": 0, "changed": 1, "removed": 2}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Type != events[j].Type {
+			return rank[events[i].Type] < rank[events[j].Type]
+		}
+		return events[i].ID < events[j].ID
+	})
+}
+
+// publicBoardDB reads an upstream's main branch anonymously, the same way
+// the public client serves unauthenticated reads.
+func publicBoardDB(upstream string) (commons.DB, error) {
+	org, db, err := federation.ParseUpstream(upstream)
+	if err != nil {
+		return nil, err
+	}
+	return backend.NewRemoteDB("", org, db, org, db, ""), nil
+}
+
+// subscribeBoard subscribes to the shared poller for upstream, creating it
+// on first use. All connections to the same wasteland share one poller; the
+// returned cancel func drops the poller once its last subscriber leaves.
+func (s *Server) subscribeBoard(upstream string) (<-chan []BoardEvent, func(), error) {
+	s.boardsMu.Lock()
+	defer s.boardsMu.Unlock()
+	p, ok := s.boards[upstream]
+	if !ok {
+		db, err := s.boardDB(upstream)
+		if err != nil {
+			return nil, nil, err
+		}
+		p = newBoardPoller(upstream, db, s.boardInterval)
+		s.boards[upstream] = p
+	}
+	events, cancel := p.subscribe()
+	return events, func() {
+		s.boardsMu.Lock()
+		defer s.boardsMu.Unlock()
+		cancel()
+		if p.idle() && s.boards[upstream] == p {
+			delete(s.boards, upstream)
+		}
+	}, nil
+}
+
+// servesBoard reports whether /ws may stream upstream's board: one set by
+// SetBoardUpstreams, or one a signed-in user has joined.
+func (s *Server) servesBoard(upstream string) bool {
+	return s.boardUpstreams[upstream] || (s.resolver != nil && s.resolver.Joined(upstream))
+}
+
+// handleWS upgrades GET /ws?wasteland=org/db to a WebSocket and pushes a
+// JSON boardMessage each time the wasteland's board changes. The stream
+// only carries public upstream state, so no session is required, but only
+// the deployment's own wastelands and those its users have joined can be
+// watched, and cross-origin pages must be allowed by SetWebSocketOrigins.
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	upstream := r.URL.Query().Get("wasteland")
+	if _, _, err := federation.ParseUpstream(upstream); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "wasteland query parameter must be org/db"})
+		return
+	}
+	if !s.servesBoard(upstream) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "wasteland " + upstream + " is not served here"})
+		return
+	}
+	if !wsOriginAllowed(r, s.wsOrigins) {
+		slog.Warn("websocket origin rejected", "origin", r.Header.Get("Origin"))
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": "origin not allowed"})
+		return
+	}
+	events, cancel, err := s.subscribeBoard(upstream)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	defer cancel()
+
+	conn, err := upgradeWebSocket(w, r)
+	if errors.Is(err, errNotWebSocket) {
+		writeJSON(w, http.StatusUpgradeRequired, map[string]string{"error": "websocket upgrade required"})
+		return
+	}
+	if errors.Is(err, errHijacked) {
+		// The connection is already closed and no longer an HTTP response.
+		slog.Warn("websocket upgrade failed", "error", err)
+		return
+	}
+	if err != nil {
+		slog.Warn("websocket upgrade failed", "error", err)
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	defer conn.Close() //nolint:errcheck // best-effort close
+
+	done := make(chan error, 1)
+	go func() { done <- conn.readLoop() }()
+
+	for {
+		select {
+		case batch := <-events:
+			data, _ := json.Marshal(boardMessage{Upstream: upstream, Events: batch})
+			if err := conn.WriteText(data); err != nil {
+				slog.Debug("websocket write failed", "upstream", upstream, "error", err)
+				return
+			}
+		case err := <-done:
+			if !errors.Is(err, io.EOF) {
+				slog.Debug("websocket closed", "upstream", upstream, "error", err)
+			}
+			return
+		}
+	}
+}
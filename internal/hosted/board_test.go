@@ -0,0 +1,281 @@
+package hosted
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// fakeBoardDB serves the board query from a mutable CSV result.
+type fakeBoardDB struct {
+	commons.DB // unused methods panic
+
+	mu  sync.Mutex
+	csv string
+}
+
+func (f *fakeBoardDB) Query(_, _ string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.csv, nil
+}
+
+func (f *fakeBoardDB) set(csv string) {
+	f.mu.Lock()
+	f.csv = csv
+	f.mu.Unlock()
+}
+
+func TestDiffBoard(t *testing.T) {
+	prev := map[string]boardRow{
+		"w-1": {title: "One", status: "open"},
+		"w-2": {title: "Two", status: "open"},
+		"w-3": {title: "Three", status: "open"},
+	}
+	current := map[string]boardRow{
+		"w-1": {title: "One", status: "open"},
+		"w-2": {title: "Two", status: "claimed", claimedBy: "bob"},
+		"w-4": {title: "Four", status: "open"},
+	}
+
+	got := diffBoard(prev, current)
+	want := []BoardEvent{
+		{Type: "added", ID: "w-4", Title: "Four", Status: "open"},
+		{Type: "changed", ID: "w-2", Title: "Two", Status: "claimed", ClaimedBy: "bob"},
+		{Type: "removed", ID: "w-3"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("diffBoard = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBoardPoller_StopsWithoutSubscribers(t *testing.T) {
+	db := &fakeBoardDB{csv: "id,title,status,claimed_by,updated_at\nw-1,One,open,,2026-01-01\n"}
+	p := newBoardPoller("hop/wl-commons", db, 5*time.Millisecond)
+
+	_, cancel := p.subscribe()
+	_, cancel2 := p.subscribe()
+	cancel()
+	cancel() // idempotent
+	p.mu.Lock()
+	running := p.stop != nil
+	p.mu.Unlock()
+	if !running {
+		t.Fatal("poller stopped while a subscriber remains")
+	}
+
+	cancel2()
+	p.pollWG.Wait() // returns only once the poll goroutine has exited
+}
+
+func TestHandleWS_PushesBoardChanges(t *testing.T) {
+	db := &fakeBoardDB{csv: "id,title,status,claimed_by,updated_at\nw-1,One,open,,2026-01-01\n"}
+	s := NewServer(nil, NewSessionStore(), nil, testSecret, "")
+	s.boardDB = func(string) (commons.DB, error) { return db, nil }
+	s.boardInterval = 10 * time.Millisecond
+	s.SetBoardUpstreams([]string{"hop/wl-commons"})
+
+	ts := httptest.NewServer(http.HandlerFunc(s.handleWS))
+	defer ts.Close()
+
+	conn, br := dialWS(t, ts.URL, "/ws?wasteland=hop/wl-commons")
+	defer conn.Close() //nolint:errcheck // test cleanup
+
+	// Let the baseline poll happen, then change the board.
+	time.Sleep(50 * time.Millisecond)
+	db.set("id,title,status,claimed_by,updated_at\nw-1,One,claimed,bob,2026-01-02\n")
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	op, payload := readServerFrame(t, br)
+	if op != wsOpText {
+		t.Fatalf("opcode = %#x, want text", op)
+	}
+	var msg boardMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatalf("decoding %s: %v", payload, err)
+	}
+	if msg.Upstream != "hop/wl-commons" || len(msg.Events) != 1 {
+		t.Fatalf("message = %+v", msg)
+	}
+	if e := msg.Events[0]; e.Type != "changed" || e.ID != "w-1" || e.Status != "claimed" || e.ClaimedBy != "bob" {
+		t.Errorf("event = %+v", e)
+	}
+
+	// A masked ping from the client is answered with a pong.
+	writeClientFrame(t, conn, wsOpPing, []byte("hi"))
+	op, payload = readServerFrame(t, br)
+	for op == wsOpText { // skip any board update racing the pong
+		op, payload = readServerFrame(t, br)
+	}
+	if op != wsOpPong || string(payload) != "hi" {
+		t.Errorf("got opcode %#x payload %q, want pong \"hi\"", op, payload)
+	}
+}
+
+func TestHandleWS_EvictsIdlePoller(t *testing.T) {
+	s := NewServer(nil, NewSessionStore(), nil, testSecret, "")
+	s.boardDB = func(string) (commons.DB, error) { return &fakeBoardDB{}, nil }
+	s.boardInterval = time.Hour
+
+	_, cancel, err := s.subscribeBoard("hop/wl-commons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, cancel2, _ := s.subscribeBoard("hop/wl-commons")
+	cancel()
+	s.boardsMu.Lock()
+	n := len(s.boards)
+	s.boardsMu.Unlock()
+	if n != 1 {
+		t.Fatalf("boards = %d while a subscriber remains, want 1", n)
+	}
+
+	cancel2()
+	s.boardsMu.Lock()
+	n = len(s.boards)
+	s.boardsMu.Unlock()
+	if n != 0 {
+		t.Errorf("boards = %d after the last subscriber left, want 0", n)
+	}
+}
+
+func TestHandleWS_RejectsBadRequests(t *testing.T) {
+	s := NewServer(nil, NewSessionStore(), nil, testSecret, "")
+	s.boardDB = func(string) (commons.DB, error) { return &fakeBoardDB{}, nil }
+	s.SetBoardUpstreams([]string{"hop/wl-commons"})
+	s.SetWebSocketOrigins([]string{"https://ui.example.com"})
+
+	tests := []struct {
+		name   string
+		target string
+		origin string
+		want   int
+	}{
+		{"missing wasteland", "/ws", "", http.StatusBadRequest},
+		{"bad wasteland", "/ws?wasteland=nope", "", http.StatusBadRequest},
+		{"unserved wasteland", "/ws?wasteland=someone/else", "", http.StatusNotFound},
+		{"foreign origin", "/ws?wasteland=hop/wl-commons", "https://evil.example.com", http.StatusForbidden},
+		{"plain GET", "/ws?wasteland=hop/wl-commons", "", http.StatusUpgradeRequired},
+		{"same origin", "/ws?wasteland=hop/wl-commons", "http://example.com", http.StatusUpgradeRequired},
+		{"allowed origin", "/ws?wasteland=hop/wl-commons", "https://ui.example.com", http.StatusUpgradeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			s.handleWS(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+	if len(s.boards) != 0 {
+		t.Errorf("boards = %d after rejected requests, want 0", len(s.boards))
+	}
+}
+
+func TestHandleWS_JoinedUpstreams(t *testing.T) {
+	resolver := NewWorkspaceResolver(nil, nil)
+	ws := sdk.NewWorkspace("alice")
+	ws.Add(sdk.UpstreamInfo{Upstream: "someone/joined"}, nil)
+	resolver.cache["conn-1"] = &cachedWorkspace{workspace: ws, expiresAt: time.Now().Add(time.Hour)}
+
+	s := NewServer(resolver, NewSessionStore(), nil, testSecret, "")
+	s.boardDB = func(string) (commons.DB, error) { return &fakeBoardDB{}, nil }
+	s.SetBoardUpstreams([]string{"hop/wl-commons"})
+
+	for target, want := range map[string]int{
+		"/ws?wasteland=hop/wl-commons":  http.StatusUpgradeRequired,
+		"/ws?wasteland=someone/joined":  http.StatusUpgradeRequired,
+		"/ws?wasteland=someone/unknown": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		s.handleWS(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
+
+// dialWS performs a raw client handshake against a test server.
+func dialWS(t *testing.T, serverURL, target string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(serverURL, "http://"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	req := "GET " + target + " HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + key + "\r\nSec-WebSocket-Version: 13\r\n\r\n"
+	if _, err := io.WriteString(conn, req); err != nil {
+		t.Fatalf("handshake write: %v", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("handshake read: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d", resp.StatusCode)
+	}
+	// Expected accept value for this key, from RFC 6455's algorithm.
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "BACScCJPNqyz+UBoqMH89VmURoA=" {
+		t.Errorf("Sec-WebSocket-Accept = %q", got)
+	}
+	return conn, br
+}
+
+func readServerFrame(t *testing.T, br *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	var hdr [2]byte
+	if _, err := io.ReadFull(br, hdr[:]); err != nil {
+		t.Fatalf("reading frame: %v", err)
+	}
+	if hdr[1]&0x80 != 0 {
+		t.Fatal("server frame is masked")
+	}
+	n := int(hdr[1] & 0x7F)
+	if n == 126 {
+		var ext [2]byte
+		_, _ = io.ReadFull(br, ext[:])
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		t.Fatalf("reading payload: %v", err)
+	}
+	return hdr[0] & 0x0F, payload
+}
+
+func writeClientFrame(t *testing.T, conn net.Conn, op byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | op, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatalf("writing frame: %v", err)
+	}
+}
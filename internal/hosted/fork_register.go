@@ -52,7 +52,7 @@ func (d *DoltHubForkRegistrar) EnsureForkAndRegister(apiKey, upstream, forkOrg,
 		if attempt > 0 {
 			time.Sleep(time.Duration(attempt) * 3 * time.Second)
 		}
-		execErr = db.Exec(branch, "", false, regSQL)
+		execErr = db.Exec(branch, "", commons.Signing{}, regSQL)
 		if execErr == nil {
 			break
 		}
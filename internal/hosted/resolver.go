@@ -27,6 +27,8 @@ type WorkspaceResolver struct {
 	sessions *SessionStore
 	mu       sync.Mutex
 	cache    map[string]*cachedWorkspace // connectionID -> cached workspace
+	usage    *UsageTracker
+	readOnly bool // every client refuses mutations (wl serve --read-only)
 
 	pendingMu    sync.Mutex
 	pendingCache map[string]*pendingUpstreamCache // upstream ("org/db") -> shared cache
@@ -101,10 +103,24 @@ func NewWorkspaceResolver(nango *NangoClient, sessions *SessionStore) *Workspace
 		nango:        nango,
 		sessions:     sessions,
 		cache:        make(map[string]*cachedWorkspace),
+		usage:        NewUsageTracker(DefaultDailyQuota),
 		pendingCache: make(map[string]*pendingUpstreamCache),
 	}
 }
 
+// SetDailyQuota changes how many DoltHub API calls each connection may make
+// per UTC day (0 disables the quota).
+func (wr *WorkspaceResolver) SetDailyQuota(limit int) {
+	wr.usage.SetLimit(limit)
+}
+
+// SetReadOnly makes every client the resolver builds refuse mutations,
+// as the local configs of 'wl serve --read-only' do. Call it before the
+// first Resolve; cached workspaces are not rebuilt.
+func (wr *WorkspaceResolver) SetReadOnly(readOnly bool) {
+	wr.readOnly = readOnly
+}
+
 // Resolve builds or returns a cached sdk.Workspace for the given session.
 func (wr *WorkspaceResolver) Resolve(session *UserSession) (*sdk.Workspace, error) {
 	// Fast path: return cached workspace if still valid.
@@ -155,6 +171,29 @@ func (wr *WorkspaceResolver) Resolve(session *UserSession) (*sdk.Workspace, erro
 	return ws, nil
 }
 
+// workspaces returns every workspace the resolver has built, including
+// ones whose cache entry has expired but not yet been rebuilt.
+func (wr *WorkspaceResolver) workspaces() []*sdk.Workspace {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	out := make([]*sdk.Workspace, 0, len(wr.cache))
+	for _, cached := range wr.cache {
+		out = append(out, cached.workspace)
+	}
+	return out
+}
+
+// Joined reports whether any workspace the resolver has built has joined
+// upstream.
+func (wr *WorkspaceResolver) Joined(upstream string) bool {
+	for _, ws := range wr.workspaces() {
+		if _, err := ws.Client(upstream); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // InvalidateConnection removes the cached workspace for a connection.
 func (wr *WorkspaceResolver) InvalidateConnection(connectionID string) {
 	wr.mu.Lock()
@@ -187,9 +226,10 @@ func (wr *WorkspaceResolver) buildClient(wl *WastelandConfig, rigHandle, connect
 		mode = "pr"
 	}
 
-	db := backend.NewRemoteDB(apiKey, upOrg, upDB, wl.ForkOrg, wl.ForkDB, mode)
-
-	provider := remote.NewDoltHubProvider(apiKey)
+	// Every DoltHub call made for this user is charged to their quota.
+	metered := newMeteredClient(wr.usage, connectionID, apiKey)
+	db := backend.NewRemoteDBWithClient(metered, upOrg, upDB, wl.ForkOrg, wl.ForkDB, mode)
+	provider := remote.NewDoltHubProviderWithClient(metered)
 
 	branchURL := func(branch string) string {
 		return fmt.Sprintf("https://www.dolthub.com/repositories/%s/%s/data/%s",
@@ -200,9 +240,10 @@ func (wr *WorkspaceResolver) buildClient(wl *WastelandConfig, rigHandle, connect
 	upstream := wl.Upstream
 
 	client := sdk.New(sdk.ClientConfig{
-		DB:        db,
+		DB:        commons.Chained(db, rigHandle, ""),
 		RigHandle: rigHandle,
 		Mode:      mode,
+		ReadOnly:  wr.readOnly,
 		LoadDiff: func(branch string) (string, error) {
 			return db.Diff(branch)
 		},
@@ -250,7 +291,9 @@ func (wr *WorkspaceResolver) buildClient(wl *WastelandConfig, rigHandle, connect
 			}
 			return provider.ClosePR(upOrg, upDB, prID)
 		},
-		ListPendingItems: wr.getOrCreatePendingCache(provider, upOrg, upDB).Get,
+		// The pending cache is shared by everyone on the upstream, so its
+		// background refreshes are not charged to whoever created it.
+		ListPendingItems: wr.getOrCreatePendingCache(remote.NewDoltHubProvider(apiKey), upOrg, upDB).Get,
 		BranchURL:        branchURL,
 		Signing:          wl.Signing,
 		SaveConfig: func(mode string, signing bool) error {
@@ -95,6 +95,34 @@ func TestWorkspaceResolver_Resolve(t *testing.T) {
 	}
 }
 
+func TestWorkspaceResolver_ReadOnly(t *testing.T) {
+	ts := newFakeNangoForResolver(t)
+	defer ts.Close()
+
+	nango := NewNangoClient(NangoConfig{
+		BaseURL:       ts.URL,
+		SecretKey:     "resolver-secret",
+		IntegrationID: "dolthub",
+	})
+	resolver := NewWorkspaceResolver(nango, NewSessionStore())
+	resolver.SetReadOnly(true)
+
+	ws, err := resolver.Resolve(&UserSession{ID: "sess-1", ConnectionID: "conn-1", CreatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := ws.Client("wasteland/wl-commons")
+	if err != nil {
+		t.Fatalf("expected client: %v", err)
+	}
+	if !client.ReadOnly() {
+		t.Error("client should be read-only")
+	}
+	if _, err := client.Claim("w-1"); err == nil {
+		t.Error("Claim succeeded on a read-only client")
+	}
+}
+
 func TestWorkspaceResolver_CachesWorkspace(t *testing.T) {
 	ts := newFakeNangoForResolver(t)
 	defer ts.Close()
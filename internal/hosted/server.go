@@ -8,9 +8,11 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gastownhall/wasteland/internal/api"
+	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/sdk"
 	"github.com/getsentry/sentry-go"
 )
@@ -21,8 +23,16 @@ type Server struct {
 	sessions      *SessionStore
 	nango         *NangoClient
 	sessionSecret string
+	cookies       CookiePolicy
 	forkRegistrar ForkRegistrar
 	environment   string // "staging", "production", or "" (unset)
+
+	boardsMu       sync.Mutex
+	boards         map[string]*boardPoller // upstream -> shared poller, while subscribed
+	boardDB        func(upstream string) (commons.DB, error)
+	boardInterval  time.Duration
+	boardUpstreams map[string]bool // upstreams /ws may watch
+	wsOrigins      []string        // cross-origin pages allowed to open /ws
 }
 
 // NewServer creates a hosted Server.
@@ -32,11 +42,37 @@ func NewServer(resolver *WorkspaceResolver, sessions *SessionStore, nango *Nango
 		sessions:      sessions,
 		nango:         nango,
 		sessionSecret: sessionSecret,
+		cookies:       DefaultCookiePolicy,
 		forkRegistrar: &DoltHubForkRegistrar{},
 		environment:   environment,
+		boards:        make(map[string]*boardPoller),
+		boardDB:       publicBoardDB,
+		boardInterval: boardPollInterval,
+	}
+}
+
+// SetCookiePolicy overrides the session cookie's Secure/SameSite attributes.
+func (s *Server) SetCookiePolicy(p CookiePolicy) {
+	s.cookies = p
+}
+
+// SetBoardUpstreams sets the wastelands whose live board /ws may stream
+// besides those signed-in users have joined. Requests for any other
+// upstream are refused, so /ws can't be used to start DoltHub pollers for
+// arbitrary databases.
+func (s *Server) SetBoardUpstreams(upstreams []string) {
+	s.boardUpstreams = make(map[string]bool, len(upstreams))
+	for _, u := range upstreams {
+		s.boardUpstreams[u] = true
 	}
 }
 
+// SetWebSocketOrigins sets the cross-origin pages allowed to open /ws, in
+// addition to the server's own origin.
+func (s *Server) SetWebSocketOrigins(origins []string) {
+	s.wsOrigins = origins
+}
+
 // Handler composes the hosted endpoints with the API server and static assets.
 func (s *Server) Handler(apiServer *api.Server, assets fs.FS) http.Handler {
 	mux := http.NewServeMux()
@@ -52,10 +88,15 @@ func (s *Server) Handler(apiServer *api.Server, assets fs.FS) http.Handler {
 	// Auth endpoints (no auth middleware required, strict rate limit).
 	mux.Handle("POST /api/auth/connect", authRL(http.HandlerFunc(s.handleConnect)))
 	mux.Handle("GET /api/auth/status", authRL(http.HandlerFunc(s.handleAuthStatus)))
+	mux.Handle("GET /api/auth/usage", authRL(http.HandlerFunc(s.handleUsage)))
 	mux.Handle("POST /api/auth/logout", authRL(http.HandlerFunc(s.handleLogout)))
 	mux.Handle("POST /api/auth/connect-session", authRL(http.HandlerFunc(s.handleConnectSession)))
 	mux.Handle("POST /api/auth/join", authRL(http.HandlerFunc(s.handleJoin)))
 	mux.Handle("DELETE /api/auth/wastelands/{upstream...}", authRL(http.HandlerFunc(s.handleLeaveWasteland)))
+	mux.Handle("PUT /api/auth/wastelands/{org}/{db}/settings", authRL(http.HandlerFunc(s.handleWastelandSettings)))
+
+	// Live board updates over WebSocket (public upstream state, no auth).
+	mux.Handle("GET /ws", generalRL(http.HandlerFunc(s.handleWS)))
 
 	// Public scoreboard endpoint (no auth, bypasses middleware).
 	mux.HandleFunc("GET /api/scoreboard", apiServer.ScoreboardHandler())
@@ -143,7 +184,12 @@ func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Create session.
+	// Connecting changes who the browser acts as, so never carry a prior
+	// session ID across it: drop the old session and issue a fresh one.
+	if oldID, _, _, ok := ReadSessionCookie(r, s.sessionSecret); ok {
+		s.sessions.Delete(oldID)
+	}
+	issuedAt := time.Now() // no later than the session's CreatedAt
 	sessionID, err := s.sessions.Create(req.ConnectionID)
 	if err != nil {
 		slog.Error("failed to create session", "error", err, "connection_id", req.ConnectionID)
@@ -151,7 +197,7 @@ func (s *Server) handleConnect(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to create session: " + err.Error()})
 		return
 	}
-	SetSessionCookie(w, sessionID, req.ConnectionID, s.sessionSecret)
+	s.cookies.SetSessionCookie(w, sessionID, req.ConnectionID, issuedAt, s.sessionSecret)
 
 	resp := map[string]string{"status": "connected"}
 	if setupWarning != "" {
@@ -171,7 +217,7 @@ type authStatusResponse struct {
 
 // handleAuthStatus returns the current session state.
 func (s *Server) handleAuthStatus(w http.ResponseWriter, r *http.Request) {
-	sessionID, _, ok := ReadSessionCookie(r, s.sessionSecret)
+	sessionID, _, _, ok := ReadSessionCookie(r, s.sessionSecret)
 	if !ok {
 		writeJSON(w, http.StatusOK, authStatusResponse{Environment: s.environment})
 		return
@@ -210,13 +256,35 @@ func (s *Server) handleAuthStatus(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleUsage reports the session's DoltHub API consumption for today.
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	sessionID, _, _, ok := ReadSessionCookie(r, s.sessionSecret)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "not authenticated"})
+		return
+	}
+
+	session, ok := s.sessions.Get(sessionID)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "session expired"})
+		return
+	}
+
+	if session.ConnectionID == "" {
+		writeJSON(w, http.StatusPreconditionFailed, map[string]string{"error": "DoltHub not connected"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toUsageResponse(s.resolver.usage.Usage(session.ConnectionID)))
+}
+
 // handleLogout destroys the session.
 func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
-	sessionID, _, ok := ReadSessionCookie(r, s.sessionSecret)
+	sessionID, _, _, ok := ReadSessionCookie(r, s.sessionSecret)
 	if ok {
 		s.sessions.Delete(sessionID)
 	}
-	ClearSessionCookie(w)
+	s.cookies.ClearSessionCookie(w)
 	writeJSON(w, http.StatusOK, map[string]string{"status": "logged out"})
 }
 
@@ -270,7 +338,7 @@ type joinRequest struct {
 // handleJoin adds a new wasteland to the user's metadata.
 // Requires a valid session cookie (manually validated, not through middleware).
 func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
-	sessionID, _, ok := ReadSessionCookie(r, s.sessionSecret)
+	sessionID, _, _, ok := ReadSessionCookie(r, s.sessionSecret)
 	if !ok {
 		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "not authenticated"})
 		return
@@ -357,6 +425,16 @@ func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
 	// Bust the workspace cache so the next request picks up the new wasteland.
 	s.resolver.InvalidateConnection(session.ConnectionID)
 
+	// Joining grants write access to another wasteland: rotate the session ID.
+	newID, err := s.sessions.Rotate(sessionID)
+	if err != nil {
+		slog.Error("failed to rotate session", "error", err, "connection_id", session.ConnectionID)
+		sentry.CaptureException(err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to rotate session: " + err.Error()})
+		return
+	}
+	s.cookies.SetSessionCookie(w, newID, session.ConnectionID, session.CreatedAt, s.sessionSecret)
+
 	resp := map[string]string{"status": "joined"}
 	if setupWarning != "" {
 		resp["setup_warning"] = setupWarning
@@ -366,7 +444,7 @@ func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
 
 // handleLeaveWasteland removes a wasteland from the user's metadata.
 func (s *Server) handleLeaveWasteland(w http.ResponseWriter, r *http.Request) {
-	sessionID, _, ok := ReadSessionCookie(r, s.sessionSecret)
+	sessionID, _, _, ok := ReadSessionCookie(r, s.sessionSecret)
 	if !ok {
 		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "not authenticated"})
 		return
@@ -424,6 +502,91 @@ func (s *Server) handleLeaveWasteland(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "removed"})
 }
 
+// wastelandSettingsRequest is the JSON body for
+// PUT /api/auth/wastelands/{org}/{db}/settings. Omitted fields are left as-is.
+type wastelandSettingsRequest struct {
+	Mode    *string `json:"mode"`
+	Signing *bool   `json:"signing"`
+}
+
+// handleWastelandSettings updates the per-wasteland options (mode, signing)
+// stored in the user's metadata for one joined wasteland.
+func (s *Server) handleWastelandSettings(w http.ResponseWriter, r *http.Request) {
+	sessionID, _, _, ok := ReadSessionCookie(r, s.sessionSecret)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "not authenticated"})
+		return
+	}
+
+	session, ok := s.sessions.Get(sessionID)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "session expired"})
+		return
+	}
+
+	if session.ConnectionID == "" {
+		writeJSON(w, http.StatusPreconditionFailed, map[string]string{"error": "DoltHub not connected"})
+		return
+	}
+
+	upstream := r.PathValue("org") + "/" + r.PathValue("db")
+	if err := validateUpstream(upstream); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	var req wastelandSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON"})
+		return
+	}
+	if req.Mode == nil && req.Signing == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "no settings to update"})
+		return
+	}
+	if req.Mode != nil && *req.Mode != "wild-west" && *req.Mode != "pr" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "mode must be \"wild-west\" or \"pr\""})
+		return
+	}
+
+	// Fetch current metadata, update the wasteland in place, write back.
+	_, meta, err := s.nango.GetConnection(session.ConnectionID)
+	if err != nil {
+		slog.Error("nango: failed to read metadata", "error", err, "connection_id", session.ConnectionID)
+		sentry.CaptureException(err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to read metadata: " + err.Error()})
+		return
+	}
+	if meta == nil {
+		writeJSON(w, http.StatusPreconditionFailed, map[string]string{"error": "no existing metadata"})
+		return
+	}
+
+	wl := meta.FindWasteland(upstream)
+	if wl == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "upstream not found"})
+		return
+	}
+	if req.Mode != nil {
+		wl.Mode = *req.Mode
+	}
+	if req.Signing != nil {
+		wl.Signing = *req.Signing
+	}
+
+	if err := s.nango.SetMetadata(session.ConnectionID, meta); err != nil {
+		slog.Error("nango: failed to save metadata", "error", err, "connection_id", session.ConnectionID)
+		sentry.CaptureException(err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to save metadata: " + err.Error()})
+		return
+	}
+
+	// Bust the workspace cache so the next request runs in the new mode.
+	s.resolver.InvalidateConnection(session.ConnectionID)
+
+	writeJSON(w, http.StatusOK, wl)
+}
+
 // NewClientFunc returns a ClientFunc that reads the client from request context.
 // This bridges the hosted auth middleware with api.Server's ClientFunc pattern.
 func NewClientFunc() api.ClientFunc {
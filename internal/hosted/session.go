@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,36 +19,84 @@ type UserSession struct {
 	ID           string
 	ConnectionID string // Nango connection ID (set after DoltHub connect)
 	CreatedAt    time.Time
+	LastSeen     time.Time // refreshed on every lookup; drives idle expiry
+}
+
+const (
+	// sessionIdleTTL is how long a session survives without being used.
+	// Each successful Get slides the window forward.
+	sessionIdleTTL = 24 * time.Hour
+
+	// sessionMaxAge caps a session's total lifetime, however active it is.
+	sessionMaxAge = 7 * 24 * time.Hour
+)
+
+// expired reports whether the session has been idle too long or has
+// outlived its absolute lifetime.
+func (sess *UserSession) expired(now time.Time) bool {
+	return now.Sub(sess.LastSeen) > sessionIdleTTL || now.Sub(sess.CreatedAt) > sessionMaxAge
 }
 
 // SessionStore is a thread-safe in-memory session store.
+//
+// Session IDs that were rotated away, logged out or expired are kept in a
+// revocation set until they could no longer pass the cookie's max-age
+// check, so a still-valid signed cookie can't bring them back through
+// Restore.
 type SessionStore struct {
 	mu       sync.RWMutex
 	sessions map[string]*UserSession
+	revoked  map[string]time.Time // session ID -> when the entry can be dropped
+	now      func() time.Time
 }
 
 // NewSessionStore creates a new empty SessionStore with periodic cleanup.
 func NewSessionStore() *SessionStore {
-	s := &SessionStore{sessions: make(map[string]*UserSession)}
+	s := &SessionStore{
+		sessions: make(map[string]*UserSession),
+		revoked:  make(map[string]time.Time),
+		now:      time.Now,
+	}
 	go s.cleanup()
 	return s
 }
 
-// cleanup periodically removes expired sessions to prevent unbounded memory growth.
+// cleanup periodically removes expired sessions and stale revocations to
+// prevent unbounded memory growth.
 func (s *SessionStore) cleanup() {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 	for range ticker.C {
 		s.mu.Lock()
+		now := s.now()
 		for id, sess := range s.sessions {
-			if time.Since(sess.CreatedAt) > sessionTTL {
-				delete(s.sessions, id)
+			if sess.expired(now) {
+				s.revokeLocked(id, now)
+			}
+		}
+		for id, until := range s.revoked {
+			if now.After(until) {
+				delete(s.revoked, id)
 			}
 		}
 		s.mu.Unlock()
 	}
 }
 
+// revokeLocked removes a session and records its ID as revoked. The entry
+// is kept until the session's max age has passed, after which a cookie for
+// it fails the issued-at check anyway. The caller holds s.mu.
+func (s *SessionStore) revokeLocked(id string, now time.Time) {
+	until := now.Add(sessionMaxAge)
+	if sess, ok := s.sessions[id]; ok {
+		until = sess.CreatedAt.Add(sessionMaxAge)
+		delete(s.sessions, id)
+	}
+	if until.After(now) {
+		s.revoked[id] = until
+	}
+}
+
 // Create creates a new session with the given Nango connection ID.
 func (s *SessionStore) Create(connectionID string) (string, error) {
 	id, err := generateSessionID()
@@ -56,17 +105,18 @@ func (s *SessionStore) Create(connectionID string) (string, error) {
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	now := s.now()
 	s.sessions[id] = &UserSession{
 		ID:           id,
 		ConnectionID: connectionID,
-		CreatedAt:    time.Now(),
+		CreatedAt:    now,
+		LastSeen:     now,
 	}
 	return id, nil
 }
 
-const sessionTTL = 24 * time.Hour
-
-// Get retrieves a session by ID. Expired sessions (>24h) are lazily evicted.
+// Get retrieves a session by ID and slides its idle expiry forward.
+// Expired sessions are lazily evicted.
 func (s *SessionStore) Get(id string) (*UserSession, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -74,31 +124,72 @@ func (s *SessionStore) Get(id string) (*UserSession, bool) {
 	if !ok {
 		return nil, false
 	}
-	if time.Since(sess.CreatedAt) > sessionTTL {
-		delete(s.sessions, id)
+	now := s.now()
+	if sess.expired(now) {
+		s.revokeLocked(id, now)
 		return nil, false
 	}
+	sess.LastSeen = now
 	return sess, true
 }
 
-// Delete removes a session by ID.
+// Rotate moves a live session to a freshly generated ID and revokes the old
+// one. The creation time carries over, so rotating never extends the
+// session's absolute lifetime.
+func (s *SessionStore) Rotate(id string) (string, error) {
+	newID, err := generateSessionID()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	now := s.now()
+	if !ok || sess.expired(now) {
+		s.revokeLocked(id, now)
+		return "", fmt.Errorf("session not found")
+	}
+	s.revokeLocked(id, now)
+	s.sessions[newID] = &UserSession{
+		ID:           newID,
+		ConnectionID: sess.ConnectionID,
+		CreatedAt:    sess.CreatedAt,
+		LastSeen:     now,
+	}
+	return newID, nil
+}
+
+// Delete removes a session by ID and revokes it, so a cookie still
+// holding the ID can't restore it.
 func (s *SessionStore) Delete(id string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	delete(s.sessions, id)
+	s.revokeLocked(id, s.now())
 }
 
 // Restore re-creates a session from cookie data after a server restart.
-// The original creation time is unknown, so the session gets a reduced TTL
-// (half the normal sessionTTL) to limit how much a restart can extend a session.
-func (s *SessionStore) Restore(sessionID, connectionID string) {
+// issuedAt is the session's creation time as signed into the cookie, so
+// the absolute max age still counts from when the session began. It
+// reports false, restoring nothing, for a revoked ID or an issued-at time
+// outside the max age. Idle time before the restart is not recorded in the
+// cookie and can't be enforced.
+func (s *SessionStore) Restore(sessionID, connectionID string, issuedAt time.Time) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	now := s.now()
+	if _, revoked := s.revoked[sessionID]; revoked {
+		return false
+	}
+	if issuedAt.IsZero() || issuedAt.After(now) || now.Sub(issuedAt) > sessionMaxAge {
+		return false
+	}
 	s.sessions[sessionID] = &UserSession{
 		ID:           sessionID,
 		ConnectionID: connectionID,
-		CreatedAt:    time.Now().Add(-sessionTTL / 2),
+		CreatedAt:    issuedAt,
+		LastSeen:     now,
 	}
+	return true
 }
 
 func generateSessionID() (string, error) {
@@ -144,25 +235,33 @@ func VerifySessionID(signed, secret string) (string, bool) {
 	return id, true
 }
 
-// SignSessionCookie signs a session cookie containing both sessionID and connectionID.
-// Format: sessionID.connectionID.HMAC(sessionID.connectionID, secret)
-func SignSessionCookie(sessionID, connectionID, secret string) string {
-	payload := sessionID + "." + connectionID
+// sessionCookieVersion prefixes cookies that carry an issued-at time. A
+// session ID is hex, so it never collides with an older cookie's first
+// segment.
+const sessionCookieVersion = "v2"
+
+// SignSessionCookie signs a session cookie containing the sessionID, the
+// time the session was issued and the connectionID.
+// Format: v2.sessionID.issuedAt.connectionID.HMAC(payload, secret), with
+// issuedAt in Unix seconds.
+func SignSessionCookie(sessionID, connectionID string, issuedAt time.Time, secret string) string {
+	payload := sessionCookieVersion + "." + sessionID + "." + strconv.FormatInt(issuedAt.Unix(), 10) + "." + connectionID
 	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write([]byte(payload))
 	sig := hex.EncodeToString(mac.Sum(nil))
 	return payload + "." + sig
 }
 
-// VerifySessionCookie verifies a signed session cookie in the new 3-segment format.
-// Returns (sessionID, connectionID, ok).
+// VerifySessionCookie verifies a signed session cookie.
+// Returns (sessionID, connectionID, issuedAt, ok); issuedAt is zero for a
+// cookie in the older sessionID.connectionID.sig format.
 // The signature is always a 64-char hex HMAC-SHA256. We split from the right
 // to handle connectionIDs that contain dots.
-func VerifySessionCookie(signed, secret string) (string, string, bool) {
+func VerifySessionCookie(signed, secret string) (string, string, time.Time, bool) {
 	// The signature is the last 64 hex characters after the last dot.
 	lastDot := strings.LastIndex(signed, ".")
 	if lastDot < 1 || lastDot == len(signed)-1 {
-		return "", "", false
+		return "", "", time.Time{}, false
 	}
 	payload := signed[:lastDot]
 	sig := signed[lastDot+1:]
@@ -171,61 +270,123 @@ func VerifySessionCookie(signed, secret string) (string, string, bool) {
 	mac.Write([]byte(payload))
 	expected := hex.EncodeToString(mac.Sum(nil))
 	if !hmac.Equal([]byte(sig), []byte(expected)) {
-		return "", "", false
+		return "", "", time.Time{}, false
+	}
+
+	if rest, ok := strings.CutPrefix(payload, sessionCookieVersion+"."); ok {
+		parts := strings.SplitN(rest, ".", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return "", "", time.Time{}, false
+		}
+		unix, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return "", "", time.Time{}, false
+		}
+		return parts[0], parts[2], time.Unix(unix, 0), true
 	}
 
-	// Split payload into sessionID.connectionID at the first dot.
+	// Older format: split payload into sessionID.connectionID at the first dot.
 	firstDot := strings.Index(payload, ".")
 	if firstDot < 1 || firstDot == len(payload)-1 {
-		return "", "", false
+		return "", "", time.Time{}, false
 	}
-	return payload[:firstDot], payload[firstDot+1:], true
+	return payload[:firstDot], payload[firstDot+1:], time.Time{}, true
+}
+
+// CookiePolicy controls the Secure and SameSite attributes of the session
+// cookie. Deployments that serve the UI from a different site than the API
+// need SameSite=None, which browsers only accept on Secure cookies.
+type CookiePolicy struct {
+	Secure   bool
+	SameSite http.SameSite
 }
 
-// SetSessionCookie sets the wl_session cookie on the response.
-func SetSessionCookie(w http.ResponseWriter, sessionID, connectionID, secret string) {
-	signed := SignSessionCookie(sessionID, connectionID, secret)
+// DefaultCookiePolicy is the strictest policy: Secure and SameSite=Strict.
+var DefaultCookiePolicy = CookiePolicy{Secure: true, SameSite: http.SameSiteStrictMode}
+
+// ParseCookiePolicy builds a CookiePolicy from deployment settings. An empty
+// secure defaults to true; sameSite is "strict" (default), "lax", or "none".
+func ParseCookiePolicy(secure, sameSite string) (CookiePolicy, error) {
+	p := DefaultCookiePolicy
+	if secure != "" {
+		v, err := strconv.ParseBool(secure)
+		if err != nil {
+			return CookiePolicy{}, fmt.Errorf("invalid cookie secure setting %q: %w", secure, err)
+		}
+		p.Secure = v
+	}
+	switch strings.ToLower(sameSite) {
+	case "", "strict":
+		p.SameSite = http.SameSiteStrictMode
+	case "lax":
+		p.SameSite = http.SameSiteLaxMode
+	case "none":
+		if !p.Secure {
+			return CookiePolicy{}, fmt.Errorf("SameSite=None requires Secure cookies")
+		}
+		p.SameSite = http.SameSiteNoneMode
+	default:
+		return CookiePolicy{}, fmt.Errorf("invalid cookie SameSite setting %q (want strict, lax, or none)", sameSite)
+	}
+	return p, nil
+}
+
+// SetSessionCookie sets the wl_session cookie on the response. issuedAt is
+// the session's creation time, which bounds how long the cookie can restore
+// the session.
+func (p CookiePolicy) SetSessionCookie(w http.ResponseWriter, sessionID, connectionID string, issuedAt time.Time, secret string) {
+	signed := SignSessionCookie(sessionID, connectionID, issuedAt, secret)
 	http.SetCookie(w, &http.Cookie{
 		Name:     cookieName,
 		Value:    signed,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
+		Secure:   p.Secure,
+		SameSite: p.SameSite,
 	})
 }
 
 // ClearSessionCookie clears the wl_session cookie.
-func ClearSessionCookie(w http.ResponseWriter) {
+func (p CookiePolicy) ClearSessionCookie(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     cookieName,
 		Value:    "",
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
+		Secure:   p.Secure,
+		SameSite: p.SameSite,
 		MaxAge:   -1,
 	})
 }
 
+// SetSessionCookie sets the wl_session cookie under DefaultCookiePolicy.
+func SetSessionCookie(w http.ResponseWriter, sessionID, connectionID string, issuedAt time.Time, secret string) {
+	DefaultCookiePolicy.SetSessionCookie(w, sessionID, connectionID, issuedAt, secret)
+}
+
+// ClearSessionCookie clears the wl_session cookie under DefaultCookiePolicy.
+func ClearSessionCookie(w http.ResponseWriter) {
+	DefaultCookiePolicy.ClearSessionCookie(w)
+}
+
 // ReadSessionCookie reads and verifies the session cookie from the request.
-// Supports both old format (sessionID.sig → empty connectionID) and new format
-// (sessionID.connectionID.sig).
-func ReadSessionCookie(r *http.Request, secret string) (string, string, bool) {
+// Returns (sessionID, connectionID, issuedAt, ok). Older formats are still
+// accepted: sessionID.connectionID.sig yields a zero issuedAt, and
+// sessionID.sig also an empty connectionID.
+func ReadSessionCookie(r *http.Request, secret string) (string, string, time.Time, bool) {
 	c, err := r.Cookie(cookieName)
 	if err != nil {
-		return "", "", false
+		return "", "", time.Time{}, false
 	}
 
-	// Try new 3-segment format first.
-	if sessionID, connectionID, ok := VerifySessionCookie(c.Value, secret); ok {
-		return sessionID, connectionID, true
+	if sessionID, connectionID, issuedAt, ok := VerifySessionCookie(c.Value, secret); ok {
+		return sessionID, connectionID, issuedAt, true
 	}
 
 	// Fall back to old 2-segment format (connectionID will be empty).
 	if sessionID, ok := VerifySessionID(c.Value, secret); ok {
-		return sessionID, "", true
+		return sessionID, "", time.Time{}, true
 	}
 
-	return "", "", false
+	return "", "", time.Time{}, false
 }
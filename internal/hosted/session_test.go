@@ -3,7 +3,9 @@ package hosted
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSessionStore_CreateAndGet(t *testing.T) {
@@ -105,8 +107,10 @@ func TestSetAndReadSessionCookie(t *testing.T) {
 	sessionID := "sess-42"
 	connectionID := "conn-99"
 
+	issuedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
 	w := httptest.NewRecorder()
-	SetSessionCookie(w, sessionID, connectionID, secret)
+	SetSessionCookie(w, sessionID, connectionID, issuedAt, secret)
 
 	// Extract the cookie from the response and put it in a request.
 	cookies := w.Result().Cookies()
@@ -127,7 +131,7 @@ func TestSetAndReadSessionCookie(t *testing.T) {
 	req := httptest.NewRequest("GET", "/", nil)
 	req.AddCookie(cookie)
 
-	gotID, gotConn, ok := ReadSessionCookie(req, secret)
+	gotID, gotConn, gotIssued, ok := ReadSessionCookie(req, secret)
 	if !ok {
 		t.Fatal("expected cookie to be valid")
 	}
@@ -137,11 +141,14 @@ func TestSetAndReadSessionCookie(t *testing.T) {
 	if gotConn != connectionID {
 		t.Errorf("expected connection %s, got %s", connectionID, gotConn)
 	}
+	if !gotIssued.Equal(issuedAt) {
+		t.Errorf("expected issued-at %v, got %v", issuedAt, gotIssued)
+	}
 }
 
 func TestReadSessionCookie_Missing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/", nil)
-	_, _, ok := ReadSessionCookie(req, "secret")
+	_, _, _, ok := ReadSessionCookie(req, "secret")
 	if ok {
 		t.Error("expected no cookie")
 	}
@@ -162,7 +169,7 @@ func TestClearSessionCookie(t *testing.T) {
 func TestClearSessionCookie_OverwritesExisting(t *testing.T) {
 	secret := "secret"
 	w := httptest.NewRecorder()
-	SetSessionCookie(w, "sess-1", "conn-1", secret)
+	SetSessionCookie(w, "sess-1", "conn-1", time.Now(), secret)
 
 	// Now clear it.
 	w2 := httptest.NewRecorder()
@@ -174,7 +181,7 @@ func TestClearSessionCookie_OverwritesExisting(t *testing.T) {
 		req.AddCookie(&http.Cookie{Name: c.Name, Value: c.Value})
 	}
 
-	_, _, ok := ReadSessionCookie(req, secret)
+	_, _, _, ok := ReadSessionCookie(req, secret)
 	if ok {
 		t.Error("expected cleared cookie to fail verification")
 	}
@@ -183,10 +190,11 @@ func TestClearSessionCookie_OverwritesExisting(t *testing.T) {
 func TestSignVerifySessionCookie(t *testing.T) {
 	secret := "test-secret"
 	sessionID := "sess-1"
-	connectionID := "conn-1"
+	connectionID := "conn.with.dots"
+	issuedAt := time.Unix(1767225600, 0)
 
-	signed := SignSessionCookie(sessionID, connectionID, secret)
-	gotSess, gotConn, ok := VerifySessionCookie(signed, secret)
+	signed := SignSessionCookie(sessionID, connectionID, issuedAt, secret)
+	gotSess, gotConn, gotIssued, ok := VerifySessionCookie(signed, secret)
 	if !ok {
 		t.Fatal("expected verification to succeed")
 	}
@@ -196,21 +204,36 @@ func TestSignVerifySessionCookie(t *testing.T) {
 	if gotConn != connectionID {
 		t.Errorf("expected connection %s, got %s", connectionID, gotConn)
 	}
+	if !gotIssued.Equal(issuedAt) {
+		t.Errorf("expected issued-at %v, got %v", issuedAt, gotIssued)
+	}
+}
+
+func TestVerifySessionCookie_NoIssuedAt(t *testing.T) {
+	// The sessionID.connectionID.sig format predates the issued-at time.
+	signed := SignSessionID("sess-1.conn-1", "secret")
+	gotSess, gotConn, gotIssued, ok := VerifySessionCookie(signed, "secret")
+	if !ok || gotSess != "sess-1" || gotConn != "conn-1" {
+		t.Fatalf("got %q, %q, %v; want sess-1, conn-1, true", gotSess, gotConn, ok)
+	}
+	if !gotIssued.IsZero() {
+		t.Errorf("expected zero issued-at, got %v", gotIssued)
+	}
 }
 
 func TestVerifySessionCookie_WrongSecret(t *testing.T) {
-	signed := SignSessionCookie("sess-1", "conn-1", "secret-1")
-	_, _, ok := VerifySessionCookie(signed, "secret-2")
+	signed := SignSessionCookie("sess-1", "conn-1", time.Now(), "secret-1")
+	_, _, _, ok := VerifySessionCookie(signed, "secret-2")
 	if ok {
 		t.Error("expected verification to fail with wrong secret")
 	}
 }
 
 func TestVerifySessionCookie_Tampered(t *testing.T) {
-	signed := SignSessionCookie("sess-1", "conn-1", "secret")
+	signed := SignSessionCookie("sess-1", "conn-1", time.Now(), "secret")
 	// Tamper with the connectionID segment.
-	tampered := "sess-1.tampered." + signed[len("sess-1.conn-1."):]
-	_, _, ok := VerifySessionCookie(tampered, "secret")
+	tampered := strings.Replace(signed, ".conn-1.", ".tampered.", 1)
+	_, _, _, ok := VerifySessionCookie(tampered, "secret")
 	if ok {
 		t.Error("expected verification to fail for tampered value")
 	}
@@ -218,7 +241,7 @@ func TestVerifySessionCookie_Tampered(t *testing.T) {
 
 func TestVerifySessionCookie_Invalid(t *testing.T) {
 	for _, val := range []string{"", "one-segment", "two.segments", ".empty.first", "a..c", "a.b."} {
-		_, _, ok := VerifySessionCookie(val, "secret")
+		_, _, _, ok := VerifySessionCookie(val, "secret")
 		if ok {
 			t.Errorf("expected verification to fail for %q", val)
 		}
@@ -234,7 +257,7 @@ func TestReadSessionCookie_OldFormat(t *testing.T) {
 	req := httptest.NewRequest("GET", "/", nil)
 	req.AddCookie(&http.Cookie{Name: cookieName, Value: signed})
 
-	gotID, gotConn, ok := ReadSessionCookie(req, secret)
+	gotID, gotConn, _, ok := ReadSessionCookie(req, secret)
 	if !ok {
 		t.Fatal("expected old-format cookie to be valid")
 	}
@@ -255,7 +278,10 @@ func TestSessionStore_Restore(t *testing.T) {
 		t.Fatal("expected session to not exist before restore")
 	}
 
-	store.Restore("sess-1", "conn-1")
+	issuedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if !store.Restore("sess-1", "conn-1", issuedAt) {
+		t.Fatal("expected restore to succeed")
+	}
 
 	sess, ok := store.Get("sess-1")
 	if !ok {
@@ -267,4 +293,165 @@ func TestSessionStore_Restore(t *testing.T) {
 	if sess.ConnectionID != "conn-1" {
 		t.Errorf("expected conn-1, got %s", sess.ConnectionID)
 	}
+	if !sess.CreatedAt.Equal(issuedAt) {
+		t.Errorf("expected CreatedAt %v from the cookie, got %v", issuedAt, sess.CreatedAt)
+	}
+}
+
+func TestSessionStore_RestorePastMaxAge(t *testing.T) {
+	store := NewSessionStore()
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	if store.Restore("sess-old", "conn-1", now.Add(-sessionMaxAge-time.Minute)) {
+		t.Error("expected a cookie issued past sessionMaxAge not to restore")
+	}
+	if store.Restore("sess-future", "conn-1", now.Add(time.Hour)) {
+		t.Error("expected a cookie issued in the future not to restore")
+	}
+	if store.Restore("sess-unknown", "conn-1", time.Time{}) {
+		t.Error("expected a cookie without an issued-at time not to restore")
+	}
+	if _, ok := store.Get("sess-old"); ok {
+		t.Error("expected no session after a refused restore")
+	}
+}
+
+func TestSessionStore_RestoreRevoked(t *testing.T) {
+	store := NewSessionStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	rotatedAway, _ := store.Create("conn-1")
+	if _, err := store.Rotate(rotatedAway); err != nil {
+		t.Fatal(err)
+	}
+	loggedOut, _ := store.Create("conn-1")
+	store.Delete(loggedOut)
+	idle, _ := store.Create("conn-1")
+	now = now.Add(sessionIdleTTL + time.Minute)
+	if _, ok := store.Get(idle); ok {
+		t.Fatal("setup: expected idle session to expire")
+	}
+
+	issuedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, id := range map[string]string{"rotated": rotatedAway, "logged out": loggedOut, "idle-expired": idle} {
+		if store.Restore(id, "conn-1", issuedAt) {
+			t.Errorf("expected %s session ID not to restore", name)
+		}
+	}
+}
+
+func TestSessionStore_SlidingExpiry(t *testing.T) {
+	store := NewSessionStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	id, _ := store.Create("conn-1")
+
+	// Each use within the idle window keeps the session alive.
+	for range 3 {
+		now = now.Add(sessionIdleTTL - time.Minute)
+		if _, ok := store.Get(id); !ok {
+			t.Fatalf("session expired at %v despite activity", now)
+		}
+	}
+
+	// Going idle past the window expires it.
+	now = now.Add(sessionIdleTTL + time.Minute)
+	if _, ok := store.Get(id); ok {
+		t.Error("expected idle session to expire")
+	}
+}
+
+func TestSessionStore_MaxAge(t *testing.T) {
+	store := NewSessionStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	id, _ := store.Create("conn-1")
+	for now.Before(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(sessionMaxAge)) {
+		if _, ok := store.Get(id); !ok {
+			t.Fatalf("session expired early at %v", now)
+		}
+		now = now.Add(12 * time.Hour)
+	}
+	now = now.Add(time.Minute)
+	if _, ok := store.Get(id); ok {
+		t.Error("expected session to expire after sessionMaxAge despite activity")
+	}
+}
+
+func TestSessionStore_Rotate(t *testing.T) {
+	store := NewSessionStore()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	oldID, _ := store.Create("conn-1")
+	now = now.Add(time.Hour)
+
+	newID, err := store.Rotate(oldID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newID == oldID {
+		t.Fatal("expected a new session ID")
+	}
+	if _, ok := store.Get(oldID); ok {
+		t.Error("expected old session ID to stop working")
+	}
+	sess, ok := store.Get(newID)
+	if !ok {
+		t.Fatal("expected rotated session to exist")
+	}
+	if sess.ConnectionID != "conn-1" {
+		t.Errorf("expected conn-1, got %s", sess.ConnectionID)
+	}
+	if !sess.CreatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected creation time to carry over, got %v", sess.CreatedAt)
+	}
+
+	if _, err := store.Rotate("nonexistent"); err == nil {
+		t.Error("expected error rotating an unknown session")
+	}
+}
+
+func TestParseCookiePolicy(t *testing.T) {
+	tests := []struct {
+		secure, sameSite string
+		want             CookiePolicy
+		wantErr          bool
+	}{
+		{"", "", DefaultCookiePolicy, false},
+		{"true", "lax", CookiePolicy{Secure: true, SameSite: http.SameSiteLaxMode}, false},
+		{"false", "Strict", CookiePolicy{Secure: false, SameSite: http.SameSiteStrictMode}, false},
+		{"", "none", CookiePolicy{Secure: true, SameSite: http.SameSiteNoneMode}, false},
+		{"false", "none", CookiePolicy{}, true},
+		{"maybe", "", CookiePolicy{}, true},
+		{"", "loose", CookiePolicy{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCookiePolicy(tt.secure, tt.sameSite)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCookiePolicy(%q, %q) error = %v, wantErr %v", tt.secure, tt.sameSite, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCookiePolicy(%q, %q) = %+v, want %+v", tt.secure, tt.sameSite, got, tt.want)
+		}
+	}
+}
+
+func TestCookiePolicy_SetSessionCookie(t *testing.T) {
+	p := CookiePolicy{Secure: true, SameSite: http.SameSiteNoneMode}
+	w := httptest.NewRecorder()
+	p.SetSessionCookie(w, "sess-1", "conn-1", time.Now(), "secret")
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got %d", len(cookies))
+	}
+	if !cookies[0].Secure || cookies[0].SameSite != http.SameSiteNoneMode || !cookies[0].HttpOnly {
+		t.Errorf("cookie = %+v, want Secure, HttpOnly, SameSite=None", cookies[0])
+	}
 }
@@ -0,0 +1,83 @@
+package hosted
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// ClaimSweepInterval is how often the hosted server reopens stale claims.
+const ClaimSweepInterval = time.Hour
+
+// ClaimSweeper periodically reverts stale claims (see sdk.Client.Sweep) in
+// the wastelands of users who have used the server. It acts with each
+// user's own credentials and only on items that user posted, since a
+// poster may always release a claim on their item.
+type ClaimSweeper struct {
+	workspaces func() []*sdk.Workspace
+	interval   time.Duration
+	done       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewClaimSweeper creates a sweeper over the workspaces the resolver has
+// built.
+func NewClaimSweeper(resolver *WorkspaceResolver, interval time.Duration) *ClaimSweeper {
+	return &ClaimSweeper{
+		workspaces: resolver.workspaces,
+		interval:   interval,
+		done:       make(chan struct{}),
+	}
+}
+
+// Start begins the background sweep goroutine.
+func (s *ClaimSweeper) Start() {
+	go s.run()
+}
+
+// Stop halts the background sweep goroutine. Safe to call multiple times.
+func (s *ClaimSweeper) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+func (s *ClaimSweeper) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.sweep()
+		}
+	}
+}
+
+// sweep runs one pass over every known workspace and wasteland, logging a
+// notice for each claim it reopens.
+func (s *ClaimSweeper) sweep() {
+	for _, ws := range s.workspaces() {
+		rig := ws.RigHandle()
+		for _, up := range ws.Upstreams() {
+			client, err := ws.Client(up.Upstream)
+			if err != nil || client.ReadOnly() {
+				continue
+			}
+			result, err := client.Sweep(sdk.SweepInput{PostedBy: rig})
+			if err != nil {
+				slog.Warn("claim sweep failed", "upstream", up.Upstream, "rig", rig, "error", err)
+				continue
+			}
+			for _, c := range result.Claims {
+				if c.Err != nil {
+					slog.Warn("reopening stale claim failed", "upstream", up.Upstream, "wanted_id", c.ID, "error", c.Err)
+					continue
+				}
+				slog.Info("stale claim reopened", "upstream", up.Upstream, "wanted_id", c.ID, "notice", c.Notice())
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package hosted
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// fakeSweepDB serves a claim expiry policy and a claimed-items list, and
+// records the statements executed against it.
+type fakeSweepDB struct {
+	commons.DB // unused methods panic
+
+	mu      sync.Mutex
+	claimed string
+	execs   []string
+}
+
+func (f *fakeSweepDB) Query(sql, _ string) (string, error) {
+	switch {
+	case strings.Contains(sql, "claim_expiry_days"):
+		return "value\n14\n", nil
+	case strings.Contains(sql, "status = 'claimed'"):
+		return f.claimed, nil
+	default:
+		return "", nil
+	}
+}
+
+func (f *fakeSweepDB) Exec(_, _ string, _ commons.Signing, stmts ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.execs = append(f.execs, stmts...)
+	return nil
+}
+
+func (f *fakeSweepDB) CanWildWest() error { return nil }
+
+func TestClaimSweeper_SweepsOwnPosts(t *testing.T) {
+	old := time.Now().UTC().Add(-30 * 24 * time.Hour).Format(time.DateTime)
+	db := &fakeSweepDB{claimed: "id,title,posted_by,claimed_by,updated_at\n" +
+		"w-mine,Mine,alice,bob," + old + "\n" +
+		"w-theirs,Theirs,carol,bob," + old + "\n"}
+
+	ws := sdk.NewWorkspace("alice")
+	ws.Add(sdk.UpstreamInfo{Upstream: "org/db", Mode: "wild-west"},
+		sdk.New(sdk.ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west", NoPush: true}))
+
+	s := &ClaimSweeper{workspaces: func() []*sdk.Workspace { return []*sdk.Workspace{ws} }}
+	s.sweep()
+
+	if len(db.execs) != 1 || !strings.Contains(db.execs[0], "id='w-mine'") {
+		t.Errorf("execs = %v, want only w-mine reopened", db.execs)
+	}
+}
+
+func TestClaimSweeper_StopIdempotent(t *testing.T) {
+	s := NewClaimSweeper(NewWorkspaceResolver(nil, nil), time.Hour)
+	s.Start()
+	s.Stop()
+	s.Stop()
+}
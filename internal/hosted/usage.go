@@ -0,0 +1,197 @@
+package hosted
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultDailyQuota is the number of DoltHub API calls a connection may make
+// per UTC day when the deployment does not configure its own limit.
+const DefaultDailyQuota = 10000
+
+// ErrQuotaExceeded is returned for DoltHub calls made after a connection has
+// used up its daily quota.
+var ErrQuotaExceeded = errors.New("daily DoltHub API quota exceeded")
+
+// quotaError is the ErrQuotaExceeded a refused call returns, carrying the
+// connection's usage at the time so the response can report it.
+type quotaError struct{ usage Usage }
+
+func (e *quotaError) Error() string {
+	return fmt.Sprintf("%s (resets at %s)", ErrQuotaExceeded, e.usage.ResetsAt.Format(time.RFC3339))
+}
+
+func (e *quotaError) Unwrap() error { return ErrQuotaExceeded }
+
+// Usage is a connection's DoltHub API consumption for the current day.
+type Usage struct {
+	Used     int
+	Limit    int // 0 means unlimited
+	ResetsAt time.Time
+}
+
+// Remaining returns how many calls are left today, or -1 when unlimited.
+func (u Usage) Remaining() int {
+	if u.Limit <= 0 {
+		return -1
+	}
+	return max(0, u.Limit-u.Used)
+}
+
+// Exceeded reports whether the quota is used up.
+func (u Usage) Exceeded() bool {
+	return u.Limit > 0 && u.Used >= u.Limit
+}
+
+// UsageTracker counts the DoltHub API calls made on behalf of each Nango
+// connection and enforces a per-day quota. Counts reset at midnight UTC.
+type UsageTracker struct {
+	mu     sync.Mutex
+	limit  int
+	day    time.Time // start of the current counting window
+	counts map[string]int
+	now    func() time.Time
+}
+
+// NewUsageTracker creates a tracker allowing limit calls per connection per
+// day. A limit of 0 or less disables enforcement but still counts calls.
+func NewUsageTracker(limit int) *UsageTracker {
+	return &UsageTracker{
+		limit:  limit,
+		counts: make(map[string]int),
+		now:    time.Now,
+	}
+}
+
+// SetLimit changes the daily quota.
+func (u *UsageTracker) SetLimit(limit int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.limit = limit
+}
+
+// rollover resets the counts when a new UTC day has started. Caller holds mu.
+func (u *UsageTracker) rollover() time.Time {
+	now := u.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !today.Equal(u.day) {
+		u.day = today
+		clear(u.counts)
+	}
+	return today
+}
+
+// Allow charges one call to connectionID. It returns false, without
+// charging, when the connection has already used its quota.
+func (u *UsageTracker) Allow(connectionID string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.rollover()
+	if u.limit > 0 && u.counts[connectionID] >= u.limit {
+		return false
+	}
+	u.counts[connectionID]++
+	return true
+}
+
+// Usage returns connectionID's consumption for the current day.
+func (u *UsageTracker) Usage(connectionID string) Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	today := u.rollover()
+	return Usage{
+		Used:     u.counts[connectionID],
+		Limit:    u.limit,
+		ResetsAt: today.AddDate(0, 0, 1),
+	}
+}
+
+// meteredTransport authenticates DoltHub API requests with a connection's
+// token and charges each one against the connection's daily quota.
+type meteredTransport struct {
+	usage        *UsageTracker
+	connectionID string
+	token        string
+	inner        http.RoundTripper // nil means http.DefaultTransport
+}
+
+// RoundTrip refuses the request with ErrQuotaExceeded once the quota is
+// used up; otherwise it adds the token and forwards the request.
+func (t *meteredTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.usage.Allow(t.connectionID) {
+		return nil, &quotaError{usage: t.usage.Usage(t.connectionID)}
+	}
+	if t.token != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("authorization", "token "+t.token)
+	}
+	inner := t.inner
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	return inner.RoundTrip(req)
+}
+
+// newMeteredClient returns an HTTP client for DoltHub API calls made on
+// behalf of connectionID.
+func newMeteredClient(usage *UsageTracker, connectionID, token string) *http.Client {
+	return &http.Client{
+		Timeout: 60 * time.Second,
+		Transport: &meteredTransport{
+			usage:        usage,
+			connectionID: connectionID,
+			token:        token,
+		},
+	}
+}
+
+// usageResponse is the JSON shape of a connection's quota state, returned
+// by GET /api/auth/usage and in 429 responses.
+type usageResponse struct {
+	Error     string `json:"error,omitempty"`
+	Used      int    `json:"used"`
+	Limit     int    `json:"limit"`               // 0 means unlimited
+	Remaining *int   `json:"remaining,omitempty"` // omitted when unlimited
+	ResetsAt  string `json:"resets_at"`
+}
+
+func toUsageResponse(u Usage) usageResponse {
+	resp := usageResponse{
+		Used:     u.Used,
+		Limit:    u.Limit,
+		ResetsAt: u.ResetsAt.Format(time.RFC3339),
+	}
+	if u.Limit > 0 {
+		remaining := u.Remaining()
+		resp.Remaining = &remaining
+	}
+	return resp
+}
+
+// WriteQuotaError answers an API error caused by the connection's quota
+// running out mid-request with the 429 the auth pre-check sends, so the
+// UI sees the quota instead of a generic upstream failure. Other errors
+// are left to the API (see api.Server.SetErrorHandler).
+func WriteQuotaError(w http.ResponseWriter, err error) bool {
+	var qe *quotaError
+	if !errors.As(err, &qe) {
+		return false
+	}
+	writeQuotaExceeded(w, qe.usage)
+	return true
+}
+
+// writeQuotaExceeded responds 429 with the quota state and a Retry-After
+// pointing at the next reset.
+func writeQuotaExceeded(w http.ResponseWriter, u Usage) {
+	retry := max(1, int(time.Until(u.ResetsAt).Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(retry))
+	resp := toUsageResponse(u)
+	resp.Error = fmt.Sprintf("%s: used %d of %d calls today; resets at %s",
+		ErrQuotaExceeded, u.Used, u.Limit, resp.ResetsAt)
+	writeJSON(w, http.StatusTooManyRequests, resp)
+}
@@ -0,0 +1,234 @@
+package hosted
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestUsageTracker_EnforcesLimit(t *testing.T) {
+	u := NewUsageTracker(2)
+	if !u.Allow("conn-1") || !u.Allow("conn-1") {
+		t.Fatal("expected the first two calls to be allowed")
+	}
+	if u.Allow("conn-1") {
+		t.Error("expected the third call to be refused")
+	}
+	if !u.Allow("conn-2") {
+		t.Error("expected another connection to have its own quota")
+	}
+
+	got := u.Usage("conn-1")
+	if got.Used != 2 || got.Limit != 2 || got.Remaining() != 0 || !got.Exceeded() {
+		t.Errorf("usage = %+v, want 2 of 2 used", got)
+	}
+}
+
+func TestUsageTracker_ResetsDaily(t *testing.T) {
+	u := NewUsageTracker(1)
+	now := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	u.now = func() time.Time { return now }
+
+	u.Allow("conn-1")
+	if u.Allow("conn-1") {
+		t.Fatal("expected quota to be used up")
+	}
+	if got := u.Usage("conn-1").ResetsAt; !got.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ResetsAt = %v, want next midnight UTC", got)
+	}
+
+	now = now.Add(time.Hour)
+	if !u.Allow("conn-1") {
+		t.Error("expected a fresh quota after midnight UTC")
+	}
+}
+
+func TestUsageTracker_Unlimited(t *testing.T) {
+	u := NewUsageTracker(0)
+	for range 100 {
+		if !u.Allow("conn-1") {
+			t.Fatal("expected no limit")
+		}
+	}
+	got := u.Usage("conn-1")
+	if got.Used != 100 || got.Exceeded() || got.Remaining() != -1 {
+		t.Errorf("usage = %+v, want 100 counted and unlimited", got)
+	}
+}
+
+func TestMeteredTransport(t *testing.T) {
+	var gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+
+	usage := NewUsageTracker(1)
+	client := newMeteredClient(usage, "conn-1", "secret-token")
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if gotAuth != "token secret-token" {
+		t.Errorf("authorization = %q, want the connection's token", gotAuth)
+	}
+
+	_, err = client.Get(ts.URL)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("second call error = %v, want ErrQuotaExceeded", err)
+	}
+	if got := usage.Usage("conn-1").Used; got != 1 {
+		t.Errorf("used = %d, want refused calls not to be counted", got)
+	}
+}
+
+func TestWriteQuotaError(t *testing.T) {
+	usage := NewUsageTracker(1)
+	usage.Allow("conn-1")
+	client := newMeteredClient(usage, "conn-1", "")
+	_, callErr := client.Get("http://dolthub.invalid/api")
+	// The backend wraps transport failures before they reach the API.
+	err := fmt.Errorf("querying wanted: %w", &commons.NetworkError{Err: callErr})
+
+	w := httptest.NewRecorder()
+	if !WriteQuotaError(w, err) {
+		t.Fatalf("WriteQuotaError(%v) = false, want the quota answered", err)
+	}
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("status = %d, Retry-After = %q, want 429 with Retry-After", w.Code, w.Header().Get("Retry-After"))
+	}
+	var body usageResponse
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Used != 1 || body.Limit != 1 {
+		t.Errorf("body = %+v, want the connection's usage", body)
+	}
+
+	if WriteQuotaError(httptest.NewRecorder(), errors.New("upstream unavailable")) {
+		t.Error("WriteQuotaError answered an unrelated error")
+	}
+}
+
+// newQuotaTestServer wires the hosted auth routes and middleware around a
+// fake Nango with one joined wasteland.
+func newQuotaTestServer(t *testing.T, limit int) (*SessionStore, *UsageTracker, *httptest.Server) {
+	t.Helper()
+
+	meta := &UserMetadata{
+		RigHandle:  "alice",
+		Wastelands: []WastelandConfig{{Upstream: "hop/wl-commons", ForkOrg: "alice-org", ForkDB: "wl-commons", Mode: "pr"}},
+	}
+	nangoTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/connection/conn-1" {
+			resp := nangoConnectionResponse{ConnectionID: "conn-1"}
+			b, _ := json.Marshal(meta)
+			resp.Metadata = json.RawMessage(b)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	t.Cleanup(nangoTS.Close)
+
+	nango := NewNangoClient(NangoConfig{BaseURL: nangoTS.URL, SecretKey: "nango-secret", IntegrationID: "dolthub"})
+	sessions := NewSessionStore()
+	resolver := NewWorkspaceResolver(nango, sessions)
+	resolver.SetDailyQuota(limit)
+	server := NewServer(resolver, sessions, nango, testSecret, "")
+
+	inner := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/auth/usage", server.handleUsage)
+	mux.Handle("/", server.AuthMiddleware(inner))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return sessions, resolver.usage, ts
+}
+
+func TestAuthMiddleware_QuotaExceeded(t *testing.T) {
+	sessions, usage, ts := newQuotaTestServer(t, 1)
+	sessionID, _ := sessions.Create("conn-1")
+	usage.Allow("conn-1")
+
+	req, _ := http.NewRequest("GET", ts.URL+"/api/wanted", nil)
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 429, got %d: %s", resp.StatusCode, string(body))
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+	var body usageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body.Error, "quota exceeded") || body.Used != 1 || body.Limit != 1 || body.ResetsAt == "" {
+		t.Errorf("body = %+v, want an informative quota error", body)
+	}
+}
+
+func TestHandleUsage(t *testing.T) {
+	sessions, usage, ts := newQuotaTestServer(t, 5)
+	sessionID, _ := sessions.Create("conn-1")
+	usage.Allow("conn-1")
+	usage.Allow("conn-1")
+
+	req, _ := http.NewRequest("GET", ts.URL+"/api/auth/usage", nil)
+	req.AddCookie(&http.Cookie{Name: cookieName, Value: SignSessionCookie(sessionID, "conn-1", time.Now(), testSecret)})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, string(body))
+	}
+	var body usageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Used != 2 || body.Limit != 5 || body.Remaining == nil || *body.Remaining != 3 {
+		t.Errorf("body = %+v, want 2 of 5 used", body)
+	}
+}
+
+func TestHandleUsage_NotAuthenticated(t *testing.T) {
+	_, _, ts := newQuotaTestServer(t, 5)
+
+	resp, err := http.Get(ts.URL + "/api/auth/usage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+}
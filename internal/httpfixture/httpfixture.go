@@ -0,0 +1,292 @@
+// Package httpfixture records real HTTP interactions into sanitized JSON
+// fixtures and replays them, so tests of the DoltHub backend and provider
+// cover real API responses without network access.
+//
+// Tests obtain a client with Client. By default it replays the fixture at
+// the given path; with WL_RECORD_FIXTURES=1 it sends requests to the real
+// API (authenticated with DOLTHUB_TOKEN) and rewrites the fixture when the
+// test finishes.
+package httpfixture
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecordEnv is the environment variable that switches Client to recording.
+const RecordEnv = "WL_RECORD_FIXTURES"
+
+// Redacted replaces secret values in recorded fixtures.
+const Redacted = "REDACTED"
+
+// Interaction is one recorded request and its response. Request headers are
+// never recorded; only the response Content-Type is kept.
+type Interaction struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	RequestBody  string `json:"request_body,omitempty"`
+	Status       int    `json:"status"`
+	ContentType  string `json:"content_type,omitempty"`
+	ResponseBody string `json:"response_body"`
+}
+
+// Fixture is an ordered list of recorded interactions.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads a fixture from path.
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing fixture %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Save writes the fixture to path, creating parent directories as needed.
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Recorder is an http.RoundTripper that forwards requests to Transport and
+// records each interaction. Secrets are replaced with Redacted in recorded
+// URLs and bodies.
+type Recorder struct {
+	// Transport performs the real requests. Nil means http.DefaultTransport.
+	Transport http.RoundTripper
+	// Header is added to every outgoing request (e.g. credentials) and is
+	// never recorded.
+	Header http.Header
+	// Secrets are redacted from recorded URLs and bodies.
+	Secrets []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(reqBody))
+	for k, vs := range r.Header {
+		if out.Header.Get(k) == "" {
+			out.Header[k] = vs
+		}
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Fixtures hold bodies as text, so compressed responses are recorded
+	// decoded, and the caller gets the decoded response too.
+	if isGzip(resp.Header) {
+		if respBody, err = gunzip(respBody); err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		resp.Header.Del("Content-Encoding")
+		resp.ContentLength = int64(len(respBody))
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Method:       req.Method,
+		URL:          r.redact(req.URL.String()),
+		RequestBody:  r.redact(string(reqBody)),
+		Status:       resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: r.redact(string(respBody)),
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Fixture returns the interactions recorded so far.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Fixture{Interactions: append([]Interaction(nil), r.interactions...)}
+}
+
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return s
+}
+
+// Replayer is an http.RoundTripper that answers requests from a fixture.
+// Each interaction is used at most once, in recorded order, matched on
+// method, URL and (when recorded) request body. Responses are never
+// compressed. A request with no match fails instead of reaching the
+// network.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer serving the fixture's interactions.
+func NewReplayer(f *Fixture) *Replayer {
+	return &Replayer{
+		interactions: f.Interactions,
+		used:         make([]bool, len(f.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	url := req.URL.String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, in := range p.interactions {
+		if p.used[i] || in.Method != req.Method || in.URL != url {
+			continue
+		}
+		if in.RequestBody != "" && in.RequestBody != string(reqBody) {
+			continue
+		}
+		p.used[i] = true
+		header := http.Header{}
+		if in.ContentType != "" {
+			header.Set("Content-Type", in.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+			StatusCode:    in.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(in.ResponseBody)),
+			ContentLength: int64(len(in.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("httpfixture: no recorded response for %s %s", req.Method, url)
+}
+
+// Unused returns the interactions that have not been replayed.
+func (p *Replayer) Unused() []Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []Interaction
+	for i, in := range p.interactions {
+		if !p.used[i] {
+			out = append(out, in)
+		}
+	}
+	return out
+}
+
+// Client returns an HTTP client for a test backed by the fixture at path.
+//
+// In replay mode (the default) the fixture must exist, and the test fails
+// if any recorded interaction is left unused. With RecordEnv set, requests
+// go to the real API with DOLTHUB_TOKEN as credentials, and the sanitized
+// fixture is written to path when the test passes.
+func Client(t testing.TB, path string) *http.Client {
+	t.Helper()
+
+	if os.Getenv(RecordEnv) != "" {
+		token := os.Getenv("DOLTHUB_TOKEN")
+		if token == "" {
+			t.Skipf("%s is set but DOLTHUB_TOKEN is not", RecordEnv)
+		}
+		rec := &Recorder{
+			Header:  http.Header{"Authorization": {"token " + token}},
+			Secrets: []string{token, os.Getenv("DOLTHUB_SESSION_TOKEN")},
+		}
+		t.Cleanup(func() {
+			if t.Failed() {
+				return
+			}
+			if err := rec.Fixture().Save(path); err != nil {
+				t.Errorf("saving fixture: %v", err)
+			}
+		})
+		return &http.Client{Transport: rec}
+	}
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("loading fixture (record it with %s=1): %v", RecordEnv, err)
+	}
+	rep := NewReplayer(f)
+	t.Cleanup(func() {
+		for _, in := range rep.Unused() {
+			t.Errorf("fixture interaction not replayed: %s %s", in.Method, in.URL)
+		}
+	})
+	return &http.Client{Transport: rep}
+}
+
+// readBody drains *body and replaces it with a re-readable copy.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// isGzip reports whether header marks its body as gzipped.
+func isGzip(header http.Header) bool {
+	return strings.EqualFold(header.Get("Content-Encoding"), "gzip")
+}
+
+// gunzip decompresses a gzipped response body.
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("httpfixture: reading gzipped body: %w", err)
+	}
+	return io.ReadAll(zr)
+}
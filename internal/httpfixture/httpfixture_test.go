@@ -0,0 +1,133 @@
+package httpfixture
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_SanitizesAndReplays(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "token s3cret" {
+			t.Errorf("Authorization = %q, want recorder credentials", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `,"session":"sess-9"}`))
+	}))
+	defer srv.Close()
+
+	rec := &Recorder{
+		Header:  http.Header{"Authorization": {"token s3cret"}},
+		Secrets: []string{"s3cret", "sess-9"},
+	}
+	client := &http.Client{Transport: rec}
+	resp, err := client.Post(srv.URL+"/q?key=s3cret", "application/json", strings.NewReader(`"hi"`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	live, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(live) != `{"echo":"hi","session":"sess-9"}` {
+		t.Errorf("live body = %s", live)
+	}
+
+	path := filepath.Join(t.TempDir(), "fx.json")
+	if err := rec.Fixture().Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(f.Interactions) != 1 {
+		t.Fatalf("recorded %d interactions, want 1", len(f.Interactions))
+	}
+	in := f.Interactions[0]
+	if strings.Contains(in.URL, "s3cret") || strings.Contains(in.ResponseBody, "sess-9") {
+		t.Errorf("secrets leaked into fixture: %+v", in)
+	}
+	if in.ContentType != "application/json" || in.Status != 200 {
+		t.Errorf("interaction = %+v", in)
+	}
+
+	// Replay the sanitized interaction without the server.
+	srv.Close()
+	rep := NewReplayer(f)
+	replay := &http.Client{Transport: rep}
+	resp, err = replay.Post(in.URL, "application/json", strings.NewReader(`"hi"`))
+	if err != nil {
+		t.Fatalf("replay Post: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != in.ResponseBody || resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("replayed %s (%s)", body, resp.Header.Get("Content-Type"))
+	}
+	if len(rep.Unused()) != 0 {
+		t.Errorf("Unused = %v, want none", rep.Unused())
+	}
+}
+
+func TestReplayer_MatchesInOrderOnce(t *testing.T) {
+	rep := NewReplayer(&Fixture{Interactions: []Interaction{
+		{Method: "GET", URL: "https://example.com/op", Status: 200, ResponseBody: "pending"},
+		{Method: "GET", URL: "https://example.com/op", Status: 200, ResponseBody: "done"},
+		{Method: "POST", URL: "https://example.com/op", RequestBody: "a", Status: 201, ResponseBody: "made a"},
+	}})
+	client := &http.Client{Transport: rep}
+
+	for _, want := range []string{"pending", "done"} {
+		resp, err := client.Get("https://example.com/op")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if string(body) != want {
+			t.Errorf("body = %q, want %q", body, want)
+		}
+	}
+	if _, err := client.Get("https://example.com/op"); err == nil {
+		t.Error("third Get succeeded, want exhausted fixture error")
+	}
+	if _, err := client.Post("https://example.com/op", "text/plain", strings.NewReader("b")); err == nil {
+		t.Error("POST with a different body matched")
+	}
+	if unused := rep.Unused(); len(unused) != 1 || unused[0].RequestBody != "a" {
+		t.Errorf("Unused = %v, want the POST", unused)
+	}
+}
+
+func TestRecorder_DecodesGzip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(`{"echo":` + string(body) + `}`))
+		_ = zw.Close()
+	}))
+	defer srv.Close()
+
+	rec := &Recorder{}
+	req, _ := http.NewRequest("POST", srv.URL, strings.NewReader(`"hi"`))
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := (&http.Client{Transport: rec}).Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	live, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(live) != `{"echo":"hi"}` || resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("live body = %q (Content-Encoding %q), want it decoded", live, resp.Header.Get("Content-Encoding"))
+	}
+
+	in := rec.Fixture().Interactions[0]
+	if in.RequestBody != `"hi"` || in.ResponseBody != `{"echo":"hi"}` {
+		t.Errorf("recorded %q -> %q, want decoded bodies", in.RequestBody, in.ResponseBody)
+	}
+}
@@ -0,0 +1,130 @@
+package i18n
+
+// catalogEN is the reference catalog: every key used by wl has an English
+// message, which is what other locales fall back to. Statuses keep their
+// stored spelling so English output matches the database and --json.
+var catalogEN = map[string]string{
+	"status.open":      "open",
+	"status.claimed":   "claimed",
+	"status.in_review": "in_review",
+	"status.completed": "completed",
+	"status.withdrawn": "withdrawn",
+
+	"filter.all":    "all",
+	"sort.priority": "priority",
+	"sort.newest":   "newest",
+	"sort.alpha":    "alpha",
+
+	"tui.title":           "Wasteland Board",
+	"tui.filter.status":   "Status",
+	"tui.filter.type":     "Type",
+	"tui.filter.mine":     "Mine",
+	"tui.filter.sort":     "Sort",
+	"tui.filter.priority": "Priority",
+	"tui.filter.project":  "Project",
+	"tui.filter.search":   "Search",
+	"tui.filter.group":    "Group",
+	"tui.on":              "ON",
+	"tui.off":             "OFF",
+
+	"hint.label":      "Hint",
+	"hint.not_joined": "Run 'wl join <org/db>' to join a wasteland, or 'wl create <org/db>' to start one.",
+	"hint.ambiguous":  "Use --wasteland <org/db> to select which wasteland.",
+	"hint.doctor":     "Run 'wl doctor' to check your setup.",
+	"hint.secret":     "Wasteland databases are shared; remove the secret, or rerun with --allow-secrets if it isn't one.",
+
+	"next.post":         "Next: others can claim this. Browse: wl browse",
+	"next.claim":        "Next: do the work, then: wl done %s --evidence <url>",
+	"next.unclaim":      "Next: item is back on the board. Browse: wl browse",
+	"next.done":         "Next: wait for review. Check: wl status %s",
+	"next.accept":       "Next: stamp issued. View: wl status %s",
+	"next.reject":       "Next: claimer can fix and resubmit: wl done %s --evidence <url>",
+	"next.close":        "Next: item completed. View: wl status %s",
+	"next.browse":       "Next: wl browse to see the board",
+	"next.infer_run":    "Next: wl infer run %s",
+	"next.infer_verify": "Next: wl infer verify %s",
+}
+
+var catalogDE = map[string]string{
+	"status.open":      "offen",
+	"status.claimed":   "vergeben",
+	"status.in_review": "in Prüfung",
+	"status.completed": "erledigt",
+	"status.withdrawn": "zurückgezogen",
+
+	"filter.all":    "alle",
+	"sort.priority": "Priorität",
+	"sort.newest":   "neueste",
+	"sort.alpha":    "alphabetisch",
+
+	"tui.title":           "Wasteland-Board",
+	"tui.filter.status":   "Status",
+	"tui.filter.type":     "Typ",
+	"tui.filter.mine":     "Meine",
+	"tui.filter.sort":     "Sortierung",
+	"tui.filter.priority": "Priorität",
+	"tui.filter.project":  "Projekt",
+	"tui.filter.search":   "Suche",
+	"tui.filter.group":    "Gruppe",
+	"tui.on":              "AN",
+	"tui.off":             "AUS",
+
+	"hint.label":      "Tipp",
+	"hint.not_joined": "Mit 'wl join <org/db>' einem Wasteland beitreten oder mit 'wl create <org/db>' eines gründen.",
+	"hint.ambiguous":  "Mit --wasteland <org/db> das Wasteland auswählen.",
+	"hint.doctor":     "Mit 'wl doctor' die Einrichtung prüfen.",
+	"hint.secret":     "Wasteland-Datenbanken sind geteilt: das Geheimnis entfernen oder, falls es keins ist, mit --allow-secrets wiederholen.",
+
+	"next.post":         "Weiter: andere können den Eintrag übernehmen. Ansehen: wl browse",
+	"next.claim":        "Weiter: Arbeit erledigen, dann: wl done %s --evidence <url>",
+	"next.unclaim":      "Weiter: der Eintrag ist wieder auf dem Board. Ansehen: wl browse",
+	"next.done":         "Weiter: auf die Prüfung warten. Stand: wl status %s",
+	"next.accept":       "Weiter: Stempel vergeben. Ansehen: wl status %s",
+	"next.reject":       "Weiter: nachbessern und erneut einreichen: wl done %s --evidence <url>",
+	"next.close":        "Weiter: Eintrag erledigt. Ansehen: wl status %s",
+	"next.browse":       "Weiter: wl browse zeigt das Board",
+	"next.infer_run":    "Weiter: wl infer run %s",
+	"next.infer_verify": "Weiter: wl infer verify %s",
+}
+
+var catalogES = map[string]string{
+	"status.open":      "abierto",
+	"status.claimed":   "asignado",
+	"status.in_review": "en revisión",
+	"status.completed": "completado",
+	"status.withdrawn": "retirado",
+
+	"filter.all":    "todos",
+	"sort.priority": "prioridad",
+	"sort.newest":   "recientes",
+	"sort.alpha":    "alfabético",
+
+	"tui.title":           "Tablero del Wasteland",
+	"tui.filter.status":   "Estado",
+	"tui.filter.type":     "Tipo",
+	"tui.filter.mine":     "Míos",
+	"tui.filter.sort":     "Orden",
+	"tui.filter.priority": "Prioridad",
+	"tui.filter.project":  "Proyecto",
+	"tui.filter.search":   "Buscar",
+	"tui.filter.group":    "Agrupar",
+	"tui.on":              "SÍ",
+	"tui.off":             "NO",
+
+	"hint.label":      "Sugerencia",
+	"hint.not_joined": "Ejecuta 'wl join <org/db>' para unirte a un wasteland, o 'wl create <org/db>' para crear uno.",
+	"hint.ambiguous":  "Usa --wasteland <org/db> para elegir el wasteland.",
+	"hint.doctor":     "Ejecuta 'wl doctor' para revisar tu configuración.",
+	"hint.secret":     "Las bases de datos del wasteland son compartidas: quita el secreto o, si no lo es, vuelve a ejecutar con --allow-secrets.",
+
+	"next.post":         "Siguiente: otros pueden reclamarlo. Ver: wl browse",
+	"next.claim":        "Siguiente: haz el trabajo y luego: wl done %s --evidence <url>",
+	"next.unclaim":      "Siguiente: el elemento vuelve al tablero. Ver: wl browse",
+	"next.done":         "Siguiente: espera la revisión. Consulta: wl status %s",
+	"next.accept":       "Siguiente: sello emitido. Ver: wl status %s",
+	"next.reject":       "Siguiente: corrige y vuelve a enviar: wl done %s --evidence <url>",
+	"next.close":        "Siguiente: elemento completado. Ver: wl status %s",
+	"next.browse":       "Siguiente: wl browse para ver el tablero",
+	"next.infer_run":    "Siguiente: wl infer run %s",
+	"next.infer_verify": "Siguiente: wl infer verify %s",
+}
@@ -0,0 +1,183 @@
+// Package i18n translates the user-facing labels wl shows in the CLI and
+// TUI — wanted statuses, filter names, next-step hints and error hints —
+// so a Gas Town can present its board in its own language.
+//
+// Messages are looked up by key in the active locale's catalog, then in
+// the locale's base language ("pt" for "pt-br"), then in English. A key
+// missing everywhere is returned as-is. Built-in catalogs cover English,
+// German and Spanish; JSON files in a locales directory add new languages
+// or override built-in messages.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Fallback is the locale used when no catalog matches.
+const Fallback = "en"
+
+var (
+	mu       sync.RWMutex
+	current  = Fallback
+	catalogs = map[string]map[string]string{
+		"en": catalogEN,
+		"de": catalogDE,
+		"es": catalogES,
+	}
+)
+
+// Normalize canonicalizes a locale name: "de_DE.UTF-8" becomes "de-de"
+// and "C" or "POSIX" become "".
+func Normalize(loc string) string {
+	loc = strings.TrimSpace(loc)
+	if i := strings.IndexAny(loc, ".@"); i >= 0 {
+		loc = loc[:i]
+	}
+	loc = strings.ToLower(strings.ReplaceAll(loc, "_", "-"))
+	if loc == "c" || loc == "posix" {
+		return ""
+	}
+	return loc
+}
+
+// Detect picks the locale to use. WL_LOCALE wins, then the configured
+// locale, then the standard LC_ALL, LC_MESSAGES and LANG variables.
+// It returns "" when none is set.
+func Detect(configured string, getenv func(string) string) string {
+	candidates := []string{getenv("WL_LOCALE"), configured, getenv("LC_ALL"), getenv("LC_MESSAGES"), getenv("LANG")}
+	for _, c := range candidates {
+		if loc := Normalize(c); loc != "" {
+			return loc
+		}
+	}
+	return ""
+}
+
+// SetLocale makes loc the active locale and returns the locale whose
+// catalog will actually be used: loc itself, its base language, or
+// Fallback.
+func SetLocale(loc string) string {
+	mu.Lock()
+	defer mu.Unlock()
+	current = resolveLocked(Normalize(loc))
+	return current
+}
+
+// Locale returns the active locale.
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Locales returns the locales with a catalog, sorted.
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	out := make([]string, 0, len(catalogs))
+	for loc := range catalogs {
+		out = append(out, loc)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// T returns the message for key in the active locale. With args, the
+// message is used as a fmt format string.
+func T(key string, args ...any) string {
+	msg, ok := lookup(key)
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Status returns the display label for a wanted status. Statuses without
+// a message are shown as stored.
+func Status(status string) string {
+	if status == "" {
+		return T("filter.all")
+	}
+	if msg, ok := lookup("status." + status); ok {
+		return msg
+	}
+	return status
+}
+
+// LoadDir merges every <locale>.json file in dir into the catalogs. Each
+// file is a flat JSON object of message keys to strings. A missing
+// directory is not an error.
+func LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("reading locale file: %w", err)
+		}
+		var msgs map[string]string
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return fmt.Errorf("parsing locale file %s: %w", filepath.Base(p), err)
+		}
+		Register(strings.TrimSuffix(filepath.Base(p), ".json"), msgs)
+	}
+	return nil
+}
+
+// Register adds msgs to the catalog for loc, overriding existing keys.
+func Register(loc string, msgs map[string]string) {
+	loc = Normalize(loc)
+	if loc == "" {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	cat := make(map[string]string, len(catalogs[loc])+len(msgs))
+	for k, v := range catalogs[loc] {
+		cat[k] = v
+	}
+	for k, v := range msgs {
+		cat[k] = v
+	}
+	catalogs[loc] = cat
+}
+
+func lookup(key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, loc := range []string{current, base(current), Fallback} {
+		if msg, ok := catalogs[loc][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// resolveLocked returns loc, its base language, or Fallback, whichever
+// has a catalog first. mu must be held.
+func resolveLocked(loc string) string {
+	if _, ok := catalogs[loc]; ok {
+		return loc
+	}
+	if _, ok := catalogs[base(loc)]; ok {
+		return base(loc)
+	}
+	return Fallback
+}
+
+// base returns the language part of loc ("pt" for "pt-br").
+func base(loc string) string {
+	lang, _, _ := strings.Cut(loc, "-")
+	return lang
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The active locale and catalogs are package state, so these tests are
+// not parallel and restore English when they finish.
+
+func TestNormalize(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"de_DE.UTF-8", "de-de"},
+		{"es", "es"},
+		{"pt_BR@euro", "pt-br"},
+		{"C", ""},
+		{"POSIX", ""},
+		{" ", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	env := map[string]string{"LANG": "es_ES.UTF-8"}
+	getenv := func(k string) string { return env[k] }
+
+	if got := Detect("", getenv); got != "es-es" {
+		t.Errorf("Detect from LANG = %q, want es-es", got)
+	}
+	if got := Detect("de", getenv); got != "de" {
+		t.Errorf("configured locale should beat LANG, got %q", got)
+	}
+	env["WL_LOCALE"] = "en"
+	if got := Detect("de", getenv); got != "en" {
+		t.Errorf("WL_LOCALE should beat config, got %q", got)
+	}
+	if got := Detect("", func(string) string { return "" }); got != "" {
+		t.Errorf("Detect with nothing set = %q, want empty", got)
+	}
+}
+
+func TestSetLocale_FallsBack(t *testing.T) {
+	defer SetLocale(Fallback)
+
+	if got := SetLocale("de_AT.UTF-8"); got != "de" {
+		t.Errorf("SetLocale(de_AT) = %q, want base language de", got)
+	}
+	if got := Status("open"); got != "offen" {
+		t.Errorf("Status(open) in de = %q", got)
+	}
+	if got := T("next.done", "w-1"); got != "Weiter: auf die Prüfung warten. Stand: wl status w-1" {
+		t.Errorf("T(next.done) in de = %q", got)
+	}
+
+	if got := SetLocale("xx"); got != Fallback {
+		t.Errorf("SetLocale(xx) = %q, want %q", got, Fallback)
+	}
+	if got := Status("in_review"); got != "in_review" {
+		t.Errorf("Status(in_review) in en = %q", got)
+	}
+	if got := Status("archived"); got != "archived" {
+		t.Errorf("unknown status should pass through, got %q", got)
+	}
+	if got := Status(""); got != "all" {
+		t.Errorf("Status(\"\") = %q, want all", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key = %q, want the key", got)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	defer SetLocale(Fallback)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "fr.json"), []byte(`{"status.open": "ouvert"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	if got := SetLocale("fr_FR"); got != "fr" {
+		t.Fatalf("SetLocale(fr_FR) = %q, want fr", got)
+	}
+	if got := Status("open"); got != "ouvert" {
+		t.Errorf("Status(open) in fr = %q", got)
+	}
+	if got := Status("claimed"); got != "claimed" {
+		t.Errorf("keys missing from fr should fall back to English, got %q", got)
+	}
+
+	if err := LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("LoadDir on a missing dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadDir(dir); err == nil {
+		t.Error("LoadDir should fail on malformed JSON")
+	}
+}
+
+func TestCatalogsCoverEnglishKeys(t *testing.T) {
+	for loc, cat := range map[string]map[string]string{"de": catalogDE, "es": catalogES} {
+		for key := range catalogEN {
+			if _, ok := cat[key]; !ok {
+				t.Errorf("%s catalog is missing %q", loc, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// windowsToast shows a toast through the WinRT notification API, reading
+// the text from the environment so it needs no quoting.
+const windowsToast = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$x = $t.GetElementsByTagName('text')
+$x.Item(0).AppendChild($t.CreateTextNode($env:WL_NOTIFY_TITLE)) > $null
+$x.Item(1).AppendChild($t.CreateTextNode($env:WL_NOTIFY_MESSAGE)) > $null
+$n = [Windows.UI.Notifications.ToastNotification]::new($t)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe').Show($n)`
+
+// Desktop shows a desktop notification: notify-send on Linux and the BSDs,
+// osascript on macOS and a toast on Windows.
+func Desktop(ctx context.Context, title, message string) error {
+	cmd := desktopCommand(ctx, runtime.GOOS, title, message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func desktopCommand(ctx context.Context, goos, title, message string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		// Passed as arguments rather than spliced into the script.
+		return exec.CommandContext(ctx, "osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, message)
+	case "windows":
+		cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToast)
+		cmd.Env = append(os.Environ(), "WL_NOTIFY_TITLE="+title, "WL_NOTIFY_MESSAGE="+message)
+		return cmd
+	default:
+		return exec.CommandContext(ctx, "notify-send", "--app-name=wl", title, message)
+	}
+}
@@ -0,0 +1,172 @@
+// Package notify follows the wanted items a rig cares about — the ones it
+// watches, posted or claimed — and reports when one changes state, on the
+// desktop or wherever the caller sends it.
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// Item is what a snapshot remembers about one followed item.
+type Item struct {
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	ClaimedBy string `json:"claimed_by,omitempty"`
+}
+
+// Snapshot holds the followed items by wanted ID as last seen.
+type Snapshot map[string]Item
+
+// Change is a followed item whose status or claimant moved since the last
+// snapshot.
+type Change struct {
+	ID   string
+	From Item
+	To   Item
+	Gone bool // the item no longer exists
+}
+
+// String describes the change for a notification, e.g.
+// `w-1 "Fix docs": claimed → in_review`.
+func (c Change) String() string {
+	if c.Gone {
+		return fmt.Sprintf("%s %q was removed", c.ID, c.From.Title)
+	}
+	s := fmt.Sprintf("%s %q: %s → %s", c.ID, c.To.Title, c.From.Status, c.To.Status)
+	if c.From.Status == c.To.Status {
+		s = fmt.Sprintf("%s %q: %s", c.ID, c.To.Title, c.To.Status)
+	}
+	if c.To.ClaimedBy != "" && c.To.ClaimedBy != c.From.ClaimedBy {
+		s += " by " + c.To.ClaimedBy
+	}
+	return s
+}
+
+// Diff returns how the items in prev changed in cur, ordered by ID. Items
+// new to cur aren't changes; they just start being followed.
+func Diff(prev Snapshot, cur []commons.WantedSummary) []Change {
+	seen := map[string]bool{}
+	var changes []Change
+	for _, w := range cur {
+		seen[w.ID] = true
+		before, ok := prev[w.ID]
+		if !ok {
+			continue
+		}
+		now := itemOf(w)
+		if now.Status != before.Status || now.ClaimedBy != before.ClaimedBy {
+			changes = append(changes, Change{ID: w.ID, From: before, To: now})
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(prev)) {
+		if !seen[id] {
+			changes = append(changes, Change{ID: id, From: prev[id], Gone: true})
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b Change) int { return strings.Compare(a.ID, b.ID) })
+	return changes
+}
+
+func itemOf(w commons.WantedSummary) Item {
+	return Item{Title: w.Title, Status: w.Status, ClaimedBy: w.ClaimedBy}
+}
+
+// Tracker compares the followed items with the snapshot saved at Path and
+// moves the snapshot forward on each Check.
+type Tracker struct {
+	Path    string   // snapshot file
+	Rig     string   // the rig whose posted and claimed items are followed
+	Watched []string // wanted IDs followed regardless of owner
+	// Fetch reads the current state of ids plus the rig's own live items
+	// (see sdk.Client.Followed).
+	Fetch func(ids []string) ([]commons.WantedSummary, error)
+}
+
+// Check returns what changed since the last check. The first check only
+// records a baseline.
+func (t *Tracker) Check() ([]Change, error) {
+	prev, err := Load(t.Path)
+	if err != nil {
+		return nil, err
+	}
+	ids := slices.Clone(t.Watched)
+	for id := range prev {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	cur, err := t.Fetch(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	if prev != nil {
+		changes = Diff(prev, cur)
+	}
+	next := Snapshot{}
+	for _, w := range cur {
+		if t.follows(w) {
+			next[w.ID] = itemOf(w)
+		}
+	}
+	if err := Save(t.Path, next); err != nil {
+		return changes, err
+	}
+	return changes, nil
+}
+
+// follows reports whether w stays in the snapshot: it's watched, or it's
+// the rig's own and not yet finished.
+func (t *Tracker) follows(w commons.WantedSummary) bool {
+	if slices.Contains(t.Watched, w.ID) {
+		return true
+	}
+	mine := w.PostedBy == t.Rig || w.ClaimedBy == t.Rig
+	return mine && (w.Status == "open" || w.Status == "claimed" || w.Status == "in_review")
+}
+
+// Load reads the snapshot at path, or nil if none was saved yet.
+func Load(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing notify snapshot %s: %w", path, err)
+	}
+	if s == nil {
+		s = Snapshot{}
+	}
+	return s, nil
+}
+
+// Save writes s to path, replacing it atomically.
+func Save(path string, s Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
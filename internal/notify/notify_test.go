@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	prev := Snapshot{
+		"w-1": {Title: "Fix docs", Status: "claimed", ClaimedBy: "alice"},
+		"w-2": {Title: "Add tests", Status: "open"},
+		"w-3": {Title: "Gone", Status: "open"},
+		"w-4": {Title: "Same", Status: "open"},
+	}
+	cur := []commons.WantedSummary{
+		{ID: "w-1", Title: "Fix docs", Status: "in_review", ClaimedBy: "alice"},
+		{ID: "w-2", Title: "Add tests", Status: "claimed", ClaimedBy: "bob"},
+		{ID: "w-4", Title: "Same", Status: "open"},
+		{ID: "w-5", Title: "New", Status: "open"},
+	}
+
+	var got []string
+	for _, c := range Diff(prev, cur) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		`w-1 "Fix docs": claimed → in_review`,
+		`w-2 "Add tests": open → claimed by bob`,
+		`w-3 "Gone" was removed`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestChangeString_ClaimantOnly(t *testing.T) {
+	t.Parallel()
+	c := Change{ID: "w-1", From: Item{Title: "T", Status: "claimed", ClaimedBy: "alice"}, To: Item{Title: "T", Status: "claimed", ClaimedBy: "bob"}}
+	if got, want := c.String(), `w-1 "T": claimed by bob`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+	board := map[string]commons.WantedSummary{
+		"w-1": {ID: "w-1", Title: "Mine", Status: "claimed", ClaimedBy: "alice"},
+		"w-2": {ID: "w-2", Title: "Watched", Status: "open", PostedBy: "bob"},
+		"w-3": {ID: "w-3", Title: "Other", Status: "open", PostedBy: "bob"},
+	}
+	var fetched []string
+	tr := &Tracker{
+		Path:    filepath.Join(t.TempDir(), "notify", "hop", "wl-commons.json"),
+		Rig:     "alice",
+		Watched: []string{"w-2"},
+		Fetch: func(ids []string) ([]commons.WantedSummary, error) {
+			fetched = ids
+			var out []commons.WantedSummary
+			for _, id := range []string{"w-1", "w-2", "w-3"} {
+				w := board[id]
+				mine := w.ClaimedBy == "alice" && w.Status != "completed"
+				if slices.Contains(ids, id) || mine {
+					out = append(out, w)
+				}
+			}
+			return out, nil
+		},
+	}
+
+	// First check: baseline only.
+	changes, err := tr.Check()
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("first Check = %v, %v; want no changes", changes, err)
+	}
+
+	// The claim is accepted: it leaves the rig's live items but is still
+	// reported, once.
+	board["w-1"] = commons.WantedSummary{ID: "w-1", Title: "Mine", Status: "completed", ClaimedBy: "alice"}
+	changes, err = tr.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(fetched, []string{"w-1", "w-2"}) {
+		t.Errorf("fetched %v, want the watched and snapshot IDs", fetched)
+	}
+	if len(changes) != 1 || changes[0].ID != "w-1" || changes[0].To.Status != "completed" {
+		t.Fatalf("changes = %+v, want w-1 completed", changes)
+	}
+
+	snap, err := Load(tr.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snap["w-1"]; ok {
+		t.Error("finished item still in the snapshot")
+	}
+	if _, ok := snap["w-2"]; !ok {
+		t.Error("watched item missing from the snapshot")
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	t.Parallel()
+	s, err := Load(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil || s != nil {
+		t.Fatalf("Load = %v, %v; want nil, nil", s, err)
+	}
+}
+
+func TestDesktopCommand(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		goos string
+		bin  string
+		want string // an argument or environment entry the command carries
+	}{
+		{"linux", "notify-send", "w-1 done"},
+		{"darwin", "osascript", "w-1 done"},
+		{"windows", "powershell", "WL_NOTIFY_MESSAGE=w-1 done"},
+	}
+	for _, tt := range tests {
+		cmd := desktopCommand(ctx, tt.goos, "wasteland", "w-1 done")
+		if filepath.Base(cmd.Path) != tt.bin && cmd.Args[0] != tt.bin {
+			t.Errorf("%s: command = %v, want %s", tt.goos, cmd.Args, tt.bin)
+		}
+		if !slices.Contains(cmd.Args, tt.want) && !slices.Contains(cmd.Env, tt.want) {
+			t.Errorf("%s: %q not passed (args %v)", tt.goos, tt.want, cmd.Args)
+		}
+	}
+}
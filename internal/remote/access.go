@@ -0,0 +1,136 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Auth describes the credentials a provider is using.
+type Auth struct {
+	User   string   // account the credentials belong to; "" when unknown
+	Scopes []string // OAuth scopes; nil when the provider doesn't report them
+}
+
+// HasScope reports whether the credentials carry any of scopes. Credentials
+// whose scopes aren't reported are assumed to.
+func (a *Auth) HasScope(scopes ...string) bool {
+	if a.Scopes == nil {
+		return true
+	}
+	for _, s := range scopes {
+		if slices.Contains(a.Scopes, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RepoAccess is what a provider reports about a database or repository
+// for the configured credentials.
+type RepoAccess struct {
+	Exists    bool
+	CanPush   bool
+	PushKnown bool // false when the provider can't tell whether pushes will succeed
+}
+
+// AuthError reports credentials that are missing or that the provider
+// rejected.
+type AuthError struct {
+	Provider string
+	Message  string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s credentials: %s", e.Provider, e.Message)
+}
+
+// AccessChecker is implemented by providers that can report what the
+// configured credentials may do. wl doctor uses it to explain failed
+// pushes and PRs before they happen.
+type AccessChecker interface {
+	// CheckAuth reports who the credentials belong to.
+	CheckAuth() (*Auth, error)
+
+	// CheckRepo reports whether org/db is visible and writable.
+	CheckRepo(org, db string) (*RepoAccess, error)
+}
+
+// CheckAuth reports whether a DoltHub API token is configured. DoltHub
+// has no identity endpoint for API tokens, so a bad token surfaces as an
+// *AuthError from CheckRepo instead.
+func (d *DoltHubProvider) CheckAuth() (*Auth, error) {
+	if d.token == "" && d.httpClient == nil {
+		return nil, &AuthError{Provider: "DoltHub", Message: "DOLTHUB_TOKEN not set"}
+	}
+	return &Auth{}, nil
+}
+
+// CheckRepo reports whether org/db exists on DoltHub. Pushes go through
+// dolt's own credentials rather than the API token, so push access is
+// never known.
+func (d *DoltHubProvider) CheckRepo(org, db string) (*RepoAccess, error) {
+	url := fmt.Sprintf("%s/%s/%s/main", dolthubAPIBase, org, db)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("authorization", "token "+d.token)
+
+	resp, err := d.getClient(10 * time.Second).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("DoltHub request failed: %w", err)
+	}
+	_ = resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &RepoAccess{Exists: true}, nil
+	case http.StatusUnauthorized:
+		return nil, &AuthError{Provider: "DoltHub", Message: "token rejected (HTTP 401)"}
+	}
+	return &RepoAccess{}, nil
+}
+
+// CheckAuth reports the gh user and the OAuth scopes of its token.
+// Fine-grained tokens report no scopes.
+func (g *GitHubProvider) CheckAuth() (*Auth, error) {
+	login, err := g.gh("api", "user", "--jq", ".login")
+	if err != nil {
+		return nil, &AuthError{Provider: "GitHub", Message: "gh is not authenticated (" + err.Error() + ")"}
+	}
+	auth := &Auth{User: strings.TrimSpace(string(login))}
+	if headers, err := g.gh("api", "--include", "user"); err == nil {
+		for _, line := range strings.Split(string(headers), "\n") {
+			name, value, ok := strings.Cut(line, ":")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "X-OAuth-Scopes") {
+				continue
+			}
+			auth.Scopes = []string{}
+			for _, s := range strings.Split(value, ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					auth.Scopes = append(auth.Scopes, s)
+				}
+			}
+		}
+	}
+	return auth, nil
+}
+
+// CheckRepo reports whether org/db is visible to the gh user and whether
+// the user can push to it.
+func (g *GitHubProvider) CheckRepo(org, db string) (*RepoAccess, error) {
+	out, err := g.gh("api", fmt.Sprintf("repos/%s/%s", org, db), "--jq", ".permissions.push")
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		switch {
+		case strings.Contains(msg, "http 404"):
+			return &RepoAccess{}, nil
+		case strings.Contains(msg, "http 401"):
+			return nil, &AuthError{Provider: "GitHub", Message: "token rejected (HTTP 401)"}
+		}
+		return nil, fmt.Errorf("checking %s/%s on GitHub: %w", org, db, err)
+	}
+	return &RepoAccess{Exists: true, CanPush: strings.TrimSpace(string(out)) == "true", PushKnown: true}, nil
+}
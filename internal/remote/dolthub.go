@@ -63,19 +63,32 @@ func (d *DoltHubProvider) getClient(timeout time.Duration) *http.Client {
 	return &http.Client{Timeout: timeout}
 }
 
-// ForkRequiredError is returned when the user needs to manually fork on DoltHub.
+// ForkRequiredError is returned when the user needs to manually fork on
+// DoltHub or GitHub.
 type ForkRequiredError struct {
 	UpstreamOrg string
 	UpstreamDB  string
 	ForkOrg     string
+	Provider    string // "github", or "" for DoltHub
 }
 
 func (e *ForkRequiredError) Error() string {
-	return fmt.Sprintf("fork %s/%s not found under %s on DoltHub", e.UpstreamOrg, e.UpstreamDB, e.ForkOrg)
+	return fmt.Sprintf("fork %s/%s not found under %s on %s", e.UpstreamOrg, e.UpstreamDB, e.ForkOrg, e.ProviderName())
 }
 
-// ForkURL returns the DoltHub URL where the user can fork the database.
+// ProviderName returns the display name of the service hosting the fork.
+func (e *ForkRequiredError) ProviderName() string {
+	if e.Provider == "github" {
+		return "GitHub"
+	}
+	return "DoltHub"
+}
+
+// ForkURL returns the URL where the user can fork the database.
 func (e *ForkRequiredError) ForkURL() string {
+	if e.Provider == "github" {
+		return fmt.Sprintf("https://github.com/%s/%s/fork", e.UpstreamOrg, e.UpstreamDB)
+	}
 	return fmt.Sprintf("%s/%s/%s", dolthubRepoBase, e.UpstreamOrg, e.UpstreamDB)
 }
 
@@ -320,6 +333,56 @@ func (d *DoltHubProvider) databaseExists(org, db string) bool {
 	return resp.StatusCode == 200
 }
 
+// CreateRepo creates an empty database org/db on DoltHub. A database that
+// already exists is left alone.
+func (d *DoltHubProvider) CreateRepo(org, db string, opts RepoOptions) error {
+	visibility := "public"
+	if opts.Private {
+		visibility = "private"
+	}
+	reqBody, err := json.Marshal(map[string]string{
+		"ownerName":   org,
+		"repoName":    db,
+		"description": opts.Description,
+		"visibility":  visibility,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling create database request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", dolthubAPIBase+"/database", bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("creating database request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("authorization", "token "+d.token)
+
+	resp, err := d.getClient(30 * time.Second).Do(req)
+	if err != nil {
+		return fmt.Errorf("DoltHub create database request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading DoltHub create database response: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		var result struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil || !strings.EqualFold(result.Status, "error") {
+			return nil
+		}
+		respBody = []byte(result.Message)
+	}
+	if strings.Contains(strings.ToLower(string(respBody)), "already exists") {
+		return nil
+	}
+	return fmt.Errorf("DoltHub create database error (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
+
 // graphqlRequest is the JSON body sent to the GraphQL endpoint.
 type graphqlRequest struct {
 	Query     string         `json:"query"`
@@ -454,6 +517,41 @@ func (d *DoltHubProvider) FindPR(upstreamOrg, db, forkOrg, fromBranch string) (p
 	return "", ""
 }
 
+// PRState returns the state ("open", "merged" or "closed") of the PR from
+// forkOrg's fromBranch, or "" if there is none. When several PRs were
+// opened from the branch, an open one wins, then a merged one.
+func (d *DoltHubProvider) PRState(upstreamOrg, db, forkOrg, fromBranch string) string {
+	pulls, err := d.listPulls(upstreamOrg, db)
+	if err != nil {
+		return ""
+	}
+
+	found := map[string]bool{}
+	for _, pr := range pulls {
+		detailURL := fmt.Sprintf("%s/%s/%s/pulls/%s", dolthubAPIBase, upstreamOrg, db, pr.PullID)
+		detail, err := d.dolthubGet(detailURL)
+		if err != nil {
+			continue
+		}
+		var prDetail struct {
+			FromBranch      string `json:"from_branch"`
+			FromBranchOwner string `json:"from_branch_owner"`
+		}
+		if err := json.Unmarshal(detail, &prDetail); err != nil {
+			continue
+		}
+		if prDetail.FromBranch == fromBranch && prDetail.FromBranchOwner == forkOrg {
+			found[strings.ToLower(pr.State)] = true
+		}
+	}
+	for _, state := range []string{"open", "merged", "closed"} {
+		if found[state] {
+			return state
+		}
+	}
+	return ""
+}
+
 // dolthubGet performs a GET request to the DoltHub API. Adds auth if a token is set.
 func (d *DoltHubProvider) dolthubGet(url string) ([]byte, error) {
 	req, err := http.NewRequest("GET", url, nil)
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/gastownhall/wasteland/internal/httpfixture"
 )
 
 func TestDoltHubProvider_ForkGraphQL(t *testing.T) {
@@ -471,6 +473,51 @@ func TestDoltHubProvider_FindPR_Pagination(t *testing.T) {
 	}
 }
 
+func TestDoltHubProvider_PRState(t *testing.T) {
+	detail := func(branch, owner string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"from_branch":       branch,
+				"from_branch_owner": owner,
+			})
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/org/db/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"pulls": []map[string]any{
+				{"pull_id": "1", "state": "closed"},
+				{"pull_id": "2", "state": "merged"},
+				{"pull_id": "3", "state": "closed"},
+			},
+		})
+	})
+	mux.HandleFunc("/org/db/pulls/1", detail("wl/alice/w-1", "alice"))
+	mux.HandleFunc("/org/db/pulls/2", detail("wl/alice/w-1", "alice"))
+	mux.HandleFunc("/org/db/pulls/3", detail("wl/alice/w-2", "alice"))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	dolthubAPIBase = server.URL
+
+	provider := NewDoltHubProvider("token")
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"wl/alice/w-1", "merged"},
+		{"wl/alice/w-2", "closed"},
+		{"wl/alice/w-3", ""},
+	}
+	for _, tt := range tests {
+		if got := provider.PRState("org", "db", "alice", tt.branch); got != tt.want {
+			t.Errorf("PRState(%s) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
 func TestDoltHubProvider_ListPendingWantedIDs(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/upstream-org/wl-commons/pulls", func(w http.ResponseWriter, r *http.Request) {
@@ -959,3 +1006,112 @@ func TestDoltHubProvider_ListPendingWantedIDs_PRFromMain(t *testing.T) {
 		t.Errorf("expected w-com-002 status=completed, got %+v", pending)
 	}
 }
+
+// TestDoltHubProvider_Replay runs the PR endpoints against recorded DoltHub
+// responses. Re-record with WL_RECORD_FIXTURES=1 DOLTHUB_TOKEN=...
+func TestDoltHubProvider_Replay(t *testing.T) {
+	// Other tests point the base URLs at local servers without restoring them.
+	oldAPI, oldRepo := dolthubAPIBase, dolthubRepoBase
+	dolthubAPIBase = "https://www.dolthub.com/api/v1alpha1"
+	dolthubRepoBase = "https://www.dolthub.com/repositories"
+	t.Cleanup(func() { dolthubAPIBase, dolthubRepoBase = oldAPI, oldRepo })
+
+	p := NewDoltHubProviderWithClient(httpfixture.Client(t, "testdata/dolthub_replay.json"))
+
+	prURL, prID := p.FindPR("hop", "wl-commons", "wl-fixtures", "wl/fixture-rig/w-fx01")
+	if prID != "43" || prURL != "https://www.dolthub.com/repositories/hop/wl-commons/pulls/43" {
+		t.Errorf("FindPR = (%q, %q), want PR 43", prURL, prID)
+	}
+
+	got, err := p.CreatePR("wl-fixtures", "hop", "wl-commons", "wl/fixture-rig/w-fx03", "wl claim: w-fx03", "Claimed by fixture-rig")
+	if err != nil {
+		t.Fatalf("CreatePR: %v", err)
+	}
+	if want := "https://www.dolthub.com/repositories/hop/wl-commons/pulls/44"; got != want {
+		t.Errorf("CreatePR = %q, want %q", got, want)
+	}
+}
+
+func TestDoltHubProvider_CreateRepo(t *testing.T) {
+	var got map[string]string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/database" {
+			w.WriteHeader(404)
+			return
+		}
+		if r.Header.Get("authorization") != "token api-token" {
+			t.Errorf("expected auth header, got %q", r.Header.Get("authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		switch got["repoName"] {
+		case "taken":
+			_, _ = w.Write([]byte(`{"status":"Error","message":"database already exists"}`))
+		case "denied":
+			w.WriteHeader(403)
+			_, _ = w.Write([]byte(`{"status":"Error","message":"permission denied"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"Success","owner_name":"alice","repository_name":"wl-commons"}`))
+		}
+	}))
+	defer apiServer.Close()
+
+	oldAPI := dolthubAPIBase
+	dolthubAPIBase = apiServer.URL
+	defer func() { dolthubAPIBase = oldAPI }()
+
+	provider := NewDoltHubProvider("api-token")
+	if err := provider.CreateRepo("alice", "wl-commons", RepoOptions{Description: "Our board", Private: true}); err != nil {
+		t.Fatalf("CreateRepo: %v", err)
+	}
+	if got["ownerName"] != "alice" || got["description"] != "Our board" || got["visibility"] != "private" {
+		t.Errorf("request = %v", got)
+	}
+	if err := provider.CreateRepo("alice", "taken", RepoOptions{}); err != nil {
+		t.Errorf("CreateRepo(existing) = %v, want nil", err)
+	}
+	if got["visibility"] != "public" {
+		t.Errorf("visibility = %q, want public by default", got["visibility"])
+	}
+	if err := provider.CreateRepo("alice", "denied", RepoOptions{}); err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Errorf("CreateRepo(denied) = %v, want HTTP 403 error", err)
+	}
+}
+
+func TestDoltHubProvider_CheckAccess(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("authorization") != "token api-token":
+			w.WriteHeader(401)
+		case r.URL.Path == "/hop/wl-commons/main":
+			w.WriteHeader(200)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer apiServer.Close()
+
+	oldAPI := dolthubAPIBase
+	dolthubAPIBase = apiServer.URL
+	defer func() { dolthubAPIBase = oldAPI }()
+
+	var authErr *AuthError
+	if _, err := NewDoltHubProvider("").CheckAuth(); !errors.As(err, &authErr) {
+		t.Errorf("CheckAuth without token = %v, want *AuthError", err)
+	}
+
+	p := NewDoltHubProvider("api-token")
+	if _, err := p.CheckAuth(); err != nil {
+		t.Errorf("CheckAuth = %v", err)
+	}
+	if got, err := p.CheckRepo("hop", "wl-commons"); err != nil || !got.Exists || got.PushKnown {
+		t.Errorf("CheckRepo(upstream) = %+v, %v", got, err)
+	}
+	if got, err := p.CheckRepo("alice", "wl-commons"); err != nil || got.Exists {
+		t.Errorf("CheckRepo(missing) = %+v, %v", got, err)
+	}
+	if _, err := NewDoltHubProvider("bad-token").CheckRepo("hop", "wl-commons"); !errors.As(err, &authErr) {
+		t.Errorf("CheckRepo(bad token) = %v, want *AuthError", err)
+	}
+}
@@ -0,0 +1,77 @@
+package remote
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ForkOwner is an account the user could fork an upstream into.
+type ForkOwner struct {
+	Name    string
+	Org     bool // an organization rather than the user's own account
+	HasFork bool // a fork of the upstream already exists under it
+}
+
+// ForkOwnerLister is implemented by providers that can list the accounts
+// the configured credentials may fork into, so wl join can offer them
+// instead of making the user guess --fork-org.
+type ForkOwnerLister interface {
+	// ListForkOwners returns the user's own account and organizations,
+	// those already holding a fork of upstreamOrg/upstreamDB first.
+	ListForkOwners(upstreamOrg, upstreamDB string) ([]ForkOwner, error)
+}
+
+// ListForkOwners lists the gh user's account and organizations, marking
+// those that already have a fork of upstreamOrg/upstreamDB. Organizations
+// whose membership is private may be missing unless gh has the read:org
+// scope.
+func (g *GitHubProvider) ListForkOwners(upstreamOrg, upstreamDB string) ([]ForkOwner, error) {
+	login, err := g.gh("api", "user", "--jq", ".login")
+	if err != nil {
+		return nil, &AuthError{Provider: "GitHub", Message: "gh is not authenticated (" + err.Error() + ")"}
+	}
+	owners := []ForkOwner{{Name: strings.TrimSpace(string(login))}}
+
+	orgs, err := g.gh("api", "user/orgs", "--paginate", "--jq", ".[].login")
+	if err != nil {
+		return nil, fmt.Errorf("listing GitHub organizations: %w", err)
+	}
+	for _, org := range ghLines(orgs) {
+		owners = append(owners, ForkOwner{Name: org, Org: true})
+	}
+
+	// A missing upstream just means there are no forks to find yet.
+	forks, _ := g.gh("api", fmt.Sprintf("repos/%s/%s/forks", upstreamOrg, upstreamDB), "--paginate", "--jq", ".[].owner.login")
+	for _, owner := range ghLines(forks) {
+		for i := range owners {
+			if strings.EqualFold(owners[i].Name, owner) {
+				owners[i].HasFork = true
+			}
+		}
+	}
+	slices.SortStableFunc(owners, func(a, b ForkOwner) int {
+		return cmp.Compare(boolRank(a.HasFork), boolRank(b.HasFork))
+	})
+	return owners, nil
+}
+
+// ghLines splits gh --jq output into its non-empty lines.
+func ghLines(out []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// boolRank orders true before false.
+func boolRank(b bool) int {
+	if b {
+		return 0
+	}
+	return 1
+}
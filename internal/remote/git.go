@@ -87,6 +87,26 @@ func (g *GitProvider) Fork(fromOrg, fromDB, toOrg string) error {
 	return nil
 }
 
+// CreateRepo initializes an empty bare git repo for org/db. Options are
+// ignored; local repos have no description or visibility.
+func (g *GitProvider) CreateRepo(org, db string, _ RepoOptions) error {
+	destPath := filepath.Join(g.baseDir, org, db+".git")
+	if info, err := os.Stat(destPath); err == nil && info.IsDir() {
+		return nil
+	}
+	if err := os.MkdirAll(destPath, 0o755); err != nil {
+		return fmt.Errorf("creating git repo dir: %w", err)
+	}
+	cmd := exec.Command("git", "init", "--bare", destPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git init --bare %s: %w (%s)", destPath, err, strings.TrimSpace(string(output)))
+	}
+	if err := seedBareGitRepo(destPath); err != nil {
+		return fmt.Errorf("seeding bare repo: %w", err)
+	}
+	return nil
+}
+
 // CreatePR is a no-op for git providers (no PR support).
 func (g *GitProvider) CreatePR(_, _, _, _, _, _ string) (string, error) { return "", nil }
 
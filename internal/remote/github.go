@@ -1,18 +1,45 @@
 package remote
 
 import (
+	"bytes"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 // GitHubProvider implements Provider using GitHub repositories as dolt remotes.
 // Dolt can push to and clone from GitHub repos via https:// URLs.
-type GitHubProvider struct{}
+type GitHubProvider struct {
+	// ConfirmFork, when set, is asked before a missing fork is created.
+	// Declining makes Fork return a *ForkRequiredError so the caller can
+	// explain how to fork by hand.
+	ConfirmFork func(upstream, forkOrg string) bool
+
+	gh           func(args ...string) ([]byte, error) // runs the gh CLI; replaced in tests
+	pollInterval time.Duration                        // how often to check for a new fork
+	pollTimeout  time.Duration                        // how long to wait for a new fork
+}
 
 // NewGitHubProvider creates a GitHubProvider.
 func NewGitHubProvider() *GitHubProvider {
-	return &GitHubProvider{}
+	return &GitHubProvider{
+		gh:           runGH,
+		pollInterval: 2 * time.Second,
+		pollTimeout:  2 * time.Minute,
+	}
+}
+
+// runGH runs the gh CLI and returns its stdout. Failures carry gh's stderr.
+func runGH(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("gh", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return out, fmt.Errorf("%w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
 }
 
 // DatabaseURL returns the GitHub HTTPS URL for org/db.
@@ -20,20 +47,77 @@ func (g *GitHubProvider) DatabaseURL(org, db string) string {
 	return fmt.Sprintf("https://github.com/%s/%s.git", org, db)
 }
 
-// Fork creates a fork of fromOrg/fromDB under toOrg on GitHub using the gh CLI.
-// If the fork already exists, this is a no-op (gh handles idempotency).
+// Fork creates a fork of fromOrg/fromDB under toOrg on GitHub via the gh
+// API. An existing fork is left alone. When toOrg is the authenticated
+// user the fork goes to their account, otherwise to the toOrg
+// organization. GitHub creates forks asynchronously, so Fork waits until
+// the new repo is reachable. A fork GitHub refuses to create (no access to
+// the upstream or to toOrg) is reported as a *ForkRequiredError.
 func (g *GitHubProvider) Fork(fromOrg, fromDB, toOrg string) error {
-	sourceRepo := fromOrg + "/" + fromDB
-	cmd := exec.Command("gh", "repo", "fork", sourceRepo, "--org", toOrg, "--clone=false")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		msg := strings.TrimSpace(string(output))
-		// gh reports "already exists" when fork is present — treat as success.
-		lower := strings.ToLower(msg)
-		if strings.Contains(lower, "already exists") {
+	if g.repoExists(toOrg, fromDB) {
+		return nil
+	}
+	forkRequired := &ForkRequiredError{UpstreamOrg: fromOrg, UpstreamDB: fromDB, ForkOrg: toOrg, Provider: "github"}
+	if g.ConfirmFork != nil && !g.ConfirmFork(fromOrg+"/"+fromDB, toOrg) {
+		return forkRequired
+	}
+
+	args := []string{"api", "-X", "POST", fmt.Sprintf("repos/%s/%s/forks", fromOrg, fromDB)}
+	if login, err := g.gh("api", "user", "--jq", ".login"); err != nil || !strings.EqualFold(strings.TrimSpace(string(login)), toOrg) {
+		args = append(args, "-f", "organization="+toOrg)
+	}
+	if _, err := g.gh(args...); err != nil {
+		msg := strings.ToLower(err.Error())
+		switch {
+		case strings.Contains(msg, "already exists"):
+		case strings.Contains(msg, "http 403"), strings.Contains(msg, "http 404"):
+			return forkRequired
+		default:
+			return fmt.Errorf("forking %s/%s to %s on GitHub: %w", fromOrg, fromDB, toOrg, err)
+		}
+	}
+	return g.waitForRepo(toOrg, fromDB)
+}
+
+// repoExists reports whether org/repo is visible to the gh user.
+func (g *GitHubProvider) repoExists(org, repo string) bool {
+	_, err := g.gh("api", fmt.Sprintf("repos/%s/%s", org, repo), "--jq", ".full_name")
+	return err == nil
+}
+
+// waitForRepo polls until org/repo exists or pollTimeout passes.
+func (g *GitHubProvider) waitForRepo(org, repo string) error {
+	deadline := time.Now().Add(g.pollTimeout)
+	for {
+		if g.repoExists(org, repo) {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("fork %s/%s was requested but is not available yet; rerun in a minute", org, repo)
+		}
+		time.Sleep(g.pollInterval)
+	}
+}
+
+// CreateRepo creates an empty repository org/db on GitHub via the gh CLI.
+// A repository that already exists is left alone.
+func (g *GitHubProvider) CreateRepo(org, db string, opts RepoOptions) error {
+	if g.repoExists(org, db) {
+		return nil
+	}
+	visibility := "--public"
+	if opts.Private {
+		visibility = "--private"
+	}
+	args := []string{"repo", "create", org + "/" + db, visibility}
+	if opts.Description != "" {
+		args = append(args, "--description", opts.Description)
+	}
+	if _, err := g.gh(args...); err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "already exists") {
 			return nil
 		}
-		return fmt.Errorf("gh repo fork %s --org %s: %w (%s)", sourceRepo, toOrg, err, msg)
+		return fmt.Errorf("creating %s/%s on GitHub: %w", org, db, err)
 	}
 	return nil
 }
@@ -1,6 +1,12 @@
 package remote
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestGitHubProviderDatabaseURL(t *testing.T) {
 	t.Parallel()
@@ -53,3 +59,225 @@ func TestGitHubProviderType(t *testing.T) {
 		t.Errorf("Type() = %q, want %q", got, "github")
 	}
 }
+
+// fakeGH is a scripted gh CLI: responses maps a call's joined args to its
+// output, or to an error when the output starts with "error:".
+type fakeGH struct {
+	calls     []string
+	responses map[string]string
+	onCall    func(f *fakeGH, call string)
+}
+
+func (f *fakeGH) run(args ...string) ([]byte, error) {
+	call := strings.Join(args, " ")
+	f.calls = append(f.calls, call)
+	if f.onCall != nil {
+		f.onCall(f, call)
+	}
+	out, ok := f.responses[call]
+	if !ok {
+		return nil, errors.New("exit status 1 (gh: Not Found (HTTP 404))")
+	}
+	if msg, isErr := strings.CutPrefix(out, "error:"); isErr {
+		return nil, errors.New(msg)
+	}
+	return []byte(out), nil
+}
+
+func newTestGitHubProvider(gh *fakeGH) *GitHubProvider {
+	p := NewGitHubProvider()
+	p.gh = gh.run
+	p.pollInterval = time.Millisecond
+	p.pollTimeout = 50 * time.Millisecond
+	return p
+}
+
+func TestGitHubProviderFork_Existing(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{
+		"api repos/alice/wl-commons --jq .full_name": "alice/wl-commons",
+	}}
+	if err := newTestGitHubProvider(gh).Fork("hop", "wl-commons", "alice"); err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	if len(gh.calls) != 1 {
+		t.Errorf("existing fork should only be checked, calls = %v", gh.calls)
+	}
+}
+
+func TestGitHubProviderFork_CreatesAndWaits(t *testing.T) {
+	tests := []struct {
+		name     string
+		login    string
+		wantPost string
+	}{
+		{"personal account", "alice", "api -X POST repos/hop/wl-commons/forks"},
+		{"organization", "bob", "api -X POST repos/hop/wl-commons/forks -f organization=alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			gh := &fakeGH{responses: map[string]string{
+				"api user --jq .login": tt.login + "\n",
+				tt.wantPost:            "{}",
+			}}
+			// The fork shows up on the second check after creation.
+			gh.onCall = func(f *fakeGH, call string) {
+				if call == "api repos/alice/wl-commons --jq .full_name" && slices.Contains(f.calls, tt.wantPost) {
+					if polls++; polls == 2 {
+						f.responses[call] = "alice/wl-commons"
+					}
+				}
+			}
+			if err := newTestGitHubProvider(gh).Fork("hop", "wl-commons", "alice"); err != nil {
+				t.Fatalf("Fork: %v (calls %v)", err, gh.calls)
+			}
+			if !slices.Contains(gh.calls, tt.wantPost) {
+				t.Errorf("calls = %v, want %q", gh.calls, tt.wantPost)
+			}
+		})
+	}
+}
+
+func TestGitHubProviderFork_Declined(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{}}
+	p := newTestGitHubProvider(gh)
+	var asked string
+	p.ConfirmFork = func(upstream, forkOrg string) bool {
+		asked = upstream + " -> " + forkOrg
+		return false
+	}
+
+	err := p.Fork("hop", "wl-commons", "alice")
+	var forkErr *ForkRequiredError
+	if !errors.As(err, &forkErr) {
+		t.Fatalf("Fork error = %v, want ForkRequiredError", err)
+	}
+	if asked != "hop/wl-commons -> alice" {
+		t.Errorf("ConfirmFork asked %q", asked)
+	}
+	if forkErr.ForkURL() != "https://github.com/hop/wl-commons/fork" || !strings.Contains(forkErr.Error(), "on GitHub") {
+		t.Errorf("fork error = %v, url %s", forkErr, forkErr.ForkURL())
+	}
+	if len(gh.calls) != 1 {
+		t.Errorf("declined fork should not call the API beyond the check, calls = %v", gh.calls)
+	}
+}
+
+func TestGitHubProviderFork_Errors(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{
+		"api user --jq .login":                   "alice",
+		"api -X POST repos/hop/wl-commons/forks": "error:exit status 1 (gh: Resource not accessible (HTTP 403))",
+	}}
+	var forkErr *ForkRequiredError
+	if err := newTestGitHubProvider(gh).Fork("hop", "wl-commons", "alice"); !errors.As(err, &forkErr) {
+		t.Errorf("HTTP 403 should ask for a manual fork, got %v", err)
+	}
+
+	gh.responses["api -X POST repos/hop/wl-commons/forks"] = "error:exit status 1 (gh: Server Error (HTTP 500))"
+	err := newTestGitHubProvider(gh).Fork("hop", "wl-commons", "alice")
+	if err == nil || errors.As(err, &forkErr) || !strings.Contains(err.Error(), "HTTP 500") {
+		t.Errorf("HTTP 500 should surface, got %v", err)
+	}
+
+	// Created but never reachable: time out with a retry hint.
+	gh.responses["api -X POST repos/hop/wl-commons/forks"] = "{}"
+	err = newTestGitHubProvider(gh).Fork("hop", "wl-commons", "alice")
+	if err == nil || !strings.Contains(err.Error(), "not available yet") {
+		t.Errorf("unreachable fork should time out, got %v", err)
+	}
+}
+
+func TestGitHubProviderCreateRepo(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{
+		"repo create alice/wl-commons --private --description Our board": "https://github.com/alice/wl-commons\n",
+	}}
+	if err := newTestGitHubProvider(gh).CreateRepo("alice", "wl-commons", RepoOptions{Description: "Our board", Private: true}); err != nil {
+		t.Fatalf("CreateRepo: %v (calls %v)", err, gh.calls)
+	}
+
+	// An existing repo is reused without calling create.
+	gh = &fakeGH{responses: map[string]string{
+		"api repos/alice/wl-commons --jq .full_name": "alice/wl-commons",
+	}}
+	if err := newTestGitHubProvider(gh).CreateRepo("alice", "wl-commons", RepoOptions{}); err != nil || len(gh.calls) != 1 {
+		t.Errorf("CreateRepo(existing) = %v, calls %v", err, gh.calls)
+	}
+
+	gh = &fakeGH{responses: map[string]string{
+		"repo create alice/wl-commons --public": "error:exit status 1 (HTTP 403: Resource not accessible)",
+	}}
+	if err := newTestGitHubProvider(gh).CreateRepo("alice", "wl-commons", RepoOptions{}); err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Errorf("CreateRepo error = %v, want the gh failure", err)
+	}
+}
+
+func TestGitHubProviderCheckAuth(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{
+		"api user --jq .login": "alice\n",
+		"api --include user":   "HTTP/2.0 200 OK\nX-Oauth-Scopes: gist, read:org, repo\n\n{}",
+	}}
+	auth, err := newTestGitHubProvider(gh).CheckAuth()
+	if err != nil {
+		t.Fatalf("CheckAuth: %v", err)
+	}
+	if auth.User != "alice" || !slices.Equal(auth.Scopes, []string{"gist", "read:org", "repo"}) || !auth.HasScope("repo") {
+		t.Errorf("auth = %+v", auth)
+	}
+
+	// Fine-grained tokens report no scopes header.
+	gh.responses["api --include user"] = "HTTP/2.0 200 OK\n\n{}"
+	if auth, _ := newTestGitHubProvider(gh).CheckAuth(); auth.Scopes != nil || !auth.HasScope("repo") {
+		t.Errorf("auth without scopes = %+v, want unknown scopes assumed ok", auth)
+	}
+
+	var authErr *AuthError
+	if _, err := newTestGitHubProvider(&fakeGH{}).CheckAuth(); !errors.As(err, &authErr) {
+		t.Errorf("CheckAuth unauthenticated error = %v, want *AuthError", err)
+	}
+}
+
+func TestGitHubProviderCheckRepo(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{
+		"api repos/alice/wl-commons --jq .permissions.push": "true\n",
+		"api repos/hop/wl-commons --jq .permissions.push":   "false\n",
+	}}
+	p := newTestGitHubProvider(gh)
+	if got, err := p.CheckRepo("alice", "wl-commons"); err != nil || !got.Exists || !got.CanPush || !got.PushKnown {
+		t.Errorf("CheckRepo(fork) = %+v, %v", got, err)
+	}
+	if got, err := p.CheckRepo("hop", "wl-commons"); err != nil || !got.Exists || got.CanPush {
+		t.Errorf("CheckRepo(upstream) = %+v, %v", got, err)
+	}
+	if got, err := p.CheckRepo("bob", "wl-commons"); err != nil || got.Exists {
+		t.Errorf("CheckRepo(missing) = %+v, %v", got, err)
+	}
+}
+
+func TestGitHubProviderListForkOwners(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{
+		"api user --jq .login":                                           "alice\n",
+		"api user/orgs --paginate --jq .[].login":                        "acme\nwidgets\n",
+		"api repos/hop/wl-commons/forks --paginate --jq .[].owner.login": "bob\nwidgets\n",
+	}}
+	owners, err := newTestGitHubProvider(gh).ListForkOwners("hop", "wl-commons")
+	if err != nil {
+		t.Fatalf("ListForkOwners: %v", err)
+	}
+	want := []ForkOwner{
+		{Name: "widgets", Org: true, HasFork: true},
+		{Name: "alice"},
+		{Name: "acme", Org: true},
+	}
+	if !slices.Equal(owners, want) {
+		t.Errorf("owners = %+v, want %+v", owners, want)
+	}
+}
+
+func TestGitHubProviderListForkOwners_NotAuthenticated(t *testing.T) {
+	gh := &fakeGH{responses: map[string]string{"api user --jq .login": "error:exit status 4 (gh auth login)"}}
+	_, err := newTestGitHubProvider(gh).ListForkOwners("hop", "wl-commons")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("error = %v, want *AuthError", err)
+	}
+}
@@ -19,3 +19,17 @@ type Provider interface {
 	// Type returns a label for logging ("dolthub", "file", "git").
 	Type() string
 }
+
+// RepoOptions describes an upstream database or repository to create.
+type RepoOptions struct {
+	Description string
+	Private     bool
+}
+
+// RepoCreator is implemented by providers that can create an empty
+// upstream database or repository, so wl create doesn't need one to
+// exist beforehand.
+type RepoCreator interface {
+	// CreateRepo creates org/db. An existing org/db is left alone.
+	CreateRepo(org, db string, opts RepoOptions) error
+}
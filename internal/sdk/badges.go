@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// Badges returns the badges held by handle, newest first.
+func (c *Client) Badges(handle string) ([]commons.BadgeRow, error) {
+	return commons.QueryBadges(c.db, handle)
+}
+
+// BadgeCatalog returns every badge type the wasteland describes or has
+// awarded, with its criteria and holder count.
+func (c *Client) BadgeCatalog() ([]commons.BadgeCatalogEntry, error) {
+	return commons.QueryBadgeCatalog(c.db)
+}
+
+// AwardBadge awards b to b.RigHandle on main and pushes it upstream. The
+// badge type and reason (b.Evidence) are required; a rig can't award
+// itself, and can't be given a badge type it already holds. Awards write
+// straight to the upstream commons, so they need wild-west mode: in
+// practice, a maintainer's direct clone. With sign set the commit is
+// GPG-signed even if signing is off in the config. The stored badge is
+// returned.
+func (c *Client) AwardBadge(b commons.BadgeRow, sign bool) (*commons.BadgeRow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode == "pr" {
+		return nil, &commons.PermissionError{Message: "badges are awarded by maintainers with write access to upstream: use a wild-west (wl join --direct) clone"}
+	}
+	if b.RigHandle == c.rigHandle {
+		return nil, &commons.PermissionError{Message: "cannot award a badge to yourself"}
+	}
+	if err := commons.ValidateBadgeType(b.BadgeType); err != nil {
+		return nil, err
+	}
+	registered, err := commons.RigRegistered(c.db, b.RigHandle)
+	if err != nil {
+		return nil, err
+	}
+	if !registered {
+		return nil, &commons.NotFoundError{Message: fmt.Sprintf("rig %q is not registered in this wasteland", b.RigHandle)}
+	}
+	held, err := commons.QueryBadges(c.db, b.RigHandle)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range held {
+		if h.BadgeType == b.BadgeType {
+			return nil, &commons.ConflictError{Message: fmt.Sprintf("%s already holds the %s badge (awarded %s)", b.RigHandle, b.BadgeType, h.AwardedAt)}
+		}
+	}
+
+	b.AwardedAt = time.Now().UTC().Format("2006-01-02 15:04:05")
+	b.ID = commons.GeneratePrefixedID("b", b.RigHandle, b.BadgeType, c.rigHandle)
+	dml, err := commons.InsertBadgeDML(&b)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("wl badge award: %s to %s by %s", b.BadgeType, b.RigHandle, c.rigHandle)
+	if err := c.checkSecrets(dml); err != nil {
+		return nil, err
+	}
+	if err := c.askPush(msg); err != nil {
+		return nil, err
+	}
+	if err := c.db.Exec("", msg, commons.Signing{Enabled: c.signing || sign, Key: c.signingKey}, dml); err != nil {
+		if commons.IsTableNotFound(err) {
+			return nil, fmt.Errorf("this wasteland has no badges table: run 'wl doctor --fix' to add it")
+		}
+		return nil, err
+	}
+	if !c.noPush {
+		if err := c.push("", msg); err != nil {
+			return nil, err
+		}
+	}
+	return &b, nil
+}
@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestAwardBadge(t *testing.T) {
+	db := newFakeDB()
+	db.rigs = []string{"alice", "bob"}
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	b, err := c.AwardBadge(commons.BadgeRow{RigHandle: "alice", BadgeType: "bug-hunter", Evidence: "fixed the auth race"}, true)
+	if err != nil {
+		t.Fatalf("AwardBadge: %v", err)
+	}
+	if b.ID == "" || b.AwardedAt == "" {
+		t.Errorf("badge = %+v", b)
+	}
+	if len(db.execCalls) != 1 {
+		t.Fatalf("exec calls = %d, want 1", len(db.execCalls))
+	}
+	call := db.execCalls[0]
+	if call.Branch != "" || call.CommitMsg != "wl badge award: bug-hunter to alice by bob" || !call.Signed {
+		t.Errorf("exec = %+v, want signed commit on main", call)
+	}
+	if !strings.Contains(call.Stmts[0], "'alice', 'bug-hunter'") || !strings.Contains(call.Stmts[0], "'fixed the auth race'") {
+		t.Errorf("DML = %s", call.Stmts[0])
+	}
+	if db.pushCalls != 1 {
+		t.Errorf("push calls = %d, want 1", db.pushCalls)
+	}
+}
+
+func TestAwardBadge_Refusals(t *testing.T) {
+	db := newFakeDB()
+	db.rigs = []string{"alice", "bob"}
+	db.badgesCSV = "id,rig_handle,badge_type,awarded_at,evidence\nb-1,alice,mentor,2026-02-01,helped\n"
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	var perm *commons.PermissionError
+	if _, err := c.AwardBadge(commons.BadgeRow{RigHandle: "bob", BadgeType: "x", Evidence: "me"}, false); !errors.As(err, &perm) {
+		t.Errorf("self-award err = %v, want PermissionError", err)
+	}
+	var nf *commons.NotFoundError
+	if _, err := c.AwardBadge(commons.BadgeRow{RigHandle: "carol", BadgeType: "x", Evidence: "why"}, false); !errors.As(err, &nf) {
+		t.Errorf("unknown rig err = %v, want NotFoundError", err)
+	}
+	var conflict *commons.ConflictError
+	if _, err := c.AwardBadge(commons.BadgeRow{RigHandle: "alice", BadgeType: "mentor", Evidence: "again"}, false); !errors.As(err, &conflict) {
+		t.Errorf("duplicate err = %v, want ConflictError", err)
+	}
+	if _, err := c.AwardBadge(commons.BadgeRow{RigHandle: "alice", BadgeType: "new", Evidence: ""}, false); err == nil {
+		t.Error("award without a reason should be rejected")
+	}
+
+	pr := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "pr"})
+	if _, err := pr.AwardBadge(commons.BadgeRow{RigHandle: "alice", BadgeType: "new", Evidence: "why"}, false); !errors.As(err, &perm) {
+		t.Errorf("PR mode err = %v, want PermissionError", err)
+	}
+	if len(db.execCalls) != 0 {
+		t.Errorf("exec calls = %d, want none", len(db.execCalls))
+	}
+}
@@ -1,9 +1,12 @@
 package sdk
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/gastownhall/wasteland/internal/commons"
 )
@@ -25,6 +28,59 @@ func (c *Client) ApplyBranch(branch string) error {
 	return nil
 }
 
+// BranchInfo describes one of the rig's wl/<rig>/* mutation branches.
+type BranchInfo struct {
+	Branch       string
+	WantedID     string
+	Title        string    // item title as of the branch ("" if unreadable)
+	Delta        string    // human-readable delta label ("" if none)
+	PRURL        string    // existing PR URL ("" if none)
+	LastCommitAt time.Time // zero if unknown
+	// Actions are the suggested branch operations, as computed by
+	// ComputeBranchActions: "submit_pr", "apply", "discard".
+	Actions []string
+}
+
+// Branches lists the rig's mutation branches with the context needed to
+// decide what to do with each: item title, delta, PR, age and actions.
+func (c *Client) Branches() ([]BranchInfo, error) {
+	prefix := "wl/" + c.rigHandle + "/"
+	names, err := c.db.Branches(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	dates, err := commons.QueryBranchCommitDates(c.db, prefix)
+	if err != nil {
+		slog.Debug("branch dates unavailable", "error", err)
+	}
+
+	out := make([]BranchInfo, 0, len(names))
+	for _, name := range names {
+		info := BranchInfo{Branch: name, WantedID: extractWantedID(name), LastCommitAt: dates[name]}
+		if info.WantedID != "" {
+			c.describeBranch(&info)
+		}
+		out = append(out, info)
+	}
+	return out, nil
+}
+
+// describeBranch fills in the item-derived fields of info.
+func (c *Client) describeBranch(info *BranchInfo) {
+	state, err := commons.ResolveItemState(c.db, c.rigHandle, info.WantedID)
+	if err != nil {
+		return
+	}
+	r := &DetailResult{Branch: info.Branch, Delta: state.Delta(), PRURL: c.prURL(info.Branch)}
+	if item := state.Effective(); item != nil {
+		info.Title = item.Title
+		r.Actions = commons.AvailableTransitions(item, c.rigHandle)
+	}
+	info.Delta = r.Delta
+	info.PRURL = r.PRURL
+	info.Actions = c.computeBranchActions(r)
+}
+
 // cleanupBranch closes any associated PR and removes the branch.
 // If branch deletion fails (e.g. RemoteDB where DOLT_BRANCH is unsupported),
 // clears item data so DetectBranchOverrides no longer flags it as pending.
@@ -32,14 +88,20 @@ func (c *Client) ApplyBranch(branch string) error {
 func (c *Client) cleanupBranch(branch string) {
 	if c.ClosePR != nil {
 		_ = c.ClosePR(branch)
+		c.notePR(branch, "")
 	}
+	_ = c.removeBranch(branch)
+}
+
+// removeBranch deletes branch locally and on the fork. If deletion isn't
+// supported, it clears the item data from the branch instead, which makes
+// branchStatus="" so DetectBranchOverrides skips it. Caller must hold c.mu.
+func (c *Client) removeBranch(branch string) error {
 	if err := c.db.DeleteBranch(branch); err == nil {
 		_ = c.db.DeleteRemoteBranch(branch)
-		return
+		return nil
 	}
-	// Branch deletion failed — clear item data from the branch instead.
-	// This makes branchStatus="" so DetectBranchOverrides skips it.
-	_ = clearBranchData(c.db, branch)
+	return clearBranchData(c.db, branch)
 }
 
 // DiscardBranch closes any associated PR and removes the mutation branch.
@@ -49,6 +111,7 @@ func (c *Client) DiscardBranch(branch string) error {
 
 	if c.ClosePR != nil {
 		_ = c.ClosePR(branch)
+		c.notePR(branch, "")
 	}
 
 	if err := c.db.DeleteBranch(branch); err == nil {
@@ -70,11 +133,11 @@ func clearBranchData(db commons.DB, branch string) error {
 	esc := commons.EscapeSQL(wantedID)
 	// DoltHub write API accepts only one statement per call.
 	// Delete completions first (FK dependency), then the wanted item.
-	if err := db.Exec(branch, "wl discard: "+wantedID, false,
+	if err := db.Exec(branch, "wl discard: "+wantedID, commons.Signing{},
 		fmt.Sprintf("DELETE FROM completions WHERE wanted_id='%s'", esc)); err != nil {
 		return fmt.Errorf("delete completions: %w", err)
 	}
-	return db.Exec(branch, "wl discard: "+wantedID, false,
+	return db.Exec(branch, "wl discard: "+wantedID, commons.Signing{},
 		fmt.Sprintf("DELETE FROM wanted WHERE id='%s'", esc))
 }
 
@@ -87,12 +150,99 @@ func extractWantedID(branch string) string {
 	return ""
 }
 
-// SubmitPR creates a pull request for the given branch.
+// updateBranch merges the latest upstream main into branch via the
+// UpdateBranch callback. Conflicts are returned as *commons.ConflictError;
+// other failures (e.g. upstream unreachable) are logged and ignored so an
+// offline rig can still push. Caller must hold c.mu.
+func (c *Client) updateBranch(branch string) error {
+	if c.UpdateBranch == nil {
+		return nil
+	}
+	err := c.UpdateBranch(branch)
+	var conflict *commons.ConflictError
+	if errors.As(err, &conflict) {
+		return err
+	}
+	if err != nil {
+		slog.Debug("branch update from upstream skipped", "branch", branch, "error", err)
+	}
+	return nil
+}
+
+// SubmitPR creates a pull request for the given branch, first merging the
+// latest upstream main into it. A branch that conflicts with upstream main
+// is not submitted.
 func (c *Client) SubmitPR(branch string) (string, error) {
 	if c.CreatePR == nil {
 		return "", fmt.Errorf("PR creation not available")
 	}
-	return c.CreatePR(branch)
+	c.mu.Lock()
+	err := c.updateBranch(branch)
+	c.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	url, err := c.CreatePR(branch)
+	if err == nil {
+		c.notePR(branch, url)
+	}
+	return url, err
+}
+
+// SquashPreview describes the single commit a branch would be collapsed
+// into before it is submitted.
+type SquashPreview struct {
+	Branch  string
+	Commits []string // messages of the branch's commits, oldest first
+	Message string   // composed message for the squashed commit
+	Delta   string   // the branch's final delta against main ("" if none)
+	// CanSquash is true when the branch has more than one commit and the
+	// backend supports squashing.
+	CanSquash bool
+}
+
+// PreviewSquash reports the commits on branch and the message and delta
+// squashing it would produce, without changing anything.
+func (c *Client) PreviewSquash(branch string) (*SquashPreview, error) {
+	commits, err := commons.QueryBranchCommits(c.db, branch)
+	if err != nil {
+		return nil, err
+	}
+	p := &SquashPreview{
+		Branch:    branch,
+		Commits:   commits,
+		Message:   commons.SquashMessage(commits),
+		CanSquash: len(commits) > 1 && c.SquashBranch != nil,
+	}
+	if wantedID := extractWantedID(branch); wantedID != "" {
+		if state, err := commons.ResolveItemState(c.db, c.rigHandle, wantedID); err == nil {
+			p.Delta = state.Delta()
+		}
+	}
+	return p, nil
+}
+
+// Squash updates branch from upstream main and collapses it into a single
+// commit with the composed message from PreviewSquash. Branches that can't
+// be squashed (one commit, or no SquashBranch callback) are left as they
+// are. The returned preview describes the branch as it was before
+// squashing.
+func (c *Client) Squash(branch string) (*SquashPreview, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Update first so the squashed commit sits on top of the latest main.
+	if err := c.updateBranch(branch); err != nil {
+		return nil, err
+	}
+	p, err := c.PreviewSquash(branch)
+	if err != nil || !p.CanSquash {
+		return p, err
+	}
+	if err := c.SquashBranch(branch, p.Message); err != nil {
+		return nil, fmt.Errorf("squash %s: %w", branch, err)
+	}
+	return p, nil
 }
 
 // BranchDiff returns a diff for the given branch.
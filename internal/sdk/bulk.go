@@ -0,0 +1,291 @@
+package sdk
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/hooks"
+)
+
+// bulkAction is one action Bulk supports. apply is its single-item
+// mutation, used in PR mode where each item gets its own branch; stmts
+// returns the DML that applies it to one item on main in wild-west mode,
+// plus a notice for that item's result.
+type bulkAction struct {
+	apply func(c *Client, wantedID string) (*MutationResult, error)
+	stmts func(c *Client, wantedID string) (stmts []string, notice string, err error)
+}
+
+// bulkActions maps the actions Bulk supports to their mutations. Only
+// actions that need nothing but an item ID are listed.
+var bulkActions = map[string]bulkAction{
+	"claim": {
+		apply: (*Client).Claim,
+		stmts: func(c *Client, id string) ([]string, string, error) {
+			if err := c.runPreHook(hooks.PreClaim, HookPayload{WantedID: id}); err != nil {
+				return nil, "", err
+			}
+			return c.claimStmts(id), "", nil
+		},
+	},
+	"unclaim": {
+		apply: (*Client).Unclaim,
+		stmts: func(c *Client, id string) ([]string, string, error) {
+			stmts, next, passed := c.releaseClaim(id, []string{commons.UnclaimWantedDML(id)})
+			return stmts, queueNotice(id, next, passed), nil
+		},
+	},
+	"close": {
+		apply: (*Client).Close,
+		stmts: func(_ *Client, id string) ([]string, string, error) {
+			return []string{commons.CloseWantedDML(id)}, "", nil
+		},
+	},
+	"delete": {
+		apply: (*Client).Delete,
+		stmts: func(_ *Client, id string) ([]string, string, error) {
+			return []string{commons.DeleteWantedDML(id)}, "", nil
+		},
+	},
+	"reject": {
+		apply: func(c *Client, id string) (*MutationResult, error) { return c.Reject(id, "") },
+		stmts: func(_ *Client, id string) ([]string, string, error) {
+			return commons.RejectCompletionDML(id), "", nil
+		},
+	},
+}
+
+// BulkActions returns the action names Bulk accepts, sorted.
+func BulkActions() []string {
+	names := make([]string, 0, len(bulkActions))
+	for name := range bulkActions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BulkResult is the outcome of one item in a bulk mutation. Exactly one of
+// Result and Err is set.
+type BulkResult struct {
+	WantedID string
+	Result   *MutationResult
+	Err      error
+}
+
+// Bulk applies one action to many wanted items. Items are processed
+// independently: a failure is recorded in that item's result and does not
+// stop the rest. Duplicate IDs are applied once and share a result. An
+// unknown action is an error for the whole call.
+//
+// In wild-west mode every item's change goes into a single commit and
+// push, and each result is read back from main afterwards; an item whose
+// row didn't change was not in the required state. In PR mode each item
+// gets its own branch, so items are mutated one at a time.
+func (c *Client) Bulk(action string, wantedIDs []string) ([]BulkResult, error) {
+	act, ok := bulkActions[action]
+	if !ok {
+		return nil, fmt.Errorf("unsupported bulk action %q (valid: %s)", action, strings.Join(BulkActions(), ", "))
+	}
+
+	var ids []string
+	for _, id := range wantedIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	var outcomes map[string]BulkResult
+	if c.mode == "pr" {
+		outcomes = make(map[string]BulkResult, len(ids))
+		for _, id := range ids {
+			result, err := act.apply(c, id)
+			outcomes[id] = BulkResult{WantedID: id, Result: result, Err: err}
+		}
+	} else {
+		outcomes = c.bulkWildWest(action, act, ids)
+		if action == "claim" {
+			c.finishBulkClaims(ids, outcomes)
+		}
+	}
+
+	results := make([]BulkResult, len(wantedIDs))
+	for i, id := range wantedIDs {
+		results[i] = outcomes[id]
+	}
+	return results, nil
+}
+
+// bulkWildWest applies act to ids on main in one commit and one push. A
+// failure of the commit or push as a whole is every item's error.
+func (c *Client) bulkWildWest(action string, act bulkAction, ids []string) map[string]BulkResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnSchemaDrift()
+
+	outcomes := make(map[string]BulkResult, len(ids))
+	fail := func(planned []string, err error) map[string]BulkResult {
+		for _, id := range planned {
+			outcomes[id] = BulkResult{WantedID: id, Err: err}
+		}
+		return outcomes
+	}
+	if err := c.db.CanWildWest(); err != nil {
+		return fail(ids, err)
+	}
+	before, err := commons.QueryWantedSummaries(c.db, ids)
+	if err != nil {
+		return fail(ids, err)
+	}
+
+	var (
+		planned []string
+		all     []string
+		notices = make(map[string]string, len(ids))
+	)
+	for _, id := range ids {
+		if _, ok := before[id]; !ok {
+			outcomes[id] = BulkResult{WantedID: id, Err: &commons.NotFoundError{Message: fmt.Sprintf("wanted item %s not found", id)}}
+			continue
+		}
+		stmts, notice, err := act.stmts(c, id)
+		if err != nil {
+			outcomes[id] = BulkResult{WantedID: id, Err: err}
+			continue
+		}
+		planned = append(planned, id)
+		all = append(all, stmts...)
+		notices[id] = notice
+	}
+	if len(planned) == 0 {
+		return outcomes
+	}
+
+	commitMsg := fmt.Sprintf("wl %s: %s", action, strings.Join(planned, ", "))
+	slog.Debug("bulk mutation", "action", action, "items", len(planned), "statements", len(all))
+	if err := c.checkSecrets(all...); err != nil {
+		return fail(planned, err)
+	}
+	if err := c.askPush(commitMsg); err != nil {
+		return fail(planned, err)
+	}
+	if err := c.db.Exec("", commitMsg, c.commitSigning(), all...); err != nil {
+		if commons.IsNothingToCommit(err) {
+			for _, id := range planned {
+				outcomes[id] = BulkResult{WantedID: id, Err: notApplied(action, id)}
+			}
+			return outcomes
+		}
+		return fail(planned, err)
+	}
+	if !c.noPush {
+		if err := c.push("", commitMsg); err != nil {
+			return fail(planned, err)
+		}
+	}
+
+	after, err := commons.QueryWantedSummaries(c.db, planned)
+	if err != nil {
+		return fail(planned, fmt.Errorf("reading back %s: %w", commitMsg, err))
+	}
+	for _, id := range planned {
+		was, now := before[id], after[id]
+		if now.Status == was.Status && now.ClaimedBy == was.ClaimedBy {
+			outcomes[id] = BulkResult{WantedID: id, Err: notApplied(action, id)}
+			continue
+		}
+		item := &commons.WantedItem{
+			ID: now.ID, Title: now.Title, Project: now.Project, Type: now.Type, Priority: now.Priority,
+			PostedBy: now.PostedBy, ClaimedBy: now.ClaimedBy, Status: now.Status, EffortLevel: now.EffortLevel,
+		}
+		hint := notices[id]
+		if c.noPush {
+			hint = joinHints(hint, "changes saved locally (--no-push)")
+		}
+		outcomes[id] = BulkResult{WantedID: id, Result: &MutationResult{
+			WantedID: id,
+			Detail:   &DetailResult{Item: item, Actions: commons.AvailableTransitions(item, c.rigHandle)},
+			Hint:     hint,
+		}}
+	}
+	return outcomes
+}
+
+// finishBulkClaims checks each claim bulkWildWest made for a lost claim
+// race and runs its post-claim hook, as Claim does for a single item.
+func (c *Client) finishBulkClaims(ids []string, outcomes map[string]BulkResult) {
+	for _, id := range ids {
+		out := outcomes[id]
+		if out.Err != nil {
+			continue
+		}
+		if err := c.verifyClaim(id, out.Result); err != nil {
+			outcomes[id] = BulkResult{WantedID: id, Err: err}
+			continue
+		}
+		c.runPostHook(hooks.PostClaim, HookPayload{WantedID: id}, out.Result)
+	}
+}
+
+// notApplied reports a bulk item whose row the action's status guard
+// left unchanged.
+func notApplied(action, wantedID string) error {
+	return &commons.ConflictError{Message: fmt.Sprintf("cannot %s %s: item is not in the required state", action, wantedID)}
+}
+
+// joinHints joins two result hints, either of which may be empty.
+func joinHints(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "; " + b
+}
+
+// BranchResult is the outcome of one branch in a bulk branch operation.
+type BranchResult struct {
+	Branch string
+	Err    error
+}
+
+// ApplyAllBranches merges every branch of the rig's whose suggested
+// actions include "apply" (wild-west branches with changes) into main.
+// Branches are processed independently: a failure is recorded in that
+// branch's result and does not stop the rest. In PR mode no branch is
+// appliable and the result is empty.
+func (c *Client) ApplyAllBranches() ([]BranchResult, error) {
+	return c.eachBranch(func(b BranchInfo) bool {
+		return slices.Contains(b.Actions, "apply")
+	}, c.ApplyBranch)
+}
+
+// DiscardAllBranches discards each of the rig's branches that filter
+// accepts, closing any associated PR; a nil filter selects every branch.
+// Like ApplyAllBranches, failures are recorded per branch.
+func (c *Client) DiscardAllBranches(filter func(BranchInfo) bool) ([]BranchResult, error) {
+	if filter == nil {
+		filter = func(BranchInfo) bool { return true }
+	}
+	return c.eachBranch(filter, c.DiscardBranch)
+}
+
+// eachBranch runs fn on each of the rig's branches that match accepts.
+func (c *Client) eachBranch(match func(BranchInfo) bool, fn func(branch string) error) ([]BranchResult, error) {
+	branches, err := c.Branches()
+	if err != nil {
+		return nil, err
+	}
+	results := []BranchResult{}
+	for _, b := range branches {
+		if !match(b) {
+			continue
+		}
+		results = append(results, BranchResult{Branch: b.Branch, Err: fn(b.Branch)})
+	}
+	return results, nil
+}
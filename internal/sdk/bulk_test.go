@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestBulk_PerItemResults(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "One", Status: "open", Priority: 1, PostedBy: "alice", EffortLevel: "medium"})
+	db.seedItem(fakeItem{ID: "w-2", Title: "Two", Status: "claimed", ClaimedBy: "carol", Priority: 1, PostedBy: "alice"})
+	db.seedItem(fakeItem{ID: "w-3", Title: "Three", Status: "open", Priority: 1, PostedBy: "alice", EffortLevel: "medium"})
+
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	results, err := c.Bulk("claim", []string{"w-1", "w-2", "w-3", "w-1"})
+	if err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	if results[0].Err != nil || results[0].Result.Detail.Item.Status != "claimed" {
+		t.Errorf("w-1 = %+v", results[0])
+	}
+	var conflict *commons.ConflictError
+	if !errors.As(results[1].Err, &conflict) {
+		t.Errorf("w-2 err = %v, want ConflictError", results[1].Err)
+	}
+	if results[2].Err != nil {
+		t.Errorf("w-3 err = %v; a failed item must not stop the rest", results[2].Err)
+	}
+	if results[3].WantedID != "w-1" || results[3].Err != nil {
+		t.Errorf("duplicate w-1 = %+v, want the first result reused", results[3])
+	}
+	if db.pushCalls != 1 {
+		t.Errorf("pushCalls = %d, want 1 (one push for the whole batch)", db.pushCalls)
+	}
+	if len(db.execCalls) != 1 {
+		t.Fatalf("execCalls = %d, want 1 (one commit for the whole batch)", len(db.execCalls))
+	}
+	if msg := db.execCalls[0].CommitMsg; msg != "wl claim: w-1, w-2, w-3" {
+		t.Errorf("commit message = %q", msg)
+	}
+	if db.items["w-3"].ClaimedBy != "bob" || db.items["w-2"].ClaimedBy != "carol" {
+		t.Errorf("w-2 claimed_by = %s, w-3 claimed_by = %s", db.items["w-2"].ClaimedBy, db.items["w-3"].ClaimedBy)
+	}
+}
+
+func TestBulk_NothingApplies(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "One", Status: "claimed", ClaimedBy: "carol", Priority: 1, PostedBy: "alice"})
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	results, err := c.Bulk("claim", []string{"w-1", "w-404"})
+	if err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	var conflict *commons.ConflictError
+	if !errors.As(results[0].Err, &conflict) {
+		t.Errorf("w-1 err = %v, want ConflictError", results[0].Err)
+	}
+	var notFound *commons.NotFoundError
+	if !errors.As(results[1].Err, &notFound) {
+		t.Errorf("w-404 err = %v, want NotFoundError", results[1].Err)
+	}
+	if db.pushCalls != 0 {
+		t.Errorf("pushCalls = %d, want 0 when nothing was committed", db.pushCalls)
+	}
+}
+
+func TestBulk_PRModePerItem(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "One", Status: "open", Priority: 1, PostedBy: "alice", EffortLevel: "medium"})
+	db.seedItem(fakeItem{ID: "w-2", Title: "Two", Status: "open", Priority: 1, PostedBy: "alice", EffortLevel: "medium"})
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "pr"})
+
+	results, err := c.Bulk("claim", []string{"w-1", "w-2"})
+	if err != nil {
+		t.Fatalf("Bulk: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil || r.Result.Branch != commons.BranchName("bob", r.WantedID) {
+			t.Errorf("%s = %+v, want a claim on its own branch", r.WantedID, r)
+		}
+	}
+	if len(db.execCalls) != 2 {
+		t.Errorf("execCalls = %d, want one per item in PR mode", len(db.execCalls))
+	}
+}
+
+func TestBulk_UnknownAction(t *testing.T) {
+	c := New(ClientConfig{DB: newFakeDB(), RigHandle: "bob", Mode: "wild-west"})
+	if _, err := c.Bulk("done", []string{"w-1"}); err == nil {
+		t.Error("Bulk(done) succeeded, want unsupported action error")
+	}
+}
+
+// seedBranches gives bob a branch with a claim on w-1 and a branch for
+// w-9, an item that exists nowhere, plus a branch of alice's.
+func seedBranches(db *fakeDB) {
+	db.seedItem(fakeItem{ID: "w-1", Title: "One", Status: "open", Priority: 1, PostedBy: "alice", EffortLevel: "medium"})
+	db.branches["wl/bob/w-1"] = true
+	db.branchItems["wl/bob/w-1"] = map[string]*fakeItem{
+		"w-1": {ID: "w-1", Title: "One", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice", EffortLevel: "medium"},
+	}
+	db.branches["wl/bob/w-9"] = true
+	db.branches["wl/alice/w-1"] = true
+}
+
+func TestApplyAllBranches(t *testing.T) {
+	db := newFakeDB()
+	seedBranches(db)
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	results, err := c.ApplyAllBranches()
+	if err != nil {
+		t.Fatalf("ApplyAllBranches: %v", err)
+	}
+	if len(results) != 1 || results[0].Branch != "wl/bob/w-1" || results[0].Err != nil {
+		t.Fatalf("results = %+v, want only wl/bob/w-1 applied", results)
+	}
+	if db.items["w-1"].Status != "claimed" {
+		t.Errorf("w-1 on main = %s, want claimed", db.items["w-1"].Status)
+	}
+	if db.branches["wl/bob/w-1"] {
+		t.Error("applied branch should be deleted")
+	}
+	if !db.branches["wl/bob/w-9"] || !db.branches["wl/alice/w-1"] {
+		t.Error("branches without changes, and other rigs' branches, must be left alone")
+	}
+}
+
+func TestApplyAllBranches_PRModeAppliesNothing(t *testing.T) {
+	db := newFakeDB()
+	seedBranches(db)
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "pr"})
+
+	results, err := c.ApplyAllBranches()
+	if err != nil {
+		t.Fatalf("ApplyAllBranches: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("results = %+v, want none in PR mode", results)
+	}
+}
+
+func TestDiscardAllBranches(t *testing.T) {
+	db := newFakeDB()
+	seedBranches(db)
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "pr"})
+
+	results, err := c.DiscardAllBranches(func(b BranchInfo) bool { return len(b.Actions) == 0 })
+	if err != nil {
+		t.Fatalf("DiscardAllBranches: %v", err)
+	}
+	if len(results) != 1 || results[0].Branch != "wl/bob/w-9" || results[0].Err != nil {
+		t.Fatalf("results = %+v, want only the actionless wl/bob/w-9", results)
+	}
+	if db.branches["wl/bob/w-9"] || !db.branches["wl/bob/w-1"] {
+		t.Errorf("branches = %v", db.branches)
+	}
+
+	results, err = c.DiscardAllBranches(nil)
+	if err != nil {
+		t.Fatalf("DiscardAllBranches(nil): %v", err)
+	}
+	if len(results) != 1 || results[0].Branch != "wl/bob/w-1" {
+		t.Errorf("results = %+v, want the remaining wl/bob/w-1", results)
+	}
+	if !db.branches["wl/alice/w-1"] {
+		t.Error("other rigs' branches must be left alone")
+	}
+}
@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// ReviewComments returns the review comments on branch, oldest first.
+func (c *Client) ReviewComments(branch string) ([]commons.ReviewComment, error) {
+	return commons.QueryReviewComments(c.db, branch)
+}
+
+// AddReviewComment stores a review comment on main, authored by this rig,
+// and pushes it so it travels with the database. Branch and Body are
+// required; the wanted ID defaults to the one in the branch name. The
+// stored comment is returned.
+func (c *Client) AddReviewComment(rc commons.ReviewComment) (*commons.ReviewComment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rc.Author = c.rigHandle
+	if rc.WantedID == "" {
+		rc.WantedID = extractWantedID(rc.Branch)
+	}
+	rc.CreatedAt = time.Now().UTC().Format("2006-01-02 15:04:05")
+	rc.ID = commons.GeneratePrefixedID("rc", rc.Branch, rc.Author, rc.Body, rc.Target())
+	dml, err := commons.InsertReviewCommentDML(&rc)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := "wl review comment: " + rc.Branch
+	if err := c.checkSecrets(dml); err != nil {
+		return nil, err
+	}
+	if err := c.askPush(msg); err != nil {
+		return nil, err
+	}
+	if err := c.db.Exec("", msg, c.commitSigning(), dml); err != nil {
+		if commons.IsTableNotFound(err) {
+			return nil, fmt.Errorf("this wasteland has no review_comments table yet: run 'wl doctor --fix' to add it")
+		}
+		return nil, err
+	}
+	if !c.noPush {
+		if err := c.push(rc.WantedID, msg); err != nil {
+			return nil, err
+		}
+	}
+	return &rc, nil
+}
+
+// itemReviewComments loads the review comments about wantedID for the
+// detail view. Failures are logged and yield no comments.
+func (c *Client) itemReviewComments(wantedID string) []commons.ReviewComment {
+	comments, err := commons.QueryItemReviewComments(c.db, wantedID)
+	if err != nil {
+		slog.Debug("loading review comments failed", "wanted_id", wantedID, "error", err)
+	}
+	return comments
+}
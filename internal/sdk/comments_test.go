@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestAddReviewComment(t *testing.T) {
+	db := newFakeDB()
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	rc, err := c.AddReviewComment(commons.ReviewComment{
+		Branch: "wl/alice/w-1", Table: "wanted", RowKey: "w-1", Column: "title", Body: "typo",
+	})
+	if err != nil {
+		t.Fatalf("AddReviewComment: %v", err)
+	}
+	if rc.Author != "bob" || rc.WantedID != "w-1" || rc.ID == "" || rc.CreatedAt == "" {
+		t.Errorf("comment = %+v", rc)
+	}
+	if len(db.execCalls) != 1 {
+		t.Fatalf("exec calls = %d, want 1", len(db.execCalls))
+	}
+	call := db.execCalls[0]
+	if call.Branch != "" || call.CommitMsg != "wl review comment: wl/alice/w-1" {
+		t.Errorf("exec = %+v, want commit on main", call)
+	}
+	if !strings.Contains(call.Stmts[0], "'wanted', 'w-1', 'title', 'bob', 'typo'") {
+		t.Errorf("DML = %s", call.Stmts[0])
+	}
+	if db.pushCalls != 1 {
+		t.Errorf("push calls = %d, want 1", db.pushCalls)
+	}
+}
+
+func TestAddReviewComment_NoPushAndValidation(t *testing.T) {
+	db := newFakeDB()
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west", NoPush: true})
+
+	if _, err := c.AddReviewComment(commons.ReviewComment{Branch: "wl/alice/w-1", Body: "ok"}); err != nil {
+		t.Fatalf("AddReviewComment: %v", err)
+	}
+	if db.pushCalls != 0 {
+		t.Errorf("push calls = %d, want 0 with NoPush", db.pushCalls)
+	}
+	if _, err := c.AddReviewComment(commons.ReviewComment{Branch: "wl/alice/w-1"}); err == nil {
+		t.Error("empty comment should be rejected")
+	}
+}
+
+func TestDetail_IncludesReviewComments(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "open", PostedBy: "alice", EffortLevel: "medium"})
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	d, err := c.Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if len(d.Comments) != 0 {
+		t.Errorf("comments without table = %+v, want none", d.Comments)
+	}
+
+	db.commentsCSV = "id,branch,wanted_id,table_name,row_key,column_name,author,body,created_at\n" +
+		"rc-1,wl/alice/w-1,w-1,,,,bob,needs a test,2026-01-02 03:04:05\n"
+	d, err = c.Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if len(d.Comments) != 1 || d.Comments[0].Body != "needs a test" {
+		t.Errorf("comments = %+v", d.Comments)
+	}
+}
+
+func TestAddReviewComment_RefusesSecrets(t *testing.T) {
+	db := newFakeDB()
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+
+	_, err := c.AddReviewComment(commons.ReviewComment{
+		Branch: "wl/alice/w-1", Body: "try token ghp_" + strings.Repeat("a1B2", 9),
+	})
+	var se *commons.SecretError
+	if !errors.As(err, &se) {
+		t.Fatalf("AddReviewComment error = %v, want *commons.SecretError", err)
+	}
+	if len(db.execCalls) != 0 {
+		t.Errorf("exec calls = %d, want 0", len(db.execCalls))
+	}
+}
@@ -96,7 +96,7 @@ func seedConformanceItem(t *testing.T, db commons.DB, id, title, postedBy string
 	if err != nil {
 		t.Fatalf("InsertWantedDML: %v", err)
 	}
-	if err := db.Exec("", "seed: "+id, false, dml); err != nil {
+	if err := db.Exec("", "seed: "+id, commons.Signing{}, dml); err != nil {
 		t.Fatalf("seed item %s: %v", id, err)
 	}
 }
@@ -104,7 +104,7 @@ func seedConformanceItem(t *testing.T, db commons.DB, id, title, postedBy string
 func seedClaimedItem(t *testing.T, db commons.DB, id, postedBy, claimedBy string) {
 	t.Helper()
 	seedConformanceItem(t, db, id, "Test "+id, postedBy)
-	if err := db.Exec("", "claim: "+id, false, commons.ClaimWantedDML(id, claimedBy)); err != nil {
+	if err := db.Exec("", "claim: "+id, commons.Signing{}, commons.ClaimWantedDML(id, claimedBy)); err != nil {
 		t.Fatalf("claim %s: %v", id, err)
 	}
 }
@@ -114,7 +114,7 @@ func seedInReviewItem(t *testing.T, db commons.DB, id, postedBy, claimedBy strin
 	seedClaimedItem(t, db, id, postedBy, claimedBy)
 	completionID = fmt.Sprintf("c-%s", id)
 	stmts := commons.SubmitCompletionDML(completionID, id, claimedBy, "http://example.com/evidence", "")
-	if err := db.Exec("", "done: "+id, false, stmts...); err != nil {
+	if err := db.Exec("", "done: "+id, commons.Signing{}, stmts...); err != nil {
 		t.Fatalf("done %s: %v", id, err)
 	}
 	return completionID
@@ -124,7 +124,7 @@ func seedCompletedItem(t *testing.T, db commons.DB, id, postedBy, claimedBy stri
 	t.Helper()
 	seedInReviewItem(t, db, id, postedBy, claimedBy)
 	// Close without stamp (simpler than accept for seeding).
-	if err := db.Exec("", "close: "+id, false, commons.CloseWantedDML(id)); err != nil {
+	if err := db.Exec("", "close: "+id, commons.Signing{}, commons.CloseWantedDML(id)); err != nil {
 		t.Fatalf("close %s: %v", id, err)
 	}
 }
@@ -183,7 +183,7 @@ func scenarios() []scenario {
 				}
 				dml, err := commons.InsertWantedDML(item)
 				assertNoError(t, err)
-				assertNoError(t, db.Exec("", "insert", false, dml))
+				assertNoError(t, db.Exec("", "insert", commons.Signing{}, dml))
 				assertItemStatus(t, db, "w-ins", "", "open")
 			},
 		},
@@ -193,7 +193,7 @@ func scenarios() []scenario {
 				seedConformanceItem(t, db, "w-cl", "Claim me", "alice")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				assertNoError(t, db.Exec("", "claim", false, commons.ClaimWantedDML("w-cl", "bob")))
+				assertNoError(t, db.Exec("", "claim", commons.Signing{}, commons.ClaimWantedDML("w-cl", "bob")))
 				assertItemStatus(t, db, "w-cl", "", "claimed")
 			},
 		},
@@ -203,7 +203,7 @@ func scenarios() []scenario {
 				seedClaimedItem(t, db, "w-uc", "alice", "bob")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				assertNoError(t, db.Exec("", "unclaim", false, commons.UnclaimWantedDML("w-uc")))
+				assertNoError(t, db.Exec("", "unclaim", commons.Signing{}, commons.UnclaimWantedDML("w-uc")))
 				assertItemStatus(t, db, "w-uc", "", "open")
 			},
 		},
@@ -214,7 +214,7 @@ func scenarios() []scenario {
 			},
 			run: func(t *testing.T, db commons.DB) {
 				stmts := commons.SubmitCompletionDML("c-dn", "w-dn", "bob", "http://example.com", "")
-				assertNoError(t, db.Exec("", "done", false, stmts...))
+				assertNoError(t, db.Exec("", "done", commons.Signing{}, stmts...))
 				assertItemStatus(t, db, "w-dn", "", "in_review")
 				// Verify completion exists.
 				c, err := commons.QueryCompletion(db, "w-dn")
@@ -237,7 +237,7 @@ func scenarios() []scenario {
 					Severity: "minor", ContextID: c.ID, ContextType: "completion",
 				}
 				stmts := commons.AcceptCompletionDML("w-ac", c.ID, "alice", "", stamp)
-				assertNoError(t, db.Exec("", "accept", false, stmts...))
+				assertNoError(t, db.Exec("", "accept", commons.Signing{}, stmts...))
 				assertItemStatus(t, db, "w-ac", "", "completed")
 				// Verify stamp exists.
 				s, err := commons.QueryStamp(db, "s-ac")
@@ -254,7 +254,7 @@ func scenarios() []scenario {
 			},
 			run: func(t *testing.T, db commons.DB) {
 				stmts := commons.RejectCompletionDML("w-rj")
-				assertNoError(t, db.Exec("", "reject", false, stmts...))
+				assertNoError(t, db.Exec("", "reject", commons.Signing{}, stmts...))
 				assertItemStatus(t, db, "w-rj", "", "claimed")
 				// Completion should be gone.
 				_, err := commons.QueryCompletion(db, "w-rj")
@@ -269,7 +269,7 @@ func scenarios() []scenario {
 				seedInReviewItem(t, db, "w-cls", "alice", "bob")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				assertNoError(t, db.Exec("", "close", false, commons.CloseWantedDML("w-cls")))
+				assertNoError(t, db.Exec("", "close", commons.Signing{}, commons.CloseWantedDML("w-cls")))
 				assertItemStatus(t, db, "w-cls", "", "completed")
 			},
 		},
@@ -279,7 +279,7 @@ func scenarios() []scenario {
 				seedConformanceItem(t, db, "w-del", "Delete me", "alice")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				assertNoError(t, db.Exec("", "delete", false, commons.DeleteWantedDML("w-del")))
+				assertNoError(t, db.Exec("", "delete", commons.Signing{}, commons.DeleteWantedDML("w-del")))
 				assertItemStatus(t, db, "w-del", "", "withdrawn")
 			},
 		},
@@ -291,7 +291,7 @@ func scenarios() []scenario {
 				seedClaimedItem(t, db, "w-cc", "alice", "bob")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				err := db.Exec("", "claim again", false, commons.ClaimWantedDML("w-cc", "carol"))
+				err := db.Exec("", "claim again", commons.Signing{}, commons.ClaimWantedDML("w-cc", "carol"))
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -299,7 +299,7 @@ func scenarios() []scenario {
 			name: "claim_nonexistent",
 			seed: func(t *testing.T, db commons.DB) {},
 			run: func(t *testing.T, db commons.DB) {
-				err := db.Exec("", "claim ghost", false, commons.ClaimWantedDML("w-none", "carol"))
+				err := db.Exec("", "claim ghost", commons.Signing{}, commons.ClaimWantedDML("w-none", "carol"))
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -309,7 +309,7 @@ func scenarios() []scenario {
 				seedConformanceItem(t, db, "w-uo", "Unclaim open", "alice")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				err := db.Exec("", "unclaim open", false, commons.UnclaimWantedDML("w-uo"))
+				err := db.Exec("", "unclaim open", commons.Signing{}, commons.UnclaimWantedDML("w-uo"))
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -320,7 +320,7 @@ func scenarios() []scenario {
 			},
 			run: func(t *testing.T, db commons.DB) {
 				stmts := commons.SubmitCompletionDML("c-dw", "w-dw", "carol", "http://example.com", "")
-				err := db.Exec("", "done wrong rig", false, stmts...)
+				err := db.Exec("", "done wrong rig", commons.Signing{}, stmts...)
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -333,7 +333,7 @@ func scenarios() []scenario {
 				// Item is open, not claimed — both UPDATE (WHERE status='claimed')
 				// and INSERT (WHERE status='in_review') match nothing.
 				stmts := commons.SubmitCompletionDML("c-do", "w-do", "bob", "http://example.com", "")
-				err := db.Exec("", "done open", false, stmts...)
+				err := db.Exec("", "done open", commons.Signing{}, stmts...)
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -343,7 +343,7 @@ func scenarios() []scenario {
 				seedCompletedItem(t, db, "w-ccl", "alice", "bob")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				err := db.Exec("", "close completed", false, commons.CloseWantedDML("w-ccl"))
+				err := db.Exec("", "close completed", commons.Signing{}, commons.CloseWantedDML("w-ccl"))
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -353,7 +353,7 @@ func scenarios() []scenario {
 				seedClaimedItem(t, db, "w-dc", "alice", "bob")
 			},
 			run: func(t *testing.T, db commons.DB) {
-				err := db.Exec("", "delete claimed", false, commons.DeleteWantedDML("w-dc"))
+				err := db.Exec("", "delete claimed", commons.Signing{}, commons.DeleteWantedDML("w-dc"))
 				assertNothingToCommit(t, err)
 			},
 		},
@@ -372,7 +372,7 @@ func scenarios() []scenario {
 					Severity: "minor", ContextID: c.ID, ContextType: "completion",
 				}
 				stmts := commons.AcceptCompletionDML("w-sr", c.ID, "alice", "", stamp)
-				assertNoError(t, db.Exec("", "accept", false, stmts...))
+				assertNoError(t, db.Exec("", "accept", commons.Signing{}, stmts...))
 				// Stamp valence contains commas in JSON — verify fields after it survive.
 				s, err := commons.QueryStamp(db, "s-sr")
 				assertNoError(t, err)
@@ -392,7 +392,7 @@ func scenarios() []scenario {
 			run: func(t *testing.T, db commons.DB) {
 				dml, err := commons.UpdateWantedDML("w-ut", &commons.WantedUpdate{Title: "New Title"})
 				assertNoError(t, err)
-				assertNoError(t, db.Exec("", "update title", false, dml))
+				assertNoError(t, db.Exec("", "update title", commons.Signing{}, dml))
 				// Verify title changed (query detail).
 				item, err := commons.QueryWantedDetail(db, "w-ut")
 				assertNoError(t, err)
@@ -413,12 +413,12 @@ func scenarios() []scenario {
 			},
 			run: func(t *testing.T, db commons.DB) {
 				// open → claim
-				assertNoError(t, db.Exec("", "claim", false, commons.ClaimWantedDML("w-lc", "bob")))
+				assertNoError(t, db.Exec("", "claim", commons.Signing{}, commons.ClaimWantedDML("w-lc", "bob")))
 				assertItemStatus(t, db, "w-lc", "", "claimed")
 
 				// claim → in_review
 				stmts := commons.SubmitCompletionDML("c-lc", "w-lc", "bob", "http://example.com", "")
-				assertNoError(t, db.Exec("", "done", false, stmts...))
+				assertNoError(t, db.Exec("", "done", commons.Signing{}, stmts...))
 				assertItemStatus(t, db, "w-lc", "", "in_review")
 
 				// in_review → completed (accept)
@@ -429,7 +429,7 @@ func scenarios() []scenario {
 					Severity: "minor", ContextID: c.ID, ContextType: "completion",
 				}
 				acceptStmts := commons.AcceptCompletionDML("w-lc", c.ID, "alice", "", stamp)
-				assertNoError(t, db.Exec("", "accept", false, acceptStmts...))
+				assertNoError(t, db.Exec("", "accept", commons.Signing{}, acceptStmts...))
 				assertItemStatus(t, db, "w-lc", "", "completed")
 			},
 		},
@@ -441,7 +441,7 @@ func scenarios() []scenario {
 			run: func(t *testing.T, db commons.DB) {
 				// Claim on branch — main should remain open.
 				branch := "wl/bob/w-br"
-				assertNoError(t, db.Exec(branch, "claim on branch", false, commons.ClaimWantedDML("w-br", "bob")))
+				assertNoError(t, db.Exec(branch, "claim on branch", commons.Signing{}, commons.ClaimWantedDML("w-br", "bob")))
 
 				// Main unchanged.
 				assertItemStatus(t, db, "w-br", "", "open")
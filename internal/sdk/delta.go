@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// ItemDelta is one wanted item whose state on the rig's mutation branch
+// differs from main — what merging the branch would propose upstream.
+type ItemDelta struct {
+	WantedID string
+	Title    string // item title as of the branch ("" if unreadable)
+	Branch   string
+	Delta    string // human-readable delta label ("" if none)
+	PRURL    string // existing PR URL ("" if none)
+	Changes  []commons.RowChange
+}
+
+// Deltas lists the items whose effective state on the rig's wl/<rig>/*
+// branches differs from main, with the row-level changes each branch
+// carries. Branches with nothing to propose are skipped.
+func (c *Client) Deltas() ([]ItemDelta, error) {
+	names, err := c.db.Branches("wl/" + c.rigHandle + "/")
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	sort.Strings(names)
+
+	var out []ItemDelta
+	for _, name := range names {
+		wantedID := extractWantedID(name)
+		if wantedID == "" {
+			continue
+		}
+		changes, err := commons.DiffWantedItem(c.db, wantedID, name)
+		if err != nil {
+			return nil, fmt.Errorf("diffing %s: %w", name, err)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		d := ItemDelta{WantedID: wantedID, Branch: name, PRURL: c.prURL(name), Changes: changes}
+		if state, err := commons.ResolveItemState(c.db, c.rigHandle, wantedID); err == nil {
+			d.Delta = state.Delta()
+			if item := state.Effective(); item != nil {
+				d.Title = item.Title
+			}
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import "testing"
+
+func TestDeltas(t *testing.T) {
+	db := newFakeDB()
+	seedBranches(db)
+	db.branchItems["wl/bob/w-1"]["w-1"].Priority = 1
+	db.seedItem(fakeItem{ID: "w-2", Title: "Two", Status: "open", PostedBy: "alice"})
+	db.branches["wl/bob/w-2"] = true // inherits main: nothing to propose
+
+	c := New(ClientConfig{
+		DB: db, RigHandle: "bob", Mode: "pr",
+		CheckPR: func(branch string) string {
+			if branch == "wl/bob/w-1" {
+				return "https://example.com/pr/1"
+			}
+			return ""
+		},
+	})
+
+	deltas, err := c.Deltas()
+	if err != nil {
+		t.Fatalf("Deltas: %v", err)
+	}
+	if len(deltas) != 1 {
+		t.Fatalf("deltas = %+v, want only w-1", deltas)
+	}
+	d := deltas[0]
+	if d.WantedID != "w-1" || d.Title != "One" || d.Branch != "wl/bob/w-1" || d.Delta == "" || d.PRURL == "" {
+		t.Errorf("delta = %+v", d)
+	}
+	if len(d.Changes) != 1 || d.Changes[0].Table != "wanted" || d.Changes[0].DiffType != "modified" {
+		t.Fatalf("changes = %+v, want one modified wanted row", d.Changes)
+	}
+	fields := map[string]string{}
+	for _, f := range d.Changes[0].Fields {
+		fields[f.Field] = f.From + "→" + f.To
+	}
+	if fields["status"] != "open→claimed" || fields["claimed_by"] != "→bob" || len(fields) != 2 {
+		t.Errorf("fields = %v", fields)
+	}
+}
@@ -0,0 +1,24 @@
+package sdk
+
+import (
+	"log/slog"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// loadEvidence fills the typed evidence entries of a detail's completion,
+// reading at ref ("" = main). Failures are logged and fall back to the
+// completion's legacy evidence string.
+func (c *Client) loadEvidence(detail *DetailResult, ref string) {
+	if detail.Completion == nil {
+		return
+	}
+	entries, err := commons.QueryEvidence(c.db, detail.Completion, ref)
+	if err != nil {
+		slog.Debug("loading evidence failed", "completion_id", detail.Completion.ID, "error", err)
+		if detail.Completion.Evidence != "" {
+			entries = []commons.Evidence{commons.ParseEvidence(detail.Completion.Evidence)}
+		}
+	}
+	detail.Evidence = entries
+}
@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestDoneEvidence_MultipleEntries(t *testing.T) {
+	db := newFakeDB()
+	db.evidence = map[string][]string{}
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice"})
+
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+	entries := []commons.Evidence{
+		{Kind: commons.EvidencePR, Value: "https://github.com/org/repo/pull/7"},
+		{Kind: commons.EvidenceCommit, Value: "abc1234"},
+		{Kind: commons.EvidenceText, Value: "benchmarks before and after"},
+	}
+	result, err := c.DoneEvidence("w-1", entries)
+	if err != nil {
+		t.Fatalf("DoneEvidence: %v", err)
+	}
+	if result.Detail.Item.Status != "in_review" {
+		t.Errorf("status = %s, want in_review", result.Detail.Item.Status)
+	}
+	if got := db.completions["w-1"].Evidence; got != "https://github.com/org/repo/pull/7; abc1234; benchmarks before and after" {
+		t.Errorf("legacy evidence = %q", got)
+	}
+	if len(result.Detail.Evidence) != 3 {
+		t.Fatalf("detail evidence = %+v, want 3 entries", result.Detail.Evidence)
+	}
+	for i, want := range entries {
+		if result.Detail.Evidence[i] != want {
+			t.Errorf("evidence[%d] = %+v, want %+v", i, result.Detail.Evidence[i], want)
+		}
+	}
+}
+
+func TestDoneEvidence_SingleEntryUsesLegacyColumn(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice"})
+
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+	result, err := c.DoneEvidence("w-1", []commons.Evidence{{Kind: commons.EvidenceArtifact, Value: "https://github.com/org/repo/pull/7"}})
+	if err != nil {
+		t.Fatalf("DoneEvidence: %v", err)
+	}
+	if got := db.completions["w-1"].Evidence; got != "artifact:https://github.com/org/repo/pull/7" {
+		t.Errorf("legacy evidence = %q", got)
+	}
+	if len(result.Detail.Evidence) != 1 || result.Detail.Evidence[0].Kind != commons.EvidenceArtifact {
+		t.Errorf("detail evidence = %+v", result.Detail.Evidence)
+	}
+}
+
+func TestDoneEvidence_Validation(t *testing.T) {
+	c := New(ClientConfig{DB: newFakeDB(), RigHandle: "bob", Mode: "wild-west"})
+	if _, err := c.DoneEvidence("w-1", nil); err == nil {
+		t.Error("expected error for no evidence")
+	}
+	if _, err := c.DoneEvidence("w-1", []commons.Evidence{{Kind: "video", Value: "x"}}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestDoneEvidence_SingleVerifiedEntryRecorded(t *testing.T) {
+	db := newFakeDB()
+	db.evidence = map[string][]string{}
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice"})
+
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+	result, err := c.DoneEvidence("w-1", []commons.Evidence{{Kind: commons.EvidenceCommit, Value: "abc1234", Verified: true}})
+	if err != nil {
+		t.Fatalf("DoneEvidence: %v", err)
+	}
+	if got := db.completions["w-1"].Evidence; got != "abc1234" {
+		t.Errorf("legacy evidence = %q", got)
+	}
+	if len(result.Detail.Evidence) != 1 || !result.Detail.Evidence[0].Verified {
+		t.Errorf("detail evidence = %+v, want one verified entry", result.Detail.Evidence)
+	}
+}
+
+func TestDoneEvidence_VerifiedWithoutTable(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice"})
+
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+	result, err := c.DoneEvidence("w-1", []commons.Evidence{{Kind: commons.EvidenceCommit, Value: "abc1234", Verified: true}})
+	if err != nil {
+		t.Fatalf("DoneEvidence: %v", err)
+	}
+	if result.Detail.Item.Status != "in_review" {
+		t.Errorf("status = %s, want in_review", result.Detail.Item.Status)
+	}
+	if !strings.Contains(result.Hint, "no completion_evidence table") {
+		t.Errorf("hint = %q", result.Hint)
+	}
+
+	db2 := newFakeDB()
+	db2.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice"})
+	c2 := New(ClientConfig{DB: db2, RigHandle: "bob", Mode: "wild-west"})
+	_, err = c2.DoneEvidence("w-1", []commons.Evidence{{Kind: commons.EvidencePR, Value: "https://x/pull/1"}, {Kind: commons.EvidenceCommit, Value: "abc1234"}})
+	if err == nil || !strings.Contains(err.Error(), "wl doctor --fix") {
+		t.Errorf("err = %v, want missing-table hint", err)
+	}
+	if len(db2.execCalls) != 0 {
+		t.Errorf("exec calls = %d, want 0", len(db2.execCalls))
+	}
+}
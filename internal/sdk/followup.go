@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"log/slog"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// loadFollowUps fills the parent link and follow-up items of a detail,
+// reading at ref ("" = main). Databases created before the parent_id
+// column existed fail these queries; failures are logged and leave the
+// detail without links.
+func (c *Client) loadFollowUps(detail *DetailResult, ref string) {
+	if detail.Item == nil {
+		return
+	}
+	parentID, err := commons.QueryParentID(c.db, detail.Item.ID, ref)
+	if err != nil {
+		slog.Debug("loading parent item failed", "wanted_id", detail.Item.ID, "error", err)
+		return
+	}
+	detail.Item.ParentID = parentID
+	followUps, err := commons.QueryFollowUps(c.db, detail.Item.ID, ref)
+	if err != nil {
+		slog.Debug("loading follow-up items failed", "wanted_id", detail.Item.ID, "error", err)
+		return
+	}
+	detail.FollowUps = followUps
+}
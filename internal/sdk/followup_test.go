@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestAccept_PartialPostsFollowUp(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Project: "gastown", Priority: 1, Status: "in_review", ClaimedBy: "bob", PostedBy: "alice", EffortLevel: "large"})
+	db.completions["w-1"] = &fakeCompletion{ID: "c-1", WantedID: "w-1", CompletedBy: "bob"}
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	result, err := c.Accept("w-1", AcceptInput{
+		Quality:  4,
+		FollowUp: &FollowUpInput{Title: "Cover the retry path", Description: "tests for retries"},
+	})
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if result.Detail.Item.Status != "completed" {
+		t.Errorf("status = %s, want completed", result.Detail.Item.Status)
+	}
+	if len(result.Detail.FollowUps) != 1 {
+		t.Fatalf("follow-ups = %+v, want 1", result.Detail.FollowUps)
+	}
+	fu := result.Detail.FollowUps[0]
+	if fu.Title != "Cover the retry path" || fu.Status != "open" {
+		t.Errorf("follow-up = %+v", fu)
+	}
+	if !strings.Contains(result.Hint, "follow-up "+fu.ID) {
+		t.Errorf("hint = %q", result.Hint)
+	}
+
+	stored := db.items[fu.ID]
+	if stored.ParentID != "w-1" || stored.Project != "gastown" || stored.Priority != 1 || stored.EffortLevel != "large" || stored.PostedBy != "alice" {
+		t.Errorf("stored follow-up = %+v, want fields inherited from w-1", stored)
+	}
+	call := db.execCalls[len(db.execCalls)-1]
+	if call.CommitMsg != "wl accept: w-1 (partial, follow-up "+fu.ID+")" {
+		t.Errorf("commit message = %q", call.CommitMsg)
+	}
+
+	d, err := c.Detail(fu.ID)
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if d.Item.ParentID != "w-1" {
+		t.Errorf("follow-up parent = %q, want w-1", d.Item.ParentID)
+	}
+}
+
+func TestAccept_PartialRequiresTitle(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	if _, err := c.Accept("w-1", AcceptInput{Quality: 4, FollowUp: &FollowUpInput{Title: "  "}}); err == nil {
+		t.Fatal("expected error for empty follow-up title")
+	}
+	if len(db.execCalls) != 0 {
+		t.Errorf("exec calls = %d, want 0", len(db.execCalls))
+	}
+}
+
+func TestAccept_PartialBeforeQuorumRejected(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+	db.acceptQuorum = 2
+	db.approvalsCSV = approvalsHeader
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	_, err := c.Accept("w-1", AcceptInput{Quality: 4, FollowUp: &FollowUpInput{Title: "More work"}})
+	var conflict *commons.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want ConflictError", err)
+	}
+	if len(db.execCalls) != 0 {
+		t.Errorf("exec calls = %d, want 0", len(db.execCalls))
+	}
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// HookRunner executes user-configured lifecycle hooks. Implemented by
+// hooks.Runner; a nil HookRunner disables hooks.
+type HookRunner interface {
+	Has(event string) bool
+	Run(event string, payload []byte) error
+}
+
+// HookPayload is the JSON document a hook receives on stdin.
+type HookPayload struct {
+	Event     string    `json:"event"`
+	WantedID  string    `json:"wanted_id"`
+	RigHandle string    `json:"rig_handle"`
+	Mode      string    `json:"mode"`
+	Item      *HookItem `json:"item,omitempty"`
+	Evidence  string    `json:"evidence,omitempty"` // done
+	Quality   int       `json:"quality,omitempty"`  // accept
+	Branch    string    `json:"branch,omitempty"`   // post-* in PR mode
+}
+
+// HookItem is the wanted item as seen by hooks.
+type HookItem struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Priority    int      `json:"priority"`
+	Tags        []string `json:"tags,omitempty"`
+	PostedBy    string   `json:"posted_by,omitempty"`
+	ClaimedBy   string   `json:"claimed_by,omitempty"`
+	Status      string   `json:"status"`
+	EffortLevel string   `json:"effort_level,omitempty"`
+}
+
+func newHookItem(item *commons.WantedItem) *HookItem {
+	if item == nil {
+		return nil
+	}
+	return &HookItem{
+		ID: item.ID, Title: item.Title, Description: item.Description,
+		Project: item.Project, Type: item.Type, Priority: item.Priority,
+		Tags: item.Tags, PostedBy: item.PostedBy, ClaimedBy: item.ClaimedBy,
+		Status: item.Status, EffortLevel: item.EffortLevel,
+	}
+}
+
+// runHook runs the hook for event, if configured. The payload's item is
+// loaded from main for pre-* hooks; post-* hooks use the mutation result.
+func (c *Client) runHook(event string, p HookPayload, item *commons.WantedItem) error {
+	if c.hooks == nil || !c.hooks.Has(event) {
+		return nil
+	}
+	if item == nil {
+		item, _ = commons.QueryWantedDetail(c.db, p.WantedID)
+	}
+	p.Event = event
+	p.RigHandle = c.rigHandle
+	p.Mode = c.mode
+	p.Item = newHookItem(item)
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding hook payload: %w", err)
+	}
+	return c.hooks.Run(event, data)
+}
+
+// runPreHook runs a pre-* hook; its error aborts the mutation.
+func (c *Client) runPreHook(event string, p HookPayload) error {
+	if c.readOnly {
+		// Fail before the hook runs: the mutation it guards can't happen.
+		return &commons.ReadOnlyError{Op: strings.TrimPrefix(event, "pre-")}
+	}
+	if err := c.runHook(event, p, nil); err != nil {
+		return fmt.Errorf("aborted by %w", err)
+	}
+	return nil
+}
+
+// runPostHook runs a post-* hook after a successful mutation. Failures
+// are logged, since the mutation has already been applied.
+func (c *Client) runPostHook(event string, p HookPayload, result *MutationResult) {
+	var item *commons.WantedItem
+	if result != nil {
+		p.Branch = result.Branch
+		if result.Detail != nil {
+			item = result.Detail.Item
+		}
+	}
+	if err := c.runHook(event, p, item); err != nil {
+		slog.Warn("post hook failed", "event", event, "wanted_id", p.WantedID, "error", err)
+	}
+}
@@ -2,18 +2,24 @@ package sdk
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"io"
+	"log/slog"
 	"strings"
 
 	"github.com/gastownhall/wasteland/internal/commons"
 )
 
+// ErrPushDeclined reports a wild-west mutation canceled at the ConfirmPush
+// prompt; nothing was committed.
+var ErrPushDeclined = errors.New("push to upstream declined; nothing was changed")
+
 // MutationResult holds the outcome of a mutation operation.
 type MutationResult struct {
-	Detail *DetailResult
-	Branch string // mutation branch name (PR mode) or ""
-	Hint   string // user-facing hint ("" if none)
+	WantedID string // the item mutated, even when Detail can't be read back
+	Detail   *DetailResult
+	Branch   string // mutation branch name (PR mode) or ""
+	Hint     string // user-facing hint ("" if none)
 }
 
 // mutate is the internal mode-aware mutation helper.
@@ -27,10 +33,21 @@ func (c *Client) mutate(wantedID, commitMsg string, stmts ...string) (*MutationR
 
 // mutateLocked is the lock-free variant for callers that already hold c.mu.
 func (c *Client) mutateLocked(wantedID, commitMsg string, stmts ...string) (*MutationResult, error) {
+	c.warnSchemaDrift()
+	slog.Debug("mutation", "wanted_id", wantedID, "mode", c.mode, "message", commitMsg, "statements", len(stmts))
+	var (
+		result *MutationResult
+		err    error
+	)
 	if c.mode == "pr" {
-		return c.mutatePR(wantedID, commitMsg, stmts...)
+		result, err = c.mutatePR(wantedID, commitMsg, stmts...)
+	} else {
+		result, err = c.mutateWildWest(wantedID, commitMsg, stmts...)
+	}
+	if result != nil {
+		result.WantedID = wantedID
 	}
-	return c.mutateWildWest(wantedID, commitMsg, stmts...)
+	return result, err
 }
 
 func (c *Client) mutateWildWest(wantedID, commitMsg string, stmts ...string) (*MutationResult, error) {
@@ -39,11 +56,17 @@ func (c *Client) mutateWildWest(wantedID, commitMsg string, stmts ...string) (*M
 	if err := c.db.CanWildWest(); err != nil {
 		return nil, err
 	}
-	if err := c.db.Exec("", commitMsg, c.signing, stmts...); err != nil {
+	if err := c.checkSecrets(stmts...); err != nil {
+		return nil, err
+	}
+	if err := c.askPush(commitMsg); err != nil {
 		return nil, err
 	}
+	if err := c.db.Exec("", commitMsg, c.commitSigning(), stmts...); err != nil {
+		return nil, noopConflict(wantedID, commitMsg, err)
+	}
 	if !c.noPush {
-		if err := c.db.PushWithSync(io.Discard); err != nil {
+		if err := c.push(wantedID, commitMsg); err != nil {
 			return nil, err
 		}
 	}
@@ -58,12 +81,112 @@ func (c *Client) mutateWildWest(wantedID, commitMsg string, stmts ...string) (*M
 	return result, nil
 }
 
+// verifyClaim guards against wild-west claim races. Two rigs can both claim
+// an open item locally; PushWithSync merges upstream before retrying the
+// push, so the second claimant's push can succeed with the first rig's
+// claim on the row. The claim is read back from upstream/main, which the
+// push just updated, rather than from local main. If another rig holds it,
+// local main is resynced from upstream, and if our claim survives the
+// resync a compensating commit puts back upstream's claimant. The race is
+// reported as a conflict; if main can't be brought back, as the error that
+// stopped it, so the caller knows the local claim remains.
+func (c *Client) verifyClaim(wantedID string, result *MutationResult) error {
+	if c.mode == "pr" || c.noPush || result.Detail == nil || result.Detail.Item == nil {
+		return nil
+	}
+	upstream, err := commons.QueryWantedDetailAsOf(c.db, wantedID, "upstream/main")
+	if err != nil {
+		return fmt.Errorf("checking %s's claim upstream: %w", wantedID, err)
+	}
+	holder := upstream.ClaimedBy
+	if holder == c.rigHandle {
+		return nil
+	}
+	slog.Warn("claim race lost", "wanted_id", wantedID, "claimed_by", holder)
+	if err := c.revertLostClaim(upstream); err != nil {
+		return fmt.Errorf("claim race on %s lost, and reverting your local claim failed: %w", wantedID, err)
+	}
+	if holder == "" {
+		holder = "another rig"
+	}
+	return &commons.ConflictError{Message: fmt.Sprintf(
+		"claim race: %s was claimed by %s before your claim reached upstream; your claim was reverted", wantedID, holder)}
+}
+
+// revertLostClaim brings local main's copy of a lost claim back to
+// upstream's: a resync, then a compensating commit if the resync merged
+// rather than replaced our claim.
+func (c *Client) revertLostClaim(upstream *commons.WantedItem) error {
+	if err := c.db.Sync(); err != nil {
+		return fmt.Errorf("resyncing main from upstream: %w", err)
+	}
+	local, err := commons.QueryWantedDetail(c.db, upstream.ID)
+	if err != nil {
+		return fmt.Errorf("reading local main: %w", err)
+	}
+	if local.ClaimedBy != c.rigHandle {
+		return nil
+	}
+	claimedBy := "NULL"
+	if upstream.ClaimedBy != "" {
+		claimedBy = "'" + commons.EscapeSQL(upstream.ClaimedBy) + "'"
+	}
+	stmt := fmt.Sprintf("UPDATE wanted SET claimed_by=%s, status='%s', updated_at=NOW() WHERE id='%s' AND claimed_by='%s'",
+		claimedBy, commons.EscapeSQL(upstream.Status), commons.EscapeSQL(upstream.ID), commons.EscapeSQL(c.rigHandle))
+	if err := c.db.Exec("", "wl claim: revert lost race on "+upstream.ID, c.commitSigning(), stmt); err != nil {
+		return fmt.Errorf("committing the revert: %w", err)
+	}
+	return nil
+}
+
+// checkSecrets refuses statements that would commit something that looks
+// like a credential: once pushed, the shared database publishes it. It
+// runs before Exec so the secret never lands in a local commit either.
+func (c *Client) checkSecrets(stmts ...string) error {
+	if c.allowSecrets {
+		return nil
+	}
+	return commons.CheckSecrets(stmts...)
+}
+
+// askPush asks ConfirmPush before a wild-west commit that will be pushed
+// upstream. It runs before Exec so a declined push leaves main untouched.
+func (c *Client) askPush(commitMsg string) error {
+	if c.confirmPush == nil || c.noPush || c.mode == "pr" {
+		return nil
+	}
+	if !c.confirmPush(commitMsg) {
+		return ErrPushDeclined
+	}
+	return nil
+}
+
+// noopConflict reports a "nothing to commit" Exec failure — the DML's
+// status guard matched no rows — as a ConflictError naming the action.
+func noopConflict(wantedID, commitMsg string, err error) error {
+	if !commons.IsNothingToCommit(err) {
+		return err
+	}
+	action, _, _ := strings.Cut(strings.TrimPrefix(commitMsg, "wl "), ":")
+	return &commons.ConflictError{Message: fmt.Sprintf("cannot %s %s: item is not in the required state (nothing to commit)", action, wantedID)}
+}
+
 func (c *Client) mutatePR(wantedID, commitMsg string, stmts ...string) (*MutationResult, error) {
+	return c.mutatePRBranch(wantedID, commitMsg, false, stmts...)
+}
+
+// mutatePRBranch is mutatePR with control over auto-cleanup: keepBranch
+// keeps a branch whose item status still matches main, for mutations such
+// as a quorum approval that change other tables but not the status.
+func (c *Client) mutatePRBranch(wantedID, commitMsg string, keepBranch bool, stmts ...string) (*MutationResult, error) {
+	if err := c.checkSecrets(stmts...); err != nil {
+		return nil, err
+	}
 	branch := commons.BranchName(c.rigHandle, wantedID)
 	mainStatus, _, _ := commons.QueryItemStatus(c.db, wantedID, "main")
 
-	if err := c.db.Exec(branch, commitMsg, c.signing, stmts...); err != nil {
-		return nil, err
+	if err := c.db.Exec(branch, commitMsg, c.commitSigning(), stmts...); err != nil {
+		return nil, noopConflict(wantedID, commitMsg, err)
 	}
 
 	result := c.mutatePRResult(wantedID, branch, mainStatus)
@@ -74,16 +197,25 @@ func (c *Client) mutatePR(wantedID, commitMsg string, stmts ...string) (*Mutatio
 		return result, nil
 	}
 
+	// Bring the branch up to date with upstream main so the PR isn't stale
+	// by the time it's reviewed. A conflict doesn't block the push; it's
+	// reported so the user can discard or resolve the branch.
+	var conflict *commons.ConflictError
+	if err := c.updateBranch(branch); errors.As(err, &conflict) {
+		result.Hint = conflict.Message
+	}
+
 	var pushLog bytes.Buffer
 	if err := c.db.PushBranch(branch, &pushLog); err != nil {
+		slog.Debug("push branch failed", "branch", branch, "output", strings.TrimSpace(pushLog.String()), "error", err)
 		if msg := strings.TrimSpace(pushLog.String()); msg != "" {
-			return nil, fmt.Errorf("%s", msg)
+			return nil, commons.RemoteError(errors.New(msg), msg)
 		}
 		return nil, fmt.Errorf("push branch: %w", err)
 	}
 
 	// Auto-cleanup: if mutation reverted item to main status, delete the branch.
-	if mainStatus != "" && result.Detail.Item != nil && result.Detail.Item.Status == mainStatus {
+	if !keepBranch && mainStatus != "" && result.Detail.Item != nil && result.Detail.Item.Status == mainStatus {
 		c.cleanupBranch(branch)
 		result.Detail.Branch = ""
 		result.Detail.BranchURL = ""
@@ -98,6 +230,7 @@ func (c *Client) mutatePR(wantedID, commitMsg string, stmts ...string) (*Mutatio
 	// Auto-submit PR if branch survived cleanup and no PR exists yet.
 	if result.Branch != "" && result.Detail.PRURL == "" && c.CreatePR != nil {
 		if url, err := c.CreatePR(result.Branch); err == nil {
+			c.notePR(result.Branch, url)
 			result.Detail.PRURL = url
 			result.Detail.BranchActions = c.computeBranchActions(result.Detail)
 		} else {
@@ -157,13 +290,18 @@ func (c *Client) mutatePRResult(wantedID, branch, mainStatus string) *MutationRe
 		detail.Actions = commons.AvailableTransitions(item, c.rigHandle)
 		detail.Delta = commons.ComputeDelta(mainStatus, item.Status, true)
 	}
-	if branch != "" && c.CheckPR != nil {
-		detail.PRURL = c.CheckPR(branch)
+	if branch != "" {
+		detail.PRURL = c.prURL(branch)
 	}
 	if branch != "" && c.BranchURL != nil {
 		detail.BranchURL = c.BranchURL(branch)
 	}
 	detail.BranchActions = c.computeBranchActions(detail)
+	c.loadApprovals(detail, branch)
+	c.loadFollowUps(detail, branch)
+	c.loadEvidence(detail, branch)
+	c.loadClaimExpiry(detail)
+	c.loadQueue(detail, branch)
 
 	return &MutationResult{Detail: detail, Branch: branch}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/hooks"
 )
 
 // AcceptInput holds the parameters for accepting a completion.
@@ -13,6 +14,15 @@ type AcceptInput struct {
 	Severity    string
 	SkillTags   []string
 	Message     string
+	// FollowUp makes the accept partial: the completion is accepted and a
+	// linked open item is posted for the remaining work.
+	FollowUp *FollowUpInput
+}
+
+// FollowUpInput describes the follow-up item a partial accept posts.
+type FollowUpInput struct {
+	Title       string
+	Description string
 }
 
 // PostInput holds the parameters for posting a new wanted item.
@@ -31,8 +41,20 @@ func (c *Client) Claim(wantedID string) (*MutationResult, error) {
 	if result := c.prIdempotent(wantedID, "claimed"); result != nil {
 		return result, nil
 	}
-	stmts := []string{commons.ClaimWantedDML(wantedID, c.rigHandle)}
-	return c.mutate(wantedID, "wl claim: "+wantedID, stmts...)
+	hook := HookPayload{WantedID: wantedID}
+	if err := c.runPreHook(hooks.PreClaim, hook); err != nil {
+		return nil, err
+	}
+	stmts := c.claimStmts(wantedID)
+	result, err := c.mutate(wantedID, "wl claim: "+wantedID, stmts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyClaim(wantedID, result); err != nil {
+		return nil, err
+	}
+	c.runPostHook(hooks.PostClaim, hook, result)
+	return result, nil
 }
 
 // Unclaim reverts a claimed wanted item to open.
@@ -40,18 +62,78 @@ func (c *Client) Unclaim(wantedID string) (*MutationResult, error) {
 	if result := c.prIdempotent(wantedID, "open"); result != nil {
 		return result, nil
 	}
-	stmts := []string{commons.UnclaimWantedDML(wantedID)}
-	return c.mutate(wantedID, "wl unclaim: "+wantedID, stmts...)
+	stmts, next, passed := c.releaseClaim(wantedID, []string{commons.UnclaimWantedDML(wantedID)})
+	result, err := c.mutate(wantedID, "wl unclaim: "+wantedID, stmts...)
+	if err != nil {
+		return nil, err
+	}
+	if notice := queueNotice(wantedID, next, passed); notice != "" {
+		if result.Hint != "" {
+			notice += "; " + result.Hint
+		}
+		result.Hint = notice
+	}
+	return result, nil
 }
 
 // Done submits completion evidence for a claimed wanted item.
 func (c *Client) Done(wantedID, evidence string) (*MutationResult, error) {
+	return c.done(wantedID, evidence, nil)
+}
+
+// DoneEvidence submits a completion with typed evidence entries. The
+// entries are summarized in the completion's evidence string and, when
+// there are several or any is verified, recorded entry by entry in the
+// completion_evidence table.
+func (c *Client) DoneEvidence(wantedID string, entries []commons.Evidence) (*MutationResult, error) {
+	if err := commons.ValidateEvidence(entries); err != nil {
+		return nil, err
+	}
+	summary := commons.EvidenceSummary(entries)
+	if len(entries) == 1 && !entries[0].Verified {
+		return c.done(wantedID, summary, nil)
+	}
+
+	hasTable, err := commons.HasEvidenceTable(c.db)
+	if err != nil {
+		return nil, err
+	}
+	if hasTable {
+		return c.done(wantedID, summary, entries)
+	}
+	if len(entries) > 1 {
+		return nil, fmt.Errorf("this wasteland has no completion_evidence table yet: run 'wl doctor --fix' to add it, or submit a single --evidence")
+	}
+	// A single verified entry still submits; only the marker is lost.
+	result, err := c.done(wantedID, summary, nil)
+	if err != nil {
+		return nil, err
+	}
+	hint := "evidence verified, but this wasteland has no completion_evidence table to record it: run 'wl doctor --fix'"
+	if result.Hint != "" {
+		hint += "; " + result.Hint
+	}
+	result.Hint = hint
+	return result, nil
+}
+
+func (c *Client) done(wantedID, evidence string, entries []commons.Evidence) (*MutationResult, error) {
 	if result := c.prIdempotent(wantedID, "in_review"); result != nil {
 		return result, nil
 	}
+	hook := HookPayload{WantedID: wantedID, Evidence: evidence}
+	if err := c.runPreHook(hooks.PreDone, hook); err != nil {
+		return nil, err
+	}
 	completionID := commons.GeneratePrefixedID("c", wantedID, c.rigHandle)
 	stmts := commons.SubmitCompletionDML(completionID, wantedID, c.rigHandle, evidence, c.hopURI)
-	return c.mutate(wantedID, "wl done: "+wantedID, stmts...)
+	stmts = append(stmts, commons.InsertEvidenceDML(completionID, entries)...)
+	result, err := c.mutate(wantedID, "wl done: "+wantedID, stmts...)
+	if err != nil {
+		return nil, err
+	}
+	c.runPostHook(hooks.PostDone, hook, result)
+	return result, nil
 }
 
 // Accept validates a completion, creates a stamp, and marks the item completed.
@@ -71,12 +153,12 @@ func (c *Client) Accept(wantedID string, input AcceptInput) (*MutationResult, er
 		return nil, fmt.Errorf("querying completion: %w", err)
 	}
 	if completion == nil {
-		return nil, fmt.Errorf("no completion found for item %s", wantedID)
+		return nil, &commons.NotFoundError{Message: fmt.Sprintf("no completion found for item %s", wantedID)}
 	}
 
 	// Self-accept guard: the accepting rig must not be the one who completed the work.
 	if completion.CompletedBy == c.rigHandle {
-		return nil, fmt.Errorf("cannot accept your own completion")
+		return nil, &commons.PermissionError{Message: "cannot accept your own completion"}
 	}
 
 	stamp := &commons.Stamp{
@@ -92,8 +174,80 @@ func (c *Client) Accept(wantedID string, input AcceptInput) (*MutationResult, er
 		Message:     input.Message,
 	}
 
+	followUp, err := c.followUpItem(wantedID, input.FollowUp)
+	if err != nil {
+		return nil, err
+	}
+
+	quorum, err := commons.QueryAcceptQuorum(c.db)
+	if err != nil {
+		return nil, err
+	}
+
+	hook := HookPayload{WantedID: wantedID, Quality: input.Quality}
+	if err := c.runPreHook(hooks.PreAccept, hook); err != nil {
+		return nil, err
+	}
+	if quorum > 1 {
+		return c.approveLocked(wantedID, completion, stamp, quorum, followUp, hook)
+	}
 	stmts := commons.AcceptCompletionDML(wantedID, completion.ID, c.rigHandle, c.hopURI, stamp)
-	return c.mutateLocked(wantedID, "wl accept: "+wantedID, stmts...)
+	stmts, commitMsg, err := withFollowUp(wantedID, "wl accept: "+wantedID, stmts, followUp)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.mutateLocked(wantedID, commitMsg, stmts...)
+	if err != nil {
+		return nil, err
+	}
+	noteFollowUp(result, followUp)
+	c.runPostHook(hooks.PostAccept, hook, result)
+	return result, nil
+}
+
+// followUpItem builds the follow-up item for a partial accept of wantedID,
+// or returns nil when the accept is not partial.
+func (c *Client) followUpItem(wantedID string, in *FollowUpInput) (*commons.WantedItem, error) {
+	if in == nil {
+		return nil, nil
+	}
+	parent, err := commons.QueryWantedDetail(c.db, wantedID)
+	if err != nil {
+		return nil, fmt.Errorf("querying item: %w", err)
+	}
+	item, err := commons.NewFollowUpItem(parent, in.Title, in.Description, c.rigHandle)
+	if err != nil {
+		return nil, err
+	}
+	if item.ID, err = c.NewWantedID(item.Title); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// withFollowUp appends the follow-up insert to an accept's statements and
+// notes it in the commit message. A nil followUp leaves both unchanged.
+func withFollowUp(wantedID, commitMsg string, stmts []string, followUp *commons.WantedItem) ([]string, string, error) {
+	if followUp == nil {
+		return stmts, commitMsg, nil
+	}
+	dml, err := commons.InsertWantedDML(followUp)
+	if err != nil {
+		return nil, "", err
+	}
+	return append(stmts, dml), fmt.Sprintf("wl accept: %s (partial, follow-up %s)", wantedID, followUp.ID), nil
+}
+
+// noteFollowUp tells the user which item picks up the remaining work.
+func noteFollowUp(result *MutationResult, followUp *commons.WantedItem) {
+	if followUp == nil {
+		return
+	}
+	hint := fmt.Sprintf("accepted as partial — follow-up %s posted for the remaining work", followUp.ID)
+	if result.Hint != "" {
+		hint += "; " + result.Hint
+	}
+	result.Hint = hint
 }
 
 // AcceptUpstream adopts a fork submission, creating a completion and stamp on the poster's branch.
@@ -123,18 +277,18 @@ func (c *Client) AcceptUpstream(wantedID, submitterHandle string, input AcceptIn
 		}
 	}
 	if match == nil {
-		return nil, fmt.Errorf("no pending submission from %s", submitterHandle)
+		return nil, &commons.NotFoundError{Message: fmt.Sprintf("no pending submission from %s", submitterHandle)}
 	}
 
 	if match.Status != "in_review" {
-		return nil, fmt.Errorf("submission is not in review")
+		return nil, &commons.ConflictError{Message: "submission is not in review"}
 	}
 	if match.CompletedBy == "" {
 		return nil, fmt.Errorf("submission has no completion data")
 	}
 
 	if submitterHandle == c.rigHandle {
-		return nil, fmt.Errorf("cannot accept your own completion")
+		return nil, &commons.PermissionError{Message: "cannot accept your own completion"}
 	}
 
 	completionID := commons.GeneratePrefixedID("c", wantedID, match.CompletedBy)
@@ -187,13 +341,13 @@ func (c *Client) CloseUpstream(wantedID, submitterHandle string) (*MutationResul
 	}
 
 	if match.Status != "in_review" {
-		return nil, fmt.Errorf("submission is not in review")
+		return nil, &commons.ConflictError{Message: "submission is not in review"}
 	}
 	if match.CompletedBy == "" {
 		return nil, fmt.Errorf("submission has no completion data")
 	}
 	if submitterHandle == c.rigHandle {
-		return nil, fmt.Errorf("cannot close your own completion")
+		return nil, &commons.PermissionError{Message: "cannot close your own completion"}
 	}
 
 	completionID := commons.GeneratePrefixedID("c", wantedID, match.CompletedBy)
@@ -225,7 +379,7 @@ func (c *Client) findUpstreamSubmission(wantedID, submitterHandle string) (*Pend
 			return &items[i], nil
 		}
 	}
-	return nil, fmt.Errorf("no pending submission from %s", submitterHandle)
+	return nil, &commons.NotFoundError{Message: fmt.Sprintf("no pending submission from %s", submitterHandle)}
 }
 
 // Reject rejects a completion, reverting the item from in_review to claimed.
@@ -286,7 +440,17 @@ func (c *Client) Delete(wantedID string) (*MutationResult, error) {
 
 // Post creates a new wanted item.
 func (c *Client) Post(input PostInput) (*MutationResult, error) {
-	id := commons.GenerateWantedID(input.Title)
+	if err := c.checkVocabulary(input.Type, input.EffortLevel); err != nil {
+		return nil, err
+	}
+	tags, err := c.normalizeTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.NewWantedID(input.Title)
+	if err != nil {
+		return nil, err
+	}
 	item := &commons.WantedItem{
 		ID:          id,
 		Title:       input.Title,
@@ -295,7 +459,7 @@ func (c *Client) Post(input PostInput) (*MutationResult, error) {
 		Type:        input.Type,
 		Priority:    input.Priority,
 		EffortLevel: input.EffortLevel,
-		Tags:        input.Tags,
+		Tags:        tags,
 		PostedBy:    c.rigHandle,
 	}
 
@@ -308,9 +472,34 @@ func (c *Client) Post(input PostInput) (*MutationResult, error) {
 
 // Update modifies mutable fields on an open wanted item.
 func (c *Client) Update(wantedID string, fields *commons.WantedUpdate) (*MutationResult, error) {
+	if err := c.checkVocabulary(fields.Type, fields.EffortLevel); err != nil {
+		return nil, err
+	}
+	if fields.TagsSet {
+		tags, err := c.normalizeTags(fields.Tags)
+		if err != nil {
+			return nil, err
+		}
+		normalized := *fields
+		normalized.Tags = tags
+		fields = &normalized
+	}
 	dml, err := commons.UpdateWantedDML(wantedID, fields)
 	if err != nil {
 		return nil, err
 	}
 	return c.mutate(wantedID, "wl update: "+wantedID, dml)
 }
+
+// checkVocabulary rejects a type or effort level the wasteland doesn't
+// accept. Empty values are left for the caller's defaults.
+func (c *Client) checkVocabulary(typ, effort string) error {
+	if typ == "" && effort == "" {
+		return nil
+	}
+	v := c.Vocabulary()
+	if err := v.CheckType(typ); err != nil {
+		return err
+	}
+	return v.CheckEffort(effort)
+}
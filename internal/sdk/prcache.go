@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// prStatusCache answers "is there a PR for this branch?" without blocking
+// on the provider API. Lookups return the last known URL immediately and
+// refresh stale entries in the background; the refreshed value is picked
+// up by the next lookup. A refresh first consults the batch pending-PR
+// listing, which fills in every branch it knows about in one call, and only
+// falls back to a per-branch check when the branch isn't listed.
+type prStatusCache struct {
+	check func(branch string) string
+	list  func() (map[string][]PendingItem, error)
+	ttl   time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]prStatusEntry
+	refreshing map[string]bool
+	sets       uint64         // bumped by set so older refreshes don't clobber it
+	wg         sync.WaitGroup // tracks in-flight refreshes
+}
+
+type prStatusEntry struct {
+	url       string
+	fetchedAt time.Time
+	set       uint64 // value of sets when this entry was recorded
+}
+
+func newPRStatusCache(check func(string) string, list func() (map[string][]PendingItem, error), ttl time.Duration) *prStatusCache {
+	return &prStatusCache{
+		check:      check,
+		list:       list,
+		ttl:        ttl,
+		entries:    make(map[string]prStatusEntry),
+		refreshing: make(map[string]bool),
+	}
+}
+
+// lookup returns the cached PR URL for branch ("" if unknown), starting a
+// background refresh when the entry is missing or older than the TTL.
+func (p *prStatusCache) lookup(branch string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.entries[branch]
+	if (!ok || time.Since(e.fetchedAt) >= p.ttl) && !p.refreshing[branch] {
+		p.refreshing[branch] = true
+		p.wg.Add(1)
+		go p.refresh(branch, p.sets)
+	}
+	return e.url
+}
+
+// set records a PR URL learned from a create or close, so the next lookup
+// doesn't have to wait for a refresh to see it.
+func (p *prStatusCache) set(branch, url string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sets++
+	p.entries[branch] = prStatusEntry{url: url, fetchedAt: time.Now(), set: p.sets}
+}
+
+func (p *prStatusCache) refresh(branch string, sets uint64) {
+	defer p.wg.Done()
+
+	url, listed := "", false
+	if p.list != nil {
+		if pending, err := p.list(); err == nil {
+			now := time.Now()
+			p.mu.Lock()
+			for _, items := range pending {
+				for _, item := range items {
+					if item.Branch == "" || item.PRURL == "" {
+						continue
+					}
+					p.storeLocked(item.Branch, item.PRURL, sets, now)
+					if item.Branch == branch {
+						url, listed = item.PRURL, true
+					}
+				}
+			}
+			p.mu.Unlock()
+		}
+	}
+	if !listed && p.check != nil {
+		url = p.check(branch)
+	}
+
+	p.mu.Lock()
+	p.storeLocked(branch, url, sets, time.Now())
+	delete(p.refreshing, branch)
+	p.mu.Unlock()
+}
+
+// storeLocked records a refreshed url unless set() recorded a newer value
+// after the refresh started (sets is the counter at that time). Caller must
+// hold p.mu.
+func (p *prStatusCache) storeLocked(branch, url string, sets uint64, now time.Time) {
+	if e, ok := p.entries[branch]; ok && e.set > sets {
+		return
+	}
+	p.entries[branch] = prStatusEntry{url: url, fetchedAt: now, set: sets}
+}
+
+// wait blocks until in-flight refreshes finish.
+func (p *prStatusCache) wait() { p.wg.Wait() }
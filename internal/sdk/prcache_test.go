@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPRStatusCache_LookupDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	cache := newPRStatusCache(func(_ string) string {
+		calls.Add(1)
+		<-release
+		return "https://example.com/pr/1"
+	}, nil, time.Minute)
+
+	if got := cache.lookup("wl/bob/w-1"); got != "" {
+		t.Errorf("first lookup = %q, want empty while refreshing", got)
+	}
+	// A second lookup while the refresh is in flight must not start another.
+	cache.lookup("wl/bob/w-1")
+	close(release)
+	cache.wait()
+
+	if got := cache.lookup("wl/bob/w-1"); got != "https://example.com/pr/1" {
+		t.Errorf("lookup after refresh = %q", got)
+	}
+	cache.wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("CheckPR called %d times, want 1", n)
+	}
+}
+
+func TestPRStatusCache_BatchListing(t *testing.T) {
+	var checks atomic.Int32
+	cache := newPRStatusCache(func(_ string) string {
+		checks.Add(1)
+		return ""
+	}, func() (map[string][]PendingItem, error) {
+		return map[string][]PendingItem{
+			"w-1": {{RigHandle: "bob", Branch: "wl/bob/w-1", PRURL: "https://example.com/pr/1"}},
+			"w-2": {{RigHandle: "bob", Branch: "wl/bob/w-2", PRURL: "https://example.com/pr/2"}},
+		}, nil
+	}, time.Minute)
+
+	cache.lookup("wl/bob/w-1")
+	cache.wait()
+
+	if got := cache.lookup("wl/bob/w-1"); got != "https://example.com/pr/1" {
+		t.Errorf("w-1 = %q", got)
+	}
+	// w-2 came from the same listing, so it needs no refresh of its own.
+	if got := cache.lookup("wl/bob/w-2"); got != "https://example.com/pr/2" {
+		t.Errorf("w-2 = %q", got)
+	}
+	cache.wait()
+	if n := checks.Load(); n != 0 {
+		t.Errorf("CheckPR called %d times, want 0 for listed branches", n)
+	}
+}
+
+func TestPRStatusCache_SetWinsOverInFlightRefresh(t *testing.T) {
+	release := make(chan struct{})
+	cache := newPRStatusCache(func(_ string) string {
+		<-release
+		return ""
+	}, nil, time.Minute)
+
+	cache.lookup("wl/bob/w-1")
+	cache.set("wl/bob/w-1", "https://example.com/pr/1")
+	close(release)
+	cache.wait()
+
+	if got := cache.lookup("wl/bob/w-1"); got != "https://example.com/pr/1" {
+		t.Errorf("lookup = %q, stale refresh overwrote the created PR", got)
+	}
+}
+
+func TestClient_PRStatusTTL_CreatedPRIsCached(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "open", Priority: 1, PostedBy: "alice", EffortLevel: "medium"})
+
+	var checks atomic.Int32
+	c := New(ClientConfig{
+		DB:        db,
+		RigHandle: "bob",
+		Mode:      "pr",
+		CheckPR: func(_ string) string {
+			checks.Add(1)
+			return ""
+		},
+		CreatePR: func(_ string) (string, error) {
+			return "https://example.com/pr/1", nil
+		},
+		PRStatusTTL: time.Minute,
+	})
+
+	if _, err := c.Claim("w-1"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	c.prCache.wait()
+
+	d, err := c.Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if d.PRURL != "https://example.com/pr/1" {
+		t.Errorf("PRURL = %q, want the PR created during Claim", d.PRURL)
+	}
+	c.prCache.wait()
+}
@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// PushError reports a wild-west mutation whose commit landed on local main
+// but whose push upstream failed. RetryPush tries the push again; left
+// alone, the commit goes upstream with the next successful push.
+type PushError struct {
+	WantedID  string // item the commit touched ("" if none)
+	CommitMsg string
+	Log       string // push output up to the failure
+	Err       error
+}
+
+func (e *PushError) Error() string {
+	return fmt.Sprintf("%q was committed locally but not pushed: %v", e.CommitMsg, e.Err)
+}
+
+func (e *PushError) Unwrap() error { return e.Err }
+
+// push pushes local main upstream, copying progress to PushOutput. A
+// failure is returned as a *PushError for the commit commitMsg.
+func (c *Client) push(wantedID, commitMsg string) error {
+	var pushLog bytes.Buffer
+	var out io.Writer = &pushLog
+	if c.pushOutput != nil {
+		out = io.MultiWriter(&pushLog, c.pushOutput)
+	}
+	err := c.db.PushWithSync(out)
+	slog.Debug("push with sync", "wanted_id", wantedID, "output", strings.TrimSpace(pushLog.String()), "error", err)
+	if err != nil {
+		return &PushError{WantedID: wantedID, CommitMsg: commitMsg, Log: pushLog.String(), Err: err}
+	}
+	return nil
+}
+
+// RetryPush pushes the local commit a PushError left behind and returns
+// the refreshed item. A retried claim is checked for a lost claim race
+// like a fresh one.
+func (c *Client) RetryPush(pe *PushError) (*MutationResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.push(pe.WantedID, pe.CommitMsg); err != nil {
+		return nil, err
+	}
+	if pe.WantedID == "" {
+		return &MutationResult{Hint: "pushed upstream"}, nil
+	}
+	detail, err := c.detailWildWest(pe.WantedID)
+	if err != nil {
+		return nil, err
+	}
+	result := &MutationResult{Detail: detail, Hint: "pushed upstream"}
+	if strings.HasPrefix(pe.CommitMsg, "wl claim: ") {
+		if err := c.verifyClaim(pe.WantedID, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
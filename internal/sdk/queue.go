@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// Queue adds the current rig to the claim queue of an item another rig has
+// claimed. When the claim is released or expires the first queued rig is
+// up next; with autoClaim the claim passes to it directly.
+func (c *Client) Queue(wantedID string, autoClaim bool) (*MutationResult, error) {
+	item, err := commons.QueryWanted(c.db, wantedID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case item.Status != "claimed":
+		return nil, &commons.ConflictError{Message: fmt.Sprintf(
+			"%s is %s, not claimed: only claimed items have a queue", wantedID, item.Status)}
+	case item.ClaimedBy == c.rigHandle:
+		return nil, &commons.ConflictError{Message: fmt.Sprintf("you already hold the claim on %s", wantedID)}
+	}
+	queue, err := commons.QueryClaimQueue(c.db, wantedID, "")
+	if err != nil {
+		return nil, err
+	}
+	for i, e := range queue {
+		if e.RigHandle == c.rigHandle {
+			return nil, &commons.ConflictError{Message: fmt.Sprintf(
+				"you are already in the claim queue for %s (position %d of %d)", wantedID, i+1, len(queue))}
+		}
+	}
+
+	result, err := c.mutate(wantedID, "wl queue: "+wantedID, commons.EnqueueClaimDML(wantedID, c.rigHandle, autoClaim))
+	if err != nil {
+		if commons.IsTableNotFound(err) {
+			return nil, fmt.Errorf("this wasteland has no claim_queue table yet: run 'wl doctor --fix' to add it")
+		}
+		return nil, err
+	}
+	hint := fmt.Sprintf("position %d in the claim queue for %s", len(queue)+1, wantedID)
+	if autoClaim {
+		hint += "; the claim passes to you automatically when it is your turn"
+	}
+	if result.Hint != "" {
+		hint += "; " + result.Hint
+	}
+	result.Hint = hint
+	return result, nil
+}
+
+// Unqueue removes the current rig from an item's claim queue.
+func (c *Client) Unqueue(wantedID string) (*MutationResult, error) {
+	queue, err := commons.QueryClaimQueue(c.db, wantedID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !queued(queue, c.rigHandle) {
+		return nil, &commons.ConflictError{Message: fmt.Sprintf("you are not in the claim queue for %s", wantedID)}
+	}
+	return c.mutate(wantedID, "wl unqueue: "+wantedID, commons.LeaveClaimQueueDML(wantedID, c.rigHandle))
+}
+
+// releaseClaim extends the statements that reopen a claimed item with the
+// hand-off to its claim queue. The first queued rig either gets the claim
+// (auto-claim) or is left up next; next is zero when the queue is empty.
+func (c *Client) releaseClaim(wantedID string, stmts []string) (_ []string, next commons.QueueEntry, passed bool) {
+	queue, err := commons.QueryClaimQueue(c.db, wantedID, "")
+	if err != nil {
+		slog.Debug("loading claim queue failed", "wanted_id", wantedID, "error", err)
+		return stmts, commons.QueueEntry{}, false
+	}
+	if len(queue) == 0 {
+		return stmts, commons.QueueEntry{}, false
+	}
+	next = queue[0]
+	if next.AutoClaim {
+		return append(stmts, commons.PassClaimDML(wantedID, next)...), next, true
+	}
+	return stmts, next, false
+}
+
+// queueNotice describes what happened to an item's claim queue when its
+// claim was released, or "" when nobody was waiting.
+func queueNotice(wantedID string, next commons.QueueEntry, passed bool) string {
+	switch {
+	case next.RigHandle == "":
+		return ""
+	case passed:
+		return fmt.Sprintf("claim on %s passed to %s, first in its claim queue", wantedID, next.RigHandle)
+	default:
+		return fmt.Sprintf("%s is first in the claim queue for %s and sees it as up next", next.RigHandle, wantedID)
+	}
+}
+
+// claimStmts returns the statements that claim wantedID for the current
+// rig, taking it out of the item's claim queue if it was waiting there.
+func (c *Client) claimStmts(wantedID string) []string {
+	stmts := []string{commons.ClaimWantedDML(wantedID, c.rigHandle)}
+	queue, err := commons.QueryClaimQueue(c.db, wantedID, "")
+	if err == nil && queued(queue, c.rigHandle) {
+		stmts = append(stmts, commons.LeaveClaimQueueDML(wantedID, c.rigHandle))
+	}
+	return stmts
+}
+
+func queued(queue []commons.QueueEntry, rig string) bool {
+	for _, e := range queue {
+		if e.RigHandle == rig {
+			return true
+		}
+	}
+	return false
+}
+
+// loadQueue fills the claim queue of an open or claimed item for the
+// detail view, reading at ref ("" = main). Failures are logged and leave
+// the detail without a queue.
+func (c *Client) loadQueue(detail *DetailResult, ref string) {
+	if detail.Item == nil || (detail.Item.Status != "claimed" && detail.Item.Status != "open") {
+		return
+	}
+	queue, err := commons.QueryClaimQueue(c.db, detail.Item.ID, ref)
+	if err != nil {
+		slog.Debug("loading claim queue failed", "wanted_id", detail.Item.ID, "error", err)
+		return
+	}
+	detail.Queue = queue
+}
@@ -0,0 +1,178 @@
+package sdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func newQueueFake() *fakeDB {
+	db := newFakeDB()
+	db.queue = map[string][]commons.QueueEntry{}
+	db.seedItem(fakeItem{ID: "w-1", Title: "Busy", Status: "claimed", PostedBy: "alice", ClaimedBy: "bob"})
+	return db
+}
+
+func TestQueue_JoinsAndRejects(t *testing.T) {
+	db := newQueueFake()
+	db.seedItem(fakeItem{ID: "w-open", Title: "Free", Status: "open", PostedBy: "alice"})
+
+	carol := New(ClientConfig{DB: db, RigHandle: "carol", Mode: "wild-west"})
+	result, err := carol.Queue("w-1", true)
+	if err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	if !strings.Contains(result.Hint, "position 1 in the claim queue") {
+		t.Errorf("hint = %q", result.Hint)
+	}
+	if q := db.queue["w-1"]; len(q) != 1 || q[0].RigHandle != "carol" || !q[0].AutoClaim {
+		t.Errorf("queue = %+v", q)
+	}
+	if db.execCalls[0].CommitMsg != "wl queue: w-1" {
+		t.Errorf("commit message = %q", db.execCalls[0].CommitMsg)
+	}
+
+	for name, tc := range map[string]struct {
+		rig, id string
+	}{
+		"already queued": {"carol", "w-1"},
+		"own claim":      {"bob", "w-1"},
+		"not claimed":    {"carol", "w-open"},
+	} {
+		_, err := New(ClientConfig{DB: db, RigHandle: tc.rig, Mode: "wild-west"}).Queue(tc.id, false)
+		var conflict *commons.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("%s: err = %v, want ConflictError", name, err)
+		}
+	}
+}
+
+func TestQueue_NoTable(t *testing.T) {
+	db := newQueueFake()
+	db.queue = nil
+
+	c := New(ClientConfig{DB: db, RigHandle: "carol", Mode: "wild-west"})
+	_, err := c.Queue("w-1", false)
+	if err == nil || !strings.Contains(err.Error(), "wl doctor --fix") {
+		t.Errorf("err = %v, want doctor hint", err)
+	}
+}
+
+func TestUnqueue(t *testing.T) {
+	db := newQueueFake()
+	c := New(ClientConfig{DB: db, RigHandle: "carol", Mode: "wild-west"})
+	if _, err := c.Unqueue("w-1"); err == nil {
+		t.Error("Unqueue without a place in the queue should fail")
+	}
+	if _, err := c.Queue("w-1", false); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+	if _, err := c.Unqueue("w-1"); err != nil {
+		t.Fatalf("Unqueue: %v", err)
+	}
+	if len(db.queue["w-1"]) != 0 {
+		t.Errorf("queue = %+v, want empty", db.queue["w-1"])
+	}
+}
+
+func TestUnclaim_PassesClaimToAutoQueue(t *testing.T) {
+	db := newQueueFake()
+	for _, rig := range []string{"carol", "dave"} {
+		if _, err := New(ClientConfig{DB: db, RigHandle: rig, Mode: "wild-west"}).Queue("w-1", rig == "carol"); err != nil {
+			t.Fatalf("Queue %s: %v", rig, err)
+		}
+	}
+
+	bob := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"})
+	result, err := bob.Unclaim("w-1")
+	if err != nil {
+		t.Fatalf("Unclaim: %v", err)
+	}
+	if item := db.items["w-1"]; item.Status != "claimed" || item.ClaimedBy != "carol" {
+		t.Errorf("item = %s/%s, want claimed by carol", item.Status, item.ClaimedBy)
+	}
+	if q := db.queue["w-1"]; len(q) != 1 || q[0].RigHandle != "dave" {
+		t.Errorf("queue = %+v, want only dave", q)
+	}
+	if !strings.Contains(result.Hint, "passed to carol") {
+		t.Errorf("hint = %q", result.Hint)
+	}
+	if last := db.execCalls[len(db.execCalls)-1]; last.CommitMsg != "wl unclaim: w-1" || len(last.Stmts) != 3 {
+		t.Errorf("exec = %+v, want one unclaim commit with the hand-off", last)
+	}
+}
+
+func TestUnclaim_LeavesQueueUpNext(t *testing.T) {
+	db := newQueueFake()
+	carol := New(ClientConfig{DB: db, RigHandle: "carol", Mode: "wild-west"})
+	if _, err := carol.Queue("w-1", false); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+
+	result, err := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "wild-west"}).Unclaim("w-1")
+	if err != nil {
+		t.Fatalf("Unclaim: %v", err)
+	}
+	if item := db.items["w-1"]; item.Status != "open" {
+		t.Errorf("status = %s, want open", item.Status)
+	}
+	if !strings.Contains(result.Hint, "carol is first in the claim queue") {
+		t.Errorf("hint = %q", result.Hint)
+	}
+
+	dash, err := carol.Dashboard()
+	if err != nil {
+		t.Fatalf("Dashboard: %v", err)
+	}
+	if len(dash.UpNext) != 1 || dash.UpNext[0].ID != "w-1" {
+		t.Errorf("up next = %+v, want w-1", dash.UpNext)
+	}
+
+	if _, err := carol.Claim("w-1"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if len(db.queue["w-1"]) != 0 {
+		t.Errorf("queue = %+v, claiming should take carol out of it", db.queue["w-1"])
+	}
+}
+
+func TestSweep_HandsOffToQueue(t *testing.T) {
+	db := newQueueFake()
+	db.claimExpiryDays = 14
+	db.items["w-1"].UpdatedAt = time.Now().UTC().Add(-30 * 24 * time.Hour).Format(time.DateTime)
+	if _, err := New(ClientConfig{DB: db, RigHandle: "carol", Mode: "wild-west"}).Queue("w-1", true); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+
+	result, err := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"}).Sweep(SweepInput{})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if len(result.Claims) != 1 || !result.Claims[0].Passed || result.Claims[0].Next != "carol" {
+		t.Fatalf("claims = %+v, want w-1 passed to carol", result.Claims)
+	}
+	if item := db.items["w-1"]; item.ClaimedBy != "carol" {
+		t.Errorf("claimed_by = %q, want carol", item.ClaimedBy)
+	}
+	if notice := result.Claims[0].Notice(); !strings.Contains(notice, "w-1 passed to carol") {
+		t.Errorf("notice = %q", notice)
+	}
+}
+
+func TestDetail_Queue(t *testing.T) {
+	db := newQueueFake()
+	if _, err := New(ClientConfig{DB: db, RigHandle: "carol", Mode: "wild-west"}).Queue("w-1", true); err != nil {
+		t.Fatalf("Queue: %v", err)
+	}
+
+	d, err := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"}).Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if got := commons.QueueSummary(d.Queue); got != "carol (auto)" {
+		t.Errorf("queue = %q", got)
+	}
+}
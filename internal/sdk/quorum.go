@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/hooks"
+)
+
+// approveLocked records one reviewer's accept toward the wasteland's accept
+// quorum. The item flips to completed only on the approval that reaches
+// quorum; earlier approvals leave it in review and report progress in the
+// result hint. Approvals are counted on main, plus the reviewer's own
+// branch in PR mode, so in PR mode an approval counts toward other
+// reviewers' quorum once its PR is merged.
+func (c *Client) approveLocked(wantedID string, completion *commons.CompletionRecord, stamp *commons.Stamp, quorum int, followUp *commons.WantedItem, hook HookPayload) (*MutationResult, error) {
+	approvals, err := c.currentApprovals(wantedID, completion.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range approvals {
+		if a.Approver == c.rigHandle {
+			return nil, &commons.ConflictError{Message: fmt.Sprintf(
+				"you have already approved %s (%s)", wantedID, commons.ApprovalProgress(approvals, quorum))}
+		}
+	}
+
+	count := len(approvals) + 1
+	final := count >= quorum
+	if followUp != nil && !final {
+		// The follow-up is posted when the item is completed, so only the
+		// accept that reaches quorum can make it partial.
+		return nil, &commons.ConflictError{Message: fmt.Sprintf(
+			"cannot accept %s as partial: this approval is %d/%d, and only the accept that reaches quorum can post a follow-up",
+			wantedID, count, quorum)}
+	}
+	stmts := commons.AcceptApprovalDML(wantedID, completion.ID, c.rigHandle, c.hopURI, stamp, final)
+
+	var result *MutationResult
+	switch {
+	case final:
+		var commitMsg string
+		stmts, commitMsg, err = withFollowUp(wantedID, "wl accept: "+wantedID, stmts, followUp)
+		if err != nil {
+			return nil, err
+		}
+		result, err = c.mutateLocked(wantedID, commitMsg, stmts...)
+	case c.mode == "pr":
+		result, err = c.mutatePRBranch(wantedID, fmt.Sprintf("wl accept: %s (approval %d/%d)", wantedID, count, quorum), true, stmts...)
+	default:
+		result, err = c.mutateWildWest(wantedID, fmt.Sprintf("wl accept: %s (approval %d/%d)", wantedID, count, quorum), stmts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if final {
+		noteFollowUp(result, followUp)
+		c.runPostHook(hooks.PostAccept, hook, result)
+		return result, nil
+	}
+
+	progress := fmt.Sprintf("%d/%d approvals — waiting for %d more before %s is completed", count, quorum, quorum-count, wantedID)
+	if result.Hint != "" {
+		progress += "; " + result.Hint
+	}
+	result.Hint = progress
+	return result, nil
+}
+
+// currentApprovals returns the approvals of a completion on main, merged in
+// PR mode with any approval pending on the rig's own branch for the item.
+func (c *Client) currentApprovals(wantedID, completionID string) ([]commons.Approval, error) {
+	approvals, err := commons.QueryApprovals(c.db, completionID, "")
+	if err != nil {
+		return nil, err
+	}
+	if c.mode != "pr" {
+		return approvals, nil
+	}
+	pending, err := commons.QueryApprovals(c.db, completionID, commons.BranchName(c.rigHandle, wantedID))
+	if err != nil {
+		// The branch usually doesn't exist yet.
+		slog.Debug("querying branch approvals failed", "wanted_id", wantedID, "error", err)
+		return approvals, nil
+	}
+	return mergeApprovals(approvals, pending), nil
+}
+
+// mergeApprovals appends the approvals in extra whose approver is not
+// already present in base.
+func mergeApprovals(base, extra []commons.Approval) []commons.Approval {
+	seen := make(map[string]bool, len(base))
+	for _, a := range base {
+		seen[a.Approver] = true
+	}
+	for _, a := range extra {
+		if !seen[a.Approver] {
+			seen[a.Approver] = true
+			base = append(base, a)
+		}
+	}
+	return base
+}
+
+// loadApprovals fills the quorum and approvals of an in-review item's
+// completion for the detail view, reading approvals at ref ("" = main).
+// Failures are logged and leave the detail without quorum progress.
+func (c *Client) loadApprovals(detail *DetailResult, ref string) {
+	if detail.Item == nil || detail.Item.Status != "in_review" || detail.Completion == nil {
+		return
+	}
+	quorum, err := commons.QueryAcceptQuorum(c.db)
+	if err != nil {
+		slog.Debug("loading accept quorum failed", "wanted_id", detail.Item.ID, "error", err)
+		return
+	}
+	if quorum <= 1 {
+		return
+	}
+	approvals, err := commons.QueryApprovals(c.db, detail.Completion.ID, ref)
+	if err != nil {
+		slog.Debug("loading approvals failed", "wanted_id", detail.Item.ID, "error", err)
+	}
+	detail.Quorum = quorum
+	detail.Approvals = approvals
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+const approvalsHeader = "completion_id,wanted_id,approver,stamp_id,approved_at\n"
+
+func seedInReview(db *fakeDB) {
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "in_review", ClaimedBy: "bob", PostedBy: "alice", EffortLevel: "medium"})
+	db.completions["w-1"] = &fakeCompletion{ID: "c-1", WantedID: "w-1", CompletedBy: "bob"}
+}
+
+func TestAccept_QuorumFirstApprovalKeepsInReview(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+	db.acceptQuorum = 2
+	db.approvalsCSV = approvalsHeader
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	result, err := c.Accept("w-1", AcceptInput{Quality: 4, Reliability: 4})
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if result.Detail.Item.Status != "in_review" {
+		t.Errorf("status = %s, want in_review", result.Detail.Item.Status)
+	}
+	if !strings.HasPrefix(result.Hint, "1/2 approvals") {
+		t.Errorf("hint = %q", result.Hint)
+	}
+	call := db.execCalls[len(db.execCalls)-1]
+	if call.CommitMsg != "wl accept: w-1 (approval 1/2)" {
+		t.Errorf("commit message = %q", call.CommitMsg)
+	}
+	if len(call.Stmts) != 2 || !strings.Contains(call.Stmts[1], "INSERT INTO accept_approvals") {
+		t.Errorf("stmts = %v, want stamp and approval only", call.Stmts)
+	}
+}
+
+func TestAccept_QuorumReachedCompletes(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+	db.acceptQuorum = 2
+	db.approvalsCSV = approvalsHeader + "c-1,w-1,carol,s-carol,2026-01-01 00:00:00\n"
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	result, err := c.Accept("w-1", AcceptInput{Quality: 4, Reliability: 4})
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if result.Detail.Item.Status != "completed" {
+		t.Errorf("status = %s, want completed", result.Detail.Item.Status)
+	}
+	if result.Hint != "" {
+		t.Errorf("hint = %q, want none", result.Hint)
+	}
+	if msg := db.execCalls[len(db.execCalls)-1].CommitMsg; msg != "wl accept: w-1" {
+		t.Errorf("commit message = %q", msg)
+	}
+}
+
+func TestAccept_QuorumRejectsSecondApprovalFromSameRig(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+	db.acceptQuorum = 3
+	db.approvalsCSV = approvalsHeader + "c-1,w-1,alice,s-alice,2026-01-01 00:00:00\n"
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	_, err := c.Accept("w-1", AcceptInput{Quality: 4})
+	var conflict *commons.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want ConflictError", err)
+	}
+	if !strings.Contains(conflict.Message, "1/3 approvals") {
+		t.Errorf("message = %q", conflict.Message)
+	}
+	if len(db.execCalls) != 0 {
+		t.Errorf("exec calls = %d, want 0", len(db.execCalls))
+	}
+}
+
+func TestAccept_QuorumPRModeKeepsApprovalBranch(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+	db.acceptQuorum = 2
+	db.approvalsCSV = approvalsHeader
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "pr"})
+	result, err := c.Accept("w-1", AcceptInput{Quality: 4})
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if result.Branch != "wl/alice/w-1" {
+		t.Errorf("branch = %q, want approval branch kept", result.Branch)
+	}
+	if len(db.pushBranchCalls) != 1 {
+		t.Errorf("push branch calls = %v", db.pushBranchCalls)
+	}
+}
+
+func TestDetail_ApprovalProgress(t *testing.T) {
+	db := newFakeDB()
+	seedInReview(db)
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+
+	d, err := c.Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if d.Quorum != 0 || d.ApprovalProgress() != "" {
+		t.Errorf("without quorum: quorum=%d progress=%q", d.Quorum, d.ApprovalProgress())
+	}
+
+	db.acceptQuorum = 2
+	db.approvalsCSV = approvalsHeader + "c-1,w-1,carol,s-carol,2026-01-01 00:00:00\n"
+	d, err = c.Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if got := d.ApprovalProgress(); got != "1/2 approvals" {
+		t.Errorf("progress = %q, want 1/2 approvals", got)
+	}
+	if len(d.Approvals) != 1 || d.Approvals[0].Approver != "carol" {
+		t.Errorf("approvals = %+v", d.Approvals)
+	}
+}
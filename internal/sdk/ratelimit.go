@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// RateLimitState returns the latest rate-limit state of every remote API
+// the client has called: the DB backend's (e.g. DoltHub) and any reported
+// by the RateLimits callback (e.g. GitHub), sorted by provider. It is
+// empty for local backends and before any remote response.
+func (c *Client) RateLimitState() []commons.RateLimit {
+	var out []commons.RateLimit
+	if r := rateLimitReporter(c.db); r != nil {
+		out = append(out, r.RateLimits()...)
+	}
+	if c.RateLimits != nil {
+		out = append(out, c.RateLimits()...)
+	}
+	slices.SortStableFunc(out, func(a, b commons.RateLimit) int { return strings.Compare(a.Provider, b.Provider) })
+	return out
+}
+
+// rateLimitReporter finds the commons.RateLimitReporter behind db,
+// looking through wrappers such as commons.ReadOnly.
+func rateLimitReporter(db commons.DB) commons.RateLimitReporter {
+	for db != nil {
+		if r, ok := db.(commons.RateLimitReporter); ok {
+			return r
+		}
+		u, ok := db.(interface{ Unwrap() commons.DB })
+		if !ok {
+			return nil
+		}
+		db = u.Unwrap()
+	}
+	return nil
+}
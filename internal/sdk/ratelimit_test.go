@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// rateLimitDB is a fakeDB whose backend reports rate-limit state.
+type rateLimitDB struct {
+	*fakeDB
+	limits []commons.RateLimit
+}
+
+func (d *rateLimitDB) RateLimits() []commons.RateLimit { return d.limits }
+
+func TestRateLimitState(t *testing.T) {
+	db := &rateLimitDB{fakeDB: newFakeDB(), limits: []commons.RateLimit{{Provider: "dolthub", Limit: 1000, Remaining: 12}}}
+	client := New(ClientConfig{
+		DB:        commons.ReadOnly(db),
+		RigHandle: "alice",
+		Mode:      "pr",
+		RateLimits: func() []commons.RateLimit {
+			return []commons.RateLimit{{Provider: "github", Limited: true}}
+		},
+	})
+
+	got := client.WithRigHandle("bob").RateLimitState()
+	if len(got) != 2 || got[0].Provider != "dolthub" || got[0].Remaining != 12 || got[1].Provider != "github" || !got[1].Limited {
+		t.Errorf("RateLimitState() = %+v, want dolthub then github", got)
+	}
+
+	plain := New(ClientConfig{DB: newFakeDB(), RigHandle: "alice", Mode: "wild-west"})
+	if got := plain.RateLimitState(); len(got) != 0 {
+		t.Errorf("RateLimitState() without reporters = %+v, want none", got)
+	}
+}
@@ -1,6 +1,12 @@
 package sdk
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
 	"github.com/gastownhall/wasteland/internal/commons"
 )
 
@@ -37,57 +43,231 @@ type DetailResult struct {
 	// BranchActions are mode-aware branch operations: "submit_pr", "apply", "discard".
 	// Computed by the SDK based on mode, branch state, delta, and existing PR.
 	BranchActions []string
-	UpstreamPRs   []PendingItem // pending upstream PRs for this item
+	UpstreamPRs   []PendingItem           // pending upstream PRs for this item
+	Comments      []commons.ReviewComment // review comments about this item, oldest first
+	// Quorum is the number of distinct accepts the completion needs; 0 or 1
+	// means a single accept completes the item and Approvals is empty.
+	Quorum    int
+	Approvals []commons.Approval // accepts recorded so far toward Quorum
+	// FollowUps are the items posted by partial accepts of this item (ID,
+	// title and status only). Item.ParentID links the other way.
+	FollowUps []commons.WantedItem
+	// Evidence is the completion's evidence as typed entries, oldest first.
+	Evidence []commons.Evidence
+	// ClaimExpiry is how long a claimed item may go without activity before
+	// 'wl sweep' reopens it; 0 when the wasteland's claims don't expire.
+	ClaimExpiry time.Duration
+	// Queue is the claim queue of an open or claimed item, first in line
+	// first.
+	Queue []commons.QueueEntry
+}
+
+// ApprovalProgress renders accept progress such as "1/2 approvals", or ""
+// when no quorum applies.
+func (d *DetailResult) ApprovalProgress() string {
+	return commons.ApprovalProgress(d.Approvals, d.Quorum)
+}
+
+// ClaimAge returns how long a claimed item has gone without activity, or
+// false when the item isn't claimed or its updated_at is unreadable.
+func (d *DetailResult) ClaimAge(now time.Time) (time.Duration, bool) {
+	if d.Item == nil || d.Item.Status != "claimed" {
+		return 0, false
+	}
+	updated, ok := commons.ParseDoltTime(d.Item.UpdatedAt)
+	if !ok {
+		return 0, false
+	}
+	return now.Sub(updated), true
 }
 
 // Browse queries the wanted board with filters, applying branch overlays in PR mode.
 func (c *Client) Browse(filter commons.BrowseFilter) (*BrowseResult, error) {
+	c.warnSchemaDrift()
+	c.canonicalFilterTags(&filter)
 	items, pendingIDs, err := commons.BrowseWantedBranchAware(c.db, c.mode, c.rigHandle, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	// In "all" view, merge upstream PR state if the callback is set.
-	var upstreamItems map[string][]PendingItem
+	upstreamItems := c.upstreamPending(filter)
+	for id, pending := range upstreamItems {
+		pendingIDs[id] += len(pending)
+	}
+	for i := range items {
+		overlayUpstreamPending(&items[i], upstreamItems[items[i].ID])
+	}
+
+	return &BrowseResult{Items: items, PendingIDs: pendingIDs, UpstreamPending: upstreamItems}, nil
+}
+
+// BrowseRow is one item yielded by BrowseEach.
+type BrowseRow struct {
+	Item            commons.WantedSummary
+	Pending         int           // count of PRs/branches with pending changes
+	UpstreamPending []PendingItem // pending upstream PR state for this item
+}
+
+// BrowseEach is the streaming form of Browse: rows are passed to fn as they
+// are read, with the same branch and upstream overlays, so large boards can
+// be exported without holding every item in memory. Iteration stops at the
+// first error fn returns.
+func (c *Client) BrowseEach(filter commons.BrowseFilter, fn func(BrowseRow) error) error {
+	c.warnSchemaDrift()
+	c.canonicalFilterTags(&filter)
+	upstreamItems := c.upstreamPending(filter)
+	return commons.EachWantedBranchAware(c.db, c.mode, c.rigHandle, filter, func(item commons.WantedSummary, pending int) error {
+		upstream := upstreamItems[item.ID]
+		overlayUpstreamPending(&item, upstream)
+		return fn(BrowseRow{Item: item, Pending: pending + len(upstream), UpstreamPending: upstream})
+	})
+}
+
+// Vocabulary returns the item types and effort levels the wasteland
+// accepts, read from upstream _meta on first use and cached for the life of
+// the client. If it can't be read, the defaults are returned and the read
+// is retried on the next call.
+func (c *Client) Vocabulary() *commons.Vocabulary {
+	c.vocabMu.Lock()
+	defer c.vocabMu.Unlock()
+	if c.vocab != nil {
+		return c.vocab
+	}
+	v, err := commons.QueryVocabulary(c.db)
+	if err != nil {
+		slog.Debug("vocabulary unavailable, using defaults", "error", err)
+		return commons.DefaultVocabulary()
+	}
+	c.vocab = v
+	return v
+}
+
+// IDScheme returns how the wasteland names new items, read from upstream
+// _meta. If it can't be read, the default scheme is returned.
+func (c *Client) IDScheme() commons.IDScheme {
+	s, err := commons.QueryIDScheme(c.db)
+	if err != nil {
+		slog.Debug("id scheme unavailable, using default", "error", err)
+	}
+	return s
+}
+
+// NewWantedID returns a fresh ID in the wasteland's scheme for an item
+// titled title. IDs already taken on main are skipped.
+func (c *Client) NewWantedID(title string) (string, error) {
+	return commons.NewWantedID(c.db, c.IDScheme(), title)
+}
+
+// ResolveID resolves a wanted ID or unambiguous ID prefix to a full ID,
+// searching main. In PR mode, items that exist only on the rig's own
+// branches are found too.
+func (c *Client) ResolveID(idOrPrefix string) (string, error) {
+	id, err := commons.ResolveWantedID(c.db, idOrPrefix)
+	var notFound *commons.NotFoundError
+	if err == nil || c.mode != "pr" || !errors.As(err, &notFound) {
+		return id, err
+	}
+	branches, berr := c.db.Branches(commons.BranchName(c.rigHandle, idOrPrefix))
+	if berr != nil || len(branches) == 0 {
+		return "", err
+	}
+	base := commons.BranchName(c.rigHandle, "")
+	if len(branches) > 1 {
+		ids := make([]string, len(branches))
+		for i, b := range branches {
+			ids[i] = strings.TrimPrefix(b, base)
+		}
+		return "", fmt.Errorf("ambiguous prefix %q matches: %s", idOrPrefix, strings.Join(ids, ", "))
+	}
+	return strings.TrimPrefix(branches[0], base), nil
+}
+
+// Tags returns the wasteland's tag registry. A wasteland without one gets
+// an empty registry.
+func (c *Client) Tags() (*commons.TagRegistry, error) {
+	return commons.QueryTagRegistry(c.db)
+}
+
+// normalizeTags maps tags to the registry's canonical forms for a post or
+// update, failing in strict mode on unregistered tags.
+func (c *Client) normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return tags, nil
+	}
+	reg, err := c.Tags()
+	if err != nil {
+		return nil, err
+	}
+	return reg.Normalize(tags)
+}
+
+// canonicalFilterTags rewrites a browse filter's tag terms to their
+// canonical forms so "tag:golang" finds items tagged "go". Items are
+// stored canonically, so an unreadable registry only costs the aliasing.
+func (c *Client) canonicalFilterTags(f *commons.BrowseFilter) {
+	if len(f.Tags) == 0 && len(f.Exclude.Tags) == 0 {
+		return
+	}
+	reg, err := c.Tags()
+	if err != nil {
+		slog.Debug("tag registry unavailable for browse filter", "error", err)
+		return
+	}
+	f.Tags = resolveTags(reg, f.Tags)
+	f.Exclude.Tags = resolveTags(reg, f.Exclude.Tags)
+}
+
+func resolveTags(reg *commons.TagRegistry, tags []string) []string {
+	if len(tags) == 0 {
+		return tags
+	}
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i], _ = reg.Resolve(t)
+	}
+	return out
+}
+
+// upstreamPending returns pending upstream PR state by wanted ID for the
+// "all" view, or nil when the view or client doesn't include it.
+func (c *Client) upstreamPending(filter commons.BrowseFilter) map[string][]PendingItem {
 	view := filter.View
 	if view == "" {
 		view = "all"
 	}
-	if view == "all" && c.ListPendingItems != nil {
-		upstreamItems, err = c.ListPendingItems()
-		if err == nil {
-			for id, pending := range upstreamItems {
-				pendingIDs[id] += len(pending)
-			}
-		}
+	if view != "all" || c.ListPendingItems == nil {
+		return nil
 	}
-
-	// Overlay claimed_by to reflect pending upstream candidates.
-	for i := range items {
-		pending := upstreamItems[items[i].ID]
-		if len(pending) == 0 {
-			continue
-		}
-		best := pending[0]
-		totalCandidates := len(pending)
-		if items[i].ClaimedBy != "" {
-			totalCandidates++
-		}
-		switch {
-		case totalCandidates > 1:
-			items[i].ClaimedBy = "Multiple (pending)"
-		case best.ClaimedBy != "":
-			items[i].ClaimedBy = best.ClaimedBy + " (pending)"
-		case best.RigHandle != "":
-			items[i].ClaimedBy = best.RigHandle + " (pending)"
-		}
+	upstreamItems, err := c.ListPendingItems()
+	if err != nil {
+		return nil
 	}
+	return upstreamItems
+}
 
-	return &BrowseResult{Items: items, PendingIDs: pendingIDs, UpstreamPending: upstreamItems}, nil
+// overlayUpstreamPending sets claimed_by to reflect pending upstream candidates.
+func overlayUpstreamPending(item *commons.WantedSummary, pending []PendingItem) {
+	if len(pending) == 0 {
+		return
+	}
+	best := pending[0]
+	totalCandidates := len(pending)
+	if item.ClaimedBy != "" {
+		totalCandidates++
+	}
+	switch {
+	case totalCandidates > 1:
+		item.ClaimedBy = "Multiple (pending)"
+	case best.ClaimedBy != "":
+		item.ClaimedBy = best.ClaimedBy + " (pending)"
+	case best.RigHandle != "":
+		item.ClaimedBy = best.RigHandle + " (pending)"
+	}
 }
 
 // Detail fetches the complete state of a wanted item including actions.
 func (c *Client) Detail(wantedID string) (*DetailResult, error) {
+	c.warnSchemaDrift()
 	if c.mode == "pr" {
 		return c.detailPR(wantedID)
 	}
@@ -116,14 +296,20 @@ func (c *Client) detailPR(wantedID string) (*DetailResult, error) {
 	if state.Main != nil {
 		result.MainStatus = state.Main.Status
 	}
-	if state.BranchName != "" && c.CheckPR != nil {
-		result.PRURL = c.CheckPR(state.BranchName)
+	if state.BranchName != "" {
+		result.PRURL = c.prURL(state.BranchName)
 	}
 	if state.BranchName != "" && c.BranchURL != nil {
 		result.BranchURL = c.BranchURL(state.BranchName)
 	}
 	result.BranchActions = c.computeBranchActions(result)
 	result.UpstreamPRs = c.fetchUpstreamPRs(wantedID)
+	result.Comments = c.itemReviewComments(wantedID)
+	c.loadApprovals(result, state.BranchName)
+	c.loadFollowUps(result, state.BranchName)
+	c.loadEvidence(result, state.BranchName)
+	c.loadClaimExpiry(result)
+	c.loadQueue(result, state.BranchName)
 	return result, nil
 }
 
@@ -139,6 +325,12 @@ func (c *Client) detailWildWest(wantedID string) (*DetailResult, error) {
 		Actions:    commons.AvailableTransitions(item, c.rigHandle),
 	}
 	result.UpstreamPRs = c.fetchUpstreamPRs(wantedID)
+	result.Comments = c.itemReviewComments(wantedID)
+	c.loadApprovals(result, "")
+	c.loadFollowUps(result, "")
+	c.loadEvidence(result, "")
+	c.loadClaimExpiry(result)
+	c.loadQueue(result, "")
 	return result, nil
 }
 
@@ -196,12 +388,39 @@ func (c *Client) computeBranchActions(r *DetailResult) []string {
 	return ComputeBranchActions(c.mode, r.Branch, r.Delta, r.PRURL, hasDelete)
 }
 
-// Dashboard fetches the personal dashboard for the current rig handle.
+// Dashboard fetches the personal dashboard for the current rig handle,
+// including released claims the rig is first in the queue for and the
+// rig's reputation.
 func (c *Client) Dashboard() (*commons.DashboardData, error) {
+	c.warnSchemaDrift()
 	return commons.QueryMyDashboardBranchAware(c.db, c.mode, c.rigHandle)
 }
 
+// Projects returns per-project item counts, top contributors and recent
+// activity read from main.
+func (c *Client) Projects() ([]commons.ProjectSummary, error) {
+	return commons.QueryProjectSummaries(c.db)
+}
+
 // Leaderboard returns ranked rig stats aggregated from completions and stamps.
-func (c *Client) Leaderboard(limit int) ([]commons.LeaderboardEntry, error) {
-	return commons.QueryLeaderboard(c.db, limit)
+func (c *Client) Leaderboard(f commons.LeaderboardFilter) ([]commons.LeaderboardEntry, error) {
+	return commons.QueryLeaderboard(c.db, f)
+}
+
+// Audit returns a page of the commit history on main: who did what, newest
+// first.
+func (c *Client) Audit(f commons.AuditFilter) (*commons.AuditPage, error) {
+	return commons.QueryAudit(c.db, f)
+}
+
+// Followed returns the items the rig follows, read from main: the watched
+// ids plus the live items it posted or claimed.
+func (c *Client) Followed(ids []string) ([]commons.WantedSummary, error) {
+	return commons.QueryFollowed(c.db, c.rigHandle, ids)
+}
+
+// Search finds wanted items, completions and stamps matching a free-text
+// query on main, best matches first.
+func (c *Client) Search(f commons.SearchFilter) ([]commons.SearchResult, error) {
+	return commons.Search(c.db, f)
 }
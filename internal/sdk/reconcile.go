@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// ReconciledBranch is a branch removed by Reconcile because its upstream
+// PR was merged or closed. Err is set if the branch could not be removed.
+type ReconciledBranch struct {
+	Branch   string
+	WantedID string
+	PRState  string // "merged" or "closed"
+	Err      error
+}
+
+// Reconcile removes the rig's branches whose upstream PR was merged or
+// closed. Each such branch is deleted locally and on the fork and its PR
+// is forgotten, so the item reads from main again and no longer shows as
+// pending. Branches without a PR, with an open PR, or whose PR state
+// can't be determined are kept. Callers should sync main first so merged
+// changes are visible there. Without a PRState callback nothing is done.
+func (c *Client) Reconcile() ([]ReconciledBranch, error) {
+	if c.PRState == nil {
+		return nil, nil
+	}
+	prefix := "wl/" + c.rigHandle + "/"
+	names, err := c.db.Branches(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var out []ReconciledBranch
+	for _, branch := range names {
+		state := c.PRState(branch)
+		if state != "merged" && state != "closed" {
+			continue
+		}
+		r := ReconciledBranch{Branch: branch, WantedID: extractWantedID(branch), PRState: state}
+		r.Err = c.removeBranch(branch)
+		c.notePR(branch, "")
+		slog.Info("reconciled branch", "branch", branch, "pr_state", state, "error", r.Err)
+		out = append(out, r)
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReconcile(t *testing.T) {
+	db := newFakeDB()
+	db.seedItem(fakeItem{ID: "w-1", Title: "Fix bug", Status: "completed", ClaimedBy: "bob", PostedBy: "alice", EffortLevel: "medium"})
+	db.seedItem(fakeItem{ID: "w-2", Title: "Write docs", Status: "open", PostedBy: "alice", EffortLevel: "small"})
+	db.seedItem(fakeItem{ID: "w-3", Title: "Ship it", Status: "open", PostedBy: "alice", EffortLevel: "small"})
+	for _, id := range []string{"w-1", "w-2", "w-3"} {
+		branch := "wl/bob/" + id
+		db.branches[branch] = true
+		db.branchItems[branch] = map[string]*fakeItem{
+			id: {ID: id, Title: "x", Status: "claimed", ClaimedBy: "bob", PostedBy: "alice", EffortLevel: "small"},
+		}
+	}
+	db.branches["wl/alice/w-2"] = true
+
+	states := map[string]string{"wl/bob/w-1": "merged", "wl/bob/w-2": "closed", "wl/bob/w-3": "open", "wl/alice/w-2": "merged"}
+	c := New(ClientConfig{
+		DB: db, RigHandle: "bob", Mode: "pr",
+		PRState: func(branch string) string { return states[branch] },
+		CheckPR: func(branch string) string {
+			if states[branch] != "" {
+				return "https://example.com/pr/" + branch
+			}
+			return ""
+		},
+		PRStatusTTL: time.Hour,
+	})
+
+	got, err := c.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].Branch < got[j].Branch })
+	var summary []string
+	for _, r := range got {
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.Branch, r.Err)
+		}
+		summary = append(summary, r.Branch+"="+r.PRState)
+	}
+	if s := strings.Join(summary, ","); s != "wl/bob/w-1=merged,wl/bob/w-2=closed" {
+		t.Errorf("reconciled = %s", s)
+	}
+
+	// Merged and closed branches are gone; the open one and other rigs' stay.
+	if db.branches["wl/bob/w-1"] || db.branches["wl/bob/w-2"] {
+		t.Errorf("reconciled branches still exist: %v", db.branches)
+	}
+	if !db.branches["wl/bob/w-3"] || !db.branches["wl/alice/w-2"] {
+		t.Errorf("kept branches were removed: %v", db.branches)
+	}
+
+	// The item now reads from main, without a pending branch.
+	detail, err := c.Detail("w-1")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if detail.Branch != "" || detail.PRURL != "" || detail.Item.Status != "completed" {
+		t.Errorf("detail after reconcile: branch=%q pr=%q status=%q", detail.Branch, detail.PRURL, detail.Item.Status)
+	}
+}
+
+func TestReconcile_NoPRState(t *testing.T) {
+	db := newFakeDB()
+	db.branches["wl/bob/w-1"] = true
+	c := New(ClientConfig{DB: db, RigHandle: "bob", Mode: "pr"})
+
+	got, err := c.Reconcile()
+	if err != nil || len(got) != 0 || !db.branches["wl/bob/w-1"] {
+		t.Errorf("got %v, err %v, branches %v; want no-op", got, err, db.branches)
+	}
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"log/slog"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// SchemaDrift compares the wasteland's schema against the one this release
+// of wl expects. It returns nil drift when the schema can't be inspected.
+func (c *Client) SchemaDrift() (*commons.SchemaDrift, error) {
+	return commons.CheckSchemaDrift(c.db)
+}
+
+// warnSchemaDrift logs a warning the first time the client touches the
+// database if its schema has drifted, so a missing column shows up as a
+// pointer to wl doctor rather than only as a confusing SQL error.
+func (c *Client) warnSchemaDrift() {
+	c.schemaOnce.Do(func() {
+		drift, err := c.SchemaDrift()
+		if err != nil {
+			slog.Debug("schema drift check skipped", "error", err)
+			return
+		}
+		if drift != nil && !drift.Empty() {
+			slog.Warn("wasteland schema differs from what this wl expects; run 'wl doctor' for details", "drift", drift.String())
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestSchemaDrift_WarnsOnce(t *testing.T) {
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	db := newFakeDB()
+	db.columnsCSV = "wanted,id\nwanted,title\n"
+	client := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+
+	drift, err := client.SchemaDrift()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift == nil || len(drift.MissingColumns["wanted"]) == 0 {
+		t.Fatalf("drift = %+v, want missing wanted columns", drift)
+	}
+
+	if _, err := client.Browse(commons.BrowseFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Dashboard(); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(logs.String(), "wl doctor"); n != 1 {
+		t.Errorf("got %d drift warnings, want 1:\n%s", n, logs.String())
+	}
+	if !strings.Contains(logs.String(), "wanted.status") {
+		t.Errorf("warning should name missing columns:\n%s", logs.String())
+	}
+}
+
+func TestSchemaDrift_NoColumnsNoWarning(t *testing.T) {
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	client := New(ClientConfig{DB: newFakeDB(), RigHandle: "alice", Mode: "wild-west"})
+	if _, err := client.Browse(commons.BrowseFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no warning without a column listing:\n%s", logs.String())
+	}
+}
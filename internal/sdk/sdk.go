@@ -5,19 +5,36 @@
 package sdk
 
 import (
+	"io"
 	"sync"
+	"time"
 
 	"github.com/gastownhall/wasteland/internal/commons"
 )
 
 // ClientConfig holds the parameters needed to create a Client.
 type ClientConfig struct {
-	DB        commons.DB // database backend (required)
-	RigHandle string     // current rig handle (required)
-	Mode      string     // "wild-west" or "pr"
-	Signing   bool       // GPG-signed dolt commits
-	HopURI    string     // rig's HOP protocol URI
-	NoPush    bool       // skip pushing after mutations
+	DB         commons.DB // database backend (required)
+	RigHandle  string     // current rig handle (required)
+	Mode       string     // "wild-west" or "pr"
+	Signing    bool       // GPG-signed dolt commits
+	SigningKey string     // GPG key ID for signed commits; "" uses dolt's user.signingkey
+	HopURI     string     // rig's HOP protocol URI
+	NoPush     bool       // skip pushing after mutations
+	ReadOnly   bool       // refuse every mutation (see commons.ReadOnly)
+
+	// AllowSecrets commits text that looks like a credential instead of
+	// refusing with *commons.SecretError.
+	AllowSecrets bool
+
+	// ConfirmPush, when set, is asked with the commit message before a
+	// wild-west mutation is committed and pushed upstream; declining
+	// cancels it with ErrPushDeclined. Nil pushes without asking.
+	ConfirmPush func(commitMsg string) bool
+
+	// PushOutput, when set, also receives the progress lines of wild-west
+	// pushes ("Pushed to upstream", "Syncing with origin...").
+	PushOutput io.Writer
 
 	// Optional callbacks — nil disables the feature.
 	CreatePR         func(branch string) (string, error)
@@ -28,17 +45,40 @@ type ClientConfig struct {
 	ListPendingItems func() (map[string][]PendingItem, error) // returns wanted IDs with pending upstream PR state
 	BranchURL        func(branch string) string               // returns a web URL for the branch
 	CloseUpstreamPR  func(prURL string) error                 // close an upstream PR by its web URL
+	SquashBranch     func(branch, message string) error       // collapse a branch into one commit
+	UpdateBranch     func(branch string) error                // merge latest upstream main into a branch
+	PRState          func(branch string) string               // "open", "merged", "closed" or "" for a branch's PR
+	Hooks            HookRunner                               // user lifecycle hooks (pre-claim, post-done, ...)
+	RateLimits       func() []commons.RateLimit               // rate-limit state of provider calls made outside DB (e.g. gh)
+
+	// PRStatusTTL caches CheckPR results for this long and refreshes them
+	// in the background, so reads never wait on the provider API. Zero
+	// calls CheckPR synchronously on every lookup.
+	PRStatusTTL time.Duration
 }
 
 // Client provides mode-aware operations against the Wasteland wanted board.
 type Client struct {
-	db        commons.DB
-	rigHandle string
-	mode      string
-	signing   bool
-	hopURI    string
-	noPush    bool
-	mu        sync.Mutex // serializes mutations (dolt CLI is single-writer)
+	db         commons.DB
+	rigHandle  string
+	mode       string
+	signing    bool
+	signingKey string
+	hopURI     string
+	noPush     bool
+	readOnly   bool
+	hooks      HookRunner
+
+	allowSecrets bool // skip the secret scan (see checkSecrets)
+	confirmPush  func(string) bool
+	pushOutput   io.Writer
+	prCache      *prStatusCache // nil when PR lookups are synchronous
+	mu           sync.Mutex     // serializes mutations (dolt CLI is single-writer)
+
+	vocabMu sync.Mutex
+	vocab   *commons.Vocabulary // nil until first successfully loaded
+
+	schemaOnce sync.Once // guards the one-time schema drift warning
 
 	// CreatePR submits a PR for the given branch. Nil disables the feature.
 	CreatePR func(branch string) (string, error)
@@ -56,17 +96,47 @@ type Client struct {
 	BranchURL func(branch string) string
 	// CloseUpstreamPR closes an upstream PR by its web URL. Nil disables the feature.
 	CloseUpstreamPR func(prURL string) error
+	// SquashBranch collapses a branch into a single commit with the given
+	// message. Nil disables the feature.
+	SquashBranch func(branch, message string) error
+	// UpdateBranch merges the latest upstream main into a branch, returning
+	// a *commons.ConflictError if they conflict. Nil disables the feature.
+	UpdateBranch func(branch string) error
+	// RateLimits reports rate-limit state for provider calls the DB doesn't
+	// make, such as PR operations through gh. Nil means none.
+	RateLimits func() []commons.RateLimit
+	// PRState returns the state of the branch's upstream PR: "open",
+	// "merged", "closed", or "" if there is none or it is unknown. Nil
+	// disables reconciliation.
+	PRState func(branch string) string
 }
 
-// New creates a Client from the given config.
+// New creates a Client from the given config. With cfg.ReadOnly the DB is
+// wrapped by commons.ReadOnly and every callback that changes a remote
+// (PRs, settings, squash, update) is replaced by one that refuses, so no
+// frontend can mutate through the client.
 func New(cfg ClientConfig) *Client {
+	if cfg.ReadOnly {
+		cfg = readOnlyConfig(cfg)
+	}
+	var prCache *prStatusCache
+	if cfg.PRStatusTTL > 0 && cfg.CheckPR != nil {
+		prCache = newPRStatusCache(cfg.CheckPR, cfg.ListPendingItems, cfg.PRStatusTTL)
+	}
 	return &Client{
 		db:               cfg.DB,
 		rigHandle:        cfg.RigHandle,
 		mode:             cfg.Mode,
 		signing:          cfg.Signing,
+		signingKey:       cfg.SigningKey,
 		hopURI:           cfg.HopURI,
 		noPush:           cfg.NoPush,
+		readOnly:         cfg.ReadOnly,
+		allowSecrets:     cfg.AllowSecrets,
+		confirmPush:      cfg.ConfirmPush,
+		pushOutput:       cfg.PushOutput,
+		hooks:            cfg.Hooks,
+		prCache:          prCache,
 		CreatePR:         cfg.CreatePR,
 		CheckPR:          cfg.CheckPR,
 		ClosePR:          cfg.ClosePR,
@@ -75,12 +145,24 @@ func New(cfg ClientConfig) *Client {
 		ListPendingItems: cfg.ListPendingItems,
 		BranchURL:        cfg.BranchURL,
 		CloseUpstreamPR:  cfg.CloseUpstreamPR,
+		SquashBranch:     cfg.SquashBranch,
+		UpdateBranch:     cfg.UpdateBranch,
+		PRState:          cfg.PRState,
+		RateLimits:       cfg.RateLimits,
 	}
 }
 
+// commitSigning returns how the client's commits are signed.
+func (c *Client) commitSigning() commons.Signing {
+	return commons.Signing{Enabled: c.signing, Key: c.signingKey}
+}
+
 // Mode returns the current workflow mode ("wild-west" or "pr").
 func (c *Client) Mode() string { return c.mode }
 
+// ReadOnly reports whether the client refuses mutations.
+func (c *Client) ReadOnly() bool { return c.readOnly }
+
 // RigHandle returns the current rig handle.
 func (c *Client) RigHandle() string { return c.rigHandle }
 
@@ -94,8 +176,14 @@ func (c *Client) WithRigHandle(handle string) *Client {
 		rigHandle:        handle,
 		mode:             c.mode,
 		signing:          c.signing,
+		signingKey:       c.signingKey,
 		hopURI:           c.hopURI,
 		noPush:           c.noPush,
+		readOnly:         c.readOnly,
+		allowSecrets:     c.allowSecrets,
+		confirmPush:      c.confirmPush,
+		pushOutput:       c.pushOutput,
+		prCache:          c.prCache,
 		CreatePR:         c.CreatePR,
 		CheckPR:          c.CheckPR,
 		ClosePR:          c.ClosePR,
@@ -104,5 +192,55 @@ func (c *Client) WithRigHandle(handle string) *Client {
 		ListPendingItems: c.ListPendingItems,
 		BranchURL:        c.BranchURL,
 		CloseUpstreamPR:  c.CloseUpstreamPR,
+		SquashBranch:     c.SquashBranch,
+		UpdateBranch:     c.UpdateBranch,
+		PRState:          c.PRState,
+		RateLimits:       c.RateLimits,
+	}
+}
+
+// prURL returns the PR URL for branch, or "" if there is none (or no
+// CheckPR callback). With a PR status cache this never blocks.
+func (c *Client) prURL(branch string) string {
+	if c.CheckPR == nil {
+		return ""
+	}
+	if c.prCache != nil {
+		return c.prCache.lookup(branch)
+	}
+	return c.CheckPR(branch)
+}
+
+// notePR records a PR URL learned from creating ("url") or closing ("")
+// a PR, keeping the cache current without another provider call.
+func (c *Client) notePR(branch, url string) {
+	if c.prCache != nil {
+		c.prCache.set(branch, url)
+	}
+}
+
+// readOnlyConfig returns cfg with its DB wrapped read-only and its mutating
+// callbacks replaced by ones that fail with *commons.ReadOnlyError. Nil
+// callbacks stay nil so features remain reported as unavailable.
+func readOnlyConfig(cfg ClientConfig) ClientConfig {
+	cfg.DB = commons.ReadOnly(cfg.DB)
+	if cfg.CreatePR != nil {
+		cfg.CreatePR = func(string) (string, error) { return "", &commons.ReadOnlyError{Op: "create PR"} }
+	}
+	if cfg.ClosePR != nil {
+		cfg.ClosePR = func(string) error { return &commons.ReadOnlyError{Op: "close PR"} }
+	}
+	if cfg.CloseUpstreamPR != nil {
+		cfg.CloseUpstreamPR = func(string) error { return &commons.ReadOnlyError{Op: "close PR"} }
+	}
+	if cfg.SaveConfig != nil {
+		cfg.SaveConfig = func(string, bool) error { return &commons.ReadOnlyError{Op: "save settings"} }
+	}
+	if cfg.SquashBranch != nil {
+		cfg.SquashBranch = func(string, string) error { return &commons.ReadOnlyError{Op: "squash"} }
+	}
+	if cfg.UpdateBranch != nil {
+		cfg.UpdateBranch = func(string) error { return &commons.ReadOnlyError{Op: "update branch"} }
 	}
+	return cfg
 }
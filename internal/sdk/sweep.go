@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// SweepInput selects which stale claims Sweep reverts.
+type SweepInput struct {
+	PostedBy string // only items posted by this rig ("" = all)
+	DryRun   bool   // report stale claims without reverting them
+}
+
+// SweptClaim is one stale claim found by Sweep.
+type SweptClaim struct {
+	ID        string
+	Title     string
+	ClaimedBy string
+	Age       time.Duration // time since the item's last activity
+	Next      string        // first rig in the item's claim queue, if any
+	Passed    bool          // the claim passed to Next (auto-claim)
+	Err       error         // why reverting failed; nil when reopened or on a dry run
+}
+
+// Notice describes the expired claim for the claimer and the board.
+func (s SweptClaim) Notice() string {
+	days := int(s.Age.Hours() / 24)
+	switch {
+	case s.Passed:
+		return fmt.Sprintf("claim by %s expired after %d days without activity; %s passed to %s, first in its claim queue",
+			s.ClaimedBy, days, s.ID, s.Next)
+	case s.Next != "":
+		return fmt.Sprintf("claim by %s expired after %d days without activity; %s is open again and up next for %s",
+			s.ClaimedBy, days, s.ID, s.Next)
+	default:
+		return fmt.Sprintf("claim by %s expired after %d days without activity; %s is open again",
+			s.ClaimedBy, days, s.ID)
+	}
+}
+
+// SweepResult is the outcome of a Sweep.
+type SweepResult struct {
+	Expiry time.Duration // the wasteland's claim expiry; 0 = claims never expire
+	Claims []SweptClaim
+}
+
+// ClaimExpiry returns how long a claim may go without activity before
+// Sweep reverts it, or 0 when the wasteland has no expiry policy.
+func (c *Client) ClaimExpiry() (time.Duration, error) {
+	return commons.QueryClaimExpiry(c.db)
+}
+
+// Sweep reverts claims that have gone without activity for longer than the
+// wasteland's claim_expiry_days policy back to open. Each item is its own
+// mutation; one failing doesn't stop the rest and is reported on its
+// SweptClaim. A reopened item is handed to its claim queue like an
+// unclaim. Without a policy nothing is swept.
+func (c *Client) Sweep(in SweepInput) (*SweepResult, error) {
+	expiry, err := c.ClaimExpiry()
+	if err != nil {
+		return nil, err
+	}
+	result := &SweepResult{Expiry: expiry}
+	if expiry == 0 {
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	stale, err := commons.QueryStaleClaims(c.db, expiry, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stale {
+		if in.PostedBy != "" && s.PostedBy != in.PostedBy {
+			continue
+		}
+		swept := SweptClaim{ID: s.ID, Title: s.Title, ClaimedBy: s.ClaimedBy, Age: now.Sub(s.ActiveAt)}
+		if !in.DryRun {
+			stmts, next, passed := c.releaseClaim(s.ID, []string{commons.ExpireClaimDML(s.ID, s.ClaimedBy)})
+			swept.Next, swept.Passed = next.RigHandle, passed
+			// The notice goes in the commit message so the item's history
+			// says why it reopened.
+			_, swept.Err = c.mutate(s.ID, "wl sweep: "+s.ID+" - "+swept.Notice(), stmts...)
+			if swept.Err != nil {
+				swept.Next, swept.Passed = "", false
+			}
+		}
+		result.Claims = append(result.Claims, swept)
+	}
+	return result, nil
+}
+
+// loadClaimExpiry fills the claim expiry policy of a claimed item for the
+// detail view. Failures are logged and leave the detail without one.
+func (c *Client) loadClaimExpiry(detail *DetailResult) {
+	if detail.Item == nil || detail.Item.Status != "claimed" {
+		return
+	}
+	expiry, err := c.ClaimExpiry()
+	if err != nil {
+		slog.Debug("loading claim expiry failed", "wanted_id", detail.Item.ID, "error", err)
+		return
+	}
+	detail.ClaimExpiry = expiry
+}
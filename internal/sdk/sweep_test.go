@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func seedSweepItems(db *fakeDB) {
+	old := time.Now().UTC().Add(-30 * 24 * time.Hour).Format(time.DateTime)
+	recent := time.Now().UTC().Add(-time.Hour).Format(time.DateTime)
+	db.seedItem(fakeItem{ID: "w-old", Title: "Old work", Status: "claimed", PostedBy: "alice", ClaimedBy: "bob", UpdatedAt: old})
+	db.seedItem(fakeItem{ID: "w-other", Title: "Other poster", Status: "claimed", PostedBy: "carol", ClaimedBy: "bob", UpdatedAt: old})
+	db.seedItem(fakeItem{ID: "w-new", Title: "New work", Status: "claimed", PostedBy: "alice", ClaimedBy: "dave", UpdatedAt: recent})
+}
+
+func TestSweep_RevertsStaleClaims(t *testing.T) {
+	db := newFakeDB()
+	db.claimExpiryDays = 14
+	seedSweepItems(db)
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	result, err := c.Sweep(SweepInput{})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if result.Expiry != 14*24*time.Hour {
+		t.Errorf("expiry = %v", result.Expiry)
+	}
+	if len(result.Claims) != 2 || result.Claims[0].ID != "w-old" || result.Claims[1].ID != "w-other" {
+		t.Fatalf("claims = %+v, want w-old and w-other", result.Claims)
+	}
+	for _, id := range []string{"w-old", "w-other"} {
+		if item := db.items[id]; item.Status != "open" || item.ClaimedBy != "" {
+			t.Errorf("%s = %s/%s, want open and unclaimed", id, item.Status, item.ClaimedBy)
+		}
+	}
+	if db.items["w-new"].Status != "claimed" {
+		t.Error("recent claim should be left alone")
+	}
+	if msg := db.execCalls[0].CommitMsg; msg != "wl sweep: w-old - "+result.Claims[0].Notice() {
+		t.Errorf("commit message = %q, want the notice recorded", msg)
+	}
+	if notice := result.Claims[0].Notice(); !strings.Contains(notice, "claim by bob expired after 30 days") {
+		t.Errorf("notice = %q", notice)
+	}
+}
+
+func TestSweep_PostedByAndDryRun(t *testing.T) {
+	db := newFakeDB()
+	db.claimExpiryDays = 14
+	seedSweepItems(db)
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	result, err := c.Sweep(SweepInput{PostedBy: "alice", DryRun: true})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if len(result.Claims) != 1 || result.Claims[0].ID != "w-old" {
+		t.Fatalf("claims = %+v, want only w-old", result.Claims)
+	}
+	if len(db.execCalls) != 0 || db.items["w-old"].Status != "claimed" {
+		t.Error("dry run should not write")
+	}
+}
+
+func TestSweep_NoPolicy(t *testing.T) {
+	db := newFakeDB()
+	seedSweepItems(db)
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	result, err := c.Sweep(SweepInput{})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if result.Expiry != 0 || len(result.Claims) != 0 || len(db.execCalls) != 0 {
+		t.Errorf("result = %+v, execs = %d; want nothing swept", result, len(db.execCalls))
+	}
+}
+
+func TestDetail_ClaimExpiry(t *testing.T) {
+	db := newFakeDB()
+	db.claimExpiryDays = 14
+	seedSweepItems(db)
+
+	c := New(ClientConfig{DB: db, RigHandle: "alice", Mode: "wild-west"})
+	detail, err := c.Detail("w-old")
+	if err != nil {
+		t.Fatalf("Detail: %v", err)
+	}
+	if detail.ClaimExpiry != 14*24*time.Hour {
+		t.Errorf("claim expiry = %v", detail.ClaimExpiry)
+	}
+	age, ok := detail.ClaimAge(time.Now().UTC())
+	if !ok || age < 29*24*time.Hour {
+		t.Errorf("claim age = %v, %v; want about 30 days", age, ok)
+	}
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// CanUndo reports whether a transition has a compensating transition that
+// Undo can issue.
+func CanUndo(t commons.Transition) bool {
+	switch t {
+	case commons.TransitionClaim, commons.TransitionUnclaim,
+		commons.TransitionClose, commons.TransitionDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Undo reverts a previous transition on a wanted item by issuing its
+// compensating transition: claim ↔ unclaim, close → in_review, delete → open.
+// prevClaimedBy restores the claimant when undoing an unclaim.
+//
+// Only supported in wild-west mode — in PR mode the branch can simply be
+// discarded instead.
+func (c *Client) Undo(wantedID string, t commons.Transition, prevClaimedBy string) (*MutationResult, error) {
+	if c.mode == "pr" {
+		return nil, fmt.Errorf("undo is only available in wild-west mode")
+	}
+
+	var stmt string
+	switch t {
+	case commons.TransitionClaim:
+		stmt = commons.UnclaimWantedDML(wantedID)
+	case commons.TransitionUnclaim:
+		if prevClaimedBy == "" {
+			return nil, fmt.Errorf("cannot undo unclaim: previous claimant unknown")
+		}
+		stmt = commons.ClaimWantedDML(wantedID, prevClaimedBy)
+	case commons.TransitionClose:
+		stmt = commons.ReopenWantedDML(wantedID)
+	case commons.TransitionDelete:
+		stmt = commons.RestoreWantedDML(wantedID)
+	default:
+		return nil, fmt.Errorf("cannot undo %s", commons.TransitionName(t))
+	}
+
+	name := commons.TransitionName(t)
+	result, err := c.mutate(wantedID, fmt.Sprintf("wl undo %s: %s", name, wantedID), stmt)
+	if err != nil {
+		return nil, err
+	}
+	if result.Hint == "" {
+		result.Hint = fmt.Sprintf("Undid %s on %s", name, wantedID)
+	}
+	return result, nil
+}
@@ -0,0 +1,188 @@
+// Package site renders a wasteland's board, item details and leaderboard
+// into a static, read-only HTML site: plain pages and one stylesheet, no
+// JavaScript, with relative links so it can be served from any path (e.g.
+// GitHub Pages).
+package site
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// pageSize is how many board rows Load reads per query.
+const pageSize = 500
+
+// leaderboardSize is how many rigs the leaderboard page ranks.
+const leaderboardSize = 100
+
+// statusOrder is the order the board lists its sections in.
+var statusOrder = []string{"open", "claimed", "in_review", "completed", "withdrawn"}
+
+// Data is everything the site shows.
+type Data struct {
+	Wasteland   string
+	Generated   time.Time
+	Items       []commons.WantedSummary // board rows, in board order
+	Details     map[string]*Detail      // by wanted ID
+	Leaderboard []commons.LeaderboardEntry
+}
+
+// Detail is one item page.
+type Detail struct {
+	Item       *commons.WantedItem
+	Completion *commons.CompletionRecord
+	Stamp      *commons.Stamp
+}
+
+// Load reads the board, every item's detail and the leaderboard from db.
+func Load(db commons.DB, wasteland string, now time.Time) (*Data, error) {
+	d := &Data{Wasteland: wasteland, Generated: now, Details: map[string]*Detail{}}
+	for offset := 0; ; offset += pageSize {
+		f := commons.BrowseFilter{Priority: -1, Limit: pageSize, Offset: offset}
+		n := 0
+		err := commons.EachWanted(db, f, func(w commons.WantedSummary) error {
+			d.Items = append(d.Items, w)
+			n++
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading board: %w", err)
+		}
+		if n < pageSize {
+			break
+		}
+	}
+	for _, w := range d.Items {
+		item, completion, stamp, err := commons.QueryFullDetail(db, w.ID)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", w.ID, err)
+		}
+		if item != nil {
+			d.Details[w.ID] = &Detail{Item: item, Completion: completion, Stamp: stamp}
+		}
+	}
+	lb, err := commons.QueryLeaderboard(db, commons.LeaderboardFilter{Limit: leaderboardSize, Now: now})
+	if err != nil {
+		return nil, fmt.Errorf("reading leaderboard: %w", err)
+	}
+	d.Leaderboard = lb
+	return d, nil
+}
+
+// section is one status group on the board page.
+type section struct {
+	Status string
+	Items  []commons.WantedSummary
+}
+
+// sections groups the board by status, in statusOrder and then any other
+// statuses in the order they appear.
+func (d *Data) sections() []section {
+	byStatus := map[string][]commons.WantedSummary{}
+	order := append([]string(nil), statusOrder...)
+	for _, w := range d.Items {
+		if _, ok := byStatus[w.Status]; !ok && !slices.Contains(order, w.Status) {
+			order = append(order, w.Status)
+		}
+		byStatus[w.Status] = append(byStatus[w.Status], w)
+	}
+	var out []section
+	for _, s := range order {
+		if items := byStatus[s]; len(items) > 0 {
+			out = append(out, section{Status: s, Items: items})
+		}
+	}
+	return out
+}
+
+var funcs = template.FuncMap{
+	"priority": commons.PriorityLabel,
+	"status":   func(s string) string { return strings.ReplaceAll(s, "_", " ") },
+	"itemPath": itemPath,
+	"isURL": func(s string) bool {
+		return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+	},
+	"join":  strings.Join,
+	"float": func(f float64) string { return fmt.Sprintf("%.1f", f) },
+	"date":  func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}
+
+// itemPath is an item page's path relative to the site root. IDs are
+// reduced to safe file name characters.
+func itemPath(id string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, id)
+	return "items/" + safe + ".html"
+}
+
+// page is what every template is executed with.
+type page struct {
+	*Data
+	Title string
+	Root  string // relative path from the page to the site root
+	// One of these is set, depending on the page.
+	Sections []section
+	Detail   *Detail
+}
+
+// Render writes the site for d into dir, creating it if needed. Existing
+// files with the same names are overwritten; others are left alone.
+func Render(dir string, d *Data) error {
+	tmpl, err := template.New("").Funcs(funcs).ParseFS(templateFS, "templates/*")
+	if err != nil {
+		return fmt.Errorf("parsing templates: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "items"), 0o755); err != nil {
+		return err
+	}
+
+	css, err := templateFS.ReadFile("templates/style.css")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "style.css"), css, 0o644); err != nil {
+		return err
+	}
+
+	write := func(name, tmplName string, p page) error {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, tmplName, p); err != nil {
+			return fmt.Errorf("rendering %s: %w", name, err)
+		}
+		return os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644)
+	}
+	if err := write("index.html", "board.html", page{Data: d, Title: d.Wasteland, Sections: d.sections()}); err != nil {
+		return err
+	}
+	if err := write("leaderboard.html", "leaderboard.html", page{Data: d, Title: "Leaderboard"}); err != nil {
+		return err
+	}
+	for _, w := range d.Items {
+		det := d.Details[w.ID]
+		if det == nil {
+			continue
+		}
+		p := page{Data: d, Title: w.ID + ": " + w.Title, Root: "../", Detail: det}
+		if err := write(itemPath(w.ID), "item.html", p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
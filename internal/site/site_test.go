@@ -0,0 +1,115 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func testData() *Data {
+	return &Data{
+		Wasteland: "hop/wl-commons",
+		Generated: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		Items: []commons.WantedSummary{
+			{ID: "w-1", Title: "Fix <script> escaping", Status: "open", Priority: 1, PostedBy: "alice"},
+			{ID: "w-2", Title: "Write docs", Status: "completed", Priority: 2, ClaimedBy: "bob"},
+			{ID: "w-3", Title: "Odd one", Status: "archived", Priority: 3},
+		},
+		Details: map[string]*Detail{
+			"w-1": {Item: &commons.WantedItem{ID: "w-1", Title: "Fix <script> escaping", Status: "open", Description: "line one\nline two"}},
+			"w-2": {
+				Item:       &commons.WantedItem{ID: "w-2", Title: "Write docs", Status: "completed", Tags: []string{"docs"}},
+				Completion: &commons.CompletionRecord{ID: "c-1", CompletedBy: "bob", Evidence: "https://example.com/pr/1"},
+				Stamp:      &commons.Stamp{Author: "alice", Quality: 4, Reliability: 5, Message: "nice"},
+			},
+		},
+		Leaderboard: []commons.LeaderboardEntry{{RigHandle: "bob", Rank: 1, Completions: 1, AvgQuality: 4}},
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	if err := Render(dir, testData()); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	index := readFile(t, filepath.Join(dir, "index.html"))
+	for _, want := range []string{
+		`href="items/w-1.html"`,
+		`href="style.css"`,
+		`Fix &lt;script&gt; escaping`,
+		`<section id="open">`,
+		`<section id="archived">`,
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html missing %q", want)
+		}
+	}
+	if strings.Contains(index, "<script>") {
+		t.Error("index.html contains an unescaped title")
+	}
+	if strings.Index(index, `id="open"`) > strings.Index(index, `id="completed"`) {
+		t.Error("open section should come before completed")
+	}
+
+	item := readFile(t, filepath.Join(dir, "items", "w-2.html"))
+	for _, want := range []string{
+		`href="../style.css"`,
+		`href="../leaderboard.html"`,
+		`<a href="https://example.com/pr/1">`,
+		`nice`,
+		`docs`,
+	} {
+		if !strings.Contains(item, want) {
+			t.Errorf("items/w-2.html missing %q", want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "items", "w-3.html")); !os.IsNotExist(err) {
+		t.Error("item without detail should have no page")
+	}
+
+	if lb := readFile(t, filepath.Join(dir, "leaderboard.html")); !strings.Contains(lb, "bob") {
+		t.Error("leaderboard.html missing bob")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "style.css")); err != nil {
+		t.Errorf("style.css: %v", err)
+	}
+}
+
+func TestRenderEvidenceNotURL(t *testing.T) {
+	d := testData()
+	d.Details["w-2"].Completion.Evidence = "javascript:alert(1)"
+	dir := t.TempDir()
+	if err := Render(dir, d); err != nil {
+		t.Fatal(err)
+	}
+	if item := readFile(t, filepath.Join(dir, "items", "w-2.html")); strings.Contains(item, `href="javascript`) {
+		t.Error("non-http evidence should not be linked")
+	}
+}
+
+func TestItemPath(t *testing.T) {
+	tests := map[string]string{
+		"w-abc123":  "items/w-abc123.html",
+		"../../etc": "items/.._.._etc.html",
+		"a b/c":     "items/a_b_c.html",
+	}
+	for id, want := range tests {
+		if got := itemPath(id); got != want {
+			t.Errorf("itemPath(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
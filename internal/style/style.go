@@ -3,9 +3,13 @@
 package style
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
 )
 
 // Ayu theme color palette (inlined from gastown's internal/ui/styles.go)
@@ -68,25 +72,114 @@ var (
 		Bold(true)
 )
 
-// SetColorMode overrides style rendering based on --color flag or NO_COLOR env.
-func SetColorMode(mode string) {
+// ColorModes are the values --color accepts. "auto" detects the terminal's
+// capability; "16", "256" and "truecolor" pin a palette size.
+var ColorModes = []string{"auto", "always", "never", "16", "256", "truecolor"}
+
+// SetColorMode configures the color profile every lipgloss style — the CLI
+// styles here and the TUI theme — renders with. Hex colors are degraded to
+// the nearest color the profile supports.
+func SetColorMode(mode string) error {
+	isTTY := isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
+	var p termenv.Profile
 	switch mode {
+	case "auto":
+		p = DetectProfile(os.Getenv, isTTY)
+	case "always":
+		_ = os.Unsetenv("NO_COLOR")
+		_ = os.Setenv("CLICOLOR_FORCE", "1")
+		p = atLeastANSI(termProfile(os.Getenv))
 	case "never":
 		_ = os.Setenv("NO_COLOR", "1")
+		p = termenv.Ascii
+	case "16":
+		p = termenv.ANSI
+	case "256":
+		p = termenv.ANSI256
+	case "truecolor":
+		p = termenv.TrueColor
+	default:
+		return fmt.Errorf("invalid --color value %q: must be one of %s", mode, strings.Join(ColorModes, ", "))
+	}
+	setProfile(p)
+	return nil
+}
+
+// Colorless reports whether output is rendered without any styling, so
+// callers can fall back to plain-text cues such as a selection marker.
+func Colorless() bool { return lipgloss.ColorProfile() == termenv.Ascii }
+
+// DetectProfile returns the color profile for output going to a terminal
+// (isTTY) with the given environment. NO_COLOR disables color; CLICOLOR=0
+// disables it unless CLICOLOR_FORCE is set; CLICOLOR_FORCE enables it even
+// when output isn't a terminal.
+func DetectProfile(getenv func(string) string, isTTY bool) termenv.Profile {
+	if getenv("NO_COLOR") != "" {
+		return termenv.Ascii
+	}
+	if forced := getenv("CLICOLOR_FORCE"); forced != "" && forced != "0" {
+		return atLeastANSI(termProfile(getenv))
+	}
+	if getenv("CLICOLOR") == "0" || !isTTY {
+		return termenv.Ascii
+	}
+	return termProfile(getenv)
+}
+
+// termProfile returns the palette the terminal advertises via COLORTERM,
+// TERM and TERM_PROGRAM, without regard to whether color is wanted.
+// termenv profiles order richest first, so TrueColor < ANSI256 < ANSI < Ascii.
+func termProfile(getenv func(string) string) termenv.Profile {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return termenv.TrueColor
+	}
+	term := strings.ToLower(getenv("TERM"))
+	switch {
+	case term == "dumb":
+		return termenv.Ascii
+	case strings.Contains(term, "direct") || strings.HasPrefix(term, "xterm-kitty") || strings.HasPrefix(term, "alacritty") || strings.HasPrefix(term, "wezterm"):
+		return termenv.TrueColor
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty":
+		return termenv.TrueColor
+	case "Apple_Terminal":
+		return termenv.ANSI256
+	}
+	if strings.Contains(term, "256color") {
+		return termenv.ANSI256
+	}
+	if term == "" {
+		return termenv.Ascii
+	}
+	return termenv.ANSI
+}
+
+// atLeastANSI upgrades a colorless profile to 16 colors for forced color.
+// termenv orders profiles richest first, so the richer profile is the min.
+func atLeastANSI(p termenv.Profile) termenv.Profile {
+	return min(p, termenv.ANSI)
+}
+
+// setProfile applies p to lipgloss and rebuilds the CLI styles. Without
+// color, Success/Warning/Error lose their bold too so NO_COLOR output is
+// plain text.
+func setProfile(p termenv.Profile) {
+	lipgloss.SetColorProfile(p)
+	if p == termenv.Ascii {
 		Success = lipgloss.NewStyle()
 		Warning = lipgloss.NewStyle()
 		Error = lipgloss.NewStyle()
 		Info = lipgloss.NewStyle()
 		Dim = lipgloss.NewStyle()
 		Bold = lipgloss.NewStyle().Bold(true)
-	case "always":
-		_ = os.Unsetenv("NO_COLOR")
-		_ = os.Setenv("CLICOLOR_FORCE", "1")
-		Success = lipgloss.NewStyle().Foreground(colorPass).Bold(true)
-		Warning = lipgloss.NewStyle().Foreground(colorWarn).Bold(true)
-		Error = lipgloss.NewStyle().Foreground(colorFail).Bold(true)
-		Info = lipgloss.NewStyle().Foreground(colorAccent)
-		Dim = lipgloss.NewStyle().Foreground(colorMuted)
-		Bold = lipgloss.NewStyle().Bold(true)
+		return
 	}
+	Success = lipgloss.NewStyle().Foreground(colorPass).Bold(true)
+	Warning = lipgloss.NewStyle().Foreground(colorWarn).Bold(true)
+	Error = lipgloss.NewStyle().Foreground(colorFail).Bold(true)
+	Info = lipgloss.NewStyle().Foreground(colorAccent)
+	Dim = lipgloss.NewStyle().Foreground(colorMuted)
+	Bold = lipgloss.NewStyle().Bold(true)
 }
@@ -3,10 +3,15 @@ package style
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func TestSetColorMode_Never(t *testing.T) {
-	SetColorMode("never")
+	if err := SetColorMode("never"); err != nil {
+		t.Fatal(err)
+	}
 	got := Success.Render("x")
 	if strings.Contains(got, "\x1b") {
 		t.Errorf("SetColorMode(never): Success.Render(\"x\") = %q, want no ANSI escapes", got)
@@ -17,7 +22,9 @@ func TestSetColorMode_Never(t *testing.T) {
 }
 
 func TestSetColorMode_Always(t *testing.T) {
-	SetColorMode("always")
+	if err := SetColorMode("always"); err != nil {
+		t.Fatal(err)
+	}
 	// Should not panic, styles should be re-initialized with colors.
 	got := Success.Render("ok")
 	if got == "" {
@@ -26,10 +33,61 @@ func TestSetColorMode_Always(t *testing.T) {
 }
 
 func TestSetColorMode_Auto(t *testing.T) {
-	// auto is a no-op; just ensure it doesn't panic.
-	SetColorMode("auto")
+	if err := SetColorMode("auto"); err != nil {
+		t.Fatal(err)
+	}
 	got := Bold.Render("hi")
 	if got == "" {
 		t.Error("SetColorMode(auto): Bold.Render returned empty string")
 	}
 }
+
+func TestSetColorMode_Invalid(t *testing.T) {
+	if err := SetColorMode("rainbow"); err == nil || !strings.Contains(err.Error(), "truecolor") {
+		t.Errorf("SetColorMode(rainbow) error = %v, want the accepted values", err)
+	}
+}
+
+func TestSetColorMode_256(t *testing.T) {
+	if err := SetColorMode("256"); err != nil {
+		t.Fatal(err)
+	}
+	if got := lipgloss.ColorProfile(); got != termenv.ANSI256 {
+		t.Errorf("profile = %v, want ANSI256", got)
+	}
+	// Hex colors degrade to the 256-color palette instead of 24-bit escapes.
+	if got := Error.Render("x"); strings.Contains(got, "38;2;") || !strings.Contains(got, "38;5;") {
+		t.Errorf("Error.Render(x) = %q, want a 256-color escape", got)
+	}
+}
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		isTTY bool
+		want  termenv.Profile
+	}{
+		{"truecolor terminal", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, true, termenv.TrueColor},
+		{"256-color terminal", map[string]string{"TERM": "xterm-256color"}, true, termenv.ANSI256},
+		{"basic terminal", map[string]string{"TERM": "xterm"}, true, termenv.ANSI},
+		{"dumb terminal", map[string]string{"TERM": "dumb"}, true, termenv.Ascii},
+		{"Apple Terminal", map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "Apple_Terminal"}, true, termenv.ANSI256},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, true, termenv.TrueColor},
+		{"not a terminal", map[string]string{"TERM": "xterm-256color"}, false, termenv.Ascii},
+		{"NO_COLOR", map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, true, termenv.Ascii},
+		{"NO_COLOR beats CLICOLOR_FORCE", map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, false, termenv.Ascii},
+		{"CLICOLOR=0", map[string]string{"TERM": "xterm-256color", "CLICOLOR": "0"}, true, termenv.Ascii},
+		{"CLICOLOR_FORCE piped", map[string]string{"CLICOLOR_FORCE": "1"}, false, termenv.ANSI},
+		{"CLICOLOR_FORCE keeps palette", map[string]string{"TERM": "xterm-256color", "CLICOLOR_FORCE": "1", "CLICOLOR": "0"}, false, termenv.ANSI256},
+		{"CLICOLOR_FORCE=0", map[string]string{"TERM": "xterm", "CLICOLOR_FORCE": "0"}, false, termenv.Ascii},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			if got := DetectProfile(getenv, tt.isTTY); got != tt.want {
+				t.Errorf("DetectProfile = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package syncd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gastownhall/wasteland/internal/notify"
+)
+
+// Event is one change a cycle found worth telling the user about.
+type Event struct {
+	Wasteland string    `json:"wasteland"`
+	Message   string    `json:"message"`
+	At        time.Time `json:"at"`
+}
+
+// Notifier delivers events.
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// Notifiers fans an event out to each notifier in turn.
+type Notifiers []Notifier
+
+// Notify implements Notifier, returning every delivery error.
+func (ns Notifiers) Notify(ctx context.Context, e Event) error {
+	var errs []error
+	for _, n := range ns {
+		if err := n.Notify(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Webhook POSTs each event as JSON to a URL.
+type Webhook struct {
+	URL    string
+	Client *http.Client // nil uses a client with a 10s timeout
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: %s returned %s", w.URL, resp.Status)
+	}
+	return nil
+}
+
+// Desktop shows each event as a desktop notification.
+type Desktop struct{}
+
+// Notify implements Notifier.
+func (Desktop) Notify(ctx context.Context, e Event) error {
+	return notify.Desktop(ctx, "wasteland: "+e.Wasteland, e.Message)
+}
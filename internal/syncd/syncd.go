@@ -0,0 +1,237 @@
+// Package syncd runs the background sync daemon behind 'wl syncd': a
+// supervisor process that periodically works through every joined
+// wasteland and reports what changed.
+package syncd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// DefaultInterval is how often the daemon runs a cycle unless told
+// otherwise.
+const DefaultInterval = 15 * time.Minute
+
+// MinInterval keeps the daemon from hammering upstream remotes.
+const MinInterval = time.Minute
+
+const startTimeout = 10 * time.Second
+
+// State is what a running daemon records about itself and its last cycle.
+type State struct {
+	PID       int               `json:"pid"`
+	Interval  time.Duration     `json:"interval"`
+	StartedAt time.Time         `json:"started_at"`
+	LastCycle time.Time         `json:"last_cycle,omitempty"`
+	Reports   map[string]Report `json:"reports,omitempty"` // by wasteland
+}
+
+// Report is what one cycle did for one wasteland.
+type Report struct {
+	At      time.Time `json:"at"`
+	Summary string    `json:"summary,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Events  []string  `json:"events,omitempty"` // changes worth a notification
+}
+
+// file returns the path of one of the daemon's bookkeeping files
+// ("state" or "log") in dir.
+func file(dir, name string) string {
+	return filepath.Join(dir, "syncd."+name)
+}
+
+// LogPath returns where the daemon started in dir writes its log.
+func LogPath(dir string) string { return file(dir, "log") }
+
+// ReadState returns the recorded state of the daemon in dir, or nil if
+// none is running. State left behind by a daemon that died is removed.
+func ReadState(dir string) (*State, error) {
+	data, err := os.ReadFile(file(dir, "state"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("parsing syncd state: %w", err)
+	}
+	if !alive(st.PID) {
+		_ = os.Remove(file(dir, "state"))
+		return nil, nil
+	}
+	return &st, nil
+}
+
+func writeState(dir string, st *State) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := file(dir, "state.tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file(dir, "state"))
+}
+
+// alive reports whether a process with pid exists.
+func alive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// Start launches cmd, the command that runs the daemon for dir (see Run),
+// in its own session with output going to LogPath, and waits until it has
+// recorded its state. It fails if a daemon is already running.
+func Start(dir string, cmd *exec.Cmd) (*State, error) {
+	if st, err := ReadState(dir); err != nil {
+		return nil, err
+	} else if st != nil {
+		return nil, fmt.Errorf("syncd is already running (pid %d)", st.PID)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	logFile, err := os.OpenFile(LogPath(dir), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening syncd log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting syncd: %w", err)
+	}
+	// The daemon outlives us; don't leave a zombie if it exits early.
+	exited := make(chan struct{})
+	go func() { _ = cmd.Wait(); close(exited) }()
+
+	deadline := time.Now().Add(startTimeout)
+	for time.Now().Before(deadline) {
+		if st, err := ReadState(dir); err == nil && st != nil && st.PID == cmd.Process.Pid {
+			return st, nil
+		}
+		select {
+		case <-exited:
+			return nil, fmt.Errorf("syncd exited during startup (see %s)", LogPath(dir))
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	return nil, fmt.Errorf("syncd did not start within %s (see %s)", startTimeout, LogPath(dir))
+}
+
+// Stop asks the daemon in dir to shut down and waits for it to exit. It
+// reports whether a daemon was running.
+func Stop(dir string) (bool, error) {
+	st, err := ReadState(dir)
+	if err != nil || st == nil {
+		return false, err
+	}
+	if proc, err := os.FindProcess(st.PID); err == nil {
+		_ = proc.Signal(syscall.SIGTERM)
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if !alive(st.PID) {
+			_ = os.Remove(file(dir, "state"))
+			return true, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return true, fmt.Errorf("syncd (pid %d) did not stop", st.PID)
+}
+
+// Options configures Run.
+type Options struct {
+	Dir      string        // where state and log files live
+	Interval time.Duration // time between cycles
+	// Wastelands lists the wastelands to work through each cycle.
+	Wastelands func() ([]string, error)
+	// Cycle does one wasteland's work: sync, prune, PR refresh.
+	Cycle func(ctx context.Context, name string) Report
+	// Notify, when set, is told about every event a cycle reports.
+	Notify Notifier
+}
+
+// Run is the body of the daemon process: it records its state in
+// opts.Dir, runs a cycle right away and then every opts.Interval, and
+// returns once ctx is done or the process receives SIGINT/SIGTERM.
+func Run(ctx context.Context, opts Options) error {
+	if opts.Interval < MinInterval {
+		return fmt.Errorf("interval %s is below the %s minimum", opts.Interval, MinInterval)
+	}
+	if err := os.MkdirAll(opts.Dir, 0o700); err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	st := &State{PID: os.Getpid(), Interval: opts.Interval, StartedAt: time.Now(), Reports: map[string]Report{}}
+	if err := writeState(opts.Dir, st); err != nil {
+		return fmt.Errorf("recording syncd state: %w", err)
+	}
+	defer func() { _ = os.Remove(file(opts.Dir, "state")) }()
+	slog.Info("syncd started", "pid", st.PID, "interval", opts.Interval)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		RunCycle(ctx, opts, st)
+		if err := writeState(opts.Dir, st); err != nil {
+			slog.Warn("recording syncd state failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			slog.Info("syncd stopping", "pid", st.PID)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunCycle works through every wasteland once, recording each report in
+// st and passing its events to opts.Notify. Wastelands run one after
+// another to keep the load on remotes low.
+func RunCycle(ctx context.Context, opts Options, st *State) {
+	names, err := opts.Wastelands()
+	if err != nil {
+		slog.Warn("listing wastelands failed", "error", err)
+		return
+	}
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return
+		}
+		r := opts.Cycle(ctx, name)
+		if r.At.IsZero() {
+			r.At = time.Now()
+		}
+		slog.Info("syncd cycle", "wasteland", name, "summary", r.Summary, "error", r.Error, "events", len(r.Events))
+		st.Reports[name] = r
+		if opts.Notify == nil {
+			continue
+		}
+		for _, e := range r.Events {
+			if err := opts.Notify.Notify(ctx, Event{Wasteland: name, Message: e, At: r.At}); err != nil {
+				slog.Warn("notification failed", "wasteland", name, "error", err)
+			}
+		}
+	}
+	st.LastCycle = time.Now()
+}
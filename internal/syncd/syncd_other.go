@@ -0,0 +1,8 @@
+//go:build !unix
+
+package syncd
+
+import "os/exec"
+
+// detach is a no-op where sessions don't exist.
+func detach(*exec.Cmd) {}
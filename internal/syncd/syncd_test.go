@@ -0,0 +1,149 @@
+package syncd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+type recordNotifier struct {
+	events []Event
+}
+
+func (r *recordNotifier) Notify(_ context.Context, e Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func TestRunCycle(t *testing.T) {
+	t.Parallel()
+	rec := &recordNotifier{}
+	opts := Options{
+		Wastelands: func() ([]string, error) { return []string{"hop/a", "hop/b"}, nil },
+		Cycle: func(_ context.Context, name string) Report {
+			if name == "hop/b" {
+				return Report{Error: "pulling upstream: offline"}
+			}
+			return Report{Summary: "pulled 2 commit(s)", Events: []string{"upstream done on your item w-1: Fix docs"}}
+		},
+		Notify: rec,
+	}
+	st := &State{Reports: map[string]Report{}}
+
+	RunCycle(context.Background(), opts, st)
+
+	if st.LastCycle.IsZero() {
+		t.Error("LastCycle not set")
+	}
+	if got := st.Reports["hop/a"].Summary; got != "pulled 2 commit(s)" {
+		t.Errorf("hop/a summary = %q", got)
+	}
+	if got := st.Reports["hop/b"].Error; got != "pulling upstream: offline" {
+		t.Errorf("hop/b error = %q", got)
+	}
+	if st.Reports["hop/b"].At.IsZero() {
+		t.Error("report time not filled in")
+	}
+	if len(rec.events) != 1 || rec.events[0].Wasteland != "hop/a" {
+		t.Fatalf("events = %+v, want one for hop/a", rec.events)
+	}
+}
+
+func TestRunCycle_ListError(t *testing.T) {
+	t.Parallel()
+	opts := Options{
+		Wastelands: func() ([]string, error) { return nil, errors.New("boom") },
+		Cycle: func(context.Context, string) Report {
+			t.Fatal("cycle should not run")
+			return Report{}
+		},
+	}
+	st := &State{Reports: map[string]Report{}}
+	RunCycle(context.Background(), opts, st)
+	if !st.LastCycle.IsZero() {
+		t.Error("LastCycle set after a failed listing")
+	}
+}
+
+func TestReadState(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	st, err := ReadState(dir)
+	if err != nil || st != nil {
+		t.Fatalf("ReadState(empty) = %+v, %v; want nil, nil", st, err)
+	}
+
+	want := &State{PID: os.Getpid(), Interval: 5 * time.Minute, StartedAt: time.Now().UTC().Truncate(time.Second)}
+	if err := writeState(dir, want); err != nil {
+		t.Fatal(err)
+	}
+	st, err = ReadState(dir)
+	if err != nil || st == nil {
+		t.Fatalf("ReadState = %+v, %v", st, err)
+	}
+	if st.PID != want.PID || st.Interval != want.Interval || !st.StartedAt.Equal(want.StartedAt) {
+		t.Errorf("ReadState = %+v, want %+v", st, want)
+	}
+}
+
+func TestReadState_DeadDaemon(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := writeState(dir, &State{PID: 1 << 22}); err != nil { // above pid_max
+		t.Fatal(err)
+	}
+	st, err := ReadState(dir)
+	if err != nil || st != nil {
+		t.Fatalf("ReadState = %+v, %v; want nil, nil", st, err)
+	}
+	if _, err := os.Stat(file(dir, "state")); !os.IsNotExist(err) {
+		t.Error("stale state file not removed")
+	}
+}
+
+func TestRun_RejectsShortInterval(t *testing.T) {
+	t.Parallel()
+	err := Run(context.Background(), Options{Dir: t.TempDir(), Interval: time.Second})
+	if err == nil {
+		t.Fatal("expected error for an interval below the minimum")
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	e := Event{Wasteland: "hop/wl-commons", Message: "PR for w-1 was merged", At: time.Now().UTC().Truncate(time.Second)}
+	if err := (&Webhook{URL: srv.URL}).Notify(context.Background(), e); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.Wasteland != e.Wasteland || got.Message != e.Message || !got.At.Equal(e.At) {
+		t.Errorf("posted %+v, want %+v", got, e)
+	}
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := (&Webhook{URL: srv.URL}).Notify(context.Background(), Event{}); err == nil {
+		t.Fatal("expected error for a 500 response")
+	}
+}
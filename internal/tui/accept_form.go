@@ -11,15 +11,22 @@ import (
 
 var severityOptions = []string{"leaf", "branch", "root"}
 
+// acceptFormFields is the number of fields the accept form cycles through.
+const acceptFormFields = 7
+
 type acceptFormModel struct {
 	quality     textinput.Model // "1"-"5"
 	reliability textinput.Model // "1"-"5", optional
 	severityIdx int             // index into severityOptions
 	skills      textinput.Model
 	message     textinput.Model
-	cursor      int // 0-4: quality, reliability, severity, skills, message
-	active      bool
-	err         string
+	// A follow-up title makes the accept partial: a linked item is posted
+	// for the remaining work.
+	followUpTitle textinput.Model
+	followUpDesc  textinput.Model
+	cursor        int // 0-6: quality, reliability, severity, skills, message, follow-up title, follow-up description
+	active        bool
+	err           string
 }
 
 func newAcceptForm() *acceptFormModel {
@@ -44,14 +51,26 @@ func newAcceptForm() *acceptFormModel {
 	message.CharLimit = 500
 	message.Width = 50
 
+	followUpTitle := textinput.New()
+	followUpTitle.Placeholder = "optional: title for remaining work (partial accept)"
+	followUpTitle.CharLimit = 200
+	followUpTitle.Width = 50
+
+	followUpDesc := textinput.New()
+	followUpDesc.Placeholder = "optional follow-up description"
+	followUpDesc.CharLimit = 500
+	followUpDesc.Width = 50
+
 	return &acceptFormModel{
-		quality:     quality,
-		reliability: reliability,
-		severityIdx: 0,
-		skills:      skills,
-		message:     message,
-		cursor:      0,
-		active:      true,
+		quality:       quality,
+		reliability:   reliability,
+		severityIdx:   0,
+		skills:        skills,
+		message:       message,
+		followUpTitle: followUpTitle,
+		followUpDesc:  followUpDesc,
+		cursor:        0,
+		active:        true,
 	}
 }
 
@@ -60,6 +79,8 @@ func (m *acceptFormModel) focusCurrent() {
 	m.reliability.Blur()
 	m.skills.Blur()
 	m.message.Blur()
+	m.followUpTitle.Blur()
+	m.followUpDesc.Blur()
 
 	switch m.cursor {
 	case 0:
@@ -72,6 +93,10 @@ func (m *acceptFormModel) focusCurrent() {
 		m.skills.Focus()
 	case 4:
 		m.message.Focus()
+	case 5:
+		m.followUpTitle.Focus()
+	case 6:
+		m.followUpDesc.Focus()
 	}
 }
 
@@ -85,24 +110,24 @@ func (m *acceptFormModel) update(msg bubbletea.Msg) (*acceptFormModel, bubbletea
 			return m, m.submit()
 
 		case msg.Type == bubbletea.KeyTab, msg.Type == bubbletea.KeyDown:
-			m.cursor = (m.cursor + 1) % 5
+			m.cursor = (m.cursor + 1) % acceptFormFields
 			m.focusCurrent()
 			m.err = ""
 			return m, nil
 
 		case msg.Type == bubbletea.KeyShiftTab, msg.Type == bubbletea.KeyUp:
-			m.cursor = (m.cursor + 4) % 5
+			m.cursor = (m.cursor + acceptFormFields - 1) % acceptFormFields
 			m.focusCurrent()
 			m.err = ""
 			return m, nil
 
 		case msg.String() == "j" && m.cursor == 2:
-			m.cursor = (m.cursor + 1) % 5
+			m.cursor = (m.cursor + 1) % acceptFormFields
 			m.focusCurrent()
 			return m, nil
 
 		case msg.String() == "k" && m.cursor == 2:
-			m.cursor = (m.cursor + 4) % 5
+			m.cursor = (m.cursor + acceptFormFields - 1) % acceptFormFields
 			m.focusCurrent()
 			return m, nil
 
@@ -127,6 +152,10 @@ func (m *acceptFormModel) update(msg bubbletea.Msg) (*acceptFormModel, bubbletea
 		m.skills, cmd = m.skills.Update(msg)
 	case 4:
 		m.message, cmd = m.message.Update(msg)
+	case 5:
+		m.followUpTitle, cmd = m.followUpTitle.Update(msg)
+	case 6:
+		m.followUpDesc, cmd = m.followUpDesc.Update(msg)
 	}
 	return m, cmd
 }
@@ -161,12 +190,21 @@ func (m *acceptFormModel) submit() bubbletea.Cmd {
 		}
 	}
 
+	followUpTitle := strings.TrimSpace(m.followUpTitle.Value())
+	followUpDesc := strings.TrimSpace(m.followUpDesc.Value())
+	if followUpDesc != "" && followUpTitle == "" {
+		m.err = "follow-up description needs a follow-up title"
+		return nil
+	}
+
 	msg := acceptSubmitMsg{
-		quality:     q,
-		reliability: r,
-		severity:    severity,
-		skills:      skills,
-		message:     strings.TrimSpace(m.message.Value()),
+		quality:       q,
+		reliability:   r,
+		severity:      severity,
+		skills:        skills,
+		message:       strings.TrimSpace(m.message.Value()),
+		followUpTitle: followUpTitle,
+		followUpDesc:  followUpDesc,
 	}
 	return func() bubbletea.Msg { return msg }
 }
@@ -185,6 +223,8 @@ func (m *acceptFormModel) view() string {
 		{"Severity:    ", m.severityView()},
 		{"Skills:      ", m.skills.View()},
 		{"Message:     ", m.message.View()},
+		{"Follow-up:   ", m.followUpTitle.View()},
+		{"  details:   ", m.followUpDesc.View()},
 	}
 
 	for i, f := range fields {
@@ -167,6 +167,16 @@ func TestAcceptForm_TabNavigation(t *testing.T) {
 		t.Errorf("after tab: cursor = %d, want 4", f.cursor)
 	}
 
+	f.update(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
+	if f.cursor != 5 {
+		t.Errorf("after tab: cursor = %d, want 5", f.cursor)
+	}
+
+	f.update(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
+	if f.cursor != 6 {
+		t.Errorf("after tab: cursor = %d, want 6", f.cursor)
+	}
+
 	// Wrap around.
 	f.update(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
 	if f.cursor != 0 {
@@ -177,15 +187,58 @@ func TestAcceptForm_TabNavigation(t *testing.T) {
 func TestAcceptForm_ShiftTabNavigation(t *testing.T) {
 	f := newAcceptForm()
 
-	// Shift-tab from 0 should wrap to 4.
+	// Shift-tab from 0 should wrap to the last field (follow-up description).
 	f.update(bubbletea.KeyMsg{Type: bubbletea.KeyShiftTab})
-	if f.cursor != 4 {
-		t.Errorf("after shift-tab from 0: cursor = %d, want 4", f.cursor)
+	if f.cursor != 6 {
+		t.Errorf("after shift-tab from 0: cursor = %d, want 6", f.cursor)
 	}
 
 	f.update(bubbletea.KeyMsg{Type: bubbletea.KeyShiftTab})
-	if f.cursor != 3 {
-		t.Errorf("after shift-tab: cursor = %d, want 3", f.cursor)
+	if f.cursor != 5 {
+		t.Errorf("after shift-tab: cursor = %d, want 5", f.cursor)
+	}
+}
+
+func TestAcceptForm_FollowUpSubmit(t *testing.T) {
+	f := newAcceptForm()
+	f.update(keyMsg("4"))
+
+	// Tab to the follow-up title (cursor=5).
+	for i := 0; i < 5; i++ {
+		f.update(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
+	}
+	for _, ch := range "Add retry tests" {
+		f.update(keyMsg(string(ch)))
+	}
+	f.update(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
+	for _, ch := range "backoff path" {
+		f.update(keyMsg(string(ch)))
+	}
+
+	_, cmd := f.update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("should return acceptSubmitMsg cmd")
+	}
+	submit := cmd().(acceptSubmitMsg)
+	if submit.followUpTitle != "Add retry tests" || submit.followUpDesc != "backoff path" {
+		t.Errorf("follow-up = %q / %q", submit.followUpTitle, submit.followUpDesc)
+	}
+}
+
+func TestAcceptForm_FollowUpDescriptionNeedsTitle(t *testing.T) {
+	f := newAcceptForm()
+	f.update(keyMsg("4"))
+	for i := 0; i < 6; i++ {
+		f.update(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
+	}
+	f.update(keyMsg("x"))
+
+	_, cmd := f.update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if cmd != nil {
+		t.Fatal("should not submit a follow-up description without a title")
+	}
+	if f.err == "" {
+		t.Error("expected an error message")
 	}
 }
 
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+// AcceptPlan is what the accept prompt collected: the stamp's ratings and
+// tags, and any follow-up item for a partial accept.
+type AcceptPlan struct {
+	Quality       int
+	Reliability   int
+	Severity      string
+	Skills        []string
+	Message       string
+	FollowUpTitle string
+	FollowUpDesc  string
+}
+
+// AcceptPromptConfig describes the completion being accepted and seeds
+// the form. Zero values leave a field empty (severity: leaf).
+type AcceptPromptConfig struct {
+	WantedID    string
+	Title       string
+	CompletedBy string // the stamp's subject
+	Author      string // the accepting rig
+	Evidence    string
+	// Quorum is the number of distinct accepts the completion needs, and
+	// Approvals how many are recorded; a quorum of 0 or 1 is not shown.
+	Quorum    int
+	Approvals int
+
+	Initial AcceptPlan
+}
+
+// AcceptPrompt is a bubbletea model for 'wl accept -i': the TUI's accept
+// form on its own, followed by a preview of the stamp to confirm. It quits
+// once the user confirms or cancels; Plan reports which.
+type AcceptPrompt struct {
+	cfg        AcceptPromptConfig
+	form       *acceptFormModel
+	plan       AcceptPlan
+	previewing bool
+	done       bool
+	cancelled  bool
+}
+
+// NewAcceptPrompt creates an accept prompt with the form filled from
+// cfg.Initial.
+func NewAcceptPrompt(cfg AcceptPromptConfig) AcceptPrompt {
+	form := newAcceptForm()
+	in := cfg.Initial
+	if in.Quality > 0 {
+		form.quality.SetValue(strconv.Itoa(in.Quality))
+	}
+	if in.Reliability > 0 && in.Reliability != in.Quality {
+		form.reliability.SetValue(strconv.Itoa(in.Reliability))
+	}
+	if i := slices.Index(severityOptions, in.Severity); i >= 0 {
+		form.severityIdx = i
+	}
+	form.skills.SetValue(strings.Join(in.Skills, ", "))
+	form.message.SetValue(in.Message)
+	form.followUpTitle.SetValue(in.FollowUpTitle)
+	form.followUpDesc.SetValue(in.FollowUpDesc)
+	return AcceptPrompt{cfg: cfg, form: form}
+}
+
+// Plan returns the confirmed plan, and false if the prompt was cancelled
+// or hasn't finished.
+func (m AcceptPrompt) Plan() (AcceptPlan, bool) {
+	return m.plan, m.done && !m.cancelled
+}
+
+// Init implements bubbletea.Model.
+func (m AcceptPrompt) Init() bubbletea.Cmd { return textinput.Blink }
+
+// Update implements bubbletea.Model.
+func (m AcceptPrompt) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
+	switch msg := msg.(type) {
+	case acceptSubmitMsg:
+		m.plan = AcceptPlan{
+			Quality:       msg.quality,
+			Reliability:   msg.reliability,
+			Severity:      msg.severity,
+			Skills:        msg.skills,
+			Message:       msg.message,
+			FollowUpTitle: msg.followUpTitle,
+			FollowUpDesc:  msg.followUpDesc,
+		}
+		m.previewing = true
+		return m, nil
+	case bubbletea.KeyMsg:
+		if msg.Type == bubbletea.KeyCtrlC {
+			m.done, m.cancelled = true, true
+			return m, bubbletea.Quit
+		}
+		if m.previewing {
+			switch {
+			case key.Matches(msg, keys.Confirm), msg.Type == bubbletea.KeyEnter:
+				m.done = true
+				return m, bubbletea.Quit
+			case key.Matches(msg, keys.Cancel), key.Matches(msg, keys.Back):
+				m.previewing = false // back to the form to edit
+			}
+			return m, nil
+		}
+	}
+	if m.previewing {
+		return m, nil
+	}
+	form, cmd := m.form.update(msg)
+	if form == nil {
+		m.done, m.cancelled = true, true
+		return m, bubbletea.Quit
+	}
+	m.form = form
+	return m, cmd
+}
+
+// View implements bubbletea.Model.
+func (m AcceptPrompt) View() string {
+	if m.done {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n  %s %s\n", styleTitle.Render(m.cfg.WantedID), m.cfg.Title)
+	fmt.Fprintf(&b, "  %s\n", styleDim.Render("completed by "+m.cfg.CompletedBy))
+	if m.cfg.Evidence != "" {
+		fmt.Fprintf(&b, "  %s\n", styleDim.Render("evidence: "+m.cfg.Evidence))
+	}
+	b.WriteString("\n")
+	if !m.previewing {
+		b.WriteString(m.form.view())
+		return b.String()
+	}
+	m.renderPreview(&b)
+	return b.String()
+}
+
+// renderPreview shows the stamp the accept will issue.
+func (m AcceptPrompt) renderPreview(b *strings.Builder) {
+	p := m.plan
+	b.WriteString(styleConfirm.Render("  Stamp to be issued") + "\n")
+	fmt.Fprintf(b, "    From:        %s\n", m.cfg.Author)
+	fmt.Fprintf(b, "    To:          %s\n", m.cfg.CompletedBy)
+	fmt.Fprintf(b, "    Quality:     %d/5\n", p.Quality)
+	fmt.Fprintf(b, "    Reliability: %d/5\n", p.Reliability)
+	fmt.Fprintf(b, "    Severity:    %s\n", p.Severity)
+	if len(p.Skills) > 0 {
+		fmt.Fprintf(b, "    Skills:      %s\n", strings.Join(p.Skills, ", "))
+	}
+	if p.Message != "" {
+		fmt.Fprintf(b, "    Message:     %s\n", p.Message)
+	}
+	if p.FollowUpTitle != "" {
+		fmt.Fprintf(b, "    Follow-up:   %s %s\n", p.FollowUpTitle, styleDim.Render("(new open item for the remaining work)"))
+	}
+	if m.cfg.Quorum > 1 {
+		b.WriteString(styleDim.Render(fmt.Sprintf("    This accept is one of %d needed; %d recorded so far.", m.cfg.Quorum, m.cfg.Approvals)) + "\n")
+	}
+	b.WriteString("\n" + styleConfirm.Render("  Issue this stamp? [y/n]") + "\n")
+	b.WriteString(styleDim.Render("  y/enter: accept   n/esc: edit   ctrl+c: cancel") + "\n")
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+func testAcceptPromptConfig() AcceptPromptConfig {
+	return AcceptPromptConfig{
+		WantedID:    "w-abc123",
+		Title:       "Fix the flaky sync test",
+		CompletedBy: "bob",
+		Author:      "alice",
+	}
+}
+
+// sendPrompt feeds msg to the prompt. For enter on the form, the
+// submission its command produces is fed back too; other commands (cursor
+// blinks, quit) are dropped.
+func sendPrompt(t *testing.T, m AcceptPrompt, msg bubbletea.Msg) AcceptPrompt {
+	t.Helper()
+	k, isKey := msg.(bubbletea.KeyMsg)
+	submitting := !m.previewing && isKey && k.Type == bubbletea.KeyEnter
+	next, cmd := m.Update(msg)
+	m = next.(AcceptPrompt)
+	if submitting && cmd != nil {
+		if sub, ok := cmd().(acceptSubmitMsg); ok {
+			next, _ = m.Update(sub)
+			m = next.(AcceptPrompt)
+		}
+	}
+	return m
+}
+
+func TestAcceptPrompt_SeedsForm(t *testing.T) {
+	cfg := testAcceptPromptConfig()
+	cfg.Initial = AcceptPlan{Quality: 4, Reliability: 3, Severity: "root", Skills: []string{"go", "dolt"}, FollowUpTitle: "Add retry tests"}
+	m := NewAcceptPrompt(cfg)
+
+	if m.form.quality.Value() != "4" || m.form.reliability.Value() != "3" {
+		t.Errorf("ratings = %q/%q, want 4/3", m.form.quality.Value(), m.form.reliability.Value())
+	}
+	if severityOptions[m.form.severityIdx] != "root" {
+		t.Errorf("severity = %q, want root", severityOptions[m.form.severityIdx])
+	}
+	if m.form.skills.Value() != "go, dolt" || m.form.followUpTitle.Value() != "Add retry tests" {
+		t.Errorf("skills = %q, follow-up = %q", m.form.skills.Value(), m.form.followUpTitle.Value())
+	}
+}
+
+func TestAcceptPrompt_ValidatesBeforePreview(t *testing.T) {
+	m := NewAcceptPrompt(testAcceptPromptConfig())
+	m = sendPrompt(t, m, bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if m.previewing {
+		t.Fatal("an empty quality should not reach the preview")
+	}
+	if !strings.Contains(m.View(), "quality must be 1-5") {
+		t.Errorf("view should show the validation error, got:\n%s", m.View())
+	}
+}
+
+func TestAcceptPrompt_PreviewThenConfirm(t *testing.T) {
+	m := NewAcceptPrompt(testAcceptPromptConfig())
+	m = sendPrompt(t, m, keyMsg("5"))
+	m = sendPrompt(t, m, bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if !m.previewing {
+		t.Fatal("a valid form should show the preview")
+	}
+	v := m.View()
+	for _, want := range []string{"Stamp to be issued", "From:        alice", "To:          bob", "Quality:     5/5", "Reliability: 5/5", "Severity:    leaf"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("preview missing %q:\n%s", want, v)
+		}
+	}
+
+	// n goes back to the form with the values kept.
+	m = sendPrompt(t, m, keyMsg("n"))
+	if m.previewing || m.form.quality.Value() != "5" {
+		t.Fatal("n should return to the form with its values")
+	}
+	m = sendPrompt(t, m, bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+
+	next, cmd := m.Update(keyMsg("y"))
+	m = next.(AcceptPrompt)
+	if cmd == nil {
+		t.Error("confirming should quit the program")
+	}
+	plan, ok := m.Plan()
+	if !ok {
+		t.Fatal("Plan() should report a confirmed plan")
+	}
+	if plan.Quality != 5 || plan.Reliability != 5 || plan.Severity != "leaf" {
+		t.Errorf("plan = %+v", plan)
+	}
+}
+
+func TestAcceptPrompt_EscCancels(t *testing.T) {
+	m := NewAcceptPrompt(testAcceptPromptConfig())
+	m = sendPrompt(t, m, bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if _, ok := m.Plan(); ok || !m.done {
+		t.Error("esc in the form should cancel the prompt")
+	}
+}
+
+func TestAcceptPrompt_QuorumNote(t *testing.T) {
+	cfg := testAcceptPromptConfig()
+	cfg.Quorum, cfg.Approvals = 2, 1
+	m := NewAcceptPrompt(cfg)
+	m = sendPrompt(t, m, keyMsg("3"))
+	m = sendPrompt(t, m, bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if !strings.Contains(m.View(), "one of 2 needed; 1 recorded") {
+		t.Errorf("preview should note the quorum, got:\n%s", m.View())
+	}
+}
@@ -0,0 +1,248 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// branchesModel holds the state for the branch manager view, which lists
+// the rig's mutation branches with their suggested actions.
+type branchesModel struct {
+	branches   []sdk.BranchInfo
+	cursor     int
+	width      int
+	height     int
+	loading    bool
+	err        error
+	confirming string // branch awaiting discard confirmation
+	bulk       string // bulk action awaiting confirmation: "apply" or "discard"
+	executing  bool
+	result     string
+}
+
+func newBranchesModel() branchesModel {
+	return branchesModel{loading: true}
+}
+
+func (m *branchesModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m *branchesModel) setData(msg branchesDataMsg) {
+	m.loading = false
+	m.err = msg.err
+	m.branches = msg.branches
+	if m.cursor >= len(m.branches) {
+		m.cursor = max(0, len(m.branches)-1)
+	}
+}
+
+// selected returns the branch at the cursor, or nil if the list is empty.
+func (m branchesModel) selected() *sdk.BranchInfo {
+	if m.cursor < 0 || m.cursor >= len(m.branches) {
+		return nil
+	}
+	return &m.branches[m.cursor]
+}
+
+func (m branchesModel) update(msg bubbletea.Msg) (branchesModel, bubbletea.Cmd) {
+	km, ok := msg.(bubbletea.KeyMsg)
+	if !ok || m.executing {
+		return m, nil
+	}
+
+	if m.bulk != "" {
+		switch {
+		case key.Matches(km, keys.Confirm):
+			action := m.bulk
+			m.bulk = ""
+			m.executing = true
+			m.result = ""
+			return m, func() bubbletea.Msg {
+				return branchBulkMsg{action: action}
+			}
+		case key.Matches(km, keys.Cancel), key.Matches(km, keys.Back):
+			m.bulk = ""
+		}
+		return m, nil
+	}
+
+	if m.confirming != "" {
+		switch {
+		case key.Matches(km, keys.Confirm):
+			branch := m.confirming
+			m.confirming = ""
+			m.executing = true
+			m.result = ""
+			return m, func() bubbletea.Msg {
+				return branchDiscardMsg{branch: branch}
+			}
+		case key.Matches(km, keys.Cancel), key.Matches(km, keys.Back):
+			m.confirming = ""
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(km, keys.Quit):
+		return m, bubbletea.Quit
+
+	case key.Matches(km, keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(km, keys.Down):
+		if m.cursor < len(m.branches)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(km, keys.Enter):
+		if b := m.selected(); b != nil && b.WantedID != "" {
+			return m, func() bubbletea.Msg {
+				return navigateMsg{view: viewDetail, wantedID: b.WantedID}
+			}
+		}
+
+	case key.Matches(km, keys.Discard):
+		if b := m.selected(); b != nil {
+			m.confirming = b.Branch
+			m.result = ""
+		}
+
+	case key.Matches(km, keys.ApplyAll):
+		m.result = ""
+		if m.countAction("apply") == 0 {
+			m.result = styleDim.Render("No branches to apply.")
+		} else {
+			m.bulk = "apply"
+		}
+
+	case key.Matches(km, keys.DiscardAll):
+		m.result = ""
+		if len(m.branches) > 0 {
+			m.bulk = "discard"
+		}
+
+	case key.Matches(km, keys.Back):
+		return m, func() bubbletea.Msg {
+			return navigateMsg{view: viewBrowse}
+		}
+	}
+	return m, nil
+}
+
+func (m branchesModel) view() string {
+	var b strings.Builder
+
+	b.WriteString(styleTitle.Render("My Branches"))
+	b.WriteByte('\n')
+	b.WriteByte('\n')
+
+	if m.loading {
+		b.WriteString(styleDim.Render("  Loading..."))
+		return b.String()
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "  Error: %v", m.err)
+		return b.String()
+	}
+	if len(m.branches) == 0 {
+		b.WriteString(styleDim.Render("  No mutation branches."))
+		b.WriteByte('\n')
+		return b.String()
+	}
+
+	b.WriteString(styleDim.Render(fmt.Sprintf("  %-28s %-30s %-10s %5s  %s", "BRANCH", "TITLE", "DELTA", "AGE", "ACTION")))
+	b.WriteByte('\n')
+	now := time.Now()
+	for i, br := range m.branches {
+		b.WriteString(m.renderRow(br, i, now))
+	}
+
+	if sel := m.selected(); sel != nil && sel.PRURL != "" {
+		b.WriteByte('\n')
+		b.WriteString(styleDim.Render("  PR: " + sel.PRURL))
+		b.WriteByte('\n')
+	}
+
+	switch {
+	case m.bulk == "apply":
+		b.WriteByte('\n')
+		b.WriteString(styleConfirm.Render(fmt.Sprintf("  Apply %d branch(es) into main? [y/n]", m.countAction("apply"))))
+		b.WriteByte('\n')
+	case m.bulk == "discard":
+		b.WriteByte('\n')
+		b.WriteString(styleConfirm.Render(fmt.Sprintf("  Discard all %d branch(es)? [y/n]", len(m.branches))))
+		b.WriteByte('\n')
+	case m.confirming != "":
+		b.WriteByte('\n')
+		b.WriteString(styleConfirm.Render(fmt.Sprintf("  Discard %s? [y/n]", m.confirming)))
+		b.WriteByte('\n')
+	case m.executing:
+		b.WriteByte('\n')
+		b.WriteString(styleDim.Render("  Working..."))
+		b.WriteByte('\n')
+	case m.result != "":
+		b.WriteByte('\n')
+		b.WriteString("  " + m.result)
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// countAction returns how many branches suggest action.
+func (m branchesModel) countAction(action string) int {
+	n := 0
+	for _, b := range m.branches {
+		if slices.Contains(b.Actions, action) {
+			n++
+		}
+	}
+	return n
+}
+
+func (m branchesModel) renderRow(br sdk.BranchInfo, idx int, now time.Time) string {
+	title := br.Title
+	if r := []rune(title); len(r) > 30 {
+		title = string(r[:27]) + "..."
+	}
+	age := "-"
+	if !br.LastCommitAt.IsZero() {
+		age = formatAge(now.Sub(br.LastCommitAt))
+	}
+	action := "-"
+	if len(br.Actions) > 0 {
+		labels := make([]string, len(br.Actions))
+		for i, a := range br.Actions {
+			labels[i] = strings.TrimSuffix(a, "_pr")
+		}
+		action = strings.Join(labels, ", ")
+	}
+	line := fmt.Sprintf("  %-28s %-30s %-10s %5s  %s", br.Branch, title, br.Delta, age, action)
+
+	if idx == m.cursor {
+		line = renderSelected(line, m.width)
+	}
+	return line + "\n"
+}
+
+// formatAge renders a duration as minutes, hours or days.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
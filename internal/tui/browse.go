@@ -4,22 +4,40 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/i18n"
 )
 
+// browseLimit caps how many items one browse fetch loads. Only the rows in
+// view are rendered, so large boards stay responsive.
+const browseLimit = 1000
+
+// browseDebounce delays filter-triggered refetches so cycling through a
+// filter with repeated key presses issues one query, not one per press.
+const browseDebounce = 150 * time.Millisecond
+
 type browseModel struct {
 	items         []commons.WantedSummary
 	pendingIDs    map[string]int // wanted IDs with pending changes; value is PR count
+	rows          []string       // rendered rows by item index; "" until first drawn
+	rowsWide      bool           // layout the cached rows were rendered for
 	cursor        int
-	statusIdx     int // index into statusCycle
-	typeIdx       int // index into typeCycle
-	priorityIdx   int // index into priorityCycle
-	sortIdx       int // index into sortCycle
+	offset        int      // index of the first visible row
+	fetchSeq      int      // bumped per filter change; stale debounced refetches are dropped
+	statusIdx     int      // index into statusCycle
+	typeIdx       int      // index into types
+	types         []string // type filter cycle; "" (all) first
+	priorityIdx   int      // index into priorityCycle
+	sortIdx       int      // index into sortCycle
+	groupIdx      int      // index into commons.ValidGroupBys()
+	collapsed     map[string]bool
+	lines         []browseLine // list rows when grouped; nil when ungrouped
 	myItems       bool
 	searchMode    bool
 	search        textinput.Model
@@ -32,6 +50,13 @@ type browseModel struct {
 	err           error
 }
 
+// browseLine is one row of a grouped list: a section header (item < 0)
+// or an item of an expanded section.
+type browseLine struct {
+	item  int // index into items; -1 for a header
+	group commons.SummaryGroup
+}
+
 func newBrowseModel() browseModel {
 	ti := textinput.New()
 	ti.Placeholder = "search title..."
@@ -43,18 +68,33 @@ func newBrowseModel() browseModel {
 
 	return browseModel{
 		statusIdx: 0, // default to "open"
+		types:     commons.ValidTypes(),
 		search:    ti,
 		project:   pi,
 		loading:   true,
 	}
 }
 
+// setTypes replaces the type filter cycle with the wasteland's own types,
+// keeping the selected type if it is still offered.
+func (m *browseModel) setTypes(types []string) {
+	cur := m.types[m.typeIdx]
+	m.types = types
+	m.typeIdx = 0
+	for i, t := range types {
+		if t == cur {
+			m.typeIdx = i
+			break
+		}
+	}
+}
+
 func (m browseModel) filter(rigHandle string) commons.BrowseFilter {
 	f := commons.BrowseFilter{
 		Status:   commons.ValidStatuses()[m.statusIdx],
-		Type:     commons.ValidTypes()[m.typeIdx],
+		Type:     m.types[m.typeIdx],
 		Priority: commons.ValidPriorities()[m.priorityIdx],
-		Limit:    100,
+		Limit:    browseLimit,
 		Search:   m.search.Value(),
 		Sort:     commons.ValidSortOrders()[m.sortIdx],
 	}
@@ -70,6 +110,11 @@ func (m browseModel) filter(rigHandle string) commons.BrowseFilter {
 func (m *browseModel) setSize(w, h int) {
 	m.width = w
 	m.height = h
+	if wide := w > 100; wide != m.rowsWide {
+		m.rows = make([]string, len(m.items))
+		m.rowsWide = wide
+	}
+	m.scrollToCursor()
 }
 
 func (m *browseModel) setData(msg browseDataMsg) {
@@ -77,9 +122,122 @@ func (m *browseModel) setData(msg browseDataMsg) {
 	m.err = msg.err
 	m.items = msg.items
 	m.pendingIDs = msg.pendingIDs
-	if m.cursor >= len(m.items) {
-		m.cursor = max(0, len(m.items)-1)
+	m.rows = make([]string, len(m.items))
+	m.rowsWide = m.width > 100
+	m.buildLines()
+	if m.cursor >= m.rowCount() {
+		m.cursor = max(0, m.rowCount()-1)
+	}
+	m.scrollToCursor()
+}
+
+func (m browseModel) groupBy() commons.GroupBy {
+	return commons.ValidGroupBys()[m.groupIdx]
+}
+
+// buildLines lays out the grouped list: each section's header followed by
+// its items unless the section is collapsed.
+func (m *browseModel) buildLines() {
+	m.lines = nil
+	by := m.groupBy()
+	if by == commons.GroupNone {
+		return
+	}
+	pos := make(map[string]int, len(m.items))
+	for i, item := range m.items {
+		pos[item.ID] = i
+	}
+	for _, g := range commons.GroupSummaries(m.items, by) {
+		m.lines = append(m.lines, browseLine{item: -1, group: g})
+		if m.collapsed[g.Key] {
+			continue
+		}
+		for _, item := range g.Items {
+			m.lines = append(m.lines, browseLine{item: pos[item.ID], group: g})
+		}
+	}
+}
+
+// rowCount returns how many rows the list has: one per item, or headers
+// plus expanded items when grouped.
+func (m browseModel) rowCount() int {
+	if m.groupBy() == commons.GroupNone {
+		return len(m.items)
+	}
+	return len(m.lines)
+}
+
+// itemAt returns the item index shown at list row i, or -1 for a header.
+func (m browseModel) itemAt(i int) int {
+	if m.groupBy() == commons.GroupNone {
+		return i
 	}
+	return m.lines[i].item
+}
+
+// toggleGroup collapses or expands the section under the cursor, keeping
+// the cursor on its header.
+func (m *browseModel) toggleGroup() {
+	key := m.lines[m.cursor].group.Key
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	m.collapsed[key] = !m.collapsed[key]
+	m.buildLines()
+	for i, l := range m.lines {
+		if l.item < 0 && l.group.Key == key {
+			m.cursor = i
+			break
+		}
+	}
+	m.scrollToCursor()
+}
+
+// refetch resets the list and schedules a debounced reload for the
+// current filter. Only the newest scheduled reload runs.
+func (m browseModel) refetch() (browseModel, bubbletea.Cmd) {
+	m.cursor = 0
+	m.offset = 0
+	m.loading = true
+	m.fetchSeq++
+	seq := m.fetchSeq
+	return m, bubbletea.Tick(browseDebounce, func(time.Time) bubbletea.Msg {
+		return browseRefetchMsg{seq: seq}
+	})
+}
+
+// listHeight returns how many item rows fit below the header.
+func (m browseModel) listHeight() int {
+	headerLines := 7 // title + filter1 + filter2 + colheader + sep + count + slack
+	if m.searchMode {
+		headerLines++
+	}
+	if m.projectMode {
+		headerLines++
+	}
+	if h := m.height - headerLines; h >= 1 {
+		return h
+	}
+	return 10
+}
+
+// scrollToCursor moves the visible window the least distance needed to
+// keep the cursor on screen.
+func (m *browseModel) scrollToCursor() {
+	h := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+	m.offset = max(0, min(m.offset, m.rowCount()-h))
+}
+
+// moveCursor moves the cursor by delta rows, clamped to the list.
+func (m *browseModel) moveCursor(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, m.rowCount()-1))
+	m.scrollToCursor()
 }
 
 func (m browseModel) update(msg bubbletea.Msg, cfg Config) (browseModel, bubbletea.Cmd) {
@@ -96,18 +254,31 @@ func (m browseModel) update(msg bubbletea.Msg, cfg Config) (browseModel, bubblet
 			return m, bubbletea.Quit
 
 		case key.Matches(msg, keys.Up):
-			if m.cursor > 0 {
-				m.cursor--
-			}
+			m.moveCursor(-1)
 
 		case key.Matches(msg, keys.Down):
-			if m.cursor < len(m.items)-1 {
-				m.cursor++
-			}
+			m.moveCursor(1)
+
+		case key.Matches(msg, keys.PageUp):
+			m.moveCursor(-m.listHeight())
+
+		case key.Matches(msg, keys.PageDown):
+			m.moveCursor(m.listHeight())
+
+		case key.Matches(msg, keys.Home):
+			m.moveCursor(-m.rowCount())
+
+		case key.Matches(msg, keys.End):
+			m.moveCursor(m.rowCount())
 
 		case key.Matches(msg, keys.Enter):
-			if m.cursor < len(m.items) {
-				item := m.items[m.cursor]
+			if m.cursor >= m.rowCount() {
+				break
+			}
+			if idx := m.itemAt(m.cursor); idx < 0 {
+				m.toggleGroup()
+			} else {
+				item := m.items[idx]
 				return m, func() bubbletea.Msg {
 					return navigateMsg{view: viewDetail, wantedID: item.ID}
 				}
@@ -120,21 +291,15 @@ func (m browseModel) update(msg bubbletea.Msg, cfg Config) (browseModel, bubblet
 
 		case key.Matches(msg, keys.Status):
 			m.statusIdx = (m.statusIdx + 1) % len(commons.ValidStatuses())
-			m.cursor = 0
-			m.loading = true
-			return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
+			return m.refetch()
 
 		case key.Matches(msg, keys.Type):
-			m.typeIdx = (m.typeIdx + 1) % len(commons.ValidTypes())
-			m.cursor = 0
-			m.loading = true
-			return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
+			m.typeIdx = (m.typeIdx + 1) % len(m.types)
+			return m.refetch()
 
 		case key.Matches(msg, keys.Priority):
 			m.priorityIdx = (m.priorityIdx + 1) % len(commons.ValidPriorities())
-			m.cursor = 0
-			m.loading = true
-			return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
+			return m.refetch()
 
 		case key.Matches(msg, keys.Project):
 			m.projectMode = true
@@ -149,15 +314,19 @@ func (m browseModel) update(msg bubbletea.Msg, cfg Config) (browseModel, bubblet
 				// not just the ones matching the current status filter.
 				m.statusIdx = len(commons.ValidStatuses()) - 1
 			}
-			m.cursor = 0
-			m.loading = true
-			return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
+			return m.refetch()
 
 		case key.Matches(msg, keys.Sort):
 			m.sortIdx = (m.sortIdx + 1) % len(commons.ValidSortOrders())
+			return m.refetch()
+
+		case key.Matches(msg, keys.Group):
+			// Grouping rearranges the loaded items; no refetch needed.
+			m.groupIdx = (m.groupIdx + 1) % len(commons.ValidGroupBys())
+			m.collapsed = nil
+			m.buildLines()
 			m.cursor = 0
-			m.loading = true
-			return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
+			m.offset = 0
 
 		case key.Matches(msg, keys.Me):
 			return m, func() bubbletea.Msg {
@@ -168,6 +337,16 @@ func (m browseModel) update(msg bubbletea.Msg, cfg Config) (browseModel, bubblet
 			return m, func() bubbletea.Msg {
 				return navigateMsg{view: viewSettings}
 			}
+
+		case key.Matches(msg, keys.Branches):
+			return m, func() bubbletea.Msg {
+				return navigateMsg{view: viewBranches}
+			}
+
+		case key.Matches(msg, keys.Delta):
+			return m, func() bubbletea.Msg {
+				return navigateMsg{view: viewDelta}
+			}
 		}
 	}
 
@@ -182,7 +361,9 @@ func (m browseModel) updateSearch(msg bubbletea.Msg, cfg Config) (browseModel, b
 			m.search.Blur()
 			if msg.String() == "enter" {
 				m.cursor = 0
+				m.offset = 0
 				m.loading = true
+				m.fetchSeq++ // supersede any pending debounced refetch
 				return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
 			}
 			return m, nil
@@ -203,7 +384,9 @@ func (m browseModel) updateProject(msg bubbletea.Msg, cfg Config) (browseModel,
 			if msg.String() == "enter" {
 				m.projectFilter = m.project.Value()
 				m.cursor = 0
+				m.offset = 0
 				m.loading = true
+				m.fetchSeq++ // supersede any pending debounced refetch
 				return m, fetchBrowse(cfg, m.filter(cfg.RigHandle))
 			}
 			return m, nil
@@ -219,34 +402,45 @@ func (m browseModel) view() string {
 	var b strings.Builder
 
 	// Title line.
-	b.WriteString(styleTitle.Render("Wasteland Board"))
+	b.WriteString(styleTitle.Render(i18n.T("tui.title")))
 	b.WriteByte('\n')
 
 	// Two-line filter bar.
-	statusLabel := commons.StatusLabel(commons.ValidStatuses()[m.statusIdx])
-	typeLabel := commons.TypeLabel(commons.ValidTypes()[m.typeIdx])
+	statusLabel := i18n.Status(commons.ValidStatuses()[m.statusIdx])
+	typeLabel := commons.TypeLabel(m.types[m.typeIdx])
+	if typeLabel == "all" {
+		typeLabel = i18n.T("filter.all")
+	}
 
-	mineLabel := "OFF"
-	mineStr := fmt.Sprintf("[i] Mine: %s", mineLabel)
+	mineStr := fmt.Sprintf("[i] %s: %s", i18n.T("tui.filter.mine"), i18n.T("tui.off"))
 	if m.myItems {
-		mineLabel = "ON"
-		mineStr = "[i] Mine: " + styleMineOn.Render(mineLabel)
+		mineStr = fmt.Sprintf("[i] %s: %s", i18n.T("tui.filter.mine"), styleMineOn.Render(i18n.T("tui.on")))
 	}
-	sortLabel := commons.SortLabel(commons.ValidSortOrders()[m.sortIdx])
+	sortLabel := i18n.T("sort." + commons.SortLabel(commons.ValidSortOrders()[m.sortIdx]))
 
-	filterLine1 := fmt.Sprintf("  [s] Status: %-12s  [t] Type: %-10s  %s  [o] Sort: %s",
-		statusLabel, typeLabel, mineStr, sortLabel)
+	filterLine1 := fmt.Sprintf("  [s] %s: %-12s  [t] %s: %-10s  %s  [o] %s: %s",
+		i18n.T("tui.filter.status"), statusLabel, i18n.T("tui.filter.type"), typeLabel,
+		mineStr, i18n.T("tui.filter.sort"), sortLabel)
 	b.WriteString(styleFilterBar.Render(filterLine1))
 	b.WriteByte('\n')
 
 	priLabel := commons.PriorityLabel(commons.ValidPriorities()[m.priorityIdx])
+	if priLabel == "all" {
+		priLabel = i18n.T("filter.all")
+	}
 	projLabel := "--"
 	if m.projectFilter != "" {
 		projLabel = m.projectFilter
 	}
-	filterLine2 := fmt.Sprintf("  [p] Priority: %-8s  [P] Project: %-8s", priLabel, projLabel)
+	groupLabel := "--"
+	if by := m.groupBy(); by != commons.GroupNone {
+		groupLabel = i18n.T("tui.filter." + string(by))
+	}
+	filterLine2 := fmt.Sprintf("  [p] %s: %-8s  [P] %s: %-8s  [v] %s: %-8s",
+		i18n.T("tui.filter.priority"), priLabel, i18n.T("tui.filter.project"), projLabel,
+		i18n.T("tui.filter.group"), groupLabel)
 	if m.search.Value() != "" {
-		filterLine2 += fmt.Sprintf("  Search: %q", m.search.Value())
+		filterLine2 += fmt.Sprintf("  %s: %q", i18n.T("tui.filter.search"), m.search.Value())
 	}
 	b.WriteString(styleFilterBar.Render(filterLine2))
 	b.WriteByte('\n')
@@ -298,59 +492,39 @@ func (m browseModel) view() string {
 	}
 
 	// Item count.
-	b.WriteString(styleDim.Render(fmt.Sprintf("  %d items", len(m.items))))
+	count := fmt.Sprintf("  %d items", len(m.items))
+	if m.groupBy() != commons.GroupNone {
+		count += fmt.Sprintf(" in %d groups (enter on a header collapses it)", len(commons.GroupSummaries(m.items, m.groupBy())))
+	}
+	b.WriteString(styleDim.Render(count))
 	b.WriteByte('\n')
 
-	// Compute visible window.
-	headerLines := 7 // title + filter1 + filter2 + colheader + sep + count + slack
-	if m.searchMode {
-		headerLines++
+	// Render only the rows in view, reusing rows already styled for the
+	// current data and layout.
+	cached := len(m.rows) == len(m.items) && m.rowsWide == wide
+	start := m.offset
+	h := m.listHeight()
+	if m.cursor < start || m.cursor >= start+h {
+		start = max(0, m.cursor-h+1)
 	}
-	if m.projectMode {
-		headerLines++
-	}
-	listHeight := m.height - headerLines
-	if listHeight < 1 {
-		listHeight = 10
-	}
-	startIdx := 0
-	if m.cursor >= listHeight {
-		startIdx = m.cursor - listHeight + 1
-	}
-	endIdx := startIdx + listHeight
-	if endIdx > len(m.items) {
-		endIdx = len(m.items)
-	}
-
-	titleMax := 30
-	for i := startIdx; i < endIdx; i++ {
-		item := m.items[i]
-		title := item.Title
-		titleRunes := []rune(title)
-		if len(titleRunes) > titleMax {
-			title = string(titleRunes[:titleMax-3]) + "..."
-		}
-		pri := padANSI(colorizePriority(item.Priority), 3)
-		status := colorizeStatus(item.Status)
-		if m.pendingIDs[item.ID] > 0 {
-			status += "*"
-		}
-		status = padANSI(status, 10)
-		claimedBy := item.ClaimedBy
-		if wide && claimedBy == "" {
-			claimedBy = styleDim.Render("—")
-		}
+	end := min(start+h, m.rowCount())
+	for i := start; i < end; i++ {
+		idx := m.itemAt(i)
 		var line string
-		if wide {
-			line = fmt.Sprintf("  %-12s %-30s %-10s %-8s %s %s %-12s %s",
-				item.ID, title, item.Project, item.Type, pri, status, item.PostedBy, claimedBy)
-		} else {
-			line = fmt.Sprintf("  %-12s %-30s %-10s %-8s %s %s",
-				item.ID, title, item.Project, item.Type, pri, status)
+		switch {
+		case idx < 0:
+			line = m.renderGroupHeader(m.lines[i].group)
+		case cached:
+			line = m.rows[idx]
+		}
+		if line == "" {
+			line = m.renderRow(m.items[idx], wide)
+			if cached {
+				m.rows[idx] = line // shared backing array, so the cache outlives this copy
+			}
 		}
-
 		if i == m.cursor {
-			line = styleSelected.Width(m.width).Render(line)
+			line = renderSelected(line, m.width)
 		}
 		b.WriteString(line)
 		b.WriteByte('\n')
@@ -359,6 +533,46 @@ func (m browseModel) view() string {
 	return b.String()
 }
 
+// renderGroupHeader formats a section header with its item count and an
+// arrow showing whether it is collapsed.
+func (m browseModel) renderGroupHeader(g commons.SummaryGroup) string {
+	arrow := "▾"
+	if m.collapsed[g.Key] {
+		arrow = "▸"
+	}
+	label := g.Label(m.groupBy())
+	if m.groupBy() == commons.GroupStatus && g.Key != "" {
+		label = i18n.Status(g.Key)
+	}
+	return fmt.Sprintf("  %s %s %s", arrow, styleTitle.Render(label), styleDim.Render(fmt.Sprintf("(%d)", len(g.Items))))
+}
+
+// renderRow formats one board row (unselected).
+func (m browseModel) renderRow(item commons.WantedSummary, wide bool) string {
+	const titleMax = 30
+	title := item.Title
+	titleRunes := []rune(title)
+	if len(titleRunes) > titleMax {
+		title = string(titleRunes[:titleMax-3]) + "..."
+	}
+	pri := padANSI(colorizePriority(item.Priority), 3)
+	status := colorizeStatus(item.Status)
+	if m.pendingIDs[item.ID] > 0 {
+		status += "*"
+	}
+	status = padANSI(status, 10)
+	if !wide {
+		return fmt.Sprintf("  %-12s %-30s %-10s %-8s %s %s",
+			item.ID, title, item.Project, item.Type, pri, status)
+	}
+	claimedBy := item.ClaimedBy
+	if claimedBy == "" {
+		claimedBy = styleDim.Render("—")
+	}
+	return fmt.Sprintf("  %-12s %-30s %-10s %-8s %s %s %-12s %s",
+		item.ID, title, item.Project, item.Type, pri, status, item.PostedBy, claimedBy)
+}
+
 // padANSI right-pads an ANSI-styled string to width based on visible characters.
 func padANSI(s string, width int) string {
 	visible := lipgloss.Width(s)
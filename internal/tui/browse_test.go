@@ -1,11 +1,13 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/i18n"
 )
 
 func keyMsg(s string) bubbletea.Msg {
@@ -52,6 +54,31 @@ func TestBrowseUpdate_TypeCycle(t *testing.T) {
 	}
 }
 
+func TestBrowseSetTypes_CustomVocabulary(t *testing.T) {
+	m := newBrowseModel()
+	m.loading = false
+	m.typeIdx = 2 // "bug"
+
+	m.setTypes([]string{"", "quest", "bug"})
+	if got := m.types[m.typeIdx]; got != "bug" {
+		t.Errorf("selected type after setTypes = %q, want bug", got)
+	}
+
+	m.setTypes([]string{"", "quest"})
+	if m.typeIdx != 0 {
+		t.Errorf("typeIdx = %d, want 0 when the selected type is gone", m.typeIdx)
+	}
+
+	m2, _ := m.update(keyMsg("t"), Config{})
+	if f := m2.filter(""); f.Type != "quest" {
+		t.Errorf("filter type after 't' = %q, want quest", f.Type)
+	}
+	m3, _ := m2.update(keyMsg("t"), Config{})
+	if m3.typeIdx != 0 {
+		t.Errorf("type cycle should wrap at the custom list's end, typeIdx = %d", m3.typeIdx)
+	}
+}
+
 func TestBrowseUpdate_PriorityCycle(t *testing.T) {
 	m := newBrowseModel()
 	m.loading = false
@@ -258,6 +285,26 @@ func TestBrowseView_StatusLabel(t *testing.T) {
 	}
 }
 
+func TestBrowseView_Localized(t *testing.T) {
+	i18n.SetLocale("de")
+	defer i18n.SetLocale(i18n.Fallback)
+
+	m := newBrowseModel()
+	m.loading = false
+	m.width = 80
+	m.height = 24
+	m.setData(browseDataMsg{items: []commons.WantedSummary{
+		{ID: "w-1", Title: "Fix it", Status: "in_review", Priority: 2},
+	}})
+
+	v := m.view()
+	for _, want := range []string{"Wasteland-Board", "[s] Status: offen", "[t] Typ: alle", "Meine: AUS", "[p] Priorität: alle", "in Prüfung"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("German view should contain %q, got:\n%s", want, v)
+		}
+	}
+}
+
 func TestBrowseView_SearchMode(t *testing.T) {
 	m := newBrowseModel()
 	m.loading = false
@@ -379,3 +426,119 @@ func TestBrowseSetData_StoresPendingIDs(t *testing.T) {
 		t.Error("pendingIDs should contain w-abc123 with count 1")
 	}
 }
+
+func largeBoard(n int) browseDataMsg {
+	items := make([]commons.WantedSummary, n)
+	for i := range items {
+		items[i] = commons.WantedSummary{ID: fmt.Sprintf("w-%04d", i), Title: "Item", Status: "open"}
+	}
+	return browseDataMsg{items: items}
+}
+
+func TestBrowseView_RendersOnlyVisibleRows(t *testing.T) {
+	m := newBrowseModel()
+	m.setSize(80, 20)
+	m.setData(largeBoard(5000))
+
+	v := m.view()
+	if !strings.Contains(v, "w-0000") || strings.Contains(v, "w-0100") {
+		t.Fatalf("first page should show w-0000 only up to the window:\n%s", v)
+	}
+	cached := 0
+	for _, r := range m.rows {
+		if r != "" {
+			cached++
+		}
+	}
+	if cached != m.listHeight() {
+		t.Errorf("rendered %d rows, want %d (visible window)", cached, m.listHeight())
+	}
+
+	m, _ = m.update(keyMsg("G"), Config{})
+	v = m.view()
+	if m.cursor != 4999 || !strings.Contains(v, "w-4999") || strings.Contains(v, "w-0000") {
+		t.Errorf("after G: cursor = %d, view:\n%s", m.cursor, v)
+	}
+}
+
+func TestBrowseUpdate_ScrollKeepsWindowUntilEdge(t *testing.T) {
+	m := newBrowseModel()
+	m.setSize(80, 20)
+	m.setData(largeBoard(100))
+	h := m.listHeight()
+
+	m, _ = m.update(bubbletea.KeyMsg{Type: bubbletea.KeyPgDown}, Config{})
+	if m.cursor != h || m.offset != 1 {
+		t.Errorf("after pgdown: cursor = %d offset = %d, want %d and 1", m.cursor, m.offset, h)
+	}
+	// Moving up inside the window doesn't scroll it.
+	m, _ = m.update(keyMsg("k"), Config{})
+	if m.offset != 1 {
+		t.Errorf("after k: offset = %d, want 1", m.offset)
+	}
+}
+
+func TestBrowseUpdate_FilterRefetchDebounced(t *testing.T) {
+	m := New(Config{RigHandle: "test", Upstream: "test/db"})
+	for range 3 {
+		result, cmd := m.Update(keyMsg("s"))
+		m = result.(Model)
+		if cmd == nil {
+			t.Fatal("expected debounce cmd after 's'")
+		}
+	}
+	if m.browse.fetchSeq != 3 {
+		t.Fatalf("fetchSeq = %d, want 3", m.browse.fetchSeq)
+	}
+
+	if _, cmd := m.Update(browseRefetchMsg{seq: 1}); cmd != nil {
+		t.Error("superseded refetch should be dropped")
+	}
+	if _, cmd := m.Update(browseRefetchMsg{seq: 3}); cmd == nil {
+		t.Error("latest refetch should fetch")
+	}
+}
+
+func TestBrowseGroupBy_HeadersAndCollapse(t *testing.T) {
+	m := newBrowseModel()
+	m.setSize(80, 24)
+	m.setData(browseDataMsg{items: []commons.WantedSummary{
+		{ID: "w-1", Title: "One", Project: "gastown", Status: "open"},
+		{ID: "w-2", Title: "Two", Project: "beads", Status: "open"},
+		{ID: "w-3", Title: "Three", Project: "gastown", Status: "open"},
+	}})
+
+	m, cmd := m.update(keyMsg("v"), Config{})
+	if cmd != nil || m.loading {
+		t.Error("grouping should not refetch")
+	}
+	if m.groupBy() != commons.GroupProject {
+		t.Fatalf("groupBy = %q, want project", m.groupBy())
+	}
+	v := m.view()
+	if !strings.Contains(v, "beads") || !strings.Contains(v, "(1)") || !strings.Contains(v, "(2)") {
+		t.Errorf("view should show section headers with counts:\n%s", v)
+	}
+	if m.rowCount() != 5 {
+		t.Errorf("rows = %d, want 2 headers + 3 items", m.rowCount())
+	}
+
+	// Rows: beads header, w-2, gastown header, w-1, w-3.
+	m.moveCursor(2)
+	m, _ = m.update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter}, Config{})
+	if !m.collapsed["gastown"] || m.rowCount() != 3 || m.cursor != 2 {
+		t.Errorf("collapse: collapsed=%v rows=%d cursor=%d", m.collapsed, m.rowCount(), m.cursor)
+	}
+	if v := m.view(); !strings.Contains(v, "▸") || strings.Contains(v, "w-3") {
+		t.Errorf("collapsed section should hide its items:\n%s", v)
+	}
+
+	m.moveCursor(-1)
+	_, cmd = m.update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter}, Config{})
+	if cmd == nil {
+		t.Fatal("enter on an item should open it")
+	}
+	if nav, ok := cmd().(navigateMsg); !ok || nav.wantedID != "w-2" {
+		t.Errorf("enter opened %+v, want w-2", nav)
+	}
+}
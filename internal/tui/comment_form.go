@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+type commentFormModel struct {
+	body   textinput.Model
+	branch string // branch the comment is attached to
+	err    string // validation error
+}
+
+func newCommentForm(branch string) *commentFormModel {
+	ti := textinput.New()
+	ti.Placeholder = "review note"
+	ti.Focus()
+	ti.CharLimit = 1000
+	ti.Width = 60
+	return &commentFormModel{body: ti, branch: branch}
+}
+
+func (m *commentFormModel) update(msg bubbletea.Msg) (*commentFormModel, bubbletea.Cmd) {
+	if msg, ok := msg.(bubbletea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, keys.Back):
+			// Cancel form.
+			return nil, nil
+		case msg.Type == bubbletea.KeyEnter:
+			val := strings.TrimSpace(m.body.Value())
+			if val == "" {
+				m.err = "comment is required"
+				return m, nil
+			}
+			m.err = ""
+			return m, func() bubbletea.Msg {
+				return commentSubmitMsg{body: val}
+			}
+		}
+	}
+
+	var cmd bubbletea.Cmd
+	m.body, cmd = m.body.Update(msg)
+	return m, cmd
+}
+
+func (m *commentFormModel) view() string {
+	var s string
+	s += styleConfirm.Render("  Comment on "+m.branch) + "\n"
+	s += "  Note: " + m.body.View() + "\n"
+	if m.err != "" {
+		s += "  " + styleError.Render(m.err) + "\n"
+	}
+	s += styleDim.Render("  enter: save   esc: cancel") + "\n"
+	return s
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+func TestCommentForm_EmptySubmit_ShowsError(t *testing.T) {
+	f := newCommentForm("wl/alice/w-1")
+
+	result, cmd := f.update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if result == nil || !strings.Contains(result.err, "required") {
+		t.Fatalf("expected required error, got %+v", result)
+	}
+	if cmd != nil {
+		t.Error("should not return cmd on validation failure")
+	}
+}
+
+func TestCommentForm_ValidSubmit_ReturnsCommentSubmitMsg(t *testing.T) {
+	f := newCommentForm("wl/alice/w-1")
+	for _, ch := range "needs a test" {
+		f.update(keyMsg(string(ch)))
+	}
+
+	_, cmd := f.update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("should return commentSubmitMsg cmd")
+	}
+	submit, ok := cmd().(commentSubmitMsg)
+	if !ok || submit.body != "needs a test" {
+		t.Errorf("msg = %+v, want commentSubmitMsg{needs a test}", submit)
+	}
+}
+
+func TestDetail_CommentKey(t *testing.T) {
+	m := newDetailForTest("open", "someone", "", "wild-west")
+	m.detail, _ = m.detail.update(keyMsg("C"))
+	if m.detail.commentForm != nil {
+		t.Fatal("C should do nothing when review comments are disabled")
+	}
+
+	m.detail.commentsEnabled = true
+	if !strings.Contains(m.detail.actionHints(), "C:comment") {
+		t.Errorf("hints missing C:comment: %s", m.detail.actionHints())
+	}
+	m.detail, _ = m.detail.update(keyMsg("C"))
+	if m.detail.commentForm == nil || m.detail.commentForm.branch != "main" {
+		t.Fatalf("commentForm = %+v, want form on main", m.detail.commentForm)
+	}
+	m.detail, _ = m.detail.update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if m.detail.commentForm != nil {
+		t.Error("esc should close the comment form")
+	}
+}
+
+func TestCommentResultMsg_AppendsComment(t *testing.T) {
+	m := newDetailForTest("open", "someone", "", "wild-west")
+	m.detail.commentsEnabled = true
+	m.detail.executing = true
+
+	result, _ := m.Update(commentResultMsg{comment: &commons.ReviewComment{
+		Branch: "main", WantedID: "w-abc123", Author: "test-rig", Body: "looks right", CreatedAt: "2026-01-02 03:04:05",
+	}})
+	m = result.(Model)
+	if m.detail.executing || len(m.detail.comments) != 1 {
+		t.Fatalf("executing=%v comments=%+v", m.detail.executing, m.detail.comments)
+	}
+	content := m.detail.renderContent()
+	if !strings.Contains(content, "Review comments (1)") || !strings.Contains(content, "looks right") {
+		t.Errorf("content missing comment:\n%s", content)
+	}
+}
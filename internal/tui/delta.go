@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/sdk"
+)
+
+// deltaModel holds the state for the delta view, which lists only the
+// items whose state on the rig's branches differs from main, with the
+// field changes each branch would propose upstream.
+type deltaModel struct {
+	deltas  []sdk.ItemDelta
+	cursor  int
+	width   int
+	height  int
+	loading bool
+	err     error
+}
+
+func newDeltaModel() deltaModel {
+	return deltaModel{loading: true}
+}
+
+func (m *deltaModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m *deltaModel) setData(msg deltaDataMsg) {
+	m.loading = false
+	m.err = msg.err
+	m.deltas = msg.deltas
+	if m.cursor >= len(m.deltas) {
+		m.cursor = max(0, len(m.deltas)-1)
+	}
+}
+
+func (m deltaModel) update(msg bubbletea.Msg) (deltaModel, bubbletea.Cmd) {
+	km, ok := msg.(bubbletea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(km, keys.Quit):
+		return m, bubbletea.Quit
+
+	case key.Matches(km, keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(km, keys.Down):
+		if m.cursor < len(m.deltas)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(km, keys.Enter):
+		if m.cursor < len(m.deltas) {
+			id := m.deltas[m.cursor].WantedID
+			return m, func() bubbletea.Msg {
+				return navigateMsg{view: viewDetail, wantedID: id}
+			}
+		}
+
+	case key.Matches(km, keys.Back):
+		return m, func() bubbletea.Msg {
+			return navigateMsg{view: viewBrowse}
+		}
+	}
+	return m, nil
+}
+
+func (m deltaModel) view() string {
+	var b strings.Builder
+
+	b.WriteString(styleTitle.Render("Pending Changes vs Main"))
+	b.WriteByte('\n')
+	b.WriteByte('\n')
+
+	if m.loading {
+		b.WriteString(styleDim.Render("  Loading..."))
+		return b.String()
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "  Error: %v", m.err)
+		return b.String()
+	}
+	if len(m.deltas) == 0 {
+		b.WriteString(styleDim.Render("  No pending changes: your branches match main."))
+		b.WriteByte('\n')
+		return b.String()
+	}
+
+	// Render every item, then window the lines so the selected item's
+	// header stays on screen.
+	var lines []string
+	selStart := 0
+	for i, d := range m.deltas {
+		if i == m.cursor {
+			selStart = len(lines)
+		}
+		lines = append(lines, m.renderItem(d, i)...)
+	}
+	avail := len(lines)
+	if m.height > 0 {
+		avail = max(1, m.height-2) // title + blank line
+	}
+	start := 0
+	if selStart+3 > avail {
+		start = selStart
+	}
+	end := min(len(lines), start+avail)
+	for _, l := range lines[start:end] {
+		b.WriteString(l)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// renderItem returns the header and change lines for one item.
+func (m deltaModel) renderItem(d sdk.ItemDelta, idx int) []string {
+	header := fmt.Sprintf("  %s  %s", d.WantedID, d.Title)
+	if idx == m.cursor {
+		header = renderSelected(header, m.width)
+	}
+	meta := "    " + d.Branch
+	if d.Delta != "" {
+		meta += "  " + d.Delta
+	}
+	if d.PRURL != "" {
+		meta += "  " + d.PRURL
+	}
+	lines := []string{header, styleDim.Render(meta)}
+
+	for _, c := range d.Changes {
+		lines = append(lines, fmt.Sprintf("    %s %s %s", c.Table, c.ID, styleDim.Render("("+c.DiffType+")")))
+		for _, f := range c.Fields {
+			switch c.DiffType {
+			case "added":
+				lines = append(lines, fmt.Sprintf("      %s %s  %s", styleSuccess.Render("+"), f.Field, f.To))
+			case "removed":
+				lines = append(lines, fmt.Sprintf("      %s %s  %s", styleError.Render("-"), f.Field, f.From))
+			default:
+				lines = append(lines, fmt.Sprintf("      %s %s  %s → %s", styleWarning.Render("~"), f.Field,
+					styleError.Render(emptyMark(f.From)), styleSuccess.Render(emptyMark(f.To))))
+			}
+		}
+	}
+	return append(lines, "")
+}
+
+// emptyMark renders an empty value visibly.
+func emptyMark(v string) string {
+	if v == "" {
+		return "∅"
+	}
+	return v
+}
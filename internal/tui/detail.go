@@ -1,14 +1,17 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/sdk"
 )
 
 // confirmAction holds state while waiting for the user to confirm.
@@ -17,6 +20,25 @@ type confirmAction struct {
 	label      string
 }
 
+// undoWindow is how long the undo prompt stays available after a
+// wild-west mutation.
+const undoWindow = 30 * time.Second
+
+// undoAction records the state needed to revert the last mutation.
+type undoAction struct {
+	wantedID      string
+	transition    commons.Transition
+	prevClaimedBy string    // claimant before the mutation (restored when undoing unclaim)
+	at            time.Time // when the mutation completed
+}
+
+// pendingPush holds a mutation waiting behind the confirm-push prompt.
+type pendingPush struct {
+	label          string // e.g. "Mark w-1 done?"
+	executingLabel string // spinner label once confirmed
+	run            bubbletea.Cmd
+}
+
 // deltaConfirmAction holds state while waiting for the user to confirm a delta action.
 type deltaConfirmAction struct {
 	action branchDeltaAction
@@ -43,24 +65,50 @@ type detailModel struct {
 	branchActions  []string            // SDK-computed: "submit_pr", "apply", "discard"
 	confirming     *confirmAction      // non-nil → showing confirmation prompt
 	deltaConfirm   *deltaConfirmAction // non-nil → showing delta confirmation prompt
+	pushConfirm    *pendingPush        // non-nil → showing confirm-push prompt
 	executing      bool                // true → showing spinner
 	executingLabel string              // e.g. "Claiming..."
+	progress       string              // latest push progress line while executing
+	pushFailed     *sdk.PushError      // non-nil → offering retry/keep after a failed push
 	spinner        spinner.Model
-	result         string // brief success/error message
+	result         string      // brief success/error message
+	undo           *undoAction // non-nil → last mutation can be undone
+
+	// Review comments stored in the database (no PR provider).
+	commentsEnabled bool
+	comments        []commons.ReviewComment
+
+	// Accept quorum progress for an in-review completion.
+	quorum    int
+	approvals []commons.Approval
+
+	// Items posted by partial accepts of this item.
+	followUps []commons.WantedItem
+
+	// Typed evidence entries of the completion.
+	evidence []commons.Evidence
+
+	// The wasteland's claim expiry; 0 when claims never expire.
+	claimExpiry time.Duration
+
+	// Rigs waiting for the claim, first in line first.
+	queue []commons.QueueEntry
 
 	// Sub-state forms.
-	submit     *submitModel
-	doneForm   *doneFormModel
-	acceptForm *acceptFormModel
+	submit      *submitModel
+	doneForm    *doneFormModel
+	acceptForm  *acceptFormModel
+	commentForm *commentFormModel
 }
 
-func newDetailModel(rigHandle, mode string) detailModel {
+func newDetailModel(rigHandle, mode string, commentsEnabled bool) detailModel {
 	s := spinner.New(spinner.WithSpinner(spinner.Dot))
 	return detailModel{
-		rigHandle: rigHandle,
-		mode:      mode,
-		spinner:   s,
-		loading:   true,
+		rigHandle:       rigHandle,
+		mode:            mode,
+		commentsEnabled: commentsEnabled,
+		spinner:         s,
+		loading:         true,
 	}
 }
 
@@ -87,15 +135,27 @@ func (m *detailModel) setData(msg detailDataMsg) {
 	m.mainStatus = msg.mainStatus
 	m.prURL = msg.prURL
 	m.branchActions = msg.branchActions
+	m.comments = msg.comments
+	m.quorum = msg.quorum
+	m.approvals = msg.approvals
+	m.followUps = msg.followUps
+	m.evidence = msg.evidence
+	m.claimExpiry = msg.claimExpiry
+	m.queue = msg.queue
 	// Clear mutation state so stale results don't mask action hints.
 	m.confirming = nil
 	m.deltaConfirm = nil
+	m.pushConfirm = nil
+	m.pushFailed = nil
 	m.executing = false
 	m.executingLabel = ""
+	m.progress = ""
 	m.result = ""
+	m.undo = nil
 	m.submit = nil
 	m.doneForm = nil
 	m.acceptForm = nil
+	m.commentForm = nil
 	if m.item != nil {
 		m.viewport.SetContent(m.renderContent())
 		m.viewport.GotoTop()
@@ -150,6 +210,42 @@ func (m detailModel) update(msg bubbletea.Msg) (detailModel, bubbletea.Cmd) {
 			return m, nil
 		}
 
+		// Confirm-push prompt active: handle y/n/esc only.
+		if m.pushConfirm != nil {
+			switch {
+			case key.Matches(msg, keys.Confirm):
+				p := m.pushConfirm
+				m.pushConfirm = nil
+				m.executing = true
+				m.executingLabel = p.executingLabel
+				m.refreshViewport()
+				return m, bubbletea.Batch(m.spinner.Tick, p.run)
+			case key.Matches(msg, keys.Cancel), key.Matches(msg, keys.Back):
+				m.pushConfirm = nil
+				m.refreshViewport()
+				return m, nil
+			}
+			return m, nil
+		}
+
+		// Failed push: retry it or keep the local commit.
+		if m.pushFailed != nil {
+			switch {
+			case msg.String() == "r":
+				pe := m.pushFailed
+				m.pushFailed = nil
+				return m, func() bubbletea.Msg {
+					return retryPushMsg{failed: pe}
+				}
+			case msg.String() == "k", key.Matches(msg, keys.Back):
+				m.pushFailed = nil
+				m.result = styleDim.Render("Kept the local commit; it goes upstream with your next push")
+				m.refreshViewport()
+				return m, nil
+			}
+			return m, nil
+		}
+
 		// Submit view active: route to submit model.
 		if m.submit != nil {
 			var cmd bubbletea.Cmd
@@ -177,6 +273,14 @@ func (m detailModel) update(msg bubbletea.Msg) (detailModel, bubbletea.Cmd) {
 			return m, cmd
 		}
 
+		// Comment form active: route to comment form.
+		if m.commentForm != nil {
+			var cmd bubbletea.Cmd
+			m.commentForm, cmd = m.commentForm.update(msg)
+			m.refreshViewport()
+			return m, cmd
+		}
+
 		// Normal key handling.
 		switch {
 		case key.Matches(msg, keys.Back):
@@ -191,6 +295,19 @@ func (m detailModel) update(msg bubbletea.Msg) (detailModel, bubbletea.Cmd) {
 			return m.tryDoneForm()
 		case key.Matches(msg, keys.Accept):
 			return m.tryAcceptForm()
+		case key.Matches(msg, keys.Comment) && m.commentsEnabled && m.item != nil:
+			m.result = ""
+			m.commentForm = newCommentForm(m.commentBranch())
+			m.refreshViewport()
+			return m, nil
+
+		// Undo shares u with unclaim; it wins while the window is open.
+		case key.Matches(msg, keys.Undo) && m.canUndo(time.Now()):
+			u := *m.undo
+			m.undo = nil
+			return m, func() bubbletea.Msg {
+				return undoRequestMsg{undo: u}
+			}
 
 		// Executable actions.
 		case key.Matches(msg, keys.Claim):
@@ -217,6 +334,24 @@ func (m detailModel) update(msg bubbletea.Msg) (detailModel, bubbletea.Cmd) {
 	return m, cmd
 }
 
+// notePushFailure switches to the retry/keep prompt when err is a push
+// that failed after its commit landed locally, reporting whether it did.
+func (m *detailModel) notePushFailure(err error) bool {
+	var pe *sdk.PushError
+	if !errors.As(err, &pe) {
+		return false
+	}
+	m.pushFailed = pe
+	m.result = ""
+	m.refreshViewport()
+	return true
+}
+
+// canUndo reports whether the last mutation can still be undone at now.
+func (m detailModel) canUndo(now time.Time) bool {
+	return m.undo != nil && m.mode != "pr" && now.Sub(m.undo.at) < undoWindow
+}
+
 // tryAction validates a transition and permission, then returns an actionRequestMsg.
 func (m detailModel) tryAction(t commons.Transition) (detailModel, bubbletea.Cmd) {
 	if m.item == nil {
@@ -283,6 +418,15 @@ func (m detailModel) tryAcceptForm() (detailModel, bubbletea.Cmd) {
 	return m, nil
 }
 
+// commentBranch is the branch a review comment from the detail view is
+// attached to: the item's mutation branch, or main when there is none.
+func (m detailModel) commentBranch() string {
+	if m.branch != "" {
+		return m.branch
+	}
+	return "main"
+}
+
 // submitOpenedMsg signals to the root that the submit view was opened
 // and diff loading should begin.
 type submitOpenedMsg struct {
@@ -384,7 +528,15 @@ func (m detailModel) renderContent() string {
 	}
 
 	if item.ClaimedBy != "" {
-		fmt.Fprintf(&b, "  Claimed by:  %s\n", item.ClaimedBy)
+		fmt.Fprintf(&b, "  Claimed by:  %s%s\n", item.ClaimedBy, m.claimAge(item))
+	}
+
+	if len(m.queue) > 0 {
+		fmt.Fprintf(&b, "  Queue:       %s\n", commons.QueueSummary(m.queue))
+	}
+
+	if item.ParentID != "" {
+		fmt.Fprintf(&b, "  Follows up:  %s\n", item.ParentID)
 	}
 
 	if len(item.Tags) > 0 {
@@ -400,15 +552,19 @@ func (m detailModel) renderContent() string {
 
 	if item.Description != "" {
 		b.WriteString("\n  Description:\n")
-		fmt.Fprintf(&b, "    %s\n", item.Description)
+		b.WriteString(renderMarkdown(item.Description, "    ", m.width))
 	}
 
 	if m.completion != nil {
 		fmt.Fprintf(&b, "\n  Completion:  %s\n", m.completion.ID)
-		if m.completion.Evidence != "" {
-			fmt.Fprintf(&b, "    Evidence:    %s\n", m.completion.Evidence)
-		}
+		m.renderEvidence(&b)
 		fmt.Fprintf(&b, "    Completed by: %s\n", m.completion.CompletedBy)
+		if progress := commons.ApprovalProgress(m.approvals, m.quorum); progress != "" {
+			if len(m.approvals) > 0 {
+				progress += " (" + strings.Join(commons.Approvers(m.approvals), ", ") + ")"
+			}
+			fmt.Fprintf(&b, "    Approvals:   %s\n", progress)
+		}
 	}
 
 	if m.stamp != nil {
@@ -426,9 +582,36 @@ func (m detailModel) renderContent() string {
 		}
 	}
 
+	if len(m.followUps) > 0 {
+		b.WriteString("\n  Follow-ups:\n")
+		for _, f := range m.followUps {
+			fmt.Fprintf(&b, "    %s  %-10s  %s\n", f.ID, f.Status, f.Title)
+		}
+	}
+
+	if len(m.comments) > 0 {
+		fmt.Fprintf(&b, "\n  Review comments (%d):\n", len(m.comments))
+		for _, c := range m.comments {
+			header := c.Author + "  " + c.CreatedAt
+			if t := c.Target(); t != "" {
+				header += "  " + t
+			}
+			if c.Branch != m.branch {
+				header += "  (" + c.Branch + ")"
+			}
+			b.WriteString(styleDim.Render("    "+header) + "\n")
+			for _, line := range strings.Split(c.Body, "\n") {
+				fmt.Fprintf(&b, "      %s\n", line)
+			}
+		}
+	}
+
 	// Status line: confirmation, executing, result, forms, or action hints.
 	b.WriteByte('\n')
 	switch {
+	case m.commentForm != nil:
+		b.WriteString(m.commentForm.view())
+		return b.String()
 	case m.doneForm != nil:
 		b.WriteString(m.doneForm.view())
 		return b.String()
@@ -440,10 +623,27 @@ func (m detailModel) renderContent() string {
 			"  %s Pushes to upstream. [y/n]", m.confirming.label)))
 	case m.deltaConfirm != nil:
 		b.WriteString(styleConfirm.Render(fmt.Sprintf("  %s", m.deltaConfirm.label)))
+	case m.pushConfirm != nil:
+		b.WriteString(styleConfirm.Render(fmt.Sprintf(
+			"  %s Pushes to upstream. [y/n]", m.pushConfirm.label)))
+	case m.pushFailed != nil:
+		b.WriteString(styleError.Render("  Push failed: " + m.pushFailed.Err.Error()))
+		b.WriteByte('\n')
+		if log := lastLine(m.pushFailed.Log); log != "" {
+			b.WriteString(styleDim.Render("  " + log))
+			b.WriteByte('\n')
+		}
+		b.WriteString(styleConfirm.Render("  Committed locally. r: retry push  k: keep local commit"))
 	case m.executing:
 		fmt.Fprintf(&b, "  %s %s", m.spinner.View(), m.executingLabel)
+		if m.progress != "" {
+			b.WriteString(styleDim.Render("  " + m.progress))
+		}
 	case m.result != "":
 		b.WriteString("  " + m.result)
+		if m.canUndo(time.Now()) {
+			b.WriteString(styleDim.Render("  u:undo"))
+		}
 		b.WriteByte('\n')
 		b.WriteString(styleDim.Render(m.actionHints()))
 	default:
@@ -454,19 +654,68 @@ func (m detailModel) renderContent() string {
 	return b.String()
 }
 
-// transitionKeyHint maps transitions to their TUI key bindings.
-var transitionKeyHint = map[commons.Transition]string{
-	commons.TransitionClaim:   "c",
-	commons.TransitionUnclaim: "u",
-	commons.TransitionDone:    "d",
-	commons.TransitionAccept:  "a",
-	commons.TransitionReject:  "x",
-	commons.TransitionClose:   "X",
-	commons.TransitionDelete:  "D",
+// transitionKeys maps transitions to their TUI key bindings.
+var transitionKeys = map[commons.Transition]key.Binding{
+	commons.TransitionClaim:   keys.Claim,
+	commons.TransitionUnclaim: keys.Unclaim,
+	commons.TransitionDone:    keys.Done,
+	commons.TransitionAccept:  keys.Accept,
+	commons.TransitionReject:  keys.Reject,
+	commons.TransitionClose:   keys.Close,
+	commons.TransitionDelete:  keys.Delete,
 }
 
 // actionHints returns a string showing valid lifecycle actions for the item,
 // filtered by both status validity and permission.
+// renderEvidence writes the completion's evidence: one entry on the
+// Evidence line, several as a list with their kinds.
+func (m detailModel) renderEvidence(b *strings.Builder) {
+	entries := m.evidence
+	if len(entries) == 0 && m.completion.Evidence != "" {
+		entries = []commons.Evidence{commons.ParseEvidence(m.completion.Evidence)}
+	}
+	switch len(entries) {
+	case 0:
+	case 1:
+		fmt.Fprintf(b, "    Evidence:    %s %s%s\n", entries[0].Value, styleDim.Render("("+entries[0].Kind+")"), verifiedMark(entries[0]))
+	default:
+		fmt.Fprintf(b, "    Evidence:    %d entries\n", len(entries))
+		for _, e := range entries {
+			fmt.Fprintf(b, "      %-9s %s%s\n", e.Kind, e.Value, verifiedMark(e))
+		}
+	}
+}
+
+// verifiedMark flags evidence that was checked to exist when submitted.
+func verifiedMark(e commons.Evidence) string {
+	if !e.Verified {
+		return ""
+	}
+	return " " + styleSuccess.Render("✓ verified")
+}
+
+// claimAge renders a claimed item's idle time, in the warning color near
+// expiry and the error color past it.
+func (m detailModel) claimAge(item *commons.WantedItem) string {
+	if item.Status != "claimed" {
+		return ""
+	}
+	updated, ok := commons.ParseDoltTime(item.UpdatedAt)
+	if !ok {
+		return ""
+	}
+	age := time.Since(updated)
+	label := "  (" + commons.ClaimAgeLabel(age, m.claimExpiry) + ")"
+	switch {
+	case m.claimExpiry > 0 && age >= m.claimExpiry:
+		return styleError.Render(label)
+	case commons.ClaimNearExpiry(age, m.claimExpiry):
+		return styleWarning.Render(label)
+	default:
+		return styleDim.Render(label)
+	}
+}
+
 func (m detailModel) actionHints() string {
 	if m.item == nil {
 		return ""
@@ -474,7 +723,7 @@ func (m detailModel) actionHints() string {
 	available := commons.AvailableTransitions(m.item, m.rigHandle)
 	var hints []string
 	for _, t := range available {
-		k := transitionKeyHint[t]
+		k := bindingKey(transitionKeys[t])
 		name := commons.TransitionName(t)
 		hint := k + ":" + name
 		if commons.TransitionRequiresInput(t) != "" {
@@ -503,8 +752,21 @@ func (m detailModel) actionHints() string {
 		hints = append(hints, deltaHints...)
 	}
 
+	if m.commentsEnabled {
+		if len(hints) > 0 {
+			hints = append(hints, "|")
+		}
+		hints = append(hints, "C:comment")
+	}
+
 	if len(hints) == 0 {
 		return "  (no actions available)"
 	}
 	return "  Actions: " + strings.Join(hints, "  ")
 }
+
+// lastLine returns the last non-blank line of s, trimmed.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -291,3 +293,54 @@ func TestDetail_SetData_StoresPRURL(t *testing.T) {
 		t.Errorf("view should contain 'PR:' line, got:\n%s", v)
 	}
 }
+
+func TestDetail_PushFailure_RetryOrKeep(t *testing.T) {
+	m := newDetailForTest("open", "other-rig", "", "wild-west")
+	m.detail.executing = true
+	pe := &sdk.PushError{WantedID: "w-abc123", CommitMsg: "wl claim: w-abc123",
+		Log: "  Syncing with upstream...\n  warning: sync from upstream failed: timeout\n", Err: errors.New("push failed for remotes: upstream")}
+
+	result, _ := m.Update(actionResultMsg{err: fmt.Errorf("claim: %w", pe)})
+	m2 := result.(Model)
+	if m2.detail.pushFailed != pe {
+		t.Fatal("a failed push should offer retry/keep")
+	}
+	v := m2.detail.renderContent()
+	for _, want := range []string{"Push failed: push failed for remotes: upstream", "sync from upstream failed: timeout", "r: retry push", "k: keep local commit"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, v)
+		}
+	}
+
+	// r asks to retry the same push.
+	_, cmd := m2.detail.update(keyMsg("r"))
+	if cmd == nil {
+		t.Fatal("r should return a cmd")
+	}
+	if retry, ok := cmd().(retryPushMsg); !ok || retry.failed != pe {
+		t.Errorf("r should request a retry of the failed push")
+	}
+
+	// k keeps the local commit and dismisses the prompt.
+	kept, _ := m2.detail.update(keyMsg("k"))
+	if kept.pushFailed != nil || !strings.Contains(kept.result, "Kept the local commit") {
+		t.Errorf("k should dismiss with a note, result = %q", kept.result)
+	}
+}
+
+func TestDetail_PushProgress_ShownWhileExecuting(t *testing.T) {
+	m := newDetailForTest("open", "other-rig", "", "wild-west")
+	ch := make(chan string, 1)
+	m.cfg.PushProgress = ch
+	m.detail.executing = true
+	m.detail.executingLabel = "Claiming..."
+
+	result, cmd := m.Update(pushProgressMsg{line: "Syncing with upstream..."})
+	m2 := result.(Model)
+	if cmd == nil {
+		t.Error("progress should keep listening for more lines")
+	}
+	if v := m2.detail.renderContent(); !strings.Contains(v, "Claiming...") || !strings.Contains(v, "Syncing with upstream...") {
+		t.Errorf("view should show the progress line, got:\n%s", v)
+	}
+}
@@ -0,0 +1,467 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/style"
+)
+
+// Join providers offered by the wizard.
+const (
+	JoinDoltHub      = "dolthub"       // DoltHub, remote API backend
+	JoinDoltHubLocal = "dolthub-local" // DoltHub, local clone
+	JoinGitHub       = "github"        // GitHub through the gh CLI
+)
+
+// JoinPlan is what the join wizard collected.
+type JoinPlan struct {
+	Provider string // JoinDoltHub, JoinDoltHubLocal or JoinGitHub
+	Upstream string // org/db
+	ForkOrg  string
+	Mode     string // "pr" or "wild-west"
+	Handle   string
+}
+
+// ForkChoice is an account offered as the home of the fork.
+type ForkChoice struct {
+	Name  string
+	Label string // e.g. "organization, fork exists"
+}
+
+// JoinCheck is the outcome of a pre-join check.
+type JoinCheck struct {
+	Name    string
+	Status  string // "pass", "warn" or "fail"
+	Message string
+	Hint    string // how to fix a warning or failure
+}
+
+// JoinWizardConfig seeds the wizard and supplies the lookups it needs.
+type JoinWizardConfig struct {
+	Provider string // preselected provider; "" for JoinDoltHub
+	Upstream string // initial upstream
+	Mode     string // preselected mode; "" for "pr"
+	Handle   string // initial rig handle; "" for the fork account
+
+	// ParseUpstream validates what the user typed as the upstream, which
+	// may be an invite URL. It returns the org/db and any mode the invite
+	// suggests.
+	ParseUpstream func(input string) (upstream, mode string, err error)
+
+	// ForkChoices lists the accounts the fork could live in, best first.
+	ForkChoices func(provider, upstream string) ([]ForkChoice, error)
+
+	// Preflight runs the checks shown before joining.
+	Preflight func(plan JoinPlan) []JoinCheck
+}
+
+type joinStep int
+
+const (
+	stepProvider joinStep = iota
+	stepUpstream
+	stepFork
+	stepForkInput
+	stepMode
+	stepHandle
+	stepChecks
+)
+
+// joinOption is one line of a choice step.
+type joinOption struct {
+	value string
+	label string
+}
+
+var joinProviderOptions = []joinOption{
+	{JoinDoltHub, "DoltHub, remote API — no local dolt needed"},
+	{JoinDoltHubLocal, "DoltHub, local clone — requires dolt"},
+	{JoinGitHub, "GitHub — requires the gh CLI"},
+}
+
+var joinModeOptions = []joinOption{
+	{"pr", "PR mode — changes go through pull requests for review"},
+	{"wild-west", "wild-west — changes are pushed to upstream directly"},
+}
+
+// forkChoicesMsg carries the accounts the fork could live in.
+type forkChoicesMsg struct {
+	choices []ForkChoice
+	err     error
+}
+
+// joinChecksMsg carries the pre-join check results.
+type joinChecksMsg struct {
+	checks []JoinCheck
+}
+
+// JoinWizard is a bubbletea model that walks through joining a
+// wasteland: provider, upstream, fork, mode, rig handle and pre-join
+// checks. It quits once the user confirms or cancels; Plan reports which.
+type JoinWizard struct {
+	cfg    JoinWizardConfig
+	step   joinStep
+	cursor int
+	plan   JoinPlan
+	input  textinput.Model
+	err    string
+
+	forks   []ForkChoice
+	loading bool
+	checks  []JoinCheck
+
+	done      bool
+	cancelled bool
+}
+
+// NewJoinWizard creates a join wizard starting at the provider step.
+func NewJoinWizard(cfg JoinWizardConfig) JoinWizard {
+	m := JoinWizard{cfg: cfg, input: textinput.New()}
+	m.input.CharLimit = 200
+	m.input.Width = 50
+	m.plan.Provider = cfg.Provider
+	if m.plan.Provider == "" {
+		m.plan.Provider = JoinDoltHub
+	}
+	m.plan.Upstream = cfg.Upstream
+	m.plan.Mode = cfg.Mode
+	m.plan.Handle = cfg.Handle
+	if m.plan.Mode == "" {
+		m.plan.Mode = "pr"
+	}
+	m.cursor = optionIndex(joinProviderOptions, m.plan.Provider)
+	return m
+}
+
+// Plan returns the collected plan, and false if the wizard was cancelled
+// or hasn't finished.
+func (m JoinWizard) Plan() (JoinPlan, bool) {
+	return m.plan, m.done && !m.cancelled
+}
+
+// Init implements bubbletea.Model.
+func (m JoinWizard) Init() bubbletea.Cmd { return nil }
+
+// Update implements bubbletea.Model.
+func (m JoinWizard) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
+	switch msg := msg.(type) {
+	case forkChoicesMsg:
+		if m.step != stepFork || !m.loading {
+			return m, nil // the user moved on before the lookup finished
+		}
+		m.loading = false
+		m.forks = msg.choices
+		if msg.err != nil {
+			m.err = "could not list your accounts: " + msg.err.Error()
+		}
+		if len(m.forks) == 0 {
+			return m.enterText(stepForkInput, m.plan.ForkOrg)
+		}
+		m.cursor = 0
+		return m, nil
+	case joinChecksMsg:
+		if m.step != stepChecks || !m.loading {
+			return m, nil
+		}
+		m.loading = false
+		m.checks = msg.checks
+		return m, nil
+	case bubbletea.KeyMsg:
+		return m.updateKey(msg)
+	}
+	if m.isTextStep() {
+		var cmd bubbletea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m JoinWizard) updateKey(msg bubbletea.KeyMsg) (bubbletea.Model, bubbletea.Cmd) {
+	switch {
+	case msg.Type == bubbletea.KeyCtrlC:
+		m.done, m.cancelled = true, true
+		return m, bubbletea.Quit
+	case key.Matches(msg, keys.Back):
+		return m.back()
+	case msg.Type == bubbletea.KeyEnter:
+		if m.loading {
+			return m, nil
+		}
+		return m.next()
+	}
+	if m.isTextStep() {
+		var cmd bubbletea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.cursor < m.optionCount()-1 {
+			m.cursor++
+		}
+	case msg.String() == "q":
+		m.done, m.cancelled = true, true
+		return m, bubbletea.Quit
+	}
+	return m, nil
+}
+
+// next validates the current step and moves forward.
+func (m JoinWizard) next() (bubbletea.Model, bubbletea.Cmd) {
+	m.err = ""
+	switch m.step {
+	case stepProvider:
+		m.plan.Provider = joinProviderOptions[m.cursor].value
+		return m.enterText(stepUpstream, m.plan.Upstream)
+	case stepUpstream:
+		upstream, mode, err := m.cfg.ParseUpstream(strings.TrimSpace(m.input.Value()))
+		if err != nil {
+			m.err = err.Error()
+			return m, nil
+		}
+		m.plan.Upstream = upstream
+		if mode != "" {
+			m.plan.Mode = mode
+		}
+		return m.enterForkStep()
+	case stepFork:
+		if m.cursor == len(m.forks) {
+			return m.enterText(stepForkInput, m.plan.ForkOrg)
+		}
+		m.plan.ForkOrg = m.forks[m.cursor].Name
+		return m.enterModeStep()
+	case stepForkInput:
+		org := strings.TrimSpace(m.input.Value())
+		if org == "" || strings.ContainsAny(org, " /") {
+			m.err = "enter an account or organization name"
+			return m, nil
+		}
+		m.plan.ForkOrg = org
+		return m.enterModeStep()
+	case stepMode:
+		m.plan.Mode = joinModeOptions[m.cursor].value
+		handle := m.plan.Handle
+		if handle == "" {
+			handle = m.plan.ForkOrg
+		}
+		return m.enterText(stepHandle, handle)
+	case stepHandle:
+		handle := strings.TrimSpace(m.input.Value())
+		if handle == "" || strings.ContainsAny(handle, " /") {
+			m.err = "the rig handle must be a single word"
+			return m, nil
+		}
+		m.plan.Handle = handle
+		m.step, m.checks = stepChecks, nil
+		if m.cfg.Preflight == nil {
+			return m, nil
+		}
+		m.loading = true
+		plan, preflight := m.plan, m.cfg.Preflight
+		return m, func() bubbletea.Msg { return joinChecksMsg{checks: preflight(plan)} }
+	case stepChecks:
+		m.done = true
+		return m, bubbletea.Quit
+	}
+	return m, nil
+}
+
+// back returns to the previous step; on the first step it cancels.
+func (m JoinWizard) back() (bubbletea.Model, bubbletea.Cmd) {
+	m.err, m.loading = "", false
+	switch m.step {
+	case stepProvider:
+		m.done, m.cancelled = true, true
+		return m, bubbletea.Quit
+	case stepUpstream:
+		m.step = stepProvider
+		m.cursor = optionIndex(joinProviderOptions, m.plan.Provider)
+		m.input.Blur()
+		return m, nil
+	case stepFork:
+		return m.enterText(stepUpstream, m.plan.Upstream)
+	case stepForkInput, stepMode:
+		if len(m.forks) > 0 {
+			m.step, m.cursor = stepFork, 0
+			m.input.Blur()
+			return m, nil
+		}
+		return m.enterText(stepUpstream, m.plan.Upstream)
+	case stepHandle:
+		return m.enterModeStep()
+	case stepChecks:
+		return m.enterText(stepHandle, m.plan.Handle)
+	}
+	return m, nil
+}
+
+func (m JoinWizard) enterText(step joinStep, value string) (bubbletea.Model, bubbletea.Cmd) {
+	m.step = step
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	switch step {
+	case stepUpstream:
+		m.input.Placeholder = "org/database or wl+invite:// URL"
+	case stepForkInput:
+		m.input.Placeholder = "account or organization"
+	case stepHandle:
+		m.input.Placeholder = "rig handle"
+	}
+	return m, m.input.Focus()
+}
+
+func (m JoinWizard) enterForkStep() (bubbletea.Model, bubbletea.Cmd) {
+	m.step, m.cursor, m.forks = stepFork, 0, nil
+	m.input.Blur()
+	if m.cfg.ForkChoices == nil {
+		return m.enterText(stepForkInput, m.plan.ForkOrg)
+	}
+	m.loading = true
+	provider, upstream, list := m.plan.Provider, m.plan.Upstream, m.cfg.ForkChoices
+	return m, func() bubbletea.Msg {
+		choices, err := list(provider, upstream)
+		return forkChoicesMsg{choices: choices, err: err}
+	}
+}
+
+func (m JoinWizard) enterModeStep() (bubbletea.Model, bubbletea.Cmd) {
+	m.step = stepMode
+	m.cursor = optionIndex(joinModeOptions, m.plan.Mode)
+	m.input.Blur()
+	return m, nil
+}
+
+func (m JoinWizard) isTextStep() bool {
+	return m.step == stepUpstream || m.step == stepForkInput || m.step == stepHandle
+}
+
+// optionCount is the number of lines the current choice step offers.
+func (m JoinWizard) optionCount() int {
+	switch m.step {
+	case stepProvider:
+		return len(joinProviderOptions)
+	case stepFork:
+		return len(m.forks) + 1 // + "another account"
+	case stepMode:
+		return len(joinModeOptions)
+	}
+	return 0
+}
+
+func optionIndex(options []joinOption, value string) int {
+	for i, o := range options {
+		if o.value == value {
+			return i
+		}
+	}
+	return 0
+}
+
+// View implements bubbletea.Model.
+func (m JoinWizard) View() string {
+	if m.done {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(styleTitle.Render("  Join a wasteland") + "\n\n")
+
+	switch m.step {
+	case stepProvider:
+		b.WriteString("  Where is the wasteland hosted?\n\n")
+		m.renderOptions(&b, joinProviderOptions)
+	case stepUpstream:
+		b.WriteString("  Which wasteland? Paste an invite link or enter org/database.\n\n")
+		b.WriteString("  " + m.input.View() + "\n")
+	case stepFork:
+		fmt.Fprintf(&b, "  Where should your fork of %s live?\n\n", m.plan.Upstream)
+		if m.loading {
+			b.WriteString(styleDim.Render("  Looking up your accounts...") + "\n")
+			break
+		}
+		options := make([]joinOption, 0, len(m.forks)+1)
+		for _, f := range m.forks {
+			options = append(options, joinOption{f.Name, fmt.Sprintf("%-24s %s", f.Name, styleDim.Render(f.Label))})
+		}
+		options = append(options, joinOption{"", "Another account..."})
+		m.renderOptions(&b, options)
+	case stepForkInput:
+		fmt.Fprintf(&b, "  Which account should hold your fork of %s?\n\n", m.plan.Upstream)
+		b.WriteString("  " + m.input.View() + "\n")
+	case stepMode:
+		b.WriteString("  How should your changes reach the wasteland?\n\n")
+		m.renderOptions(&b, joinModeOptions)
+	case stepHandle:
+		b.WriteString("  Pick your rig handle — your name on the wanted board.\n\n")
+		b.WriteString("  " + m.input.View() + "\n")
+	case stepChecks:
+		m.renderChecks(&b)
+	}
+
+	if m.err != "" {
+		b.WriteString("\n  " + styleError.Render(m.err) + "\n")
+	}
+	b.WriteString("\n" + styleDim.Render("  "+m.hints()) + "\n")
+	return b.String()
+}
+
+func (m JoinWizard) renderOptions(b *strings.Builder, options []joinOption) {
+	for i, o := range options {
+		line := "  " + o.label
+		if i == m.cursor {
+			line = renderSelected(line, 0)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+}
+
+func (m JoinWizard) renderChecks(b *strings.Builder) {
+	p := m.plan
+	fmt.Fprintf(b, "  Provider:  %s\n", p.Provider)
+	fmt.Fprintf(b, "  Upstream:  %s\n", p.Upstream)
+	fmt.Fprintf(b, "  Fork:      %s\n", p.ForkOrg)
+	fmt.Fprintf(b, "  Mode:      %s\n", p.Mode)
+	fmt.Fprintf(b, "  Handle:    %s\n\n", p.Handle)
+	if m.loading {
+		b.WriteString(styleDim.Render("  Running checks...") + "\n")
+		return
+	}
+	failed := false
+	for _, c := range m.checks {
+		icon := styleSuccess.Render(style.IconPass)
+		switch c.Status {
+		case "warn":
+			icon = styleWarning.Render(style.IconWarn)
+		case "fail":
+			icon, failed = styleError.Render(style.IconFail), true
+		}
+		fmt.Fprintf(b, "  %s %s: %s\n", icon, c.Name, c.Message)
+		if c.Hint != "" && c.Status != "pass" {
+			b.WriteString(styleDim.Render("      "+c.Hint) + "\n")
+		}
+	}
+	if failed {
+		b.WriteString("\n" + styleConfirm.Render("  Some checks failed; joining will likely fail until they are fixed.") + "\n")
+	}
+}
+
+func (m JoinWizard) hints() string {
+	switch {
+	case m.step == stepChecks:
+		return "enter: join   esc: back   ctrl+c: cancel"
+	case m.isTextStep():
+		return "enter: next   esc: back   ctrl+c: cancel"
+	case m.step == stepProvider:
+		return "j/k: move   enter: next   esc/q: cancel"
+	default:
+		return "j/k: move   enter: next   esc: back   q: cancel"
+	}
+}
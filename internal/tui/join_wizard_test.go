@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+func testJoinWizardConfig() JoinWizardConfig {
+	return JoinWizardConfig{
+		Upstream: "hop/wl-commons",
+		ParseUpstream: func(input string) (string, string, error) {
+			if input == "wl+invite://hop/other?mode=wild-west" {
+				return "hop/other", "wild-west", nil
+			}
+			if !strings.Contains(input, "/") {
+				return "", "", errors.New("upstream must be org/database")
+			}
+			return input, "", nil
+		},
+		ForkChoices: func(provider, _ string) ([]ForkChoice, error) {
+			if provider != JoinGitHub {
+				return nil, nil
+			}
+			return []ForkChoice{{Name: "alice", Label: "your account"}, {Name: "acme", Label: "organization"}}, nil
+		},
+		Preflight: func(plan JoinPlan) []JoinCheck {
+			return []JoinCheck{{Name: "upstream", Status: "fail", Message: plan.Upstream + " not found", Hint: "check the name"}}
+		},
+	}
+}
+
+// send feeds msg to the wizard. When that starts a lookup, the lookup is
+// run and its result fed back too; other commands (cursor blinks, quit)
+// are dropped.
+func send(t *testing.T, m JoinWizard, msg bubbletea.Msg) JoinWizard {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	m = next.(JoinWizard)
+	if m.loading && cmd != nil {
+		next, _ = m.Update(cmd())
+		m = next.(JoinWizard)
+	}
+	return m
+}
+
+// wizardKey returns the key message for a named key, or runes otherwise.
+func wizardKey(k string) bubbletea.Msg {
+	switch k {
+	case "enter":
+		return bubbletea.KeyMsg{Type: bubbletea.KeyEnter}
+	case "esc":
+		return bubbletea.KeyMsg{Type: bubbletea.KeyEsc}
+	case "down":
+		return bubbletea.KeyMsg{Type: bubbletea.KeyDown}
+	case "ctrl+c":
+		return bubbletea.KeyMsg{Type: bubbletea.KeyCtrlC}
+	}
+	return keyMsg(k)
+}
+
+func TestJoinWizard_GitHubFlow(t *testing.T) {
+	m := NewJoinWizard(testJoinWizardConfig())
+	m = send(t, m, wizardKey("down"))
+	m = send(t, m, wizardKey("down")) // GitHub
+	m = send(t, m, wizardKey("enter"))
+	if m.step != stepUpstream || m.input.Value() != "hop/wl-commons" {
+		t.Fatalf("step = %d, input %q; want upstream prefilled", m.step, m.input.Value())
+	}
+	m = send(t, m, wizardKey("enter"))
+	if m.step != stepFork || len(m.forks) != 2 {
+		t.Fatalf("step = %d, forks %+v; want the listed accounts", m.step, m.forks)
+	}
+	if v := m.View(); !strings.Contains(v, "acme") || !strings.Contains(v, "Another account") {
+		t.Errorf("fork view missing choices:\n%s", v)
+	}
+	m = send(t, m, wizardKey("down"))
+	m = send(t, m, wizardKey("enter")) // acme
+	if m.step != stepMode || m.plan.ForkOrg != "acme" {
+		t.Fatalf("step = %d, fork %q; want mode step with acme", m.step, m.plan.ForkOrg)
+	}
+	m = send(t, m, wizardKey("enter")) // pr
+	if m.step != stepHandle || m.input.Value() != "acme" {
+		t.Fatalf("handle should default to the fork account, got %q", m.input.Value())
+	}
+	m = send(t, m, wizardKey("-bot"))
+	m = send(t, m, wizardKey("enter"))
+	if m.step != stepChecks || len(m.checks) != 1 {
+		t.Fatalf("step = %d, checks %+v; want preflight results", m.step, m.checks)
+	}
+	if v := m.View(); !strings.Contains(v, "hop/wl-commons not found") || !strings.Contains(v, "check the name") || !strings.Contains(v, "Some checks failed") {
+		t.Errorf("checks view:\n%s", v)
+	}
+	if _, ok := m.Plan(); ok {
+		t.Error("Plan should not be ready before confirming")
+	}
+	m = send(t, m, wizardKey("enter"))
+	plan, ok := m.Plan()
+	want := JoinPlan{Provider: JoinGitHub, Upstream: "hop/wl-commons", ForkOrg: "acme", Mode: "pr", Handle: "acme-bot"}
+	if !ok || plan != want {
+		t.Errorf("Plan() = %+v, %v; want %+v", plan, ok, want)
+	}
+}
+
+func TestJoinWizard_InviteAndTypedFork(t *testing.T) {
+	m := NewJoinWizard(testJoinWizardConfig())
+	m = send(t, m, wizardKey("enter")) // DoltHub remote
+	m.input.SetValue("nope")
+	m = send(t, m, wizardKey("enter"))
+	if m.step != stepUpstream || !strings.Contains(m.View(), "upstream must be org/database") {
+		t.Fatalf("invalid upstream should stay on the step with an error:\n%s", m.View())
+	}
+	m.input.SetValue("wl+invite://hop/other?mode=wild-west")
+	m = send(t, m, wizardKey("enter"))
+	if m.step != stepForkInput {
+		t.Fatalf("no fork choices should ask for the account, step = %d", m.step)
+	}
+	m = send(t, m, wizardKey("bob"))
+	m = send(t, m, wizardKey("enter"))
+	if m.step != stepMode || m.cursor != 1 {
+		t.Fatalf("invite mode should preselect wild-west, step %d cursor %d", m.step, m.cursor)
+	}
+	m = send(t, m, wizardKey("enter"))
+	m = send(t, m, wizardKey("enter"))
+	m = send(t, m, wizardKey("enter"))
+	plan, ok := m.Plan()
+	want := JoinPlan{Provider: JoinDoltHub, Upstream: "hop/other", ForkOrg: "bob", Mode: "wild-west", Handle: "bob"}
+	if !ok || plan != want {
+		t.Errorf("Plan() = %+v, %v; want %+v", plan, ok, want)
+	}
+}
+
+func TestJoinWizard_BackAndCancel(t *testing.T) {
+	m := NewJoinWizard(testJoinWizardConfig())
+	m = send(t, m, wizardKey("enter"))
+	m = send(t, m, wizardKey("esc"))
+	if m.step != stepProvider {
+		t.Fatalf("esc should return to the provider step, got %d", m.step)
+	}
+	m = send(t, m, wizardKey("esc"))
+	if _, ok := m.Plan(); ok || !m.cancelled {
+		t.Error("esc on the first step should cancel")
+	}
+
+	m = NewJoinWizard(testJoinWizardConfig())
+	m = send(t, m, wizardKey("enter"))
+	m = send(t, m, wizardKey("q")) // typed into the upstream, not a quit
+	if m.cancelled || !strings.HasSuffix(m.input.Value(), "q") {
+		t.Fatalf("q in a text step should be typed, input %q", m.input.Value())
+	}
+	m = send(t, m, wizardKey("ctrl+c"))
+	if _, ok := m.Plan(); ok || !m.cancelled {
+		t.Error("ctrl+c should cancel")
+	}
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpModel is the jump-to-ID prompt: typing or pasting a wanted ID, or
+// an unambiguous prefix of one, opens the item's detail view from any
+// screen, whether or not the browse filters show it.
+type jumpModel struct {
+	open      bool
+	resolving bool
+	err       string
+	input     textinput.Model
+}
+
+func newJumpModel() jumpModel {
+	ti := textinput.New()
+	ti.Prompt = "Jump to: "
+	ti.Placeholder = "wanted ID or prefix..."
+	ti.CharLimit = 256 // room for a pasted URL or branch name
+	return jumpModel{input: ti}
+}
+
+// start opens an empty prompt.
+func (m *jumpModel) start() bubbletea.Cmd {
+	m.open = true
+	m.resolving = false
+	m.err = ""
+	m.input.SetValue("")
+	m.input.Focus()
+	return textinput.Blink
+}
+
+// close hides the prompt.
+func (m *jumpModel) close() {
+	m.open = false
+	m.resolving = false
+	m.err = ""
+	m.input.Blur()
+}
+
+// update handles a key while the prompt is open. Enter resolves the
+// query with the client; esc cancels.
+func (m jumpModel) update(msg bubbletea.KeyMsg, cfg Config) (jumpModel, bubbletea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.close()
+		return m, nil
+	case "enter":
+		query := jumpQuery(m.input.Value())
+		if query == "" {
+			m.close()
+			return m, nil
+		}
+		m.resolving = true
+		m.err = ""
+		return m, resolveJump(cfg, query)
+	}
+	var cmd bubbletea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.err = ""
+	return m, cmd
+}
+
+// view renders the prompt in place of the status bar.
+func (m jumpModel) view(width int) string {
+	line := m.input.View()
+	switch {
+	case m.resolving:
+		line += "  " + styleDim.Render("looking up...")
+	case m.err != "":
+		line += "  " + styleError.Render(m.err)
+	default:
+		line += "  " + styleDim.Render("enter: open  esc: cancel")
+	}
+	return styleBar.Width(width).Render(line)
+}
+
+// jumpQuery extracts the ID from what was typed or pasted: surrounding
+// space and quotes are dropped, and for a pasted branch name or URL only
+// the last path segment is kept.
+func jumpQuery(s string) string {
+	s = strings.Trim(strings.TrimSpace(s), "\"'`")
+	s = strings.TrimRight(s, "/")
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.TrimSpace(s)
+}
+
+func resolveJump(cfg Config, query string) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		id, err := cfg.Client.ResolveID(query)
+		return jumpResultMsg{id: id, err: err}
+	}
+}
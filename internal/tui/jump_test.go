@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+func newJumpTestModel() Model {
+	m := New(Config{RigHandle: "test", Upstream: "test/db"})
+	m.browse.loading = false
+	m.width = 80
+	m.height = 24
+	m.browse.setSize(80, 23)
+	return m
+}
+
+func TestJumpQuery(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"w-abc123", "w-abc123"},
+		{"  w-abc  ", "w-abc"},
+		{`"w-abc"`, "w-abc"},
+		{"wl/alice/w-abc123", "w-abc123"},
+		{"https://example.com/wanted/w-abc123/", "w-abc123"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := jumpQuery(tt.in); got != tt.want {
+			t.Errorf("jumpQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestJump_ColonOpensPrompt(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(keyMsg(":"))
+	m2 := result.(Model)
+	if !m2.jump.open {
+		t.Fatal("after ':': jump prompt should be open")
+	}
+	if !strings.Contains(m2.View(), "Jump to:") {
+		t.Errorf("view should show the jump prompt, got:\n%s", m2.View())
+	}
+
+	// Keys go to the prompt, not the browse list.
+	result, _ = m2.Update(keyMsg("s"))
+	m3 := result.(Model)
+	if m3.browse.statusIdx != 0 {
+		t.Error("'s' in the jump prompt should not cycle the status filter")
+	}
+	if m3.jump.input.Value() != "s" {
+		t.Errorf("prompt value = %q, want %q", m3.jump.input.Value(), "s")
+	}
+
+	result, _ = m3.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if result.(Model).jump.open {
+		t.Error("esc should close the jump prompt")
+	}
+}
+
+func TestJump_GInBrowseStaysFirst(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(keyMsg("g"))
+	if result.(Model).jump.open {
+		t.Error("'g' in browse should not open the jump prompt")
+	}
+
+	m.active = viewMe
+	result, _ = m.Update(keyMsg("g"))
+	if !result.(Model).jump.open {
+		t.Error("'g' outside browse should open the jump prompt")
+	}
+}
+
+func TestJump_NotWhileSearching(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(keyMsg("/"))
+	result, _ = result.(Model).Update(keyMsg(":"))
+	m2 := result.(Model)
+	if m2.jump.open {
+		t.Error("':' while searching should be typed, not open the jump prompt")
+	}
+}
+
+func TestJump_EnterResolves(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(keyMsg(":"))
+	m2 := result.(Model)
+	m2.jump.input.SetValue("w-abc")
+
+	result, cmd := m2.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	m3 := result.(Model)
+	if !m3.jump.resolving {
+		t.Error("enter should start resolving")
+	}
+	if cmd == nil {
+		t.Fatal("enter should return a resolve cmd")
+	}
+}
+
+func TestJump_ResultNavigatesToDetail(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(keyMsg(":"))
+	m2 := result.(Model)
+	m2.jump.resolving = true
+
+	result, cmd := m2.Update(jumpResultMsg{id: "w-abc123"})
+	m3 := result.(Model)
+	if m3.jump.open {
+		t.Error("jump prompt should close on success")
+	}
+	if m3.active != viewDetail {
+		t.Errorf("active = %d, want viewDetail", m3.active)
+	}
+	if cmd == nil {
+		t.Error("expected a detail fetch cmd")
+	}
+}
+
+func TestJump_ResultErrorStaysOpen(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(keyMsg(":"))
+	m2 := result.(Model)
+	m2.jump.resolving = true
+
+	result, _ = m2.Update(jumpResultMsg{err: errors.New(`no wanted item matching "w-zzz"`)})
+	m3 := result.(Model)
+	if !m3.jump.open || m3.jump.resolving {
+		t.Error("jump prompt should stay open and stop resolving on error")
+	}
+	if m3.active != viewBrowse {
+		t.Errorf("active = %d, want viewBrowse", m3.active)
+	}
+	if !strings.Contains(m3.View(), "no wanted item") {
+		t.Errorf("view should show the error, got:\n%s", m3.View())
+	}
+}
+
+func TestJump_ResultAfterCancelIgnored(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(jumpResultMsg{id: "w-abc123"})
+	if result.(Model).active != viewBrowse {
+		t.Error("a result for a cancelled prompt should not navigate")
+	}
+}
@@ -3,31 +3,44 @@ package tui
 import "github.com/charmbracelet/bubbles/key"
 
 type keyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Enter    key.Binding
-	Back     key.Binding
-	Quit     key.Binding
-	Search   key.Binding
-	Status   key.Binding
-	Type     key.Binding
-	Priority key.Binding
-	Project  key.Binding
-	MyItems  key.Binding
-	Sort     key.Binding
-	Me       key.Binding
-	Claim    key.Binding
-	Unclaim  key.Binding
-	Done     key.Binding
-	Accept   key.Binding
-	Reject   key.Binding
-	Close    key.Binding
-	Delete   key.Binding
-	Apply    key.Binding
-	Discard  key.Binding
-	Confirm  key.Binding
-	Cancel   key.Binding
-	Settings key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	Home       key.Binding
+	End        key.Binding
+	Enter      key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+	Search     key.Binding
+	Status     key.Binding
+	Type       key.Binding
+	Priority   key.Binding
+	Project    key.Binding
+	MyItems    key.Binding
+	Sort       key.Binding
+	Group      key.Binding
+	Me         key.Binding
+	Claim      key.Binding
+	Unclaim    key.Binding
+	Undo       key.Binding
+	Done       key.Binding
+	Accept     key.Binding
+	Reject     key.Binding
+	Close      key.Binding
+	Delete     key.Binding
+	Apply      key.Binding
+	Discard    key.Binding
+	ApplyAll   key.Binding
+	DiscardAll key.Binding
+	Confirm    key.Binding
+	Cancel     key.Binding
+	Settings   key.Binding
+	Branches   key.Binding
+	Delta      key.Binding
+	Comment    key.Binding
+	Jump       key.Binding
+	Palette    key.Binding
 }
 
 var keys = keyMap{
@@ -39,6 +52,22 @@ var keys = keyMap{
 		key.WithKeys("down", "j"),
 		key.WithHelp("j/down", "down"),
 	),
+	PageUp: key.NewBinding(
+		key.WithKeys("pgup", "ctrl+b"),
+		key.WithHelp("pgup", "page up"),
+	),
+	PageDown: key.NewBinding(
+		key.WithKeys("pgdown", "ctrl+f"),
+		key.WithHelp("pgdn", "page down"),
+	),
+	Home: key.NewBinding(
+		key.WithKeys("home", "g"),
+		key.WithHelp("g/home", "first"),
+	),
+	End: key.NewBinding(
+		key.WithKeys("end", "G"),
+		key.WithHelp("G/end", "last"),
+	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "open"),
@@ -79,6 +108,10 @@ var keys = keyMap{
 		key.WithKeys("o"),
 		key.WithHelp("o", "sort"),
 	),
+	Group: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "group"),
+	),
 	Me: key.NewBinding(
 		key.WithKeys("m"),
 		key.WithHelp("m", "me"),
@@ -91,6 +124,10 @@ var keys = keyMap{
 		key.WithKeys("u"),
 		key.WithHelp("u", "unclaim"),
 	),
+	Undo: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "undo"),
+	),
 	Done: key.NewBinding(
 		key.WithKeys("d"),
 		key.WithHelp("d", "done"),
@@ -119,6 +156,14 @@ var keys = keyMap{
 		key.WithKeys("b"),
 		key.WithHelp("b", "discard"),
 	),
+	ApplyAll: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "apply all"),
+	),
+	DiscardAll: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "discard all"),
+	),
 	Confirm: key.NewBinding(
 		key.WithKeys("y"),
 		key.WithHelp("y", "confirm"),
@@ -131,4 +176,25 @@ var keys = keyMap{
 		key.WithKeys("S"),
 		key.WithHelp("S", "settings"),
 	),
+	Branches: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "branches"),
+	),
+	Delta: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "delta"),
+	),
+	Comment: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "comment"),
+	),
+	// Jump opens from every view; in the browse list "g" stays "first".
+	Jump: key.NewBinding(
+		key.WithKeys(":", "g"),
+		key.WithHelp(":", "jump to ID"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "commands"),
+	),
 }
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// minMarkdownWidth is the narrowest viewport markdown is formatted for.
+// Below it, hanging indents and rules would leave a word or two per line,
+// so descriptions are shown as written, only wrapped.
+const minMarkdownWidth = 40
+
+var (
+	mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdBulletRe  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrderedRe = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdQuoteRe   = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdRuleRe    = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdFenceRe   = regexp.MustCompile("^\\s*(```|~~~)")
+
+	mdLinkRe   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRe   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalicRe = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+)
+
+// renderMarkdown renders a description for the detail view: headings,
+// lists, block quotes, rules, fenced code, inline code, emphasis and
+// links are styled, and prose is wrapped to width with every line
+// prefixed by indent. Code blocks keep their lines as written. Narrower
+// than minMarkdownWidth, the text is only wrapped.
+func renderMarkdown(src, indent string, width int) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	avail := width - len(indent)
+	if width > 0 && avail < minMarkdownWidth {
+		return renderPlain(src, indent, max(avail, 10))
+	}
+	if width <= 0 {
+		avail = 0 // size not known yet: don't wrap
+	}
+
+	var (
+		b      strings.Builder
+		para   []string
+		inCode bool
+		blank  bool
+	)
+	emit := func(prefix, rest, text string) {
+		for _, line := range wrapLines(text, avail-ansi.StringWidth(prefix)) {
+			b.WriteString(indent + prefix + line + "\n")
+			prefix = rest
+		}
+		blank = false
+	}
+	flush := func() {
+		if len(para) > 0 {
+			emit("", "", renderInline(strings.Join(para, " ")))
+			para = nil
+		}
+	}
+	gap := func() {
+		if !blank && b.Len() > 0 {
+			b.WriteString("\n")
+			blank = true
+		}
+	}
+
+	for _, line := range strings.Split(src, "\n") {
+		if mdFenceRe.MatchString(line) {
+			flush()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			b.WriteString(indent + styleDim.Render("│ ") + styleMDCode.Render(line) + "\n")
+			blank = false
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+			gap()
+			continue
+		}
+
+		switch {
+		case mdRuleRe.MatchString(line):
+			flush()
+			b.WriteString(indent + styleDim.Render(strings.Repeat("─", min(max(avail, 3), 40))) + "\n")
+			blank = false
+		case mdHeadingRe.MatchString(line):
+			flush()
+			sm := mdHeadingRe.FindStringSubmatch(line)
+			emit("", "", styleMDHeading.Render(sm[2]))
+		case mdBulletRe.MatchString(line):
+			flush()
+			sm := mdBulletRe.FindStringSubmatch(line)
+			pad := strings.Repeat("  ", len(sm[1])/2)
+			emit(pad+"• ", pad+"  ", renderInline(sm[2]))
+		case mdOrderedRe.MatchString(line):
+			flush()
+			sm := mdOrderedRe.FindStringSubmatch(line)
+			pad := strings.Repeat("  ", len(sm[1])/2)
+			marker := sm[2] + ". "
+			emit(pad+marker, pad+strings.Repeat(" ", len(marker)), renderInline(sm[3]))
+		case mdQuoteRe.MatchString(line):
+			flush()
+			sm := mdQuoteRe.FindStringSubmatch(line)
+			bar := styleDim.Render("│ ")
+			emit(bar, bar, styleMDQuote.Render(sm[1]))
+		default:
+			para = append(para, strings.TrimSpace(line))
+		}
+	}
+	flush()
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// renderInline styles inline code, links, bold and italic text. Code
+// spans are left alone, so markup inside backticks shows as written.
+func renderInline(s string) string {
+	parts := strings.Split(s, "`")
+	if len(parts)%2 == 0 {
+		// An unmatched backtick is literal.
+		parts[len(parts)-2] += "`" + parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+	for i, p := range parts {
+		if i%2 == 1 {
+			parts[i] = styleMDCode.Render(p)
+			continue
+		}
+		p = mdLinkRe.ReplaceAllStringFunc(p, func(m string) string {
+			sm := mdLinkRe.FindStringSubmatch(m)
+			if sm[1] == sm[2] {
+				return styleMDLink.Render(sm[2])
+			}
+			return styleMDLink.Render(sm[1]) + " " + styleDim.Render("("+sm[2]+")")
+		})
+		p = mdBoldRe.ReplaceAllStringFunc(p, func(m string) string {
+			return styleTitle.Render(m[2 : len(m)-2])
+		})
+		p = mdItalicRe.ReplaceAllStringFunc(p, func(m string) string {
+			return styleMDItalic.Render(m[1 : len(m)-1])
+		})
+		parts[i] = p
+	}
+	return strings.Join(parts, "")
+}
+
+// renderPlain wraps src's lines to width, prefixing each with indent.
+func renderPlain(src, indent string, width int) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(src, "\n"), "\n") {
+		for _, w := range wrapLines(line, width) {
+			b.WriteString(indent + w + "\n")
+		}
+	}
+	return b.String()
+}
+
+// wrapLines word-wraps s to width columns, breaking words longer than a
+// line. A width under one leaves s whole.
+func wrapLines(s string, width int) []string {
+	if width < 1 {
+		return []string{s}
+	}
+	return strings.Split(ansi.Wrap(s, width, ""), "\n")
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	src := "# Plan\n\nFix the **claim** race in `sdk.Claim`,\nsee [the issue](https://example.com/42).\n\n" +
+		"- first step\n  - nested step\n1. numbered\n> quoted note\n\n---\n```go\nfunc f() {}\n```\n"
+	got := renderMarkdown(src, "    ", 80)
+
+	for _, want := range []string{
+		"    Plan\n",
+		"    Fix the claim race in sdk.Claim, see the issue (https://example.com/42).\n",
+		"    • first step\n",
+		"      • nested step\n",
+		"    1. numbered\n",
+		"    │ quoted note\n",
+		"    ─",
+		"    │ func f() {}\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	for _, raw := range []string{"#", "**", "`", "](", "```"} {
+		if strings.Contains(got, raw) {
+			t.Errorf("markup %q left in:\n%s", raw, got)
+		}
+	}
+}
+
+func TestRenderMarkdown_WrapsWithHangingIndent(t *testing.T) {
+	src := "- " + strings.Repeat("word ", 20)
+	got := renderMarkdown(src, "  ", 50)
+	lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected the item to wrap:\n%s", got)
+	}
+	for i, line := range lines {
+		if len(line) > 50 {
+			t.Errorf("line %d is %d wide: %q", i, len(line), line)
+		}
+		if i > 0 && !strings.HasPrefix(line, "    word") {
+			t.Errorf("continuation line %d not hung under the bullet: %q", i, line)
+		}
+	}
+}
+
+func TestRenderMarkdown_NarrowFallsBackToPlain(t *testing.T) {
+	src := "# Title\n- **bold** item that goes on for a while"
+	got := renderMarkdown(src, "  ", 30)
+	if !strings.HasPrefix(got, "  # Title\n  - **bold**") {
+		t.Errorf("narrow render should keep the text as written:\n%s", got)
+	}
+	for _, line := range strings.Split(strings.TrimRight(got, "\n"), "\n") {
+		if len(line) > 30 {
+			t.Errorf("line %q wider than 30", line)
+		}
+	}
+}
+
+func TestRenderInline_CodeSpansKeepMarkup(t *testing.T) {
+	if got := renderInline("run `a*b*c` and *go*"); got != "run a*b*c and go" {
+		t.Errorf("renderInline = %q", got)
+	}
+	if got := renderInline("a stray ` backtick"); got != "a stray ` backtick" {
+		t.Errorf("renderInline(unmatched) = %q", got)
+	}
+}
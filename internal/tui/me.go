@@ -43,7 +43,7 @@ func (m meModel) totalItems() int {
 	if m.data == nil {
 		return 0
 	}
-	return len(m.data.Claimed) + len(m.data.InReview) + len(m.data.Completed)
+	return len(m.data.Claimed) + len(m.data.UpNext) + len(m.data.InReview) + len(m.data.Completed)
 }
 
 // selectedItem returns the item at the current cursor position.
@@ -56,6 +56,10 @@ func (m meModel) selectedItem() *commons.WantedSummary {
 		return &m.data.Claimed[idx]
 	}
 	idx -= len(m.data.Claimed)
+	if idx < len(m.data.UpNext) {
+		return &m.data.UpNext[idx]
+	}
+	idx -= len(m.data.UpNext)
 	if idx < len(m.data.InReview) {
 		return &m.data.InReview[idx]
 	}
@@ -136,6 +140,16 @@ func (m meModel) view() string {
 		b.WriteByte('\n')
 	}
 
+	if len(m.data.UpNext) > 0 {
+		b.WriteString(styleFilterBar.Render("  Up Next (first in claim queue)"))
+		b.WriteByte('\n')
+		for _, item := range m.data.UpNext {
+			b.WriteString(m.renderRow(item, flatIdx))
+			flatIdx++
+		}
+		b.WriteByte('\n')
+	}
+
 	if len(m.data.InReview) > 0 {
 		b.WriteString(styleFilterBar.Render("  Awaiting My Review"))
 		b.WriteByte('\n')
@@ -161,6 +175,45 @@ func (m meModel) view() string {
 		b.WriteByte('\n')
 	}
 
+	if rep := m.data.Reputation; rep != nil {
+		b.WriteByte('\n')
+		b.WriteString(m.renderReputation(rep))
+	}
+
+	return b.String()
+}
+
+// renderReputation summarizes the stamps and badges the rig has received.
+func (m meModel) renderReputation(rep *commons.Reputation) string {
+	var b strings.Builder
+	b.WriteString(styleFilterBar.Render("  My Reputation"))
+	b.WriteByte('\n')
+	if rep.StampCount == 0 && len(rep.Badges) == 0 {
+		b.WriteString(styleDim.Render("  No stamps yet — complete and get validated to earn them."))
+		b.WriteByte('\n')
+		return b.String()
+	}
+	fmt.Fprintf(&b, "  %d stamp(s)   quality %.1f   reliability %.1f\n",
+		rep.StampCount, rep.AvgQuality, rep.AvgReliability)
+	if len(rep.Badges) > 0 {
+		badges := make([]string, len(rep.Badges))
+		for i, badge := range rep.Badges {
+			badges[i] = badge.BadgeType
+		}
+		fmt.Fprintf(&b, "  Badges: %s\n", strings.Join(badges, ", "))
+	}
+	for _, s := range rep.RecentStamps {
+		date := s.CreatedAt
+		if len(date) > 10 {
+			date = date[:10]
+		}
+		line := fmt.Sprintf("  %-10s %-12s q%.0f r%.0f %s", date, s.Author, s.Quality, s.Reliability, s.Message)
+		if runes := []rune(line); m.width > 0 && len(runes) > m.width {
+			line = string(runes[:max(m.width-3, 0)]) + "..."
+		}
+		b.WriteString(styleDim.Render(line))
+		b.WriteByte('\n')
+	}
 	return b.String()
 }
 
@@ -176,7 +229,7 @@ func (m meModel) renderRow(item commons.WantedSummary, flatIdx int) string {
 		item.ID, title, status, pri, item.Project)
 
 	if flatIdx == m.cursor {
-		line = styleSelected.Width(m.width).Render(line)
+		line = renderSelected(line, m.width)
 	}
 	return line + "\n"
 }
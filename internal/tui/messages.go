@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/sdk"
 )
@@ -13,6 +15,8 @@ const (
 	viewDetail
 	viewMe
 	viewSettings
+	viewBranches
+	viewDelta
 )
 
 // navigateMsg requests a view switch.
@@ -21,6 +25,12 @@ type navigateMsg struct {
 	wantedID string // non-empty when navigating to detail
 }
 
+// jumpResultMsg carries the item a jump-to-ID query resolved to.
+type jumpResultMsg struct {
+	id  string
+	err error
+}
+
 // browseDataMsg carries browse query results.
 type browseDataMsg struct {
 	items      []commons.WantedSummary
@@ -28,6 +38,17 @@ type browseDataMsg struct {
 	err        error
 }
 
+// browseRefetchMsg fires when a debounced browse refetch is due. It is
+// ignored unless seq still matches the latest filter change.
+type browseRefetchMsg struct {
+	seq int
+}
+
+// vocabularyMsg carries the wasteland's item type filter cycle.
+type vocabularyMsg struct {
+	types []string
+}
+
 // detailDataMsg carries detail query results.
 type detailDataMsg struct {
 	item          *commons.WantedItem
@@ -38,6 +59,13 @@ type detailDataMsg struct {
 	mainStatus    string   // status on main when detail was read from a branch
 	prURL         string   // non-empty when an upstream PR already exists for this branch
 	branchActions []string // SDK-computed branch operations: "submit_pr", "apply", "discard"
+	comments      []commons.ReviewComment
+	quorum        int                  // accepts needed to complete; <= 1 means no quorum
+	approvals     []commons.Approval   // accepts recorded toward quorum
+	followUps     []commons.WantedItem // items posted by partial accepts of this item
+	evidence      []commons.Evidence   // typed evidence entries of the completion
+	claimExpiry   time.Duration        // how long a claim may stay idle; 0 = never expires
+	queue         []commons.QueueEntry // rigs waiting for the claim, first in line first
 }
 
 // meDataMsg carries dashboard query results.
@@ -46,6 +74,58 @@ type meDataMsg struct {
 	err  error
 }
 
+// reconcileMsg carries the branches removed by startup reconciliation.
+type reconcileMsg struct {
+	branches []sdk.ReconciledBranch
+	err      error
+}
+
+// notifyMsg carries the result of a Config.CheckNotify run.
+type notifyMsg struct {
+	changes []string
+	err     error
+}
+
+// notifyTickMsg triggers the next Config.CheckNotify run.
+type notifyTickMsg struct{}
+
+// deltaDataMsg carries the items whose branch state differs from main.
+type deltaDataMsg struct {
+	deltas []sdk.ItemDelta
+	err    error
+}
+
+// branchesDataMsg carries the branch manager's branch list.
+type branchesDataMsg struct {
+	branches []sdk.BranchInfo
+	err      error
+}
+
+// branchDiscardMsg is sent when the user confirms discarding a branch in
+// the branch manager.
+type branchDiscardMsg struct {
+	branch string
+}
+
+// branchDiscardResultMsg carries the result of a branch manager discard.
+type branchDiscardResultMsg struct {
+	branch string
+	err    error
+}
+
+// branchBulkMsg is sent when the user confirms a bulk action ("apply" or
+// "discard") in the branch manager.
+type branchBulkMsg struct {
+	action string
+}
+
+// branchBulkResultMsg carries the per-branch results of a bulk action.
+type branchBulkResultMsg struct {
+	action  string
+	results []sdk.BranchResult
+	err     error
+}
+
 // errMsg carries an error to display.
 type errMsg struct {
 	err error
@@ -66,6 +146,17 @@ type actionConfirmedMsg struct {
 type actionResultMsg struct {
 	err    error
 	result *sdk.MutationResult // non-nil on success
+	undo   *undoAction         // non-nil when the mutation can be undone
+}
+
+// undoRequestMsg is sent when the user presses u while an undo is available.
+type undoRequestMsg struct {
+	undo undoAction
+}
+
+// undoExpiredMsg fires when the undo window for a mutation closes.
+type undoExpiredMsg struct {
+	at time.Time // identifies which undo the timer was started for
 }
 
 // branchDeltaAction identifies a delta resolution action.
@@ -98,6 +189,17 @@ type doneSubmitMsg struct {
 	evidence string
 }
 
+// commentSubmitMsg is sent when the user submits the review comment form.
+type commentSubmitMsg struct {
+	body string
+}
+
+// commentResultMsg carries the result of storing a review comment.
+type commentResultMsg struct {
+	comment *commons.ReviewComment
+	err     error
+}
+
 // acceptSubmitMsg is sent when the user submits the accept form.
 type acceptSubmitMsg struct {
 	quality     int
@@ -105,6 +207,9 @@ type acceptSubmitMsg struct {
 	severity    string
 	skills      []string
 	message     string
+	// followUpTitle, when set, accepts as partial and posts a follow-up.
+	followUpTitle string
+	followUpDesc  string
 }
 
 // submitDiffMsg carries the async-loaded diff for the submit PR view.
@@ -113,15 +218,32 @@ type submitDiffMsg struct {
 	err  error
 }
 
+// submitSquashMsg carries the squash preview for the submit PR view.
+type submitSquashMsg struct {
+	preview *sdk.SquashPreview
+	err     error
+}
+
 // submitResultMsg carries the result of creating an upstream PR.
 type submitResultMsg struct {
 	prURL string
 	err   error
 }
 
+// pushProgressMsg carries one line of wild-west push output.
+type pushProgressMsg struct {
+	line string
+}
+
+// retryPushMsg asks to push the local commit a failed push left behind.
+type retryPushMsg struct {
+	failed *sdk.PushError
+}
+
 // settingsSavedMsg carries the result of saving settings.
 type settingsSavedMsg struct {
-	mode    string
-	signing bool
-	err     error
+	mode        string
+	signing     bool
+	confirmPush bool
+	err         error
 }
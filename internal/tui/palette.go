@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/gastownhall/wasteland/internal/commons"
+)
+
+// paletteCommand is one entry in the command palette. Choosing it sends
+// msg through the root model, so a command does exactly what its key (or
+// the navigation it stands for) does.
+type paletteCommand struct {
+	name string
+	key  string // the view's own key for it, shown as a hint; may be empty
+	msg  bubbletea.Msg
+}
+
+// paletteModel is the ctrl+p command palette: the actions available in
+// the active view plus navigation to every view, fuzzy-filtered as the
+// user types.
+type paletteModel struct {
+	open     bool
+	input    textinput.Model
+	commands []paletteCommand
+	matches  []paletteCommand
+	cursor   int
+}
+
+func newPaletteModel() paletteModel {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "type to filter commands..."
+	ti.CharLimit = 64
+	return paletteModel{input: ti}
+}
+
+// start opens the palette over commands.
+func (m *paletteModel) start(commands []paletteCommand) bubbletea.Cmd {
+	m.open = true
+	m.commands = commands
+	m.input.SetValue("")
+	m.input.Focus()
+	m.filter()
+	return textinput.Blink
+}
+
+// close hides the palette.
+func (m *paletteModel) close() {
+	m.open = false
+	m.commands = nil
+	m.matches = nil
+	m.input.Blur()
+}
+
+// filter keeps the commands that fuzzily match the query, best first.
+func (m *paletteModel) filter() {
+	query := strings.TrimSpace(m.input.Value())
+	type scored struct {
+		cmd   paletteCommand
+		score int
+	}
+	var hits []scored
+	for _, c := range m.commands {
+		if score, ok := fuzzyScore(query, c.name); ok {
+			hits = append(hits, scored{c, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	m.matches = make([]paletteCommand, len(hits))
+	for i, h := range hits {
+		m.matches[i] = h.cmd
+	}
+	m.cursor = 0
+}
+
+// update handles a key while the palette is open. Enter closes it and
+// runs the selected command; esc closes it.
+func (m paletteModel) update(msg bubbletea.KeyMsg) (paletteModel, bubbletea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.close()
+		return m, nil
+	case "enter":
+		if m.cursor >= len(m.matches) {
+			return m, nil
+		}
+		chosen := m.matches[m.cursor].msg
+		m.close()
+		return m, func() bubbletea.Msg { return chosen }
+	case "up", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+	before := m.input.Value()
+	var cmd bubbletea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != before {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// view renders the palette in place of the active view's content.
+func (m paletteModel) view(width, height int) string {
+	var b strings.Builder
+	b.WriteString(styleTitle.Render("Commands"))
+	b.WriteString("\n\n  ")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(styleDim.Render("  no matching commands"))
+		b.WriteByte('\n')
+		return b.String()
+	}
+
+	visible := max(height-5, 1) // title, input and spacing
+	start := max(m.cursor-visible+1, 0)
+	end := min(start+visible, len(m.matches))
+	for i := start; i < end; i++ {
+		c := m.matches[i]
+		line := "  " + c.name
+		if c.key != "" {
+			line += "  " + styleDim.Render(c.key)
+		}
+		if i == m.cursor {
+			line = renderSelected(line, width)
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// fuzzyScore reports whether the runes of query appear in s in order,
+// ignoring case, and scores the match: a rune that follows the previous
+// match, or starts a word, counts for more than one found mid-word.
+func fuzzyScore(query, s string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	r := []rune(strings.ToLower(s))
+	score, qi, prev := 0, 0, -2
+	for i := 0; i < len(r) && qi < len(q); i++ {
+		if r[i] != q[qi] {
+			continue
+		}
+		switch {
+		case i == prev+1:
+			score += 3
+		case i == 0 || !unicode.IsLetter(r[i-1]) && !unicode.IsDigit(r[i-1]):
+			score += 2
+		default:
+			score++
+		}
+		prev = i
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// canOpenPalette reports whether msg opens the command palette.
+func (m Model) canOpenPalette(msg bubbletea.KeyMsg) bool {
+	return key.Matches(msg, keys.Palette) && !m.busy()
+}
+
+// paletteCommands lists what the palette offers from the active view: its
+// own actions first, then navigation, jump and quit. Each command's key
+// comes from its binding in keys, so the palette can't drift from what
+// the views match.
+func (m Model) paletteCommands() []paletteCommand {
+	var cmds []paletteCommand
+	add := func(name string, b key.Binding, msg bubbletea.Msg) {
+		cmds = append(cmds, paletteCommand{name: name, key: bindingKey(b), msg: msg})
+	}
+	press := func(name string, b key.Binding) {
+		k := bindingKey(b)
+		add(name, b, bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune(k)})
+	}
+
+	switch m.active {
+	case viewBrowse:
+		press("Search", keys.Search)
+		press("Filter: cycle status", keys.Status)
+		press("Filter: cycle type", keys.Type)
+		press("Filter: cycle priority", keys.Priority)
+		press("Filter: project", keys.Project)
+		press("Filter: toggle mine only", keys.MyItems)
+		press("Sort: cycle order", keys.Sort)
+		press("Group: cycle grouping", keys.Group)
+		press("List: first item", keys.Home)
+		press("List: last item", keys.End)
+	case viewDetail:
+		d := m.detail
+		if d.item == nil {
+			break
+		}
+		if d.canUndo(time.Now()) {
+			press("Item: undo last action", keys.Undo)
+		}
+		for _, t := range commons.AvailableTransitions(d.item, d.rigHandle) {
+			press("Item: "+commons.TransitionName(t), transitionKeys[t])
+		}
+		for _, action := range d.branchActions {
+			switch action {
+			case "submit_pr":
+				press("Branch: submit PR", keys.Apply)
+			case "apply":
+				press(fmt.Sprintf("Branch: apply %s", commons.DeltaLabel(d.mainStatus, d.item.Status)), keys.Apply)
+			case "discard":
+				press("Branch: discard", keys.Discard)
+			}
+		}
+		if d.commentsEnabled {
+			press("Item: comment", keys.Comment)
+		}
+	case viewBranches:
+		if len(m.branches.branches) > 0 {
+			press("Branches: discard selected", keys.Discard)
+			press("Branches: apply all", keys.ApplyAll)
+			press("Branches: discard all", keys.DiscardAll)
+		}
+	}
+
+	// Navigation, with the active view's key for it where it has one.
+	navKeys := map[activeView]key.Binding{viewBrowse: keys.Back}
+	switch m.active {
+	case viewBrowse:
+		navKeys = map[activeView]key.Binding{viewMe: keys.Me, viewBranches: keys.Branches, viewDelta: keys.Delta, viewSettings: keys.Settings}
+	case viewMe:
+		navKeys[viewSettings] = keys.Settings
+	}
+	for _, nav := range []struct {
+		name string
+		view activeView
+	}{
+		{"Go to: browse", viewBrowse},
+		{"Go to: dashboard", viewMe},
+		{"Go to: branches", viewBranches},
+		{"Go to: delta", viewDelta},
+		{"Go to: settings", viewSettings},
+	} {
+		if nav.view != m.active {
+			add(nav.name, navKeys[nav.view], navigateMsg{view: nav.view})
+		}
+	}
+	press("Jump to item by ID", keys.Jump)
+	add("Quit", keys.Quit, bubbletea.KeyMsg{Type: bubbletea.KeyCtrlC})
+	return cmds
+}
+
+// bindingKey returns the key shown and pressed for b: its first
+// single-character key, so "home"/"g" is "g", else its first key. It is
+// "" for an unset binding.
+func bindingKey(b key.Binding) string {
+	ks := b.Keys()
+	for _, k := range ks {
+		if utf8.RuneCountInString(k) == 1 {
+			return k
+		}
+	}
+	if len(ks) == 0 {
+		return ""
+	}
+	return ks[0]
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+var ctrlP = bubbletea.KeyMsg{Type: bubbletea.KeyCtrlP}
+
+// typeInto sends s to m one rune at a time.
+func typeInto(m Model, s string) Model {
+	for _, r := range s {
+		result, _ := m.Update(keyMsg(string(r)))
+		m = result.(Model)
+	}
+	return m
+}
+
+// paletteNames returns the names of the palette's current matches.
+func paletteNames(m Model) []string {
+	names := make([]string, len(m.palette.matches))
+	for i, c := range m.palette.matches {
+		names[i] = c.name
+	}
+	return names
+}
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("gtset", "Go to: settings"); !ok {
+		t.Error(`"gtset" should match "Go to: settings"`)
+	}
+	if _, ok := fuzzyScore("xyz", "Go to: settings"); ok {
+		t.Error(`"xyz" should not match "Go to: settings"`)
+	}
+	if _, ok := fuzzyScore("", "anything"); !ok {
+		t.Error("an empty query should match everything")
+	}
+	prefix, _ := fuzzyScore("set", "Go to: settings")
+	scattered, _ := fuzzyScore("set", "Filter: cycle status")
+	if prefix <= scattered {
+		t.Errorf("contiguous word match scored %d, scattered %d; want contiguous higher", prefix, scattered)
+	}
+}
+
+func TestPalette_CtrlPOpensWithViewCommands(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(ctrlP)
+	m2 := result.(Model)
+	if !m2.palette.open {
+		t.Fatal("ctrl+p should open the command palette")
+	}
+	names := strings.Join(paletteNames(m2), "\n")
+	for _, want := range []string{"Filter: cycle status", "Go to: settings", "Jump to item by ID", "Quit"} {
+		if !strings.Contains(names, want) {
+			t.Errorf("palette should offer %q, got:\n%s", want, names)
+		}
+	}
+	if strings.Contains(names, "Go to: browse") {
+		t.Error("palette should not offer navigating to the active view")
+	}
+	if !strings.Contains(m2.View(), "Commands") {
+		t.Errorf("view should show the palette, got:\n%s", m2.View())
+	}
+}
+
+func TestPalette_FilterAndRun(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(ctrlP)
+	m2 := typeInto(result.(Model), "cystat")
+
+	names := paletteNames(m2)
+	if len(names) == 0 || names[0] != "Filter: cycle status" {
+		t.Fatalf("matches = %v, want Filter: cycle status first", names)
+	}
+	if m2.browse.statusIdx != 0 {
+		t.Error("typing in the palette should not reach the browse list")
+	}
+
+	result, cmd := m2.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	m3 := result.(Model)
+	if m3.palette.open {
+		t.Error("enter should close the palette")
+	}
+	if cmd == nil {
+		t.Fatal("enter should return the command's cmd")
+	}
+	result, _ = m3.Update(cmd())
+	if got := result.(Model).browse.statusIdx; got != 1 {
+		t.Errorf("after running the command: statusIdx = %d, want 1", got)
+	}
+}
+
+func TestPalette_NavigateFromDetail(t *testing.T) {
+	m := newDetailForTest("open", "someone", "", "wild-west")
+	result, _ := m.Update(ctrlP)
+	m2 := result.(Model)
+	names := strings.Join(paletteNames(m2), "\n")
+	if !strings.Contains(names, "Item: claim") {
+		t.Errorf("palette should offer the item's actions, got:\n%s", names)
+	}
+
+	m2 = typeInto(m2, "branches")
+	result, cmd := m2.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should return the command's cmd")
+	}
+	nav, ok := cmd().(navigateMsg)
+	if !ok || nav.view != viewBranches {
+		t.Fatalf("command msg = %#v, want navigateMsg to branches", cmd())
+	}
+	result, _ = result.(Model).Update(nav)
+	if result.(Model).active != viewBranches {
+		t.Error("running the command should open the branches view")
+	}
+}
+
+func TestPalette_SelectionMoves(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(ctrlP)
+	result, _ = result.(Model).Update(bubbletea.KeyMsg{Type: bubbletea.KeyDown})
+	m2 := result.(Model)
+	if m2.palette.cursor != 1 {
+		t.Errorf("after down: cursor = %d, want 1", m2.palette.cursor)
+	}
+	result, _ = m2.Update(ctrlP)
+	if got := result.(Model).palette.cursor; got != 0 {
+		t.Errorf("after ctrl+p: cursor = %d, want 0", got)
+	}
+}
+
+func TestPalette_EscCloses(t *testing.T) {
+	m := newJumpTestModel()
+	result, _ := m.Update(ctrlP)
+	result, cmd := result.(Model).Update(bubbletea.KeyMsg{Type: bubbletea.KeyEsc})
+	if result.(Model).palette.open {
+		t.Error("esc should close the palette")
+	}
+	if cmd != nil {
+		t.Error("esc should not run a command")
+	}
+}
+
+func TestPalette_NotWhileConfirming(t *testing.T) {
+	m := newDetailForTest("open", "someone", "", "wild-west")
+	_, cmd := m.Update(keyMsg("c"))
+	result, _ := m.Update(cmd())
+	m2 := result.(Model)
+	if m2.detail.confirming == nil {
+		t.Fatal("setup: claim should ask for confirmation")
+	}
+	result, _ = m2.Update(ctrlP)
+	if result.(Model).palette.open {
+		t.Error("ctrl+p should not open the palette over a confirmation prompt")
+	}
+}
+
+func TestBindingKey(t *testing.T) {
+	for _, tt := range []struct {
+		b    key.Binding
+		want string
+	}{
+		{keys.Home, "g"},
+		{keys.Jump, ":"},
+		{keys.Back, "esc"},
+		{key.Binding{}, ""},
+	} {
+		if got := bindingKey(tt.b); got != tt.want {
+			t.Errorf("bindingKey(%v) = %q, want %q", tt.b.Keys(), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+)
+
+// NewPushProgress returns a writer for sdk.ClientConfig.PushOutput and the
+// channel to pass as Config.PushProgress, which turns each line the push
+// writes into a status update under the spinner.
+func NewPushProgress() (io.Writer, <-chan string) {
+	ch := make(chan string, 16)
+	return &progressWriter{ch: ch}, ch
+}
+
+// progressWriter splits push output into lines. Lines the TUI hasn't
+// picked up yet are dropped rather than blocking the push.
+type progressWriter struct {
+	mu   sync.Mutex
+	ch   chan<- string
+	part string
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	lines := strings.Split(w.part+string(p), "\n")
+	w.part = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		select {
+		case w.ch <- line:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// waitForProgress delivers the next push progress line as a
+// pushProgressMsg.
+func waitForProgress(ch <-chan string) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		line, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return pushProgressMsg{line: line}
+	}
+}
@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPushProgress_SplitsLines(t *testing.T) {
+	w, ch := NewPushProgress()
+	fmt.Fprint(w, "  Syncing with upstream...\n  Pushed to ")
+	fmt.Fprint(w, "upstream\n\n")
+
+	for _, want := range []string{"Syncing with upstream...", "Pushed to upstream"} {
+		if got := <-ch; got != want {
+			t.Errorf("line = %q, want %q", got, want)
+		}
+	}
+	select {
+	case extra := <-ch:
+		t.Errorf("unexpected line %q", extra)
+	default:
+	}
+}
+
+func TestPushProgress_DropsLinesWhenFull(t *testing.T) {
+	w, ch := NewPushProgress()
+	for i := range 100 {
+		fmt.Fprintf(w, "line %d\n", i)
+	}
+	if n := len(ch); n != cap(ch) {
+		t.Errorf("buffered lines = %d, want the channel full at %d", n, cap(ch))
+	}
+}
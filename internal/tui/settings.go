@@ -10,12 +10,13 @@ import (
 
 // settingsModel holds the state for the Settings view.
 type settingsModel struct {
-	cursor  int // 0=mode, 1=signing
-	mode    string
-	signing bool
-	width   int
-	height  int
-	result  string // "Saved" or error text
+	cursor      int // 0=mode, 1=signing, 2=confirm push
+	mode        string
+	signing     bool
+	confirmPush bool
+	width       int
+	height      int
+	result      string // "Saved" or error text
 }
 
 func newSettingsModel(mode string, signing bool) settingsModel {
@@ -54,7 +55,7 @@ func (m settingsModel) update(msg bubbletea.Msg, cfg Config) (settingsModel, bub
 			}
 
 		case key.Matches(msg, keys.Down):
-			if m.cursor < 1 {
+			if m.cursor < 2 {
 				m.cursor++
 			}
 
@@ -79,17 +80,29 @@ func (m settingsModel) toggle(cfg Config) (settingsModel, bubbletea.Cmd) {
 		}
 	case 1: // signing
 		m.signing = !m.signing
+	case 2: // confirm push
+		m.confirmPush = !m.confirmPush
 	}
 
 	mode := m.mode
 	signing := m.signing
+	confirmPush := m.confirmPush
+	if m.cursor == 2 {
+		return m, func() bubbletea.Msg {
+			msg := settingsSavedMsg{mode: mode, signing: signing, confirmPush: confirmPush}
+			if cfg.SaveConfirmPush != nil {
+				msg.err = cfg.SaveConfirmPush(confirmPush)
+			}
+			return msg
+		}
+	}
 	return m, func() bubbletea.Msg {
 		if cfg.Client != nil {
 			if err := cfg.Client.SaveSettings(mode, signing); err != nil {
-				return settingsSavedMsg{mode: mode, signing: signing, err: err}
+				return settingsSavedMsg{mode: mode, signing: signing, confirmPush: confirmPush, err: err}
 			}
 		}
-		return settingsSavedMsg{mode: mode, signing: signing}
+		return settingsSavedMsg{mode: mode, signing: signing, confirmPush: confirmPush}
 	}
 }
 
@@ -121,6 +134,14 @@ func (m settingsModel) view(cfg Config) string {
 	b.WriteString(signingLine)
 	b.WriteByte('\n')
 
+	// Confirm-before-push toggle; only wild-west mode pushes upstream.
+	confirmLine := m.renderToggle("Confirm push", fmt.Sprintf("%t", m.confirmPush), "true", "false", m.cursor == 2)
+	b.WriteString(confirmLine)
+	if m.mode != "wild-west" {
+		b.WriteString(styleDim.Render("  (wild-west only)"))
+	}
+	b.WriteByte('\n')
+
 	// Result feedback.
 	if m.result != "" {
 		b.WriteString("\n  " + m.result)
@@ -141,9 +162,9 @@ func (m settingsModel) renderToggle(label, current, optA, optB string, active bo
 		b = fmt.Sprintf(" [%s]", optB)
 	}
 
-	line := fmt.Sprintf("  %-11s %s %s", label+":", a, b)
+	line := fmt.Sprintf("  %-13s %s %s", label+":", a, b)
 	if active {
-		line = styleSelected.Width(m.width).Render(line)
+		line = renderSelected(line, m.width)
 	}
 	return line
 }
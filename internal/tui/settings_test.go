@@ -75,6 +75,38 @@ func TestSettings_Toggle_Signing(t *testing.T) {
 	}
 }
 
+func TestSettings_Toggle_ConfirmPush(t *testing.T) {
+	m := newSettingsModel("wild-west", false)
+	m.cursor = 2 // confirm push
+
+	var saved []bool
+	modeSaves := 0
+	cfg := Config{
+		Client: sdk.New(sdk.ClientConfig{SaveConfig: func(string, bool) error {
+			modeSaves++
+			return nil
+		}}),
+		SaveConfirmPush: func(on bool) error {
+			saved = append(saved, on)
+			return nil
+		},
+	}
+	m2, cmd := m.toggle(cfg)
+	if !m2.confirmPush {
+		t.Error("confirmPush should be true after toggle")
+	}
+	msg := cmd().(settingsSavedMsg)
+	if !msg.confirmPush || msg.mode != "wild-west" || msg.err != nil {
+		t.Errorf("saved msg = %+v", msg)
+	}
+	if len(saved) != 1 || !saved[0] || modeSaves != 0 {
+		t.Errorf("SaveConfirmPush calls = %v, SaveConfig calls = %d; want [true] and 0", saved, modeSaves)
+	}
+	if v := m2.view(cfg); !strings.Contains(v, "Confirm push:") || !strings.Contains(v, "[true]") {
+		t.Errorf("view should show confirm push on, got:\n%s", v)
+	}
+}
+
 func TestSettings_Toggle_SaveError(t *testing.T) {
 	m := newSettingsModel("wild-west", false)
 	m.cursor = 0
@@ -123,14 +155,16 @@ func TestSettings_Cursor_Navigation(t *testing.T) {
 		t.Errorf("cursor = %d, want 1", m2.cursor)
 	}
 
-	// Can't go below 1.
+	// Down again reaches confirm push at 2, and can't go below it.
 	m3, _ := m2.update(keyMsg("j"), cfg)
-	if m3.cursor != 1 {
-		t.Errorf("cursor = %d, want 1 (clamped)", m3.cursor)
+	m3, _ = m3.update(keyMsg("j"), cfg)
+	if m3.cursor != 2 {
+		t.Errorf("cursor = %d, want 2 (clamped)", m3.cursor)
 	}
 
-	// Press up goes back to 0.
+	// Press up twice goes back to 0.
 	m4, _ := m3.update(keyMsg("k"), cfg)
+	m4, _ = m4.update(keyMsg("k"), cfg)
 	if m4.cursor != 0 {
 		t.Errorf("cursor = %d, want 0", m4.cursor)
 	}
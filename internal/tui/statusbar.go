@@ -1,23 +1,92 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/gastownhall/wasteland/internal/commons"
 )
 
 // statusBar renders the bottom bar showing handle, context, and key hints.
 type statusBar struct {
 	handle string
 	width  int
+
+	// unavailableUntil is when the remote backend's circuit breaker is due
+	// to let calls through again; zero while the backend is reachable.
+	unavailableUntil time.Time
+
+	// rateLimits reports the remote APIs' quota; entries that are low or
+	// exhausted are shown in the bar. Nil shows none.
+	rateLimits func() []commons.RateLimit
+
+	// notice is a one-off message, such as the result of startup branch
+	// reconciliation. It is cleared by the next key press.
+	notice string
 }
 
 func newStatusBar(handle string) statusBar {
 	return statusBar{handle: handle}
 }
 
+// noteBackend records the outcome of a backend call. An open circuit
+// breaker shows a countdown in the bar; a successful call clears it. Other
+// errors are reported by the view that made the call.
+func (s *statusBar) noteBackend(err error) {
+	var unavail *commons.UnavailableError
+	switch {
+	case errors.As(err, &unavail):
+		s.unavailableUntil = time.Now().Add(unavail.RetryIn)
+	case err == nil:
+		s.unavailableUntil = time.Time{}
+	}
+}
+
+// backendNotice returns the bar text for an unavailable backend, or "".
+func (s statusBar) backendNotice(now time.Time) string {
+	if s.unavailableUntil.IsZero() {
+		return ""
+	}
+	if wait := s.unavailableUntil.Sub(now); wait > 0 {
+		return fmt.Sprintf("backend unavailable, retry in %ds", int(wait.Round(time.Second).Seconds()))
+	}
+	return "backend unavailable, retrying"
+}
+
+// rateLimitNotices returns bar text for each API whose quota is low or
+// exhausted at now, styled by severity.
+func (s statusBar) rateLimitNotices(now time.Time) []string {
+	if s.rateLimits == nil {
+		return nil
+	}
+	var out []string
+	for _, rl := range s.rateLimits() {
+		if !rl.Low(now) {
+			continue
+		}
+		if rl.Limited {
+			out = append(out, styleError.Render(rl.Describe(now)))
+		} else {
+			out = append(out, styleWarning.Render(rl.Describe(now)))
+		}
+	}
+	return out
+}
+
 func (s statusBar) render(hints string) string {
+	now := time.Now()
 	left := styleDim.Render(s.handle)
+	if notice := s.backendNotice(now); notice != "" {
+		left += "  " + styleError.Render(notice)
+	}
+	for _, notice := range s.rateLimitNotices(now) {
+		left += "  " + notice
+	}
+	if s.notice != "" {
+		left += "  " + styleSuccess.Render(s.notice)
+	}
 	right := styleDim.Render(hints)
 
 	gap := s.width - lipgloss.Width(left) - lipgloss.Width(right)
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/gastownhall/wasteland/internal/commons"
+	"github.com/gastownhall/wasteland/internal/sdk"
 )
 
 type submitModel struct {
@@ -17,6 +18,9 @@ type submitModel struct {
 	diffLoaded bool
 	diffErr    error
 	showDiff   bool
+	squash     *sdk.SquashPreview // nil until loaded
+	squashErr  error
+	noSquash   bool // user turned squashing off
 	viewport   viewport.Model
 	width      int
 	height     int
@@ -43,6 +47,18 @@ func (m *submitModel) setDiff(msg submitDiffMsg) {
 	m.refreshContent()
 }
 
+func (m *submitModel) setSquash(msg submitSquashMsg) {
+	m.squash = msg.preview
+	m.squashErr = msg.err
+	m.refreshContent()
+}
+
+// squashing reports whether the branch will be squashed before the PR is
+// created.
+func (m *submitModel) squashing() bool {
+	return m.squash != nil && m.squash.CanSquash && !m.noSquash
+}
+
 func (m *submitModel) refreshContent() {
 	m.viewport.SetContent(m.renderContent())
 }
@@ -57,6 +73,7 @@ func (m *submitModel) renderContent() string {
 	delta := commons.DeltaLabel(m.mainStatus, m.item.Status)
 	fmt.Fprintf(&b, "  Transition:  %s → %s (%s)\n", m.mainStatus, m.item.Status, delta)
 	fmt.Fprintf(&b, "  Branch:      %s\n", m.branch)
+	m.renderSquash(&b)
 	b.WriteString("\n")
 
 	if m.showDiff {
@@ -76,11 +93,44 @@ func (m *submitModel) renderContent() string {
 		b.WriteString(styleDim.Render("  (press tab to show diff)") + "\n\n")
 	}
 
-	b.WriteString(styleDim.Render("  enter: create PR   esc: back   tab: diff   q: quit") + "\n")
+	hints := "  enter: create PR   esc: back   tab: diff   q: quit"
+	if m.squash != nil && m.squash.CanSquash {
+		hints = "  enter: create PR   s: toggle squash   esc: back   tab: diff   q: quit"
+	}
+	b.WriteString(styleDim.Render(hints) + "\n")
 
 	return b.String()
 }
 
+// renderSquash shows the branch's commits and, when there is more than
+// one, the single commit they will be squashed into.
+func (m *submitModel) renderSquash(b *strings.Builder) {
+	switch {
+	case m.squashErr != nil:
+		b.WriteString(styleDim.Render(fmt.Sprintf("  Commits:     unavailable (%v)", m.squashErr)) + "\n")
+		return
+	case m.squash == nil:
+		b.WriteString(styleDim.Render("  Commits:     loading...") + "\n")
+		return
+	}
+
+	fmt.Fprintf(b, "  Commits:     %d\n", len(m.squash.Commits))
+	for _, msg := range m.squash.Commits {
+		b.WriteString(styleDim.Render("    "+msg) + "\n")
+	}
+	switch {
+	case m.squashing():
+		fmt.Fprintf(b, "  Squash into: %s\n", m.squash.Message)
+	case m.squash.CanSquash:
+		b.WriteString(styleDim.Render("  Squash:      off") + "\n")
+	case len(m.squash.Commits) > 1:
+		b.WriteString(styleDim.Render("  Squash:      not supported by this backend") + "\n")
+	}
+	if m.squash.Delta != "" {
+		fmt.Fprintf(b, "  Final delta: %s\n", m.squash.Delta)
+	}
+}
+
 func (m *submitModel) update(msg bubbletea.Msg) (*submitModel, bubbletea.Cmd) {
 	if msg, ok := msg.(bubbletea.KeyMsg); ok {
 		switch msg.String() {
@@ -92,10 +142,17 @@ func (m *submitModel) update(msg bubbletea.Msg) (*submitModel, bubbletea.Cmd) {
 			m.showDiff = !m.showDiff
 			m.refreshContent()
 			return m, nil
+		case "s":
+			if m.squash != nil && m.squash.CanSquash {
+				m.noSquash = !m.noSquash
+				m.refreshContent()
+			}
+			return m, nil
 		case "enter":
 			// Signal PR creation — root handles the actual execution.
+			squash := m.squashing()
 			return m, func() bubbletea.Msg {
-				return submitConfirmMsg{}
+				return submitConfirmMsg{squash: squash}
 			}
 		}
 	}
@@ -109,5 +166,8 @@ func (m *submitModel) view() string {
 	return m.viewport.View()
 }
 
-// submitConfirmMsg is an internal message to trigger PR creation from the submit view.
-type submitConfirmMsg struct{}
+// submitConfirmMsg is an internal message to trigger PR creation from the
+// submit view, squashing the branch first when squash is set.
+type submitConfirmMsg struct {
+	squash bool
+}
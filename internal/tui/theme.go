@@ -2,8 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/gastownhall/wasteland/internal/i18n"
+	"github.com/gastownhall/wasteland/internal/style"
 )
 
 // Ayu theme colors for TUI contexts.
@@ -39,24 +42,45 @@ var (
 	styleMineOn    = lipgloss.NewStyle().Foreground(colorPass).Bold(true)
 	styleConfirm   = lipgloss.NewStyle().Foreground(colorWarn).Bold(true)
 	styleSuccess   = lipgloss.NewStyle().Foreground(colorPass)
+	styleWarning   = lipgloss.NewStyle().Foreground(colorWarn)
 	styleError     = lipgloss.NewStyle().Foreground(colorFail)
 
+	styleMDHeading = lipgloss.NewStyle().Foreground(colorWarn).Bold(true)
+	styleMDCode    = lipgloss.NewStyle().Foreground(colorPass)
+	styleMDLink    = lipgloss.NewStyle().Underline(true)
+	styleMDItalic  = lipgloss.NewStyle().Italic(true)
+	styleMDQuote   = lipgloss.NewStyle().Foreground(colorDim).Italic(true)
+
 	styleP0 = lipgloss.NewStyle().Foreground(colorFail).Bold(true)
 	styleP1 = lipgloss.NewStyle().Foreground(colorWarn)
 )
 
+// renderSelected highlights the cursor line. Without color (NO_COLOR,
+// --color never, a dumb terminal) the background wouldn't show, so the
+// line's leading indent becomes a "> " marker instead.
+func renderSelected(line string, width int) string {
+	if style.Colorless() {
+		if rest, ok := strings.CutPrefix(line, "  "); ok {
+			return "> " + rest
+		}
+		return line
+	}
+	return styleSelected.Width(width).Render(line)
+}
+
 func colorizeStatus(status string) string {
+	label := i18n.Status(status)
 	switch status {
 	case "open":
-		return styleStatusOpen.Render(status)
+		return styleStatusOpen.Render(label)
 	case "claimed":
-		return styleStatusClaimed.Render(status)
+		return styleStatusClaimed.Render(label)
 	case "in_review":
-		return styleStatusReview.Render(status)
+		return styleStatusReview.Render(label)
 	case "completed":
-		return styleStatusComplete.Render(status)
+		return styleStatusComplete.Render(label)
 	default:
-		return styleDim.Render(status)
+		return styleDim.Render(label)
 	}
 }
 
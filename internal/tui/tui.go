@@ -2,7 +2,10 @@ package tui
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	bubbletea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gastownhall/wasteland/internal/commons"
@@ -17,12 +20,33 @@ type Config struct {
 	Mode      string      // "wild-west" or "pr" (display and behavior)
 	Signing   bool        // GPG-signed dolt commits (display)
 
+	// ConfirmPush asks before every wild-west push to upstream, including
+	// done, accept, comment and undo, which have no prompt of their own.
+	ConfirmPush bool
+	// SaveConfirmPush persists the confirm-push setting. Nil keeps the
+	// change for this session only.
+	SaveConfirmPush func(bool) error
+	// PushProgress streams push output lines into the detail view (see
+	// NewPushProgress). Nil shows only the spinner.
+	PushProgress <-chan string
+
+	// CheckNotify, when set, runs at startup and then every NotifyInterval
+	// (default one minute), returning the changes to followed items since
+	// its last run. The latest is shown in the status bar; desktop
+	// notifications are CheckNotify's own business.
+	CheckNotify    func() ([]string, error)
+	NotifyInterval time.Duration
+
 	// Settings view: read-only context
 	ProviderType string
 	ForkOrg      string
 	ForkDB       string
 	LocalDir     string
 	JoinedAt     string
+
+	// ReviewComments enables review notes stored in the database, for
+	// wastelands without a PR provider.
+	ReviewComments bool
 }
 
 // Model is the root TUI model that routes between views.
@@ -32,7 +56,11 @@ type Model struct {
 	browse   browseModel
 	detail   detailModel
 	me       meModel
+	branches branchesModel
+	delta    deltaModel
 	settings settingsModel
+	jump     jumpModel
+	palette  paletteModel
 	bar      statusBar
 	width    int
 	height   int
@@ -42,20 +70,39 @@ type Model struct {
 
 // New creates a new root TUI model.
 func New(cfg Config) Model {
-	return Model{
+	m := Model{
 		cfg:      cfg,
 		active:   viewBrowse,
 		browse:   newBrowseModel(),
-		detail:   newDetailModel(cfg.RigHandle, cfg.Mode),
+		detail:   newDetailModel(cfg.RigHandle, cfg.Mode, cfg.ReviewComments),
 		me:       newMeModel(),
+		branches: newBranchesModel(),
+		delta:    newDeltaModel(),
 		settings: newSettingsModel(cfg.Mode, cfg.Signing),
+		jump:     newJumpModel(),
+		palette:  newPaletteModel(),
 		bar:      newStatusBar(fmt.Sprintf("%s@%s", cfg.RigHandle, cfg.Upstream)),
 	}
+	m.settings.confirmPush = cfg.ConfirmPush
+	if cfg.Client != nil {
+		m.bar.rateLimits = cfg.Client.RateLimitState
+	}
+	return m
 }
 
 // Init starts the initial data load.
 func (m Model) Init() bubbletea.Cmd {
-	return fetchBrowse(m.cfg, m.browse.filter(m.cfg.RigHandle))
+	cmds := []bubbletea.Cmd{fetchBrowse(m.cfg, m.browse.filter(m.cfg.RigHandle)), fetchVocabulary(m.cfg)}
+	if m.cfg.Mode == "pr" {
+		cmds = append(cmds, reconcileBranches(m.cfg))
+	}
+	if m.cfg.PushProgress != nil {
+		cmds = append(cmds, waitForProgress(m.cfg.PushProgress))
+	}
+	if m.cfg.CheckNotify != nil {
+		cmds = append(cmds, checkNotify(m.cfg))
+	}
+	return bubbletea.Batch(cmds...)
 }
 
 // Update processes messages.
@@ -66,6 +113,23 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 			m.quitting = true
 			return m, bubbletea.Quit
 		}
+		m.bar.notice = ""
+		if m.palette.open {
+			var cmd bubbletea.Cmd
+			m.palette, cmd = m.palette.update(msg)
+			return m, cmd
+		}
+		if m.jump.open {
+			var cmd bubbletea.Cmd
+			m.jump, cmd = m.jump.update(msg, m.cfg)
+			return m, cmd
+		}
+		if m.canOpenPalette(msg) {
+			return m, m.palette.start(m.paletteCommands())
+		}
+		if m.canJump(msg) {
+			return m, m.jump.start()
+		}
 
 	case bubbletea.WindowSizeMsg:
 		m.width = msg.Width
@@ -74,6 +138,8 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		m.browse.setSize(msg.Width, msg.Height-1) // -1 for statusbar
 		m.detail.setSize(msg.Width, msg.Height-1)
 		m.me.setSize(msg.Width, msg.Height-1)
+		m.branches.setSize(msg.Width, msg.Height-1)
+		m.delta.setSize(msg.Width, msg.Height-1)
 		m.settings.setSize(msg.Width, msg.Height-1)
 
 	case navigateMsg:
@@ -86,23 +152,116 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		case viewMe:
 			m.me.loading = true
 			return m, fetchMe(m.cfg)
+		case viewBranches:
+			m.branches.loading = true
+			return m, fetchBranches(m.cfg)
+		case viewDelta:
+			m.delta.loading = true
+			return m, fetchDeltas(m.cfg)
 		case viewSettings:
 			m.settings.sync(m.cfg.Mode, m.cfg.Signing)
+			m.settings.confirmPush = m.cfg.ConfirmPush
 			return m, nil
 		}
 
+	case jumpResultMsg:
+		if !m.jump.open {
+			return m, nil // cancelled while resolving
+		}
+		m.bar.noteBackend(msg.err)
+		if msg.err != nil {
+			m.jump.resolving = false
+			m.jump.err = msg.err.Error()
+			return m, nil
+		}
+		m.jump.close()
+		return m.Update(navigateMsg{view: viewDetail, wantedID: msg.id})
+
 	case browseDataMsg:
+		m.bar.noteBackend(msg.err)
 		m.browse.setData(msg)
 		return m, nil
 
+	case vocabularyMsg:
+		m.browse.setTypes(msg.types)
+		return m, nil
+
+	case reconcileMsg:
+		if msg.err != nil || len(msg.branches) == 0 {
+			return m, nil
+		}
+		m.bar.notice = fmt.Sprintf("cleaned up %d merged/closed branch(es)", len(msg.branches))
+		// Items read from main again; refresh so pending markers disappear.
+		return m, fetchBrowse(m.cfg, m.browse.filter(m.cfg.RigHandle))
+
+	case notifyMsg:
+		if msg.err == nil && len(msg.changes) > 0 {
+			m.bar.notice = msg.changes[len(msg.changes)-1]
+			if n := len(msg.changes); n > 1 {
+				m.bar.notice += fmt.Sprintf(" (+%d more)", n-1)
+			}
+		}
+		interval := m.cfg.NotifyInterval
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		return m, bubbletea.Tick(interval, func(time.Time) bubbletea.Msg { return notifyTickMsg{} })
+
+	case notifyTickMsg:
+		return m, checkNotify(m.cfg)
+
+	case browseRefetchMsg:
+		if msg.seq != m.browse.fetchSeq {
+			return m, nil
+		}
+		return m, fetchBrowse(m.cfg, m.browse.filter(m.cfg.RigHandle))
+
 	case detailDataMsg:
+		m.bar.noteBackend(msg.err)
 		m.detail.setData(msg)
 		return m, nil
 
 	case meDataMsg:
+		m.bar.noteBackend(msg.err)
 		m.me.setData(msg)
 		return m, nil
 
+	case branchesDataMsg:
+		m.bar.noteBackend(msg.err)
+		m.branches.setData(msg)
+		return m, nil
+
+	case deltaDataMsg:
+		m.bar.noteBackend(msg.err)
+		m.delta.setData(msg)
+		return m, nil
+
+	case branchDiscardMsg:
+		return m, discardBranch(m.cfg, msg.branch)
+
+	case branchDiscardResultMsg:
+		m.bar.noteBackend(msg.err)
+		m.branches.executing = false
+		if msg.err != nil {
+			m.branches.result = styleError.Render("Error: " + msg.err.Error())
+			return m, nil
+		}
+		m.branches.result = styleSuccess.Render("Discarded " + msg.branch)
+		return m, fetchBranches(m.cfg)
+
+	case branchBulkMsg:
+		return m, bulkBranches(m.cfg, msg.action)
+
+	case branchBulkResultMsg:
+		m.bar.noteBackend(msg.err)
+		m.branches.executing = false
+		if msg.err != nil {
+			m.branches.result = styleError.Render("Error: " + msg.err.Error())
+			return m, nil
+		}
+		m.branches.result = bulkSummary(msg)
+		return m, fetchBranches(m.cfg)
+
 	case actionRequestMsg:
 		if m.detail.item == nil {
 			return m, nil
@@ -115,7 +274,7 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 			m.detail.refreshViewport()
 			return m, bubbletea.Batch(
 				m.detail.spinner.Tick,
-				executeMutation(m.cfg, m.detail.item.ID, msg.transition),
+				executeMutation(m.cfg, m.detail.item.ID, msg.transition, nil),
 			)
 		}
 		// Wild-west: show confirmation prompt.
@@ -135,14 +294,42 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		m.detail.executing = true
 		m.detail.executingLabel = commons.TransitionLabel(msg.transition)
 		m.detail.refreshViewport()
+		// Wild-west: remember the prior state so the mutation can be undone.
+		var undo *undoAction
+		if sdk.CanUndo(msg.transition) {
+			undo = &undoAction{
+				wantedID:      m.detail.item.ID,
+				transition:    msg.transition,
+				prevClaimedBy: m.detail.item.ClaimedBy,
+			}
+		}
 		return m, bubbletea.Batch(
 			m.detail.spinner.Tick,
-			executeMutation(m.cfg, m.detail.item.ID, msg.transition),
+			executeMutation(m.cfg, m.detail.item.ID, msg.transition, undo),
 		)
 
+	case pushProgressMsg:
+		if m.detail.executing {
+			m.detail.progress = msg.line
+			m.detail.refreshViewport()
+		}
+		return m, waitForProgress(m.cfg.PushProgress)
+
+	case retryPushMsg:
+		m.detail.result = ""
+		m.detail.executing = true
+		m.detail.executingLabel = "Retrying push..."
+		m.detail.refreshViewport()
+		return m, bubbletea.Batch(m.detail.spinner.Tick, executeRetryPush(m.cfg, msg.failed))
+
 	case actionResultMsg:
+		m.bar.noteBackend(msg.err)
 		m.detail.executing = false
 		m.detail.executingLabel = ""
+		m.detail.progress = ""
+		if m.detail.notePushFailure(msg.err) {
+			return m, nil
+		}
 		if msg.err != nil {
 			m.detail.result = styleError.Render("Error: " + msg.err.Error())
 			m.detail.refreshViewport()
@@ -157,9 +344,38 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		} else if r != nil && r.Branch != "" {
 			m.detail.result = styleSuccess.Render("Pushed to " + r.Branch)
 		}
+		if msg.undo != nil && m.cfg.Mode != "pr" {
+			u := *msg.undo
+			u.at = time.Now()
+			m.detail.undo = &u
+			if m.detail.result == "" {
+				name := commons.TransitionName(u.transition)
+				m.detail.result = styleSuccess.Render(fmt.Sprintf("%s %s pushed", strings.ToUpper(name[:1])+name[1:], u.wantedID))
+			}
+			m.detail.refreshViewport()
+			return m, bubbletea.Tick(undoWindow, func(time.Time) bubbletea.Msg {
+				return undoExpiredMsg{at: u.at}
+			})
+		}
 		m.detail.refreshViewport()
 		return m, nil
 
+	case undoRequestMsg:
+		if m.detail.item == nil {
+			return m, nil
+		}
+		m.detail.undo = nil
+		m.detail.result = ""
+		return m.startPush(fmt.Sprintf("Undo %s of %s?", commons.TransitionName(msg.undo.transition), msg.undo.wantedID), "Undoing...",
+			executeUndo(m.cfg, msg.undo))
+
+	case undoExpiredMsg:
+		if m.detail.undo != nil && m.detail.undo.at.Equal(msg.at) {
+			m.detail.undo = nil
+			m.detail.refreshViewport()
+		}
+		return m, nil
+
 	case deltaRequestMsg:
 		m.detail.deltaConfirm = &deltaConfirmAction{
 			action: msg.action,
@@ -185,6 +401,7 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		)
 
 	case deltaResultMsg:
+		m.bar.noteBackend(msg.err)
 		m.detail.executing = false
 		m.detail.executingLabel = ""
 		if msg.err != nil {
@@ -208,26 +425,41 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 			return m, nil
 		}
 		m.detail.doneForm = nil
-		m.detail.executing = true
-		m.detail.executingLabel = "Submitting..."
+		return m.startPush(fmt.Sprintf("Mark %s done?", m.detail.item.ID), "Submitting...",
+			executeDoneMutation(m.cfg, m.detail.item.ID, msg.evidence))
+
+	case commentSubmitMsg:
+		if m.detail.item == nil {
+			return m, nil
+		}
+		m.detail.commentForm = nil
+		return m.startPush(fmt.Sprintf("Comment on %s?", m.detail.commentBranch()), "Commenting...",
+			executeComment(m.cfg, m.detail.commentBranch(), m.detail.item.ID, msg.body))
+
+	case commentResultMsg:
+		m.bar.noteBackend(msg.err)
+		m.detail.executing = false
+		m.detail.executingLabel = ""
+		m.detail.progress = ""
+		if m.detail.notePushFailure(msg.err) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.detail.result = styleError.Render("Error: " + msg.err.Error())
+		} else {
+			m.detail.comments = append(m.detail.comments, *msg.comment)
+			m.detail.result = styleSuccess.Render("Comment added to " + msg.comment.Branch)
+		}
 		m.detail.refreshViewport()
-		return m, bubbletea.Batch(
-			m.detail.spinner.Tick,
-			executeDoneMutation(m.cfg, m.detail.item.ID, msg.evidence),
-		)
+		return m, nil
 
 	case acceptSubmitMsg:
 		if m.detail.item == nil {
 			return m, nil
 		}
 		m.detail.acceptForm = nil
-		m.detail.executing = true
-		m.detail.executingLabel = "Accepting..."
-		m.detail.refreshViewport()
-		return m, bubbletea.Batch(
-			m.detail.spinner.Tick,
-			executeAcceptMutation(m.cfg, m.detail.item.ID, msg),
-		)
+		return m.startPush(fmt.Sprintf("Accept %s?", m.detail.item.ID), "Accepting...",
+			executeAcceptMutation(m.cfg, m.detail.item.ID, msg))
 
 	case submitDiffMsg:
 		if m.detail.submit != nil {
@@ -235,9 +467,15 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		}
 		return m, nil
 
+	case submitSquashMsg:
+		if m.detail.submit != nil {
+			m.detail.submit.setSquash(msg)
+		}
+		return m, nil
+
 	case submitOpenedMsg:
-		// Submit view opened — start loading diff in background.
-		return m, fetchDiff(m.cfg, msg.branch)
+		// Submit view opened — start loading diff and squash preview in background.
+		return m, bubbletea.Batch(fetchDiff(m.cfg, msg.branch), fetchSquashPreview(m.cfg, msg.branch))
 
 	case submitConfirmMsg:
 		if m.detail.submit == nil {
@@ -245,11 +483,14 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		}
 		m.detail.executing = true
 		m.detail.executingLabel = "Creating PR..."
+		if msg.squash {
+			m.detail.executingLabel = "Squashing and creating PR..."
+		}
 		m.detail.submit = nil
 		m.detail.refreshViewport()
 		return m, bubbletea.Batch(
 			m.detail.spinner.Tick,
-			createPR(m.cfg, m.detail.branch),
+			createPR(m.cfg, m.detail.branch, msg.squash),
 		)
 
 	case submitResultMsg:
@@ -275,9 +516,11 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		}
 		m.cfg.Mode = msg.mode
 		m.cfg.Signing = msg.signing
+		m.cfg.ConfirmPush = msg.confirmPush
 		m.detail.mode = msg.mode
 		m.settings.mode = msg.mode
 		m.settings.signing = msg.signing
+		m.settings.confirmPush = msg.confirmPush
 		m.settings.result = styleSuccess.Render("Saved")
 		return m, nil
 
@@ -286,8 +529,14 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		return m, nil
 	}
 
-	// Delegate to active view.
-	var cmd bubbletea.Cmd
+	// Delegate to active view. An open prompt's cursor blinks alongside.
+	var cmd, promptCmd bubbletea.Cmd
+	switch {
+	case m.palette.open:
+		m.palette.input, promptCmd = m.palette.input.Update(msg)
+	case m.jump.open:
+		m.jump.input, promptCmd = m.jump.input.Update(msg)
+	}
 	switch m.active {
 	case viewBrowse:
 		m.browse, cmd = m.browse.update(msg, m.cfg)
@@ -295,10 +544,54 @@ func (m Model) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
 		m.detail, cmd = m.detail.update(msg)
 	case viewMe:
 		m.me, cmd = m.me.update(msg)
+	case viewBranches:
+		m.branches, cmd = m.branches.update(msg)
+	case viewDelta:
+		m.delta, cmd = m.delta.update(msg)
 	case viewSettings:
 		m.settings, cmd = m.settings.update(msg, m.cfg)
 	}
-	return m, cmd
+	return m, bubbletea.Batch(cmd, promptCmd)
+}
+
+// busy reports whether the active view is taking text or waiting on an
+// answer to a prompt, so keys must reach it rather than open the jump
+// prompt or the command palette.
+func (m Model) busy() bool {
+	switch m.active {
+	case viewBrowse:
+		return m.browse.searchMode || m.browse.projectMode
+	case viewDetail:
+		d := m.detail
+		return d.confirming != nil || d.deltaConfirm != nil || d.pushConfirm != nil ||
+			d.submit != nil || d.doneForm != nil || d.acceptForm != nil || d.commentForm != nil
+	case viewBranches:
+		return m.branches.confirming != "" || m.branches.bulk != ""
+	}
+	return false
+}
+
+// canJump reports whether msg opens the jump-to-ID prompt. In the browse
+// list "g" keeps its "first item" meaning.
+func (m Model) canJump(msg bubbletea.KeyMsg) bool {
+	if !key.Matches(msg, keys.Jump) || m.busy() {
+		return false
+	}
+	return m.active != viewBrowse || !key.Matches(msg, keys.Home)
+}
+
+// startPush runs a detail-view mutation. With confirm-push on in
+// wild-west mode it first waits behind a "Pushes to upstream" prompt.
+func (m Model) startPush(label, executingLabel string, run bubbletea.Cmd) (Model, bubbletea.Cmd) {
+	if m.cfg.ConfirmPush && m.cfg.Mode != "pr" {
+		m.detail.pushConfirm = &pendingPush{label: label, executingLabel: executingLabel, run: run}
+		m.detail.refreshViewport()
+		return m, nil
+	}
+	m.detail.executing = true
+	m.detail.executingLabel = executingLabel
+	m.detail.refreshViewport()
+	return m, bubbletea.Batch(m.detail.spinner.Tick, run)
 }
 
 // View renders the current view.
@@ -313,16 +606,27 @@ func (m Model) View() string {
 	switch m.active {
 	case viewBrowse:
 		content = m.browse.view()
-		hints = "j/k: navigate  enter: open  s/t/p/o: filters  i: mine  P: project  /: search  m: me  S: settings  q: quit"
+		hints = "j/k: navigate  pgup/pgdn: page  enter: open  s/t/p/o: filters  v: group  i: mine  P: project  /: search  :: jump  ctrl+p: commands  m: me  B: branches  d: delta  S: settings  q: quit"
 	case viewDetail:
 		content = m.detail.view()
-		hints = "esc: back  j/k: scroll  c/u/x/X/D: actions  q: quit"
+		hints = "esc: back  j/k: scroll  c/u/x/X/D: actions  g: jump  ctrl+p: commands  q: quit"
 	case viewMe:
 		content = m.me.view()
-		hints = "j/k: navigate  enter: open  esc: back  S: settings  q: quit"
+		hints = "j/k: navigate  enter: open  g: jump  ctrl+p: commands  esc: back  S: settings  q: quit"
+	case viewBranches:
+		content = m.branches.view()
+		hints = "j/k: navigate  enter: open item  b: discard  A: apply all  X: discard all  g: jump  ctrl+p: commands  esc: back  q: quit"
+	case viewDelta:
+		content = m.delta.view()
+		hints = "j/k: navigate  enter: open item  g: jump  ctrl+p: commands  esc: back  q: quit"
 	case viewSettings:
 		content = m.settings.view(m.cfg)
-		hints = "j/k: select  enter: toggle  esc: back  q: quit"
+		hints = "j/k: select  enter: toggle  g: jump  ctrl+p: commands  esc: back  q: quit"
+	}
+
+	if m.palette.open {
+		content = m.palette.view(m.width, m.height-1)
+		hints = "type: filter  up/down: select  enter: run  esc: close"
 	}
 
 	// Pad content to fill available height.
@@ -333,6 +637,9 @@ func (m Model) View() string {
 		Render(content)
 
 	bar := m.bar.render(hints)
+	if m.jump.open {
+		bar = m.jump.view(m.width)
+	}
 
 	return content + "\n" + bar
 }
@@ -349,6 +656,13 @@ func sdkDetailToMsg(d *sdk.DetailResult) detailDataMsg {
 		mainStatus:    d.MainStatus,
 		prURL:         d.PRURL,
 		branchActions: d.BranchActions,
+		comments:      d.Comments,
+		quorum:        d.Quorum,
+		approvals:     d.Approvals,
+		followUps:     d.FollowUps,
+		evidence:      d.Evidence,
+		claimExpiry:   d.ClaimExpiry,
+		queue:         d.Queue,
 	}
 }
 
@@ -362,6 +676,28 @@ func fetchBrowse(cfg Config, f commons.BrowseFilter) bubbletea.Cmd {
 	}
 }
 
+// reconcileBranches removes branches whose upstream PR was merged or
+// closed since the last run.
+func reconcileBranches(cfg Config) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		branches, err := cfg.Client.Reconcile()
+		return reconcileMsg{branches: branches, err: err}
+	}
+}
+
+func checkNotify(cfg Config) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		changes, err := cfg.CheckNotify()
+		return notifyMsg{changes: changes, err: err}
+	}
+}
+
+func fetchVocabulary(cfg Config) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		return vocabularyMsg{types: cfg.Client.Vocabulary().TypeCycle()}
+	}
+}
+
 func fetchMe(cfg Config) bubbletea.Cmd {
 	return func() bubbletea.Msg {
 		data, err := cfg.Client.Dashboard()
@@ -369,6 +705,59 @@ func fetchMe(cfg Config) bubbletea.Cmd {
 	}
 }
 
+func fetchBranches(cfg Config) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		branches, err := cfg.Client.Branches()
+		return branchesDataMsg{branches: branches, err: err}
+	}
+}
+
+func fetchDeltas(cfg Config) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		deltas, err := cfg.Client.Deltas()
+		return deltaDataMsg{deltas: deltas, err: err}
+	}
+}
+
+func discardBranch(cfg Config, branch string) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		return branchDiscardResultMsg{branch: branch, err: cfg.Client.DiscardBranch(branch)}
+	}
+}
+
+func bulkBranches(cfg Config, action string) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		var results []sdk.BranchResult
+		var err error
+		if action == "apply" {
+			results, err = cfg.Client.ApplyAllBranches()
+		} else {
+			results, err = cfg.Client.DiscardAllBranches(nil)
+		}
+		return branchBulkResultMsg{action: action, results: results, err: err}
+	}
+}
+
+// bulkSummary renders a bulk branch result as "Applied 2 branch(es)",
+// followed by the first failure when any branch failed.
+func bulkSummary(msg branchBulkResultMsg) string {
+	verb := "Discarded"
+	if msg.action == "apply" {
+		verb = "Applied"
+	}
+	var failed []sdk.BranchResult
+	for _, r := range msg.results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	summary := fmt.Sprintf("%s %d branch(es)", verb, len(msg.results)-len(failed))
+	if len(failed) == 0 {
+		return styleSuccess.Render(summary)
+	}
+	return styleError.Render(fmt.Sprintf("%s, %d failed (%s: %v)", summary, len(failed), failed[0].Branch, failed[0].Err))
+}
+
 func fetchDetail(cfg Config, wantedID string) bubbletea.Cmd {
 	return func() bubbletea.Msg {
 		result, err := cfg.Client.Detail(wantedID)
@@ -379,7 +768,7 @@ func fetchDetail(cfg Config, wantedID string) bubbletea.Cmd {
 	}
 }
 
-func executeMutation(cfg Config, wantedID string, t commons.Transition) bubbletea.Cmd {
+func executeMutation(cfg Config, wantedID string, t commons.Transition, undo *undoAction) bubbletea.Cmd {
 	return func() bubbletea.Msg {
 		var result *sdk.MutationResult
 		var err error
@@ -397,6 +786,16 @@ func executeMutation(cfg Config, wantedID string, t commons.Transition) bubblete
 		default:
 			err = fmt.Errorf("unsupported transition")
 		}
+		if err != nil {
+			undo = nil
+		}
+		return actionResultMsg{err: err, result: result, undo: undo}
+	}
+}
+
+func executeUndo(cfg Config, u undoAction) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		result, err := cfg.Client.Undo(u.wantedID, u.transition, u.prevClaimedBy)
 		return actionResultMsg{err: err, result: result}
 	}
 }
@@ -424,6 +823,13 @@ func executeDelta(cfg Config, branch string, action branchDeltaAction) bubbletea
 	}
 }
 
+func executeRetryPush(cfg Config, pe *sdk.PushError) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		result, err := cfg.Client.RetryPush(pe)
+		return actionResultMsg{err: err, result: result}
+	}
+}
+
 func executeDoneMutation(cfg Config, wantedID, evidence string) bubbletea.Cmd {
 	return func() bubbletea.Msg {
 		result, err := cfg.Client.Done(wantedID, evidence)
@@ -431,15 +837,26 @@ func executeDoneMutation(cfg Config, wantedID, evidence string) bubbletea.Cmd {
 	}
 }
 
+func executeComment(cfg Config, branch, wantedID, body string) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		rc, err := cfg.Client.AddReviewComment(commons.ReviewComment{Branch: branch, WantedID: wantedID, Body: body})
+		return commentResultMsg{comment: rc, err: err}
+	}
+}
+
 func executeAcceptMutation(cfg Config, wantedID string, msg acceptSubmitMsg) bubbletea.Cmd {
 	return func() bubbletea.Msg {
-		result, err := cfg.Client.Accept(wantedID, sdk.AcceptInput{
+		input := sdk.AcceptInput{
 			Quality:     msg.quality,
 			Reliability: msg.reliability,
 			Severity:    msg.severity,
 			SkillTags:   msg.skills,
 			Message:     msg.message,
-		})
+		}
+		if msg.followUpTitle != "" {
+			input.FollowUp = &sdk.FollowUpInput{Title: msg.followUpTitle, Description: msg.followUpDesc}
+		}
+		result, err := cfg.Client.Accept(wantedID, input)
 		return actionResultMsg{err: err, result: result}
 	}
 }
@@ -451,8 +868,22 @@ func fetchDiff(cfg Config, branch string) bubbletea.Cmd {
 	}
 }
 
-func createPR(cfg Config, branch string) bubbletea.Cmd {
+func fetchSquashPreview(cfg Config, branch string) bubbletea.Cmd {
 	return func() bubbletea.Msg {
+		preview, err := cfg.Client.PreviewSquash(branch)
+		return submitSquashMsg{preview: preview, err: err}
+	}
+}
+
+// createPR submits branch as a PR, collapsing it into one commit first
+// when squash is set.
+func createPR(cfg Config, branch string, squash bool) bubbletea.Cmd {
+	return func() bubbletea.Msg {
+		if squash {
+			if _, err := cfg.Client.Squash(branch); err != nil {
+				return submitResultMsg{err: err}
+			}
+		}
 		prURL, err := cfg.Client.SubmitPR(branch)
 		return submitResultMsg{prURL: prURL, err: err}
 	}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/gastownhall/wasteland/internal/commons"
 	"github.com/gastownhall/wasteland/internal/sdk"
+	"github.com/muesli/termenv"
 )
 
 func TestRootModel_DelegatesToBrowse(t *testing.T) {
@@ -485,6 +488,129 @@ func TestDetail_ExecutingState_IgnoresKeys(t *testing.T) {
 	}
 }
 
+func TestDetail_ActionResultMsg_WildWest_OffersUndo(t *testing.T) {
+	m := newDetailForTest("claimed", "other-rig", "test-rig", "wild-west")
+	m.detail.executing = true
+
+	result, cmd := m.Update(actionResultMsg{
+		result: &sdk.MutationResult{
+			Detail: &sdk.DetailResult{
+				Item: &commons.WantedItem{ID: "w-abc123", Title: "Test Item", Status: "open", PostedBy: "other-rig"},
+			},
+		},
+		undo: &undoAction{wantedID: "w-abc123", transition: commons.TransitionUnclaim, prevClaimedBy: "test-rig"},
+	})
+	m2 := result.(Model)
+	if m2.detail.undo == nil {
+		t.Fatal("wild-west result should record undo")
+	}
+	if m2.detail.undo.at.IsZero() {
+		t.Error("undo should be timestamped")
+	}
+	if !strings.Contains(m2.detail.result, "Unclaim w-abc123") {
+		t.Errorf("result should describe the mutation, got: %q", m2.detail.result)
+	}
+	if v := m2.View(); !strings.Contains(v, "u:undo") {
+		t.Errorf("view should offer undo, got:\n%s", v)
+	}
+	if cmd == nil {
+		t.Error("expected undo expiry timer cmd")
+	}
+}
+
+func TestDetail_ActionResultMsg_PRMode_NoUndo(t *testing.T) {
+	m := newDetailForTest("open", "other-rig", "", "pr")
+
+	result, _ := m.Update(actionResultMsg{
+		result: &sdk.MutationResult{
+			Detail: &sdk.DetailResult{
+				Item: &commons.WantedItem{ID: "w-abc123", Title: "Test Item", Status: "claimed", ClaimedBy: "test-rig"},
+			},
+			Branch: "wl/test-rig/w-abc123",
+		},
+		undo: &undoAction{wantedID: "w-abc123", transition: commons.TransitionClaim},
+	})
+	m2 := result.(Model)
+	if m2.detail.undo != nil {
+		t.Error("PR mode should not record undo")
+	}
+}
+
+func TestDetail_UndoKey_ReturnsUndoRequest(t *testing.T) {
+	m := newDetailForTest("open", "other-rig", "", "wild-west")
+	m.detail.undo = &undoAction{
+		wantedID:      "w-abc123",
+		transition:    commons.TransitionUnclaim,
+		prevClaimedBy: "test-rig",
+		at:            time.Now(),
+	}
+
+	result, cmd := m.Update(keyMsg("u"))
+	m2 := result.(Model)
+	if cmd == nil {
+		t.Fatal("expected cmd from 'u' key")
+	}
+	req, ok := cmd().(undoRequestMsg)
+	if !ok {
+		t.Fatal("expected undoRequestMsg")
+	}
+	if req.undo.transition != commons.TransitionUnclaim || req.undo.prevClaimedBy != "test-rig" {
+		t.Errorf("unexpected undo request: %+v", req.undo)
+	}
+
+	result, cmd = m2.Update(req)
+	m3 := result.(Model)
+	if !m3.detail.executing {
+		t.Error("undo request should set executing")
+	}
+	if m3.detail.executingLabel != "Undoing..." {
+		t.Errorf("executingLabel = %q, want %q", m3.detail.executingLabel, "Undoing...")
+	}
+	if cmd == nil {
+		t.Error("expected cmd to execute undo")
+	}
+}
+
+func TestDetail_UndoKey_ExpiredFallsThroughToUnclaim(t *testing.T) {
+	m := newDetailForTest("claimed", "other-rig", "test-rig", "wild-west")
+	m.detail.undo = &undoAction{
+		wantedID:   "w-abc123",
+		transition: commons.TransitionClaim,
+		at:         time.Now().Add(-undoWindow),
+	}
+
+	_, cmd := m.Update(keyMsg("u"))
+	if cmd == nil {
+		t.Fatal("expected cmd from 'u' key")
+	}
+	req, ok := cmd().(actionRequestMsg)
+	if !ok {
+		t.Fatal("expired undo should fall through to unclaim")
+	}
+	if req.transition != commons.TransitionUnclaim {
+		t.Errorf("transition = %v, want TransitionUnclaim", req.transition)
+	}
+}
+
+func TestDetail_UndoExpiredMsg_ClearsUndo(t *testing.T) {
+	m := newDetailForTest("open", "other-rig", "", "wild-west")
+	at := time.Now()
+	m.detail.undo = &undoAction{wantedID: "w-abc123", transition: commons.TransitionUnclaim, at: at}
+
+	// A timer from an earlier mutation must not clear the current undo.
+	result, _ := m.Update(undoExpiredMsg{at: at.Add(-time.Second)})
+	m2 := result.(Model)
+	if m2.detail.undo == nil {
+		t.Fatal("stale timer should not clear undo")
+	}
+
+	result, _ = m2.Update(undoExpiredMsg{at: at})
+	m3 := result.(Model)
+	if m3.detail.undo != nil {
+		t.Error("undo should be cleared after window expires")
+	}
+}
+
 func TestRootModel_MeKey_NavigatesToMe(t *testing.T) {
 	m := New(Config{RigHandle: "test", Upstream: "test/db"})
 	m.browse.loading = false
@@ -549,6 +675,27 @@ func TestRootModel_MeDataMsg_SetsData(t *testing.T) {
 	}
 }
 
+func TestMe_ViewShowsReputation(t *testing.T) {
+	m := newMeModel()
+	m.setSize(80, 24)
+	m.setData(meDataMsg{data: &commons.DashboardData{
+		Reputation: &commons.Reputation{
+			StampCount:     2,
+			AvgQuality:     4.5,
+			AvgReliability: 4,
+			RecentStamps:   []commons.StampDetail{{Author: "bob", Quality: 5, Reliability: 4, Message: "solid", CreatedAt: "2026-03-02 10:00:00"}},
+			Badges:         []commons.BadgeDetail{{BadgeType: "first_blood"}},
+		},
+	}})
+
+	v := m.view()
+	for _, want := range []string{"My Reputation", "2 stamp(s)", "quality 4.5", "first_blood", "bob", "2026-03-02 "} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view missing %q:\n%s", want, v)
+		}
+	}
+}
+
 func TestMe_EscReturns(t *testing.T) {
 	m := New(Config{RigHandle: "test", Upstream: "test/db"})
 	m.active = viewMe
@@ -638,6 +785,24 @@ func TestMe_View_ShowsSections(t *testing.T) {
 	}
 }
 
+func TestMe_UpNextSection(t *testing.T) {
+	m := newMeModel()
+	m.loading = false
+	m.width = 80
+	m.data = &commons.DashboardData{
+		Claimed: []commons.WantedSummary{{ID: "w-1", Title: "Claimed item", Status: "claimed"}},
+		UpNext:  []commons.WantedSummary{{ID: "w-9", Title: "Released item", Status: "open"}},
+	}
+
+	if v := m.view(); !strings.Contains(v, "Up Next") || !strings.Contains(v, "w-9") {
+		t.Errorf("view should contain up next section, got:\n%s", v)
+	}
+	m.cursor = 1
+	if item := m.selectedItem(); item == nil || item.ID != "w-9" {
+		t.Errorf("selected = %+v, want w-9", item)
+	}
+}
+
 func TestRootModel_ProjectFilter_RoundTrip(t *testing.T) {
 	m := New(Config{RigHandle: "test", Upstream: "test/db"})
 	m.browse.loading = false
@@ -1046,6 +1211,50 @@ func TestDetail_DoneSubmitMsg_SetsExecuting(t *testing.T) {
 	}
 }
 
+func TestDetail_DoneSubmitMsg_ConfirmPush(t *testing.T) {
+	m := newDetailForTest("claimed", "other-rig", "test-rig", "wild-west")
+	m.cfg.ConfirmPush = true
+	m.detail.doneForm = newDoneForm()
+
+	result, cmd := m.Update(doneSubmitMsg{evidence: "https://example.com/pr/1"})
+	m2 := result.(Model)
+	if cmd != nil || m2.detail.executing {
+		t.Fatal("done should wait for push confirmation")
+	}
+	if m2.detail.pushConfirm == nil {
+		t.Fatal("pushConfirm should be set")
+	}
+	if v := m2.detail.renderContent(); !strings.Contains(v, "Pushes to upstream") {
+		t.Errorf("view should show the push prompt, got:\n%s", v)
+	}
+
+	// Declining drops the mutation.
+	declined, _ := m2.detail.update(keyMsg("n"))
+	if declined.pushConfirm != nil || declined.executing {
+		t.Error("n should cancel the pending push")
+	}
+
+	// Confirming runs it.
+	confirmed, cmd := m2.detail.update(keyMsg("y"))
+	if confirmed.pushConfirm != nil || !confirmed.executing || confirmed.executingLabel != "Submitting..." {
+		t.Errorf("y should start the mutation: executing=%v label=%q", confirmed.executing, confirmed.executingLabel)
+	}
+	if cmd == nil {
+		t.Error("y should return the mutation cmd")
+	}
+}
+
+func TestDetail_DoneSubmitMsg_ConfirmPushSkippedInPRMode(t *testing.T) {
+	m := newDetailForTest("claimed", "other-rig", "test-rig", "pr")
+	m.cfg.ConfirmPush = true
+	m.detail.doneForm = newDoneForm()
+
+	result, _ := m.Update(doneSubmitMsg{evidence: "https://example.com/pr/1"})
+	if m2 := result.(Model); m2.detail.pushConfirm != nil || !m2.detail.executing {
+		t.Error("PR mode pushes only to the fork and should not ask")
+	}
+}
+
 func TestDetail_AcceptSubmitMsg_SetsExecuting(t *testing.T) {
 	m := newDetailForTest("in_review", "test-rig", "other-rig", "wild-west")
 	m.detail.completion = &commons.CompletionRecord{ID: "c-test"}
@@ -1191,6 +1400,62 @@ func TestDetail_SubmitResultMsg_Error(t *testing.T) {
 	}
 }
 
+func TestDetail_SubmitSquashPreview(t *testing.T) {
+	m := newDetailForTest("done", "other-rig", "test-rig", "pr")
+	m.detail.branch = "wl/test-rig/w-abc123"
+	m.detail.mainStatus = "open"
+	m.detail.submit = newSubmitModel(m.detail.item, m.detail.branch, m.detail.mainStatus, 80, 22)
+	if v := m.detail.submit.renderContent(); !strings.Contains(v, "Commits:     loading...") {
+		t.Errorf("expected loading placeholder:\n%s", v)
+	}
+
+	result, _ := m.Update(submitSquashMsg{preview: &sdk.SquashPreview{
+		Branch:    "wl/test-rig/w-abc123",
+		Commits:   []string{"wl claim: w-abc123", "wl done: w-abc123"},
+		Message:   "wl claim + done: w-abc123",
+		Delta:     "claim + done",
+		CanSquash: true,
+	}})
+	m2 := result.(Model)
+	v := m2.detail.submit.renderContent()
+	for _, want := range []string{"Commits:     2", "wl claim: w-abc123", "Squash into: wl claim + done: w-abc123", "Final delta: claim + done", "s: toggle squash"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("submit view missing %q:\n%s", want, v)
+		}
+	}
+
+	// enter confirms with squash on.
+	_, cmd := m2.Update(keyMsg("enter"))
+	if cmd == nil {
+		t.Fatal("expected submitConfirmMsg cmd")
+	}
+	if msg, ok := cmd().(submitConfirmMsg); !ok || !msg.squash {
+		t.Errorf("expected submitConfirmMsg with squash, got %#v", msg)
+	}
+
+	// s turns squashing off.
+	result, _ = m2.Update(keyMsg("s"))
+	m3 := result.(Model)
+	if v := m3.detail.submit.renderContent(); !strings.Contains(v, "Squash:      off") {
+		t.Errorf("expected squash off:\n%s", v)
+	}
+	_, cmd = m3.Update(keyMsg("enter"))
+	if msg, ok := cmd().(submitConfirmMsg); !ok || msg.squash {
+		t.Errorf("expected submitConfirmMsg without squash, got %#v", msg)
+	}
+}
+
+func TestDetail_SubmitConfirmMsg_SquashLabel(t *testing.T) {
+	m := newDetailForTest("done", "other-rig", "test-rig", "pr")
+	m.detail.branch = "wl/test-rig/w-abc123"
+	m.detail.submit = newSubmitModel(m.detail.item, m.detail.branch, "open", 80, 22)
+
+	result, _ := m.Update(submitConfirmMsg{squash: true})
+	if l := result.(Model).detail.executingLabel; l != "Squashing and creating PR..." {
+		t.Errorf("executingLabel = %q", l)
+	}
+}
+
 func TestDetail_SubmitOpenedMsg_DispatchesFetchDiff(t *testing.T) {
 	diffCalled := false
 	client := sdk.New(sdk.ClientConfig{
@@ -1216,8 +1481,12 @@ func TestDetail_SubmitOpenedMsg_DispatchesFetchDiff(t *testing.T) {
 		t.Fatal("submitOpenedMsg should return fetchDiff cmd")
 	}
 
-	// Execute the cmd.
-	msg := cmd()
+	// The diff and the squash preview load together; execute the diff cmd.
+	batch, ok := cmd().(bubbletea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected a batch of diff and squash preview cmds, got %#v", batch)
+	}
+	msg := batch[0]()
 	diffMsg, ok := msg.(submitDiffMsg)
 	if !ok {
 		t.Fatalf("expected submitDiffMsg, got %T", msg)
@@ -1284,3 +1553,452 @@ func TestDetail_SetData_ClearsForms(t *testing.T) {
 		t.Error("setData should clear submit")
 	}
 }
+
+func TestRootModel_BackendUnavailableInStatusBar(t *testing.T) {
+	m := New(Config{RigHandle: "test", Upstream: "test/db"})
+	m.width = 120
+	m.height = 24
+	m.bar.width = 120
+
+	unavail := &commons.UnavailableError{RetryIn: 30 * time.Second, Err: &commons.NetworkError{Err: fmt.Errorf("HTTP 503")}}
+	result, _ := m.Update(browseDataMsg{err: fmt.Errorf("browse: %w", unavail)})
+	m = result.(Model)
+	if v := m.View(); !strings.Contains(v, "backend unavailable, retry in 30s") {
+		t.Errorf("status bar should show the open circuit, got:\n%s", v)
+	}
+
+	// Other errors leave the notice alone; a successful load clears it.
+	result, _ = m.Update(detailDataMsg{err: fmt.Errorf("not found")})
+	m = result.(Model)
+	if m.bar.unavailableUntil.IsZero() {
+		t.Error("unrelated error cleared the backend notice")
+	}
+	result, _ = m.Update(browseDataMsg{})
+	m = result.(Model)
+	if v := m.View(); strings.Contains(v, "backend unavailable") {
+		t.Errorf("successful load should clear the notice, got:\n%s", v)
+	}
+}
+
+func TestStatusBar_BackendNotice(t *testing.T) {
+	now := time.Now()
+	bar := statusBar{unavailableUntil: now.Add(12 * time.Second)}
+	if got := bar.backendNotice(now); got != "backend unavailable, retry in 12s" {
+		t.Errorf("notice = %q", got)
+	}
+	if got := bar.backendNotice(now.Add(time.Minute)); got != "backend unavailable, retrying" {
+		t.Errorf("notice after cooldown = %q", got)
+	}
+	if got := (statusBar{}).backendNotice(now); got != "" {
+		t.Errorf("notice when reachable = %q", got)
+	}
+}
+
+func TestStatusBar_RateLimitNotices(t *testing.T) {
+	now := time.Now()
+	bar := statusBar{handle: "alice@hop/wl-commons", width: 160, rateLimits: func() []commons.RateLimit {
+		return []commons.RateLimit{
+			{Provider: "dolthub", Limit: 1000, Remaining: 900},
+			{Provider: "github", Limited: true, Reset: now.Add(90 * time.Second)},
+		}
+	}}
+	notices := bar.rateLimitNotices(now)
+	if len(notices) != 1 || !strings.Contains(notices[0], "github: rate limited, retry in 2m") {
+		t.Fatalf("notices = %q, want only the exhausted github quota", notices)
+	}
+	if v := bar.render(""); !strings.Contains(v, "github: rate limited") || strings.Contains(v, "dolthub") {
+		t.Errorf("bar should show low quotas only, got:\n%s", v)
+	}
+	if got := (statusBar{}).rateLimitNotices(now); got != nil {
+		t.Errorf("notices without a reporter = %q", got)
+	}
+}
+
+func TestRootModel_BranchesKey_NavigatesToBranches(t *testing.T) {
+	m := New(Config{RigHandle: "test", Upstream: "test/db"})
+	m.browse.loading = false
+	m.width = 80
+	m.height = 24
+	m.browse.setSize(80, 23)
+
+	_, cmd := m.Update(keyMsg("B"))
+	if cmd == nil {
+		t.Fatal("after 'B': expected a cmd, got nil")
+	}
+	nav, ok := cmd().(navigateMsg)
+	if !ok || nav.view != viewBranches {
+		t.Fatalf("expected navigateMsg to viewBranches, got %#v", nav)
+	}
+
+	result, cmd := m.Update(nav)
+	m2 := result.(Model)
+	if m2.active != viewBranches || !m2.branches.loading {
+		t.Errorf("active = %d, loading = %v; want branches view loading", m2.active, m2.branches.loading)
+	}
+	if cmd == nil {
+		t.Error("expected fetchBranches cmd")
+	}
+}
+
+func newBranchesForTest() Model {
+	m := New(Config{RigHandle: "alice", Upstream: "test/db", Mode: "pr"})
+	m.active = viewBranches
+	m.width = 100
+	m.height = 24
+	result, _ := m.Update(branchesDataMsg{branches: []sdk.BranchInfo{
+		{
+			Branch: "wl/alice/w-1", WantedID: "w-1", Title: "Fix bug", Delta: "claim",
+			LastCommitAt: time.Now().Add(-3 * time.Hour), Actions: []string{"submit_pr", "discard"},
+		},
+		{
+			Branch: "wl/alice/w-2", WantedID: "w-2", Title: "Write docs", Delta: "done",
+			PRURL: "https://example.com/pr/2", Actions: []string{"discard"},
+		},
+	}})
+	return result.(Model)
+}
+
+func TestBranches_View_ShowsContext(t *testing.T) {
+	m := newBranchesForTest()
+	v := m.View()
+	for _, want := range []string{"My Branches", "wl/alice/w-1", "Fix bug", "claim", "3h", "submit, discard", "b: discard"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view missing %q:\n%s", want, v)
+		}
+	}
+
+	// The PR URL is shown for the selected branch.
+	result, _ := m.Update(keyMsg("j"))
+	if v := result.(Model).View(); !strings.Contains(v, "PR: https://example.com/pr/2") {
+		t.Errorf("view missing selected branch PR:\n%s", v)
+	}
+}
+
+func TestBranches_EnterOpensDetail(t *testing.T) {
+	m := newBranchesForTest()
+	_, cmd := m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd from enter, got nil")
+	}
+	nav, ok := cmd().(navigateMsg)
+	if !ok || nav.view != viewDetail || nav.wantedID != "w-1" {
+		t.Errorf("expected navigate to detail w-1, got %#v", nav)
+	}
+}
+
+func TestBranches_DiscardConfirm(t *testing.T) {
+	m := newBranchesForTest()
+
+	result, _ := m.Update(keyMsg("b"))
+	m2 := result.(Model)
+	if m2.branches.confirming != "wl/alice/w-1" {
+		t.Fatalf("confirming = %q, want wl/alice/w-1", m2.branches.confirming)
+	}
+	if !strings.Contains(m2.View(), "Discard wl/alice/w-1?") {
+		t.Errorf("view missing confirmation:\n%s", m2.View())
+	}
+
+	// n cancels.
+	result, _ = m2.Update(keyMsg("n"))
+	if c := result.(Model).branches.confirming; c != "" {
+		t.Errorf("after n: confirming = %q, want empty", c)
+	}
+
+	// y confirms.
+	result, cmd := m2.Update(keyMsg("y"))
+	m3 := result.(Model)
+	if !m3.branches.executing || cmd == nil {
+		t.Fatal("after y: expected executing with a cmd")
+	}
+	msg, ok := cmd().(branchDiscardMsg)
+	if !ok || msg.branch != "wl/alice/w-1" {
+		t.Fatalf("expected branchDiscardMsg for wl/alice/w-1, got %#v", msg)
+	}
+}
+
+func TestBranches_DiscardResult(t *testing.T) {
+	m := newBranchesForTest()
+	m.branches.executing = true
+
+	result, _ := m.Update(branchDiscardResultMsg{branch: "wl/alice/w-1", err: fmt.Errorf("boom")})
+	m2 := result.(Model)
+	if m2.branches.executing || !strings.Contains(m2.branches.result, "boom") {
+		t.Errorf("after error: executing = %v, result = %q", m2.branches.executing, m2.branches.result)
+	}
+
+	result, cmd := m.Update(branchDiscardResultMsg{branch: "wl/alice/w-1"})
+	if !strings.Contains(result.(Model).branches.result, "Discarded wl/alice/w-1") {
+		t.Errorf("result = %q", result.(Model).branches.result)
+	}
+	if cmd == nil {
+		t.Error("expected branch list refetch after discard")
+	}
+}
+
+func TestBranches_BulkDiscardConfirm(t *testing.T) {
+	m := newBranchesForTest()
+
+	result, _ := m.Update(keyMsg("X"))
+	m2 := result.(Model)
+	if m2.branches.bulk != "discard" || !strings.Contains(m2.View(), "Discard all 2 branch(es)?") {
+		t.Fatalf("bulk = %q, view:\n%s", m2.branches.bulk, m2.View())
+	}
+
+	result, _ = m2.Update(keyMsg("n"))
+	if b := result.(Model).branches.bulk; b != "" {
+		t.Errorf("after n: bulk = %q, want empty", b)
+	}
+
+	result, cmd := m2.Update(keyMsg("y"))
+	if !result.(Model).branches.executing || cmd == nil {
+		t.Fatal("after y: expected executing with a cmd")
+	}
+	if msg, ok := cmd().(branchBulkMsg); !ok || msg.action != "discard" {
+		t.Fatalf("expected branchBulkMsg discard, got %#v", msg)
+	}
+}
+
+func TestBranches_ApplyAllNothingToApply(t *testing.T) {
+	m := newBranchesForTest()
+
+	result, cmd := m.Update(keyMsg("A"))
+	m2 := result.(Model)
+	if m2.branches.bulk != "" || cmd != nil {
+		t.Errorf("bulk = %q, cmd = %v; want no confirmation", m2.branches.bulk, cmd)
+	}
+	if !strings.Contains(m2.branches.result, "No branches to apply") {
+		t.Errorf("result = %q", m2.branches.result)
+	}
+}
+
+func TestBranches_BulkResult(t *testing.T) {
+	m := newBranchesForTest()
+	m.branches.executing = true
+
+	result, cmd := m.Update(branchBulkResultMsg{action: "apply", results: []sdk.BranchResult{
+		{Branch: "wl/alice/w-1"},
+		{Branch: "wl/alice/w-2", Err: fmt.Errorf("conflict")},
+	}})
+	m2 := result.(Model)
+	if m2.branches.executing {
+		t.Error("executing should be cleared")
+	}
+	if r := m2.branches.result; !strings.Contains(r, "Applied 1 branch(es), 1 failed") || !strings.Contains(r, "conflict") {
+		t.Errorf("result = %q", r)
+	}
+	if cmd == nil {
+		t.Error("expected branch list refetch after bulk action")
+	}
+}
+
+func TestRootModel_ReconcileMsg_ShowsNotice(t *testing.T) {
+	m := New(Config{RigHandle: "alice", Upstream: "test/db", Mode: "pr"})
+	m.width = 100
+
+	result, cmd := m.Update(reconcileMsg{branches: []sdk.ReconciledBranch{
+		{Branch: "wl/alice/w-1", WantedID: "w-1", PRState: "merged"},
+		{Branch: "wl/alice/w-2", WantedID: "w-2", PRState: "closed"},
+	}})
+	m2 := result.(Model)
+	if !strings.Contains(m2.View(), "cleaned up 2 merged/closed branch(es)") {
+		t.Errorf("view missing notice:\n%s", m2.View())
+	}
+	if cmd == nil {
+		t.Error("expected browse refetch after reconcile")
+	}
+
+	// The next key press clears the notice.
+	result, _ = m2.Update(keyMsg("j"))
+	if n := result.(Model).bar.notice; n != "" {
+		t.Errorf("notice after key = %q, want empty", n)
+	}
+}
+
+func TestRootModel_ReconcileMsg_NothingToDo(t *testing.T) {
+	m := New(Config{RigHandle: "alice", Upstream: "test/db", Mode: "pr"})
+
+	result, cmd := m.Update(reconcileMsg{})
+	if result.(Model).bar.notice != "" || cmd != nil {
+		t.Errorf("notice = %q, cmd = %v; want no-op", result.(Model).bar.notice, cmd)
+	}
+}
+
+func TestRootModel_NotifyMsg(t *testing.T) {
+	calls := 0
+	m := New(Config{RigHandle: "alice", Upstream: "test/db", CheckNotify: func() ([]string, error) {
+		calls++
+		return []string{`w-1 "Fix docs": claimed → in_review`}, nil
+	}})
+	m.width = 120
+
+	result, cmd := m.Update(notifyMsg{changes: []string{`w-1 "A": open → claimed by bob`, `w-2 "B": claimed → in_review`}})
+	m2 := result.(Model)
+	if want := `w-2 "B": claimed → in_review (+1 more)`; m2.bar.notice != want {
+		t.Errorf("notice = %q, want %q", m2.bar.notice, want)
+	}
+	if cmd == nil {
+		t.Fatal("expected the next check to be scheduled")
+	}
+
+	// The tick runs the check again.
+	_, cmd = m2.Update(notifyTickMsg{})
+	if cmd == nil {
+		t.Fatal("expected a check command on tick")
+	}
+	if msg, ok := cmd().(notifyMsg); !ok || len(msg.changes) != 1 || calls != 1 {
+		t.Errorf("check result = %+v, calls = %d", msg, calls)
+	}
+}
+
+func TestRootModel_NotifyMsg_NoChanges(t *testing.T) {
+	m := New(Config{RigHandle: "alice", Upstream: "test/db"})
+	m.bar.notice = "earlier"
+
+	result, cmd := m.Update(notifyMsg{})
+	if n := result.(Model).bar.notice; n != "earlier" {
+		t.Errorf("notice = %q, want it left alone", n)
+	}
+	if cmd == nil {
+		t.Error("expected the next check to be scheduled")
+	}
+}
+
+func TestDetailView_ApprovalProgress(t *testing.T) {
+	m := newDetailForTest("in_review", "someone", "worker", "wild-west")
+	m.detail.setData(detailDataMsg{
+		item:       m.detail.item,
+		completion: &commons.CompletionRecord{ID: "c-1", WantedID: "w-abc123", CompletedBy: "worker"},
+		quorum:     2,
+		approvals:  []commons.Approval{{CompletionID: "c-1", Approver: "carol"}},
+	})
+	if content := m.detail.renderContent(); !strings.Contains(content, "Approvals:   1/2 approvals (carol)") {
+		t.Errorf("content missing approval progress:\n%s", content)
+	}
+}
+
+func TestDetailView_FollowUps(t *testing.T) {
+	m := newDetailForTest("completed", "someone", "worker", "wild-west")
+	item := *m.detail.item
+	item.ParentID = "w-parent"
+	m.detail.setData(detailDataMsg{
+		item:      &item,
+		followUps: []commons.WantedItem{{ID: "w-next", Title: "Cover the retry path", Status: "open"}},
+	})
+	content := m.detail.renderContent()
+	if !strings.Contains(content, "Follows up:  w-parent") || !strings.Contains(content, "w-next  open        Cover the retry path") {
+		t.Errorf("content missing follow-up links:\n%s", content)
+	}
+}
+
+func TestDetailView_MultipleEvidence(t *testing.T) {
+	m := newDetailForTest("in_review", "someone", "worker", "wild-west")
+	m.detail.setData(detailDataMsg{
+		item:       m.detail.item,
+		completion: &commons.CompletionRecord{ID: "c-1", CompletedBy: "worker", Evidence: "https://x/pull/1; abc1234"},
+		evidence: []commons.Evidence{
+			{Kind: commons.EvidencePR, Value: "https://x/pull/1", Verified: true},
+			{Kind: commons.EvidenceCommit, Value: "abc1234"},
+		},
+	})
+	content := m.detail.renderContent()
+	if !strings.Contains(content, "Evidence:    2 entries") || !strings.Contains(content, "commit    abc1234") {
+		t.Errorf("content missing evidence list:\n%s", content)
+	}
+	if strings.Count(content, "✓ verified") != 1 {
+		t.Errorf("content should mark only the verified entry:\n%s", content)
+	}
+}
+
+func TestDetailView_ClaimAge(t *testing.T) {
+	m := newDetailForTest("claimed", "someone", "worker", "wild-west")
+	item := *m.detail.item
+	item.UpdatedAt = time.Now().UTC().Add(-12 * 24 * time.Hour).Format(time.DateTime)
+	m.detail.setData(detailDataMsg{item: &item, claimExpiry: 14 * 24 * time.Hour})
+
+	content := m.detail.renderContent()
+	if !strings.Contains(content, "12d idle, expires in 1d") {
+		t.Errorf("content missing claim age:\n%s", content)
+	}
+}
+
+func TestDetailView_Queue(t *testing.T) {
+	m := newDetailForTest("claimed", "someone", "worker", "wild-west")
+	m.detail.setData(detailDataMsg{item: m.detail.item, queue: []commons.QueueEntry{
+		{RigHandle: "bob", AutoClaim: true},
+		{RigHandle: "carol"},
+	}})
+
+	if content := m.detail.renderContent(); !strings.Contains(content, "Queue:       bob (auto), carol") {
+		t.Errorf("content missing claim queue:\n%s", content)
+	}
+}
+
+func TestDelta_KeyOpensViewAndEnterOpensDetail(t *testing.T) {
+	m := New(Config{RigHandle: "alice", Upstream: "test/db", Mode: "pr"})
+	m.browse.loading = false
+	m.width = 100
+	m.height = 24
+
+	_, cmd := m.Update(keyMsg("d"))
+	if cmd == nil {
+		t.Fatal("after 'd': expected a cmd, got nil")
+	}
+	nav, ok := cmd().(navigateMsg)
+	if !ok || nav.view != viewDelta {
+		t.Fatalf("expected navigateMsg to viewDelta, got %#v", nav)
+	}
+	result, _ := m.Update(nav)
+	m = result.(Model)
+	if m.active != viewDelta || !m.delta.loading {
+		t.Fatalf("active = %d, loading = %v; want delta view loading", m.active, m.delta.loading)
+	}
+
+	result, _ = m.Update(deltaDataMsg{deltas: []sdk.ItemDelta{{
+		WantedID: "w-1", Title: "Fix bug", Branch: "wl/alice/w-1", Delta: "claim",
+		Changes: []commons.RowChange{{Table: "wanted", ID: "w-1", DiffType: "modified", Fields: []commons.FieldChange{
+			{Field: "status", From: "open", To: "claimed"},
+		}}},
+	}}})
+	m = result.(Model)
+	v := m.View()
+	for _, want := range []string{"Pending Changes vs Main", "w-1", "Fix bug", "wl/alice/w-1", "claim", "status", "open → claimed"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view missing %q:\n%s", want, v)
+		}
+	}
+
+	_, cmd = m.Update(bubbletea.KeyMsg{Type: bubbletea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd from enter, got nil")
+	}
+	if nav, ok := cmd().(navigateMsg); !ok || nav.view != viewDetail || nav.wantedID != "w-1" {
+		t.Errorf("expected navigate to detail w-1, got %#v", nav)
+	}
+}
+
+func TestDelta_Empty(t *testing.T) {
+	m := New(Config{RigHandle: "alice", Upstream: "test/db"})
+	m.active = viewDelta
+	m.width = 100
+	m.height = 24
+	result, _ := m.Update(deltaDataMsg{})
+	if v := result.(Model).View(); !strings.Contains(v, "No pending changes") {
+		t.Errorf("view missing empty state:\n%s", v)
+	}
+}
+
+func TestRenderSelected_ColorlessMarker(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	lipgloss.SetColorProfile(termenv.Ascii)
+	if got := renderSelected("  w-1 title", 40); got != "> w-1 title" {
+		t.Errorf("colorless renderSelected = %q, want a > marker", got)
+	}
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	got := renderSelected("  w-1 title", 40)
+	if !strings.Contains(got, "\x1b[") || !strings.Contains(got, "  w-1 title") {
+		t.Errorf("colored renderSelected = %q, want a highlighted line", got)
+	}
+}
@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is the schema_version this release's commons schema records.
+var Version = versionRe.FindStringSubmatch(SQL)[1]
+
+var versionRe = regexp.MustCompile(`'schema_version',\s*'([^']+)'`)
+
+// Column is one column definition from a CREATE TABLE statement.
+type Column struct {
+	Name string
+	Def  string // full definition, e.g. "status VARCHAR(32) DEFAULT 'open'"
+}
+
+// constraintPrefixes start table-level clauses that aren't columns.
+var constraintPrefixes = []string{"PRIMARY ", "UNIQUE ", "KEY ", "INDEX ", "CHECK ", "CHECK(", "CONSTRAINT ", "FOREIGN "}
+
+// Columns returns the columns each CREATE TABLE in ddl defines, keyed by
+// table name. It expects one column per line, as in commons.sql.
+func Columns(ddl string) map[string][]Column {
+	out := map[string][]Column{}
+	for _, stmt := range strings.Split(ddl, ";") {
+		m := createRe.FindStringSubmatchIndex(stmt)
+		if m == nil {
+			continue
+		}
+		table := stmt[m[2]:m[3]]
+		body := stmt[m[1]:]
+		start, end := strings.Index(body, "("), strings.LastIndex(body, ")")
+		if start < 0 || end <= start {
+			continue
+		}
+		cols := []Column{}
+		for _, line := range strings.Split(body[start+1:end], "\n") {
+			line = strings.TrimSuffix(strings.TrimSpace(line), ",")
+			if line == "" || isConstraint(line) {
+				continue
+			}
+			name, _, _ := strings.Cut(line, " ")
+			cols = append(cols, Column{Name: strings.Trim(name, "`"), Def: line})
+		}
+		out[table] = cols
+	}
+	return out
+}
+
+func isConstraint(line string) bool {
+	upper := strings.ToUpper(line)
+	for _, p := range constraintPrefixes {
+		if strings.HasPrefix(upper, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareVersions compares dotted schema versions numerically, returning
+// -1, 0 or 1. Non-numeric parts compare as strings.
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range max(len(as), len(bs)) {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xn, xerr := strconv.Atoi(x)
+		yn, yerr := strconv.Atoi(y)
+		switch {
+		case xerr == nil && yerr == nil && xn != yn:
+			if xn < yn {
+				return -1
+			}
+			return 1
+		case (xerr != nil || yerr != nil) && x != y:
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -3,7 +3,7 @@ CREATE TABLE IF NOT EXISTS _meta (
     value TEXT
 );
 
-INSERT IGNORE INTO _meta (`key`, value) VALUES ('schema_version', '1.2');
+INSERT IGNORE INTO _meta (`key`, value) VALUES ('schema_version', '1.3');
 
 CREATE TABLE IF NOT EXISTS rigs (
     handle VARCHAR(255) PRIMARY KEY,
@@ -35,10 +35,18 @@ CREATE TABLE IF NOT EXISTS wanted (
     sandbox_required TINYINT(1) DEFAULT 0,
     sandbox_scope JSON,
     sandbox_min_tier VARCHAR(32),
+    parent_id VARCHAR(64),
     created_at TIMESTAMP,
     updated_at TIMESTAMP
 );
 
+CREATE TABLE IF NOT EXISTS tags (
+    tag VARCHAR(64) PRIMARY KEY,
+    aliases JSON,
+    description TEXT,
+    created_at TIMESTAMP
+);
+
 CREATE TABLE IF NOT EXISTS completions (
     id VARCHAR(64) PRIMARY KEY,
     wanted_id VARCHAR(64),
@@ -95,7 +103,9 @@ CREATE TABLE IF NOT EXISTS chain_meta (
     parent_chain_id VARCHAR(64),
     hop_uri VARCHAR(512),
     dolt_database VARCHAR(255),
-    created_at TIMESTAMP
+    created_at TIMESTAMP,
+    digest VARCHAR(64),
+    rig_handle VARCHAR(255)
 );
 
 CREATE TABLE IF NOT EXISTS rig_links (
@@ -113,3 +123,75 @@ CREATE TABLE IF NOT EXISTS rig_links (
     UNIQUE KEY uq_rig_pair (rig_a, rig_b),
     CHECK (rig_a != rig_b)
 );
+
+CREATE TABLE IF NOT EXISTS review_comments (
+    id VARCHAR(64) PRIMARY KEY,
+    branch VARCHAR(255) NOT NULL,
+    wanted_id VARCHAR(64),
+    table_name VARCHAR(64),
+    row_key VARCHAR(255),
+    column_name VARCHAR(64),
+    author VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS accept_approvals (
+    completion_id VARCHAR(64) NOT NULL,
+    approver VARCHAR(255) NOT NULL,
+    wanted_id VARCHAR(64),
+    stamp_id VARCHAR(64),
+    approved_at TIMESTAMP,
+    PRIMARY KEY (completion_id, approver)
+);
+
+CREATE TABLE IF NOT EXISTS completion_evidence (
+    completion_id VARCHAR(64) NOT NULL,
+    position INT NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    value TEXT NOT NULL,
+    verified TINYINT(1) DEFAULT 0,
+    PRIMARY KEY (completion_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS claim_queue (
+    wanted_id VARCHAR(64) NOT NULL,
+    rig_handle VARCHAR(255) NOT NULL,
+    auto_claim TINYINT(1) DEFAULT 0,
+    queued_at TIMESTAMP,
+    PRIMARY KEY (wanted_id, rig_handle)
+);
+
+-- Report views. Reports and dashboards query these instead of repeating
+-- the SQL. Columns are stable and thresholds are left to the query.
+-- (Statements are split on semicolons, so comments must not use them.)
+
+-- Claimed items idle for at least the claim_expiry_days in _meta, or none
+-- when claims don't expire.
+CREATE OR REPLACE VIEW stale_claims AS
+SELECT w.id, w.title, w.posted_by, w.claimed_by, w.updated_at,
+    TIMESTAMPDIFF(DAY, w.updated_at, NOW()) AS idle_days,
+    CAST(m.value AS SIGNED) AS expiry_days
+FROM wanted w
+JOIN _meta m ON m.`key` = 'claim_expiry_days'
+WHERE w.status = 'claimed'
+    AND CAST(m.value AS SIGNED) >= 1
+    AND TIMESTAMPDIFF(SECOND, w.updated_at, NOW()) >= CAST(m.value AS SIGNED) * 86400;
+
+-- Open items with how long they have waited for a claim.
+CREATE OR REPLACE VIEW aging_open_items AS
+SELECT id, title, project, type, priority, posted_by, created_at, updated_at,
+    TIMESTAMPDIFF(DAY, created_at, NOW()) AS age_days,
+    TIMESTAMPDIFF(DAY, COALESCE(updated_at, created_at), NOW()) AS idle_days
+FROM wanted
+WHERE status = 'open';
+
+-- Completions waiting for review, one row per item, with the rig expected
+-- to review it (the poster) and how long the submission has waited.
+CREATE OR REPLACE VIEW reviewer_backlog AS
+SELECT w.posted_by AS reviewer, w.id AS wanted_id, w.title, w.project,
+    c.id AS completion_id, c.completed_by, c.completed_at,
+    TIMESTAMPDIFF(DAY, COALESCE(c.completed_at, w.updated_at), NOW()) AS waiting_days
+FROM wanted w
+LEFT JOIN completions c ON c.wanted_id = w.id
+WHERE w.status = 'in_review';
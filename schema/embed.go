@@ -1,10 +1,94 @@
 // Package schema provides the canonical commons database schema.
 package schema
 
-import _ "embed"
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 // SQL contains the commons schema DDL (CREATE TABLEs and seed data).
 // It does not include DOLT_ADD/DOLT_COMMIT — callers handle that.
 //
 //go:embed commons.sql
 var SQL string
+
+// Schema templates accepted by wl create. TemplateCustom marks a
+// wasteland created from a caller-supplied SQL file.
+const (
+	TemplateFull    = "full"
+	TemplateMinimal = "minimal"
+	TemplateCustom  = "custom"
+)
+
+// Templates lists the built-in schema templates.
+var Templates = []string{TemplateFull, TemplateMinimal}
+
+// minimalTables are the tables a minimal wasteland needs to post, claim
+// and browse wanted items, plus the report views over them.
+var minimalTables = map[string]bool{
+	"_meta": true, "rigs": true, "wanted": true,
+	"stale_claims": true, "aging_open_items": true,
+}
+
+var (
+	// createRe extracts the table a CREATE TABLE statement creates.
+	createRe = regexp.MustCompile("(?i)CREATE TABLE IF NOT EXISTS `?(\\w+)`?")
+	// viewRe extracts the view a CREATE VIEW statement creates.
+	viewRe = regexp.MustCompile("(?i)CREATE OR REPLACE VIEW `?(\\w+)`?")
+	// tableRe extracts the table or view a DDL or seed statement applies to.
+	tableRe = regexp.MustCompile("(?i)(?:CREATE TABLE IF NOT EXISTS|INSERT IGNORE INTO|CREATE OR REPLACE VIEW) `?(\\w+)`?")
+)
+
+// Template returns the DDL for a built-in schema template.
+func Template(name string) (string, error) {
+	switch name {
+	case "", TemplateFull:
+		return SQL, nil
+	case TemplateMinimal:
+		return filterTables(SQL, tableRe, minimalTables), nil
+	}
+	return "", fmt.Errorf("unknown schema template %q (want %s)", name, strings.Join(Templates, " or "))
+}
+
+// Tables returns the names of the tables ddl creates, in order.
+func Tables(ddl string) []string {
+	var out []string
+	for _, m := range createRe.FindAllStringSubmatch(ddl, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// Views returns the names of the views ddl creates, in order.
+func Views(ddl string) []string {
+	var out []string
+	for _, m := range viewRe.FindAllStringSubmatch(ddl, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// Custom prepares a caller-supplied schema: it makes sure _meta exists so
+// wl can record metadata, and defaults schema_version to "custom" unless
+// ddl sets its own.
+func Custom(ddl string) string {
+	meta := filterTables(SQL, createRe, map[string]bool{"_meta": true})
+	return meta + ddl + "\nINSERT IGNORE INTO _meta (`key`, value) VALUES ('schema_version', 'custom');\n"
+}
+
+// filterTables keeps the statements of ddl that re matches for one of
+// tables.
+func filterTables(ddl string, re *regexp.Regexp, tables map[string]bool) string {
+	var b strings.Builder
+	for _, stmt := range strings.Split(ddl, ";") {
+		m := re.FindStringSubmatch(stmt)
+		if m == nil || !tables[m[1]] {
+			continue
+		}
+		b.WriteString(strings.TrimSpace(stmt))
+		b.WriteString(";\n\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTemplate_Full(t *testing.T) {
+	got, err := Template(TemplateFull)
+	if err != nil || got != SQL {
+		t.Errorf("Template(full) should return the commons schema, err = %v", err)
+	}
+}
+
+func TestTemplate_Minimal(t *testing.T) {
+	got, err := Template(TemplateMinimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tables := Tables(got); !slices.Equal(tables, []string{"_meta", "rigs", "wanted"}) {
+		t.Errorf("minimal tables = %v", tables)
+	}
+	if !strings.Contains(got, "'schema_version'") {
+		t.Error("minimal schema should record schema_version")
+	}
+	if views := Views(got); !slices.Equal(views, []string{"stale_claims", "aging_open_items"}) {
+		t.Errorf("minimal views = %v, want those over wanted only", views)
+	}
+	for _, table := range []string{"stamps", "badges", "chain_meta"} {
+		if strings.Contains(got, table) {
+			t.Errorf("minimal schema should not create %s", table)
+		}
+	}
+}
+
+func TestTemplate_Unknown(t *testing.T) {
+	if _, err := Template("huge"); err == nil || !strings.Contains(err.Error(), "unknown schema template") {
+		t.Errorf("Template(huge) error = %v", err)
+	}
+}
+
+func TestCustom(t *testing.T) {
+	got := Custom("CREATE TABLE IF NOT EXISTS notes (id INT PRIMARY KEY);")
+	if tables := Tables(got); !slices.Equal(tables, []string{"_meta", "notes"}) {
+		t.Errorf("custom tables = %v", tables)
+	}
+	if !strings.Contains(got, "('schema_version', 'custom')") {
+		t.Errorf("custom schema should default schema_version:\n%s", got)
+	}
+	if strings.Contains(got, "'"+Version+"'") {
+		t.Error("custom schema should not claim the commons schema version")
+	}
+}
+
+func TestViews(t *testing.T) {
+	if views := Views(SQL); !slices.Equal(views, []string{"stale_claims", "aging_open_items", "reviewer_backlog"}) {
+		t.Errorf("views = %v", views)
+	}
+	if slices.Contains(Tables(SQL), "stale_claims") {
+		t.Error("Tables should not list views")
+	}
+	if strings.Contains(Custom("CREATE TABLE IF NOT EXISTS notes (id INT PRIMARY KEY);"), "VIEW") {
+		t.Error("custom schema should not create the commons views")
+	}
+}
+
+func TestVersion(t *testing.T) {
+	if Version == "" || !strings.Contains(SQL, "'"+Version+"'") {
+		t.Errorf("Version = %q, want the schema_version seeded by commons.sql", Version)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	cols := Columns(SQL)
+	if len(cols) != len(Tables(SQL)) {
+		t.Errorf("Columns found %d tables, Tables %d", len(cols), len(Tables(SQL)))
+	}
+	var names []string
+	for _, c := range cols["rig_links"] {
+		names = append(names, c.Name)
+	}
+	if len(names) != 11 || names[0] != "id" || slices.Contains(names, "UNIQUE") || slices.Contains(names, "CHECK") {
+		t.Errorf("rig_links columns = %v", names)
+	}
+	if got := cols["wanted"][9]; got.Name != "status" || got.Def != "status VARCHAR(32) DEFAULT 'open'" {
+		t.Errorf("wanted column 10 = %+v", got)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2", "1.2", 0},
+		{"1.1", "1.2", -1},
+		{"1.10", "1.2", 1},
+		{"2", "1.9", 1},
+		{"1.2", "1.2.1", -1},
+		{"custom", "1.2", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -8,8 +8,10 @@ import (
 )
 
 func TestBrowseIntegration(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 			env.createUpstreamStoreWithData(t, upstreamOrg, upstreamDB)
 			env.joinWasteland(t, upstream, forkOrg)
@@ -27,8 +29,10 @@ func TestBrowseIntegration(t *testing.T) {
 }
 
 func TestBrowseJSON(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 			env.createUpstreamStoreWithData(t, upstreamOrg, upstreamDB)
 			env.joinWasteland(t, upstream, forkOrg)
@@ -46,8 +50,10 @@ func TestBrowseJSON(t *testing.T) {
 }
 
 func TestBrowseFilterByType(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 			env.createUpstreamStoreWithData(t, upstreamOrg, upstreamDB)
 			env.joinWasteland(t, upstream, forkOrg)
@@ -44,8 +44,10 @@ func forkCloneDir(t *testing.T, env *testEnv) string {
 }
 
 func TestJoinCreatesConfig(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			cfg := env.loadConfig(t, upstream)
@@ -103,8 +105,10 @@ func TestJoinCreatesConfig(t *testing.T) {
 }
 
 func TestJoinAlreadyJoined(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Second join to same upstream should succeed (no-op).
@@ -122,8 +126,10 @@ func TestJoinAlreadyJoined(t *testing.T) {
 }
 
 func TestSchemaInit(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -149,8 +155,10 @@ func TestSchemaInit(t *testing.T) {
 }
 
 func TestPostWanted(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -203,8 +211,10 @@ func TestPostWanted(t *testing.T) {
 }
 
 func TestClaimWanted(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -238,8 +248,10 @@ func TestClaimWanted(t *testing.T) {
 }
 
 func TestClaimAlreadyClaimed(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -272,8 +284,10 @@ func TestClaimAlreadyClaimed(t *testing.T) {
 }
 
 func TestDoneFullLifecycle(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -320,8 +334,10 @@ func TestDoneFullLifecycle(t *testing.T) {
 }
 
 func TestDoneWrongClaimer(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -357,8 +373,10 @@ func TestDoneWrongClaimer(t *testing.T) {
 }
 
 func TestDoneUnclaimed(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -390,8 +408,10 @@ func TestDoneUnclaimed(t *testing.T) {
 }
 
 func TestPostOutput(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 
 			stdout, _, err := runWL(t, env, "post", "--title", "Output format test", "--type", "docs", "--no-push")
@@ -413,8 +433,10 @@ func TestPostOutput(t *testing.T) {
 }
 
 func TestAcceptFullLifecycle(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -490,8 +512,10 @@ func TestAcceptFullLifecycle(t *testing.T) {
 }
 
 func TestAcceptSelfReject(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 
 			// Post → claim → done as forkOrg.
@@ -521,8 +545,10 @@ func TestAcceptSelfReject(t *testing.T) {
 }
 
 func TestRejectFullLifecycle(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -605,8 +631,10 @@ func TestRejectFullLifecycle(t *testing.T) {
 }
 
 func TestUpdateWanted(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -646,8 +674,10 @@ func TestUpdateWanted(t *testing.T) {
 }
 
 func TestUpdateClaimedFails(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 
 			// Post and claim.
@@ -672,8 +702,10 @@ func TestUpdateClaimedFails(t *testing.T) {
 }
 
 func TestUnclaimWanted(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -716,8 +748,10 @@ func TestUnclaimWanted(t *testing.T) {
 }
 
 func TestUnclaimByPoster(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -764,8 +798,10 @@ func TestUnclaimByPoster(t *testing.T) {
 }
 
 func TestDeleteWanted(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -796,8 +832,10 @@ func TestDeleteWanted(t *testing.T) {
 }
 
 func TestDeleteClaimedFails(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedLifecycleEnv(t, backend)
 
 			// Post and claim.
@@ -843,8 +881,10 @@ func writeConfig(t *testing.T, env *testEnv, upstreamPath, rigHandle string) {
 // --- Multi-wasteland tests ---
 
 func TestMultiWastelandJoin(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 
 			// Create two upstream stores.
@@ -874,8 +914,10 @@ func TestMultiWastelandJoin(t *testing.T) {
 }
 
 func TestMultiWasteland_RequiresFlag(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 
 			// Create and join two upstreams.
@@ -908,8 +950,10 @@ func TestMultiWasteland_RequiresFlag(t *testing.T) {
 }
 
 func TestLeave(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 
 			// Create and join.
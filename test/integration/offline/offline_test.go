@@ -13,6 +13,7 @@
 package offline
 
 import (
+	"database/sql"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -56,7 +57,16 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	// Share one dolt sql-server across tests for upstream work databases.
+	sharedServer, err = startDoltServer(filepath.Join(tmpDir, "sql-server"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "starting shared dolt sql-server: %v\n", err)
+		os.RemoveAll(tmpDir)
+		os.Exit(1)
+	}
+
 	code := m.Run()
+	sharedServer.stop()
 	os.RemoveAll(tmpDir)
 	os.Exit(code)
 }
@@ -101,6 +111,8 @@ type testEnv struct {
 	Home       string      // HOME override
 	RemoteBase string      // base dir for file:// remote stores
 	Backend    backendKind // "file" or "git"
+
+	upstreams map[string]*sql.DB // org/db -> upstream work database
 }
 
 func newTestEnv(t *testing.T, backend backendKind) *testEnv {
@@ -142,6 +154,7 @@ func newTestEnv(t *testing.T, backend backendKind) *testEnv {
 		Home:       home,
 		RemoteBase: remoteBase,
 		Backend:    backend,
+		upstreams:  make(map[string]*sql.DB),
 	}
 }
 
@@ -163,47 +176,43 @@ func (e *testEnv) envSlice() []string {
 // "wl join" can fork from.
 func (e *testEnv) createUpstreamStore(t *testing.T, org, db string) {
 	t.Helper()
-
-	// Step 1: init a temp working directory with the schema.
-	workDir := filepath.Join(e.Root, "upstream-work-"+org+"-"+db)
-	initDoltDB(t, e, workDir)
-	doltSQLScript(t, e, workDir, wlCommonsSchema())
-
-	// Step 2: create remote store and push into it.
-	storeURL := e.createStoreDir(t, org, db)
-	doltCmd(t, e, workDir, "remote", "add", "store", storeURL)
-	doltCmd(t, e, workDir, "push", "store", "main")
+	e.initUpstream(t, org, db, wlCommonsSchema())
 }
 
 // createUpstreamStoreWithData creates an upstream and adds seed data.
 func (e *testEnv) createUpstreamStoreWithData(t *testing.T, org, db string) {
 	t.Helper()
-
-	// Step 1: init a temp working directory with the schema + seed data.
-	workDir := filepath.Join(e.Root, "upstream-work-"+org+"-"+db)
-	initDoltDB(t, e, workDir)
-	doltSQLScript(t, e, workDir, wlCommonsSchema())
-
 	seedSQL := `INSERT INTO wanted (id, title, status, type, priority, effort_level, created_at, updated_at)
 VALUES ('w-seed001', 'Seed item from upstream', 'open', 'feature', 2, 'medium', NOW(), NOW());
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'Seed upstream data');
 `
-	doltSQLScript(t, e, workDir, seedSQL)
+	e.initUpstream(t, org, db, wlCommonsSchema()+seedSQL)
+}
+
+// initUpstream builds an upstream in a work database on the shared
+// sql-server by running script, then pushes it to a new remote store.
+func (e *testEnv) initUpstream(t *testing.T, org, db, script string) {
+	t.Helper()
+	work := sharedServer.createDatabase(t, org+"-"+db)
+	execScript(t, work, script)
 
-	// Step 2: create remote store and push into it.
 	storeURL := e.createStoreDir(t, org, db)
-	doltCmd(t, e, workDir, "remote", "add", "store", storeURL)
-	doltCmd(t, e, workDir, "push", "store", "main")
+	execScript(t, work, "CALL DOLT_REMOTE('add', 'store', "+sqlQuote(storeURL)+");\n"+
+		"CALL DOLT_PUSH('store', 'main');\n")
+	e.upstreams[org+"/"+db] = work
 }
 
-// pushToUpstreamStore adds data to the upstream by committing in the work dir
-// and pushing to the store. The workDir must already exist from createUpstreamStore*.
+// pushToUpstreamStore adds data to the upstream by running sql in its work
+// database and pushing to the store. The upstream must already exist from
+// createUpstreamStore*.
 func (e *testEnv) pushToUpstreamStore(t *testing.T, org, db, sql string) {
 	t.Helper()
-	workDir := filepath.Join(e.Root, "upstream-work-"+org+"-"+db)
-	doltSQLScript(t, e, workDir, sql)
-	doltCmd(t, e, workDir, "push", "store", "main")
+	work, ok := e.upstreams[org+"/"+db]
+	if !ok {
+		t.Fatalf("no upstream %s/%s: create it first", org, db)
+	}
+	execScript(t, work, sql+"CALL DOLT_PUSH('store', 'main');\n")
 }
 
 // createStoreDir creates the remote store directory for the current backend
@@ -384,46 +393,6 @@ func extractWantedID(t *testing.T, stdout string) string {
 	return match
 }
 
-// initDoltDB initializes a dolt database in a directory.
-func initDoltDB(t *testing.T, env *testEnv, dbDir string) {
-	t.Helper()
-	if err := os.MkdirAll(dbDir, 0o755); err != nil {
-		t.Fatalf("creating db dir: %v", err)
-	}
-
-	cmd := exec.Command(doltPath, "init")
-	cmd.Dir = dbDir
-	cmd.Env = env.envSlice()
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		t.Fatalf("dolt init in %s: %v\n%s", dbDir, err, string(out))
-	}
-}
-
-// doltSQLScript runs a multi-statement SQL script in a dolt database.
-func doltSQLScript(t *testing.T, env *testEnv, dbDir, script string) {
-	t.Helper()
-	tmpFile, err := os.CreateTemp("", "dolt-script-*.sql")
-	if err != nil {
-		t.Fatalf("creating temp SQL file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-
-	if _, err := tmpFile.WriteString(script); err != nil {
-		tmpFile.Close()
-		t.Fatalf("writing SQL script: %v", err)
-	}
-	tmpFile.Close()
-
-	cmd := exec.Command(doltPath, "sql", "--file", tmpFile.Name())
-	cmd.Dir = dbDir
-	cmd.Env = env.envSlice()
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		t.Fatalf("dolt sql script in %s: %v\n%s", dbDir, err, string(out))
-	}
-}
-
 // wlCommonsSchema returns the full SQL schema for wl-commons.
 func wlCommonsSchema() string {
 	return schema.SQL +
@@ -9,8 +9,10 @@ import (
 )
 
 func TestConfigGetProviderType(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			stdout, stderr, err := runWL(t, env, "config", "get", "provider-type")
@@ -28,8 +30,10 @@ func TestConfigGetProviderType(t *testing.T) {
 }
 
 func TestReviewMarkdown(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Switch to PR mode and post.
@@ -66,8 +70,10 @@ func TestReviewMarkdown(t *testing.T) {
 }
 
 func TestReviewJSON(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Switch to PR mode and post.
@@ -101,8 +107,10 @@ func TestReviewJSON(t *testing.T) {
 }
 
 func TestPRModePost(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -137,8 +145,10 @@ func TestPRModePost(t *testing.T) {
 }
 
 func TestPRModeClaim(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnvInMode(t, backend, "wild-west")
 			dbDir := forkCloneDir(t, env)
 
@@ -178,8 +188,10 @@ func TestPRModeClaim(t *testing.T) {
 }
 
 func TestPRModeReturnToMain(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -212,8 +224,10 @@ func TestPRModeReturnToMain(t *testing.T) {
 }
 
 func TestWildWestModeUnchanged(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnvInMode(t, backend, "wild-west")
 			dbDir := forkCloneDir(t, env)
 
@@ -251,8 +265,10 @@ func TestWildWestModeUnchanged(t *testing.T) {
 }
 
 func TestReviewListBranches(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Switch to PR mode and post.
@@ -283,8 +299,10 @@ func TestReviewListBranches(t *testing.T) {
 }
 
 func TestReviewShowsDiff(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Switch to PR mode and post.
@@ -315,8 +333,10 @@ func TestReviewShowsDiff(t *testing.T) {
 }
 
 func TestMergeBasic(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -374,8 +394,10 @@ func TestMergeBasic(t *testing.T) {
 }
 
 func TestMergeKeepBranch(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -409,8 +431,10 @@ func TestMergeKeepBranch(t *testing.T) {
 }
 
 func TestMergeNonExistentBranch(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			_, _, err := runWL(t, env, "merge", "wl/fake/w-nonexistent", "--no-push")
@@ -422,8 +446,10 @@ func TestMergeNonExistentBranch(t *testing.T) {
 }
 
 func TestMergeFullLifecycle(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 			dbDir := forkCloneDir(t, env)
 
@@ -467,8 +493,10 @@ func TestMergeFullLifecycle(t *testing.T) {
 }
 
 func TestConfigSetGetMode(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Default mode should be PR.
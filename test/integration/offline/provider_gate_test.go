@@ -8,11 +8,13 @@ import (
 )
 
 func TestProviderGateApprove(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		if backend == githubBackend {
 			continue // on github backend, approve passes the gate
 		}
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			_, stderr, err := runWL(t, env, "approve", "wl/x/w-y")
@@ -27,11 +29,13 @@ func TestProviderGateApprove(t *testing.T) {
 }
 
 func TestProviderGateRequestChanges(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		if backend == githubBackend {
 			continue // on github backend, request-changes passes the gate
 		}
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			_, stderr, err := runWL(t, env, "request-changes", "wl/x/w-y", "--comment", "needs work")
@@ -46,11 +50,13 @@ func TestProviderGateRequestChanges(t *testing.T) {
 }
 
 func TestProviderGateReviewCreatePR(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		if backend == githubBackend {
 			continue // on github backend, --create-pr passes the gate
 		}
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// Switch to PR mode and post.
@@ -79,11 +85,13 @@ func TestProviderGateReviewCreatePR(t *testing.T) {
 }
 
 func TestProviderGateApproveOnGitHub(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		if backend != githubBackend {
 			continue // only test on github backend
 		}
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnv(t, backend)
 
 			// On the github backend, approve should pass the provider gate
@@ -0,0 +1,181 @@
+//go:build integration
+
+package offline
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" driver
+)
+
+// sharedServer is the dolt sql-server that holds every test's upstream
+// work database. Starting one server in TestMain and giving each upstream
+// its own database replaces a dolt init and several dolt CLI invocations
+// per upstream, each of which paid dolt's startup cost.
+var sharedServer *doltServer
+
+// doltServer is a dolt sql-server running over a private data dir.
+type doltServer struct {
+	root   string // data dir; HOME and DOLT_ROOT_PATH for the server
+	addr   string
+	cmd    *exec.Cmd
+	exited chan error
+	seq    atomic.Int64
+}
+
+// startDoltServer starts dolt sql-server on a free loopback port with its
+// databases under root and waits until it accepts connections.
+func startDoltServer(root string) (*doltServer, error) {
+	doltCfgDir := filepath.Join(root, ".dolt")
+	if err := os.MkdirAll(doltCfgDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating dolt config dir: %w", err)
+	}
+	globalCfg := `{"user.name":"test-user","user.email":"test@example.com","user.creds":""}` + "\n"
+	if err := os.WriteFile(filepath.Join(doltCfgDir, "config_global.json"), []byte(globalCfg), 0o644); err != nil {
+		return nil, fmt.Errorf("writing dolt global config: %w", err)
+	}
+	dataDir := filepath.Join(root, "databases")
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating data dir: %w", err)
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	logFile, err := os.Create(filepath.Join(root, "sql-server.log"))
+	if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(doltPath, "sql-server",
+		"--host", "127.0.0.1", "--port", strconv.Itoa(port), "--data-dir", dataDir)
+	cmd.Dir = root
+	cmd.Env = []string{
+		"HOME=" + root,
+		"DOLT_ROOT_PATH=" + root,
+		"PATH=" + os.Getenv("PATH"),
+	}
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting dolt sql-server: %w", err)
+	}
+	s := &doltServer{
+		root:   root,
+		addr:   net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		cmd:    cmd,
+		exited: make(chan error, 1),
+	}
+	go func() { s.exited <- cmd.Wait() }()
+
+	db, err := sql.Open("mysql", "root@tcp("+s.addr+")/")
+	if err != nil {
+		s.stop()
+		return nil, err
+	}
+	defer db.Close()
+	deadline := time.Now().Add(30 * time.Second)
+	for db.Ping() != nil {
+		select {
+		case err := <-s.exited:
+			return nil, fmt.Errorf("dolt sql-server exited during startup: %v (see %s)", err, logFile.Name())
+		case <-time.After(100 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			s.stop()
+			return nil, fmt.Errorf("dolt sql-server did not accept connections on %s (see %s)", s.addr, logFile.Name())
+		}
+	}
+	return s, nil
+}
+
+// stop shuts the server down, killing it if it hasn't exited after a
+// grace period.
+func (s *doltServer) stop() {
+	_ = s.cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-s.exited:
+	case <-time.After(10 * time.Second):
+		_ = s.cmd.Process.Kill()
+		<-s.exited
+	}
+}
+
+// freePort asks the kernel for an unused loopback port.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("finding a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+var dbNameRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// createDatabase creates a database named after t and label, unique for
+// the run, and returns a connection to it that the test's cleanup closes
+// before dropping the database. The connection runs multi-statement
+// scripts and is limited to one session so session state carries across
+// calls.
+func (s *doltServer) createDatabase(t *testing.T, label string) *sql.DB {
+	t.Helper()
+	name := dbNameRe.ReplaceAllString(strings.ToLower(t.Name()+"_"+label), "_")
+	name = strings.Trim(name, "_")
+	if len(name) > 48 {
+		name = name[:48]
+	}
+	name = fmt.Sprintf("%s_%d", name, s.seq.Add(1))
+
+	admin, err := sql.Open("mysql", "root@tcp("+s.addr+")/")
+	if err != nil {
+		t.Fatalf("connecting to sql-server: %v", err)
+	}
+	defer admin.Close()
+	if _, err := admin.Exec("CREATE DATABASE `" + name + "`"); err != nil {
+		t.Fatalf("creating database %s: %v", name, err)
+	}
+
+	db, err := sql.Open("mysql", "root@tcp("+s.addr+")/"+name+"?multiStatements=true")
+	if err != nil {
+		t.Fatalf("connecting to database %s: %v", name, err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+		drop, err := sql.Open("mysql", "root@tcp("+s.addr+")/")
+		if err != nil {
+			return
+		}
+		defer drop.Close()
+		if _, err := drop.Exec("DROP DATABASE `" + name + "`"); err != nil {
+			t.Logf("dropping database %s: %v", name, err)
+		}
+	})
+	return db
+}
+
+// execScript runs a multi-statement SQL script on db.
+func execScript(t *testing.T, db *sql.DB, script string) {
+	t.Helper()
+	if _, err := db.Exec(script); err != nil {
+		t.Fatalf("running SQL script: %v\n%s", err, script)
+	}
+}
+
+// sqlQuote quotes s as a SQL string literal.
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -8,8 +8,10 @@ import (
 )
 
 func TestStatusFullLifecycle(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := joinedEnvInMode(t, backend, "wild-west")
 
 			// 1. Post as forkOrg → status shows "open" + title.
@@ -8,8 +8,10 @@ import (
 )
 
 func TestSyncFromUpstream(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 			env.createUpstreamStoreWithData(t, upstreamOrg, upstreamDB)
 			env.joinWasteland(t, upstream, forkOrg)
@@ -56,8 +58,10 @@ CALL DOLT_COMMIT('-m', 'Add new upstream data');
 }
 
 func TestSyncDryRun(t *testing.T) {
+	t.Parallel()
 	for _, backend := range backends {
 		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
 			env := newTestEnv(t, backend)
 			env.createUpstreamStoreWithData(t, upstreamOrg, upstreamDB)
 			env.joinWasteland(t, upstream, forkOrg)
@@ -8,7 +8,9 @@ import { DetailView } from "./components/DetailView";
 import { Layout } from "./components/Layout";
 import { ProfileSearch } from "./components/ProfileSearch";
 import { ProfileView } from "./components/ProfileView";
+import { Projects } from "./components/Projects";
 import { Scoreboard } from "./components/Scoreboard";
+import { Search } from "./components/Search";
 import { Settings } from "./components/Settings";
 import { WastelandProvider } from "./context/WastelandContext";
 
@@ -34,7 +36,9 @@ export function App() {
               <Route path="/me" element={<Dashboard />} />
               <Route path="/profile" element={<ProfileSearch />} />
               <Route path="/profile/:handle" element={<ProfileView />} />
+              <Route path="/projects" element={<Projects />} />
               <Route path="/scoreboard" element={<Scoreboard />} />
+              <Route path="/search" element={<Search />} />
               <Route path="/settings" element={<Settings />} />
               <Route path="/connect" element={<ConnectPage />} />
               <Route path="/join" element={<ConnectPage />} />
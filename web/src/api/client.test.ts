@@ -20,6 +20,7 @@ import {
   sync,
   unclaim,
   updateItem,
+  updateWastelandSettings,
 } from "./client";
 
 let cleanup: () => void;
@@ -249,4 +250,12 @@ describe("API functions", () => {
     expect(call[1]?.method).toBe("PUT");
     expect(JSON.parse(call[1]?.body as string)).toEqual({ mode: "pr", signing: true });
   });
+
+  it("updateWastelandSettings() calls PUT on the wasteland settings path", async () => {
+    await updateWastelandSettings("hop/wl-commons", { mode: "wild-west" });
+    const call = vi.mocked(globalThis.fetch).mock.calls[0];
+    expect(call[0]).toBe("/api/auth/wastelands/hop/wl-commons/settings");
+    expect(call[1]?.method).toBe("PUT");
+    expect(JSON.parse(call[1]?.body as string)).toEqual({ mode: "wild-west" });
+  });
 });
@@ -1,8 +1,13 @@
 import * as Sentry from "@sentry/react";
 import type {
+  AuditFilter,
+  AuditResponse,
   AuthStatusResponse,
+  BadgeCatalogResponse,
   BrowseFilter,
   BrowseResponse,
+  BulkItem,
+  BulkResponse,
   ConfigResponse,
   ConnectInput,
   ConnectResponse,
@@ -16,9 +21,17 @@ import type {
   PostInput,
   ProfileResponse,
   ProfileSummary,
+  ProjectsResponse,
+  RigBadgesResponse,
   ScoreboardResponse,
+  SearchResponse,
+  SearchType,
   SettingsInput,
+  TagsResponse,
   UpdateInput,
+  VocabularyResponse,
+  WastelandConfig,
+  WastelandSettingsInput,
 } from "./types";
 
 // --- Active upstream tracking (seeded from localStorage to avoid race with context) ---
@@ -141,6 +154,8 @@ function buildQuery(filter: BrowseFilter): string {
   if (filter.priority !== undefined && filter.priority >= 0) params.set("priority", String(filter.priority));
   if (filter.project) params.set("project", filter.project);
   if (filter.search) params.set("search", filter.search);
+  if (filter.q) params.set("q", filter.q);
+  if (filter.tag) params.set("tag", filter.tag);
   if (filter.sort) params.set("sort", filter.sort);
   if (filter.limit) params.set("limit", String(filter.limit));
   if (filter.view && filter.view !== "mine") params.set("view", filter.view);
@@ -164,6 +179,44 @@ export async function config(): Promise<ConfigResponse> {
   return request<ConfigResponse>("/api/config");
 }
 
+export async function tags(): Promise<TagsResponse> {
+  return request<TagsResponse>("/api/tags");
+}
+
+export async function vocabulary(): Promise<VocabularyResponse> {
+  return request<VocabularyResponse>("/api/vocabulary");
+}
+
+export async function projects(): Promise<ProjectsResponse> {
+  return request<ProjectsResponse>("/api/projects");
+}
+
+export async function audit(filter: AuditFilter = {}): Promise<AuditResponse> {
+  const params = new URLSearchParams();
+  if (filter.item) params.set("item", filter.item);
+  if (filter.rig) params.set("rig", filter.rig);
+  if (filter.since) params.set("since", filter.since);
+  if (filter.limit) params.set("limit", String(filter.limit));
+  if (filter.offset) params.set("offset", String(filter.offset));
+  const qs = params.toString();
+  return request<AuditResponse>(`/api/audit${qs ? `?${qs}` : ""}`);
+}
+
+export async function search(q: string, types: SearchType[] = [], limit?: number): Promise<SearchResponse> {
+  const params = new URLSearchParams({ q });
+  if (types.length > 0) params.set("type", types.join(","));
+  if (limit) params.set("limit", String(limit));
+  return request<SearchResponse>(`/api/search?${params}`);
+}
+
+export async function rigBadges(handle: string): Promise<RigBadgesResponse> {
+  return request<RigBadgesResponse>(`/api/rigs/${encodeURIComponent(handle)}/badges`);
+}
+
+export async function badgeCatalog(): Promise<BadgeCatalogResponse> {
+  return request<BadgeCatalogResponse>("/api/badges");
+}
+
 export async function scoreboard(): Promise<ScoreboardResponse> {
   return request<ScoreboardResponse>("/api/scoreboard");
 }
@@ -204,6 +257,8 @@ export async function accept(
     severity?: string;
     skill_tags?: string[];
     message?: string;
+    follow_up_title?: string;
+    follow_up_description?: string;
   },
 ): Promise<MutationResponse> {
   return request<MutationResponse>(`/api/wanted/${id}/accept`, {
@@ -279,6 +334,14 @@ export async function createItem(input: PostInput): Promise<MutationResponse> {
   });
 }
 
+export async function bulk(items: BulkItem[]): Promise<BulkResponse> {
+  return request<BulkResponse>("/api/wanted/bulk", {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ items }),
+  });
+}
+
 export async function updateItem(id: string, input: UpdateInput): Promise<MutationResponse> {
   return request<MutationResponse>(`/api/wanted/${id}`, {
     method: "PATCH",
@@ -331,6 +394,17 @@ export async function leaveWasteland(upstream: string): Promise<void> {
   });
 }
 
+export async function updateWastelandSettings(
+  upstream: string,
+  input: WastelandSettingsInput,
+): Promise<WastelandConfig> {
+  return request<WastelandConfig>(`/api/auth/wastelands/${upstream}/settings`, {
+    method: "PUT",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(input),
+  });
+}
+
 export async function logout(): Promise<void> {
   await request<Record<string, string>>("/api/auth/logout", { method: "POST" });
 }
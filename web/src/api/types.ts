@@ -36,10 +36,23 @@ export interface WantedItem {
   claimed_by?: string;
   status: string;
   effort_level: string;
+  parent_id?: string;
   created_at?: string;
   updated_at?: string;
 }
 
+export interface Evidence {
+  kind: string;
+  value: string;
+  verified?: boolean;
+}
+
+export interface QueueEntry {
+  rig_handle: string;
+  auto_claim?: boolean;
+  queued_at?: string;
+}
+
 export interface Completion {
   id: string;
   wanted_id: string;
@@ -87,6 +100,11 @@ export interface DetailResponse {
   branch_actions: string[];
   mode: string;
   upstream_prs?: UpstreamPR[];
+  quorum?: number;
+  approvals?: string[];
+  follow_ups?: WantedItem[];
+  evidence?: Evidence[];
+  queue?: QueueEntry[];
 }
 
 export interface MutationResponse {
@@ -95,10 +113,55 @@ export interface MutationResponse {
   hint?: string;
 }
 
+export type BulkAction = "claim" | "unclaim" | "close" | "delete" | "reject";
+
+export interface BulkItem {
+  id: string;
+  action: BulkAction;
+}
+
+export interface BulkItemResult {
+  id: string;
+  action: BulkAction;
+  ok: boolean;
+  status?: number;
+  error?: string;
+  result?: MutationResponse;
+}
+
+export interface BulkResponse {
+  results: BulkItemResult[];
+}
+
+export interface ReputationStamp {
+  author: string;
+  severity: string;
+  quality: number;
+  reliability: number;
+  skill_tags?: string[];
+  message?: string;
+  created_at: string;
+}
+
+export interface ReputationBadge {
+  badge_type: string;
+  awarded_at: string;
+}
+
+export interface Reputation {
+  stamp_count: number;
+  avg_quality: number;
+  avg_reliability: number;
+  recent_stamps: ReputationStamp[];
+  badges: ReputationBadge[];
+}
+
 export interface DashboardResponse {
   claimed: WantedSummary[];
   in_review: WantedSummary[];
   completed: WantedSummary[];
+  up_next?: WantedSummary[];
+  reputation?: Reputation;
 }
 
 export interface UpstreamInfo {
@@ -111,6 +174,7 @@ export interface UpstreamInfo {
 export interface ConfigResponse {
   rig_handle: string;
   mode: string;
+  read_only?: boolean;
   hosted?: boolean;
   connected?: boolean;
   upstream?: string;
@@ -178,6 +242,10 @@ export interface BrowseFilter {
   priority?: number;
   project?: string;
   search?: string;
+  /** Filter expression, e.g. "status:open tag:go -project:infra". */
+  q?: string;
+  /** Tag the items must carry; aliases resolve to the canonical tag. */
+  tag?: string;
   sort?: string;
   limit?: number;
   view?: string;
@@ -209,6 +277,11 @@ export interface SettingsInput {
   signing: boolean;
 }
 
+export interface WastelandSettingsInput {
+  mode?: string;
+  signing?: boolean;
+}
+
 export interface ProfileSkillEntry {
   name: string;
   quality: number;
@@ -269,6 +342,114 @@ export interface ScoreboardEntry {
   top_skills?: string[];
 }
 
+export interface TagDef {
+  tag: string;
+  aliases?: string[];
+  description?: string;
+}
+
+export interface TagsResponse {
+  tags: TagDef[];
+  strict: boolean;
+}
+
+/** Item types and effort levels the wasteland accepts. */
+export interface VocabularyResponse {
+  types: string[];
+  efforts: string[];
+}
+
+export interface ProjectContributor {
+  rig_handle: string;
+  completions: number;
+}
+
+export interface ProjectActivity {
+  id: string;
+  title: string;
+  status: string;
+  updated_at?: string;
+}
+
+export interface ProjectSummary {
+  project: string;
+  open: number;
+  claimed: number;
+  in_review: number;
+  completed: number;
+  top_contributors: ProjectContributor[];
+  recent: ProjectActivity[];
+  last_activity?: string;
+}
+
+export interface ProjectsResponse {
+  projects: ProjectSummary[];
+}
+
+export interface AuditEntry {
+  commit_hash: string;
+  rig_handle: string;
+  action?: string;
+  wanted_id?: string;
+  message: string;
+  date?: string;
+}
+
+export interface AuditFilter {
+  item?: string;
+  rig?: string;
+  since?: string;
+  limit?: number;
+  offset?: number;
+}
+
+export interface AuditResponse {
+  entries: AuditEntry[];
+  next_offset?: number;
+}
+
+export type SearchType = "wanted" | "completion" | "stamp";
+
+export interface SearchResult {
+  type: SearchType;
+  id: string;
+  wanted_id?: string;
+  title?: string;
+  status?: string;
+  rig?: string;
+  field: string;
+  snippet?: string;
+  score: number;
+}
+
+export interface Badge {
+  id: string;
+  badge_type: string;
+  awarded_at: string;
+  reason: string;
+}
+
+export interface RigBadgesResponse {
+  rig_handle: string;
+  badges: Badge[];
+}
+
+export interface BadgeCatalogEntry {
+  type: string;
+  criteria?: string;
+  holders: number;
+  last_awarded?: string;
+}
+
+export interface BadgeCatalogResponse {
+  badges: BadgeCatalogEntry[];
+}
+
+export interface SearchResponse {
+  query: string;
+  results: SearchResult[];
+}
+
 export interface ScoreboardResponse {
   entries: ScoreboardEntry[];
   updated_at: string;
@@ -1,9 +1,11 @@
 import { startTransition, useCallback, useEffect, useRef, useState } from "react";
 import { Link, useNavigate } from "react-router-dom";
 import { toast } from "sonner";
-import { browse } from "../api/client";
+import { browse, tags as fetchTags, vocabulary } from "../api/client";
 import { consumePrefetch } from "../api/prefetch";
 import type { PendingItemSummary, WantedSummary } from "../api/types";
+import { useWasteland } from "../context/WastelandContext";
+import { useBoardUpdates } from "../hooks/useBoardUpdates";
 import { useFilterParams } from "../hooks/useFilterParams";
 import styles from "./BrowseList.module.css";
 import { EmptyState } from "./EmptyState";
@@ -26,6 +28,9 @@ export function BrowseList() {
   const selectedIndexRef = useRef(-1);
   const searchRef = useRef<HTMLInputElement>(null);
   const hasLoadedRef = useRef(false);
+  const [tagOptions, setTagOptions] = useState<string[]>([]);
+  const [typeOptions, setTypeOptions] = useState<string[] | undefined>();
+  const { active } = useWasteland();
 
   const setSelection = useCallback((next: number) => {
     selectedIndexRef.current = next;
@@ -57,6 +62,36 @@ export function BrowseList() {
     load();
   }, [load]);
 
+  // Canonical tags for the tag filter; wastelands without a registry get none.
+  useEffect(() => {
+    let cancelled = false;
+    fetchTags()
+      .then((resp) => {
+        if (!cancelled) setTagOptions((resp.tags ?? []).map((t) => t.tag));
+      })
+      .catch(() => {
+        if (!cancelled) setTagOptions([]);
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [active]);
+
+  // The wasteland's item types for the type filter; the built-ins until they load.
+  useEffect(() => {
+    let cancelled = false;
+    vocabulary()
+      .then((resp) => {
+        if (!cancelled) setTypeOptions(resp.types?.length ? resp.types : undefined);
+      })
+      .catch(() => {
+        if (!cancelled) setTypeOptions(undefined);
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [active]);
+
   // Silent background poll — no loading spinner, no error toasts.
   useEffect(() => {
     if (!hasLoadedRef.current) return;
@@ -72,6 +107,13 @@ export function BrowseList() {
     return () => clearInterval(id);
   }, [filter, setSelection]);
 
+  // Live updates pushed by the hosted server when another user changes the board.
+  useBoardUpdates(active, () => {
+    browse(filter)
+      .then((resp) => setItems(resp.items))
+      .catch(() => {});
+  });
+
   useEffect(() => {
     const handler = (e: KeyboardEvent) => {
       const target = e.target as HTMLElement;
@@ -131,7 +173,7 @@ export function BrowseList() {
         </div>
       </div>
 
-      <FilterBar filter={filter} onChange={setFilter} searchRef={searchRef} />
+      <FilterBar filter={filter} onChange={setFilter} searchRef={searchRef} tags={tagOptions} types={typeOptions} />
 
       {error && <p className={styles.error}>{error}</p>}
       {warning && <p className={styles.warning}>{warning}</p>}
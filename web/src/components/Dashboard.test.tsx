@@ -55,4 +55,24 @@ describe("Dashboard", () => {
     expect(screen.getByText("In Review (0)")).toBeInTheDocument();
     expect(screen.getByText("Completed (1)")).toBeInTheDocument();
   });
+  it("shows reputation summary", async () => {
+    cleanupFetch = mockFetch(() =>
+      makeDashboardResponse({
+        reputation: {
+          stamp_count: 2,
+          avg_quality: 4.5,
+          avg_reliability: 4,
+          recent_stamps: [
+            { author: "bob", severity: "leaf", quality: 5, reliability: 4, message: "solid work", created_at: "2026-03-02" },
+          ],
+          badges: [{ badge_type: "first_blood", awarded_at: "2026-02-01" }],
+        },
+      }),
+    );
+    renderWithRouter(<Dashboard />);
+    await waitFor(() => expect(screen.getByText("2 stamps")).toBeInTheDocument());
+    expect(screen.getByText("quality 4.5")).toBeInTheDocument();
+    expect(screen.getByText("first_blood")).toBeInTheDocument();
+    expect(screen.getByText("solid work")).toBeInTheDocument();
+  });
 });
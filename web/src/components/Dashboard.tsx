@@ -2,7 +2,7 @@ import { useEffect, useState } from "react";
 import { Link } from "react-router-dom";
 import { toast } from "sonner";
 import { dashboard } from "../api/client";
-import type { DashboardResponse, WantedSummary } from "../api/types";
+import type { DashboardResponse, Reputation, WantedSummary } from "../api/types";
 import styles from "./Dashboard.module.css";
 import { EmptyState } from "./EmptyState";
 import { PriorityBadge } from "./PriorityBadge";
@@ -47,8 +47,12 @@ export function Dashboard() {
     <div className={styles.page}>
       <h2 className={styles.heading}>My Dashboard</h2>
       <DashboardSection title="Claimed" status="claimed" items={data.claimed} />
+      {data.up_next && data.up_next.length > 0 && (
+        <DashboardSection title="Up Next" status="open" items={data.up_next} />
+      )}
       <DashboardSection title="In Review" status="in_review" items={data.in_review} />
       <DashboardSection title="Completed" status="completed" items={data.completed} />
+      {data.reputation && <ReputationSection reputation={data.reputation} />}
     </div>
   );
 }
@@ -88,3 +92,50 @@ function DashboardSection({ title, status, items }: { title: string; status: str
     </div>
   );
 }
+
+function ReputationSection({ reputation }: { reputation: Reputation }) {
+  const { stamp_count, avg_quality, avg_reliability, recent_stamps, badges } = reputation;
+  return (
+    <div className={styles.section}>
+      <h3 className={styles.sectionTitle} data-status="reputation">
+        Reputation
+      </h3>
+      {stamp_count === 0 && badges.length === 0 ? (
+        <EmptyState title="No stamps yet" description="Stamps from validated completions will appear here." />
+      ) : (
+        <>
+          <div className={styles.repStats}>
+            <span>{stamp_count} stamps</span>
+            <span>quality {avg_quality.toFixed(1)}</span>
+            <span>reliability {avg_reliability.toFixed(1)}</span>
+          </div>
+          {badges.length > 0 && (
+            <div className={styles.badges}>
+              {badges.map((b) => (
+                <span key={b.badge_type} className={styles.badge} title={b.awarded_at}>
+                  {b.badge_type}
+                </span>
+              ))}
+            </div>
+          )}
+          {recent_stamps.length > 0 && (
+            <table className={styles.table}>
+              <tbody>
+                {recent_stamps.map((s) => (
+                  <tr key={`${s.author}-${s.created_at}`} className={styles.stampRow}>
+                    <td className={styles.cellDate}>{s.created_at.slice(0, 10)}</td>
+                    <td className={styles.cellAuthor}>{s.author}</td>
+                    <td className={styles.cellScores}>
+                      q{s.quality} r{s.reliability}
+                    </td>
+                    <td className={styles.cellTitle}>{s.message}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </>
+      )}
+    </div>
+  );
+}
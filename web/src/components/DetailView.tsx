@@ -1,5 +1,5 @@
 import { useCallback, useEffect, useOptimistic, useState } from "react";
-import { useNavigate, useParams } from "react-router-dom";
+import { Link, useNavigate, useParams } from "react-router-dom";
 import { toast } from "sonner";
 import {
   accept,
@@ -232,6 +232,11 @@ export function DetailView() {
     actions,
     branch_actions,
     upstream_prs,
+    quorum,
+    approvals,
+    follow_ups,
+    evidence,
+    queue,
   } = data;
   const branchActions = branch_actions || [];
   const displayStatus = optimisticStatus || item.status;
@@ -266,6 +271,14 @@ export function DetailView() {
         <span className={styles.metaValue}>{item.posted_by || "-"}</span>
         <span className={styles.metaLabel}>Claimed by</span>
         <span className={styles.metaValue}>{item.claimed_by || "-"}</span>
+        {queue && queue.length > 0 && (
+          <>
+            <span className={styles.metaLabel}>Queue</span>
+            <span className={styles.metaValue}>
+              {queue.map((e) => e.rig_handle + (e.auto_claim ? " (auto)" : "")).join(", ")}
+            </span>
+          </>
+        )}
         <span className={styles.metaLabel}>Effort</span>
         <span className={styles.metaValue}>{item.effort_level || "-"}</span>
         {item.tags && item.tags.length > 0 && (
@@ -395,13 +408,72 @@ export function DetailView() {
         </Section>
       )}
 
+      {(item.parent_id || (follow_ups && follow_ups.length > 0)) && (
+        <Section title="Follow-ups">
+          <div className={styles.sectionContent}>
+            {item.parent_id && (
+              <p className={styles.sectionText}>
+                Follows up: <Link to={`/wanted/${item.parent_id}`}>{item.parent_id}</Link>
+              </p>
+            )}
+            {follow_ups?.map((fu) => (
+              <p key={fu.id} className={styles.sectionText}>
+                <Link to={`/wanted/${fu.id}`}>{fu.id}</Link> ({fu.status}) {fu.title}
+              </p>
+            ))}
+          </div>
+        </Section>
+      )}
+
+      {evidence && evidence.length > 0 && displayStatus === "in_review" && (
+        <Section title="Evidence">
+          <div className={styles.sectionContent}>
+            {evidence.map((e, i) => (
+              <p key={`${e.kind}-${i}`} className={styles.sectionText}>
+                <span className={styles.highlightBrass}>{e.kind}</span>{" "}
+                {/^https?:\/\//.test(e.value) ? (
+                  <a href={e.value} target="_blank" rel="noopener noreferrer" className={styles.prLink}>
+                    {e.value}
+                  </a>
+                ) : (
+                  e.value
+                )}
+                {e.verified && <span className={styles.highlightGreen}> ✓ verified</span>}
+              </p>
+            ))}
+          </div>
+        </Section>
+      )}
+
+      {quorum && quorum > 1 && displayStatus === "in_review" && (
+        <Section title="Approvals">
+          <div className={styles.sectionContent}>
+            <p className={styles.sectionText}>
+              {(approvals || []).length}/{quorum} approvals
+            </p>
+            {approvals && approvals.length > 0 && (
+              <p className={styles.sectionTextLast}>
+                Accepted by: <span className={styles.highlightGreen}>{approvals.join(", ")}</span>
+              </p>
+            )}
+          </div>
+        </Section>
+      )}
+
       {completion && displayStatus === "completed" && (
         <Section title="Completion">
           <div className={styles.sectionContent}>
             <p className={styles.sectionText}>
               Completed by: <span className={styles.highlightBrass}>{completion.completed_by}</span>
             </p>
-            {completion.evidence && <p className={styles.sectionText}>Evidence: {completion.evidence}</p>}
+            {evidence && evidence.length > 0
+              ? evidence.map((e, i) => (
+                  <p key={`${e.kind}-${i}`} className={styles.sectionText}>
+                    Evidence ({e.kind}): {e.value}
+                    {e.verified && <span className={styles.highlightGreen}> ✓ verified</span>}
+                  </p>
+                ))
+              : completion.evidence && <p className={styles.sectionText}>Evidence: {completion.evidence}</p>}
             {completion.validated_by && (
               <p className={styles.sectionTextLast}>
                 Validated by: <span className={styles.highlightGreen}>{completion.validated_by}</span>
@@ -33,6 +33,26 @@ describe("FilterBar", () => {
     expect(onChange).toHaveBeenCalledWith(expect.objectContaining({ search: "test" }));
   });
 
+  it("hides the tag filter without registered tags", () => {
+    render(<FilterBar filter={baseFilter} onChange={onChange} />);
+    expect(screen.queryByLabelText("Filter by tag")).not.toBeInTheDocument();
+  });
+
+  it("tag select offers registered tags", () => {
+    render(<FilterBar filter={baseFilter} onChange={onChange} tags={["docs", "go"]} />);
+    fireEvent.change(screen.getByLabelText("Filter by tag"), { target: { value: "go" } });
+    expect(onChange).toHaveBeenCalledWith(expect.objectContaining({ tag: "go" }));
+  });
+
+  it("type select offers the wasteland's types", () => {
+    render(<FilterBar filter={baseFilter} onChange={onChange} types={["feature", "chore"]} />);
+    const select = screen.getByLabelText("Filter by type");
+    expect(select).toHaveTextContent("chore");
+    expect(select).not.toHaveTextContent("bug");
+    fireEvent.change(select, { target: { value: "chore" } });
+    expect(onChange).toHaveBeenCalledWith(expect.objectContaining({ type: "chore" }));
+  });
+
   it("values reflect current filter prop", () => {
     const filter: BrowseFilter = { status: "claimed", type: "feature", sort: "alpha", search: "hello" };
     render(<FilterBar filter={filter} onChange={onChange} />);
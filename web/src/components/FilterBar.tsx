@@ -3,7 +3,7 @@ import type { BrowseFilter } from "../api/types";
 import styles from "./FilterBar.module.css";
 
 const statuses = ["", "open", "claimed", "in_review", "completed"];
-const types = ["", "feature", "bug", "design", "rfc", "docs", ...(__INFER_ENABLED__ ? ["inference"] : [])];
+const defaultTypes = ["feature", "bug", "design", "rfc", "docs", "inference"];
 const sorts = ["priority", "newest", "alpha"];
 const views = ["mine", "all", "upstream"] as const;
 const viewLabels: Record<string, string> = { mine: "my PRs", all: "all PRs", upstream: "upstream" };
@@ -12,9 +12,15 @@ interface FilterBarProps {
   filter: BrowseFilter;
   onChange: (filter: BrowseFilter) => void;
   searchRef?: RefObject<HTMLInputElement | null>;
+  /** Canonical tags from the wasteland's registry; the tag filter is hidden when empty. */
+  tags?: string[];
+  /** Item types from the wasteland's vocabulary; the built-in types until it loads. */
+  types?: string[];
 }
 
-export function FilterBar({ filter, onChange, searchRef }: FilterBarProps) {
+export function FilterBar({ filter, onChange, searchRef, tags = [], types = defaultTypes }: FilterBarProps) {
+  const typeOptions = ["", ...types.filter((t) => __INFER_ENABLED__ || t !== "inference")];
+  const tagOptions = filter.tag && !tags.includes(filter.tag) ? [filter.tag, ...tags] : tags;
   return (
     <div className={styles.bar} role="search" aria-label="Filter wanted items">
       <select
@@ -36,13 +42,29 @@ export function FilterBar({ filter, onChange, searchRef }: FilterBarProps) {
         value={filter.type || ""}
         onChange={(e) => onChange({ ...filter, type: e.target.value || undefined })}
       >
-        {types.map((t) => (
+        {typeOptions.map((t) => (
           <option key={t} value={t}>
             {t || "all types"}
           </option>
         ))}
       </select>
 
+      {tagOptions.length > 0 && (
+        <select
+          className={styles.select}
+          aria-label="Filter by tag"
+          value={filter.tag || ""}
+          onChange={(e) => onChange({ ...filter, tag: e.target.value || undefined })}
+        >
+          <option value="">all tags</option>
+          {tagOptions.map((t) => (
+            <option key={t} value={t}>
+              {t}
+            </option>
+          ))}
+        </select>
+      )}
+
       <select
         className={styles.select}
         aria-label="Sort order"
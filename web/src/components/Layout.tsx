@@ -13,6 +13,7 @@ export function Layout() {
   const [helpOpen, setHelpOpen] = useState(false);
   const [impersonating, setImpersonating] = useState<string>(getImpersonation() ?? "");
   const [impersonateInput, setImpersonateInput] = useState(getImpersonation() ?? "");
+  const [searchInput, setSearchInput] = useState("");
   const navigate = useNavigate();
   const { wastelands, active, authenticated, environment, switchTo } = useWasteland();
 
@@ -150,6 +151,9 @@ export function Layout() {
           <NavLink to="/profile" className={({ isActive }) => (isActive ? styles.navLinkActive : styles.navLink)}>
             profiles
           </NavLink>
+          <NavLink to="/projects" className={({ isActive }) => (isActive ? styles.navLinkActive : styles.navLink)}>
+            projects
+          </NavLink>
           <NavLink to="/scoreboard" className={({ isActive }) => (isActive ? styles.navLinkActive : styles.navLink)}>
             scoreboard
           </NavLink>
@@ -162,6 +166,24 @@ export function Layout() {
               sign in
             </NavLink>
           )}
+          <form
+            className={styles.searchForm}
+            role="search"
+            onSubmit={(e) => {
+              e.preventDefault();
+              const q = searchInput.trim();
+              if (q) navigate(`/search?q=${encodeURIComponent(q)}`);
+            }}
+          >
+            <input
+              className={styles.searchInput}
+              type="search"
+              placeholder="search..."
+              aria-label="Search items, completions and stamps"
+              value={searchInput}
+              onChange={(e) => setSearchInput(e.target.value)}
+            />
+          </form>
           <a
             href="https://github.com/gastownhall/marketplace/blob/main/plugins/wasteland/skills/wasteland/SKILL.md"
             target="_blank"
@@ -0,0 +1,49 @@
+import { screen, waitFor } from "@testing-library/react";
+import { afterEach, describe, expect, it } from "vitest";
+import type { ProjectsResponse } from "../api/types";
+import { mockFetch, renderWithRouter } from "../test-utils";
+import { Projects } from "./Projects";
+
+let cleanupFetch: () => void;
+afterEach(() => cleanupFetch?.());
+
+const response: ProjectsResponse = {
+  projects: [
+    {
+      project: "gastown",
+      open: 3,
+      claimed: 1,
+      in_review: 0,
+      completed: 5,
+      top_contributors: [{ rig_handle: "alice", completions: 4 }],
+      recent: [{ id: "w-1", title: "Fix the login bug", status: "claimed", updated_at: "2026-03-04T12:00:00Z" }],
+      last_activity: "2026-03-04T12:00:00Z",
+    },
+    { project: "", open: 1, claimed: 0, in_review: 0, completed: 0, top_contributors: [], recent: [] },
+  ],
+};
+
+describe("Projects", () => {
+  it("renders project cards with counts, contributors and recent items", async () => {
+    cleanupFetch = mockFetch(() => response);
+    renderWithRouter(<Projects />);
+    await waitFor(() => expect(screen.getByText("gastown")).toBeInTheDocument());
+    expect(screen.getByText("3 open")).toBeInTheDocument();
+    expect(screen.getByText("5 completed")).toBeInTheDocument();
+    expect(screen.getByText("alice (4)")).toBeInTheDocument();
+    expect(screen.getByText("Fix the login bug")).toBeInTheDocument();
+    expect(screen.getByText("(no project)")).toBeInTheDocument();
+  });
+
+  it("shows empty state when there are no projects", async () => {
+    cleanupFetch = mockFetch(() => ({ projects: [] }));
+    renderWithRouter(<Projects />);
+    await waitFor(() => expect(screen.getByText("No projects yet")).toBeInTheDocument());
+  });
+
+  it("shows error on fetch failure", async () => {
+    cleanupFetch = mockFetch(() => new Response(JSON.stringify({ error: "projects error" }), { status: 500 }));
+    renderWithRouter(<Projects />);
+    await waitFor(() => expect(screen.getByText("projects error")).toBeInTheDocument());
+  });
+});
@@ -0,0 +1,103 @@
+import { useEffect, useState } from "react";
+import { Link } from "react-router-dom";
+import { toast } from "sonner";
+import { projects } from "../api/client";
+import type { ProjectSummary, ProjectsResponse } from "../api/types";
+import { EmptyState } from "./EmptyState";
+import styles from "./Projects.module.css";
+import { SkeletonRows } from "./Skeleton";
+import { StatusBadge } from "./StatusBadge";
+
+function ProjectCard({ project }: { project: ProjectSummary }) {
+  const name = project.project || "(no project)";
+  return (
+    <section className={styles.card} data-testid={`project-${project.project || "none"}`}>
+      <header className={styles.cardHeader}>
+        <h3 className={styles.name}>{name}</h3>
+        {project.last_activity && (
+          <span className={styles.lastActivity}>
+            active {new Date(project.last_activity).toLocaleDateString()}
+          </span>
+        )}
+      </header>
+
+      <div className={styles.counts}>
+        <span>{project.open} open</span>
+        <span>{project.claimed} claimed</span>
+        <span>{project.in_review} in review</span>
+        <span>{project.completed} completed</span>
+      </div>
+
+      {project.top_contributors.length > 0 && (
+        <div className={styles.contributors}>
+          <span className={styles.label}>Top contributors</span>
+          {project.top_contributors.map((c) => (
+            <Link key={c.rig_handle} to={`/profile/${c.rig_handle}`} className={styles.rigLink}>
+              {c.rig_handle} ({c.completions})
+            </Link>
+          ))}
+        </div>
+      )}
+
+      {project.recent.length > 0 && (
+        <ul className={styles.recent}>
+          {project.recent.map((a) => (
+            <li key={a.id} className={styles.recentItem}>
+              <StatusBadge status={a.status} />
+              <Link to={`/wanted/${a.id}`} className={styles.itemLink}>
+                {a.title}
+              </Link>
+            </li>
+          ))}
+        </ul>
+      )}
+    </section>
+  );
+}
+
+export function Projects() {
+  const [data, setData] = useState<ProjectsResponse | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState("");
+
+  useEffect(() => {
+    (async () => {
+      try {
+        setData(await projects());
+      } catch (e) {
+        const msg = e instanceof Error ? e.message : "Failed to load";
+        setError(msg);
+        toast.error(msg);
+      } finally {
+        setLoading(false);
+      }
+    })();
+  }, []);
+
+  if (loading)
+    return (
+      <div className={styles.page}>
+        <h2 className={styles.heading}>Projects</h2>
+        <SkeletonRows count={6} />
+      </div>
+    );
+  if (error) return <p className={styles.errorText}>{error}</p>;
+  if (!data || data.projects.length === 0)
+    return (
+      <div className={styles.page}>
+        <h2 className={styles.heading}>Projects</h2>
+        <EmptyState title="No projects yet" description="Projects appear here once wanted items are posted." />
+      </div>
+    );
+
+  return (
+    <div className={styles.page}>
+      <h2 className={styles.heading}>Projects</h2>
+      <div className={styles.grid}>
+        {data.projects.map((p) => (
+          <ProjectCard key={p.project || "(none)"} project={p} />
+        ))}
+      </div>
+    </div>
+  );
+}
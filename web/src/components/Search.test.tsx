@@ -0,0 +1,65 @@
+import { fireEvent, screen, waitFor } from "@testing-library/react";
+import { afterEach, describe, expect, it } from "vitest";
+import type { SearchResponse } from "../api/types";
+import { mockFetch, renderWithRouter } from "../test-utils";
+import { Search } from "./Search";
+
+let cleanupFetch: () => void;
+afterEach(() => cleanupFetch?.());
+
+const response: SearchResponse = {
+  query: "auth",
+  results: [
+    { type: "wanted", id: "w-1", wanted_id: "w-1", title: "Fix auth refresh", status: "open", field: "title", score: 10 },
+    {
+      type: "stamp",
+      id: "s-1",
+      wanted_id: "w-9",
+      title: "Harden login",
+      rig: "alice",
+      field: "skills",
+      snippet: "auth, go",
+      score: 6,
+    },
+  ],
+};
+
+describe("Search", () => {
+  it("renders type-tagged results linking to their items", async () => {
+    const urls: string[] = [];
+    cleanupFetch = mockFetch((url) => {
+      urls.push(url);
+      return response;
+    });
+    renderWithRouter(<Search />, { route: "/search?q=auth" });
+    await waitFor(() => expect(screen.getByText("Fix auth refresh")).toBeInTheDocument());
+    expect(screen.getByText("Harden login").closest("a")).toHaveAttribute("href", "/wanted/w-9");
+    expect(screen.getByTestId("result-stamp-s-1")).toHaveTextContent("stamp");
+    expect(screen.getByText("auth, go")).toBeInTheDocument();
+    expect(urls[0]).toContain("/api/search?q=auth");
+  });
+
+  it("narrows to one result type", async () => {
+    const urls: string[] = [];
+    cleanupFetch = mockFetch((url) => {
+      urls.push(url);
+      return response;
+    });
+    renderWithRouter(<Search />, { route: "/search?q=auth" });
+    await waitFor(() => expect(screen.getByText("Fix auth refresh")).toBeInTheDocument());
+    fireEvent.click(screen.getByRole("button", { name: "stamps" }));
+    await waitFor(() => expect(urls.some((u) => u.includes("type=stamp"))).toBe(true));
+  });
+
+  it("prompts for a query when there is none", () => {
+    cleanupFetch = mockFetch(() => response);
+    renderWithRouter(<Search />, { route: "/search" });
+    expect(screen.getByText("Search the wasteland")).toBeInTheDocument();
+  });
+
+  it("shows an empty state when nothing matches", async () => {
+    cleanupFetch = mockFetch(() => ({ query: "zzz", results: [] }));
+    renderWithRouter(<Search />, { route: "/search?q=zzz" });
+    await waitFor(() => expect(screen.getByText("No matches")).toBeInTheDocument());
+  });
+});
@@ -0,0 +1,124 @@
+import { useEffect, useState } from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { search } from "../api/client";
+import type { SearchResult, SearchType } from "../api/types";
+import { EmptyState } from "./EmptyState";
+import styles from "./Search.module.css";
+import { SkeletonRows } from "./Skeleton";
+import { StatusBadge } from "./StatusBadge";
+
+const TYPES: { value: SearchType; label: string }[] = [
+  { value: "wanted", label: "items" },
+  { value: "completion", label: "completions" },
+  { value: "stamp", label: "stamps" },
+];
+
+function ResultRow({ result }: { result: SearchResult }) {
+  const title = result.title || result.wanted_id || result.id;
+  return (
+    <li className={styles.result} data-testid={`result-${result.type}-${result.id}`}>
+      <div className={styles.resultHeader}>
+        <span className={styles.typeTag} data-type={result.type}>
+          {result.type}
+        </span>
+        {result.wanted_id ? (
+          <Link to={`/wanted/${result.wanted_id}`} className={styles.title}>
+            {title}
+          </Link>
+        ) : (
+          <span className={styles.title}>{title}</span>
+        )}
+        {result.status && <StatusBadge status={result.status} />}
+        {result.rig && (
+          <Link to={`/profile/${result.rig}`} className={styles.rig}>
+            {result.rig}
+          </Link>
+        )}
+      </div>
+      {result.snippet && (
+        <p className={styles.snippet}>
+          <span className={styles.field}>{result.field}</span> {result.snippet}
+        </p>
+      )}
+    </li>
+  );
+}
+
+export function Search() {
+  const [params, setParams] = useSearchParams();
+  const q = params.get("q") ?? "";
+  const type = (params.get("type") ?? "") as SearchType | "";
+  const [results, setResults] = useState<SearchResult[] | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState("");
+
+  useEffect(() => {
+    if (!q.trim()) {
+      setResults(null);
+      return;
+    }
+    let cancelled = false;
+    setLoading(true);
+    setError("");
+    (async () => {
+      try {
+        const resp = await search(q, type ? [type] : [], 50);
+        if (!cancelled) setResults(resp.results);
+      } catch (e) {
+        if (!cancelled) setError(e instanceof Error ? e.message : "Search failed");
+      } finally {
+        if (!cancelled) setLoading(false);
+      }
+    })();
+    return () => {
+      cancelled = true;
+    };
+  }, [q, type]);
+
+  const setType = (t: SearchType | "") => {
+    const next = new URLSearchParams(params);
+    if (t) next.set("type", t);
+    else next.delete("type");
+    setParams(next);
+  };
+
+  return (
+    <div className={styles.page}>
+      <h2 className={styles.heading}>Search</h2>
+      <div className={styles.filters} role="group" aria-label="Result type">
+        <button type="button" className={type === "" ? styles.chipActive : styles.chip} onClick={() => setType("")}>
+          all
+        </button>
+        {TYPES.map((t) => (
+          <button
+            key={t.value}
+            type="button"
+            className={type === t.value ? styles.chipActive : styles.chip}
+            onClick={() => setType(t.value)}
+          >
+            {t.label}
+          </button>
+        ))}
+      </div>
+
+      {!q.trim() ? (
+        <EmptyState
+          title="Search the wasteland"
+          description="Find wanted items by title, description or tag, completions by evidence, and stamps by message or skill."
+        />
+      ) : loading ? (
+        <SkeletonRows count={5} />
+      ) : error ? (
+        <p className={styles.errorText}>{error}</p>
+      ) : results && results.length > 0 ? (
+        <ul className={styles.results}>
+          {results.map((r) => (
+            <ResultRow key={`${r.type}-${r.id}`} result={r} />
+          ))}
+        </ul>
+      ) : (
+        <EmptyState title="No matches" description={`Nothing matches "${q}".`} />
+      )}
+    </div>
+  );
+}
@@ -1,13 +1,13 @@
 import { useEffect, useRef, useState } from "react";
 import { toast } from "sonner";
-import { createItem, updateItem } from "../api/client";
+import { createItem, updateItem, vocabulary } from "../api/client";
 import type { DetailResponse, MutationResponse, WantedItem } from "../api/types";
 import { useFocusTrap } from "../hooks/useFocusTrap";
 import styles from "./WantedForm.module.css";
 
-const types = ["feature", "bug", "design", "rfc", "docs"];
+const defaultTypes = ["feature", "bug", "design", "rfc", "docs"];
 const priorities = [0, 1, 2, 3, 4];
-const efforts = ["trivial", "small", "medium", "large", "epic"];
+const defaultEfforts = ["trivial", "small", "medium", "large", "epic"];
 
 interface WantedFormProps {
   item?: WantedItem;
@@ -37,6 +37,8 @@ export function WantedForm({ item, mode = "default", onClose, onSaved }: WantedF
   const [effortLevel, setEffortLevel] = useState(item?.effort_level ?? "medium");
   const [tags, setTags] = useState(item?.tags?.join(", ") ?? "");
   const [saving, setSaving] = useState(false);
+  const [types, setTypes] = useState(defaultTypes);
+  const [efforts, setEfforts] = useState(defaultEfforts);
   const savingRef = useRef(false);
 
   // Inference-mode state
@@ -46,6 +48,21 @@ export function WantedForm({ item, mode = "default", onClose, onSaved }: WantedF
   const [maxTokens, setMaxTokens] = useState(0);
   const [showAdvanced, setShowAdvanced] = useState(false);
 
+  // The wasteland's own types and effort levels; the built-ins until they load.
+  useEffect(() => {
+    let cancelled = false;
+    vocabulary()
+      .then((v) => {
+        if (cancelled) return;
+        if (v.types?.length) setTypes(v.types.filter((t) => t !== "inference"));
+        if (v.efforts?.length) setEfforts(v.efforts);
+      })
+      .catch(() => {});
+    return () => {
+      cancelled = true;
+    };
+  }, []);
+
   useEffect(() => {
     const handleKey = (e: KeyboardEvent) => {
       if (e.key === "Escape") onClose();
@@ -1,6 +1,6 @@
 import type { ReactNode } from "react";
 import { createContext, useCallback, useContext, useEffect, useMemo, useState } from "react";
-import { authStatus, setActiveUpstream } from "../api/client";
+import { authStatus, config, setActiveUpstream } from "../api/client";
 import type { WastelandConfig } from "../api/types";
 
 interface WastelandContextValue {
@@ -39,10 +39,17 @@ export function WastelandProvider({ children }: { children: ReactNode }) {
 
   const refresh = useCallback(async () => {
     try {
-      const status = await authStatus();
-      const wls = status.wastelands ?? [];
+      let wls: WastelandConfig[];
+      try {
+        const status = await authStatus();
+        wls = status.wastelands ?? [];
+        setEnvironment(status.environment);
+      } catch {
+        // Self-sovereign server: joined wastelands come from /api/config.
+        const cfg = await config();
+        wls = (cfg.upstreams ?? []).map((u) => ({ ...u, signing: false }));
+      }
       setWastelands(wls);
-      setEnvironment(status.environment);
 
       if (wls.length === 0) {
         applyActive(null);
@@ -54,7 +61,7 @@ export function WastelandProvider({ children }: { children: ReactNode }) {
       const match = stored && wls.some((w) => w.upstream === stored);
       applyActive(match ? stored : wls[0].upstream);
     } catch {
-      // Not in hosted mode or server not running — no wastelands.
+      // Server not running — no wastelands.
     }
   }, [applyActive]);
 
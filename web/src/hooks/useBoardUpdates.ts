@@ -0,0 +1,65 @@
+import { useEffect, useRef } from "react";
+
+export interface BoardEvent {
+  type: "added" | "changed" | "removed";
+  id: string;
+  title?: string;
+  status?: string;
+  claimed_by?: string;
+  updated_at?: string;
+}
+
+interface BoardMessage {
+  upstream: string;
+  events: BoardEvent[];
+}
+
+const MAX_BACKOFF_MS = 60_000;
+
+// Subscribes to live board changes for a wasteland over /ws (hosted mode).
+// onChange runs once per server poll that saw changes. Reconnects with
+// backoff after a drop; gives up if the server never accepts a connection,
+// which is the case for local `wl serve`.
+export function useBoardUpdates(upstream: string | null, onChange: (events: BoardEvent[]) => void) {
+  const onChangeRef = useRef(onChange);
+  onChangeRef.current = onChange;
+
+  useEffect(() => {
+    if (!upstream || typeof WebSocket === "undefined") return;
+
+    let ws: WebSocket | null = null;
+    let timer: ReturnType<typeof setTimeout> | undefined;
+    let backoff = 1000;
+    let everOpened = false;
+    let stopped = false;
+
+    const connect = () => {
+      const proto = location.protocol === "https:" ? "wss:" : "ws:";
+      ws = new WebSocket(`${proto}//${location.host}/ws?wasteland=${encodeURIComponent(upstream)}`);
+      ws.onopen = () => {
+        everOpened = true;
+        backoff = 1000;
+      };
+      ws.onmessage = (e) => {
+        try {
+          const msg = JSON.parse(e.data) as BoardMessage;
+          if (msg.upstream === upstream && msg.events.length > 0) onChangeRef.current(msg.events);
+        } catch {
+          // Ignore malformed frames.
+        }
+      };
+      ws.onclose = () => {
+        if (stopped || !everOpened) return;
+        timer = setTimeout(connect, backoff);
+        backoff = Math.min(backoff * 2, MAX_BACKOFF_MS);
+      };
+    };
+
+    connect();
+    return () => {
+      stopped = true;
+      clearTimeout(timer);
+      ws?.close();
+    };
+  }, [upstream]);
+}
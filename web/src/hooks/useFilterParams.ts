@@ -13,12 +13,14 @@ export function useFilterParams(): [BrowseFilter, (filter: BrowseFilter) => void
     const search = searchParams.get("search");
     const priority = searchParams.get("priority");
     const view = searchParams.get("view");
+    const tag = searchParams.get("tag");
     if (status) f.status = status;
     if (type) f.type = type;
     if (sort) f.sort = sort;
     if (search) f.search = search;
     if (priority) f.priority = Number(priority);
     if (view) f.view = view;
+    if (tag) f.tag = tag;
     return f;
   }, [searchParams]);
 
@@ -31,6 +33,7 @@ export function useFilterParams(): [BrowseFilter, (filter: BrowseFilter) => void
       if (f.search) params.set("search", f.search);
       if (f.priority !== undefined && f.priority >= 0) params.set("priority", String(f.priority));
       if (f.view && f.view !== "mine") params.set("view", f.view);
+      if (f.tag) params.set("tag", f.tag);
       setSearchParams(params, { replace: true });
     },
     [setSearchParams],
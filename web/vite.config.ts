@@ -21,6 +21,7 @@ export default defineConfig({
   server: {
     proxy: {
       '/api': 'http://localhost:8999',
+      '/ws': { target: 'ws://localhost:8999', ws: true },
     },
   },
 });